@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var debugBundleCmd = &cobra.Command{
+	Use:   "debug-bundle",
+	Short: "Download a post-mortem debug bundle for a slot",
+	Long: `Asks a running buildoor instance to gather everything it knows about a
+problematic slot — redacted config, logs around the slot, the cached payload,
+bids seen, reveal attempts, the recorded slot result, raw SSZ artifacts and the
+beacon block — and saves it as a single tarball for filing issues.
+
+The instance must have --api-port enabled. Example:
+
+  buildoor debug-bundle --slot 1234 --host http://buildoor:8082`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		slot, err := cmd.Flags().GetUint64("slot")
+		if err != nil {
+			return err
+		}
+
+		host, err := cmd.Flags().GetString("host")
+		if err != nil {
+			return err
+		}
+
+		if host == "" {
+			if cfg.APIPort == 0 {
+				return fmt.Errorf("--host is required (or --api-port of the local instance)")
+			}
+
+			host = fmt.Sprintf("http://127.0.0.1:%d", cfg.APIPort)
+		}
+
+		out, err := cmd.Flags().GetString("out")
+		if err != nil {
+			return err
+		}
+
+		if out == "" {
+			out = fmt.Sprintf("buildoor-debug-slot-%d.tar.gz", slot)
+		}
+
+		token, err := cmd.Flags().GetString("auth-token")
+		if err != nil {
+			return err
+		}
+
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		url := fmt.Sprintf("%s/api/buildoor/debug-bundle/%d", strings.TrimRight(host, "/"), slot)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to request debug bundle: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return fmt.Errorf("debug bundle request failed: status %d: %s",
+				resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", out, err)
+		}
+		defer f.Close()
+
+		size, err := io.Copy(f, resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+
+		logger.WithFields(map[string]any{
+			"slot":  slot,
+			"file":  out,
+			"bytes": size,
+		}).Info("Debug bundle saved")
+
+		return nil
+	},
+}
+
+func init() {
+	debugBundleCmd.Flags().Uint64("slot", 0, "Slot to collect the bundle for")
+	debugBundleCmd.Flags().String("host", "", "URL of the buildoor instance API (default http://127.0.0.1:<api-port>)")
+	debugBundleCmd.Flags().String("out", "", "Output file (default buildoor-debug-slot-<slot>.tar.gz)")
+	debugBundleCmd.Flags().String("auth-token", "", "Bearer token for instances running with --auth-provider-url")
+	debugBundleCmd.Flags().Duration("timeout", time.Minute, "Timeout for generating and downloading the bundle")
+
+	if err := debugBundleCmd.MarkFlagRequired("slot"); err != nil {
+		panic(err)
+	}
+
+	rootCmd.AddCommand(debugBundleCmd)
+}
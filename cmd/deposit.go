@@ -7,11 +7,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ethpandaops/buildoor/pkg/buildoor"
 	"github.com/ethpandaops/buildoor/pkg/chain"
 	"github.com/ethpandaops/buildoor/pkg/lifecycle"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
-	"github.com/ethpandaops/buildoor/pkg/signer"
 	"github.com/ethpandaops/buildoor/pkg/wallet"
 )
 
@@ -22,11 +22,13 @@ var depositCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
-		// Validate required config
-		if cfg.BuilderPrivkey == "" && cfg.BuilderMnemonic == "" {
-			return fmt.Errorf("--builder-privkey or --builder-mnemonic is required")
+		// Initialize the builder key's signer (local key or remote signer)
+		blsSigner, err := buildoor.NewBuilderSigner(cfg)
+		if err != nil {
+			return fmt.Errorf("invalid builder key: %w", err)
 		}
 
+		// Validate required config
 		if cfg.CLClient == "" {
 			return fmt.Errorf("--cl-client is required")
 		}
@@ -40,7 +42,7 @@ var depositCmd = &cobra.Command{
 		}
 
 		// Initialize CL client
-		clClient, err := beacon.NewClient(ctx, cfg.CLClient, logger)
+		clClient, err := beacon.NewFailoverClient(ctx, cfg.CLClientURLs(), cfg.CLClientSSZ, logger)
 		if err != nil {
 			return fmt.Errorf("failed to connect to CL: %w", err)
 		}
@@ -53,12 +55,6 @@ var depositCmd = &cobra.Command{
 		}
 		defer rpcClient.Close()
 
-		// Initialize BLS signer (raw hex key or mnemonic-derived)
-		blsSigner, err := signer.NewBuilderSigner(cfg.BuilderPrivkey, cfg.BuilderMnemonic, cfg.BuilderKeyIndex)
-		if err != nil {
-			return fmt.Errorf("invalid builder key: %w", err)
-		}
-
 		// Initialize wallet
 		w, err := wallet.NewWallet(cfg.WalletPrivkey, rpcClient, logger)
 		if err != nil {
@@ -6,11 +6,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ethpandaops/buildoor/pkg/buildoor"
 	"github.com/ethpandaops/buildoor/pkg/chain"
 	"github.com/ethpandaops/buildoor/pkg/lifecycle"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
-	"github.com/ethpandaops/buildoor/pkg/signer"
 	"github.com/ethpandaops/buildoor/pkg/wallet"
 )
 
@@ -21,11 +21,13 @@ var exitCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
-		// Validate required config
-		if cfg.BuilderPrivkey == "" && cfg.BuilderMnemonic == "" {
-			return fmt.Errorf("--builder-privkey or --builder-mnemonic is required")
+		// Initialize the builder key's signer (local key or remote signer)
+		blsSigner, err := buildoor.NewBuilderSigner(cfg)
+		if err != nil {
+			return fmt.Errorf("invalid builder key: %w", err)
 		}
 
+		// Validate required config
 		if cfg.CLClient == "" {
 			return fmt.Errorf("--cl-client is required")
 		}
@@ -39,7 +41,7 @@ var exitCmd = &cobra.Command{
 		}
 
 		// Initialize CL client
-		clClient, err := beacon.NewClient(ctx, cfg.CLClient, logger)
+		clClient, err := beacon.NewFailoverClient(ctx, cfg.CLClientURLs(), cfg.CLClientSSZ, logger)
 		if err != nil {
 			return fmt.Errorf("failed to connect to CL: %w", err)
 		}
@@ -52,12 +54,6 @@ var exitCmd = &cobra.Command{
 		}
 		defer rpcClient.Close()
 
-		// Initialize BLS signer (raw hex key or mnemonic-derived)
-		blsSigner, err := signer.NewBuilderSigner(cfg.BuilderPrivkey, cfg.BuilderMnemonic, cfg.BuilderKeyIndex)
-		if err != nil {
-			return fmt.Errorf("invalid builder key: %w", err)
-		}
-
 		pubkey := blsSigner.PublicKey()
 
 		// Initialize wallet (its address is the exit source; must match the builder's
@@ -96,6 +92,12 @@ var exitCmd = &cobra.Command{
 			return fmt.Errorf("builder not registered")
 		}
 
+		// Same interlock as the lifecycle manager's, from the beacon state: the
+		// chain ignores exit requests while payments are pending.
+		if builderInfo.PendingPayments > 0 {
+			return fmt.Errorf("builder has %d gwei in pending payments; the exit request would be ignored on chain — retry after they settle", builderInfo.PendingPayments)
+		}
+
 		logger.WithFields(map[string]any{
 			"builder_index": builderInfo.Index,
 			"pubkey":        fmt.Sprintf("%x", pubkey[:8]),
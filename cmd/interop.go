@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/ethpandaops/buildoor/pkg/interop"
+)
+
+var interopCmd = &cobra.Command{
+	Use:   "interop",
+	Short: "Check a Builder API endpoint against each consensus client's expectations",
+	Long: `Replays the recorded Builder API requests of Lighthouse, Prysm, Teku,
+Nimbus and Lodestar (status, validator registrations as JSON or SSZ and
+getHeader with each client's Accept header) against a buildoor Builder API
+endpoint, and checks every response against what that client expects: the
+content type, the Eth-Consensus-Version header, the bid fields and the
+"no bid" and error response shapes.
+
+Every client registers its own synthetic validator. getHeader requests need
+--slot and --parent-hash (they are skipped otherwise) and use the client's
+synthetic validator unless --pubkey names a registered proposer. The command
+fails when a check fails. Example:
+
+  buildoor interop --target http://buildoor:8082 --slot 1234 --parent-hash 0xabc...`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		flags := cmd.Flags()
+
+		opts := interop.Options{}
+
+		var err error
+
+		if opts.Target, err = flags.GetString("target"); err != nil {
+			return err
+		}
+
+		if opts.Timeout, err = flags.GetDuration("request-timeout"); err != nil {
+			return err
+		}
+
+		slot, err := flags.GetUint64("slot")
+		if err != nil {
+			return err
+		}
+
+		opts.Slot = phase0.Slot(slot)
+
+		for flag, dst := range map[string][]byte{
+			"parent-hash":   opts.ParentHash[:],
+			"pubkey":        opts.Pubkey[:],
+			"fee-recipient": opts.FeeRecipient[:],
+		} {
+			value, err := flags.GetString(flag)
+			if err != nil {
+				return err
+			}
+
+			if err := decodeFixedHex(value, dst); err != nil {
+				return fmt.Errorf("invalid --%s: %w", flag, err)
+			}
+		}
+
+		clients, err := flags.GetStringSlice("client")
+		if err != nil {
+			return err
+		}
+
+		jsonOut, err := flags.GetBool("json")
+		if err != nil {
+			return err
+		}
+
+		profiles, err := interop.SelectProfiles(clients)
+		if err != nil {
+			return err
+		}
+
+		report, err := interop.NewRunner(opts).Run(context.Background(), profiles)
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+
+			if err := enc.Encode(report); err != nil {
+				return err
+			}
+		} else if err := report.WriteText(os.Stdout); err != nil {
+			return err
+		}
+
+		if failures := report.Failures(); len(failures) > 0 {
+			return fmt.Errorf("%d checks failed", len(failures))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	f := interopCmd.Flags()
+	f.String("target", "", "Builder API base URL of the buildoor under test")
+	f.Uint64("slot", 0, "Slot requested in getHeader")
+	f.String("parent-hash", "", "Parent execution block hash requested in getHeader (hex)")
+	f.String("pubkey", "", "Registered proposer pubkey requested in getHeader (hex, default each client's synthetic validator)")
+	f.String("fee-recipient", "", "Fee recipient advertised in the registrations (hex)")
+	f.StringSlice("client", nil, "Clients to check (lighthouse, lodestar, nimbus, prysm, teku; default all)")
+	f.Duration("request-timeout", 5*time.Second, "Timeout of a single request")
+	f.Bool("json", false, "Print the report as JSON")
+
+	if err := interopCmd.MarkFlagRequired("target"); err != nil {
+		panic(err)
+	}
+
+	rootCmd.AddCommand(interopCmd)
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/ethpandaops/buildoor/pkg/loadtest"
+)
+
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Generate synthetic load against builder endpoints",
+}
+
+var loadtestBuilderAPICmd = &cobra.Command{
+	Use:   "builder-api",
+	Short: "Load-test a Builder API endpoint",
+	Long: `Registers a set of synthetic validators, then issues getHeader requests at
+a fixed rate (optionally following up delivered headers with blinded block
+submissions) against a buildoor or relay Builder API endpoint, and reports
+per-operation latency percentiles.
+
+The synthetic validator keys are derived deterministically from their index,
+so repeated runs reuse the same validator set. Blinded block submissions make
+a buildoor target publish the unblinded block; only enable --submit-ratio
+against devnets. The synthetic validators are not the slot's proposer, so
+submissions also require a target that skips proposer signature verification
+(buildoor: --builder-api-verify-proposer-signature=false), confirmed with
+--target-skips-proposer-signature.
+
+Example:
+
+  buildoor loadtest builder-api --target http://buildoor:8082 \
+    --validators 500 --rps 50 --duration 1m --slot 1234 --parent-hash 0xabc...`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ltCfg := loadtest.DefaultConfig()
+		flags := cmd.Flags()
+
+		var err error
+
+		if ltCfg.Target, err = flags.GetString("target"); err != nil {
+			return err
+		}
+
+		if ltCfg.Validators, err = flags.GetInt("validators"); err != nil {
+			return err
+		}
+
+		if ltCfg.RPS, err = flags.GetFloat64("rps"); err != nil {
+			return err
+		}
+
+		if ltCfg.Duration, err = flags.GetDuration("duration"); err != nil {
+			return err
+		}
+
+		if ltCfg.Concurrency, err = flags.GetInt("concurrency"); err != nil {
+			return err
+		}
+
+		if ltCfg.RegistrationBatch, err = flags.GetInt("registration-batch"); err != nil {
+			return err
+		}
+
+		if ltCfg.SubmitRatio, err = flags.GetFloat64("submit-ratio"); err != nil {
+			return err
+		}
+
+		if ltCfg.SubmitAPIVersion, err = flags.GetInt("submit-api-version"); err != nil {
+			return err
+		}
+
+		if ltCfg.TargetSkipsProposerSignature, err = flags.GetBool("target-skips-proposer-signature"); err != nil {
+			return err
+		}
+
+		if ltCfg.RequestTimeout, err = flags.GetDuration("request-timeout"); err != nil {
+			return err
+		}
+
+		slot, err := flags.GetUint64("slot")
+		if err != nil {
+			return err
+		}
+
+		ltCfg.Slot = phase0.Slot(slot)
+
+		parentHash, err := flags.GetString("parent-hash")
+		if err != nil {
+			return err
+		}
+
+		if err := decodeFixedHex(parentHash, ltCfg.ParentHash[:]); err != nil {
+			return fmt.Errorf("invalid --parent-hash: %w", err)
+		}
+
+		feeRecipient, err := flags.GetString("fee-recipient")
+		if err != nil {
+			return err
+		}
+
+		if err := decodeFixedHex(feeRecipient, ltCfg.FeeRecipient[:]); err != nil {
+			return fmt.Errorf("invalid --fee-recipient: %w", err)
+		}
+
+		jsonOut, err := flags.GetBool("json")
+		if err != nil {
+			return err
+		}
+
+		runner, err := loadtest.NewRunner(ltCfg, logger)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		report, err := runner.Run(ctx)
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+
+			return enc.Encode(report)
+		}
+
+		return report.WriteText(os.Stdout)
+	},
+}
+
+// decodeFixedHex decodes an optionally 0x-prefixed hex string into dst,
+// requiring an exact length match. An empty string leaves dst zeroed.
+func decodeFixedHex(s string, dst []byte) error {
+	if s == "" {
+		return nil
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return err
+	}
+
+	if len(raw) != len(dst) {
+		return fmt.Errorf("expected %d bytes, got %d", len(dst), len(raw))
+	}
+
+	copy(dst, raw)
+
+	return nil
+}
+
+func init() {
+	defaults := loadtest.DefaultConfig()
+
+	f := loadtestBuilderAPICmd.Flags()
+	f.String("target", "", "Builder API base URL of the buildoor or relay under test")
+	f.Int("validators", defaults.Validators, "Number of synthetic validators to register and request headers for")
+	f.Float64("rps", defaults.RPS, "getHeader requests per second")
+	f.Duration("duration", defaults.Duration, "Duration of the getHeader storm")
+	f.Int("concurrency", defaults.Concurrency, "Maximum number of in-flight requests")
+	f.Int("registration-batch", defaults.RegistrationBatch, "Validator registrations per request")
+	f.Uint64("slot", 0, "Slot requested in getHeader")
+	f.String("parent-hash", "", "Parent execution block hash requested in getHeader (hex)")
+	f.String("fee-recipient", "", "Fee recipient advertised in the registrations (hex)")
+	f.Float64("submit-ratio", defaults.SubmitRatio, "Fraction (0-1) of delivered headers followed by a blinded block submission")
+	f.Int("submit-api-version", defaults.SubmitAPIVersion, "Blinded block submission endpoint version (1 or 2)")
+	f.Bool("target-skips-proposer-signature", false, "Confirm the target unblinds without verifying the proposer signature (required with --submit-ratio)")
+	f.Duration("request-timeout", defaults.RequestTimeout, "Timeout of a single request")
+	f.Bool("json", false, "Print the report as JSON")
+
+	if err := loadtestBuilderAPICmd.MarkFlagRequired("target"); err != nil {
+		panic(err)
+	}
+
+	loadtestCmd.AddCommand(loadtestBuilderAPICmd)
+	rootCmd.AddCommand(loadtestCmd)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ethpandaops/buildoor/pkg/probe"
+)
+
+var probeCmd = &cobra.Command{
+	Use:   "probe",
+	Short: "Check a beacon node's compatibility with buildoor",
+	Long: `Probes a beacon node for everything buildoor relies on and prints a
+compatibility matrix: the fork schedule, the SSE topics of the event stream,
+the ePBS bid and payload envelope endpoints and the builder registry.
+
+Submission endpoints are probed with an empty body, so nothing is published.
+Required checks are marked with *; the command fails when one is missing.
+Example:
+
+  buildoor probe --beacon http://localhost:5052`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		beaconURL, err := cmd.Flags().GetString("beacon")
+		if err != nil {
+			return err
+		}
+
+		if beaconURL == "" {
+			if cfg.CLClient == "" {
+				return fmt.Errorf("--beacon is required (or --cl-client)")
+			}
+
+			beaconURL = cfg.CLClient
+		}
+
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+
+		jsonOut, err := cmd.Flags().GetBool("json")
+		if err != nil {
+			return err
+		}
+
+		report, err := probe.NewProber(beaconURL, timeout).Run(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to probe beacon node: %w", err)
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+
+			if err := enc.Encode(report); err != nil {
+				return err
+			}
+		} else if err := report.WriteText(os.Stdout); err != nil {
+			return err
+		}
+
+		if missing := report.Missing(); len(missing) > 0 {
+			return fmt.Errorf("beacon node is missing %d required features", len(missing))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	probeCmd.Flags().String("beacon", "", "Beacon node API URL (default --cl-client)")
+	probeCmd.Flags().Duration("timeout", 5*time.Second, "Timeout of a single probe request")
+	probeCmd.Flags().Bool("json", false, "Print the report as JSON")
+
+	rootCmd.AddCommand(probeCmd)
+}
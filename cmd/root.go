@@ -4,12 +4,15 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/signer"
 )
 
 var (
@@ -44,28 +47,51 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path")
+	rootCmd.PersistentFlags().String("profile", "", "Built-in config profile presetting service toggles and timings ("+strings.Join(config.ProfileNames(), ", ")+"); the config file and explicit flags override it")
 	rootCmd.PersistentFlags().String("builder-privkey", "", "Builder BLS private key (hex)")
 	rootCmd.PersistentFlags().String("builder-mnemonic", "", "BIP-39 mnemonic to derive the builder BLS key from (path m/12381/3600/{index}/0/0; mutually exclusive with --builder-privkey)")
 	rootCmd.PersistentFlags().Uint64("builder-key-index", 0, "Account index for --builder-mnemonic key derivation")
 	rootCmd.PersistentFlags().String("cl-client", "", "Consensus layer client URL")
-	rootCmd.PersistentFlags().String("el-engine-api", "", "Execution layer engine API URL (JWT-authenticated)")
+	rootCmd.PersistentFlags().StringSlice("cl-client-fallbacks", nil, "Additional beacon node URLs to fail over to when --cl-client is unreachable or lagging (comma-separated)")
+	rootCmd.PersistentFlags().Bool("cl-client-ssz", defaults.CLClientSSZ, "Negotiate SSZ instead of JSON with the beacon node where supported (block/state/envelope fetches, submissions); fetches whose SSZ response cannot be decoded are retried as JSON. Disable to force JSON")
+	rootCmd.PersistentFlags().String("el-engine-api", "", "Execution layer engine API URL (JWT-authenticated, http:// or https://; WebSocket is not supported)")
 	rootCmd.PersistentFlags().String("el-jwt-secret", "", "Path to JWT secret file for engine API authentication")
+	rootCmd.PersistentFlags().StringSlice("el-engine-race-apis", nil, "Additional engine API URLs (sharing --el-jwt-secret) to race every payload build on; the highest-value payload wins (comma-separated)")
 	rootCmd.PersistentFlags().String("el-rpc", "", "Execution layer JSON-RPC URL (for lifecycle transactions)")
+	rootCmd.PersistentFlags().String("el-ws-rpc", "", "Execution layer WebSocket JSON-RPC URL; its newHeads subscription withdraws and rebuilds payloads built on a parent the EL head moved away from, ahead of the next payload attributes")
+	rootCmd.PersistentFlags().StringSlice("builder-txs", nil, "Raw signed transactions (hex, comma-separated; blob transactions with sidecar) to inject into the next built payloads via the EL mempool (requires --el-rpc)")
 	rootCmd.PersistentFlags().String("wallet-privkey", "", "Wallet ECDSA private key (hex)")
+	rootCmd.PersistentFlags().StringSlice("extra-builder-privkeys", nil, "Additional builder BLS private keys (hex, comma-separated) bidding as distinct builders in the same slots (p2p bidding only)")
+	rootCmd.PersistentFlags().StringSlice("extra-wallet-privkeys", nil, "Wallet private keys (hex, comma-separated) for --extra-builder-privkeys, by position; builders without one are tracked read-only")
+	rootCmd.PersistentFlags().String("builder-withdrawal-address", "", "Withdrawal (execution) address the builder's beacon state record must carry; bidding halts on a mismatch (empty = wallet address with lifecycle enabled, otherwise unchecked)")
 	rootCmd.PersistentFlags().Int("api-port", 0, "HTTP API port (0 = disabled)")
 	rootCmd.PersistentFlags().String("auth-provider-url", "", "Optional authenticatoor URL (e.g. https://auth.<devnet>.example.io); when set, API requests must carry a JWT verified against the authenticatoor's JWKS. When empty the API is unauthenticated.")
 	rootCmd.PersistentFlags().String("inject-head-html", "", "Raw HTML snippet injected into <head> of the served SPA (e.g. global panda menu loader). Falls back to the BUILDOOR_INJECT_HEAD_HTML env var when empty.")
 	rootCmd.PersistentFlags().String("overview-url", "", "Optional URL of the multi-instance overview UI. When set, the dashboard renders an Overview entry as the first top-nav item so navigation stays consistent across instances.")
 	rootCmd.PersistentFlags().Bool("lifecycle", false, "Enable builder lifecycle management")
+	rootCmd.PersistentFlags().Bool("lifecycle-read-only", false, "Track an existing (out-of-band) builder registration — state, balance, pending payments — without ever sending deposits, top-ups or exits")
 	rootCmd.PersistentFlags().Bool("epbs-enabled", false, "Enable ePBS bidding/revealing at startup")
 	rootCmd.PersistentFlags().Bool("builder-api-enabled", defaults.BuilderAPIEnabled, "Enable traditional Builder API at startup (served on --api-port)")
 	rootCmd.PersistentFlags().Uint64("builder-api-subsidy", defaults.BuilderAPI.BlockValueSubsidyGwei, "Gwei added to the bid value in both Fulu (getHeader) and Gloas (ExecutionPayment) Builder API bids")
 	rootCmd.PersistentFlags().Uint64("builder-api-value-override", defaults.BuilderAPI.ValueOverrideGwei, "Absolute total value in gwei served in Builder API bids, replacing block value + subsidy (0 = disabled)")
+	rootCmd.PersistentFlags().Uint64("builder-api-value-boost-pct", defaults.BuilderAPI.ValueBoostPct, "Test-only: report Builder API bid values at this percentage of their real value (e.g. 150 = 1.5x), decoupled from the payload value; boosted bids are marked in all local accounting (0 = disabled)")
 	rootCmd.PersistentFlags().String("builder-api-url", defaults.BuilderAPI.BuilderURL, "Publicly reachable URL of this builder (e.g. https://builder.example.com); used to validate builder_url in SignedRequestAuthV1")
 	rootCmd.PersistentFlags().Bool("builder-api-require-auth", defaults.BuilderAPI.RequireRequestAuth, "Require SignedRequestAuthV1 on getExecutionPayloadBid requests; reject unauthenticated requests with 401")
+	rootCmd.PersistentFlags().Bool("builder-api-verify-proposer-signature", defaults.BuilderAPI.VerifyProposerSignature, "Verify the proposer signature on submitted blinded blocks before releasing the payload (disable on devnets only)")
+	rootCmd.PersistentFlags().StringSlice("builder-api-publish-nodes", nil, "Additional beacon node URLs the unblinded block is published to alongside the CL client (comma-separated; first acceptance answers the proposer)")
+	rootCmd.PersistentFlags().String("builder-api-broadcast-validation", defaults.BuilderAPI.BroadcastValidation, "Broadcast validation level for publishing unblinded blocks: gossip, consensus or consensus_and_equivocation")
+	rootCmd.PersistentFlags().String("builder-api-blob-sidecars", defaults.BuilderAPI.BlobSidecars, "Separate blob sidecar publication for Fulu blocks: auto (nodes missing the blobs), always or never")
+	rootCmd.PersistentFlags().Int("builder-api-parent-candidates", defaults.BuilderAPI.ParentCandidates, "Alternative parents per slot that get their own candidate payload when payload attributes change parent, so bid requests for them are served (0 = latest parent only)")
+	rootCmd.PersistentFlags().Int("builder-api-capture-size", defaults.BuilderAPI.CaptureSize, "Recent Builder API request/response exchanges kept (secrets scrubbed) for download from /api/buildoor/builder-api/captures (0 = disabled)")
+	rootCmd.PersistentFlags().Int("builder-api-capture-max-body", defaults.BuilderAPI.CaptureMaxBody, "Maximum captured bytes of each Builder API request and response body (longer bodies are truncated)")
+	rootCmd.PersistentFlags().StringSlice("builder-api-client-quirks", nil, "Per-client workarounds as client:quirk (quirks: lenient_timestamp, lenient_content_type, json_responses; client detected from the User-Agent, e.g. prysm:json_responses)")
+	rootCmd.PersistentFlags().Bool("builder-api-payment-tx", defaults.BuilderAPI.PaymentTx, "Append a block value transfer from the builder wallet to the proposer's fee recipient to pre-Gloas payloads (requires --wallet-privkey and --el-rpc)")
+	rootCmd.PersistentFlags().StringSlice("builder-api-backfill-relays", nil, "Relay URLs the registrations of upcoming proposers are pulled from each epoch into the validator registration store, for proposers registered only with a relay (comma-separated; empty = off)")
+	rootCmd.PersistentFlags().Uint64("builder-api-slot-tolerance", defaults.BuilderAPI.SlotTolerance, "Slots by which Builder API bid requests and block submissions may deviate beyond the current/next slot window")
 	rootCmd.PersistentFlags().Uint64("deposit-amount", defaults.DepositAmount, "Builder deposit amount in Gwei")
 	rootCmd.PersistentFlags().Uint64("topup-threshold", defaults.TopupThreshold, "Balance threshold for auto top-up in Gwei")
 	rootCmd.PersistentFlags().Uint64("topup-amount", defaults.TopupAmount, "Amount to top-up in Gwei")
+	rootCmd.PersistentFlags().String("deposit-batch-contract", "", "Optional batching contract forwarding concatenated builder deposit requests to the deposit predeploy; multi-amount top-ups go out as one transaction")
 	rootCmd.PersistentFlags().Uint64("deposit-max-fee", defaults.DepositMaxFeeGwei, "Max builder deposit contract queue fee in Gwei; deposits/top-ups are delayed above this (0 = no limit)")
 	rootCmd.PersistentFlags().String("extra-data", defaults.ExtraData, "Prefix injected into the built payload's extra-data field (padded with the EL's original extra data, truncated to 32 bytes)")
 	rootCmd.PersistentFlags().String("log-level", "info", "Log level (debug, info, warn, error)")
@@ -89,6 +115,14 @@ func init() {
 	rootCmd.PersistentFlags().Uint64("epbs-bid-subsidy", defaults.EPBS.BidSubsidy, "Gwei added to every bid so it clears the proposer's local-EL threshold")
 	rootCmd.PersistentFlags().Uint64("epbs-bid-value-override", defaults.EPBS.BidValueOverride, "Absolute p2p bid base value in gwei, replacing max(blockValue, bid-min) + subsidy (0 = disabled); allows underbidding the block value for testing")
 	rootCmd.PersistentFlags().Uint64("epbs-vote-threshold", defaults.EPBS.HeadVoteThresholdPct, "Head-vote participation threshold in percent; crossing it fires an immediate threshold_met update (0 = disabled)")
+	rootCmd.PersistentFlags().Bool("epbs-replace-stale-bids", defaults.EPBS.ReplaceStaleBids, "Replace the bid committing to a stale payload (rebuilt after the slot's payload attributes changed), outbidding it on the same parent")
+	rootCmd.PersistentFlags().Bool("epbs-snipe", defaults.EPBS.SnipeEnabled, "Snipe mode: send a single late bid --epbs-snipe-lead ms before bid end, only if no competitor bid above --epbs-snipe-max-competitor was seen")
+	rootCmd.PersistentFlags().Int64("epbs-snipe-lead", defaults.EPBS.SnipeLeadMs, "How long before the bid end time the snipe bid fires, in ms")
+	rootCmd.PersistentFlags().Uint64("epbs-snipe-max-competitor", defaults.EPBS.SnipeMaxCompetitorGwei, "Highest competitor bid in gwei a snipe tolerates; a higher competitor bid skips the slot (0 = any competitor bid skips it)")
+	rootCmd.PersistentFlags().String("epbs-bid-strategy", defaults.EPBS.BidStrategy, "p2p bid strategy: fixed, linear, counter-bid, last-moment-snipe or random-walk")
+	rootCmd.PersistentFlags().Uint64("epbs-counter-bid-step", defaults.EPBS.CounterBidStepGwei, "counter-bid strategy: gwei to outbid the highest competitor bid by")
+	rootCmd.PersistentFlags().Uint64("epbs-counter-bid-max", defaults.EPBS.CounterBidMaxGwei, "counter-bid strategy: highest counter-bid in gwei (0 = no cap)")
+	rootCmd.PersistentFlags().Uint64("epbs-random-walk-step", defaults.EPBS.RandomWalkStepGwei, "random-walk strategy: largest step in gwei a re-bid moves up or down")
 
 	// Payload reveal (shared by the p2p bidder and Builder API flows)
 	rootCmd.PersistentFlags().Bool("reveal-enabled", defaults.Reveal.Enabled, "Globally enable payload reveals (per-slot action plans can still force/suppress)")
@@ -98,6 +132,18 @@ func init() {
 	rootCmd.PersistentFlags().String("reveal-broadcast-validation", defaults.Reveal.BroadcastValidation, "Envelope broadcast validation: gossip, consensus or consensus_and_equivocation")
 	rootCmd.PersistentFlags().Uint64("reveal-max-attempts", defaults.Reveal.MaxAttempts, "Total publish attempts per reveal")
 	rootCmd.PersistentFlags().Int64("reveal-retry-interval", defaults.Reveal.RetryIntervalMs, "Wait between failed reveal attempts in ms")
+	rootCmd.PersistentFlags().Bool("reveal-require-inclusion", defaults.Reveal.RequireInclusion, "Only reveal automatically once the committing block is seen at the head carrying our bid (skips unconfirmed reveals at slot end)")
+	rootCmd.PersistentFlags().Uint64("reveal-chaos-withhold-pct", 0, "Chaos testing: percent of slots whose payload reveal is deliberately withheld")
+	rootCmd.PersistentFlags().Uint64("reveal-chaos-delay-pct", 0, "Chaos testing: percent of slots whose payload reveal is deliberately delayed by --reveal-chaos-delay")
+	rootCmd.PersistentFlags().Int64("reveal-chaos-delay", defaults.Reveal.ChaosDelayMs, "Chaos testing: delay of chaos-delayed reveals in ms past the reveal time")
+
+	// Canary bid mode
+	rootCmd.PersistentFlags().Bool("canary-mode", defaults.Canary.Enabled, "Canary mode: run the full build/bid/reveal pipeline but price every bid at --canary-bid-gwei, to validate a deployment without economic exposure")
+	rootCmd.PersistentFlags().Uint64("canary-bid-gwei", defaults.Canary.BidGwei, "Fixed bid value in gwei used in canary mode")
+
+	// Epoch bid budget
+	rootCmd.PersistentFlags().Uint64("bid-budget-epoch-gwei", defaults.BidBudget.EpochMaxGwei, "Maximum p2p bid spend per epoch in gwei; bids are capped to the slot's share and refused once exhausted (0 = no budget)")
+	rootCmd.PersistentFlags().String("bid-budget-distribution", defaults.BidBudget.Distribution, "How the epoch bid budget is spread over slots: uniform, front_loaded or value_weighted")
 
 	// Payload Build Time (0 = auto from slot time, scaled from the 12s value)
 	rootCmd.PersistentFlags().Uint64("payload-build-time", 0, "Time to allow the EL to build the payload in ms (0 = auto: 2100ms @12s, scaled to slot time)")
@@ -105,12 +151,71 @@ func init() {
 	// Per-slot result/artifact history
 	rootCmd.PersistentFlags().Uint64("slot-result-retention-epochs", defaults.SlotResultRetentionEpochs, "Epochs of per-slot action plan + result history to keep before pruning (must be > 0)")
 	rootCmd.PersistentFlags().Uint64("slot-artifact-retention-epochs", defaults.SlotArtifactRetentionEpochs, "Epochs of raw SSZ artifacts (payloads, signed bids, envelopes) to keep in the state-db; raw payloads dominate disk usage (must be > 0)")
+	rootCmd.PersistentFlags().Uint64("slot-result-max-slots", 0, "Cap the per-slot result history at the newest N slots on top of the retention window (0 = no cap)")
+	rootCmd.PersistentFlags().Uint64("slot-artifact-max-mb", 0, "Cap the raw SSZ artifacts stored in the state-db at this size in MB, pruning the oldest slots first (0 = no cap)")
+	rootCmd.PersistentFlags().String("retention-archive-dir", "", "Archive pruned slot results and artifacts to gzip-compressed NDJSON files (one per store and UTC day) in this directory before pruning")
 	rootCmd.PersistentFlags().Bool("slot-artifact-capture-enabled", defaults.SlotArtifactCaptureEnabled, "Capture raw SSZ artifacts (payloads, signed bids, envelopes) per slot; result summaries are recorded regardless")
 
 	// Validator ranges
 	rootCmd.PersistentFlags().String("validator-ranges-file", "", "Path to validator ranges YAML file (format: '0-127: client-name')")
 	rootCmd.PersistentFlags().String("validator-ranges-url", "", "URL to fetch validator ranges JSON (format: {\"ranges\": {\"0-127\": \"client-name\"}})")
 
+	// Audit exporter
+	rootCmd.PersistentFlags().String("audit-export-url", "", "Optional endpoint receiving an HMAC-signed JSON summary of each slot's builder actions (bid roots, reveal status, payment)")
+	rootCmd.PersistentFlags().String("audit-export-secret", "", "HMAC-SHA256 secret used to sign audit export summaries (required with --audit-export-url)")
+	rootCmd.PersistentFlags().Uint64("audit-export-delay-slots", defaults.AuditExport.DelaySlots, "Slots to wait after a slot before exporting its audit summary")
+
+	// Peer mesh
+	rootCmd.PersistentFlags().StringSlice("peer-urls", nil, "API base URLs of other buildoor instances to share observed p2p bids with (comma-separated)")
+	rootCmd.PersistentFlags().String("peer-name", "", "Name of this node shown to mesh peers (default: short builder pubkey)")
+	rootCmd.PersistentFlags().Int64("peer-poll-interval", defaults.PeerMesh.PollIntervalMs, "Interval between peer bid polls in ms")
+
+	// Alerting
+	rootCmd.PersistentFlags().String("alert-rules-file", "", "Optional YAML file with alerting threshold rules (e.g. reveal failure rate, balance) that notify via webhook or disable services")
+
+	// Circuit breaker
+	rootCmd.PersistentFlags().Int("circuit-breaker-threshold", defaults.CircuitBreakerThreshold, "Consecutive p2p bid submission or reveal failures after which the offending service is disabled (0 = off)")
+
+	// Liveness watchdog
+	rootCmd.PersistentFlags().Bool("watchdog-enabled", defaults.Watchdog.Enabled, "Raise a critical alert for every epoch in which no payload was built for any eligible slot (or no slot was evaluated)")
+	rootCmd.PersistentFlags().Bool("watchdog-restart-event-stream", defaults.Watchdog.RestartEventStream, "Reconnect the beacon node event stream when an epoch fails the liveness check")
+
+	// Penalty monitor
+	rootCmd.PersistentFlags().Bool("penalty-monitor", defaults.PenaltyMonitor, "Hold bidding until acknowledged when a bid with our builder index for a payload we never built, or a proposer slashing of a slot we bid in, is observed")
+
+	// Beacon node capability check
+	rootCmd.PersistentFlags().Bool("capability-check", defaults.CapabilityCheck, "Probe the beacon node at startup and disable features it lacks the event topics or endpoints for (head-vote tracking, p2p bidding, proposer preferences)")
+
+	// Optional modules
+	rootCmd.PersistentFlags().StringSlice("modules", nil, "Optional modules to construct (comma-separated: "+strings.Join(config.ModuleNames, ", ")+"; dependencies are added; empty = all)")
+
+	// Event recording (replayed by `buildoor simulate --replay`)
+	rootCmd.PersistentFlags().String("record-events", "", "Append every head and payload_attributes event, timed relative to its slot, to this file for `buildoor simulate --replay`")
+
+	// Accelerated clock (local simulation only)
+	rootCmd.PersistentFlags().Float64("clock-speedup", 1, "Run the slot clock this many times faster than wall time from genesis on, for local simulation against nodes sharing the speedup (1 = wall time; never on a real network)")
+
+	// Signer backend
+	rootCmd.PersistentFlags().String("signer-backend", signer.BackendLocal, "Builder key signing backend: local (--builder-privkey/--builder-mnemonic) or web3signer (--remote-signer-url)")
+	rootCmd.PersistentFlags().String("remote-signer-url", "", "Web3Signer URL (with --signer-backend=web3signer)")
+	rootCmd.PersistentFlags().String("remote-signer-pubkey", "", "Builder BLS public key (hex) held by the Web3Signer (optional when it holds a single key)")
+
+	// Relay proxy
+	rootCmd.PersistentFlags().String("identity-name", "", "Builder name, appended to the extra-data prefix and served by /eth/v1/builder/info")
+	rootCmd.PersistentFlags().String("identity-url", "", "Builder operator URL served by /eth/v1/builder/info")
+	rootCmd.PersistentFlags().String("identity-contact", "", "Builder operator contact served by /eth/v1/builder/info")
+	rootCmd.PersistentFlags().String("withdrawals-source", defaults.Withdrawals.Source, "Preferred withdrawals source of payload builds: attributes (payload_attributes) or state (beacon node expected_withdrawals); the other one is the fallback")
+	rootCmd.PersistentFlags().Bool("withdrawals-cross-check", defaults.Withdrawals.CrossCheck, "Fetch both withdrawals sources for every build and report divergences (one extra beacon node state lookup per build)")
+	rootCmd.PersistentFlags().String("fee-recipient-rotation", defaults.FeeRecipientRotation.Mode, "Rotate the builder's own fee recipient (payload coinbase) across --fee-recipient-pool: off, slot or epoch (devnet testing)")
+	rootCmd.PersistentFlags().StringSlice("fee-recipient-pool", nil, "Addresses the builder's fee recipient rotates through with --fee-recipient-rotation (comma-separated)")
+	rootCmd.PersistentFlags().StringSlice("payload-fault-fields", nil, "Fault injection: payload fields corrupted in --payload-fault-pct of the built slots (state_root, receipts_root, withdrawals, blob_commitments; comma-separated)")
+	rootCmd.PersistentFlags().Uint64("payload-fault-pct", 0, "Fault injection: percent of built slots whose payload gets --payload-fault-fields corrupted before bidding and reveal")
+	rootCmd.PersistentFlags().String("blob-stuffing", "", "Blob stuffing: fill scheduled blocks with synthetic blob transactions from the builder wallet up to target, max or a blob count (requires --el-rpc and --wallet-privkey; empty = off)")
+	rootCmd.PersistentFlags().Uint64("blob-stuffing-every-nth", 1, "Blob stuffing: stuff the slots divisible by this number (1 = every slot)")
+	rootCmd.PersistentFlags().Uint64("gas-limit", 0, "Gas limit target built payloads move toward, replacing the proposer's registered/announced target (pre-Gloas requires --el-rpc; 0 = proposer's)")
+	rootCmd.PersistentFlags().Bool("gas-limit-violate", false, "Negative testing: move built payloads' gas limit the full protocol step away from the target instead of toward it (requires --el-rpc)")
+	rootCmd.PersistentFlags().StringSlice("relay-proxy-urls", nil, "Relay URLs that validator requests to /relay-proxy are forwarded to and recorded (comma-separated; empty = proxy off)")
+
 	// Bind all flags to viper
 	if err := v.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		logger.WithError(err).Fatal("Failed to bind flags")
@@ -160,34 +265,80 @@ func loadConfigFile() {
 	}
 }
 
+// applyProfile presets the selected built-in profile's settings as viper
+// defaults, below the config file, environment and explicit flags.
+func applyProfile() error {
+	name := v.GetString("profile")
+	if name == "" {
+		return nil
+	}
+
+	profile, err := config.LookupProfile(name)
+	if err != nil {
+		return fmt.Errorf("invalid --profile: %w", err)
+	}
+
+	for key, value := range profile.Settings {
+		v.SetDefault(key, value)
+	}
+
+	logger.WithField("profile", profile.Name).Info("Applied config profile")
+
+	return nil
+}
+
 func initConfig() error {
+	if err := applyProfile(); err != nil {
+		return err
+	}
+
 	cfg = &config.Config{
-		BuilderPrivkey:    v.GetString("builder-privkey"),
-		BuilderMnemonic:   v.GetString("builder-mnemonic"),
-		BuilderKeyIndex:   v.GetUint64("builder-key-index"),
-		CLClient:          v.GetString("cl-client"),
-		ELEngineAPI:       v.GetString("el-engine-api"),
-		ELJWTSecret:       v.GetString("el-jwt-secret"),
-		ELRPC:             v.GetString("el-rpc"),
-		WalletPrivkey:     v.GetString("wallet-privkey"),
-		APIPort:           v.GetInt("api-port"),
-		AuthProviderURL:   v.GetString("auth-provider-url"),
-		InjectHeadHTML:    v.GetString("inject-head-html"),
-		OverviewURL:       v.GetString("overview-url"),
-		LifecycleEnabled:  v.GetBool("lifecycle"),
-		EPBSEnabled:       v.GetBool("epbs-enabled"),
-		BuilderAPIEnabled: v.GetBool("builder-api-enabled"),
+		BuilderPrivkey:           v.GetString("builder-privkey"),
+		BuilderMnemonic:          v.GetString("builder-mnemonic"),
+		BuilderKeyIndex:          v.GetUint64("builder-key-index"),
+		CLClient:                 v.GetString("cl-client"),
+		CLClientFallbacks:        v.GetStringSlice("cl-client-fallbacks"),
+		CLClientSSZ:              v.GetBool("cl-client-ssz"),
+		ELEngineAPI:              v.GetString("el-engine-api"),
+		ELJWTSecret:              v.GetString("el-jwt-secret"),
+		ELEngineRaceAPIs:         v.GetStringSlice("el-engine-race-apis"),
+		ELRPC:                    v.GetString("el-rpc"),
+		ELWSRPC:                  v.GetString("el-ws-rpc"),
+		BuilderTxs:               v.GetStringSlice("builder-txs"),
+		WalletPrivkey:            v.GetString("wallet-privkey"),
+		BuilderWithdrawalAddress: v.GetString("builder-withdrawal-address"),
+		APIPort:                  v.GetInt("api-port"),
+		AuthProviderURL:          v.GetString("auth-provider-url"),
+		InjectHeadHTML:           v.GetString("inject-head-html"),
+		OverviewURL:              v.GetString("overview-url"),
+		LifecycleEnabled:         v.GetBool("lifecycle"),
+		LifecycleReadOnly:        v.GetBool("lifecycle-read-only"),
+		EPBSEnabled:              v.GetBool("epbs-enabled"),
+		BuilderAPIEnabled:        v.GetBool("builder-api-enabled"),
 		BuilderAPI: config.BuilderAPIConfig{
-			BuilderURL:            v.GetString("builder-api-url"),
-			RequireRequestAuth:    v.GetBool("builder-api-require-auth"),
-			BlockValueSubsidyGwei: v.GetUint64("builder-api-subsidy"),
-			ValueOverrideGwei:     v.GetUint64("builder-api-value-override"),
-		},
-		DepositMaxFeeGwei: v.GetUint64("deposit-max-fee"),
-		DepositAmount:     v.GetUint64("deposit-amount"),
-		TopupThreshold:    v.GetUint64("topup-threshold"),
-		TopupAmount:       v.GetUint64("topup-amount"),
-		ExtraData:         v.GetString("extra-data"),
+			BuilderURL:              v.GetString("builder-api-url"),
+			RequireRequestAuth:      v.GetBool("builder-api-require-auth"),
+			BlockValueSubsidyGwei:   v.GetUint64("builder-api-subsidy"),
+			ValueOverrideGwei:       v.GetUint64("builder-api-value-override"),
+			ValueBoostPct:           v.GetUint64("builder-api-value-boost-pct"),
+			SlotTolerance:           v.GetUint64("builder-api-slot-tolerance"),
+			VerifyProposerSignature: v.GetBool("builder-api-verify-proposer-signature"),
+			PublishBeaconNodes:      v.GetStringSlice("builder-api-publish-nodes"),
+			BroadcastValidation:     v.GetString("builder-api-broadcast-validation"),
+			BlobSidecars:            v.GetString("builder-api-blob-sidecars"),
+			ParentCandidates:        v.GetInt("builder-api-parent-candidates"),
+			PaymentTx:               v.GetBool("builder-api-payment-tx"),
+			ClientQuirks:            v.GetStringSlice("builder-api-client-quirks"),
+			CaptureSize:             v.GetInt("builder-api-capture-size"),
+			CaptureMaxBody:          v.GetInt("builder-api-capture-max-body"),
+			BackfillRelays:          v.GetStringSlice("builder-api-backfill-relays"),
+		},
+		DepositMaxFeeGwei:    v.GetUint64("deposit-max-fee"),
+		DepositBatchContract: v.GetString("deposit-batch-contract"),
+		DepositAmount:        v.GetUint64("deposit-amount"),
+		TopupThreshold:       v.GetUint64("topup-threshold"),
+		TopupAmount:          v.GetUint64("topup-amount"),
+		ExtraData:            v.GetString("extra-data"),
 		Schedule: config.ScheduleConfig{
 			Mode:      config.ScheduleMode(v.GetString("schedule-mode")),
 			EveryNth:  v.GetUint64("schedule-every-nth"),
@@ -195,15 +346,23 @@ func initConfig() error {
 			StartSlot: v.GetUint64("schedule-start-slot"),
 		},
 		EPBS: config.EPBSConfig{
-			BuildStartTime:       v.GetInt64("build-start-time"),
-			BidStartTime:         v.GetInt64("epbs-bid-start"),
-			BidEndTime:           v.GetInt64("epbs-bid-end"),
-			BidMinAmount:         v.GetUint64("epbs-bid-min"),
-			BidIncrease:          v.GetUint64("epbs-bid-increase"),
-			BidInterval:          v.GetInt64("epbs-bid-interval"),
-			BidSubsidy:           v.GetUint64("epbs-bid-subsidy"),
-			BidValueOverride:     v.GetUint64("epbs-bid-value-override"),
-			HeadVoteThresholdPct: v.GetUint64("epbs-vote-threshold"),
+			BuildStartTime:         v.GetInt64("build-start-time"),
+			BidStartTime:           v.GetInt64("epbs-bid-start"),
+			BidEndTime:             v.GetInt64("epbs-bid-end"),
+			BidMinAmount:           v.GetUint64("epbs-bid-min"),
+			BidIncrease:            v.GetUint64("epbs-bid-increase"),
+			BidInterval:            v.GetInt64("epbs-bid-interval"),
+			BidSubsidy:             v.GetUint64("epbs-bid-subsidy"),
+			BidValueOverride:       v.GetUint64("epbs-bid-value-override"),
+			HeadVoteThresholdPct:   v.GetUint64("epbs-vote-threshold"),
+			ReplaceStaleBids:       v.GetBool("epbs-replace-stale-bids"),
+			SnipeEnabled:           v.GetBool("epbs-snipe"),
+			SnipeLeadMs:            v.GetInt64("epbs-snipe-lead"),
+			SnipeMaxCompetitorGwei: v.GetUint64("epbs-snipe-max-competitor"),
+			BidStrategy:            v.GetString("epbs-bid-strategy"),
+			CounterBidStepGwei:     v.GetUint64("epbs-counter-bid-step"),
+			CounterBidMaxGwei:      v.GetUint64("epbs-counter-bid-max"),
+			RandomWalkStepGwei:     v.GetUint64("epbs-random-walk-step"),
 		},
 		Reveal: config.RevealConfig{
 			Enabled:             v.GetBool("reveal-enabled"),
@@ -213,22 +372,155 @@ func initConfig() error {
 			BroadcastValidation: v.GetString("reveal-broadcast-validation"),
 			MaxAttempts:         v.GetUint64("reveal-max-attempts"),
 			RetryIntervalMs:     v.GetInt64("reveal-retry-interval"),
+			RequireInclusion:    v.GetBool("reveal-require-inclusion"),
+			ChaosWithholdPct:    v.GetUint64("reveal-chaos-withhold-pct"),
+			ChaosDelayPct:       v.GetUint64("reveal-chaos-delay-pct"),
+			ChaosDelayMs:        v.GetInt64("reveal-chaos-delay"),
+		},
+		Canary: config.CanaryConfig{
+			Enabled: v.GetBool("canary-mode"),
+			BidGwei: v.GetUint64("canary-bid-gwei"),
+		},
+		BidBudget: config.BidBudgetConfig{
+			EpochMaxGwei: v.GetUint64("bid-budget-epoch-gwei"),
+			Distribution: v.GetString("bid-budget-distribution"),
 		},
 		PayloadBuildTime:            v.GetUint64("payload-build-time"),
 		SlotResultRetentionEpochs:   v.GetUint64("slot-result-retention-epochs"),
 		SlotArtifactRetentionEpochs: v.GetUint64("slot-artifact-retention-epochs"),
+		SlotResultMaxSlots:          v.GetUint64("slot-result-max-slots"),
+		SlotArtifactMaxMB:           v.GetUint64("slot-artifact-max-mb"),
+		RetentionArchiveDir:         v.GetString("retention-archive-dir"),
 		SlotArtifactCaptureEnabled:  v.GetBool("slot-artifact-capture-enabled"),
 		ValidatorRanges: config.ValidatorRangesConfig{
 			File: v.GetString("validator-ranges-file"),
 			URL:  v.GetString("validator-ranges-url"),
 		},
 		StateDBPath: v.GetString("state-db"),
+		AuditExport: config.AuditExportConfig{
+			URL:        v.GetString("audit-export-url"),
+			Secret:     v.GetString("audit-export-secret"),
+			DelaySlots: v.GetUint64("audit-export-delay-slots"),
+		},
+		PeerMesh: config.PeerMeshConfig{
+			Name:           v.GetString("peer-name"),
+			Peers:          v.GetStringSlice("peer-urls"),
+			PollIntervalMs: v.GetInt64("peer-poll-interval"),
+		},
+		AlertRulesFile:          v.GetString("alert-rules-file"),
+		CircuitBreakerThreshold: v.GetInt("circuit-breaker-threshold"),
+		Watchdog: config.WatchdogConfig{
+			Enabled:            v.GetBool("watchdog-enabled"),
+			RestartEventStream: v.GetBool("watchdog-restart-event-stream"),
+		},
+		PenaltyMonitor:   v.GetBool("penalty-monitor"),
+		CapabilityCheck:  v.GetBool("capability-check"),
+		ClockSpeedup:     v.GetFloat64("clock-speedup"),
+		Modules:          v.GetStringSlice("modules"),
+		RecordEventsFile: v.GetString("record-events"),
+		Signer: config.SignerConfig{
+			Backend:      v.GetString("signer-backend"),
+			RemoteURL:    v.GetString("remote-signer-url"),
+			RemotePubkey: v.GetString("remote-signer-pubkey"),
+		},
+		RelayProxy: config.RelayProxyConfig{
+			Relays: v.GetStringSlice("relay-proxy-urls"),
+		},
+		Identity: config.IdentityConfig{
+			Name:    v.GetString("identity-name"),
+			URL:     v.GetString("identity-url"),
+			Contact: v.GetString("identity-contact"),
+		},
+		Withdrawals: config.WithdrawalsConfig{
+			Source:     v.GetString("withdrawals-source"),
+			CrossCheck: v.GetBool("withdrawals-cross-check"),
+		},
+		FeeRecipientRotation: config.FeeRecipientRotationConfig{
+			Mode: v.GetString("fee-recipient-rotation"),
+			Pool: v.GetStringSlice("fee-recipient-pool"),
+		},
+		PayloadFaults: config.PayloadFaultsConfig{
+			Fields: v.GetStringSlice("payload-fault-fields"),
+			Pct:    v.GetUint64("payload-fault-pct"),
+		},
+		BlobStuffing: config.BlobStuffingConfig{
+			Blobs:    v.GetString("blob-stuffing"),
+			EveryNth: v.GetUint64("blob-stuffing-every-nth"),
+		},
+		GasLimit: config.GasLimitConfig{
+			Target:  v.GetUint64("gas-limit"),
+			Violate: v.GetBool("gas-limit-violate"),
+		},
+	}
+
+	if branding := cfg.ExtraDataBranding(); len(branding) > 32 {
+		return fmt.Errorf("--extra-data plus --identity-name %q exceed the 32-byte extra-data limit", branding)
 	}
 
 	if cfg.BuilderPrivkey != "" && cfg.BuilderMnemonic != "" {
 		return fmt.Errorf("provide only one of --builder-privkey or --builder-mnemonic, not both")
 	}
 
+	extraWallets := v.GetStringSlice("extra-wallet-privkeys")
+	extraBuilders := v.GetStringSlice("extra-builder-privkeys")
+
+	if len(extraWallets) > len(extraBuilders) {
+		return fmt.Errorf("--extra-wallet-privkeys has %d keys for %d extra builders", len(extraWallets), len(extraBuilders))
+	}
+
+	for i, privkey := range extraBuilders {
+		extra := config.ExtraBuilderConfig{Privkey: privkey}
+		if i < len(extraWallets) {
+			extra.WalletPrivkey = extraWallets[i]
+		}
+
+		cfg.ExtraBuilders = append(cfg.ExtraBuilders, extra)
+	}
+
+	if cfg.Signer.Backend == signer.BackendWeb3Signer && cfg.Signer.RemoteURL == "" {
+		return fmt.Errorf("--remote-signer-url is required with --signer-backend=web3signer")
+	}
+
+	if cfg.AuditExport.URL != "" && cfg.AuditExport.Secret == "" {
+		return fmt.Errorf("--audit-export-secret is required when --audit-export-url is set")
+	}
+
+	if cfg.CircuitBreakerThreshold < 0 {
+		return fmt.Errorf("--circuit-breaker-threshold must be >= 0")
+	}
+
+	if len(cfg.PeerMesh.Peers) > 0 && cfg.PeerMesh.PollIntervalMs <= 0 {
+		return fmt.Errorf("--peer-poll-interval must be > 0")
+	}
+
+	// The engine API client only speaks HTTP; WebSocket engine endpoints are
+	// not supported.
+	if isWebSocketURL(cfg.ELEngineAPI) {
+		return fmt.Errorf("invalid --el-engine-api %q: WebSocket engine APIs are not supported, use the http:// endpoint", cfg.ELEngineAPI)
+	}
+
+	engineURLs := map[string]bool{cfg.ELEngineAPI: true}
+	for _, rawURL := range cfg.ELEngineRaceAPIs {
+		if rawURL == "" || engineURLs[rawURL] {
+			return fmt.Errorf("invalid --el-engine-race-apis: %q is empty or duplicates another engine API", rawURL)
+		}
+
+		if isWebSocketURL(rawURL) {
+			return fmt.Errorf("invalid --el-engine-race-apis: %q is a WebSocket URL, only http:// engine APIs are supported", rawURL)
+		}
+
+		engineURLs[rawURL] = true
+	}
+
+	if cfg.ELWSRPC != "" && !isWebSocketURL(cfg.ELWSRPC) {
+		return fmt.Errorf("invalid --el-ws-rpc %q: must be a ws:// or wss:// URL", cfg.ELWSRPC)
+	}
+
+	if !config.IsBidStrategy(cfg.EPBS.BidStrategy) {
+		return fmt.Errorf("invalid --epbs-bid-strategy %q: must be fixed, linear, counter-bid, last-moment-snipe or random-walk",
+			cfg.EPBS.BidStrategy)
+	}
+
 	if cfg.Reveal.GateMode != cfg.Reveal.NormalizedGateMode() {
 		return fmt.Errorf("invalid --reveal-gate-mode %q: must be time, vote, vote_or_time or vote_and_time",
 			cfg.Reveal.GateMode)
@@ -239,5 +531,114 @@ func initConfig() error {
 			cfg.Reveal.BroadcastValidation)
 	}
 
+	if cfg.Reveal.ChaosWithholdPct+cfg.Reveal.ChaosDelayPct > 100 {
+		return fmt.Errorf("--reveal-chaos-withhold-pct and --reveal-chaos-delay-pct must add up to at most 100")
+	}
+
+	if cfg.Reveal.ChaosDelayMs < 0 {
+		return fmt.Errorf("--reveal-chaos-delay must not be negative")
+	}
+
+	if cfg.BuilderAPI.BroadcastValidation != cfg.BuilderAPI.NormalizedBroadcastValidation() {
+		return fmt.Errorf("invalid --builder-api-broadcast-validation %q: must be gossip, consensus or consensus_and_equivocation",
+			cfg.BuilderAPI.BroadcastValidation)
+	}
+
+	if cfg.BuilderAPI.BlobSidecars != cfg.BuilderAPI.NormalizedBlobSidecars() {
+		return fmt.Errorf("invalid --builder-api-blob-sidecars %q: must be auto, always or never",
+			cfg.BuilderAPI.BlobSidecars)
+	}
+
+	if cfg.Withdrawals.Source != cfg.Withdrawals.NormalizedSource() {
+		return fmt.Errorf("invalid --withdrawals-source %q: must be attributes or state", cfg.Withdrawals.Source)
+	}
+
+	if cfg.DepositBatchContract != "" && !common.IsHexAddress(cfg.DepositBatchContract) {
+		return fmt.Errorf("invalid --deposit-batch-contract %q", cfg.DepositBatchContract)
+	}
+
+	if cfg.BuilderWithdrawalAddress != "" && !common.IsHexAddress(cfg.BuilderWithdrawalAddress) {
+		return fmt.Errorf("invalid --builder-withdrawal-address %q", cfg.BuilderWithdrawalAddress)
+	}
+
+	if cfg.BidBudget.Distribution != cfg.BidBudget.NormalizedDistribution() {
+		return fmt.Errorf("invalid --bid-budget-distribution %q: must be uniform, front_loaded or value_weighted",
+			cfg.BidBudget.Distribution)
+	}
+
+	if cfg.FeeRecipientRotation.Mode != cfg.FeeRecipientRotation.NormalizedMode() {
+		return fmt.Errorf("invalid --fee-recipient-rotation %q: must be off, slot or epoch", cfg.FeeRecipientRotation.Mode)
+	}
+
+	for _, addr := range cfg.FeeRecipientRotation.Pool {
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("invalid --fee-recipient-pool address %q", addr)
+		}
+	}
+
+	if cfg.FeeRecipientRotation.Mode != config.FeeRecipientRotationOff && len(cfg.FeeRecipientRotation.Pool) == 0 {
+		return fmt.Errorf("--fee-recipient-pool is required with --fee-recipient-rotation=%s", cfg.FeeRecipientRotation.Mode)
+	}
+
+	if err := config.ValidatePayloadFaults(cfg.PayloadFaults.Fields); err != nil {
+		return fmt.Errorf("invalid --payload-fault-fields: %w", err)
+	}
+
+	if cfg.PayloadFaults.Pct > 100 {
+		return fmt.Errorf("--payload-fault-pct must be at most 100")
+	}
+
+	if cfg.BlobStuffing.Enabled() {
+		if err := config.ValidateBlobStuffing(cfg.BlobStuffing.Blobs); err != nil {
+			return fmt.Errorf("invalid --blob-stuffing: %w", err)
+		}
+	}
+
+	if err := config.ValidateGasLimit(cfg.GasLimit.Target); err != nil {
+		return fmt.Errorf("invalid --gas-limit: %w", err)
+	}
+
+	if cfg.GasLimit.Violate && cfg.ELRPC == "" {
+		return fmt.Errorf("--gas-limit-violate requires --el-rpc")
+	}
+
+	if cfg.BuilderAPI.ParentCandidates < 0 {
+		return fmt.Errorf("invalid --builder-api-parent-candidates %d: must not be negative",
+			cfg.BuilderAPI.ParentCandidates)
+	}
+
+	if err := config.ValidateClientQuirks(cfg.BuilderAPI.ClientQuirks); err != nil {
+		return fmt.Errorf("invalid --builder-api-client-quirks: %w", err)
+	}
+
+	if boost := cfg.BuilderAPI.ValueBoostPct; boost != 0 && boost < 100 {
+		return fmt.Errorf("invalid --builder-api-value-boost-pct %d: must be 0 (disabled) or at least 100", boost)
+	}
+
+	if cfg.BuilderAPI.CaptureSize < 0 || cfg.BuilderAPI.CaptureMaxBody < 0 {
+		return fmt.Errorf("--builder-api-capture-size and --builder-api-capture-max-body must not be negative")
+	}
+
+	if len(cfg.BuilderTxs) > 0 && cfg.ELRPC == "" {
+		return fmt.Errorf("--builder-txs requires --el-rpc")
+	}
+
+	if cfg.BuilderAPI.PaymentTx && (cfg.WalletPrivkey == "" || cfg.ELRPC == "") {
+		return fmt.Errorf("--builder-api-payment-tx requires --wallet-privkey and --el-rpc")
+	}
+
+	if cfg.ClockSpeedup < 0 {
+		return fmt.Errorf("invalid --clock-speedup %v: must not be negative", cfg.ClockSpeedup)
+	}
+
+	if err := cfg.ValidateModules(); err != nil {
+		return fmt.Errorf("invalid --modules: %w", err)
+	}
+
 	return nil
 }
+
+// isWebSocketURL reports whether rawURL uses the ws:// or wss:// scheme.
+func isWebSocketURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "ws://") || strings.HasPrefix(rawURL, "wss://")
+}
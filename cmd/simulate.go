@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ethpandaops/buildoor/pkg/simulation"
+	"github.com/ethpandaops/buildoor/pkg/simulation/recording"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Run the bid strategy against a simulated chain on an accelerated clock",
+	Long: `Starts buildoor in-process against a fake Gloas beacon node and engine API
+running on an accelerated clock, and drives --slots proposal slots through the
+full build/bid/reveal pipeline. Every slot gets a seeded block value and, in
+--competitor-ratio of the slots, a competing bid; the proposer takes the
+highest bid. The report sums up wins, losses, reveals and the value paid.
+
+The strategy under test is the regular buildoor configuration: the bidding
+(--epbs-bid-strategy, --epbs-bid-subsidy, ...), reveal, bid budget and
+schedule settings apply as with "run". Endpoints and keys are the
+simulation's own; nothing leaves the process. Equal seeds replay the same market. Example:
+
+  buildoor simulate --slots 5000 --speedup 100 --epbs-bid-strategy counter-bid
+
+With --replay, the slots follow a head/payload_attributes event sequence
+recorded by "run --record-events" instead: each recorded block and payload
+attributes event arrives at its recorded offset into the slot (scaled to the
+simulated slot time), and slots without a recorded block stay empty. This
+reproduces timing bugs deterministically, without a live beacon node:
+
+  buildoor run ... --record-events events.jsonl
+  buildoor simulate --replay events.jsonl --speedup 20`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		simCfg := simulation.DefaultConfig()
+		flags := cmd.Flags()
+
+		var err error
+
+		if simCfg.Slots, err = flags.GetInt("slots"); err != nil {
+			return err
+		}
+
+		if simCfg.Speedup, err = flags.GetFloat64("speedup"); err != nil {
+			return err
+		}
+
+		if simCfg.Seed, err = flags.GetUint64("seed"); err != nil {
+			return err
+		}
+
+		if simCfg.BlockValueMinGwei, err = flags.GetUint64("block-value-min"); err != nil {
+			return err
+		}
+
+		if simCfg.BlockValueMaxGwei, err = flags.GetUint64("block-value-max"); err != nil {
+			return err
+		}
+
+		if simCfg.CompetitorRatio, err = flags.GetFloat64("competitor-ratio"); err != nil {
+			return err
+		}
+
+		if simCfg.CompetitorMinGwei, err = flags.GetUint64("competitor-bid-min"); err != nil {
+			return err
+		}
+
+		if simCfg.CompetitorMaxGwei, err = flags.GetUint64("competitor-bid-max"); err != nil {
+			return err
+		}
+
+		if simCfg.CompetitorBidTime, err = flags.GetInt64("competitor-bid-time"); err != nil {
+			return err
+		}
+
+		jsonOut, err := flags.GetBool("json")
+		if err != nil {
+			return err
+		}
+
+		replayPath, err := flags.GetString("replay")
+		if err != nil {
+			return err
+		}
+
+		if replayPath != "" {
+			if simCfg.Replay, err = recording.Load(replayPath); err != nil {
+				return fmt.Errorf("invalid --replay: %w", err)
+			}
+		}
+
+		runner, err := simulation.NewRunner(simCfg, cfg, logger)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		report, err := runner.Run(ctx)
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+
+			return enc.Encode(report)
+		}
+
+		return report.WriteText(os.Stdout)
+	},
+}
+
+func init() {
+	defaults := simulation.DefaultConfig()
+
+	f := simulateCmd.Flags()
+	f.Int("slots", defaults.Slots, "Number of simulated proposal slots")
+	f.Float64("speedup", defaults.Speedup, "Clock speedup of the simulated chain (4s slots; 100 = 1500 slots per minute)")
+	f.Uint64("seed", defaults.Seed, "Seed of the simulated market (block values and competing bids)")
+	f.Uint64("block-value-min", defaults.BlockValueMinGwei, "Lowest payload block value in gwei")
+	f.Uint64("block-value-max", defaults.BlockValueMaxGwei, "Highest payload block value in gwei")
+	f.Float64("competitor-ratio", defaults.CompetitorRatio, "Fraction (0-1) of slots with a competing bid")
+	f.Uint64("competitor-bid-min", defaults.CompetitorMinGwei, "Lowest competing bid in gwei")
+	f.Uint64("competitor-bid-max", defaults.CompetitorMaxGwei, "Highest competing bid in gwei")
+	f.Int64("competitor-bid-time", defaults.CompetitorBidTime, "When the competing bid is gossiped, in ms relative to slot start")
+	f.String("replay", "", "Replay a head/payload_attributes recording (run --record-events) instead of announcing every slot at its start; --slots is ignored")
+	f.Bool("json", false, "Print the report (including every slot's outcome) as JSON")
+
+	rootCmd.AddCommand(simulateCmd)
+}
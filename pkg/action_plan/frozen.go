@@ -1,6 +1,9 @@
 package action_plan
 
 import (
+	"math"
+	"math/bits"
+	"slices"
 	"time"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
@@ -24,6 +27,14 @@ const (
 	BuildSkipReasonNoConsumer = "no_consumer"
 )
 
+// Reveal chaos outcomes carried by ResolvedRevealSettings.Chaos.
+const (
+	// RevealChaosWithhold marks a reveal withheld by reveal chaos.
+	RevealChaosWithhold = "withhold"
+	// RevealChaosDelay marks a reveal delayed by reveal chaos.
+	RevealChaosDelay = "delay"
+)
+
 // FrozenPlan is the immutable per-slot execution snapshot taken when execution
 // for the slot can begin: the raw sparse plan plus all effective settings
 // resolved from the live global config at freeze time. Consumers act on the
@@ -87,6 +98,25 @@ type ResolvedBuildSettings struct {
 	// execution payload instead of the immediate parent — a deliberate
 	// parent-payload reorg attempt (see BuildPlan.ReorgParentPayload).
 	ReorgParentPayload bool `json:"reorg_parent_payload,omitempty"`
+
+	// Faults are the payload fields (config.PayloadFault*) corrupted after
+	// the build: the plan's build.faults, else the configured fields when the
+	// slot's roll falls within --payload-fault-pct.
+	Faults []string `json:"faults,omitempty"`
+
+	// BlobStuffing is the blob count the block is filled to with synthetic
+	// blob transactions (target, max or a number): the plan's
+	// build.blob_stuffing, else --blob-stuffing when the schedule selects the
+	// slot. Empty for no stuffing.
+	BlobStuffing string `json:"blob_stuffing,omitempty"`
+
+	// GasLimit is the gas limit target replacing the proposer's: the plan's
+	// build.gas_limit, else --gas-limit. 0 keeps the proposer's target.
+	GasLimit uint64 `json:"gas_limit,omitempty"`
+
+	// GasLimitViolate moves the payload's gas limit away from the target
+	// (build.gas_limit_violate or --gas-limit-violate).
+	GasLimitViolate bool `json:"gas_limit_violate,omitempty"`
 }
 
 // ResolvedBidSettings are the effective p2p bidding parameters for the slot.
@@ -108,6 +138,28 @@ type ResolvedBidSettings struct {
 	// Forced marks that the plan activated bidding although the module is
 	// globally disabled.
 	Forced bool `json:"forced,omitempty"`
+
+	// Canary marks that canary mode priced the slot's bids at the fixed
+	// canary value (ValueGwei; no increase or subsidy).
+	Canary bool `json:"canary,omitempty"`
+
+	// ReplaceStale replaces bids committing to a stale payload with the
+	// rebuilt payload's bid (epbs.replace_stale_bids).
+	ReplaceStale bool `json:"replace_stale,omitempty"`
+
+	// Snipe sends a single bid SnipeLeadMs before EndMs, only when no
+	// competitor bid above SnipeMaxCompetitorGwei was seen (epbs.snipe_*).
+	Snipe                  bool   `json:"snipe,omitempty"`
+	SnipeLeadMs            int64  `json:"snipe_lead_ms,omitempty"`
+	SnipeMaxCompetitorGwei uint64 `json:"snipe_max_competitor_gwei,omitempty"`
+
+	// Strategy prices the slot's automatic bids (epbs.bid_strategy, one of
+	// the config.BidStrategy* names); the counter-bid and random-walk
+	// parameters are only set for their strategy.
+	Strategy           string `json:"strategy"`
+	CounterStepGwei    uint64 `json:"counter_step_gwei,omitempty"`
+	CounterMaxGwei     uint64 `json:"counter_max_gwei,omitempty"`
+	RandomWalkStepGwei uint64 `json:"random_walk_step_gwei,omitempty"`
 }
 
 // ResolvedBuilderAPISettings are the effective Builder API bid-serving
@@ -124,6 +176,33 @@ type ResolvedBuilderAPISettings struct {
 	// Forced marks that the plan activated serving although the module is
 	// globally disabled.
 	Forced bool `json:"forced,omitempty"`
+
+	// Canary marks that canary mode priced the served bid at the fixed
+	// canary value (TotalValueGwei; no subsidy).
+	Canary bool `json:"canary,omitempty"`
+
+	// ValueBoostPct, when non-zero, reports the served bid's total value at
+	// this percentage of its real value (builder_api.value_boost_pct,
+	// test-only; see BoostGwei).
+	ValueBoostPct uint64 `json:"value_boost_pct,omitempty"`
+}
+
+// BoostGwei returns the reported bid value for the real total value
+// valueGwei: valueGwei scaled by ValueBoostPct (clamped to MaxUint64), or
+// valueGwei unchanged without a boost.
+func (s *ResolvedBuilderAPISettings) BoostGwei(valueGwei uint64) uint64 {
+	if s == nil || s.ValueBoostPct == 0 {
+		return valueGwei
+	}
+
+	hi, lo := bits.Mul64(valueGwei, s.ValueBoostPct)
+	if hi >= 100 {
+		return math.MaxUint64
+	}
+
+	boosted, _ := bits.Div64(hi, lo, 100)
+
+	return boosted
 }
 
 // ResolvedRevealSettings are the effective reveal parameters for the slot.
@@ -154,6 +233,16 @@ type ResolvedRevealSettings struct {
 	// BypassDeadline disables the "past the in-slot deadline → skip" check so
 	// deliberately late reveals are attempted.
 	BypassDeadline bool `json:"bypass_deadline,omitempty"`
+
+	// RequireInclusion holds the reveal until the committing block is seen
+	// at the head with our bid (global-only, no per-slot override).
+	RequireInclusion bool `json:"require_inclusion,omitempty"`
+
+	// Chaos is the slot's reveal chaos outcome (RevealChaos* constants):
+	// withheld reveals are Suppressed, delayed ones are time-gated
+	// reveal.chaos_delay_ms after the reveal time with the deadline
+	// bypassed. Empty without chaos.
+	Chaos string `json:"chaos,omitempty"`
 }
 
 // resolveFrozenPlan merges the live global config with a slot's plan (which
@@ -170,7 +259,7 @@ func resolveFrozenPlan(slot phase0.Slot, plan *SlotPlan, cfg *config.Config,
 
 	frozen.Bid = resolveBid(plan, cfg, fork)
 	frozen.BuilderAPI = resolveBuilderAPI(plan, cfg)
-	frozen.Reveal = resolveReveal(plan, cfg)
+	frozen.Reveal = resolveReveal(slot, plan, cfg)
 	frozen.Build = resolveBuild(frozen, cfg, slotsBuilt)
 	frozen.Transforms = resolveTransforms(plan)
 
@@ -206,6 +295,28 @@ func resolveBuild(frozen *FrozenPlan, cfg *config.Config, slotsBuilt uint64) *Re
 	// suppresses the build decision itself.
 	if frozen.Plan != nil && frozen.Plan.Build != nil {
 		build.ReorgParentPayload = frozen.Plan.Build.ReorgParentPayload
+		build.Faults = slices.Clone(frozen.Plan.Build.Faults)
+		build.BlobStuffing = frozen.Plan.Build.BlobStuffing
+		build.GasLimit = frozen.Plan.Build.GasLimit
+		build.GasLimitViolate = frozen.Plan.Build.GasLimitViolate
+	}
+
+	if build.GasLimit == 0 {
+		build.GasLimit = cfg.GasLimit.Target
+	}
+
+	build.GasLimitViolate = build.GasLimitViolate || cfg.GasLimit.Violate
+
+	switch {
+	case build.BlobStuffing == config.BlobStuffingOff:
+		build.BlobStuffing = ""
+	case build.BlobStuffing == "" && cfg.BlobStuffing.Scheduled(uint64(frozen.Slot)):
+		build.BlobStuffing = cfg.BlobStuffing.Blobs
+	}
+
+	if len(build.Faults) == 0 && cfg.PayloadFaults.Enabled() &&
+		slotRoll(frozen.Slot, payloadFaultSalt) < cfg.PayloadFaults.Pct {
+		build.Faults = slices.Clone(cfg.PayloadFaults.Fields)
 	}
 
 	// A plan that explicitly activates (mode custom) an available consumer
@@ -343,6 +454,20 @@ func resolveBid(plan *SlotPlan, cfg *config.Config, fork version.DataVersion) *R
 		IncreaseGwei: cfg.EPBS.BidIncrease,
 		SubsidyGwei:  cfg.EPBS.BidSubsidy,
 		Forced:       forced,
+		ReplaceStale: cfg.EPBS.ReplaceStaleBids,
+		Strategy:     cfg.EPBS.NormalizedBidStrategy(),
+	}
+
+	switch resolved.Strategy {
+	case config.BidStrategySnipe:
+		resolved.Snipe = true
+		resolved.SnipeLeadMs = cfg.EPBS.SnipeLeadMs
+		resolved.SnipeMaxCompetitorGwei = cfg.EPBS.SnipeMaxCompetitorGwei
+	case config.BidStrategyCounterBid:
+		resolved.CounterStepGwei = cfg.EPBS.CounterBidStepGwei
+		resolved.CounterMaxGwei = cfg.EPBS.CounterBidMaxGwei
+	case config.BidStrategyRandomWalk:
+		resolved.RandomWalkStepGwei = cfg.EPBS.RandomWalkStepGwei
 	}
 
 	if cfg.EPBS.BidValueOverride > 0 {
@@ -366,6 +491,24 @@ func resolveBid(plan *SlotPlan, cfg *config.Config, fork version.DataVersion) *R
 		resolved.IgnoreMissingPrefs = bid.IgnoreMissingPrefs
 	}
 
+	// Canary mode caps the economic exposure, so it wins over every value
+	// source above and over the strategies that move the value (a linear
+	// strategy without increase keeps the value).
+	if cfg.Canary.Enabled {
+		value := cfg.Canary.BidGwei
+		resolved.ValueGwei = &value
+		resolved.IncreaseGwei = 0
+		resolved.SubsidyGwei = 0
+		resolved.Canary = true
+
+		if resolved.Strategy == config.BidStrategyCounterBid || resolved.Strategy == config.BidStrategyRandomWalk {
+			resolved.Strategy = config.BidStrategyLinear
+			resolved.CounterStepGwei = 0
+			resolved.CounterMaxGwei = 0
+			resolved.RandomWalkStepGwei = 0
+		}
+	}
+
 	return resolved
 }
 
@@ -389,8 +532,9 @@ func resolveBuilderAPI(plan *SlotPlan, cfg *config.Config) *ResolvedBuilderAPISe
 	}
 
 	resolved := &ResolvedBuilderAPISettings{
-		SubsidyGwei: cfg.BuilderAPI.BlockValueSubsidyGwei,
-		Forced:      forced,
+		SubsidyGwei:   cfg.BuilderAPI.BlockValueSubsidyGwei,
+		Forced:        forced,
+		ValueBoostPct: cfg.BuilderAPI.ValueBoostPct,
 	}
 
 	if cfg.BuilderAPI.ValueOverrideGwei > 0 {
@@ -408,10 +552,18 @@ func resolveBuilderAPI(plan *SlotPlan, cfg *config.Config) *ResolvedBuilderAPISe
 		}
 	}
 
+	if cfg.Canary.Enabled {
+		value := cfg.Canary.BidGwei
+		resolved.TotalValueGwei = &value
+		resolved.SubsidyGwei = 0
+		resolved.ValueBoostPct = 0
+		resolved.Canary = true
+	}
+
 	return resolved
 }
 
-func resolveReveal(plan *SlotPlan, cfg *config.Config) *ResolvedRevealSettings {
+func resolveReveal(slot phase0.Slot, plan *SlotPlan, cfg *config.Config) *ResolvedRevealSettings {
 	resolved := &ResolvedRevealSettings{
 		Suppressed:          !cfg.Reveal.Enabled,
 		RevealTimeMs:        cfg.Reveal.TimeMs,
@@ -420,9 +572,14 @@ func resolveReveal(plan *SlotPlan, cfg *config.Config) *ResolvedRevealSettings {
 		BroadcastValidation: cfg.Reveal.NormalizedBroadcastValidation(),
 		MaxAttempts:         max(cfg.Reveal.MaxAttempts, 1),
 		RetryIntervalMs:     cfg.Reveal.RetryIntervalMs,
+		RequireInclusion:    cfg.Reveal.RequireInclusion,
 	}
 
 	if plan == nil || plan.Reveal == nil {
+		if !resolved.Suppressed {
+			applyRevealChaos(resolved, slot, &cfg.Reveal)
+		}
+
 		return resolved
 	}
 
@@ -444,6 +601,44 @@ func resolveReveal(plan *SlotPlan, cfg *config.Config) *ResolvedRevealSettings {
 	return resolved
 }
 
+// applyRevealChaos rolls the slot's reveal chaos: the first
+// chaos_withhold_pct percent of the roll range withhold the reveal, the next
+// chaos_delay_pct percent delay it.
+func applyRevealChaos(resolved *ResolvedRevealSettings, slot phase0.Slot, cfg *config.RevealConfig) {
+	if !cfg.ChaosEnabled() {
+		return
+	}
+
+	roll := slotRoll(slot, 0)
+
+	switch {
+	case roll < cfg.ChaosWithholdPct:
+		resolved.Chaos = RevealChaosWithhold
+		resolved.Suppressed = true
+	case roll < cfg.ChaosWithholdPct+cfg.ChaosDelayPct:
+		resolved.Chaos = RevealChaosDelay
+		resolved.GateMode = config.RevealGateTime
+		resolved.RevealTimeMs += cfg.ChaosDelayMs
+		resolved.BypassDeadline = true
+	}
+}
+
+// payloadFaultSalt decorrelates the payload fault roll from the reveal chaos
+// roll, so both modes do not pick the same slots.
+const payloadFaultSalt = 0x5fa17ed0
+
+// slotRoll maps a slot to a uniformly spread value in [0, 100) (splitmix64
+// finalizer), so chaos picks the same slots on every run. Each mode uses its
+// own salt.
+func slotRoll(slot phase0.Slot, salt uint64) uint64 {
+	z := (uint64(slot) ^ salt) + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+
+	return z % 100
+}
+
 func applyOverride[T any](target *T, override *T) {
 	if override != nil {
 		*target = *override
@@ -226,6 +226,15 @@ func (s *PlanService) GetSlotsRemaining() int {
 	return int(s.cfg.Schedule.NextN - s.slotsBuilt)
 }
 
+// GetFrozen returns the slot's frozen snapshot without freezing it, or nil
+// when the slot has not been frozen (yet).
+func (s *PlanService) GetFrozen(slot phase0.Slot) *FrozenPlan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.frozen[slot]
+}
+
 // IsFrozen reports whether the slot's plan has been frozen already.
 func (s *PlanService) IsFrozen(slot phase0.Slot) bool {
 	s.mu.Lock()
@@ -2,6 +2,7 @@ package action_plan
 
 import (
 	"encoding/json"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -351,6 +352,102 @@ func TestFreezeReorgParentPayload(t *testing.T) {
 	require.False(t, plain.Build.ReorgParentPayload)
 }
 
+func TestFreezePayloadFaults(t *testing.T) {
+	chainSvc := newStubChain()
+
+	cfg := config.DefaultConfig()
+	cfg.EPBSEnabled = true
+	cfg.PayloadFaults = config.PayloadFaultsConfig{
+		Fields: []string{config.PayloadFaultStateRoot},
+		Pct:    100,
+	}
+	svc := newTestService(chainSvc, cfg)
+
+	_, err := svc.ApplyUpdates([]*PlanUpdate{
+		{Slots: []uint64{9200}, Build: json.RawMessage(`{"faults":["withdrawals"]}`)},
+	}, "tester")
+	require.NoError(t, err)
+
+	// A per-slot plan wins over the probabilistic selection.
+	require.Equal(t, []string{config.PayloadFaultWithdrawals}, svc.Freeze(9200).Build.Faults)
+	require.Equal(t, []string{config.PayloadFaultStateRoot}, svc.Freeze(9201).Build.Faults)
+
+	_, err = svc.ApplyUpdates([]*PlanUpdate{
+		{Slots: []uint64{9202}, Build: json.RawMessage(`{"faults":["gas_used"]}`)},
+	}, "tester")
+	require.ErrorContains(t, err, "gas_used")
+
+	// Pct picks a deterministic share of the slots.
+	cfg.PayloadFaults.Pct = 30
+	picked := 0
+
+	for slot := phase0.Slot(0); slot < 1000; slot++ {
+		if slotRoll(slot, payloadFaultSalt) < cfg.PayloadFaults.Pct {
+			picked++
+		}
+	}
+
+	require.InDelta(t, 300, picked, 60)
+}
+
+func TestFreezeBlobStuffing(t *testing.T) {
+	chainSvc := newStubChain()
+
+	cfg := config.DefaultConfig()
+	cfg.EPBSEnabled = true
+	cfg.BlobStuffing = config.BlobStuffingConfig{Blobs: config.BlobStuffingMax, EveryNth: 4}
+	svc := newTestService(chainSvc, cfg)
+
+	_, err := svc.ApplyUpdates([]*PlanUpdate{
+		{Slots: []uint64{9301}, Build: json.RawMessage(`{"blob_stuffing":"3"}`)},
+		{Slots: []uint64{9304}, Build: json.RawMessage(`{"blob_stuffing":"off"}`)},
+	}, "tester")
+	require.NoError(t, err)
+
+	// The schedule stuffs every 4th slot; plans pick or suppress slots.
+	require.Equal(t, config.BlobStuffingMax, svc.Freeze(9300).Build.BlobStuffing)
+	require.Equal(t, "3", svc.Freeze(9301).Build.BlobStuffing)
+	require.Empty(t, svc.Freeze(9302).Build.BlobStuffing)
+	require.Empty(t, svc.Freeze(9304).Build.BlobStuffing)
+
+	for _, level := range []string{"0", "many", "-1"} {
+		_, err = svc.ApplyUpdates([]*PlanUpdate{
+			{Slots: []uint64{9305}, Build: json.RawMessage(`{"blob_stuffing":"` + level + `"}`)},
+		}, "tester")
+		require.ErrorContains(t, err, "blob stuffing level", level)
+	}
+}
+
+func TestFreezeGasLimit(t *testing.T) {
+	chainSvc := newStubChain()
+
+	cfg := config.DefaultConfig()
+	cfg.EPBSEnabled = true
+	cfg.GasLimit = config.GasLimitConfig{Target: 36_000_000}
+	svc := newTestService(chainSvc, cfg)
+
+	_, err := svc.ApplyUpdates([]*PlanUpdate{
+		{Slots: []uint64{9401}, Build: json.RawMessage(`{"gas_limit":45000000}`)},
+		{Slots: []uint64{9402}, Build: json.RawMessage(`{"gas_limit_violate":true}`)},
+	}, "tester")
+	require.NoError(t, err)
+
+	build := svc.Freeze(9400).Build
+	require.Equal(t, uint64(36_000_000), build.GasLimit)
+	require.False(t, build.GasLimitViolate)
+
+	require.Equal(t, uint64(45_000_000), svc.Freeze(9401).Build.GasLimit)
+
+	build = svc.Freeze(9402).Build
+	require.Equal(t, uint64(36_000_000), build.GasLimit)
+	require.True(t, build.GasLimitViolate)
+
+	_, err = svc.ApplyUpdates([]*PlanUpdate{
+		{Slots: []uint64{9403}, Build: json.RawMessage(`{"gas_limit":100}`)},
+	}, "tester")
+	require.ErrorContains(t, err, "gas limit")
+}
+
 func TestPruneForEpochKeepsFuturePlans(t *testing.T) {
 	chainSvc := newStubChain()
 
@@ -674,6 +771,58 @@ func TestResolveRevealSettings(t *testing.T) {
 		assert.True(t, svc.Freeze(2005).Reveal.Suppressed)
 	})
 
+	t.Run("chaos withholds and delays a share of slots", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Reveal.TimeMs = 5000
+		cfg.Reveal.ChaosWithholdPct = 20
+		cfg.Reveal.ChaosDelayPct = 30
+		cfg.Reveal.ChaosDelayMs = 2000
+
+		svc := newTestService(chainSvc, cfg)
+		counts := map[string]int{}
+
+		for slot := phase0.Slot(3000); slot < 4000; slot++ {
+			rs := svc.Freeze(slot).Reveal
+			counts[rs.Chaos]++
+
+			switch rs.Chaos {
+			case RevealChaosWithhold:
+				assert.True(t, rs.Suppressed)
+			case RevealChaosDelay:
+				assert.False(t, rs.Suppressed)
+				assert.True(t, rs.BypassDeadline)
+				assert.Equal(t, config.RevealGateTime, rs.GateMode)
+				assert.Equal(t, int64(7000), rs.RevealTimeMs)
+			default:
+				assert.False(t, rs.Suppressed)
+				assert.Equal(t, int64(5000), rs.RevealTimeMs)
+			}
+		}
+
+		assert.InDelta(t, 200, counts[RevealChaosWithhold], 50)
+		assert.InDelta(t, 300, counts[RevealChaosDelay], 50)
+
+		// The roll is a function of the slot: a second service agrees.
+		assert.Equal(t, svc.Freeze(3000).Reveal.Chaos, newTestService(chainSvc, cfg).Freeze(3000).Reveal.Chaos)
+	})
+
+	t.Run("plan overrides chaos", func(t *testing.T) {
+		cfg := config.DefaultConfig()
+		cfg.Reveal.ChaosWithholdPct = 100
+
+		svc := newTestService(chainSvc, cfg)
+		_, err := svc.ApplyUpdates([]*PlanUpdate{{
+			Slots:  []uint64{2007},
+			Reveal: json.RawMessage(`{"mode":"custom"}`),
+		}}, "test")
+		require.NoError(t, err)
+
+		rs := svc.Freeze(2007).Reveal
+		assert.False(t, rs.Suppressed)
+		assert.Empty(t, rs.Chaos)
+		assert.Equal(t, RevealChaosWithhold, svc.Freeze(2008).Reveal.Chaos)
+	})
+
 	t.Run("invalid overrides rejected", func(t *testing.T) {
 		svc := newTestService(chainSvc, nil)
 
@@ -691,3 +840,107 @@ func TestResolveRevealSettings(t *testing.T) {
 		}
 	})
 }
+
+// TestFreezeCanaryMode covers canary pricing: the fixed canary value wins
+// over the global override and plan-custom values, without increase or
+// subsidy, on both bid transports.
+func TestFreezeCanaryMode(t *testing.T) {
+	chainSvc := newStubChain()
+
+	cfg := config.DefaultConfig()
+	cfg.EPBSEnabled = true
+	cfg.BuilderAPIEnabled = true
+	cfg.APIPort = 8080
+	cfg.EPBS.BidValueOverride = 5000
+	cfg.BuilderAPI.ValueOverrideGwei = 6000
+	cfg.Canary.Enabled = true
+	cfg.Canary.BidGwei = 1
+
+	svc := newTestService(chainSvc, cfg)
+	_, err := svc.ApplyUpdates([]*PlanUpdate{{
+		Slots:      []uint64{2100},
+		Bid:        json.RawMessage(`{"mode":"custom","bid_value_gwei":9000,"bid_subsidy":10}`),
+		BuilderAPI: json.RawMessage(`{"mode":"custom","total_value_override_gwei":9000}`),
+	}}, "test")
+	require.NoError(t, err)
+
+	frozen := svc.Freeze(2100)
+	require.NotNil(t, frozen.Bid)
+	assert.True(t, frozen.Bid.Canary)
+	assert.Equal(t, uint64(1), *frozen.Bid.ValueGwei)
+	assert.Zero(t, frozen.Bid.IncreaseGwei)
+	assert.Zero(t, frozen.Bid.SubsidyGwei)
+
+	require.NotNil(t, frozen.BuilderAPI)
+	assert.True(t, frozen.BuilderAPI.Canary)
+	assert.Equal(t, uint64(1), *frozen.BuilderAPI.TotalValueGwei)
+
+	// Without canary mode the plan values apply and nothing is marked.
+	cfg.Canary.Enabled = false
+	frozen = svc.Freeze(2101)
+	assert.False(t, frozen.Bid.Canary)
+	assert.Equal(t, uint64(5000), *frozen.Bid.ValueGwei)
+}
+
+// TestFreezeValueBoost covers the test-only Builder API value boost: it is
+// frozen with the slot, scales the reported value and yields to canary mode.
+func TestFreezeValueBoost(t *testing.T) {
+	chainSvc := newStubChain()
+
+	cfg := config.DefaultConfig()
+	cfg.BuilderAPIEnabled = true
+	cfg.APIPort = 8080
+	cfg.BuilderAPI.ValueBoostPct = 250
+
+	svc := newTestService(chainSvc, cfg)
+
+	frozen := svc.Freeze(2200)
+	require.NotNil(t, frozen.BuilderAPI)
+	assert.Equal(t, uint64(250), frozen.BuilderAPI.ValueBoostPct)
+	assert.Equal(t, uint64(2500), frozen.BuilderAPI.BoostGwei(1000))
+	assert.Equal(t, uint64(math.MaxUint64), frozen.BuilderAPI.BoostGwei(math.MaxUint64/2))
+
+	cfg.Canary.Enabled = true
+	frozen = svc.Freeze(2201)
+	assert.Zero(t, frozen.BuilderAPI.ValueBoostPct)
+	assert.Equal(t, uint64(1000), frozen.BuilderAPI.BoostGwei(1000))
+}
+
+// TestFreezeBidStrategy covers the frozen bid strategy: only the selected
+// strategy's parameters are carried, the legacy snipe switch selects the
+// snipe strategy, and canary mode keeps the value from moving.
+func TestFreezeBidStrategy(t *testing.T) {
+	chainSvc := newStubChain()
+
+	cfg := config.DefaultConfig()
+	cfg.EPBSEnabled = true
+	cfg.EPBS.BidStrategy = config.BidStrategyCounterBid
+	cfg.EPBS.CounterBidStepGwei = 5
+	cfg.EPBS.CounterBidMaxGwei = 500
+	cfg.EPBS.RandomWalkStepGwei = 7
+
+	svc := newTestService(chainSvc, cfg)
+
+	frozen := svc.Freeze(2100)
+	require.NotNil(t, frozen.Bid)
+	assert.Equal(t, config.BidStrategyCounterBid, frozen.Bid.Strategy)
+	assert.Equal(t, uint64(5), frozen.Bid.CounterStepGwei)
+	assert.Equal(t, uint64(500), frozen.Bid.CounterMaxGwei)
+	assert.Zero(t, frozen.Bid.RandomWalkStepGwei)
+	assert.False(t, frozen.Bid.Snipe)
+
+	cfg.EPBS.BidStrategy = "bogus"
+	assert.Equal(t, config.BidStrategyLinear, svc.Freeze(2101).Bid.Strategy)
+
+	cfg.EPBS.SnipeEnabled = true
+	frozen = svc.Freeze(2102)
+	assert.Equal(t, config.BidStrategySnipe, frozen.Bid.Strategy)
+	assert.True(t, frozen.Bid.Snipe)
+
+	cfg.EPBS.SnipeEnabled = false
+	cfg.EPBS.BidStrategy = config.BidStrategyRandomWalk
+	cfg.Canary.Enabled = true
+	frozen = svc.Freeze(2103)
+	assert.Equal(t, config.BidStrategyLinear, frozen.Bid.Strategy)
+	assert.Zero(t, frozen.Bid.RandomWalkStepGwei)
+}
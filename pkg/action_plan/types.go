@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -253,6 +254,25 @@ type BuildPlan struct {
 	// rejected by mainnet forkchoice, but useful for exercising the reveal /
 	// inclusion path against a withheld parent.
 	ReorgParentPayload bool `json:"reorg_parent_payload,omitempty"`
+
+	// Faults corrupts these payload fields (config.PayloadFault*) after the
+	// build, before the payload feeds the bid and the reveal — an invalid
+	// payload for testing client rejection paths. Set on a slot it wins over
+	// the probabilistic --payload-fault-pct selection.
+	Faults []string `json:"faults,omitempty"`
+
+	// BlobStuffing fills the slot's block with synthetic blob transactions
+	// from the builder wallet: target, max or a blob count, or off to
+	// suppress the --blob-stuffing schedule for the slot.
+	BlobStuffing string `json:"blob_stuffing,omitempty"`
+
+	// GasLimit is the gas limit target of the slot's payload, replacing
+	// --gas-limit and the proposer's target. 0 inherits.
+	GasLimit uint64 `json:"gas_limit,omitempty"`
+
+	// GasLimitViolate moves the slot's payload gas limit away from its
+	// target (negative testing, see --gas-limit-violate).
+	GasLimitViolate bool `json:"gas_limit_violate,omitempty"`
 }
 
 func (p *BuildPlan) clone() *BuildPlan {
@@ -261,6 +281,7 @@ func (p *BuildPlan) clone() *BuildPlan {
 	}
 
 	c := *p
+	c.Faults = slices.Clone(p.Faults)
 
 	return &c
 }
@@ -268,11 +289,27 @@ func (p *BuildPlan) clone() *BuildPlan {
 // isZero reports whether the build plan carries no active instruction; such a
 // plan is dropped rather than persisted.
 func (p *BuildPlan) isZero() bool {
-	return p == nil || !p.ReorgParentPayload
+	return p == nil || (!p.ReorgParentPayload && len(p.Faults) == 0 && p.BlobStuffing == "" &&
+		p.GasLimit == 0 && !p.GasLimitViolate)
 }
 
 func (p *BuildPlan) validate() error {
-	// No mode and no bounded fields yet; the boolean flag is always valid.
+	// No mode; only the fault fields, the stuffing level and the gas limit
+	// are bounded.
+	if err := config.ValidatePayloadFaults(p.Faults); err != nil {
+		return fmt.Errorf("build.faults: %w", err)
+	}
+
+	if err := config.ValidateGasLimit(p.GasLimit); err != nil {
+		return fmt.Errorf("build.gas_limit: %w", err)
+	}
+
+	if p.BlobStuffing != "" && p.BlobStuffing != config.BlobStuffingOff {
+		if err := config.ValidateBlobStuffing(p.BlobStuffing); err != nil {
+			return fmt.Errorf("build.blob_stuffing: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,450 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// webhookTimeout bounds one webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// Rule states.
+const (
+	StateOK     = "ok"
+	StateFiring = "firing"
+	StateNoData = "no_data"
+)
+
+// Alert transition states.
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+// ResultSource provides the recorded slot results (implemented by the slot
+// results tracker).
+type ResultSource interface {
+	GetRange(minSlot, maxSlot phase0.Slot) []*slot_results.SlotResult
+}
+
+// SettingsWriter applies setting overrides (implemented by the settings
+// service); disable actions go through it so the change is persisted,
+// audited by actor and visible in the UI like a manual toggle.
+type SettingsWriter interface {
+	Set(key string, raw json.RawMessage, actor string) error
+}
+
+// Alert is one rule state transition (or an alert raised by another
+// component through Publish).
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Severity  string    `json:"severity"`
+	State     string    `json:"state"` // firing | resolved
+	Condition string    `json:"condition,omitempty"`
+	Value     float64   `json:"value"`
+	Slot      uint64    `json:"slot"`
+	Message   string    `json:"message"`
+	Actions   []string  `json:"actions,omitempty"` // actions applied on this transition
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// RuleStatus is a rule with its current evaluation state.
+type RuleStatus struct {
+	Rule
+
+	State         string     `json:"state"`
+	Value         *float64   `json:"value,omitempty"`
+	Samples       int        `json:"samples"`
+	EvaluatedSlot uint64     `json:"evaluated_slot"`
+	FiringSince   *time.Time `json:"firing_since,omitempty"`
+	LastFiredAt   *time.Time `json:"last_fired_at,omitempty"`
+	FireCount     int        `json:"fire_count"`
+	ActionError   string     `json:"action_error,omitempty"`
+}
+
+// Engine evaluates the rule set at every slot boundary over the window
+// ending at the previous (complete) slot. Disable actions are one-way: a
+// resolved rule never re-enables a service, the operator does.
+type Engine struct {
+	rules    *RuleSet
+	chainSvc chain.Service
+	results  ResultSource
+	settings SettingsWriter
+	pubkey   phase0.BLSPubKey
+	client   *http.Client
+
+	mu       sync.RWMutex
+	statuses []*RuleStatus // rule order
+
+	dispatcher utils.Dispatcher[*Alert]
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewEngine creates the alerting engine for a validated rule set. settings
+// may be nil when no rule uses a disable action.
+func NewEngine(rules *RuleSet, chainSvc chain.Service, results ResultSource, settings SettingsWriter,
+	builderPubkey phase0.BLSPubKey, log logrus.FieldLogger) *Engine {
+	statuses := make([]*RuleStatus, 0, len(rules.Rules))
+	for _, rule := range rules.Rules {
+		statuses = append(statuses, &RuleStatus{Rule: *rule, State: StateNoData})
+	}
+
+	return &Engine{
+		rules:    rules,
+		chainSvc: chainSvc,
+		results:  results,
+		settings: settings,
+		pubkey:   builderPubkey,
+		client:   &http.Client{Timeout: webhookTimeout},
+		statuses: statuses,
+		ctx:      context.Background(),
+		log:      log.WithField("component", "alerting"),
+	}
+}
+
+// Start launches the slot-clocked evaluation loop.
+func (e *Engine) Start(ctx context.Context) error {
+	e.ctx, e.cancel = context.WithCancel(ctx)
+
+	e.wg.Add(1)
+
+	go e.run()
+
+	e.log.WithField("rules", len(e.statuses)).Info("Alerting engine started")
+
+	return nil
+}
+
+// Stop terminates the evaluation loop and waits for pending webhooks.
+func (e *Engine) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+
+	e.wg.Wait()
+}
+
+// SubscribeAlerts subscribes to alert transitions (non-blocking).
+func (e *Engine) SubscribeAlerts(capacity int) *utils.Subscription[*Alert] {
+	return e.dispatcher.Subscribe(capacity, false)
+}
+
+// GetStatuses returns a snapshot of every rule's status in file order.
+func (e *Engine) GetStatuses() []RuleStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	statuses := make([]RuleStatus, 0, len(e.statuses))
+	for _, status := range e.statuses {
+		statuses = append(statuses, *status)
+	}
+
+	return statuses
+}
+
+func (e *Engine) run() {
+	defer e.wg.Done()
+
+	slotTimer := chain.NewSlotTimer(e.chainSvc)
+	defer slotTimer.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+
+		case <-slotTimer.C():
+			if currentSlot := e.chainSvc.GetCurrentSlot(); currentSlot > 0 {
+				e.Evaluate(currentSlot - 1)
+			}
+
+			chain.ResetSlotTimer(e.chainSvc, slotTimer)
+		}
+	}
+}
+
+// Evaluate evaluates every rule with slot as the last window slot and
+// applies the actions of rules changing state.
+func (e *Engine) Evaluate(slot phase0.Slot) {
+	var (
+		alerts  []*Alert
+		windows = make(map[uint64][]*slot_results.SlotResult, 2)
+	)
+
+	e.mu.Lock()
+
+	for _, status := range e.statuses {
+		reading := e.read(&status.Rule, slot, windows)
+
+		status.EvaluatedSlot = uint64(slot)
+		status.Samples = reading.samples
+
+		if reading.samples < status.MinSamples {
+			// Missing data never resolves a firing rule.
+			status.Value = nil
+			if status.State != StateFiring {
+				status.State = StateNoData
+			}
+
+			continue
+		}
+
+		value := reading.value
+		status.Value = &value
+
+		breached := status.holds(value)
+
+		switch {
+		case breached && status.State != StateFiring:
+			now := time.Now()
+			status.State = StateFiring
+			status.FiringSince = &now
+			status.LastFiredAt = &now
+			status.FireCount++
+
+			alerts = append(alerts, e.newAlert(status, AlertFiring, value, slot))
+
+		case !breached && status.State == StateFiring:
+			status.State = StateOK
+			status.FiringSince = nil
+
+			alerts = append(alerts, e.newAlert(status, AlertResolved, value, slot))
+
+		case !breached:
+			status.State = StateOK
+		}
+	}
+
+	e.mu.Unlock()
+
+	for _, alert := range alerts {
+		e.apply(alert)
+	}
+}
+
+// read computes the rule's metric, sharing window result reads across rules
+// with the same window.
+func (e *Engine) read(rule *Rule, slot phase0.Slot, windows map[uint64][]*slot_results.SlotResult) sample {
+	if balanceMetrics[rule.Metric] {
+		info := e.chainSvc.GetBuilderByPubkey(e.pubkey)
+		if info == nil {
+			return sample{}
+		}
+
+		value := info.Balance
+		if rule.Metric == MetricEffectiveBalanceGwei {
+			value = 0
+			if info.Balance > info.PendingPayments {
+				value = info.Balance - info.PendingPayments
+			}
+		}
+
+		return sample{value: float64(value), samples: 1}
+	}
+
+	results, ok := windows[rule.WindowSlots]
+	if !ok {
+		minSlot := phase0.Slot(0)
+		if uint64(slot)+1 > rule.WindowSlots {
+			minSlot = slot + 1 - phase0.Slot(rule.WindowSlots)
+		}
+
+		results = e.results.GetRange(minSlot, slot)
+		windows[rule.WindowSlots] = results
+	}
+
+	return windowMetric(rule.Metric, results)
+}
+
+func (e *Engine) newAlert(status *RuleStatus, state string, value float64, slot phase0.Slot) *Alert {
+	verb := "breached"
+	if state == AlertResolved {
+		verb = "recovered"
+	}
+
+	message := fmt.Sprintf("%s: %s (value %g)", status.Name, verb, value)
+	if status.Description != "" {
+		message += " — " + status.Description
+	}
+
+	return &Alert{
+		Rule:      status.Name,
+		Severity:  status.Severity,
+		State:     state,
+		Condition: status.condition(),
+		Value:     value,
+		Slot:      uint64(slot),
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+}
+
+// apply runs the rule's actions for a transition, then publishes the alert.
+// Disable actions only run when a rule starts firing.
+func (e *Engine) apply(alert *Alert) {
+	rule := e.rule(alert.Rule)
+	if rule == nil {
+		return
+	}
+
+	var errs []error
+
+	for _, action := range rule.Actions {
+		key, isDisable := actionSettings[action]
+		if !isDisable {
+			continue
+		}
+
+		if alert.State != AlertFiring {
+			continue
+		}
+
+		if e.settings == nil {
+			errs = append(errs, fmt.Errorf("%s: no settings service", action))
+			continue
+		}
+
+		if err := e.settings.Set(key, json.RawMessage("false"), "alert:"+rule.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", action, err))
+			continue
+		}
+
+		alert.Actions = append(alert.Actions, action)
+	}
+
+	webhook := ""
+	if hasAction(rule.Actions, ActionNotify) {
+		webhook = rule.WebhookURL
+		if webhook == "" {
+			webhook = e.rules.WebhookURL
+		}
+
+		alert.Actions = append(alert.Actions, ActionNotify)
+	}
+
+	e.mu.Lock()
+	for _, status := range e.statuses {
+		if status.Name == rule.Name {
+			status.ActionError = ""
+			if err := errors.Join(errs...); err != nil {
+				status.ActionError = err.Error()
+			}
+		}
+	}
+	e.mu.Unlock()
+
+	if err := errors.Join(errs...); err != nil {
+		e.log.WithError(err).WithField("rule", rule.Name).Error("Alert action failed")
+	}
+
+	e.publish(alert, webhook)
+}
+
+// Publish emits an alert raised outside the rule set (logged, dispatched to
+// subscribers and posted to the file-level webhook if one is configured).
+func (e *Engine) Publish(alert *Alert) {
+	if alert.Timestamp.IsZero() {
+		alert.Timestamp = time.Now()
+	}
+
+	e.publish(alert, e.rules.WebhookURL)
+}
+
+func (e *Engine) publish(alert *Alert, webhook string) {
+	entry := e.log.WithFields(logrus.Fields{
+		"rule":     alert.Rule,
+		"severity": alert.Severity,
+		"state":    alert.State,
+		"slot":     alert.Slot,
+		"actions":  alert.Actions,
+	})
+
+	if alert.State == AlertFiring && alert.Severity != SeverityInfo {
+		entry.Warn(alert.Message)
+	} else {
+		entry.Info(alert.Message)
+	}
+
+	e.dispatcher.Fire(alert)
+
+	if webhook == "" {
+		return
+	}
+
+	e.wg.Add(1)
+
+	go func() {
+		defer e.wg.Done()
+
+		if err := e.post(webhook, alert); err != nil {
+			e.log.WithError(err).WithField("rule", alert.Rule).Warn("Failed to deliver alert webhook")
+		}
+	}()
+}
+
+// post delivers one alert to a webhook as JSON.
+func (e *Engine) post(webhook string, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(e.ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (e *Engine) rule(name string) *Rule {
+	for _, rule := range e.rules.Rules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func hasAction(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package alerting
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/chain/chaintest"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+type stubResults struct {
+	results []*slot_results.SlotResult
+}
+
+func (s *stubResults) GetRange(minSlot, maxSlot phase0.Slot) []*slot_results.SlotResult {
+	var out []*slot_results.SlotResult
+
+	for _, result := range s.results {
+		if result.Slot >= minSlot && result.Slot <= maxSlot {
+			out = append(out, result)
+		}
+	}
+
+	return out
+}
+
+type stubSettings struct {
+	sets map[string]string
+}
+
+func (s *stubSettings) Set(key string, raw json.RawMessage, _ string) error {
+	s.sets[key] = string(raw)
+	return nil
+}
+
+// stubChain only implements GetBuilderByPubkey; the embedded interface is nil.
+type stubChain struct {
+	chain.Service
+
+	builder *chain.BuilderInfo
+}
+
+func (s *stubChain) GetBuilderByPubkey(_ phase0.BLSPubKey) *chain.BuilderInfo {
+	return s.builder
+}
+
+func revealResult(slot phase0.Slot, status slot_results.RevealStatus) *slot_results.SlotResult {
+	return &slot_results.SlotResult{
+		Slot:           slot,
+		RevealAttempts: []slot_results.RevealAttempt{{Status: status}},
+	}
+}
+
+func TestEngineFiresAndResolves(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - name: reveal-failures
+    metric: reveal_failure_pct
+    operator: ">"
+    threshold: 10
+    window_slots: 4
+    min_samples: 2
+    actions: [disable_epbs]
+`))
+	require.NoError(t, err)
+
+	results := &stubResults{}
+	settings := &stubSettings{sets: map[string]string{}}
+	engine := NewEngine(rules, nil, results, settings, phase0.BLSPubKey{}, logrus.New())
+
+	sub := engine.SubscribeAlerts(8)
+	defer sub.Unsubscribe()
+
+	// One sample is below min_samples.
+	results.results = append(results.results, revealResult(1, slot_results.RevealStatusFailed))
+	engine.Evaluate(1)
+	require.Equal(t, StateNoData, engine.GetStatuses()[0].State)
+
+	results.results = append(results.results, revealResult(2, slot_results.RevealStatusPublished))
+	engine.Evaluate(2)
+
+	status := engine.GetStatuses()[0]
+	require.Equal(t, StateFiring, status.State)
+	require.InDelta(t, 50, *status.Value, 0.001)
+	require.Equal(t, 1, status.FireCount)
+	require.Equal(t, "false", settings.sets[config.KeyEPBSEnabled])
+
+	alert := <-sub.Channel()
+	require.Equal(t, AlertFiring, alert.State)
+	require.Equal(t, []string{ActionDisableEPBS}, alert.Actions)
+
+	// Staying in breach does not re-fire.
+	engine.Evaluate(2)
+	require.Equal(t, 1, engine.GetStatuses()[0].FireCount)
+
+	// The failure leaves the window.
+	for slot := phase0.Slot(3); slot <= 5; slot++ {
+		results.results = append(results.results, revealResult(slot, slot_results.RevealStatusPublished))
+	}
+
+	engine.Evaluate(5)
+	require.Equal(t, StateOK, engine.GetStatuses()[0].State)
+
+	alert = <-sub.Channel()
+	require.Equal(t, AlertResolved, alert.State)
+	require.Empty(t, alert.Actions, "disable actions never run on resolve")
+}
+
+func TestEngineBalanceRule(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - name: low-effective-balance
+    metric: effective_balance_gwei
+    operator: "<"
+    threshold: 100
+`))
+	require.NoError(t, err)
+
+	chainSvc := &stubChain{}
+	engine := NewEngine(rules, chainSvc, &stubResults{}, nil, phase0.BLSPubKey{}, logrus.New())
+
+	engine.Evaluate(1)
+	require.Equal(t, StateNoData, engine.GetStatuses()[0].State, "unknown builder")
+
+	chainSvc.builder = &chain.BuilderInfo{Balance: 150, PendingPayments: 80}
+	engine.Evaluate(2)
+
+	status := engine.GetStatuses()[0]
+	require.Equal(t, StateFiring, status.State)
+	require.InDelta(t, 70, *status.Value, 0.001)
+
+	// Losing the data keeps the rule firing.
+	chainSvc.builder = nil
+	engine.Evaluate(3)
+	require.Equal(t, StateFiring, engine.GetStatuses()[0].State)
+}
+
+func TestEngineEvaluatesOnChainClock(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - name: reveal-failures
+    metric: reveal_failure_pct
+    operator: ">"
+    threshold: 10
+    window_slots: 4
+    actions: [disable_epbs]
+`))
+	require.NoError(t, err)
+
+	// The chain clock is a fake: a wall-clock slot timer would never fire.
+	chainSvc := chaintest.NewClocked(time.Unix(1000*12+1, 0))
+	engine := NewEngine(rules, chainSvc, &stubResults{}, &stubSettings{sets: map[string]string{}},
+		phase0.BLSPubKey{}, logrus.New())
+
+	require.NoError(t, engine.Start(t.Context()))
+	t.Cleanup(engine.Stop)
+
+	require.Eventually(t, func() bool { return chainSvc.Clk.Pending() > 0 }, time.Second, time.Millisecond)
+
+	chainSvc.Clk.Advance(11 * time.Second)
+
+	require.Eventually(t, func() bool { return engine.GetStatuses()[0].EvaluatedSlot == 1000 }, time.Second, 5*time.Millisecond,
+		"slot 1000 not evaluated on the chain clock")
+}
@@ -0,0 +1,114 @@
+package alerting
+
+import (
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+// sample is one metric reading: the value and how many observations it was
+// derived from (0 = no data).
+type sample struct {
+	value   float64
+	samples int
+}
+
+// windowMetric computes a window metric over the slot results of the window.
+func windowMetric(metric string, results []*slot_results.SlotResult) sample {
+	switch metric {
+	case MetricRevealFailurePct:
+		var total, missed int
+
+		for _, result := range results {
+			if n := len(result.RevealAttempts); n > 0 {
+				last := result.RevealAttempts[n-1].Status
+				if last == slot_results.RevealStatusSuppressed {
+					continue // intentional (plan), not a failure signal
+				}
+
+				total++
+
+				if !revealPublished(result) &&
+					(last == slot_results.RevealStatusFailed || last == slot_results.RevealStatusSkipped) {
+					missed++
+				}
+			}
+		}
+
+		return pct(missed, total)
+
+	case MetricBuildFailurePct:
+		var total, failed int
+
+		for _, result := range results {
+			if result.Build == nil {
+				continue
+			}
+
+			switch result.Build.Status {
+			case slot_results.BuildStatusReady:
+				total++
+			case slot_results.BuildStatusFailed:
+				total++
+				failed++
+			}
+		}
+
+		return pct(failed, total)
+
+	case MetricBidFailurePct:
+		var total, failed int
+
+		for _, result := range results {
+			for _, bid := range result.Bids {
+				if bid.Fault != "" {
+					continue
+				}
+
+				switch bid.Status {
+				case slot_results.BidStatusSubmitted, slot_results.BidStatusServed:
+					total++
+				case slot_results.BidStatusFailed:
+					total++
+					failed++
+				}
+			}
+		}
+
+		return pct(failed, total)
+
+	case MetricPayloadsMissed:
+		missed := 0
+
+		for _, result := range results {
+			if result.Inclusion == nil {
+				continue
+			}
+
+			switch result.Inclusion.PayloadStatus {
+			case slot_results.PayloadStatusMissed, slot_results.PayloadStatusOrphaned:
+				missed++
+			}
+		}
+
+		return sample{value: float64(missed), samples: len(results)}
+	}
+
+	return sample{}
+}
+
+func revealPublished(result *slot_results.SlotResult) bool {
+	for _, attempt := range result.RevealAttempts {
+		if attempt.Status == slot_results.RevealStatusPublished {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pct(part, total int) sample {
+	if total == 0 {
+		return sample{}
+	}
+
+	return sample{value: float64(part) * 100 / float64(total), samples: total}
+}
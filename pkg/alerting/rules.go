@@ -0,0 +1,241 @@
+// Package alerting evaluates operator-defined threshold rules over the slot
+// outcome history and builder balance once per slot, and on state changes
+// notifies (log, webhook, event) and optionally disables services.
+package alerting
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// Metric names a rule can evaluate.
+const (
+	// MetricRevealFailurePct is the share of window slots with reveal
+	// attempts that never published (last attempt failed or skipped).
+	MetricRevealFailurePct = "reveal_failure_pct"
+	// MetricBuildFailurePct is the share of window builds that failed.
+	MetricBuildFailurePct = "build_failure_pct"
+	// MetricBidFailurePct is the share of window bid attempts that failed.
+	MetricBidFailurePct = "bid_failure_pct"
+	// MetricPayloadsMissed counts won window slots whose payload was missed
+	// or orphaned.
+	MetricPayloadsMissed = "payloads_missed"
+	// MetricCLBalanceGwei is the builder's current CL balance.
+	MetricCLBalanceGwei = "cl_balance_gwei"
+	// MetricEffectiveBalanceGwei is the CL balance minus pending payments.
+	MetricEffectiveBalanceGwei = "effective_balance_gwei"
+)
+
+// Rule actions.
+const (
+	// ActionNotify posts the alert to the rule's (or the file's) webhook.
+	// Alerts are always logged and emitted as events regardless.
+	ActionNotify = "notify"
+	// ActionDisableEPBS turns off p2p bidding (epbs_enabled setting).
+	ActionDisableEPBS = "disable_epbs"
+	// ActionDisableBuilderAPI turns off the Builder API (builder_api_enabled).
+	ActionDisableBuilderAPI = "disable_builder_api"
+	// ActionDisableReveal turns off payload reveals (reveal.enabled).
+	ActionDisableReveal = "disable_reveal"
+)
+
+// actionSettings maps the disable actions to the setting they switch off.
+var actionSettings = map[string]string{
+	ActionDisableEPBS:       config.KeyEPBSEnabled,
+	ActionDisableBuilderAPI: config.KeyBuilderAPIEnabled,
+	ActionDisableReveal:     config.KeyRevealEnabled,
+}
+
+// windowMetrics are evaluated over the last WindowSlots slot results.
+var windowMetrics = map[string]bool{
+	MetricRevealFailurePct: true,
+	MetricBuildFailurePct:  true,
+	MetricBidFailurePct:    true,
+	MetricPayloadsMissed:   true,
+}
+
+// balanceMetrics are point-in-time reads of the builder's chain state.
+var balanceMetrics = map[string]bool{
+	MetricCLBalanceGwei:        true,
+	MetricEffectiveBalanceGwei: true,
+}
+
+// Severity levels.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// RuleSet is the YAML rules file.
+type RuleSet struct {
+	// WebhookURL receives notify actions of rules without their own URL.
+	WebhookURL string  `yaml:"webhook_url"`
+	Rules      []*Rule `yaml:"rules"`
+}
+
+// Rule is one threshold rule: it fires when Metric compared with Threshold
+// by Operator holds, and resolves when it no longer does.
+type Rule struct {
+	Name        string  `yaml:"name" json:"name"`
+	Description string  `yaml:"description" json:"description,omitempty"`
+	Metric      string  `yaml:"metric" json:"metric"`
+	Operator    string  `yaml:"operator" json:"operator"` // >, >=, <, <=
+	Threshold   float64 `yaml:"threshold" json:"threshold"`
+
+	// WindowSlots is the sliding slot window of window metrics.
+	WindowSlots uint64 `yaml:"window_slots" json:"window_slots,omitempty"`
+	// MinSamples is the minimum number of window samples (e.g. slots with a
+	// reveal attempt) before the rule is evaluated; fewer reports no_data.
+	MinSamples int `yaml:"min_samples" json:"min_samples,omitempty"`
+
+	Severity   string   `yaml:"severity" json:"severity"`
+	Actions    []string `yaml:"actions" json:"actions"`
+	WebhookURL string   `yaml:"webhook_url" json:"-"`
+}
+
+// LoadRules reads and validates a YAML rules file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert rules: %w", err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates YAML rules, applying defaults.
+func ParseRules(data []byte) (*RuleSet, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules: %w", err)
+	}
+
+	if err := set.validate(); err != nil {
+		return nil, err
+	}
+
+	return &set, nil
+}
+
+func (s *RuleSet) validate() error {
+	if s.WebhookURL != "" {
+		if err := validateWebhookURL(s.WebhookURL); err != nil {
+			return err
+		}
+	}
+
+	names := make(map[string]bool, len(s.Rules))
+
+	for i, rule := range s.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("alert rule %d: name is required", i)
+		}
+
+		if names[rule.Name] {
+			return fmt.Errorf("alert rule %q: duplicate name", rule.Name)
+		}
+
+		names[rule.Name] = true
+
+		if err := rule.validate(s.WebhookURL); err != nil {
+			return fmt.Errorf("alert rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Rule) validate(defaultWebhook string) error {
+	switch {
+	case windowMetrics[r.Metric]:
+		if r.WindowSlots == 0 {
+			return fmt.Errorf("window_slots is required for metric %s", r.Metric)
+		}
+	case balanceMetrics[r.Metric]:
+		r.WindowSlots = 0
+	default:
+		return fmt.Errorf("unknown metric %q", r.Metric)
+	}
+
+	switch r.Operator {
+	case ">", ">=", "<", "<=":
+	default:
+		return fmt.Errorf("invalid operator %q: must be >, >=, < or <=", r.Operator)
+	}
+
+	if r.MinSamples <= 0 {
+		r.MinSamples = 1
+	}
+
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityWarning
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("invalid severity %q: must be info, warning or critical", r.Severity)
+	}
+
+	for _, action := range r.Actions {
+		switch {
+		case action == ActionNotify:
+			if r.WebhookURL == "" && defaultWebhook == "" {
+				return fmt.Errorf("action notify needs a webhook_url")
+			}
+		case actionSettings[action] != "":
+		default:
+			return fmt.Errorf("unknown action %q", action)
+		}
+	}
+
+	if r.WebhookURL != "" {
+		return validateWebhookURL(r.WebhookURL)
+	}
+
+	return nil
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook_url %q: must be an http(s) URL", raw)
+	}
+
+	return nil
+}
+
+// holds reports whether value breaches the rule's threshold.
+func (r *Rule) holds(value float64) bool {
+	switch r.Operator {
+	case ">":
+		return value > r.Threshold
+	case ">=":
+		return value >= r.Threshold
+	case "<":
+		return value < r.Threshold
+	case "<=":
+		return value <= r.Threshold
+	}
+
+	return false
+}
+
+// condition renders the rule's condition for messages, e.g.
+// "reveal_failure_pct > 10 over 10 slots".
+func (r *Rule) condition() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s %g", r.Metric, r.Operator, r.Threshold)
+
+	if r.WindowSlots > 0 {
+		fmt.Fprintf(&b, " over %d slots", r.WindowSlots)
+	}
+
+	return b.String()
+}
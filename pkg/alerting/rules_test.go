@@ -0,0 +1,89 @@
+package alerting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+func TestParseRules(t *testing.T) {
+	set, err := ParseRules([]byte(`
+webhook_url: https://hooks.example.com/buildoor
+rules:
+  - name: reveal-failures
+    metric: reveal_failure_pct
+    operator: ">"
+    threshold: 10
+    window_slots: 10
+    actions: [notify, disable_epbs]
+  - name: low-balance
+    metric: cl_balance_gwei
+    operator: "<"
+    threshold: 1000000000
+    window_slots: 5
+    severity: critical
+`))
+	require.NoError(t, err)
+	require.Len(t, set.Rules, 2)
+
+	require.Equal(t, SeverityWarning, set.Rules[0].Severity, "severity defaults to warning")
+	require.Equal(t, 1, set.Rules[0].MinSamples, "min_samples defaults to 1")
+	require.Equal(t, "reveal_failure_pct > 10 over 10 slots", set.Rules[0].condition())
+	require.Zero(t, set.Rules[1].WindowSlots, "balance metrics ignore the window")
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown metric":     "rules: [{name: a, metric: nope, operator: '>', threshold: 1}]",
+		"missing window":     "rules: [{name: a, metric: build_failure_pct, operator: '>', threshold: 1}]",
+		"bad operator":       "rules: [{name: a, metric: cl_balance_gwei, operator: '==', threshold: 1}]",
+		"unknown action":     "rules: [{name: a, metric: cl_balance_gwei, operator: '<', threshold: 1, actions: [reboot]}]",
+		"notify w/o webhook": "rules: [{name: a, metric: cl_balance_gwei, operator: '<', threshold: 1, actions: [notify]}]",
+		"duplicate name":     "rules: [{name: a, metric: cl_balance_gwei, operator: '<', threshold: 1}, {name: a, metric: cl_balance_gwei, operator: '<', threshold: 2}]",
+		"missing name":       "rules: [{metric: cl_balance_gwei, operator: '<', threshold: 1}]",
+	} {
+		_, err := ParseRules([]byte(data))
+		require.Error(t, err, name)
+	}
+}
+
+func TestWindowMetric(t *testing.T) {
+	results := []*slot_results.SlotResult{
+		{
+			Slot:           10,
+			Build:          &slot_results.BuildOutcome{Status: slot_results.BuildStatusReady},
+			Bids:           []slot_results.BidAttempt{{Status: slot_results.BidStatusSubmitted}, {Status: slot_results.BidStatusFailed}},
+			RevealAttempts: []slot_results.RevealAttempt{{Status: slot_results.RevealStatusPublished}},
+			Inclusion:      &slot_results.InclusionResult{PayloadStatus: slot_results.PayloadStatusCanonical},
+		},
+		{
+			Slot:           11,
+			Build:          &slot_results.BuildOutcome{Status: slot_results.BuildStatusFailed},
+			RevealAttempts: []slot_results.RevealAttempt{{Status: slot_results.RevealStatusFailed}},
+			Inclusion:      &slot_results.InclusionResult{PayloadStatus: slot_results.PayloadStatusMissed},
+		},
+		{
+			Slot:           12,
+			Build:          &slot_results.BuildOutcome{Status: slot_results.BuildStatusSkipped},
+			RevealAttempts: []slot_results.RevealAttempt{{Status: slot_results.RevealStatusSuppressed}},
+		},
+	}
+
+	reveal := windowMetric(MetricRevealFailurePct, results)
+	require.Equal(t, 2, reveal.samples, "suppressed reveals are not samples")
+	require.InDelta(t, 50, reveal.value, 0.001)
+
+	build := windowMetric(MetricBuildFailurePct, results)
+	require.Equal(t, 2, build.samples, "skipped builds are not samples")
+	require.InDelta(t, 50, build.value, 0.001)
+
+	bids := windowMetric(MetricBidFailurePct, results)
+	require.InDelta(t, 50, bids.value, 0.001)
+
+	missed := windowMetric(MetricPayloadsMissed, results)
+	require.InDelta(t, 1, missed.value, 0.001)
+
+	require.Zero(t, windowMetric(MetricRevealFailurePct, nil).samples)
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/db"
+)
+
+// Namespace is the kv_store namespace holding the persisted slot decisions.
+const Namespace = "slot_decisions"
+
+// DecisionsCodec translates slot journals to their persisted form in the
+// kv_store: decimal slot string keys, JSON values.
+type DecisionsCodec struct{}
+
+var _ db.KVCodec[phase0.Slot, []Decision] = DecisionsCodec{}
+
+// EncodeKey encodes a slot as its decimal string form.
+func (DecisionsCodec) EncodeKey(slot phase0.Slot) string {
+	return strconv.FormatUint(uint64(slot), 10)
+}
+
+// DecodeKey parses a decimal slot string.
+func (DecisionsCodec) DecodeKey(key string) (phase0.Slot, error) {
+	slot, err := strconv.ParseUint(key, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid slot decisions key %q: %w", key, err)
+	}
+
+	return phase0.Slot(slot), nil
+}
+
+// EncodeValue JSON-encodes a slot's decisions.
+func (DecisionsCodec) EncodeValue(decisions []Decision) ([]byte, error) {
+	return json.Marshal(decisions)
+}
+
+// DecodeValue JSON-decodes a slot's decisions.
+func (DecisionsCodec) DecodeValue(value []byte) ([]Decision, error) {
+	var decisions []Decision
+	if err := json.Unmarshal(value, &decisions); err != nil {
+		return nil, fmt.Errorf("failed to decode slot decisions: %w", err)
+	}
+
+	return decisions, nil
+}
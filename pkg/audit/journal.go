@@ -0,0 +1,393 @@
+// Package audit keeps the per-slot decision journal: a structured record of
+// why buildoor did or did not build, bid and reveal for each slot.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// Pipeline stages a decision is recorded for.
+const (
+	StageBuild  = "build"
+	StageBid    = "bid"
+	StageReveal = "reveal"
+)
+
+// Decision outcomes.
+const (
+	OutcomeDone    = "done"
+	OutcomeSkipped = "skipped"
+	OutcomeFailed  = "failed"
+)
+
+// Reasons of done and failed decisions. Skipped decisions carry the
+// producer's own skip reason (action_plan.BuildSkipReason*,
+// p2p_bidder.BidSkipReason*, payload_bidder.RevealSkipReason*).
+const (
+	ReasonBuilt        = "built"
+	ReasonSubmitted    = "submitted"
+	ReasonPublished    = "published"
+	ReasonSubmitFailed = "submit_failed" // bid built and signed, gossip failed
+	ReasonError        = "error"
+)
+
+// maxDecisionsPerSlot bounds a slot's journal; interval bidding records one
+// decision per bid.
+const maxDecisionsPerSlot = 64
+
+// Decision is one journal entry: what a pipeline stage did for a slot and
+// why.
+type Decision struct {
+	Stage   string    `json:"stage"`   // Stage* constants
+	Outcome string    `json:"outcome"` // Outcome* constants
+	Reason  string    `json:"reason"`
+	Detail  string    `json:"detail,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// Journal records the build, bid and reveal decisions of every slot from the
+// services' events, bounded to the slot result retention window.
+type Journal struct {
+	cfg        *config.Config
+	chainSvc   chain.Service
+	builderSvc *payload_builder.Service
+	epbsSvc    *p2p_bidder.Service           // may be nil pre-Gloas
+	revealSvc  *payload_bidder.RevealService // may be nil pre-Gloas
+
+	store *memstore.Store[phase0.Slot, []Decision]
+	mu    sync.Mutex // serialises read-append-put on the store
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewJournal creates the decision journal. epbsSvc and revealSvc may be nil
+// when the Gloas fork is not scheduled.
+func NewJournal(cfg *config.Config, chainSvc chain.Service, builderSvc *payload_builder.Service,
+	epbsSvc *p2p_bidder.Service, revealSvc *payload_bidder.RevealService, log logrus.FieldLogger) *Journal {
+	return &Journal{
+		cfg:        cfg,
+		chainSvc:   chainSvc,
+		builderSvc: builderSvc,
+		epbsSvc:    epbsSvc,
+		revealSvc:  revealSvc,
+		store:      memstore.New[phase0.Slot, []Decision](),
+		log:        log.WithField("component", "audit-journal"),
+	}
+}
+
+// SetPersistence attaches the kv_store persistence and rehydrates prior
+// decisions.
+func (j *Journal) SetPersistence(ctx context.Context, stateDB *db.Database) {
+	j.store.SetPersistence(ctx, db.NewKVPersistence(stateDB, Namespace, DecisionsCodec{}), j.log)
+}
+
+// Start subscribes to the decision sources and launches the journal loop.
+func (j *Journal) Start(ctx context.Context) error {
+	j.ctx, j.cancel = context.WithCancel(ctx)
+
+	readySub := j.builderSvc.SubscribePayloadReady(16, true)
+	failedSub := j.builderSvc.SubscribePayloadBuildFailed(16, true)
+	skippedSub := j.builderSvc.SubscribeBuildSkipped(16, true)
+	epochSub := j.chainSvc.SubscribeEpochStats()
+
+	var (
+		bidSub     *utils.Subscription[*p2p_bidder.BidSubmissionEvent]
+		bidSkipSub *utils.Subscription[*p2p_bidder.BidSkippedEvent]
+		revealSub  *utils.Subscription[*payload_bidder.RevealResult]
+	)
+
+	if j.epbsSvc != nil {
+		bidSub = j.epbsSvc.SubscribeBidSubmissions(64, true)
+		bidSkipSub = j.epbsSvc.SubscribeBidSkipped(16, true)
+	}
+
+	if j.revealSvc != nil {
+		revealSub = j.revealSvc.SubscribeResults(16, true)
+	}
+
+	j.wg.Add(1)
+
+	go j.run(readySub, failedSub, skippedSub, bidSub, bidSkipSub, revealSub, epochSub)
+
+	j.log.Info("Decision journal started")
+
+	return nil
+}
+
+// Stop terminates the journal loop and flushes the store. Must be called
+// before the state-db closes.
+func (j *Journal) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+
+	j.wg.Wait()
+	j.store.Stop()
+
+	j.log.Info("Decision journal stopped")
+}
+
+// Decisions returns the slot's decisions in recording order (nil when none
+// were recorded).
+func (j *Journal) Decisions(slot phase0.Slot) []Decision {
+	decisions, _ := j.store.Get(slot)
+
+	return decisions
+}
+
+// Record appends a decision to the slot's journal. A zero At is stamped with
+// the current time.
+func (j *Journal) Record(slot phase0.Slot, decision Decision) {
+	if decision.At.IsZero() {
+		decision.At = time.Now()
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	decisions, _ := j.store.Get(slot)
+	if len(decisions) >= maxDecisionsPerSlot {
+		return
+	}
+
+	// Copy-on-write: readers may hold the previous slice.
+	next := make([]Decision, len(decisions), len(decisions)+1)
+	copy(next, decisions)
+
+	j.store.Put(slot, append(next, decision))
+}
+
+//nolint:gocyclo // single select loop over all decision sources
+func (j *Journal) run(
+	readySub *utils.Subscription[*payload_builder.Payload],
+	failedSub *utils.Subscription[*payload_builder.PayloadBuildFailedEvent],
+	skippedSub *utils.Subscription[*payload_builder.BuildSkippedEvent],
+	bidSub *utils.Subscription[*p2p_bidder.BidSubmissionEvent],
+	bidSkipSub *utils.Subscription[*p2p_bidder.BidSkippedEvent],
+	revealSub *utils.Subscription[*payload_bidder.RevealResult],
+	epochSub *utils.Subscription[*chain.EpochStats],
+) {
+	defer j.wg.Done()
+	defer readySub.Unsubscribe()
+	defer failedSub.Unsubscribe()
+	defer skippedSub.Unsubscribe()
+	defer epochSub.Unsubscribe()
+
+	// nil subscriptions must never fire in the select.
+	var bidCh <-chan *p2p_bidder.BidSubmissionEvent
+	if bidSub != nil {
+		defer bidSub.Unsubscribe()
+		bidCh = bidSub.Channel()
+	}
+
+	var bidSkipCh <-chan *p2p_bidder.BidSkippedEvent
+	if bidSkipSub != nil {
+		defer bidSkipSub.Unsubscribe()
+		bidSkipCh = bidSkipSub.Channel()
+	}
+
+	var revealCh <-chan *payload_bidder.RevealResult
+	if revealSub != nil {
+		defer revealSub.Unsubscribe()
+		revealCh = revealSub.Channel()
+	}
+
+	for {
+		select {
+		case <-j.ctx.Done():
+			return
+
+		case payload, ok := <-readySub.Channel():
+			if !ok {
+				return
+			}
+
+			j.handlePayloadReady(payload)
+
+		case event, ok := <-failedSub.Channel():
+			if !ok {
+				return
+			}
+
+			j.Record(event.Slot, Decision{
+				Stage: StageBuild, Outcome: OutcomeFailed, Reason: ReasonError,
+				Detail: event.Error, At: event.FailedAt,
+			})
+
+		case event, ok := <-skippedSub.Channel():
+			if !ok {
+				return
+			}
+
+			j.Record(event.Slot, Decision{Stage: StageBuild, Outcome: OutcomeSkipped, Reason: event.Reason})
+
+		case event, ok := <-bidCh:
+			if !ok {
+				return
+			}
+
+			j.handleBidSubmission(event)
+
+		case event, ok := <-bidSkipCh:
+			if !ok {
+				return
+			}
+
+			j.Record(event.Slot, Decision{
+				Stage: StageBid, Outcome: OutcomeSkipped, Reason: event.Reason, Detail: event.Detail,
+			})
+
+		case result, ok := <-revealCh:
+			if !ok {
+				return
+			}
+
+			j.handleRevealResult(result)
+
+		case epochStats, ok := <-epochSub.Channel():
+			if !ok {
+				return
+			}
+
+			j.prune(epochStats.Epoch)
+		}
+	}
+}
+
+func (j *Journal) handlePayloadReady(payload *payload_builder.Payload) {
+	if payload == nil || payload.Attributes == nil {
+		return
+	}
+
+	detail := fmt.Sprintf("block %x…", payload.BlockHash[:4])
+	if payload.BlockValue != nil {
+		detail += fmt.Sprintf(", value %s wei", payload.BlockValue.String())
+	}
+
+	j.Record(payload.Attributes.ProposalSlot, Decision{
+		Stage: StageBuild, Outcome: OutcomeDone, Reason: ReasonBuilt, Detail: detail, At: payload.ReadyAt,
+	})
+}
+
+// handleBidSubmission records bid attempts. Pre-construction skip warnings
+// (no Status) are left to the slot's BidSkippedEvent, which reports the
+// final reason once bidding closes.
+func (j *Journal) handleBidSubmission(event *p2p_bidder.BidSubmissionEvent) {
+	decision := Decision{Stage: StageBid}
+
+	switch event.Status {
+	case p2p_bidder.BidStatusSubmitted:
+		decision.Outcome = OutcomeDone
+		decision.Reason = ReasonSubmitted
+		decision.Detail = bidDetail(event)
+	case p2p_bidder.BidStatusConstructed:
+		decision.Outcome = OutcomeFailed
+		decision.Reason = ReasonSubmitFailed
+		decision.Detail = event.Error
+	case p2p_bidder.BidStatusFailed:
+		decision.Outcome = OutcomeFailed
+		decision.Reason = ReasonError
+		decision.Detail = event.Error
+	default:
+		return
+	}
+
+	j.Record(event.Slot, decision)
+}
+
+// bidDetail describes a submitted bid: its value and every adjustment made
+// to it.
+func bidDetail(event *p2p_bidder.BidSubmissionEvent) string {
+	parts := []string{fmt.Sprintf("%d gwei", event.Value)}
+
+	if event.MinRaised {
+		parts = append(parts, "raised to bid minimum")
+	}
+
+	if event.BudgetCapped {
+		parts = append(parts, "capped by epoch budget")
+	}
+
+	if event.Canary {
+		parts = append(parts, "canary")
+	}
+
+	if event.Snipe {
+		parts = append(parts, "snipe")
+	}
+
+	if event.Manual {
+		parts = append(parts, "manual")
+	}
+
+	if event.Replaces != ([32]byte{}) {
+		parts = append(parts, fmt.Sprintf("replaces %x…", event.Replaces[:4]))
+	}
+
+	if event.Warning != "" {
+		parts = append(parts, event.Warning)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (j *Journal) handleRevealResult(result *payload_bidder.RevealResult) {
+	decision := Decision{Stage: StageReveal, At: result.CompletedAt}
+
+	switch {
+	case result.Skipped:
+		decision.Outcome = OutcomeSkipped
+		decision.Reason = result.SkipReason
+	case result.Success:
+		decision.Outcome = OutcomeDone
+		decision.Reason = ReasonPublished
+		decision.Detail = fmt.Sprintf("attempt %d/%d", result.Attempt, result.MaxAttempts)
+	default:
+		decision.Outcome = OutcomeFailed
+		decision.Reason = ReasonError
+		decision.Detail = fmt.Sprintf("attempt %d/%d: %s", result.Attempt, result.MaxAttempts, result.Error)
+	}
+
+	if result.Manual {
+		decision.Detail = strings.TrimPrefix(decision.Detail+", manual", ", ")
+	}
+
+	j.Record(result.Slot, decision)
+}
+
+// prune drops the journals of slots outside the slot result retention
+// window.
+func (j *Journal) prune(epoch phase0.Epoch) {
+	retention := j.cfg.SlotResultRetentionEpochs
+	if retention == 0 || uint64(epoch) <= retention {
+		return
+	}
+
+	cutoff := phase0.Slot((uint64(epoch) - retention) * j.chainSvc.GetChainSpec().SlotsPerEpoch)
+
+	if pruned := j.store.Prune(func(slot phase0.Slot) bool { return slot < cutoff }); pruned > 0 {
+		j.log.WithFields(logrus.Fields{
+			"pruned": pruned,
+			"cutoff": cutoff,
+		}).Debug("Pruned slot decisions")
+	}
+}
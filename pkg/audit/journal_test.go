@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+func newTestJournal() *Journal {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	return NewJournal(config.DefaultConfig(), nil, nil, nil, nil, log)
+}
+
+func TestJournalRecordsPipelineDecisions(t *testing.T) {
+	j := newTestJournal()
+	slot := phase0.Slot(100)
+
+	j.handlePayloadReady(&payload_builder.Payload{
+		Attributes: &beacon.PayloadAttributesEvent{ProposalSlot: slot},
+		BlockHash:  phase0.Hash32{0xab, 0xcd},
+		BlockValue: big.NewInt(42),
+		ReadyAt:    time.Now(),
+	})
+	j.handleBidSubmission(&p2p_bidder.BidSubmissionEvent{
+		Slot: slot, Warning: "no proposer preferences for slot — bid skipped",
+	})
+	j.handleBidSubmission(&p2p_bidder.BidSubmissionEvent{
+		Slot: slot, Status: p2p_bidder.BidStatusSubmitted, Success: true, Value: 1000, MinRaised: true,
+	})
+	j.handleBidSubmission(&p2p_bidder.BidSubmissionEvent{
+		Slot: slot, Status: p2p_bidder.BidStatusConstructed, Error: "gossip rejected",
+	})
+	j.handleRevealResult(&payload_bidder.RevealResult{
+		Slot: slot, Success: true, Attempt: 1, MaxAttempts: 3,
+	})
+
+	decisions := j.Decisions(slot)
+	require.Len(t, decisions, 4, "pre-construction skip warnings are left to the bid-close event")
+
+	assert.Equal(t, StageBuild, decisions[0].Stage)
+	assert.Equal(t, OutcomeDone, decisions[0].Outcome)
+	assert.Equal(t, ReasonBuilt, decisions[0].Reason)
+	assert.Contains(t, decisions[0].Detail, "abcd")
+
+	assert.Equal(t, ReasonSubmitted, decisions[1].Reason)
+	assert.Equal(t, "1000 gwei, raised to bid minimum", decisions[1].Detail)
+
+	assert.Equal(t, OutcomeFailed, decisions[2].Outcome)
+	assert.Equal(t, ReasonSubmitFailed, decisions[2].Reason)
+	assert.Equal(t, "gossip rejected", decisions[2].Detail)
+
+	assert.Equal(t, StageReveal, decisions[3].Stage)
+	assert.Equal(t, ReasonPublished, decisions[3].Reason)
+	assert.Equal(t, "attempt 1/3", decisions[3].Detail)
+
+	assert.Nil(t, j.Decisions(slot+1))
+}
+
+func TestJournalSkipsAndFailures(t *testing.T) {
+	j := newTestJournal()
+	slot := phase0.Slot(7)
+
+	j.Record(slot, Decision{Stage: StageBuild, Outcome: OutcomeSkipped, Reason: action_plan.BuildSkipReasonSchedule})
+	j.handleRevealResult(&payload_bidder.RevealResult{
+		Slot: slot, Skipped: true, SkipReason: payload_bidder.RevealSkipReasonLate, Manual: true,
+	})
+	j.handleRevealResult(&payload_bidder.RevealResult{
+		Slot: slot, Attempt: 2, MaxAttempts: 2, Error: "timeout",
+	})
+
+	decisions := j.Decisions(slot)
+	require.Len(t, decisions, 3)
+
+	assert.Equal(t, action_plan.BuildSkipReasonSchedule, decisions[0].Reason)
+	assert.False(t, decisions[0].At.IsZero(), "a zero At is stamped on record")
+
+	assert.Equal(t, OutcomeSkipped, decisions[1].Outcome)
+	assert.Equal(t, payload_bidder.RevealSkipReasonLate, decisions[1].Reason)
+	assert.Equal(t, "manual", decisions[1].Detail)
+
+	assert.Equal(t, OutcomeFailed, decisions[2].Outcome)
+	assert.Equal(t, "attempt 2/2: timeout", decisions[2].Detail)
+}
+
+func TestJournalBoundsSlotDecisions(t *testing.T) {
+	j := newTestJournal()
+
+	for i := range maxDecisionsPerSlot + 5 {
+		j.Record(1, Decision{Stage: StageBid, Outcome: OutcomeDone, Reason: ReasonSubmitted, Detail: string(rune('a' + i%26))})
+	}
+
+	decisions := j.Decisions(1)
+	assert.Len(t, decisions, maxDecisionsPerSlot)
+	assert.Equal(t, "a", decisions[0].Detail, "the earliest decisions are kept")
+}
+
+func TestDecisionsCodecRoundTrip(t *testing.T) {
+	codec := DecisionsCodec{}
+
+	slot, err := codec.DecodeKey(codec.EncodeKey(123))
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Slot(123), slot)
+
+	_, err = codec.DecodeKey("nope")
+	require.Error(t, err)
+
+	in := []Decision{{
+		Stage: StageBid, Outcome: OutcomeSkipped, Reason: p2p_bidder.BidSkipReasonNoPrefs,
+		At: time.Unix(1700000000, 0).UTC(),
+	}}
+
+	raw, err := codec.EncodeValue(in)
+	require.NoError(t, err)
+
+	out, err := codec.DecodeValue(raw)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,367 @@
+// Package audit_export posts a signed summary of each slot's builder actions
+// (bid roots, reveal status, payment) to an external audit service, so shared
+// devnets can monitor builder behavior independently of the builder's own UI.
+package audit_export
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+const (
+	// SignatureHeader carries "sha256=<hex>", the HMAC-SHA256 over
+	// "<timestamp>.<body>" keyed with the configured secret.
+	SignatureHeader = "X-Buildoor-Signature"
+	// TimestampHeader carries the unix-second signing time that is part of
+	// the signed message (lets receivers reject replays).
+	TimestampHeader = "X-Buildoor-Timestamp"
+
+	// postTimeout bounds one export request. Requests are sent from the
+	// export queue, so a slow receiver never delays the slot loop.
+	postTimeout = 10 * time.Second
+
+	// exportQueueSize bounds the summaries waiting for delivery; when a slow
+	// receiver lets it fill up, the oldest summary is dropped.
+	exportQueueSize = 16
+
+	// maxSlotCatchUp bounds how many missed slots are exported after a
+	// stall or clock jump.
+	maxSlotCatchUp = 4
+)
+
+// ResultSource provides the recorded slot results (implemented by the slot
+// results tracker).
+type ResultSource interface {
+	Get(slot phase0.Slot) *slot_results.SlotResult
+}
+
+// SlotSummary is the exported per-slot audit record.
+type SlotSummary struct {
+	Slot          uint64 `json:"slot"`
+	Epoch         uint64 `json:"epoch"`
+	Fork          string `json:"fork"`
+	BuilderPubkey string `json:"builder_pubkey"`
+
+	BuildStatus string          `json:"build_status,omitempty"`
+	BuildHash   string          `json:"build_block_hash,omitempty"`
+	Bids        []BidSummary    `json:"bids"`
+	Reveal      *RevealSummary  `json:"reveal,omitempty"`
+	Payment     *PaymentSummary `json:"payment,omitempty"`
+
+	Signatures []SignatureSummary `json:"signatures,omitempty"`
+
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// BidSummary is one bid attempt reduced to its auditable identity.
+type BidSummary struct {
+	Transport      string `json:"transport"`
+	Status         string `json:"status"`
+	BidRoot        string `json:"bid_root,omitempty"`
+	BlockHash      string `json:"block_hash,omitempty"`
+	TotalValueGwei uint64 `json:"total_value_gwei"`
+	At             int64  `json:"at"` // unix milliseconds
+}
+
+// SignatureSummary is the signing context of one bid, header or envelope
+// signature.
+type SignatureSummary struct {
+	Artifact              string `json:"artifact"`
+	MessageRoot           string `json:"message_root"`
+	ForkVersion           string `json:"fork_version"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	Domain                string `json:"domain"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// RevealSummary is the final state of the slot's reveal.
+type RevealSummary struct {
+	Status     string `json:"status"`
+	Attempts   int    `json:"attempts"`
+	SkipReason string `json:"skip_reason,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PaymentSummary describes the payment owed for a won slot.
+type PaymentSummary struct {
+	Source        string `json:"source"`
+	BlockHash     string `json:"block_hash"`
+	ValueWei      string `json:"value_wei"`
+	PayloadStatus string `json:"payload_status,omitempty"`
+}
+
+// Exporter exports one summary per slot with recorded builder activity,
+// DelaySlots after the slot. Exports are fire-and-forget: summaries are
+// delivered from a bounded queue, failures are logged, never retried, and
+// never affect the builder.
+type Exporter struct {
+	cfg      *config.AuditExportConfig
+	chainSvc chain.Service
+	results  ResultSource
+	pubkey   string
+	client   *http.Client
+	queue    chan *SlotSummary
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewExporter creates the audit exporter.
+func NewExporter(cfg *config.AuditExportConfig, chainSvc chain.Service, results ResultSource,
+	builderPubkey phase0.BLSPubKey, log logrus.FieldLogger) *Exporter {
+	return &Exporter{
+		cfg:      cfg,
+		chainSvc: chainSvc,
+		results:  results,
+		pubkey:   fmt.Sprintf("%#x", builderPubkey),
+		client:   &http.Client{Timeout: postTimeout},
+		queue:    make(chan *SlotSummary, exportQueueSize),
+		log:      log.WithField("component", "audit-export"),
+	}
+}
+
+// Start launches the slot-clocked export loop.
+func (e *Exporter) Start(ctx context.Context) error {
+	if e.cfg.URL == "" {
+		return fmt.Errorf("audit export url not configured")
+	}
+
+	e.ctx, e.cancel = context.WithCancel(ctx)
+
+	e.wg.Add(2)
+
+	go e.run()
+	go e.deliver()
+
+	e.log.WithField("url", e.cfg.URL).Info("Audit exporter started")
+
+	return nil
+}
+
+// Stop terminates the export loop. Summaries still queued are dropped.
+func (e *Exporter) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+
+	e.wg.Wait()
+}
+
+func (e *Exporter) run() {
+	defer e.wg.Done()
+
+	lastExported := e.exportHorizon()
+	slotTimer := chain.NewSlotTimer(e.chainSvc)
+
+	defer slotTimer.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+
+		case <-slotTimer.C():
+			horizon := e.exportHorizon()
+
+			firstSlot := lastExported + 1
+			if horizon > firstSlot+maxSlotCatchUp {
+				firstSlot = horizon - maxSlotCatchUp
+			}
+
+			for slot := firstSlot; slot <= horizon; slot++ {
+				e.exportSlot(slot)
+			}
+
+			if horizon > lastExported {
+				lastExported = horizon
+			}
+
+			chain.ResetSlotTimer(e.chainSvc, slotTimer)
+		}
+	}
+}
+
+// exportHorizon is the latest slot that is old enough to be exported.
+func (e *Exporter) exportHorizon() phase0.Slot {
+	currentSlot := e.chainSvc.GetCurrentSlot()
+	delay := phase0.Slot(e.cfg.DelaySlots)
+
+	if currentSlot < delay {
+		return 0
+	}
+
+	return currentSlot - delay
+}
+
+func (e *Exporter) exportSlot(slot phase0.Slot) {
+	result := e.results.Get(slot)
+	if result == nil {
+		return
+	}
+
+	summary := BuildSummary(result, e.pubkey)
+	summary.ExportedAt = time.Now()
+
+	e.enqueue(summary)
+}
+
+// enqueue queues a summary for delivery, dropping the oldest queued one when
+// the queue is full. Only the export loop enqueues, so the dropped entry
+// always makes room.
+func (e *Exporter) enqueue(summary *SlotSummary) {
+	select {
+	case e.queue <- summary:
+		return
+	default:
+	}
+
+	select {
+	case dropped := <-e.queue:
+		e.log.WithField("slot", dropped.Slot).Warn("Audit export queue full, dropped the oldest summary")
+	default:
+	}
+
+	e.queue <- summary
+}
+
+// deliver posts the queued summaries one at a time.
+func (e *Exporter) deliver() {
+	defer e.wg.Done()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+
+		case summary := <-e.queue:
+			if err := e.post(e.ctx, summary); err != nil {
+				e.log.WithError(err).WithField("slot", summary.Slot).Warn("Failed to export audit summary")
+				continue
+			}
+
+			e.log.WithField("slot", summary.Slot).Debug("Exported audit summary")
+		}
+	}
+}
+
+// post signs and delivers one summary.
+func (e *Exporter) post(ctx context.Context, summary *SlotSummary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(e.cfg.Secret, timestamp, body))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with
+// secret. Receivers recompute it to authenticate a summary.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BuildSummary reduces a slot result to the exported audit record.
+func BuildSummary(result *slot_results.SlotResult, builderPubkey string) *SlotSummary {
+	summary := &SlotSummary{
+		Slot:          uint64(result.Slot),
+		Epoch:         result.Epoch,
+		Fork:          result.Fork,
+		BuilderPubkey: builderPubkey,
+		Bids:          make([]BidSummary, 0, len(result.Bids)),
+	}
+
+	if result.Build != nil {
+		summary.BuildStatus = string(result.Build.Status)
+		summary.BuildHash = result.Build.BlockHash
+	}
+
+	for _, bid := range result.Bids {
+		summary.Bids = append(summary.Bids, BidSummary{
+			Transport:      bid.Transport,
+			Status:         string(bid.Status),
+			BidRoot:        bid.BidRoot,
+			BlockHash:      bid.BlockHash,
+			TotalValueGwei: bid.TotalValueGwei,
+			At:             bid.At.UnixMilli(),
+		})
+	}
+
+	for _, sig := range result.Signatures {
+		summary.Signatures = append(summary.Signatures, SignatureSummary{
+			Artifact:              sig.Artifact,
+			MessageRoot:           sig.MessageRoot,
+			ForkVersion:           sig.ForkVersion,
+			GenesisValidatorsRoot: sig.GenesisValidatorsRoot,
+			Domain:                sig.Domain,
+			Error:                 sig.Error,
+		})
+	}
+
+	if n := len(result.RevealAttempts); n > 0 {
+		last := result.RevealAttempts[n-1]
+		summary.Reveal = &RevealSummary{
+			Status:     string(last.Status),
+			Attempts:   n,
+			SkipReason: last.SkipReason,
+			Error:      last.Error,
+		}
+	}
+
+	if inclusion := result.Inclusion; inclusion != nil {
+		summary.Payment = &PaymentSummary{
+			Source:        inclusion.Source,
+			BlockHash:     inclusion.BlockHash,
+			ValueWei:      inclusion.ValueWei,
+			PayloadStatus: string(inclusion.PayloadStatus),
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,223 @@
+package audit_export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain/chaintest"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+func testResult() *slot_results.SlotResult {
+	at := time.UnixMilli(1700000000000)
+
+	return &slot_results.SlotResult{
+		Slot:  100,
+		Epoch: 3,
+		Fork:  "gloas",
+		Build: &slot_results.BuildOutcome{Status: slot_results.BuildStatusReady, BlockHash: "0xaa"},
+		Bids: []slot_results.BidAttempt{
+			{Status: slot_results.BidStatusSubmitted, Transport: "p2p", BidRoot: "0x01", BlockHash: "0xaa", TotalValueGwei: 10, At: at},
+			{Status: slot_results.BidStatusServed, Transport: "builder_api", BidRoot: "0x02", TotalValueGwei: 20, At: at},
+		},
+		RevealAttempts: []slot_results.RevealAttempt{
+			{Status: slot_results.RevealStatusFailed, Attempt: 1, Error: "timeout"},
+			{Status: slot_results.RevealStatusPublished, Attempt: 2},
+		},
+		Signatures: []slot_results.SigningRecord{
+			{Artifact: "bid", MessageRoot: "0x01", ForkVersion: "0x07000000", Domain: "0x0b000000aa", At: at},
+		},
+		Inclusion: &slot_results.InclusionResult{
+			Source:        "epbs",
+			BlockHash:     "0xaa",
+			ValueWei:      "20000000000",
+			PayloadStatus: slot_results.PayloadStatusCanonical,
+		},
+	}
+}
+
+func TestBuildSummary(t *testing.T) {
+	summary := BuildSummary(testResult(), "0xbeef")
+
+	require.Equal(t, uint64(100), summary.Slot)
+	require.Equal(t, "0xbeef", summary.BuilderPubkey)
+	require.Equal(t, "ready", summary.BuildStatus)
+	require.Len(t, summary.Bids, 2)
+	require.Equal(t, "0x01", summary.Bids[0].BidRoot)
+	require.Equal(t, "0x02", summary.Bids[1].BidRoot)
+	require.Len(t, summary.Signatures, 1)
+	require.Equal(t, "0x07000000", summary.Signatures[0].ForkVersion)
+
+	require.NotNil(t, summary.Reveal)
+	require.Equal(t, "published", summary.Reveal.Status, "the last attempt decides the reveal status")
+	require.Equal(t, 2, summary.Reveal.Attempts)
+
+	require.NotNil(t, summary.Payment)
+	require.Equal(t, "20000000000", summary.Payment.ValueWei)
+	require.Equal(t, "canonical", summary.Payment.PayloadStatus)
+}
+
+func TestBuildSummaryNoActivity(t *testing.T) {
+	summary := BuildSummary(&slot_results.SlotResult{Slot: 5}, "0xbeef")
+
+	require.NotNil(t, summary.Bids, "bids must encode as an empty list, not null")
+	require.Empty(t, summary.Bids)
+	require.Nil(t, summary.Reveal)
+	require.Nil(t, summary.Payment)
+}
+
+func TestPostSignsSummary(t *testing.T) {
+	const secret = "s3cret"
+
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotTimestamp string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotTimestamp = r.Header.Get(TimestampHeader)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	exporter := NewExporter(&config.AuditExportConfig{URL: srv.URL, Secret: secret},
+		nil, nil, phase0.BLSPubKey{0x01}, log)
+
+	require.NoError(t, exporter.post(context.Background(), BuildSummary(testResult(), exporter.pubkey)))
+
+	require.NotEmpty(t, gotTimestamp)
+	require.Equal(t, "sha256="+Sign(secret, gotTimestamp, gotBody), gotSignature)
+	require.NotEqual(t, "sha256="+Sign("other", gotTimestamp, gotBody), gotSignature)
+
+	var decoded SlotSummary
+	require.NoError(t, json.Unmarshal(gotBody, &decoded))
+	require.Equal(t, uint64(100), decoded.Slot)
+	require.Len(t, decoded.Bids, 2)
+}
+
+func TestPostRejectsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	exporter := NewExporter(&config.AuditExportConfig{URL: srv.URL, Secret: "x"},
+		nil, nil, phase0.BLSPubKey{}, log)
+
+	require.Error(t, exporter.post(context.Background(), BuildSummary(testResult(), exporter.pubkey)))
+}
+
+type stubResults map[phase0.Slot]*slot_results.SlotResult
+
+func (s stubResults) Get(slot phase0.Slot) *slot_results.SlotResult { return s[slot] }
+
+func TestExporterExportsOnChainClock(t *testing.T) {
+	exported := make(chan uint64, 4)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var summary SlotSummary
+		if err := json.NewDecoder(r.Body).Decode(&summary); err == nil {
+			exported <- summary.Slot
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	// Slot 100 is due two slots after it, at slot 102. The chain clock is
+	// a fake: a wall-clock slot timer would never fire.
+	chainSvc := chaintest.NewClocked(time.Unix(101*12+1, 0))
+	exporter := NewExporter(&config.AuditExportConfig{URL: srv.URL, Secret: "x", DelaySlots: 2},
+		chainSvc, stubResults{100: testResult()}, phase0.BLSPubKey{}, log)
+
+	require.NoError(t, exporter.Start(t.Context()))
+	t.Cleanup(exporter.Stop)
+
+	require.Eventually(t, func() bool { return chainSvc.Clk.Pending() > 0 }, time.Second, time.Millisecond)
+
+	chainSvc.Clk.Advance(11 * time.Second)
+
+	select {
+	case slot := <-exported:
+		require.Equal(t, uint64(100), slot)
+	case <-time.After(2 * time.Second):
+		t.Fatal("slot 100 not exported on the chain clock")
+	}
+}
+
+func TestExportQueueDropsOldest(t *testing.T) {
+	received := make(chan uint64, 2*exportQueueSize)
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var summary SlotSummary
+		if err := json.NewDecoder(r.Body).Decode(&summary); err == nil {
+			received <- summary.Slot
+		}
+
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	exporter := NewExporter(&config.AuditExportConfig{URL: srv.URL, Secret: "x"},
+		chaintest.NewClocked(time.Unix(12, 0)), stubResults{}, phase0.BLSPubKey{}, log)
+
+	require.NoError(t, exporter.Start(t.Context()))
+	t.Cleanup(exporter.Stop)
+
+	// The receiver stalls on slot 1; the slots queued behind it overflow
+	// the queue without blocking the caller.
+	exporter.enqueue(&SlotSummary{Slot: 1})
+	require.Equal(t, uint64(1), <-received)
+
+	last := uint64(exportQueueSize + 3)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for slot := uint64(2); slot <= last; slot++ {
+			exporter.enqueue(&SlotSummary{Slot: slot})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("enqueue blocked on a stalled receiver")
+	}
+
+	require.Len(t, exporter.queue, exportQueueSize)
+
+	release <- struct{}{}
+
+	require.Equal(t, uint64(4), <-received, "slots 2 and 3 were dropped as the oldest")
+}
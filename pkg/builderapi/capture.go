@@ -0,0 +1,272 @@
+package builderapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// redacted replaces scrubbed secrets in captured exchanges.
+const redacted = "[redacted]"
+
+// secretHeaders are request and response headers whose values are scrubbed.
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+}
+
+// secretJSONField matches JSON string fields whose name suggests a secret
+// (e.g. "private_key", "api_token"); their values are scrubbed.
+var secretJSONField = regexp.MustCompile(
+	`("[A-Za-z0-9_-]*(?i:secret|password|passwd|privkey|private_key|token|mnemonic)[A-Za-z0-9_-]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// CapturedExchange is one captured Builder API request and its response.
+type CapturedExchange struct {
+	ID         uint64          `json:"id"`
+	Time       time.Time       `json:"time"`
+	DurationMs float64         `json:"duration_ms"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	Route      string          `json:"route,omitempty"` // route template, e.g. /eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}
+	RemoteAddr string          `json:"remote_addr"`
+	Status     int             `json:"status"`
+	Request    CapturedMessage `json:"request"`
+	Response   CapturedMessage `json:"response"`
+}
+
+// CapturedMessage is the headers and (size-limited) body of a captured
+// request or response.
+type CapturedMessage struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is the captured body: text as is, binary (SSZ) bodies base64
+	// encoded (BodyEncoding "base64").
+	Body         string `json:"body,omitempty"`
+	BodyEncoding string `json:"body_encoding,omitempty"`
+	BodySize     int64  `json:"body_size"` // full body size in bytes
+	Truncated    bool   `json:"truncated,omitempty"`
+}
+
+// captureBuffer keeps the most recent Builder API exchanges in a ring
+// buffer, so a rejected validator client request can be inspected without
+// raising the log level and redeploying.
+type captureBuffer struct {
+	maxBody int
+
+	mu      sync.Mutex
+	entries []CapturedExchange
+	next    int
+	full    bool
+	nextID  uint64
+}
+
+// newCaptureBuffer creates a capture buffer holding up to size exchanges
+// with bodies capped at maxBody bytes; nil when size is 0 (disabled).
+func newCaptureBuffer(size, maxBody int) *captureBuffer {
+	if size <= 0 {
+		return nil
+	}
+
+	return &captureBuffer{
+		maxBody: maxBody,
+		entries: make([]CapturedExchange, size),
+	}
+}
+
+// Middleware captures every request passing through it. A nil buffer passes
+// requests through untouched.
+func (c *captureBuffer) Middleware(next http.Handler) http.Handler {
+	if c == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		// The body prefix is read up front, so it is captured even when the
+		// handler rejects the request without reading it.
+		reqBody := &limitedBuffer{limit: c.maxBody}
+		if r.Body != nil && r.Body != http.NoBody {
+			prefix, err := io.ReadAll(io.LimitReader(r.Body, int64(c.maxBody)))
+			_, _ = reqBody.Write(prefix)
+
+			rest := io.TeeReader(r.Body, reqBody)
+			if err != nil {
+				rest = errReader{err}
+			}
+
+			r.Body = &teeReadCloser{Reader: io.MultiReader(bytes.NewReader(prefix), rest), Closer: r.Body}
+		}
+
+		recorder := &captureRecorder{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+			body:           &limitedBuffer{limit: c.maxBody},
+		}
+
+		next.ServeHTTP(recorder, r)
+
+		exchange := CapturedExchange{
+			Time:       start,
+			DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+			Method:     r.Method,
+			Path:       r.URL.RequestURI(),
+			RemoteAddr: r.RemoteAddr,
+			Status:     recorder.status,
+			Request:    capturedMessage(r.Header, reqBody),
+			Response:   capturedMessage(w.Header(), recorder.body),
+		}
+
+		// The handler may not have read a body longer than the capture.
+		exchange.Request.BodySize = max(exchange.Request.BodySize, r.ContentLength)
+		exchange.Request.Truncated = exchange.Request.BodySize > int64(reqBody.buf.Len())
+
+		if route := mux.CurrentRoute(r); route != nil {
+			exchange.Route, _ = route.GetPathTemplate()
+		}
+
+		c.add(exchange)
+	})
+}
+
+// add appends an exchange, evicting the oldest when the buffer is full.
+func (c *captureBuffer) add(exchange CapturedExchange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	exchange.ID = c.nextID
+
+	c.entries[c.next] = exchange
+	c.next = (c.next + 1) % len(c.entries)
+
+	if c.next == 0 {
+		c.full = true
+	}
+}
+
+// recent returns up to n (all when n <= 0) of the most recently captured
+// exchanges, newest first.
+func (c *captureBuffer) recent(n int) []CapturedExchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := c.next
+	if c.full {
+		count = len(c.entries)
+	}
+
+	if n <= 0 || n > count {
+		n = count
+	}
+
+	out := make([]CapturedExchange, n)
+
+	for i := range n {
+		out[i] = c.entries[(c.next-1-i+len(c.entries))%len(c.entries)]
+	}
+
+	return out
+}
+
+// capturedMessage snapshots headers and body with secrets scrubbed.
+func capturedMessage(header http.Header, body *limitedBuffer) CapturedMessage {
+	msg := CapturedMessage{
+		BodySize:  body.size,
+		Truncated: body.size > int64(body.buf.Len()),
+	}
+
+	if len(header) > 0 {
+		msg.Headers = make(map[string]string, len(header))
+
+		for name, values := range header {
+			value := strings.Join(values, ", ")
+			if secretHeaders[http.CanonicalHeaderKey(name)] {
+				value = redacted
+			}
+
+			msg.Headers[name] = value
+		}
+	}
+
+	data := body.buf.Bytes()
+
+	switch {
+	case len(data) == 0:
+	case utf8.Valid(data):
+		msg.Body = secretJSONField.ReplaceAllString(string(data), `${1}"`+redacted+`"`)
+	default:
+		msg.Body = base64.StdEncoding.EncodeToString(data)
+		msg.BodyEncoding = "base64"
+	}
+
+	return msg
+}
+
+// limitedBuffer keeps the first limit bytes written to it and counts the
+// rest.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+	size  int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.size += int64(len(p))
+
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+
+	return len(p), nil
+}
+
+// teeReadCloser reads the request body through the capture while closing
+// the original body.
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader fails every read with err (the error reading the body prefix).
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// captureRecorder captures the response status and body.
+type captureRecorder struct {
+	http.ResponseWriter
+	status int
+	body   *limitedBuffer
+}
+
+// WriteHeader records the status code before writing it.
+func (r *captureRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Write captures the body bytes before writing them.
+func (r *captureRecorder) Write(p []byte) (int, error) {
+	_, _ = r.body.Write(p)
+
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController.
+func (r *captureRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
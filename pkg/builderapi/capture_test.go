@@ -0,0 +1,78 @@
+package builderapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func TestCaptures(t *testing.T) {
+	cfg := &config.BuilderAPIConfig{CaptureSize: 2, CaptureMaxBody: 64}
+	srv := NewServer(cfg, logrus.New(), &mockChainService{}, newServingPlanService(), nil, nil, nil)
+	handler := srv.Handler()
+
+	require.True(t, srv.CapturesEnabled())
+	require.Empty(t, srv.Captures(0))
+
+	body := `[{"message":{"fee_recipient":"0xabcd","api_token":"hunter2"},"signature":"0x12"}]`
+	req := httptest.NewRequest(http.MethodPost, "/eth/v1/builder/validators", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("User-Agent", "Lighthouse/v7.0.0")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	captures := srv.Captures(0)
+	require.Len(t, captures, 1)
+
+	c := captures[0]
+	assert.Equal(t, uint64(1), c.ID)
+	assert.Equal(t, http.MethodPost, c.Method)
+	assert.Equal(t, "/eth/v1/builder/validators", c.Route)
+	assert.Equal(t, http.StatusBadRequest, c.Status)
+	assert.Equal(t, "[redacted]", c.Request.Headers["Authorization"])
+	assert.Equal(t, "Lighthouse/v7.0.0", c.Request.Headers["User-Agent"])
+	assert.Equal(t, int64(len(body)), c.Request.BodySize)
+	assert.True(t, c.Request.Truncated)
+	assert.True(t, strings.HasPrefix(c.Request.Body, `[{"message":{"fee_recipient":"0xabcd","api_token":"[redacted]"},`))
+	assert.NotContains(t, c.Request.Body, "hunter2")
+	assert.Contains(t, c.Response.Body, `"code":400`)
+	assert.Equal(t, "application/json", c.Response.Headers["Content-Type"])
+
+	// Binary (SSZ) bodies are base64 encoded; unknown endpoints are captured.
+	ssz := []byte{0x00, 0xff, 0xfe, 0x01}
+	req = httptest.NewRequest(http.MethodPost, "/eth/v1/builder/unknown", bytes.NewReader(ssz))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/eth/v1/builder/status", nil))
+
+	// The oldest exchange was evicted; newest first.
+	captures = srv.Captures(0)
+	require.Len(t, captures, 2)
+	assert.Equal(t, uint64(3), captures[0].ID)
+	assert.Equal(t, "/eth/v1/builder/status", captures[0].Path)
+	assert.Equal(t, http.StatusOK, captures[0].Status)
+	assert.Equal(t, uint64(2), captures[1].ID)
+	assert.Equal(t, http.StatusNotFound, captures[1].Status)
+	assert.Equal(t, "base64", captures[1].Request.BodyEncoding)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(ssz), captures[1].Request.Body)
+
+	require.Len(t, srv.Captures(1), 1)
+}
+
+func TestCaptures_Disabled(t *testing.T) {
+	srv := NewServer(&config.BuilderAPIConfig{}, logrus.New(), &mockChainService{}, newServingPlanService(), nil, nil, nil)
+	srv.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/eth/v1/builder/status", nil))
+
+	assert.False(t, srv.CapturesEnabled())
+	assert.Nil(t, srv.Captures(0))
+}
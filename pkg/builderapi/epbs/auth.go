@@ -51,9 +51,8 @@ func VerifyRequestAuth(
 	}
 
 	domain := signer.ComputeDomain(DomainRequestAuth, genesisForkVersion, phase0.Root{})
-	signingRoot := signer.ComputeSigningRoot(msgRoot, domain)
 
-	if !signer.VerifyBLSSignature(validatorPubkey, signingRoot[:], phase0.BLSSignature(signed.Signature)) {
+	if !signer.VerifyWithDomain(validatorPubkey, msgRoot, domain, phase0.BLSSignature(signed.Signature)) {
 		return ErrInvalidRequestAuthSignature
 	}
 	return nil
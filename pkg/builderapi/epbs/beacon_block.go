@@ -14,6 +14,7 @@ import (
 	dynssz "github.com/pk910/dynamic-ssz"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/builderapi/internal/slotwindow"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
@@ -32,7 +33,9 @@ import (
 // publishes it at the configured reveal time (deduped per slot with the p2p
 // flow).
 //
-// Returns 202 on success, 400 on a malformed block or missing payload,
+// Returns 202 on success, 400 on a malformed block, a block for a slot outside
+// the current/next slot window (widened by the configured slot tolerance) or a
+// missing payload (builder-specs "Invalid block" / "Unknown hash"),
 // 415 on wrong Content-Type, 500 on broadcast/internal errors, and 503 if the
 // dialect is disabled, not fully configured, or the chain has not activated
 // Gloas yet.
@@ -63,6 +66,8 @@ func (h *Handler) HandleSubmitBeaconBlock(w http.ResponseWriter, r *http.Request
 	}
 
 	fork := h.chainSvc.GetCurrentFork()
+	setConsensusVersion(w, fork)
+
 	if fork < version.DataVersionGloas {
 		log.WithField("fork", fork.String()).Warn(
 			"submitBeaconBlock: 503 — post-Gloas Builder API dialect not available pre-Gloas")
@@ -81,6 +86,7 @@ func (h *Handler) HandleSubmitBeaconBlock(w http.ResponseWriter, r *http.Request
 		}
 
 		fork = parsed
+		setConsensusVersion(w, fork)
 	}
 
 	if fork < version.DataVersionGloas {
@@ -115,7 +121,7 @@ func (h *Handler) HandleSubmitBeaconBlock(w http.ResponseWriter, r *http.Request
 		block.Message.Body.SignedExecutionPayloadBid == nil ||
 		block.Message.Body.SignedExecutionPayloadBid.Message == nil {
 		log.Warn("submitBeaconBlock: missing signed_execution_payload_bid in block body")
-		writeError(w, http.StatusBadRequest, "missing signed_execution_payload_bid in block body")
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": missing signed_execution_payload_bid in block body")
 		return
 	}
 
@@ -133,11 +139,21 @@ func (h *Handler) HandleSubmitBeaconBlock(w http.ResponseWriter, r *http.Request
 		h.events.BroadcastBuilderAPISubmitBlockReceived(uint64(bid.Slot), blockHashHex)
 	}
 
+	// A block outside the slot window can no longer (or not yet) be
+	// proposed; publishing it and revealing the envelope would be wasted.
+	if msg := slotwindow.Check(bid.Slot, h.chainSvc.GetCurrentSlot(), h.cfg.SlotTolerance); msg != "" {
+		log.Warn("submitBeaconBlock: block slot outside submission window")
+		h.recordSubmission(bid.Slot, submissionStatusFailed, msg)
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": "+msg)
+
+		return
+	}
+
 	event := h.payloadCache.GetByBlockHash(bid.BlockHash)
 	if event == nil {
 		log.Info("submitBeaconBlock: 400 — no cached payload for bid block hash")
 		h.recordSubmission(bid.Slot, submissionStatusFailed, "no cached payload for bid block hash")
-		writeError(w, http.StatusBadRequest, "no cached payload for bid block hash")
+		writeError(w, http.StatusBadRequest, errUnknownHash+": no payload for bid block hash "+blockHashHex)
 
 		return
 	}
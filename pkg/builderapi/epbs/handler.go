@@ -88,7 +88,7 @@ type Handler struct {
 	chainSvc     chain.Service
 	payloadCache *payload_builder.PayloadCache
 	bidderSigner *payload_bidder.Signer // shared Gloas bid signer (wraps blsSigner)
-	blsSigner    *signer.BLSSigner      // IsBuilderActive pubkey check
+	blsSigner    signer.Signer          // IsBuilderActive pubkey check
 
 	// planSvc is the mandatory per-slot scheduling/settings authority: bid
 	// serving is decided exclusively by the slot's frozen plan.
@@ -108,6 +108,8 @@ type Handler struct {
 	enabled        atomic.Bool
 	bidsRequested  atomic.Uint64 // count of getExecutionPayloadBid requests received
 	blocksAccepted atomic.Uint64 // count of accepted signed beacon blocks
+
+	bidHold atomic.Pointer[string] // SetBidHold; nil = bids served
 }
 
 // NewHandler creates a new post-Gloas Builder API dialect handler. cfg is the
@@ -116,7 +118,7 @@ type Handler struct {
 // (via Freeze) on every getExecutionPayloadBid request.
 func NewHandler(cfg *config.BuilderAPIConfig, log logrus.FieldLogger, chainSvc chain.Service,
 	planSvc *action_plan.PlanService, payloadCache *payload_builder.PayloadCache,
-	blsSigner *signer.BLSSigner) *Handler {
+	blsSigner signer.Signer) *Handler {
 	return &Handler{
 		cfg:          cfg,
 		log:          log.WithField("component", "builderapi-epbs"),
@@ -230,6 +232,12 @@ func (h *Handler) SetEventBroadcaster(b EventBroadcaster) {
 	h.events = b
 }
 
+// SetSigningAuditor validates and records the signing domain of every served
+// Gloas bid.
+func (h *Handler) SetSigningAuditor(auditor *payload_bidder.SigningAuditor) {
+	h.bidderSigner.SetAuditor(auditor)
+}
+
 // SetBuilderIndex sets the on-chain builder index inserted into Gloas bids.
 // Called from the lifecycle manager once registration is observed.
 func (h *Handler) SetBuilderIndex(index uint64) {
@@ -244,6 +252,17 @@ func (h *Handler) SetEnabled(enabled bool) {
 	h.enabled.Store(enabled)
 }
 
+// SetBidHold stops serving bids for the given reason until it is cleared with
+// an empty reason; unlike SetEnabled it also overrides the action plan.
+func (h *Handler) SetBidHold(reason string) {
+	if reason == "" {
+		h.bidHold.Store(nil)
+		return
+	}
+
+	h.bidHold.Store(&reason)
+}
+
 // BidsRequested returns the count of getExecutionPayloadBid requests received.
 func (h *Handler) BidsRequested() uint64 {
 	return h.bidsRequested.Load()
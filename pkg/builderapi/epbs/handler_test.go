@@ -32,6 +32,7 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
@@ -79,6 +80,7 @@ func (m *stubChainService) TimeToSlot(t time.Time) phase0.Slot {
 
 func (m *stubChainService) GetCurrentEpoch() phase0.Epoch { return 0 }
 func (m *stubChainService) GetCurrentSlot() phase0.Slot   { return m.currentSlot }
+func (m *stubChainService) Clock() clock.Clock            { return clock.Real() }
 
 func (m *stubChainService) GetCurrentFork() version.DataVersion { return m.currentFork }
 func (m *stubChainService) ActiveForkAtEpoch(phase0.Epoch) version.DataVersion {
@@ -94,8 +96,11 @@ func (m *stubChainService) GetCurrentEpochStats() *chain.EpochStats      { retur
 func (m *stubChainService) GetEpochStats(phase0.Epoch) *chain.EpochStats { return nil }
 
 func (m *stubChainService) SubscribeEpochStats() *utils.Subscription[*chain.EpochStats] { return nil }
-func (m *stubChainService) GetHeadVoteTracker() *chain.HeadVoteTracker                  { return nil }
-func (m *stubChainService) GetFinalizedEpoch() phase0.Epoch                             { return m.finalizedEpoch }
+func (m *stubChainService) SubscribeForkTransitions() *utils.Subscription[*chain.ForkTransition] {
+	return nil
+}
+func (m *stubChainService) GetHeadVoteTracker() *chain.HeadVoteTracker { return nil }
+func (m *stubChainService) GetFinalizedEpoch() phase0.Epoch            { return m.finalizedEpoch }
 
 func (m *stubChainService) GetBuilderByIndex(uint64) *chain.BuilderInfo { return nil }
 func (m *stubChainService) GetBuilderByPubkey(phase0.BLSPubKey) *chain.BuilderInfo {
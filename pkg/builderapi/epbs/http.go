@@ -8,13 +8,38 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	"github.com/ethpandaops/buildoor/pkg/builderapi/internal/slotwindow"
 )
 
-// writeError writes a JSON error response of the form {"code": ..., "message": ...}.
+// ErrorMessage is the builder-specs error response body. Stacktraces is
+// optional in the spec and never populated by this builder.
+type ErrorMessage struct {
+	Code        int      `json:"code"`
+	Message     string   `json:"message"`
+	Stacktraces []string `json:"stacktraces,omitempty"`
+}
+
+// Builder-specs error message prefixes. Relays and proposer tooling match on
+// them, so details are appended after a colon rather than rephrased.
+const (
+	errUnknownHash  = "Unknown hash"
+	errInvalidBlock = "Invalid block"
+	errSlotTooOld   = slotwindow.ErrSlotTooOld
+)
+
+// writeError writes a builder-specs ErrorMessage response.
 func writeError(w http.ResponseWriter, code int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
-	_ = json.NewEncoder(w).Encode(map[string]any{"code": code, "message": message})
+	_ = json.NewEncoder(w).Encode(&ErrorMessage{Code: code, Message: message})
+}
+
+// setConsensusVersion sets the Eth-Consensus-Version response header. It is
+// set on every response of a versioned endpoint — errors and 204s included —
+// as soon as the fork the request resolves to is known.
+func setConsensusVersion(w http.ResponseWriter, fork version.DataVersion) {
+	w.Header().Set("Eth-Consensus-Version", fork.String())
 }
 
 // preferSSZ reports whether an Accept header prefers the SSZ
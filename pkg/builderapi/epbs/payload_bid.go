@@ -18,6 +18,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/builderapi/internal/slotwindow"
 	"github.com/ethpandaops/buildoor/pkg/chain"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
@@ -38,9 +39,11 @@ type GetExecutionPayloadBidResponse struct {
 // SignedExecutionPayloadBid using the proposer's fee recipient from the
 // ProposerPreferences cache. The fork version — for the bid signing domain,
 // the response envelope, and the Eth-Consensus-Version header — is the fork
-// active at the requested slot's epoch. Returns 204 if no payload is cached,
-// 400 if the inputs do not match the cached payload or proposer preferences
-// are missing, and 503 while the requested slot is pre-Gloas.
+// active at the requested slot's epoch; the header is set on every response
+// once the slot is parsed. Returns 204 if no payload is cached, 400 for a past
+// slot, inputs that do not match the cached payload (builder-specs "Unknown
+// hash") or missing proposer preferences, and 503 while the requested slot is
+// pre-Gloas.
 //
 // If the request body contains a SignedRequestAuthV1, it is validated:
 //   - auth.message.slot must match the requested slot
@@ -61,6 +64,12 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if hold := h.bidHold.Load(); hold != nil {
+		log.WithField("reason", *hold).Warn("getExecutionPayloadBid: returning 204 — bidding held")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	if h.propPrefsStore == nil {
 		log.Warn("getExecutionPayloadBid: proposer preferences store not configured")
 		writeError(w, http.StatusInternalServerError, "proposer preferences store not configured")
@@ -98,12 +107,21 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 	}
 	slot := phase0.Slot(slotU64)
 
+	// The bid is for a specific slot, so resolve the fork active at that
+	// slot's epoch — bids are requested ahead of the slot and may cross a
+	// fork boundary.
+	fork := h.chainSvc.ActiveForkAtEpoch(h.chainSvc.GetEpochOfSlot(slot))
+	setConsensusVersion(w, fork)
+
 	// Bound the request horizon BEFORE freezing the slot's plan: proposers
 	// only ever request the current or next slot, and freezing arbitrary
 	// far-future slots would permanently lock their plans against edits.
-	if currentSlot := h.chainSvc.GetCurrentSlot(); slot > currentSlot+1 {
-		log.WithField("current_slot", currentSlot).Warn("getExecutionPayloadBid: slot too far ahead")
-		writeError(w, http.StatusBadRequest, "slot too far ahead: max current slot + 1")
+	// Past slots are rejected likewise: their proposal window has closed.
+	// The configured slot tolerance widens the window for clock skew.
+	currentSlot := h.chainSvc.GetCurrentSlot()
+	if msg := slotwindow.Check(slot, currentSlot, h.cfg.SlotTolerance); msg != "" {
+		log.WithField("current_slot", currentSlot).Warn("getExecutionPayloadBid: slot outside request window")
+		writeError(w, http.StatusBadRequest, msg)
 
 		return
 	}
@@ -114,8 +132,7 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 	frozenSettings, serve := h.frozenBuilderAPISettings(slot)
 	if !serve {
 		log.Info("getExecutionPayloadBid: returning 204 — bid serving suppressed (plan or global disable)")
-		h.recordBid(slot, h.chainSvc.ActiveForkAtEpoch(h.chainSvc.GetEpochOfSlot(slot)).String(),
-			"", nil, 0, 0, bidStatusSuppressed, "")
+		h.recordBid(slot, fork.String(), "", nil, 0, 0, bidStatusSuppressed, "")
 		w.WriteHeader(http.StatusNoContent)
 
 		return
@@ -127,12 +144,9 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 		h.events.BroadcastBuilderAPIGetBidReceived(slotU64, parentHashStr, proposerPubkeyStr)
 	}
 
-	// The bid is for a specific slot, so resolve the fork active at that
-	// slot's epoch — bids are requested ahead of the slot and may cross a
-	// fork boundary. The post-Gloas dialect only serves Gloas+ slots; reject
-	// earlier slots cleanly instead of leaking an internal error from the
-	// fork-version lookup below.
-	fork := h.chainSvc.ActiveForkAtEpoch(h.chainSvc.GetEpochOfSlot(slot))
+	// The post-Gloas dialect only serves Gloas+ slots; reject earlier slots
+	// cleanly instead of leaking an internal error from the fork-version
+	// lookup below.
 	if fork < version.DataVersionGloas {
 		log.WithField("fork", fork.String()).Warn(
 			"getExecutionPayloadBid: 503 — post-Gloas Builder API dialect not available pre-Gloas")
@@ -237,7 +251,13 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 	var parentRoot phase0.Root
 	copy(parentRoot[:], parentRootBytes)
 
-	event := h.payloadCache.Get(slot)
+	// The payload built on the requested parent: the slot's latest, or a
+	// candidate / superseded payload when the chain is unstable
+	// (--builder-api-parent-candidates, payload_attributes changes).
+	event := h.payloadCache.GetByParent(slot, parentHash)
+	if event == nil {
+		event = h.payloadCache.Get(slot)
+	}
 	if event == nil {
 		log.Info("getExecutionPayloadBid: returning 204 — no cached payload for slot")
 		h.recordBid(slot, fork.String(), "", nil, 0, 0, bidStatusFailed, "no cached payload for slot")
@@ -299,6 +319,10 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 		valueAfterSubsidy = phase0.Gwei(*frozenSettings.TotalValueGwei)
 	}
 
+	// A test-only value boost inflates the reported total value, decoupling
+	// it from the payload's (the slot result marks the bid as boosted).
+	valueAfterSubsidy = phase0.Gwei(frozenSettings.BoostGwei(uint64(valueAfterSubsidy)))
+
 	maxExecutionPayment := h.prefsStore.GetOrDefault(proposerPubkey)
 	executionPayment := min(valueAfterSubsidy, maxExecutionPayment)
 	value := valueAfterSubsidy - executionPayment
@@ -342,6 +366,7 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 		"fee_recipient": prefs.FeeRecipient.String(),
 		"gas_limit":     signedBid.Message.GasLimit,
 		"fork":          fork.String(),
+		"value_boost":   frozenSettings.ValueBoostPct,
 	}).Info("getExecutionPayloadBid: delivered SignedExecutionPayloadBid")
 
 	if h.events != nil {
@@ -360,7 +385,7 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 	// the bid response; a proposer hangup during the wait cancels the serve.
 	if frozenSettings.DelayMs > 0 {
 		select {
-		case <-time.After(time.Duration(frozenSettings.DelayMs) * time.Millisecond):
+		case <-h.chainSvc.Clock().After(time.Duration(frozenSettings.DelayMs) * time.Millisecond):
 		case <-r.Context().Done():
 			log.WithField("delay_ms", frozenSettings.DelayMs).Info(
 				"getExecutionPayloadBid: request cancelled during planned response delay")
@@ -371,8 +396,6 @@ func (h *Handler) HandleGetExecutionPayloadBid(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	w.Header().Set("Eth-Consensus-Version", fork.String())
-
 	// Per builder-specs the response may be SSZ; the proposer opts in via the
 	// Accept header.
 	if preferSSZ(r.Header.Get("Accept")) {
@@ -320,6 +320,25 @@ func TestHandleGetExecutionPayloadBid_PlanEffectiveEnable(t *testing.T) {
 	}
 }
 
+// TestHandleGetExecutionPayloadBid_BidHold verifies a bid hold suppresses
+// bids even for a force-serving plan until it is cleared.
+func TestHandleGetExecutionPayloadBid_BidHold(t *testing.T) {
+	env := newPayloadBidTestEnv(t, true)
+	applyBuilderAPIPlan(t, env.planSvc, 1, `{"mode":"custom"}`)
+
+	env.handler.SetBidHold("builder equivocation")
+
+	rec := httptest.NewRecorder()
+	env.handler.HandleGetExecutionPayloadBid(rec, newPayloadBidRequest())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	env.handler.SetBidHold("")
+
+	rec = httptest.NewRecorder()
+	env.handler.HandleGetExecutionPayloadBid(rec, newPayloadBidRequest())
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
 // TestHandleGetExecutionPayloadBid_PlanValueResolution verifies the frozen
 // per-slot value settings: the absolute total replaces blockValue+subsidy
 // BEFORE the execution-payment split, and a per-slot subsidy override is
@@ -398,7 +417,9 @@ func TestHandleGetExecutionPayloadBid_PlanResponseDelay(t *testing.T) {
 
 		assert.Less(t, time.Since(start), 2*time.Second, "cancelled delay must not be waited out")
 		assert.Zero(t, rec.Body.Len(), "no response body may be written after cancellation")
-		assert.Empty(t, rec.Header().Get("Eth-Consensus-Version"), "no response headers may be set")
+		assert.Empty(t, rec.Header().Get("Content-Type"), "no response may be written")
+		assert.Equal(t, "gloas", rec.Header().Get("Eth-Consensus-Version"),
+			"the fork header is staged as soon as the requested slot's fork is resolved")
 
 		calls := env.recorder.bidCalls()
 		require.Len(t, calls, 1)
@@ -515,12 +536,15 @@ func TestHandleSubmitBeaconBlock_RecordsSubmissions(t *testing.T) {
 
 // TestHandleGetExecutionPayloadBid_SlotHorizonBound verifies far-ahead
 // requests are rejected with 400 BEFORE the slot's plan is frozen, so
-// arbitrary clients cannot lock future plans against edits.
+// arbitrary clients cannot lock future plans against edits. Slots widened in
+// by the slot tolerance pass the window and freeze; they answer 204 only
+// because the env holds proposer preferences for slot 1 alone.
 func TestHandleGetExecutionPayloadBid_SlotHorizonBound(t *testing.T) {
 	tests := []struct {
 		name        string
 		currentSlot phase0.Slot
 		requestSlot uint64
+		tolerance   uint64
 		wantCode    int
 		wantFrozen  bool
 	}{
@@ -539,12 +563,33 @@ func TestHandleGetExecutionPayloadBid_SlotHorizonBound(t *testing.T) {
 			currentSlot: 1, requestSlot: 1_000_000,
 			wantCode: http.StatusBadRequest, wantFrozen: false,
 		},
+		{
+			name:        "past slot is rejected without freezing",
+			currentSlot: 5, requestSlot: 4,
+			wantCode: http.StatusBadRequest, wantFrozen: false,
+		},
+		{
+			name:        "past slot within tolerance passes the window",
+			currentSlot: 5, requestSlot: 4, tolerance: 1,
+			wantCode: http.StatusNoContent, wantFrozen: true,
+		},
+		{
+			name:        "past slot beyond tolerance is rejected",
+			currentSlot: 5, requestSlot: 3, tolerance: 1,
+			wantCode: http.StatusBadRequest, wantFrozen: false,
+		},
+		{
+			name:        "two slots ahead within tolerance passes the window",
+			currentSlot: 1, requestSlot: 3, tolerance: 1,
+			wantCode: http.StatusNoContent, wantFrozen: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			env := newPayloadBidTestEnv(t, true)
 			env.chainSvc.currentSlot = tt.currentSlot
+			env.cfg.BuilderAPI.SlotTolerance = tt.tolerance
 
 			rec := httptest.NewRecorder()
 			env.handler.HandleGetExecutionPayloadBid(rec, newPayloadBidRequestForSlot(tt.requestSlot))
@@ -0,0 +1,30 @@
+// Package slotwindow holds the request slot window shared by the legacy and
+// post-Gloas Builder API dialects.
+package slotwindow
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// ErrSlotTooOld is the builder-specs error message prefix for requests and
+// submissions of a past slot.
+const ErrSlotTooOld = "Slot too old"
+
+// Check validates a requested slot against the wall-clock current slot.
+// Accepted are the current and the next slot, widened by tolerance slots in
+// both directions; bids for older slots would be stale and far-future ones
+// lock plans. Returns the error message for slots outside the window, ""
+// otherwise.
+func Check(slot, currentSlot phase0.Slot, tolerance uint64) string {
+	if slot > currentSlot+1+phase0.Slot(tolerance) {
+		return fmt.Sprintf("slot too far ahead: slot %d, current slot %d", slot, currentSlot)
+	}
+
+	if slot+phase0.Slot(tolerance) < currentSlot {
+		return fmt.Sprintf("%s: slot %d, current slot %d", ErrSlotTooOld, slot, currentSlot)
+	}
+
+	return ""
+}
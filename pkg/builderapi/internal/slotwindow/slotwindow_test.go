@@ -0,0 +1,32 @@
+package slotwindow
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		slot        phase0.Slot
+		currentSlot phase0.Slot
+		tolerance   uint64
+		want        string
+	}{
+		{name: "current slot", slot: 10, currentSlot: 10},
+		{name: "next slot", slot: 11, currentSlot: 10},
+		{name: "two ahead", slot: 12, currentSlot: 10, want: "slot too far ahead: slot 12, current slot 10"},
+		{name: "two ahead within tolerance", slot: 12, currentSlot: 10, tolerance: 1},
+		{name: "past slot", slot: 9, currentSlot: 10, want: "Slot too old: slot 9, current slot 10"},
+		{name: "past slot within tolerance", slot: 9, currentSlot: 10, tolerance: 1},
+		{name: "genesis", slot: 0, currentSlot: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Check(tt.slot, tt.currentSlot, tt.tolerance))
+		})
+	}
+}
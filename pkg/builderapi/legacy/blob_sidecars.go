@@ -0,0 +1,96 @@
+package legacy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	dynssz "github.com/pk910/dynamic-ssz"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// BlobSidecarPublisher is implemented by publish nodes (*beacon.Client) that
+// can report a block's blobs and accept them via the separate blob sidecar
+// endpoint. Nodes without it only get the block publication.
+type BlobSidecarPublisher interface {
+	GetBlobCount(ctx context.Context, blockID string) (int, error)
+	SubmitBlobSidecars(ctx context.Context, fork version.DataVersion, blockRoot phase0.Root,
+		commitments []deneb.KZGCommitment, blobs []deneb.Blob, proofs []deneb.KZGProof) error
+}
+
+var _ BlobSidecarPublisher = (*beacon.Client)(nil)
+
+// blobSidecars are the blobs of a published Fulu block, for the separate
+// sidecar publication.
+type blobSidecars struct {
+	fork        version.DataVersion
+	blockRoot   phase0.Root
+	commitments []deneb.KZGCommitment
+	blobs       []deneb.Blob
+	proofs      []deneb.KZGProof // cell proofs
+}
+
+// newBlobSidecars returns the sidecars of the unblinded block contents, or
+// nil when there is nothing to publish separately (pre-Fulu, no blobs, or the
+// mode is never).
+func newBlobSidecars(mode string, contents *apiv1all.SignedBlockContents) (*blobSidecars, error) {
+	if mode == config.BlobSidecarsNever || contents.Version < version.DataVersionFulu || len(contents.Blobs) == 0 {
+		return nil, nil
+	}
+
+	blockRoot, err := dynssz.GetGlobalDynSsz().HashTreeRoot(contents.SignedBlock.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute block root: %w", err)
+	}
+
+	return &blobSidecars{
+		fork:        contents.Version,
+		blockRoot:   blockRoot,
+		commitments: contents.SignedBlock.Message.Body.BlobKZGCommitments,
+		blobs:       contents.Blobs,
+		proofs:      contents.KZGProofs,
+	}, nil
+}
+
+// publishBlobSidecars publishes the block's blob sidecars to a node that
+// accepted the block. In auto mode the node is asked for the block's blobs
+// first and only gets the sidecars when it does not hold all of them.
+func (h *Handler) publishBlobSidecars(ctx context.Context, log logrus.FieldLogger,
+	node ProposalSubmitter, sidecars *blobSidecars) {
+	publisher, ok := node.(BlobSidecarPublisher)
+	if !ok {
+		return
+	}
+
+	blockID := fmt.Sprintf("%#x", sidecars.blockRoot[:])
+
+	if h.cfg.NormalizedBlobSidecars() == config.BlobSidecarsAuto {
+		held, err := publisher.GetBlobCount(ctx, blockID)
+		if err != nil {
+			log.WithError(err).Debug("submitBlindedBlock: blob detection failed, publishing sidecars")
+		} else if held >= len(sidecars.blobs) {
+			return
+		}
+	}
+
+	start := time.Now()
+
+	err := publisher.SubmitBlobSidecars(ctx, sidecars.fork, sidecars.blockRoot,
+		sidecars.commitments, sidecars.blobs, sidecars.proofs)
+	if err != nil {
+		log.WithError(err).Warn("submitBlindedBlock: failed to publish blob sidecars")
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"blobs":      len(sidecars.blobs),
+		"latency_ms": time.Since(start).Milliseconds(),
+	}).Info("submitBlindedBlock: published blob sidecars")
+}
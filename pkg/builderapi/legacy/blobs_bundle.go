@@ -0,0 +1,237 @@
+package legacy
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+// ErrInvalidBlobsBundle is returned when a payload's blobs bundle does not
+// match its blob transactions or its KZG proofs do not verify. Serving such a
+// header would get the block rejected (or silently filtered) downstream.
+var ErrInvalidBlobsBundle = errors.New("invalid blobs bundle")
+
+// bundleVerdictSlots is how many slots behind the newest validated slot
+// bundle verdicts are kept (mirrors headerCacheSlots).
+const bundleVerdictSlots = 2
+
+// ValidateBlobsBundle checks the blobs bundle served with a payload for fork:
+// one commitment per versioned hash of the payload's blob transactions (same
+// order), one blob per commitment, one KZG proof per blob (one cell proof per
+// cell from Fulu onwards), and valid proofs. Pre-Deneb payloads carry no blobs.
+// A Fulu bundle may still carry blob proofs (ELs without cell proof support);
+// its cell proofs are recomputed on publication (see CellProofs).
+func ValidateBlobsBundle(fork version.DataVersion, payload *eth2all.ExecutionPayload,
+	bundle *payload_builder.BlobsBundle) error {
+	if fork < version.DataVersionDeneb || payload == nil {
+		return nil
+	}
+
+	versionedHashes, err := blobVersionedHashes(payload)
+	if err != nil {
+		return err
+	}
+
+	if bundle == nil {
+		bundle = &payload_builder.BlobsBundle{}
+	}
+
+	if len(bundle.Commitments) != len(versionedHashes) {
+		return fmt.Errorf("%w: %d commitments for %d blob versioned hashes in the payload",
+			ErrInvalidBlobsBundle, len(bundle.Commitments), len(versionedHashes))
+	}
+
+	if len(bundle.Blobs) != len(bundle.Commitments) {
+		return fmt.Errorf("%w: %d blobs for %d commitments",
+			ErrInvalidBlobsBundle, len(bundle.Blobs), len(bundle.Commitments))
+	}
+
+	proofsPerBlob := 1
+	if fork >= version.DataVersionFulu && !hasBlobProofs(bundle) {
+		proofsPerBlob = kzg4844.CellProofsPerBlob
+	}
+
+	if len(bundle.Proofs) != len(bundle.Blobs)*proofsPerBlob {
+		return fmt.Errorf("%w: %d proofs for %d blobs (%d per blob)",
+			ErrInvalidBlobsBundle, len(bundle.Proofs), len(bundle.Blobs), proofsPerBlob)
+	}
+
+	if len(bundle.Blobs) == 0 {
+		return nil
+	}
+
+	hasher := sha256.New()
+	commitments := make([]kzg4844.Commitment, len(bundle.Commitments))
+
+	for i := range bundle.Commitments {
+		commitments[i] = kzg4844.Commitment(bundle.Commitments[i])
+		hasher.Reset()
+
+		if common.Hash(kzg4844.CalcBlobHashV1(hasher, &commitments[i])) != versionedHashes[i] {
+			return fmt.Errorf("%w: commitment %d does not match the payload's versioned hash %#x",
+				ErrInvalidBlobsBundle, i, versionedHashes[i][:])
+		}
+	}
+
+	blobs := make([]kzg4844.Blob, len(bundle.Blobs))
+	for i := range bundle.Blobs {
+		blobs[i] = kzg4844.Blob(bundle.Blobs[i])
+	}
+
+	proofs := make([]kzg4844.Proof, len(bundle.Proofs))
+	for i := range bundle.Proofs {
+		proofs[i] = kzg4844.Proof(bundle.Proofs[i])
+	}
+
+	if proofsPerBlob == kzg4844.CellProofsPerBlob {
+		if err := kzg4844.VerifyCellProofs(blobs, commitments, proofs); err != nil {
+			return fmt.Errorf("%w: cell proofs do not verify: %v", ErrInvalidBlobsBundle, err)
+		}
+
+		return nil
+	}
+
+	for i := range blobs {
+		if err := kzg4844.VerifyBlobProof(&blobs[i], commitments[i], proofs[i]); err != nil {
+			return fmt.Errorf("%w: proof of blob %d does not verify: %v", ErrInvalidBlobsBundle, i, err)
+		}
+	}
+
+	return nil
+}
+
+// hasBlobProofs reports whether the bundle carries one blob proof per blob
+// rather than cell proofs.
+func hasBlobProofs(bundle *payload_builder.BlobsBundle) bool {
+	return len(bundle.Blobs) > 0 && len(bundle.Proofs) == len(bundle.Blobs)
+}
+
+// CellProofs returns the bundle's KZG cell proofs (CellProofsPerBlob per
+// blob, blob-major), recomputing them from the blobs when the bundle carries
+// blob proofs instead. The bundle itself is not modified.
+func CellProofs(bundle *payload_builder.BlobsBundle) ([]deneb.KZGProof, error) {
+	if bundle == nil {
+		return nil, nil
+	}
+
+	if !hasBlobProofs(bundle) {
+		return bundle.Proofs, nil
+	}
+
+	proofs := make([]deneb.KZGProof, 0, len(bundle.Blobs)*kzg4844.CellProofsPerBlob)
+
+	for i := range bundle.Blobs {
+		blob := kzg4844.Blob(bundle.Blobs[i])
+
+		cellProofs, err := kzg4844.ComputeCellProofs(&blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute cell proofs of blob %d: %w", i, err)
+		}
+
+		for _, proof := range cellProofs {
+			proofs = append(proofs, deneb.KZGProof(proof))
+		}
+	}
+
+	return proofs, nil
+}
+
+// blobVersionedHashes returns the blob versioned hashes of the payload's blob
+// transactions in block order. Only blob transactions are decoded.
+func blobVersionedHashes(payload *eth2all.ExecutionPayload) ([]common.Hash, error) {
+	var hashes []common.Hash
+
+	for i, txBytes := range payload.Transactions {
+		if len(txBytes) == 0 || txBytes[0] != types.BlobTxType {
+			continue
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode blob transaction %d: %v", ErrInvalidBlobsBundle, i, err)
+		}
+
+		hashes = append(hashes, tx.BlobHashes()...)
+	}
+
+	return hashes, nil
+}
+
+// checkBlindedCommitments checks that the proposer signed the commitments of
+// the bundle the payload is unblinded with.
+func checkBlindedCommitments(blinded []deneb.KZGCommitment, bundle *payload_builder.BlobsBundle) error {
+	var commitments []deneb.KZGCommitment
+	if bundle != nil {
+		commitments = bundle.Commitments
+	}
+
+	if len(blinded) != len(commitments) {
+		return fmt.Errorf("%w: blinded block commits to %d blobs, payload bundle has %d",
+			ErrInvalidBlobsBundle, len(blinded), len(commitments))
+	}
+
+	for i := range blinded {
+		if blinded[i] != commitments[i] {
+			return fmt.Errorf("%w: blinded block commitment %d does not match the payload bundle",
+				ErrInvalidBlobsBundle, i)
+		}
+	}
+
+	return nil
+}
+
+// bundleVerdict is the validation outcome of one payload's blobs bundle.
+type bundleVerdict struct {
+	slot phase0.Slot
+	err  error
+}
+
+// bundleVerdicts memoizes ValidateBlobsBundle per cached payload: proof
+// verification is far too slow to repeat on every getHeader poll.
+type bundleVerdicts struct {
+	mu       sync.Mutex
+	verdicts map[*payload_builder.Payload]bundleVerdict
+}
+
+func newBundleVerdicts() *bundleVerdicts {
+	return &bundleVerdicts{verdicts: make(map[*payload_builder.Payload]bundleVerdict, 4)}
+}
+
+// validate returns the (memoized) validation outcome of the payload's bundle.
+func (v *bundleVerdicts) validate(slot phase0.Slot, fork version.DataVersion, event *payload_builder.Payload) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if verdict, ok := v.verdicts[event]; ok {
+		return verdict.err
+	}
+
+	err := ValidateBlobsBundle(fork, event.ExecutionPayload, event.BlobsBundle)
+
+	for payload, verdict := range v.verdicts {
+		if verdict.slot+bundleVerdictSlots < slot {
+			delete(v.verdicts, payload)
+		}
+	}
+
+	v.verdicts[event] = bundleVerdict{slot: slot, err: err}
+
+	return err
+}
+
+// WarmUpKZG loads the KZG trusted setup (seconds of work), so the first
+// getHeader with blobs does not pay for it within the proposer's deadline.
+func WarmUpKZG() {
+	_, _ = kzg4844.BlobToCommitment(&kzg4844.Blob{})
+}
@@ -0,0 +1,130 @@
+package legacy
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+// testBlobPayload returns a payload with one blob transaction and its blobs
+// bundle (Deneb blob proofs, or Fulu cell proofs).
+func testBlobPayload(t *testing.T, fork version.DataVersion) (*eth2all.ExecutionPayload, *payload_builder.BlobsBundle) {
+	t.Helper()
+
+	var blob kzg4844.Blob
+	for i := 0; i < 16; i++ {
+		blob[i*32+31] = byte(i + 1) // canonical field elements
+	}
+
+	commitment, err := kzg4844.BlobToCommitment(&blob)
+	require.NoError(t, err)
+
+	bundle := &payload_builder.BlobsBundle{
+		Commitments: []deneb.KZGCommitment{deneb.KZGCommitment(commitment)},
+		Blobs:       []deneb.Blob{deneb.Blob(blob)},
+	}
+
+	if fork >= version.DataVersionFulu {
+		proofs, err := kzg4844.ComputeCellProofs(&blob)
+		require.NoError(t, err)
+
+		for _, proof := range proofs {
+			bundle.Proofs = append(bundle.Proofs, deneb.KZGProof(proof))
+		}
+	} else {
+		proof, err := kzg4844.ComputeBlobProof(&blob, commitment)
+		require.NoError(t, err)
+
+		bundle.Proofs = []deneb.KZGProof{deneb.KZGProof(proof)}
+	}
+
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Gas:        21000,
+		GasFeeCap:  uint256.NewInt(1),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: []common.Hash{kzg4844.CalcBlobHashV1(sha256.New(), &commitment)},
+	})
+
+	txBytes, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	payload := &eth2all.ExecutionPayload{
+		Version:      fork,
+		Transactions: []bellatrix.Transaction{{0x02, 0xc0}, txBytes},
+	}
+
+	return payload, bundle
+}
+
+func TestValidateBlobsBundle(t *testing.T) {
+	for _, fork := range []version.DataVersion{version.DataVersionElectra, version.DataVersionFulu} {
+		t.Run(fork.String(), func(t *testing.T) {
+			payload, bundle := testBlobPayload(t, fork)
+			require.NoError(t, ValidateBlobsBundle(fork, payload, bundle))
+
+			require.NoError(t, ValidateBlobsBundle(version.DataVersionCapella, payload, nil),
+				"pre-Deneb payloads carry no blobs")
+
+			err := ValidateBlobsBundle(fork, payload, nil)
+			require.ErrorIs(t, err, ErrInvalidBlobsBundle)
+			require.ErrorContains(t, err, "0 commitments for 1 blob versioned hashes")
+
+			short := *bundle
+			short.Proofs = short.Proofs[1:]
+			require.ErrorContains(t, ValidateBlobsBundle(fork, payload, &short), "proofs for 1 blobs")
+
+			tampered := *bundle
+			tampered.Blobs = []deneb.Blob{{}}
+			require.ErrorIs(t, ValidateBlobsBundle(fork, payload, &tampered), ErrInvalidBlobsBundle,
+				"proofs of another blob do not verify")
+
+			otherHash := *payload
+			otherHash.Transactions = otherHash.Transactions[:1]
+			require.ErrorContains(t, ValidateBlobsBundle(fork, &otherHash, bundle),
+				"1 commitments for 0 blob versioned hashes")
+		})
+	}
+}
+
+func TestCheckBlindedCommitments(t *testing.T) {
+	bundle := &payload_builder.BlobsBundle{Commitments: []deneb.KZGCommitment{{0x01}, {0x02}}}
+
+	require.NoError(t, checkBlindedCommitments([]deneb.KZGCommitment{{0x01}, {0x02}}, bundle))
+	require.NoError(t, checkBlindedCommitments(nil, nil))
+	require.ErrorIs(t, checkBlindedCommitments([]deneb.KZGCommitment{{0x01}}, bundle), ErrInvalidBlobsBundle)
+	require.ErrorIs(t, checkBlindedCommitments([]deneb.KZGCommitment{{0x02}, {0x01}}, bundle), ErrInvalidBlobsBundle)
+}
+
+func TestCellProofs(t *testing.T) {
+	payload, cellBundle := testBlobPayload(t, version.DataVersionFulu)
+	_, blobBundle := testBlobPayload(t, version.DataVersionElectra)
+
+	proofs, err := CellProofs(cellBundle)
+	require.NoError(t, err)
+	require.Equal(t, cellBundle.Proofs, proofs, "cell proofs are used as-is")
+
+	// A Fulu bundle with blob proofs (EL without cell proof support) is
+	// servable and gets its cell proofs recomputed on publication.
+	require.NoError(t, ValidateBlobsBundle(version.DataVersionFulu, payload, blobBundle))
+
+	proofs, err = CellProofs(blobBundle)
+	require.NoError(t, err)
+	require.Equal(t, cellBundle.Proofs, proofs)
+	require.Len(t, blobBundle.Proofs, 1, "the bundle itself is not modified")
+
+	proofs, err = CellProofs(nil)
+	require.NoError(t, err)
+	require.Empty(t, proofs)
+}
@@ -7,19 +7,42 @@ import (
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
 	"github.com/holiman/uint256"
+	dynssz "github.com/pk910/dynamic-ssz"
 	"github.com/pk910/dynamic-ssz/hasher"
 	"github.com/pk910/dynamic-ssz/sszutils"
 
 	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/signer"
 )
 
+// defaultMaxWithdrawalsPerPayload is the mainnet MAX_WITHDRAWALS_PER_PAYLOAD,
+// used when the dynssz spec does not carry the value.
 const defaultMaxWithdrawalsPerPayload = 16
 
-// BidSigner signs a BuilderBid and returns the signature.
-type BidSigner interface {
-	SignWithDomain(root phase0.Root, domain phase0.Domain) (phase0.BLSSignature, error)
+// auditedBidSigner checks a header's signing domain with the signing auditor
+// before signing it. BuildSignedBuilderBid derives the domain from
+// DOMAIN_APPLICATION_BUILDER, the genesis fork version it is given and a zero
+// root; the auditor verifies those inputs and that the domain matches them.
+type auditedBidSigner struct {
+	signer.Signer
+	auditor            *payload_bidder.SigningAuditor
+	slot               phase0.Slot
+	genesisForkVersion phase0.Version
+}
+
+func (s *auditedBidSigner) SignWithDomain(root phase0.Root, domain phase0.Domain) (phase0.BLSSignature, error) {
+	err := s.auditor.Check(payload_bidder.SigningArtifactHeader, s.slot, root, signer.SigningDomain{
+		Type:        signer.DomainApplicationBuilder,
+		ForkVersion: s.genesisForkVersion,
+		Domain:      domain,
+	})
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	return s.Signer.SignWithDomain(root, domain)
 }
 
 // gweiFactor converts gwei to wei; the multiplication is done in uint256 so
@@ -34,21 +57,23 @@ var gweiFactor = uint256.NewInt(1_000_000_000)
 // subsidyGwei is added to the bid value so the proposer sees a higher bid (e.g. for testing).
 // totalValueGwei, when non-nil, is the absolute total bid value in gwei and replaces
 // blockValue+subsidy entirely (it may exceed the block value, e.g. for payment-edge testing).
+// valueBoostPct, when non-zero, reports the resulting value at this percentage of itself
+// (test-only value boosting, decoupled from the payload value).
 func BuildSignedBuilderBid(
 	event *payload_builder.Payload,
 	fork version.DataVersion,
 	proposerPubkey phase0.BLSPubKey,
-	blsSigner BidSigner,
+	blsSigner signer.Signer,
 	subsidyGwei uint64,
 	totalValueGwei *uint64,
+	valueBoostPct uint64,
 	genesisForkVersion phase0.Version,
-	maxWithdrawalsPerPayload uint64,
 ) (*legacytypes.SignedBuilderBid, error) {
 	if event == nil || event.ExecutionPayload == nil {
 		return nil, nil
 	}
 
-	header, err := ExecutionPayloadHeaderFromBeacon(event.ExecutionPayload, fork, maxWithdrawalsPerPayload)
+	header, err := ExecutionPayloadHeaderFromBeacon(event.ExecutionPayload, fork)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +96,11 @@ func BuildSignedBuilderBid(
 		}
 	}
 
+	if valueBoostPct > 0 {
+		value.Mul(value, uint256.NewInt(valueBoostPct))
+		value.Div(value, uint256.NewInt(100))
+	}
+
 	bid := &legacytypes.BuilderBid{
 		Version: fork,
 		Header:  header,
@@ -124,11 +154,10 @@ func BuildSignedBuilderBid(
 // ExecutionPayloadHeaderFromBeacon builds a fork-agnostic execution payload
 // header, pinned to the given fork, from the fork-agnostic beacon execution
 // payload. Used to construct BuilderBids for getHeader responses (Bellatrix
-// onwards).
+// onwards). Preset-dependent list limits resolve from the global dynssz spec.
 func ExecutionPayloadHeaderFromBeacon(
 	p *eth2all.ExecutionPayload,
 	fork version.DataVersion,
-	maxWithdrawalsPerPayload uint64,
 ) (*eth2all.ExecutionPayloadHeader, error) {
 	if p == nil {
 		return nil, nil
@@ -190,7 +219,7 @@ func ExecutionPayloadHeaderFromBeacon(
 	// Withdrawals exist from Capella onwards; the Bellatrix header view has
 	// no withdrawals root.
 	if fork >= version.DataVersionCapella {
-		withdrawalsRoot, err := withdrawalsRoot(p.Withdrawals, maxWithdrawalsPerPayload)
+		withdrawalsRoot, err := withdrawalsRoot(p.Withdrawals)
 		if err != nil {
 			return nil, err
 		}
@@ -203,7 +232,7 @@ func ExecutionPayloadHeaderFromBeacon(
 // UnblindSignedBlindedBeaconBlock builds full SignedBlockContents from a
 // fork-agnostic blinded block and the matching Payload (full payload +
 // blobs). The proposer signature is preserved and the returned contents
-// carry the blinded block's fork version.
+// carry the blinded block's fork version (and cell proofs from Fulu onwards).
 func UnblindSignedBlindedBeaconBlock(
 	blinded *apiv1all.SignedBlindedBeaconBlock,
 	event *payload_builder.Payload,
@@ -232,9 +261,12 @@ func UnblindSignedBlindedBeaconBlock(
 		ExecutionRequests:     blindedBody.ExecutionRequests,
 	}
 
-	// Copy BlobKZGCommitments from event if we have blobs (must match blinded commitments).
-	if event.BlobsBundle != nil && len(event.BlobsBundle.Commitments) > 0 {
-		fullBody.BlobKZGCommitments = event.BlobsBundle.Commitments
+	// The proposer signed the blinded commitments: the bundle published with
+	// the block must carry exactly those.
+	if blinded.Version >= version.DataVersionDeneb {
+		if err := checkBlindedCommitments(blindedBody.BlobKZGCommitments, event.BlobsBundle); err != nil {
+			return nil, err
+		}
 	}
 
 	fullBlock := &eth2all.BeaconBlock{
@@ -257,6 +289,17 @@ func UnblindSignedBlindedBeaconBlock(
 	if event.BlobsBundle != nil {
 		contents.KZGProofs = event.BlobsBundle.Proofs
 		contents.Blobs = event.BlobsBundle.Blobs
+
+		// Fulu block contents carry cell proofs; a bundle from an EL without
+		// cell proof support gets them recomputed here.
+		if blinded.Version >= version.DataVersionFulu {
+			proofs, err := CellProofs(event.BlobsBundle)
+			if err != nil {
+				return nil, err
+			}
+
+			contents.KZGProofs = proofs
+		}
 	}
 
 	return contents, nil
@@ -267,13 +310,22 @@ func transactionsRoot(txs [][]byte) ([32]byte, error) {
 	return merkleizeByteLists(txs, 1048576, 1073741824)
 }
 
-// withdrawalsRoot computes the SSZ hash tree root of the withdrawals list.
-func withdrawalsRoot(list []*capella.Withdrawal, maxWithdrawalsPerPayload uint64) ([32]byte, error) {
-	if maxWithdrawalsPerPayload == 0 {
-		maxWithdrawalsPerPayload = defaultMaxWithdrawalsPerPayload
+// withdrawalsRoot computes the SSZ hash tree root of the withdrawals list. The
+// list limit is MAX_WITHDRAWALS_PER_PAYLOAD from the global dynssz spec (4 on
+// the minimal preset, 16 on mainnet).
+func withdrawalsRoot(list []*capella.Withdrawal) ([32]byte, error) {
+	maxWithdrawalsPerPayload := uint64(defaultMaxWithdrawalsPerPayload)
+
+	resolved, value, err := dynssz.GetGlobalDynSsz().ResolveSpecValue("MAX_WITHDRAWALS_PER_PAYLOAD")
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if resolved && value > 0 {
+		maxWithdrawalsPerPayload = value
 	}
+
 	var root [32]byte
-	err := hasher.WithDefaultHasher(func(hh sszutils.HashWalker) error {
+	err = hasher.WithDefaultHasher(func(hh sszutils.HashWalker) error {
 		idx := hh.Index()
 		for _, w := range list {
 			if err := w.HashTreeRootWith(hh); err != nil {
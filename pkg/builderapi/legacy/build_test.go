@@ -8,6 +8,7 @@ import (
 	"github.com/ethpandaops/go-eth2-client/spec/capella"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
+	dynssz "github.com/pk910/dynamic-ssz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -22,7 +23,7 @@ func TestBuildSignedBuilderBid_NilEvent(t *testing.T) {
 	pk := blsSigner.PublicKey()
 
 	var genesisForkVersion phase0.Version // zero version
-	bid, err := BuildSignedBuilderBid(nil, version.DataVersionFulu, pk, blsSigner, 0, nil, genesisForkVersion, defaultMaxWithdrawalsPerPayload)
+	bid, err := BuildSignedBuilderBid(nil, version.DataVersionFulu, pk, blsSigner, 0, nil, 0, genesisForkVersion)
 	require.NoError(t, err)
 	assert.Nil(t, bid)
 }
@@ -36,7 +37,7 @@ func TestBuildSignedBuilderBid_NoSubsidy(t *testing.T) {
 	event := minimalPayload(t, blockValue)
 
 	var genesisForkVersion phase0.Version // zero version
-	bid, err := BuildSignedBuilderBid(event, version.DataVersionFulu, pk, blsSigner, 0, nil, genesisForkVersion, defaultMaxWithdrawalsPerPayload)
+	bid, err := BuildSignedBuilderBid(event, version.DataVersionFulu, pk, blsSigner, 0, nil, 0, genesisForkVersion)
 	require.NoError(t, err)
 	require.NotNil(t, bid)
 	require.NotNil(t, bid.Message)
@@ -55,7 +56,7 @@ func TestBuildSignedBuilderBid_SubsidyAdded(t *testing.T) {
 	event := minimalPayload(t, blockValue)
 
 	var genesisForkVersion phase0.Version // zero version
-	bid, err := BuildSignedBuilderBid(event, version.DataVersionFulu, pk, blsSigner, subsidy, nil, genesisForkVersion, defaultMaxWithdrawalsPerPayload)
+	bid, err := BuildSignedBuilderBid(event, version.DataVersionFulu, pk, blsSigner, subsidy, nil, 0, genesisForkVersion)
 	require.NoError(t, err)
 	require.NotNil(t, bid)
 	require.NotNil(t, bid.Message)
@@ -66,13 +67,28 @@ func TestBuildSignedBuilderBid_SubsidyAdded(t *testing.T) {
 		"bid value should be block_value_wei + subsidy_gwei_converted_to_wei")
 }
 
+func TestBuildSignedBuilderBid_ValueBoost(t *testing.T) {
+	blsSigner, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	event := minimalPayload(t, new(big.Int).SetUint64(1_000_000_000_000))
+
+	var genesisForkVersion phase0.Version
+	bid, err := BuildSignedBuilderBid(event, version.DataVersionFulu, blsSigner.PublicKey(), blsSigner,
+		1_000, nil, 150, genesisForkVersion)
+	require.NoError(t, err)
+
+	// (1000 gwei block value + 1000 gwei subsidy) * 150%.
+	assert.Equal(t, uint64(3_000_000_000_000), bid.Message.Value.Uint64())
+}
+
 func TestBuildSignedBuilderBid_SignsWithZeroGenesisValidatorsRoot(t *testing.T) {
 	blsSigner, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
 	require.NoError(t, err)
 	pk := blsSigner.PublicKey()
 
 	genesisForkVersion := phase0.Version{1, 2, 3, 4}
-	bid, err := BuildSignedBuilderBid(minimalPayload(t, big.NewInt(1)), version.DataVersionFulu, pk, blsSigner, 0, nil, genesisForkVersion, defaultMaxWithdrawalsPerPayload)
+	bid, err := BuildSignedBuilderBid(minimalPayload(t, big.NewInt(1)), version.DataVersionFulu, pk, blsSigner, 0, nil, 0, genesisForkVersion)
 	require.NoError(t, err)
 	require.NotNil(t, bid)
 
@@ -100,13 +116,19 @@ func TestExecutionPayloadHeaderFromBeacon_UsesMaxWithdrawalsPerPayload(t *testin
 		Amount:         3,
 	}}
 
-	header, err := ExecutionPayloadHeaderFromBeacon(payload, version.DataVersionFulu, 4)
+	t.Cleanup(func() { dynssz.SetGlobalSpecs(nil) })
+
+	mainnetRoot, err := withdrawalsRoot(payload.Withdrawals)
 	require.NoError(t, err)
-	require.NotNil(t, header)
 
-	minimalRoot, err := withdrawalsRoot(payload.Withdrawals, 4)
+	// Minimal preset: the limit resolves from the global dynssz spec.
+	dynssz.SetGlobalSpecs(map[string]any{"MAX_WITHDRAWALS_PER_PAYLOAD": uint64(4)})
+
+	header, err := ExecutionPayloadHeaderFromBeacon(payload, version.DataVersionFulu)
 	require.NoError(t, err)
-	mainnetRoot, err := withdrawalsRoot(payload.Withdrawals, 16)
+	require.NotNil(t, header)
+
+	minimalRoot, err := withdrawalsRoot(payload.Withdrawals)
 	require.NoError(t, err)
 
 	assert.Equal(t, phase0.Root(minimalRoot), header.WithdrawalsRoot)
@@ -0,0 +1,70 @@
+package legacy
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// maxRegistrationFutureSkew bounds how far in the future a registration may
+// be timestamped (mev-boost-relay rejects registrations likewise).
+const maxRegistrationFutureSkew = 10 * time.Second
+
+// detectClient returns the client (config.QuirkClients) named in a
+// User-Agent, or "" when none is.
+func detectClient(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	for _, client := range config.QuirkClients {
+		if strings.Contains(ua, client) {
+			return client
+		}
+	}
+
+	return ""
+}
+
+// hasQuirk reports whether the request's client has the quirk workaround
+// enabled (--builder-api-client-quirks).
+func (h *Handler) hasQuirk(r *http.Request, log logrus.FieldLogger, quirk string) bool {
+	if len(h.cfg.ClientQuirks) == 0 {
+		return false
+	}
+
+	client := detectClient(r.Header.Get("User-Agent"))
+	if client == "" || !h.cfg.HasClientQuirk(client, quirk) {
+		return false
+	}
+
+	log.WithFields(logrus.Fields{"client": client, "quirk": quirk}).Debug("Applying client quirk workaround")
+
+	return true
+}
+
+// requestContentType returns the media type of a request body:
+// application/json or application/octet-stream, or "" when unsupported.
+// Clients with the lenient_content_type quirk have a missing or unsupported
+// Content-Type read as JSON.
+func (h *Handler) requestContentType(r *http.Request, log logrus.FieldLogger) string {
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err == nil && (contentType == "application/json" || contentType == "application/octet-stream") {
+		return contentType
+	}
+
+	if h.hasQuirk(r, log, config.ClientQuirkLenientContentType) {
+		return "application/json"
+	}
+
+	return ""
+}
+
+// respondSSZ reports whether a response goes out as SSZ: the Accept header
+// prefers it and the client has no json_responses quirk.
+func (h *Handler) respondSSZ(r *http.Request, log logrus.FieldLogger) bool {
+	return preferSSZ(r.Header.Get("Accept")) && !h.hasQuirk(r, log, config.ClientQuirkJSONResponses)
+}
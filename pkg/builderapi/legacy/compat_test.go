@@ -0,0 +1,103 @@
+package legacy
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func TestDetectClient(t *testing.T) {
+	tests := map[string]string{
+		"Lighthouse/v7.1.0-abcdef/x86_64-linux":  "lighthouse",
+		"Prysm/v6.0.0/abcdef":                    "prysm",
+		"teku/v25.6.0":                           "teku",
+		"mev-boost/v1.9 Lodestar/v1.30.0/abcdef": "lodestar",
+		"mev-boost/v1.9":                         "mev-boost",
+		"Go-http-client/1.1":                     "",
+		"":                                       "",
+	}
+
+	for userAgent, want := range tests {
+		assert.Equal(t, want, detectClient(userAgent), userAgent)
+	}
+}
+
+// TestHandleRegisterValidators_ClientQuirks verifies the timestamp and
+// Content-Type checks are only relaxed for the clients with the quirk.
+func TestHandleRegisterValidators_ClientQuirks(t *testing.T) {
+	blsSigner, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	cfg := &config.BuilderAPIConfig{
+		ClientQuirks: []string{"teku:lenient_timestamp", "nimbus:lenient_content_type"},
+	}
+	h := NewHandler(cfg, logrus.New(), &stubChainService{},
+		newServingPlanService(&stubChainService{}), payload_builder.NewPayloadCache(10),
+		memstore.New[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration](), blsSigner)
+
+	register := func(userAgent, contentType string, timestamp time.Time) int {
+		body, err := json.Marshal([]*apiv1.SignedValidatorRegistration{
+			signedRegistrationAt(t, blsSigner, 30_000_000, timestamp),
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/eth/v1/builder/validators", bytes.NewReader(body))
+		req.Header.Set("User-Agent", userAgent)
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		rec := httptest.NewRecorder()
+		h.HandleRegisterValidators(rec, req)
+
+		return rec.Code
+	}
+
+	future := time.Now().Add(time.Minute)
+	now := time.Now()
+
+	assert.Equal(t, http.StatusBadRequest, register("Prysm/v6.0.0", "application/json", future))
+	assert.Equal(t, http.StatusOK, register("teku/v25.6.0", "application/json", future))
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, register("Prysm/v6.0.0", "", now))
+	assert.Equal(t, http.StatusOK, register("nimbus", "", now))
+	assert.Equal(t, http.StatusOK, register("nimbus", "text/plain", now))
+}
+
+// TestHandleGetHeader_JSONResponsesQuirk verifies a client with the
+// json_responses quirk gets JSON although its Accept header prefers SSZ.
+func TestHandleGetHeader_JSONResponsesQuirk(t *testing.T) {
+	env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
+	env.cfg.BuilderAPI.ClientQuirks = []string{"prysm:json_responses"}
+
+	contentType := func(userAgent string) string {
+		req := newGetHeaderRequestFor(env.pubkey)
+		req.Header.Set("Accept", "application/octet-stream;q=1.0,application/json;q=0.9")
+		req.Header.Set("User-Agent", userAgent)
+
+		rec := httptest.NewRecorder()
+		env.handler.HandleGetHeader(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		return rec.Header().Get("Content-Type")
+	}
+
+	assert.Equal(t, "application/json", contentType("Prysm/v6.0.0"))
+	assert.Equal(t, "application/octet-stream", contentType("Lighthouse/v7.1.0"))
+}
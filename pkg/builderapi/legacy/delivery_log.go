@@ -0,0 +1,48 @@
+package legacy
+
+import (
+	"sync"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// deliveryLogSlots is how many slots behind the newest delivery released
+// block hashes are kept; submissions outside the slot window are rejected
+// long before their record would be dropped.
+const deliveryLogSlots = 64
+
+// deliveryLog records the block hash whose payload was released per slot.
+// A proposer submitting a second, different blinded block for a slot it
+// already got a payload for is equivocating; its payload must not be
+// released as well.
+type deliveryLog struct {
+	mu     sync.Mutex
+	hashes map[phase0.Slot]phase0.Hash32
+}
+
+func newDeliveryLog() *deliveryLog {
+	return &deliveryLog{hashes: make(map[phase0.Slot]phase0.Hash32, 8)}
+}
+
+// claim records blockHash as the slot's released payload. It returns the
+// previously released block hash and false when the slot already released a
+// different one; resubmitting the same block hash (proposer retries) is
+// allowed.
+func (l *deliveryLog) claim(slot phase0.Slot, blockHash phase0.Hash32) (phase0.Hash32, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if delivered, ok := l.hashes[slot]; ok {
+		return delivered, delivered == blockHash
+	}
+
+	for s := range l.hashes {
+		if s+deliveryLogSlots < slot {
+			delete(l.hashes, s)
+		}
+	}
+
+	l.hashes[slot] = blockHash
+
+	return blockHash, true
+}
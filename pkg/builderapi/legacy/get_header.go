@@ -3,6 +3,7 @@ package legacy
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/builderapi/internal/slotwindow"
 	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 )
@@ -26,8 +28,10 @@ type GetHeaderResponse struct {
 }
 
 // HandleGetHeader handles GET /eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}.
-// Returns 200 with the active fork's SignedBuilderBid, or 204 if no bid, or 400 on
-// invalid params / unregistered proposer.
+// Returns 200 with the SignedBuilderBid of the fork active at the requested
+// slot, or 204 if no bid (including an unknown parent hash), or 400 on
+// malformed params or a slot outside the request window (builder-specs error
+// messages). Every response carries Eth-Consensus-Version.
 //
 // Whether a bid is served for the slot is decided exclusively by the frozen
 // per-slot action plan: a plan may force-serve a slot although the dialect is
@@ -36,6 +40,9 @@ type GetHeaderResponse struct {
 func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 	log := h.log.WithField("path", "/eth/v1/builder/header/...")
 
+	fork := h.chainSvc.GetCurrentFork()
+	setConsensusVersion(w, fork)
+
 	if h.payloadCache == nil || h.blsSigner == nil {
 		log.Warn("getHeader: returning 204 — payload cache or BLS signer not available")
 		w.WriteHeader(http.StatusNoContent)
@@ -44,7 +51,6 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 
 	// The legacy dialect ends at Gloas: post-Gloas proposers must use the
 	// execution_payload_bid flow instead.
-	fork := h.chainSvc.GetCurrentFork()
 	if fork >= version.DataVersionGloas {
 		log.WithField("fork", fork.String()).Info(
 			"getHeader: returning 204 — legacy Builder API dialect not served post-Gloas")
@@ -76,10 +82,26 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 	// Bound the request horizon BEFORE freezing the slot's plan: proposers
 	// only ever request the current or next slot, and freezing arbitrary
 	// far-future slots would permanently lock their plans against edits.
-	if currentSlot := h.chainSvc.GetCurrentSlot(); slot > currentSlot+1 {
-		log.WithField("current_slot", currentSlot).Warn("getHeader: slot too far ahead")
-		writeError(w, http.StatusBadRequest, "slot too far ahead: max current slot + 1")
+	// Past slots are rejected likewise: their proposal window has closed.
+	// The configured slot tolerance widens the window for clock skew.
+	currentSlot := h.chainSvc.GetCurrentSlot()
+	if msg := slotwindow.Check(slot, currentSlot, h.cfg.SlotTolerance); msg != "" {
+		log.WithField("current_slot", currentSlot).Warn("getHeader: slot outside request window")
+		writeError(w, http.StatusBadRequest, msg)
+
+		return
+	}
 
+	// The bid's fork follows the requested slot, not the wall clock: around a
+	// fork boundary the next slot's proposer needs the new fork's bid, and
+	// the first Gloas slot gets no legacy bid at all.
+	fork = h.chainSvc.ActiveForkAtEpoch(h.chainSvc.GetEpochOfSlot(slot))
+	setConsensusVersion(w, fork)
+
+	if fork >= version.DataVersionGloas {
+		log.WithField("fork", fork.String()).Info(
+			"getHeader: returning 204 — requested slot is post-Gloas")
+		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
@@ -130,7 +152,13 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	event := h.payloadCache.Get(slot)
+	// The payload built on the requested parent: the slot's latest, or a
+	// candidate / superseded payload when the chain is unstable
+	// (--builder-api-parent-candidates, payload_attributes changes).
+	event := h.payloadCache.GetByParent(slot, parentHash)
+	if event == nil {
+		event = h.payloadCache.Get(slot)
+	}
 	if event == nil {
 		log.WithField("slot", slotU64).Info(
 			"getHeader: returning 204 — no cached payload for slot")
@@ -159,12 +187,15 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 	totalValueGwei := frozenSettings.TotalValueGwei
 
 	log.Info("Subsidy Gwei: " + fmt.Sprintf("%d", subsidyGwei))
-	maxWithdrawalsPerPayload := uint64(0)
-	if chainSpec := h.chainSvc.GetChainSpec(); chainSpec != nil {
-		maxWithdrawalsPerPayload = chainSpec.MaxWithdrawalsPerPayload
+	signedBid, err := h.signedHeader(event, slot, parentHash, fork, subsidyGwei, totalValueGwei,
+		frozenSettings.ValueBoostPct)
+	if errors.Is(err, ErrInvalidBlobsBundle) {
+		log.WithError(err).Warn("getHeader: returning 204 — cached payload has an invalid blobs bundle")
+		h.recordBid(slot, fork.String(), "", nil, 0, bidStatusFailed, err.Error())
+		w.WriteHeader(http.StatusNoContent)
+
+		return
 	}
-	signedBid, err := BuildSignedBuilderBid(event, fork, h.blsSigner.PublicKey(), h.blsSigner,
-		subsidyGwei, totalValueGwei, h.chainSvc.GetGenesis().GenesisForkVersion, maxWithdrawalsPerPayload)
 	if err != nil {
 		log.WithError(err).Warn("getHeader: failed to build SignedBuilderBid")
 		h.recordBid(slot, fork.String(), "", nil, 0, bidStatusFailed,
@@ -189,6 +220,7 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 		"parent_hash": "0x" + hex.EncodeToString(parentHash[:]),
 		"value":       signedBid.Message.Value.String(),
 		"gas_limit":   signedBid.Message.Header.GasLimit,
+		"value_boost": frozenSettings.ValueBoostPct,
 	}).Infof("getHeader: delivered header for slot %d", slotU64)
 
 	blockHashHex := "0x" + hex.EncodeToString(event.BlockHash[:])
@@ -202,7 +234,7 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 	// the bid response; a proposer hangup during the wait cancels the serve.
 	if frozenSettings.DelayMs > 0 {
 		select {
-		case <-time.After(time.Duration(frozenSettings.DelayMs) * time.Millisecond):
+		case <-h.chainSvc.Clock().After(time.Duration(frozenSettings.DelayMs) * time.Millisecond):
 		case <-r.Context().Done():
 			log.WithField("delay_ms", frozenSettings.DelayMs).Info(
 				"getHeader: request cancelled during planned response delay")
@@ -213,11 +245,9 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.Header().Set("Eth-Consensus-Version", fork.String())
-
 	// Per builder-specs the response may be SSZ; the proposer opts in via
-	// the Accept header.
-	if preferSSZ(r.Header.Get("Accept")) {
+	// the Accept header, unless its client has the json_responses quirk.
+	if h.respondSSZ(r, log) {
 		body, err := signedBid.MarshalSSZ()
 		if err != nil {
 			log.WithError(err).Warn("getHeader: failed to SSZ-encode SignedBuilderBid")
@@ -262,3 +292,52 @@ func (h *Handler) HandleGetHeader(w http.ResponseWriter, r *http.Request) {
 
 	h.recordBid(slot, fork.String(), blockHashHex, signedBid, bidValueGwei, bidStatusServed, "")
 }
+
+// signedHeader returns the SignedBuilderBid for the slot's cached payload,
+// reusing the one signed for an earlier identical request while the payload
+// is unchanged (see headerCache).
+func (h *Handler) signedHeader(event *payload_builder.Payload, slot phase0.Slot, parentHash phase0.Hash32,
+	fork version.DataVersion, subsidyGwei uint64, totalValueGwei *uint64, valueBoostPct uint64,
+) (*legacytypes.SignedBuilderBid, error) {
+	key := headerCacheKey{
+		slot:          slot,
+		parentHash:    parentHash,
+		fork:          fork,
+		subsidyGwei:   subsidyGwei,
+		valueBoostPct: valueBoostPct,
+	}
+	if totalValueGwei != nil {
+		key.totalValueGwei = *totalValueGwei
+		key.hasTotalValue = true
+	}
+
+	if signedBid := h.headers.get(key, event); signedBid != nil {
+		return signedBid, nil
+	}
+
+	if err := h.bundles.validate(slot, fork, event); err != nil {
+		return nil, err
+	}
+
+	genesisForkVersion := h.chainSvc.GetGenesis().GenesisForkVersion
+
+	bidSigner := h.blsSigner
+	if h.auditor != nil {
+		bidSigner = &auditedBidSigner{
+			Signer:             h.blsSigner,
+			auditor:            h.auditor,
+			slot:               slot,
+			genesisForkVersion: genesisForkVersion,
+		}
+	}
+
+	signedBid, err := BuildSignedBuilderBid(event, fork, h.blsSigner.PublicKey(), bidSigner,
+		subsidyGwei, totalValueGwei, valueBoostPct, genesisForkVersion)
+	if err != nil || signedBid == nil {
+		return signedBid, err
+	}
+
+	h.headers.put(key, event, signedBid)
+
+	return signedBid, nil
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/signer"
 )
 
@@ -368,7 +370,9 @@ func TestHandleGetHeader_PlanResponseDelay(t *testing.T) {
 
 		assert.Less(t, time.Since(start), 2*time.Second, "cancelled delay must not be waited out")
 		assert.Zero(t, rec.Body.Len(), "no response body may be written after cancellation")
-		assert.Empty(t, rec.Header().Get("Eth-Consensus-Version"), "no response headers may be set")
+		assert.Empty(t, rec.Header().Get("Content-Type"), "no response may be written")
+		assert.Equal(t, "fulu", rec.Header().Get("Eth-Consensus-Version"),
+			"the fork header is staged as soon as the requested slot's fork is resolved")
 
 		calls := env.recorder.bidCalls()
 		require.Len(t, calls, 1)
@@ -468,6 +472,7 @@ func TestHandleGetHeader_SlotHorizonBound(t *testing.T) {
 		name        string
 		currentSlot phase0.Slot
 		requestSlot uint64
+		tolerance   uint64
 		wantCode    int
 		wantFrozen  bool
 	}{
@@ -491,12 +496,33 @@ func TestHandleGetHeader_SlotHorizonBound(t *testing.T) {
 			currentSlot: 1, requestSlot: 1_000_000,
 			wantCode: http.StatusBadRequest, wantFrozen: false,
 		},
+		{
+			name:        "past slot is rejected without freezing",
+			currentSlot: 5, requestSlot: 4,
+			wantCode: http.StatusBadRequest, wantFrozen: false,
+		},
+		{
+			name:        "past slot within tolerance is served",
+			currentSlot: 5, requestSlot: 4, tolerance: 1,
+			wantCode: http.StatusOK, wantFrozen: true,
+		},
+		{
+			name:        "past slot beyond tolerance is rejected",
+			currentSlot: 5, requestSlot: 3, tolerance: 1,
+			wantCode: http.StatusBadRequest, wantFrozen: false,
+		},
+		{
+			name:        "two slots ahead within tolerance is served",
+			currentSlot: 1, requestSlot: 3, tolerance: 1,
+			wantCode: http.StatusOK, wantFrozen: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
 			env.chainSvc.currentSlot = tt.currentSlot
+			env.cfg.BuilderAPI.SlotTolerance = tt.tolerance
 
 			// Seed the payload cache for the requested slot so in-horizon
 			// requests can actually serve.
@@ -515,3 +541,190 @@ func TestHandleGetHeader_SlotHorizonBound(t *testing.T) {
 		})
 	}
 }
+
+// TestHandleGetHeader_ErrorSchema verifies errors use the builder-specs
+// ErrorMessage body and messages, and carry Eth-Consensus-Version.
+func TestHandleGetHeader_ErrorSchema(t *testing.T) {
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) ErrorMessage {
+		t.Helper()
+
+		var msg ErrorMessage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &msg))
+
+		return msg
+	}
+
+	t.Run("unknown parent hash", func(t *testing.T) {
+		env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
+
+		req := newGetHeaderRequestFor(env.pubkey)
+		otherHash := "0x" + strings.Repeat("11", 32)
+		req = mux.SetURLVars(req, map[string]string{
+			"slot":        "1",
+			"parent_hash": otherHash,
+			"pubkey":      "0x" + hex.EncodeToString(env.pubkey[:]),
+		})
+
+		rec := httptest.NewRecorder()
+		env.handler.HandleGetHeader(rec, req)
+
+		// A well-formed request for a parent we built nothing on is a
+		// no-bid, not an error.
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Zero(t, rec.Body.Len())
+		assert.Equal(t, "fulu", rec.Header().Get("Eth-Consensus-Version"))
+
+		calls := env.recorder.bidCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, bidStatusFailed, calls[0].status)
+	})
+
+	t.Run("slot too old", func(t *testing.T) {
+		env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
+		env.chainSvc.currentSlot = 2
+
+		rec := httptest.NewRecorder()
+		env.handler.HandleGetHeader(rec, newGetHeaderRequestFor(env.pubkey))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "fulu", rec.Header().Get("Eth-Consensus-Version"))
+		assert.Equal(t, "Slot too old: slot 1, current slot 2", decode(t, rec).Message)
+	})
+}
+
+// TestHandleGetHeader_ForkBoundary verifies the bid follows the fork of the
+// requested slot rather than the wall clock's fork: the last slot of an epoch
+// may request the next epoch's fork, and a Gloas slot gets no legacy bid.
+func TestHandleGetHeader_ForkBoundary(t *testing.T) {
+	tests := []struct {
+		name        string
+		requestSlot uint64
+		nextFork    version.DataVersion
+		wantCode    int
+		wantVersion string
+	}{
+		{
+			name:        "current slot keeps the current fork",
+			requestSlot: 31, nextFork: version.DataVersionFulu,
+			wantCode: http.StatusOK, wantVersion: "electra",
+		},
+		{
+			name:        "first slot of the next fork serves its bid",
+			requestSlot: 32, nextFork: version.DataVersionFulu,
+			wantCode: http.StatusOK, wantVersion: "fulu",
+		},
+		{
+			name:        "first gloas slot gets no legacy bid",
+			requestSlot: 32, nextFork: version.DataVersionGloas,
+			wantCode: http.StatusNoContent, wantVersion: "gloas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
+			env.chainSvc.currentFork = version.DataVersionElectra
+			env.chainSvc.currentSlot = 31
+			env.chainSvc.forkAtEpoch = map[phase0.Epoch]version.DataVersion{1: tt.nextFork}
+
+			seedPayloadAtSlot(env.handler, phase0.Slot(tt.requestSlot), big.NewInt(1_000_000_000))
+
+			rec := httptest.NewRecorder()
+			env.handler.HandleGetHeader(rec, newGetHeaderRequestForSlot(env.pubkey, tt.requestSlot))
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantVersion, rec.Header().Get("Eth-Consensus-Version"))
+
+			if tt.wantCode == http.StatusOK {
+				var resp struct {
+					Version string `json:"version"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tt.wantVersion, resp.Version)
+			}
+		})
+	}
+}
+
+// TestHandleGetHeader_ServesParentCandidate serves the candidate payload built
+// on the requested parent when the slot's latest payload is on another one.
+func TestHandleGetHeader_ServesParentCandidate(t *testing.T) {
+	env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
+
+	latest := env.handler.payloadCache.Get(1)
+	require.NotNil(t, latest)
+
+	candidateParent := phase0.Hash32{0x11}
+	candidatePayload := *latest.ExecutionPayload
+	candidatePayload.ParentHash = candidateParent
+	candidatePayload.BlockHash = phase0.Hash32{0x22}
+	env.handler.payloadCache.StoreCandidate(&payload_builder.Payload{
+		Attributes:       &beacon.PayloadAttributesEvent{ProposalSlot: 1, ParentBlockHash: candidateParent},
+		ExecutionPayload: &candidatePayload,
+		BlockHash:        candidatePayload.BlockHash,
+		BlockValue:       big.NewInt(1_000_000_000),
+	})
+
+	req := mux.SetURLVars(newGetHeaderRequestFor(env.pubkey), map[string]string{
+		"slot":        "1",
+		"parent_hash": "0x" + hex.EncodeToString(candidateParent[:]),
+		"pubkey":      "0x" + hex.EncodeToString(env.pubkey[:]),
+	})
+
+	rec := httptest.NewRecorder()
+	env.handler.HandleGetHeader(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	bid := decodeSignedBuilderBid(t, rec.Body.Bytes(), version.DataVersionFulu)
+	assert.Equal(t, candidateParent, bid.Message.Header.ParentHash)
+	assert.Equal(t, phase0.Hash32{0x22}, bid.Message.Header.BlockHash)
+}
+
+// countingSigner counts the signatures produced by the wrapped signer.
+type countingSigner struct {
+	signer.Signer
+	signatures int
+}
+
+func (s *countingSigner) SignWithDomain(root phase0.Root, domain phase0.Domain) (phase0.BLSSignature, error) {
+	s.signatures++
+	return s.Signer.SignWithDomain(root, domain)
+}
+
+// TestHandleGetHeader_HeaderCache verifies repeated polls reuse the signed
+// header and a payload update invalidates it.
+func TestHandleGetHeader_HeaderCache(t *testing.T) {
+	env := newGetHeaderTestEnv(t, true, big.NewInt(1_000_000_000))
+
+	counter := &countingSigner{Signer: env.handler.blsSigner}
+	env.handler.blsSigner = counter
+
+	var first *legacytypes.SignedBuilderBid
+
+	for range 3 {
+		rec := httptest.NewRecorder()
+		env.handler.HandleGetHeader(rec, newGetHeaderRequestFor(env.pubkey))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		bid := decodeSignedBuilderBid(t, rec.Body.Bytes(), version.DataVersionFulu)
+		if first == nil {
+			first = bid
+		}
+
+		assert.Equal(t, first.Signature, bid.Signature)
+	}
+
+	assert.Equal(t, 1, counter.signatures, "repeated polls must reuse the signed header")
+
+	// A rebuilt payload for the slot replaces the cached one.
+	seedPayload(env.handler, big.NewInt(2_000_000_000))
+
+	rec := httptest.NewRecorder()
+	env.handler.HandleGetHeader(rec, newGetHeaderRequestFor(env.pubkey))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	bid := decodeSignedBuilderBid(t, rec.Body.Bytes(), version.DataVersionFulu)
+	assert.Equal(t, 2, counter.signatures, "a payload update must invalidate the signed header")
+	assert.NotEqual(t, first.Message.Value, bid.Message.Value)
+}
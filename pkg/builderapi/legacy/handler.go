@@ -14,9 +14,11 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
 	"github.com/ethpandaops/buildoor/pkg/chain"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/signer"
 )
@@ -81,7 +83,7 @@ type Handler struct {
 	chainSvc        chain.Service
 	payloadCache    *payload_builder.PayloadCache
 	validatorsStore *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration]
-	blsSigner       *signer.BLSSigner
+	blsSigner       signer.Signer
 
 	// planSvc is the mandatory per-slot scheduling/settings authority: bid
 	// serving is decided exclusively by the slot's frozen plan.
@@ -91,9 +93,21 @@ type Handler struct {
 	events   EventBroadcaster   // optional; set via SetEventBroadcaster (nil-checked)
 	recorder SlotResultRecorder // optional; set via SetResultRecorder (nil-checked)
 
+	// publishNodes are additional beacon nodes unblinded blocks are fanned
+	// out to alongside clClient; set via SetPublishNodes.
+	publishNodes []ProposalSubmitter
+
+	auditor *payload_bidder.SigningAuditor // optional; set via SetSigningAuditor (nil-checked)
+	alerts  *alerting.Engine               // optional; set via SetAlertEngine (nil-checked)
+
 	lastBidMu sync.Mutex
 	lastBids  map[phase0.Slot]recordedBid // dedupe of repeated identical bid records
 
+	headers *headerCache    // signed headers reused across getHeader polls
+	bundles *bundleVerdicts // blobs bundle validation outcome per payload
+
+	deliveries *deliveryLog // released block hash per slot (replay protection)
+
 	enabled          atomic.Bool
 	headersRequested atomic.Uint64
 	blocksPublished  atomic.Uint64
@@ -106,7 +120,7 @@ type Handler struct {
 func NewHandler(cfg *config.BuilderAPIConfig, log logrus.FieldLogger, chainSvc chain.Service,
 	planSvc *action_plan.PlanService, payloadCache *payload_builder.PayloadCache,
 	validatorsStore *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration],
-	blsSigner *signer.BLSSigner) *Handler {
+	blsSigner signer.Signer) *Handler {
 	return &Handler{
 		cfg:             cfg,
 		log:             log.WithField("component", "builderapi-legacy"),
@@ -116,6 +130,9 @@ func NewHandler(cfg *config.BuilderAPIConfig, log logrus.FieldLogger, chainSvc c
 		validatorsStore: validatorsStore,
 		blsSigner:       blsSigner,
 		lastBids:        make(map[phase0.Slot]recordedBid, maxRecordedBidSlots),
+		headers:         newHeaderCache(),
+		bundles:         newBundleVerdicts(),
+		deliveries:      newDeliveryLog(),
 	}
 }
 
@@ -124,6 +141,18 @@ func (h *Handler) SetResultRecorder(rec SlotResultRecorder) {
 	h.recorder = rec
 }
 
+// SetSigningAuditor wires the optional signing auditor validating and
+// recording the signing domain of every served header.
+func (h *Handler) SetSigningAuditor(auditor *payload_bidder.SigningAuditor) {
+	h.auditor = auditor
+}
+
+// SetAlertEngine wires the optional alerting engine raising an alert when a
+// proposer submits a second, different blinded block for a slot.
+func (h *Handler) SetAlertEngine(alerts *alerting.Engine) {
+	h.alerts = alerts
+}
+
 // frozenBuilderAPISettings resolves whether a bid may be served for the slot
 // and with which effective settings. The frozen plan is the single authority:
 // it can activate a globally disabled dialect and suppress an enabled one
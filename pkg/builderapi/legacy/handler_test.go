@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/ethpandaops/go-eth2-client/api"
 	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
 	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
+	apiv2 "github.com/ethpandaops/go-eth2-client/api/v2"
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
@@ -25,8 +27,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
 	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
@@ -43,8 +47,10 @@ type stubChainService struct {
 	forkVersion   phase0.Version
 	currentFork   version.DataVersion
 	currentSlot   phase0.Slot
+	forkAtEpoch   map[phase0.Epoch]version.DataVersion // overrides currentFork per epoch
 	pubkeyByIndex map[phase0.ValidatorIndex]phase0.BLSPubKey
 	chainSpec     *chain.ChainSpec
+	epochStats    *chain.EpochStats
 }
 
 var _ chain.Service = (*stubChainService)(nil)
@@ -59,19 +65,33 @@ func (m *stubChainService) SlotToTime(phase0.Slot) time.Time { return time.Time{
 func (m *stubChainService) TimeToSlot(time.Time) phase0.Slot { return 0 }
 func (m *stubChainService) GetCurrentEpoch() phase0.Epoch    { return 0 }
 func (m *stubChainService) GetCurrentSlot() phase0.Slot      { return m.currentSlot }
+func (m *stubChainService) Clock() clock.Clock               { return clock.Real() }
 
 func (m *stubChainService) GetCurrentFork() version.DataVersion { return m.currentFork }
-func (m *stubChainService) ActiveForkAtEpoch(phase0.Epoch) version.DataVersion {
+func (m *stubChainService) ActiveForkAtEpoch(epoch phase0.Epoch) version.DataVersion {
+	if fork, ok := m.forkAtEpoch[epoch]; ok {
+		return fork
+	}
+
 	return m.currentFork
 }
-func (m *stubChainService) GetForkVersion() (phase0.Version, error)      { return m.forkVersion, nil }
-func (m *stubChainService) GetEpochOfSlot(phase0.Slot) phase0.Epoch      { return 0 }
+func (m *stubChainService) GetForkVersion() (phase0.Version, error) { return m.forkVersion, nil }
+func (m *stubChainService) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
+	if m.chainSpec == nil || m.chainSpec.SlotsPerEpoch == 0 {
+		return 0
+	}
+
+	return phase0.Epoch(uint64(slot) / m.chainSpec.SlotsPerEpoch)
+}
 func (m *stubChainService) GetCurrentEpochStats() *chain.EpochStats      { return nil }
-func (m *stubChainService) GetEpochStats(phase0.Epoch) *chain.EpochStats { return nil }
+func (m *stubChainService) GetEpochStats(phase0.Epoch) *chain.EpochStats { return m.epochStats }
 
 func (m *stubChainService) SubscribeEpochStats() *utils.Subscription[*chain.EpochStats] { return nil }
-func (m *stubChainService) GetHeadVoteTracker() *chain.HeadVoteTracker                  { return nil }
-func (m *stubChainService) GetFinalizedEpoch() phase0.Epoch                             { return 0 }
+func (m *stubChainService) SubscribeForkTransitions() *utils.Subscription[*chain.ForkTransition] {
+	return nil
+}
+func (m *stubChainService) GetHeadVoteTracker() *chain.HeadVoteTracker { return nil }
+func (m *stubChainService) GetFinalizedEpoch() phase0.Epoch            { return 0 }
 
 func (m *stubChainService) GetBuilderByIndex(uint64) *chain.BuilderInfo            { return nil }
 func (m *stubChainService) GetBuilderByPubkey(phase0.BLSPubKey) *chain.BuilderInfo { return nil }
@@ -89,12 +109,14 @@ func (m *stubChainService) RefreshBuilders(context.Context) error { return nil }
 
 // stubProposalSubmitter records the last SubmitProposal call for tests.
 type stubProposalSubmitter struct {
-	lastProposal *api.VersionedSignedProposal
-	err          error
+	lastProposal   *api.VersionedSignedProposal
+	lastValidation *apiv2.BroadcastValidation
+	err            error
 }
 
 func (p *stubProposalSubmitter) SubmitProposal(_ context.Context, opts *api.SubmitProposalOpts) error {
 	p.lastProposal = opts.Proposal
+	p.lastValidation = opts.BroadcastValidation
 	return p.err
 }
 
@@ -298,6 +320,219 @@ func TestHandleSubmitBlindedBlock_Success(t *testing.T) {
 	assert.Equal(t, uint64(1), h.BlocksPublished())
 }
 
+// TestHandleSubmitBlindedBlock_SlotWindow rejects blocks for slots the
+// proposal window has passed with 400 "Slot too old" before anything is
+// published, unless the slot tolerance reaches back to them.
+func TestHandleSubmitBlindedBlock_SlotWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance uint64
+		wantCode  int
+	}{
+		{name: "stale block is rejected", tolerance: 0, wantCode: http.StatusBadRequest},
+		{name: "stale block within tolerance is published", tolerance: 2, wantCode: http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainSvc := &stubChainService{currentFork: version.DataVersionFulu, currentSlot: 3}
+			h := NewHandler(&config.BuilderAPIConfig{SlotTolerance: tt.tolerance}, logrus.New(), chainSvc,
+				newServingPlanService(chainSvc), payload_builder.NewPayloadCache(10),
+				memstore.New[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration](), nil)
+			h.SetEnabled(true)
+
+			submitter := &stubProposalSubmitter{}
+			h.SetCLClient(submitter)
+
+			seedPayload(h, new(big.Int).SetUint64(1_500_000_000_000_000))
+
+			// The blinded block is for slot 1, two slots behind the chain.
+			req := httptest.NewRequest(http.MethodPost, "/eth/v2/builder/blinded_blocks",
+				bytes.NewReader([]byte(blindedBlockJSON())))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			h.HandleSubmitBlindedBlock(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+
+			if tt.wantCode == http.StatusBadRequest {
+				assert.Contains(t, rec.Body.String(), errInvalidBlock+": "+errSlotTooOld)
+				assert.Nil(t, submitter.lastProposal, "stale blocks must not be published")
+			}
+		})
+	}
+}
+
+// TestHandleSubmitBlindedBlock_SingleDeliveryPerSlot resubmits the delivered
+// block hash without objection, but refuses a different block hash for a
+// slot that already had a payload released and raises an alert.
+func TestHandleSubmitBlindedBlock_SingleDeliveryPerSlot(t *testing.T) {
+	submit := func(h *Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/eth/v2/builder/blinded_blocks",
+			bytes.NewReader([]byte(blindedBlockJSON())))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.HandleSubmitBlindedBlock(rec, req)
+
+		return rec
+	}
+
+	t.Run("same block hash is resubmittable", func(t *testing.T) {
+		h := newTestHandler(&stubChainService{currentFork: version.DataVersionFulu}, nil)
+		h.SetEnabled(true)
+		h.SetCLClient(&stubProposalSubmitter{})
+		seedPayload(h, new(big.Int).SetUint64(1_500_000_000_000_000))
+
+		require.Equal(t, http.StatusAccepted, submit(h).Code)
+		require.Equal(t, http.StatusAccepted, submit(h).Code)
+		assert.Equal(t, uint64(2), h.BlocksPublished())
+	})
+
+	t.Run("different block hash is refused", func(t *testing.T) {
+		h := newTestHandler(&stubChainService{currentFork: version.DataVersionFulu}, nil)
+		h.SetEnabled(true)
+
+		submitter := &stubProposalSubmitter{}
+		h.SetCLClient(submitter)
+		seedPayload(h, new(big.Int).SetUint64(1_500_000_000_000_000))
+
+		engine := alerting.NewEngine(&alerting.RuleSet{}, nil, nil, nil, phase0.BLSPubKey{}, logrus.New())
+		alerts := engine.SubscribeAlerts(1)
+		defer alerts.Unsubscribe()
+
+		h.SetAlertEngine(engine)
+
+		// Another payload of slot 1 was already released.
+		_, ok := h.deliveries.claim(1, phase0.Hash32{0x01})
+		require.True(t, ok)
+
+		rec := submit(h)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), errInvalidBlock+": slot 1 already delivered block hash 0x01")
+		assert.Nil(t, submitter.lastProposal, "the second payload must not be released")
+		assert.Equal(t, uint64(0), h.BlocksPublished())
+
+		select {
+		case alert := <-alerts.Channel():
+			assert.Equal(t, "builder_api:equivocation", alert.Rule)
+			assert.Equal(t, alerting.SeverityCritical, alert.Severity)
+			assert.Equal(t, uint64(1), alert.Slot)
+		default:
+			t.Fatal("expected an equivocation alert")
+		}
+	})
+}
+
+// TestHandleSubmitBlindedBlock_ProposerSignature releases the payload only
+// for blocks signed by the slot's proposer under DOMAIN_BEACON_PROPOSER, and
+// skips the check when verification is disabled.
+func TestHandleSubmitBlindedBlock_ProposerSignature(t *testing.T) {
+	proposerKey, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	otherKey, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, err)
+
+	gvr := phase0.Root{0x42}
+	forkVersion := phase0.Version{0x06, 0x00, 0x00, 0x00}
+
+	// signedBlockJSON re-signs the slot-1 fixture block (proposer index 0).
+	signedBlockJSON := func(key *signer.BLSSigner) string {
+		blinded := apiv1all.SignedBlindedBeaconBlock{Version: version.DataVersionFulu}
+		require.NoError(t, json.Unmarshal([]byte(blindedBlockJSON()), &blinded))
+
+		root, err := blinded.Message.HashTreeRoot()
+		require.NoError(t, err)
+
+		domain := signer.NewSigningDomain(signer.DomainBeaconProposer, forkVersion, gvr)
+		sig, err := key.SignWithDomain(root, domain.Domain)
+		require.NoError(t, err)
+
+		return strings.Replace(blindedBlockJSON(), `"signature":"`+randaoReveal96Hex+`"}`,
+			`"signature":"0x`+hex.EncodeToString(sig[:])+`"}`, 1)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		verify     bool
+		proposer   phase0.ValidatorIndex // duty of slot 1
+		knownKey   bool
+		wantCode   int
+		wantErrMsg string
+	}{
+		{
+			name: "signed by the proposer", body: signedBlockJSON(proposerKey),
+			verify: true, knownKey: true, wantCode: http.StatusAccepted,
+		},
+		{
+			name: "signed by another key", body: signedBlockJSON(otherKey),
+			verify: true, knownKey: true, wantCode: http.StatusBadRequest,
+			wantErrMsg: errInvalidProposerSignature.Error(),
+		},
+		{
+			name: "block of another validator than the duty", body: signedBlockJSON(proposerKey),
+			verify: true, proposer: 7, knownKey: true, wantCode: http.StatusBadRequest,
+			wantErrMsg: "validator 0 is not the proposer of slot 1",
+		},
+		{
+			name: "unknown proposer pubkey", body: signedBlockJSON(proposerKey),
+			verify: true, wantCode: http.StatusBadRequest,
+			wantErrMsg: "proposer 0 pubkey unknown",
+		},
+		{
+			name: "verification disabled", body: blindedBlockJSON(),
+			verify: false, wantCode: http.StatusAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainSvc := &stubChainService{
+				currentFork: version.DataVersionFulu,
+				genesis:     beacon.Genesis{GenesisValidatorsRoot: gvr},
+				chainSpec: &chain.ChainSpec{
+					SlotsPerEpoch: 32,
+					ForkSchedule:  []chain.ForkSchedule{{Fork: version.DataVersionFulu, Version: forkVersion}},
+				},
+				epochStats: &chain.EpochStats{
+					ProposerDuties: []phase0.ValidatorIndex{0, tt.proposer},
+				},
+			}
+
+			if tt.knownKey {
+				chainSvc.pubkeyByIndex = map[phase0.ValidatorIndex]phase0.BLSPubKey{0: proposerKey.PublicKey()}
+			}
+
+			h := NewHandler(&config.BuilderAPIConfig{VerifyProposerSignature: tt.verify}, logrus.New(), chainSvc,
+				newServingPlanService(chainSvc), payload_builder.NewPayloadCache(10),
+				memstore.New[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration](), nil)
+			h.SetEnabled(true)
+
+			submitter := &stubProposalSubmitter{}
+			h.SetCLClient(submitter)
+			seedPayload(h, new(big.Int).SetUint64(1_500_000_000_000_000))
+
+			req := httptest.NewRequest(http.MethodPost, "/eth/v2/builder/blinded_blocks",
+				bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			h.HandleSubmitBlindedBlock(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code, rec.Body.String())
+
+			if tt.wantErrMsg != "" {
+				assert.Contains(t, rec.Body.String(), errInvalidBlock+": "+tt.wantErrMsg)
+				assert.Nil(t, submitter.lastProposal, "the payload must not be released")
+			}
+		})
+	}
+}
+
 // TestHandleSubmitBlindedBlock_ConsensusVersionHeader decodes the blinded
 // block with the fork version from the Eth-Consensus-Version header instead
 // of the chain's current fork.
@@ -423,7 +658,7 @@ func TestWriteUnblindedPayloadResponse_ForkShapes(t *testing.T) {
 	// Capella: data is the bare execution payload (no blobs bundle pre-Deneb).
 	rec := httptest.NewRecorder()
 	h.writeUnblindedPayloadResponse(rec, logrus.New(), version.DataVersionCapella,
-		newEvent(version.DataVersionCapella))
+		newEvent(version.DataVersionCapella), nil)
 
 	require.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, "capella", rec.Header().Get("Eth-Consensus-Version"))
@@ -443,7 +678,7 @@ func TestWriteUnblindedPayloadResponse_ForkShapes(t *testing.T) {
 	// Deneb+: data wraps the payload and a (possibly empty) blobs bundle.
 	rec = httptest.NewRecorder()
 	h.writeUnblindedPayloadResponse(rec, logrus.New(), version.DataVersionDeneb,
-		newEvent(version.DataVersionDeneb))
+		newEvent(version.DataVersionDeneb), nil)
 
 	require.Equal(t, http.StatusOK, rec.Code)
 
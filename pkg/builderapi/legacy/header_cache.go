@@ -0,0 +1,77 @@
+package legacy
+
+import (
+	"sync"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+// headerCacheSlots is how many slots behind the newest cached slot signed
+// headers are kept; proposers only request the current or next slot.
+const headerCacheSlots = 2
+
+// headerCacheKey identifies a signed header: the same payload served for the
+// same request and value resolution yields an identical SignedBuilderBid.
+type headerCacheKey struct {
+	slot           phase0.Slot
+	parentHash     phase0.Hash32
+	fork           version.DataVersion
+	subsidyGwei    uint64
+	totalValueGwei uint64
+	hasTotalValue  bool
+	valueBoostPct  uint64
+}
+
+// headerCacheEntry is a signed header and the payload it was built from.
+type headerCacheEntry struct {
+	payload   *payload_builder.Payload
+	signedBid *legacytypes.SignedBuilderBid
+}
+
+// headerCache caches the signed builder bids served by getHeader, so repeated
+// polls (several validator clients per proposer on devnets) skip the header
+// hash tree root and the BLS signature. An entry is only served while it was
+// built from the slot's current cached payload: a payload update (rebuild or
+// replacement) invalidates every entry of the slot.
+type headerCache struct {
+	mu      sync.Mutex
+	entries map[headerCacheKey]headerCacheEntry
+}
+
+func newHeaderCache() *headerCache {
+	return &headerCache{entries: make(map[headerCacheKey]headerCacheEntry, 8)}
+}
+
+// get returns the signed header for key if it was built from payload.
+func (c *headerCache) get(key headerCacheKey, payload *payload_builder.Payload) *legacytypes.SignedBuilderBid {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.payload != payload {
+		return nil
+	}
+
+	return entry.signedBid
+}
+
+// put caches the signed header built from payload. Entries of the slot built
+// from another payload and entries of slots older than headerCacheSlots are
+// dropped.
+func (c *headerCache) put(key headerCacheKey, payload *payload_builder.Payload,
+	signedBid *legacytypes.SignedBuilderBid) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, entry := range c.entries {
+		if (k.slot == key.slot && entry.payload != payload) || k.slot+headerCacheSlots < key.slot {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = headerCacheEntry{payload: payload, signedBid: signedBid}
+}
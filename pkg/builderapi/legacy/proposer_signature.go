@@ -0,0 +1,68 @@
+package legacy
+
+import (
+	"errors"
+	"fmt"
+
+	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// errInvalidProposerSignature is returned when a blinded block's signature
+// does not verify against its proposer's pubkey.
+var errInvalidProposerSignature = errors.New("invalid proposer signature")
+
+// verifyProposerSignature checks that the slot's proposer signed the blinded
+// block, before its payload is released. The block's proposer index must be
+// the slot's proposer duty (checked when the epoch's duties are known), and
+// the signature must verify against that validator's pubkey over the block
+// root in the DOMAIN_BEACON_PROPOSER domain of the block's fork.
+func (h *Handler) verifyProposerSignature(blinded *apiv1all.SignedBlindedBeaconBlock) error {
+	block := blinded.Message
+	epoch := h.chainSvc.GetEpochOfSlot(block.Slot)
+
+	spec := h.chainSvc.GetChainSpec()
+	if spec == nil {
+		return errors.New("chain spec unknown")
+	}
+
+	if stats := h.chainSvc.GetEpochStats(epoch); stats != nil && spec.SlotsPerEpoch > 0 {
+		slotIndex := uint64(block.Slot) % spec.SlotsPerEpoch
+		if slotIndex < uint64(len(stats.ProposerDuties)) && stats.ProposerDuties[slotIndex] != block.ProposerIndex {
+			return fmt.Errorf("validator %d is not the proposer of slot %d (expected %d)",
+				block.ProposerIndex, block.Slot, stats.ProposerDuties[slotIndex])
+		}
+	}
+
+	pubkey := h.chainSvc.GetValidatorPubkeyByIndex(block.ProposerIndex)
+	if pubkey == nil {
+		return fmt.Errorf("proposer %d pubkey unknown", block.ProposerIndex)
+	}
+
+	genesis := h.chainSvc.GetGenesis()
+	if genesis == nil || genesis.GenesisValidatorsRoot == (phase0.Root{}) {
+		return errors.New("genesis validators root unknown")
+	}
+
+	forkVersion, err := spec.GetForkVersion(h.chainSvc.ActiveForkAtEpoch(epoch))
+	if err != nil {
+		return fmt.Errorf("fork version of epoch %d: %w", epoch, err)
+	}
+
+	blockRoot, err := dynssz.GetGlobalDynSsz().HashTreeRoot(block)
+	if err != nil {
+		return fmt.Errorf("failed to compute block root: %w", err)
+	}
+
+	domain := signer.NewSigningDomain(signer.DomainBeaconProposer, forkVersion, genesis.GenesisValidatorsRoot)
+	signingRoot := signer.ComputeSigningRoot(blockRoot, domain.Domain)
+
+	if !signer.VerifyBLSSignature(*pubkey, signingRoot[:], blinded.Signature) {
+		return errInvalidProposerSignature
+	}
+
+	return nil
+}
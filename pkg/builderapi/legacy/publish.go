@@ -0,0 +1,122 @@
+package legacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/api"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// publishTimeout bounds each beacon node's publication of an unblinded block,
+// including publications still running after the proposer was answered.
+const publishTimeout = 10 * time.Second
+
+// publishOutcome is one beacon node's answer to a block publication.
+type publishOutcome struct {
+	node string
+	err  error
+}
+
+// SetPublishNodes sets the additional beacon nodes unblinded blocks are
+// published to alongside the CL client.
+func (h *Handler) SetPublishNodes(nodes []ProposalSubmitter) {
+	h.publishNodes = nodes
+}
+
+// publishProposal publishes the proposal to the CL client and every additional
+// publish node concurrently, with the configured broadcast validation level.
+// It returns the first node that accepted the block, or every node's error
+// joined when none did. Publications still running after the first acceptance
+// complete in the background (bounded by publishTimeout); each node's outcome
+// and latency is recorded on the payload for the won-block summary. Nodes
+// that accepted the block get the blob sidecars (when non-nil) afterwards.
+func (h *Handler) publishProposal(ctx context.Context, log logrus.FieldLogger,
+	proposal *api.VersionedSignedProposal, event *payload_builder.Payload,
+	sidecars *blobSidecars) (string, error) {
+	validation, err := beacon.ParseBroadcastValidation(h.cfg.NormalizedBroadcastValidation())
+	if err != nil {
+		return "", err
+	}
+
+	nodes := append([]ProposalSubmitter{h.clClient}, h.publishNodes...)
+	outcomes := make(chan publishOutcome, len(nodes))
+
+	// Detached from the request: the proposer is answered on the first
+	// acceptance, the remaining nodes must still receive the block.
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+
+	var wg sync.WaitGroup
+
+	for i, node := range nodes {
+		name := publishNodeName(node, i)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := node.SubmitProposal(publishCtx, &api.SubmitProposalOpts{
+				Proposal:            proposal,
+				BroadcastValidation: validation,
+			})
+			latency := time.Since(start)
+
+			rec := payload_builder.PublishRecord{Node: name, Latency: latency, At: time.Now()}
+
+			nodeLog := log.WithFields(logrus.Fields{"node": name, "latency_ms": latency.Milliseconds()})
+			if err != nil {
+				rec.Err = err.Error()
+				nodeLog.WithError(err).Warn("submitBlindedBlock: beacon node rejected unblinded block")
+			} else {
+				nodeLog.Debug("submitBlindedBlock: beacon node accepted unblinded block")
+			}
+
+			event.AddPublish(rec)
+			outcomes <- publishOutcome{node: name, err: err}
+
+			if err == nil && sidecars != nil {
+				h.publishBlobSidecars(publishCtx, nodeLog, node, sidecars)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
+
+	errs := make([]error, 0, len(nodes))
+
+	for range nodes {
+		outcome := <-outcomes
+		if outcome.err == nil {
+			return outcome.node, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", outcome.node, outcome.err))
+	}
+
+	return "", errors.Join(errs...)
+}
+
+// publishNodeName names a publish node by its beacon node host, so URLs with
+// embedded credentials never reach logs or records. Nodes without a known
+// address are named by their position (node-0 is the CL client).
+func publishNodeName(node ProposalSubmitter, index int) string {
+	if addressed, ok := node.(interface{ GetBaseURL() string }); ok {
+		if u, err := url.Parse(addressed.GetBaseURL()); err == nil && u.Host != "" {
+			return u.Host
+		}
+	}
+
+	return fmt.Sprintf("node-%d", index)
+}
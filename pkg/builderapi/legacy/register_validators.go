@@ -6,21 +6,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime"
 	"net/http"
+	"time"
 
 	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/signer"
 )
 
 // HandleRegisterValidators handles POST /eth/v1/builder/validators.
 // Accepts a list of SignedValidatorRegistration as JSON or SSZ
 // (application/octet-stream), verifies each signature, and stores valid
-// registrations. Returns 200 on success, 400 on validation failure, and 415
-// on an unsupported Content-Type.
+// registrations. Returns 200 on success, 400 on validation failure (including
+// registrations timestamped more than 10s in the future), and 415 on an
+// unsupported Content-Type. Per-client quirk workarounds
+// (--builder-api-client-quirks) relax the timestamp and Content-Type checks.
 func (h *Handler) HandleRegisterValidators(w http.ResponseWriter, r *http.Request) {
 	log := h.log.WithField("path", "/eth/v1/builder/validators")
 	defer func() {
@@ -36,8 +39,8 @@ func (h *Handler) HandleRegisterValidators(w http.ResponseWriter, r *http.Reques
 		"content_length": r.Header.Get("Content-Length"),
 	}).Debug("Validator registration request received")
 
-	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
-	if err != nil || (contentType != "application/json" && contentType != "application/octet-stream") {
+	contentType := h.requestContentType(r, log)
+	if contentType == "" {
 		log.WithField("content_type", r.Header.Get("Content-Type")).Warn(
 			"Rejected: Content-Type must be application/json or application/octet-stream")
 		writeError(w, http.StatusUnsupportedMediaType,
@@ -75,6 +78,14 @@ func (h *Handler) HandleRegisterValidators(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	genesis := h.chainSvc.GetGenesis()
+
+	// Verify the whole list at once under the builder-specs domain (the
+	// common case); only if that fails is each registration tried against
+	// every accepted domain, which also finds the offending one.
+	batchValid := verifyRegistrationsBatch(regs, genesis.GenesisForkVersion)
+	maxTimestamp := time.Now().Add(maxRegistrationFutureSkew)
+
 	for i, reg := range regs {
 		if reg == nil || reg.Message == nil {
 			log.WithFields(logrus.Fields{"index": i, "total": len(regs)}).Warn("Rejected: registration message missing")
@@ -82,7 +93,16 @@ func (h *Handler) HandleRegisterValidators(w http.ResponseWriter, r *http.Reques
 			return
 		}
 		pubkeyHex := hex.EncodeToString(reg.Message.Pubkey[:])
-		if !VerifyRegistrationWithDomain(reg, h.chainSvc.GetGenesis().GenesisForkVersion, forkVersion, h.chainSvc.GetGenesis().GenesisValidatorsRoot) {
+		if reg.Message.Timestamp.After(maxTimestamp) && !h.hasQuirk(r, log, config.ClientQuirkLenientTimestamp) {
+			log.WithFields(logrus.Fields{
+				"index":     i,
+				"pubkey":    pubkeyHex,
+				"timestamp": reg.Message.Timestamp.Unix(),
+			}).Warn("Rejected: registration timestamp too far in the future")
+			writeError(w, http.StatusBadRequest, "invalid timestamp for validator "+pubkeyHex+": too far in the future")
+			return
+		}
+		if !batchValid && !VerifyRegistrationWithDomain(reg, genesis.GenesisForkVersion, forkVersion, genesis.GenesisValidatorsRoot) {
 			// Log first failing registration as JSON for debugging (copy and share).
 			rejJSON, _ := json.Marshal(reg)
 			log.WithFields(logrus.Fields{
@@ -128,6 +148,35 @@ func decodeRegistrationsSSZ(body []byte) ([]*apiv1.SignedValidatorRegistration,
 	return regs, nil
 }
 
+// verifyRegistrationsBatch batch-verifies all registrations under
+// DOMAIN_APPLICATION_BUILDER with the genesis fork version and a zero root
+// (builder-specs, mev-boost-relay style). It returns false if any
+// registration is missing or fails, without telling which.
+func verifyRegistrationsBatch(regs []*apiv1.SignedValidatorRegistration, genesisForkVersion phase0.Version) bool {
+	domain := signer.ComputeDomain(signer.DomainApplicationBuilder, genesisForkVersion, phase0.Root{})
+	sets := make([]signer.SignatureSet, 0, len(regs))
+
+	for _, reg := range regs {
+		if reg == nil || reg.Message == nil {
+			return false
+		}
+
+		root, err := reg.Message.HashTreeRoot()
+		if err != nil {
+			return false
+		}
+
+		sets = append(sets, signer.SignatureSet{
+			Pubkey:    reg.Message.Pubkey,
+			Root:      root,
+			Domain:    domain,
+			Signature: reg.Signature,
+		})
+	}
+
+	return signer.VerifyBatch(sets)
+}
+
 // VerifyRegistration verifies the BLS signature of a validator registration
 // using DOMAIN_APPLICATION_BUILDER with zero parameters (for tests).
 // For chain-specific verification (e.g. mev-boost registrations), use VerifyRegistrationWithDomain.
@@ -151,24 +200,21 @@ func VerifyRegistrationWithDomain(reg *apiv1.SignedValidatorRegistration, genesi
 		return false
 	}
 
-	var root phase0.Root
-	copy(root[:], messageRoot[:])
+	root := phase0.Root(messageRoot)
 
 	var zeroVersion phase0.Version
 	var zeroRoot phase0.Root
 
 	// 1) (0, 0) — some clients use this for DOMAIN_APPLICATION_BUILDER.
 	domainZero := signer.ComputeDomain(signer.DomainApplicationBuilder, zeroVersion, zeroRoot)
-	signingRootZero := signer.ComputeSigningRoot(root, domainZero)
-	if signer.VerifyBLSSignature(reg.Message.Pubkey, signingRootZero[:], reg.Signature) {
+	if signer.VerifyWithDomain(reg.Message.Pubkey, root, domainZero, reg.Signature) {
 		return true
 	}
 
 	// 2) (genesisForkVersion, 0) — mev-boost-relay style; genesis fork from beacon (devnet-friendly).
 	if genesisForkVersion != zeroVersion {
 		domainRelay := signer.ComputeDomain(signer.DomainApplicationBuilder, genesisForkVersion, zeroRoot)
-		signingRootRelay := signer.ComputeSigningRoot(root, domainRelay)
-		if signer.VerifyBLSSignature(reg.Message.Pubkey, signingRootRelay[:], reg.Signature) {
+		if signer.VerifyWithDomain(reg.Message.Pubkey, root, domainRelay, reg.Signature) {
 			return true
 		}
 	}
@@ -176,8 +222,7 @@ func VerifyRegistrationWithDomain(reg *apiv1.SignedValidatorRegistration, genesi
 	// 3) (forkVersion, genesisValidatorsRoot) — chain-specific domain.
 	if forkVersion != zeroVersion || genesisValidatorsRoot != zeroRoot {
 		domainChain := signer.ComputeDomain(signer.DomainApplicationBuilder, forkVersion, genesisValidatorsRoot)
-		signingRootChain := signer.ComputeSigningRoot(root, domainChain)
-		if signer.VerifyBLSSignature(reg.Message.Pubkey, signingRootChain[:], reg.Signature) {
+		if signer.VerifyWithDomain(reg.Message.Pubkey, root, domainChain, reg.Signature) {
 			return true
 		}
 	}
@@ -3,6 +3,7 @@ package legacy
 import (
 	_ "embed"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -158,3 +159,41 @@ func TestVerifyRegistration_NilInputs(t *testing.T) {
 	}
 	require.False(t, VerifyRegistration(regNoMessage))
 }
+
+// TestVerifyRegistrationsBatch verifies that a list signed under the
+// builder-specs domain batch-verifies, and that one bad registration fails
+// the batch while the others still verify on their own.
+func TestVerifyRegistrationsBatch(t *testing.T) {
+	genesisForkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	domain := signer.ComputeDomain(signer.DomainApplicationBuilder, genesisForkVersion, phase0.Root{})
+
+	regs := make([]*apiv1.SignedValidatorRegistration, 0, 4)
+
+	for i := 1; i <= 4; i++ {
+		blsSigner, err := signer.NewBLSSigner(fmt.Sprintf("%064x", i))
+		require.NoError(t, err)
+
+		msg := &apiv1.ValidatorRegistration{
+			GasLimit:  30_000_000,
+			Timestamp: time.Unix(12345, 0),
+			Pubkey:    blsSigner.PublicKey(),
+		}
+
+		root, err := msg.HashTreeRoot()
+		require.NoError(t, err)
+
+		sig, err := blsSigner.SignWithDomain(root, domain)
+		require.NoError(t, err)
+
+		regs = append(regs, &apiv1.SignedValidatorRegistration{Message: msg, Signature: sig})
+	}
+
+	require.True(t, verifyRegistrationsBatch(regs, genesisForkVersion))
+	require.False(t, verifyRegistrationsBatch(append(regs, nil), genesisForkVersion))
+
+	regs[2].Message.GasLimit++
+
+	require.False(t, verifyRegistrationsBatch(regs, genesisForkVersion))
+	require.True(t, VerifyRegistrationWithDomain(regs[1], genesisForkVersion, phase0.Version{}, phase0.Root{}))
+	require.False(t, VerifyRegistrationWithDomain(regs[2], genesisForkVersion, phase0.Version{}, phase0.Root{}))
+}
@@ -0,0 +1,341 @@
+package legacy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/version"
+)
+
+// RegistrationSourcesNamespace is the kv_store namespace holding the relay a
+// backfilled validator registration was pulled from.
+const RegistrationSourcesNamespace = "validator_registration_sources"
+
+// RegistrationSourceLocal is the source of registrations submitted to our own
+// Builder API.
+const RegistrationSourceLocal = "local"
+
+// relayRegistrationPath is the relay data API endpoint returning the latest
+// registration of a validator (mev-boost-relay).
+const relayRegistrationPath = "/relay/v1/data/validator_registration"
+
+// relayRequestTimeout bounds a single relay registration lookup.
+const relayRequestTimeout = 5 * time.Second
+
+// maxRelayResponseSize bounds the read relay response body.
+const maxRelayResponseSize = 64 * 1024
+
+// RegistrationSource records which relay a backfilled registration came
+// from; Timestamp identifies the registration, so a newer local one
+// supersedes the record.
+type RegistrationSource struct {
+	Relay     string `json:"relay"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// RegistrationSourceCodec translates the registration source store's entries
+// to their persisted form: 0x-hex pubkey keys, JSON values.
+type RegistrationSourceCodec struct{}
+
+var _ db.KVCodec[phase0.BLSPubKey, *RegistrationSource] = RegistrationSourceCodec{}
+
+// EncodeKey encodes a validator pubkey as its 0x-prefixed hex string form.
+func (RegistrationSourceCodec) EncodeKey(pubkey phase0.BLSPubKey) string {
+	return RegistrationCodec{}.EncodeKey(pubkey)
+}
+
+// DecodeKey parses a 0x-prefixed hex pubkey string.
+func (RegistrationSourceCodec) DecodeKey(key string) (phase0.BLSPubKey, error) {
+	return RegistrationCodec{}.DecodeKey(key)
+}
+
+// EncodeValue JSON-encodes a registration source.
+func (RegistrationSourceCodec) EncodeValue(source *RegistrationSource) ([]byte, error) {
+	if source == nil {
+		return nil, fmt.Errorf("cannot encode nil registration source")
+	}
+
+	return json.Marshal(source)
+}
+
+// DecodeValue JSON-decodes a registration source.
+func (RegistrationSourceCodec) DecodeValue(value []byte) (*RegistrationSource, error) {
+	source := &RegistrationSource{}
+	if err := json.Unmarshal(value, source); err != nil {
+		return nil, fmt.Errorf("failed to decode registration source: %w", err)
+	}
+
+	return source, nil
+}
+
+// RegistrationBackfill pulls the registrations of upcoming proposers from
+// relays into the validator registration store, so hybrid setups serve
+// proposers that registered only with a relay. Relay registrations are
+// signature-checked like local ones and only replace older registrations.
+type RegistrationBackfill struct {
+	relays   []string
+	store    *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration]
+	sources  *memstore.Store[phase0.BLSPubKey, *RegistrationSource]
+	chainSvc chain.Service
+	client   *http.Client
+
+	mu        sync.Mutex
+	lastEpoch phase0.Epoch
+	started   bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewRegistrationBackfill creates the backfill of store from the relay base
+// URLs.
+func NewRegistrationBackfill(relays []string,
+	store *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration],
+	chainSvc chain.Service, log logrus.FieldLogger) *RegistrationBackfill {
+	trimmed := make([]string, 0, len(relays))
+	for _, relay := range relays {
+		trimmed = append(trimmed, strings.TrimRight(relay, "/"))
+	}
+
+	return &RegistrationBackfill{
+		relays:   trimmed,
+		store:    store,
+		sources:  memstore.New[phase0.BLSPubKey, *RegistrationSource](),
+		chainSvc: chainSvc,
+		client:   &http.Client{Timeout: relayRequestTimeout},
+		log:      log.WithField("component", "registration-backfill"),
+	}
+}
+
+// SetPersistence attaches the optional state-db so the registration sources
+// survive restarts along with the registrations. Call Stop before the
+// state-db closes.
+func (b *RegistrationBackfill) SetPersistence(ctx context.Context, stateDB *db.Database) {
+	if stateDB == nil {
+		return
+	}
+
+	b.sources.SetPersistence(ctx,
+		db.NewKVPersistence(stateDB, RegistrationSourcesNamespace, RegistrationSourceCodec{}), b.log)
+}
+
+// Start backfills the proposers of every new epoch's duties.
+func (b *RegistrationBackfill) Start(ctx context.Context) error {
+	b.ctx, b.cancel = context.WithCancel(ctx)
+
+	sub := b.chainSvc.SubscribeEpochStats()
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+		defer sub.Unsubscribe()
+
+		if stats := b.chainSvc.GetCurrentEpochStats(); stats != nil {
+			b.handleEpochStats(stats)
+		}
+
+		for {
+			select {
+			case <-b.ctx.Done():
+				return
+			case stats := <-sub.Channel():
+				b.handleEpochStats(stats)
+			}
+		}
+	}()
+
+	b.log.WithField("relays", len(b.relays)).Info("Validator registration backfill started")
+
+	return nil
+}
+
+// Stop terminates the backfill loop and flushes the registration sources.
+func (b *RegistrationBackfill) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+
+	b.wg.Wait()
+	b.sources.Stop()
+}
+
+// Source returns where the stored registration of pubkey came from: the
+// relay URL for backfilled registrations, RegistrationSourceLocal otherwise.
+func (b *RegistrationBackfill) Source(pubkey phase0.BLSPubKey) string {
+	reg, ok := b.store.Get(pubkey)
+	if !ok || reg.Message == nil {
+		return ""
+	}
+
+	source, ok := b.sources.Get(pubkey)
+	if !ok || source.Timestamp != reg.Message.Timestamp.Unix() {
+		return RegistrationSourceLocal
+	}
+
+	return source.Relay
+}
+
+func (b *RegistrationBackfill) handleEpochStats(stats *chain.EpochStats) {
+	b.mu.Lock()
+	if b.started && stats.Epoch <= b.lastEpoch {
+		b.mu.Unlock()
+		return
+	}
+
+	b.started = true
+	b.lastEpoch = stats.Epoch
+	b.mu.Unlock()
+
+	pubkeys := make([]phase0.BLSPubKey, 0, len(stats.ProposerDuties))
+	for _, index := range stats.ProposerDuties {
+		if pubkey := b.chainSvc.GetValidatorPubkeyByIndex(index); pubkey != nil {
+			pubkeys = append(pubkeys, *pubkey)
+		}
+	}
+
+	if backfilled := b.Backfill(b.ctx, pubkeys); backfilled > 0 {
+		b.log.WithFields(logrus.Fields{
+			"epoch":      stats.Epoch,
+			"backfilled": backfilled,
+		}).Info("Backfilled validator registrations from relays")
+	}
+}
+
+// Backfill looks the pubkeys up on every relay and stores the newest valid
+// registration found when it is newer than the stored one. It returns the
+// number of registrations stored.
+func (b *RegistrationBackfill) Backfill(ctx context.Context, pubkeys []phase0.BLSPubKey) int {
+	forkVersion, err := b.chainSvc.GetForkVersion()
+	if err != nil {
+		b.log.WithError(err).Warn("Skipping registration backfill: failed to get fork version")
+		return 0
+	}
+
+	genesis := b.chainSvc.GetGenesis()
+	backfilled := 0
+	seen := make(map[phase0.BLSPubKey]bool, len(pubkeys))
+
+	for _, pubkey := range pubkeys {
+		if seen[pubkey] {
+			continue
+		}
+
+		seen[pubkey] = true
+
+		var (
+			newest *apiv1.SignedValidatorRegistration
+			relay  string
+		)
+
+		if stored, ok := b.store.Get(pubkey); ok && stored.Message != nil {
+			newest = stored
+		}
+
+		maxTimestamp := time.Now().Add(maxRegistrationFutureSkew)
+
+		for _, url := range b.relays {
+			if ctx.Err() != nil {
+				return backfilled
+			}
+
+			reg, err := b.fetchRegistration(ctx, url, pubkey)
+			if err != nil {
+				b.log.WithError(err).WithFields(logrus.Fields{
+					"relay":  url,
+					"pubkey": pubkey.String(),
+				}).Debug("No relay registration")
+
+				continue
+			}
+
+			switch {
+			case reg.Message.Pubkey != pubkey:
+				b.log.WithField("relay", url).Warn("Relay returned a registration for another pubkey")
+				continue
+			case reg.Message.Timestamp.After(maxTimestamp):
+				continue
+			case newest != nil && !reg.Message.Timestamp.After(newest.Message.Timestamp):
+				continue
+			case !VerifyRegistrationWithDomain(reg, genesis.GenesisForkVersion, forkVersion, genesis.GenesisValidatorsRoot):
+				b.log.WithFields(logrus.Fields{
+					"relay":  url,
+					"pubkey": pubkey.String(),
+				}).Warn("Relay returned a registration with an invalid signature")
+
+				continue
+			}
+
+			newest, relay = reg, url
+		}
+
+		if relay == "" {
+			continue
+		}
+
+		b.store.Put(pubkey, newest)
+		b.sources.Put(pubkey, &RegistrationSource{Relay: relay, Timestamp: newest.Message.Timestamp.Unix()})
+		backfilled++
+
+		b.log.WithFields(logrus.Fields{
+			"relay":  relay,
+			"pubkey": pubkey.String(),
+		}).Debug("Backfilled validator registration")
+	}
+
+	return backfilled
+}
+
+// fetchRegistration queries one relay for the latest registration of pubkey.
+func (b *RegistrationBackfill) fetchRegistration(ctx context.Context, relay string,
+	pubkey phase0.BLSPubKey) (*apiv1.SignedValidatorRegistration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		relay+relayRegistrationPath+"?pubkey="+pubkey.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "buildoor/"+version.GetBuildVersion())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRelayResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("relay answered %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	reg := &apiv1.SignedValidatorRegistration{}
+	if err := json.Unmarshal(body, reg); err != nil {
+		return nil, fmt.Errorf("invalid registration: %w", err)
+	}
+
+	if reg.Message == nil {
+		return nil, fmt.Errorf("registration message missing")
+	}
+
+	return reg, nil
+}
@@ -0,0 +1,110 @@
+package legacy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func signedTestRegistration(t *testing.T, blsSigner *signer.BLSSigner, timestamp int64) *apiv1.SignedValidatorRegistration {
+	t.Helper()
+
+	msg := &apiv1.ValidatorRegistration{
+		GasLimit:  30_000_000,
+		Timestamp: time.Unix(timestamp, 0),
+		Pubkey:    blsSigner.PublicKey(),
+	}
+
+	root, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+
+	domain := signer.ComputeDomain(signer.DomainApplicationBuilder, phase0.Version{}, phase0.Root{})
+	signingRoot := signer.ComputeSigningRoot(root, domain)
+	sig, err := blsSigner.Sign(signingRoot[:])
+	require.NoError(t, err)
+
+	return &apiv1.SignedValidatorRegistration{Message: msg, Signature: sig}
+}
+
+// newTestRelay serves the registrations by pubkey on the relay data API.
+func newTestRelay(t *testing.T, regs map[phase0.BLSPubKey]*apiv1.SignedValidatorRegistration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != relayRegistrationPath {
+			http.NotFound(w, r)
+			return
+		}
+
+		for pubkey, reg := range regs {
+			if pubkey.String() == r.URL.Query().Get("pubkey") {
+				_ = json.NewEncoder(w).Encode(reg)
+				return
+			}
+		}
+
+		http.Error(w, `{"code":400,"message":"no registration found for validator"}`, http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRegistrationBackfill(t *testing.T) {
+	local, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	remote, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, err)
+
+	forged := signedTestRegistration(t, remote, 300)
+	forged.Message.GasLimit++
+
+	staleRelay := newTestRelay(t, map[phase0.BLSPubKey]*apiv1.SignedValidatorRegistration{
+		local.PublicKey():  signedTestRegistration(t, local, 50),
+		remote.PublicKey(): signedTestRegistration(t, remote, 100),
+	})
+	freshRelay := newTestRelay(t, map[phase0.BLSPubKey]*apiv1.SignedValidatorRegistration{
+		remote.PublicKey(): signedTestRegistration(t, remote, 200),
+	})
+	forgingRelay := newTestRelay(t, map[phase0.BLSPubKey]*apiv1.SignedValidatorRegistration{
+		remote.PublicKey(): forged,
+	})
+
+	store := memstore.New[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration]()
+	store.Put(local.PublicKey(), signedTestRegistration(t, local, 150))
+
+	backfill := NewRegistrationBackfill([]string{staleRelay.URL + "/", freshRelay.URL, forgingRelay.URL},
+		store, &stubChainService{}, logrus.New())
+
+	unknown := phase0.BLSPubKey{0x01}
+	backfilled := backfill.Backfill(context.Background(),
+		[]phase0.BLSPubKey{local.PublicKey(), remote.PublicKey(), remote.PublicKey(), unknown})
+	assert.Equal(t, 1, backfilled, "only the relay-only proposer is backfilled")
+
+	reg, ok := store.Get(remote.PublicKey())
+	require.True(t, ok)
+	assert.Equal(t, int64(200), reg.Message.Timestamp.Unix(), "newest valid registration wins, forged one ignored")
+	assert.Equal(t, freshRelay.URL, backfill.Source(remote.PublicKey()))
+
+	reg, ok = store.Get(local.PublicKey())
+	require.True(t, ok)
+	assert.Equal(t, int64(150), reg.Message.Timestamp.Unix(), "older relay registration does not replace a local one")
+	assert.Equal(t, RegistrationSourceLocal, backfill.Source(local.PublicKey()))
+	assert.Empty(t, backfill.Source(unknown))
+
+	// A newer local registration supersedes the backfilled one.
+	store.Put(remote.PublicKey(), signedTestRegistration(t, remote, 250))
+	assert.Equal(t, RegistrationSourceLocal, backfill.Source(remote.PublicKey()))
+}
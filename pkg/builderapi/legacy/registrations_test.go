@@ -31,6 +31,14 @@ func signedRegistration(t *testing.T, blsSigner *signer.BLSSigner,
 	gasLimit uint64) *apiv1.SignedValidatorRegistration {
 	t.Helper()
 
+	return signedRegistrationAt(t, blsSigner, gasLimit, time.Unix(100, 0))
+}
+
+// signedRegistrationAt is signedRegistration with the given timestamp.
+func signedRegistrationAt(t *testing.T, blsSigner *signer.BLSSigner,
+	gasLimit uint64, timestamp time.Time) *apiv1.SignedValidatorRegistration {
+	t.Helper()
+
 	var feeRecipient bellatrix.ExecutionAddress
 	for i := range feeRecipient {
 		feeRecipient[i] = byte(i)
@@ -39,7 +47,7 @@ func signedRegistration(t *testing.T, blsSigner *signer.BLSSigner,
 	msg := &apiv1.ValidatorRegistration{
 		FeeRecipient: feeRecipient,
 		GasLimit:     gasLimit,
-		Timestamp:    time.Unix(100, 0),
+		Timestamp:    timestamp,
 		Pubkey:       blsSigner.PublicKey(),
 	}
 
@@ -195,7 +203,7 @@ func TestHandleRegisterValidators_OverwriteAndFlush(t *testing.T) {
 }
 
 // TestRegistrationSettingsResolver pins the pre-Gloas resolver semantics:
-// fee recipient from the registration pre-Gloas, self-scoped false post-Gloas
+// fee recipient and gas limit from the registration pre-Gloas, self-scoped false post-Gloas
 // and for unknown proposers.
 func TestRegistrationSettingsResolver(t *testing.T) {
 	blsSigner, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
@@ -214,12 +222,11 @@ func TestRegistrationSettingsResolver(t *testing.T) {
 		})
 	}
 
-	// Pre-Gloas with a registration: fee recipient resolved, gas limit
-	// deliberately not announced.
+	// Pre-Gloas with a registration: fee recipient and gas limit resolved.
 	settings, ok := newResolver(version.DataVersionFulu).ResolveProposerSettings(1, 7)
 	require.True(t, ok)
 	assert.Equal(t, reg.Message.FeeRecipient[:], settings.FeeRecipient[:])
-	assert.Zero(t, settings.TargetGasLimit, "registration gas limit must not be announced")
+	assert.Equal(t, uint64(30_000_000), settings.TargetGasLimit)
 
 	// Post-Gloas: the resolver self-scopes out.
 	_, ok = newResolver(version.DataVersionGloas).ResolveProposerSettings(1, 7)
@@ -35,12 +35,12 @@ func NewRegistrationSettingsResolver(
 }
 
 // ResolveProposerSettings looks up the proposer's validator registration and
-// returns its fee recipient. TargetGasLimit is deliberately left 0 (not
-// announced): the registration gas limit was never used for pre-Gloas builds
-// and this preserves that behavior.
-func (r *RegistrationSettingsResolver) ResolveProposerSettings(_ phase0.Slot,
+// returns its fee recipient and gas limit. The engine API cannot carry the
+// gas limit pre-Gloas; the payload builder moves the built payload toward it
+// when it can read the parent's gas limit (--el-rpc).
+func (r *RegistrationSettingsResolver) ResolveProposerSettings(slot phase0.Slot,
 	proposerIndex phase0.ValidatorIndex) (payload_builder.ProposerSettings, bool) {
-	if r.chainSvc.GetCurrentFork() >= version.DataVersionGloas {
+	if r.chainSvc.ActiveForkAtEpoch(r.chainSvc.GetEpochOfSlot(slot)) >= version.DataVersionGloas {
 		return payload_builder.ProposerSettings{}, false
 	}
 
@@ -56,6 +56,6 @@ func (r *RegistrationSettingsResolver) ResolveProposerSettings(_ phase0.Slot,
 
 	return payload_builder.ProposerSettings{
 		FeeRecipient:   common.Address(reg.Message.FeeRecipient),
-		TargetGasLimit: 0,
+		TargetGasLimit: reg.Message.GasLimit,
 	}, true
 }
@@ -3,17 +3,20 @@ package legacy
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
-	"mime"
 	"net/http"
 
 	"github.com/ethpandaops/go-eth2-client/api"
 	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+	"github.com/ethpandaops/buildoor/pkg/builderapi/internal/slotwindow"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 )
 
@@ -38,7 +41,8 @@ type executionPayloadAndBlobsBundle struct {
 // (Bellatrix onwards). Unlike v2, the v1 flow returns the unblinded execution
 // payload (plus blobs bundle from Deneb) in the response body so the proposer
 // can publish the block itself; the block is additionally published by the
-// builder, mirroring mev-boost-relay behavior.
+// builder, mirroring mev-boost-relay behavior. Older CL clients on mixed
+// devnets still unblind Deneb and Electra blocks through it.
 func (h *Handler) HandleSubmitBlindedBlockV1(w http.ResponseWriter, r *http.Request) {
 	h.handleSubmitBlindedBlock(w, r, 1)
 }
@@ -57,8 +61,12 @@ func (h *Handler) HandleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 // current fork when the header is absent. After a successful unblind+publish,
 // apiVersion selects the response shape: v1 returns 200 with the unblinded
 // payload (and blobs bundle from Deneb), v2 returns 202 with no body.
-// Returns 400 on validation/match failure, 415 on wrong Content-Type, and 503
-// when the legacy Builder API dialect is disabled.
+// Returns 400 on validation/match failure (builder-specs "Invalid block" /
+// "Unknown hash", including blocks for slots outside the current/next slot
+// window widened by the configured slot tolerance, and blocks not signed by
+// the slot's proposer unless proposer signature verification is disabled), 415 on wrong Content-Type, and 503 when the legacy Builder
+// API dialect is disabled. Responses carry Eth-Consensus-Version once the
+// block's fork is resolved.
 func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Request, apiVersion int) {
 	log := h.log.WithField("path", r.URL.Path)
 
@@ -71,6 +79,8 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 	// The legacy dialect ends at Gloas: post-Gloas blocks carry the payload bid
 	// inside the beacon block and are submitted via the beacon_block endpoint.
 	fork := h.chainSvc.GetCurrentFork()
+	setConsensusVersion(w, fork)
+
 	if fork >= version.DataVersionGloas {
 		log.WithField("fork", fork.String()).Warn(
 			"submitBlindedBlock: 400 — legacy Builder API dialect not served post-Gloas")
@@ -78,8 +88,8 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
-	if err != nil || (contentType != "application/json" && contentType != "application/octet-stream") {
+	contentType := h.requestContentType(r, log)
+	if contentType == "" {
 		log.Warn("submitBlindedBlock: Content-Type must be application/json or application/octet-stream")
 		writeError(w, http.StatusUnsupportedMediaType,
 			"Content-Type must be application/json or application/octet-stream")
@@ -103,6 +113,7 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 		}
 
 		fork = parsed
+		setConsensusVersion(w, fork)
 	}
 
 	blinded := apiv1all.SignedBlindedBeaconBlock{Version: fork}
@@ -129,7 +140,7 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 	if blinded.Message == nil || blinded.Message.Body == nil ||
 		blinded.Message.Body.ExecutionPayloadHeader == nil {
 		log.Warn("submitBlindedBlock: blinded block missing message or execution_payload_header")
-		writeError(w, http.StatusBadRequest, "invalid blinded block: missing message or execution_payload_header")
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": missing message or execution_payload_header")
 		return
 	}
 
@@ -149,27 +160,49 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 		h.events.BroadcastBuilderAPISubmitBlindedReceived(uint64(slot), blockHashHex)
 	}
 
+	// A block outside the slot window can no longer (or not yet) be
+	// proposed; unblinding it would only reveal the payload for nothing.
+	if msg := slotwindow.Check(slot, h.chainSvc.GetCurrentSlot(), h.cfg.SlotTolerance); msg != "" {
+		log.Warn("submitBlindedBlock: block slot outside submission window")
+		h.recordSubmission(slot, submissionStatusFailed, msg)
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": "+msg)
+
+		return
+	}
+
 	event := h.payloadCache.GetByBlockHash(blockHash)
 	if event == nil {
 		log.Info("submitBlindedBlock: no cached payload for block hash (payload may not have been built or already evicted)")
 		h.recordSubmission(slot, submissionStatusFailed, "no matching payload for block hash")
-		writeError(w, http.StatusBadRequest, "no matching payload for block hash")
+		writeError(w, http.StatusBadRequest, errUnknownHash+": no payload for block hash "+blockHashHex)
 
 		return
 	}
 
+	// Only the slot's proposer may obtain the payload: verify its signature
+	// over the blinded block before anything is unblinded.
+	if h.cfg.VerifyProposerSignature {
+		if err := h.verifyProposerSignature(&blinded); err != nil {
+			log.WithError(err).Warn("submitBlindedBlock: proposer signature verification failed")
+			h.recordSubmission(slot, submissionStatusFailed, "proposer signature verification failed: "+err.Error())
+			writeError(w, http.StatusBadRequest, errInvalidBlock+": "+err.Error())
+
+			return
+		}
+	}
+
 	contents, err := UnblindSignedBlindedBeaconBlock(&blinded, event)
 	if err != nil {
 		log.WithError(err).Warn("submitBlindedBlock: unblind failed")
 		h.recordSubmission(slot, submissionStatusFailed, "unblind failed: "+err.Error())
-		writeError(w, http.StatusBadRequest, "unblind failed: "+err.Error())
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": unblind failed: "+err.Error())
 
 		return
 	}
 	if contents == nil {
 		log.Warn("submitBlindedBlock: unblind produced no contents")
 		h.recordSubmission(slot, submissionStatusFailed, "unblind produced no contents")
-		writeError(w, http.StatusBadRequest, "unblind produced no contents")
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": unblind produced no contents")
 
 		return
 	}
@@ -203,7 +236,29 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.clClient.SubmitProposal(r.Context(), &api.SubmitProposalOpts{Proposal: proposal}); err != nil {
+	// Single delivery per slot: once a payload was released for the slot, a
+	// different block hash means the proposer signed two blocks. Releasing a
+	// second payload would help it equivocate.
+	if delivered, ok := h.deliveries.claim(slot, blockHash); !ok {
+		deliveredHex := "0x" + hex.EncodeToString(delivered[:])
+		msg := fmt.Sprintf("slot %d already delivered block hash %s", slot, deliveredHex)
+
+		log.WithField("delivered_block_hash", deliveredHex).Error(
+			"submitBlindedBlock: refused second block for a delivered slot (proposer equivocation)")
+		h.recordSubmission(slot, submissionStatusFailed, "equivocation: "+msg)
+		h.raiseEquivocationAlert(slot, deliveredHex, blockHashHex)
+		writeError(w, http.StatusBadRequest, errInvalidBlock+": "+msg)
+
+		return
+	}
+
+	sidecars, err := newBlobSidecars(h.cfg.NormalizedBlobSidecars(), contents)
+	if err != nil {
+		log.WithError(err).Warn("submitBlindedBlock: blob sidecars unavailable, publishing the block only")
+	}
+
+	node, err := h.publishProposal(r.Context(), log, proposal, event, sidecars)
+	if err != nil {
 		log.WithError(err).Error("submitBlindedBlock: failed to publish unblinded block")
 		h.recordSubmission(slot, submissionStatusFailed, "failed to publish block: "+err.Error())
 		writeError(w, http.StatusInternalServerError, "failed to publish block: "+err.Error())
@@ -211,7 +266,7 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	log.Info("SubmitBlindedBlock: Successfully published block!")
+	log.WithField("node", node).Info("SubmitBlindedBlock: Successfully published block!")
 
 	h.recordSubmission(slot, submissionStatusAccepted, "")
 	h.blocksPublished.Add(1)
@@ -226,21 +281,40 @@ func (h *Handler) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Reques
 	}
 
 	if apiVersion == 1 {
-		h.writeUnblindedPayloadResponse(w, log, blinded.Version, event)
+		h.writeUnblindedPayloadResponse(w, log, blinded.Version, event, contents.KZGProofs)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 }
 
+// raiseEquivocationAlert publishes a critical alert for a refused second
+// blinded block of a slot (no-op without an alerting engine).
+func (h *Handler) raiseEquivocationAlert(slot phase0.Slot, deliveredHash, requestedHash string) {
+	if h.alerts == nil {
+		return
+	}
+
+	h.alerts.Publish(&alerting.Alert{
+		Rule:     "builder_api:equivocation",
+		Severity: alerting.SeverityCritical,
+		State:    alerting.AlertFiring,
+		Slot:     uint64(slot),
+		Message: fmt.Sprintf("proposer submitted a second blinded block for slot %d: delivered %s, refused %s",
+			slot, deliveredHash, requestedHash),
+	})
+}
+
 // writeUnblindedPayloadResponse writes the v1 submitBlindedBlock 200 response:
 // the bare execution payload pre-Deneb, or the payload plus blobs bundle from
-// Deneb onwards (an empty bundle when the block carries no blobs).
+// Deneb onwards (an empty bundle when the block carries no blobs), with the
+// published block contents' KZG proofs.
 func (h *Handler) writeUnblindedPayloadResponse(
 	w http.ResponseWriter,
 	log logrus.FieldLogger,
 	fork version.DataVersion,
 	event *payload_builder.Payload,
+	kzgProofs []deneb.KZGProof,
 ) {
 	var data any = event.ExecutionPayload
 
@@ -254,6 +328,14 @@ func (h *Handler) writeUnblindedPayloadResponse(
 			}
 		}
 
+		// Answer with the proofs the block was published with (cell
+		// proofs recomputed for a Fulu bundle that lacked them).
+		if len(kzgProofs) != len(bundle.Proofs) {
+			withProofs := *bundle
+			withProofs.Proofs = kzgProofs
+			bundle = &withProofs
+		}
+
 		data = &executionPayloadAndBlobsBundle{
 			ExecutionPayload: event.ExecutionPayload,
 			BlobsBundle:      bundle,
@@ -261,7 +343,7 @@ func (h *Handler) writeUnblindedPayloadResponse(
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Eth-Consensus-Version", fork.String())
+	setConsensusVersion(w, fork)
 	w.WriteHeader(http.StatusOK)
 
 	if err := json.NewEncoder(w).Encode(SubmitBlindedBlockV1Response{
@@ -2,16 +2,27 @@ package legacy
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
+	apiv2 "github.com/ethpandaops/go-eth2-client/api/v2"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
 	"github.com/holiman/uint256"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
 )
 
 // postBlindedBlock submits the builder-specs Fulu example blinded block to the
@@ -126,3 +137,168 @@ func TestHandleSubmitBlindedBlock_PublishFailureRecorded(t *testing.T) {
 	assert.Equal(t, submissionStatusFailed, calls[1].status)
 	assert.Contains(t, calls[1].errMsg, "failed to publish")
 }
+
+// TestHandleSubmitBlindedBlock_PublishFanOut publishes the unblinded block to
+// the CL client and every publish node with the configured broadcast
+// validation, answers on the first acceptance, and records each node's
+// outcome on the payload; only a rejection by every node fails the submission.
+func TestHandleSubmitBlindedBlock_PublishFanOut(t *testing.T) {
+	t.Run("first acceptance answers the proposer", func(t *testing.T) {
+		h := newTestHandler(&stubChainService{currentFork: version.DataVersionFulu}, nil)
+		h.cfg.BroadcastValidation = config.BroadcastValidationConsensusAndEquivocation
+		h.SetEnabled(true)
+
+		accepting := &stubProposalSubmitter{}
+		h.SetCLClient(&stubProposalSubmitter{err: assert.AnError})
+		h.SetPublishNodes([]ProposalSubmitter{accepting})
+
+		payload := seedPayload(h, big.NewInt(1_000_000_000))
+
+		rec := postBlindedBlock(h, 2)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		require.NotNil(t, accepting.lastProposal)
+		require.NotNil(t, accepting.lastValidation)
+		assert.Equal(t, apiv2.BroadcastValidationConsensusAndEquivocation, *accepting.lastValidation)
+
+		// The rejecting CL client may answer after the proposer was served.
+		require.Eventually(t, func() bool { return len(payload.Publishes()) == 2 },
+			time.Second, 5*time.Millisecond)
+
+		errs := map[string]string{}
+		for _, publish := range payload.Publishes() {
+			errs[publish.Node] = publish.Err
+		}
+
+		assert.Equal(t, map[string]string{"node-0": assert.AnError.Error(), "node-1": ""}, errs)
+	})
+
+	t.Run("rejection by every node fails", func(t *testing.T) {
+		h := newTestHandler(&stubChainService{currentFork: version.DataVersionFulu}, nil)
+		h.SetEnabled(true)
+		h.SetCLClient(&stubProposalSubmitter{err: assert.AnError})
+		h.SetPublishNodes([]ProposalSubmitter{&stubProposalSubmitter{err: assert.AnError}})
+
+		payload := seedPayload(h, big.NewInt(1_000_000_000))
+
+		rec := postBlindedBlock(h, 2)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "node-0: ")
+		assert.Contains(t, rec.Body.String(), "node-1: ")
+		assert.Len(t, payload.Publishes(), 2)
+		assert.Equal(t, uint64(0), h.BlocksPublished())
+	})
+}
+
+// stubSidecarNode is a publish node that reports heldBlobs for every block
+// and counts blob sidecar publications.
+type stubSidecarNode struct {
+	stubProposalSubmitter
+	heldBlobs int
+	submitted int
+}
+
+func (n *stubSidecarNode) GetBlobCount(context.Context, string) (int, error) {
+	return n.heldBlobs, nil
+}
+
+func (n *stubSidecarNode) SubmitBlobSidecars(_ context.Context, _ version.DataVersion, _ phase0.Root,
+	_ []deneb.KZGCommitment, _ []deneb.Blob, _ []deneb.KZGProof) error {
+	n.submitted++
+	return nil
+}
+
+func TestPublishBlobSidecars(t *testing.T) {
+	sidecars := &blobSidecars{
+		fork:        version.DataVersionFulu,
+		commitments: []deneb.KZGCommitment{{0x01}, {0x02}},
+		blobs:       make([]deneb.Blob, 2),
+	}
+
+	for _, tc := range []struct {
+		mode      string
+		heldBlobs int
+		submitted int
+	}{
+		{mode: config.BlobSidecarsAuto, heldBlobs: 2, submitted: 0},
+		{mode: config.BlobSidecarsAuto, heldBlobs: 1, submitted: 1},
+		{mode: config.BlobSidecarsAlways, heldBlobs: 2, submitted: 1},
+	} {
+		h := newTestHandler(&stubChainService{}, nil)
+		h.cfg.BlobSidecars = tc.mode
+
+		node := &stubSidecarNode{heldBlobs: tc.heldBlobs}
+		h.publishBlobSidecars(context.Background(), logrus.New(), node, sidecars)
+		assert.Equal(t, tc.submitted, node.submitted, "mode %s, %d blobs held", tc.mode, tc.heldBlobs)
+	}
+
+	// Nodes without the sidecar endpoint only get the block.
+	h := newTestHandler(&stubChainService{}, nil)
+	h.publishBlobSidecars(context.Background(), logrus.New(), &stubProposalSubmitter{}, sidecars)
+
+	contents := &apiv1all.SignedBlockContents{Version: version.DataVersionFulu}
+	none, err := newBlobSidecars(config.BlobSidecarsAlways, contents)
+	require.NoError(t, err)
+	assert.Nil(t, none, "a block without blobs has no sidecars")
+
+	contents.Blobs = make([]deneb.Blob, 1)
+	none, err = newBlobSidecars(config.BlobSidecarsNever, contents)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
+
+// TestHandleSubmitBlindedBlockV1_PreFuluForks unblinds Deneb and Electra
+// blinded blocks via v1 (older CL clients on mixed devnets), with the fork
+// taken from the Eth-Consensus-Version header or the chain, and returns the
+// payload and blobs bundle under that fork.
+func TestHandleSubmitBlindedBlockV1_PreFuluForks(t *testing.T) {
+	for _, fork := range []version.DataVersion{version.DataVersionDeneb, version.DataVersionElectra} {
+		for _, withHeader := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s header=%v", fork, withHeader), func(t *testing.T) {
+				h := newTestHandler(&stubChainService{currentFork: fork}, nil)
+				h.SetEnabled(true)
+
+				submitter := &stubProposalSubmitter{}
+				h.SetCLClient(submitter)
+
+				event := seedPayload(h, big.NewInt(1_000_000_000))
+				event.ExecutionPayload.Version = fork
+				event.ExecutionPayload.BaseFeePerGas = uint256.NewInt(7)
+
+				body := blindedBlockJSON()
+				if fork == version.DataVersionDeneb {
+					body = strings.Replace(body,
+						`,"execution_requests":{"deposits":[],"withdrawals":[],"consolidations":[]}`, "", 1)
+				}
+
+				req := httptest.NewRequest(http.MethodPost, "/eth/v1/builder/blinded_blocks",
+					bytes.NewReader([]byte(body)))
+				req.Header.Set("Content-Type", "application/json")
+
+				if withHeader {
+					req.Header.Set("Eth-Consensus-Version", fork.String())
+				}
+
+				rec := httptest.NewRecorder()
+				h.HandleSubmitBlindedBlockV1(rec, req)
+				require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+				assert.Equal(t, fork.String(), rec.Header().Get("Eth-Consensus-Version"))
+
+				var resp struct {
+					Version string `json:"version"`
+					Data    struct {
+						ExecutionPayload json.RawMessage `json:"execution_payload"`
+						BlobsBundle      json.RawMessage `json:"blobs_bundle"`
+					} `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, fork.String(), resp.Version)
+				assert.NotEmpty(t, resp.Data.ExecutionPayload)
+				assert.NotEmpty(t, resp.Data.BlobsBundle)
+
+				require.NotNil(t, submitter.lastProposal)
+				assert.Equal(t, fork, submitter.lastProposal.Version)
+			})
+		}
+	}
+}
@@ -66,6 +66,12 @@ func (b *BuilderBid) viewType() (any, error) {
 	}
 }
 
+// ViewType returns the fork-specific schema type pointer (a typed nil) for
+// the active Version, for tooling that inspects the wire layout.
+func (b *BuilderBid) ViewType() (any, error) {
+	return b.viewType()
+}
+
 // assertSupportedVersion rejects versions without a builder bid wire type.
 func (b *BuilderBid) assertSupportedVersion() error {
 	_, err := b.viewType()
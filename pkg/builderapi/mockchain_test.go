@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/utils"
@@ -48,6 +49,7 @@ func (m *mockChainService) SlotToTime(phase0.Slot) time.Time { return time.Time{
 func (m *mockChainService) TimeToSlot(time.Time) phase0.Slot { return 0 }
 func (m *mockChainService) GetCurrentEpoch() phase0.Epoch    { return 0 }
 func (m *mockChainService) GetCurrentSlot() phase0.Slot      { return 0 }
+func (m *mockChainService) Clock() clock.Clock               { return clock.Real() }
 
 func (m *mockChainService) GetCurrentFork() version.DataVersion { return m.currentFork }
 func (m *mockChainService) ActiveForkAtEpoch(phase0.Epoch) version.DataVersion {
@@ -59,8 +61,11 @@ func (m *mockChainService) GetCurrentEpochStats() *chain.EpochStats      { retur
 func (m *mockChainService) GetEpochStats(phase0.Epoch) *chain.EpochStats { return nil }
 
 func (m *mockChainService) SubscribeEpochStats() *utils.Subscription[*chain.EpochStats] { return nil }
-func (m *mockChainService) GetHeadVoteTracker() *chain.HeadVoteTracker                  { return nil }
-func (m *mockChainService) GetFinalizedEpoch() phase0.Epoch                             { return 0 }
+func (m *mockChainService) SubscribeForkTransitions() *utils.Subscription[*chain.ForkTransition] {
+	return nil
+}
+func (m *mockChainService) GetHeadVoteTracker() *chain.HeadVoteTracker { return nil }
+func (m *mockChainService) GetFinalizedEpoch() phase0.Epoch            { return 0 }
 
 func (m *mockChainService) GetBuilderByIndex(uint64) *chain.BuilderInfo            { return nil }
 func (m *mockChainService) GetBuilderByPubkey(phase0.BLSPubKey) *chain.BuilderInfo { return nil }
@@ -0,0 +1,377 @@
+package builderapi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+// Relay data API page sizes (defaults and maxima of mev-boost-relay).
+const (
+	maxDeliveredTraces = 200
+	maxReceivedTraces  = 500
+)
+
+// RelayDataSource is the per-slot result history the relay data API is
+// served from. *slot_results.Tracker satisfies it.
+type RelayDataSource interface {
+	GetRange(minSlot, maxSlot phase0.Slot) []*slot_results.SlotResult
+}
+
+// BidTrace is a relay data API bid trace (mev-boost-relay BidTraceV2). All
+// numbers are decimal strings, hashes and keys 0x-hex.
+type BidTrace struct {
+	Slot                 string `json:"slot"`
+	ParentHash           string `json:"parent_hash"`
+	BlockHash            string `json:"block_hash"`
+	BuilderPubkey        string `json:"builder_pubkey"`
+	ProposerPubkey       string `json:"proposer_pubkey"`
+	ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+	GasLimit             string `json:"gas_limit"`
+	GasUsed              string `json:"gas_used"`
+	Value                string `json:"value"` // wei
+	NumTx                string `json:"num_tx"`
+	BlockNumber          string `json:"block_number"`
+}
+
+// ReceivedBidTrace is a bid trace with the time the bid left the builder
+// (mev-boost-relay BidTraceV2WithTimestamp).
+type ReceivedBidTrace struct {
+	BidTrace
+	Timestamp            string `json:"timestamp"`
+	TimestampMs          string `json:"timestamp_ms"`
+	OptimisticSubmission bool   `json:"optimistic_submission"`
+}
+
+// relayDataFilter is the parsed query of a relay data API request.
+type relayDataFilter struct {
+	slot           *phase0.Slot
+	cursor         *phase0.Slot
+	blockHash      string
+	blockNumber    *uint64
+	proposerPubkey string
+	builderPubkey  string
+	orderBy        string
+	limit          int
+}
+
+// SetResultSource wires the slot result history backing the relay data API.
+// Without it the data endpoints answer 503.
+func (s *Server) SetResultSource(source RelayDataSource) {
+	s.results = source
+}
+
+// handleProposerPayloadDelivered handles GET
+// /relay/v1/data/bidtraces/proposer_payload_delivered: the bid traces of our
+// blocks included on chain (the bids won), newest slot first.
+func (s *Server) handleProposerPayloadDelivered(w http.ResponseWriter, r *http.Request) {
+	filter, msg := parseRelayDataFilter(r, maxDeliveredTraces)
+	if msg != "" {
+		writeRelayError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if s.results == nil {
+		writeRelayError(w, http.StatusServiceUnavailable, "relay data API not available")
+		return
+	}
+
+	traces := make([]BidTrace, 0, 16)
+
+	results := s.filteredResults(filter)
+
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		if result.Inclusion == nil {
+			continue
+		}
+
+		trace := s.bidTrace(result, result.Inclusion.BlockHash, result.Inclusion.ValueWei)
+		if result.Build == nil || result.Build.BlockHash != result.Inclusion.BlockHash {
+			trace.NumTx = strconv.Itoa(result.Inclusion.NumTransactions)
+		}
+
+		if filter.matches(&trace) {
+			traces = append(traces, trace)
+		}
+	}
+
+	if filter.orderBy != "" {
+		sort.SliceStable(traces, func(i, j int) bool {
+			return lessValue(traces[i].Value, traces[j].Value, filter.orderBy)
+		})
+	}
+
+	writeRelayJSON(w, traces[:min(len(traces), filter.limit)])
+}
+
+// handleBuilderBlocksReceived handles GET
+// /relay/v1/data/bidtraces/builder_blocks_received: the bid traces of every
+// bid we served (Builder API) or submitted (p2p), in the order they left the
+// builder. Legacy Builder API bids carry no block hash of their own; they are
+// reported with the slot's latest built payload.
+func (s *Server) handleBuilderBlocksReceived(w http.ResponseWriter, r *http.Request) {
+	filter, msg := parseRelayDataFilter(r, maxReceivedTraces)
+	if msg != "" {
+		writeRelayError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if s.results == nil {
+		writeRelayError(w, http.StatusServiceUnavailable, "relay data API not available")
+		return
+	}
+
+	traces := make([]ReceivedBidTrace, 0, 16)
+
+	for _, result := range s.filteredResults(filter) {
+		for i := range result.Bids {
+			bid := &result.Bids[i]
+			if bid.Status != slot_results.BidStatusServed && bid.Status != slot_results.BidStatusSubmitted {
+				continue
+			}
+
+			blockHash := bid.BlockHash
+			if blockHash == "" && result.Build != nil {
+				blockHash = result.Build.BlockHash
+			}
+
+			value := new(big.Int).Mul(new(big.Int).SetUint64(bid.TotalValueGwei), big.NewInt(1e9))
+			trace := ReceivedBidTrace{
+				BidTrace:    s.bidTrace(result, blockHash, value.String()),
+				Timestamp:   strconv.FormatInt(bid.At.Unix(), 10),
+				TimestampMs: strconv.FormatInt(bid.At.UnixMilli(), 10),
+			}
+
+			if bid.ParentBlockHash != "" {
+				trace.ParentHash = bid.ParentBlockHash
+			}
+
+			if bid.FeeRecipient != "" {
+				trace.ProposerFeeRecipient = bid.FeeRecipient
+			}
+
+			if bid.GasLimit != 0 {
+				trace.GasLimit = strconv.FormatUint(bid.GasLimit, 10)
+			}
+
+			if filter.matches(&trace.BidTrace) {
+				traces = append(traces, trace)
+			}
+		}
+	}
+
+	if filter.orderBy != "" {
+		sort.SliceStable(traces, func(i, j int) bool {
+			return lessValue(traces[i].Value, traces[j].Value, filter.orderBy)
+		})
+	}
+
+	writeRelayJSON(w, traces[:min(len(traces), filter.limit)])
+}
+
+// filteredResults returns the slot results the filter's slot and cursor
+// select, slot-ascending.
+func (s *Server) filteredResults(filter *relayDataFilter) []*slot_results.SlotResult {
+	minSlot, maxSlot := phase0.Slot(0), phase0.Slot(math.MaxUint64)
+
+	if filter.cursor != nil {
+		maxSlot = *filter.cursor
+	}
+
+	if filter.slot != nil {
+		minSlot, maxSlot = *filter.slot, min(maxSlot, *filter.slot)
+	}
+
+	if minSlot > maxSlot {
+		return nil
+	}
+
+	return s.results.GetRange(minSlot, maxSlot)
+}
+
+// bidTrace builds the trace of blockHash in the slot. The execution fields
+// come from the slot's build outcome and stay zero when the build produced a
+// different block.
+func (s *Server) bidTrace(result *slot_results.SlotResult, blockHash, valueWei string) BidTrace {
+	trace := BidTrace{
+		Slot:          strconv.FormatUint(uint64(result.Slot), 10),
+		BlockHash:     blockHash,
+		BuilderPubkey: s.builderPubkey(),
+		GasLimit:      "0",
+		GasUsed:       "0",
+		Value:         valueWei,
+		NumTx:         "0",
+		BlockNumber:   "0",
+	}
+
+	build := result.Build
+	if build == nil {
+		return trace
+	}
+
+	if build.Attributes != nil {
+		trace.ParentHash = build.Attributes.ParentBlockHash
+		trace.ProposerPubkey = s.proposerPubkey(phase0.ValidatorIndex(build.Attributes.ProposerIndex))
+	}
+
+	if build.BlockHash != blockHash {
+		return trace
+	}
+
+	if build.ParentHash != "" {
+		trace.ParentHash = build.ParentHash
+	}
+
+	trace.ProposerFeeRecipient = build.FeeRecipient
+	trace.GasLimit = strconv.FormatUint(build.GasLimit, 10)
+	trace.GasUsed = strconv.FormatUint(build.GasUsed, 10)
+	trace.NumTx = strconv.Itoa(build.NumTransactions)
+	trace.BlockNumber = strconv.FormatUint(build.BlockNumber, 10)
+
+	return trace
+}
+
+// builderPubkey returns our builder BLS pubkey (0x-hex), "" without a signer.
+func (s *Server) builderPubkey() string {
+	if s.blsSigner == nil {
+		return ""
+	}
+
+	pubkey := s.blsSigner.PublicKey()
+
+	return "0x" + hex.EncodeToString(pubkey[:])
+}
+
+// proposerPubkey resolves the proposer's pubkey (0x-hex) from the chain's
+// validator set, "" when unknown.
+func (s *Server) proposerPubkey(index phase0.ValidatorIndex) string {
+	pubkey := s.chainSvc.GetValidatorPubkeyByIndex(index)
+	if pubkey == nil {
+		return ""
+	}
+
+	return "0x" + hex.EncodeToString(pubkey[:])
+}
+
+// parseRelayDataFilter parses the mev-boost-relay data API query parameters.
+// It returns the error message of an invalid query, "" otherwise.
+func parseRelayDataFilter(r *http.Request, maxLimit int) (*relayDataFilter, string) {
+	query := r.URL.Query()
+	filter := &relayDataFilter{limit: maxLimit}
+
+	for name, target := range map[string]**phase0.Slot{"slot": &filter.slot, "cursor": &filter.cursor} {
+		if raw := query.Get(name); raw != "" {
+			value, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return nil, "invalid " + name
+			}
+
+			slot := phase0.Slot(value)
+			*target = &slot
+		}
+	}
+
+	if raw := query.Get("block_number"); raw != "" {
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, "invalid block_number"
+		}
+
+		filter.blockNumber = &value
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return nil, "invalid limit"
+		}
+
+		if value > maxLimit {
+			return nil, "maximum limit is " + strconv.Itoa(maxLimit)
+		}
+
+		filter.limit = value
+	}
+
+	for name, target := range map[string]*string{
+		"block_hash":      &filter.blockHash,
+		"proposer_pubkey": &filter.proposerPubkey,
+		"builder_pubkey":  &filter.builderPubkey,
+	} {
+		if raw := query.Get(name); raw != "" {
+			if _, err := hex.DecodeString(strings.TrimPrefix(raw, "0x")); err != nil || !strings.HasPrefix(raw, "0x") {
+				return nil, "invalid " + name
+			}
+
+			*target = strings.ToLower(raw)
+		}
+	}
+
+	switch filter.orderBy = query.Get("order_by"); filter.orderBy {
+	case "", "value", "-value":
+	default:
+		return nil, "invalid order_by, must be value or -value"
+	}
+
+	return filter, ""
+}
+
+// matches applies the block and pubkey filters to a trace.
+func (f *relayDataFilter) matches(trace *BidTrace) bool {
+	switch {
+	case f.blockHash != "" && !strings.EqualFold(trace.BlockHash, f.blockHash):
+		return false
+	case f.blockNumber != nil && trace.BlockNumber != strconv.FormatUint(*f.blockNumber, 10):
+		return false
+	case f.proposerPubkey != "" && !strings.EqualFold(trace.ProposerPubkey, f.proposerPubkey):
+		return false
+	case f.builderPubkey != "" && !strings.EqualFold(trace.BuilderPubkey, f.builderPubkey):
+		return false
+	}
+
+	return true
+}
+
+// lessValue orders wei values for order_by "value" (ascending) or "-value"
+// (descending).
+func lessValue(a, b, orderBy string) bool {
+	av, _ := new(big.Int).SetString(a, 10)
+	bv, _ := new(big.Int).SetString(b, 10)
+
+	if av == nil || bv == nil {
+		return false
+	}
+
+	if orderBy == "-value" {
+		return av.Cmp(bv) > 0
+	}
+
+	return av.Cmp(bv) < 0
+}
+
+// writeRelayJSON writes a relay data API result list.
+func writeRelayJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeRelayError writes a relay data API error ({"code", "message"}).
+func writeRelayError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    code,
+		"message": message,
+	})
+}
@@ -0,0 +1,153 @@
+package builderapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+// stubResultSource serves fixed slot results (slot-ascending).
+type stubResultSource []*slot_results.SlotResult
+
+func (s stubResultSource) GetRange(minSlot, maxSlot phase0.Slot) []*slot_results.SlotResult {
+	results := make([]*slot_results.SlotResult, 0, len(s))
+	for _, result := range s {
+		if result.Slot >= minSlot && result.Slot <= maxSlot {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+func relayDataResults() stubResultSource {
+	at := time.UnixMilli(1_700_000_000_123)
+
+	return stubResultSource{
+		{
+			Slot: 10,
+			Build: &slot_results.BuildOutcome{
+				Status: slot_results.BuildStatusReady, BlockHash: "0xaa", ParentHash: "0x0a",
+				FeeRecipient: "0xfe", GasLimit: 30_000_000, GasUsed: 21_000, NumTransactions: 1, BlockNumber: 100,
+			},
+			Bids: []slot_results.BidAttempt{
+				{Status: slot_results.BidStatusServed, Transport: "builder_api", TotalValueGwei: 5, At: at},
+				{Status: slot_results.BidStatusFailed, Transport: "builder_api", At: at},
+			},
+			Inclusion: &slot_results.InclusionResult{BlockHash: "0xaa", ValueWei: "5000000000", NumTransactions: 1},
+		},
+		{
+			Slot:  11,
+			Build: &slot_results.BuildOutcome{Status: slot_results.BuildStatusReady, BlockHash: "0xbb", BlockNumber: 101},
+			Bids: []slot_results.BidAttempt{
+				{Status: slot_results.BidStatusSubmitted, Transport: "p2p", TotalValueGwei: 7, BlockHash: "0xbb", At: at},
+			},
+		},
+		{
+			Slot:      12,
+			Inclusion: &slot_results.InclusionResult{BlockHash: "0xcc", ValueWei: "9000000000", NumTransactions: 3},
+		},
+	}
+}
+
+func getRelayData(t *testing.T, srv *Server, path string, out any) int {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	if out != nil && rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out))
+	}
+
+	return rec.Code
+}
+
+func TestRelayDataProposerPayloadDelivered(t *testing.T) {
+	blsSigner, err := signer.NewBLSSigner(testValidatorPrivkey)
+	require.NoError(t, err)
+
+	srv := NewServer(&config.BuilderAPIConfig{}, logrus.New(), &mockChainService{}, newServingPlanService(),
+		nil, blsSigner, nil)
+
+	const path = "/relay/v1/data/bidtraces/proposer_payload_delivered"
+
+	assert.Equal(t, http.StatusServiceUnavailable, getRelayData(t, srv, path, nil))
+
+	srv.SetResultSource(relayDataResults())
+
+	var traces []BidTrace
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path, &traces))
+	require.Len(t, traces, 2)
+	assert.Equal(t, "12", traces[0].Slot, "newest slot first")
+	assert.Equal(t, "3", traces[0].NumTx)
+	assert.Equal(t, "0", traces[0].GasUsed, "no build outcome for the block")
+
+	assert.Equal(t, BidTrace{
+		Slot: "10", ParentHash: "0x0a", BlockHash: "0xaa", BuilderPubkey: srv.builderPubkey(),
+		ProposerFeeRecipient: "0xfe", GasLimit: "30000000", GasUsed: "21000", Value: "5000000000",
+		NumTx: "1", BlockNumber: "100",
+	}, traces[1])
+
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path+"?cursor=11", &traces))
+	require.Len(t, traces, 1)
+	assert.Equal(t, "10", traces[0].Slot)
+
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path+"?block_hash=0xCC", &traces))
+	require.Len(t, traces, 1)
+	assert.Equal(t, "12", traces[0].Slot)
+
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path+"?order_by=value&limit=1", &traces))
+	require.Len(t, traces, 1)
+	assert.Equal(t, "10", traces[0].Slot)
+
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path+"?builder_pubkey=0x01", &traces))
+	assert.Empty(t, traces)
+
+	for _, query := range []string{"?slot=x", "?limit=201", "?order_by=slot", "?block_hash=aa"} {
+		assert.Equal(t, http.StatusBadRequest, getRelayData(t, srv, path+query, nil), query)
+	}
+}
+
+func TestRelayDataBuilderBlocksReceived(t *testing.T) {
+	srv := NewServer(&config.BuilderAPIConfig{}, logrus.New(), &mockChainService{}, newServingPlanService(),
+		nil, nil, nil)
+	srv.SetResultSource(relayDataResults())
+
+	const path = "/relay/v1/data/bidtraces/builder_blocks_received"
+
+	var traces []ReceivedBidTrace
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path, &traces))
+	require.Len(t, traces, 2, "served and submitted bids only")
+
+	assert.Equal(t, "10", traces[0].Slot)
+	assert.Equal(t, "0xaa", traces[0].BlockHash, "legacy bid reported with the built block")
+	assert.Equal(t, "5000000000", traces[0].Value)
+	assert.Equal(t, "21000", traces[0].GasUsed)
+	assert.Equal(t, "1700000000", traces[0].Timestamp)
+	assert.Equal(t, "1700000000123", traces[0].TimestampMs)
+
+	assert.Equal(t, "0xbb", traces[1].BlockHash)
+	assert.Equal(t, "7000000000", traces[1].Value)
+
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path+"?block_number=101", &traces))
+	require.Len(t, traces, 1)
+	assert.Equal(t, "11", traces[0].Slot)
+
+	require.Equal(t, http.StatusOK, getRelayData(t, srv, path+"?order_by=-value", &traces))
+	require.Len(t, traces, 2)
+	assert.Equal(t, "11", traces[0].Slot)
+
+	assert.Equal(t, http.StatusBadRequest, getRelayData(t, srv, path+"?limit=501", nil))
+}
@@ -5,7 +5,9 @@
 //   - pkg/builderapi/epbs — the post-Gloas dialect (Gloas/Heze+):
 //     getExecutionPayloadBid, submitBeaconBlock, submitBuilderPreferences
 //
-// plus Buildoor-specific debug/tooling endpoints under /buildoor/v1/*.
+// plus Buildoor-specific debug/tooling endpoints under /buildoor/v1/* and the
+// mev-boost-relay style data API under /relay/v1/data/* (served from the slot
+// result history).
 //
 // Builder API follows https://github.com/ethereum/builder-specs
 package builderapi
@@ -25,15 +27,18 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
 	epbsapi "github.com/ethpandaops/buildoor/pkg/builderapi/epbs"
 	"github.com/ethpandaops/buildoor/pkg/builderapi/legacy"
 	"github.com/ethpandaops/buildoor/pkg/chain"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/metrics"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/signer"
+	"github.com/ethpandaops/buildoor/version"
 )
 
 // EventBroadcaster provides methods for broadcasting Builder API events to the
@@ -92,6 +97,11 @@ type Server struct {
 	legacy          *legacy.Handler  // pre-Gloas dialect (Electra/Fulu)
 	epbs            *epbsapi.Handler // post-Gloas dialect (Gloas/Heze+)
 	enabled         atomic.Bool      // runtime toggle for enabling/disabling the builder API
+	captures        *captureBuffer   // recent request/response exchanges; nil when capturing is disabled
+
+	blsSigner signer.Signer          // may be nil; its pubkey is served by /eth/v1/builder/info
+	identity  *config.IdentityConfig // optional; set via SetIdentity (nil-checked)
+	results   RelayDataSource        // optional; backs the relay data API (set via SetResultSource)
 }
 
 // NewServer creates a new server and constructs both dialect handlers.
@@ -104,7 +114,7 @@ type Server struct {
 // settings resolver.
 func NewServer(cfg *config.BuilderAPIConfig, log *logrus.Logger, chainSvc chain.Service,
 	planSvc *action_plan.PlanService, payloadCache *payload_builder.PayloadCache,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	validatorStore *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration]) *Server {
 	store := validatorStore
 	if store == nil {
@@ -117,6 +127,8 @@ func NewServer(cfg *config.BuilderAPIConfig, log *logrus.Logger, chainSvc chain.
 		chainSvc:        chainSvc,
 		payloadCache:    payloadCache,
 		validatorsStore: store,
+		blsSigner:       blsSigner,
+		captures:        newCaptureBuffer(cfg.CaptureSize, cfg.CaptureMaxBody),
 		legacy:          legacy.NewHandler(cfg, log, chainSvc, planSvc, payloadCache, store, blsSigner),
 		epbs:            epbsapi.NewHandler(cfg, log, chainSvc, planSvc, payloadCache, blsSigner),
 	}
@@ -130,6 +142,12 @@ func (s *Server) SetEnabled(enabled bool) {
 	s.epbs.SetEnabled(enabled)
 }
 
+// SetBidHold stops serving post-Gloas bids (which carry our builder index)
+// for the given reason until it is cleared with an empty reason.
+func (s *Server) SetBidHold(reason string) {
+	s.epbs.SetBidHold(reason)
+}
+
 // IsEnabled returns whether the Builder API server is enabled.
 func (s *Server) IsEnabled() bool {
 	return s.enabled.Load()
@@ -144,6 +162,17 @@ func (s *Server) SetCLClient(c *beacon.Client) {
 	s.epbs.SetBlockBroadcaster(c)
 }
 
+// SetPublishNodes sets the additional beacon nodes unblinded blocks are
+// published to alongside the CL client (legacy dialect).
+func (s *Server) SetPublishNodes(clients []*beacon.Client) {
+	nodes := make([]legacy.ProposalSubmitter, 0, len(clients))
+	for _, c := range clients {
+		nodes = append(nodes, c)
+	}
+
+	s.legacy.SetPublishNodes(nodes)
+}
+
 // SetRevealService wires the shared reveal service used by the post-Gloas
 // dialect to schedule execution payload envelope reveals.
 func (s *Server) SetRevealService(rs *payload_bidder.RevealService) {
@@ -171,6 +200,25 @@ func (s *Server) SetResultRecorder(rec SlotResultRecorder) {
 	s.epbs.SetResultRecorder(rec)
 }
 
+// SetSigningAuditor validates and records the signing domain of every served
+// bid of both dialects.
+func (s *Server) SetSigningAuditor(auditor *payload_bidder.SigningAuditor) {
+	s.legacy.SetSigningAuditor(auditor)
+	s.epbs.SetSigningAuditor(auditor)
+}
+
+// SetAlertEngine wires the alerting engine raising alerts on proposer
+// equivocation (a second, different blinded block for a delivered slot).
+func (s *Server) SetAlertEngine(alerts *alerting.Engine) {
+	s.legacy.SetAlertEngine(alerts)
+}
+
+// SetIdentity sets the operator-set builder identity served by
+// /eth/v1/builder/info.
+func (s *Server) SetIdentity(identity *config.IdentityConfig) {
+	s.identity = identity
+}
+
 // SetBuilderIndex sets the on-chain builder index inserted into Gloas bids.
 // Called from the lifecycle manager once registration is observed.
 func (s *Server) SetBuilderIndex(index uint64) {
@@ -193,13 +241,31 @@ func (s *Server) GetRequestStats() RequestStats {
 	}
 }
 
+// CapturesEnabled reports whether Builder API exchanges are captured
+// (--builder-api-capture-size).
+func (s *Server) CapturesEnabled() bool {
+	return s.captures != nil
+}
+
+// Captures returns up to n (all when n <= 0) of the most recently captured
+// Builder API exchanges, newest first; nil when capturing is disabled.
+func (s *Server) Captures(n int) []CapturedExchange {
+	if s.captures == nil {
+		return nil
+	}
+
+	return s.captures.recent(n)
+}
+
 // RegisterRoutes registers Builder API and Buildoor API routes onto the given
 // router, delegating the spec endpoints to the dialect handlers.
 func (s *Server) RegisterRoutes(router *mux.Router) {
 	// --- Builder API (standard spec) ---
 	// https://github.com/ethereum/builder-specs
 	builderAPI := router.PathPrefix("/eth/v1/builder").Subrouter()
+	builderAPI.Use(metrics.InstrumentBuilderAPI, s.captures.Middleware)
 	builderAPI.HandleFunc("/status", s.handleBuilderStatus).Methods(http.MethodGet)
+	builderAPI.HandleFunc("/info", s.handleBuilderInfo).Methods(http.MethodGet)
 	builderAPI.HandleFunc("/validators", s.legacy.HandleRegisterValidators).Methods(http.MethodPost)
 	builderAPI.HandleFunc("/header/{slot}/{parent_hash}/{pubkey}", s.legacy.HandleGetHeader).Methods(http.MethodGet)
 	// v1 blinded-block submit (Bellatrix onwards): returns the unblinded
@@ -209,6 +275,7 @@ func (s *Server) RegisterRoutes(router *mux.Router) {
 
 	// --- Builder API v2 (blinded-block submit, 202 + no body) ---
 	builderAPIv2 := router.PathPrefix("/eth/v2/builder").Subrouter()
+	builderAPIv2.Use(metrics.InstrumentBuilderAPI, s.captures.Middleware)
 	builderAPIv2.HandleFunc("/blinded_blocks", s.legacy.HandleSubmitBlindedBlock).Methods(http.MethodPost)
 
 	// --- Builder API (post-Gloas dialect) ---
@@ -230,11 +297,18 @@ func (s *Server) RegisterRoutes(router *mux.Router) {
 	buildoorAPI.HandleFunc("/payloads/{slot}", s.handleGetPayloadBySlot).Methods(http.MethodGet)
 	buildoorAPI.HandleFunc("/validators", s.handleGetValidators).Methods(http.MethodGet)
 
+	// --- Relay data API (mev-boost-relay compatible, for relay tooling) ---
+	// https://flashbots.github.io/relay-specs/#/Data
+	relayData := router.PathPrefix("/relay/v1/data/bidtraces").Subrouter()
+	relayData.HandleFunc("/proposer_payload_delivered", s.handleProposerPayloadDelivered).Methods(http.MethodGet)
+	relayData.HandleFunc("/builder_blocks_received", s.handleBuilderBlocksReceived).Methods(http.MethodGet)
+
 	// Unmatched /eth/* paths must answer with a JSON 404 rather than falling
 	// through to the WebUI SPA catch-all (which serves index.html with 200 —
 	// an API client decoding that HTML fails with a confusing parse error
 	// instead of a clear "endpoint not found").
-	router.PathPrefix("/eth/").HandlerFunc(s.handleUnknownEndpoint)
+	// Captured too: a client calling a wrong path is a common interop issue.
+	router.PathPrefix("/eth/").Handler(s.captures.Middleware(http.HandlerFunc(s.handleUnknownEndpoint)))
 }
 
 // handleUnknownEndpoint answers unmatched /eth/* requests with a JSON 404.
@@ -265,6 +339,37 @@ func (s *Server) handleBuilderStatus(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// BuilderInfo is the builder identity served by GET /eth/v1/builder/info
+// (wrapped in a {"data": ...} envelope).
+type BuilderInfo struct {
+	Name    string `json:"name,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Contact string `json:"contact,omitempty"`
+	Pubkey  string `json:"pubkey,omitempty"` // builder BLS pubkey (0x-hex)
+	Version string `json:"version"`
+}
+
+// handleBuilderInfo handles GET /eth/v1/builder/info (buildoor extension):
+// the operator-set identity, the builder pubkey and the software version.
+func (s *Server) handleBuilderInfo(w http.ResponseWriter, r *http.Request) {
+	info := BuilderInfo{Version: version.GetBuildVersion()}
+
+	if s.identity != nil {
+		info.Name = s.identity.Name
+		info.URL = s.identity.URL
+		info.Contact = s.identity.Contact
+	}
+
+	if s.blsSigner != nil {
+		pubkey := s.blsSigner.PublicKey()
+		info.Pubkey = "0x" + hex.EncodeToString(pubkey[:])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": info})
+}
+
 // PayloadBySlotResponse is the JSON response for GET /buildoor/v1/payloads/{slot}.
 type PayloadBySlotResponse struct {
 	Slot            uint64          `json:"slot"`
@@ -537,3 +537,30 @@ func TestUnknownEthEndpoint_JSON404(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, errResp.Code)
 	}
 }
+
+// TestBuilderInfo serves the configured identity, pubkey and version.
+func TestBuilderInfo(t *testing.T) {
+	blsSigner, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	srv := NewServer(&config.BuilderAPIConfig{}, logrus.New(), &mockChainService{}, newServingPlanService(), nil, blsSigner, nil)
+	srv.SetIdentity(&config.IdentityConfig{Name: "alice", URL: "https://alice.example", Contact: "ops@alice.example"})
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/builder/info", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data BuilderInfo `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	pk := blsSigner.PublicKey()
+	assert.Equal(t, "alice", resp.Data.Name)
+	assert.Equal(t, "https://alice.example", resp.Data.URL)
+	assert.Equal(t, "ops@alice.example", resp.Data.Contact)
+	assert.Equal(t, "0x"+hex.EncodeToString(pk[:]), resp.Data.Pubkey)
+	assert.NotEmpty(t, resp.Data.Version)
+}
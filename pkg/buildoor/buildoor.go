@@ -0,0 +1,1251 @@
+// Package buildoor is the embeddable entry point of the builder: New takes a
+// fully populated config, Start wires and starts every service exactly like
+// the `buildoor run` command does, and Stop tears them down again. Other
+// tools can embed a builder in tests instead of shelling out to the binary.
+package buildoor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+	"github.com/ethpandaops/buildoor/pkg/audit"
+	"github.com/ethpandaops/buildoor/pkg/audit_export"
+	"github.com/ethpandaops/buildoor/pkg/builderapi"
+	"github.com/ethpandaops/buildoor/pkg/builderapi/legacy"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/circuit_breaker"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/debug_bundle"
+	"github.com/ethpandaops/buildoor/pkg/epoch_summary"
+	"github.com/ethpandaops/buildoor/pkg/lifecycle"
+	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/peer_mesh"
+	"github.com/ethpandaops/buildoor/pkg/penalty_monitor"
+	"github.com/ethpandaops/buildoor/pkg/preflight"
+	"github.com/ethpandaops/buildoor/pkg/probe"
+	"github.com/ethpandaops/buildoor/pkg/relay_proxy"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/rpc/engine"
+	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+	"github.com/ethpandaops/buildoor/pkg/simulation/recording"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+	"github.com/ethpandaops/buildoor/pkg/validatorranges"
+	"github.com/ethpandaops/buildoor/pkg/wallet"
+	"github.com/ethpandaops/buildoor/pkg/watchdog"
+	"github.com/ethpandaops/buildoor/pkg/webui"
+	"github.com/ethpandaops/buildoor/pkg/webui/types"
+)
+
+// defaultFeeRecipient is used for built payloads when no wallet is configured.
+var defaultFeeRecipient = common.HexToAddress("0x8943545177806ED17B9F23F0a21ee5948eCaa776")
+
+// Buildoor is one embedded builder instance. The service accessors and
+// Subscribe* hooks are valid once Start has returned successfully.
+type Buildoor struct {
+	cfg      *config.Config
+	supplied map[string]bool
+	log      *logrus.Logger
+
+	// modules are the enabled optional modules (cfg.Modules closed over
+	// their dependencies); disabled modules are never constructed.
+	modules config.Modules
+
+	clClient         *beacon.Client
+	chainSvc         chain.Service
+	settingsSvc      *config.Service
+	planSvc          *action_plan.PlanService
+	lifecycleMgr     *lifecycle.Manager
+	builderSvc       *payload_builder.Service
+	paymentTracker   *payload_bidder.PaymentTracker
+	revealSvc        *payload_bidder.RevealService
+	inclusionTracker *payload_bidder.InclusionTracker
+	propPrefSvc      *payload_bidder.ProposerPreferencesService
+	epbsSvc          *p2p_bidder.Service
+	extraBuilders    []*extraBuilder
+	peerMesh         *peer_mesh.Service
+	builderAPISrv    *builderapi.Server
+	resultTracker    *slot_results.Tracker
+	decisions        *audit.Journal
+	epochSummaries   *epoch_summary.Aggregator
+	alerts           *alerting.Engine
+	breaker          *circuit_breaker.Breaker
+	penalties        *penalty_monitor.Monitor
+	logBuffer        *debug_bundle.LogBuffer
+	relayProxy       *relay_proxy.Service
+	regBackfill      *legacy.RegistrationBackfill
+	degradations     probe.Degradations
+	preflight        *preflight.Report
+
+	cancel context.CancelFunc
+
+	// teardown holds every started service, store and client; Stop releases
+	// them in reverse order (the order `defer` gave them in the run command).
+	teardown []any
+}
+
+// New creates a builder for cfg. cfg is owned by the builder from here on:
+// Start applies slot-time defaults and persisted setting overrides to it in
+// place.
+func New(cfg *config.Config, log *logrus.Logger) (*Buildoor, error) {
+	switch {
+	case usesLocalKey(cfg) && cfg.BuilderPrivkey == "" && cfg.BuilderMnemonic == "":
+		return nil, fmt.Errorf("a builder private key or mnemonic is required")
+	case cfg.Signer.Backend == signer.BackendWeb3Signer && cfg.Signer.RemoteURL == "":
+		return nil, fmt.Errorf("a remote signer URL is required for the web3signer backend")
+	case cfg.BuilderPrivkey != "" && cfg.BuilderMnemonic != "":
+		return nil, fmt.Errorf("provide only one of builder private key or mnemonic, not both")
+	case cfg.CLClient == "":
+		return nil, fmt.Errorf("a consensus layer client URL is required")
+	case cfg.ELEngineAPI == "":
+		return nil, fmt.Errorf("an execution layer engine API URL is required")
+	case cfg.ELJWTSecret == "":
+		return nil, fmt.Errorf("an execution layer JWT secret file is required")
+	case cfg.LifecycleEnabled && cfg.LifecycleReadOnly:
+		return nil, fmt.Errorf("lifecycle management and read-only lifecycle tracking are mutually exclusive")
+	case cfg.LifecycleEnabled && (cfg.ELRPC == "" || cfg.WalletPrivkey == ""):
+		return nil, fmt.Errorf("an EL RPC URL and wallet key are required when lifecycle is enabled")
+	case cfg.BlobStuffing.Enabled() && (cfg.ELRPC == "" || cfg.WalletPrivkey == ""):
+		return nil, fmt.Errorf("an EL RPC URL and wallet key are required for blob stuffing")
+	}
+
+	modules, err := config.ResolveModules(cfg.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modules: %w", err)
+	}
+
+	return &Buildoor{
+		cfg:     cfg,
+		log:     log,
+		modules: modules,
+	}, nil
+}
+
+// NewBuilderSigner creates the builder key's signer on the configured signing
+// backend.
+func NewBuilderSigner(cfg *config.Config) (signer.Signer, error) {
+	if usesLocalKey(cfg) && cfg.BuilderPrivkey == "" && cfg.BuilderMnemonic == "" {
+		return nil, fmt.Errorf("a builder private key or mnemonic is required")
+	}
+
+	return signer.NewBackend(signer.BackendConfig{
+		Backend:      cfg.Signer.Backend,
+		PrivkeyHex:   cfg.BuilderPrivkey,
+		Mnemonic:     cfg.BuilderMnemonic,
+		KeyIndex:     cfg.BuilderKeyIndex,
+		RemoteURL:    cfg.Signer.RemoteURL,
+		RemotePubkey: cfg.Signer.RemotePubkey,
+	})
+}
+
+// requireSigningDomain fails startup when the builder key's backend cannot
+// sign the messages module produces under domainType (Web3Signer only signs
+// deposits and exits), instead of failing every signing attempt later.
+func requireSigningDomain(cfg *config.Config, s signer.Signer, module string, domainType phase0.DomainType, what string) error {
+	if signer.CanSign(s, domainType) {
+		return nil
+	}
+
+	return fmt.Errorf("signer backend %q cannot sign %s: use another --signer-backend or leave the %q module out of --modules",
+		cfg.Signer.Backend, what, module)
+}
+
+// usesLocalKey reports whether the builder key is held in process.
+func usesLocalKey(cfg *config.Config) bool {
+	return cfg.Signer.Backend == "" || cfg.Signer.Backend == signer.BackendLocal
+}
+
+// SetSuppliedSettings marks which settings keys (config.Fields) the operator
+// supplied explicitly; they form the CLI layer of the settings service. By
+// default every key counts as supplied, so cfg is authoritative over
+// persisted defaults. Must be called before Start.
+func (b *Buildoor) SetSuppliedSettings(supplied map[string]bool) {
+	b.supplied = supplied
+}
+
+// Start connects to the beacon and execution nodes, waits for the beacon node
+// to serve the chain spec and starts every service. On error everything
+// started so far is stopped again.
+func (b *Buildoor) Start(ctx context.Context) error {
+	ctx, b.cancel = context.WithCancel(ctx)
+
+	if err := b.start(ctx); err != nil {
+		b.Stop()
+		return err
+	}
+
+	return nil
+}
+
+// Stop stops every started service in reverse start order.
+func (b *Buildoor) Stop() {
+	for i := len(b.teardown) - 1; i >= 0; i-- {
+		release(b.teardown[i])
+	}
+
+	b.teardown = nil
+
+	if b.cancel != nil {
+		b.cancel()
+	}
+}
+
+// release stops or closes one teardown entry.
+func release(item any) {
+	switch s := item.(type) {
+	case interface{ Stop() }:
+		s.Stop()
+	case interface{ Stop() error }:
+		_ = s.Stop()
+	case interface{ Close() }:
+		s.Close()
+	case interface{ Close() error }:
+		_ = s.Close()
+	}
+}
+
+func (b *Buildoor) start(ctx context.Context) error {
+	cfg := b.cfg
+	logger := b.log
+
+	// Capture recent log lines for post-mortem debug bundles.
+	if b.logBuffer == nil {
+		b.logBuffer = debug_bundle.NewLogBuffer(debug_bundle.DefaultLogBufferSize)
+		logger.AddHook(b.logBuffer)
+	}
+
+	logger.WithField("modules", b.modules.List()).Info("Optional modules enabled")
+
+	// 1. Initialize CL client
+	logger.Info("Connecting to consensus layer...")
+
+	clClient, err := beacon.NewFailoverClient(ctx, cfg.CLClientURLs(), cfg.CLClientSSZ, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to CL: %w", err)
+	}
+
+	b.clClient = clClient
+	b.teardown = append(b.teardown, clClient)
+
+	// 2. Initialize Engine API client (always required for payload building)
+	logger.Info("Connecting to execution layer engine API...")
+
+	engineClient, err := engine.NewClient(ctx, cfg.ELEngineAPI, cfg.ELJWTSecret, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to EL engine API: %w", err)
+	}
+
+	b.teardown = append(b.teardown, engineClient)
+
+	raceEngines := make([]payload_builder.EngineEndpoint, 0, len(cfg.ELEngineRaceAPIs))
+
+	for _, rawURL := range cfg.ELEngineRaceAPIs {
+		raceClient, err := engine.NewClient(ctx, rawURL, cfg.ELJWTSecret, logger)
+		if err != nil {
+			return fmt.Errorf("failed to connect to race engine API %s: %w", rawURL, err)
+		}
+
+		b.teardown = append(b.teardown, raceClient)
+
+		raceEngines = append(raceEngines, payload_builder.NewEngineEndpoint(rawURL, raceClient))
+	}
+
+	if len(raceEngines) > 0 {
+		logger.WithField("engines", len(raceEngines)+1).Info("Racing payload builds across engine APIs")
+	}
+
+	// 3. Initialize the builder key's signer (local key or remote signer)
+	blsSigner, err := NewBuilderSigner(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid builder key: %w", err)
+	}
+
+	pubkey := blsSigner.PublicKey()
+	logger.WithFields(logrus.Fields{
+		"pubkey":  fmt.Sprintf("%x", pubkey[:8]),
+		"backend": cfg.Signer.Backend,
+	}).Info("Builder key loaded")
+
+	// 4. Initialize RPC client and wallet (if lifecycle enabled)
+	var rpcClient *execution.Client
+
+	var w *wallet.Wallet
+
+	// Initialize RPC client and wallet when prerequisites are available.
+	// This makes lifecycle management available for on-the-fly toggling
+	// even when not enabled at startup via --lifecycle.
+	lifecycleAvailable := cfg.ELRPC != "" && cfg.WalletPrivkey != ""
+
+	if lifecycleAvailable {
+		logger.Info("Connecting to EL RPC for lifecycle management...")
+
+		rpcClient, err = execution.NewClient(ctx, cfg.ELRPC, logger)
+		if err != nil {
+			return fmt.Errorf("failed to connect to EL RPC: %w", err)
+		}
+
+		b.teardown = append(b.teardown, rpcClient)
+
+		w, err = wallet.NewWallet(cfg.WalletPrivkey, rpcClient, logger)
+		if err != nil {
+			return fmt.Errorf("invalid wallet key: %w", err)
+		}
+
+		logger.WithField("wallet", w.Address().Hex()).Info("Wallet loaded")
+	}
+
+	// 5. Fetch chain spec & genesis (wait for the beacon node), then apply
+	// slot-time timing defaults. Retry until the beacon node is ready.
+	logger.Info("Waiting for beacon node to serve chain spec and genesis...")
+
+	var chainSpec *chain.ChainSpec
+
+	for {
+		specData, rawData, err := clClient.GetRawSpecData(ctx)
+		if err == nil {
+			chainSpec, err = chain.ParseChainSpec(specData, rawData)
+			if err != nil {
+				return fmt.Errorf("failed to parse chain spec: %w", err)
+			}
+
+			break
+		}
+
+		logger.WithError(err).Warn("Beacon node not ready, retrying in 5s...")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to get chain spec: %w", ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	var genesis *beacon.Genesis
+
+	for {
+		genesis, err = clClient.GetGenesis(ctx)
+		if err == nil {
+			break
+		}
+
+		logger.WithError(err).Warn("Beacon node genesis not available, retrying in 5s...")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to get genesis: %w", ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	// Configure the global dynssz instance with this network's spec so the
+	// go-eth2-client SSZ codecs (block.Root(), envelope HashTreeRoot, ...)
+	// compute correct hash-tree-roots on non-mainnet presets (e.g. minimal).
+	if err := clClient.InitGlobalSSZSpecs(ctx); err != nil {
+		return fmt.Errorf("failed to init global SSZ specs: %w", err)
+	}
+
+	// Apply slot-relative timing defaults now that we know the slot duration
+	slotTimeMs := chainSpec.SecondsPerSlot.Milliseconds()
+	cfg.ApplySlotDefaults(slotTimeMs)
+
+	logger.WithFields(logrus.Fields{
+		"slot_time_ms":       slotTimeMs,
+		"build_start_time":   cfg.EPBS.BuildStartTime,
+		"payload_build_time": cfg.PayloadBuildTime,
+		"bid_start_time":     cfg.EPBS.BidStartTime,
+		"bid_end_time":       cfg.EPBS.BidEndTime,
+	}).Info("Timing defaults applied")
+
+	// 5b. Probe the beacon node's capabilities and disable the optional
+	// features it lacks the event topics or endpoints for (degraded mode,
+	// reported in /api/status). The unserved topics are dropped from the
+	// event stream before it starts in step 9.
+	if cfg.CapabilityCheck {
+		b.degradations = b.checkCapabilities(ctx, chainSpec.IsForkScheduled(version.DataVersionGloas))
+		clClient.Events().DisableTopics(b.degradations.Topics()...)
+	}
+
+	// 6. Open the optional state-db and build the central settings service.
+	// The settings service applies persisted UI overrides (and detects CLI
+	// changes) into cfg in place BEFORE any service reads it, so every
+	// module starts from the effective configuration.
+	stateDB := db.NewDatabase(&db.Config{File: cfg.StateDBPath}, logger)
+	if err := stateDB.Init(); err != nil {
+		return fmt.Errorf("failed to init state-db: %w", err)
+	}
+
+	b.teardown = append(b.teardown, stateDB)
+
+	defaults := config.DefaultConfig()
+	defaults.ApplySlotDefaults(slotTimeMs)
+
+	supplied := b.supplied
+	if supplied == nil {
+		supplied = make(map[string]bool)
+		for _, f := range config.Fields() {
+			supplied[f.Key] = true
+		}
+	}
+
+	settingsSvc, err := config.NewService(cfg, defaults, supplied, stateDB, logger)
+	if err != nil {
+		return fmt.Errorf("failed to init settings service: %w", err)
+	}
+
+	b.settingsSvc = settingsSvc
+
+	// 7. Start chain service (epoch-level state management)
+	chainSvc := chain.NewService(cfg, clClient, chainSpec, genesis, logger)
+	if err := chainSvc.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start chain service: %w", err)
+	}
+
+	b.chainSvc = chainSvc
+	b.teardown = append(b.teardown, chainSvc)
+
+	if w != nil {
+		w.SetClock(chainSvc.Clock())
+	}
+
+	if cfg.ClockSpeedup > 1 {
+		b.log.WithField("factor", cfg.ClockSpeedup).Warn("Clock speedup active: slot timing runs faster than wall time (local simulation only)")
+	}
+
+	// Every bid, header and envelope signature is checked against the
+	// connected chain's signing domain at sign time and recorded in the slot
+	// results.
+	signingAuditor := payload_bidder.NewSigningAuditor(chainSvc, logger)
+
+	// 7b. Initialize the per-slot action plan service. Decision points
+	// (build/bid/serve/reveal) freeze the slot's plan on first use; plans
+	// persist in the state-db's kv_store when --state-db is set.
+	planSvc := action_plan.NewPlanService(cfg, chainSvc, logger)
+	planSvc.SetPersistence(ctx, stateDB)
+
+	if err := planSvc.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start action plan service: %w", err)
+	}
+
+	// Released after stateDB's own close (LIFO) → the plan store's final
+	// flush (inside Stop) runs while the state-db is still open.
+	b.planSvc = planSvc
+	b.teardown = append(b.teardown, planSvc)
+
+	// 8. Initialize lifecycle manager (if the lifecycle module is enabled
+	// and its prerequisites are available). Read-only tracking of an
+	// out-of-band registration needs no wallet.
+	var lifecycleMgr *lifecycle.Manager
+
+	if b.modules.Enabled(config.ModuleLifecycle) && (lifecycleAvailable || cfg.LifecycleReadOnly) {
+		if !cfg.LifecycleReadOnly && chainSpec.IsForkScheduled(version.DataVersionGloas) {
+			if err := requireSigningDomain(cfg, blsSigner, config.ModuleLifecycle, signer.DomainBuilderDeposit, "builder deposits"); err != nil {
+				return err
+			}
+		}
+
+		lifecycleMgr, err = lifecycle.NewManager(cfg, clClient, chainSvc, blsSigner, w, logger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize lifecycle: %w", err)
+		}
+
+		lifecycleMgr.SetEnabled(cfg.LifecycleEnabled)
+	}
+
+	b.lifecycleMgr = lifecycleMgr
+
+	// 9. Initialize builder service (standalone block building). When the
+	// Builder API is available this also creates the shared validator
+	// registration memstore and registers the pre-Gloas settings resolver.
+	logger.Info("Initializing builder service...")
+
+	// Get fee recipient from wallet or use default address
+	feeRecipient := defaultFeeRecipient
+	if w != nil {
+		feeRecipient = w.Address()
+	}
+
+	// Validator registration store when Builder API is available (port > 0):
+	// written by the legacy dialect's registerValidators handler, read by the
+	// pre-Gloas proposer-settings resolver and the WebUI. Buffered persistence
+	// into the state-db's kv_store (released after the state-db's own close,
+	// LIFO ⇒ the final flush runs while the state-db is still open).
+	var validatorStore *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration]
+
+	builderAPIAvailable := cfg.APIPort > 0 && b.modules.Enabled(config.ModuleBuilderAPI)
+	if builderAPIAvailable {
+		validatorStore = memstore.New[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration]()
+		validatorStore.SetPersistence(ctx,
+			db.NewKVPersistence(stateDB, legacy.RegistrationsNamespace, legacy.RegistrationCodec{}),
+			logger)
+
+		b.teardown = append(b.teardown, validatorStore)
+	}
+
+	builderSvc, err := payload_builder.NewService(cfg, clClient, chainSvc, planSvc, engineClient, feeRecipient, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize builder: %w", err)
+	}
+
+	b.builderSvc = builderSvc
+	builderSvc.SetRaceEngines(raceEngines)
+
+	if cfg.ELWSRPC != "" {
+		// EL head changes withdraw stale payloads ahead of the beacon node's
+		// payload_attributes.
+		heads := execution.NewHeadStream(cfg.ELWSRPC, logger)
+		heads.Start(ctx)
+
+		b.teardown = append(b.teardown, heads)
+
+		builderSvc.SetHeadSource(heads)
+	}
+
+	if cfg.ELRPC != "" {
+		// Injected transactions reach the builds through the EL's mempool.
+		txSender := rpcClient
+		if txSender == nil {
+			txSender, err = execution.NewClient(ctx, cfg.ELRPC, logger)
+			if err != nil {
+				return fmt.Errorf("failed to connect to EL RPC: %w", err)
+			}
+
+			b.teardown = append(b.teardown, txSender)
+		}
+
+		txInjector := payload_builder.NewTxInjector(txSender, logger)
+
+		if len(cfg.BuilderTxs) > 0 {
+			if _, err := txInjector.Queue(cfg.BuilderTxs); err != nil {
+				return fmt.Errorf("invalid --builder-txs: %w", err)
+			}
+		}
+
+		builderSvc.SetTxInjector(txInjector)
+
+		// Gas limit targets pre-Gloas and violations move the built payload
+		// from its parent's gas limit.
+		builderSvc.SetParentHeaderReader(txSender)
+	}
+
+	if w != nil {
+		// Blob stuffing signs with the chain ID the wallet learns on sync;
+		// without --blob-stuffing only plans can ask for it, so a failed sync
+		// just leaves it unavailable.
+		if err := w.Sync(ctx); err == nil {
+			builderSvc.SetBlobStuffer(payload_builder.NewBlobStuffer(w, rpcClient, chainSvc, logger))
+		} else if cfg.BlobStuffing.Enabled() {
+			return fmt.Errorf("failed to sync wallet for blob stuffing: %w", err)
+		} else {
+			logger.WithError(err).Warn("Failed to sync wallet, blob stuffing unavailable")
+		}
+	}
+
+	if builderAPIAvailable {
+		// Pre-Gloas proposer settings resolve from Builder API validator
+		// registrations; the Gloas+ gossip-preferences resolver is registered
+		// in step 10 below. Both self-scope by fork, so the registration
+		// order is not load-bearing.
+		builderSvc.AddProposerSettingsResolver(
+			legacy.NewRegistrationSettingsResolver(validatorStore, chainSvc))
+
+		if cfg.BuilderAPI.PaymentTx {
+			// The wallet signs with the chain ID it learns on sync.
+			if err := w.Sync(ctx); err != nil {
+				return fmt.Errorf("failed to sync wallet for payment transactions: %w", err)
+			}
+
+			builderSvc.SetPaymentSealer(payload_builder.NewPaymentSealer(engineClient, rpcClient, w, logger))
+
+			logger.WithField("wallet", w.Address().Hex()).Info("Builder API proposer payments enabled")
+		}
+	}
+
+	// 9b. Start shared payment tracker, reveal service, and inclusion tracker.
+	// The inclusion tracker runs on ALL networks (it detects inclusion of our
+	// payloads and fires events carrying the won-block summary; won-block
+	// storage is owned by the slot results tracker); the reveal service and
+	// payment tracker only exist when Gloas is scheduled and the reveal
+	// module is enabled. These are independent of the epbs_enabled flag.
+	var paymentTracker *payload_bidder.PaymentTracker
+
+	var revealSvc *payload_bidder.RevealService
+
+	gloasScheduled := chainSpec.IsForkScheduled(version.DataVersionGloas)
+	epbsAvailable := gloasScheduled && b.modules.Enabled(config.ModuleEPBS)
+
+	if gloasScheduled && b.modules.Enabled(config.ModuleReveal) {
+		if err := requireSigningDomain(cfg, blsSigner, config.ModuleReveal, payload_bidder.DomainBeaconBuilder, "execution payload envelopes"); err != nil {
+			return err
+		}
+
+		// The pending payment ledger is persisted so won-but-unsettled bids
+		// survive restarts; reveals that happened while down are reconciled
+		// against the chain's payload envelopes.
+		paymentTracker = payload_bidder.NewPaymentTracker(chainSvc, logger)
+		paymentTracker.SetPersistence(ctx, stateDB)
+		paymentTracker.ReconcileLedger(ctx, clClient)
+
+		b.teardown = append(b.teardown, paymentTracker)
+
+		revealSigner := payload_bidder.NewSigner(blsSigner)
+		revealSigner.SetAuditor(signingAuditor)
+
+		revealSvc = payload_bidder.NewRevealService(cfg, revealSigner,
+			clClient, chainSvc, builderSvc, paymentTracker, planSvc,
+			chainSvc.GetHeadVoteTracker(), logger)
+		if b.degradations.Has(probe.FeatureHeadVotes) {
+			revealSvc.DisableVoteGates()
+		}
+
+		if err := revealSvc.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start reveal service: %w", err)
+		}
+
+		b.teardown = append(b.teardown, revealSvc)
+	}
+
+	b.paymentTracker = paymentTracker
+	b.revealSvc = revealSvc
+
+	inclusionTracker := payload_bidder.NewInclusionTracker(clClient, chainSvc, builderSvc, revealSvc, paymentTracker, logger)
+
+	// Seed our builder index when already registered; the lifecycle
+	// registration callback keeps it current.
+	if info := chainSvc.GetBuilderByPubkey(pubkey); info != nil {
+		inclusionTracker.SetBuilderIndex(info.Index)
+	}
+
+	if err := inclusionTracker.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start inclusion tracker: %w", err)
+	}
+
+	b.inclusionTracker = inclusionTracker
+	b.teardown = append(b.teardown, inclusionTracker)
+
+	// 10. Initialize the proposer preferences service (started later in step
+	// 20). Its per-slot store feeds the p2p bidder's bid gate, the Builder API
+	// epbs dialect's bid construction, and the payload builder's Gloas+
+	// proposer-settings resolution.
+	var propPrefSvc *payload_bidder.ProposerPreferencesService
+
+	if gloasScheduled && b.modules.Enabled(config.ModuleProposerPrefs) {
+		propPrefSvc = payload_bidder.NewProposerPreferencesService(clClient, chainSvc, logger)
+		propPrefSvc.GetStore().SetPersistence(ctx,
+			db.NewKVPersistence(stateDB, payload_bidder.ProposerPreferencesNamespace, payload_bidder.ProposerPreferencesCodec{}),
+			logger)
+		// Released after the state-db's own close (LIFO) → the store's final
+		// flush runs while the state-db is still open.
+		b.teardown = append(b.teardown, propPrefSvc.GetStore())
+
+		builderSvc.AddProposerSettingsResolver(propPrefSvc)
+	}
+
+	b.propPrefSvc = propPrefSvc
+
+	// 11. Initialize p2p bidder service (if Gloas fork is scheduled and the
+	// beacon node serves bids) and the peer mesh sharing its observed bids
+	// (started with it in step 18)
+	var (
+		epbsSvc  *p2p_bidder.Service
+		peerMesh *peer_mesh.Service
+	)
+
+	if epbsAvailable && !b.degradations.Has(probe.FeatureP2PBidding) {
+		if err := requireSigningDomain(cfg, blsSigner, config.ModuleEPBS, payload_bidder.DomainBeaconBuilder, "execution payload bids"); err != nil {
+			return err
+		}
+
+		gloasForkEpoch := chainSpec.GetForkEpoch(version.DataVersionGloas)
+		logger.WithField("gloas_fork_epoch", gloasForkEpoch).Info("Initializing p2p bidder service...")
+
+		epbsSvc, err = p2p_bidder.NewService(clClient, chainSvc, blsSigner, propPrefSvc.GetStore(), planSvc, logger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize p2p bidder: %w", err)
+		}
+
+		epbsSvc.SetEnabled(cfg.EPBSEnabled)
+		epbsSvc.SetSigningAuditor(signingAuditor)
+
+		// The lifecycle manager deposits with the wallet as withdrawal address.
+		switch {
+		case cfg.BuilderWithdrawalAddress != "":
+			addr := bellatrix.ExecutionAddress(common.HexToAddress(cfg.BuilderWithdrawalAddress))
+			epbsSvc.SetExpectedWithdrawalAddress(&addr)
+		case cfg.LifecycleEnabled && w != nil:
+			addr := bellatrix.ExecutionAddress(w.Address())
+			epbsSvc.SetExpectedWithdrawalAddress(&addr)
+		}
+
+		peerMesh = peer_mesh.NewService(&cfg.PeerMesh, chainSvc, epbsSvc, logger)
+
+		// Extra builder keys bid next to the builder key as distinct builders.
+		b.extraBuilders, err = newExtraBuilders(b, clClient, chainSvc, propPrefSvc, signingAuditor, rpcClient, pubkey)
+		if err != nil {
+			return err
+		}
+	} else if len(cfg.ExtraBuilders) > 0 {
+		logger.Warn("Extra builder keys configured but p2p bidding is unavailable; they stay unused")
+	}
+
+	b.epbsSvc = epbsSvc
+	b.peerMesh = peerMesh
+
+	// 12. Initialize Builder API server (routes served on --api-port via the shared server)
+	var builderAPISrv *builderapi.Server
+
+	if builderAPIAvailable {
+		if err := requireSigningDomain(cfg, blsSigner, config.ModuleBuilderAPI, signer.DomainApplicationBuilder, "builder bids"); err != nil {
+			return err
+		}
+
+		logger.Info("Initializing Builder API server...")
+
+		// Get genesis parameters from beacon client
+		g := chainSvc.GetGenesis()
+		if g == nil {
+			return fmt.Errorf("failed to get genesis from beacon node")
+		}
+
+		genesisForkVersion := g.GenesisForkVersion
+		genesisValidatorsRoot := g.GenesisValidatorsRoot
+
+		logger.WithFields(logrus.Fields{
+			"genesis_fork_version":    fmt.Sprintf("0x%x", genesisForkVersion[:]),
+			"genesis_validators_root": fmt.Sprintf("0x%x", genesisValidatorsRoot[:]),
+		}).Info("Using genesis parameters from beacon node")
+
+		builderAPISrv = builderapi.NewServer(&cfg.BuilderAPI, logger, chainSvc, planSvc, builderSvc.GetPayloadCache(), blsSigner, validatorStore)
+		builderAPISrv.SetCLClient(clClient)
+
+		// Unblinded blocks are additionally fanned out to these nodes.
+		publishNodes := make([]*beacon.Client, 0, len(cfg.BuilderAPI.PublishBeaconNodes))
+		for i, nodeURL := range cfg.BuilderAPI.PublishBeaconNodes {
+			node, err := beacon.NewClient(ctx, nodeURL, cfg.CLClientSSZ, logger)
+			if err != nil {
+				return fmt.Errorf("failed to set up publish beacon node %d: %w", i, err)
+			}
+
+			b.teardown = append(b.teardown, node)
+			publishNodes = append(publishNodes, node)
+		}
+
+		builderAPISrv.SetPublishNodes(publishNodes)
+		builderAPISrv.SetSigningAuditor(signingAuditor)
+		builderAPISrv.SetIdentity(&cfg.Identity)
+		builderAPISrv.SetEnabled(cfg.BuilderAPIEnabled)
+
+		// getHeader validates blobs bundles against their KZG proofs; load the
+		// trusted setup now rather than on the first proposer request.
+		go legacy.WarmUpKZG()
+
+		// Persist builder preferences (max_execution_payment) into the
+		// state-db's kv_store so they survive restarts.
+		builderAPISrv.GetBuilderPreferencesStore().SetPersistence(ctx, stateDB, logger)
+		b.teardown = append(b.teardown, builderAPISrv.GetBuilderPreferencesStore())
+
+		if revealSvc != nil {
+			builderAPISrv.SetRevealService(revealSvc)
+		}
+
+		if propPrefSvc != nil {
+			builderAPISrv.SetProposerPreferencesStore(propPrefSvc.GetStore())
+		}
+	}
+
+	b.builderAPISrv = builderAPISrv
+
+	// 12b. Start the slot results tracker: the single owner of per-slot
+	// outcome history (build/bid/submission/reveal/inclusion) and raw SSZ
+	// artifacts. Started before the producer services so its blocking
+	// subscriptions never miss an event; SetPersistence migrates any
+	// legacy won_blocks namespace into slot results.
+	resultTracker := slot_results.NewTracker(cfg, chainSvc, stateDB, planSvc,
+		builderSvc, epbsSvc, revealSvc, inclusionTracker, logger)
+	resultTracker.SetPersistence(ctx, stateDB)
+	resultTracker.SetSigningAuditor(signingAuditor)
+
+	if err := resultTracker.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start slot results tracker: %w", err)
+	}
+
+	// Released after the state-db's close (LIFO) → the artifact writer and
+	// the result store flush while the state-db is still open.
+	b.resultTracker = resultTracker
+	b.teardown = append(b.teardown, resultTracker)
+
+	if builderAPISrv != nil {
+		builderAPISrv.SetResultRecorder(resultTracker)
+		builderAPISrv.SetResultSource(resultTracker)
+	}
+
+	// 12c. Start the optional audit exporter: posts an HMAC-signed summary
+	// of each slot's recorded builder actions to an external service.
+	if cfg.AuditExport.URL != "" {
+		auditExporter := audit_export.NewExporter(&cfg.AuditExport, chainSvc, resultTracker, pubkey, logger)
+		if err := auditExporter.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start audit exporter: %w", err)
+		}
+
+		b.teardown = append(b.teardown, auditExporter)
+	}
+
+	// 12d. Start the epoch summary aggregator: folds each finished epoch's
+	// slot results and head vote participation into one summary event.
+	epochSummaries := epoch_summary.NewAggregator(chainSvc, resultTracker, logger)
+	if err := epochSummaries.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start epoch summary aggregator: %w", err)
+	}
+
+	b.epochSummaries = epochSummaries
+	b.teardown = append(b.teardown, epochSummaries)
+
+	// 12e. Start the optional alerting engine: evaluates the operator's
+	// threshold rules every slot; disable actions go through the settings
+	// service so they persist and show up in the UI like a manual toggle.
+	var alerts *alerting.Engine
+
+	if cfg.AlertRulesFile != "" {
+		rules, err := alerting.LoadRules(cfg.AlertRulesFile)
+		if err != nil {
+			return fmt.Errorf("failed to load alert rules: %w", err)
+		}
+
+		alerts = alerting.NewEngine(rules, chainSvc, resultTracker, settingsSvc, pubkey, logger)
+		if err := alerts.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start alerting engine: %w", err)
+		}
+
+		b.alerts = alerts
+		b.teardown = append(b.teardown, alerts)
+
+		if builderAPISrv != nil {
+			builderAPISrv.SetAlertEngine(alerts)
+		}
+	}
+
+	// 12f. Start the circuit breaker: disables p2p bidding / the Builder API
+	// after repeated bid submission or reveal failures.
+	var breaker *circuit_breaker.Breaker
+
+	if cfg.CircuitBreakerThreshold > 0 {
+		breaker = circuit_breaker.NewBreaker(cfg.CircuitBreakerThreshold, epbsSvc, revealSvc, settingsSvc, alerts, logger)
+		if err := breaker.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start circuit breaker: %w", err)
+		}
+
+		b.breaker = breaker
+		b.teardown = append(b.teardown, breaker)
+	}
+
+	// 12g. Start the liveness watchdog: checks every finished epoch's
+	// summary for built payloads and raises a critical alert when the build
+	// path went silent.
+	if cfg.Watchdog.Enabled {
+		liveness := watchdog.NewWatchdog(cfg, chainSvc, epochSummaries, planSvc, clClient.Events(), alerts, logger)
+		if err := liveness.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start liveness watchdog: %w", err)
+		}
+
+		b.teardown = append(b.teardown, liveness)
+	}
+
+	// 12h. Start the penalty monitor: bids with our builder indices for
+	// payloads we never built and proposer slashings of slots we bid in hold
+	// p2p bidding and Builder API bids until acknowledged.
+	if cfg.PenaltyMonitor && epbsSvc != nil {
+		builders := []penalty_monitor.Builder{epbsSvc}
+		holders := []penalty_monitor.BidHolder{epbsSvc}
+
+		for _, extra := range b.extraBuilders {
+			builders = append(builders, extra.bidder)
+			holders = append(holders, extra.bidder)
+		}
+
+		if builderAPISrv != nil {
+			holders = append(holders, builderAPISrv)
+		}
+
+		penalties := penalty_monitor.NewMonitor(chainSvc, clClient.Events(), builderSvc.GetPayloadCache(),
+			builders, holders, alerts, logger)
+		if err := penalties.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start penalty monitor: %w", err)
+		}
+
+		b.penalties = penalties
+		b.teardown = append(b.teardown, penalties)
+	}
+
+	// 12i. Initialize the relay proxy (routes served on --api-port under
+	// /relay-proxy): validator requests are forwarded to the configured
+	// relays and recorded.
+	var relayProxy *relay_proxy.Service
+
+	if len(cfg.RelayProxy.Relays) > 0 {
+		logger.WithField("relays", len(cfg.RelayProxy.Relays)).Info("Initializing relay proxy...")
+
+		relayProxy = relay_proxy.NewService(&cfg.RelayProxy, logger)
+		relayProxy.SetIdentity(&cfg.Identity)
+		b.relayProxy = relayProxy
+	}
+
+	// 12j. Start the validator registration backfill: each epoch's proposers
+	// are looked up on the configured relays and registrations newer than
+	// ours are merged into the registration store (needs the Builder API).
+	if len(cfg.BuilderAPI.BackfillRelays) > 0 {
+		if validatorStore == nil {
+			logger.Warn("--builder-api-backfill-relays requires --api-port; registration backfill disabled")
+		} else {
+			backfill := legacy.NewRegistrationBackfill(cfg.BuilderAPI.BackfillRelays, validatorStore, chainSvc, logger)
+			backfill.SetPersistence(ctx, stateDB)
+
+			if err := backfill.Start(ctx); err != nil {
+				return fmt.Errorf("failed to start registration backfill: %w", err)
+			}
+
+			b.regBackfill = backfill
+			b.teardown = append(b.teardown, backfill)
+		}
+	}
+
+	// 12k. Start the decision journal: records why each slot was or was not
+	// built, bid on and revealed, served at /api/slots/{slot}/decisions.
+	decisions := audit.NewJournal(cfg, chainSvc, builderSvc, epbsSvc, revealSvc, logger)
+	decisions.SetPersistence(ctx, stateDB)
+
+	if err := decisions.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start decision journal: %w", err)
+	}
+
+	b.decisions = decisions
+	b.teardown = append(b.teardown, decisions)
+
+	// 12l. Start the optional event recorder: appends head and
+	// payload_attributes events to --record-events for replay by
+	// `buildoor simulate --replay`.
+	if cfg.RecordEventsFile != "" {
+		recorder := recording.NewRecorder(cfg.RecordEventsFile, clClient.Events(), chainSvc, logger)
+		if err := recorder.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start event recorder: %w", err)
+		}
+
+		b.teardown = append(b.teardown, recorder)
+	}
+
+	// 13. Initialize and start validator ranges resolver.
+	valRanges := validatorranges.NewResolver(&cfg.ValidatorRanges, logger)
+	valRanges.Start(ctx)
+
+	// 14. Register settings OnChange subscribers: route changes through the
+	// modules. The settings service has already mutated cfg in place; these
+	// callbacks trigger module-side resets (schedule counters, scheduler) and
+	// sync the enable flags.
+	settingsSvc.OnChange(func() {
+		// The plan service is the scheduling authority: schedule-mode
+		// changes reset its next_n accounting.
+		planSvc.UpdateConfig()
+
+		if err := builderSvc.UpdateConfig(cfg); err != nil {
+			logger.WithError(err).Warn("failed to apply builder config update")
+		}
+
+		if epbsSvc != nil {
+			epbsSvc.SetEnabled(cfg.EPBSEnabled)
+		}
+
+		if builderAPISrv != nil {
+			builderAPISrv.SetEnabled(cfg.BuilderAPIEnabled)
+		}
+
+		if lifecycleMgr != nil {
+			lifecycleMgr.SetEnabled(cfg.LifecycleEnabled)
+		}
+
+		for _, extra := range b.extraBuilders {
+			extra.bidder.SetEnabled(cfg.EPBSEnabled)
+			extra.lifecycleMgr.SetEnabled(cfg.LifecycleEnabled)
+		}
+	})
+
+	// 14b. Assemble and print the preflight report (fork, preset,
+	// registration, wallet balances, services, timing, warnings), served at
+	// /api/status/preflight.
+	b.preflight = b.buildPreflight(ctx, pubkey, w)
+	b.logPreflight(b.preflight)
+
+	// 15. Start WebUI/API server (if configured)
+	if cfg.APIPort > 0 {
+		logger.WithField("port", cfg.APIPort).Info("Starting API server...")
+
+		apiHandler, httpSrv := webui.StartHttpServer(&types.FrontendConfig{
+			Port:     cfg.APIPort,
+			Host:     "0.0.0.0",
+			SiteName: "Buildoor",
+			Debug:    cfg.Debug,
+			Pprof:    cfg.Pprof,
+			Minify:   !cfg.Debug,
+
+			AuthProviderURL: cfg.AuthProviderURL,
+			InjectHeadHTML:  cfg.InjectHeadHTML,
+			OverviewURL:     cfg.OverviewURL,
+		}, settingsSvc, stateDB, builderSvc, epbsSvc, lifecycleMgr, chainSvc, validatorStore, builderAPISrv, propPrefSvc, valRanges, revealSvc, inclusionTracker, paymentTracker, planSvc, resultTracker, peerMesh, b.logBuffer, epochSummaries, alerts, breaker, relayProxy)
+
+		apiHandler.SetExtraBuilders(b.extraBuilderIdentities())
+		apiHandler.SetDegradations(b.degradations)
+		apiHandler.SetPreflight(b.preflight)
+		apiHandler.SetPenaltyMonitor(b.penalties)
+		apiHandler.SetRegistrationBackfill(b.regBackfill)
+		apiHandler.SetDecisionJournal(b.decisions)
+
+		if eventStreamMgr := apiHandler.GetEventStreamManager(); eventStreamMgr != nil {
+			b.teardown = append(b.teardown, eventStreamMgr)
+			b.broadcastLifecycle(eventStreamMgr)
+
+			// Connect Builder API server to event stream (if both are enabled)
+			if builderAPISrv != nil {
+				builderAPISrv.SetEventBroadcaster(eventStreamMgr)
+				logger.Info("Connected Builder API server to WebUI event stream")
+			}
+		}
+
+		b.teardown = append(b.teardown, httpSrv)
+	}
+
+	// 16. Wire lifecycle manager callbacks to ePBS (if both present)
+	if lifecycleMgr != nil && epbsSvc != nil {
+		lifecycleMgr.SetDepositPendingCallback(func() {
+			epbsSvc.SetRegistrationPending()
+		})
+		lifecycleMgr.SetOutstandingBidsFunc(epbsSvc.OutstandingBidSlots)
+		lifecycleMgr.SetRegistrationCallback(b.setBuilderIndex)
+	}
+
+	if len(b.extraBuilders) > 0 {
+		b.wireExtraBuilders(ctx)
+	}
+
+	// 16b. Follow runtime fork transitions (builder index on entering Gloas)
+	if gloasScheduled {
+		b.watchForkTransitions(ctx, pubkey)
+	}
+
+	// 17. Start builder service
+	logger.Info("Starting builder service...")
+
+	if err := builderSvc.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start builder: %w", err)
+	}
+
+	b.teardown = append(b.teardown, builderSvc)
+
+	// 18. Start p2p bidder service (if available)
+	if epbsSvc != nil {
+		logger.Info("Starting p2p bidder service...")
+
+		if err := epbsSvc.Start(ctx, builderSvc); err != nil {
+			return fmt.Errorf("failed to start p2p bidder: %w", err)
+		}
+
+		b.teardown = append(b.teardown, epbsSvc)
+
+		for _, extra := range b.extraBuilders {
+			if err := extra.bidder.Start(ctx, builderSvc); err != nil {
+				return fmt.Errorf("failed to start p2p bidder of extra builder: %w", err)
+			}
+
+			b.teardown = append(b.teardown, extra.bidder)
+		}
+
+		if err := peerMesh.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start peer mesh: %w", err)
+		}
+
+		b.teardown = append(b.teardown, peerMesh)
+	}
+
+	// 19. Start lifecycle manager
+	if lifecycleMgr != nil {
+		if paymentTracker != nil {
+			lifecycleMgr.SetPaymentTracker(paymentTracker)
+		}
+
+		if err := lifecycleMgr.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start lifecycle manager: %w", err)
+		}
+
+		b.teardown = append(b.teardown, lifecycleMgr)
+	}
+
+	// Extra builders' lifecycle managers: the payment ledger tracks the
+	// builder key's wins only, so they monitor balances without it.
+	for _, extra := range b.extraBuilders {
+		extra.lifecycleMgr.SetPaymentTracker(nil)
+
+		if err := extra.lifecycleMgr.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start lifecycle manager of extra builder: %w", err)
+		}
+
+		b.teardown = append(b.teardown, extra.lifecycleMgr)
+	}
+
+	// 20. Start proposer preferences service (if initialized)
+	if propPrefSvc != nil {
+		if err := propPrefSvc.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start proposer preferences service: %w", err)
+		}
+
+		b.teardown = append(b.teardown, propPrefSvc)
+
+		logger.Info("Proposer preferences SSE listener started")
+	}
+
+	return nil
+}
+
+// Config returns the effective configuration (after slot defaults and
+// persisted overrides once started).
+func (b *Buildoor) Config() *config.Config {
+	return b.cfg
+}
+
+// Settings returns the central settings service.
+func (b *Buildoor) Settings() *config.Service {
+	return b.settingsSvc
+}
+
+// Preflight returns the startup report (nil before Start).
+func (b *Buildoor) Preflight() *preflight.Report {
+	return b.preflight
+}
+
+// ChainService returns the chain service.
+func (b *Buildoor) ChainService() chain.Service {
+	return b.chainSvc
+}
+
+// PlanService returns the per-slot action plan service.
+func (b *Buildoor) PlanService() *action_plan.PlanService {
+	return b.planSvc
+}
+
+// PayloadBuilder returns the payload builder service.
+func (b *Buildoor) PayloadBuilder() *payload_builder.Service {
+	return b.builderSvc
+}
+
+// P2PBidder returns the p2p bidder service (nil when Gloas is not scheduled).
+func (b *Buildoor) P2PBidder() *p2p_bidder.Service {
+	return b.epbsSvc
+}
+
+// BuilderAPI returns the Builder API server (nil when the API port is 0).
+func (b *Buildoor) BuilderAPI() *builderapi.Server {
+	return b.builderAPISrv
+}
+
+// SlotResults returns the slot results tracker.
+func (b *Buildoor) SlotResults() *slot_results.Tracker {
+	return b.resultTracker
+}
+
+// Decisions returns the per-slot decision journal.
+func (b *Buildoor) Decisions() *audit.Journal {
+	return b.decisions
+}
+
+// RevealService returns the reveal service (nil when Gloas is not scheduled).
+func (b *Buildoor) RevealService() *payload_bidder.RevealService {
+	return b.revealSvc
+}
+
+// InclusionTracker returns the payload inclusion tracker.
+func (b *Buildoor) InclusionTracker() *payload_bidder.InclusionTracker {
+	return b.inclusionTracker
+}
+
+// EpochSummaries returns the epoch summary aggregator.
+func (b *Buildoor) EpochSummaries() *epoch_summary.Aggregator {
+	return b.epochSummaries
+}
+
+// Alerts returns the alerting engine, or nil without a rules file.
+func (b *Buildoor) Alerts() *alerting.Engine {
+	return b.alerts
+}
+
+// CircuitBreaker returns the circuit breaker, or nil when disabled.
+func (b *Buildoor) CircuitBreaker() *circuit_breaker.Breaker {
+	return b.breaker
+}
+
+// PenaltyMonitor returns the penalty monitor, or nil when disabled.
+func (b *Buildoor) PenaltyMonitor() *penalty_monitor.Monitor {
+	return b.penalties
+}
+
+// Event hooks. All subscriptions are non-blocking: a consumer that falls
+// behind its channel capacity misses events instead of stalling the builder.
+
+// SubscribePayloadReady subscribes to built payloads.
+func (b *Buildoor) SubscribePayloadReady(capacity int) *utils.Subscription[*payload_builder.Payload] {
+	return b.builderSvc.SubscribePayloadReady(capacity, false)
+}
+
+// SubscribeBidSubmissions subscribes to p2p bid submissions. Without a p2p
+// bidder the subscription never fires.
+func (b *Buildoor) SubscribeBidSubmissions(capacity int) *utils.Subscription[*p2p_bidder.BidSubmissionEvent] {
+	if b.epbsSvc == nil {
+		return (&utils.Dispatcher[*p2p_bidder.BidSubmissionEvent]{}).Subscribe(capacity, false)
+	}
+
+	return b.epbsSvc.SubscribeBidSubmissions(capacity, false)
+}
+
+// SubscribeRevealResults subscribes to payload reveal outcomes. Without a
+// reveal service the subscription never fires.
+func (b *Buildoor) SubscribeRevealResults(capacity int) *utils.Subscription[*payload_bidder.RevealResult] {
+	if b.revealSvc == nil {
+		return (&utils.Dispatcher[*payload_bidder.RevealResult]{}).Subscribe(capacity, false)
+	}
+
+	return b.revealSvc.SubscribeResults(capacity, false)
+}
+
+// SubscribePayloadIncluded subscribes to on-chain inclusions of our payloads.
+func (b *Buildoor) SubscribePayloadIncluded(capacity int) *utils.Subscription[*payload_bidder.PayloadIncludedEvent] {
+	return b.inclusionTracker.SubscribeIncluded(capacity, false)
+}
+
+// SubscribeSlotResults subscribes to per-slot result updates.
+func (b *Buildoor) SubscribeSlotResults(capacity int) *utils.Subscription[*slot_results.SlotResult] {
+	return b.resultTracker.SubscribeUpdates(capacity)
+}
+
+// SubscribeEpochSummaries subscribes to finished-epoch summaries.
+func (b *Buildoor) SubscribeEpochSummaries(capacity int) *utils.Subscription[*epoch_summary.EpochSummary] {
+	return b.epochSummaries.SubscribeSummaries(capacity)
+}
+
+// SubscribeAlerts subscribes to alert transitions. Without a rules file the
+// subscription never fires.
+func (b *Buildoor) SubscribeAlerts(capacity int) *utils.Subscription[*alerting.Alert] {
+	if b.alerts == nil {
+		return (&utils.Dispatcher[*alerting.Alert]{}).Subscribe(capacity, false)
+	}
+
+	return b.alerts.SubscribeAlerts(capacity)
+}
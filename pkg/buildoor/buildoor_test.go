@@ -0,0 +1,109 @@
+package buildoor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func validConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.BuilderPrivkey = "0x3f6b8c0e0d4bd6d5c5a1e8d1f0c9b7a6e5d4c3b2a19080706050403020100f0e"
+	cfg.CLClient = "http://localhost:5052"
+	cfg.ELEngineAPI = "http://localhost:8551"
+	cfg.ELJWTSecret = "/tmp/jwtsecret"
+
+	return cfg
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+	}{
+		{"missing builder key", func(cfg *config.Config) { cfg.BuilderPrivkey = "" }},
+		{"privkey and mnemonic", func(cfg *config.Config) { cfg.BuilderMnemonic = "test test" }},
+		{"missing cl client", func(cfg *config.Config) { cfg.CLClient = "" }},
+		{"missing engine api", func(cfg *config.Config) { cfg.ELEngineAPI = "" }},
+		{"missing jwt secret", func(cfg *config.Config) { cfg.ELJWTSecret = "" }},
+		{"lifecycle without wallet", func(cfg *config.Config) { cfg.LifecycleEnabled = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			_, err := New(cfg, logrus.New())
+			require.Error(t, err)
+		})
+	}
+
+	b, err := New(validConfig(), logrus.New())
+	require.NoError(t, err)
+	require.NotNil(t, b.Config())
+}
+
+type recorder struct {
+	name  string
+	order *[]string
+}
+
+type stopper struct{ recorder }
+
+func (s *stopper) Stop() { *s.order = append(*s.order, s.name) }
+
+type closer struct{ recorder }
+
+func (c *closer) Close() error {
+	*c.order = append(*c.order, c.name)
+	return errors.New("ignored")
+}
+
+func TestStopReleasesInReverseOrder(t *testing.T) {
+	var order []string
+
+	b, err := New(validConfig(), logrus.New())
+	require.NoError(t, err)
+
+	b.teardown = []any{
+		&closer{recorder{"client", &order}},
+		&stopper{recorder{"store", &order}},
+		&stopper{recorder{"service", &order}},
+	}
+
+	b.Stop()
+	require.Equal(t, []string{"service", "store", "client"}, order)
+
+	// A second Stop is a no-op.
+	b.Stop()
+	require.Len(t, order, 3)
+}
+
+// depositOnlySigner can only sign validator deposits.
+type depositOnlySigner struct{ signer.Signer }
+
+func (depositOnlySigner) CanSign(domainType phase0.DomainType) bool {
+	return domainType == signer.DomainDeposit
+}
+
+func TestRequireSigningDomain(t *testing.T) {
+	cfg := validConfig()
+	cfg.Signer.Backend = signer.BackendWeb3Signer
+
+	key, err := signer.NewBLSSigner(cfg.BuilderPrivkey)
+	require.NoError(t, err)
+
+	require.NoError(t, requireSigningDomain(cfg, key, config.ModuleBuilderAPI, signer.DomainApplicationBuilder, "builder bids"))
+	require.NoError(t, requireSigningDomain(cfg, depositOnlySigner{key}, config.ModuleLifecycle, signer.DomainDeposit, "deposits"))
+
+	err = requireSigningDomain(cfg, depositOnlySigner{key}, config.ModuleBuilderAPI, signer.DomainApplicationBuilder, "builder bids")
+	require.ErrorContains(t, err, `signer backend "web3signer" cannot sign builder bids`)
+	require.ErrorContains(t, err, `"builder_api" module`)
+}
@@ -0,0 +1,39 @@
+package buildoor
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/probe"
+)
+
+// capabilityProbeTimeout bounds each request of the startup capability check.
+const capabilityProbeTimeout = 5 * time.Second
+
+// checkCapabilities probes the beacon node and returns the optional features
+// to disable for it. A failed probe keeps every feature enabled.
+func (b *Buildoor) checkCapabilities(ctx context.Context, gloas bool) probe.Degradations {
+	report, err := probe.NewProber(b.cfg.CLClient, capabilityProbeTimeout).Run(ctx)
+	if err != nil {
+		b.log.WithError(err).Warn("Beacon node capability check failed, keeping all features enabled")
+		return nil
+	}
+
+	degradations := report.Degradations(gloas)
+
+	for _, d := range degradations {
+		b.log.WithFields(logrus.Fields{
+			"feature":           d.Feature,
+			"missing_topics":    d.MissingTopics,
+			"missing_endpoints": d.MissingEndpoints,
+		}).Warn("Beacon node lacks a prerequisite, running degraded: " + d.Effect)
+	}
+
+	if len(degradations) == 0 {
+		b.log.WithField("version", report.Version).Info("Beacon node supports all optional features")
+	}
+
+	return degradations
+}
@@ -0,0 +1,236 @@
+package buildoor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/lifecycle"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+	"github.com/ethpandaops/buildoor/pkg/wallet"
+	"github.com/ethpandaops/buildoor/pkg/webui/handlers/api"
+)
+
+// extraBuilder is one extra builder key (cfg.ExtraBuilders): it bids in the
+// same slots as the primary builder through its own p2p bidder (own bid
+// tracker, own registration state) and is registered and funded by its own
+// lifecycle manager, read-only without a wallet.
+type extraBuilder struct {
+	revealSigner *payload_bidder.Signer
+	bidder       *p2p_bidder.Service
+	lifecycleMgr *lifecycle.Manager
+}
+
+// newExtraBuilders creates the extra builder keys' signers, wallets, p2p
+// bidders and lifecycle managers. rpcClient is required for extra builders
+// with a wallet key.
+func newExtraBuilders(
+	b *Buildoor,
+	clClient *beacon.Client,
+	chainSvc chain.Service,
+	propPrefSvc *payload_bidder.ProposerPreferencesService,
+	signingAuditor *payload_bidder.SigningAuditor,
+	rpcClient *execution.Client,
+	primary phase0.BLSPubKey,
+) ([]*extraBuilder, error) {
+	cfg := b.cfg
+	seen := map[phase0.BLSPubKey]bool{primary: true}
+	extras := make([]*extraBuilder, 0, len(cfg.ExtraBuilders))
+
+	for i, extraCfg := range cfg.ExtraBuilders {
+		blsSigner, err := signer.NewBLSSigner(extraCfg.Privkey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extra builder key %d: %w", i, err)
+		}
+
+		pubkey := blsSigner.PublicKey()
+		if seen[pubkey] {
+			return nil, fmt.Errorf("extra builder key %d duplicates another builder key", i)
+		}
+
+		seen[pubkey] = true
+
+		log := b.log.WithField("builder", fmt.Sprintf("%x", pubkey[:8]))
+
+		bidder, err := p2p_bidder.NewService(clClient, chainSvc, blsSigner, propPrefSvc.GetStore(), b.planSvc, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize p2p bidder of extra builder %d: %w", i, err)
+		}
+
+		bidder.SetEnabled(cfg.EPBSEnabled)
+		bidder.SetSigningAuditor(signingAuditor)
+
+		var lifecycleMgr *lifecycle.Manager
+
+		if extraCfg.WalletPrivkey != "" {
+			if rpcClient == nil {
+				return nil, fmt.Errorf("extra builder %d has a wallet key but no EL RPC is configured", i)
+			}
+
+			w, err := wallet.NewWallet(extraCfg.WalletPrivkey, rpcClient, log)
+			if err != nil {
+				return nil, fmt.Errorf("invalid wallet key of extra builder %d: %w", i, err)
+			}
+
+			w.SetClock(chainSvc.Clock())
+
+			lifecycleMgr, err = lifecycle.NewManager(cfg, clClient, chainSvc, blsSigner, w, log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize lifecycle of extra builder %d: %w", i, err)
+			}
+
+			lifecycleMgr.SetEnabled(cfg.LifecycleEnabled)
+
+			if cfg.LifecycleEnabled && !lifecycleMgr.IsReadOnly() {
+				addr := bellatrix.ExecutionAddress(w.Address())
+				bidder.SetExpectedWithdrawalAddress(&addr)
+			}
+		} else {
+			lifecycleMgr = lifecycle.NewReadOnlyManager(cfg, clClient, chainSvc, blsSigner, nil, log)
+		}
+
+		revealSigner := payload_bidder.NewSigner(blsSigner)
+		revealSigner.SetAuditor(signingAuditor)
+
+		extras = append(extras, &extraBuilder{
+			revealSigner: revealSigner,
+			bidder:       bidder,
+			lifecycleMgr: lifecycleMgr,
+		})
+
+		log.WithField("wallet", extraCfg.WalletPrivkey != "").Info("Extra builder key loaded")
+	}
+
+	return extras, nil
+}
+
+// wireExtraBuilders connects the extra builders' registrations to their p2p
+// bidder, the reveal service and the inclusion tracker, and keeps every
+// builder's sibling indices current (each epoch and on registration).
+func (b *Buildoor) wireExtraBuilders(ctx context.Context) {
+	for _, extra := range b.extraBuilders {
+		extra.lifecycleMgr.SetDepositPendingCallback(extra.bidder.SetRegistrationPending)
+		extra.lifecycleMgr.SetOutstandingBidsFunc(extra.bidder.OutstandingBidSlots)
+		extra.lifecycleMgr.SetRegistrationCallback(func(index uint64) {
+			extra.bidder.SetBuilderRegistered(index)
+			b.syncBuilderIndices()
+		})
+	}
+
+	b.syncBuilderIndices()
+
+	epochSub := b.chainSvc.SubscribeEpochStats()
+
+	go func() {
+		defer epochSub.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-epochSub.Channel():
+				if !ok {
+					return
+				}
+
+				b.syncBuilderIndices()
+			}
+		}
+	}()
+}
+
+// syncBuilderIndices resolves the builder indices of all builder keys from
+// the beacon state: extra builders' indices are registered with the reveal
+// service and inclusion tracker, and every p2p bidder learns its siblings'
+// indices so our builders never outbid each other.
+func (b *Buildoor) syncBuilderIndices() {
+	if b.epbsSvc == nil {
+		return
+	}
+
+	bidders := make([]*p2p_bidder.Service, 0, 1+len(b.extraBuilders))
+	bidders = append(bidders, b.epbsSvc)
+
+	for _, extra := range b.extraBuilders {
+		bidders = append(bidders, extra.bidder)
+	}
+
+	indices := make(map[*p2p_bidder.Service]uint64, len(bidders))
+
+	for _, bidder := range bidders {
+		if info := b.chainSvc.GetBuilderByPubkey(bidder.GetBuilderPubkey()); info != nil {
+			indices[bidder] = info.Index
+		}
+	}
+
+	for _, extra := range b.extraBuilders {
+		index, ok := indices[extra.bidder]
+		if !ok {
+			continue
+		}
+
+		if b.revealSvc != nil {
+			b.revealSvc.SetExtraBuilderSigner(index, extra.revealSigner)
+		}
+
+		b.inclusionTracker.AddExtraBuilderIndex(index)
+	}
+
+	for _, bidder := range bidders {
+		siblings := make([]uint64, 0, len(indices))
+
+		for other, index := range indices {
+			if other != bidder {
+				siblings = append(siblings, index)
+			}
+		}
+
+		bidder.SetSiblingIndices(siblings)
+	}
+}
+
+// broadcastLifecycle routes the extra builders' lifecycle events to the WebUI
+// event stream, prefixed with the builder's pubkey (also set as the
+// builder_pubkey param).
+func (b *Buildoor) broadcastLifecycle(eventStreamMgr *api.EventStreamManager) {
+	for _, extra := range b.extraBuilders {
+		pubkey := extra.bidder.GetBuilderPubkey()
+		prefix := fmt.Sprintf("Builder %x…: ", pubkey[:4])
+
+		extra.lifecycleMgr.SetEventCallback(func(event *lifecycle.LifecycleEvent) {
+			params := make(map[string]any, len(event.Params)+1)
+			maps.Copy(params, event.Params)
+			params["builder_pubkey"] = pubkey.String()
+
+			eventStreamMgr.BroadcastLifecycle(api.LifecycleStreamEvent{
+				Action:  event.Action,
+				Code:    event.Code,
+				Params:  params,
+				Message: prefix + event.Message,
+				Status:  event.Status,
+			})
+		})
+	}
+}
+
+// extraBuilderIdentities lists the extra builders for the WebUI.
+func (b *Buildoor) extraBuilderIdentities() []api.BuilderIdentity {
+	identities := make([]api.BuilderIdentity, 0, len(b.extraBuilders))
+
+	for _, extra := range b.extraBuilders {
+		identities = append(identities, api.BuilderIdentity{
+			Bidder:    extra.bidder,
+			Lifecycle: extra.lifecycleMgr,
+		})
+	}
+
+	return identities
+}
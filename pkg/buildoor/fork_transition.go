@@ -0,0 +1,71 @@
+package buildoor
+
+import (
+	"context"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+)
+
+// setBuilderIndex hands the builder key's on-chain index to every Gloas
+// consumer: the p2p bidder, the Builder API's bid construction, the reveal
+// service and the inclusion tracker. Idempotent.
+func (b *Buildoor) setBuilderIndex(index uint64) {
+	if b.epbsSvc != nil {
+		b.epbsSvc.SetBuilderRegistered(index)
+	}
+
+	if b.builderAPISrv != nil {
+		b.builderAPISrv.SetBuilderIndex(index)
+	}
+
+	if b.revealSvc != nil {
+		b.revealSvc.SetBuilderIndex(index)
+	}
+
+	b.inclusionTracker.SetBuilderIndex(index)
+	b.syncBuilderIndices()
+}
+
+// watchForkTransitions follows the chain across fork boundaries at runtime.
+// The build path, the Builder API dialects and the bid gates already pick
+// the fork per slot; what a pre-Gloas start cannot know is the builder index,
+// as the builder registry only exists from the first Gloas state on (the
+// fork upgrade imports pre-fork builder deposits). On entering Gloas the
+// index is resolved and handed out like a lifecycle registration.
+func (b *Buildoor) watchForkTransitions(ctx context.Context, pubkey phase0.BLSPubKey) {
+	sub := b.chainSvc.SubscribeForkTransitions()
+
+	go func() {
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case transition, ok := <-sub.Channel():
+				if !ok {
+					return
+				}
+
+				if transition.Previous >= version.DataVersionGloas || transition.Current < version.DataVersionGloas {
+					continue
+				}
+
+				info := b.chainSvc.GetBuilderByPubkey(pubkey)
+				if info == nil {
+					b.log.WithField("epoch", transition.Epoch).Info("Gloas fork active, builder not registered yet")
+					continue
+				}
+
+				b.log.WithFields(logrus.Fields{
+					"epoch":         transition.Epoch,
+					"builder_index": info.Index,
+				}).Info("Gloas fork active, builder registered")
+
+				b.setBuilderIndex(info.Index)
+			}
+		}
+	}()
+}
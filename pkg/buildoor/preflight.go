@@ -0,0 +1,182 @@
+package buildoor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/preflight"
+	"github.com/ethpandaops/buildoor/pkg/wallet"
+	buildversion "github.com/ethpandaops/buildoor/version"
+)
+
+// walletBalanceTimeout bounds each wallet balance read of the preflight
+// report.
+const walletBalanceTimeout = 5 * time.Second
+
+// buildPreflight assembles the startup report from the wired services: w is
+// the builder's wallet (nil without lifecycle prerequisites).
+func (b *Buildoor) buildPreflight(ctx context.Context, pubkey phase0.BLSPubKey, w *wallet.Wallet) *preflight.Report {
+	cfg := b.cfg
+	spec := b.chainSvc.GetChainSpec()
+
+	report := &preflight.Report{
+		GeneratedAt: time.Now().UTC(),
+		Version:     buildversion.GetBuildVersion(),
+		Fork:        b.chainSvc.GetCurrentFork().String(),
+		Preset:      spec.PresetBase,
+		ConfigName:  spec.ConfigName,
+		Registration: preflight.Registration{
+			BuilderPubkey: pubkey.String(),
+		},
+		Timing: preflight.Timing{
+			SlotTimeMs:       spec.SecondsPerSlot.Milliseconds(),
+			BuildStartTime:   cfg.EPBS.BuildStartTime,
+			PayloadBuildTime: cfg.PayloadBuildTime,
+			BidStartTime:     cfg.EPBS.BidStartTime,
+			BidEndTime:       cfg.EPBS.BidEndTime,
+			BidInterval:      cfg.EPBS.BidInterval,
+			RevealTime:       cfg.Reveal.TimeMs,
+		},
+		Warnings: []string{},
+	}
+
+	if spec.IsForkScheduled(version.DataVersionGloas) {
+		epoch := uint64(spec.GetForkEpoch(version.DataVersionGloas))
+		report.GloasEpoch = &epoch
+	}
+
+	if info := b.chainSvc.GetBuilderByPubkey(pubkey); info != nil {
+		report.Registration.Registered = true
+		report.Registration.BuilderIndex = info.Index
+		report.Registration.BalanceGwei = info.Balance
+		report.Registration.Active = info.Active
+	}
+
+	if w != nil {
+		report.Wallets = append(report.Wallets, walletBalance(ctx, "builder", w))
+	}
+
+	for i, extra := range b.extraBuilders {
+		if extraWallet := extra.lifecycleMgr.GetWallet(); extraWallet != nil {
+			report.Wallets = append(report.Wallets, walletBalance(ctx, fmt.Sprintf("extra-%d", i), extraWallet))
+		}
+	}
+
+	report.Services = b.preflightServices()
+
+	for _, d := range b.degradations {
+		report.Warn("beacon node lacks %s: %s", d.Feature, d.Effect)
+	}
+
+	report.Check()
+
+	return report
+}
+
+// moduleDisabled is the preflight detail of services left out by --modules.
+const moduleDisabled = "module disabled"
+
+// preflightServices lists every optional service and whether it runs, with
+// the reason it does not where that is not just configuration.
+func (b *Buildoor) preflightServices() []preflight.Service {
+	cfg := b.cfg
+
+	epbs := preflight.Service{Name: preflight.ServiceEPBS, Enabled: b.epbsSvc != nil && cfg.EPBSEnabled}
+	switch {
+	case !b.modules.Enabled(config.ModuleEPBS):
+		epbs.Detail = moduleDisabled
+	case b.epbsSvc != nil && len(b.extraBuilders) > 0:
+		epbs.Detail = fmt.Sprintf("%d extra builder keys", len(b.extraBuilders))
+	case b.epbsSvc == nil && !b.chainSvc.GetChainSpec().IsForkScheduled(version.DataVersionGloas):
+		epbs.Detail = "gloas not scheduled"
+	case b.epbsSvc == nil:
+		epbs.Detail = "beacon node lacks the bid endpoints"
+	}
+
+	builderAPI := preflight.Service{Name: preflight.ServiceBuilderAPI, Enabled: b.builderAPISrv != nil && cfg.BuilderAPIEnabled}
+	switch {
+	case !b.modules.Enabled(config.ModuleBuilderAPI):
+		builderAPI.Detail = moduleDisabled
+	case b.builderAPISrv == nil:
+		builderAPI.Detail = "requires --api-port"
+	}
+
+	lifecycle := preflight.Service{Name: preflight.ServiceLifecycle, Enabled: b.lifecycleMgr != nil && cfg.LifecycleEnabled}
+	switch {
+	case !b.modules.Enabled(config.ModuleLifecycle):
+		lifecycle.Detail = moduleDisabled
+	case cfg.LifecycleReadOnly:
+		lifecycle.Detail = "read-only tracking"
+	case b.lifecycleMgr == nil:
+		lifecycle.Detail = "requires --el-rpc and a wallet key"
+	}
+
+	services := []preflight.Service{
+		{Name: preflight.ServiceBuilder, Enabled: true, Detail: fmt.Sprintf("%d engine API endpoints", 1+len(cfg.ELEngineRaceAPIs))},
+		epbs,
+		builderAPI,
+		lifecycle,
+		{
+			Name:    preflight.ServicePeerMesh,
+			Enabled: b.peerMesh != nil && len(cfg.PeerMesh.Peers) > 0,
+			Detail:  fmt.Sprintf("%d peers", len(cfg.PeerMesh.Peers)),
+		},
+		{
+			Name:    preflight.ServiceRelayProxy,
+			Enabled: b.relayProxy != nil,
+			Detail:  fmt.Sprintf("%d relays", len(cfg.RelayProxy.Relays)),
+		},
+		{Name: preflight.ServiceAlerting, Enabled: b.alerts != nil},
+		{Name: preflight.ServiceBreaker, Enabled: b.breaker != nil},
+		{Name: preflight.ServicePenalties, Enabled: b.penalties != nil},
+		{Name: preflight.ServiceWebUI, Enabled: cfg.APIPort > 0},
+	}
+
+	if cfg.APIPort > 0 {
+		services[len(services)-1].Detail = fmt.Sprintf("port %d", cfg.APIPort)
+	}
+
+	return services
+}
+
+// logPreflight prints the rendered report as one log entry, at warning level
+// when it carries warnings.
+func (b *Buildoor) logPreflight(report *preflight.Report) {
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		b.log.WithError(err).Warn("failed to render preflight report")
+		return
+	}
+
+	logf := b.log.Info
+	if len(report.Warnings) > 0 {
+		logf = b.log.Warn
+	}
+
+	logf("Preflight report:\n" + strings.TrimRight(buf.String(), "\n"))
+}
+
+// walletBalance reads the balance of one wallet for the preflight report.
+func walletBalance(ctx context.Context, label string, w *wallet.Wallet) preflight.Wallet {
+	entry := preflight.Wallet{Label: label, Address: w.Address().Hex()}
+
+	ctx, cancel := context.WithTimeout(ctx, walletBalanceTimeout)
+	defer cancel()
+
+	balance, err := w.GetBalance(ctx)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	entry.BalanceWei = balance.String()
+
+	return entry
+}
@@ -0,0 +1,55 @@
+// Package chaintest provides chain.Service stubs for tests.
+package chaintest
+
+import (
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
+)
+
+const (
+	// SecondsPerSlot is the slot duration of Clocked.
+	SecondsPerSlot = 12
+	// SlotsPerEpoch is the epoch length of Clocked.
+	SlotsPerEpoch = 32
+)
+
+// Clocked derives the current slot from a fake clock (12s slots from genesis
+// at the Unix epoch, 32 slots per epoch). Methods it does not override panic
+// through the nil embedded Service; embed Clocked to add more.
+type Clocked struct {
+	chain.Service
+
+	Clk *clock.Fake
+}
+
+// NewClocked returns a Clocked chain whose fake clock starts at now.
+func NewClocked(now time.Time) *Clocked {
+	return &Clocked{Clk: clock.NewFake(now)}
+}
+
+// SlotStart returns the start time of slot.
+func SlotStart(slot phase0.Slot) time.Time {
+	return time.Unix(int64(slot)*SecondsPerSlot, 0) //nolint:gosec // test slots
+}
+
+func (c *Clocked) Clock() clock.Clock { return c.Clk }
+
+func (c *Clocked) GetChainSpec() *chain.ChainSpec {
+	return &chain.ChainSpec{SlotsPerEpoch: SlotsPerEpoch}
+}
+
+func (c *Clocked) GetHeadVoteTracker() *chain.HeadVoteTracker { return nil }
+
+func (c *Clocked) SlotToTime(slot phase0.Slot) time.Time { return SlotStart(slot) }
+
+func (c *Clocked) GetCurrentSlot() phase0.Slot {
+	return phase0.Slot(c.Clk.Now().Unix() / SecondsPerSlot) //nolint:gosec // after genesis
+}
+
+func (c *Clocked) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(slot / SlotsPerEpoch)
+}
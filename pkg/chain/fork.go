@@ -0,0 +1,48 @@
+package chain
+
+import (
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// ForkTransition is fired when the chain service enters the first epoch of a
+// new fork at runtime. It fires after the epoch's stats, so subscribers read
+// the new fork's state (e.g. the Gloas builder registry) right away.
+type ForkTransition struct {
+	Epoch    phase0.Epoch
+	Previous version.DataVersion
+	Current  version.DataVersion
+}
+
+// SubscribeForkTransitions returns a subscription for runtime fork
+// transitions.
+func (s *service) SubscribeForkTransitions() *utils.Subscription[*ForkTransition] {
+	return s.forkTransitionDispatcher.Subscribe(4, false)
+}
+
+// checkForkTransition fires a ForkTransition when moving from prevEpoch to
+// newEpoch crosses a fork boundary (one transition per epoch change, to the
+// fork active at newEpoch, even when several fork epochs were skipped).
+func (s *service) checkForkTransition(prevEpoch, newEpoch phase0.Epoch) {
+	previous := s.ActiveForkAtEpoch(prevEpoch)
+	current := s.ActiveForkAtEpoch(newEpoch)
+
+	if current == previous {
+		return
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"epoch":         newEpoch,
+		"previous_fork": previous.String(),
+		"fork":          current.String(),
+	}).Info("Fork transition")
+
+	s.forkTransitionDispatcher.Fire(&ForkTransition{
+		Epoch:    newEpoch,
+		Previous: previous,
+		Current:  current,
+	})
+}
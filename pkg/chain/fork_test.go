@@ -0,0 +1,56 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+func TestCheckForkTransition(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	svc := &service{
+		chainSpec: &ChainSpec{ForkSchedule: []ForkSchedule{
+			{Fork: version.DataVersionFulu, Epoch: 0},
+			{Fork: version.DataVersionGloas, Epoch: 5},
+			{Fork: version.DataVersionHeze, Epoch: 8},
+		}},
+		log:                      log,
+		forkTransitionDispatcher: &utils.Dispatcher[*ForkTransition]{},
+	}
+
+	sub := svc.SubscribeForkTransitions()
+	defer sub.Unsubscribe()
+
+	next := func() *ForkTransition {
+		select {
+		case transition := <-sub.Channel():
+			return transition
+		default:
+			return nil
+		}
+	}
+
+	svc.checkForkTransition(3, 4)
+	assert.Nil(t, next(), "no boundary crossed")
+
+	svc.checkForkTransition(4, 5)
+	transition := next()
+	require.NotNil(t, transition)
+	assert.Equal(t, ForkTransition{Epoch: 5, Previous: version.DataVersionFulu, Current: version.DataVersionGloas}, *transition)
+
+	svc.checkForkTransition(5, 6)
+	assert.Nil(t, next())
+
+	// Epochs without a head (no state fetched) skip straight to the latest fork.
+	svc.checkForkTransition(4, 9)
+	transition = next()
+	require.NotNil(t, transition)
+	assert.Equal(t, ForkTransition{Epoch: 9, Previous: version.DataVersionFulu, Current: version.DataVersionHeze}, *transition)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/utils"
@@ -38,6 +39,7 @@ func (s *stubChainService) GetCurrentEpoch() phase0.Epoch {
 	return phase0.Epoch(uint64(s.currentSlot) / s.spec.SlotsPerEpoch)
 }
 func (s *stubChainService) GetCurrentSlot() phase0.Slot { return s.currentSlot }
+func (s *stubChainService) Clock() clock.Clock          { return clock.Real() }
 func (s *stubChainService) GetCurrentFork() version.DataVersion {
 	return version.DataVersionGloas
 }
@@ -59,6 +61,9 @@ func (s *stubChainService) GetEpochStats(epoch phase0.Epoch) *EpochStats {
 func (s *stubChainService) SubscribeEpochStats() *utils.Subscription[*EpochStats] {
 	return nil
 }
+func (s *stubChainService) SubscribeForkTransitions() *utils.Subscription[*ForkTransition] {
+	return nil
+}
 func (s *stubChainService) GetHeadVoteTracker() *HeadVoteTracker { return nil }
 func (s *stubChainService) GetFinalizedEpoch() phase0.Epoch      { return 0 }
 func (s *stubChainService) GetBuilderByIndex(_ uint64) *BuilderInfo {
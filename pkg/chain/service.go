@@ -10,6 +10,7 @@ import (
 	"github.com/ethpandaops/go-eth2-client/spec/version"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/utils"
@@ -20,6 +21,10 @@ type Service interface {
 	Start(ctx context.Context) error
 	Stop() error
 
+	// Clock is the time source of the slot math and every slot scheduler:
+	// wall time, or the accelerated clock of --clock-speedup.
+	Clock() clock.Clock
+
 	// Chain state accessors
 	GetChainSpec() *ChainSpec
 	GetGenesis() *beacon.Genesis
@@ -38,6 +43,7 @@ type Service interface {
 
 	// Subscriptions
 	SubscribeEpochStats() *utils.Subscription[*EpochStats]
+	SubscribeForkTransitions() *utils.Subscription[*ForkTransition]
 
 	// Head vote tracking
 	GetHeadVoteTracker() *HeadVoteTracker
@@ -66,6 +72,7 @@ type service struct {
 	clClient  *beacon.Client
 	chainSpec *ChainSpec
 	genesis   *beacon.Genesis
+	clock     clock.Clock
 	log       logrus.FieldLogger
 
 	// State cache (keeps latest 2 epochs)
@@ -78,7 +85,8 @@ type service struct {
 	headVoteTracker *HeadVoteTracker
 
 	// Event dispatching
-	epochStatsDispatcher *utils.Dispatcher[*EpochStats]
+	epochStatsDispatcher     *utils.Dispatcher[*EpochStats]
+	forkTransitionDispatcher *utils.Dispatcher[*ForkTransition]
 
 	// Lifecycle
 	ctx    context.Context
@@ -94,14 +102,23 @@ func NewService(
 	genesis *beacon.Genesis,
 	log logrus.FieldLogger,
 ) Service {
+	// The accelerated clock agrees with wall time at genesis, like every
+	// simulated node anchored there.
+	clk := clock.Real()
+	if cfg.ClockSpeedup > 1 {
+		clk = clock.NewScaled(genesis.GenesisTime, cfg.ClockSpeedup)
+	}
+
 	return &service{
-		cfg:                  cfg,
-		clClient:             clClient,
-		chainSpec:            chainSpec,
-		genesis:              genesis,
-		log:                  log.WithField("component", "chain-service"),
-		stateCache:           make(map[phase0.Epoch]*EpochStats, 2),
-		epochStatsDispatcher: &utils.Dispatcher[*EpochStats]{},
+		cfg:                      cfg,
+		clClient:                 clClient,
+		chainSpec:                chainSpec,
+		genesis:                  genesis,
+		clock:                    clk,
+		log:                      log.WithField("component", "chain-service"),
+		stateCache:               make(map[phase0.Epoch]*EpochStats, 2),
+		epochStatsDispatcher:     &utils.Dispatcher[*EpochStats]{},
+		forkTransitionDispatcher: &utils.Dispatcher[*ForkTransition]{},
 	}
 }
 
@@ -264,15 +281,20 @@ func (s *service) TimeToSlot(t time.Time) phase0.Slot {
 	return phase0.Slot(elapsed / s.chainSpec.SecondsPerSlot)
 }
 
+// Clock returns the service's time source.
+func (s *service) Clock() clock.Clock {
+	return s.clock
+}
+
 // GetCurrentEpoch calculates the current epoch from the head slot.
 func (s *service) GetCurrentEpoch() phase0.Epoch {
-	currentSlot := s.TimeToSlot(time.Now())
+	currentSlot := s.TimeToSlot(s.clock.Now())
 	return phase0.Epoch(uint64(currentSlot) / s.chainSpec.SlotsPerEpoch)
 }
 
 // GetCurrentSlot calculates the current slot from the current time.
 func (s *service) GetCurrentSlot() phase0.Slot {
-	currentSlot := s.TimeToSlot(time.Now())
+	currentSlot := s.TimeToSlot(s.clock.Now())
 	return currentSlot
 }
 
@@ -369,6 +391,8 @@ func (s *service) handleHeadEvent(event *beacon.HeadEvent) {
 	if stats != nil {
 		s.epochStatsDispatcher.Fire(stats)
 	}
+
+	s.checkForkTransition(currentEpoch, newEpoch)
 }
 
 // fetchCurrentEpochState fetches the state for the current epoch at startup.
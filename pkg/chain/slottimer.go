@@ -0,0 +1,35 @@
+package chain
+
+import (
+	"time"
+
+	"github.com/ethpandaops/buildoor/pkg/clock"
+)
+
+// minSlotWait floors the slot timer so a boundary that has just passed does
+// not spin the loop.
+const minSlotWait = 10 * time.Millisecond
+
+// NewSlotTimer returns a timer on the service's clock that fires at the next
+// slot boundary. Re-arm it with ResetSlotTimer after every tick.
+func NewSlotTimer(svc Service) clock.Timer {
+	return svc.Clock().NewTimer(DurationToNextSlot(svc))
+}
+
+// ResetSlotTimer re-arms timer for the next slot boundary.
+func ResetSlotTimer(svc Service, timer clock.Timer) {
+	timer.Reset(DurationToNextSlot(svc))
+}
+
+// DurationToNextSlot computes the wait until the next slot boundary. It is
+// re-derived every tick so clock jumps self-correct.
+func DurationToNextSlot(svc Service) time.Duration {
+	nextSlotTime := svc.SlotToTime(svc.GetCurrentSlot() + 1)
+
+	wait := svc.Clock().Until(nextSlotTime)
+	if wait < minSlotWait {
+		wait = minSlotWait
+	}
+
+	return wait
+}
@@ -0,0 +1,36 @@
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethpandaops/buildoor/pkg/clock"
+)
+
+type slotClockService struct {
+	Service
+
+	clk *clock.Fake
+}
+
+func (s *slotClockService) Clock() clock.Clock { return s.clk }
+
+func (s *slotClockService) SlotToTime(slot phase0.Slot) time.Time {
+	return time.Unix(int64(slot)*12, 0) //nolint:gosec // test slots
+}
+
+func (s *slotClockService) GetCurrentSlot() phase0.Slot {
+	return phase0.Slot(s.clk.Now().Unix() / 12) //nolint:gosec // after genesis
+}
+
+func TestDurationToNextSlot(t *testing.T) {
+	svc := &slotClockService{clk: clock.NewFake(time.Unix(10*12+3, 0))}
+	assert.Equal(t, 9*time.Second, DurationToNextSlot(svc))
+
+	// Right at the boundary the wait is floored instead of zero.
+	svc.clk.Set(time.Unix(11*12, 0).Add(-time.Millisecond))
+	assert.Equal(t, minSlotWait, DurationToNextSlot(svc))
+}
@@ -21,6 +21,11 @@ type ChainSpec struct {
 	SecondsPerSlot time.Duration
 	SlotsPerEpoch  uint64
 
+	// Network identity: the spec preset (mainnet, minimal) and config name
+	// (empty when the node does not report them)
+	PresetBase string
+	ConfigName string
+
 	// Duty calculation parameters
 	ShuffleRoundCount          uint64
 	TargetCommitteeSize        uint64
@@ -50,8 +55,11 @@ type ChainSpec struct {
 	// Fork epochs and versions (nil if not configured)
 	ForkSchedule []ForkSchedule
 
-	// Blob schedule (BPO - Blob Parameters Only)
-	BlobSchedule []BlobScheduleEntry
+	// Blob limits: Deneb and Electra maxima, and the blob schedule (BPO -
+	// Blob Parameters Only) overriding them from its epochs on
+	MaxBlobsPerBlock        uint64
+	MaxBlobsPerBlockElectra uint64
+	BlobSchedule            []BlobScheduleEntry
 
 	// ePBS parameters
 	PtcSize uint64
@@ -107,6 +115,8 @@ func (s *ChainSpec) parseSpecData(specData map[string]string, rawData map[string
 	}
 
 	s.SlotsPerEpoch = slotsPerEpoch
+	s.PresetBase = specData["PRESET_BASE"]
+	s.ConfigName = specData["CONFIG_NAME"]
 
 	// Parse duty calculation parameters (use defaults if not present)
 	if v, err := parseSpecUint64(specData, "SHUFFLE_ROUND_COUNT"); err == nil {
@@ -237,6 +247,14 @@ func (s *ChainSpec) parseSpecData(specData map[string]string, rawData map[string
 	s.ForkSchedule = forkSchedule
 
 	// Parse ePBS parameters
+	if v, err := parseSpecUint64(specData, "MAX_BLOBS_PER_BLOCK"); err == nil {
+		s.MaxBlobsPerBlock = v
+	}
+
+	if v, err := parseSpecUint64(specData, "MAX_BLOBS_PER_BLOCK_ELECTRA"); err == nil {
+		s.MaxBlobsPerBlockElectra = v
+	}
+
 	if v, err := parseSpecUint64(specData, "PTC_SIZE"); err == nil {
 		s.PtcSize = v
 	}
@@ -334,6 +352,32 @@ func (s *ChainSpec) GetForkEpoch(fork version.DataVersion) phase0.Epoch {
 	return math.MaxUint64
 }
 
+// MaxBlobsPerBlockAtEpoch returns the blob limit of blocks in the given
+// epoch: the latest blob schedule entry in effect, else the Electra or Deneb
+// maximum.
+func (s *ChainSpec) MaxBlobsPerBlockAtEpoch(epoch phase0.Epoch) uint64 {
+	var (
+		maxBlobs  uint64
+		fromEpoch uint64
+		scheduled bool
+	)
+
+	for _, entry := range s.BlobSchedule {
+		if entry.Epoch <= uint64(epoch) && (!scheduled || entry.Epoch >= fromEpoch) {
+			maxBlobs, fromEpoch, scheduled = entry.MaxBlobsPerBlock, entry.Epoch, true
+		}
+	}
+
+	switch {
+	case scheduled:
+		return maxBlobs
+	case s.IsForkActive(version.DataVersionElectra, epoch):
+		return s.MaxBlobsPerBlockElectra
+	default:
+		return s.MaxBlobsPerBlock
+	}
+}
+
 // GetForkVersion returns the fork version for a given fork.
 func (s *ChainSpec) GetForkVersion(fork version.DataVersion) (phase0.Version, error) {
 	for _, forkSchedule := range s.ForkSchedule {
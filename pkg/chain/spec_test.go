@@ -0,0 +1,25 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBlobsPerBlockAtEpoch(t *testing.T) {
+	spec := &ChainSpec{
+		MaxBlobsPerBlock:        6,
+		MaxBlobsPerBlockElectra: 9,
+		ForkSchedule:            []ForkSchedule{{Fork: version.DataVersionElectra, Epoch: 10}},
+		BlobSchedule: []BlobScheduleEntry{
+			{Epoch: 30, MaxBlobsPerBlock: 21},
+			{Epoch: 20, MaxBlobsPerBlock: 15},
+		},
+	}
+
+	assert.Equal(t, uint64(6), spec.MaxBlobsPerBlockAtEpoch(5))
+	assert.Equal(t, uint64(9), spec.MaxBlobsPerBlockAtEpoch(10))
+	assert.Equal(t, uint64(15), spec.MaxBlobsPerBlockAtEpoch(25))
+	assert.Equal(t, uint64(21), spec.MaxBlobsPerBlockAtEpoch(30))
+}
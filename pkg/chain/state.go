@@ -2,11 +2,9 @@ package chain
 
 import (
 	"context"
-	"fmt"
 
-	eth2client "github.com/ethpandaops/go-eth2-client"
-	"github.com/ethpandaops/go-eth2-client/api"
 	"github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
 	"github.com/ethpandaops/go-eth2-client/spec/electra"
 	"github.com/ethpandaops/go-eth2-client/spec/gloas"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
@@ -62,6 +60,7 @@ type PendingDepositInfo struct {
 type BuilderInfo struct {
 	Index             uint64
 	Pubkey            phase0.BLSPubKey
+	ExecutionAddress  bellatrix.ExecutionAddress // withdrawal address of the builder's balance
 	Balance           uint64
 	Active            bool
 	DepositEpoch      uint64
@@ -75,30 +74,19 @@ func (s *service) fetchEpochStats(
 	stateID string,
 	epoch phase0.Epoch,
 ) (*EpochStats, error) {
-	provider, ok := s.clClient.GetRawClient().(eth2client.BeaconStateProvider)
-	if !ok {
-		return nil, fmt.Errorf("client does not support beacon state provider")
-	}
-
-	resp, err := provider.AgnosticBeaconState(ctx, &api.BeaconStateOpts{
-		State: stateID,
-	})
+	state, err := s.clClient.GetBeaconState(ctx, stateID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get beacon state: %w", err)
-	}
-
-	if resp.Data == nil {
-		return nil, fmt.Errorf("beacon state response is nil")
+		return nil, err
 	}
 
 	validatorPubkeys := s.validatorIndexCache
-	if cap(validatorPubkeys) < len(resp.Data.Validators) {
-		validatorPubkeys = make([]phase0.BLSPubKey, len(resp.Data.Validators))
+	if cap(validatorPubkeys) < len(state.Validators) {
+		validatorPubkeys = make([]phase0.BLSPubKey, len(state.Validators))
 	} else {
-		validatorPubkeys = validatorPubkeys[:len(resp.Data.Validators)]
+		validatorPubkeys = validatorPubkeys[:len(state.Validators)]
 	}
 
-	for i, v := range resp.Data.Validators {
+	for i, v := range state.Validators {
 		validatorPubkeys[i] = v.PublicKey
 	}
 
@@ -106,7 +94,7 @@ func (s *service) fetchEpochStats(
 	s.validatorIndexCache = validatorPubkeys
 	s.cacheMu.Unlock()
 
-	return s.computeEpochStats(resp.Data, epoch)
+	return s.computeEpochStats(state, epoch)
 }
 
 // computeEpochStats extracts relevant statistics from the beacon state and computes duties.
@@ -266,6 +254,7 @@ func builderToInfo(index uint64, builder *gloas.Builder) *BuilderInfo {
 	info := &BuilderInfo{
 		Index:             index,
 		Pubkey:            builder.PublicKey,
+		ExecutionAddress:  builder.ExecutionAddress,
 		Balance:           uint64(builder.Balance),
 		DepositEpoch:      uint64(builder.DepositEpoch),
 		WithdrawableEpoch: uint64(builder.WithdrawableEpoch),
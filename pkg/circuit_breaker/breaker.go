@@ -0,0 +1,365 @@
+// Package circuit_breaker disables a service after N consecutive critical
+// failures (p2p bid submissions, payload reveals) so a misconfigured builder
+// stops winning bids it cannot deliver and burning pending payments every
+// slot. A tripped service stays disabled until an operator re-enables it.
+package circuit_breaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// Circuits. Each counts consecutive failures of one operation and trips the
+// service it belongs to.
+const (
+	// CircuitP2PBids counts failed p2p bid submissions (construction or
+	// gossip); a gossiped bid resets it.
+	CircuitP2PBids = "p2p_bids"
+	// CircuitP2PReveals counts reveals of p2p bids that failed every attempt.
+	CircuitP2PReveals = "p2p_reveals"
+	// CircuitBuilderAPIReveals counts reveals of Builder API bids that failed
+	// every attempt.
+	CircuitBuilderAPIReveals = "builder_api_reveals"
+)
+
+// Services a circuit can disable.
+const (
+	ServiceEPBS       = "epbs"
+	ServiceBuilderAPI = "builder_api"
+)
+
+// serviceSettings maps each service to its enable setting.
+var serviceSettings = map[string]string{
+	ServiceEPBS:       config.KeyEPBSEnabled,
+	ServiceBuilderAPI: config.KeyBuilderAPIEnabled,
+}
+
+// SettingsWriter applies setting overrides (implemented by the settings
+// service).
+type SettingsWriter interface {
+	Set(key string, raw json.RawMessage, actor string) error
+}
+
+// Circuit is the state of one failure counter.
+type Circuit struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Threshold           int        `json:"threshold"`
+	Tripped             bool       `json:"tripped"`
+	TrippedAt           *time.Time `json:"tripped_at,omitempty"`
+	TripCount           int        `json:"trip_count"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// Breaker watches bid submission and reveal outcomes and disables the
+// offending service once a circuit reaches the threshold.
+type Breaker struct {
+	threshold int
+	epbsSvc   *p2p_bidder.Service
+	revealSvc *payload_bidder.RevealService
+	settings  SettingsWriter
+	alerts    *alerting.Engine
+
+	mu       sync.Mutex
+	circuits []*Circuit
+
+	dispatcher utils.Dispatcher[*Circuit]
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewBreaker creates a circuit breaker tripping after threshold consecutive
+// failures. epbsSvc and revealSvc may be nil (their circuits never count);
+// alerts may be nil (trips are then only logged and emitted as events).
+func NewBreaker(threshold int, epbsSvc *p2p_bidder.Service, revealSvc *payload_bidder.RevealService,
+	settings SettingsWriter, alerts *alerting.Engine, log logrus.FieldLogger) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		epbsSvc:   epbsSvc,
+		revealSvc: revealSvc,
+		settings:  settings,
+		alerts:    alerts,
+		circuits: []*Circuit{
+			{Name: CircuitP2PBids, Service: ServiceEPBS, Threshold: threshold},
+			{Name: CircuitP2PReveals, Service: ServiceEPBS, Threshold: threshold},
+			{Name: CircuitBuilderAPIReveals, Service: ServiceBuilderAPI, Threshold: threshold},
+		},
+		log: log.WithField("component", "circuit-breaker"),
+	}
+}
+
+// Start subscribes to the outcome streams.
+func (b *Breaker) Start(ctx context.Context) error {
+	b.ctx, b.cancel = context.WithCancel(ctx)
+
+	var bidSub *utils.Subscription[*p2p_bidder.BidSubmissionEvent]
+
+	var bidChan <-chan *p2p_bidder.BidSubmissionEvent
+
+	if b.epbsSvc != nil {
+		bidSub = b.epbsSvc.SubscribeBidSubmissions(64, false)
+		bidChan = bidSub.Channel()
+	}
+
+	var revealSub *utils.Subscription[*payload_bidder.RevealResult]
+
+	var revealChan <-chan *payload_bidder.RevealResult
+
+	if b.revealSvc != nil {
+		revealSub = b.revealSvc.SubscribeResults(64, false)
+		revealChan = revealSub.Channel()
+	}
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		if bidSub != nil {
+			defer bidSub.Unsubscribe()
+		}
+
+		if revealSub != nil {
+			defer revealSub.Unsubscribe()
+		}
+
+		for {
+			select {
+			case <-b.ctx.Done():
+				return
+
+			case event, ok := <-bidChan:
+				if !ok {
+					bidChan = nil
+					continue
+				}
+
+				b.HandleBidSubmission(event)
+
+			case result, ok := <-revealChan:
+				if !ok {
+					revealChan = nil
+					continue
+				}
+
+				b.HandleRevealResult(result)
+			}
+		}
+	}()
+
+	b.log.WithField("threshold", b.threshold).Info("Circuit breaker started")
+
+	return nil
+}
+
+// Stop unsubscribes and waits for the event loop.
+func (b *Breaker) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+
+	b.wg.Wait()
+}
+
+// SubscribeChanges subscribes to circuit trips and resets (non-blocking).
+func (b *Breaker) SubscribeChanges(capacity int) *utils.Subscription[*Circuit] {
+	return b.dispatcher.Subscribe(capacity, false)
+}
+
+// GetCircuits returns a snapshot of every circuit.
+func (b *Breaker) GetCircuits() []Circuit {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuits := make([]Circuit, 0, len(b.circuits))
+	for _, circuit := range b.circuits {
+		circuits = append(circuits, *circuit)
+	}
+
+	return circuits
+}
+
+// HandleBidSubmission counts a p2p bid submission outcome. Pre-construction
+// skip events carry no status and are ignored, as are fault bids (meant to be
+// rejected).
+func (b *Breaker) HandleBidSubmission(event *p2p_bidder.BidSubmissionEvent) {
+	if event.Fault != "" {
+		return
+	}
+
+	switch event.Status {
+	case p2p_bidder.BidStatusSubmitted:
+		b.record(CircuitP2PBids, uint64(event.Slot), "")
+	case p2p_bidder.BidStatusConstructed, p2p_bidder.BidStatusFailed:
+		b.record(CircuitP2PBids, uint64(event.Slot), event.Error)
+	}
+}
+
+// HandleRevealResult counts a reveal outcome. Skips are intentional (plan,
+// late) and ignored, as are operator-triggered publishes; a failed attempt
+// only counts once it was the last one.
+func (b *Breaker) HandleRevealResult(result *payload_bidder.RevealResult) {
+	if result.Skipped || result.Manual {
+		return
+	}
+
+	name := CircuitP2PReveals
+	if result.Transport == payload_builder.BidTransportBuilderAPI {
+		name = CircuitBuilderAPIReveals
+	}
+
+	switch {
+	case result.Success:
+		b.record(name, uint64(result.Slot), "")
+	case result.Attempt >= result.MaxAttempts:
+		errMsg := result.Error
+		if errMsg == "" {
+			errMsg = "reveal failed"
+		}
+
+		b.record(name, uint64(result.Slot), errMsg)
+	}
+}
+
+// record applies one outcome (errMsg empty = success) to a circuit and trips
+// it at the threshold. A success clears the tripped flag; a tripped circuit
+// keeps counting and re-trips if the service is re-enabled and fails again.
+func (b *Breaker) record(name string, slot uint64, errMsg string) {
+	b.mu.Lock()
+
+	circuit := b.circuit(name)
+
+	if errMsg == "" {
+		changed := circuit.Tripped
+		circuit.ConsecutiveFailures = 0
+		circuit.Tripped = false
+		circuit.TrippedAt = nil
+
+		snapshot := *circuit
+		b.mu.Unlock()
+
+		if changed {
+			b.log.WithField("circuit", name).Info("Circuit closed after a successful operation")
+			b.dispatcher.Fire(&snapshot)
+		}
+
+		return
+	}
+
+	circuit.ConsecutiveFailures++
+	circuit.LastError = errMsg
+
+	if circuit.ConsecutiveFailures < b.threshold {
+		b.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	circuit.ConsecutiveFailures = 0
+	circuit.Tripped = true
+	circuit.TrippedAt = &now
+	circuit.TripCount++
+
+	snapshot := *circuit
+	b.mu.Unlock()
+
+	b.trip(&snapshot, slot)
+}
+
+// trip disables the circuit's service and emits the alert.
+func (b *Breaker) trip(circuit *Circuit, slot uint64) {
+	message := fmt.Sprintf("circuit %s tripped after %d consecutive failures (last: %s); disabling %s",
+		circuit.Name, b.threshold, circuit.LastError, circuit.Service)
+
+	if b.settings != nil {
+		err := b.settings.Set(serviceSettings[circuit.Service], json.RawMessage("false"), "circuit-breaker:"+circuit.Name)
+		if err != nil {
+			message += fmt.Sprintf(" (disable failed: %v)", err)
+		}
+	}
+
+	b.log.WithFields(logrus.Fields{
+		"circuit": circuit.Name,
+		"service": circuit.Service,
+		"slot":    slot,
+	}).Error(message)
+
+	b.dispatcher.Fire(circuit)
+
+	if b.alerts != nil {
+		b.alerts.Publish(&alerting.Alert{
+			Rule:     "circuit_breaker:" + circuit.Name,
+			Severity: alerting.SeverityCritical,
+			State:    alerting.AlertFiring,
+			Value:    float64(b.threshold),
+			Slot:     slot,
+			Message:  message,
+			Actions:  []string{"disable_" + circuit.Service},
+		})
+	}
+}
+
+// Reset closes every circuit of a service (after an operator re-enabled it).
+func (b *Breaker) Reset(service string) error {
+	if _, ok := serviceSettings[service]; !ok {
+		return fmt.Errorf("unknown service %q", service)
+	}
+
+	var changed []*Circuit
+
+	b.mu.Lock()
+
+	for _, circuit := range b.circuits {
+		if circuit.Service != service {
+			continue
+		}
+
+		circuit.ConsecutiveFailures = 0
+		circuit.Tripped = false
+		circuit.TrippedAt = nil
+
+		snapshot := *circuit
+		changed = append(changed, &snapshot)
+	}
+
+	b.mu.Unlock()
+
+	for _, circuit := range changed {
+		b.dispatcher.Fire(circuit)
+	}
+
+	return nil
+}
+
+// ServiceSetting returns the enable setting key of a service.
+func ServiceSetting(service string) (string, bool) {
+	key, ok := serviceSettings[service]
+	return key, ok
+}
+
+func (b *Breaker) circuit(name string) *Circuit {
+	for _, circuit := range b.circuits {
+		if circuit.Name == name {
+			return circuit
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package circuit_breaker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+type stubSettings struct {
+	sets map[string]string
+}
+
+func (s *stubSettings) Set(key string, raw json.RawMessage, _ string) error {
+	s.sets[key] = string(raw)
+	return nil
+}
+
+func circuitByName(b *Breaker, name string) Circuit {
+	for _, circuit := range b.GetCircuits() {
+		if circuit.Name == name {
+			return circuit
+		}
+	}
+
+	return Circuit{}
+}
+
+func TestBidSubmissionTrips(t *testing.T) {
+	settings := &stubSettings{sets: map[string]string{}}
+	breaker := NewBreaker(3, nil, nil, settings, nil, logrus.New())
+
+	failed := &p2p_bidder.BidSubmissionEvent{Status: p2p_bidder.BidStatusConstructed, Error: "gossip rejected"}
+
+	breaker.HandleBidSubmission(failed)
+	breaker.HandleBidSubmission(failed)
+	breaker.HandleBidSubmission(&p2p_bidder.BidSubmissionEvent{Status: p2p_bidder.BidStatusSubmitted})
+	breaker.HandleBidSubmission(&p2p_bidder.BidSubmissionEvent{}) // skip event
+	breaker.HandleBidSubmission(failed)
+	breaker.HandleBidSubmission(failed)
+	require.Empty(t, settings.sets, "a success resets the streak")
+
+	breaker.HandleBidSubmission(failed)
+
+	circuit := circuitByName(breaker, CircuitP2PBids)
+	require.True(t, circuit.Tripped)
+	require.Equal(t, 1, circuit.TripCount)
+	require.Equal(t, "gossip rejected", circuit.LastError)
+	require.Equal(t, "false", settings.sets[config.KeyEPBSEnabled])
+
+	require.NoError(t, breaker.Reset(ServiceEPBS))
+	require.False(t, circuitByName(breaker, CircuitP2PBids).Tripped)
+	require.Error(t, breaker.Reset("lifecycle"))
+}
+
+func TestRevealResultTrips(t *testing.T) {
+	settings := &stubSettings{sets: map[string]string{}}
+	breaker := NewBreaker(2, nil, nil, settings, nil, logrus.New())
+
+	failed := func(attempt int) *payload_bidder.RevealResult {
+		return &payload_bidder.RevealResult{
+			Transport:   payload_builder.BidTransportBuilderAPI,
+			Error:       "publish failed",
+			Attempt:     attempt,
+			MaxAttempts: 3,
+		}
+	}
+
+	breaker.HandleRevealResult(failed(1))
+	breaker.HandleRevealResult(failed(2))
+	breaker.HandleRevealResult(&payload_bidder.RevealResult{Skipped: true, Transport: payload_builder.BidTransportBuilderAPI})
+	require.Zero(t, circuitByName(breaker, CircuitBuilderAPIReveals).ConsecutiveFailures, "only final attempts count")
+
+	breaker.HandleRevealResult(failed(3))
+	breaker.HandleRevealResult(failed(3))
+
+	require.True(t, circuitByName(breaker, CircuitBuilderAPIReveals).Tripped)
+	require.False(t, circuitByName(breaker, CircuitP2PReveals).Tripped)
+	require.Equal(t, "false", settings.sets[config.KeyBuilderAPIEnabled])
+
+	breaker.HandleRevealResult(&payload_bidder.RevealResult{Success: true, Transport: payload_builder.BidTransportBuilderAPI})
+	require.False(t, circuitByName(breaker, CircuitBuilderAPIReveals).Tripped, "a success closes the circuit")
+}
+
+func TestFaultBidsIgnored(t *testing.T) {
+	settings := &stubSettings{sets: map[string]string{}}
+	breaker := NewBreaker(2, nil, nil, settings, nil, logrus.New())
+
+	rejected := &p2p_bidder.BidSubmissionEvent{
+		Status: p2p_bidder.BidStatusConstructed,
+		Error:  "bid value exceeds builder balance",
+		Manual: true,
+		Fault:  p2p_bidder.BidFaultExceedsBalance,
+	}
+
+	for range 5 {
+		breaker.HandleBidSubmission(rejected)
+	}
+
+	require.False(t, circuitByName(breaker, CircuitP2PBids).Tripped)
+	require.Empty(t, settings.sets)
+}
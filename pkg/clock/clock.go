@@ -0,0 +1,68 @@
+// Package clock abstracts wall time for the slot schedulers (payload builds,
+// p2p bids, reveals, Builder API response delays). Production code runs on
+// the system clock; Scaled accelerates time for local simulation against
+// simulated beacon and execution nodes sharing the speedup, and Fake is a
+// manually advanced clock for deterministic unit tests of slot timing.
+package clock
+
+import "time"
+
+// Clock tells the time and schedules timers.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	Until(t time.Time) time.Duration
+
+	// After returns a channel receiving the time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer returns a timer delivering the time on its channel once d
+	// has elapsed.
+	NewTimer(d time.Duration) Timer
+
+	// AfterFunc calls f once d has elapsed, in its own goroutine (Fake:
+	// synchronously while advancing). The returned timer has no channel.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single-shot timer of a Clock (see time.Timer).
+type Timer interface {
+	// C is the channel the time is delivered on; nil for AfterFunc timers.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false when the timer
+	// already fired or was stopped.
+	Stop() bool
+
+	// Reset re-arms the timer to fire after d. It returns whether the timer
+	// was active.
+	Reset(d time.Duration) bool
+}
+
+// Real returns the system clock.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) Until(t time.Time) time.Duration        { return time.Until(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{timer: time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time        { return t.timer.C }
+func (t realTimer) Stop() bool                 { return t.timer.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.timer.Reset(d) }
@@ -0,0 +1,103 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEpoch = time.Unix(1_700_000_000, 0)
+
+func TestFakeFiresTimersInDueOrder(t *testing.T) {
+	fake := NewFake(testEpoch)
+
+	var fired []string
+
+	fake.AfterFunc(3*time.Second, func() { fired = append(fired, "third") })
+	fake.AfterFunc(time.Second, func() { fired = append(fired, "first") })
+	fake.AfterFunc(2*time.Second, func() {
+		fired = append(fired, "second")
+
+		// Armed while advancing and already due: fires in the same advance.
+		fake.AfterFunc(0, func() { fired = append(fired, "nested") })
+	})
+
+	fake.Advance(999 * time.Millisecond)
+	assert.Empty(t, fired)
+	assert.Equal(t, 3, fake.Pending())
+
+	fake.Advance(2 * time.Second)
+	assert.Equal(t, []string{"first", "second", "nested"}, fired)
+	assert.Equal(t, 1, fake.Pending())
+
+	fake.Advance(time.Second)
+	assert.Equal(t, []string{"first", "second", "nested", "third"}, fired)
+	assert.Zero(t, fake.Pending())
+}
+
+func TestFakeTimerStopAndReset(t *testing.T) {
+	fake := NewFake(testEpoch)
+
+	timer := fake.NewTimer(time.Second)
+	assert.Equal(t, time.Second, fake.Until(testEpoch.Add(time.Second)))
+
+	fake.Advance(time.Second)
+
+	select {
+	case at := <-timer.C():
+		assert.Equal(t, testEpoch.Add(time.Second), at)
+	default:
+		t.Fatal("timer did not fire")
+	}
+
+	assert.False(t, timer.Stop(), "fired timer is no longer active")
+	assert.False(t, timer.Reset(time.Second))
+
+	fake.Advance(500 * time.Millisecond)
+	assert.True(t, timer.Stop())
+
+	fake.Advance(time.Second)
+
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	// Reset discards an undelivered time.
+	timer.Reset(0)
+	fake.Advance(0)
+	timer.Reset(time.Second)
+
+	select {
+	case <-timer.C():
+		t.Fatal("reset timer delivered a stale time")
+	default:
+	}
+
+	fake.Set(testEpoch) // backwards: nothing fires
+	assert.Equal(t, 1, fake.Pending())
+}
+
+func TestScaled(t *testing.T) {
+	origin := time.Now()
+	scaled := NewScaled(origin, 100)
+	require.InDelta(t, 100, scaled.Factor(), 0)
+
+	time.Sleep(10 * time.Millisecond)
+	assert.GreaterOrEqual(t, scaled.Since(origin), time.Second, "10ms real time is at least 1s scaled")
+
+	start := time.Now()
+
+	select {
+	case <-scaled.After(2 * time.Second):
+	case <-time.After(time.Second):
+		t.Fatal("2s scaled timer did not fire within 1s")
+	}
+
+	assert.Less(t, time.Since(start), time.Second)
+
+	assert.InDelta(t, 1, NewScaled(origin, 0.5).Factor(), 0, "factors below 1 run at system speed")
+}
@@ -0,0 +1,180 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a manually advanced clock for tests. Time stands still until Set
+// or Advance moves it; timers due by then fire in due order, AfterFunc
+// callbacks synchronously in the advancing goroutine, so a test observes
+// their effects as soon as Advance returns.
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake returns a fake clock standing at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Until returns the fake time until t.
+func (f *Fake) Until(t time.Time) time.Duration {
+	return t.Sub(f.Now())
+}
+
+// After returns a channel receiving the fake time once it advanced by d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer returns a timer firing once the fake time advanced by d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return f.arm(&fakeTimer{clock: f, c: make(chan time.Time, 1)}, d)
+}
+
+// AfterFunc calls f once the fake time advanced by d.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	return f.arm(&fakeTimer{clock: f, fn: fn}, d)
+}
+
+// Pending returns the number of armed timers.
+func (f *Fake) Pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.timers)
+}
+
+// Advance moves the fake time forward by d, firing the timers due.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves the fake time to t, firing the timers due. Moving backwards
+// fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	f.now = t
+	f.mu.Unlock()
+
+	// Fire one timer at a time: a callback may arm further timers that are
+	// already due.
+	for {
+		timer := f.popDue()
+		if timer == nil {
+			return
+		}
+
+		if timer.fn != nil {
+			timer.fn()
+			continue
+		}
+
+		select {
+		case timer.c <- timer.when:
+		default:
+		}
+	}
+}
+
+// arm schedules timer d from now (immediately due for d <= 0, fired on the
+// next Set or Advance).
+func (f *Fake) arm(timer *fakeTimer, d time.Duration) *fakeTimer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	timer.when = f.now.Add(d)
+	f.timers = append(f.timers, timer)
+
+	return timer
+}
+
+// popDue removes and returns the earliest timer due, nil when none is.
+func (f *Fake) popDue() *fakeTimer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sort.SliceStable(f.timers, func(i, j int) bool { return f.timers[i].when.Before(f.timers[j].when) })
+
+	if len(f.timers) == 0 || f.timers[0].when.After(f.now) {
+		return nil
+	}
+
+	timer := f.timers[0]
+	f.timers = f.timers[1:]
+
+	return timer
+}
+
+// remove disarms timer; it reports whether the timer was armed.
+func (f *Fake) remove(timer *fakeTimer) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, armed := range f.timers {
+		if armed == timer {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakeTimer struct {
+	clock *Fake
+	when  time.Time
+	c     chan time.Time
+	fn    func()
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	if t.c == nil {
+		return nil
+	}
+
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	active := t.clock.remove(t)
+	t.drain()
+
+	return active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	active := t.clock.remove(t)
+	t.drain()
+	t.clock.arm(t, d)
+
+	return active
+}
+
+func (t *fakeTimer) drain() {
+	if t.c == nil {
+		return
+	}
+
+	select {
+	case <-t.c:
+	default:
+	}
+}
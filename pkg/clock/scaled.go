@@ -0,0 +1,108 @@
+package clock
+
+import "time"
+
+// Scaled is a clock running factor times faster than the system clock. It
+// agrees with the system clock at origin; anchoring every simulated node at
+// the same origin (the chain's genesis) keeps their slot boundaries aligned.
+type Scaled struct {
+	origin time.Time
+	factor float64
+}
+
+// NewScaled returns a clock running factor times faster than the system
+// clock from origin. A factor of 1 or below runs at system speed.
+func NewScaled(origin time.Time, factor float64) *Scaled {
+	return &Scaled{origin: origin, factor: max(factor, 1)}
+}
+
+// Factor returns the speedup factor.
+func (c *Scaled) Factor() float64 {
+	return c.factor
+}
+
+// Now returns the accelerated time.
+func (c *Scaled) Now() time.Time {
+	return c.origin.Add(time.Duration(float64(time.Since(c.origin)) * c.factor))
+}
+
+// Since returns the accelerated time elapsed since t.
+func (c *Scaled) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the accelerated time until t.
+func (c *Scaled) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// After returns a channel receiving the accelerated time once d accelerated
+// time has elapsed.
+func (c *Scaled) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer returns a timer firing after d accelerated time.
+func (c *Scaled) NewTimer(d time.Duration) Timer {
+	t := &scaledTimer{clock: c, c: make(chan time.Time, 1)}
+	t.timer = time.AfterFunc(c.real(d), func() {
+		select {
+		case t.c <- c.Now():
+		default:
+		}
+	})
+
+	return t
+}
+
+// AfterFunc calls f in its own goroutine after d accelerated time.
+func (c *Scaled) AfterFunc(d time.Duration, f func()) Timer {
+	return &scaledTimer{clock: c, timer: time.AfterFunc(c.real(d), f)}
+}
+
+// real converts an accelerated duration to system time.
+func (c *Scaled) real(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / c.factor)
+}
+
+type scaledTimer struct {
+	clock *Scaled
+	timer *time.Timer
+	c     chan time.Time
+}
+
+func (t *scaledTimer) C() <-chan time.Time {
+	if t.c == nil {
+		return nil
+	}
+
+	return t.c
+}
+
+// Stop and Reset discard an undelivered value like time.Timer does (Go
+// 1.23+), so a re-armed timer never delivers a stale time.
+func (t *scaledTimer) Stop() bool {
+	active := t.timer.Stop()
+	t.drain()
+
+	return active
+}
+
+func (t *scaledTimer) Reset(d time.Duration) bool {
+	active := t.timer.Stop()
+	t.drain()
+	t.timer.Reset(t.clock.real(d))
+
+	return active
+}
+
+func (t *scaledTimer) drain() {
+	if t.c == nil {
+		return
+	}
+
+	select {
+	case <-t.c:
+	default:
+	}
+}
@@ -5,19 +5,27 @@ package config
 // Call ApplySlotDefaults after loading the chain spec to fill them in.
 func DefaultConfig() *Config {
 	return &Config{
+		CLClientSSZ:       true,
 		APIPort:           0,
 		AuthProviderURL:   "",
 		LifecycleEnabled:  false,
 		EPBSEnabled:       false, // Disabled by default
 		BuilderAPIEnabled: false, // Disabled by default
 		BuilderAPI: BuilderAPIConfig{
-			BlockValueSubsidyGwei: 100000, // 100k Gwei
+			BlockValueSubsidyGwei:   100000, // 100k Gwei
+			VerifyProposerSignature: true,
+			BroadcastValidation:     BroadcastValidationGossip,
+			BlobSidecars:            BlobSidecarsAuto,
+			CaptureSize:             100,
+			CaptureMaxBody:          64 << 10,
 		},
 		DepositAmount:               50000000000, // 50 ETH in Gwei
 		TopupThreshold:              10000000000, // 10 ETH in Gwei
 		TopupAmount:                 50000000000, // 50 ETH in Gwei
 		DepositMaxFeeGwei:           1000000,     // 0.001 ETH in Gwei; delay deposits/topups above this queue fee
 		ExtraData:                   "buildoor/",
+		Withdrawals:                 WithdrawalsConfig{Source: WithdrawalsSourceAttributes},
+		FeeRecipientRotation:        FeeRecipientRotationConfig{Mode: FeeRecipientRotationOff},
 		SlotResultRetentionEpochs:   100,
 		SlotArtifactRetentionEpochs: 100,
 		SlotArtifactCaptureEnabled:  true,
@@ -33,6 +41,10 @@ func DefaultConfig() *Config {
 			BidInterval:          500,       // 500ms between bids
 			BidSubsidy:           100000000, // 100M gwei = 0.1 ETH; clears validator local-EL threshold
 			HeadVoteThresholdPct: 60,        // Gloas builder payment quorum (6/10)
+			SnipeLeadMs:          250,       // late enough to see the competition, early enough to propagate
+			BidStrategy:          BidStrategyLinear,
+			CounterBidStepGwei:   1000000, // 1M gwei = 0.001 ETH above the competitor
+			RandomWalkStepGwei:   1000000, // up to 0.001 ETH per re-bid
 		},
 		Reveal: RevealConfig{
 			Enabled: true,
@@ -45,8 +57,27 @@ func DefaultConfig() *Config {
 			BroadcastValidation: BroadcastValidationConsensusAndEquivocation,
 			MaxAttempts:         3,
 			RetryIntervalMs:     500,
+			ChaosDelayMs:        4000,
 			// TimeMs: 0 = auto-compute from slot time (see ApplySlotDefaults).
 		},
+		Canary: CanaryConfig{
+			BidGwei: 1,
+		},
+		BidBudget: BidBudgetConfig{
+			Distribution: BidBudgetUniform,
+		},
+		AuditExport: AuditExportConfig{
+			DelaySlots: 2,
+		},
+		PeerMesh: PeerMeshConfig{
+			PollIntervalMs: 1000,
+		},
+		CircuitBreakerThreshold: 5,
+		Watchdog: WatchdogConfig{
+			Enabled: true,
+		},
+		PenaltyMonitor:  true,
+		CapabilityCheck: true,
 	}
 }
 
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Optional modules: service groups that are only constructed when enabled.
+// The core pipeline (chain, action plan, payload builder, inclusion and
+// result tracking, WebUI) always runs. A module that is enabled but lacks its
+// prerequisites (e.g. Gloas not scheduled, no --api-port, no wallet) is still
+// not constructed.
+const (
+	// ModuleEPBS is the p2p bidder with its peer mesh, extra builder keys and
+	// penalty monitor.
+	ModuleEPBS = "epbs"
+	// ModuleBuilderAPI is the Builder API server with the validator
+	// registration store and its relay backfill.
+	ModuleBuilderAPI = "builder_api"
+	// ModuleReveal is the payload reveal service and the payment tracker.
+	ModuleReveal = "reveal"
+	// ModuleProposerPrefs is the gossip proposer preferences service.
+	ModuleProposerPrefs = "proposer_preferences"
+	// ModuleLifecycle is the builder lifecycle manager (deposits, top-ups,
+	// exits, read-only tracking).
+	ModuleLifecycle = "lifecycle"
+)
+
+// moduleDeps is the module dependency graph: enabling a module enables the
+// modules it depends on. Both bid flows reveal their payloads and commit to
+// the proposer's gossip preferences on Gloas.
+var moduleDeps = map[string][]string{
+	ModuleEPBS:          {ModuleReveal, ModuleProposerPrefs},
+	ModuleBuilderAPI:    {ModuleReveal, ModuleProposerPrefs},
+	ModuleReveal:        nil,
+	ModuleProposerPrefs: nil,
+	ModuleLifecycle:     nil,
+}
+
+// ModuleNames lists every optional module.
+var ModuleNames = []string{
+	ModuleEPBS,
+	ModuleBuilderAPI,
+	ModuleReveal,
+	ModuleProposerPrefs,
+	ModuleLifecycle,
+}
+
+// Modules is a resolved set of enabled modules; see ResolveModules.
+type Modules map[string]bool
+
+// ResolveModules validates the requested module names and adds their
+// (transitive) dependencies. An empty request enables every module.
+func ResolveModules(names []string) (Modules, error) {
+	modules := make(Modules, len(ModuleNames))
+
+	if len(names) == 0 {
+		for _, name := range ModuleNames {
+			modules[name] = true
+		}
+
+		return modules, nil
+	}
+
+	var enable func(name string)
+
+	enable = func(name string) {
+		if modules[name] {
+			return
+		}
+
+		modules[name] = true
+
+		for _, dep := range moduleDeps[name] {
+			enable(dep)
+		}
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(ModuleNames, name) {
+			return nil, fmt.Errorf("unknown module %q: must be one of %s", name, strings.Join(ModuleNames, ", "))
+		}
+
+		enable(name)
+	}
+
+	return modules, nil
+}
+
+// Enabled reports whether the module is enabled.
+func (m Modules) Enabled(name string) bool {
+	return m[name]
+}
+
+// List returns the enabled modules in ModuleNames order.
+func (m Modules) List() []string {
+	list := make([]string, 0, len(m))
+
+	for _, name := range ModuleNames {
+		if m[name] {
+			list = append(list, name)
+		}
+	}
+
+	return list
+}
+
+// ValidateModules checks the module selection against the startup toggles:
+// a service enabled at startup must have its module enabled. Monitors of a
+// disabled module (e.g. the penalty monitor without epbs) stay off silently.
+func (c *Config) ValidateModules() error {
+	modules, err := ResolveModules(c.Modules)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case c.EPBSEnabled && !modules.Enabled(ModuleEPBS):
+		return fmt.Errorf("--epbs-enabled requires the %s module", ModuleEPBS)
+	case c.BuilderAPIEnabled && !modules.Enabled(ModuleBuilderAPI):
+		return fmt.Errorf("--builder-api-enabled requires the %s module", ModuleBuilderAPI)
+	case (c.LifecycleEnabled || c.LifecycleReadOnly) && !modules.Enabled(ModuleLifecycle):
+		return fmt.Errorf("--lifecycle and --lifecycle-read-only require the %s module", ModuleLifecycle)
+	case len(c.ExtraBuilders) > 0 && !modules.Enabled(ModuleEPBS):
+		return fmt.Errorf("extra builder keys require the %s module", ModuleEPBS)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveModules(t *testing.T) {
+	all, err := ResolveModules(nil)
+	require.NoError(t, err)
+	assert.Equal(t, ModuleNames, all.List())
+
+	modules, err := ResolveModules([]string{ModuleEPBS})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ModuleEPBS, ModuleReveal, ModuleProposerPrefs}, modules.List())
+	assert.False(t, modules.Enabled(ModuleBuilderAPI))
+	assert.False(t, modules.Enabled(ModuleLifecycle))
+
+	modules, err = ResolveModules([]string{" lifecycle", ModuleReveal})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ModuleReveal, ModuleLifecycle}, modules.List())
+
+	_, err = ResolveModules([]string{"relay"})
+	require.Error(t, err)
+}
+
+func TestValidateModules(t *testing.T) {
+	require.NoError(t, DefaultConfig().ValidateModules())
+
+	for name, tc := range map[string]struct {
+		modules []string
+		mutate  func(*Config)
+		wantErr bool
+	}{
+		"epbs enabled":               {[]string{ModuleEPBS}, func(c *Config) { c.EPBSEnabled = true }, false},
+		"epbs without module":        {[]string{ModuleBuilderAPI}, func(c *Config) { c.EPBSEnabled = true }, true},
+		"builder api without module": {[]string{ModuleEPBS}, func(c *Config) { c.BuilderAPIEnabled = true }, true},
+		"read-only lifecycle":        {[]string{ModuleEPBS}, func(c *Config) { c.LifecycleReadOnly = true }, true},
+		"extra builders":             {[]string{ModuleBuilderAPI}, func(c *Config) { c.ExtraBuilders = []ExtraBuilderConfig{{}} }, true},
+		"unknown module":             {[]string{"relay"}, func(*Config) {}, true},
+	} {
+		cfg := DefaultConfig()
+		cfg.Modules = tc.modules
+		tc.mutate(cfg)
+
+		if tc.wantErr {
+			assert.Error(t, cfg.ValidateModules(), name)
+		} else {
+			assert.NoError(t, cfg.ValidateModules(), name)
+		}
+	}
+}
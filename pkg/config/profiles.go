@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Built-in config profiles (--profile).
+const (
+	ProfileEPBSDevnet     = "epbs-devnet"
+	ProfileRelayBuilder   = "relay-builder"
+	ProfileBuilderAPIOnly = "builder-api-only"
+)
+
+// Profile is a built-in preset for a common deployment. Its settings are
+// keyed by flag name (which is also the config file key) and are applied
+// as defaults: the user's config file, environment and explicit flags
+// override every one of them.
+type Profile struct {
+	Name        string
+	Description string
+	Settings    map[string]any
+}
+
+// profiles lists the built-in profiles. Values carry the flag's type.
+var profiles = []*Profile{
+	{
+		Name:        ProfileEPBSDevnet,
+		Description: "p2p bidding and reveals on a Gloas devnet, with builder lifecycle management",
+		Settings: map[string]any{
+			"modules":                 []string{ModuleEPBS, ModuleLifecycle},
+			"epbs-enabled":            true,
+			"builder-api-enabled":     false,
+			"lifecycle":               true,
+			"capability-check":        true,
+			"epbs-bid-strategy":       BidStrategyCounterBid,
+			"epbs-bid-interval":       int64(250),
+			"epbs-replace-stale-bids": true,
+			"reveal-gate-mode":        RevealGateVoteOrTime,
+		},
+	},
+	{
+		Name:        ProfileRelayBuilder,
+		Description: "Builder API serving relays: equivocation-checked publishing, alternative parents, no p2p bidding",
+		Settings: map[string]any{
+			"modules":                          []string{ModuleBuilderAPI},
+			"epbs-enabled":                     false,
+			"builder-api-enabled":              true,
+			"builder-api-broadcast-validation": BroadcastValidationConsensusAndEquivocation,
+			"builder-api-blob-sidecars":        BlobSidecarsAlways,
+			"builder-api-parent-candidates":    2,
+			"builder-api-slot-tolerance":       uint64(1),
+		},
+	},
+	{
+		Name:        ProfileBuilderAPIOnly,
+		Description: "Builder API served directly to proposers (e.g. via mev-boost); no p2p bidding or lifecycle",
+		Settings: map[string]any{
+			"modules":                               []string{ModuleBuilderAPI},
+			"epbs-enabled":                          false,
+			"builder-api-enabled":                   true,
+			"builder-api-verify-proposer-signature": true,
+			"builder-api-broadcast-validation":      BroadcastValidationConsensus,
+		},
+	},
+}
+
+// ProfileNames lists the built-in profiles.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+
+	return names
+}
+
+// LookupProfile returns the built-in profile of the given name.
+func LookupProfile(name string) (*Profile, error) {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown profile %q: must be one of %s", name, strings.Join(ProfileNames(), ", "))
+}
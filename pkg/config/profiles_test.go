@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupProfile(t *testing.T) {
+	for _, name := range ProfileNames() {
+		profile, err := LookupProfile(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, profile.Name)
+
+		// The preset toggles must pass the module validation on their own.
+		cfg := DefaultConfig()
+		cfg.Modules, _ = profile.Settings["modules"].([]string)
+		cfg.EPBSEnabled, _ = profile.Settings["epbs-enabled"].(bool)
+		cfg.BuilderAPIEnabled, _ = profile.Settings["builder-api-enabled"].(bool)
+		cfg.LifecycleEnabled, _ = profile.Settings["lifecycle"].(bool)
+		assert.NoError(t, cfg.ValidateModules(), name)
+	}
+
+	_, err := LookupProfile("mainnet")
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -19,6 +20,18 @@ const (
 	SourceUI      = "ui"
 )
 
+// ErrVersionConflict is returned by Update when the settings changed since the
+// version the caller based its edit on.
+var ErrVersionConflict = errors.New("settings changed since the given version")
+
+// Change is one setting changed by Update: its effective value before and
+// after the update.
+type Change struct {
+	Key  string `json:"key"`
+	From any    `json:"from"`
+	To   any    `json:"to"`
+}
+
 // keyState holds the in-memory 3-way state for one setting key.
 type keyState struct {
 	hasCLI   bool
@@ -50,6 +63,7 @@ type Service struct {
 	seq         int64
 	keyState    map[string]*keyState
 	subscribers []func()
+	revisions   []Revision // oldest first, bounded by maxRevisions
 }
 
 // New constructs the settings service.
@@ -86,6 +100,8 @@ func NewService(effective, defaults *Config, supplied map[string]bool, store *db
 		rowByKey[r.Key] = r
 	}
 
+	s.loadRevisions()
+
 	// Pass 1: load persisted UI state and find the high-water seq mark so any
 	// newly-allocated CLI-change seq is guaranteed greater than every stored one.
 	for _, f := range s.fields {
@@ -117,6 +133,10 @@ func NewService(effective, defaults *Config, supplied map[string]bool, store *db
 	}
 
 	// Pass 2: reconcile the CLI layer against what the operator supplied now.
+	// The value each changed key resolved to on the previous run is kept so
+	// the change is recorded as a reload revision.
+	before := make(map[string]any)
+
 	for _, f := range s.fields {
 		ks := s.keyState[f.Key]
 		row := rowByKey[f.Key]
@@ -132,6 +152,11 @@ func NewService(effective, defaults *Config, supplied map[string]bool, store *db
 			}
 		}
 
+		prev := *ks
+		prev.hasCLI = storedHasCLI
+		prev.cliValue = storedCLI
+		prev.cliSeq = row.CLISeq
+
 		isSupplied := supplied[f.Key]
 		changed := false
 
@@ -157,11 +182,18 @@ func NewService(effective, defaults *Config, supplied map[string]bool, store *db
 
 		if changed {
 			s.persist(f, ks, SourceCLI)
+			before[f.Key] = s.resolve(f, &prev)
 		}
 	}
 
 	s.recompute()
 
+	// Only a restart (persisted state exists) is a reload; a first boot has
+	// nothing to diff against.
+	if len(rows) > 0 {
+		s.recordRevision(SourceCLI, RevisionSourceReload, s.changesSince(before))
+	}
+
 	return s, nil
 }
 
@@ -185,10 +217,37 @@ func (s *Service) Set(key string, raw json.RawMessage, actor string) error {
 	return s.SetMany(map[string]json.RawMessage{key: raw}, actor)
 }
 
-// SetMany applies a batch of UI overrides atomically: all values are validated
-// and decoded first, then applied, persisted, and the effective config
-// recomputed before subscribers are notified once.
+// SetMany applies a batch of UI overrides atomically, regardless of the current
+// version (see Update).
 func (s *Service) SetMany(updates map[string]json.RawMessage, actor string) error {
+	_, _, err := s.Update(updates, actor, -1)
+	return err
+}
+
+// Version returns the settings version: the seq of the newest change. It
+// increases with every applied change and survives restarts (it is the
+// persisted seq high-water mark), so it serves as the config ETag.
+func (s *Service) Version() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seq
+}
+
+// Update applies a batch of UI overrides atomically: all values are validated
+// and decoded first, then applied, persisted, and the effective config
+// recomputed before subscribers are notified once. It is idempotent: overrides
+// already in effect are skipped, and a batch that changes nothing succeeds
+// without bumping the version. Otherwise, when ifVersion is non-negative and
+// the version moved past it, nothing is applied and ErrVersionConflict is
+// returned. Returns the effective changes (recorded as a revision) and the
+// resulting version.
+func (s *Service) Update(updates map[string]json.RawMessage, actor string, ifVersion int64) ([]Change, int64, error) {
+	return s.update(updates, actor, RevisionSourceAPI, ifVersion)
+}
+
+// update implements Update, recording the revision under source.
+func (s *Service) update(updates map[string]json.RawMessage, actor, source string, ifVersion int64) ([]Change, int64, error) {
 	s.mu.Lock()
 
 	decoded := make(map[string]any, len(updates))
@@ -197,23 +256,46 @@ func (s *Service) SetMany(updates map[string]json.RawMessage, actor string) erro
 		f, ok := s.byKey[key]
 		if !ok {
 			s.mu.Unlock()
-			return fmt.Errorf("unknown setting %q", key)
+			return nil, 0, fmt.Errorf("unknown setting %q", key)
 		}
 
 		v, err := f.Decode(raw)
 		if err != nil {
 			s.mu.Unlock()
-			return fmt.Errorf("decode %q: %w", key, err)
+			return nil, 0, fmt.Errorf("decode %q: %w", key, err)
 		}
 
 		if err := validateValue(key, v); err != nil {
 			s.mu.Unlock()
-			return err
+			return nil, 0, err
+		}
+
+		if ks := s.keyState[key]; ks.hasUI && ks.uiSeq > ks.cliSeq && f.Equal(ks.uiValue, v) {
+			continue // already the winning override
 		}
 
 		decoded[key] = v
 	}
 
+	if len(decoded) == 0 {
+		version := s.seq
+		s.mu.Unlock()
+
+		return nil, version, nil
+	}
+
+	if ifVersion >= 0 && ifVersion != s.seq {
+		version := s.seq
+		s.mu.Unlock()
+
+		return nil, version, fmt.Errorf("%w: version is %d, not %d", ErrVersionConflict, version, ifVersion)
+	}
+
+	before := make(map[string]any, len(decoded))
+	for key := range decoded {
+		before[key] = s.byKey[key].Get(s.effective)
+	}
+
 	for key, v := range decoded {
 		f := s.byKey[key]
 		ks := s.keyState[key]
@@ -225,6 +307,10 @@ func (s *Service) SetMany(updates map[string]json.RawMessage, actor string) erro
 
 	s.recompute()
 
+	changes := s.changesSince(before)
+	s.recordRevision(actor, source, changes)
+
+	version := s.seq
 	subs := make([]func(), len(s.subscribers))
 	copy(subs, s.subscribers)
 	s.mu.Unlock()
@@ -233,30 +319,55 @@ func (s *Service) SetMany(updates map[string]json.RawMessage, actor string) erro
 		fn()
 	}
 
-	return nil
+	return changes, version, nil
 }
 
 // recompute rebuilds the effective config in place: each registered field is set
 // to the highest-seq layer present (defaults are the seq-0 floor). Must hold mu.
 func (s *Service) recompute() {
 	for _, f := range s.fields {
-		ks := s.keyState[f.Key]
-		val := f.Get(s.defaults)
-		winSeq := int64(0)
-
-		if ks.hasCLI && ks.cliSeq > winSeq {
-			val = ks.cliValue
-			winSeq = ks.cliSeq
+		if err := f.Set(s.effective, s.resolve(f, s.keyState[f.Key])); err != nil {
+			s.log.WithError(err).WithField("key", f.Key).Error("failed to apply setting")
 		}
+	}
+}
+
+// resolve returns the value of the highest-seq layer of ks (the default when
+// neither the CLI nor the UI layer is present).
+func (s *Service) resolve(f Field, ks *keyState) any {
+	val := f.Get(s.defaults)
+	winSeq := int64(0)
+
+	if ks.hasCLI && ks.cliSeq > winSeq {
+		val = ks.cliValue
+		winSeq = ks.cliSeq
+	}
+
+	if ks.hasUI && ks.uiSeq > winSeq {
+		val = ks.uiValue
+	}
+
+	return val
+}
+
+// changesSince diffs the effective config against the previous values of the
+// touched keys, in registry order. Keys whose effective value did not change
+// are omitted. Must hold mu.
+func (s *Service) changesSince(before map[string]any) []Change {
+	changes := make([]Change, 0, len(before))
 
-		if ks.hasUI && ks.uiSeq > winSeq {
-			val = ks.uiValue
+	for _, f := range s.fields {
+		from, ok := before[f.Key]
+		if !ok {
+			continue
 		}
 
-		if err := f.Set(s.effective, val); err != nil {
-			s.log.WithError(err).WithField("key", f.Key).Error("failed to apply setting")
+		if to := f.Get(s.effective); !f.Equal(from, to) {
+			changes = append(changes, Change{Key: f.Key, From: from, To: to})
 		}
 	}
+
+	return changes
 }
 
 // nextSeq allocates a monotonic sequence number. Must hold mu.
@@ -307,6 +418,29 @@ func validateValue(key string, v any) error {
 		}
 	}
 
+	if key == KeyBidBudgetDistribution {
+		budget := BidBudgetConfig{}
+		budget.Distribution, _ = v.(string)
+
+		if budget.Distribution != budget.NormalizedDistribution() {
+			return fmt.Errorf("invalid bid budget distribution %q", budget.Distribution)
+		}
+	}
+
+	if key == KeyRevealChaosWithholdPct || key == KeyRevealChaosDelayPct {
+		pct, _ := v.(uint64)
+		if pct > 100 {
+			return fmt.Errorf("%s must be within [0, 100], got %d", key, pct)
+		}
+	}
+
+	if key == KeyRevealChaosDelayMs {
+		delay, _ := v.(int64)
+		if delay < 0 {
+			return fmt.Errorf("%s must not be negative", key)
+		}
+	}
+
 	if key == KeySlotResultRetentionEpochs || key == KeySlotArtifactRetentionEpochs {
 		epochs, _ := v.(uint64)
 		if epochs == 0 {
@@ -93,6 +93,14 @@ func Fields() []Field {
 		newField(KeyEPBSBidSubsidy, "epbs-bid-subsidy", func(c *Config) *uint64 { return &c.EPBS.BidSubsidy }),
 		newField(KeyEPBSBidValueOverride, "epbs-bid-value-override", func(c *Config) *uint64 { return &c.EPBS.BidValueOverride }),
 		newField(KeyEPBSHeadVoteThreshold, "epbs-vote-threshold", func(c *Config) *uint64 { return &c.EPBS.HeadVoteThresholdPct }),
+		newField(KeyEPBSReplaceStaleBids, "epbs-replace-stale-bids", func(c *Config) *bool { return &c.EPBS.ReplaceStaleBids }),
+		newField(KeyEPBSSnipeEnabled, "epbs-snipe", func(c *Config) *bool { return &c.EPBS.SnipeEnabled }),
+		newField(KeyEPBSSnipeLeadMs, "epbs-snipe-lead", func(c *Config) *int64 { return &c.EPBS.SnipeLeadMs }),
+		newField(KeyEPBSSnipeMaxCompetitor, "epbs-snipe-max-competitor", func(c *Config) *uint64 { return &c.EPBS.SnipeMaxCompetitorGwei }),
+		newField(KeyEPBSBidStrategy, "epbs-bid-strategy", func(c *Config) *string { return &c.EPBS.BidStrategy }),
+		newField(KeyEPBSCounterBidStep, "epbs-counter-bid-step", func(c *Config) *uint64 { return &c.EPBS.CounterBidStepGwei }),
+		newField(KeyEPBSCounterBidMax, "epbs-counter-bid-max", func(c *Config) *uint64 { return &c.EPBS.CounterBidMaxGwei }),
+		newField(KeyEPBSRandomWalkStep, "epbs-random-walk-step", func(c *Config) *uint64 { return &c.EPBS.RandomWalkStepGwei }),
 
 		newField(KeyRevealEnabled, "reveal-enabled", func(c *Config) *bool { return &c.Reveal.Enabled }),
 		newField(KeyRevealGateMode, "reveal-gate-mode", func(c *Config) *string { return &c.Reveal.GateMode }),
@@ -101,6 +109,16 @@ func Fields() []Field {
 		newField(KeyRevealBroadcastValidation, "reveal-broadcast-validation", func(c *Config) *string { return &c.Reveal.BroadcastValidation }),
 		newField(KeyRevealMaxAttempts, "reveal-max-attempts", func(c *Config) *uint64 { return &c.Reveal.MaxAttempts }),
 		newField(KeyRevealRetryInterval, "reveal-retry-interval", func(c *Config) *int64 { return &c.Reveal.RetryIntervalMs }),
+		newField(KeyRevealRequireInclusion, "reveal-require-inclusion", func(c *Config) *bool { return &c.Reveal.RequireInclusion }),
+		newField(KeyRevealChaosWithholdPct, "reveal-chaos-withhold-pct", func(c *Config) *uint64 { return &c.Reveal.ChaosWithholdPct }),
+		newField(KeyRevealChaosDelayPct, "reveal-chaos-delay-pct", func(c *Config) *uint64 { return &c.Reveal.ChaosDelayPct }),
+		newField(KeyRevealChaosDelayMs, "reveal-chaos-delay", func(c *Config) *int64 { return &c.Reveal.ChaosDelayMs }),
+
+		newField(KeyCanaryEnabled, "canary-mode", func(c *Config) *bool { return &c.Canary.Enabled }),
+		newField(KeyCanaryBidGwei, "canary-bid-gwei", func(c *Config) *uint64 { return &c.Canary.BidGwei }),
+
+		newField(KeyBidBudgetEpochMaxGwei, "bid-budget-epoch-gwei", func(c *Config) *uint64 { return &c.BidBudget.EpochMaxGwei }),
+		newField(KeyBidBudgetDistribution, "bid-budget-distribution", func(c *Config) *string { return &c.BidBudget.Distribution }),
 
 		newField(KeyPayloadBuildTime, "payload-build-time", func(c *Config) *uint64 { return &c.PayloadBuildTime }),
 		newField(KeyExtraData, "extra-data", func(c *Config) *string { return &c.ExtraData }),
@@ -109,6 +127,8 @@ func Fields() []Field {
 
 		newField(KeySlotResultRetentionEpochs, "slot-result-retention-epochs", func(c *Config) *uint64 { return &c.SlotResultRetentionEpochs }),
 		newField(KeySlotArtifactRetentionEpochs, "slot-artifact-retention-epochs", func(c *Config) *uint64 { return &c.SlotArtifactRetentionEpochs }),
+		newField(KeySlotResultMaxSlots, "slot-result-max-slots", func(c *Config) *uint64 { return &c.SlotResultMaxSlots }),
+		newField(KeySlotArtifactMaxMB, "slot-artifact-max-mb", func(c *Config) *uint64 { return &c.SlotArtifactMaxMB }),
 		newField(KeySlotArtifactCaptureEnabled, "slot-artifact-capture-enabled", func(c *Config) *bool { return &c.SlotArtifactCaptureEnabled }),
 
 		newField(KeyDepositAmount, "deposit-amount", func(c *Config) *uint64 { return &c.DepositAmount }),
@@ -9,15 +9,23 @@ const (
 	KeyScheduleNextN     = "schedule.next_n"
 	KeyScheduleStartSlot = "schedule.start_slot"
 
-	KeyEPBSBuildStartTime    = "epbs.build_start_time"
-	KeyEPBSBidStartTime      = "epbs.bid_start_time"
-	KeyEPBSBidEndTime        = "epbs.bid_end_time"
-	KeyEPBSBidMinAmount      = "epbs.bid_min_amount"
-	KeyEPBSBidIncrease       = "epbs.bid_increase"
-	KeyEPBSBidInterval       = "epbs.bid_interval"
-	KeyEPBSBidSubsidy        = "epbs.bid_subsidy"
-	KeyEPBSBidValueOverride  = "epbs.bid_value_override"
-	KeyEPBSHeadVoteThreshold = "epbs.head_vote_threshold_pct"
+	KeyEPBSBuildStartTime     = "epbs.build_start_time"
+	KeyEPBSBidStartTime       = "epbs.bid_start_time"
+	KeyEPBSBidEndTime         = "epbs.bid_end_time"
+	KeyEPBSBidMinAmount       = "epbs.bid_min_amount"
+	KeyEPBSBidIncrease        = "epbs.bid_increase"
+	KeyEPBSBidInterval        = "epbs.bid_interval"
+	KeyEPBSBidSubsidy         = "epbs.bid_subsidy"
+	KeyEPBSBidValueOverride   = "epbs.bid_value_override"
+	KeyEPBSHeadVoteThreshold  = "epbs.head_vote_threshold_pct"
+	KeyEPBSReplaceStaleBids   = "epbs.replace_stale_bids"
+	KeyEPBSSnipeEnabled       = "epbs.snipe_enabled"
+	KeyEPBSSnipeLeadMs        = "epbs.snipe_lead_ms"
+	KeyEPBSSnipeMaxCompetitor = "epbs.snipe_max_competitor_gwei"
+	KeyEPBSBidStrategy        = "epbs.bid_strategy"
+	KeyEPBSCounterBidStep     = "epbs.counter_bid_step_gwei"
+	KeyEPBSCounterBidMax      = "epbs.counter_bid_max_gwei"
+	KeyEPBSRandomWalkStep     = "epbs.random_walk_step_gwei"
 
 	KeyRevealEnabled             = "reveal.enabled"
 	KeyRevealGateMode            = "reveal.gate_mode"
@@ -26,6 +34,16 @@ const (
 	KeyRevealBroadcastValidation = "reveal.broadcast_validation"
 	KeyRevealMaxAttempts         = "reveal.max_attempts"
 	KeyRevealRetryInterval       = "reveal.retry_interval_ms"
+	KeyRevealRequireInclusion    = "reveal.require_inclusion"
+	KeyRevealChaosWithholdPct    = "reveal.chaos_withhold_pct"
+	KeyRevealChaosDelayPct       = "reveal.chaos_delay_pct"
+	KeyRevealChaosDelayMs        = "reveal.chaos_delay_ms"
+
+	KeyCanaryEnabled = "canary.enabled"
+	KeyCanaryBidGwei = "canary.bid_gwei"
+
+	KeyBidBudgetEpochMaxGwei = "bid_budget.epoch_max_gwei"
+	KeyBidBudgetDistribution = "bid_budget.distribution"
 
 	KeyPayloadBuildTime        = "payload_build_time"
 	KeyExtraData               = "extra_data"
@@ -34,6 +52,8 @@ const (
 
 	KeySlotResultRetentionEpochs   = "slot_result_retention_epochs"
 	KeySlotArtifactRetentionEpochs = "slot_artifact_retention_epochs"
+	KeySlotResultMaxSlots          = "slot_result_max_slots"
+	KeySlotArtifactMaxMB           = "slot_artifact_max_mb"
 	KeySlotArtifactCaptureEnabled  = "slot_artifact_capture_enabled"
 
 	KeyDepositAmount  = "deposit_amount"
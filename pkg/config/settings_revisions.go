@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/buildoor/pkg/db"
+)
+
+// Revision sources: what produced a config revision.
+const (
+	RevisionSourceAPI      = "api"      // UI/API settings update
+	RevisionSourceReload   = "reload"   // operator config (flags/env/file) changed across a restart
+	RevisionSourceRollback = "rollback" // Rollback to an earlier revision
+)
+
+// maxRevisions bounds the in-memory revision history (the state-db keeps the
+// same number).
+const maxRevisions = 1000
+
+// ErrUnknownRevision is returned by Rollback for a version that is not in the
+// retained revision history.
+var ErrUnknownRevision = errors.New("unknown config revision")
+
+// Revision is one recorded runtime config change: the settings version it
+// produced, who made it, and the before/after values of every setting whose
+// effective value changed.
+type Revision struct {
+	Version   int64    `json:"version"`
+	Timestamp int64    `json:"timestamp"` // unix ms
+	Actor     string   `json:"actor"`
+	Source    string   `json:"source"`
+	Changes   []Change `json:"changes"`
+}
+
+// Revisions returns up to limit of the newest revisions, newest first (all
+// retained revisions when limit <= 0).
+func (s *Service) Revisions(limit int) []Revision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if limit <= 0 || limit > len(s.revisions) {
+		limit = len(s.revisions)
+	}
+
+	revisions := make([]Revision, 0, limit)
+	for i := len(s.revisions) - 1; i >= len(s.revisions)-limit; i-- {
+		revisions = append(revisions, s.revisions[i])
+	}
+
+	return revisions
+}
+
+// Rollback restores the effective config as it was right after revision
+// version: every setting changed by a later revision is set back (as a UI
+// override) to the value it had before its first later change. The rollback is
+// itself recorded as a revision, so it can be rolled back too. ifVersion has
+// the same optimistic-concurrency meaning as for Update. Returns the applied
+// changes and the resulting version.
+func (s *Service) Rollback(version int64, actor string, ifVersion int64) ([]Change, int64, error) {
+	s.mu.Lock()
+
+	current := s.seq
+	if ifVersion >= 0 && ifVersion != current {
+		s.mu.Unlock()
+		return nil, current, fmt.Errorf("%w: version is %d, not %d", ErrVersionConflict, current, ifVersion)
+	}
+
+	found := false
+	restore := make(map[string]any)
+
+	for _, rev := range s.revisions {
+		if rev.Version == version {
+			found = true
+		}
+
+		if rev.Version <= version {
+			continue
+		}
+
+		for _, change := range rev.Changes {
+			if _, ok := restore[change.Key]; !ok {
+				restore[change.Key] = change.From
+			}
+		}
+	}
+
+	if !found {
+		s.mu.Unlock()
+		return nil, current, fmt.Errorf("%w: %d", ErrUnknownRevision, version)
+	}
+
+	updates := make(map[string]json.RawMessage, len(restore))
+
+	for key, value := range restore {
+		if _, ok := s.byKey[key]; !ok {
+			continue // setting no longer exists
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, current, fmt.Errorf("encode %q: %w", key, err)
+		}
+
+		updates[key] = raw
+	}
+
+	s.mu.Unlock()
+
+	// Applied against the version the restore set was computed at: a change
+	// racing in between surfaces as a conflict instead of being reverted blindly.
+	return s.update(updates, actor, RevisionSourceRollback, current)
+}
+
+// recordRevision appends a revision for the current version and persists it.
+// No-op without changes. Must hold mu (or run before the service is shared).
+func (s *Service) recordRevision(actor, source string, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	rev := Revision{
+		Version:   s.seq,
+		Timestamp: time.Now().UnixMilli(),
+		Actor:     actor,
+		Source:    source,
+		Changes:   changes,
+	}
+
+	s.revisions = append(s.revisions, rev)
+	if len(s.revisions) > maxRevisions {
+		s.revisions = append([]Revision(nil), s.revisions[len(s.revisions)-maxRevisions:]...)
+	}
+
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to encode config revision")
+		return
+	}
+
+	if err := s.store.AppendConfigRevision(db.ConfigRevision{
+		Version:   rev.Version,
+		Timestamp: rev.Timestamp,
+		Actor:     rev.Actor,
+		Source:    rev.Source,
+		Changes:   string(encoded),
+	}); err != nil {
+		s.log.WithError(err).Warn("failed to persist config revision")
+	}
+}
+
+// loadRevisions rehydrates the revision history from the state-db. Values are
+// decoded as json.Number so large integers survive a rollback unchanged.
+func (s *Service) loadRevisions() {
+	rows, err := s.store.GetConfigRevisions(maxRevisions)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to load config revisions")
+		return
+	}
+
+	for _, row := range rows {
+		var changes []Change
+
+		dec := json.NewDecoder(bytes.NewReader([]byte(row.Changes)))
+		dec.UseNumber()
+
+		if err := dec.Decode(&changes); err != nil {
+			s.log.WithError(err).WithField("version", row.Version).Warn("ignoring undecodable config revision")
+			continue
+		}
+
+		s.revisions = append(s.revisions, Revision{
+			Version:   row.Version,
+			Timestamp: row.Timestamp,
+			Actor:     row.Actor,
+			Source:    row.Source,
+			Changes:   changes,
+		})
+	}
+}
@@ -138,3 +138,93 @@ func TestUnsuppliedUsesDefault(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, defaults.EPBS.BidSubsidy, svc.Load().EPBS.BidSubsidy)
 }
+
+// TestUpdateOptimisticConcurrency verifies version-guarded updates: a stale
+// version is rejected, and re-applying overrides already in effect is an
+// idempotent no-op that keeps the version.
+func TestUpdateOptimisticConcurrency(t *testing.T) {
+	store := db.NewDatabase(&db.Config{File: ""}, testLogger())
+	require.NoError(t, store.Init())
+
+	defaults := defaultsConfig()
+	svc := boot(t, store, defaults, nil)
+	base := svc.Version()
+
+	changes, version, err := svc.Update(map[string]json.RawMessage{subsidyKey: json.RawMessage("600")}, "alice", base)
+	require.NoError(t, err)
+	require.Greater(t, version, base)
+	require.Equal(t, version, svc.Version())
+	require.Equal(t, []Change{{Key: subsidyKey, From: defaults.EPBS.BidSubsidy, To: uint64(600)}}, changes)
+
+	// A concurrent edit based on the old version is rejected untouched.
+	_, current, err := svc.Update(map[string]json.RawMessage{subsidyKey: json.RawMessage("700")}, "bob", base)
+	require.ErrorIs(t, err, ErrVersionConflict)
+	require.Equal(t, version, current)
+	require.Equal(t, uint64(600), svc.Load().EPBS.BidSubsidy)
+
+	// Retrying the applied edit changes nothing, even with the stale version.
+	changes, current, err = svc.Update(map[string]json.RawMessage{subsidyKey: json.RawMessage("600")}, "alice", base)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, version, current)
+
+	// Unconditional updates (SetMany) still apply.
+	setSubsidy(t, svc, 700)
+	require.Equal(t, uint64(700), svc.Load().EPBS.BidSubsidy)
+	require.Greater(t, svc.Version(), version)
+}
+
+// TestRevisionsAndRollback verifies that every effective change is recorded
+// (API updates and changed operator config on restart) and that rolling back
+// restores the values of a revision and survives restarts.
+func TestRevisionsAndRollback(t *testing.T) {
+	dir := t.TempDir()
+	store := db.NewDatabase(&db.Config{File: filepath.Join(dir, "state.db")}, testLogger())
+	require.NoError(t, store.Init())
+
+	defaults := defaultsConfig()
+	svc := boot(t, store, defaults, u64(500))
+	require.Empty(t, svc.Revisions(0), "a first boot is not a reload")
+
+	setSubsidy(t, svc, 600)
+	first := svc.Version()
+	setSubsidy(t, svc, 700)
+
+	// Restart with a changed flag: recorded as a reload revision.
+	svc = boot(t, store, defaults, u64(800))
+	require.Equal(t, uint64(800), svc.Load().EPBS.BidSubsidy)
+
+	revisions := svc.Revisions(0)
+	require.Len(t, revisions, 3)
+	require.Equal(t, RevisionSourceReload, revisions[0].Source)
+	require.Equal(t, SourceCLI, revisions[0].Actor)
+	require.Equal(t, "tester", revisions[2].Actor)
+	require.Equal(t, first, revisions[2].Version)
+
+	_, _, err := svc.Rollback(first-1, "alice", -1)
+	require.ErrorIs(t, err, ErrUnknownRevision)
+
+	_, _, err = svc.Rollback(first, "alice", first)
+	require.ErrorIs(t, err, ErrVersionConflict)
+
+	changes, version, err := svc.Rollback(first, "alice", svc.Version())
+	require.NoError(t, err)
+	require.Equal(t, uint64(600), svc.Load().EPBS.BidSubsidy)
+	require.Len(t, changes, 1)
+	require.Equal(t, uint64(800), changes[0].From)
+
+	latest := svc.Revisions(1)
+	require.Len(t, latest, 1)
+	require.Equal(t, RevisionSourceRollback, latest[0].Source)
+	require.Equal(t, version, latest[0].Version)
+
+	// The history survives a restart and can still be rolled back through.
+	svc = boot(t, store, defaults, u64(800))
+	require.Equal(t, uint64(600), svc.Load().EPBS.BidSubsidy)
+
+	_, _, err = svc.Rollback(revisions[0].Version, "bob", -1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(800), svc.Load().EPBS.BidSubsidy)
+
+	require.NoError(t, store.Close())
+}
@@ -1,6 +1,13 @@
 // Package config handles configuration loading and validation for buildoor.
 package config
 
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
 // ValidatorRangesConfig configures how to load validator index → client name mappings.
 // If both are set, URL takes precedence.
 type ValidatorRangesConfig struct {
@@ -22,31 +29,58 @@ type Config struct {
 	// BuilderKeyIndex using the standard validator key path m/12381/3600/{index}/0/0.
 	// Mutually exclusive with BuilderPrivkey. json:"-" keeps the secret out of every JSON
 	// serialization path (WebUI REST + SSE); YAML config loading is unaffected.
-	BuilderMnemonic   string           `yaml:"builder_mnemonic" json:"-"`
-	BuilderKeyIndex   uint64           `yaml:"builder_key_index" json:"builder_key_index"`
-	CLClient          string           `yaml:"cl_client" json:"cl_client,omitempty"`
-	ELEngineAPI       string           `yaml:"el_engine_api" json:"el_engine_api,omitempty"`   // Engine API URL (required for payload building)
-	ELJWTSecret       string           `yaml:"el_jwt_secret" json:"el_jwt_secret,omitempty"`   // Path to JWT secret file for engine API auth
-	ELRPC             string           `yaml:"el_rpc" json:"el_rpc,omitempty"`                 // Optional: EL JSON-RPC for transactions (lifecycle only)
-	WalletPrivkey     string           `yaml:"wallet_privkey" json:"wallet_privkey,omitempty"` // Optional: only if lifecycle enabled
-	APIPort           int              `yaml:"api_port" json:"api_port"`                       // Optional, 0 = disabled
-	AuthProviderURL   string           `yaml:"auth_provider_url" json:"auth_provider_url"`     // Optional: authenticatoor URL; when set, API requests must carry a JWT verified against the authenticatoor's JWKS. When empty, the API is unauthenticated.
-	InjectHeadHTML    string           `yaml:"inject_head_html" json:"inject_head_html"`       // Optional: raw HTML snippet (e.g. analytics tags) injected into <head> of the served SPA. Falls back to BUILDOOR_INJECT_HEAD_HTML env var when empty.
-	OverviewURL       string           `yaml:"overview_url" json:"overview_url"`               // Optional: URL of the multi-instance overview UI. When set, the dashboard renders an "Overview" entry in the top nav so operators get consistent navigation across instances.
-	LifecycleEnabled  bool             `yaml:"lifecycle_enabled" json:"lifecycle_enabled"`
-	EPBSEnabled       bool             `yaml:"epbs_enabled" json:"epbs_enabled"`               // Initial enabled state for ePBS (service available if Gloas fork is scheduled)
-	BuilderAPIEnabled bool             `yaml:"builder_api_enabled" json:"builder_api_enabled"` // Initial enabled state for Builder API
-	BuilderAPI        BuilderAPIConfig `yaml:"builder_api" json:"builder_api"`                 // Builder API configuration
-	DepositAmount     uint64           `yaml:"deposit_amount" json:"deposit_amount"`           // Gwei, default 10 ETH
-	TopupThreshold    uint64           `yaml:"topup_threshold" json:"topup_threshold"`         // Gwei
-	TopupAmount       uint64           `yaml:"topup_amount" json:"topup_amount"`               // Gwei
-	DepositMaxFeeGwei uint64           `yaml:"deposit_max_fee" json:"deposit_max_fee"`
-	Schedule          ScheduleConfig   `yaml:"schedule" json:"schedule"`
-	EPBS              EPBSConfig       `yaml:"epbs" json:"epbs"`     // Time-scheduled ePBS config
-	Reveal            RevealConfig     `yaml:"reveal" json:"reveal"` // Payload reveal config (shared by p2p bidder + Builder API)
-	Debug             bool             `yaml:"debug" json:"debug"`
-	Pprof             bool             `yaml:"pprof" json:"pprof"`
-	PayloadBuildTime  uint64           `yaml:"payload_build_time" json:"payload_build_time"` // The time given to the EL to build the payload after triggering the payload build via fcu (in ms)
+	BuilderMnemonic string `yaml:"builder_mnemonic" json:"-"`
+	BuilderKeyIndex uint64 `yaml:"builder_key_index" json:"builder_key_index"`
+	CLClient        string `yaml:"cl_client" json:"cl_client,omitempty"`
+	// CLClientFallbacks are further beacon nodes the CL client fails over to
+	// (scored by health checks) when CLClient is unreachable or lagging.
+	// Startup-only.
+	CLClientFallbacks []string `yaml:"cl_client_fallbacks" json:"cl_client_fallbacks,omitempty"`
+	CLClientSSZ       bool     `yaml:"cl_client_ssz" json:"cl_client_ssz"`             // Negotiate SSZ with the beacon node (JSON fallback on undecodable fetches); false forces JSON
+	ELEngineAPI       string   `yaml:"el_engine_api" json:"el_engine_api,omitempty"`   // Engine API URL, http(s) only (required for payload building)
+	ELJWTSecret       string   `yaml:"el_jwt_secret" json:"el_jwt_secret,omitempty"`   // Path to JWT secret file for engine API auth
+	ELRPC             string   `yaml:"el_rpc" json:"el_rpc,omitempty"`                 // Optional: EL JSON-RPC for transactions (lifecycle only)
+	WalletPrivkey     string   `yaml:"wallet_privkey" json:"wallet_privkey,omitempty"` // Optional: only if lifecycle enabled
+	// ELEngineRaceAPIs are further engine APIs (sharing ELJWTSecret) every
+	// payload build is also requested from; the highest-value payload of the
+	// race wins. Startup-only.
+	ELEngineRaceAPIs []string `yaml:"el_engine_race_apis" json:"el_engine_race_apis,omitempty"`
+	// ELWSRPC is an optional EL WebSocket JSON-RPC URL whose newHeads
+	// subscription withdraws (and, in budget, rebuilds) payloads built on a
+	// parent the EL head moved away from, before the new payload_attributes
+	// arrive. Startup-only.
+	ELWSRPC string `yaml:"el_ws_rpc" json:"el_ws_rpc,omitempty"`
+	// BuilderTxs are raw signed transactions (hex) injected into the next
+	// built payloads via the EL mempool (requires ELRPC); more can be queued
+	// at runtime (POST /api/buildoor/txs). Startup-only.
+	BuilderTxs []string `yaml:"builder_txs" json:"builder_txs,omitempty"`
+	// BuilderWithdrawalAddress is the execution (withdrawal) address the
+	// builder record in the beacon state must carry; empty = the wallet
+	// address when lifecycle deposits with it, otherwise unchecked.
+	// Startup-only.
+	BuilderWithdrawalAddress string           `yaml:"builder_withdrawal_address" json:"builder_withdrawal_address,omitempty"`
+	APIPort                  int              `yaml:"api_port" json:"api_port"`                   // Optional, 0 = disabled
+	AuthProviderURL          string           `yaml:"auth_provider_url" json:"auth_provider_url"` // Optional: authenticatoor URL; when set, API requests must carry a JWT verified against the authenticatoor's JWKS. When empty, the API is unauthenticated.
+	InjectHeadHTML           string           `yaml:"inject_head_html" json:"inject_head_html"`   // Optional: raw HTML snippet (e.g. analytics tags) injected into <head> of the served SPA. Falls back to BUILDOOR_INJECT_HEAD_HTML env var when empty.
+	OverviewURL              string           `yaml:"overview_url" json:"overview_url"`           // Optional: URL of the multi-instance overview UI. When set, the dashboard renders an "Overview" entry in the top nav so operators get consistent navigation across instances.
+	LifecycleEnabled         bool             `yaml:"lifecycle_enabled" json:"lifecycle_enabled"`
+	LifecycleReadOnly        bool             `yaml:"lifecycle_read_only" json:"lifecycle_read_only"` // Track an out-of-band builder registration (state, balance, pending payments) without ever sending deposits or exits; no wallet needed. Startup-only
+	EPBSEnabled              bool             `yaml:"epbs_enabled" json:"epbs_enabled"`               // Initial enabled state for ePBS (service available if Gloas fork is scheduled)
+	BuilderAPIEnabled        bool             `yaml:"builder_api_enabled" json:"builder_api_enabled"` // Initial enabled state for Builder API
+	BuilderAPI               BuilderAPIConfig `yaml:"builder_api" json:"builder_api"`                 // Builder API configuration
+	DepositAmount            uint64           `yaml:"deposit_amount" json:"deposit_amount"`           // Gwei, default 10 ETH
+	TopupThreshold           uint64           `yaml:"topup_threshold" json:"topup_threshold"`         // Gwei
+	TopupAmount              uint64           `yaml:"topup_amount" json:"topup_amount"`               // Gwei
+	DepositMaxFeeGwei        uint64           `yaml:"deposit_max_fee" json:"deposit_max_fee"`
+	DepositBatchContract     string           `yaml:"deposit_batch_contract" json:"deposit_batch_contract,omitempty"` // Optional: batching contract sending several top-ups in one transaction (concatenated 184-byte requests). Startup-only
+	Schedule                 ScheduleConfig   `yaml:"schedule" json:"schedule"`
+	EPBS                     EPBSConfig       `yaml:"epbs" json:"epbs"`             // Time-scheduled ePBS config
+	Reveal                   RevealConfig     `yaml:"reveal" json:"reveal"`         // Payload reveal config (shared by p2p bidder + Builder API)
+	Canary                   CanaryConfig     `yaml:"canary" json:"canary"`         // Canary bid mode (fixed minimal bids)
+	BidBudget                BidBudgetConfig  `yaml:"bid_budget" json:"bid_budget"` // Per-epoch p2p bid spend cap
+	Debug                    bool             `yaml:"debug" json:"debug"`
+	Pprof                    bool             `yaml:"pprof" json:"pprof"`
+	PayloadBuildTime         uint64           `yaml:"payload_build_time" json:"payload_build_time"` // The time given to the EL to build the payload after triggering the payload build via fcu (in ms)
 	// ExtraData is the prefix injected into the built payload's extra-data field
 	// (then padded with the EL's original extra data, truncated to 32 bytes). Used
 	// to mark blocks built by this builder. Defaulted to "buildoor/" when empty.
@@ -61,6 +95,17 @@ type Config struct {
 	// Raw payloads dominate disk usage — lower this on disk-sensitive
 	// deployments. Must be > 0.
 	SlotArtifactRetentionEpochs uint64 `yaml:"slot_artifact_retention_epochs" json:"slot_artifact_retention_epochs"`
+	// SlotResultMaxSlots caps the result history at the newest N slots on top
+	// of the retention window (0 = no cap).
+	SlotResultMaxSlots uint64 `yaml:"slot_result_max_slots" json:"slot_result_max_slots"`
+	// SlotArtifactMaxMB caps the stored artifact data in the slot_artifacts
+	// table, pruning the oldest slots first, on top of the retention window
+	// (0 = no cap).
+	SlotArtifactMaxMB uint64 `yaml:"slot_artifact_max_mb" json:"slot_artifact_max_mb"`
+	// RetentionArchiveDir, when set, archives pruned result summaries and
+	// state-db artifacts to gzip-compressed NDJSON files in this directory
+	// (one per store and UTC day) before they are pruned. Startup-only.
+	RetentionArchiveDir string `yaml:"retention_archive_dir" json:"retention_archive_dir,omitempty"`
 	// SlotArtifactCaptureEnabled toggles raw SSZ artifact capture. Result
 	// summaries are recorded regardless.
 	SlotArtifactCaptureEnabled bool `yaml:"slot_artifact_capture_enabled" json:"slot_artifact_capture_enabled"`
@@ -69,6 +114,367 @@ type Config struct {
 	// proposer preferences and an audit log across restarts. Startup-only and
 	// never itself persisted. Empty disables persistence (in-memory only).
 	StateDBPath string `yaml:"state_db" json:"state_db,omitempty"`
+	// AuditExport configures the optional per-slot audit summary exporter.
+	AuditExport AuditExportConfig `yaml:"audit_export" json:"audit_export"`
+	// PeerMesh configures bid sharing with other buildoor instances.
+	PeerMesh PeerMeshConfig `yaml:"peer_mesh" json:"peer_mesh"`
+	// AlertRulesFile, when set, loads alerting threshold rules from this
+	// YAML file and starts the rules engine. Startup-only.
+	AlertRulesFile string `yaml:"alert_rules_file" json:"alert_rules_file,omitempty"`
+	// CircuitBreakerThreshold is the number of consecutive p2p bid submission
+	// or reveal failures after which the offending service is disabled.
+	// Startup-only; 0 disables the circuit breaker.
+	CircuitBreakerThreshold int `yaml:"circuit_breaker_threshold" json:"circuit_breaker_threshold"`
+	// Watchdog configures the per-epoch end-to-end liveness check.
+	Watchdog WatchdogConfig `yaml:"watchdog" json:"watchdog"`
+	// PenaltyMonitor watches the beacon node for equivocation evidence
+	// against our builder indices and holds bidding until an operator
+	// acknowledges it. Startup-only.
+	PenaltyMonitor bool `yaml:"penalty_monitor" json:"penalty_monitor"`
+	// CapabilityCheck probes the beacon node at startup and disables the
+	// optional features it lacks the topics or endpoints for (reported as
+	// degraded mode in /api/status). Startup-only.
+	CapabilityCheck bool `yaml:"capability_check" json:"capability_check"`
+	// ClockSpeedup runs buildoor's clock this many times faster than wall
+	// time from the chain's genesis on, for accelerated local simulation
+	// against beacon and execution nodes that share the speedup. 1 (or 0)
+	// is wall time. Startup-only; never set on a real network.
+	ClockSpeedup float64 `yaml:"clock_speedup" json:"clock_speedup,omitempty"`
+	// Signer selects where the builder key signs. Startup-only.
+	Signer SignerConfig `yaml:"signer" json:"signer"`
+	// RelayProxy configures the relay registration proxy. Startup-only.
+	RelayProxy RelayProxyConfig `yaml:"relay_proxy" json:"relay_proxy"`
+	// Identity is the operator-set builder identity. Startup-only.
+	Identity IdentityConfig `yaml:"identity" json:"identity"`
+	// Withdrawals selects where built payloads take their withdrawals from.
+	Withdrawals WithdrawalsConfig `yaml:"withdrawals" json:"withdrawals"`
+	// FeeRecipientRotation rotates the builder's own fee recipient across an
+	// address pool (devnet testing). Startup-only.
+	FeeRecipientRotation FeeRecipientRotationConfig `yaml:"fee_recipient_rotation" json:"fee_recipient_rotation"`
+	// PayloadFaults corrupts fields of a share of built payloads so client
+	// rejection paths can be exercised (devnet testing). Startup-only.
+	PayloadFaults PayloadFaultsConfig `yaml:"payload_faults" json:"payload_faults"`
+	// BlobStuffing fills scheduled blocks with synthetic blob transactions
+	// from the builder wallet (devnet blob throughput testing). Startup-only.
+	BlobStuffing BlobStuffingConfig `yaml:"blob_stuffing" json:"blob_stuffing"`
+	// GasLimit overrides or deliberately violates the gas limit target of
+	// built payloads (devnet testing). Startup-only.
+	GasLimit GasLimitConfig `yaml:"gas_limit" json:"gas_limit"`
+	// ExtraBuilders are additional builder identities run next to the
+	// primary builder key: each bids in the same slots through its own p2p
+	// bidder. Startup-only; json:"-" keeps the keys out of every JSON path.
+	ExtraBuilders []ExtraBuilderConfig `yaml:"extra_builders" json:"-"`
+	// Modules selects the optional modules to construct (Module* names;
+	// dependencies are added, empty = all). Startup-only.
+	Modules []string `yaml:"modules" json:"modules,omitempty"`
+	// RecordEventsFile appends every head and payload_attributes event to
+	// this file for `buildoor simulate --replay` (empty = off).
+	// Startup-only.
+	RecordEventsFile string `yaml:"record_events_file" json:"record_events_file,omitempty"`
+}
+
+// ExtraBuilderConfig is one additional builder identity (p2p bidding only;
+// the Builder API serves the primary builder key).
+type ExtraBuilderConfig struct {
+	// Privkey is the builder BLS private key (hex), always signed locally.
+	Privkey string `yaml:"privkey"`
+
+	// WalletPrivkey funds the builder's deposits, top-ups and exits through
+	// its own lifecycle manager (hex). Empty: the builder must be registered
+	// out of band and its registration is tracked read-only.
+	WalletPrivkey string `yaml:"wallet_privkey"`
+}
+
+// CLClientURLs returns every beacon node URL, the primary CLClient first.
+func (c *Config) CLClientURLs() []string {
+	return append([]string{c.CLClient}, c.CLClientFallbacks...)
+}
+
+// ExtraDataBranding returns the extra-data prefix of built payloads: the
+// ExtraData prefix followed by the identity name (when set).
+func (c *Config) ExtraDataBranding() string {
+	return c.ExtraData + c.Identity.Name
+}
+
+// IdentityConfig is the operator-set builder identity: appended to the payload
+// extra-data branding, served by the Builder API info endpoint
+// (/eth/v1/builder/info) and sent along with relay proxy requests.
+type IdentityConfig struct {
+	// Name is the builder's display name.
+	Name string `yaml:"name" json:"name,omitempty"`
+
+	// URL is the operator's website or documentation URL.
+	URL string `yaml:"url" json:"url,omitempty"`
+
+	// Contact is how to reach the operator (e-mail, handle, ...).
+	Contact string `yaml:"contact" json:"contact,omitempty"`
+}
+
+// Withdrawals sources of payload builds.
+const (
+	// WithdrawalsSourceAttributes takes the withdrawals the beacon node sent
+	// in payload_attributes (the canonical list for the build).
+	WithdrawalsSourceAttributes = "attributes"
+	// WithdrawalsSourceState takes the withdrawals the beacon node computes
+	// from the parent state (expected_withdrawals).
+	WithdrawalsSourceState = "state"
+)
+
+// WithdrawalsConfig selects the withdrawals source of payload builds. The
+// source not selected is the fallback when the preferred one is unavailable
+// and, with CrossCheck, validates it.
+type WithdrawalsConfig struct {
+	// Source is the preferred withdrawals source: attributes (default) |
+	// state. Unknown values fall back to attributes.
+	Source string `yaml:"source" json:"source"`
+
+	// CrossCheck fetches both sources for every build and reports
+	// divergences (log, stats, slot build outcome). Costs one beacon node
+	// state lookup per build.
+	CrossCheck bool `yaml:"cross_check" json:"cross_check"`
+}
+
+// NormalizedSource returns the preferred withdrawals source, falling back to
+// attributes for unknown values.
+func (c *WithdrawalsConfig) NormalizedSource() string {
+	if c.Source == WithdrawalsSourceState {
+		return WithdrawalsSourceState
+	}
+
+	return WithdrawalsSourceAttributes
+}
+
+// Fee recipient rotation modes.
+const (
+	// FeeRecipientRotationOff keeps the builder's single fee recipient.
+	FeeRecipientRotationOff = "off"
+	// FeeRecipientRotationSlot picks the next pool address every slot.
+	FeeRecipientRotationSlot = "slot"
+	// FeeRecipientRotationEpoch picks the next pool address every epoch.
+	FeeRecipientRotationEpoch = "epoch"
+)
+
+// FeeRecipientRotationConfig rotates the builder's own fee recipient (the
+// suggested fee recipient of our payload builds, i.e. the block coinbase)
+// across an address pool, to exercise indexers and payment-tracking tooling
+// with many builder addresses. The pool index is the slot (or epoch) modulo
+// the pool size, so the address of any slot is predictable.
+type FeeRecipientRotationConfig struct {
+	// Mode is off (default) | slot | epoch. Unknown values fall back to off.
+	Mode string `yaml:"mode" json:"mode"`
+
+	// Pool is the list of 0x-prefixed addresses rotated through.
+	Pool []string `yaml:"pool" json:"pool,omitempty"`
+}
+
+// NormalizedMode returns the rotation mode, falling back to off for unknown
+// values.
+func (c *FeeRecipientRotationConfig) NormalizedMode() string {
+	switch c.Mode {
+	case FeeRecipientRotationSlot, FeeRecipientRotationEpoch:
+		return c.Mode
+	default:
+		return FeeRecipientRotationOff
+	}
+}
+
+// Payload fault fields: the parts of a built payload fault injection can
+// corrupt.
+const (
+	// PayloadFaultStateRoot replaces the execution payload's state root.
+	PayloadFaultStateRoot = "state_root"
+	// PayloadFaultReceiptsRoot replaces the execution payload's receipts root.
+	PayloadFaultReceiptsRoot = "receipts_root"
+	// PayloadFaultWithdrawals alters the payload's withdrawals (the first
+	// amount, or a bogus entry when the list is empty).
+	PayloadFaultWithdrawals = "withdrawals"
+	// PayloadFaultBlobCommitments alters the first KZG commitment of the
+	// payload's blobs bundle.
+	PayloadFaultBlobCommitments = "blob_commitments"
+)
+
+// PayloadFaultFields lists every corruptible payload field.
+var PayloadFaultFields = []string{
+	PayloadFaultStateRoot,
+	PayloadFaultReceiptsRoot,
+	PayloadFaultWithdrawals,
+	PayloadFaultBlobCommitments,
+}
+
+// ValidatePayloadFaults checks that every field names a corruptible payload
+// field.
+func ValidatePayloadFaults(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(PayloadFaultFields, field) {
+			return fmt.Errorf("unknown payload fault field %q: must be one of %s",
+				field, strings.Join(PayloadFaultFields, ", "))
+		}
+	}
+
+	return nil
+}
+
+// PayloadFaultsConfig enables probabilistic invalid-payload injection: each
+// slot's roll is a deterministic function of the slot, and Pct percent of
+// the built slots get Fields corrupted before bidding and reveal. Per-slot
+// plans (build.faults) select slots explicitly on top of this.
+type PayloadFaultsConfig struct {
+	// Fields are the PayloadFault* fields corrupted in the selected slots.
+	Fields []string `yaml:"fields" json:"fields,omitempty"`
+
+	// Pct is the share of built slots corrupted (0-100, 0 = off).
+	Pct uint64 `yaml:"pct" json:"pct"`
+}
+
+// Enabled reports whether probabilistic fault injection is configured.
+func (c *PayloadFaultsConfig) Enabled() bool {
+	return c.Pct > 0 && len(c.Fields) > 0
+}
+
+// Blob stuffing levels besides an explicit blob count.
+const (
+	// BlobStuffingTarget fills blocks to the fork's target blob count.
+	BlobStuffingTarget = "target"
+	// BlobStuffingMax fills blocks to the fork's maximum blob count.
+	BlobStuffingMax = "max"
+	// BlobStuffingOff suppresses the schedule's stuffing for a slot (plans
+	// only).
+	BlobStuffingOff = "off"
+)
+
+// ValidateBlobStuffing checks a blob stuffing level: target, max or a blob
+// count above 0.
+func ValidateBlobStuffing(level string) error {
+	if level == BlobStuffingTarget || level == BlobStuffingMax {
+		return nil
+	}
+
+	if count, err := strconv.ParseUint(level, 10, 64); err != nil || count == 0 {
+		return fmt.Errorf("invalid blob stuffing level %q: must be %s, %s or a blob count",
+			level, BlobStuffingTarget, BlobStuffingMax)
+	}
+
+	return nil
+}
+
+// BlobStuffingConfig schedules blob stuffing: ahead of the builds of every
+// EveryNth slot, synthetic blob transactions are signed by the builder wallet
+// and submitted to the EL mempool so the block carries Blobs blobs. Per-slot
+// plans (build.blob_stuffing) select slots explicitly on top of this.
+type BlobStuffingConfig struct {
+	// Blobs is the blob count scheduled blocks are filled to: target, max
+	// or a number (capped at the maximum). Empty disables the schedule.
+	Blobs string `yaml:"blobs" json:"blobs,omitempty"`
+
+	// EveryNth stuffs the slots divisible by it (0 or 1 = every slot).
+	EveryNth uint64 `yaml:"every_nth" json:"every_nth"`
+}
+
+// Enabled reports whether scheduled blob stuffing is configured.
+func (c *BlobStuffingConfig) Enabled() bool {
+	return c.Blobs != ""
+}
+
+// Scheduled reports whether the schedule stuffs the slot.
+func (c *BlobStuffingConfig) Scheduled(slot uint64) bool {
+	return c.Enabled() && (c.EveryNth <= 1 || slot%c.EveryNth == 0)
+}
+
+// MinGasLimit is the protocol minimum of a block's gas limit.
+const MinGasLimit = 5000
+
+// ValidateGasLimit checks a gas limit target: 0 (none) or at least
+// MinGasLimit.
+func ValidateGasLimit(target uint64) error {
+	if target != 0 && target < MinGasLimit {
+		return fmt.Errorf("invalid gas limit %d: must be 0 or at least %d", target, MinGasLimit)
+	}
+
+	return nil
+}
+
+// GasLimitConfig controls the gas limit of built payloads. Without it the
+// payloads follow the proposer's target: the registered gas_limit pre-Gloas,
+// the announced target gas limit from Gloas on. Per-slot plans
+// (build.gas_limit, build.gas_limit_violate) override it.
+type GasLimitConfig struct {
+	// Target is the gas limit built payloads move toward (by at most the
+	// protocol step per block), replacing the proposer's target. 0 (default)
+	// keeps the proposer's.
+	Target uint64 `yaml:"target" json:"target"`
+
+	// Violate moves the gas limit the full protocol step away from the
+	// target instead: a consensus-valid block that ignores the proposer's
+	// preference, for negative testing. Requires --el-rpc.
+	Violate bool `yaml:"violate" json:"violate"`
+}
+
+// PeerMeshConfig configures the optional HTTP mesh between buildoor instances
+// on the same devnet: each node polls its peers' observed p2p bids for its
+// network-wide view. Bids verifying against their builder's registered
+// pubkey also feed counter-bidding. Startup-only; no peers disables polling.
+type PeerMeshConfig struct {
+	// Name labels this node in peer responses. Empty defaults to the short
+	// builder pubkey.
+	Name string `yaml:"name" json:"name,omitempty"`
+
+	// Peers are the API base URLs (http://host:api-port) of the other nodes.
+	Peers []string `yaml:"peers" json:"peers,omitempty"`
+
+	// PollIntervalMs is the wait between polls of every peer.
+	PollIntervalMs int64 `yaml:"poll_interval_ms" json:"poll_interval_ms"`
+}
+
+// WatchdogConfig configures the liveness watchdog: every finished epoch in
+// which no payload was built for any eligible slot, or no slot was evaluated
+// at all, raises a critical alert.
+type WatchdogConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// RestartEventStream reconnects the beacon node event stream when an
+	// epoch fails the check.
+	RestartEventStream bool `yaml:"restart_event_stream" json:"restart_event_stream"`
+}
+
+// SignerConfig selects the builder key's signing backend.
+type SignerConfig struct {
+	// Backend is "local" (default; the in-process BuilderPrivkey or
+	// BuilderMnemonic key) or "web3signer" (a Web3Signer-compatible signing
+	// service holding the key).
+	Backend string `yaml:"backend" json:"backend,omitempty"`
+
+	// RemoteURL is the remote signing service's base URL.
+	RemoteURL string `yaml:"remote_url" json:"remote_url,omitempty"`
+
+	// RemotePubkey is the hex BLS public key the remote signer signs with
+	// (optional when it holds a single key).
+	RemotePubkey string `yaml:"remote_pubkey" json:"remote_pubkey,omitempty"`
+}
+
+// RelayProxyConfig configures the optional relay proxy: validator clients
+// point their builder URL at {api}/relay-proxy and buildoor forwards their
+// registrations, getHeader and blinded block requests to the relays, recording
+// every exchange. Startup-only; no relays disables the proxy.
+type RelayProxyConfig struct {
+	// Relays are the relay base URLs requests are forwarded to.
+	Relays []string `yaml:"relays" json:"relays,omitempty"`
+}
+
+// AuditExportConfig configures the optional audit exporter: a signed summary
+// of each slot's builder actions (bid roots, reveal status, payment) is POSTed
+// to URL for external builder-behavior monitoring on shared devnets.
+// Startup-only; an empty URL disables the exporter.
+type AuditExportConfig struct {
+	// URL receives one JSON summary per slot with recorded builder activity.
+	URL string `yaml:"url" json:"url,omitempty"`
+
+	// Secret is the HMAC-SHA256 key the summaries are signed with. Required
+	// when URL is set. json:"-" keeps it out of every JSON serialization path.
+	Secret string `yaml:"secret" json:"-"`
+
+	// DelaySlots is how many slots after a slot its summary is exported, so
+	// the reveal and the inclusion verdict have settled.
+	DelaySlots uint64 `yaml:"delay_slots" json:"delay_slots"`
 }
 
 // ScheduleConfig defines when the builder should build blocks.
@@ -113,6 +519,164 @@ type BuilderAPIConfig struct {
 	// (block value + subsidy) with this absolute amount in gwei — an alternative
 	// to the subsidy for testing. Per-slot action plans override this per slot.
 	ValueOverrideGwei uint64 `yaml:"value_override_gwei" json:"value_override_gwei"`
+
+	// ValueBoostPct, when non-zero, reports served bid values at this
+	// percentage of their real total value (after subsidy and overrides, e.g.
+	// 150 = 1.5x), decoupling the advertised value from the payload's to test
+	// how proposers and relays treat bids that lie about their value.
+	// Test-only: boosted bids are marked in slot results, the epoch summary
+	// and /api/stats. 0 (default) is off; canary mode wins over it.
+	ValueBoostPct uint64 `yaml:"value_boost_pct" json:"value_boost_pct"`
+
+	// SlotTolerance widens the accepted slot window of bid requests and block
+	// submissions (the current and next wall-clock slot) by this many slots
+	// in both directions. 0 (default) serves no bids for past slots.
+	SlotTolerance uint64 `yaml:"slot_tolerance" json:"slot_tolerance"`
+
+	// VerifyProposerSignature requires a valid proposer signature on submitted
+	// blinded blocks before their payload is released (default true). Devnets
+	// with unknown proposer keys or non-standard signing can turn it off.
+	VerifyProposerSignature bool `yaml:"verify_proposer_signature" json:"verify_proposer_signature"`
+
+	// PublishBeaconNodes are additional beacon node URLs the unblinded block
+	// of a submitted blinded block is published to, concurrently with the
+	// primary CL client; the proposer is answered on the first acceptance.
+	PublishBeaconNodes []string `yaml:"publish_beacon_nodes" json:"publish_beacon_nodes,omitempty"`
+
+	// BroadcastValidation is the validation level the beacon nodes must apply
+	// before broadcasting the unblinded block: gossip (default) | consensus |
+	// consensus_and_equivocation. Unknown values fall back to gossip.
+	BroadcastValidation string `yaml:"broadcast_validation" json:"broadcast_validation"`
+
+	// BlobSidecars controls the separate blob sidecar publication of Fulu
+	// blocks with blobs, which some CL client/devnet combinations need next
+	// to the block publication: auto (default; only to nodes that do not hold
+	// the block's blobs after accepting it) | always | never.
+	BlobSidecars string `yaml:"blob_sidecars" json:"blob_sidecars"`
+
+	// ParentCandidates is how many alternative parents per slot get their own
+	// candidate payload when the slot's payload_attributes change parent
+	// (unstable chain), so bid requests for any of them are served instead of
+	// rejected. 0 (default) builds on the latest parent only.
+	ParentCandidates int `yaml:"parent_candidates" json:"parent_candidates"`
+
+	// PaymentTx seals every pre-Gloas payload built for a registered proposer
+	// with a transfer of the block value from the builder wallet to the
+	// proposer's fee recipient (the payload's coinbase is the builder's), so
+	// the getHeader bid value is actually paid. Requires --wallet-privkey and
+	// --el-rpc; payloads that cannot be sealed are served unsealed.
+	PaymentTx bool `yaml:"payment_tx" json:"payment_tx"`
+
+	// ClientQuirks enables workarounds for the known quirks of individual
+	// consensus clients, as "client:quirk" entries (ClientQuirk*). The client
+	// of a request is detected from its User-Agent, so one instance can
+	// serve a heterogeneous validator set.
+	ClientQuirks []string `yaml:"client_quirks" json:"client_quirks,omitempty"`
+
+	// CaptureSize is how many of the most recent Builder API request/response
+	// exchanges are kept in memory (secrets scrubbed) for download from the
+	// authenticated captures endpoint. 0 disables capturing. Startup-only.
+	CaptureSize int `yaml:"capture_size" json:"capture_size"`
+
+	// CaptureMaxBody caps the captured bytes of each request and response
+	// body; longer bodies are truncated. Startup-only.
+	CaptureMaxBody int `yaml:"capture_max_body" json:"capture_max_body"`
+
+	// BackfillRelays are relay base URLs the registrations of each epoch's
+	// proposers are pulled from (relay data API) into the validator
+	// registration store, so hybrid setups serve proposers that registered
+	// only with a relay. Backfilled registrations are signature-checked and
+	// only replace older ones. Startup-only; empty disables the backfill.
+	BackfillRelays []string `yaml:"backfill_relays" json:"backfill_relays,omitempty"`
+}
+
+// HasClientQuirk reports whether the quirk is enabled for the client.
+func (c *BuilderAPIConfig) HasClientQuirk(client, quirk string) bool {
+	return slices.Contains(c.ClientQuirks, client+":"+quirk)
+}
+
+// Client quirk workarounds of the legacy Builder API.
+const (
+	// ClientQuirkLenientTimestamp accepts validator registrations timestamped
+	// in the future (clock skew).
+	ClientQuirkLenientTimestamp = "lenient_timestamp"
+	// ClientQuirkLenientContentType reads request bodies with a missing or
+	// unsupported Content-Type as JSON instead of answering 415.
+	ClientQuirkLenientContentType = "lenient_content_type"
+	// ClientQuirkJSONResponses answers JSON even when the Accept header
+	// prefers SSZ (clients with broken SSZ decoding).
+	ClientQuirkJSONResponses = "json_responses"
+)
+
+// ClientQuirkNames lists every client quirk workaround.
+var ClientQuirkNames = []string{
+	ClientQuirkLenientTimestamp,
+	ClientQuirkLenientContentType,
+	ClientQuirkJSONResponses,
+}
+
+// QuirkClients lists the clients detected from a request's User-Agent, in
+// detection order: mev-boost comes last as it prepends itself to the
+// User-Agent of the beacon node it forwards for.
+var QuirkClients = []string{
+	"grandine",
+	"lighthouse",
+	"lodestar",
+	"nimbus",
+	"prysm",
+	"teku",
+	"vouch",
+	"mev-boost",
+}
+
+// ValidateClientQuirks checks that every entry is a "client:quirk" pair of
+// a known client and quirk.
+func ValidateClientQuirks(entries []string) error {
+	for _, entry := range entries {
+		client, quirk, ok := strings.Cut(entry, ":")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: must be client:quirk", entry)
+		}
+
+		if !slices.Contains(QuirkClients, client) {
+			return fmt.Errorf("unknown client %q: must be one of %s", client, strings.Join(QuirkClients, ", "))
+		}
+
+		if !slices.Contains(ClientQuirkNames, quirk) {
+			return fmt.Errorf("unknown quirk %q: must be one of %s", quirk, strings.Join(ClientQuirkNames, ", "))
+		}
+	}
+
+	return nil
+}
+
+// NormalizedBroadcastValidation returns the block broadcast validation level,
+// falling back to gossip for unknown values.
+func (c *BuilderAPIConfig) NormalizedBroadcastValidation() string {
+	return normalizeBroadcastValidation(c.BroadcastValidation)
+}
+
+// Blob sidecar publication modes of the Builder API block publisher.
+const (
+	// BlobSidecarsAuto publishes sidecars to nodes that accepted the block
+	// but do not hold its blobs.
+	BlobSidecarsAuto = "auto"
+	// BlobSidecarsAlways publishes sidecars to every node that accepted the
+	// block.
+	BlobSidecarsAlways = "always"
+	// BlobSidecarsNever never publishes sidecars separately.
+	BlobSidecarsNever = "never"
+)
+
+// NormalizedBlobSidecars returns the blob sidecar publication mode, falling
+// back to auto for unknown values.
+func (c *BuilderAPIConfig) NormalizedBlobSidecars() string {
+	switch c.BlobSidecars {
+	case BlobSidecarsAuto, BlobSidecarsAlways, BlobSidecarsNever:
+		return c.BlobSidecars
+	default:
+		return BlobSidecarsAuto
+	}
 }
 
 // EPBSConfig defines time-scheduled bidding parameters for ePBS.
@@ -135,7 +699,8 @@ type EPBSConfig struct {
 	// Bids use max(blockValue, BidMinAmount) as the starting bid value.
 	BidMinAmount uint64 `yaml:"bid_min_amount" json:"bid_min_amount"`
 
-	// BidIncrease is the amount to increase bid per subsequent bid in gwei.
+	// BidIncrease is the amount to increase bid per subsequent bid in gwei
+	// (linear strategy).
 	BidIncrease uint64 `yaml:"bid_increase" json:"bid_increase"`
 
 	// BidInterval is milliseconds between bids. 0 means single bid.
@@ -159,6 +724,91 @@ type EPBSConfig struct {
 	// (BUILDER_PAYMENT_THRESHOLD_NUMERATOR/DENOMINATOR = 6/10) — the
 	// participation level at which the builder's payment actually settles.
 	HeadVoteThresholdPct uint64 `yaml:"head_vote_threshold_pct" json:"head_vote_threshold_pct"`
+
+	// ReplaceStaleBids makes the bid for a payload rebuilt after its slot's
+	// payload_attributes changed (e.g. the parent payload was revealed late)
+	// replace the one committing to the stale payload: a replacement on the
+	// same parent is raised above our earlier bid, since gossip only forwards
+	// the highest bid per slot and parent. Superseded payloads stay cached:
+	// the reveal always follows whichever bid the proposer committed to.
+	ReplaceStaleBids bool `yaml:"replace_stale_bids" json:"replace_stale_bids"`
+
+	// SnipeEnabled switches to the late-slot snipe strategy: instead of
+	// bidding through the window, a single bid is sent SnipeLeadMs before
+	// BidEndTime, and only when no competitor bid above
+	// SnipeMaxCompetitorGwei has been seen for the slot — uncontested slots
+	// are won without revealing the bid early or bidding up.
+	SnipeEnabled bool `yaml:"snipe_enabled" json:"snipe_enabled"`
+
+	// SnipeLeadMs is how long before BidEndTime the snipe bid fires.
+	SnipeLeadMs int64 `yaml:"snipe_lead_ms" json:"snipe_lead_ms"`
+
+	// SnipeMaxCompetitorGwei is the highest competitor bid (gwei) a snipe
+	// tolerates; any competitor bid above it aborts the slot's snipe
+	// (0 = any competitor bid aborts).
+	SnipeMaxCompetitorGwei uint64 `yaml:"snipe_max_competitor_gwei" json:"snipe_max_competitor_gwei"`
+
+	// BidStrategy prices the automatic bids of a slot (see the BidStrategy*
+	// constants). Every strategy starts from the same base value; they
+	// differ in what the re-bids (every BidInterval) are worth. Unknown
+	// values fall back to linear; SnipeEnabled selects last-moment-snipe.
+	BidStrategy string `yaml:"bid_strategy" json:"bid_strategy"`
+
+	// CounterBidStepGwei is how far a counter-bid outbids the highest
+	// competitor bid.
+	CounterBidStepGwei uint64 `yaml:"counter_bid_step_gwei" json:"counter_bid_step_gwei"`
+
+	// CounterBidMaxGwei caps the counter-bids (0 = no cap); the base value
+	// is bid regardless.
+	CounterBidMaxGwei uint64 `yaml:"counter_bid_max_gwei" json:"counter_bid_max_gwei"`
+
+	// RandomWalkStepGwei is the largest step of a random-walk re-bid, up or
+	// down from the previous bid (never below the base value).
+	RandomWalkStepGwei uint64 `yaml:"random_walk_step_gwei" json:"random_walk_step_gwei"`
+}
+
+// Bid strategies: how the p2p bidder prices a slot's automatic bids.
+const (
+	// BidStrategyFixed bids the base value once per payload.
+	BidStrategyFixed = "fixed"
+	// BidStrategyLinear raises every re-bid by BidIncrease.
+	BidStrategyLinear = "linear"
+	// BidStrategyCounterBid re-bids only when outbid, CounterBidStepGwei
+	// above the highest competitor bid (up to CounterBidMaxGwei), as soon as
+	// the competitor's bid event arrives.
+	BidStrategyCounterBid = "counter-bid"
+	// BidStrategySnipe sends a single bid SnipeLeadMs before BidEndTime,
+	// unless a competitor bid above SnipeMaxCompetitorGwei was seen.
+	BidStrategySnipe = "last-moment-snipe"
+	// BidStrategyRandomWalk moves every re-bid by a random step of up to
+	// RandomWalkStepGwei.
+	BidStrategyRandomWalk = "random-walk"
+)
+
+// NormalizedBidStrategy returns the bid strategy, falling back to
+// BidStrategyLinear for unknown values (UI overrides are free-form strings).
+// SnipeEnabled, the original switch for the snipe strategy, selects it
+// regardless of BidStrategy.
+func (c *EPBSConfig) NormalizedBidStrategy() string {
+	if c.SnipeEnabled {
+		return BidStrategySnipe
+	}
+
+	if !IsBidStrategy(c.BidStrategy) {
+		return BidStrategyLinear
+	}
+
+	return c.BidStrategy
+}
+
+// IsBidStrategy reports whether name is one of the BidStrategy* names.
+func IsBidStrategy(name string) bool {
+	switch name {
+	case BidStrategyFixed, BidStrategyLinear, BidStrategyCounterBid, BidStrategySnipe, BidStrategyRandomWalk:
+		return true
+	default:
+		return false
+	}
 }
 
 // Reveal gate modes: how the reveal moment of a won slot is decided.
@@ -175,7 +825,7 @@ const (
 	RevealGateVoteAndTime = "vote_and_time"
 )
 
-// Broadcast validation levels for the envelope submission API
+// Broadcast validation levels for the block and envelope submission APIs
 // (beacon-API broadcast_validation query parameter).
 const (
 	BroadcastValidationGossip                   = "gossip"
@@ -217,6 +867,22 @@ type RevealConfig struct {
 
 	// RetryIntervalMs is the wait between failed publish attempts.
 	RetryIntervalMs int64 `yaml:"retry_interval_ms" json:"retry_interval_ms"`
+
+	// RequireInclusion gates automatic reveals on the committing block being
+	// seen at the head with our bid in its body (builder index match), rather
+	// than on local bookkeeping such as a Builder API block delivery. Reveals
+	// never confirmed by the slot end are skipped (not_included).
+	RequireInclusion bool `yaml:"require_inclusion" json:"require_inclusion"`
+
+	// ChaosWithholdPct / ChaosDelayPct enable reveal chaos for ePBS testing:
+	// the share (percent) of slots whose reveal is deliberately withheld, or
+	// delayed by ChaosDelayMs past the reveal time. Each slot's roll is a
+	// deterministic function of its slot number and is frozen with the
+	// slot's action plan; a per-slot reveal plan takes precedence. Together
+	// at most 100.
+	ChaosWithholdPct uint64 `yaml:"chaos_withhold_pct" json:"chaos_withhold_pct"`
+	ChaosDelayPct    uint64 `yaml:"chaos_delay_pct" json:"chaos_delay_pct"`
+	ChaosDelayMs     int64  `yaml:"chaos_delay_ms" json:"chaos_delay_ms"`
 }
 
 // NormalizedGateMode returns the gate mode, falling back to RevealGateTime
@@ -233,15 +899,73 @@ func (c *RevealConfig) NormalizedGateMode() string {
 // NormalizedBroadcastValidation returns the broadcast validation level,
 // falling back to gossip for unknown values.
 func (c *RevealConfig) NormalizedBroadcastValidation() string {
-	switch c.BroadcastValidation {
+	return normalizeBroadcastValidation(c.BroadcastValidation)
+}
+
+// ChaosEnabled reports whether any reveal chaos is configured.
+func (c *RevealConfig) ChaosEnabled() bool {
+	return c.ChaosWithholdPct > 0 || c.ChaosDelayPct > 0
+}
+
+func normalizeBroadcastValidation(level string) string {
+	switch level {
 	case BroadcastValidationGossip, BroadcastValidationConsensus,
 		BroadcastValidationConsensusAndEquivocation:
-		return c.BroadcastValidation
+		return level
 	default:
 		return BroadcastValidationGossip
 	}
 }
 
+// CanaryConfig configures canary bid mode: the full build/bid/reveal pipeline
+// keeps running, but every p2p bid and served Builder API bid is priced at
+// BidGwei, so a deployment can be validated without economic exposure.
+type CanaryConfig struct {
+	// Enabled switches canary mode on. It wins over the global value
+	// overrides and per-slot plan values; bid increases and subsidies are
+	// not applied.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// BidGwei is the fixed bid value in canary mode.
+	BidGwei uint64 `yaml:"bid_gwei" json:"bid_gwei"`
+}
+
+// Bid budget distributions: how the remaining epoch budget is spread over the
+// epoch's remaining slots.
+const (
+	// BidBudgetUniform gives every remaining slot an equal share.
+	BidBudgetUniform = "uniform"
+	// BidBudgetFrontLoaded gives earlier slots linearly larger shares.
+	BidBudgetFrontLoaded = "front_loaded"
+	// BidBudgetValueWeighted weights a slot's share by its payload value
+	// against the average payload value seen so far in the epoch.
+	BidBudgetValueWeighted = "value_weighted"
+)
+
+// BidBudgetConfig caps the p2p bid spend per epoch. A slot's spend is the
+// highest bid we gossiped for it (at most one of our bids can win the slot);
+// bids are capped at the slot's share of the remaining budget and refused
+// once the budget is exhausted. Read live by the p2p bidder.
+type BidBudgetConfig struct {
+	// EpochMaxGwei is the maximum spend per epoch in gwei (0 = no budget).
+	EpochMaxGwei uint64 `yaml:"epoch_max_gwei" json:"epoch_max_gwei"`
+
+	// Distribution is uniform (default) | front_loaded | value_weighted.
+	// Unknown values fall back to uniform.
+	Distribution string `yaml:"distribution" json:"distribution"`
+}
+
+// NormalizedDistribution returns the distribution, falling back to uniform
+// for unknown values.
+func (c *BidBudgetConfig) NormalizedDistribution() string {
+	switch c.Distribution {
+	case BidBudgetFrontLoaded, BidBudgetValueWeighted:
+		return c.Distribution
+	default:
+		return BidBudgetUniform
+	}
+}
+
 // BuilderState represents the current state of a builder in the beacon chain.
 type BuilderState struct {
 	Pubkey            []byte
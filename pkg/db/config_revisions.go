@@ -0,0 +1,68 @@
+package db
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// maxConfigRevisions bounds the config_revisions table; older rows are pruned
+// on insert.
+const maxConfigRevisions = 1000
+
+// ConfigRevision is one persisted runtime config change: the settings version
+// it produced and its before/after diff (JSON, owned by the settings service).
+type ConfigRevision struct {
+	Version   int64  `db:"version"`
+	Timestamp int64  `db:"timestamp"`
+	Actor     string `db:"actor"`
+	Source    string `db:"source"`
+	Changes   string `db:"changes"`
+}
+
+// AppendConfigRevision inserts a config revision and prunes the table to
+// maxConfigRevisions rows. No-op when the database is disabled.
+func (d *Database) AppendConfigRevision(rev ConfigRevision) error {
+	if !d.enabled {
+		return nil
+	}
+
+	return d.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO config_revisions
+				(version, timestamp, actor, source, changes)
+			VALUES ($1, $2, $3, $4, $5)`,
+			rev.Version, rev.Timestamp, rev.Actor, rev.Source, rev.Changes); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(`
+			DELETE FROM config_revisions
+			WHERE version NOT IN (SELECT version FROM config_revisions ORDER BY version DESC LIMIT $1)`,
+			maxConfigRevisions)
+
+		return err
+	})
+}
+
+// GetConfigRevisions returns up to limit of the newest config revisions,
+// oldest first. Returns an empty slice when the database is disabled.
+func (d *Database) GetConfigRevisions(limit int) ([]ConfigRevision, error) {
+	if !d.enabled {
+		return []ConfigRevision{}, nil
+	}
+
+	if limit <= 0 {
+		limit = maxConfigRevisions
+	}
+
+	revs := []ConfigRevision{}
+
+	err := d.readerDB.Select(&revs, `
+		SELECT version, timestamp, actor, source, changes
+		FROM (SELECT * FROM config_revisions ORDER BY version DESC LIMIT $1)
+		ORDER BY version ASC`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return revs, nil
+}
@@ -49,6 +49,21 @@ func TestAuditLogRoundTrip(t *testing.T) {
 	require.Equal(t, "alice", entries[1].Actor)
 }
 
+func TestConfigRevisionsRoundTrip(t *testing.T) {
+	d := testDB(t)
+
+	require.NoError(t, d.AppendConfigRevision(ConfigRevision{Version: 3, Actor: "alice", Source: "api", Changes: `[]`}))
+	require.NoError(t, d.AppendConfigRevision(ConfigRevision{Version: 5, Actor: "cli", Source: "reload", Changes: `[]`}))
+	require.NoError(t, d.AppendConfigRevision(ConfigRevision{Version: 7, Actor: "bob", Source: "rollback", Changes: `[]`}))
+
+	revs, err := d.GetConfigRevisions(2)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	require.Equal(t, int64(5), revs[0].Version) // newest two, oldest first
+	require.Equal(t, int64(7), revs[1].Version)
+	require.Equal(t, "rollback", revs[1].Source)
+}
+
 func TestDisabledDBNoOps(t *testing.T) {
 	log := logrus.New()
 	log.SetOutput(io.Discard)
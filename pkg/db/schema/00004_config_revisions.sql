@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "config_revisions"
+(
+    "version"   INTEGER NOT NULL,
+    "timestamp" INTEGER NOT NULL DEFAULT 0,
+    "actor"     TEXT    NOT NULL DEFAULT '',
+    "source"    TEXT    NOT NULL DEFAULT '',
+    "changes"   TEXT    NOT NULL DEFAULT '[]',
+    CONSTRAINT "config_revisions_pkey" PRIMARY KEY ("version")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "config_revisions";
+-- +goose StatementEnd
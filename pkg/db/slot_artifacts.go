@@ -137,3 +137,65 @@ func (d *Database) DeleteSlotArtifactsBefore(cutoffSlot uint64) (int64, error) {
 
 	return deleted, err
 }
+
+// SlotArtifactSize is the stored data size of one slot's artifacts.
+type SlotArtifactSize struct {
+	Slot  uint64 `db:"slot"`
+	Bytes int64  `db:"bytes"`
+}
+
+// GetSlotArtifactSizes returns the stored data size per slot, newest slot
+// first — used to enforce the artifact size cap. Returns an empty slice when
+// the database is disabled.
+func (d *Database) GetSlotArtifactSizes() ([]SlotArtifactSize, error) {
+	if !d.enabled {
+		return []SlotArtifactSize{}, nil
+	}
+
+	sizes := []SlotArtifactSize{}
+
+	err := d.readerDB.Select(&sizes, `
+		SELECT slot, SUM(LENGTH(data)) AS bytes
+		FROM slot_artifacts
+		GROUP BY slot
+		ORDER BY slot DESC`)
+	if err != nil {
+		return nil, err
+	}
+
+	return sizes, nil
+}
+
+// ForEachSlotArtifactBefore streams all artifacts for slots below the cutoff
+// (slot, kind, idx ascending), including their data blobs, without loading
+// them all at once. Iteration stops at the first error fn returns. No-op when
+// the database is disabled.
+func (d *Database) ForEachSlotArtifactBefore(cutoffSlot uint64, fn func(artifact *SlotArtifact) error) error {
+	if !d.enabled {
+		return nil
+	}
+
+	rows, err := d.readerDB.Queryx(`
+		SELECT slot, kind, idx, fork, meta, data, created_at
+		FROM slot_artifacts
+		WHERE slot < $1
+		ORDER BY slot ASC, kind ASC, idx ASC`, cutoffSlot)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		artifact := &SlotArtifact{}
+		if err := rows.StructScan(artifact); err != nil {
+			return err
+		}
+
+		if err := fn(artifact); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"io"
 	"testing"
 
@@ -81,6 +82,41 @@ func TestSlotArtifactsPrune(t *testing.T) {
 	require.Nil(t, gone)
 }
 
+func TestSlotArtifactsSizesAndArchiveScan(t *testing.T) {
+	d := testDB(t)
+
+	require.NoError(t, d.InsertSlotArtifacts([]SlotArtifact{
+		{Slot: 10, Kind: "payload", Data: []byte{1, 2, 3}},
+		{Slot: 10, Kind: "bid", Data: []byte{4}},
+		{Slot: 20, Kind: "payload", Data: []byte{5, 6}},
+		{Slot: 30, Kind: "payload", Data: []byte{7}},
+	}))
+
+	sizes, err := d.GetSlotArtifactSizes()
+	require.NoError(t, err)
+	require.Equal(t, []SlotArtifactSize{{Slot: 30, Bytes: 1}, {Slot: 20, Bytes: 2}, {Slot: 10, Bytes: 4}}, sizes)
+
+	var scanned []SlotArtifact
+
+	require.NoError(t, d.ForEachSlotArtifactBefore(30, func(artifact *SlotArtifact) error {
+		scanned = append(scanned, *artifact)
+		return nil
+	}))
+	require.Len(t, scanned, 3)
+	require.Equal(t, "bid", scanned[0].Kind, "slot, kind, idx ascending")
+	require.Equal(t, []byte{1, 2, 3}, scanned[1].Data, "the scan includes the blobs")
+	require.Equal(t, uint64(20), scanned[2].Slot)
+
+	stop := errors.New("stop")
+	calls := 0
+
+	require.ErrorIs(t, d.ForEachSlotArtifactBefore(30, func(*SlotArtifact) error {
+		calls++
+		return stop
+	}), stop)
+	require.Equal(t, 1, calls)
+}
+
 func TestSlotArtifactsDisabledNoOp(t *testing.T) {
 	log := logrus.New()
 	log.SetOutput(io.Discard)
@@ -106,4 +142,13 @@ func TestSlotArtifactsDisabledNoOp(t *testing.T) {
 	deleted, err := d.DeleteSlotArtifactsBefore(100)
 	require.NoError(t, err)
 	require.Zero(t, deleted)
+
+	sizes, err := d.GetSlotArtifactSizes()
+	require.NoError(t, err)
+	require.Empty(t, sizes)
+
+	require.NoError(t, d.ForEachSlotArtifactBefore(100, func(*SlotArtifact) error {
+		t.Fatal("disabled database must not scan")
+		return nil
+	}))
 }
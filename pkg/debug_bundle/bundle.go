@@ -0,0 +1,397 @@
+// Package debug_bundle gathers everything buildoor knows about a problematic
+// slot — redacted config, surrounding logs, the cached payload, bids seen,
+// reveal attempts, recorded outcome, raw artifacts and the beacon block —
+// into a single tar.gz for attaching to issue reports.
+package debug_bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+	buildoorversion "github.com/ethpandaops/buildoor/version"
+)
+
+// blockFetchTimeout bounds the beacon block lookup; a slow beacon node must
+// not stall the bundle download.
+const blockFetchTimeout = 10 * time.Second
+
+// BlockSource fetches beacon blocks (implemented by *beacon.Client).
+type BlockSource interface {
+	GetSignedBeaconBlock(ctx context.Context, blockID string) (*eth2all.SignedBeaconBlock, error)
+}
+
+// Sources are the services a bundle is collected from. Every source is
+// optional; a missing source is noted in the manifest instead of failing the
+// bundle.
+type Sources struct {
+	Config       any // redacted config snapshot
+	ChainSvc     chain.Service
+	Results      *slot_results.Tracker
+	Plans        *action_plan.PlanService
+	PayloadCache *payload_builder.PayloadCache
+	BidTracker   *p2p_bidder.BidTracker
+	Blocks       BlockSource
+	Logs         *LogBuffer
+}
+
+// Manifest describes a bundle's contents. Sections that could not be
+// collected are listed in Errors with the reason.
+type Manifest struct {
+	Slot        uint64            `json:"slot"`
+	Version     string            `json:"buildoor_version"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Files       []string          `json:"files"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// PayloadSnapshot is the bundle view of a cached payload, including the
+// bid/reveal activity log kept alongside it.
+type PayloadSnapshot struct {
+	BlockHash         string                         `json:"block_hash"`
+	FeeRecipient      string                         `json:"fee_recipient"`
+	BlockValueWei     string                         `json:"block_value_wei"`
+	ReadyAt           time.Time                      `json:"ready_at"`
+	Attributes        *beacon.PayloadAttributesEvent `json:"attributes,omitempty"`
+	ExecutionPayload  *eth2all.ExecutionPayload      `json:"execution_payload,omitempty"`
+	ExecutionRequests *eth2all.ExecutionRequests     `json:"execution_requests,omitempty"`
+	BlobCount         int                            `json:"blob_count"`
+	Bids              []payload_builder.BidRecord    `json:"bids"`
+	Reveal            *payload_builder.RevealRecord  `json:"reveal,omitempty"`
+}
+
+// ArtifactEntry indexes one raw SSZ artifact file of the bundle.
+type ArtifactEntry struct {
+	File  string `json:"file"`
+	Kind  string `json:"kind"`
+	Index int    `json:"index"`
+	Fork  string `json:"fork"`
+	Meta  string `json:"meta,omitempty"`
+}
+
+// bundleFile is one collected file awaiting the tar writer.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// collector accumulates the bundle files and per-section errors.
+type collector struct {
+	files  []bundleFile
+	errors map[string]string
+}
+
+func (c *collector) addJSON(name string, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		c.fail(name, fmt.Errorf("failed to encode: %w", err))
+		return
+	}
+
+	c.files = append(c.files, bundleFile{name: name, data: data})
+}
+
+func (c *collector) addRaw(name string, data []byte) {
+	c.files = append(c.files, bundleFile{name: name, data: data})
+}
+
+func (c *collector) fail(section string, err error) {
+	c.errors[section] = err.Error()
+}
+
+// Write collects the bundle for slot from src and writes it to w as a
+// gzip-compressed tarball rooted at "buildoor-debug-slot-<slot>/".
+func Write(ctx context.Context, w io.Writer, slot phase0.Slot, src *Sources) error {
+	c := &collector{errors: make(map[string]string)}
+
+	if src.Config != nil {
+		c.addJSON("config.json", src.Config)
+	} else {
+		c.fail("config.json", fmt.Errorf("config not available"))
+	}
+
+	collectLogs(c, slot, src)
+	collectSlotResult(c, slot, src)
+	collectPlan(c, slot, src)
+	collectPayload(c, slot, src)
+	collectBidsSeen(c, slot, src)
+	collectArtifacts(c, slot, src)
+	collectBeaconBlock(ctx, c, slot, src)
+
+	manifest := &Manifest{
+		Slot:        uint64(slot),
+		Version:     buildoorversion.GetBuildVersion(),
+		GeneratedAt: time.Now().UTC(),
+		Files:       make([]string, 0, len(c.files)),
+		Errors:      c.errors,
+	}
+
+	for _, f := range c.files {
+		manifest.Files = append(manifest.Files, f.name)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	files := append([]bundleFile{{name: "manifest.json", data: manifestData}}, c.files...)
+
+	return writeTarGz(w, fmt.Sprintf("buildoor-debug-slot-%d", slot), manifest.GeneratedAt, files)
+}
+
+// writeTarGz writes files under root into a gzip-compressed tarball.
+func writeTarGz(w io.Writer, root string, modTime time.Time, files []bundleFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    root + "/" + f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: modTime,
+		}); err != nil {
+			return fmt.Errorf("failed to write %s header: %w", f.name, err)
+		}
+
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize tar: %w", err)
+	}
+
+	return gz.Close()
+}
+
+// collectLogs adds the captured log lines from one slot before the slot's
+// start until the end of the following slot, plus any line tagged with the
+// slot, as JSON lines.
+func collectLogs(c *collector, slot phase0.Slot, src *Sources) {
+	const name = "logs.jsonl"
+
+	if src.Logs == nil {
+		c.fail(name, fmt.Errorf("log capture not enabled"))
+		return
+	}
+
+	var from, to time.Time
+
+	if src.ChainSvc != nil {
+		start := src.ChainSvc.SlotToTime(slot)
+		slotDuration := src.ChainSvc.SlotToTime(slot + 1).Sub(start)
+		from = start.Add(-slotDuration)
+		to = start.Add(2 * slotDuration)
+	}
+
+	var data []byte
+
+	for _, entry := range src.Logs.Between(from, to, uint64(slot)) {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+
+	c.addRaw(name, data)
+}
+
+func collectSlotResult(c *collector, slot phase0.Slot, src *Sources) {
+	const name = "slot_result.json"
+
+	if src.Results == nil {
+		c.fail(name, fmt.Errorf("slot results tracker not available"))
+		return
+	}
+
+	result := src.Results.Get(slot)
+	if result == nil {
+		c.fail(name, fmt.Errorf("no result recorded for slot"))
+		return
+	}
+
+	c.addJSON(name, result)
+}
+
+// collectPlan adds the slot's stored plan and, when the slot was already
+// frozen, its frozen execution snapshot. Unfrozen slots are never frozen
+// here: generating a bundle must not lock a future slot's plan.
+func collectPlan(c *collector, slot phase0.Slot, src *Sources) {
+	const name = "action_plan.json"
+
+	if src.Plans == nil {
+		c.fail(name, fmt.Errorf("plan service not available"))
+		return
+	}
+
+	out := struct {
+		Plan   *action_plan.SlotPlan   `json:"plan"`
+		Frozen *action_plan.FrozenPlan `json:"frozen,omitempty"`
+	}{
+		Plan: src.Plans.Get(slot),
+	}
+
+	if src.Plans.IsFrozen(slot) {
+		out.Frozen = src.Plans.Freeze(slot)
+	}
+
+	c.addJSON(name, out)
+}
+
+func collectPayload(c *collector, slot phase0.Slot, src *Sources) {
+	const name = "payload.json"
+
+	if src.PayloadCache == nil {
+		c.fail(name, fmt.Errorf("payload cache not available"))
+		return
+	}
+
+	payload := src.PayloadCache.Get(slot)
+	if payload == nil {
+		c.fail(name, fmt.Errorf("no cached payload for slot (not built or already evicted)"))
+		return
+	}
+
+	snapshot := &PayloadSnapshot{
+		BlockHash:         payload.BlockHash.String(),
+		FeeRecipient:      payload.FeeRecipient.Hex(),
+		ReadyAt:           payload.ReadyAt,
+		Attributes:        payload.Attributes,
+		ExecutionPayload:  payload.ExecutionPayload,
+		ExecutionRequests: payload.ExecutionRequests,
+		Bids:              payload.Bids(),
+		Reveal:            payload.Reveal(),
+	}
+
+	if payload.BlockValue != nil {
+		snapshot.BlockValueWei = payload.BlockValue.String()
+	}
+
+	if payload.BlobsBundle != nil {
+		snapshot.BlobCount = len(payload.BlobsBundle.Blobs)
+	}
+
+	c.addJSON(name, snapshot)
+}
+
+// collectBidsSeen adds every p2p bid observed for the slot (ours, competitors
+// and mesh-shared ones).
+func collectBidsSeen(c *collector, slot phase0.Slot, src *Sources) {
+	const name = "bids_seen.json"
+
+	if src.BidTracker == nil {
+		c.fail(name, fmt.Errorf("p2p bid tracker not available"))
+		return
+	}
+
+	bids := make([]p2p_bidder.TrackedBid, 0, 8)
+
+	for _, bid := range src.BidTracker.BidsSince(slot, false) {
+		if bid.Bid != nil && bid.Bid.Slot == slot {
+			bids = append(bids, bid)
+		}
+	}
+
+	c.addJSON(name, bids)
+}
+
+// collectArtifacts adds the slot's raw SSZ artifacts (built payload, signed
+// bids, signed envelope) plus an index describing their forks.
+func collectArtifacts(c *collector, slot phase0.Slot, src *Sources) {
+	const section = "artifacts"
+
+	if src.Results == nil {
+		c.fail(section, fmt.Errorf("slot results tracker not available"))
+		return
+	}
+
+	store := src.Results.Artifacts()
+	index := make([]ArtifactEntry, 0, 4)
+
+	add := func(kind string, idx int, file string) {
+		artifact, err := store.Get(slot, kind, idx)
+		if err != nil {
+			c.fail(section+"/"+file, err)
+			return
+		}
+
+		if artifact == nil {
+			return
+		}
+
+		c.addRaw(section+"/"+file, artifact.Data)
+		index = append(index, ArtifactEntry{
+			File:  file,
+			Kind:  kind,
+			Index: idx,
+			Fork:  version.DataVersion(artifact.Fork).String(),
+			Meta:  artifact.Meta,
+		})
+	}
+
+	add(slot_results.ArtifactKindPayload, 0, "payload.ssz")
+
+	bids, err := store.ListBids(slot)
+	if err != nil {
+		c.fail(section+"/bids", err)
+	}
+
+	for _, bid := range bids {
+		add(slot_results.ArtifactKindBid, bid.Idx, "bid_"+strconv.Itoa(bid.Idx)+".ssz")
+	}
+
+	add(slot_results.ArtifactKindEnvelope, 0, "envelope.ssz")
+
+	if len(index) == 0 {
+		c.fail(section, fmt.Errorf("no artifacts captured for slot"))
+		return
+	}
+
+	c.addJSON(section+"/index.json", index)
+}
+
+func collectBeaconBlock(ctx context.Context, c *collector, slot phase0.Slot, src *Sources) {
+	const name = "beacon_block.json"
+
+	if src.Blocks == nil {
+		c.fail(name, fmt.Errorf("beacon client not available"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, blockFetchTimeout)
+	defer cancel()
+
+	block, err := src.Blocks.GetSignedBeaconBlock(ctx, strconv.FormatUint(uint64(slot), 10))
+	if err != nil {
+		c.fail(name, err)
+		return
+	}
+
+	if block == nil {
+		c.fail(name, fmt.Errorf("no beacon block at slot (missed or not yet proposed)"))
+		return
+	}
+
+	c.addJSON(name, block)
+}
@@ -0,0 +1,160 @@
+package debug_bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+type fakeBlocks struct {
+	block *eth2all.SignedBeaconBlock
+}
+
+func (f *fakeBlocks) GetSignedBeaconBlock(_ context.Context, _ string) (*eth2all.SignedBeaconBlock, error) {
+	return f.block, nil
+}
+
+// readBundle unpacks a tar.gz bundle into a name → content map with the root
+// directory stripped.
+func readBundle(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		root, name, ok := strings.Cut(hdr.Name, "/")
+		require.True(t, ok)
+		require.Equal(t, "buildoor-debug-slot-42", root)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		files[name] = content
+	}
+
+	return files
+}
+
+func TestWriteCollectsAvailableSections(t *testing.T) {
+	logs := NewLogBuffer(16)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(logs)
+
+	logger.WithField("slot", phase0.Slot(42)).Info("building payload")
+	logger.WithField("slot", phase0.Slot(41)).Info("other slot")
+
+	cache := payload_builder.NewPayloadCache(4)
+	cache.Store(&payload_builder.Payload{
+		Attributes: &beacon.PayloadAttributesEvent{ProposalSlot: 42},
+		BlockHash:  phase0.Hash32{0xab},
+		BlockValue: big.NewInt(1234),
+		ReadyAt:    time.Unix(1700000000, 0),
+	})
+
+	var buf bytes.Buffer
+
+	err := Write(context.Background(), &buf, 42, &Sources{
+		Config:       map[string]any{"builder_privkey": "***"},
+		PayloadCache: cache,
+		Blocks:       &fakeBlocks{},
+		Logs:         logs,
+	})
+	require.NoError(t, err)
+
+	files := readBundle(t, buf.Bytes())
+
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	require.Equal(t, uint64(42), manifest.Slot)
+	require.ElementsMatch(t, []string{"config.json", "logs.jsonl", "payload.json"}, manifest.Files)
+
+	for _, section := range []string{"slot_result.json", "action_plan.json", "bids_seen.json", "artifacts", "beacon_block.json"} {
+		require.Contains(t, manifest.Errors, section, "missing source should be noted in the manifest")
+	}
+
+	var payload PayloadSnapshot
+	require.NoError(t, json.Unmarshal(files["payload.json"], &payload))
+	require.Equal(t, "1234", payload.BlockValueWei)
+	require.Equal(t, phase0.Hash32{0xab}.String(), payload.BlockHash)
+
+	// Without a chain service only slot-tagged lines are selected.
+	logLines := strings.Split(strings.TrimSpace(string(files["logs.jsonl"])), "\n")
+	require.Len(t, logLines, 1)
+	require.Contains(t, logLines[0], "building payload")
+}
+
+func TestLogBufferWrapsAndFiltersByWindow(t *testing.T) {
+	logs := NewLogBuffer(3)
+	base := time.Unix(1700000000, 0)
+
+	for i := range 5 {
+		require.NoError(t, logs.Fire(&logrus.Entry{
+			Time:    base.Add(time.Duration(i) * time.Second),
+			Level:   logrus.InfoLevel,
+			Message: string(rune('a' + i)),
+			Data:    logrus.Fields{"err": io.EOF},
+		}))
+	}
+
+	entries := logs.Between(base, base.Add(time.Hour), 0)
+	require.Len(t, entries, 3, "oldest entries should be overwritten")
+	require.Equal(t, "c", entries[0].Message)
+	require.Equal(t, "e", entries[2].Message)
+	require.Equal(t, "EOF", entries[0].Fields["err"], "errors should be stringified")
+
+	entries = logs.Between(base.Add(3*time.Second), base.Add(3*time.Second), 0)
+	require.Len(t, entries, 1)
+	require.Equal(t, "d", entries[0].Message)
+}
+
+func TestLogBufferRecentAndSubscribe(t *testing.T) {
+	logs := NewLogBuffer(3)
+	require.Empty(t, logs.Recent(10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := logs.Subscribe(ctx, 8)
+
+	for i := range 4 {
+		require.NoError(t, logs.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: string(rune('a' + i))}))
+	}
+
+	recent := logs.Recent(2)
+	require.Len(t, recent, 2)
+	require.Equal(t, "c", recent[0].Message)
+	require.Equal(t, "d", recent[1].Message)
+	require.Len(t, logs.Recent(10), 3)
+
+	for _, want := range []string{"a", "b", "c", "d"} {
+		entry := <-sub.Channel()
+		require.Equal(t, want, entry.Message)
+	}
+}
@@ -0,0 +1,162 @@
+package debug_bundle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// DefaultLogBufferSize is the number of recent log entries kept in memory for
+// debug bundles (a few minutes of info-level logging on a busy devnet).
+const DefaultLogBufferSize = 20000
+
+// LogEntry is one captured log line.
+type LogEntry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"msg"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// LogBuffer is a logrus hook keeping the most recent log entries in a ring
+// buffer, so a debug bundle can include the logs around a problematic slot
+// without the operator having to collect container logs. New entries are
+// also fanned out to live subscribers (the WebUI log stream).
+type LogBuffer struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int
+	full    bool
+
+	liveDispatch utils.Dispatcher[LogEntry]
+}
+
+var _ logrus.Hook = (*LogBuffer)(nil)
+
+// NewLogBuffer creates a log buffer holding up to capacity entries.
+func NewLogBuffer(capacity int) *LogBuffer {
+	if capacity <= 0 {
+		capacity = DefaultLogBufferSize
+	}
+
+	return &LogBuffer{entries: make([]LogEntry, capacity)}
+}
+
+// Levels implements logrus.Hook: every level is captured.
+func (b *LogBuffer) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook. Field values are stringified unless they are
+// plain JSON scalars, so later mutation of logged objects cannot race with
+// bundle generation.
+func (b *LogBuffer) Fire(entry *logrus.Entry) error {
+	captured := LogEntry{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+	}
+
+	if len(entry.Data) > 0 {
+		captured.Fields = make(map[string]any, len(entry.Data))
+
+		for k, v := range entry.Data {
+			captured.Fields[k] = snapshotField(v)
+		}
+	}
+
+	b.mu.Lock()
+	b.entries[b.next] = captured
+	b.next = (b.next + 1) % len(b.entries)
+
+	if b.next == 0 {
+		b.full = true
+	}
+	b.mu.Unlock()
+
+	// Non-blocking fan-out: a slow stream client drops lines instead of
+	// stalling the logging goroutine.
+	b.liveDispatch.Fire(captured)
+
+	return nil
+}
+
+// Subscribe streams entries logged from now on until ctx is cancelled.
+// Delivery is non-blocking: entries beyond the buffer capacity are dropped
+// (counted on the subscription).
+func (b *LogBuffer) Subscribe(ctx context.Context, capacity int) *utils.Subscription[LogEntry] {
+	return b.liveDispatch.SubscribeWithContext(ctx, capacity, false)
+}
+
+// Recent returns up to n of the most recently captured entries, oldest
+// first.
+func (b *LogBuffer) Recent(n int) []LogEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.entries)
+	}
+
+	n = min(n, count)
+	out := make([]LogEntry, n)
+
+	for i := range n {
+		out[i] = b.entries[(b.next-n+i+len(b.entries))%len(b.entries)]
+	}
+
+	return out
+}
+
+// snapshotField converts a log field value into an immutable JSON-friendly
+// representation.
+func snapshotField(v any) any {
+	switch val := v.(type) {
+	case nil, bool, string, int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, float32, float64:
+		return val
+	case error:
+		return val.Error()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// Between returns the captured entries logged within [from, to] plus entries
+// outside the window carrying a "slot" field equal to slot, oldest first.
+func (b *LogBuffer) Between(from, to time.Time, slot uint64) []LogEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slotStr := fmt.Sprint(slot)
+	count := b.next
+	start := 0
+
+	if b.full {
+		count = len(b.entries)
+		start = b.next
+	}
+
+	out := make([]LogEntry, 0, 64)
+
+	for i := range count {
+		entry := b.entries[(start+i)%len(b.entries)]
+
+		inWindow := !entry.Time.Before(from) && !entry.Time.After(to)
+		if !inWindow {
+			if s, ok := entry.Fields["slot"]; !ok || fmt.Sprint(s) != slotStr {
+				continue
+			}
+		}
+
+		out = append(out, entry)
+	}
+
+	return out
+}
@@ -0,0 +1,415 @@
+// Package epoch_summary folds the per-slot outcome history into one summary
+// per finished epoch (slots built, bids won, value earned, reveals missed,
+// head vote participation) for longer-horizon monitoring.
+package epoch_summary
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+const (
+	// summaryDelaySlots postpones an epoch's summary past its boundary so the
+	// last slot's reveal and payload verdict (decided by the follow-up block)
+	// are recorded first.
+	summaryDelaySlots = 2
+
+	// maxEpochCatchUp bounds how many missed epochs are summarized after a
+	// stall or clock jump.
+	maxEpochCatchUp = 4
+
+	// summaryRetentionEpochs bounds the in-memory summary history; older
+	// epochs are recomputed on demand while their slot results are retained.
+	summaryRetentionEpochs = 1024
+)
+
+// ResultSource provides the recorded slot results (implemented by the slot
+// results tracker).
+type ResultSource interface {
+	GetRange(minSlot, maxSlot phase0.Slot) []*slot_results.SlotResult
+}
+
+// EpochSummary is the aggregated builder activity of one finished epoch.
+type EpochSummary struct {
+	Epoch     uint64 `json:"epoch"`
+	StartSlot uint64 `json:"start_slot"`
+	EndSlot   uint64 `json:"end_slot"`
+
+	// SlotsTracked counts slots with a recorded result (ePBS or Builder API
+	// active).
+	SlotsTracked  int `json:"slots_tracked"`
+	SlotsBuilt    int `json:"slots_built"`
+	BuildsFailed  int `json:"builds_failed"`
+	BuildsSkipped int `json:"builds_skipped"`
+
+	// SlotsBid counts slots with at least one submitted or served bid.
+	SlotsBid   int `json:"slots_bid"`
+	BidsSent   int `json:"bids_sent"`
+	BidsFailed int `json:"bids_failed"`
+	// BidsCanary is the subset of BidsSent priced at the canary value.
+	BidsCanary int `json:"bids_canary"`
+	// BidsBoosted is the subset of BidsSent reporting a boosted value
+	// (test-only value boosting).
+	BidsBoosted int `json:"bids_boosted"`
+
+	BidsWon           int `json:"bids_won"`
+	PayloadsCanonical int `json:"payloads_canonical"`
+	PayloadsMissed    int `json:"payloads_missed"`
+	PayloadsOrphaned  int `json:"payloads_orphaned"`
+
+	// ValueEarnedWei sums the won block values whose payload was not missed
+	// or orphaned.
+	ValueEarnedWei string `json:"value_earned_wei"`
+
+	RevealsPublished int `json:"reveals_published"`
+	// RevealsMissed counts slots whose reveal attempts never published and
+	// ended failed or skipped (plan suppressions are intentional and not
+	// counted).
+	RevealsMissed int `json:"reveals_missed"`
+
+	// Head vote participation of the epoch's slots, as last streamed by the
+	// head vote tracker. Only slots observed while running are included.
+	ParticipationSlots  int     `json:"participation_slots"`
+	AvgParticipationPct float64 `json:"avg_participation_pct"`
+	MinParticipationPct float64 `json:"min_participation_pct"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Aggregator builds and retains epoch summaries and fires one summary event
+// per finished epoch.
+type Aggregator struct {
+	chainSvc chain.Service
+	results  ResultSource
+
+	mu            sync.RWMutex
+	summaries     map[phase0.Epoch]*EpochSummary
+	participation map[phase0.Slot]float64 // latest primary-root participation per slot
+
+	dispatcher utils.Dispatcher[*EpochSummary]
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewAggregator creates the epoch summary aggregator.
+func NewAggregator(chainSvc chain.Service, results ResultSource, log logrus.FieldLogger) *Aggregator {
+	return &Aggregator{
+		chainSvc:      chainSvc,
+		results:       results,
+		summaries:     make(map[phase0.Epoch]*EpochSummary, 64),
+		participation: make(map[phase0.Slot]float64, 64),
+		log:           log.WithField("component", "epoch-summary"),
+	}
+}
+
+// Start launches the epoch-boundary loop.
+func (a *Aggregator) Start(ctx context.Context) error {
+	a.ctx, a.cancel = context.WithCancel(ctx)
+
+	var hvSub *utils.Subscription[*chain.HeadVoteUpdate]
+	if tracker := a.chainSvc.GetHeadVoteTracker(); tracker != nil {
+		hvSub = tracker.SubscribeUpdates()
+	}
+
+	a.wg.Add(1)
+
+	go a.run(hvSub)
+
+	return nil
+}
+
+// Stop terminates the aggregator loop.
+func (a *Aggregator) Stop() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+
+	a.wg.Wait()
+}
+
+// SubscribeSummaries subscribes to finished-epoch summaries (non-blocking).
+func (a *Aggregator) SubscribeSummaries(capacity int) *utils.Subscription[*EpochSummary] {
+	return a.dispatcher.Subscribe(capacity, false)
+}
+
+// GetSummary returns the summary of a finished epoch. Summaries not retained
+// (older than the retention window, or finished before the aggregator
+// started) are recomputed from the slot results still held; ok is false while
+// the epoch is still in progress.
+func (a *Aggregator) GetSummary(epoch phase0.Epoch) (*EpochSummary, bool) {
+	a.mu.RLock()
+	summary, ok := a.summaries[epoch]
+	a.mu.RUnlock()
+
+	if ok {
+		c := *summary
+		return &c, true
+	}
+
+	if epoch >= a.chainSvc.GetCurrentEpoch() {
+		return nil, false
+	}
+
+	return a.buildSummary(epoch), true
+}
+
+func (a *Aggregator) run(hvSub *utils.Subscription[*chain.HeadVoteUpdate]) {
+	defer a.wg.Done()
+
+	var headVoteChan <-chan *chain.HeadVoteUpdate
+
+	if hvSub != nil {
+		defer hvSub.Unsubscribe()
+
+		headVoteChan = hvSub.Channel()
+	}
+
+	// Epochs finished before start are only served on demand.
+	lastSummarized, hasLast := a.summaryHorizon()
+	slotTimer := chain.NewSlotTimer(a.chainSvc)
+
+	defer slotTimer.Stop()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+
+		case update, ok := <-headVoteChan:
+			if !ok {
+				headVoteChan = nil
+				continue
+			}
+
+			a.mu.Lock()
+			a.participation[update.Slot] = update.ParticipationPct
+			a.mu.Unlock()
+
+		case <-slotTimer.C():
+			if horizon, ok := a.summaryHorizon(); ok && (!hasLast || horizon > lastSummarized) {
+				firstEpoch := horizon
+				if hasLast {
+					firstEpoch = lastSummarized + 1
+				}
+
+				if horizon > firstEpoch+maxEpochCatchUp {
+					firstEpoch = horizon - maxEpochCatchUp
+				}
+
+				for epoch := firstEpoch; epoch <= horizon; epoch++ {
+					a.summarize(epoch)
+				}
+
+				lastSummarized, hasLast = horizon, true
+			}
+
+			chain.ResetSlotTimer(a.chainSvc, slotTimer)
+		}
+	}
+}
+
+// summaryHorizon is the latest epoch whose summary is due: its end lies at
+// least summaryDelaySlots in the past. ok is false before the first one.
+func (a *Aggregator) summaryHorizon() (phase0.Epoch, bool) {
+	slotsPerEpoch := phase0.Slot(a.chainSvc.GetChainSpec().SlotsPerEpoch)
+	currentSlot := a.chainSvc.GetCurrentSlot()
+
+	if currentSlot < slotsPerEpoch+summaryDelaySlots {
+		return 0, false
+	}
+
+	return a.chainSvc.GetEpochOfSlot(currentSlot-summaryDelaySlots) - 1, true
+}
+
+// summarize builds, retains and fires the summary of a finished epoch.
+func (a *Aggregator) summarize(epoch phase0.Epoch) {
+	summary := a.buildSummary(epoch)
+	startSlot := phase0.Slot(summary.StartSlot)
+
+	a.mu.Lock()
+	a.summaries[epoch] = summary
+
+	for e := range a.summaries {
+		if uint64(epoch) >= summaryRetentionEpochs && uint64(e) <= uint64(epoch)-summaryRetentionEpochs {
+			delete(a.summaries, e)
+		}
+	}
+
+	// Participation is only kept until its epoch is summarized.
+	for slot := range a.participation {
+		if slot < startSlot {
+			delete(a.participation, slot)
+		}
+	}
+	a.mu.Unlock()
+
+	a.log.WithFields(logrus.Fields{
+		"epoch":         epoch,
+		"slots_built":   summary.SlotsBuilt,
+		"bids_won":      summary.BidsWon,
+		"value_wei":     summary.ValueEarnedWei,
+		"reveals_miss":  summary.RevealsMissed,
+		"participation": summary.AvgParticipationPct,
+	}).Info("Epoch summary")
+
+	c := *summary
+	a.dispatcher.Fire(&c)
+}
+
+// buildSummary aggregates the epoch's slot results and the participation
+// recorded for its slots.
+func (a *Aggregator) buildSummary(epoch phase0.Epoch) *EpochSummary {
+	slotsPerEpoch := a.chainSvc.GetChainSpec().SlotsPerEpoch
+	startSlot := phase0.Slot(uint64(epoch) * slotsPerEpoch)
+	endSlot := startSlot + phase0.Slot(slotsPerEpoch) - 1
+
+	summary := Summarize(epoch, startSlot, endSlot, a.results.GetRange(startSlot, endSlot))
+
+	a.mu.RLock()
+	pcts := make([]float64, 0, slotsPerEpoch)
+
+	for slot := startSlot; slot <= endSlot; slot++ {
+		if pct, ok := a.participation[slot]; ok {
+			pcts = append(pcts, pct)
+		}
+	}
+	a.mu.RUnlock()
+
+	summary.applyParticipation(pcts)
+	summary.GeneratedAt = time.Now()
+
+	return summary
+}
+
+// Summarize folds one epoch's slot results into a summary (participation
+// fields left empty).
+func Summarize(epoch phase0.Epoch, startSlot, endSlot phase0.Slot, results []*slot_results.SlotResult) *EpochSummary {
+	summary := &EpochSummary{
+		Epoch:     uint64(epoch),
+		StartSlot: uint64(startSlot),
+		EndSlot:   uint64(endSlot),
+	}
+
+	earned := new(big.Int)
+
+	for _, result := range results {
+		if result.Slot < startSlot || result.Slot > endSlot {
+			continue
+		}
+
+		summary.SlotsTracked++
+
+		if result.Build != nil {
+			switch result.Build.Status {
+			case slot_results.BuildStatusReady:
+				summary.SlotsBuilt++
+			case slot_results.BuildStatusFailed:
+				summary.BuildsFailed++
+			case slot_results.BuildStatusSkipped:
+				summary.BuildsSkipped++
+			}
+		}
+
+		sent := 0
+
+		for _, bid := range result.Bids {
+			if bid.Fault != "" {
+				// Deliberately invalid test bids.
+				continue
+			}
+
+			switch bid.Status {
+			case slot_results.BidStatusSubmitted, slot_results.BidStatusServed:
+				sent++
+
+				if bid.Canary {
+					summary.BidsCanary++
+				}
+
+				if bid.ValueBoostPct > 0 {
+					summary.BidsBoosted++
+				}
+			case slot_results.BidStatusFailed:
+				summary.BidsFailed++
+			}
+		}
+
+		summary.BidsSent += sent
+		if sent > 0 {
+			summary.SlotsBid++
+		}
+
+		if n := len(result.RevealAttempts); n > 0 {
+			published := false
+
+			for _, attempt := range result.RevealAttempts {
+				if attempt.Status == slot_results.RevealStatusPublished {
+					published = true
+					break
+				}
+			}
+
+			switch last := result.RevealAttempts[n-1].Status; {
+			case published:
+				summary.RevealsPublished++
+			case last == slot_results.RevealStatusFailed || last == slot_results.RevealStatusSkipped:
+				summary.RevealsMissed++
+			}
+		}
+
+		if inclusion := result.Inclusion; inclusion != nil {
+			summary.BidsWon++
+
+			switch inclusion.PayloadStatus {
+			case slot_results.PayloadStatusCanonical:
+				summary.PayloadsCanonical++
+			case slot_results.PayloadStatusMissed:
+				summary.PayloadsMissed++
+			case slot_results.PayloadStatusOrphaned:
+				summary.PayloadsOrphaned++
+			}
+
+			if inclusion.PayloadStatus != slot_results.PayloadStatusMissed &&
+				inclusion.PayloadStatus != slot_results.PayloadStatusOrphaned {
+				if value, ok := new(big.Int).SetString(inclusion.ValueWei, 10); ok {
+					earned.Add(earned, value)
+				}
+			}
+		}
+	}
+
+	summary.ValueEarnedWei = earned.String()
+
+	return summary
+}
+
+// applyParticipation fills the participation averages from per-slot
+// percentages.
+func (s *EpochSummary) applyParticipation(pcts []float64) {
+	s.ParticipationSlots = len(pcts)
+	if len(pcts) == 0 {
+		return
+	}
+
+	total := 0.0
+	s.MinParticipationPct = pcts[0]
+
+	for _, pct := range pcts {
+		total += pct
+		s.MinParticipationPct = min(s.MinParticipationPct, pct)
+	}
+
+	s.AvgParticipationPct = total / float64(len(pcts))
+}
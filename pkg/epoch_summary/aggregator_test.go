@@ -0,0 +1,124 @@
+package epoch_summary
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain/chaintest"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+func TestSummarize(t *testing.T) {
+	results := []*slot_results.SlotResult{
+		{
+			Slot:  64,
+			Build: &slot_results.BuildOutcome{Status: slot_results.BuildStatusReady},
+			Bids: []slot_results.BidAttempt{
+				{Status: slot_results.BidStatusSubmitted},
+				{Status: slot_results.BidStatusSubmitted, Canary: true},
+				{Status: slot_results.BidStatusFailed, Canary: true},
+			},
+			RevealAttempts: []slot_results.RevealAttempt{
+				{Status: slot_results.RevealStatusFailed, Attempt: 1},
+				{Status: slot_results.RevealStatusPublished, Attempt: 2},
+			},
+			Inclusion: &slot_results.InclusionResult{
+				ValueWei:      "1000",
+				PayloadStatus: slot_results.PayloadStatusCanonical,
+			},
+		},
+		{
+			Slot:           65,
+			Build:          &slot_results.BuildOutcome{Status: slot_results.BuildStatusReady},
+			Bids:           []slot_results.BidAttempt{{Status: slot_results.BidStatusServed, ValueBoostPct: 150}},
+			RevealAttempts: []slot_results.RevealAttempt{{Status: slot_results.RevealStatusSkipped, SkipReason: "late"}},
+			Inclusion: &slot_results.InclusionResult{
+				ValueWei:      "500",
+				PayloadStatus: slot_results.PayloadStatusMissed,
+			},
+		},
+		{
+			Slot:           66,
+			Build:          &slot_results.BuildOutcome{Status: slot_results.BuildStatusSkipped},
+			RevealAttempts: []slot_results.RevealAttempt{{Status: slot_results.RevealStatusSuppressed}},
+		},
+		{
+			Slot:  67,
+			Build: &slot_results.BuildOutcome{Status: slot_results.BuildStatusFailed},
+			Inclusion: &slot_results.InclusionResult{
+				ValueWei: "250", // pre-Gloas win, no payload verdict
+			},
+		},
+		{Slot: 96, Build: &slot_results.BuildOutcome{Status: slot_results.BuildStatusReady}}, // next epoch
+	}
+
+	summary := Summarize(2, 64, 95, results)
+
+	require.Equal(t, uint64(2), summary.Epoch)
+	require.Equal(t, 4, summary.SlotsTracked)
+	require.Equal(t, 2, summary.SlotsBuilt)
+	require.Equal(t, 1, summary.BuildsFailed)
+	require.Equal(t, 1, summary.BuildsSkipped)
+
+	require.Equal(t, 2, summary.SlotsBid)
+	require.Equal(t, 3, summary.BidsSent)
+	require.Equal(t, 1, summary.BidsFailed)
+	require.Equal(t, 1, summary.BidsCanary, "failed canary bids are not sent")
+	require.Equal(t, 1, summary.BidsBoosted)
+
+	require.Equal(t, 3, summary.BidsWon)
+	require.Equal(t, 1, summary.PayloadsCanonical)
+	require.Equal(t, 1, summary.PayloadsMissed)
+	require.Equal(t, "1250", summary.ValueEarnedWei, "missed payloads earn nothing")
+
+	require.Equal(t, 1, summary.RevealsPublished, "a retry that published counts as published")
+	require.Equal(t, 1, summary.RevealsMissed, "suppressed reveals are intentional")
+}
+
+func TestApplyParticipation(t *testing.T) {
+	summary := &EpochSummary{}
+	summary.applyParticipation(nil)
+	require.Zero(t, summary.ParticipationSlots)
+
+	summary.applyParticipation([]float64{90, 70, 80})
+	require.Equal(t, 3, summary.ParticipationSlots)
+	require.InDelta(t, 80.0, summary.AvgParticipationPct, 1e-9)
+	require.InDelta(t, 70.0, summary.MinParticipationPct, 1e-9)
+}
+
+type stubResults struct{}
+
+func (stubResults) GetRange(_, _ phase0.Slot) []*slot_results.SlotResult { return nil }
+
+func TestAggregatorSummarizesOnChainClock(t *testing.T) {
+	// One slot before epoch 2's summary is due (summaryDelaySlots after
+	// its end). The chain clock is a fake: a wall-clock slot timer would
+	// never fire.
+	chainSvc := chaintest.NewClocked(time.Unix((3*32+summaryDelaySlots-1)*12+1, 0))
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	aggregator := NewAggregator(chainSvc, stubResults{}, log)
+
+	summaries := aggregator.SubscribeSummaries(4)
+	defer summaries.Unsubscribe()
+
+	require.NoError(t, aggregator.Start(t.Context()))
+	t.Cleanup(aggregator.Stop)
+
+	require.Eventually(t, func() bool { return chainSvc.Clk.Pending() > 0 }, time.Second, time.Millisecond)
+
+	chainSvc.Clk.Advance(11 * time.Second)
+
+	select {
+	case summary := <-summaries.Channel():
+		require.Equal(t, uint64(2), summary.Epoch)
+	case <-time.After(time.Second):
+		t.Fatal("epoch 2 not summarized on the chain clock")
+	}
+}
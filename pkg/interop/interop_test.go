@@ -0,0 +1,186 @@
+package interop
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeBuilder serves a minimal Builder API that accepts JSON and SSZ
+// registrations and never has a bid. With noBidBody set, a "no bid" carries
+// a JSON null body, which clients reject.
+func newFakeBuilder(t *testing.T, noBidBody bool) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var registered []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/builder/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("POST /eth/v1/builder/validators", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get("Content-Type") == "application/octet-stream" {
+			if err := new(apiv1.SignedValidatorRegistration).UnmarshalSSZ(body); err != nil {
+				http.Error(w, `{"code":400,"message":"invalid SSZ"}`, http.StatusBadRequest)
+				return
+			}
+		} else {
+			var regs []*apiv1.SignedValidatorRegistration
+			if err := json.Unmarshal(body, &regs); err != nil || len(regs) != 1 {
+				http.Error(w, `{"code":400,"message":"invalid JSON"}`, http.StatusBadRequest)
+				return
+			}
+		}
+
+		registered = append(registered, r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Eth-Consensus-Version", "fulu")
+
+		if len(r.PathValue("parent_hash")) != 66 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":400,"message":"invalid parent_hash"}`))
+
+			return
+		}
+
+		if noBidBody {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"version":"fulu","data":null}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv, &registered
+}
+
+func TestProfiles(t *testing.T) {
+	profiles, err := Profiles()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"lighthouse", "lodestar", "nimbus", "prysm", "teku"}, clientNames(profiles))
+
+	for _, p := range profiles {
+		assert.NotEmpty(t, p.UserAgent, p.Client)
+		assert.NotEmpty(t, p.Exchanges, p.Client)
+	}
+
+	selected, err := SelectProfiles([]string{"teku", "prysm"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"teku", "prysm"}, clientNames(selected))
+
+	_, err = SelectProfiles([]string{"grandine"})
+	require.ErrorContains(t, err, `unknown client "grandine"`)
+}
+
+func TestRunnerRun(t *testing.T) {
+	srv, registered := newFakeBuilder(t, false)
+
+	profiles, err := Profiles()
+	require.NoError(t, err)
+
+	report, err := NewRunner(Options{
+		Target:     srv.URL + "/",
+		Slot:       12,
+		ParentHash: phase0.Hash32{0x01},
+	}).Run(t.Context(), profiles)
+	require.NoError(t, err)
+
+	require.Empty(t, report.Failures())
+	require.Len(t, report.Clients, len(profiles))
+	require.Len(t, *registered, len(profiles), "every client registered (teku as SSZ)")
+	assert.Contains(t, *registered, "teku/v25.6.0")
+
+	for _, client := range report.Clients {
+		for _, c := range client.Checks {
+			assert.Equal(t, ResultPass, c.Result, "%s/%s: %s", client.Client, c.Exchange, c.Detail)
+		}
+	}
+
+	var text strings.Builder
+	require.NoError(t, report.WriteText(&text))
+	assert.Contains(t, text.String(), "\ncompatible\n")
+}
+
+func TestRunnerRun_SkipsHeaderWithoutParentHash(t *testing.T) {
+	srv, _ := newFakeBuilder(t, false)
+
+	profiles, err := SelectProfiles([]string{"nimbus"})
+	require.NoError(t, err)
+
+	report, err := NewRunner(Options{Target: srv.URL}).Run(t.Context(), profiles)
+	require.NoError(t, err)
+
+	results := map[string]string{}
+	for _, c := range report.Clients[0].Checks {
+		results[c.Exchange] = c.Result
+	}
+
+	assert.Equal(t, map[string]string{
+		"status":                  ResultPass,
+		"register_validators":     ResultPass,
+		"get_header":              ResultSkip,
+		"get_header_unregistered": ResultSkip,
+	}, results)
+}
+
+func TestRunnerRun_ReportsIncompatibleNoBid(t *testing.T) {
+	srv, _ := newFakeBuilder(t, true)
+
+	profiles, err := SelectProfiles([]string{"prysm", "lighthouse"})
+	require.NoError(t, err)
+
+	report, err := NewRunner(Options{
+		Target:     srv.URL,
+		Slot:       12,
+		ParentHash: phase0.Hash32{0x01},
+	}).Run(t.Context(), profiles)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"prysm/get_header",
+		"prysm/get_header_unregistered",
+		"lighthouse/get_header",
+		"lighthouse/get_header_unregistered",
+	}, report.Failures())
+
+	// Prysm asked for JSON and got it, but without the bid fields.
+	assert.Contains(t, report.Clients[0].Checks[2].Detail, "JSON field data.message.header.parent_hash missing")
+	// Lighthouse asked for SSZ and got JSON.
+	assert.Contains(t, report.Clients[1].Checks[2].Detail, `Content-Type "application/json"`)
+	assert.Contains(t, report.Clients[1].Checks[3].Detail, "unexpected status (client accepts 204)")
+}
+
+func TestCheckResponse_VersionMatchesHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Eth-Consensus-Version", "electra")
+
+	exp := Expect{ContentType: "application/json", VersionMatchesHeader: true}
+
+	require.NoError(t, checkResponse(exp, header, []byte(`{"version":"Electra"}`)))
+	require.ErrorContains(t, checkResponse(exp, header, []byte(`{"version":"fulu"}`)),
+		`version "fulu" does not match Eth-Consensus-Version "electra"`)
+
+	header.Del("Eth-Consensus-Version")
+	require.ErrorContains(t, checkResponse(Expect{SSZBid: true}, header, nil), "Eth-Consensus-Version header missing")
+}
@@ -0,0 +1,199 @@
+// Package interop replays the Builder API requests of the major consensus
+// clients (Lighthouse, Prysm, Teku, Nimbus, Lodestar) against a buildoor
+// endpoint and checks every response against what that client expects:
+// registration encodings, getHeader content negotiation (SSZ vs JSON), the
+// Eth-Consensus-Version header and the "no bid" / error response shapes.
+//
+// The request shapes and expectations of each client are fixtures embedded
+// from fixtures/<client>.json. Registrations are sent by a validator client
+// through its beacon node, so the fixtures capture the beacon node's builder
+// client, which is what buildoor actually talks to.
+package interop
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed fixtures/*.json
+var fixturesFS embed.FS
+
+// Request body kinds of an exchange.
+const (
+	// BodyRegistrationsJSON is a JSON array of signed validator registrations.
+	BodyRegistrationsJSON = "registrations_json"
+	// BodyRegistrationsSSZ is an SSZ list of signed validator registrations.
+	BodyRegistrationsSSZ = "registrations_ssz"
+)
+
+// Path placeholders substituted by the runner.
+const (
+	placeholderSlot                = "{slot}"
+	placeholderParentHash          = "{parent_hash}"
+	placeholderPubkey              = "{pubkey}"
+	placeholderUnregisteredPubkey  = "{unregistered_pubkey}"
+	placeholderMalformedParentHash = "{malformed_parent_hash}"
+)
+
+// Profile is the recorded Builder API behaviour of one consensus client.
+type Profile struct {
+	Client      string     `json:"client"`
+	Description string     `json:"description"`
+	UserAgent   string     `json:"user_agent"`
+	Quirks      []string   `json:"quirks,omitempty"`
+	Exchanges   []Exchange `json:"exchanges"`
+}
+
+// Exchange is one request the client sends and the responses it accepts,
+// keyed by HTTP status. A status missing from Responses is a failure.
+type Exchange struct {
+	Name      string            `json:"name"`
+	Method    string            `json:"method"`
+	Path      string            `json:"path"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Body      string            `json:"body,omitempty"` // Body* kind
+	Notes     string            `json:"notes,omitempty"`
+	Responses map[string]Expect `json:"responses"`
+}
+
+// Expect is what the client requires of a response with a given status.
+type Expect struct {
+	// ContentType is the required media type of the response.
+	ContentType string `json:"content_type,omitempty"`
+	// ConsensusVersion requires the Eth-Consensus-Version header.
+	ConsensusVersion bool `json:"consensus_version,omitempty"`
+	// EmptyBody requires an empty body (e.g. 204 "no bid").
+	EmptyBody bool `json:"empty_body,omitempty"`
+	// JSONFields are dot-separated paths that must be present and non-null
+	// in a JSON body.
+	JSONFields []string `json:"json_fields,omitempty"`
+	// VersionMatchesHeader requires the JSON "version" field to equal the
+	// Eth-Consensus-Version header (case-insensitive).
+	VersionMatchesHeader bool `json:"version_matches_header,omitempty"`
+	// SSZBid requires the body to decode as an SSZ SignedBuilderBid of the
+	// fork named by the Eth-Consensus-Version header.
+	SSZBid bool `json:"ssz_bid,omitempty"`
+}
+
+// NeedsHeader reports whether the exchange requests a header and so needs a
+// slot and parent hash.
+func (e *Exchange) NeedsHeader() bool {
+	return strings.Contains(e.Path, placeholderSlot)
+}
+
+// expect returns the expectation for a response status.
+func (e *Exchange) expect(status int) (Expect, bool) {
+	exp, ok := e.Responses[strconv.Itoa(status)]
+	return exp, ok
+}
+
+// statuses returns the accepted statuses in ascending order.
+func (e *Exchange) statuses() []string {
+	out := make([]string, 0, len(e.Responses))
+	for status := range e.Responses {
+		out = append(out, status)
+	}
+
+	sort.Strings(out)
+
+	return out
+}
+
+// Profiles returns the embedded profiles of every client, sorted by client
+// name.
+func Profiles() ([]*Profile, error) {
+	entries, err := fixturesFS.ReadDir("fixtures")
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*Profile, 0, len(entries))
+
+	for _, entry := range entries {
+		data, err := fixturesFS.ReadFile(path.Join("fixtures", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		profile := &Profile{}
+		if err := json.Unmarshal(data, profile); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", entry.Name(), err)
+		}
+
+		if err := profile.validate(); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", entry.Name(), err)
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Client < profiles[j].Client })
+
+	return profiles, nil
+}
+
+// SelectProfiles returns the embedded profiles of the named clients, or all
+// of them when clients is empty.
+func SelectProfiles(clients []string) ([]*Profile, error) {
+	profiles, err := Profiles()
+	if err != nil || len(clients) == 0 {
+		return profiles, err
+	}
+
+	selected := make([]*Profile, 0, len(clients))
+
+	for _, client := range clients {
+		idx := sort.Search(len(profiles), func(i int) bool { return profiles[i].Client >= client })
+		if idx == len(profiles) || profiles[idx].Client != client {
+			return nil, fmt.Errorf("unknown client %q (known: %s)", client, strings.Join(clientNames(profiles), ", "))
+		}
+
+		selected = append(selected, profiles[idx])
+	}
+
+	return selected, nil
+}
+
+func clientNames(profiles []*Profile) []string {
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Client
+	}
+
+	return names
+}
+
+func (p *Profile) validate() error {
+	if p.Client == "" {
+		return fmt.Errorf("client missing")
+	}
+
+	for _, e := range p.Exchanges {
+		if e.Name == "" || e.Method == "" || e.Path == "" {
+			return fmt.Errorf("exchange %q: name, method and path are required", e.Name)
+		}
+
+		if len(e.Responses) == 0 {
+			return fmt.Errorf("exchange %q: no accepted responses", e.Name)
+		}
+
+		for status := range e.Responses {
+			if _, err := strconv.Atoi(status); err != nil {
+				return fmt.Errorf("exchange %q: invalid status %q", e.Name, status)
+			}
+		}
+
+		switch e.Body {
+		case "", BodyRegistrationsJSON, BodyRegistrationsSSZ:
+		default:
+			return fmt.Errorf("exchange %q: unknown body kind %q", e.Name, e.Body)
+		}
+	}
+
+	return nil
+}
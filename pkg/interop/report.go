@@ -0,0 +1,93 @@
+package interop
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Check results.
+const (
+	ResultPass = "pass"
+	ResultFail = "fail"
+	ResultSkip = "skip"
+)
+
+// Report is the outcome of a compatibility self-check run.
+type Report struct {
+	Target  string         `json:"target"`
+	Clients []ClientReport `json:"clients"`
+}
+
+// ClientReport holds the checks of one client profile.
+type ClientReport struct {
+	Client    string   `json:"client"`
+	UserAgent string   `json:"user_agent,omitempty"`
+	Quirks    []string `json:"quirks,omitempty"`
+	Checks    []Check  `json:"checks"`
+}
+
+// Check is the result of one exchange.
+type Check struct {
+	Exchange string `json:"exchange"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Result   string `json:"result"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+func (c Check) fail(format string, args ...any) Check {
+	c.Result = ResultFail
+	c.Detail = fmt.Sprintf(format, args...)
+
+	return c
+}
+
+// Failures returns the failed checks as "client/exchange".
+func (r *Report) Failures() []string {
+	var failures []string
+
+	for _, client := range r.Clients {
+		for _, c := range client.Checks {
+			if c.Result == ResultFail {
+				failures = append(failures, client.Client+"/"+c.Exchange)
+			}
+		}
+	}
+
+	return failures
+}
+
+// WriteText renders the report as one table per client.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "builder API: %s\n", r.Target)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for _, client := range r.Clients {
+		fmt.Fprintf(tw, "\t\t\t\t\n%s (%s)\tREQUEST\tRESULT\tSTATUS\tDETAIL\n", strings.ToUpper(client.Client), client.UserAgent)
+
+		for _, c := range client.Checks {
+			status := "-"
+			if c.Status != 0 {
+				status = fmt.Sprintf("%d", c.Status)
+			}
+
+			fmt.Fprintf(tw, "%s\t%s %s\t%s\t%s\t%s\n", c.Exchange, c.Method, c.Path, c.Result, status, c.Detail)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failures := r.Failures(); len(failures) > 0 {
+		fmt.Fprintf(w, "\nincompatible: %s\n", strings.Join(failures, ", "))
+	} else {
+		fmt.Fprintln(w, "\ncompatible")
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package interop
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// maxResponseBody bounds the response bytes read per exchange.
+const maxResponseBody = 8 << 20
+
+// Options configures a compatibility self-check run.
+type Options struct {
+	// Target is the Builder API base URL (e.g. http://buildoor:8082).
+	Target string
+	// Slot and ParentHash are the getHeader path parameters. getHeader
+	// exchanges are skipped while ParentHash is zero.
+	Slot       phase0.Slot
+	ParentHash phase0.Hash32
+	// Pubkey is the proposer of the getHeader requests. Zero uses each
+	// client's synthetic validator, which the run registers first.
+	Pubkey phase0.BLSPubKey
+	// FeeRecipient and GasLimit are advertised in the registrations.
+	FeeRecipient bellatrix.ExecutionAddress
+	GasLimit     uint64
+	// Timeout bounds a single request.
+	Timeout time.Duration
+}
+
+// Runner replays client profiles against a Builder API endpoint.
+type Runner struct {
+	opts   Options
+	client *http.Client
+}
+
+// NewRunner creates a runner for the given options.
+func NewRunner(opts Options) *Runner {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	if opts.GasLimit == 0 {
+		opts.GasLimit = 60_000_000
+	}
+
+	opts.Target = strings.TrimSuffix(opts.Target, "/")
+
+	return &Runner{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+// Run replays every exchange of the profiles in order and returns the
+// per-client results. Only setup failures (e.g. key derivation) are errors;
+// an incompatible response is a failed check.
+func (r *Runner) Run(ctx context.Context, profiles []*Profile) (*Report, error) {
+	unregistered, err := syntheticKey("unregistered")
+	if err != nil {
+		return nil, err
+	}
+
+	unregisteredPubkey := unregistered.PublicKey()
+	report := &Report{Target: r.opts.Target}
+
+	for _, profile := range profiles {
+		key, err := syntheticKey(profile.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		reg, err := r.signRegistration(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign %s registration: %w", profile.Client, err)
+		}
+
+		pubkey := r.opts.Pubkey
+		if pubkey == (phase0.BLSPubKey{}) {
+			pubkey = key.PublicKey()
+		}
+
+		vars := strings.NewReplacer(
+			placeholderSlot, strconv.FormatUint(uint64(r.opts.Slot), 10),
+			placeholderParentHash, fmt.Sprintf("%#x", r.opts.ParentHash[:]),
+			placeholderMalformedParentHash, "0x1234",
+			placeholderPubkey, fmt.Sprintf("%#x", pubkey[:]),
+			placeholderUnregisteredPubkey, fmt.Sprintf("%#x", unregisteredPubkey[:]),
+		)
+
+		result := ClientReport{
+			Client:    profile.Client,
+			UserAgent: profile.UserAgent,
+			Quirks:    profile.Quirks,
+			Checks:    make([]Check, 0, len(profile.Exchanges)),
+		}
+
+		for i := range profile.Exchanges {
+			result.Checks = append(result.Checks, r.runExchange(ctx, profile, &profile.Exchanges[i], vars, reg))
+		}
+
+		report.Clients = append(report.Clients, result)
+	}
+
+	return report, nil
+}
+
+// runExchange sends one exchange and checks the response against the
+// expectation for its status.
+func (r *Runner) runExchange(
+	ctx context.Context,
+	profile *Profile,
+	e *Exchange,
+	vars *strings.Replacer,
+	reg *apiv1.SignedValidatorRegistration,
+) Check {
+	check := Check{Exchange: e.Name, Method: e.Method, Path: e.Path}
+
+	if e.NeedsHeader() && r.opts.ParentHash == (phase0.Hash32{}) {
+		check.Result = ResultSkip
+		check.Detail = "no slot / parent hash given"
+
+		return check
+	}
+
+	body, err := encodeBody(e.Body, reg)
+	if err != nil {
+		return check.fail("failed to encode request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, e.Method, r.opts.Target+vars.Replace(e.Path), bytes.NewReader(body))
+	if err != nil {
+		return check.fail("invalid request: %v", err)
+	}
+
+	if profile.UserAgent != "" {
+		req.Header.Set("User-Agent", profile.UserAgent)
+	}
+
+	for name, value := range e.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return check.fail("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return check.fail("failed to read response: %v", err)
+	}
+
+	check.Status = resp.StatusCode
+
+	exp, ok := e.expect(resp.StatusCode)
+	if !ok {
+		return check.fail("unexpected status (client accepts %s): %s",
+			strings.Join(e.statuses(), ", "), truncate(respBody))
+	}
+
+	if err := checkResponse(exp, resp.Header, respBody); err != nil {
+		return check.fail("%v", err)
+	}
+
+	check.Result = ResultPass
+
+	if resp.StatusCode == http.StatusNoContent {
+		check.Detail = "no bid"
+	}
+
+	return check
+}
+
+// checkResponse checks a response against a client's expectation.
+func checkResponse(exp Expect, header http.Header, body []byte) error {
+	if exp.EmptyBody && len(body) > 0 {
+		return fmt.Errorf("expected an empty body, got %d bytes", len(body))
+	}
+
+	if exp.ContentType != "" {
+		mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+		if err != nil || mediaType != exp.ContentType {
+			return fmt.Errorf("Content-Type %q, client expects %s", header.Get("Content-Type"), exp.ContentType)
+		}
+	}
+
+	consensusVersion := header.Get("Eth-Consensus-Version")
+	if (exp.ConsensusVersion || exp.SSZBid) && consensusVersion == "" {
+		return fmt.Errorf("Eth-Consensus-Version header missing")
+	}
+
+	if len(exp.JSONFields) > 0 || exp.VersionMatchesHeader {
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return fmt.Errorf("invalid JSON body: %w", err)
+		}
+
+		for _, field := range exp.JSONFields {
+			if lookupField(doc, field) == nil {
+				return fmt.Errorf("JSON field %s missing", field)
+			}
+		}
+
+		if exp.VersionMatchesHeader {
+			bodyVersion, _ := lookupField(doc, "version").(string)
+			if !strings.EqualFold(bodyVersion, consensusVersion) {
+				return fmt.Errorf("version %q does not match Eth-Consensus-Version %q", bodyVersion, consensusVersion)
+			}
+		}
+	}
+
+	if exp.SSZBid {
+		var fork version.DataVersion
+		if err := fork.UnmarshalJSON([]byte(strconv.Quote(strings.ToLower(consensusVersion)))); err != nil {
+			return fmt.Errorf("invalid Eth-Consensus-Version %q: %w", consensusVersion, err)
+		}
+
+		bid := &legacytypes.SignedBuilderBid{Version: fork}
+		if err := bid.UnmarshalSSZ(body); err != nil {
+			return fmt.Errorf("body is not an SSZ %s SignedBuilderBid: %w", fork, err)
+		}
+	}
+
+	return nil
+}
+
+// lookupField returns the value at a dot-separated path of a decoded JSON
+// document, or nil when it is missing.
+func lookupField(doc any, path string) any {
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		doc = obj[key]
+	}
+
+	return doc
+}
+
+// encodeBody encodes the registration in the exchange's body kind.
+func encodeBody(kind string, reg *apiv1.SignedValidatorRegistration) ([]byte, error) {
+	switch kind {
+	case BodyRegistrationsJSON:
+		return json.Marshal([]*apiv1.SignedValidatorRegistration{reg})
+	case BodyRegistrationsSSZ:
+		// The registration container is fixed-size: the SSZ list is the
+		// plain concatenation of its elements.
+		return reg.MarshalSSZ()
+	default:
+		return nil, nil
+	}
+}
+
+// signRegistration signs a registration for the key under the builder
+// application domain with zero fork version and genesis root (accepted by
+// buildoor and mev-boost-relay).
+func (r *Runner) signRegistration(key *signer.BLSSigner) (*apiv1.SignedValidatorRegistration, error) {
+	msg := &apiv1.ValidatorRegistration{
+		FeeRecipient: r.opts.FeeRecipient,
+		GasLimit:     r.opts.GasLimit,
+		Timestamp:    time.Now().Truncate(time.Second),
+		Pubkey:       key.PublicKey(),
+	}
+
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := signer.ComputeDomain(signer.DomainApplicationBuilder, phase0.Version{}, phase0.Root{})
+
+	sig, err := key.SignWithDomain(root, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.SignedValidatorRegistration{Message: msg, Signature: sig}, nil
+}
+
+// syntheticKey deterministically derives the synthetic validator key of a
+// client. Clearing the top two bits keeps the scalar below the BLS curve
+// order.
+func syntheticKey(name string) (*signer.BLSSigner, error) {
+	sk := sha256.Sum256([]byte("buildoor-interop-validator-" + name))
+	sk[0] &= 0x3f
+
+	return signer.NewBLSSigner(hex.EncodeToString(sk[:]))
+}
+
+// truncate shortens a response body for a check detail.
+func truncate(body []byte) string {
+	const limit = 200
+
+	s := strings.TrimSpace(string(body))
+	if len(s) > limit {
+		return s[:limit] + "..."
+	}
+
+	if s == "" {
+		return "empty body"
+	}
+
+	return s
+}
@@ -0,0 +1,133 @@
+package lifecycle
+
+// Deposit batching: several builder deposit requests sent in one transaction
+// through an operator-deployed batching contract (--deposit-batch-contract).
+//
+// The batching contract takes the concatenated raw 184-byte requests as its
+// calldata and forwards each one to the EIP-8282 builder deposit predeploy with
+// msg.value = amount (from the request's amount field, gwei -> wei) + the
+// current queue fee (read from the predeploy, per EIP-7002), refunding any
+// excess to the sender. All requests of one transaction land in the same block
+// and so pay the same queue fee.
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+)
+
+// depositRequestSize is the length of a raw builder deposit request.
+const depositRequestSize = 184
+
+// BuildBatchDepositCalldata concatenates raw builder deposit requests into the
+// batching contract's calldata.
+func BuildBatchDepositCalldata(requests [][]byte) ([]byte, error) {
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no deposit requests to batch")
+	}
+
+	data := make([]byte, 0, len(requests)*depositRequestSize)
+
+	for i, request := range requests {
+		if len(request) != depositRequestSize {
+			return nil, fmt.Errorf("deposit request %d must be %d bytes, got %d", i, depositRequestSize, len(request))
+		}
+
+		data = append(data, request...)
+	}
+
+	return data, nil
+}
+
+// batchContract returns the configured batching contract, or nil.
+func (s *DepositService) batchContract() *common.Address {
+	if s.cfg.DepositBatchContract == "" {
+		return nil
+	}
+
+	addr := common.HexToAddress(s.cfg.DepositBatchContract)
+
+	return &addr
+}
+
+// CreateTopups sends several top-ups (additional deposits) for the builder.
+// With a batching contract configured they go out as one transaction;
+// otherwise each is sent on its own.
+func (s *DepositService) CreateTopups(ctx context.Context, amountsGwei []uint64) error {
+	batchContract := s.batchContract()
+	if batchContract == nil || len(amountsGwei) < 2 {
+		for _, amount := range amountsGwei {
+			if err := s.CreateTopup(ctx, amount); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if chain.HasBuilderExited(s.chainSvc.GetBuilderByPubkey(s.signer.PublicKey())) {
+		return ErrBuilderExited
+	}
+
+	requests := make([][]byte, 0, len(amountsGwei))
+	total := new(big.Int)
+
+	for _, amount := range amountsGwei {
+		request, err := s.buildDepositRequest(amount)
+		if err != nil {
+			return err
+		}
+
+		requests = append(requests, request)
+		total.Add(total, GweiToWei(amount))
+	}
+
+	calldata, err := BuildBatchDepositCalldata(requests)
+	if err != nil {
+		return err
+	}
+
+	fee, err := s.resolveDepositFee(ctx)
+	if err != nil {
+		return err
+	}
+
+	// msg.value = stakes + one queue fee per request.
+	value := new(big.Int).Mul(fee, big.NewInt(int64(len(requests))))
+	value.Add(value, total)
+
+	s.log.WithFields(logrus.Fields{
+		"batch_contract": batchContract.Hex(),
+		"requests":       len(requests),
+		"amounts_gwei":   amountsGwei,
+		"queue_fee_wei":  fee.String(),
+		"value_wei":      value.String(),
+	}).Info("Batched builder top-ups prepared")
+
+	receipt, err := s.wallet.SendAndConfirm(
+		ctx,
+		txKindBatchTopup,
+		*batchContract,
+		value,
+		calldata,
+		depositGasLimit*uint64(len(requests)),
+		5*time.Minute,
+	)
+	if err != nil {
+		return fmt.Errorf("batched top-up transaction failed: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tx_hash":      receipt.TxHash.Hex(),
+		"block_number": receipt.BlockNumber.Uint64(),
+		"requests":     len(requests),
+	}).Info("Batched top-up transaction confirmed")
+
+	return nil
+}
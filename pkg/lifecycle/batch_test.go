@@ -0,0 +1,33 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBatchDepositCalldata(t *testing.T) {
+	var wc [32]byte
+
+	first, err := BuildBuilderDepositCalldata(make([]byte, 48), wc[:], 1_000_000_000, make([]byte, 96))
+	require.NoError(t, err)
+
+	second, err := BuildBuilderDepositCalldata(make([]byte, 48), wc[:], 2_000_000_000, make([]byte, 96))
+	require.NoError(t, err)
+
+	data, err := BuildBatchDepositCalldata([][]byte{first, second})
+	require.NoError(t, err)
+	require.Len(t, data, 2*depositRequestSize)
+
+	assert.Equal(t, first, data[:depositRequestSize], "requests concatenated in order")
+	assert.Equal(t, second, data[depositRequestSize:])
+}
+
+func TestBuildBatchDepositCalldataRejectsBadInput(t *testing.T) {
+	_, err := BuildBatchDepositCalldata(nil)
+	assert.Error(t, err, "empty batch rejected")
+
+	_, err = BuildBatchDepositCalldata([][]byte{make([]byte, depositRequestSize), make([]byte, depositRequestSize-1)})
+	assert.Error(t, err, "short request rejected")
+}
@@ -53,7 +53,7 @@ const depositGasLimit = 1000000
 type DepositService struct {
 	cfg      *config.Config
 	chainSvc chain.Service
-	signer   *signer.BLSSigner
+	signer   signer.Signer
 	wallet   *wallet.Wallet
 	log      logrus.FieldLogger
 }
@@ -62,7 +62,7 @@ type DepositService struct {
 func NewDepositService(
 	cfg *config.Config,
 	chainSvc chain.Service,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	w *wallet.Wallet,
 	log logrus.FieldLogger,
 ) (*DepositService, error) {
@@ -114,6 +114,11 @@ func (s *DepositService) IsBuilderRegistered(_ context.Context) (bool, *BuilderS
 // DepositMaxFeeGwei is set, returns ErrDepositFeeTooHigh if the fee exceeds the limit
 // so the caller can delay and retry. The transaction value is stake + queue fee.
 func (s *DepositService) CreateDeposit(ctx context.Context, amountGwei uint64) error {
+	return s.createDeposit(ctx, txKindDeposit, amountGwei)
+}
+
+// createDeposit sends a deposit; kind labels the wallet transaction.
+func (s *DepositService) createDeposit(ctx context.Context, kind string, amountGwei uint64) error {
 	pubkey := s.signer.PublicKey()
 
 	// Refuse deposits for an exited builder entry: they cannot reactivate it and
@@ -124,29 +129,11 @@ func (s *DepositService) CreateDeposit(ctx context.Context, amountGwei uint64) e
 	}
 
 	s.log.WithField("amount_gwei", amountGwei).Info("Creating builder deposit")
-	withdrawalCredentials := BuilderWithdrawalCredentials(s.wallet.Address())
-
-	// Step 1: Compute the builder-deposit signing root (DOMAIN_BUILDER_DEPOSIT,
-	// GENESIS_FORK_VERSION) and sign it as a proof-of-possession.
-	signingRoot, err := signer.ComputeBuilderDepositSigningRoot(
-		pubkey,
-		withdrawalCredentials,
-		amountGwei,
-		s.chainSvc.GetGenesis().GenesisForkVersion,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to compute signing root: %w", err)
-	}
-
-	signature, err := s.signer.Sign(signingRoot[:])
-	if err != nil {
-		return fmt.Errorf("failed to sign deposit: %w", err)
-	}
 
-	// Step 2: Build the raw 184-byte request calldata.
-	calldata, err := BuildBuilderDepositCalldata(pubkey[:], withdrawalCredentials[:], amountGwei, signature[:])
+	// Steps 1-2: Sign the deposit and build the raw 184-byte request calldata.
+	calldata, err := s.buildDepositRequest(amountGwei)
 	if err != nil {
-		return fmt.Errorf("failed to build deposit calldata: %w", err)
+		return err
 	}
 
 	// Step 3: Resolve the queue fee and enforce the operator's fee limit.
@@ -160,20 +147,45 @@ func (s *DepositService) CreateDeposit(ctx context.Context, amountGwei uint64) e
 
 	s.log.WithFields(logrus.Fields{
 		"pubkey":           fmt.Sprintf("0x%x", pubkey[:]),
-		"withdrawal_creds": fmt.Sprintf("0x%x", withdrawalCredentials[:]),
+		"withdrawal_creds": fmt.Sprintf("0x%x", calldata[48:80]),
 		"amount_gwei":      amountGwei,
 		"queue_fee_wei":    fee.String(),
 		"value_wei":        value.String(),
 	}).Info("Builder deposit prepared")
 
-	return s.sendDepositTransaction(ctx, calldata, value)
+	return s.sendDepositTransaction(ctx, kind, calldata, value)
+}
+
+// buildDepositRequest signs a deposit of amountGwei under DOMAIN_BUILDER_DEPOSIT
+// and GENESIS_FORK_VERSION (the proof-of-possession) and returns its raw
+// 184-byte request calldata.
+func (s *DepositService) buildDepositRequest(amountGwei uint64) ([]byte, error) {
+	pubkey := s.signer.PublicKey()
+	withdrawalCredentials := BuilderWithdrawalCredentials(s.wallet.Address())
+
+	signature, err := signer.SignBuilderDeposit(
+		s.signer,
+		withdrawalCredentials,
+		amountGwei,
+		s.chainSvc.GetGenesis().GenesisForkVersion,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign deposit: %w", err)
+	}
+
+	calldata, err := BuildBuilderDepositCalldata(pubkey[:], withdrawalCredentials[:], amountGwei, signature[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to build deposit calldata: %w", err)
+	}
+
+	return calldata, nil
 }
 
 // CreateTopup creates and sends a top-up transaction (an additional deposit).
 func (s *DepositService) CreateTopup(ctx context.Context, amountGwei uint64) error {
 	s.log.WithField("amount_gwei", amountGwei).Info("Creating builder top-up")
 
-	return s.CreateDeposit(ctx, amountGwei)
+	return s.createDeposit(ctx, txKindTopup, amountGwei)
 }
 
 // resolveDepositFee reads the builder deposit contract's current queue fee and
@@ -208,9 +220,10 @@ func (s *DepositService) resolveDepositFee(ctx context.Context) (*big.Int, error
 //
 // SendAndConfirm sources a fresh nonce and resolves nonce conflicts/displacement, so
 // several instances can share this funding key safely.
-func (s *DepositService) sendDepositTransaction(ctx context.Context, calldata []byte, value *big.Int) error {
+func (s *DepositService) sendDepositTransaction(ctx context.Context, kind string, calldata []byte, value *big.Int) error {
 	receipt, err := s.wallet.SendAndConfirm(
 		ctx,
+		kind,
 		BuilderDepositContractAddress,
 		value,
 		calldata,
@@ -38,7 +38,7 @@ const depositContractABI = `[{"name":"deposit","type":"function","stateMutabilit
 type EarlyDepositService struct {
 	cfg        *config.Config
 	chainSvc   chain.Service
-	signer     *signer.BLSSigner
+	signer     signer.Signer
 	wallet     *wallet.Wallet
 	depositABI abi.ABI
 	log        logrus.FieldLogger
@@ -48,7 +48,7 @@ type EarlyDepositService struct {
 func NewEarlyDepositService(
 	cfg *config.Config,
 	chainSvc chain.Service,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	w *wallet.Wallet,
 	log logrus.FieldLogger,
 ) (*EarlyDepositService, error) {
@@ -101,12 +101,7 @@ func (s *EarlyDepositService) CreateEarlyDeposit(ctx context.Context, amountGwei
 	genesisForkVersion := s.chainSvc.GetGenesis().GenesisForkVersion
 
 	// Sign the deposit message with the validator deposit domain (DOMAIN_DEPOSIT).
-	signingRoot, err := signer.ComputeDepositSigningRoot(pubkey, withdrawalCredentials, amountGwei, genesisForkVersion)
-	if err != nil {
-		return fmt.Errorf("failed to compute deposit signing root: %w", err)
-	}
-
-	signature, err := s.signer.Sign(signingRoot[:])
+	signature, err := signer.SignDeposit(s.signer, withdrawalCredentials, amountGwei, genesisForkVersion)
 	if err != nil {
 		return fmt.Errorf("failed to sign early deposit: %w", err)
 	}
@@ -137,7 +132,7 @@ func (s *EarlyDepositService) CreateEarlyDeposit(ctx context.Context, amountGwei
 		"value_wei":        value.String(),
 	}).Info("Early builder deposit prepared (regular deposit contract)")
 
-	receipt, err := s.wallet.SendAndConfirm(ctx, *depositContract, value, calldata, depositGasLimit, 5*time.Minute)
+	receipt, err := s.wallet.SendAndConfirm(ctx, txKindEarlyDeposit, *depositContract, value, calldata, depositGasLimit, 5*time.Minute)
 	if err != nil {
 		return fmt.Errorf("early deposit transaction failed: %w", err)
 	}
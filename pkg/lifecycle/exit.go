@@ -28,7 +28,7 @@ const exitGasLimit = 1000000
 // as msg.value.
 type ExitService struct {
 	chainSvc chain.Service
-	signer   *signer.BLSSigner
+	signer   signer.Signer
 	wallet   *wallet.Wallet
 	log      logrus.FieldLogger
 }
@@ -36,7 +36,7 @@ type ExitService struct {
 // NewExitService creates a new exit service.
 func NewExitService(
 	chainSvc chain.Service,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	w *wallet.Wallet,
 	log logrus.FieldLogger,
 ) *ExitService {
@@ -76,6 +76,7 @@ func (s *ExitService) CreateExit(ctx context.Context) error {
 
 	receipt, err := s.wallet.SendAndConfirm(
 		ctx,
+		txKindExit,
 		BuilderExitContractAddress,
 		fee,
 		calldata,
@@ -0,0 +1,75 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// ErrExitBlocked is returned by InitiateExit while the builder has local
+// obligations outstanding: won bids not revealed yet or bids still in play.
+// An exit then races the payments they owe; force overrides the interlock.
+var ErrExitBlocked = errors.New("builder exit blocked by outstanding obligations")
+
+// ExitBlockers lists the local obligations that block a builder exit.
+type ExitBlockers struct {
+	// PendingPaymentSlots are won bids whose payload is not revealed yet
+	// (payment ledger), owing PendingPaymentsGwei in total.
+	PendingPaymentSlots []uint64 `json:"pending_payment_slots"`
+	PendingPaymentsGwei uint64   `json:"pending_payments_gwei"`
+	// OutstandingBidSlots are slots from the current one on that carry a bid
+	// of ours: committed, outcome not known yet.
+	OutstandingBidSlots []uint64 `json:"outstanding_bid_slots"`
+}
+
+// Blocked reports whether any obligation is outstanding.
+func (b *ExitBlockers) Blocked() bool {
+	return len(b.PendingPaymentSlots) > 0 || len(b.OutstandingBidSlots) > 0
+}
+
+// String summarizes the obligations for errors and events.
+func (b *ExitBlockers) String() string {
+	parts := make([]string, 0, 2)
+
+	if len(b.PendingPaymentSlots) > 0 {
+		parts = append(parts, fmt.Sprintf("%d unrevealed won bid(s) owing %d gwei (slots %v)",
+			len(b.PendingPaymentSlots), b.PendingPaymentsGwei, b.PendingPaymentSlots))
+	}
+
+	if len(b.OutstandingBidSlots) > 0 {
+		parts = append(parts, fmt.Sprintf("bids outstanding for slots %v", b.OutstandingBidSlots))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// SetOutstandingBidsFunc sets the source of slots with bids of ours still in
+// play (the p2p bidder), consulted by the exit interlock.
+func (m *Manager) SetOutstandingBidsFunc(fn func() []phase0.Slot) {
+	m.outstandingBids = fn
+}
+
+// ExitBlockers collects the local obligations that block an exit.
+func (m *Manager) ExitBlockers() *ExitBlockers {
+	blockers := &ExitBlockers{
+		PendingPaymentSlots: []uint64{},
+		OutstandingBidSlots: []uint64{},
+	}
+
+	if tracker := m.GetPaymentTracker(); tracker != nil {
+		for _, payment := range tracker.GetPendingPayments() {
+			blockers.PendingPaymentSlots = append(blockers.PendingPaymentSlots, uint64(payment.Slot))
+			blockers.PendingPaymentsGwei += payment.Value
+		}
+	}
+
+	if m.outstandingBids != nil {
+		for _, slot := range m.outstandingBids() {
+			blockers.OutstandingBidSlots = append(blockers.OutstandingBidSlots, uint64(slot))
+		}
+	}
+
+	return blockers
+}
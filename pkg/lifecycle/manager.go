@@ -32,10 +32,26 @@ const earlyOnboardFinalizationMargin = 4
 // and let the normal post-fork builder deposit register the builder instead.
 const minEarlyOnboardSlots uint64 = 10
 
+// Wallet transaction kinds (wallet.TxRecord.Kind).
+const (
+	txKindDeposit      = "deposit"
+	txKindTopup        = "topup"
+	txKindBatchTopup   = "batch_topup"
+	txKindExit         = "exit"
+	txKindEarlyDeposit = "early_deposit"
+)
+
+// ErrReadOnly is returned by deposit, top-up and exit requests in read-only
+// mode (--lifecycle-read-only): the builder was registered out of band and is
+// only tracked, never funded or exited by buildoor.
+var ErrReadOnly = errors.New("lifecycle is read-only: deposits, top-ups and exits are disabled")
+
 // LifecycleEvent represents a lifecycle action for UI logging.
 type LifecycleEvent struct {
 	Action  string // "deposit", "topup", "exit", "state_change", "waiting_gloas", "balance_topup"
-	Message string // Human-readable description
+	Code    string // Machine code of the event, e.g. "deposit_failed"
+	Params  map[string]any
+	Message string // Human-readable description (English rendering of Code and Params)
 	Status  string // "info", "success", "warning", "error"
 }
 
@@ -44,7 +60,7 @@ type Manager struct {
 	cfg             *config.Config
 	clClient        *beacon.Client
 	chainSvc        chain.Service
-	signer          *signer.BLSSigner
+	signer          signer.Signer
 	wallet          *wallet.Wallet
 	builderState    *BuilderState
 	stateMu         sync.RWMutex
@@ -57,8 +73,13 @@ type Manager struct {
 	stopCh          chan struct{}
 	wg              sync.WaitGroup
 
+	// readOnly tracks an out-of-band registration: no deposit, early
+	// deposit or exit services exist and the wallet may be nil.
+	readOnly bool
+
 	registrationCallback   func(index uint64)
 	depositPendingCallback func()
+	outstandingBids        func() []phase0.Slot
 	registrationDone       atomic.Bool
 	enabled                atomic.Bool
 	// exitNoticed dedupes the exited-builder warning event; re-armed when the
@@ -67,30 +88,25 @@ type Manager struct {
 	eventCallback func(*LifecycleEvent)
 }
 
-// NewManager creates a new lifecycle manager.
+// NewManager creates a new lifecycle manager. With cfg.LifecycleReadOnly the
+// manager only tracks the builder's registration, balance and pending
+// payments; w may then be nil.
 func NewManager(
 	cfg *config.Config,
 	clClient *beacon.Client,
 	chainSvc chain.Service,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	w *wallet.Wallet,
 	log logrus.FieldLogger,
 ) (*Manager, error) {
-	managerLog := log.WithField("component", "lifecycle-manager")
-
-	m := &Manager{
-		cfg:          cfg,
-		clClient:     clClient,
-		chainSvc:     chainSvc,
-		signer:       blsSigner,
-		wallet:       w,
-		builderState: &BuilderState{},
-		log:          managerLog,
-		stopCh:       make(chan struct{}),
+	if cfg.LifecycleReadOnly {
+		return NewReadOnlyManager(cfg, clClient, chainSvc, blsSigner, w, log), nil
 	}
 
+	m := newManager(cfg, clClient, chainSvc, blsSigner, w, log)
+
 	// Initialize services
-	depositSvc, err := NewDepositService(cfg, chainSvc, blsSigner, w, managerLog)
+	depositSvc, err := NewDepositService(cfg, chainSvc, blsSigner, w, m.log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create deposit service: %w", err)
 	}
@@ -99,7 +115,7 @@ func NewManager(
 
 	// Early deposit service (regular validator deposit contract, used to onboard the
 	// builder before the Gloas fork so there is no Builder-API-to-Gloas coverage gap).
-	earlyDepositSvc, err := NewEarlyDepositService(cfg, chainSvc, blsSigner, w, managerLog)
+	earlyDepositSvc, err := NewEarlyDepositService(cfg, chainSvc, blsSigner, w, m.log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create early deposit service: %w", err)
 	}
@@ -107,16 +123,61 @@ func NewManager(
 	m.earlyDepositSvc = earlyDepositSvc
 
 	// Exit service (builder exit system contract, sent from the funding wallet)
-	m.exitSvc = NewExitService(chainSvc, blsSigner, w, managerLog)
+	m.exitSvc = NewExitService(chainSvc, blsSigner, w, m.log)
 
 	return m, nil
 }
 
+// NewReadOnlyManager creates a lifecycle manager that only tracks the
+// builder's registration, balance and pending payments (whatever
+// cfg.LifecycleReadOnly says); w may be nil.
+func NewReadOnlyManager(
+	cfg *config.Config,
+	clClient *beacon.Client,
+	chainSvc chain.Service,
+	blsSigner signer.Signer,
+	w *wallet.Wallet,
+	log logrus.FieldLogger,
+) *Manager {
+	m := newManager(cfg, clClient, chainSvc, blsSigner, w, log)
+	m.readOnly = true
+
+	m.log.Info("Lifecycle manager in read-only mode: tracking the existing builder registration only")
+
+	return m
+}
+
+// newManager creates the manager state shared by both modes.
+func newManager(
+	cfg *config.Config,
+	clClient *beacon.Client,
+	chainSvc chain.Service,
+	blsSigner signer.Signer,
+	w *wallet.Wallet,
+	log logrus.FieldLogger,
+) *Manager {
+	return &Manager{
+		cfg:          cfg,
+		clClient:     clClient,
+		chainSvc:     chainSvc,
+		signer:       blsSigner,
+		wallet:       w,
+		builderState: &BuilderState{},
+		log:          log.WithField("component", "lifecycle-manager"),
+		stopCh:       make(chan struct{}),
+	}
+}
+
 // SetEnabled sets whether the lifecycle manager is actively managing the builder.
 func (m *Manager) SetEnabled(enabled bool) {
 	m.enabled.Store(enabled)
 }
 
+// IsReadOnly returns whether the manager only tracks an existing registration.
+func (m *Manager) IsReadOnly() bool {
+	return m.readOnly
+}
+
 // IsEnabled returns whether the lifecycle manager is enabled.
 func (m *Manager) IsEnabled() bool {
 	return m.enabled.Load()
@@ -138,10 +199,14 @@ func (m *Manager) SetEventCallback(cb func(*LifecycleEvent)) {
 }
 
 // fireEvent sends a lifecycle event to the UI if a callback is registered.
-func (m *Manager) fireEvent(action, message, status string) {
+// params carries the raw values the message renders (gwei amounts, indices,
+// epochs, errors), so consumers need not parse the message.
+func (m *Manager) fireEvent(action, code, status string, params map[string]any, message string) {
 	if m.eventCallback != nil {
 		m.eventCallback(&LifecycleEvent{
 			Action:  action,
+			Code:    code,
+			Params:  params,
 			Message: message,
 			Status:  status,
 		})
@@ -185,6 +250,10 @@ func (m *Manager) GetWallet() *wallet.Wallet {
 // EnsureBuilderRegistered checks if builder is registered and deposits if needed.
 // This is the synchronous version used by CLI commands (e.g. cmd/deposit.go).
 func (m *Manager) EnsureBuilderRegistered(ctx context.Context) error {
+	if m.readOnly {
+		return ErrReadOnly
+	}
+
 	isRegistered, state, err := m.depositSvc.IsBuilderRegistered(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to check builder registration: %w", err)
@@ -200,7 +269,9 @@ func (m *Manager) EnsureBuilderRegistered(ctx context.Context) error {
 			"balance":       state.Balance,
 		}).Info("Builder already registered")
 
-		m.fireEvent("state_change", fmt.Sprintf("Builder already registered (index: %d, balance: %d gwei)", state.Index, state.Balance), "info")
+		m.fireEvent("state_change", "already_registered", "info",
+			map[string]any{"builder_index": state.Index, "balance_gwei": state.Balance},
+			fmt.Sprintf("Builder already registered (index: %d, balance: %d gwei)", state.Index, state.Balance))
 		m.onRegistered(state.Index)
 
 		if state.WithdrawableEpoch != chain.FarFutureEpoch {
@@ -211,7 +282,8 @@ func (m *Manager) EnsureBuilderRegistered(ctx context.Context) error {
 	}
 
 	m.log.Info("Builder not registered, creating deposit")
-	m.fireEvent("deposit", fmt.Sprintf("Builder not registered, submitting deposit (%d gwei)", m.cfg.DepositAmount), "info")
+	m.fireEvent("deposit", "deposit_submitting", "info", map[string]any{"amount_gwei": m.cfg.DepositAmount},
+		fmt.Sprintf("Builder not registered, submitting deposit (%d gwei)", m.cfg.DepositAmount))
 
 	if m.depositPendingCallback != nil {
 		m.depositPendingCallback()
@@ -220,15 +292,18 @@ func (m *Manager) EnsureBuilderRegistered(ctx context.Context) error {
 	if err := m.depositSvc.CreateDeposit(ctx, m.cfg.DepositAmount); err != nil {
 		if isDepositDeferred(err) {
 			// Fee too high or contract not active yet — delay, don't treat as failure.
-			m.fireEvent("deposit", fmt.Sprintf("Deposit deferred: %v", err), "info")
+			m.fireEvent("deposit", "deposit_deferred", "info", map[string]any{"error": err.Error()},
+				fmt.Sprintf("Deposit deferred: %v", err))
 		} else {
-			m.fireEvent("deposit", fmt.Sprintf("Deposit failed: %v", err), "error")
+			m.fireEvent("deposit", "deposit_failed", "error", map[string]any{"error": err.Error()},
+				fmt.Sprintf("Deposit failed: %v", err))
 		}
 
 		return fmt.Errorf("failed to create deposit: %w", err)
 	}
 
-	m.fireEvent("deposit", "Deposit transaction confirmed, waiting for beacon chain inclusion", "success")
+	m.fireEvent("deposit", "deposit_confirmed", "success", nil,
+		"Deposit transaction confirmed, waiting for beacon chain inclusion")
 
 	// Wait for registration
 	return m.WaitForRegistration(ctx, 5*time.Minute)
@@ -236,6 +311,10 @@ func (m *Manager) EnsureBuilderRegistered(ctx context.Context) error {
 
 // CheckAndTopup checks balance and tops up if needed.
 func (m *Manager) CheckAndTopup(ctx context.Context) error {
+	if m.readOnly {
+		return ErrReadOnly
+	}
+
 	if m.balanceSvc == nil {
 		return nil
 	}
@@ -244,7 +323,13 @@ func (m *Manager) CheckAndTopup(ctx context.Context) error {
 }
 
 // InitiateExit submits a builder exit request via the builder exit system contract.
-func (m *Manager) InitiateExit(ctx context.Context) error {
+// It refuses with ErrExitBlocked while won bids are unrevealed or bids are
+// still in play (see ExitBlockers); force submits anyway, with a warning.
+func (m *Manager) InitiateExit(ctx context.Context, force bool) error {
+	if m.readOnly {
+		return ErrReadOnly
+	}
+
 	m.stateMu.RLock()
 	builderIndex := m.builderState.Index
 	isRegistered := m.builderState.IsRegistered
@@ -271,19 +356,93 @@ func (m *Manager) InitiateExit(ctx context.Context) error {
 		return fmt.Errorf("builder has %d gwei in pending payments; the exit request would be ignored on chain — retry after they settle", info.PendingPayments)
 	}
 
-	m.fireEvent("exit", fmt.Sprintf("Submitting builder exit for builder index %d", builderIndex), "info")
+	if blockers := m.ExitBlockers(); blockers.Blocked() {
+		if !force {
+			return fmt.Errorf("%w: %s; retry once they settle or force the exit", ErrExitBlocked, blockers)
+		}
+
+		m.log.WithField("blockers", blockers.String()).Warn("Forcing builder exit despite outstanding obligations")
+		m.fireEvent("exit", "exit_forced", "warning", map[string]any{"blockers": blockers},
+			fmt.Sprintf("Forcing exit despite %s", blockers))
+	}
+
+	m.fireEvent("exit", "exit_submitting", "info", map[string]any{"builder_index": builderIndex},
+		fmt.Sprintf("Submitting builder exit for builder index %d", builderIndex))
 
 	if err := m.exitSvc.CreateExit(ctx); err != nil {
-		m.fireEvent("exit", fmt.Sprintf("Exit failed: %v", err), "error")
+		m.fireEvent("exit", "exit_failed", "error", map[string]any{"error": err.Error()},
+			fmt.Sprintf("Exit failed: %v", err))
 
 		return err
 	}
 
-	m.fireEvent("exit", fmt.Sprintf("Builder exit submitted for builder index %d", builderIndex), "success")
+	m.fireEvent("exit", "exit_submitted", "success", map[string]any{"builder_index": builderIndex},
+		fmt.Sprintf("Builder exit submitted for builder index %d", builderIndex))
 
 	return nil
 }
 
+// PreviewDeposit estimates the cost and effect of depositing amountsGwei
+// without sending anything. Without amounts it previews the configured
+// deposit (unregistered builder) or top-up amount.
+func (m *Manager) PreviewDeposit(ctx context.Context, amountsGwei []uint64) (*DepositPreview, error) {
+	if m.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	if len(amountsGwei) == 0 {
+		amountsGwei = []uint64{m.defaultDepositAmount()}
+	}
+
+	return m.depositSvc.Preview(ctx, amountsGwei)
+}
+
+// Topup sends top-ups of amountsGwei, in one transaction when a batching
+// contract is configured.
+func (m *Manager) Topup(ctx context.Context, amountsGwei []uint64) error {
+	if m.readOnly {
+		return ErrReadOnly
+	}
+
+	var total uint64
+	for _, amount := range amountsGwei {
+		total += amount
+	}
+
+	m.fireEvent("topup", "topup_submitting", "info", map[string]any{"count": len(amountsGwei), "amount_gwei": total},
+		fmt.Sprintf("Submitting %d top-up(s) totalling %d gwei", len(amountsGwei), total))
+
+	if err := m.depositSvc.CreateTopups(ctx, amountsGwei); err != nil {
+		m.fireEvent("topup", "topup_failed", "error", map[string]any{"error": err.Error()},
+			fmt.Sprintf("Top-up failed: %v", err))
+
+		return err
+	}
+
+	if tracker := m.GetPaymentTracker(); tracker != nil {
+		tracker.AddDeposit(total)
+	}
+
+	m.fireEvent("topup", "topup_confirmed", "success", map[string]any{"amount_gwei": total},
+		fmt.Sprintf("Top-up of %d gwei confirmed", total))
+
+	return nil
+}
+
+// defaultDepositAmount is the registration deposit for an unregistered
+// builder, else the top-up amount.
+func (m *Manager) defaultDepositAmount() uint64 {
+	if m.chainSvc.GetBuilderByPubkey(m.signer.PublicKey()) == nil {
+		return m.cfg.DepositAmount
+	}
+
+	if m.cfg.TopupAmount > 0 {
+		return m.cfg.TopupAmount
+	}
+
+	return m.cfg.TopupThreshold
+}
+
 // WaitForRegistration waits for the builder to be registered.
 func (m *Manager) WaitForRegistration(ctx context.Context, timeout time.Duration) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
@@ -323,7 +482,9 @@ func (m *Manager) WaitForRegistration(ctx context.Context, timeout time.Duration
 				m.stateMu.Unlock()
 
 				m.log.WithField("builder_index", info.Index).Info("Builder registered")
-				m.fireEvent("state_change", fmt.Sprintf("Builder registered on beacon chain (index: %d, deposit epoch: %d)", info.Index, info.DepositEpoch), "success")
+				m.fireEvent("state_change", "registered", "success",
+					map[string]any{"builder_index": info.Index, "deposit_epoch": info.DepositEpoch},
+					fmt.Sprintf("Builder registered on beacon chain (index: %d, deposit epoch: %d)", info.Index, info.DepositEpoch))
 				m.onRegistered(info.Index)
 
 				return nil
@@ -333,10 +494,13 @@ func (m *Manager) WaitForRegistration(ctx context.Context, timeout time.Duration
 }
 
 // SetPaymentTracker sets the shared payment tracker for the balance service and
-// stores it for direct access.
+// stores it for direct access. Read-only managers get no balance service.
 func (m *Manager) SetPaymentTracker(payments *payload_bidder.PaymentTracker) {
 	m.payments = payments
-	m.balanceSvc = NewBalanceService(m.cfg, m.clClient, m.depositSvc, payments, m.log)
+
+	if !m.readOnly {
+		m.balanceSvc = NewBalanceService(m.cfg, m.clClient, m.depositSvc, payments, m.log)
+	}
 }
 
 // GetPaymentTracker returns the shared payment tracker.
@@ -358,6 +522,17 @@ func (m *Manager) onRegistered(index uint64) {
 func (m *Manager) runRegistrationAndMonitor(ctx context.Context) {
 	defer m.wg.Done()
 
+	// Read-only: track the out-of-band registration regardless of the
+	// enabled flag; the balance monitor never tops up without a balance
+	// service.
+	if m.readOnly {
+		if m.waitForGloasState(ctx) && m.waitForImportedRegistration(ctx) {
+			m.runBalanceMonitor(ctx)
+		}
+
+		return
+	}
+
 	// Wait until enabled before doing anything
 	if !m.waitForEnabled(ctx) {
 		return
@@ -385,6 +560,60 @@ func (m *Manager) runRegistrationAndMonitor(ctx context.Context) {
 	m.runBalanceMonitor(ctx)
 }
 
+// waitForImportedRegistration waits (read-only mode) until the builder
+// registered out of band shows up in the beacon state, checking every epoch
+// state. It returns false when stopped.
+func (m *Manager) waitForImportedRegistration(ctx context.Context) bool {
+	epochSub := m.chainSvc.SubscribeEpochStats()
+	defer epochSub.Unsubscribe()
+
+	if m.importRegistration() {
+		return true
+	}
+
+	m.log.Info("Read-only lifecycle: builder not in the beacon state yet, waiting for its registration")
+	m.fireEvent("state_change", "read_only_waiting", "info", nil,
+		"Read-only lifecycle: waiting for the builder to appear in the beacon state")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-m.stopCh:
+			return false
+		case _, ok := <-epochSub.Channel():
+			if !ok {
+				return false
+			}
+
+			if m.importRegistration() {
+				return true
+			}
+		}
+	}
+}
+
+// importRegistration adopts the builder's beacon state record when present.
+func (m *Manager) importRegistration() bool {
+	info := m.chainSvc.GetBuilderByPubkey(m.signer.PublicKey())
+	if info == nil {
+		return false
+	}
+
+	m.refreshBuilderState()
+
+	m.log.WithFields(logrus.Fields{
+		"builder_index": info.Index,
+		"balance":       info.Balance,
+	}).Info("Imported existing builder registration")
+	m.fireEvent("state_change", "registration_imported", "success",
+		map[string]any{"builder_index": info.Index, "balance_gwei": info.Balance},
+		fmt.Sprintf("Imported existing builder registration (index: %d, balance: %d gwei)", info.Index, info.Balance))
+	m.onRegistered(info.Index)
+
+	return true
+}
+
 // waitForEnabled waits until the manager is enabled or stopped.
 func (m *Manager) waitForEnabled(ctx context.Context) bool {
 	if m.enabled.Load() {
@@ -405,7 +634,7 @@ func (m *Manager) waitForEnabled(ctx context.Context) bool {
 		case <-ticker.C:
 			if m.enabled.Load() {
 				m.log.Info("Lifecycle manager enabled")
-				m.fireEvent("state_change", "Lifecycle management enabled", "success")
+				m.fireEvent("state_change", "lifecycle_enabled", "success", nil, "Lifecycle management enabled")
 
 				return true
 			}
@@ -443,7 +672,8 @@ func (m *Manager) maybeEarlyOnboard(ctx context.Context) {
 	forkEpoch := spec.GetForkEpoch(version.DataVersionGloas)
 
 	m.log.WithField("gloas_fork_epoch", forkEpoch).Info("Gloas scheduled, evaluating early builder onboarding")
-	m.fireEvent("early_onboard", fmt.Sprintf("Gloas fork at epoch %d, preparing early builder onboarding", forkEpoch), "info")
+	m.fireEvent("early_onboard", "early_onboard_preparing", "info", map[string]any{"fork_epoch": forkEpoch},
+		fmt.Sprintf("Gloas fork at epoch %d, preparing early builder onboarding", forkEpoch))
 
 	// Subscribe before the first evaluation so an epoch transition can't slip through
 	// between a "wait" decision and the subscription.
@@ -493,7 +723,8 @@ func (m *Manager) tryEarlyOnboardOnce(ctx context.Context, forkEpoch phase0.Epoc
 	// just wait for the fork transition to convert it into a builder.
 	if m.earlyDepositSvc.HasPendingDeposit() {
 		m.log.Info("Early builder deposit already pending, waiting for registration")
-		m.fireEvent("early_onboard", "Early deposit already pending, waiting for registration", "info")
+		m.fireEvent("early_onboard", "early_deposit_pending", "info", nil,
+			"Early deposit already pending, waiting for registration")
 		m.waitForEarlyRegistration(ctx, forkEpoch, currentEpoch)
 
 		return true
@@ -513,7 +744,8 @@ func (m *Manager) tryEarlyOnboardOnce(ctx context.Context, forkEpoch phase0.Epoc
 			"fork_epoch":       forkEpoch,
 			"slots_until_fork": slotsUntilFork,
 		}).Info("Fewer than minimum slots until Gloas, skipping early onboarding (will deposit via builder contract after the fork)")
-		m.fireEvent("early_onboard", fmt.Sprintf("Only %d slots until Gloas, skipping early onboarding; will deposit after the fork", slotsUntilFork), "info")
+		m.fireEvent("early_onboard", "early_onboard_skipped", "info", map[string]any{"slots_until_fork": slotsUntilFork},
+			fmt.Sprintf("Only %d slots until Gloas, skipping early onboarding; will deposit after the fork", slotsUntilFork))
 
 		return true
 	}
@@ -541,7 +773,9 @@ func (m *Manager) tryEarlyOnboardOnce(ctx context.Context, forkEpoch phase0.Epoc
 		return false
 	}
 
-	m.fireEvent("early_onboard", fmt.Sprintf("Submitting early onboarding deposit (%d gwei, %d slots before fork)", amount, slotsUntilFork), "info")
+	m.fireEvent("early_onboard", "early_deposit_submitting", "info",
+		map[string]any{"amount_gwei": amount, "slots_until_fork": slotsUntilFork},
+		fmt.Sprintf("Submitting early onboarding deposit (%d gwei, %d slots before fork)", amount, slotsUntilFork))
 
 	if m.depositPendingCallback != nil {
 		m.depositPendingCallback()
@@ -549,12 +783,14 @@ func (m *Manager) tryEarlyOnboardOnce(ctx context.Context, forkEpoch phase0.Epoc
 
 	if err := m.earlyDepositSvc.CreateEarlyDeposit(ctx, amount); err != nil {
 		m.log.WithError(err).Warn("Early onboarding deposit failed, retrying next epoch")
-		m.fireEvent("early_onboard", fmt.Sprintf("Early deposit failed: %v, retrying", err), "warning")
+		m.fireEvent("early_onboard", "early_deposit_failed", "warning", map[string]any{"error": err.Error()},
+			fmt.Sprintf("Early deposit failed: %v, retrying", err))
 
 		return false // retry on the next epoch
 	}
 
-	m.fireEvent("early_onboard", "Early deposit confirmed, waiting for fork transition and registration", "success")
+	m.fireEvent("early_onboard", "early_deposit_confirmed", "success", nil,
+		"Early deposit confirmed, waiting for fork transition and registration")
 	m.waitForEarlyRegistration(ctx, forkEpoch, currentEpoch)
 
 	return true
@@ -573,7 +809,8 @@ func (m *Manager) waitForEarlyRegistration(ctx context.Context, forkEpoch, curre
 
 	if err := m.WaitForRegistration(ctx, timeout); err != nil {
 		m.log.WithError(err).Warn("Builder not registered after early deposit; normal post-fork flow will retry")
-		m.fireEvent("early_onboard", "Builder not yet registered after early deposit; post-fork flow will retry", "warning")
+		m.fireEvent("early_onboard", "early_registration_missing", "warning", nil,
+			"Builder not yet registered after early deposit; post-fork flow will retry")
 	}
 }
 
@@ -592,7 +829,7 @@ func (m *Manager) waitForGloasState(ctx context.Context) bool {
 	}
 
 	m.log.Info("Waiting for first Gloas beacon state before builder registration")
-	m.fireEvent("waiting_gloas", "Waiting for Gloas state before builder registration", "info")
+	m.fireEvent("waiting_gloas", "waiting_gloas", "info", nil, "Waiting for Gloas state before builder registration")
 
 	for {
 		select {
@@ -607,7 +844,8 @@ func (m *Manager) waitForGloasState(ctx context.Context) bool {
 
 			if stats.Version >= version.DataVersionGloas {
 				m.log.Info("Gloas state loaded, proceeding with builder registration")
-				m.fireEvent("state_change", "Gloas state loaded, proceeding with builder registration", "success")
+				m.fireEvent("state_change", "gloas_state_loaded", "success", nil,
+					"Gloas state loaded, proceeding with builder registration")
 
 				return true
 			}
@@ -636,7 +874,8 @@ func (m *Manager) ensureRegisteredWithRetry(ctx context.Context) {
 			m.log.WithError(err).Info("Builder registration deferred, retrying in 30s")
 		} else {
 			m.log.WithError(err).Warn("Builder registration attempt failed, retrying in 30s")
-			m.fireEvent("deposit", fmt.Sprintf("Registration attempt failed: %v, retrying in 30s", err), "warning")
+			m.fireEvent("deposit", "registration_failed", "warning", map[string]any{"error": err.Error()},
+				fmt.Sprintf("Registration attempt failed: %v, retrying in 30s", err))
 		}
 
 		select {
@@ -683,17 +922,20 @@ func (m *Manager) runBalanceMonitor(ctx context.Context) {
 			}
 
 			if needsTopup {
-				m.fireEvent("balance_topup", fmt.Sprintf("Balance below threshold, topping up %d gwei", amount), "info")
+				m.fireEvent("balance_topup", "balance_topup_submitting", "info", map[string]any{"amount_gwei": amount},
+					fmt.Sprintf("Balance below threshold, topping up %d gwei", amount))
 
 				if err := m.balanceSvc.CheckAndTopup(ctx); err != nil {
 					if isDepositDeferred(err) {
 						// Queue fee too high or contract not active — delay this top-up
 						// to the next monitor tick instead of failing.
 						m.log.WithError(err).Info("Balance top-up deferred")
-						m.fireEvent("balance_topup", fmt.Sprintf("Top-up deferred: %v", err), "info")
+						m.fireEvent("balance_topup", "balance_topup_deferred", "info", map[string]any{"error": err.Error()},
+							fmt.Sprintf("Top-up deferred: %v", err))
 					} else {
 						m.log.WithError(err).Warn("Balance topup failed")
-						m.fireEvent("balance_topup", fmt.Sprintf("Balance topup failed: %v", err), "error")
+						m.fireEvent("balance_topup", "balance_topup_failed", "error", map[string]any{"error": err.Error()},
+							fmt.Sprintf("Balance topup failed: %v", err))
 					}
 				} else {
 					// Immediately reflect the topup in the live balance (no finalization delay)
@@ -701,7 +943,8 @@ func (m *Manager) runBalanceMonitor(ctx context.Context) {
 						tracker.AddDeposit(amount)
 					}
 
-					m.fireEvent("balance_topup", fmt.Sprintf("Balance topped up by %d gwei", amount), "success")
+					m.fireEvent("balance_topup", "balance_topup_confirmed", "success", map[string]any{"amount_gwei": amount},
+						fmt.Sprintf("Balance topped up by %d gwei", amount))
 				}
 			}
 		}
@@ -718,11 +961,10 @@ func (m *Manager) noticeExitOnce(withdrawableEpoch uint64) {
 
 	m.log.WithField("withdrawable_epoch", withdrawableEpoch).
 		Warn("Builder exit initiated; top-ups disabled (an exited builder cannot be reactivated)")
-	m.fireEvent("state_change",
+	m.fireEvent("state_change", "exit_initiated", "warning", map[string]any{"withdrawable_epoch": withdrawableEpoch},
 		fmt.Sprintf(
 			"Builder exit initiated (withdrawable epoch %d). The key cannot be reactivated — deposits would be withdrawn back to the wallet, so top-ups are disabled until the pubkey leaves the builder set.",
-			withdrawableEpoch),
-		"warning")
+			withdrawableEpoch))
 }
 
 // refreshBuilderState updates the cached builder state from the chain service.
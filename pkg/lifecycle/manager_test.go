@@ -0,0 +1,63 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func TestReadOnlyManagerRefusesActions(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.LifecycleReadOnly = true
+
+	// Read-only needs no wallet: no deposit or exit services are created.
+	m, err := NewManager(cfg, nil, nil, nil, nil, logrus.New())
+	require.NoError(t, err)
+	assert.True(t, m.IsReadOnly())
+
+	ctx := context.Background()
+	assert.ErrorIs(t, m.EnsureBuilderRegistered(ctx), ErrReadOnly)
+	assert.ErrorIs(t, m.CheckAndTopup(ctx), ErrReadOnly)
+	assert.ErrorIs(t, m.InitiateExit(ctx, false), ErrReadOnly)
+
+	m.SetPaymentTracker(nil)
+	assert.Nil(t, m.balanceSvc)
+}
+
+func TestExitBlockers(t *testing.T) {
+	m := &Manager{}
+
+	blockers := m.ExitBlockers()
+	assert.False(t, blockers.Blocked(), "no tracker and no bid source: nothing outstanding")
+
+	m.SetOutstandingBidsFunc(func() []phase0.Slot { return []phase0.Slot{40, 41} })
+
+	blockers = m.ExitBlockers()
+	assert.True(t, blockers.Blocked())
+	assert.Equal(t, []uint64{40, 41}, blockers.OutstandingBidSlots)
+	assert.Contains(t, blockers.String(), "bids outstanding for slots [40 41]")
+}
+
+func TestLifecycleEventCarriesCodeAndParams(t *testing.T) {
+	m := &Manager{log: logrus.New()}
+
+	var events []*LifecycleEvent
+
+	m.SetEventCallback(func(event *LifecycleEvent) { events = append(events, event) })
+
+	m.noticeExitOnce(1234)
+	m.noticeExitOnce(1234)
+
+	require.Len(t, events, 1, "the exit notice fires once")
+	assert.Equal(t, "state_change", events[0].Action)
+	assert.Equal(t, "exit_initiated", events[0].Code)
+	assert.Equal(t, map[string]any{"withdrawable_epoch": uint64(1234)}, events[0].Params)
+	assert.Contains(t, events[0].Message, "withdrawable epoch 1234")
+	assert.Equal(t, "warning", events[0].Status)
+}
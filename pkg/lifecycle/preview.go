@@ -0,0 +1,212 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+)
+
+// builderActivationLagEpochs estimates how many epochs after inclusion a new
+// builder becomes active: is_active_builder requires deposit_epoch < the
+// finalized epoch, which under normal finality trails the current epoch by 2.
+const builderActivationLagEpochs = 3
+
+// DepositPreview estimates the cost and effect of a deposit or top-up
+// without sending anything. Wei amounts are decimal strings.
+type DepositPreview struct {
+	Kind            string   `json:"kind"` // "deposit" (registration) or "topup"
+	AmountsGwei     []uint64 `json:"amounts_gwei"`
+	TotalAmountGwei uint64   `json:"total_amount_gwei"`
+	// Batched is set when the amounts go out as one transaction through the
+	// configured batching contract.
+	Batched        bool   `json:"batched"`
+	BatchContract  string `json:"batch_contract,omitempty"`
+	Transactions   int    `json:"transactions"`
+	ContractActive bool   `json:"contract_active"`
+	QueueFeeWei    string `json:"queue_fee_wei,omitempty"` // per request
+	// QueueFeeTooHigh is set when the queue fee exceeds --deposit-max-fee:
+	// the send would be delayed until it drops.
+	QueueFeeTooHigh     bool   `json:"queue_fee_too_high"`
+	GasLimit            uint64 `json:"gas_limit"`     // summed over the transactions
+	EstimatedGas        uint64 `json:"estimated_gas"` // summed; the gas limit when estimation failed
+	GasPriceWei         string `json:"gas_price_wei"` // base fee + priority fee
+	EstimatedGasCostWei string `json:"estimated_gas_cost_wei"`
+	// TotalCostWei is stake + queue fees + estimated gas cost.
+	TotalCostWei         string   `json:"total_cost_wei"`
+	WalletBalanceWei     string   `json:"wallet_balance_wei"`
+	SufficientFunds      bool     `json:"sufficient_funds"`
+	CurrentBalanceGwei   uint64   `json:"current_balance_gwei"`
+	ResultingBalanceGwei uint64   `json:"resulting_balance_gwei"`
+	CurrentEpoch         uint64   `json:"current_epoch"`
+	ActivationEpoch      uint64   `json:"activation_epoch,omitempty"` // estimate, registrations only
+	Warnings             []string `json:"warnings,omitempty"`
+}
+
+// Preview estimates the deposits of amountsGwei: one deposit when the
+// builder is not registered yet, top-ups otherwise. Gas is estimated with a
+// placeholder signature (the contracts do not verify it), so nothing is
+// signed. Failures to read a figure become warnings, not errors.
+func (s *DepositService) Preview(ctx context.Context, amountsGwei []uint64) (*DepositPreview, error) {
+	if len(amountsGwei) == 0 {
+		return nil, fmt.Errorf("no deposit amounts")
+	}
+
+	pubkey := s.signer.PublicKey()
+	info := s.chainSvc.GetBuilderByPubkey(pubkey)
+	rpc := s.wallet.GetRPCClient()
+	currentEpoch := uint64(s.chainSvc.GetCurrentEpoch())
+
+	preview := &DepositPreview{
+		Kind:         "topup",
+		AmountsGwei:  amountsGwei,
+		CurrentEpoch: currentEpoch,
+	}
+
+	if info == nil {
+		preview.Kind = "deposit"
+		preview.ActivationEpoch = currentEpoch + builderActivationLagEpochs
+	} else {
+		preview.CurrentBalanceGwei = info.Balance
+	}
+
+	for _, amount := range amountsGwei {
+		preview.TotalAmountGwei += amount
+	}
+
+	preview.ResultingBalanceGwei = preview.CurrentBalanceGwei + preview.TotalAmountGwei
+
+	if chain.HasBuilderExited(info) {
+		preview.Warnings = append(preview.Warnings, ErrBuilderExited.Error())
+	}
+
+	// Transactions: one batch, or one per amount.
+	to := BuilderDepositContractAddress
+	calldatas := make([][]byte, 0, len(amountsGwei))
+	values := make([]*big.Int, 0, len(amountsGwei))
+	withdrawalCredentials := BuilderWithdrawalCredentials(s.wallet.Address())
+
+	for _, amount := range amountsGwei {
+		request, err := BuildBuilderDepositCalldata(pubkey[:], withdrawalCredentials[:], amount, make([]byte, 96))
+		if err != nil {
+			return nil, err
+		}
+
+		calldatas = append(calldatas, request)
+		values = append(values, GweiToWei(amount))
+	}
+
+	requestsPerTx := 1
+
+	if batchContract := s.batchContract(); batchContract != nil && len(amountsGwei) > 1 {
+		batch, err := BuildBatchDepositCalldata(calldatas)
+		if err != nil {
+			return nil, err
+		}
+
+		total := GweiToWei(preview.TotalAmountGwei)
+		to = *batchContract
+		calldatas = [][]byte{batch}
+		values = []*big.Int{total}
+		requestsPerTx = len(amountsGwei)
+		preview.Batched = true
+		preview.BatchContract = batchContract.Hex()
+	}
+
+	preview.Transactions = len(calldatas)
+	preview.GasLimit = depositGasLimit * uint64(len(amountsGwei))
+
+	// Queue fee, per request.
+	fee := new(big.Int)
+
+	queueFee, active, err := ReadQueueFee(ctx, rpc, BuilderDepositContractAddress)
+
+	switch {
+	case err != nil:
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("queue fee unavailable: %v", err))
+	case !active:
+		preview.Warnings = append(preview.Warnings, ErrContractNotActive.Error())
+	default:
+		fee = queueFee
+		preview.ContractActive = true
+		preview.QueueFeeWei = fee.String()
+
+		if maxFee := s.cfg.DepositMaxFeeGwei; maxFee > 0 && fee.Cmp(GweiToWei(maxFee)) > 0 {
+			preview.QueueFeeTooHigh = true
+			preview.Warnings = append(preview.Warnings,
+				fmt.Sprintf("queue fee %s wei exceeds --deposit-max-fee %d gwei; the send would be delayed", fee, maxFee))
+		}
+	}
+
+	// Gas: estimate each transaction (value includes its queue fees).
+	totalValue := new(big.Int)
+
+	for i, calldata := range calldatas {
+		value := new(big.Int).Mul(fee, big.NewInt(int64(requestsPerTx)))
+		value.Add(value, values[i])
+		totalValue.Add(totalValue, value)
+
+		gas, err := rpc.EstimateGas(ctx, ethereum.CallMsg{
+			From:  s.wallet.Address(),
+			To:    &to,
+			Value: value,
+			Data:  calldata,
+		})
+		if err != nil {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("gas estimation failed, using the gas limit: %v", err))
+			gas = depositGasLimit * uint64(requestsPerTx)
+		}
+
+		preview.EstimatedGas += gas
+	}
+
+	gasPrice, err := s.gasPrice(ctx)
+	if err != nil {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("gas price unavailable: %v", err))
+		gasPrice = new(big.Int)
+	}
+
+	gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(preview.EstimatedGas))
+	totalCost := new(big.Int).Add(totalValue, gasCost)
+
+	preview.GasPriceWei = gasPrice.String()
+	preview.EstimatedGasCostWei = gasCost.String()
+	preview.TotalCostWei = totalCost.String()
+
+	walletBalance, err := s.wallet.GetBalance(ctx)
+	if err != nil {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("wallet balance unavailable: %v", err))
+		walletBalance = new(big.Int)
+	}
+
+	preview.WalletBalanceWei = walletBalance.String()
+	preview.SufficientFunds = walletBalance.Cmp(totalCost) >= 0
+
+	return preview, nil
+}
+
+// gasPrice returns the expected effective gas price: the latest base fee plus
+// the suggested priority fee (the wallet caps at twice the base fee + tip).
+func (s *DepositService) gasPrice(ctx context.Context) (*big.Int, error) {
+	rpc := s.wallet.GetRPCClient()
+
+	tip, err := rpc.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := rpc.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	price := new(big.Int).Set(tip)
+	if header.BaseFee != nil {
+		price.Add(price, header.BaseFee)
+	}
+
+	return price, nil
+}
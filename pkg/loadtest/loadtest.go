@@ -0,0 +1,531 @@
+// Package loadtest drives synthetic Builder API traffic (validator
+// registrations, getHeader storms and blinded block submissions) against a
+// buildoor or relay endpoint and reports per-operation latency percentiles.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/OffchainLabs/go-bitfield"
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/altair"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/capella"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+
+	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// blsCurveOrder is the order r of the BLS12-381 scalar field; synthetic
+// validator keys are reduced modulo r.
+var blsCurveOrder, _ = new(big.Int).SetString(
+	"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16,
+)
+
+// domainBeaconProposer is the spec DOMAIN_BEACON_PROPOSER type. The synthetic
+// validators are never the slot's actual proposer, so blinded blocks are
+// signed under a zero fork version and only pass against a target that skips
+// proposer signature verification (see Config.TargetSkipsProposerSignature).
+var domainBeaconProposer = phase0.DomainType{0x00, 0x00, 0x00, 0x00}
+
+// Config configures a Builder API load test run.
+type Config struct {
+	// Target is the Builder API base URL (e.g. http://buildoor:8082).
+	Target string
+	// Validators is the number of synthetic validator keys to register and
+	// request headers for.
+	Validators int
+	// RPS is the getHeader request rate.
+	RPS float64
+	// Duration is the length of the getHeader storm.
+	Duration time.Duration
+	// Concurrency bounds the number of in-flight requests.
+	Concurrency int
+	// RegistrationBatch is the number of registrations per POST.
+	RegistrationBatch int
+	// Slot and ParentHash are the getHeader path parameters.
+	Slot       phase0.Slot
+	ParentHash phase0.Hash32
+	// SubmitRatio is the fraction (0-1) of delivered headers followed up by a
+	// blinded block submission.
+	SubmitRatio float64
+	// SubmitAPIVersion selects the blinded block endpoint (1 or 2).
+	SubmitAPIVersion int
+	// TargetSkipsProposerSignature confirms that the target unblinds without
+	// verifying the proposer signature (buildoor:
+	// --builder-api-verify-proposer-signature=false). Required for
+	// submissions: the synthetic keys cannot produce a valid one.
+	TargetSkipsProposerSignature bool
+	// FeeRecipient and GasLimit are advertised in the registrations.
+	FeeRecipient bellatrix.ExecutionAddress
+	GasLimit     uint64
+	// RequestTimeout bounds a single HTTP request.
+	RequestTimeout time.Duration
+}
+
+// DefaultConfig returns a load test configuration with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Validators:        100,
+		RPS:               10,
+		Duration:          30 * time.Second,
+		Concurrency:       64,
+		RegistrationBatch: 100,
+		SubmitRatio:       0,
+		SubmitAPIVersion:  2,
+		GasLimit:          60_000_000,
+		RequestTimeout:    10 * time.Second,
+	}
+}
+
+// Validate checks the configuration for consistency.
+func (c *Config) Validate() error {
+	if c.Target == "" {
+		return fmt.Errorf("target URL is required")
+	}
+
+	if c.Validators <= 0 {
+		return fmt.Errorf("validators must be > 0")
+	}
+
+	if c.RPS <= 0 {
+		return fmt.Errorf("rps must be > 0")
+	}
+
+	if c.Concurrency <= 0 {
+		return fmt.Errorf("concurrency must be > 0")
+	}
+
+	if c.RegistrationBatch <= 0 {
+		return fmt.Errorf("registration batch must be > 0")
+	}
+
+	if c.SubmitRatio < 0 || c.SubmitRatio > 1 {
+		return fmt.Errorf("submit ratio must be within [0, 1]")
+	}
+
+	if c.SubmitAPIVersion != 1 && c.SubmitAPIVersion != 2 {
+		return fmt.Errorf("submit API version must be 1 or 2")
+	}
+
+	if c.SubmitRatio > 0 && !c.TargetSkipsProposerSignature {
+		return fmt.Errorf("blinded block submissions require a target that skips proposer signature verification")
+	}
+
+	return nil
+}
+
+// Runner executes a Builder API load test.
+type Runner struct {
+	cfg        *Config
+	log        logrus.FieldLogger
+	httpClient *http.Client
+	stats      *recorder
+	validators []*signer.BLSSigner
+	dropped    atomic.Uint64
+	rejectOnce sync.Once
+}
+
+// NewRunner creates a load test runner and derives its synthetic validator
+// keys.
+func NewRunner(cfg *Config, log logrus.FieldLogger) (*Runner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	validators := make([]*signer.BLSSigner, cfg.Validators)
+
+	for i := range validators {
+		key, err := validatorKey(uint64(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive validator key %d: %w", i, err)
+		}
+
+		validators[i] = key
+	}
+
+	return &Runner{
+		cfg: cfg,
+		log: log.WithField("component", "loadtest"),
+		httpClient: &http.Client{
+			Timeout: cfg.RequestTimeout,
+			Transport: &http.Transport{
+				MaxIdleConns:        cfg.Concurrency,
+				MaxIdleConnsPerHost: cfg.Concurrency,
+			},
+		},
+		stats:      newRecorder(),
+		validators: validators,
+	}, nil
+}
+
+// validatorKey deterministically derives the synthetic validator key for an
+// index, so repeated runs register the same validator set.
+func validatorKey(index uint64) (*signer.BLSSigner, error) {
+	var seed [8]byte
+
+	binary.BigEndian.PutUint64(seed[:], index)
+
+	digest := sha256.Sum256(append([]byte("buildoor-loadtest-validator-"), seed[:]...))
+	sk := new(big.Int).Mod(new(big.Int).SetBytes(digest[:]), blsCurveOrder)
+
+	if sk.Sign() == 0 {
+		sk.SetUint64(index + 1)
+	}
+
+	var skBytes [32]byte
+
+	sk.FillBytes(skBytes[:])
+
+	return signer.NewBLSSigner(hex.EncodeToString(skBytes[:]))
+}
+
+// Run registers all synthetic validators, then issues getHeader requests at
+// the configured rate for the configured duration (following up a share of the
+// delivered headers with blinded block submissions) and returns the report.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	start := time.Now()
+
+	if err := r.registerValidators(ctx); err != nil {
+		return nil, err
+	}
+
+	r.headerStorm(ctx)
+
+	return &Report{
+		Target:     r.cfg.Target,
+		Validators: r.cfg.Validators,
+		Elapsed:    time.Since(start),
+		Dropped:    r.dropped.Load(),
+		Operations: r.stats.report(),
+	}, nil
+}
+
+// registerValidators posts signed registrations for all synthetic validators
+// in batches, with up to Concurrency batches in flight.
+func (r *Runner) registerValidators(ctx context.Context) error {
+	registrations := make([]*apiv1.SignedValidatorRegistration, len(r.validators))
+	timestamp := time.Now()
+
+	for i, key := range r.validators {
+		reg, err := r.signRegistration(key, timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to sign registration %d: %w", i, err)
+		}
+
+		registrations[i] = reg
+	}
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, r.cfg.Concurrency)
+
+	for offset := 0; offset < len(registrations); offset += r.cfg.RegistrationBatch {
+		batch := registrations[offset:min(offset+r.cfg.RegistrationBatch, len(registrations))]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			body, err := json.Marshal(batch)
+			if err != nil {
+				r.log.WithError(err).Error("Failed to encode registrations")
+				return
+			}
+
+			status, _, err := r.do(ctx, OpRegisterValidators, http.MethodPost,
+				"/eth/v1/builder/validators", body, nil)
+			if err != nil {
+				r.log.WithError(err).Debug("Registration request failed")
+			} else if status != http.StatusOK {
+				r.log.WithField("status", status).Debug("Registration request rejected")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	r.log.WithField("validators", len(registrations)).Info("Validator registrations sent")
+
+	return nil
+}
+
+// signRegistration builds a registration for the key, signed under the
+// builder application domain with zero fork version and genesis root (accepted
+// by buildoor and mev-boost-relay).
+func (r *Runner) signRegistration(key *signer.BLSSigner, timestamp time.Time) (*apiv1.SignedValidatorRegistration, error) {
+	msg := &apiv1.ValidatorRegistration{
+		FeeRecipient: r.cfg.FeeRecipient,
+		GasLimit:     r.cfg.GasLimit,
+		Timestamp:    timestamp,
+		Pubkey:       key.PublicKey(),
+	}
+
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := signer.ComputeDomain(signer.DomainApplicationBuilder, phase0.Version{}, phase0.Root{})
+
+	sig, err := key.SignWithDomain(root, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.SignedValidatorRegistration{Message: msg, Signature: sig}, nil
+}
+
+// headerStorm issues getHeader requests at the configured rate, rotating
+// through the validator set. Ticks that find Concurrency requests already in
+// flight are dropped (and counted) rather than queued, so the offered rate
+// never silently degrades into a backlog.
+func (r *Runner) headerStorm(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Duration)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / r.cfg.RPS))
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, r.cfg.Concurrency)
+	next := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			r.dropped.Add(1)
+			continue
+		}
+
+		key := r.validators[next%len(r.validators)]
+		next++
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// In-flight requests finish against the parent context so the
+			// storm deadline does not count them as transport errors.
+			r.getHeaderAndSubmit(context.WithoutCancel(ctx), key)
+		}()
+	}
+}
+
+// getHeaderAndSubmit requests a header for the key and, for a SubmitRatio
+// share of the delivered bids, submits a blinded block built on top of it.
+func (r *Runner) getHeaderAndSubmit(ctx context.Context, key *signer.BLSSigner) {
+	pubkey := key.PublicKey()
+	path := fmt.Sprintf("/eth/v1/builder/header/%d/0x%x/0x%x", r.cfg.Slot, r.cfg.ParentHash[:], pubkey[:])
+
+	status, body, err := r.do(ctx, OpGetHeader, http.MethodGet, path, nil, nil)
+	if err != nil || status != http.StatusOK {
+		return
+	}
+
+	if r.cfg.SubmitRatio == 0 || rand.Float64() >= r.cfg.SubmitRatio { //nolint:gosec // sampling only
+		return
+	}
+
+	fork, bid, err := decodeHeaderResponse(body)
+	if err != nil {
+		r.log.WithError(err).Debug("Failed to decode getHeader response")
+		return
+	}
+
+	blinded, err := r.buildBlindedBlock(key, fork, bid)
+	if err != nil {
+		r.log.WithError(err).Debug("Failed to build blinded block")
+		return
+	}
+
+	payload, err := json.Marshal(blinded)
+	if err != nil {
+		r.log.WithError(err).Debug("Failed to encode blinded block")
+		return
+	}
+
+	headers := map[string]string{"Eth-Consensus-Version": fork.String()}
+
+	status, _, err = r.do(ctx, OpSubmitBlinded, http.MethodPost,
+		fmt.Sprintf("/eth/v%d/builder/blinded_blocks", r.cfg.SubmitAPIVersion), payload, headers)
+	if err == nil && status == http.StatusBadRequest {
+		r.rejectOnce.Do(func() {
+			r.log.Warn("Blinded block submission rejected: check that the target skips proposer signature verification")
+		})
+	}
+}
+
+// decodeHeaderResponse decodes a getHeader JSON response envelope.
+func decodeHeaderResponse(body []byte) (version.DataVersion, *legacytypes.SignedBuilderBid, error) {
+	var envelope struct {
+		Version string          `json:"version"`
+		Data    json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return version.DataVersionUnknown, nil, err
+	}
+
+	var fork version.DataVersion
+	if err := fork.UnmarshalJSON([]byte(`"` + strings.ToLower(envelope.Version) + `"`)); err != nil {
+		return version.DataVersionUnknown, nil, fmt.Errorf("invalid version %q: %w", envelope.Version, err)
+	}
+
+	bid := &legacytypes.SignedBuilderBid{Version: fork}
+	if err := json.Unmarshal(envelope.Data, bid); err != nil {
+		return version.DataVersionUnknown, nil, err
+	}
+
+	if bid.Message == nil || bid.Message.Header == nil {
+		return version.DataVersionUnknown, nil, fmt.Errorf("bid missing message or header")
+	}
+
+	return fork, bid, nil
+}
+
+// buildBlindedBlock wraps a bid's header into an otherwise empty blinded
+// block for the requested slot, signed by the synthetic proposer key.
+func (r *Runner) buildBlindedBlock(
+	key *signer.BLSSigner,
+	fork version.DataVersion,
+	bid *legacytypes.SignedBuilderBid,
+) (*apiv1all.SignedBlindedBeaconBlock, error) {
+	header := *bid.Message.Header
+	header.Version = fork
+
+	block := &apiv1all.BlindedBeaconBlock{
+		Version: fork,
+		Slot:    r.cfg.Slot,
+		Body: &apiv1all.BlindedBeaconBlockBody{
+			Version:  fork,
+			ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+			SyncAggregate: &altair.SyncAggregate{
+				SyncCommitteeBits: bitfield.NewBitvector512(),
+			},
+			// The JSON codecs reject nil lists; empty operation lists
+			// keep the block well-formed.
+			ProposerSlashings:      []*phase0.ProposerSlashing{},
+			AttesterSlashings:      []*eth2all.AttesterSlashing{},
+			Attestations:           []*eth2all.Attestation{},
+			Deposits:               []*phase0.Deposit{},
+			VoluntaryExits:         []*phase0.SignedVoluntaryExit{},
+			BLSToExecutionChanges:  []*capella.SignedBLSToExecutionChange{},
+			ExecutionPayloadHeader: &header,
+			BlobKZGCommitments:     bid.Message.BlobKZGCommitments,
+			ExecutionRequests:      bid.Message.ExecutionRequests,
+		},
+	}
+
+	if block.Body.BlobKZGCommitments == nil {
+		block.Body.BlobKZGCommitments = []deneb.KZGCommitment{}
+	}
+
+	root, err := block.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := key.SignWithDomain(root, signer.ComputeDomain(domainBeaconProposer, phase0.Version{}, phase0.Root{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1all.SignedBlindedBeaconBlock{Version: fork, Message: block, Signature: sig}, nil
+}
+
+// do performs one timed request and records its outcome under op. The
+// response body is returned for 200 responses only.
+func (r *Runner) do(
+	ctx context.Context,
+	op, method, path string,
+	body []byte,
+	headers map[string]string,
+) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(r.cfg.Target, "/")+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.stats.record(op, time.Since(start), 0)
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	var respBody []byte
+	if resp.StatusCode == http.StatusOK {
+		respBody, err = io.ReadAll(resp.Body)
+	} else {
+		_, err = io.Copy(io.Discard, resp.Body)
+	}
+
+	latency := time.Since(start)
+
+	if err != nil {
+		r.stats.record(op, latency, 0)
+		return 0, nil, err
+	}
+
+	r.stats.record(op, latency, resp.StatusCode)
+
+	return resp.StatusCode, respBody, nil
+}
@@ -0,0 +1,180 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	apiv1all "github.com/ethpandaops/go-eth2-client/api/v1/all"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/holiman/uint256"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/builderapi/legacy"
+	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+)
+
+// fakeBuilderAPI serves a fixed Fulu bid and records what it receives.
+type fakeBuilderAPI struct {
+	t *testing.T
+
+	mu            sync.Mutex
+	registrations map[phase0.BLSPubKey]bool
+	headers       int
+	submissions   []*apiv1all.SignedBlindedBeaconBlock
+}
+
+func (f *fakeBuilderAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/eth/v1/builder/validators":
+		var regs []*apiv1.SignedValidatorRegistration
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&regs))
+
+		for _, reg := range regs {
+			require.True(f.t, legacy.VerifyRegistrationWithDomain(reg, phase0.Version{}, phase0.Version{}, phase0.Root{}),
+				"registration signature must verify")
+			f.registrations[reg.Message.Pubkey] = true
+		}
+
+		w.WriteHeader(http.StatusOK)
+	case strings.HasPrefix(r.URL.Path, "/eth/v1/builder/header/"):
+		f.headers++
+
+		bid := &legacytypes.SignedBuilderBid{
+			Version: version.DataVersionFulu,
+			Message: &legacytypes.BuilderBid{
+				Version: version.DataVersionFulu,
+				Header: &eth2all.ExecutionPayloadHeader{
+					Version:       version.DataVersionFulu,
+					BlockNumber:   7,
+					ExtraData:     []byte{},
+					BaseFeePerGas: uint256.NewInt(9),
+					BlockHash:     phase0.Hash32{0x0a},
+				},
+				ExecutionRequests: &eth2all.ExecutionRequests{Version: version.DataVersionFulu},
+				Value:             uint256.NewInt(1000),
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(f.t, json.NewEncoder(w).Encode(legacy.GetHeaderResponse{Version: "fulu", Data: bid}))
+	case r.URL.Path == "/eth/v2/builder/blinded_blocks":
+		require.Equal(f.t, "fulu", r.Header.Get("Eth-Consensus-Version"))
+
+		blinded := &apiv1all.SignedBlindedBeaconBlock{Version: version.DataVersionFulu}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		require.NoError(f.t, json.Unmarshal(body, blinded))
+
+		f.submissions = append(f.submissions, blinded)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRunnerDrivesAllOperations(t *testing.T) {
+	fake := &fakeBuilderAPI{t: t, registrations: map[phase0.BLSPubKey]bool{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.Target = srv.URL
+	cfg.Validators = 5
+	cfg.RegistrationBatch = 2
+	cfg.RPS = 200
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Slot = 12
+	cfg.SubmitRatio = 1
+	cfg.TargetSkipsProposerSignature = true
+	cfg.FeeRecipient = bellatrix.ExecutionAddress{0x01}
+
+	runner, err := NewRunner(cfg, logrus.New())
+	require.NoError(t, err)
+
+	report, err := runner.Run(context.Background())
+	require.NoError(t, err)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	require.Len(t, fake.registrations, 5, "every synthetic validator should be registered")
+	require.NotZero(t, fake.headers)
+	require.NotEmpty(t, fake.submissions)
+	require.Equal(t, phase0.Hash32{0x0a}, fake.submissions[0].Message.Body.ExecutionPayloadHeader.BlockHash,
+		"blinded block should carry the served header")
+	require.Equal(t, phase0.Slot(12), fake.submissions[0].Message.Slot)
+
+	ops := make(map[string]OpStats, len(report.Operations))
+	for _, op := range report.Operations {
+		ops[op.Operation] = op
+	}
+
+	require.Equal(t, 3, ops[OpRegisterValidators].Count, "5 validators in batches of 2")
+	require.Equal(t, uint64(3), ops[OpRegisterValidators].StatusCodes[http.StatusOK])
+	require.Equal(t, fake.headers, ops[OpGetHeader].Count)
+	require.Equal(t, len(fake.submissions), ops[OpSubmitBlinded].Count)
+	require.Zero(t, ops[OpGetHeader].Errors)
+
+	var out strings.Builder
+	require.NoError(t, report.WriteText(&out))
+	require.Contains(t, out.String(), OpGetHeader)
+}
+
+func TestValidatorKeysAreDeterministic(t *testing.T) {
+	a, err := validatorKey(3)
+	require.NoError(t, err)
+
+	b, err := validatorKey(3)
+	require.NoError(t, err)
+
+	c, err := validatorKey(4)
+	require.NoError(t, err)
+
+	require.Equal(t, a.PublicKey(), b.PublicKey())
+	require.NotEqual(t, a.PublicKey(), c.PublicKey())
+}
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(samples, 50))
+	require.Equal(t, 90*time.Millisecond, percentile(samples, 90))
+	require.Equal(t, 99*time.Millisecond, percentile(samples, 99))
+	require.Equal(t, time.Millisecond, percentile(samples[:1], 99))
+	require.Zero(t, percentile(nil, 50))
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Error(t, cfg.Validate(), "target is required")
+
+	cfg.Target = "http://localhost"
+	require.NoError(t, cfg.Validate())
+
+	cfg.SubmitRatio = 1.5
+	require.Error(t, cfg.Validate())
+
+	cfg.SubmitRatio = 0.5
+	require.Error(t, cfg.Validate(), "submissions need a target without proposer signature verification")
+
+	cfg.TargetSkipsProposerSignature = true
+	require.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,189 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Operation names used as report keys.
+const (
+	OpRegisterValidators = "register_validators"
+	OpGetHeader          = "get_header"
+	OpSubmitBlinded      = "submit_blinded_block"
+)
+
+// opOrder is the fixed report order of the operations.
+var opOrder = []string{OpRegisterValidators, OpGetHeader, OpSubmitBlinded}
+
+// recorder collects per-operation latency samples and status codes. It is
+// safe for concurrent use by the request workers.
+type recorder struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	status  map[string]map[int]uint64 // status code 0 = transport error
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		samples: make(map[string][]time.Duration, len(opOrder)),
+		status:  make(map[string]map[int]uint64, len(opOrder)),
+	}
+}
+
+// record stores one request outcome. statusCode is 0 when the request failed
+// before a response was received.
+func (r *recorder) record(op string, latency time.Duration, statusCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[op] = append(r.samples[op], latency)
+
+	codes := r.status[op]
+	if codes == nil {
+		codes = make(map[int]uint64, 4)
+		r.status[op] = codes
+	}
+
+	codes[statusCode]++
+}
+
+// OpStats summarizes the latency distribution and outcomes of one operation.
+type OpStats struct {
+	Operation   string         `json:"operation"`
+	Count       int            `json:"count"`
+	Errors      int            `json:"errors"` // transport errors and 5xx responses
+	StatusCodes map[int]uint64 `json:"status_codes"`
+	Mean        time.Duration  `json:"mean"`
+	P50         time.Duration  `json:"p50"`
+	P90         time.Duration  `json:"p90"`
+	P99         time.Duration  `json:"p99"`
+	Max         time.Duration  `json:"max"`
+}
+
+// Report is the result of a load test run.
+type Report struct {
+	Target     string        `json:"target"`
+	Validators int           `json:"validators"`
+	Elapsed    time.Duration `json:"elapsed"`
+	Dropped    uint64        `json:"dropped"` // getHeader ticks skipped at the concurrency limit
+	Operations []OpStats     `json:"operations"`
+}
+
+// report builds the per-operation summary of everything recorded so far.
+func (r *recorder) report() []OpStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]OpStats, 0, len(opOrder))
+
+	for _, op := range opOrder {
+		samples := r.samples[op]
+		if len(samples) == 0 {
+			continue
+		}
+
+		sorted := slices.Clone(samples)
+		slices.Sort(sorted)
+
+		var total time.Duration
+		for _, d := range sorted {
+			total += d
+		}
+
+		codes := make(map[int]uint64, len(r.status[op]))
+		errors := 0
+
+		for code, n := range r.status[op] {
+			codes[code] = n
+			if code == 0 || code >= 500 {
+				errors += int(n)
+			}
+		}
+
+		stats = append(stats, OpStats{
+			Operation:   op,
+			Count:       len(sorted),
+			Errors:      errors,
+			StatusCodes: codes,
+			Mean:        total / time.Duration(len(sorted)),
+			P50:         percentile(sorted, 50),
+			P90:         percentile(sorted, 90),
+			P99:         percentile(sorted, 99),
+			Max:         sorted[len(sorted)-1],
+		})
+	}
+
+	return stats
+}
+
+// percentile returns the nearest-rank percentile p (0-100) of an ascending
+// sorted sample slice.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+
+	return sorted[rank]
+}
+
+// WriteText renders the report as a human-readable table.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "target: %s  validators: %d  elapsed: %s  dropped: %d\n\n",
+		r.Target, r.Validators, r.Elapsed.Round(time.Millisecond), r.Dropped)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPERATION\tCOUNT\tERRORS\tRPS\tMEAN\tP50\tP90\tP99\tMAX\tSTATUS")
+
+	for _, op := range r.Operations {
+		rps := 0.0
+		if r.Elapsed > 0 {
+			rps = float64(op.Count) / r.Elapsed.Seconds()
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			op.Operation, op.Count, op.Errors, rps,
+			fmtLatency(op.Mean), fmtLatency(op.P50), fmtLatency(op.P90),
+			fmtLatency(op.P99), fmtLatency(op.Max), fmtStatusCodes(op.StatusCodes))
+	}
+
+	return tw.Flush()
+}
+
+func fmtLatency(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d.Microseconds())/1000)
+}
+
+// fmtStatusCodes renders a status code histogram as "200:12 204:3 err:1".
+func fmtStatusCodes(codes map[int]uint64) string {
+	keys := make([]int, 0, len(codes))
+	for code := range codes {
+		keys = append(keys, code)
+	}
+
+	sort.Ints(keys)
+
+	out := ""
+
+	for i, code := range keys {
+		if i > 0 {
+			out += " "
+		}
+
+		if code == 0 {
+			out += fmt.Sprintf("err:%d", codes[code])
+		} else {
+			out += fmt.Sprintf("%d:%d", code, codes[code])
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,130 @@
+// Package metrics holds the Prometheus metrics of the build and bid paths —
+// payload builds, engine API calls, p2p bid submissions, reveals and Builder
+// API requests — and the /metrics handler serving them. Subsystems with their
+// own health metrics (beacon event streams, dispatchers, supervised
+// goroutines) register those next to the code they describe; all of them land
+// in the default registry served here.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Result label values.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultSkipped = "skipped"
+)
+
+var (
+	payloadBuildDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "buildoor_payload_build_duration_seconds",
+		Help:    "Payload builds from payload_attributes to built payload (including the configured build time), per result.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 1.5, 2, 3, 4, 6, 8, 12},
+	}, []string{"result"})
+
+	engineCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "buildoor_engine_call_duration_seconds",
+		Help:    "Engine API calls to the execution client, per method and result.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"method", "result"})
+
+	bidSubmissionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "buildoor_bid_submission_duration_seconds",
+		Help:    "p2p bid submissions (sign and gossip via the beacon node), per result.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"result"})
+
+	reveals = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_reveals_total",
+		Help: "Payload reveals (execution payload envelopes), per result.",
+	}, []string{"result"})
+
+	builderAPIRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_builder_api_requests_total",
+		Help: "Builder API requests served, per route and HTTP status code.",
+	}, []string{"route", "code"})
+
+	builderAPIRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "buildoor_builder_api_request_duration_seconds",
+		Help:    "Builder API request handling time, per route.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"route"})
+)
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObservePayloadBuild records a payload build that took d.
+func ObservePayloadBuild(d time.Duration, err error) {
+	payloadBuildDuration.WithLabelValues(result(err)).Observe(d.Seconds())
+}
+
+// ObserveEngineCall records an engine API call started at start.
+func ObserveEngineCall(method string, start time.Time, err error) {
+	engineCallDuration.WithLabelValues(method, result(err)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveBidSubmission records a p2p bid submission that took d.
+func ObserveBidSubmission(d time.Duration, err error) {
+	bidSubmissionDuration.WithLabelValues(result(err)).Observe(d.Seconds())
+}
+
+// RecordReveal counts a reveal with the given result (ResultSuccess,
+// ResultFailure or ResultSkipped).
+func RecordReveal(result string) {
+	reveals.WithLabelValues(result).Inc()
+}
+
+// InstrumentBuilderAPI is a mux middleware counting and timing Builder API
+// requests per route template, so path parameters (slot, pubkey, ...) do not
+// explode the label cardinality.
+func InstrumentBuilderAPI(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		builderAPIRequests.WithLabelValues(route, strconv.Itoa(recorder.code)).Inc()
+		builderAPIRequestDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder captures the response status code.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+// WriteHeader records the status code before writing it.
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// result maps an error to its result label.
+func result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+
+	return ResultSuccess
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentBuilderAPI(t *testing.T) {
+	router := mux.NewRouter()
+	api := router.PathPrefix("/eth/v1/builder").Subrouter()
+	api.Use(InstrumentBuilderAPI)
+	api.HandleFunc("/header/{slot}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	api.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	route := "/eth/v1/builder/header/{slot}"
+	before := testutil.ToFloat64(builderAPIRequests.WithLabelValues(route, "204"))
+
+	for _, slot := range []string{"1", "2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/eth/v1/builder/header/"+slot, nil))
+	}
+
+	// Requests are labelled by route template, not by path.
+	assert.Equal(t, before+2, testutil.ToFloat64(builderAPIRequests.WithLabelValues(route, "204")))
+
+	before = testutil.ToFloat64(builderAPIRequests.WithLabelValues("/eth/v1/builder/status", "200"))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/eth/v1/builder/status", nil))
+	assert.Equal(t, before+1, testutil.ToFloat64(builderAPIRequests.WithLabelValues("/eth/v1/builder/status", "200")))
+}
+
+func TestObserveResults(t *testing.T) {
+	ObserveEngineCall("engine_test", time.Now(), nil)
+	ObserveEngineCall("engine_test", time.Now(), errors.New("boom"))
+	RecordReveal(ResultSkipped)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `buildoor_engine_call_duration_seconds_count{method="engine_test",result="success"} 1`)
+	assert.Contains(t, body, `buildoor_engine_call_duration_seconds_count{method="engine_test",result="failure"} 1`)
+	assert.Contains(t, body, `buildoor_reveals_total{result="skipped"}`)
+}
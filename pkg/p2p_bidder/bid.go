@@ -3,6 +3,7 @@ package p2p_bidder
 import (
 	"time"
 
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 )
 
@@ -17,6 +18,10 @@ type ExecutionPayloadBid struct {
 	Slot             phase0.Slot
 	Value            uint64 // Gwei
 	ExecutionPayment uint64 // Gwei
+
+	// Signed is the bid as signed by its builder, when it was received over
+	// gossip (nil for our own tracked submissions); the peer mesh shares it.
+	Signed *eth2all.SignedExecutionPayloadBid
 }
 
 // TrackedBid represents a bid being tracked for competition analysis.
@@ -25,6 +30,7 @@ type TrackedBid struct {
 	BuilderIndex uint64
 	ReceivedAt   time.Time
 	IsOurs       bool
+	Peer         string // mesh peer that shared the bid ("" = observed locally)
 }
 
 // SlotBids holds all bids for a specific slot.
@@ -0,0 +1,259 @@
+package p2p_bidder
+
+import (
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// BidBudgetStatus is the epoch bid budget as reported by the API.
+type BidBudgetStatus struct {
+	Enabled      bool   `json:"enabled"`
+	Epoch        uint64 `json:"epoch"`
+	Distribution string `json:"distribution"`
+	EpochMaxGwei uint64 `json:"epoch_max_gwei"`
+	// SpentGwei is the sum of the highest bid gossiped per slot this epoch.
+	SpentGwei     uint64 `json:"spent_gwei"`
+	RemainingGwei uint64 `json:"remaining_gwei"`
+	SlotsBid      int    `json:"slots_bid"`
+	SlotsLeft     uint64 `json:"slots_left"` // slots of the epoch from the current one on
+	Exhausted     bool   `json:"exhausted"`
+	// BidsCapped and SlotsRefused count since startup the bids lowered to
+	// their slot's allowance and the slots not bid on for lack of budget.
+	BidsCapped   uint64 `json:"bids_capped"`
+	SlotsRefused uint64 `json:"slots_refused"`
+}
+
+// BidBudget caps the p2p bid spend per epoch. A slot's spend is the highest
+// bid gossiped for it — at most one of our bids can win a slot, so that is
+// its worst-case payment. Each bid is capped at the slot's share of the
+// budget left by the epoch's other slots; the share follows the configured
+// distribution over the epoch's remaining slots. The limits are read live
+// from the config, so changes apply to the next bid.
+type BidBudget struct {
+	cfg           *config.Config
+	slotsPerEpoch uint64
+
+	mu        sync.Mutex
+	committed map[phase0.Slot]uint64 // highest gossiped bid per slot
+	values    map[phase0.Slot]uint64 // payload value (gwei) per evaluated slot
+	capped    uint64
+	refused   uint64
+}
+
+// NewBidBudget creates an epoch bid budget reading its limits from cfg.
+func NewBidBudget(cfg *config.Config, slotsPerEpoch uint64) *BidBudget {
+	return &BidBudget{
+		cfg:           cfg,
+		slotsPerEpoch: max(slotsPerEpoch, 1),
+		committed:     make(map[phase0.Slot]uint64),
+		values:        make(map[phase0.Slot]uint64),
+	}
+}
+
+// Allowance returns the most the slot may bid, given its payload value in
+// gwei. ok is false when no budget is configured; a zero allowance means the
+// budget is exhausted and the bid must be refused.
+func (b *BidBudget) Allowance(slot phase0.Slot, payloadValueGwei uint64) (allowance uint64, ok bool) {
+	budget := b.cfg.BidBudget
+	if budget.EpochMaxGwei == 0 {
+		return 0, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.prune(slot)
+	b.values[slot] = payloadValueGwei
+
+	remaining := b.remainingExcluding(budget.EpochMaxGwei, slot)
+	slotsLeft := b.slotsLeft(slot)
+
+	switch budget.NormalizedDistribution() {
+	case config.BidBudgetFrontLoaded:
+		// Linearly decaying weights slotsLeft, ..., 1: the current slot gets
+		// slotsLeft / (slotsLeft*(slotsLeft+1)/2) of the remaining budget.
+		allowance = mulDiv(remaining, 2, slotsLeft+1)
+	case config.BidBudgetValueWeighted:
+		allowance = b.valueWeightedShare(slot, remaining, slotsLeft, payloadValueGwei)
+	default:
+		allowance = remaining / slotsLeft
+	}
+
+	return allowance, true
+}
+
+// Commit records a gossiped bid; the slot's spend is its highest bid.
+func (b *BidBudget) Commit(slot phase0.Slot, valueGwei uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if valueGwei > b.committed[slot] {
+		b.committed[slot] = valueGwei
+	}
+}
+
+// RecordCapped counts a bid lowered to the slot's allowance.
+func (b *BidBudget) RecordCapped() {
+	b.mu.Lock()
+	b.capped++
+	b.mu.Unlock()
+}
+
+// RecordRefused counts a slot not bid on for lack of budget.
+func (b *BidBudget) RecordRefused() {
+	b.mu.Lock()
+	b.refused++
+	b.mu.Unlock()
+}
+
+// Status reports the budget of the epoch of the given (current) slot.
+func (b *BidBudget) Status(slot phase0.Slot) *BidBudgetStatus {
+	budget := b.cfg.BidBudget
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	epoch := b.epochOf(slot)
+	status := &BidBudgetStatus{
+		Enabled:      budget.EpochMaxGwei > 0,
+		Epoch:        uint64(epoch),
+		Distribution: budget.NormalizedDistribution(),
+		EpochMaxGwei: budget.EpochMaxGwei,
+		SpentGwei:    b.spent(epoch, nil),
+		SlotsLeft:    b.slotsLeft(slot),
+		BidsCapped:   b.capped,
+		SlotsRefused: b.refused,
+	}
+
+	for committed := range b.committed {
+		if b.epochOf(committed) == epoch {
+			status.SlotsBid++
+		}
+	}
+
+	if status.Enabled {
+		if status.SpentGwei < budget.EpochMaxGwei {
+			status.RemainingGwei = budget.EpochMaxGwei - status.SpentGwei
+		}
+
+		status.Exhausted = status.RemainingGwei == 0
+	}
+
+	return status
+}
+
+// prune drops the bookkeeping of epochs before the previous one (the
+// scheduler evaluates the next epoch's first slot while the current epoch's
+// last slot is still open). Must be called with mu held.
+func (b *BidBudget) prune(slot phase0.Slot) {
+	epoch := b.epochOf(slot)
+	if epoch == 0 {
+		return
+	}
+
+	for tracked := range b.values {
+		if b.epochOf(tracked) < epoch-1 {
+			delete(b.values, tracked)
+		}
+	}
+
+	for tracked := range b.committed {
+		if b.epochOf(tracked) < epoch-1 {
+			delete(b.committed, tracked)
+		}
+	}
+}
+
+// epochOf returns the epoch of slot.
+func (b *BidBudget) epochOf(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / b.slotsPerEpoch)
+}
+
+// spent sums the committed spends of the epoch's slots, skipping exclude
+// when set. Must be called with mu held.
+func (b *BidBudget) spent(epoch phase0.Epoch, exclude *phase0.Slot) uint64 {
+	var total uint64
+
+	for slot, value := range b.committed {
+		if b.epochOf(slot) != epoch || (exclude != nil && slot == *exclude) {
+			continue
+		}
+
+		total = addClamped(total, value)
+	}
+
+	return total
+}
+
+// remainingExcluding is the budget left by every slot of slot's epoch except
+// slot itself, so re-bids of a slot see a stable allowance. Must be called
+// with mu held.
+func (b *BidBudget) remainingExcluding(epochMax uint64, slot phase0.Slot) uint64 {
+	spent := b.spent(b.epochOf(slot), &slot)
+	if spent >= epochMax {
+		return 0
+	}
+
+	return epochMax - spent
+}
+
+// slotsLeft counts the epoch's slots from slot on (at least 1).
+func (b *BidBudget) slotsLeft(slot phase0.Slot) uint64 {
+	return b.slotsPerEpoch - uint64(slot)%b.slotsPerEpoch
+}
+
+// valueWeightedShare weights the slot's payload value against the average
+// payload value of the epoch's earlier evaluated slots, which stands in for
+// each of the remaining slots. Without values to compare it falls back to a
+// uniform share. Must be called with mu held.
+func (b *BidBudget) valueWeightedShare(slot phase0.Slot, remaining, slotsLeft, value uint64) uint64 {
+	epoch := b.epochOf(slot)
+	sum := new(big.Int)
+	count := int64(0)
+
+	for earlier, v := range b.values {
+		if earlier >= slot || b.epochOf(earlier) != epoch || v == 0 {
+			continue
+		}
+
+		sum.Add(sum, new(big.Int).SetUint64(v))
+		count++
+	}
+
+	if value == 0 || count == 0 {
+		return remaining / slotsLeft
+	}
+
+	// share = remaining * value / (value + (slotsLeft-1) * avg)
+	//       = remaining * value * count / (value*count + (slotsLeft-1) * sum)
+	num := new(big.Int).SetUint64(remaining)
+	num.Mul(num, new(big.Int).SetUint64(value))
+	num.Mul(num, big.NewInt(count))
+
+	den := new(big.Int).Mul(new(big.Int).SetUint64(value), big.NewInt(count))
+	den.Add(den, new(big.Int).Mul(new(big.Int).SetUint64(slotsLeft-1), sum))
+
+	return num.Div(num, den).Uint64()
+}
+
+// mulDiv returns a*b/c without intermediate overflow.
+func mulDiv(a, b, c uint64) uint64 {
+	res := new(big.Int).SetUint64(a)
+	res.Mul(res, new(big.Int).SetUint64(b))
+
+	return res.Div(res, new(big.Int).SetUint64(c)).Uint64()
+}
+
+// addClamped adds without wrapping past MaxUint64.
+func addClamped(a, b uint64) uint64 {
+	if sum := a + b; sum >= a {
+		return sum
+	}
+
+	return math.MaxUint64
+}
@@ -0,0 +1,100 @@
+package p2p_bidder
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func newTestBidBudget(epochMax uint64, distribution string) *BidBudget {
+	cfg := config.DefaultConfig()
+	cfg.BidBudget.EpochMaxGwei = epochMax
+	cfg.BidBudget.Distribution = distribution
+
+	return NewBidBudget(cfg, 4)
+}
+
+func TestBidBudgetDisabled(t *testing.T) {
+	budget := newTestBidBudget(0, config.BidBudgetUniform)
+
+	_, ok := budget.Allowance(1, 100)
+	assert.False(t, ok)
+	assert.False(t, budget.Status(1).Enabled)
+}
+
+func TestBidBudgetUniform(t *testing.T) {
+	budget := newTestBidBudget(1000, config.BidBudgetUniform)
+
+	allowance, ok := budget.Allowance(4, 0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(250), allowance)
+
+	budget.Commit(4, 200)
+	budget.Commit(4, 100) // lower re-bid: the slot still spends its highest bid
+
+	// Re-bids of a slot see the budget left by the other slots.
+	allowance, _ = budget.Allowance(4, 0)
+	assert.Equal(t, uint64(250), allowance)
+
+	allowance, _ = budget.Allowance(5, 0)
+	assert.Equal(t, uint64(800/3), allowance)
+
+	status := budget.Status(5)
+	assert.Equal(t, uint64(1), status.Epoch)
+	assert.Equal(t, uint64(200), status.SpentGwei)
+	assert.Equal(t, uint64(800), status.RemainingGwei)
+	assert.Equal(t, uint64(3), status.SlotsLeft)
+
+	// A new epoch starts with the full budget.
+	allowance, _ = budget.Allowance(8, 0)
+	assert.Equal(t, uint64(250), allowance)
+	assert.Zero(t, budget.Status(8).SpentGwei)
+}
+
+func TestBidBudgetFrontLoaded(t *testing.T) {
+	budget := newTestBidBudget(1000, config.BidBudgetFrontLoaded)
+
+	// Weights 4, 3, 2, 1 over the epoch's slots.
+	allowance, _ := budget.Allowance(0, 0)
+	assert.Equal(t, uint64(400), allowance)
+
+	budget.Commit(0, 400)
+
+	allowance, _ = budget.Allowance(1, 0)
+	assert.Equal(t, uint64(300), allowance)
+
+	// The last slot of the epoch may spend the rest.
+	allowance, _ = budget.Allowance(3, 0)
+	assert.Equal(t, uint64(600), allowance)
+}
+
+func TestBidBudgetValueWeighted(t *testing.T) {
+	budget := newTestBidBudget(1000, config.BidBudgetValueWeighted)
+
+	// Nothing to compare against yet: uniform share.
+	allowance, _ := budget.Allowance(0, 100)
+	assert.Equal(t, uint64(250), allowance)
+
+	budget.Commit(0, 100)
+
+	// Slot 1 is worth 3x the epoch average so far: 900 * 300 / (300 + 2*100).
+	allowance, _ = budget.Allowance(1, 300)
+	assert.Equal(t, uint64(540), allowance)
+}
+
+func TestBidBudgetExhausted(t *testing.T) {
+	budget := newTestBidBudget(100, config.BidBudgetUniform)
+
+	budget.Commit(phase0.Slot(0), 150)
+
+	allowance, ok := budget.Allowance(1, 0)
+	assert.True(t, ok)
+	assert.Zero(t, allowance)
+
+	status := budget.Status(1)
+	assert.True(t, status.Exhausted)
+	assert.Zero(t, status.RemainingGwei)
+}
@@ -0,0 +1,270 @@
+package p2p_bidder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+// Bid faults: deliberately invalid bids exercising the beacon node's bid
+// validation (POST /api/buildoor/bid with a fault).
+const (
+	// BidFaultZeroValue bids a value of 0.
+	BidFaultZeroValue = "zero_value"
+	// BidFaultExceedsBalance bids more than the builder's balance covers.
+	BidFaultExceedsBalance = "exceeds_balance"
+	// BidFaultMalformedValue gossips the bid with its JSON value replaced by
+	// a raw, malformed token (signed over the given value).
+	BidFaultMalformedValue = "malformed_value"
+)
+
+// DefaultMalformedBidValue is the raw value of a malformed_value bid: one
+// above the uint64 range.
+const DefaultMalformedBidValue = `"18446744073709551616"`
+
+// ErrUnknownBidFault is returned for a bid fault that is not one of BidFault*.
+var ErrUnknownBidFault = errors.New("unknown bid fault")
+
+// IsBidFault reports whether name is a known bid fault.
+func IsBidFault(name string) bool {
+	switch name {
+	case BidFaultZeroValue, BidFaultExceedsBalance, BidFaultMalformedValue:
+		return true
+	default:
+		return false
+	}
+}
+
+// rawBidSubmitter posts a JSON bid as is (implemented by *beacon.Client).
+type rawBidSubmitter interface {
+	SubmitRawExecutionPayloadBid(ctx context.Context, fork version.DataVersion, body []byte) error
+}
+
+// SubmitFaultBid gossips a deliberately invalid bid for the slot's cached
+// payload and reports the beacon node's verdict: Success when it accepted the
+// bid, otherwise Error holds its rejection reason. valueGwei is the bid value
+// (ignored for zero_value; the signed value for malformed_value, whose JSON
+// value is replaced by rawValue, DefaultMalformedBidValue when empty).
+//
+// Fault bids leave the slot's bidding state, the bid budget and the circuit
+// breaker untouched.
+func (s *Service) SubmitFaultBid(
+	ctx context.Context,
+	slot phase0.Slot,
+	fault string,
+	valueGwei uint64,
+	rawValue string,
+) (*BidSubmissionEvent, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("p2p bidder not started")
+	}
+
+	return s.scheduler.SubmitFaultBid(ctx, slot, fault, valueGwei, rawValue)
+}
+
+// SubmitFaultBid gossips a fault bid for the slot (see Service.SubmitFaultBid).
+func (s *Scheduler) SubmitFaultBid(
+	ctx context.Context,
+	slot phase0.Slot,
+	fault string,
+	valueGwei uint64,
+	rawValue string,
+) (*BidSubmissionEvent, error) {
+	if !IsBidFault(fault) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBidFault, fault)
+	}
+
+	payload := s.payloadCache.Get(slot)
+	if payload == nil {
+		return nil, ErrNoCachedPayload
+	}
+
+	s.effectiveBidSettings(slot)
+
+	s.mu.Lock()
+	state := s.getSlotState(slot)
+	closed := state.BidsClosed
+	bidCount := state.BidCount
+	s.mu.Unlock()
+
+	if closed {
+		return nil, ErrBidsClosed
+	}
+
+	var bidTransform string
+	if state.Frozen != nil && state.Frozen.Transforms != nil {
+		bidTransform = state.Frozen.Transforms.Bid
+	}
+
+	if fault == BidFaultZeroValue {
+		valueGwei = 0
+	}
+
+	if fault == BidFaultMalformedValue && rawValue == "" {
+		rawValue = DefaultMalformedBidValue
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"slot":       slot,
+		"fault":      fault,
+		"bid_value":  valueGwei,
+		"block_hash": fmt.Sprintf("%x", payload.BlockHash[:8]),
+	}).Warn("Creating and submitting fault bid")
+
+	var (
+		signedBid *eth2all.SignedExecutionPayloadBid
+		err       error
+	)
+
+	if fault == BidFaultMalformedValue {
+		signedBid, err = s.bidCreator.CreateAndSubmitRawValueBid(ctx, payload, valueGwei, bidTransform, rawValue)
+	} else {
+		signedBid, err = s.bidCreator.CreateAndSubmitBid(ctx, payload, valueGwei, bidTransform)
+	}
+
+	event := &BidSubmissionEvent{
+		Slot:      slot,
+		BlockHash: payload.BlockHash,
+		Value:     valueGwei,
+		BidCount:  bidCount,
+		SignedBid: signedBid,
+		Manual:    true,
+		Fault:     fault,
+	}
+
+	if high, ok := s.bidTracker.GetHighestCompetitorBid(slot, s.bidCreator.GetBuilderIndex()); ok {
+		event.CompetitorHighGwei = &high
+	}
+
+	switch {
+	case err == nil:
+		event.Success = true
+		event.Status = BidStatusSubmitted
+
+		// A malformed value has no meaning to track; the others were
+		// gossiped as signed.
+		if fault != BidFaultMalformedValue {
+			s.bidTracker.TrackBid(&ExecutionPayloadBid{
+				Slot:         slot,
+				BuilderIndex: s.bidCreator.builderIndex,
+				Value:        valueGwei,
+				BlockHash:    payload.BlockHash,
+			}, true)
+		}
+
+		s.log.WithFields(logrus.Fields{"slot": slot, "fault": fault}).Warn("Fault bid accepted by the beacon node")
+	case signedBid != nil:
+		event.Status = BidStatusConstructed
+		event.Error = err.Error()
+
+		s.log.WithError(err).WithFields(logrus.Fields{"slot": slot, "fault": fault}).Info("Fault bid rejected by the beacon node")
+	default:
+		event.Status = BidStatusFailed
+		event.Error = err.Error()
+	}
+
+	if s.service != nil {
+		s.service.FireBidSubmission(event)
+	}
+
+	return event, nil
+}
+
+// CreateAndSubmitRawValueBid builds and signs a bid of bidValue like
+// CreateAndSubmitBid, but gossips its JSON form with the message's value
+// replaced by rawValue (a JSON token; anything else is sent as a JSON
+// string). The signed bid is returned with its signed value.
+func (c *BidCreator) CreateAndSubmitRawValueBid(
+	ctx context.Context,
+	payload *payload_builder.Payload,
+	bidValue uint64,
+	bidTransform string,
+	rawValue string,
+) (*eth2all.SignedExecutionPayloadBid, error) {
+	raw, ok := c.clClient.(rawBidSubmitter)
+	if !ok {
+		return nil, fmt.Errorf("beacon client cannot submit raw bids")
+	}
+
+	var feeRecipient bellatrix.ExecutionAddress
+
+	copy(feeRecipient[:], payload.FeeRecipient[:])
+
+	targetSlot := payload.Attributes.ProposalSlot
+	targetFork := c.chainSvc.ActiveForkAtEpoch(c.chainSvc.GetEpochOfSlot(targetSlot))
+
+	forkVersion, err := c.chainSvc.GetChainSpec().GetForkVersion(targetFork)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fork version for slot %d: %w", targetSlot, err)
+	}
+
+	signedBid, err := payload_bidder.BuildSignedBid(ctx, payload, payload_bidder.BidParams{
+		BuilderIndex: c.builderIndex,
+		FeeRecipient: feeRecipient,
+		Value:        phase0.Gwei(bidValue),
+		Transform:    bidTransform,
+	}, c.signer, forkVersion, c.chainSvc.GetGenesis().GenesisValidatorsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signed bid: %w", err)
+	}
+
+	body, err := replaceBidValue(signedBid, rawValue)
+	if err != nil {
+		return signedBid, err
+	}
+
+	if err := raw.SubmitRawExecutionPayloadBid(ctx, signedBid.Version, body); err != nil {
+		return signedBid, fmt.Errorf("failed to submit bid: %w", err)
+	}
+
+	payload.AddBid(payload_builder.BidRecord{
+		Transport: payload_builder.BidTransportP2P,
+		Value:     phase0.Gwei(bidValue),
+		At:        time.Now(),
+	})
+
+	return signedBid, nil
+}
+
+// replaceBidValue returns the signed bid's JSON with message.value replaced
+// by rawValue.
+func replaceBidValue(signedBid *eth2all.SignedExecutionPayloadBid, rawValue string) ([]byte, error) {
+	encoded, err := json.Marshal(signedBid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bid: %w", err)
+	}
+
+	var bid map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &bid); err != nil {
+		return nil, fmt.Errorf("failed to decode bid: %w", err)
+	}
+
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(bid["message"], &message); err != nil {
+		return nil, fmt.Errorf("failed to decode bid message: %w", err)
+	}
+
+	value := json.RawMessage(rawValue)
+	if !json.Valid(value) {
+		value, _ = json.Marshal(rawValue)
+	}
+
+	message["value"] = value
+
+	if bid["message"], err = json.Marshal(message); err != nil {
+		return nil, fmt.Errorf("failed to encode bid message: %w", err)
+	}
+
+	return json.Marshal(bid)
+}
@@ -0,0 +1,69 @@
+package p2p_bidder
+
+import (
+	"encoding/json"
+	"testing"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBidFault(t *testing.T) {
+	assert.True(t, IsBidFault(BidFaultZeroValue))
+	assert.True(t, IsBidFault(BidFaultExceedsBalance))
+	assert.True(t, IsBidFault(BidFaultMalformedValue))
+	assert.False(t, IsBidFault(""))
+	assert.False(t, IsBidFault("negative_value"))
+}
+
+func TestReplaceBidValue(t *testing.T) {
+	signedBid := &eth2all.SignedExecutionPayloadBid{
+		Version: version.DataVersionGloas,
+		Message: &eth2all.ExecutionPayloadBid{
+			Version:            version.DataVersionGloas,
+			Slot:               42,
+			Value:              phase0.Gwei(1000),
+			BlobKZGCommitments: []deneb.KZGCommitment{},
+			InclusionListBits:  []byte{0xff, 0xff},
+		},
+	}
+
+	messageOf := func(body []byte) map[string]json.RawMessage {
+		var bid struct {
+			Message   map[string]json.RawMessage `json:"message"`
+			Signature string                     `json:"signature"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &bid))
+		require.NotEmpty(t, bid.Signature)
+
+		return bid.Message
+	}
+
+	tests := []struct {
+		name     string
+		rawValue string
+		want     string
+	}{
+		{name: "json string", rawValue: DefaultMalformedBidValue, want: `"18446744073709551616"`},
+		{name: "json number", rawValue: "-1", want: "-1"},
+		{name: "not json", rawValue: "0xzz", want: `"0xzz"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := replaceBidValue(signedBid, tt.rawValue)
+			require.NoError(t, err)
+
+			message := messageOf(body)
+			assert.JSONEq(t, tt.want, string(message["value"]))
+			assert.JSONEq(t, `"42"`, string(message["slot"]), "other fields are kept")
+		})
+	}
+
+	assert.Equal(t, phase0.Gwei(1000), signedBid.Message.Value, "the signed bid is left untouched")
+}
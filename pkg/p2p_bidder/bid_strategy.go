@@ -0,0 +1,136 @@
+package p2p_bidder
+
+import (
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// BidRound is what a bid strategy prices a slot's next automatic bid from.
+type BidRound struct {
+	// Base is the slot's base value in gwei: the frozen absolute value, or
+	// max(blockValue, min) + subsidy.
+	Base uint64
+	// BidCount is the number of bids already sent for the slot.
+	BidCount int
+	// NewPayload is set when the payload differs from the one last bid on
+	// (or nothing was bid yet).
+	NewPayload bool
+	// LastValue is our last gossiped bid for the slot in gwei (0 = none).
+	LastValue uint64
+	// CompetitorHigh is the highest competitor bid seen for the slot in
+	// gwei (0 = none).
+	CompetitorHigh uint64
+}
+
+// BidStrategy prices a slot's automatic bids. The scheduler decides when a
+// bid may be sent (bid window, interval, snipe moment); the strategy decides
+// what it is worth, or that no bid is worth sending this time (ok false).
+type BidStrategy interface {
+	NextBid(round BidRound) (value uint64, ok bool)
+}
+
+// bidStrategy returns the slot's bid strategy from its frozen bid settings.
+// Strategies price with the scheduler's overflow-clamping gwei arithmetic.
+func (s *Scheduler) bidStrategy(slot phase0.Slot, settings *action_plan.ResolvedBidSettings) BidStrategy {
+	add := func(a, b uint64) uint64 { return s.addGweiClamped(slot, a, b) }
+
+	switch settings.Strategy {
+	case config.BidStrategyFixed, config.BidStrategySnipe:
+		// A snipe is a single shot at the base value.
+		return fixedBidStrategy{}
+	case config.BidStrategyCounterBid:
+		return counterBidStrategy{step: settings.CounterStepGwei, max: settings.CounterMaxGwei, add: add}
+	case config.BidStrategyRandomWalk:
+		return randomWalkBidStrategy{
+			step:  settings.RandomWalkStepGwei,
+			add:   add,
+			mul:   func(a, b uint64) uint64 { return s.mulGweiClamped(slot, a, b) },
+			randN: s.randN,
+		}
+	default:
+		return linearBidStrategy{
+			increase: settings.IncreaseGwei,
+			rebid:    settings.IntervalMs > 0,
+			add:      add,
+			mul:      func(a, b uint64) uint64 { return s.mulGweiClamped(slot, a, b) },
+		}
+	}
+}
+
+// fixedBidStrategy bids the base value once per payload.
+type fixedBidStrategy struct{}
+
+func (fixedBidStrategy) NextBid(round BidRound) (uint64, bool) {
+	return round.Base, round.NewPayload
+}
+
+// linearBidStrategy raises every re-bid by increase: base + BidCount *
+// increase. Without re-bids (no interval) it bids the base value.
+type linearBidStrategy struct {
+	increase uint64
+	rebid    bool
+	add, mul func(a, b uint64) uint64
+}
+
+func (l linearBidStrategy) NextBid(round BidRound) (uint64, bool) {
+	if !l.rebid || round.BidCount == 0 {
+		return round.Base, true
+	}
+
+	return l.add(round.Base, l.mul(uint64(round.BidCount), l.increase)), true //nolint:gosec // BidCount >= 0
+}
+
+// counterBidStrategy bids step above the highest competitor bid (capped at
+// max, 0 = no cap), never below the base value, and re-bids only when that
+// beats our last bid — i.e. when a competitor outbid us.
+type counterBidStrategy struct {
+	step, max uint64
+	add       func(a, b uint64) uint64
+}
+
+func (c counterBidStrategy) NextBid(round BidRound) (uint64, bool) {
+	value := round.Base
+
+	if round.CompetitorHigh > 0 {
+		counter := c.add(round.CompetitorHigh, c.step)
+		if c.max > 0 && counter > c.max {
+			counter = c.max
+		}
+
+		value = max(value, counter)
+	}
+
+	if !round.NewPayload && value <= round.LastValue {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// randomWalkBidStrategy moves every re-bid a uniformly random step of up to
+// step gwei up or down from our last bid, never below the base value.
+type randomWalkBidStrategy struct {
+	step     uint64
+	add, mul func(a, b uint64) uint64
+	randN    func(n uint64) uint64 // uniform in [0, n)
+}
+
+func (w randomWalkBidStrategy) NextBid(round BidRound) (uint64, bool) {
+	if round.BidCount == 0 || round.LastValue == 0 || w.step == 0 {
+		return round.Base, true
+	}
+
+	offset := w.randN(w.add(w.mul(w.step, 2), 1)) // [0, 2*step]
+
+	var value uint64
+
+	if offset >= w.step {
+		value = w.add(round.LastValue, offset-w.step)
+	} else if down := w.step - offset; round.LastValue > down {
+		value = round.LastValue - down
+	}
+
+	return max(value, round.Base), true
+}
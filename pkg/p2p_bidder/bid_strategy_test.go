@@ -0,0 +1,245 @@
+package p2p_bidder
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func TestBidStrategies(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{})
+
+	strategy := func(settings action_plan.ResolvedBidSettings) BidStrategy {
+		return h.scheduler.bidStrategy(testSlot, &settings)
+	}
+
+	type step struct {
+		round BidRound
+		value uint64
+		ok    bool
+	}
+
+	tests := []struct {
+		name     string
+		settings action_plan.ResolvedBidSettings
+		steps    []step
+	}{
+		{
+			name:     "fixed bids the base once per payload",
+			settings: action_plan.ResolvedBidSettings{Strategy: config.BidStrategyFixed, IntervalMs: 100, IncreaseGwei: 10},
+			steps: []step{
+				{round: BidRound{Base: 100, NewPayload: true}, value: 100, ok: true},
+				{round: BidRound{Base: 100, BidCount: 1, LastValue: 100}, ok: false},
+				{round: BidRound{Base: 120, BidCount: 1, LastValue: 100, NewPayload: true}, value: 120, ok: true},
+			},
+		},
+		{
+			name:     "linear adds the increase per re-bid",
+			settings: action_plan.ResolvedBidSettings{Strategy: config.BidStrategyLinear, IntervalMs: 100, IncreaseGwei: 10},
+			steps: []step{
+				{round: BidRound{Base: 100, NewPayload: true}, value: 100, ok: true},
+				{round: BidRound{Base: 100, BidCount: 3, LastValue: 120}, value: 130, ok: true},
+			},
+		},
+		{
+			name:     "linear without interval bids the base",
+			settings: action_plan.ResolvedBidSettings{Strategy: config.BidStrategyLinear, IncreaseGwei: 10},
+			steps: []step{
+				{round: BidRound{Base: 100, BidCount: 2, NewPayload: true}, value: 100, ok: true},
+			},
+		},
+		{
+			name:     "unknown strategy prices linearly",
+			settings: action_plan.ResolvedBidSettings{IntervalMs: 100, IncreaseGwei: 10},
+			steps: []step{
+				{round: BidRound{Base: 100, BidCount: 1}, value: 110, ok: true},
+			},
+		},
+		{
+			name:     "counter-bid outbids the competitor only when outbid",
+			settings: action_plan.ResolvedBidSettings{Strategy: config.BidStrategyCounterBid, CounterStepGwei: 5, CounterMaxGwei: 200},
+			steps: []step{
+				{round: BidRound{Base: 100, NewPayload: true}, value: 100, ok: true},
+				{round: BidRound{Base: 100, BidCount: 1, LastValue: 100, CompetitorHigh: 90}, ok: false},
+				{round: BidRound{Base: 100, BidCount: 1, LastValue: 100, CompetitorHigh: 150}, value: 155, ok: true},
+				{round: BidRound{Base: 100, BidCount: 2, LastValue: 155, CompetitorHigh: 198}, value: 200, ok: true},
+				{round: BidRound{Base: 100, BidCount: 3, LastValue: 200, CompetitorHigh: 250}, ok: false},
+				{round: BidRound{Base: 100, BidCount: 3, LastValue: 200, CompetitorHigh: 250, NewPayload: true}, value: 200, ok: true},
+			},
+		},
+		{
+			name:     "counter-bid never bids below the base",
+			settings: action_plan.ResolvedBidSettings{Strategy: config.BidStrategyCounterBid, CounterStepGwei: 5, CounterMaxGwei: 50},
+			steps: []step{
+				{round: BidRound{Base: 100, CompetitorHigh: 300, NewPayload: true}, value: 100, ok: true},
+			},
+		},
+		{
+			name:     "snipe is a single shot at the base",
+			settings: action_plan.ResolvedBidSettings{Strategy: config.BidStrategySnipe, Snipe: true},
+			steps: []step{
+				{round: BidRound{Base: 100, NewPayload: true}, value: 100, ok: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := strategy(tt.settings)
+
+			for i, st := range tt.steps {
+				value, ok := s.NextBid(st.round)
+				require.Equal(t, st.ok, ok, "step %d", i)
+
+				if ok {
+					assert.Equal(t, st.value, value, "step %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomWalkBidStrategy(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{})
+
+	var draws []uint64
+
+	h.scheduler.randN = func(n uint64) uint64 {
+		assert.Equal(t, uint64(21), n, "offsets span [0, 2*step]")
+
+		draw := draws[0]
+		draws = draws[1:]
+
+		return draw
+	}
+
+	s := h.scheduler.bidStrategy(testSlot, &action_plan.ResolvedBidSettings{
+		Strategy:           config.BidStrategyRandomWalk,
+		RandomWalkStepGwei: 10,
+	})
+
+	value, ok := s.NextBid(BidRound{Base: 100, NewPayload: true})
+	require.True(t, ok)
+	assert.Equal(t, uint64(100), value, "the first bid is the base")
+
+	draws = []uint64{20, 0, 3}
+
+	value, _ = s.NextBid(BidRound{Base: 100, BidCount: 1, LastValue: 100})
+	assert.Equal(t, uint64(110), value, "largest step up")
+
+	value, _ = s.NextBid(BidRound{Base: 100, BidCount: 2, LastValue: 110})
+	assert.Equal(t, uint64(100), value, "largest step down")
+
+	value, _ = s.NextBid(BidRound{Base: 100, BidCount: 3, LastValue: 104})
+	assert.Equal(t, uint64(100), value, "never below the base")
+
+	// A step too large for 2*step+1 clamps instead of wrapping.
+	h.scheduler.randN = func(n uint64) uint64 { return n - 1 }
+
+	s = h.scheduler.bidStrategy(testSlot, &action_plan.ResolvedBidSettings{
+		Strategy:           config.BidStrategyRandomWalk,
+		RandomWalkStepGwei: math.MaxUint64 / 2,
+	})
+
+	value, _ = s.NextBid(BidRound{Base: 100, BidCount: 1, LastValue: 100})
+	assert.Equal(t, uint64(math.MaxUint64/2+100), value)
+}
+
+func TestSchedulerCounterBidStrategy(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{
+		epbsEnabled: true,
+	})
+	h.cfg.EPBS.BidInterval = 100
+	h.cfg.EPBS.BidStrategy = config.BidStrategyCounterBid
+	h.cfg.EPBS.CounterBidStepGwei = 5
+
+	h.preparePayload(testSlot, 100, false)
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1000)
+
+	event := h.nextEvent()
+	require.NotNil(t, event)
+	assert.Equal(t, uint64(100), event.Value)
+
+	ageLastBid := func() {
+		h.scheduler.mu.Lock()
+		h.scheduler.slotStates[testSlot].LastBidTime = time.Now().Add(-time.Second)
+		h.scheduler.mu.Unlock()
+	}
+
+	// Uncontested: no re-bid against ourselves.
+	ageLastBid()
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1200)
+	assert.Nil(t, h.nextEvent())
+
+	// Outbid: answer the competitor.
+	h.scheduler.bidTracker.TrackBid(newTestBid(testSlot, 99, 300), false)
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1300)
+
+	event = h.nextEvent()
+	require.NotNil(t, event)
+	assert.Equal(t, uint64(305), event.Value)
+	assert.Equal(t, 2, event.BidCount)
+	assert.Len(t, h.submitter.submitted, 2)
+}
+
+func TestSchedulerCounterBidReactsToCompetitorBids(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		react    bool
+	}{
+		{name: "counter-bid answers immediately", strategy: config.BidStrategyCounterBid, react: true},
+		{name: "linear waits for its interval", strategy: config.BidStrategyLinear},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newSchedulerHarness(t, harnessOptions{
+				epbsEnabled: true,
+			})
+			h.cfg.EPBS.BidInterval = 60000 // no timer-driven re-bid in this test
+			h.cfg.EPBS.BidStrategy = tt.strategy
+			h.cfg.EPBS.CounterBidStepGwei = 5
+
+			// testSlot started one second ago.
+			h.chainSvc.genesis.GenesisTime = time.Now().Add(-time.Second).
+				Add(-time.Duration(testSlot) * h.chainSvc.spec.SecondsPerSlot)
+
+			// A competitor bid before our first bid is left to the tick.
+			h.scheduler.OnCompetitorBid(context.Background(), testSlot)
+			assert.Nil(t, h.nextEvent())
+
+			h.preparePayload(testSlot, 100, false)
+			h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1000)
+			require.NotNil(t, h.nextEvent())
+
+			h.scheduler.bidTracker.TrackBid(newTestBid(testSlot, 99, 300), false)
+			h.scheduler.OnCompetitorBid(context.Background(), testSlot)
+
+			event := h.nextEvent()
+			if !tt.react {
+				assert.Nil(t, event)
+				return
+			}
+
+			require.NotNil(t, event)
+			assert.Equal(t, uint64(305), event.Value)
+
+			// A competitor bid below ours is held, and the interval still
+			// applies to the next tick.
+			h.scheduler.bidTracker.TrackBid(newTestBid(testSlot, 98, 200), false)
+			h.scheduler.OnCompetitorBid(context.Background(), testSlot)
+			h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1100)
+			assert.Nil(t, h.nextEvent())
+			assert.Len(t, h.submitter.submitted, 2)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package p2p_bidder
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/sirupsen/logrus"
@@ -11,7 +13,10 @@ import (
 type BidTracker struct {
 	slotBids      map[phase0.Slot]*SlotBids
 	ourBuilderIdx uint64
-	mu            sync.RWMutex
+	// siblings are the builder indices of the other builder keys run by
+	// this instance: never competitors of ours.
+	siblings map[uint64]bool
+	mu       sync.RWMutex
 
 	log logrus.FieldLogger
 }
@@ -39,6 +44,7 @@ func (t *BidTracker) TrackBid(bid *ExecutionPayloadBid, isOurs bool) {
 	tracked := &TrackedBid{
 		Bid:          bid,
 		BuilderIndex: bid.BuilderIndex,
+		ReceivedAt:   time.Now(),
 		IsOurs:       isOurs,
 	}
 
@@ -61,6 +67,79 @@ func (t *BidTracker) TrackBid(bid *ExecutionPayloadBid, isOurs bool) {
 	}).Debug("Tracked bid")
 }
 
+// TrackPeerBid merges a bid shared by a mesh peer into the competitor view.
+// Callers pass only bids whose signature verified against the builder's
+// registered pubkey (see peer_mesh.MergeSnapshot). Peer bids never replace
+// our own bid or a locally observed bid of equal or higher value, so local
+// gossip stays authoritative. Returns whether the bid was merged.
+func (t *BidTracker) TrackPeerBid(bid *ExecutionPayloadBid, peer string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if bid.BuilderIndex == t.ourBuilderIdx || t.siblings[bid.BuilderIndex] {
+		return false
+	}
+
+	slotBids, ok := t.slotBids[bid.Slot]
+	if !ok {
+		slotBids = NewSlotBids(bid.Slot)
+		t.slotBids[bid.Slot] = slotBids
+	}
+
+	if existing := slotBids.Bids[bid.BuilderIndex]; existing != nil && existing.Bid.Value >= bid.Value {
+		return false
+	}
+
+	tracked := &TrackedBid{
+		Bid:          bid,
+		BuilderIndex: bid.BuilderIndex,
+		ReceivedAt:   time.Now(),
+		Peer:         peer,
+	}
+
+	slotBids.Bids[bid.BuilderIndex] = tracked
+
+	if slotBids.HighestBid == nil || bid.Value > slotBids.HighestBid.Bid.Value {
+		slotBids.HighestBid = tracked
+	}
+
+	return true
+}
+
+// BidsSince returns copies of all tracked bids for slots >= minSlot, ordered
+// by slot and builder index. With localOnly, bids merged from mesh peers are
+// left out (peers only relay what they observed themselves).
+func (t *BidTracker) BidsSince(minSlot phase0.Slot, localOnly bool) []TrackedBid {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	bids := make([]TrackedBid, 0, 16)
+
+	for slot, slotBids := range t.slotBids {
+		if slot < minSlot {
+			continue
+		}
+
+		for _, tracked := range slotBids.Bids {
+			if localOnly && tracked.Peer != "" {
+				continue
+			}
+
+			bids = append(bids, *tracked)
+		}
+	}
+
+	sort.Slice(bids, func(i, j int) bool {
+		if bids[i].Bid.Slot != bids[j].Bid.Slot {
+			return bids[i].Bid.Slot < bids[j].Bid.Slot
+		}
+
+		return bids[i].BuilderIndex < bids[j].BuilderIndex
+	})
+
+	return bids
+}
+
 // GetHighestBid returns the highest bid for a slot.
 func (t *BidTracker) GetHighestBid(slot phase0.Slot) *TrackedBid {
 	t.mu.RLock()
@@ -75,8 +154,9 @@ func (t *BidTracker) GetHighestBid(slot phase0.Slot) *TrackedBid {
 }
 
 // GetHighestCompetitorBid returns the highest tracked bid value (gwei) for
-// the slot excluding our own builder index, and whether any competitor bid is
-// known. Unlike GetHighestBid it can never report our own bid back to us.
+// the slot excluding our own and our siblings' builder indices, and whether
+// any competitor bid is known. Unlike GetHighestBid it can never report our
+// own bid back to us.
 func (t *BidTracker) GetHighestCompetitorBid(slot phase0.Slot, ourBuilderIndex uint64) (uint64, bool) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -91,7 +171,7 @@ func (t *BidTracker) GetHighestCompetitorBid(slot phase0.Slot, ourBuilderIndex u
 	found := false
 
 	for builderIndex, tracked := range slotBids.Bids {
-		if builderIndex == ourBuilderIndex || tracked.IsOurs {
+		if builderIndex == ourBuilderIndex || tracked.IsOurs || t.siblings[builderIndex] {
 			continue
 		}
 
@@ -117,6 +197,24 @@ func (t *BidTracker) GetOurBid(slot phase0.Slot) *TrackedBid {
 	return slotBids.OurBid
 }
 
+// OurBidSlotsSince returns the slots >= minSlot we have bid for, in order.
+func (t *BidTracker) OurBidSlotsSince(minSlot phase0.Slot) []phase0.Slot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	slots := make([]phase0.Slot, 0, 2)
+
+	for slot, slotBids := range t.slotBids {
+		if slot >= minSlot && slotBids.OurBid != nil {
+			slots = append(slots, slot)
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	return slots
+}
+
 // GetSlotBids returns all bids for a slot.
 func (t *BidTracker) GetSlotBids(slot phase0.Slot) *SlotBids {
 	t.mu.RLock()
@@ -144,3 +242,16 @@ func (t *BidTracker) SetBuilderIndex(index uint64) {
 
 	t.ourBuilderIdx = index
 }
+
+// SetSiblingIndices replaces the builder indices of our sibling builders.
+func (t *BidTracker) SetSiblingIndices(indices []uint64) {
+	siblings := make(map[uint64]bool, len(indices))
+	for _, index := range indices {
+		siblings[index] = true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.siblings = siblings
+}
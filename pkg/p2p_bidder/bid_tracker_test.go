@@ -178,6 +178,32 @@ func TestBidTracker_GetHighestCompetitorBid(t *testing.T) {
 	}
 }
 
+// TestBidTracker_SiblingIndices asserts sibling builders' bids (our other
+// builder keys) are never competition, neither gossiped nor shared by peers.
+func TestBidTracker_SiblingIndices(t *testing.T) {
+	tracker := newTestBidTracker(1)
+	tracker.SetSiblingIndices([]uint64{4})
+
+	tracker.TrackBid(newTestBid(100, 1, 300), true)
+	tracker.TrackBid(newTestBid(100, 4, 900), false)
+	tracker.TrackBid(newTestBid(100, 2, 500), false)
+
+	value, ok := tracker.GetHighestCompetitorBid(100, 1)
+	require.True(t, ok)
+	assert.Equal(t, uint64(500), value, "the sibling's higher bid is not competition")
+
+	assert.False(t, tracker.TrackPeerBid(newTestBid(101, 4, 700), "peer-a"), "sibling bids from peers are dropped")
+
+	_, ok = tracker.GetHighestCompetitorBid(101, 1)
+	assert.False(t, ok)
+
+	tracker.SetSiblingIndices(nil)
+
+	value, ok = tracker.GetHighestCompetitorBid(100, 1)
+	require.True(t, ok)
+	assert.Equal(t, uint64(900), value)
+}
+
 func TestBidTracker_GetHighestBidUnknownSlot(t *testing.T) {
 	tracker := newTestBidTracker(1)
 
@@ -231,6 +257,41 @@ func TestBidTracker_SetBuilderIndex(t *testing.T) {
 	assert.True(t, ourBid.IsOurs)
 }
 
+func TestBidTracker_TrackPeerBid(t *testing.T) {
+	tracker := newTestBidTracker(1)
+
+	tracker.TrackBid(newTestBid(100, 2, 500), false)
+
+	assert.False(t, tracker.TrackPeerBid(newTestBid(100, 1, 900), "peer-a"), "our own bid is never merged")
+	assert.False(t, tracker.TrackPeerBid(newTestBid(100, 2, 400), "peer-a"), "a lower peer value keeps the local bid")
+	assert.True(t, tracker.TrackPeerBid(newTestBid(100, 3, 700), "peer-a"))
+
+	high, ok := tracker.GetHighestCompetitorBid(100, 1)
+	require.True(t, ok)
+	assert.Equal(t, uint64(700), high, "peer bids feed the competitor view")
+
+	local := tracker.BidsSince(100, true)
+	require.Len(t, local, 1)
+	assert.Equal(t, uint64(2), local[0].BuilderIndex)
+
+	all := tracker.BidsSince(100, false)
+	require.Len(t, all, 2)
+	assert.Equal(t, "peer-a", all[1].Peer)
+	assert.Empty(t, tracker.BidsSince(101, false))
+}
+
 func uint64Ptr(v uint64) *uint64 {
 	return &v
 }
+
+func TestBidTracker_OurBidSlotsSince(t *testing.T) {
+	tracker := newTestBidTracker(1)
+
+	tracker.TrackBid(newTestBid(98, 1, 100), true)
+	tracker.TrackBid(newTestBid(101, 1, 100), true)
+	tracker.TrackBid(newTestBid(100, 1, 100), true)
+	tracker.TrackBid(newTestBid(102, 2, 100), false) // competitor only
+
+	assert.Equal(t, []phase0.Slot{100, 101}, tracker.OurBidSlotsSince(100))
+	assert.Empty(t, tracker.OurBidSlotsSince(103))
+}
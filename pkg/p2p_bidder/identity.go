@@ -0,0 +1,135 @@
+package p2p_bidder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+)
+
+// maxIdentityStateLagEpochs is how many epochs the builder registry the
+// identity check runs against may lag the wall-clock epoch before it is
+// considered stale.
+const maxIdentityStateLagEpochs = 2
+
+// SetExpectedWithdrawalAddress sets the execution (withdrawal) address the
+// builder's beacon state record must carry. Must be called before Start; nil
+// leaves the address unchecked.
+func (s *Service) SetExpectedWithdrawalAddress(addr *bellatrix.ExecutionAddress) {
+	s.expectedWithdrawalAddr = addr
+}
+
+// IdentityError returns why the builder identity check failed, or empty when
+// it passed (or has not run yet). Bidding is halted while it is set.
+func (s *Service) IdentityError() string {
+	if msg := s.identityErr.Load(); msg != nil {
+		return *msg
+	}
+
+	return ""
+}
+
+// VerifyBuilderIdentity cross-checks the resolved builder index against the
+// beacon state: our pubkey must appear exactly once, at that index, with the
+// expected withdrawal address, in a registry that is not stale. A mismatch
+// halts bidding until a later check passes. Builders not (yet) in the state
+// are skipped — there is no index to verify and nothing is bid.
+func (s *Service) VerifyBuilderIdentity() {
+	switch s.registrationState.Load() {
+	case RegistrationStateRegistered, RegistrationStatePendingFinalization,
+		RegistrationStateExiting:
+	default:
+		s.identityErr.Store(nil)
+		return
+	}
+
+	var err error
+
+	if stats := s.latestEpochStats(); stats == nil {
+		err = fmt.Errorf("no builder registry within %d epochs of the current epoch %d",
+			maxIdentityStateLagEpochs, s.chainSvc.GetCurrentEpoch())
+	} else {
+		err = verifyBuilderRecord(stats.Builders, s.builderPubkey, s.builderIndex, s.expectedWithdrawalAddr)
+	}
+
+	s.setIdentityError(err)
+}
+
+// latestEpochStats returns the newest cached epoch stats that lag the current
+// epoch by at most maxIdentityStateLagEpochs, or nil when all are stale.
+func (s *Service) latestEpochStats() *chain.EpochStats {
+	current := s.chainSvc.GetCurrentEpoch()
+
+	for lag := phase0.Epoch(0); lag <= maxIdentityStateLagEpochs && lag <= current; lag++ {
+		if stats := s.chainSvc.GetEpochStats(current - lag); stats != nil {
+			return stats
+		}
+	}
+
+	return nil
+}
+
+// setIdentityError records the identity check outcome, logging transitions.
+func (s *Service) setIdentityError(err error) {
+	previous := s.IdentityError()
+
+	if err == nil {
+		s.identityErr.Store(nil)
+
+		if previous != "" {
+			s.log.WithField("builder_index", s.builderIndex).Info("Builder identity verified, bidding resumed")
+		}
+
+		return
+	}
+
+	msg := err.Error()
+	s.identityErr.Store(&msg)
+
+	if msg != previous {
+		s.log.WithFields(logrus.Fields{
+			"builder_index":  s.builderIndex,
+			"builder_pubkey": fmt.Sprintf("%x", s.builderPubkey[:8]),
+		}).WithError(err).Error("Builder identity mismatch, bidding halted")
+	}
+}
+
+// verifyBuilderRecord checks that pubkey appears exactly once in the builder
+// registry, at index, with the expected withdrawal address (when set).
+func verifyBuilderRecord(
+	builders []*chain.BuilderInfo,
+	pubkey phase0.BLSPubKey,
+	index uint64,
+	expectedAddr *bellatrix.ExecutionAddress,
+) error {
+	var matches []string
+
+	for _, builder := range builders {
+		if builder != nil && builder.Pubkey == pubkey {
+			matches = append(matches, fmt.Sprintf("%d", builder.Index))
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return fmt.Errorf("builder pubkey not in the builder registry")
+	case len(matches) > 1:
+		return fmt.Errorf("builder pubkey appears %d times in the builder registry (indices %s)",
+			len(matches), strings.Join(matches, ", "))
+	}
+
+	if index >= uint64(len(builders)) || builders[index] == nil || builders[index].Pubkey != pubkey {
+		return fmt.Errorf("resolved builder index %d does not hold our pubkey (registry index %s)", index, matches[0])
+	}
+
+	if expectedAddr != nil && builders[index].ExecutionAddress != *expectedAddr {
+		return fmt.Errorf("builder %d withdrawal address %s does not match the expected %s",
+			index, builders[index].ExecutionAddress, expectedAddr)
+	}
+
+	return nil
+}
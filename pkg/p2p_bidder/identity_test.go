@@ -0,0 +1,36 @@
+package p2p_bidder
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+)
+
+func TestVerifyBuilderRecord(t *testing.T) {
+	ours := phase0.BLSPubKey{0x01}
+	other := phase0.BLSPubKey{0x02}
+	addr := bellatrix.ExecutionAddress{0xaa}
+	wrongAddr := bellatrix.ExecutionAddress{0xbb}
+
+	registry := func(pubkeys ...phase0.BLSPubKey) []*chain.BuilderInfo {
+		builders := make([]*chain.BuilderInfo, len(pubkeys))
+		for i, pubkey := range pubkeys {
+			builders[i] = &chain.BuilderInfo{Index: uint64(i), Pubkey: pubkey, ExecutionAddress: addr}
+		}
+
+		return builders
+	}
+
+	assert.NoError(t, verifyBuilderRecord(registry(other, ours), ours, 1, &addr))
+	assert.NoError(t, verifyBuilderRecord(registry(other, ours), ours, 1, nil), "address unchecked")
+
+	assert.ErrorContains(t, verifyBuilderRecord(registry(other), ours, 0, nil), "not in the builder registry")
+	assert.ErrorContains(t, verifyBuilderRecord(registry(ours, other, ours), ours, 0, nil), "appears 2 times")
+	assert.ErrorContains(t, verifyBuilderRecord(registry(other, ours), ours, 0, nil), "index 0 does not hold our pubkey")
+	assert.ErrorContains(t, verifyBuilderRecord(registry(other, ours), ours, 5, nil), "index 5 does not hold our pubkey")
+	assert.ErrorContains(t, verifyBuilderRecord(registry(other, ours), ours, 1, &wrongAddr), "withdrawal address")
+}
@@ -2,10 +2,12 @@ package p2p_bidder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"math/bits"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -16,12 +18,22 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/metrics"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/signer"
 )
 
+// ErrNoCachedPayload is returned by a manual bid when no payload was built
+// for the slot (or it was evicted from the cache).
+var ErrNoCachedPayload = errors.New("no cached payload for slot")
+
+// ErrBidsClosed is returned by a manual bid once the slot's block was
+// received and no bid can make it anymore.
+var ErrBidsClosed = errors.New("bidding closed for slot (block received)")
+
 // SlotState tracks the bidding state for a single slot.
 type SlotState struct {
 	LastBidTime      time.Time
@@ -29,6 +41,20 @@ type SlotState struct {
 	BidCount         int
 	BidsClosed       bool // Block received, no more bids possible
 	NoPrefsWarnedFor bool // Missing-preferences skip already reported for this slot
+	BudgetRefused    bool // Exhausted-budget skip already reported for this slot
+	SnipeAborted     bool // Contested-slot snipe skip already reported for this slot
+	CounterDue       bool // A competitor bid arrived since the last counter-bid evaluation
+
+	// SkipReason/SkipDetail are why the slot's latest evaluation did not bid
+	// (a BidSkipReason* constant), reported when the slot closes without a
+	// bid of ours.
+	SkipReason string
+	SkipDetail string
+
+	// Last gossiped bid (stale-bid replacement bookkeeping).
+	LastSubmittedHash   phase0.Hash32
+	LastSubmittedParent phase0.Hash32
+	LastSubmittedValue  uint64
 
 	// Frozen is the slot's immutable action-plan snapshot, resolved on the
 	// first scheduler evaluation of the slot (nil until then).
@@ -43,9 +69,11 @@ type Scheduler struct {
 	bidTracker     *BidTracker
 	payloadCache   *payload_builder.PayloadCache
 	service        *Service // Reference to parent service for firing events
-	blsSigner      *signer.BLSSigner
+	blsSigner      signer.Signer
 	propPrefsStore *memstore.Store[phase0.Slot, *gloasspec.SignedProposerPreferences]
 	planSvc        *action_plan.PlanService // per-slot scheduling/settings authority
+	budget         *BidBudget               // epoch bid budget; nil = no budget
+	randN          func(n uint64) uint64    // random-walk step source, uniform in [0, n)
 	log            logrus.FieldLogger
 
 	// Simple state tracking per slot
@@ -55,16 +83,18 @@ type Scheduler struct {
 
 // NewScheduler creates a new scheduler. planSvc is the mandatory per-slot
 // action plan service: every bid setting the scheduler acts on comes from its
-// frozen slot snapshots, never from the live config.
+// frozen slot snapshots, never from the live config. budget, when set, caps
+// the automatic bids to the epoch bid budget (it reads its limits live).
 func NewScheduler(
 	chainSvc chain.Service,
 	bidCreator *BidCreator,
 	bidTracker *BidTracker,
 	payloadCache *payload_builder.PayloadCache,
 	service *Service,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	propPrefsStore *memstore.Store[phase0.Slot, *gloasspec.SignedProposerPreferences],
 	planSvc *action_plan.PlanService,
+	budget *BidBudget,
 	log logrus.FieldLogger,
 ) *Scheduler {
 	return &Scheduler{
@@ -76,6 +106,8 @@ func NewScheduler(
 		blsSigner:      blsSigner,
 		propPrefsStore: propPrefsStore,
 		planSvc:        planSvc,
+		budget:         budget,
+		randN:          rand.Uint64N,
 		slotStates:     make(map[phase0.Slot]*SlotState),
 		log:            log.WithField("component", "scheduler"),
 	}
@@ -92,21 +124,83 @@ func (s *Scheduler) getSlotState(slot phase0.Slot) *SlotState {
 	return state
 }
 
-// OnHeadEvent closes bidding for the slot — once a block is produced, no more bids can make it.
-func (s *Scheduler) OnHeadEvent(event *beacon.HeadEvent) {
+// OnHeadEvent closes bidding for the slot — once a block is produced, no more
+// bids can make it. It returns why the slot closed without a bid of ours, or
+// nil when we bid on it (or it was already closed).
+func (s *Scheduler) OnHeadEvent(event *beacon.HeadEvent) *BidSkippedEvent {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	slotState := s.getSlotState(event.Slot)
-	if !slotState.BidsClosed {
-		slotState.BidsClosed = true
-		s.log.WithField("slot", event.Slot).Debug("Bidding closed for slot (block received)")
+	if slotState.BidsClosed {
+		return nil
+	}
+
+	slotState.BidsClosed = true
+	s.log.WithField("slot", event.Slot).Debug("Bidding closed for slot (block received)")
+
+	if slotState.BidCount > 0 {
+		return nil
+	}
+
+	skip := &BidSkippedEvent{Slot: event.Slot, Reason: slotState.SkipReason, Detail: slotState.SkipDetail}
+
+	switch {
+	case slotState.Frozen == nil:
+		skip.Reason = BidSkipReasonNotEvaluated
+	case slotState.Frozen.Bid == nil:
+		skip.Reason = BidSkipReasonSuppressed
+	case skip.Reason == "":
+		skip.Reason = BidSkipReasonOutsideWindow
+	}
+
+	return skip
+}
+
+// noteSkip records why the slot's current evaluation did not bid. The bid
+// window not being open (yet) never replaces a more specific reason noted on
+// an earlier tick.
+func (s *Scheduler) noteSkip(slot phase0.Slot, reason, detail string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.noteSkipLocked(s.getSlotState(slot), reason, detail)
+}
+
+// noteSkipLocked is noteSkip for a slot state already held under mu.
+func (s *Scheduler) noteSkipLocked(state *SlotState, reason, detail string) {
+	if reason == BidSkipReasonOutsideWindow && state.SkipReason != "" {
+		return
 	}
+
+	state.SkipReason = reason
+	state.SkipDetail = detail
+}
+
+// OnCompetitorBid evaluates a counter-bid slot as soon as a competitor bid
+// for it arrives, instead of waiting for the next bid interval tick. Only
+// slots we already bid on react; the first bid and every other strategy stay
+// timer-driven.
+func (s *Scheduler) OnCompetitorBid(ctx context.Context, slot phase0.Slot) {
+	s.mu.Lock()
+	state, ok := s.slotStates[slot]
+
+	if !ok || state.BidCount == 0 || state.BidsClosed || state.Frozen == nil ||
+		state.Frozen.Bid == nil || state.Frozen.Bid.Strategy != config.BidStrategyCounterBid {
+		s.mu.Unlock()
+		return
+	}
+
+	state.CounterDue = true
+	s.mu.Unlock()
+
+	now := s.chainSvc.Clock().Now()
+	s.checkSlotForBidding(ctx, slot, now, now.Sub(s.chainSvc.SlotToTime(slot)).Milliseconds())
 }
 
 // ProcessTick is called frequently to check if any bids are due.
 func (s *Scheduler) ProcessTick(ctx context.Context) {
-	now := time.Now()
+	now := s.chainSvc.Clock().Now()
 
 	// Calculate current slot and position within slot
 	genesisTime := s.chainSvc.GetGenesis().GenesisTime
@@ -171,12 +265,25 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 	// Are we in bid period for this slot?
 	// msRelativeToSlot is negative if we're before the slot starts
 	if msRelativeToSlot < bidSettings.StartMs || msRelativeToSlot >= bidSettings.EndMs {
+		s.noteSkip(slot, BidSkipReasonOutsideWindow,
+			fmt.Sprintf("bid window %d..%d ms", bidSettings.StartMs, bidSettings.EndMs))
+
+		return
+	}
+
+	// Snipe mode holds the slot's single bid until shortly before the window
+	// closes, so the competition has shown its hand.
+	if bidSettings.Snipe && msRelativeToSlot < bidSettings.EndMs-bidSettings.SnipeLeadMs {
+		s.noteSkip(slot, BidSkipReasonOutsideWindow,
+			fmt.Sprintf("snipe held until %d ms", bidSettings.EndMs-bidSettings.SnipeLeadMs))
+
 		return
 	}
 
 	// Get payload from builder cache
 	payload := s.payloadCache.Get(slot)
 	if payload == nil {
+		s.noteSkip(slot, BidSkipReasonNoPayload, "")
 		return
 	}
 
@@ -195,6 +302,7 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 		state := s.getSlotState(slot)
 		alreadyWarned := state.NoPrefsWarnedFor
 		state.NoPrefsWarnedFor = true
+		s.noteSkipLocked(state, BidSkipReasonNoPrefs, "")
 		s.mu.Unlock()
 
 		if !alreadyWarned {
@@ -217,6 +325,12 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 		return
 	}
 
+	if bidSettings.Snipe && s.snipeContested(slot, payload, bidSettings) {
+		return
+	}
+
+	competitorHigh, _ := s.bidTracker.GetHighestCompetitorBid(slot, s.bidCreator.GetBuilderIndex())
+
 	s.mu.Lock()
 	state := s.getSlotState(slot)
 
@@ -229,13 +343,25 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 		return
 	}
 
+	// A snipe is a single shot.
+	if bidSettings.Snipe && state.BidCount > 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	// A counter-bid answers a competitor bid right away (OnCompetitorBid),
+	// bypassing the interval and single-bid gates; the strategy still holds
+	// unless the competitor outbid us.
+	counterDue := state.CounterDue
+	state.CounterDue = false
+
 	// Check bid interval
-	if bidSettings.IntervalMs > 0 {
-		if time.Since(state.LastBidTime) < time.Duration(bidSettings.IntervalMs)*time.Millisecond {
+	if !counterDue && bidSettings.IntervalMs > 0 {
+		if now.Sub(state.LastBidTime) < time.Duration(bidSettings.IntervalMs)*time.Millisecond {
 			s.mu.Unlock()
 			return
 		}
-	} else {
+	} else if !counterDue {
 		// Single bid mode - only bid if payload changed or never bid
 		if state.BidCount > 0 && state.LastBidHash == payload.BlockHash {
 			s.mu.Unlock()
@@ -257,15 +383,66 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 		bidBase = s.addGweiClamped(slot, bidBase, bidSettings.SubsidyGwei)
 	}
 
-	// Re-bid increase applies in interval mode regardless of the base source.
-	bidValue := bidBase
-	if bidSettings.IntervalMs > 0 && state.BidCount > 0 {
-		increase := s.mulGweiClamped(slot, uint64(state.BidCount), bidSettings.IncreaseGwei) //nolint:gosec // BidCount >= 0
-		bidValue = s.addGweiClamped(slot, bidValue, increase)
+	// The slot's strategy prices the bid from the base, regardless of the
+	// base source, or holds it.
+	bidValue, ok := s.bidStrategy(slot, bidSettings).NextBid(BidRound{
+		Base:           bidBase,
+		BidCount:       state.BidCount,
+		NewPayload:     state.BidCount == 0 || state.LastBidHash != payload.BlockHash,
+		LastValue:      state.LastSubmittedValue,
+		CompetitorHigh: competitorHigh,
+	})
+	if !ok {
+		s.noteSkipLocked(state, BidSkipReasonStrategyHold, bidSettings.Strategy)
+		s.mu.Unlock()
+
+		return
+	}
+
+	// A replacement bid on the same parent payload only propagates if it
+	// outbids our earlier one (gossip keeps the highest bid per slot and
+	// parent block hash), so lift it just above.
+	if bidSettings.ReplaceStale && state.LastSubmittedValue > 0 &&
+		state.LastSubmittedHash != payload.BlockHash &&
+		state.LastSubmittedParent == payload.Attributes.ParentBlockHash &&
+		bidValue <= state.LastSubmittedValue {
+		bidValue = s.addGweiClamped(slot, state.LastSubmittedValue, 1)
 	}
 
 	s.mu.Unlock()
 
+	event := &BidSubmissionEvent{
+		Canary:    bidSettings.Canary,
+		Snipe:     bidSettings.Snipe,
+		MinRaised: bidSettings.ValueGwei == nil && weiToGweiClamped(payload.BlockValue) < bidSettings.MinGwei,
+	}
+	if prefsBypassed {
+		event.Warning = "no proposer preferences for slot — bid sent anyway (ignore_missing_prefs)"
+	}
+
+	// The epoch bid budget caps the bid at the slot's share of what is left
+	// and refuses it once nothing is.
+	if s.budget != nil {
+		allowance, ok := s.budget.Allowance(slot, weiToGweiClamped(payload.BlockValue))
+
+		switch {
+		case ok && allowance == 0:
+			s.reportBudgetRefused(slot, payload)
+			return
+		case ok && bidValue > allowance:
+			s.log.WithFields(logrus.Fields{
+				"slot":      slot,
+				"bid_value": bidValue,
+				"allowance": allowance,
+			}).Debug("Bid capped to the slot's epoch budget allowance")
+
+			bidValue = allowance
+			event.BudgetCapped = true
+
+			s.budget.RecordCapped()
+		}
+	}
+
 	s.log.WithFields(logrus.Fields{
 		"slot":         slot,
 		"bid_value":    bidValue,
@@ -274,13 +451,141 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 		"ms_into_slot": msRelativeToSlot,
 	}).Info("Creating and submitting bid")
 
+	s.submitBid(ctx, slot, payload, state, bidValue, now, event)
+}
+
+// snipeContested reports whether a competitor bid above the snipe threshold
+// was seen for the slot, which aborts its snipe. The skip is reported once
+// per slot (this runs on a 10ms tick).
+func (s *Scheduler) snipeContested(
+	slot phase0.Slot,
+	payload *payload_builder.Payload,
+	bidSettings *action_plan.ResolvedBidSettings,
+) bool {
+	high, ok := s.bidTracker.GetHighestCompetitorBid(slot, s.bidCreator.GetBuilderIndex())
+	if !ok || high <= bidSettings.SnipeMaxCompetitorGwei {
+		return false
+	}
+
+	s.mu.Lock()
+	state := s.getSlotState(slot)
+	alreadyReported := state.SnipeAborted || state.BidCount > 0
+	state.SnipeAborted = true
+	s.noteSkipLocked(state, BidSkipReasonSnipeAborted,
+		fmt.Sprintf("competitor bid %d gwei above %d gwei", high, bidSettings.SnipeMaxCompetitorGwei))
+	s.mu.Unlock()
+
+	if alreadyReported {
+		return true
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"slot":            slot,
+		"competitor_high": high,
+		"threshold":       bidSettings.SnipeMaxCompetitorGwei,
+	}).Info("Competitor bid above snipe threshold — skipping slot")
+
+	if s.service != nil {
+		s.service.FireBidSubmission(&BidSubmissionEvent{
+			Slot:               slot,
+			BlockHash:          payload.BlockHash,
+			Success:            false,
+			Warning:            fmt.Sprintf("snipe aborted: competitor bid %d gwei above threshold %d gwei", high, bidSettings.SnipeMaxCompetitorGwei),
+			CompetitorHighGwei: &high,
+			Snipe:              true,
+		})
+	}
+
+	return true
+}
+
+// reportBudgetRefused reports a slot skipped on an exhausted epoch bid
+// budget, once per slot (this runs on a 10ms tick).
+func (s *Scheduler) reportBudgetRefused(slot phase0.Slot, payload *payload_builder.Payload) {
+	s.mu.Lock()
+	state := s.getSlotState(slot)
+	alreadyReported := state.BudgetRefused
+	state.BudgetRefused = true
+	s.noteSkipLocked(state, BidSkipReasonBudget, "")
+	s.mu.Unlock()
+
+	if alreadyReported {
+		return
+	}
+
+	s.budget.RecordRefused()
+
+	s.log.WithFields(logrus.Fields{
+		"slot":       slot,
+		"block_hash": fmt.Sprintf("%x", payload.BlockHash[:8]),
+	}).Warn("Epoch bid budget exhausted — skipping bids")
+
+	if s.service != nil {
+		s.service.FireBidSubmission(&BidSubmissionEvent{
+			Slot:      slot,
+			BlockHash: payload.BlockHash,
+			Success:   false,
+			Warning:   "epoch bid budget exhausted — bid skipped",
+		})
+	}
+}
+
+// SubmitManualBid forces a one-off bid of valueGwei for the slot from its
+// cached payload, bypassing the automatic strategy: the bid window, interval,
+// enable flag and proposer-preferences gate are not consulted and the value
+// is used as is (no canary pricing). The slot's frozen bid transform still
+// applies. The returned event carries the submission outcome.
+func (s *Scheduler) SubmitManualBid(ctx context.Context, slot phase0.Slot, valueGwei uint64) (*BidSubmissionEvent, error) {
+	payload := s.payloadCache.Get(slot)
+	if payload == nil {
+		return nil, ErrNoCachedPayload
+	}
+
+	// Freeze the slot like the automatic path so the transform (and any later
+	// automatic bid) sees the same snapshot.
+	s.effectiveBidSettings(slot)
+
+	s.mu.Lock()
+	state := s.getSlotState(slot)
+	closed := state.BidsClosed
+	s.mu.Unlock()
+
+	if closed {
+		return nil, ErrBidsClosed
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"slot":       slot,
+		"bid_value":  valueGwei,
+		"block_hash": fmt.Sprintf("%x", payload.BlockHash[:8]),
+	}).Info("Creating and submitting manual bid")
+
+	event := s.submitBid(ctx, slot, payload, state, valueGwei, s.chainSvc.Clock().Now(), &BidSubmissionEvent{Manual: true})
+
+	return event, nil
+}
+
+// submitBid creates, signs and gossips a bid, records it on the slot state
+// and fires the submission event. event carries the caller's flags (canary,
+// manual, warning); the rest is filled in here.
+func (s *Scheduler) submitBid(
+	ctx context.Context,
+	slot phase0.Slot,
+	payload *payload_builder.Payload,
+	state *SlotState,
+	bidValue uint64,
+	now time.Time,
+	event *BidSubmissionEvent,
+) *BidSubmissionEvent {
 	// Submit bid, applying the slot's frozen bid transform if any.
 	var bidTransform string
 	if state.Frozen != nil && state.Frozen.Transforms != nil {
 		bidTransform = state.Frozen.Transforms.Bid
 	}
 
+	submitStart := time.Now()
 	signedBid, err := s.bidCreator.CreateAndSubmitBid(ctx, payload, bidValue, bidTransform)
+	metrics.ObserveBidSubmission(time.Since(submitStart), err)
 
 	// Update state regardless of success - we don't want to spam on failure
 	s.mu.Lock()
@@ -288,20 +593,32 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 	state.LastBidHash = payload.BlockHash
 	state.BidCount++
 	bidCount := state.BidCount
-	s.mu.Unlock()
 
-	event := &BidSubmissionEvent{
-		Slot:      slot,
-		BlockHash: payload.BlockHash,
-		Value:     bidValue,
-		BidCount:  bidCount,
-		SignedBid: signedBid,
+	// A bid for a different payload than our last gossiped one replaces it
+	// (stale-payload rebuild); the proposer may still commit to either.
+	if state.LastSubmittedValue > 0 && state.LastSubmittedHash != payload.BlockHash {
+		event.Replaces = state.LastSubmittedHash
 	}
 
-	if prefsBypassed {
-		event.Warning = "no proposer preferences for slot — bid sent anyway (ignore_missing_prefs)"
+	if err == nil {
+		state.LastSubmittedHash = payload.BlockHash
+		state.LastSubmittedParent = payload.Attributes.ParentBlockHash
+		state.LastSubmittedValue = bidValue
+	}
+	s.mu.Unlock()
+
+	// Every gossiped bid counts against the epoch bid budget, manual ones
+	// included.
+	if err == nil && s.budget != nil {
+		s.budget.Commit(slot, bidValue)
 	}
 
+	event.Slot = slot
+	event.BlockHash = payload.BlockHash
+	event.Value = bidValue
+	event.BidCount = bidCount
+	event.SignedBid = signedBid
+
 	if high, ok := s.bidTracker.GetHighestCompetitorBid(slot, s.bidCreator.GetBuilderIndex()); ok {
 		event.CompetitorHighGwei = &high
 	}
@@ -322,7 +639,7 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 			s.service.FireBidSubmission(event)
 		}
 
-		return
+		return event
 	}
 
 	// Track the bid
@@ -344,14 +661,27 @@ func (s *Scheduler) checkSlotForBidding(ctx context.Context, slot phase0.Slot, n
 	// Increment stats (count each bid submission)
 	if s.service != nil && s.service.builderSvc != nil {
 		s.service.builderSvc.IncrementBidsSubmitted()
+
+		if event.Canary {
+			s.service.builderSvc.IncrementCanaryBidsSubmitted()
+		}
 	}
 
-	s.log.WithFields(logrus.Fields{
+	fields := logrus.Fields{
 		"slot":       slot,
 		"bid_value":  bidValue,
 		"bid_count":  bidCount,
 		"block_hash": payload.BlockHash[:8],
-	}).Info("Bid submitted")
+		"manual":     event.Manual,
+	}
+
+	if event.Replaces != ([32]byte{}) {
+		fields["replaces"] = fmt.Sprintf("%x", event.Replaces[:8])
+	}
+
+	s.log.WithFields(fields).Info("Bid submitted")
+
+	return event
 }
 
 // weiToGweiClamped converts a wei amount to gwei, clamping to MaxUint64 when
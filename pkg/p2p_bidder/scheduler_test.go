@@ -19,6 +19,7 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
@@ -63,6 +64,7 @@ func newStubChainService() *stubChainService {
 func (s *stubChainService) GetChainSpec() *chain.ChainSpec { return s.spec }
 func (s *stubChainService) GetGenesis() *beacon.Genesis    { return s.genesis }
 func (s *stubChainService) GetCurrentSlot() phase0.Slot    { return s.currentSlot }
+func (s *stubChainService) Clock() clock.Clock             { return clock.Real() }
 
 func (s *stubChainService) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
 	return phase0.Epoch(uint64(slot) / s.spec.SlotsPerEpoch)
@@ -70,6 +72,10 @@ func (s *stubChainService) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
 
 func (s *stubChainService) ActiveForkAtEpoch(phase0.Epoch) version.DataVersion { return s.fork }
 
+func (s *stubChainService) SlotToTime(slot phase0.Slot) time.Time {
+	return s.genesis.GenesisTime.Add(time.Duration(slot) * s.spec.SecondsPerSlot)
+}
+
 // mockBidSubmitter records submitted bids and can be told to fail.
 type mockBidSubmitter struct {
 	submitted []*eth2all.SignedExecutionPayloadBid
@@ -163,7 +169,7 @@ func newSchedulerHarness(t *testing.T, opts harnessOptions) *schedulerHarness {
 	cache := payload_builder.NewPayloadCache(8)
 
 	scheduler := NewScheduler(chainSvc, bidCreator, bidTracker,
-		cache, svc, blsSigner, prefs, planSvc, log)
+		cache, svc, blsSigner, prefs, planSvc, NewBidBudget(cfg, chainSvc.spec.SlotsPerEpoch), log)
 
 	events := svc.SubscribeBidSubmissions(16, false)
 	t.Cleanup(events.Unsubscribe)
@@ -433,6 +439,78 @@ func TestSchedulerConstructedEventOnSubmitFailure(t *testing.T) {
 	assert.Contains(t, event.Error, "gossip rejected")
 }
 
+func TestSchedulerManualBid(t *testing.T) {
+	// Bidding globally disabled and no proposer preferences: a manual bid
+	// bypasses both.
+	h := newSchedulerHarness(t, harnessOptions{
+		epbsEnabled: false,
+	})
+
+	_, err := h.scheduler.SubmitManualBid(context.Background(), testSlot, 5000)
+	require.ErrorIs(t, err, ErrNoCachedPayload)
+
+	h.preparePayload(testSlot, 100, true)
+
+	event, err := h.scheduler.SubmitManualBid(context.Background(), testSlot, 5000)
+	require.NoError(t, err)
+	require.Len(t, h.submitter.submitted, 1)
+	assert.True(t, event.Success)
+	assert.True(t, event.Manual)
+	assert.Equal(t, uint64(5000), event.Value)
+	assert.Equal(t, phase0.Gwei(5000), h.submitter.submitted[0].Message.Value)
+	assert.Equal(t, event, h.nextEvent(), "manual bids fire the regular submission event")
+
+	h.scheduler.OnHeadEvent(&beacon.HeadEvent{Slot: testSlot})
+
+	_, err = h.scheduler.SubmitManualBid(context.Background(), testSlot, 5000)
+	require.ErrorIs(t, err, ErrBidsClosed)
+}
+
+func TestSchedulerStaleBidReplacement(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{
+		epbsEnabled: true,
+	})
+	h.cfg.EPBS.ReplaceStaleBids = true
+
+	h.preparePayload(testSlot, 100, false)
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1000)
+
+	first := h.nextEvent()
+	require.NotNil(t, first)
+	assert.Equal(t, uint64(100), first.Value)
+	assert.Zero(t, first.Replaces)
+
+	// A rebuild on the same parent must outbid our earlier bid to propagate.
+	rebuilt := newSchedulerTestPayload(testSlot, gweiToWei(80))
+	rebuilt.BlockHash = phase0.Hash32{0xcc}
+	rebuilt.Supersedes = first.BlockHash
+	h.cache.Store(rebuilt)
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1100)
+
+	second := h.nextEvent()
+	require.NotNil(t, second)
+	assert.Equal(t, uint64(101), second.Value, "same-parent replacement is lifted above the last bid")
+	assert.Equal(t, first.BlockHash, second.Replaces)
+
+	// A rebuild on a new parent is priced normally.
+	moved := newSchedulerTestPayload(testSlot, gweiToWei(50))
+	moved.BlockHash = phase0.Hash32{0xdd}
+	moved.Attributes.ParentBlockHash = phase0.Hash32{0x01}
+	h.cache.Store(moved)
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1200)
+
+	third := h.nextEvent()
+	require.NotNil(t, third)
+	assert.Equal(t, uint64(50), third.Value)
+	assert.Equal(t, second.BlockHash, third.Replaces)
+
+	// The superseded payloads stay resolvable for the reveal.
+	assert.NotNil(t, h.cache.GetByBlockHash(first.BlockHash))
+	assert.Equal(t, moved, h.cache.Get(testSlot))
+}
+
 func TestSchedulerGlobalDefaultsWithoutPlan(t *testing.T) {
 	// Globally enabled bidding with no per-slot plan: the freeze resolves the
 	// global config into the snapshot and the slot is bid on with those
@@ -577,3 +655,148 @@ func TestBidCreatorReturnsBidOnSubmitFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerEpochBidBudget(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{
+		epbsEnabled: true,
+	})
+	// testSlot is slot 16 of its epoch: 16 slots left share the budget.
+	h.cfg.BidBudget.EpochMaxGwei = 800
+
+	h.preparePayload(testSlot, 100, false)
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1000)
+
+	capped := h.nextEvent()
+	require.NotNil(t, capped)
+	assert.True(t, capped.Success)
+	assert.True(t, capped.BudgetCapped)
+	assert.Equal(t, uint64(50), capped.Value, "bid capped to 800 / 16 slots")
+
+	// A manual bid bypasses the cap but still spends the budget.
+	h.preparePayload(testSlot+1, 100, false)
+	_, err := h.scheduler.SubmitManualBid(context.Background(), testSlot+1, 5000)
+	require.NoError(t, err)
+	require.NotNil(t, h.nextEvent())
+
+	h.preparePayload(testSlot+2, 100, false)
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot+2, time.Now(), 1000)
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot+2, time.Now(), 1010)
+
+	refused := h.nextEvent()
+	require.NotNil(t, refused)
+	assert.False(t, refused.Success)
+	assert.Contains(t, refused.Warning, "budget exhausted")
+	assert.Nil(t, h.nextEvent(), "the refusal is reported once per slot")
+	assert.Len(t, h.submitter.submitted, 2)
+
+	status := h.scheduler.budget.Status(testSlot + 2)
+	assert.True(t, status.Exhausted)
+	assert.Equal(t, uint64(5050), status.SpentGwei)
+	assert.Zero(t, status.RemainingGwei)
+	assert.Equal(t, 2, status.SlotsBid)
+	assert.Equal(t, uint64(1), status.BidsCapped)
+	assert.Equal(t, uint64(1), status.SlotsRefused)
+}
+
+func TestSchedulerSnipe(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{
+		epbsEnabled: true,
+	})
+	h.cfg.EPBS.BidInterval = 100
+	h.cfg.EPBS.SnipeEnabled = true
+	h.cfg.EPBS.SnipeLeadMs = 200
+	h.cfg.EPBS.SnipeMaxCompetitorGwei = 500
+
+	// Uncontested slot: nothing before the snipe moment, then a single bid.
+	h.preparePayload(testSlot, 100, false)
+	h.scheduler.bidTracker.TrackBid(newTestBid(testSlot, 99, 400), false)
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 1000)
+	assert.Nil(t, h.nextEvent(), "no bid before the snipe moment")
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 3850)
+
+	event := h.nextEvent()
+	require.NotNil(t, event)
+	assert.True(t, event.Success)
+	assert.True(t, event.Snipe)
+	assert.Equal(t, uint64(100), event.Value)
+
+	h.scheduler.mu.Lock()
+	h.scheduler.slotStates[testSlot].LastBidTime = time.Now().Add(-time.Second)
+	h.scheduler.mu.Unlock()
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot, time.Now(), 3950)
+	assert.Nil(t, h.nextEvent(), "a snipe is a single shot")
+
+	// Contested slot: a competitor above the threshold aborts the snipe.
+	h.preparePayload(testSlot+1, 100, false)
+	h.scheduler.bidTracker.TrackBid(newTestBid(testSlot+1, 99, 600), false)
+
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot+1, time.Now(), 3850)
+	h.scheduler.checkSlotForBidding(context.Background(), testSlot+1, time.Now(), 3900)
+
+	skipped := h.nextEvent()
+	require.NotNil(t, skipped)
+	assert.False(t, skipped.Success)
+	assert.True(t, skipped.Snipe)
+	assert.Contains(t, skipped.Warning, "snipe aborted")
+	require.NotNil(t, skipped.CompetitorHighGwei)
+	assert.Equal(t, uint64(600), *skipped.CompetitorHighGwei)
+	assert.Nil(t, h.nextEvent(), "the skip is reported once per slot")
+	assert.Len(t, h.submitter.submitted, 1)
+}
+
+func TestSchedulerBidSkipReasonOnClose(t *testing.T) {
+	h := newSchedulerHarness(t, harnessOptions{
+		epbsEnabled: true,
+	})
+	ctx := context.Background()
+
+	closeSlot := func(slot phase0.Slot) *BidSkippedEvent {
+		return h.scheduler.OnHeadEvent(&beacon.HeadEvent{Slot: slot})
+	}
+
+	// Never evaluated (the run loop gated the scheduler off).
+	skip := closeSlot(testSlot)
+	require.NotNil(t, skip)
+	assert.Equal(t, BidSkipReasonNotEvaluated, skip.Reason)
+	assert.Nil(t, closeSlot(testSlot), "a slot closes once")
+
+	// Bid window not open yet.
+	h.scheduler.checkSlotForBidding(ctx, testSlot+1, time.Now(), -500)
+	assert.Equal(t, BidSkipReasonOutsideWindow, closeSlot(testSlot+1).Reason)
+
+	// No payload; the closed window afterwards keeps the specific reason.
+	h.scheduler.checkSlotForBidding(ctx, testSlot+2, time.Now(), 1000)
+	h.scheduler.checkSlotForBidding(ctx, testSlot+2, time.Now(), 5000)
+	assert.Equal(t, BidSkipReasonNoPayload, closeSlot(testSlot+2).Reason)
+
+	// Missing proposer preferences.
+	h.preparePayload(testSlot+3, 100, true)
+	h.scheduler.checkSlotForBidding(ctx, testSlot+3, time.Now(), 1000)
+	assert.Equal(t, BidSkipReasonNoPrefs, closeSlot(testSlot+3).Reason)
+
+	// Plan-suppressed slot.
+	h.applyBidPlan(t, testSlot+4, `{"mode":"disabled"}`)
+	h.scheduler.checkSlotForBidding(ctx, testSlot+4, time.Now(), 1000)
+	assert.Equal(t, BidSkipReasonSuppressed, closeSlot(testSlot+4).Reason)
+
+	// A slot we bid on closes without a skip; the bid was raised to the
+	// minimum.
+	h.cfg.EPBS.BidMinAmount = 500
+	h.preparePayload(testSlot+5, 100, false)
+	h.scheduler.checkSlotForBidding(ctx, testSlot+5, time.Now(), 1000)
+
+	var bid *BidSubmissionEvent
+	for event := h.nextEvent(); event != nil; event = h.nextEvent() {
+		if event.Status == BidStatusSubmitted {
+			bid = event
+		}
+	}
+
+	require.NotNil(t, bid)
+	assert.True(t, bid.MinRaised)
+	assert.Equal(t, uint64(500), bid.Value)
+	assert.Nil(t, closeSlot(testSlot+5))
+}
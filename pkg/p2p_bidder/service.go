@@ -10,6 +10,7 @@ import (
 	"time"
 
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
 	gloasspec "github.com/ethpandaops/go-eth2-client/spec/gloas"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
@@ -91,6 +92,63 @@ type BidSubmissionEvent struct {
 	// CompetitorHighGwei is the highest competitor bid known for the slot at
 	// fire time (our own builder index excluded); nil when none is known.
 	CompetitorHighGwei *uint64
+	// Canary marks a bid priced at the canary value (canary mode).
+	Canary bool
+	// Snipe marks a bid (or contested-slot skip) of the late-slot snipe
+	// strategy.
+	Snipe bool
+	// BudgetCapped marks a bid lowered to its slot's share of the epoch bid
+	// budget.
+	BudgetCapped bool
+	// MinRaised marks a formula bid whose payload value was below the bid
+	// minimum and was raised to it.
+	MinRaised bool
+	// Manual marks an operator-forced bid (POST /api/buildoor/bid).
+	Manual bool
+	// Fault is the bid fault (BidFault*) of a deliberately invalid manual
+	// bid; empty for a regular bid.
+	Fault string
+	// Replaces is the block hash of our earlier gossiped bid this one
+	// replaces (a rebuilt payload); zero when it does not replace one.
+	Replaces [32]byte
+}
+
+// Bid skip reasons reported via BidSkippedEvent.Reason.
+const (
+	// BidSkipReasonNotRegistered means the builder is not (yet) registered
+	// on-chain.
+	BidSkipReasonNotRegistered = "not_registered"
+	// BidSkipReasonIdentity means the builder identity check failed.
+	BidSkipReasonIdentity = "identity_unverified"
+	// BidSkipReasonHold means bidding was held by an external monitor.
+	BidSkipReasonHold = "bid_hold"
+	// BidSkipReasonNotEvaluated means the scheduler never evaluated the slot
+	// (e.g. the builder is not active on-chain).
+	BidSkipReasonNotEvaluated = "not_evaluated"
+	// BidSkipReasonSuppressed means the slot's frozen plan disables bidding.
+	BidSkipReasonSuppressed = "suppressed"
+	// BidSkipReasonOutsideWindow means the bid window never opened for the
+	// slot before its block arrived.
+	BidSkipReasonOutsideWindow = "outside_window"
+	// BidSkipReasonNoPayload means no payload was built for the slot in time.
+	BidSkipReasonNoPayload = "no_payload"
+	// BidSkipReasonNoPrefs means the proposer's preferences were missing.
+	BidSkipReasonNoPrefs = "no_proposer_preferences"
+	// BidSkipReasonSnipeAborted means a competitor bid above the snipe
+	// threshold aborted the slot's snipe.
+	BidSkipReasonSnipeAborted = "snipe_aborted"
+	// BidSkipReasonStrategyHold means the slot's bid strategy held the bid.
+	BidSkipReasonStrategyHold = "strategy_hold"
+	// BidSkipReasonBudget means the epoch bid budget was exhausted.
+	BidSkipReasonBudget = "budget_exhausted"
+)
+
+// BidSkippedEvent reports a slot whose bidding closed (its block arrived)
+// without a bid of ours, and why.
+type BidSkippedEvent struct {
+	Slot   phase0.Slot
+	Reason string // one of the BidSkipReason* constants
+	Detail string
 }
 
 // Service is the p2p bidder orchestrator that handles time-scheduled bidding.
@@ -101,10 +159,11 @@ type BidSubmissionEvent struct {
 // payload_bidder services.
 type Service struct {
 	signer                *payload_bidder.Signer
-	blsSigner             *signer.BLSSigner
+	blsSigner             signer.Signer
 	scheduler             *Scheduler
 	bidCreator            *BidCreator
 	bidTracker            *BidTracker
+	bidBudget             *BidBudget
 	clClient              *beacon.Client
 	chainSvc              chain.Service
 	propPrefsStore        *memstore.Store[phase0.Slot, *gloasspec.SignedProposerPreferences]
@@ -112,8 +171,24 @@ type Service struct {
 	builderIndex          uint64
 	builderPubkey         phase0.BLSPubKey
 	bidSubmissionDispatch *utils.Dispatcher[*BidSubmissionEvent]
+	bidSkippedDispatch    *utils.Dispatcher[*BidSkippedEvent]
 	builderSvc            *payload_builder.Service
 
+	// expectedWithdrawalAddr is the withdrawal address our builder record
+	// must carry (nil: unchecked); identityErr holds the last failed builder
+	// identity check (nil: verified), which halts bidding.
+	expectedWithdrawalAddr *bellatrix.ExecutionAddress
+	identityErr            atomic.Pointer[string]
+
+	// bidHold is why bidding is held by an external monitor (nil: not
+	// held), see SetBidHold.
+	bidHold atomic.Pointer[string]
+
+	// siblingIndices are the builder indices of the other builder keys run
+	// by this instance (handed to the bid tracker once started).
+	siblingIndices []uint64
+	siblingsMu     sync.Mutex
+
 	enabled           atomic.Bool
 	registrationState atomic.Int32
 	ctx               context.Context
@@ -134,7 +209,7 @@ type Service struct {
 func NewService(
 	clClient *beacon.Client,
 	chainSvc chain.Service,
-	blsSigner *signer.BLSSigner,
+	blsSigner signer.Signer,
 	propPrefsStore *memstore.Store[phase0.Slot, *gloasspec.SignedProposerPreferences],
 	planSvc *action_plan.PlanService,
 	log logrus.FieldLogger,
@@ -153,6 +228,7 @@ func NewService(
 		planSvc:               planSvc,
 		builderPubkey:         blsSigner.PublicKey(),
 		bidSubmissionDispatch: &utils.Dispatcher[*BidSubmissionEvent]{},
+		bidSkippedDispatch:    &utils.Dispatcher[*BidSkippedEvent]{},
 		log:                   serviceLog,
 	}
 
@@ -162,6 +238,12 @@ func NewService(
 	return s, nil
 }
 
+// SetSigningAuditor validates and records the signing domain of every p2p
+// bid. Must be called before Start.
+func (s *Service) SetSigningAuditor(auditor *payload_bidder.SigningAuditor) {
+	s.signer.SetAuditor(auditor)
+}
+
 // SetEnabled sets the enabled state of the p2p bidder service. The flag is
 // status reporting only (WebUI/API); the per-slot bid decision comes solely
 // from the action plan's frozen snapshots.
@@ -188,6 +270,11 @@ func (s *Service) FireBidSubmission(event *BidSubmissionEvent) {
 	s.bidSubmissionDispatch.Fire(event)
 }
 
+// SubscribeBidSkipped subscribes to slots closed without a bid of ours.
+func (s *Service) SubscribeBidSkipped(capacity int, blocking bool) *utils.Subscription[*BidSkippedEvent] {
+	return s.bidSkippedDispatch.Subscribe(capacity, blocking)
+}
+
 // Start starts the p2p bidder service.
 func (s *Service) Start(ctx context.Context, builderSvc *payload_builder.Service) error {
 	s.ctx, s.cancel = context.WithCancel(ctx)
@@ -212,8 +299,14 @@ func (s *Service) Start(ctx context.Context, builderSvc *payload_builder.Service
 		}).Info("Builder found in beacon state")
 	}
 
+	s.VerifyBuilderIdentity()
+
 	// Initialize components
+	s.siblingsMu.Lock()
 	s.bidTracker = NewBidTracker(s.builderIndex, s.log)
+	s.bidTracker.SetSiblingIndices(s.siblingIndices)
+	s.siblingsMu.Unlock()
+
 	s.bidCreator = NewBidCreator(
 		s.signer,
 		s.clClient,
@@ -221,6 +314,7 @@ func (s *Service) Start(ctx context.Context, builderSvc *payload_builder.Service
 		s.builderIndex,
 		s.log,
 	)
+	s.bidBudget = NewBidBudget(builderSvc.GetConfig(), s.chainSvc.GetChainSpec().SlotsPerEpoch)
 	// The scheduler skips bidding for slots without cached proposer preferences:
 	// the BN's gossip validator silently rejects such bids.
 	s.scheduler = NewScheduler(
@@ -232,6 +326,7 @@ func (s *Service) Start(ctx context.Context, builderSvc *payload_builder.Service
 		s.blsSigner,
 		s.propPrefsStore,
 		s.planSvc,
+		s.bidBudget,
 		s.log,
 	)
 
@@ -286,14 +381,16 @@ func (s *Service) run() {
 		case _, ok := <-epochSub.Channel():
 			if ok {
 				s.RefreshRegistrationState()
+				s.VerifyBuilderIdentity()
 			}
 
 		case <-ticker.C:
 			// The enable policy is per slot: the scheduler resolves it from
 			// the frozen action plan (a plan may activate bidding for a slot
 			// even when ePBS is globally disabled). Registration stays a hard
-			// availability gate.
-			if s.IsRegistered() {
+			// availability gate, as are a verified builder identity and the
+			// absence of a bid hold.
+			if s.IsRegistered() && s.IdentityError() == "" && s.BidHold() == "" {
 				s.scheduler.ProcessTick(s.ctx)
 			}
 		}
@@ -308,7 +405,27 @@ func (s *Service) handleHeadEvent(event *beacon.HeadEvent) {
 	}).Info("Head event received")
 
 	// Close bidding for this slot - block already produced
-	s.scheduler.OnHeadEvent(event)
+	skip := s.scheduler.OnHeadEvent(event)
+	if skip == nil || s.chainSvc.GetCurrentFork() < version.DataVersionGloas {
+		return
+	}
+
+	// A slot the scheduler never evaluated was gated off in the run loop.
+	if skip.Reason == BidSkipReasonNotEvaluated {
+		switch {
+		case !s.IsRegistered():
+			skip.Reason = BidSkipReasonNotRegistered
+			skip.Detail = RegistrationStateName(s.registrationState.Load())
+		case s.IdentityError() != "":
+			skip.Reason = BidSkipReasonIdentity
+			skip.Detail = s.IdentityError()
+		case s.BidHold() != "":
+			skip.Reason = BidSkipReasonHold
+			skip.Detail = s.BidHold()
+		}
+	}
+
+	s.bidSkippedDispatch.Fire(skip)
 }
 
 // handleBidEvent processes a bid event from the event stream.
@@ -325,6 +442,7 @@ func (s *Service) handleBidEvent(event *beacon.BidEvent) {
 		BuilderIndex:     event.BuilderIndex,
 		Value:            event.Value,
 		ExecutionPayment: event.ExecutionPayment,
+		Signed:           event.SignedBid,
 	}
 
 	s.bidTracker.TrackBid(bid, isOurs)
@@ -335,6 +453,37 @@ func (s *Service) handleBidEvent(event *beacon.BidEvent) {
 		"value":         event.Value,
 		"is_ours":       isOurs,
 	}).Debug("Bid event received")
+
+	// Counter-bid slots answer competitor bids immediately, under the same
+	// availability gates as the bid tick.
+	if !isOurs && s.IsRegistered() && s.IdentityError() == "" && s.BidHold() == "" {
+		s.scheduler.OnCompetitorBid(s.ctx, event.Slot)
+	}
+}
+
+// SetBidHold halts bidding for the given reason until it is cleared with an
+// empty reason, independent of the enable setting and the action plan.
+func (s *Service) SetBidHold(reason string) {
+	if reason == "" {
+		if s.bidHold.Swap(nil) != nil {
+			s.log.Info("Bid hold cleared, bidding resumed")
+		}
+
+		return
+	}
+
+	if previous := s.bidHold.Swap(&reason); previous == nil || *previous != reason {
+		s.log.WithField("reason", reason).Error("Bidding held")
+	}
+}
+
+// BidHold returns why bidding is held, or empty when it is not.
+func (s *Service) BidHold() string {
+	if reason := s.bidHold.Load(); reason != nil {
+		return *reason
+	}
+
+	return ""
 }
 
 // GetRegistrationState returns the current registration state.
@@ -386,6 +535,8 @@ func (s *Service) SetBuilderRegistered(index uint64) {
 		"builder_index": index,
 		"state":         RegistrationStateName(s.registrationState.Load()),
 	}).Info("Builder registration detected, updating state")
+
+	s.VerifyBuilderIdentity()
 }
 
 // computeRegistrationState determines the registration state from beacon chain builder info.
@@ -414,8 +565,18 @@ func (s *Service) computeRegistrationState(info *chain.BuilderInfo) int32 {
 func (s *Service) RefreshRegistrationState() {
 	currentState := s.registrationState.Load()
 
+	// Started pre-Gloas: the builder registry exists from the first Gloas
+	// state on, evaluated like at a post-Gloas start.
+	if currentState == RegistrationStateWaitingGloas {
+		if stats := s.chainSvc.GetCurrentEpochStats(); stats != nil && stats.Version >= version.DataVersionGloas {
+			s.leaveWaitingGloas()
+		}
+
+		return
+	}
+
 	// States that don't need refresh
-	if currentState == RegistrationStateWaitingGloas || currentState == RegistrationStateUnknown {
+	if currentState == RegistrationStateUnknown {
 		return
 	}
 
@@ -441,11 +602,65 @@ func (s *Service) RefreshRegistrationState() {
 	}
 }
 
+// leaveWaitingGloas resolves the registration once the Gloas state is loaded.
+func (s *Service) leaveWaitingGloas() {
+	info := s.chainSvc.GetBuilderByPubkey(s.builderPubkey)
+	if info == nil {
+		s.registrationState.Store(RegistrationStateUnregistered)
+		s.log.Info("Gloas state loaded, builder not found in beacon state")
+
+		return
+	}
+
+	s.SetBuilderRegistered(info.Index)
+}
+
+// SubmitManualBid forces a one-off bid of valueGwei for the slot using its
+// cached payload, bypassing the automatic bid strategy.
+func (s *Service) SubmitManualBid(ctx context.Context, slot phase0.Slot, valueGwei uint64) (*BidSubmissionEvent, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("p2p bidder not started")
+	}
+
+	return s.scheduler.SubmitManualBid(ctx, slot, valueGwei)
+}
+
+// GetBidBudgetStatus reports the epoch bid budget of the current epoch; nil
+// before the service started.
+func (s *Service) GetBidBudgetStatus() *BidBudgetStatus {
+	if s.bidBudget == nil {
+		return nil
+	}
+
+	return s.bidBudget.Status(s.chainSvc.GetCurrentSlot())
+}
+
 // GetBidTracker returns the bid tracker.
 func (s *Service) GetBidTracker() *BidTracker {
 	return s.bidTracker
 }
 
+// SetSiblingIndices sets the builder indices of the other builder keys run by
+// this instance: their bids never count as competition, so sibling builders
+// do not outbid each other.
+func (s *Service) SetSiblingIndices(indices []uint64) {
+	s.siblingsMu.Lock()
+	defer s.siblingsMu.Unlock()
+
+	s.siblingIndices = indices
+
+	if s.bidTracker != nil {
+		s.bidTracker.SetSiblingIndices(indices)
+	}
+}
+
+// OutstandingBidSlots returns the slots from the current one on that carry a
+// bid of ours: committed, but whether it wins (and owes a payment) is not
+// known yet.
+func (s *Service) OutstandingBidSlots() []phase0.Slot {
+	return s.bidTracker.OurBidSlotsSince(s.chainSvc.GetCurrentSlot())
+}
+
 // GetBuilderIndex returns the builder index.
 func (s *Service) GetBuilderIndex() uint64 {
 	return s.builderIndex
@@ -0,0 +1,94 @@
+package p2p_bidder
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// registryChainService serves one epoch's stats (nil before the first state
+// is loaded) as the builder registry.
+type registryChainService struct {
+	stubChainService
+
+	stats *chain.EpochStats
+}
+
+func (s *registryChainService) GetCurrentEpoch() phase0.Epoch { return 10 }
+
+func (s *registryChainService) GetCurrentEpochStats() *chain.EpochStats { return s.stats }
+
+func (s *registryChainService) GetEpochStats(phase0.Epoch) *chain.EpochStats { return s.stats }
+
+func (s *registryChainService) GetFinalizedEpoch() phase0.Epoch {
+	if s.stats == nil {
+		return 0
+	}
+
+	return s.stats.FinalizedEpoch
+}
+
+func (s *registryChainService) GetBuilderByPubkey(pubkey phase0.BLSPubKey) *chain.BuilderInfo {
+	if s.stats == nil {
+		return nil
+	}
+
+	for _, info := range s.stats.Builders {
+		if info.Pubkey == pubkey {
+			return info
+		}
+	}
+
+	return nil
+}
+
+func TestRefreshRegistrationStateLeavesWaitingGloas(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	blsSigner, err := signer.NewBLSSigner(testBuilderPrivkey)
+	require.NoError(t, err)
+
+	chainSvc := &registryChainService{stubChainService: *newStubChainService()}
+
+	svc, err := NewService(nil, chainSvc, blsSigner, nil, nil, log)
+	require.NoError(t, err)
+
+	// Started pre-Gloas.
+	svc.registrationState.Store(RegistrationStateWaitingGloas)
+
+	svc.RefreshRegistrationState()
+	assert.Equal(t, RegistrationStateWaitingGloas, svc.GetRegistrationState(), "no state loaded yet")
+
+	chainSvc.stats = &chain.EpochStats{Version: version.DataVersionFulu, Epoch: 10}
+	svc.RefreshRegistrationState()
+	assert.Equal(t, RegistrationStateWaitingGloas, svc.GetRegistrationState(), "pre-Gloas state")
+
+	// The first Gloas state without our builder.
+	chainSvc.stats = &chain.EpochStats{Version: version.DataVersionGloas, Epoch: 10, Builders: []*chain.BuilderInfo{}}
+	svc.RefreshRegistrationState()
+	assert.Equal(t, RegistrationStateUnregistered, svc.GetRegistrationState())
+
+	// The fork upgrade imported a pre-fork deposit of our builder.
+	svc.registrationState.Store(RegistrationStateWaitingGloas)
+	chainSvc.stats = &chain.EpochStats{
+		Version:        version.DataVersionGloas,
+		Epoch:          10,
+		FinalizedEpoch: 8,
+		Builders: []*chain.BuilderInfo{
+			{Index: 0, Pubkey: phase0.BLSPubKey{0x01}, WithdrawableEpoch: chain.FarFutureEpoch},
+			{Index: 1, Pubkey: blsSigner.PublicKey(), DepositEpoch: 2, WithdrawableEpoch: chain.FarFutureEpoch},
+		},
+	}
+	svc.RefreshRegistrationState()
+	assert.Equal(t, RegistrationStateRegistered, svc.GetRegistrationState())
+	assert.Equal(t, uint64(1), svc.builderIndex)
+	assert.Empty(t, svc.IdentityError())
+}
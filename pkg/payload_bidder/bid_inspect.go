@@ -0,0 +1,305 @@
+package payload_bidder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// BidFieldRoot is one top-level field of a bid message: its spec name, its
+// generalized index in the message tree and its hash tree root.
+type BidFieldRoot struct {
+	Name   string
+	GIndex uint64
+	Root   phase0.Root
+}
+
+// BidInspection is the signing breakdown of one signed bid, for cross-checking
+// a bid against a consensus client's verification: per-field roots, the
+// message root, the connected chain's expected signing domain, the resulting
+// signing root and the signature.
+type BidInspection struct {
+	Artifact    string // SigningArtifactBid or SigningArtifactHeader
+	Slot        phase0.Slot
+	Fields      []BidFieldRoot
+	MessageRoot phase0.Root
+	Domain      signer.SigningDomain
+	SigningRoot phase0.Root
+	Signature   phase0.BLSSignature
+
+	// Pubkey is the key the signature is verified against: the registered
+	// builder's key for payload bids (unknown while the builder is not in
+	// the current epoch stats), the embedded key for legacy bids.
+	Pubkey         phase0.BLSPubKey
+	PubkeyKnown    bool
+	SignatureValid bool
+}
+
+// InspectBid breaks down a signed bid as decoded from a stored artifact — a
+// Gloas+ *eth2all.SignedExecutionPayloadBid or a legacy
+// *legacytypes.SignedBuilderBid. Roots are computed via dynamic-ssz, so
+// preset-dependent limits resolve exactly as at signing time.
+func InspectBid(chainSvc chain.Service, slot phase0.Slot, signed any) (*BidInspection, error) {
+	var (
+		inspection = &BidInspection{Slot: slot}
+		message    any
+		view       any
+		err        error
+	)
+
+	switch bid := signed.(type) {
+	case *eth2all.SignedExecutionPayloadBid:
+		if bid.Message == nil {
+			return nil, errors.New("bid has no message")
+		}
+
+		inspection.Artifact = SigningArtifactBid
+		inspection.Slot = bid.Message.Slot
+		inspection.Signature = bid.Signature
+		message = bid.Message
+
+		if view, err = bid.Message.ToView(); err != nil {
+			return nil, fmt.Errorf("failed to resolve bid schema: %w", err)
+		}
+
+		if builder := chainSvc.GetBuilderByIndex(uint64(bid.Message.BuilderIndex)); builder != nil {
+			inspection.Pubkey = builder.Pubkey
+			inspection.PubkeyKnown = true
+		}
+	case *legacytypes.SignedBuilderBid:
+		if bid.Message == nil {
+			return nil, errors.New("bid has no message")
+		}
+
+		inspection.Artifact = SigningArtifactHeader
+		inspection.Signature = bid.Signature
+		inspection.Pubkey = bid.Message.Pubkey
+		inspection.PubkeyKnown = true
+		message = bid.Message
+
+		if view, err = bid.Message.ViewType(); err != nil {
+			return nil, fmt.Errorf("failed to resolve bid schema: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported bid type %T", signed)
+	}
+
+	msgRoot, err := dynssz.GetGlobalDynSsz().HashTreeRoot(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute hash tree root: %w", err)
+	}
+
+	inspection.MessageRoot = msgRoot
+
+	if inspection.Fields, err = containerFieldRoots(message, reflect.TypeOf(view)); err != nil {
+		return nil, err
+	}
+
+	if inspection.Domain, err = ExpectedSigningDomain(chainSvc, inspection.Artifact, inspection.Slot); err != nil {
+		return nil, err
+	}
+
+	inspection.SigningRoot = signer.ComputeSigningRoot(inspection.MessageRoot, inspection.Domain.Domain)
+
+	if inspection.PubkeyKnown {
+		// Verify over a copy: the BLS binding must not see a pointer into a
+		// struct that holds Go pointers.
+		signingRoot := inspection.SigningRoot
+		inspection.SignatureValid = signer.VerifyBLSSignature(inspection.Pubkey, signingRoot[:], inspection.Signature)
+	}
+
+	return inspection, nil
+}
+
+// ErrBidSignatureInvalid is returned for a bid whose signature does not
+// verify against its builder's registered pubkey.
+var ErrBidSignatureInvalid = errors.New("bid signature does not verify")
+
+// VerifyBidSignature verifies a signed execution payload bid against the
+// registered pubkey of its builder under the connected chain's signing domain
+// for the bid's slot. Bids of builders unknown to the current epoch stats
+// cannot be verified and are rejected.
+func VerifyBidSignature(chainSvc chain.Service, bid *eth2all.SignedExecutionPayloadBid) error {
+	if bid == nil || bid.Message == nil {
+		return errors.New("bid has no message")
+	}
+
+	builder := chainSvc.GetBuilderByIndex(uint64(bid.Message.BuilderIndex))
+	if builder == nil {
+		return fmt.Errorf("%w: builder %d is not registered", ErrBidSignatureInvalid, bid.Message.BuilderIndex)
+	}
+
+	msgRoot, err := dynssz.GetGlobalDynSsz().HashTreeRoot(bid.Message)
+	if err != nil {
+		return fmt.Errorf("failed to compute hash tree root: %w", err)
+	}
+
+	domain, err := ExpectedSigningDomain(chainSvc, SigningArtifactBid, bid.Message.Slot)
+	if err != nil {
+		return err
+	}
+
+	if !signer.VerifyWithDomain(builder.Pubkey, msgRoot, domain.Domain, bid.Signature) {
+		return fmt.Errorf("%w against builder %d", ErrBidSignatureInvalid, bid.Message.BuilderIndex)
+	}
+
+	return nil
+}
+
+// containerFieldRoots computes the root of every top-level field of a
+// container, laid out per its fork schema type: binary for regular
+// containers, progressive (fields placed by their ssz-index) for progressive
+// containers. Values are read from the same-named fields of msg.
+func containerFieldRoots(msg any, schema reflect.Type) ([]BidFieldRoot, error) {
+	for schema.Kind() == reflect.Pointer {
+		schema = schema.Elem()
+	}
+
+	source := reflect.ValueOf(msg)
+	for source.Kind() == reflect.Pointer {
+		source = source.Elem()
+	}
+
+	if schema.Kind() != reflect.Struct || source.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema %s is not a container", schema)
+	}
+
+	progressive := false
+
+	for i := range schema.NumField() {
+		if _, ok := schema.Field(i).Tag.Lookup("ssz-index"); ok {
+			progressive = true
+			break
+		}
+	}
+
+	fields := make([]BidFieldRoot, 0, schema.NumField())
+
+	for i := range schema.NumField() {
+		field := schema.Field(i)
+
+		var gindex uint64
+
+		if progressive {
+			position, err := strconv.ParseUint(field.Tag.Get("ssz-index"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: invalid ssz-index: %w", field.Name, err)
+			}
+
+			gindex = progressiveFieldGIndex(position)
+		} else {
+			gindex = binaryFieldGIndex(uint64(i), uint64(schema.NumField()))
+		}
+
+		value := source.FieldByName(field.Name)
+		if !value.IsValid() {
+			return nil, fmt.Errorf("field %s: missing in %s", field.Name, source.Type())
+		}
+
+		root, err := fieldRoot(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		fields = append(fields, BidFieldRoot{Name: snakeCase(field.Name), GIndex: gindex, Root: root})
+	}
+
+	return fields, nil
+}
+
+// fieldRoot computes the hash tree root of one schema field holding value. A
+// single-field container merkleizes to its only chunk, so hashing the field
+// wrapped in one (ssz-index dropped, keeping it a regular container) yields
+// the field's root with its size and type tags intact. Fork-agnostic values
+// are converted to the schema's fork type via their ToView.
+func fieldRoot(field reflect.StructField, value reflect.Value) (phase0.Root, error) {
+	if value.Type() != field.Type {
+		viewer, ok := value.Interface().(interface{ ToView() (any, error) })
+		if !ok || value.IsNil() {
+			return phase0.Root{}, fmt.Errorf("cannot convert %s to %s", value.Type(), field.Type)
+		}
+
+		view, err := viewer.ToView()
+		if err != nil {
+			return phase0.Root{}, err
+		}
+
+		value = reflect.ValueOf(view)
+		if value.Type() != field.Type {
+			return phase0.Root{}, fmt.Errorf("cannot convert %s to %s", value.Type(), field.Type)
+		}
+	}
+
+	tag := strings.TrimSpace(strings.Replace(string(field.Tag),
+		`ssz-index:"`+field.Tag.Get("ssz-index")+`"`, "", 1))
+
+	holder := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: field.Name,
+		Type: field.Type,
+		Tag:  reflect.StructTag(tag),
+	}}))
+	holder.Elem().Field(0).Set(value)
+
+	return dynssz.GetGlobalDynSsz().HashTreeRoot(holder.Interface())
+}
+
+// binaryFieldGIndex is the generalized index of field i of a regular
+// container with n fields (leaves padded to the next power of two).
+func binaryFieldGIndex(i, n uint64) uint64 {
+	width := uint64(1)
+	for width < n {
+		width <<= 1
+	}
+
+	return width + i
+}
+
+// progressiveFieldGIndex is the generalized index of the field at position
+// of a progressive container: the root mixes the active-fields bitvector into
+// the progressive tree (gindex 2), whose levels hold binary subtrees of 1, 4,
+// 16, ... leaves on the left and the remainder on the right.
+func progressiveFieldGIndex(position uint64) uint64 {
+	gindex, width := uint64(2), uint64(1)
+
+	for position >= width {
+		position -= width
+		gindex = gindex*2 + 1
+		width *= 4
+	}
+
+	return gindex*2*width + position
+}
+
+// snakeCase converts a Go field name to its spec name, keeping acronyms
+// together (BlobKZGCommitments -> blob_kzg_commitments).
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
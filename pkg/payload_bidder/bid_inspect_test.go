@@ -0,0 +1,133 @@
+package payload_bidder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func TestInspectBid(t *testing.T) {
+	gvr := phase0.Root{0x42}
+	chainSvc := &stubChainService{
+		currentFork:  version.DataVersionGloas,
+		slotDuration: 12 * time.Second,
+		genesis:      beacon.Genesis{GenesisValidatorsRoot: gvr, GenesisForkVersion: phase0.Version{0x10}},
+	}
+
+	key, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	forkVersion := phase0.Version{0x01, 0x00, 0x00, 0x00} // the stub's Gloas fork version
+	payload := newTestPayload(7, phase0.Hash32{0xaa}, big.NewInt(1))
+
+	signed, err := BuildSignedBid(context.Background(), payload, BidParams{Value: 1}, NewSigner(key), forkVersion, gvr)
+	require.NoError(t, err)
+
+	inspection, err := InspectBid(chainSvc, 7, signed)
+	require.NoError(t, err)
+
+	msgRoot, err := signed.Message.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(msgRoot), inspection.MessageRoot)
+
+	require.Equal(t, SigningArtifactBid, inspection.Artifact)
+	require.Equal(t, signer.NewSigningDomain(DomainBeaconBuilder, forkVersion, gvr), inspection.Domain)
+	require.Equal(t, signer.ComputeSigningRoot(inspection.MessageRoot, inspection.Domain.Domain), inspection.SigningRoot)
+	require.Equal(t, signed.Signature, inspection.Signature)
+
+	// The stub chain does not know the builder, so nothing is verified.
+	require.False(t, inspection.PubkeyKnown)
+	signingRoot := inspection.SigningRoot
+	require.True(t, signer.VerifyBLSSignature(key.PublicKey(), signingRoot[:], inspection.Signature))
+
+	require.Len(t, inspection.Fields, 12)
+	require.Equal(t, "parent_block_hash", inspection.Fields[0].Name)
+	require.Equal(t, "blob_kzg_commitments", inspection.Fields[10].Name)
+	require.Equal(t, "execution_requests_root", inspection.Fields[11].Name)
+
+	// Basic fields are their little-endian value padded to a chunk.
+	slotField := inspection.Fields[7]
+	require.Equal(t, "slot", slotField.Name)
+
+	var slotRoot phase0.Root
+
+	binary.LittleEndian.PutUint64(slotRoot[:], 7)
+	require.Equal(t, slotRoot, slotField.Root)
+	require.Equal(t, phase0.Root(signed.Message.BlockHash), inspection.Fields[2].Root)
+}
+
+func TestInspectLegacyBid(t *testing.T) {
+	chainSvc := &stubChainService{
+		currentFork:  version.DataVersionDeneb,
+		slotDuration: 12 * time.Second,
+		genesis:      beacon.Genesis{GenesisForkVersion: phase0.Version{0x10}},
+	}
+
+	key, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	bid := &legacytypes.BuilderBid{
+		Version: version.DataVersionDeneb,
+		Header: &eth2all.ExecutionPayloadHeader{
+			Version:       version.DataVersionDeneb,
+			BaseFeePerGas: uint256.NewInt(7),
+			BlockHash:     phase0.Hash32{0xaa},
+		},
+		Value:  uint256.NewInt(5),
+		Pubkey: key.PublicKey(),
+	}
+
+	bidRoot, err := bid.HashTreeRoot()
+	require.NoError(t, err)
+
+	domain := signer.NewSigningDomain(signer.DomainApplicationBuilder, phase0.Version{0x10}, phase0.Root{})
+	sig, err := key.SignWithDomain(bidRoot, domain.Domain)
+	require.NoError(t, err)
+
+	signed := &legacytypes.SignedBuilderBid{Version: version.DataVersionDeneb, Message: bid, Signature: sig}
+
+	inspection, err := InspectBid(chainSvc, 7, signed)
+	require.NoError(t, err)
+
+	require.Equal(t, SigningArtifactHeader, inspection.Artifact)
+	require.Equal(t, phase0.Root(bidRoot), inspection.MessageRoot)
+	require.Equal(t, domain, inspection.Domain)
+	require.True(t, inspection.PubkeyKnown)
+	require.True(t, inspection.SignatureValid)
+
+	names := make([]string, 0, len(inspection.Fields))
+	for _, f := range inspection.Fields {
+		names = append(names, f.Name)
+	}
+
+	require.Equal(t, []string{"header", "blob_kzg_commitments", "value", "pubkey"}, names)
+
+	headerRoot, err := bid.Header.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(headerRoot), inspection.Fields[0].Root)
+
+	// The field roots sit at gindices 4..7 and recombine to the message root.
+	pair := func(a, b phase0.Root) phase0.Root { return sha256.Sum256(append(a[:], b[:]...)) }
+
+	for i, f := range inspection.Fields {
+		require.Equal(t, uint64(4+i), f.GIndex)
+	}
+
+	require.Equal(t, inspection.MessageRoot, pair(
+		pair(inspection.Fields[0].Root, inspection.Fields[1].Root),
+		pair(inspection.Fields[2].Root, inspection.Fields[3].Root),
+	))
+}
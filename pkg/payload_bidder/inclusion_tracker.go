@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
@@ -72,12 +73,14 @@ type wonTracking struct {
 	verdict   PayloadVerdict // last fired verdict; empty until first resolution
 }
 
-// InclusionTracker watches head events and detects inclusion of our payloads.
-// It records the payment obligation, requests the payload reveal, fires
-// inclusion events carrying the won-block summary (won-block storage is owned
-// by the slot results tracker), and checks the follow-up block to detect
-// orphaned (unrevealed) payloads. Shared by both the p2p and Builder API
-// flows.
+// InclusionTracker watches head events and detects inclusion of our payloads
+// by parsing the head block: from Gloas on the block's signed execution
+// payload bid must carry our builder index, before that the embedded payload's
+// block hash must be one we built. It drives the BlocksIncluded stat, records
+// the payment obligation, requests the payload reveal, fires inclusion events
+// carrying the won-block summary (won-block storage is owned by the slot
+// results tracker), and checks the follow-up block to detect orphaned
+// (unrevealed) payloads. Shared by both the p2p and Builder API flows.
 type InclusionTracker struct {
 	clClient   *beacon.Client
 	chainSvc   chain.Service
@@ -85,6 +88,16 @@ type InclusionTracker struct {
 	revealSvc  *RevealService           // optional; nil pre-Gloas
 	payments   *PaymentTracker          // optional; nil pre-Gloas
 
+	// Our builder index, set once registration completes; Gloas blocks only
+	// count as ours when their bid carries it.
+	builderIndex    atomic.Uint64
+	builderIndexSet atomic.Bool
+
+	// extraIndices are the builder indices of the extra builder keys run by
+	// this instance; their bids are ours too.
+	extraIndices   map[uint64]bool
+	extraIndicesMu sync.RWMutex
+
 	includedDispatch      utils.Dispatcher[*PayloadIncludedEvent]
 	payloadStatusDispatch utils.Dispatcher[*PayloadStatusEvent]
 
@@ -123,6 +136,31 @@ func NewInclusionTracker(
 	}
 }
 
+// SetBuilderIndex sets our builder index, matched against the builder index of
+// Gloas blocks' execution payload bids.
+func (t *InclusionTracker) SetBuilderIndex(index uint64) {
+	t.builderIndex.Store(index)
+	t.builderIndexSet.Store(true)
+}
+
+// AddExtraBuilderIndex adds the builder index of an extra builder key; blocks
+// committing to its bids count as ours.
+func (t *InclusionTracker) AddExtraBuilderIndex(index uint64) {
+	t.extraIndicesMu.Lock()
+	defer t.extraIndicesMu.Unlock()
+
+	if t.extraIndices == nil {
+		t.extraIndices = make(map[uint64]bool, 2)
+	}
+
+	t.extraIndices[index] = true
+}
+
+// BuilderIndex returns our builder index and whether it is known yet.
+func (t *InclusionTracker) BuilderIndex() (uint64, bool) {
+	return t.builderIndex.Load(), t.builderIndexSet.Load()
+}
+
 // SubscribeIncluded subscribes to payload inclusion events.
 func (t *InclusionTracker) SubscribeIncluded(capacity int, blocking bool) *utils.Subscription[*PayloadIncludedEvent] {
 	return t.includedDispatch.Subscribe(capacity, blocking)
@@ -362,12 +400,24 @@ func (t *InclusionTracker) logPaymentState(slot phase0.Slot) {
 		Warn("Won bid was NOT revealed — payment pending for 2 epochs")
 }
 
-// checkForOurPayload checks if the beacon block commits to one of our payloads
-// (pre-Gloas the payload is embedded in the block, so the execution block hash
-// match works on all forks).
+// checkForOurPayload checks if the beacon block commits to one of our payloads.
+// From Gloas on the block's bid must carry our builder index (the bid's block
+// hash then selects the payload); pre-Gloas the payload is embedded in the
+// block and the execution block hash match alone identifies it.
 func (t *InclusionTracker) checkForOurPayload(blockInfo *beacon.BlockInfo) {
+	if !t.isOurBid(blockInfo) {
+		return
+	}
+
 	payload := t.builderSvc.GetPayloadCache().GetByBlockHash(blockInfo.ExecutionBlockHash)
 	if payload == nil {
+		if blockInfo.BidBuilderIndex != nil {
+			t.log.WithFields(logrus.Fields{
+				"slot":       blockInfo.Slot,
+				"block_hash": fmt.Sprintf("%x", blockInfo.ExecutionBlockHash[:8]),
+			}).Warn("Block commits to a bid with our builder index, but the payload is unknown")
+		}
+
 		return
 	}
 
@@ -384,11 +434,13 @@ func (t *InclusionTracker) checkForOurPayload(blockInfo *beacon.BlockInfo) {
 
 	// Builder payments and reveals only exist post-Gloas; before that the
 	// payload is part of the block itself and nothing is owed or revealed.
-	if t.chainSvc.GetCurrentFork() >= version.DataVersionGloas && t.revealSvc != nil && t.payments != nil {
+	if t.chainSvc.ActiveForkAtEpoch(t.chainSvc.GetEpochOfSlot(blockInfo.Slot)) >= version.DataVersionGloas &&
+		t.revealSvc != nil && t.payments != nil {
 		// Record as pending payment (moved to a balance deduction if revealed,
-		// or pending for 2 epochs if not).
-		if bidValueGwei > 0 {
-			t.payments.RecordWonBid(payload.Attributes.ProposalSlot, bidValueGwei)
+		// or pending for 2 epochs if not). The ledger is the builder key's:
+		// extra builder keys' wins are owed from their own balances.
+		if bidValueGwei > 0 && !t.isExtraBuilderBid(blockInfo) {
+			t.payments.RecordWonBid(payload.Attributes.ProposalSlot, payload.BlockHash, bidValueGwei)
 		}
 
 		// Request the reveal; the per-slot dedup makes this a no-op for
@@ -397,6 +449,7 @@ func (t *InclusionTracker) checkForOurPayload(blockInfo *beacon.BlockInfo) {
 			Payload:   payload,
 			BlockInfo: blockInfo,
 			Transport: payload_builder.BidTransportP2P,
+			Confirmed: true,
 		})
 	}
 
@@ -427,6 +480,35 @@ func (t *InclusionTracker) checkForOurPayload(blockInfo *beacon.BlockInfo) {
 	}
 }
 
+// isOurBid reports whether the block's committed bid is ours: Gloas+ blocks
+// must carry our (or an extra builder key's) builder index in their execution
+// payload bid (never ours before registration), pre-Gloas blocks carry no bid and are left to the
+// block hash match.
+func (t *InclusionTracker) isOurBid(blockInfo *beacon.BlockInfo) bool {
+	if blockInfo.BidBuilderIndex == nil {
+		return true
+	}
+
+	if t.builderIndexSet.Load() && *blockInfo.BidBuilderIndex == t.builderIndex.Load() {
+		return true
+	}
+
+	return t.isExtraBuilderBid(blockInfo)
+}
+
+// isExtraBuilderBid reports whether the block's committed bid carries an
+// extra builder key's index.
+func (t *InclusionTracker) isExtraBuilderBid(blockInfo *beacon.BlockInfo) bool {
+	if blockInfo.BidBuilderIndex == nil {
+		return false
+	}
+
+	t.extraIndicesMu.RLock()
+	defer t.extraIndicesMu.RUnlock()
+
+	return t.extraIndices[*blockInfo.BidBuilderIndex]
+}
+
 // buildWonBlock derives the won-block summary for an included payload (no
 // storage side effects). The source is derived from the payload's bid
 // records: any Builder-API bid marks the win as a Builder API delivery,
@@ -460,6 +542,16 @@ func (t *InclusionTracker) buildWonBlock(
 		}
 	}
 
+	var publishes []WonBlockPublish
+
+	for _, rec := range payload.Publishes() {
+		publishes = append(publishes, WonBlockPublish{
+			Node:      rec.Node,
+			LatencyMs: rec.Latency.Milliseconds(),
+			Error:     rec.Err,
+		})
+	}
+
 	return &WonBlock{
 		Source:          source,
 		Slot:            uint64(payload.Attributes.ProposalSlot),
@@ -469,6 +561,7 @@ func (t *InclusionTracker) buildWonBlock(
 		ValueWei:        valueWei,
 		ValueETH:        valueETH,
 		Timestamp:       time.Now().UnixMilli(),
+		Publishes:       publishes,
 	}
 }
 
@@ -296,6 +296,58 @@ func TestInclusionTracker_GloasGatingAndInclusion(t *testing.T) {
 	}
 }
 
+// TestInclusionTracker_GloasBidBuilderIndex confirms Gloas inclusion from the
+// block's bid: only a bid carrying our (or an extra builder key's) builder
+// index counts, even when the committed block hash is one of ours.
+func TestInclusionTracker_GloasBidBuilderIndex(t *testing.T) {
+	ourIndex, otherIndex := uint64(3), uint64(9)
+
+	tests := []struct {
+		name         string
+		setIndex     bool
+		extraIndex   bool
+		bidIndex     uint64
+		wantIncluded bool
+	}{
+		{name: "bid with our builder index", setIndex: true, bidIndex: ourIndex, wantIncluded: true},
+		{name: "bid from another builder", setIndex: true, bidIndex: otherIndex},
+		{name: "bid from an extra builder key", setIndex: true, extraIndex: true, bidIndex: otherIndex, wantIncluded: true},
+		{name: "builder index not yet known", bidIndex: ourIndex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := newHookedLogger()
+			chainSvc := &stubChainService{currentFork: version.DataVersionGloas}
+			builderSvc := newTestBuilderSvc(chainSvc)
+			tracker := NewInclusionTracker(nil, chainSvc, builderSvc, nil, nil, logger)
+
+			if tt.setIndex {
+				tracker.SetBuilderIndex(ourIndex)
+			}
+
+			if tt.extraIndex {
+				tracker.AddExtraBuilderIndex(otherIndex)
+			}
+
+			ourHash := phase0.Hash32{0xab}
+			builderSvc.GetPayloadCache().Store(newTestPayload(5, ourHash, big.NewInt(1_000_000_000_000)))
+
+			tracker.processBlockInfo(&beacon.BlockInfo{
+				Slot: 5, Root: phase0.Root{0x05}, ExecutionBlockHash: ourHash, BidBuilderIndex: &tt.bidIndex,
+			})
+
+			if tt.wantIncluded {
+				assert.Equal(t, uint64(1), builderSvc.GetStats().BlocksIncluded)
+				assert.Contains(t, tracker.trackedWins, phase0.Slot(5))
+			} else {
+				assert.Zero(t, builderSvc.GetStats().BlocksIncluded)
+				assert.Empty(t, tracker.trackedWins)
+			}
+		})
+	}
+}
+
 func TestInclusionTracker_BuildWonBlockSource(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -13,6 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
@@ -28,6 +29,7 @@ type stubChainService struct {
 	currentFork  version.DataVersion
 	currentEpoch phase0.Epoch
 	genesis      beacon.Genesis
+	clock        clock.Clock // nil = system clock
 
 	epochStatsDispatch utils.Dispatcher[*chain.EpochStats]
 }
@@ -59,6 +61,14 @@ func (m *stubChainService) TimeToSlot(t time.Time) phase0.Slot {
 func (m *stubChainService) GetCurrentEpoch() phase0.Epoch { return m.currentEpoch }
 func (m *stubChainService) GetCurrentSlot() phase0.Slot   { return 0 }
 
+func (m *stubChainService) Clock() clock.Clock {
+	if m.clock == nil {
+		return clock.Real()
+	}
+
+	return m.clock
+}
+
 func (m *stubChainService) GetCurrentFork() version.DataVersion { return m.currentFork }
 func (m *stubChainService) ActiveForkAtEpoch(phase0.Epoch) version.DataVersion {
 	return m.currentFork
@@ -75,6 +85,9 @@ func (m *stubChainService) GetEpochStats(phase0.Epoch) *chain.EpochStats { retur
 func (m *stubChainService) SubscribeEpochStats() *utils.Subscription[*chain.EpochStats] {
 	return m.epochStatsDispatch.Subscribe(4, false)
 }
+func (m *stubChainService) SubscribeForkTransitions() *utils.Subscription[*chain.ForkTransition] {
+	return nil
+}
 
 func (m *stubChainService) GetHeadVoteTracker() *chain.HeadVoteTracker { return nil }
 func (m *stubChainService) GetFinalizedEpoch() phase0.Epoch            { return 0 }
@@ -0,0 +1,116 @@
+package payload_bidder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/db"
+)
+
+// PaymentLedgerNamespace is the kv_store namespace holding the pending payment
+// ledger (won bids whose reveal has not been observed yet).
+const PaymentLedgerNamespace = "payment_ledger"
+
+// PaymentLedgerCodec translates the payment ledger's entries to their
+// persisted form: decimal slot string keys, JSON-encoded values.
+type PaymentLedgerCodec struct{}
+
+var _ db.KVCodec[phase0.Slot, *PendingPayment] = PaymentLedgerCodec{}
+
+// EncodeKey encodes a slot as its decimal string form.
+func (PaymentLedgerCodec) EncodeKey(slot phase0.Slot) string {
+	return strconv.FormatUint(uint64(slot), 10)
+}
+
+// DecodeKey parses a decimal slot string.
+func (PaymentLedgerCodec) DecodeKey(key string) (phase0.Slot, error) {
+	slot, err := strconv.ParseUint(key, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid payment ledger slot key %q: %w", key, err)
+	}
+
+	return phase0.Slot(slot), nil
+}
+
+// EncodeValue JSON-encodes a pending payment.
+func (PaymentLedgerCodec) EncodeValue(payment *PendingPayment) ([]byte, error) {
+	if payment == nil {
+		return nil, fmt.Errorf("cannot encode nil pending payment")
+	}
+
+	return json.Marshal(payment)
+}
+
+// DecodeValue JSON-decodes a pending payment.
+func (PaymentLedgerCodec) DecodeValue(value []byte) (*PendingPayment, error) {
+	payment := &PendingPayment{}
+	if err := json.Unmarshal(value, payment); err != nil {
+		return nil, fmt.Errorf("failed to decode pending payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// PayloadEnvelopeFetcher is the beacon client subset the ledger reconciliation
+// needs (satisfied by *beacon.Client).
+type PayloadEnvelopeFetcher interface {
+	GetExecutionPayloadEnvelope(ctx context.Context, blockID string) (*eth2all.SignedExecutionPayloadEnvelope, error)
+}
+
+// SetPersistence attaches the optional state-db so the pending payment ledger
+// survives restarts: previously persisted entries are loaded and future
+// changes are flushed into the payment_ledger kv_store namespace. Call Stop
+// before the state-db closes.
+func (t *PaymentTracker) SetPersistence(ctx context.Context, stateDB *db.Database) {
+	if stateDB == nil {
+		return
+	}
+
+	t.pendingPayments.SetPersistence(ctx,
+		db.NewKVPersistence(stateDB, PaymentLedgerNamespace, PaymentLedgerCodec{}),
+		t.log.WithField("component", "payment-ledger"))
+}
+
+// Stop flushes pending ledger changes and stops the persistence flush loop.
+// No-op when no persistence is attached.
+func (t *PaymentTracker) Stop() {
+	t.pendingPayments.Stop()
+}
+
+// ReconcileLedger brings a ledger loaded from the state-db up to date with the
+// chain after a restart: expired entries are pruned, and entries whose reveal
+// happened while buildoor was down (the slot's payload envelope carries our
+// block hash) are marked revealed. Entries without an envelope stay pending;
+// a failed lookup leaves the entry to expire normally.
+func (t *PaymentTracker) ReconcileLedger(ctx context.Context, fetcher PayloadEnvelopeFetcher) {
+	t.PruneExpiredPayments(t.chainSvc.GetCurrentEpoch())
+
+	for slot, p := range t.pendingPayments.Entries() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		envelope, err := fetcher.GetExecutionPayloadEnvelope(ctx, strconv.FormatUint(uint64(slot), 10))
+		if err != nil {
+			t.log.WithError(err).WithField("slot", slot).Debug("No payload envelope for pending payment")
+			continue
+		}
+
+		if envelope.Message.Payload.BlockHash != p.BlockHash {
+			continue
+		}
+
+		t.log.WithFields(logrus.Fields{
+			"slot":       slot,
+			"block_hash": p.BlockHash.String(),
+		}).Info("Pending payment was revealed while offline")
+
+		t.MarkRevealed(slot)
+	}
+}
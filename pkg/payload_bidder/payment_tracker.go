@@ -1,19 +1,23 @@
 package payload_bidder
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/memstore"
 )
 
 // PendingPayment records an unrevealed won bid that may be deducted later.
+// It is the payment ledger entry persisted across restarts.
 type PendingPayment struct {
-	Slot  phase0.Slot
-	Epoch phase0.Epoch
-	Value uint64 // Gwei
+	Slot      phase0.Slot   `json:"slot"`
+	Epoch     phase0.Epoch  `json:"epoch"`
+	BlockHash phase0.Hash32 `json:"block_hash"` // our included payload, matched against its envelope
+	Value     uint64        `json:"value"`      // Gwei
 }
 
 // PaymentTracker tracks the builder's payment obligations and live balance
@@ -33,8 +37,10 @@ type PaymentTracker struct {
 	adjustmentMu      sync.Mutex
 
 	// Pending payments: unrevealed won bids, pending for 2 epochs.
-	// Only these count as "pending" in the UI and for topup checks.
-	pendingPayments map[phase0.Slot]*PendingPayment
+	// Only these count as "pending" in the UI and for topup checks. The store
+	// is the payment ledger, persisted when a state-db is attached
+	// (SetPersistence); pendingMu serializes read-modify-write sequences.
+	pendingPayments *memstore.Store[phase0.Slot, *PendingPayment]
 	pendingMu       sync.Mutex
 
 	chainSvc chain.Service
@@ -44,7 +50,7 @@ type PaymentTracker struct {
 // NewPaymentTracker creates a new payment tracker.
 func NewPaymentTracker(chainSvc chain.Service, log logrus.FieldLogger) *PaymentTracker {
 	return &PaymentTracker{
-		pendingPayments: make(map[phase0.Slot]*PendingPayment, 16),
+		pendingPayments: memstore.New[phase0.Slot, *PendingPayment](),
 		chainSvc:        chainSvc,
 		log:             log.WithField("component", "payment-tracker"),
 	}
@@ -54,17 +60,18 @@ func NewPaymentTracker(chainSvc chain.Service, log logrus.FieldLogger) *PaymentT
 // Called when our bid is included in a beacon block.
 // If we later reveal, call MarkRevealed to move it from pending to a balance deduction.
 // If we don't reveal, it stays pending for 2 epochs then expires.
-func (t *PaymentTracker) RecordWonBid(slot phase0.Slot, value uint64) {
+func (t *PaymentTracker) RecordWonBid(slot phase0.Slot, blockHash phase0.Hash32, value uint64) {
 	t.pendingMu.Lock()
 	defer t.pendingMu.Unlock()
 
 	epoch := t.chainSvc.GetEpochOfSlot(slot)
 
-	t.pendingPayments[slot] = &PendingPayment{
-		Slot:  slot,
-		Epoch: epoch,
-		Value: value,
-	}
+	t.pendingPayments.Put(slot, &PendingPayment{
+		Slot:      slot,
+		Epoch:     epoch,
+		BlockHash: blockHash,
+		Value:     value,
+	})
 
 	t.log.WithFields(logrus.Fields{
 		"slot":  slot,
@@ -77,14 +84,14 @@ func (t *PaymentTracker) RecordWonBid(slot phase0.Slot, value uint64) {
 // The payment is removed from pending and subtracted from the balance adjustment.
 func (t *PaymentTracker) MarkRevealed(slot phase0.Slot) {
 	t.pendingMu.Lock()
-	p, ok := t.pendingPayments[slot]
+	p, ok := t.pendingPayments.Get(slot)
 	if !ok {
 		t.pendingMu.Unlock()
 		return
 	}
 
 	value := p.Value
-	delete(t.pendingPayments, slot)
+	t.pendingPayments.Delete(slot)
 	t.pendingMu.Unlock()
 
 	// Deduct from live balance, anchored to this slot's epoch so the
@@ -145,6 +152,36 @@ func (t *PaymentTracker) ReconcileToEpoch(snapshotEpoch phase0.Epoch) {
 	t.adjustmentEpoch = snapshotEpoch
 }
 
+// GetPendingPayment returns the slot's pending (unrevealed) payment, or nil
+// when the slot owes none (revealed, expired or never won).
+func (t *PaymentTracker) GetPendingPayment(slot phase0.Slot) *PendingPayment {
+	t.pendingMu.Lock()
+	defer t.pendingMu.Unlock()
+
+	payment, _ := t.pendingPayments.Get(slot)
+
+	return payment
+}
+
+// GetPendingPayments returns copies of the pending (unrevealed) payments,
+// ordered by slot.
+func (t *PaymentTracker) GetPendingPayments() []PendingPayment {
+	t.pendingMu.Lock()
+	defer t.pendingMu.Unlock()
+
+	payments := make([]PendingPayment, 0, t.pendingPayments.Len())
+
+	for _, p := range t.pendingPayments.Values() {
+		payments = append(payments, *p)
+	}
+
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].Slot < payments[j].Slot
+	})
+
+	return payments
+}
+
 // GetTotalPendingPayments returns the sum of unrevealed won bid obligations.
 func (t *PaymentTracker) GetTotalPendingPayments() uint64 {
 	t.pendingMu.Lock()
@@ -152,7 +189,7 @@ func (t *PaymentTracker) GetTotalPendingPayments() uint64 {
 
 	var total uint64
 
-	for _, p := range t.pendingPayments {
+	for _, p := range t.pendingPayments.Values() {
 		total += p.Value
 	}
 
@@ -164,7 +201,7 @@ func (t *PaymentTracker) PruneExpiredPayments(currentEpoch phase0.Epoch) {
 	t.pendingMu.Lock()
 	defer t.pendingMu.Unlock()
 
-	for slot, p := range t.pendingPayments {
+	for slot, p := range t.pendingPayments.Entries() {
 		if currentEpoch > p.Epoch+1 {
 			t.log.WithFields(logrus.Fields{
 				"slot":          slot,
@@ -173,7 +210,7 @@ func (t *PaymentTracker) PruneExpiredPayments(currentEpoch phase0.Epoch) {
 				"value":         p.Value,
 			}).Debug("Pruning expired pending payment")
 
-			delete(t.pendingPayments, slot)
+			t.pendingPayments.Delete(slot)
 		}
 	}
 }
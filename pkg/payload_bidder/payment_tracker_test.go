@@ -1,11 +1,15 @@
 package payload_bidder
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func newTestPaymentTracker() *PaymentTracker {
@@ -18,11 +22,16 @@ func newTestPaymentTracker() *PaymentTracker {
 func TestPaymentTracker_RecordAndReveal(t *testing.T) {
 	tracker := newTestPaymentTracker()
 
-	tracker.RecordWonBid(100, 1000)
-	tracker.RecordWonBid(101, 500)
+	tracker.RecordWonBid(100, phase0.Hash32{}, 1000)
+	tracker.RecordWonBid(101, phase0.Hash32{}, 500)
 	assert.Equal(t, uint64(1500), tracker.GetTotalPendingPayments())
 	assert.Equal(t, int64(0), tracker.GetBalanceAdjustment())
 
+	pending := tracker.GetPendingPayments()
+	require.Len(t, pending, 2)
+	assert.Equal(t, phase0.Slot(100), pending[0].Slot, "ordered by slot")
+	assert.Equal(t, phase0.Slot(101), pending[1].Slot)
+
 	// Revealing moves the payment from pending to a balance deduction.
 	tracker.MarkRevealed(100)
 	assert.Equal(t, uint64(500), tracker.GetTotalPendingPayments())
@@ -34,7 +43,7 @@ func TestPaymentTracker_RecordAndReveal(t *testing.T) {
 	assert.Equal(t, int64(-1000), tracker.GetBalanceAdjustment())
 
 	// Re-recording a slot overwrites the pending value.
-	tracker.RecordWonBid(101, 700)
+	tracker.RecordWonBid(101, phase0.Hash32{}, 700)
 	assert.Equal(t, uint64(700), tracker.GetTotalPendingPayments())
 }
 
@@ -44,7 +53,7 @@ func TestPaymentTracker_DepositsAndDeductions(t *testing.T) {
 	tracker.AddDeposit(3000)
 	assert.Equal(t, int64(3000), tracker.GetBalanceAdjustment())
 
-	tracker.RecordWonBid(10, 1000)
+	tracker.RecordWonBid(10, phase0.Hash32{}, 1000)
 	tracker.MarkRevealed(10)
 	assert.Equal(t, int64(2000), tracker.GetBalanceAdjustment())
 }
@@ -77,7 +86,7 @@ func TestPaymentTracker_ReconcileToEpoch(t *testing.T) {
 	// A reveal deduction in the new epoch anchors to that epoch and survives
 	// same-epoch reconciles.
 	chainSvc.currentEpoch = 6
-	tracker.RecordWonBid(6*32, 1000)
+	tracker.RecordWonBid(6*32, phase0.Hash32{}, 1000)
 	tracker.MarkRevealed(6 * 32)
 	assert.Equal(t, int64(-1000), tracker.GetBalanceAdjustment())
 
@@ -92,8 +101,8 @@ func TestPaymentTracker_PruneExpiredPayments(t *testing.T) {
 	tracker := newTestPaymentTracker()
 
 	// stubChainService maps slot -> epoch via slot/32.
-	tracker.RecordWonBid(32, 100) // epoch 1
-	tracker.RecordWonBid(64, 200) // epoch 2
+	tracker.RecordWonBid(32, phase0.Hash32{}, 100) // epoch 1
+	tracker.RecordWonBid(64, phase0.Hash32{}, 200) // epoch 2
 
 	// Payments stay pending through payment epoch + 1.
 	tracker.PruneExpiredPayments(phase0.Epoch(2))
@@ -109,3 +118,60 @@ func TestPaymentTracker_PruneExpiredPayments(t *testing.T) {
 	// Pruning never touches the balance adjustment.
 	assert.Equal(t, int64(0), tracker.GetBalanceAdjustment())
 }
+
+// stubEnvelopeFetcher serves payload envelopes by slot block ID.
+type stubEnvelopeFetcher map[string]phase0.Hash32
+
+func (f stubEnvelopeFetcher) GetExecutionPayloadEnvelope(_ context.Context,
+	blockID string) (*eth2all.SignedExecutionPayloadEnvelope, error) {
+	blockHash, ok := f[blockID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return &eth2all.SignedExecutionPayloadEnvelope{
+		Message: &eth2all.ExecutionPayloadEnvelope{
+			Payload: &eth2all.ExecutionPayload{BlockHash: blockHash},
+		},
+	}, nil
+}
+
+func TestPaymentTracker_ReconcileLedger(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	tracker := NewPaymentTracker(&stubChainService{currentEpoch: 3}, log)
+
+	tracker.RecordWonBid(32, phase0.Hash32{0x01}, 100) // epoch 1: expired
+	tracker.RecordWonBid(96, phase0.Hash32{0x02}, 200) // revealed while offline
+	tracker.RecordWonBid(97, phase0.Hash32{0x03}, 400) // slot carries another payload
+	tracker.RecordWonBid(98, phase0.Hash32{0x04}, 800) // no envelope yet
+
+	tracker.ReconcileLedger(context.Background(), stubEnvelopeFetcher{
+		"32": {0x01},
+		"96": {0x02},
+		"97": {0xff},
+	})
+
+	assert.Equal(t, uint64(1200), tracker.GetTotalPendingPayments())
+	assert.Equal(t, int64(-200), tracker.GetBalanceAdjustment())
+}
+
+func TestPaymentLedgerCodec(t *testing.T) {
+	codec := PaymentLedgerCodec{}
+	payment := &PendingPayment{Slot: 96, Epoch: 3, BlockHash: phase0.Hash32{0x02}, Value: 200}
+
+	slot, err := codec.DecodeKey(codec.EncodeKey(payment.Slot))
+	require.NoError(t, err)
+	assert.Equal(t, payment.Slot, slot)
+
+	encoded, err := codec.EncodeValue(payment)
+	require.NoError(t, err)
+
+	decoded, err := codec.DecodeValue(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, payment, decoded)
+
+	_, err = codec.DecodeKey("nope")
+	assert.Error(t, err)
+}
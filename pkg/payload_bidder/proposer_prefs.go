@@ -153,12 +153,12 @@ func (s *ProposerPreferencesService) pruneForEpoch(epoch phase0.Epoch) {
 }
 
 // ResolveProposerSettings resolves the proposer's announced settings for a
-// build from the cached gossip preference. Self-scoped: it only applies from
-// the Gloas fork onwards and returns false when no preference is cached for
-// the slot.
+// build from the cached gossip preference. Self-scoped: it only applies to
+// Gloas+ slots (the first Gloas slot's build runs in the last pre-Gloas
+// slot) and returns false when no preference is cached for the slot.
 func (s *ProposerPreferencesService) ResolveProposerSettings(slot phase0.Slot,
 	_ phase0.ValidatorIndex) (payload_builder.ProposerSettings, bool) {
-	if s.chainSvc.GetCurrentFork() < version.DataVersionGloas {
+	if s.chainSvc.ActiveForkAtEpoch(s.chainSvc.GetEpochOfSlot(slot)) < version.DataVersionGloas {
 		return payload_builder.ProposerSettings{}, false
 	}
 
@@ -69,7 +69,7 @@ func BuildSignedEnvelope(
 		envelope = transformed
 	}
 
-	sig, err := s.SignEnvelope(envelope, forkVersion, genesisValidatorsRoot)
+	sig, err := s.SignEnvelope(envelope, p.Attributes.ProposalSlot, forkVersion, genesisValidatorsRoot)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to sign envelope: %w", err)
 	}
@@ -2,6 +2,7 @@ package payload_bidder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -13,7 +14,9 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/metrics"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/utils"
@@ -41,6 +44,12 @@ type RevealRequest struct {
 	Payload   *payload_builder.Payload
 	BlockInfo *beacon.BlockInfo // root + parent root of the committing beacon block
 	Transport payload_builder.BidTransport
+
+	// Confirmed marks a request derived from the committing block seen at the
+	// head with our bid in its body (inclusion tracker), as opposed to local
+	// bookkeeping (Builder API block delivery). Reveals requiring inclusion
+	// wait for a confirmed request.
+	Confirmed bool
 }
 
 // RevealStarted announces the beginning of a reveal attempt (envelope
@@ -59,10 +68,15 @@ type RevealResult struct {
 	Transport   payload_builder.BidTransport
 	Success     bool
 	Skipped     bool   // reveal was skipped without publishing (see SkipReason)
-	SkipReason  string `json:"skip_reason,omitempty"` // RevealSkipReasonPlanDisabled | RevealSkipReasonLate
+	SkipReason  string `json:"skip_reason,omitempty"` // RevealSkipReason* constants
 	Error       string // failure reason (when Success is false)
-	Attempt     int    // 1-based
+	Attempt     int    // 1-based (manual attempts are numbered separately)
 	MaxAttempts int
+	Manual      bool // operator-triggered publish (PublishNow), outside the retry policy
+
+	// Chaos is the slot's reveal chaos outcome (action_plan.RevealChaos*) on
+	// automatic results; empty without chaos and on manual publishes.
+	Chaos string `json:"chaos,omitempty"`
 
 	// StartedAt/CompletedAt bracket the attempt (envelope construction +
 	// submit call) — the submit call alone takes several hundred ms on some
@@ -90,8 +104,47 @@ const (
 	// RevealSkipReasonVoteGateTimeout marks a vote-gated reveal whose
 	// participation threshold was never reached before the slot expired.
 	RevealSkipReasonVoteGateTimeout = "vote_gate_timeout"
+	// RevealSkipReasonNotIncluded marks a reveal requiring inclusion whose
+	// bid was never confirmed in a head block before the slot expired.
+	RevealSkipReasonNotIncluded = "not_included"
+	// RevealSkipReasonChaos marks a reveal deliberately withheld by reveal
+	// chaos (reveal.chaos_withhold_pct).
+	RevealSkipReasonChaos = "chaos_withheld"
 )
 
+// ErrNoRevealRequest is returned by PreviewReveal and PublishNow for a slot
+// without a reveal request: no bid of ours was committed to it (or its entry
+// was already pruned, two slots after it finished).
+var ErrNoRevealRequest = errors.New("no reveal request for slot")
+
+// RevealPreview is the signed envelope a slot's reveal publishes (or
+// published) together with its reveal state.
+type RevealPreview struct {
+	Slot                phase0.Slot
+	Transport           payload_builder.BidTransport
+	Envelope            *eth2all.SignedExecutionPayloadEnvelope
+	BlobCount           int
+	BroadcastValidation string // the slot's frozen broadcast validation level
+	Attempts            int    // automatic publish attempts so far
+	Published           bool   // the beacon node accepted the envelope
+	Done                bool   // no further automatic attempts (published, skipped or given up)
+}
+
+// revealCommand is an operator request (preview or manual publish) executed
+// on the run loop, which owns the pending states.
+type revealCommand struct {
+	slot                phase0.Slot
+	publish             bool
+	broadcastValidation string // publish only; "" = the slot's frozen level
+	reply               chan revealCommandReply
+}
+
+type revealCommandReply struct {
+	preview *RevealPreview
+	result  *RevealResult
+	err     error
+}
+
 // RevealService publishes execution payload envelopes once the slot's reveal
 // gates open. It runs its own main loop: requests arrive on a channel, due
 // times are awaited with a timer (no polling), head-vote updates open vote
@@ -113,7 +166,17 @@ type RevealService struct {
 	votes        headVoteSource           // optional; nil = vote gates can never open
 	builderIndex atomic.Uint64
 
+	// voteGatesDisabled makes vote-gated reveals fall back to the time gate
+	// (see DisableVoteGates).
+	voteGatesDisabled atomic.Bool
+
+	// extraSigners sign the envelopes of bids won by extra builder keys,
+	// keyed by their builder index.
+	extraSigners   map[uint64]*Signer
+	extraSignersMu sync.RWMutex
+
 	requests chan *RevealRequest
+	commands chan *revealCommand
 	results  utils.Dispatcher[*RevealResult]
 	starts   utils.Dispatcher[*RevealStarted]
 	pending  map[phase0.Slot]*revealState // owned by the run loop; no mutex
@@ -129,11 +192,14 @@ type RevealService struct {
 type revealState struct {
 	req              *RevealRequest
 	settings         *action_plan.ResolvedRevealSettings     // frozen reveal settings for the slot
-	envelope         *eth2all.SignedExecutionPayloadEnvelope // built on the first attempt, reused on retries
+	envelope         *eth2all.SignedExecutionPayloadEnvelope // built on the first attempt (or preview), reused on retries
 	blobs            [][]byte
 	proofs           [][]byte
 	attempts         int
 	attemptStartedAt time.Time // start of the current attempt (construction + submit)
+	manualAttempts   int
+	published        bool
+	confirmed        bool // bid seen included at the head (see RevealRequest.Confirmed)
 
 	voteGateMet bool
 	timeDue     time.Time // when the time gate opens (gate modes involving time)
@@ -153,7 +219,12 @@ func (st *revealState) voteGated() bool {
 }
 
 // gateSatisfied reports whether the reveal may be published at the given time.
+// Reveals requiring inclusion stay closed until their bid is confirmed.
 func (st *revealState) gateSatisfied(now time.Time) bool {
+	if st.settings.RequireInclusion && !st.confirmed {
+		return false
+	}
+
 	switch st.settings.GateMode {
 	case config.RevealGateVote:
 		return st.voteGateMet
@@ -166,6 +237,38 @@ func (st *revealState) gateSatisfied(now time.Time) bool {
 	}
 }
 
+// firstAttempt returns the first attempt time per gate mode; vote-waiting
+// states park on the expiry (a vote update pulls them forward).
+func (st *revealState) firstAttempt(now time.Time) time.Time {
+	var next time.Time
+
+	switch st.settings.GateMode {
+	case config.RevealGateVote:
+		next = st.expiry
+		if st.voteGateMet {
+			next = now
+		}
+	case config.RevealGateVoteOrTime:
+		next = st.timeDue
+		if st.voteGateMet {
+			next = now
+		}
+	case config.RevealGateVoteAndTime:
+		next = st.expiry
+		if st.voteGateMet {
+			next = st.timeDue
+		}
+	default: // config.RevealGateTime
+		next = st.timeDue
+	}
+
+	if next.Before(now) {
+		next = now
+	}
+
+	return next
+}
+
 // NewRevealService creates a new reveal service. planSvc is required — every
 // reveal decision resolves the slot's frozen action plan; passing nil is a
 // programming error. votes is the head-vote tracker backing the vote gates;
@@ -191,6 +294,7 @@ func NewRevealService(
 		planSvc:    planSvc,
 		votes:      votes,
 		requests:   make(chan *RevealRequest, 16),
+		commands:   make(chan *revealCommand),
 		pending:    make(map[phase0.Slot]*revealState, 8),
 		log:        log.WithField("component", "reveal-service"),
 	}
@@ -225,6 +329,44 @@ func (s *RevealService) SetBuilderIndex(index uint64) {
 	s.builderIndex.Store(index)
 }
 
+// DisableVoteGates makes every vote-gated reveal fall back to the time gate,
+// for beacon nodes that do not stream the attestations head-vote tracking
+// is built on. Without it such reveals would wait out the slot.
+func (s *RevealService) DisableVoteGates() {
+	s.voteGatesDisabled.Store(true)
+}
+
+// SetExtraBuilderSigner registers the envelope signer of an extra builder
+// key: reveals of blocks committing to a bid with its builder index are
+// signed by it under that index.
+func (s *RevealService) SetExtraBuilderSigner(index uint64, signer *Signer) {
+	s.extraSignersMu.Lock()
+	defer s.extraSignersMu.Unlock()
+
+	if s.extraSigners == nil {
+		s.extraSigners = make(map[uint64]*Signer, 2)
+	}
+
+	s.extraSigners[index] = signer
+}
+
+// envelopeIdentity returns the builder index and signer a reveal is signed
+// with: the extra builder key whose bid the committing block carries, else
+// our builder key.
+func (s *RevealService) envelopeIdentity(req *RevealRequest) (uint64, *Signer) {
+	if bidIndex := req.BlockInfo.BidBuilderIndex; bidIndex != nil {
+		s.extraSignersMu.RLock()
+		extra, ok := s.extraSigners[*bidIndex]
+		s.extraSignersMu.RUnlock()
+
+		if ok {
+			return *bidIndex, extra
+		}
+	}
+
+	return s.builderIndex.Load(), s.signer
+}
+
 // SubscribeResults subscribes to reveal results (consumed by the WebUI).
 func (s *RevealService) SubscribeResults(capacity int, blocking bool) *utils.Subscription[*RevealResult] {
 	return s.results.Subscribe(capacity, blocking)
@@ -253,7 +395,9 @@ func (s *RevealService) RequestReveal(req *RevealRequest) {
 func (s *RevealService) run() {
 	defer s.wg.Done()
 
-	timer := time.NewTimer(time.Hour)
+	clk := s.chainSvc.Clock()
+
+	timer := clk.NewTimer(time.Hour)
 	defer timer.Stop()
 
 	var voteChan <-chan *chain.HeadVoteUpdate
@@ -271,10 +415,12 @@ func (s *RevealService) run() {
 			return
 		case req := <-s.requests:
 			s.schedule(req)
+		case cmd := <-s.commands:
+			s.handleCommand(cmd)
 		case update := <-voteChan:
 			s.handleVoteUpdate(update)
-		case <-timer.C:
-			s.processDue(time.Now())
+		case <-timer.C():
+			s.processDue(clk.Now())
 		}
 
 		s.rearm(timer)
@@ -303,7 +449,7 @@ func (s *RevealService) handleVoteUpdate(update *chain.HeadVoteUpdate) {
 
 	state.voteGateMet = true
 
-	next := time.Now()
+	next := s.chainSvc.Clock().Now()
 	if state.settings.GateMode == config.RevealGateVoteAndTime && state.timeDue.After(next) {
 		next = state.timeDue
 	}
@@ -314,7 +460,7 @@ func (s *RevealService) handleVoteUpdate(update *chain.HeadVoteUpdate) {
 		"slot":          update.Slot,
 		"participation": fmt.Sprintf("%.1f%%", update.ParticipationPct),
 		"threshold":     state.settings.VoteThresholdPct,
-		"due_in":        time.Until(next),
+		"due_in":        s.chainSvc.Clock().Until(next),
 	}).Info("Reveal vote gate opened")
 }
 
@@ -334,7 +480,12 @@ func (s *RevealService) schedule(req *RevealRequest) {
 
 	slot := req.Payload.Attributes.ProposalSlot
 
-	if _, exists := s.pending[slot]; exists {
+	if state, exists := s.pending[slot]; exists {
+		if req.Confirmed {
+			s.confirm(slot, state, req)
+			return
+		}
+
 		s.log.WithFields(logrus.Fields{
 			"slot":      slot,
 			"transport": req.Transport,
@@ -353,9 +504,14 @@ func (s *RevealService) schedule(req *RevealRequest) {
 		s.pending[slot] = &revealState{req: req, settings: settings, done: true}
 
 		reason := RevealSkipReasonDisabled
-		if frozen.Plan != nil && frozen.Plan.Reveal != nil &&
-			frozen.Plan.Reveal.Mode == action_plan.ModeDisabled {
+
+		switch {
+		case frozen.Plan != nil && frozen.Plan.Reveal != nil &&
+			frozen.Plan.Reveal.Mode == action_plan.ModeDisabled:
 			reason = RevealSkipReasonPlanDisabled
+		case settings.Chaos == action_plan.RevealChaosWithhold:
+			reason = RevealSkipReasonChaos
+			s.builderSvc.IncrementRevealsChaosWithheld()
 		}
 
 		s.results.Fire(&RevealResult{
@@ -364,6 +520,7 @@ func (s *RevealService) schedule(req *RevealRequest) {
 			Skipped:     true,
 			SkipReason:  reason,
 			MaxAttempts: maxAttempts,
+			Chaos:       settings.Chaos,
 		})
 
 		s.log.WithFields(logrus.Fields{
@@ -375,7 +532,13 @@ func (s *RevealService) schedule(req *RevealRequest) {
 		return
 	}
 
-	now := time.Now()
+	if s.voteGatesDisabled.Load() && settings.GateMode != config.RevealGateTime {
+		fallback := *settings
+		fallback.GateMode = config.RevealGateTime
+		settings = &fallback
+	}
+
+	now := s.chainSvc.Clock().Now()
 	slotStart := s.chainSvc.SlotToTime(slot)
 	slotDuration := s.chainSvc.GetChainSpec().SecondsPerSlot
 	timeDue := slotStart.Add(time.Duration(settings.RevealTimeMs) * time.Millisecond)
@@ -409,59 +572,94 @@ func (s *RevealService) schedule(req *RevealRequest) {
 	}
 
 	state := &revealState{
-		req:      req,
-		settings: settings,
-		timeDue:  timeDue,
-		expiry:   expiry,
-	}
-
-	if state.voteGated() {
-		if s.votes == nil {
-			s.log.WithField("slot", slot).Warn(
-				"Reveal vote gate configured but head vote tracking is unavailable")
-		} else if p, ok := s.votes.GetParticipation(slot, req.BlockInfo.Root); ok &&
-			p.ParticipationPct >= float64(settings.VoteThresholdPct) {
-			state.voteGateMet = true
-		}
+		req:       req,
+		settings:  settings,
+		confirmed: req.Confirmed,
+		timeDue:   timeDue,
+		expiry:    expiry,
 	}
 
-	// The first attempt time per gate mode; vote-waiting states park on the
-	// expiry (a vote update pulls them forward).
-	switch settings.GateMode {
-	case config.RevealGateVote:
-		state.nextAttempt = expiry
-		if state.voteGateMet {
-			state.nextAttempt = now
-		}
-	case config.RevealGateVoteOrTime:
-		state.nextAttempt = timeDue
-		if state.voteGateMet {
-			state.nextAttempt = now
-		}
-	case config.RevealGateVoteAndTime:
-		state.nextAttempt = expiry
-		if state.voteGateMet {
-			state.nextAttempt = timeDue
-		}
-	default: // config.RevealGateTime
-		state.nextAttempt = timeDue
-	}
-
-	if state.nextAttempt.Before(now) {
-		state.nextAttempt = now
-	}
+	s.checkVoteGate(slot, state)
 
+	state.nextAttempt = state.firstAttempt(now)
 	s.pending[slot] = state
 
+	if settings.Chaos == action_plan.RevealChaosDelay {
+		s.builderSvc.IncrementRevealsChaosDelayed()
+		s.log.WithFields(logrus.Fields{
+			"slot":   slot,
+			"due_in": s.chainSvc.Clock().Until(state.nextAttempt),
+		}).Warn("Reveal chaos: delaying payload reveal")
+	}
+
 	s.log.WithFields(logrus.Fields{
 		"slot":      slot,
 		"transport": req.Transport,
 		"gate_mode": settings.GateMode,
 		"vote_met":  state.voteGateMet,
-		"due_in":    time.Until(state.nextAttempt),
+		"confirmed": state.confirmed,
+		"due_in":    s.chainSvc.Clock().Until(state.nextAttempt),
 	}).Debug("Scheduled payload reveal")
 }
 
+// checkVoteGate opens a vote-gated state's gate when the committing block's
+// participation already reached the slot's threshold.
+func (s *RevealService) checkVoteGate(slot phase0.Slot, state *revealState) {
+	if !state.voteGated() {
+		return
+	}
+
+	if s.votes == nil {
+		s.log.WithField("slot", slot).Warn(
+			"Reveal vote gate configured but head vote tracking is unavailable")
+
+		return
+	}
+
+	if p, ok := s.votes.GetParticipation(slot, state.req.BlockInfo.Root); ok &&
+		p.ParticipationPct >= float64(state.settings.VoteThresholdPct) {
+		state.voteGateMet = true
+	}
+}
+
+// confirm records the inclusion confirmation of an already scheduled reveal.
+// Under RequireInclusion this opens the reveal (re-deriving its first attempt
+// time); when the confirmed head block commits to a different block or
+// payload than the scheduled request (our bid lost to another of ours, e.g.
+// an equivocating proposer), the reveal switches to the confirmed one before
+// its first attempt.
+func (s *RevealService) confirm(slot phase0.Slot, state *revealState, req *RevealRequest) {
+	if state.done || state.confirmed {
+		return
+	}
+
+	state.confirmed = true
+
+	if !state.settings.RequireInclusion || state.attempts > 0 {
+		return
+	}
+
+	log := s.log.WithFields(logrus.Fields{
+		"slot":       slot,
+		"block_hash": fmt.Sprintf("%x", req.Payload.BlockHash[:8]),
+	})
+
+	if state.req.BlockInfo.Root != req.BlockInfo.Root || state.req.Payload.BlockHash != req.Payload.BlockHash {
+		log.WithField("scheduled_block_hash", fmt.Sprintf("%x", state.req.Payload.BlockHash[:8])).
+			Warn("Included block differs from the scheduled reveal, revealing the included payload")
+
+		state.req = req
+		state.envelope, state.blobs, state.proofs = nil, nil, nil
+		state.voteGateMet = false
+
+		s.checkVoteGate(slot, state)
+	}
+
+	state.nextAttempt = state.firstAttempt(s.chainSvc.Clock().Now())
+
+	log.Info("Reveal bid inclusion confirmed")
+}
+
 // processDue publishes every pending reveal whose attempt time has come and
 // whose gates are open, expires unsatisfied vote gates, handles success
 // bookkeeping and bounded retries, then prunes stale entries.
@@ -481,18 +679,32 @@ func (s *RevealService) processDue(now time.Time) {
 
 			state.done = true
 
+			reason := RevealSkipReasonVoteGateTimeout
+			if state.settings.RequireInclusion && !state.confirmed {
+				reason = RevealSkipReasonNotIncluded
+				s.builderSvc.IncrementRevealsSkippedNotIncluded()
+			}
+
+			metrics.RecordReveal(metrics.ResultSkipped)
+
 			s.results.Fire(&RevealResult{
 				Slot:        slot,
 				Transport:   state.req.Transport,
 				Skipped:     true,
-				SkipReason:  RevealSkipReasonVoteGateTimeout,
+				SkipReason:  reason,
 				MaxAttempts: int(state.settings.MaxAttempts),
+				Chaos:       state.settings.Chaos,
 			})
 
-			s.log.WithFields(logrus.Fields{
-				"slot":      slot,
-				"threshold": state.settings.VoteThresholdPct,
-			}).Warn("Reveal vote gate never opened, withholding payload")
+			if reason == RevealSkipReasonNotIncluded {
+				s.log.WithField("slot", slot).
+					Warn("Bid inclusion never confirmed at the head, withholding payload")
+			} else {
+				s.log.WithFields(logrus.Fields{
+					"slot":      slot,
+					"threshold": state.settings.VoteThresholdPct,
+				}).Warn("Reveal vote gate never opened, withholding payload")
+			}
 
 			continue
 		}
@@ -514,14 +726,9 @@ func (s *RevealService) processDue(now time.Time) {
 			"max_attempts": state.settings.MaxAttempts,
 		}).Info("Revealing payload")
 
-		if state.envelope == nil {
-			envelope, blobs, proofs, err := s.buildEnvelope(state.req)
-			if err != nil {
-				s.handlePublishFailure(slot, state, now, err)
-				continue
-			}
-
-			state.envelope, state.blobs, state.proofs = envelope, blobs, proofs
+		if err := s.ensureEnvelope(state); err != nil {
+			s.handlePublishFailure(slot, state, now, err)
+			continue
 		}
 
 		if err := s.publish(state.envelope, state.blobs, state.proofs,
@@ -530,19 +737,7 @@ func (s *RevealService) processDue(now time.Time) {
 			continue
 		}
 
-		state.done = true
-
-		state.req.Payload.MarkRevealed(payload_builder.RevealRecord{
-			Transport:       state.req.Transport,
-			BeaconBlockRoot: state.req.BlockInfo.Root,
-			At:              now,
-		})
-
-		if s.payments != nil {
-			s.payments.MarkRevealed(slot)
-		}
-
-		s.builderSvc.IncrementRevealsSuccess()
+		s.markPublished(slot, state, now)
 
 		s.results.Fire(&RevealResult{
 			Slot:        slot,
@@ -553,6 +748,7 @@ func (s *RevealService) processDue(now time.Time) {
 			StartedAt:   state.attemptStartedAt,
 			CompletedAt: time.Now(),
 			Envelope:    state.envelope,
+			Chaos:       state.settings.Chaos,
 		})
 
 		s.log.WithFields(logrus.Fields{
@@ -565,6 +761,191 @@ func (s *RevealService) processDue(now time.Time) {
 	s.pruneDone(now)
 }
 
+// ensureEnvelope builds and caches the state's signed envelope unless it was
+// built before (earlier attempt or preview).
+func (s *RevealService) ensureEnvelope(state *revealState) error {
+	if state.envelope != nil {
+		return nil
+	}
+
+	envelope, blobs, proofs, err := s.buildEnvelope(state.req)
+	if err != nil {
+		return err
+	}
+
+	state.envelope, state.blobs, state.proofs = envelope, blobs, proofs
+
+	return nil
+}
+
+// markPublished finishes a state after the beacon node accepted its envelope
+// (reveal bookkeeping runs once, even if a manual re-publish follows).
+func (s *RevealService) markPublished(slot phase0.Slot, state *revealState, now time.Time) {
+	state.done = true
+
+	if state.published {
+		return
+	}
+
+	state.published = true
+
+	state.req.Payload.MarkRevealed(payload_builder.RevealRecord{
+		Transport:       state.req.Transport,
+		BeaconBlockRoot: state.req.BlockInfo.Root,
+		At:              now,
+	})
+
+	if s.payments != nil {
+		s.payments.MarkRevealed(slot)
+	}
+
+	s.builderSvc.IncrementRevealsSuccess()
+	metrics.RecordReveal(metrics.ResultSuccess)
+}
+
+// PreviewReveal returns the signed envelope the slot's reveal publishes,
+// building (and caching it for the reveal) if no attempt built it yet.
+func (s *RevealService) PreviewReveal(ctx context.Context, slot phase0.Slot) (*RevealPreview, error) {
+	reply, err := s.runCommand(ctx, &revealCommand{slot: slot})
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.preview, nil
+}
+
+// PublishNow publishes the slot's envelope immediately, bypassing its reveal
+// gates, suppression and deadline, with the given broadcast validation level
+// ("" = the slot's frozen level). It works on finished reveals too
+// (re-publishing a published envelope), never consumes the automatic retry
+// budget, and a success ends the automatic schedule. The returned result is
+// also fired to result subscribers (marked Manual).
+func (s *RevealService) PublishNow(ctx context.Context, slot phase0.Slot, broadcastValidation string) (*RevealResult, error) {
+	reply, err := s.runCommand(ctx, &revealCommand{
+		slot:                slot,
+		publish:             true,
+		broadcastValidation: broadcastValidation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.result, nil
+}
+
+// runCommand hands a command to the run loop and waits for its reply.
+func (s *RevealService) runCommand(ctx context.Context, cmd *revealCommand) (revealCommandReply, error) {
+	if s.ctx == nil {
+		return revealCommandReply{}, errors.New("reveal service not started")
+	}
+
+	cmd.reply = make(chan revealCommandReply, 1)
+
+	select {
+	case s.commands <- cmd:
+	case <-ctx.Done():
+		return revealCommandReply{}, ctx.Err()
+	case <-s.ctx.Done():
+		return revealCommandReply{}, s.ctx.Err()
+	}
+
+	// The reply channel is buffered, so the run loop never blocks on a
+	// caller that gave up.
+	select {
+	case reply := <-cmd.reply:
+		return reply, reply.err
+	case <-ctx.Done():
+		return revealCommandReply{}, ctx.Err()
+	case <-s.ctx.Done():
+		return revealCommandReply{}, s.ctx.Err()
+	}
+}
+
+// handleCommand executes an operator command on the run loop.
+func (s *RevealService) handleCommand(cmd *revealCommand) {
+	state, ok := s.pending[cmd.slot]
+	if !ok {
+		cmd.reply <- revealCommandReply{err: ErrNoRevealRequest}
+		return
+	}
+
+	if !cmd.publish {
+		if err := s.ensureEnvelope(state); err != nil {
+			cmd.reply <- revealCommandReply{err: err}
+			return
+		}
+
+		cmd.reply <- revealCommandReply{preview: &RevealPreview{
+			Slot:                cmd.slot,
+			Transport:           state.req.Transport,
+			Envelope:            state.envelope,
+			BlobCount:           len(state.blobs),
+			BroadcastValidation: state.settings.BroadcastValidation,
+			Attempts:            state.attempts,
+			Published:           state.published,
+			Done:                state.done,
+		}}
+
+		return
+	}
+
+	cmd.reply <- revealCommandReply{result: s.publishManual(cmd.slot, state, cmd.broadcastValidation)}
+}
+
+// publishManual runs one operator-triggered publish attempt. A failure leaves
+// the automatic schedule untouched.
+func (s *RevealService) publishManual(slot phase0.Slot, state *revealState, broadcastValidation string) *RevealResult {
+	if broadcastValidation == "" {
+		broadcastValidation = state.settings.BroadcastValidation
+	}
+
+	state.manualAttempts++
+	startedAt := time.Now()
+
+	s.starts.Fire(&RevealStarted{
+		Slot:      slot,
+		Transport: state.req.Transport,
+		Attempt:   state.manualAttempts,
+		StartedAt: startedAt,
+	})
+
+	s.log.WithFields(logrus.Fields{
+		"slot":                 slot,
+		"transport":            state.req.Transport,
+		"attempt":              state.manualAttempts,
+		"broadcast_validation": broadcastValidation,
+	}).Info("Manually revealing payload")
+
+	err := s.ensureEnvelope(state)
+	if err == nil {
+		err = s.publish(state.envelope, state.blobs, state.proofs, broadcastValidation)
+	}
+
+	result := &RevealResult{
+		Slot:        slot,
+		Transport:   state.req.Transport,
+		Attempt:     state.manualAttempts,
+		MaxAttempts: int(state.settings.MaxAttempts),
+		Manual:      true,
+		StartedAt:   startedAt,
+		CompletedAt: time.Now(),
+		Envelope:    state.envelope,
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		s.log.WithError(err).WithField("slot", slot).Error("Manual reveal failed")
+	} else {
+		result.Success = true
+		s.markPublished(slot, state, result.CompletedAt)
+		s.log.WithField("slot", slot).Info("Payload manually revealed")
+	}
+
+	s.results.Fire(result)
+
+	return result
+}
+
 // handlePublishFailure surfaces a failed reveal attempt (envelope construction
 // or network publish) and either schedules a retry or gives up once the retry
 // budget is spent. The fired result carries the built envelope when
@@ -588,11 +969,13 @@ func (s *RevealService) handlePublishFailure(slot phase0.Slot, state *revealStat
 		StartedAt:   state.attemptStartedAt,
 		CompletedAt: time.Now(),
 		Envelope:    state.envelope,
+		Chaos:       state.settings.Chaos,
 	})
 
 	if state.attempts >= maxAttempts {
 		state.done = true
 		s.builderSvc.IncrementRevealsFailed()
+		metrics.RecordReveal(metrics.ResultFailure)
 		s.log.WithField("slot", slot).Error("Giving up on reveal after max attempts")
 
 		return
@@ -620,16 +1003,11 @@ func (s *RevealService) pruneDone(now time.Time) {
 // rearm resets the timer to the earliest pending attempt (or an hour when
 // idle). Must be called after every loop iteration that may have changed the
 // pending set.
-func (s *RevealService) rearm(timer *time.Timer) {
-	if !timer.Stop() {
-		select {
-		case <-timer.C:
-		default:
-		}
-	}
+func (s *RevealService) rearm(timer clock.Timer) {
+	timer.Stop()
 
 	next := time.Hour
-	now := time.Now()
+	now := s.chainSvc.Clock().Now()
 
 	for _, state := range s.pending {
 		if state.done {
@@ -674,12 +1052,14 @@ func (s *RevealService) buildEnvelope(req *RevealRequest) (
 	ctx, cancel := context.WithTimeout(s.ctx, transformTimeout)
 	defer cancel()
 
+	builderIndex, envelopeSigner := s.envelopeIdentity(req)
+
 	envelope, blobs, proofs, err = BuildSignedEnvelope(ctx, req.Payload, RevealContext{
-		BuilderIndex:          s.builderIndex.Load(),
+		BuilderIndex:          builderIndex,
 		BeaconBlockRoot:       req.BlockInfo.Root,
 		ParentBeaconBlockRoot: req.BlockInfo.ParentRoot,
 		Transform:             envelopeTransform,
-	}, s.signer, forkVersion, s.chainSvc.GetGenesis().GenesisValidatorsRoot)
+	}, envelopeSigner, forkVersion, s.chainSvc.GetGenesis().GenesisValidatorsRoot)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to build signed envelope: %w", err)
 	}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
@@ -209,7 +210,7 @@ func TestRevealService_RevealsAtDueTime(t *testing.T) {
 	blockRoot := phase0.Root{0x11}
 	payload := newTestPayload(slot, phase0.Hash32{0xab}, big.NewInt(2_000_000_000_000)) // 2000 gwei
 
-	env.payments.RecordWonBid(slot, 2000)
+	env.payments.RecordWonBid(slot, phase0.Hash32{}, 2000)
 
 	env.svc.RequestReveal(&RevealRequest{
 		Payload:   payload,
@@ -247,6 +248,50 @@ func TestRevealService_RevealsAtDueTime(t *testing.T) {
 	assert.Equal(t, uint64(1), env.builderSvc.GetStats().RevealsSuccess)
 }
 
+// TestRevealService_FakeClockRevealTime drives the reveal timer with a fake
+// clock: nothing publishes until the clock passes the reveal time.
+func TestRevealService_FakeClockRevealTime(t *testing.T) {
+	env := newRevealTestEnv(t, 12*time.Second, 500)
+
+	fake := clock.NewFake(time.Unix(1_700_000_000, 0))
+	env.chainSvc.clock = fake
+	env.chainSvc.genesisTime = fake.Now().Add(-12 * time.Second) // slot 1 starts now
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	slot := phase0.Slot(1)
+
+	env.svc.RequestReveal(&RevealRequest{
+		Payload:   newTestPayload(slot, phase0.Hash32{0xab}, big.NewInt(1)),
+		BlockInfo: &beacon.BlockInfo{Slot: slot, Root: phase0.Root{0x11}, ParentRoot: phase0.Root{0x22}},
+		Transport: payload_builder.BidTransportP2P,
+	})
+
+	// The preview command round-trips the run loop, so the request is
+	// scheduled once it succeeds.
+	require.Eventually(t, func() bool {
+		_, err := env.svc.PreviewReveal(context.Background(), slot)
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	fake.Advance(499 * time.Millisecond)
+
+	preview, err := env.svc.PreviewReveal(context.Background(), slot)
+	require.NoError(t, err)
+	assert.Zero(t, preview.Attempts, "must not publish before the reveal time")
+	assert.Zero(t, env.publisher.callCount())
+
+	fake.Advance(time.Millisecond)
+
+	// The loop re-arms its timer after every iteration; re-firing at the
+	// same fake time covers a re-arm racing the advance.
+	require.Eventually(t, func() bool {
+		fake.Advance(0)
+		return env.publisher.callCount() == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
 func TestRevealService_DedupsBySlot(t *testing.T) {
 	env := newRevealTestEnv(t, 4*time.Second, 10)
 	sub := env.svc.SubscribeResults(4, false)
@@ -307,7 +352,7 @@ func TestRevealService_RetriesThenGivesUp(t *testing.T) {
 	slot := phase0.Slot(1)
 	payload := newTestPayload(slot, phase0.Hash32{0xab}, big.NewInt(1))
 
-	env.payments.RecordWonBid(slot, 42)
+	env.payments.RecordWonBid(slot, phase0.Hash32{}, 42)
 
 	env.svc.RequestReveal(&RevealRequest{
 		Payload:   payload,
@@ -580,6 +625,76 @@ func TestRevealService_VoteGateTimeoutWithholds(t *testing.T) {
 	assert.Equal(t, 0, env.publisher.callCount())
 }
 
+func TestRevealService_DisabledVoteGatesFallBackToTime(t *testing.T) {
+	env := newRevealTestEnv(t, 700*time.Millisecond, 100)
+	env.cfg.Reveal.GateMode = config.RevealGateVote
+	env.cfg.Reveal.VoteThresholdPct = 60
+	env.svc.DisableVoteGates()
+
+	sub := env.svc.SubscribeResults(4, false)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	env.svc.RequestReveal(revealRequest(1, phase0.Root{0x11}))
+
+	// No vote ever arrives; the time gate publishes instead of the vote
+	// gate timing out at the slot end.
+	res := waitForResult(t, sub.Channel(), 3*time.Second)
+	assert.True(t, res.Success)
+	assert.Equal(t, 1, env.publisher.callCount())
+}
+
+func TestRevealService_RequireInclusionWaitsForConfirmation(t *testing.T) {
+	env := newRevealTestEnv(t, 4*time.Second, 100)
+	env.cfg.Reveal.GateMode = config.RevealGateTime
+	env.cfg.Reveal.RequireInclusion = true
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	// A Builder API delivery schedules the reveal from local bookkeeping.
+	delivered := revealRequest(1, phase0.Root{0x11})
+	delivered.Transport = payload_builder.BidTransportBuilderAPI
+	env.svc.RequestReveal(delivered)
+
+	time.Sleep(300 * time.Millisecond)
+	require.Equal(t, 0, env.publisher.callCount(), "unconfirmed reveal must wait past the time gate")
+
+	// The inclusion tracker confirms the bid at the head.
+	confirmed := revealRequest(1, phase0.Root{0x11})
+	confirmed.Confirmed = true
+	env.svc.RequestReveal(confirmed)
+
+	require.Eventually(t, func() bool {
+		return env.publisher.callCount() == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestRevealService_RequireInclusionSkipsUnconfirmed(t *testing.T) {
+	env := newRevealTestEnv(t, 700*time.Millisecond, 100)
+	env.cfg.Reveal.GateMode = config.RevealGateTime
+	env.cfg.Reveal.RequireInclusion = true
+
+	sub := env.svc.SubscribeResults(4, false)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	env.svc.RequestReveal(revealRequest(1, phase0.Root{0x11}))
+
+	res := waitForResult(t, sub.Channel(), 3*time.Second)
+	assert.True(t, res.Skipped)
+	assert.Equal(t, RevealSkipReasonNotIncluded, res.SkipReason)
+	assert.Equal(t, 0, env.publisher.callCount())
+
+	stats := env.builderSvc.GetStats()
+	assert.Equal(t, uint64(1), stats.RevealsSkippedNotIncluded)
+	assert.Equal(t, uint64(1), stats.RevealsSkipped)
+}
+
 func TestRevealService_GloballyDisabled(t *testing.T) {
 	env := newRevealTestEnv(t, 4*time.Second, 10)
 	env.cfg.Reveal.Enabled = false
@@ -598,6 +713,53 @@ func TestRevealService_GloballyDisabled(t *testing.T) {
 	assert.Equal(t, 0, env.publisher.callCount())
 }
 
+func TestRevealService_ChaosWithholds(t *testing.T) {
+	env := newRevealTestEnv(t, 4*time.Second, 10)
+	env.cfg.Reveal.ChaosWithholdPct = 100
+
+	sub := env.svc.SubscribeResults(4, false)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	env.svc.RequestReveal(revealRequest(1, phase0.Root{0x11}))
+
+	res := waitForResult(t, sub.Channel(), 2*time.Second)
+	assert.True(t, res.Skipped)
+	assert.Equal(t, RevealSkipReasonChaos, res.SkipReason)
+	assert.Equal(t, action_plan.RevealChaosWithhold, res.Chaos)
+	assert.Equal(t, 0, env.publisher.callCount())
+
+	stats := env.builderSvc.GetStats()
+	assert.Equal(t, uint64(1), stats.RevealsChaosWithheld)
+	assert.Zero(t, stats.RevealsSkipped, "deliberate withholding is not a skip")
+}
+
+func TestRevealService_ChaosDelaysPastTheSlot(t *testing.T) {
+	slotDuration := 300 * time.Millisecond
+	env := newRevealTestEnv(t, slotDuration, 10)
+	env.cfg.Reveal.GateMode = config.RevealGateVote // chaos delays are time-gated
+	env.cfg.Reveal.ChaosDelayPct = 100
+	env.cfg.Reveal.ChaosDelayMs = 400
+
+	sub := env.svc.SubscribeResults(4, false)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	start := time.Now()
+
+	env.svc.RequestReveal(revealRequest(1, phase0.Root{0x11}))
+
+	res := waitForResult(t, sub.Channel(), 3*time.Second)
+	assert.True(t, res.Success)
+	assert.Equal(t, action_plan.RevealChaosDelay, res.Chaos)
+	assert.GreaterOrEqual(t, time.Since(start), slotDuration, "published after the slot end")
+	assert.Equal(t, uint64(1), env.builderSvc.GetStats().RevealsChaosDelayed)
+}
+
 func TestRevealService_PlanCustomForcesDespiteGlobalDisable(t *testing.T) {
 	env := newRevealTestEnv(t, 4*time.Second, 50)
 	env.cfg.Reveal.Enabled = false
@@ -659,3 +821,96 @@ func TestRevealService_RetryPolicyFromConfig(t *testing.T) {
 		assert.Equal(t, 2, res.MaxAttempts)
 	}
 }
+
+func TestRevealService_PreviewAndPublishNow(t *testing.T) {
+	// The reveal is due 3s into the slot: only the manual trigger publishes.
+	env := newRevealTestEnv(t, 4*time.Second, 3000)
+	env.publisher.fail = true
+
+	sub := env.svc.SubscribeResults(8, false)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	ctx := context.Background()
+
+	_, err := env.svc.PreviewReveal(ctx, 5)
+	require.ErrorIs(t, err, ErrNoRevealRequest)
+
+	env.svc.RequestReveal(revealRequest(1, phase0.Root{0x11}))
+
+	var preview *RevealPreview
+
+	require.Eventually(t, func() bool {
+		preview, err = env.svc.PreviewReveal(ctx, 1)
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NotNil(t, preview.Envelope)
+	assert.Equal(t, phase0.Root{0x11}, preview.Envelope.Message.BeaconBlockRoot)
+	assert.False(t, preview.Published)
+	assert.Zero(t, env.publisher.callCount(), "a preview never publishes")
+
+	// A failed manual publish leaves the automatic schedule untouched.
+	res, err := env.svc.PublishNow(ctx, 1, config.BroadcastValidationConsensus)
+	require.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.True(t, res.Manual)
+	assert.Equal(t, 1, res.Attempt)
+	assert.Equal(t, config.BroadcastValidationConsensus, env.publisher.lastValidation())
+	assert.Same(t, preview.Envelope, res.Envelope, "the previewed envelope is the one published")
+
+	env.publisher.mu.Lock()
+	env.publisher.fail = false
+	env.publisher.mu.Unlock()
+
+	res, err = env.svc.PublishNow(ctx, 1, "")
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, 2, res.Attempt)
+	assert.Equal(t, uint64(1), env.builderSvc.GetStats().RevealsSuccess)
+
+	assert.False(t, waitForResult(t, sub.Channel(), time.Second).Success)
+	assert.True(t, waitForResult(t, sub.Channel(), time.Second).Success)
+
+	preview, err = env.svc.PreviewReveal(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, preview.Published)
+	assert.True(t, preview.Done, "a manual success ends the automatic schedule")
+	assert.Zero(t, preview.Attempts)
+}
+
+// TestRevealService_ExtraBuilderSignsItsWins asserts reveals of blocks
+// committing to an extra builder key's bid are built under its index.
+func TestRevealService_ExtraBuilderSignsItsWins(t *testing.T) {
+	env := newRevealTestEnv(t, 4*time.Second, 3000)
+	env.svc.SetBuilderIndex(3)
+
+	extraSigner, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, err)
+
+	env.svc.SetExtraBuilderSigner(7, NewSigner(extraSigner))
+
+	require.NoError(t, env.svc.Start(context.Background()))
+	defer env.svc.Stop()
+
+	ctx := context.Background()
+
+	extraIndex := uint64(7)
+	extraReq := revealRequest(1, phase0.Root{0x11})
+	extraReq.BlockInfo.BidBuilderIndex = &extraIndex
+	env.svc.RequestReveal(extraReq)
+	env.svc.RequestReveal(revealRequest(2, phase0.Root{0x12}))
+
+	for slot, wantIndex := range map[phase0.Slot]uint64{1: 7, 2: 3} {
+		var preview *RevealPreview
+
+		require.Eventually(t, func() bool {
+			preview, err = env.svc.PreviewReveal(ctx, slot)
+			return err == nil
+		}, 2*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, wantIndex, uint64(preview.Envelope.Message.BuilderIndex), "slot %d", slot)
+	}
+}
@@ -26,15 +26,24 @@ import (
 var DomainBeaconBuilder = phase0.DomainType{0x0B, 0x00, 0x00, 0x00}
 
 // Signer signs execution payload bids and envelopes with the builder's BLS key.
+// The consensus layer verifies them against the registered builder pubkey.
 type Signer struct {
-	blsSigner *signer.BLSSigner
+	blsSigner signer.Signer
+	auditor   *SigningAuditor // SetAuditor (nil-checked)
 }
 
 // NewSigner creates a new payload bidder signer.
-func NewSigner(blsSigner *signer.BLSSigner) *Signer {
+func NewSigner(blsSigner signer.Signer) *Signer {
 	return &Signer{blsSigner: blsSigner}
 }
 
+// SetAuditor wires the shared signing auditor: every signature's domain is
+// then validated against the connected chain (a mismatch fails the signing)
+// and recorded. Must be called before signing starts.
+func (s *Signer) SetAuditor(auditor *SigningAuditor) {
+	s.auditor = auditor
+}
+
 // SignBid signs an execution payload bid. forkVersion must be the fork version
 // the consensus client verifies against (the Gloas fork version).
 func (s *Signer) SignBid(
@@ -42,22 +51,26 @@ func (s *Signer) SignBid(
 	forkVersion phase0.Version,
 	genesisValidatorsRoot phase0.Root,
 ) (phase0.BLSSignature, error) {
-	return s.sign(bid, forkVersion, genesisValidatorsRoot)
+	return s.sign(SigningArtifactBid, bid.Slot, bid, forkVersion, genesisValidatorsRoot)
 }
 
-// SignEnvelope signs an execution payload envelope.
+// SignEnvelope signs the execution payload envelope of the slot.
 func (s *Signer) SignEnvelope(
 	envelope *eth2all.ExecutionPayloadEnvelope,
+	slot phase0.Slot,
 	forkVersion phase0.Version,
 	genesisValidatorsRoot phase0.Root,
 ) (phase0.BLSSignature, error) {
-	return s.sign(envelope, forkVersion, genesisValidatorsRoot)
+	return s.sign(SigningArtifactEnvelope, slot, envelope, forkVersion, genesisValidatorsRoot)
 }
 
 // sign computes the dynssz hash-tree-root of msg (so preset-dependent list
 // limits resolve from the global spec rather than the static mainnet preset)
-// and signs it under DomainBeaconBuilder.
+// and signs it under DomainBeaconBuilder, after the auditor (if wired)
+// accepted the domain for the slot.
 func (s *Signer) sign(
+	artifact string,
+	slot phase0.Slot,
 	msg any,
 	forkVersion phase0.Version,
 	genesisValidatorsRoot phase0.Root,
@@ -71,7 +84,13 @@ func (s *Signer) sign(
 
 	copy(root[:], msgRoot[:])
 
-	domain := signer.ComputeDomain(DomainBeaconBuilder, forkVersion, genesisValidatorsRoot)
+	domain := signer.NewSigningDomain(DomainBeaconBuilder, forkVersion, genesisValidatorsRoot)
+
+	if s.auditor != nil {
+		if err := s.auditor.Check(artifact, slot, root, domain); err != nil {
+			return phase0.BLSSignature{}, err
+		}
+	}
 
-	return s.blsSigner.SignWithDomain(root, domain)
+	return s.blsSigner.SignWithDomain(root, domain.Domain)
 }
@@ -0,0 +1,42 @@
+package payload_bidder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func TestSignerSignsWithBuilderKey(t *testing.T) {
+	main, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	s := NewSigner(main)
+
+	payload := newTestPayload(7, phase0.Hash32{0xaa}, big.NewInt(1))
+	domain := signer.ComputeDomain(DomainBeaconBuilder, phase0.Version{}, phase0.Root{})
+
+	verifyWith := func(pubkey phase0.BLSPubKey) bool {
+		bid, err := BuildSignedBid(context.Background(), payload, BidParams{Value: 1}, s, phase0.Version{}, phase0.Root{})
+		require.NoError(t, err)
+
+		root, err := dynssz.GetGlobalDynSsz().HashTreeRoot(bid.Message)
+		require.NoError(t, err)
+
+		signingRoot := signer.ComputeSigningRoot(root, domain)
+
+		return signer.VerifyBLSSignature(pubkey, signingRoot[:], bid.Signature)
+	}
+
+	// The CL verifies bids against the registered builder pubkey.
+	require.True(t, verifyWith(main.PublicKey()))
+
+	other, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, err)
+	require.False(t, verifyWith(other.PublicKey()))
+}
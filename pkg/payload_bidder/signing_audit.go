@@ -0,0 +1,144 @@
+package payload_bidder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// Signed artifacts checked by the SigningAuditor.
+const (
+	// SigningArtifactBid is an execution payload bid (p2p or Builder API).
+	SigningArtifactBid = "bid"
+	// SigningArtifactHeader is a legacy Builder API builder bid (header).
+	SigningArtifactHeader = "header"
+	// SigningArtifactEnvelope is an execution payload envelope (reveal).
+	SigningArtifactEnvelope = "envelope"
+)
+
+// ErrSigningDomainMismatch is returned when a signature's domain inputs do
+// not match the connected chain; the message is then not signed.
+var ErrSigningDomainMismatch = errors.New("signing domain does not match the connected chain")
+
+// SigningRecord is one signature the builder produced, or refused to produce
+// because its domain did not match the connected chain (Error set).
+type SigningRecord struct {
+	Artifact    string
+	Slot        phase0.Slot
+	MessageRoot phase0.Root
+	Domain      signer.SigningDomain
+	Error       string
+	At          time.Time
+}
+
+// SigningAuditor validates the domain inputs of every builder signature
+// against the connected chain at sign time — catching wrong-chain signing (a
+// fork version or genesis validators root of another network or fork) before
+// anything is published — and fires a record of each signature.
+type SigningAuditor struct {
+	chainSvc   chain.Service
+	dispatcher utils.Dispatcher[*SigningRecord]
+	log        logrus.FieldLogger
+}
+
+// NewSigningAuditor creates a signing auditor for the connected chain.
+func NewSigningAuditor(chainSvc chain.Service, log logrus.FieldLogger) *SigningAuditor {
+	return &SigningAuditor{
+		chainSvc: chainSvc,
+		log:      log.WithField("component", "signing-auditor"),
+	}
+}
+
+// SubscribeRecords subscribes to signing records.
+func (a *SigningAuditor) SubscribeRecords(capacity int, blocking bool) *utils.Subscription[*SigningRecord] {
+	return a.dispatcher.Subscribe(capacity, blocking)
+}
+
+// Check validates the signing domain of an artifact for the slot and fires
+// its record. A mismatch returns an error wrapping ErrSigningDomainMismatch;
+// the caller must not sign.
+func (a *SigningAuditor) Check(artifact string, slot phase0.Slot, messageRoot phase0.Root,
+	used signer.SigningDomain) error {
+	err := a.validate(artifact, slot, used)
+
+	record := &SigningRecord{
+		Artifact:    artifact,
+		Slot:        slot,
+		MessageRoot: messageRoot,
+		Domain:      used,
+		At:          time.Now(),
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+
+		a.log.WithError(err).WithFields(logrus.Fields{
+			"artifact":     artifact,
+			"slot":         slot,
+			"fork_version": fmt.Sprintf("%#x", used.ForkVersion),
+			"gvr":          fmt.Sprintf("%#x", used.GenesisValidatorsRoot),
+		}).Error("Refusing to sign with a signing domain of another chain")
+	}
+
+	a.dispatcher.Fire(record)
+
+	return err
+}
+
+// ExpectedSigningDomain returns the connected chain's signing domain for an
+// artifact of the slot. Bids and envelopes sign under DOMAIN_BEACON_BUILDER
+// with the fork version of the slot's fork and the genesis validators root;
+// legacy headers under DOMAIN_APPLICATION_BUILDER with the genesis fork
+// version and a zero root (builder-specs).
+func ExpectedSigningDomain(chainSvc chain.Service, artifact string, slot phase0.Slot) (signer.SigningDomain, error) {
+	genesis := chainSvc.GetGenesis()
+	if genesis == nil {
+		return signer.SigningDomain{}, fmt.Errorf("%w: genesis unknown", ErrSigningDomainMismatch)
+	}
+
+	if artifact == SigningArtifactHeader {
+		return signer.NewSigningDomain(signer.DomainApplicationBuilder, genesis.GenesisForkVersion, phase0.Root{}), nil
+	}
+
+	if genesis.GenesisValidatorsRoot == (phase0.Root{}) {
+		return signer.SigningDomain{}, fmt.Errorf("%w: genesis validators root unknown", ErrSigningDomainMismatch)
+	}
+
+	fork := chainSvc.ActiveForkAtEpoch(chainSvc.GetEpochOfSlot(slot))
+
+	forkVersion, err := chainSvc.GetChainSpec().GetForkVersion(fork)
+	if err != nil {
+		return signer.SigningDomain{}, fmt.Errorf("%w: %v", ErrSigningDomainMismatch, err)
+	}
+
+	return signer.NewSigningDomain(DomainBeaconBuilder, forkVersion, genesis.GenesisValidatorsRoot), nil
+}
+
+func (a *SigningAuditor) validate(artifact string, slot phase0.Slot, used signer.SigningDomain) error {
+	want, err := ExpectedSigningDomain(a.chainSvc, artifact, slot)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case used.Type != want.Type:
+		return fmt.Errorf("%w: domain type %#x, expected %#x", ErrSigningDomainMismatch, used.Type, want.Type)
+	case used.ForkVersion != want.ForkVersion:
+		return fmt.Errorf("%w: fork version %#x, expected %#x for slot %d",
+			ErrSigningDomainMismatch, used.ForkVersion, want.ForkVersion, slot)
+	case used.GenesisValidatorsRoot != want.GenesisValidatorsRoot:
+		return fmt.Errorf("%w: genesis validators root %#x, expected %#x",
+			ErrSigningDomainMismatch, used.GenesisValidatorsRoot, want.GenesisValidatorsRoot)
+	case used.Domain != want.Domain:
+		return fmt.Errorf("%w: domain %#x is not derived from its inputs", ErrSigningDomainMismatch, used.Domain)
+	}
+
+	return nil
+}
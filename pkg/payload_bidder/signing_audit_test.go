@@ -0,0 +1,65 @@
+package payload_bidder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func TestSigningAuditorValidatesAgainstChain(t *testing.T) {
+	gvr := phase0.Root{0x42}
+	chainSvc := &stubChainService{
+		currentFork:  version.DataVersionGloas,
+		slotDuration: 12 * time.Second,
+		genesis:      beacon.Genesis{GenesisValidatorsRoot: gvr, GenesisForkVersion: phase0.Version{0x10}},
+	}
+
+	auditor := NewSigningAuditor(chainSvc, logrus.New())
+	records := auditor.SubscribeRecords(8, false)
+	t.Cleanup(records.Unsubscribe)
+
+	main, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	s := NewSigner(main)
+	s.SetAuditor(auditor)
+
+	payload := newTestPayload(7, phase0.Hash32{0xaa}, big.NewInt(1))
+	forkVersion := phase0.Version{0x01, 0x00, 0x00, 0x00} // the stub's Gloas fork version
+
+	_, err = BuildSignedBid(context.Background(), payload, BidParams{Value: 1}, s, forkVersion, gvr)
+	require.NoError(t, err)
+
+	record := <-records.Channel()
+	require.Equal(t, SigningArtifactBid, record.Artifact)
+	require.Equal(t, phase0.Slot(7), record.Slot)
+	require.Equal(t, forkVersion, record.Domain.ForkVersion)
+	require.Equal(t, gvr, record.Domain.GenesisValidatorsRoot)
+	require.Empty(t, record.Error)
+
+	// Another network's genesis validators root or fork version is refused.
+	_, err = BuildSignedBid(context.Background(), payload, BidParams{Value: 1}, s, forkVersion, phase0.Root{0x01})
+	require.ErrorIs(t, err, ErrSigningDomainMismatch)
+	require.NotEmpty(t, (<-records.Channel()).Error)
+
+	_, _, _, err = BuildSignedEnvelope(context.Background(), payload, RevealContext{}, s, phase0.Version{0x02}, gvr)
+	require.ErrorIs(t, err, ErrSigningDomainMismatch)
+	require.Equal(t, SigningArtifactEnvelope, (<-records.Channel()).Artifact)
+
+	// Legacy headers sign with the genesis fork version and a zero root.
+	header := signer.NewSigningDomain(signer.DomainApplicationBuilder, phase0.Version{0x10}, phase0.Root{})
+	require.NoError(t, auditor.Check(SigningArtifactHeader, 7, phase0.Root{}, header))
+
+	header.Domain = signer.ComputeDomain(signer.DomainApplicationBuilder, forkVersion, phase0.Root{})
+	require.ErrorIs(t, auditor.Check(SigningArtifactHeader, 7, phase0.Root{}, header), ErrSigningDomainMismatch,
+		"a domain not derived from the recorded inputs is refused")
+}
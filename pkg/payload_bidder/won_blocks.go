@@ -34,6 +34,17 @@ type WonBlock struct {
 	ValueWei        string `json:"value_wei"`
 	ValueETH        string `json:"value_eth"`
 	Timestamp       int64  `json:"timestamp"` // Unix milliseconds at inclusion time
+
+	// Publishes are the per-beacon-node publications of the block (Builder
+	// API flow only; the block was fanned out to every configured node).
+	Publishes []WonBlockPublish `json:"publishes,omitempty"`
+}
+
+// WonBlockPublish is one beacon node's publication of a won block.
+type WonBlockPublish struct {
+	Node      string `json:"node"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"` // empty when the node accepted the block
 }
 
 // WonBlockCodec translates the won-block store's entries to their persisted
@@ -0,0 +1,54 @@
+package payload_builder
+
+import (
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// changedAttributes lists the build-relevant payload_attributes fields that
+// differ between the attributes a payload was built from and newer ones for
+// the same slot (e.g. withdrawals after a late reorg). Empty when the built
+// payload is still valid.
+func changedAttributes(built, latest *beacon.PayloadAttributesEvent) []string {
+	var changed []string
+
+	if built.ParentBlockHash != latest.ParentBlockHash {
+		changed = append(changed, "parent_block_hash")
+	}
+
+	if built.ParentBeaconBlockRoot != latest.ParentBeaconBlockRoot {
+		changed = append(changed, "parent_beacon_block_root")
+	}
+
+	if built.Timestamp != latest.Timestamp {
+		changed = append(changed, "timestamp")
+	}
+
+	if built.PrevRandao != latest.PrevRandao {
+		changed = append(changed, "prev_randao")
+	}
+
+	if built.SuggestedFeeRecipient != latest.SuggestedFeeRecipient {
+		changed = append(changed, "suggested_fee_recipient")
+	}
+
+	if withdrawalsDivergence(built.Withdrawals, latest.Withdrawals) != "" {
+		changed = append(changed, "withdrawals")
+	}
+
+	return changed
+}
+
+// canRebuild reports whether a payload build started now still finishes
+// within the slot's build budget: the configured build time must end before
+// the proposal deadline, a third into the proposal slot (the attestation
+// deadline; a block published later is not attested).
+func (s *Service) canRebuild(slot phase0.Slot) bool {
+	deadline := s.chainSvc.SlotToTime(slot).Add(s.chainSvc.GetChainSpec().SecondsPerSlot / 3)
+	done := s.chainSvc.Clock().Now().Add(time.Duration(s.cfg.PayloadBuildTime) * time.Millisecond)
+
+	return done.Before(deadline)
+}
@@ -0,0 +1,79 @@
+package payload_builder
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/capella"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+func TestChangedAttributes(t *testing.T) {
+	built := &beacon.PayloadAttributesEvent{
+		ProposalSlot:    20,
+		ParentBlockHash: phase0.Hash32{0xa1},
+		Timestamp:       1000,
+		Withdrawals:     []*capella.Withdrawal{{Index: 1, ValidatorIndex: 2, Amount: 3}},
+	}
+
+	same := *built
+	same.Withdrawals = []*capella.Withdrawal{{Index: 1, ValidatorIndex: 2, Amount: 3}}
+	assert.Empty(t, changedAttributes(built, &same), "equal withdrawals behind different pointers")
+
+	reorged := same
+	reorged.ParentBlockHash = phase0.Hash32{0xa2}
+	reorged.Withdrawals = []*capella.Withdrawal{{Index: 1, ValidatorIndex: 2, Amount: 4}}
+	assert.Equal(t, []string{"parent_block_hash", "withdrawals"}, changedAttributes(built, &reorged))
+
+	later := same
+	later.Timestamp = 1012
+	later.Withdrawals = nil
+	assert.Equal(t, []string{"timestamp", "withdrawals"}, changedAttributes(built, &later))
+}
+
+// TestCheckStalePayloadWithdrawsWithoutBudget withdraws a payload whose
+// attributes changed after the build even when no build time is left for a
+// rebuild (the slot already started long ago in this test).
+func TestCheckStalePayloadWithdrawsWithoutBudget(t *testing.T) {
+	frozen := &action_plan.FrozenPlan{Build: &action_plan.ResolvedBuildSettings{Build: true}}
+
+	svc := newCandidateTestService(t, 0)
+
+	built := &Payload{
+		Attributes: &beacon.PayloadAttributesEvent{
+			ProposalSlot:    20,
+			ParentBlockHash: phase0.Hash32{0xa1},
+			Withdrawals:     []*capella.Withdrawal{{Index: 1}},
+		},
+		BlockHash: phase0.Hash32{0x01},
+	}
+	svc.payloadCache.Store(built)
+
+	// Unchanged attributes keep the payload.
+	svc.checkStalePayload(built.Attributes, frozen)
+	assert.Equal(t, built, svc.payloadCache.Get(20))
+
+	// Same parent, different withdrawals: the payload is invalid.
+	changed := *built.Attributes
+	changed.Withdrawals = []*capella.Withdrawal{{Index: 2}}
+	svc.checkStalePayload(&changed, frozen)
+
+	assert.Nil(t, svc.payloadCache.Get(20))
+	assert.Nil(t, svc.payloadCache.GetByParent(20, phase0.Hash32{0xa1}))
+	assert.Equal(t, built, svc.payloadCache.GetByBlockHash(built.BlockHash))
+	assert.Empty(t, svc.rebuildingSlots, "no rebuild in flight")
+
+	// New parent: the payload stays servable for its own parent.
+	onParent := &Payload{Attributes: &changed, BlockHash: phase0.Hash32{0x02}}
+	svc.payloadCache.Store(onParent)
+
+	reorged := changed
+	reorged.ParentBlockHash = phase0.Hash32{0xa2}
+	svc.checkStalePayload(&reorged, frozen)
+
+	assert.Nil(t, svc.payloadCache.Get(20))
+	assert.Equal(t, onParent, svc.payloadCache.GetByParent(20, phase0.Hash32{0xa1}))
+}
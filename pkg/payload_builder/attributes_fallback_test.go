@@ -32,7 +32,7 @@ func TestApplyAttributesFallback(t *testing.T) {
 	log.SetLevel(logrus.ErrorLevel)
 
 	// The client never connects (delayed start); only its event stream is used.
-	clClient, err := beacon.NewClient(context.Background(), "http://127.0.0.1:1", log)
+	clClient, err := beacon.NewClient(context.Background(), "http://127.0.0.1:1", false, log)
 	require.NoError(t, err)
 
 	planSvc := action_plan.NewPlanService(cfg, chainSvc, log)
@@ -104,7 +104,7 @@ func TestApplyAttributesFallbackMultiSlotGap(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
 
-	clClient, err := beacon.NewClient(context.Background(), "http://127.0.0.1:1", log)
+	clClient, err := beacon.NewClient(context.Background(), "http://127.0.0.1:1", false, log)
 	require.NoError(t, err)
 
 	planSvc := action_plan.NewPlanService(cfg, chainSvc, log)
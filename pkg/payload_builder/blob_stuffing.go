@@ -0,0 +1,377 @@
+package payload_builder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/holiman/uint256"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+const (
+	// maxBlobsPerStuffingTx is the blob count of a full stuffing transaction
+	// (the per-transaction blob limit since Osaka).
+	maxBlobsPerStuffingTx = params.BlobTxMaxBlobs
+
+	// stuffedTxExpiry is the number of slots a submitted stuffing transaction
+	// counts towards later slots' blobs while it is not mined.
+	stuffedTxExpiry = 4
+)
+
+// BlobTxSigner signs the stuffing transactions. *wallet.Wallet satisfies it.
+type BlobTxSigner interface {
+	Address() common.Address
+	NextNonce(ctx context.Context) (uint64, error)
+	SignTransaction(tx *types.Transaction) (*types.Transaction, error)
+}
+
+// BlobTxNode reads the fees and the sender's mined nonce from the EL and
+// submits the stuffing transactions. *execution.Client satisfies it.
+type BlobTxNode interface {
+	TxSender
+	GetChainID(ctx context.Context) (*big.Int, error)
+	GetConfirmedNonce(ctx context.Context, address common.Address) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlobBaseFee(ctx context.Context) (*big.Int, error)
+}
+
+// BlobStuffer fills blocks with synthetic blob transactions: ahead of the
+// build of a slot whose build decision carries a blob stuffing level
+// (--blob-stuffing or the plan's build.blob_stuffing), blob transactions
+// signed by the builder wallet are submitted to the EL's mempool until the
+// slot's blob count is pending, and the EL's block building picks them up
+// like any other mempool transaction.
+//
+// Stuffing transactions submitted for earlier slots count towards the blob
+// count until they are mined or expire (stuffedTxExpiry slots). The blobs are
+// a fixed deterministic set, so their KZG proofs are computed only once.
+type BlobStuffer struct {
+	signer   BlobTxSigner
+	node     BlobTxNode
+	chainSvc chain.Service
+	log      logrus.FieldLogger
+
+	mu          sync.Mutex // serializes stuff
+	chainID     *big.Int
+	blobs       []kzg4844.Blob
+	commitments []kzg4844.Commitment
+	proofs      map[byte][][]kzg4844.Proof // per sidecar version, per blob
+	pending     []stuffedTx
+}
+
+// stuffedTx is a submitted stuffing transaction not yet known to be mined.
+type stuffedTx struct {
+	hash  common.Hash
+	nonce uint64
+	blobs uint64
+	slot  phase0.Slot
+}
+
+// SetBlobStuffer enables blob stuffing of the slots whose build decision asks
+// for it. Register before Start().
+func (s *Service) SetBlobStuffer(stuffer *BlobStuffer) {
+	s.blobStuffer = stuffer
+}
+
+// NewBlobStuffer creates a blob stuffer sending from signer's address.
+func NewBlobStuffer(signer BlobTxSigner, node BlobTxNode, chainSvc chain.Service, log logrus.FieldLogger) *BlobStuffer {
+	return &BlobStuffer{
+		signer:   signer,
+		node:     node,
+		chainSvc: chainSvc,
+		log:      log.WithField("component", "blob-stuffer"),
+		proofs:   make(map[byte][][]kzg4844.Proof, 2),
+	}
+}
+
+// blobStuffingCount resolves a blob stuffing level to a blob count.
+func blobStuffingCount(level string, target, maxBlobs uint64) uint64 {
+	switch level {
+	case config.BlobStuffingTarget:
+		return target
+	case config.BlobStuffingMax:
+		return maxBlobs
+	}
+
+	count, err := strconv.ParseUint(level, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return min(count, maxBlobs)
+}
+
+// blobLimits returns the target and maximum blob count of blocks in the
+// epoch. The beacon spec only carries the maximum: the target is half of it
+// in Deneb and two thirds from Electra on (including the blob schedule).
+func (s *BlobStuffer) blobLimits(epoch phase0.Epoch) (target, maxBlobs uint64) {
+	maxBlobs = s.chainSvc.GetChainSpec().MaxBlobsPerBlockAtEpoch(epoch)
+
+	if s.chainSvc.ActiveForkAtEpoch(epoch) < version.DataVersionElectra {
+		return maxBlobs / 2, maxBlobs
+	}
+
+	return maxBlobs * 2 / 3, maxBlobs
+}
+
+// stuff submits the blob transactions missing for the slot's block to carry
+// the level's blob count. Failures are logged; the build goes ahead with
+// what the mempool holds.
+func (s *BlobStuffer) stuff(ctx context.Context, slot phase0.Slot, level string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	epoch := s.chainSvc.GetEpochOfSlot(slot)
+	target, maxBlobs := s.blobLimits(epoch)
+	want := blobStuffingCount(level, target, maxBlobs)
+
+	log := s.log.WithFields(logrus.Fields{"slot": slot, "level": level, "blobs": want})
+
+	if want == 0 {
+		log.Debug("No blobs to stuff for the slot")
+		return
+	}
+
+	mined, err := s.node.GetConfirmedNonce(ctx, s.signer.Address())
+	if err != nil {
+		log.WithError(err).Warn("Blob stuffing skipped: failed to get the wallet nonce")
+		return
+	}
+
+	pendingBlobs := s.prunePending(slot, mined)
+	if pendingBlobs >= want {
+		log.WithField("pending_blobs", pendingBlobs).Debug("Stuffed blobs already pending")
+		return
+	}
+
+	sidecarVersion := types.BlobSidecarVersion0
+	if s.chainSvc.ActiveForkAtEpoch(epoch) >= version.DataVersionFulu {
+		sidecarVersion = types.BlobSidecarVersion1
+	}
+
+	txs, err := s.buildTxs(ctx, want-pendingBlobs, sidecarVersion)
+	if err != nil {
+		log.WithError(err).Warn("Blob stuffing skipped: failed to build blob transactions")
+		return
+	}
+
+	var submitted uint64
+
+	for _, tx := range txs {
+		if err := s.node.SendTransaction(ctx, tx); err != nil {
+			// Later nonces cannot be mined without this one.
+			log.WithError(err).WithField("tx_hash", tx.Hash().Hex()).Warn("Blob stuffing transaction rejected by the EL")
+			break
+		}
+
+		blobs := uint64(len(tx.BlobHashes()))
+		submitted += blobs
+
+		s.pending = append(s.pending, stuffedTx{hash: tx.Hash(), nonce: tx.Nonce(), blobs: blobs, slot: slot})
+	}
+
+	log.WithFields(logrus.Fields{
+		"pending_blobs":   pendingBlobs,
+		"submitted_blobs": submitted,
+	}).Info("Blob stuffing transactions submitted")
+}
+
+// prunePending forgets the stuffing transactions mined (nonce below mined) or
+// expired by the slot and returns the blob count of the remaining ones.
+func (s *BlobStuffer) prunePending(slot phase0.Slot, mined uint64) uint64 {
+	var blobs uint64
+
+	kept := s.pending[:0]
+
+	for _, tx := range s.pending {
+		if tx.nonce < mined || tx.slot+stuffedTxExpiry < slot {
+			continue
+		}
+
+		kept = append(kept, tx)
+		blobs += tx.blobs
+	}
+
+	s.pending = kept
+
+	return blobs
+}
+
+// buildTxs signs blob transactions carrying count blobs in total, at most
+// maxBlobsPerStuffingTx each, on consecutive nonces.
+func (s *BlobStuffer) buildTxs(ctx context.Context, count uint64, sidecarVersion byte) ([]*types.Transaction, error) {
+	if s.chainID == nil {
+		chainID, err := s.node.GetChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.chainID = chainID
+	}
+
+	gasTipCap, err := s.node.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.node.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	blobBaseFee, err := s.node.BlobBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := s.signer.NextNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fee caps at twice the current base fees, like the wallet's own
+	// transactions, so a few blocks of rising fees do not strand them.
+	gasFeeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	gasFeeCap.Add(gasFeeCap, gasTipCap)
+
+	blobFeeCap := new(big.Int).Mul(blobBaseFee, big.NewInt(2))
+	to := s.signer.Address()
+
+	txs := make([]*types.Transaction, 0, (count+maxBlobsPerStuffingTx-1)/maxBlobsPerStuffingTx)
+
+	for remaining := count; remaining > 0; {
+		blobs := min(remaining, maxBlobsPerStuffingTx)
+
+		sidecar, err := s.sidecar(int(blobs), sidecarVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := s.signer.SignTransaction(types.NewTx(&types.BlobTx{
+			ChainID:    uint256.MustFromBig(s.chainID),
+			Nonce:      nonce,
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        params.TxGas,
+			To:         to,
+			Value:      new(uint256.Int),
+			BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+			BlobHashes: sidecar.BlobHashes(),
+			Sidecar:    sidecar,
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+		nonce++
+		remaining -= blobs
+	}
+
+	return txs, nil
+}
+
+// sidecar returns a sidecar of the first count stuffing blobs, computing the
+// missing blobs' commitments and proofs (cell proofs for version 1) once.
+func (s *BlobStuffer) sidecar(count int, sidecarVersion byte) (*types.BlobTxSidecar, error) {
+	start := time.Now()
+	computed := false
+
+	for len(s.blobs) < count {
+		blob := stuffingBlob(len(s.blobs))
+
+		commitment, err := kzg4844.BlobToCommitment(&blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob commitment: %w", err)
+		}
+
+		s.blobs = append(s.blobs, blob)
+		s.commitments = append(s.commitments, commitment)
+		computed = true
+	}
+
+	proofs := s.proofs[sidecarVersion]
+
+	for len(proofs) < count {
+		idx := len(proofs)
+
+		var (
+			blobProofs []kzg4844.Proof
+			err        error
+		)
+
+		if sidecarVersion == types.BlobSidecarVersion1 {
+			blobProofs, err = kzg4844.ComputeCellProofs(&s.blobs[idx])
+		} else {
+			var proof kzg4844.Proof
+
+			proof, err = kzg4844.ComputeBlobProof(&s.blobs[idx], s.commitments[idx])
+			blobProofs = []kzg4844.Proof{proof}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob proof: %w", err)
+		}
+
+		proofs = append(proofs, blobProofs)
+		computed = true
+	}
+
+	s.proofs[sidecarVersion] = proofs
+
+	if computed {
+		s.log.WithFields(logrus.Fields{
+			"blobs":           count,
+			"sidecar_version": sidecarVersion,
+			"duration":        time.Since(start).String(),
+		}).Info("Computed stuffing blob proofs")
+	}
+
+	flatProofs := make([]kzg4844.Proof, 0, count*len(proofs[0]))
+	for _, blobProofs := range proofs[:count] {
+		flatProofs = append(flatProofs, blobProofs...)
+	}
+
+	return types.NewBlobTxSidecar(
+		sidecarVersion,
+		append([]kzg4844.Blob(nil), s.blobs[:count]...),
+		append([]kzg4844.Commitment(nil), s.commitments[:count]...),
+		flatProofs,
+	), nil
+}
+
+// stuffingBlob returns the index-th stuffing blob: pseudo-random bytes derived
+// from the index, with every field element's leading byte zeroed to keep it
+// below the BLS modulus.
+func stuffingBlob(index int) kzg4844.Blob {
+	var (
+		blob kzg4844.Blob
+		seed [8]byte
+	)
+
+	binary.BigEndian.PutUint64(seed[:], uint64(index))
+	digest := sha256.Sum256(append([]byte("buildoor blob stuffing"), seed[:]...))
+
+	for offset := 0; offset < len(blob); offset += 32 {
+		copy(blob[offset+1:offset+32], digest[:31])
+		digest = sha256.Sum256(digest[:])
+	}
+
+	return blob
+}
@@ -0,0 +1,126 @@
+package payload_builder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// stuffingChainService is an Electra chain with a 9 blob maximum.
+type stuffingChainService struct {
+	stubChainService
+}
+
+func (s *stuffingChainService) ActiveForkAtEpoch(_ phase0.Epoch) version.DataVersion {
+	return version.DataVersionElectra
+}
+
+// stuffingNode records the submitted transactions; mined is the sender's
+// confirmed nonce.
+type stuffingNode struct {
+	mined uint64
+	sent  []*types.Transaction
+}
+
+func (n *stuffingNode) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	n.sent = append(n.sent, tx)
+	return nil
+}
+
+func (n *stuffingNode) GetChainID(context.Context) (*big.Int, error) { return big.NewInt(1337), nil }
+
+func (n *stuffingNode) GetConfirmedNonce(context.Context, common.Address) (uint64, error) {
+	return n.mined, nil
+}
+
+func (n *stuffingNode) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (n *stuffingNode) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(7)}, nil
+}
+
+func (n *stuffingNode) BlobBaseFee(context.Context) (*big.Int, error) { return big.NewInt(1), nil }
+
+// stuffingSigner signs with a throwaway key; nonces continue after the
+// submitted transactions like the EL's pending nonce.
+type stuffingSigner struct {
+	node *stuffingNode
+}
+
+var stuffingKey, _ = crypto.GenerateKey()
+
+func (s *stuffingSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(stuffingKey.PublicKey)
+}
+
+func (s *stuffingSigner) NextNonce(context.Context) (uint64, error) {
+	return uint64(len(s.node.sent)), nil
+}
+
+func (s *stuffingSigner) SignTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, types.NewCancunSigner(big.NewInt(1337)), stuffingKey)
+}
+
+func TestBlobStuffingCount(t *testing.T) {
+	assert.Equal(t, uint64(6), blobStuffingCount(config.BlobStuffingTarget, 6, 9))
+	assert.Equal(t, uint64(9), blobStuffingCount(config.BlobStuffingMax, 6, 9))
+	assert.Equal(t, uint64(4), blobStuffingCount("4", 6, 9))
+	assert.Equal(t, uint64(9), blobStuffingCount("20", 6, 9), "capped at the maximum")
+	assert.Equal(t, uint64(0), blobStuffingCount("many", 6, 9))
+}
+
+func TestBlobStufferTopsUpPendingBlobs(t *testing.T) {
+	chainSvc := &stuffingChainService{stubChainService{spec: &chain.ChainSpec{
+		SlotsPerEpoch:           32,
+		MaxBlobsPerBlockElectra: 9,
+		ForkSchedule:            []chain.ForkSchedule{{Fork: version.DataVersionElectra}},
+	}}}
+	node := &stuffingNode{}
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	stuffer := NewBlobStuffer(&stuffingSigner{node: node}, node, chainSvc, log)
+
+	// 8 blobs: a full transaction of 6 and one of 2 on consecutive nonces.
+	stuffer.stuff(context.Background(), 100, "8")
+	require.Len(t, node.sent, 2)
+	assert.Len(t, node.sent[0].BlobHashes(), maxBlobsPerStuffingTx)
+	assert.Len(t, node.sent[1].BlobHashes(), 2)
+	assert.Equal(t, uint64(1), node.sent[1].Nonce())
+
+	sidecar := node.sent[1].BlobTxSidecar()
+	require.NotNil(t, sidecar)
+	assert.Equal(t, types.BlobSidecarVersion0, sidecar.Version)
+	require.NoError(t, sidecar.ValidateBlobCommitmentHashes(node.sent[1].BlobHashes()))
+
+	// Still pending: the target (6) is covered.
+	stuffer.stuff(context.Background(), 101, config.BlobStuffingTarget)
+	require.Len(t, node.sent, 2)
+
+	// The first transaction was mined: with 2 blobs pending, the max (9)
+	// needs 7 more.
+	node.mined = 1
+	stuffer.stuff(context.Background(), 102, config.BlobStuffingMax)
+	require.Len(t, node.sent, 4)
+	assert.Len(t, node.sent[2].BlobHashes(), maxBlobsPerStuffingTx)
+	assert.Len(t, node.sent[3].BlobHashes(), 1)
+
+	// Unmined transactions expire and stop counting.
+	stuffer.stuff(context.Background(), 102+stuffedTxExpiry+1, "2")
+	require.Len(t, node.sent, 5)
+}
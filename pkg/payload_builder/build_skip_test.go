@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/utils"
 )
@@ -26,6 +27,7 @@ type stubChainService struct {
 
 func (s *stubChainService) GetChainSpec() *chain.ChainSpec { return s.spec }
 func (s *stubChainService) GetCurrentSlot() phase0.Slot    { return 100 }
+func (s *stubChainService) Clock() clock.Clock             { return clock.Real() }
 func (s *stubChainService) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
 	return phase0.Epoch(uint64(slot) / s.spec.SlotsPerEpoch)
 }
@@ -0,0 +1,133 @@
+package payload_builder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// HeadSource delivers the EL's new chain heads (execution.HeadStream).
+type HeadSource interface {
+	Subscribe(capacity int) *utils.Subscription[*types.Header]
+}
+
+// SetHeadSource registers the EL new-heads source (--el-ws-rpc) used to
+// withdraw payloads built on a parent the EL head moved away from, ahead of
+// the beacon node's payload_attributes. Register before Start.
+func (s *Service) SetHeadSource(heads HeadSource) {
+	s.heads = heads
+}
+
+// handleELHead reacts to a new EL head: when the payload built for the slot
+// after the head's slot sits on another parent (a late block or a reorg after
+// the build), it is withdrawn right away, so nothing is bid or served on the
+// stale parent. While the build budget allows, the slot is rebuilt on the new
+// head at once from the payload's attributes with the parent swapped; the
+// payload_attributes for the new head then only rebuild again when they differ
+// in more than the parent (checkStalePayload). Attributes that already name
+// the new head rebuild from those.
+func (s *Service) handleELHead(head *types.Header) {
+	slot := s.chainSvc.TimeToSlot(time.Unix(int64(head.Time), 0)) + 1
+	headHash := phase0.Hash32(head.Hash())
+
+	cached := s.payloadCache.Get(slot)
+	if cached == nil || cached.Attributes.ParentBlockHash == headHash {
+		return
+	}
+
+	frozen := s.planSvc.Freeze(slot)
+	if frozen.Build.ReorgParentPayload {
+		return
+	}
+
+	if s.clClient != nil {
+		if latest := s.clClient.Events().GetLatestPayloadAttributes(slot); latest != nil &&
+			latest.ParentBlockHash == headHash {
+			s.checkStalePayload(latest, frozen)
+			return
+		}
+	}
+
+	s.scheduledBuildMu.Lock()
+	if s.rebuildingSlots[slot] {
+		s.scheduledBuildMu.Unlock()
+
+		return
+	}
+
+	s.headMovedPayloads[slot] = cached
+
+	for oldSlot := range s.headMovedPayloads {
+		if oldSlot+64 < slot {
+			delete(s.headMovedPayloads, oldSlot)
+		}
+	}
+	s.scheduledBuildMu.Unlock()
+
+	if !s.payloadCache.Supersede(slot, cached.BlockHash) {
+		return
+	}
+
+	s.incrementStat(func(stats *BuilderStats) {
+		stats.ELHeadWithdrawals++
+	})
+
+	log := s.log.WithFields(logrus.Fields{
+		"slot":        slot,
+		"stale_hash":  fmt.Sprintf("%x", cached.BlockHash[:8]),
+		"parent_hash": fmt.Sprintf("%x", cached.Attributes.ParentBlockHash[:8]),
+		"el_head":     fmt.Sprintf("%x", headHash[:8]),
+		"el_number":   head.Number.Uint64(),
+	})
+
+	if s.clClient == nil || !s.canRebuild(slot) {
+		log.Warn("EL head moved after build, stale payload withdrawn until the new payload attributes arrive")
+
+		return
+	}
+
+	s.scheduledBuildMu.Lock()
+	if s.rebuildingSlots[slot] {
+		s.scheduledBuildMu.Unlock()
+
+		return
+	}
+
+	s.rebuildingSlots[slot] = true
+	s.scheduledBuildMu.Unlock()
+
+	attrs := *cached.Attributes
+	attrs.ParentBlockHash = headHash
+	attrs.ParentBlockNumber = head.Number.Uint64()
+
+	behind := s.clClient.Events().GetLatestPayloadAttributes(slot)
+
+	log.Warn("EL head moved after build, rebuilding stale payload on the new head")
+
+	go func() {
+		defer func() {
+			s.scheduledBuildMu.Lock()
+			delete(s.rebuildingSlots, slot)
+			s.scheduledBuildMu.Unlock()
+		}()
+
+		s.executeBuild(slot, &attrs, cached.BlockHash, behind)
+	}()
+}
+
+// takeHeadMovedPayload returns (and forgets) the slot's payload withdrawn by
+// handleELHead, nil if there is none.
+func (s *Service) takeHeadMovedPayload(slot phase0.Slot) *Payload {
+	s.scheduledBuildMu.Lock()
+	defer s.scheduledBuildMu.Unlock()
+
+	payload := s.headMovedPayloads[slot]
+	delete(s.headMovedPayloads, slot)
+
+	return payload
+}
@@ -0,0 +1,143 @@
+package payload_builder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+type elHeadChainService struct {
+	candidateChainService
+}
+
+func (s *elHeadChainService) TimeToSlot(_ time.Time) phase0.Slot { return 19 }
+
+// TestHandleELHeadWithdrawsStalePayload withdraws the payload built on a
+// parent the EL head moved away from, restores it when the attributes keep
+// its parent and leaves the rebuild to the new attributes otherwise.
+func TestHandleELHeadWithdrawsStalePayload(t *testing.T) {
+	frozen := &action_plan.FrozenPlan{Build: &action_plan.ResolvedBuildSettings{Build: true}}
+
+	svc := newCandidateTestService(t, 0)
+	svc.chainSvc = &elHeadChainService{candidateChainService{stubChainService{spec: svc.chainSvc.GetChainSpec()}}}
+
+	parent := &types.Header{Number: big.NewInt(18), Time: 988}
+	head := &types.Header{Number: big.NewInt(19), Time: 1000}
+
+	built := &Payload{
+		Attributes: &beacon.PayloadAttributesEvent{ProposalSlot: 20, ParentBlockHash: phase0.Hash32(parent.Hash())},
+		BlockHash:  phase0.Hash32{0x01},
+	}
+	svc.payloadCache.Store(built)
+
+	// A head matching the payload's parent changes nothing.
+	svc.handleELHead(parent)
+	require.Equal(t, built, svc.payloadCache.Get(20))
+
+	// The head moves: the payload is withdrawn but stays servable for its
+	// parent.
+	svc.handleELHead(head)
+
+	assert.Nil(t, svc.payloadCache.Get(20))
+	assert.Equal(t, built, svc.payloadCache.GetByParent(20, built.Attributes.ParentBlockHash))
+	assert.Equal(t, uint64(1), svc.GetStats().ELHeadWithdrawals)
+
+	// Attributes still naming the built parent (the head moved back) restore
+	// it.
+	svc.checkStalePayload(built.Attributes, frozen)
+	assert.Equal(t, built, svc.payloadCache.Get(20))
+	assert.Empty(t, svc.headMovedPayloads)
+
+	// Attributes for the new head take over from the withdrawn payload (no
+	// build time is left for the rebuild in this test).
+	svc.handleELHead(head)
+
+	moved := *built.Attributes
+	moved.ParentBlockHash = phase0.Hash32(head.Hash())
+	svc.checkStalePayload(&moved, frozen)
+
+	assert.Nil(t, svc.payloadCache.Get(20))
+	assert.Empty(t, svc.headMovedPayloads)
+	assert.Empty(t, svc.rebuildingSlots)
+	assert.Equal(t, uint64(2), svc.GetStats().ELHeadWithdrawals)
+}
+
+// elHeadRebuildChainService leaves the slot's whole build budget ahead.
+type elHeadRebuildChainService struct {
+	elHeadChainService
+}
+
+func (s *elHeadRebuildChainService) SlotToTime(_ phase0.Slot) time.Time {
+	return time.Now().Add(time.Hour)
+}
+
+// TestHandleELHeadRebuildsOnNewHead starts the rebuild on the new EL head
+// right away while the build budget allows, without waiting for the payload
+// attributes.
+func TestHandleELHeadRebuildsOnNewHead(t *testing.T) {
+	svc := newCandidateTestService(t, 0)
+	svc.ctx = context.Background()
+	svc.chainSvc = &elHeadRebuildChainService{elHeadChainService{candidateChainService{
+		stubChainService{spec: svc.chainSvc.GetChainSpec()},
+	}}}
+	svc.payloadBuilder = NewPayloadBuilder(svc.clClient, &raceTestEngine{}, svc.chainSvc,
+		common.Address{}, svc.cfg, svc.log, nil)
+
+	started := svc.SubscribePayloadBuildStarted(4, false)
+	defer started.Unsubscribe()
+
+	failed := svc.SubscribePayloadBuildFailed(4, false)
+	defer failed.Unsubscribe()
+
+	parent := &types.Header{Number: big.NewInt(18), Time: 988}
+	head := &types.Header{Number: big.NewInt(19), Time: 1000}
+
+	built := &Payload{
+		Attributes: &beacon.PayloadAttributesEvent{ProposalSlot: 20, ParentBlockHash: phase0.Hash32(parent.Hash())},
+		BlockHash:  phase0.Hash32{0x01},
+	}
+	svc.payloadCache.Store(built)
+
+	svc.handleELHead(head)
+
+	assert.Nil(t, svc.payloadCache.Get(20), "the stale payload is withdrawn during the rebuild")
+	assert.Equal(t, uint64(1), svc.GetStats().ELHeadWithdrawals)
+
+	select {
+	case event := <-started.Channel():
+		assert.Equal(t, phase0.Slot(20), event.Slot)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no rebuild started on the new EL head")
+	}
+
+	svc.scheduledBuildMu.Lock()
+	assert.Equal(t, map[phase0.Hash32]bool{phase0.Hash32(head.Hash()): true}, svc.buildParents[20],
+		"the rebuild builds on the new head")
+	svc.scheduledBuildMu.Unlock()
+
+	// The beacon node is unreachable in this test, so the rebuild fails and
+	// releases the slot.
+	select {
+	case event := <-failed.Channel():
+		assert.Equal(t, phase0.Slot(20), event.Slot)
+	case <-time.After(10 * time.Second):
+		t.Fatal("rebuild did not finish")
+	}
+
+	require.Eventually(t, func() bool {
+		svc.scheduledBuildMu.Lock()
+		defer svc.scheduledBuildMu.Unlock()
+
+		return !svc.rebuildingSlots[20]
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,219 @@
+package payload_builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	enginev "github.com/ethpandaops/go-eth-engine-client/spec/version"
+	"github.com/sirupsen/logrus"
+)
+
+// raceEngineGrace is how long a racing (non-primary) engine may take beyond
+// the payload build time before it is dropped from the race, so a slow or
+// hung secondary EL never delays the primary's payload.
+const raceEngineGrace = time.Second
+
+// EngineEndpoint is an engine API a payload build is requested from.
+type EngineEndpoint struct {
+	// Name identifies the endpoint (its host:port) in payloads, slot results
+	// and stats.
+	Name   string
+	Client EngineClient
+}
+
+// NewEngineEndpoint wraps an engine API client for payload racing, named
+// after the host of its URL.
+func NewEngineEndpoint(rawURL string, client EngineClient) EngineEndpoint {
+	return EngineEndpoint{
+		Name:   EngineName(rawURL),
+		Client: &instrumentedEngineClient{EngineClient: client},
+	}
+}
+
+// EngineName returns the display name of an engine API URL: its host:port,
+// or the URL itself when it has no host.
+func EngineName(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+
+	return rawURL
+}
+
+// EngineResult is one engine's outcome in a payload race.
+type EngineResult struct {
+	Engine        string `json:"engine"`
+	BlockValueWei string `json:"block_value_wei,omitempty"`
+	Error         string `json:"error,omitempty"`
+	Won           bool   `json:"won,omitempty"`
+}
+
+// SetRaceEngines registers further engine APIs to race payload builds on
+// alongside the primary engine (--el-engine-race-apis). Register before Start.
+func (s *Service) SetRaceEngines(engines []EngineEndpoint) {
+	s.raceEngines = engines
+}
+
+// raceEngineBuild is one engine's build in a race.
+type raceEngineBuild struct {
+	resp *engineall.GetPayloadResponse
+	err  error
+}
+
+// racePayload requests the payload build from the primary engine and every
+// race engine in parallel and returns the highest-value payload (ties go to
+// the earlier engine, the primary first), the winning engine's name and the
+// per-engine results. With only the primary engine it is a plain build and
+// returns no name or results.
+func (b *PayloadBuilder) racePayload(
+	ctx context.Context,
+	fcuReq *engineall.ForkchoiceUpdatedRequest,
+	engineVersion enginev.DataVersion,
+	onPayloadID func(paris.PayloadID),
+) (*engineall.GetPayloadResponse, string, []EngineResult, error) {
+	if len(b.raceEngines) == 0 {
+		resp, err := b.buildOnEngine(ctx, b.engineClient, fcuReq, engineVersion, onPayloadID)
+		return resp, "", nil, err
+	}
+
+	engines := append([]EngineEndpoint{{Name: EngineName(b.cfg.ELEngineAPI), Client: b.engineClient}}, b.raceEngines...)
+	builds := make([]raceEngineBuild, len(engines))
+
+	var wg sync.WaitGroup
+
+	for i, engine := range engines {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			engineCtx := ctx
+			notify := onPayloadID
+
+			if i > 0 {
+				var cancel context.CancelFunc
+
+				buildTime := time.Duration(b.cfg.PayloadBuildTime) * time.Millisecond
+				engineCtx, cancel = context.WithTimeout(ctx, buildTime+raceEngineGrace)
+
+				defer cancel()
+
+				notify = nil
+			}
+
+			builds[i].resp, builds[i].err = b.buildOnEngine(engineCtx, engine.Client, fcuReq, engineVersion, notify)
+		}()
+	}
+
+	wg.Wait()
+
+	results := make([]EngineResult, len(engines))
+	winner := -1
+
+	var (
+		bestValue *big.Int
+		errs      []error
+	)
+
+	for i, build := range builds {
+		results[i].Engine = engines[i].Name
+
+		if build.err != nil {
+			results[i].Error = build.err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", engines[i].Name, build.err))
+
+			continue
+		}
+
+		value := new(big.Int)
+		if build.resp.BlockValue != nil {
+			value = build.resp.BlockValue.ToBig()
+		}
+
+		results[i].BlockValueWei = value.String()
+
+		if winner < 0 || value.Cmp(bestValue) > 0 {
+			winner, bestValue = i, value
+		}
+	}
+
+	if winner < 0 {
+		return nil, "", results, fmt.Errorf("all %d engines failed: %w", len(engines), errors.Join(errs...))
+	}
+
+	results[winner].Won = true
+
+	b.log.WithFields(logrus.Fields{
+		"winner":      engines[winner].Name,
+		"block_value": bestValue.String(),
+		"engines":     len(engines),
+		"failed":      len(errs),
+	}).Debug("Payload race decided")
+
+	return builds[winner].resp, engines[winner].Name, results, nil
+}
+
+// buildOnEngine starts a payload build on one engine, lets it build for the
+// configured build time and retrieves the payload. onPayloadID, if set, is
+// told the payload ID once the build started.
+func (b *PayloadBuilder) buildOnEngine(
+	ctx context.Context,
+	client EngineClient,
+	fcuReq *engineall.ForkchoiceUpdatedRequest,
+	engineVersion enginev.DataVersion,
+	onPayloadID func(paris.PayloadID),
+) (*engineall.GetPayloadResponse, error) {
+	fcuResp, err := client.ForkchoiceUpdatedAgnostic(ctx, fcuReq)
+	if err != nil {
+		return nil, fmt.Errorf("forkchoiceUpdated failed: %w", err)
+	}
+
+	status := fcuResp.PayloadStatus.Status
+	if status != paris.PayloadValidationStatusValid && status != paris.PayloadValidationStatusSyncing {
+		return nil, fmt.Errorf("forkchoice status: %s", status)
+	}
+
+	if fcuResp.PayloadID == nil {
+		return nil, fmt.Errorf("no payload ID returned")
+	}
+
+	payloadID := *fcuResp.PayloadID
+
+	if onPayloadID != nil {
+		onPayloadID(payloadID)
+	}
+
+	// Read the build time live from config so UI overrides take effect immediately.
+	payloadBuildTime := b.cfg.PayloadBuildTime
+
+	// Wait for the EL to accumulate transactions, but abort early (with an error)
+	// if the build is cancelled by a newer slot or the context deadline is hit,
+	// rather than sleeping into a doomed getPayload call.
+	buildTimer := b.chainSvc.Clock().NewTimer(time.Duration(payloadBuildTime) * time.Millisecond)
+	defer buildTimer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("build aborted while waiting for payload: %w", ctx.Err())
+	case <-buildTimer.C():
+	}
+
+	// Retrieve the built payload as the fork-agnostic union.
+	resp, err := client.GetPayloadAgnostic(ctx, engineVersion, payloadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payload: %w", err)
+	}
+
+	if resp.ExecutionPayload == nil {
+		return nil, fmt.Errorf("getPayload returned no execution payload")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,131 @@
+package payload_builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/identification"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	enginev "github.com/ethpandaops/go-eth-engine-client/spec/version"
+	"github.com/holiman/uint256"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// raceTestEngine builds a payload of a fixed block value, or fails
+// forkchoiceUpdated with err; hang blocks getPayload until the context ends.
+type raceTestEngine struct {
+	value uint64
+	err   error
+	hang  bool
+}
+
+func (e *raceTestEngine) ForkchoiceUpdatedAgnostic(
+	context.Context,
+	*engineall.ForkchoiceUpdatedRequest,
+) (*paris.ForkchoiceUpdatedResponse, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return &paris.ForkchoiceUpdatedResponse{
+		PayloadStatus: paris.PayloadStatus{Status: paris.PayloadValidationStatusValid},
+		PayloadID:     &paris.PayloadID{0x01},
+	}, nil
+}
+
+func (e *raceTestEngine) GetPayloadAgnostic(
+	ctx context.Context,
+	_ enginev.DataVersion,
+	_ paris.PayloadID,
+) (*engineall.GetPayloadResponse, error) {
+	if e.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return &engineall.GetPayloadResponse{
+		ExecutionPayload: &engineall.ExecutionPayload{},
+		BlockValue:       uint256.NewInt(e.value),
+	}, nil
+}
+
+func (e *raceTestEngine) ClientVersion(
+	context.Context,
+	*identification.ClientVersion,
+) ([]*identification.ClientVersion, error) {
+	return nil, nil
+}
+
+func newRaceTestBuilder(primary EngineClient, race ...EngineEndpoint) *PayloadBuilder {
+	b := NewPayloadBuilder(nil, primary, &stubChainService{}, common.Address{},
+		&config.Config{ELEngineAPI: "http://el-a:8551"}, logrus.New(), nil)
+	b.raceEngines = race
+
+	return b
+}
+
+func TestRacePayload(t *testing.T) {
+	b := newRaceTestBuilder(&raceTestEngine{value: 100},
+		EngineEndpoint{Name: "el-b:8551", Client: &raceTestEngine{value: 300}},
+		EngineEndpoint{Name: "el-c:8551", Client: &raceTestEngine{err: errors.New("connection refused")}},
+		EngineEndpoint{Name: "el-d:8551", Client: &raceTestEngine{value: 300}},
+	)
+
+	var payloadIDs int
+
+	resp, winner, results, err := b.racePayload(t.Context(), &engineall.ForkchoiceUpdatedRequest{}, enginev.DataVersionPrague,
+		func(paris.PayloadID) { payloadIDs++ })
+	require.NoError(t, err)
+
+	// Ties go to the earlier engine; only the primary reports its payload ID.
+	assert.Equal(t, "el-b:8551", winner)
+	assert.Equal(t, uint64(300), resp.BlockValue.Uint64())
+	assert.Equal(t, 1, payloadIDs)
+	assert.Equal(t, []EngineResult{
+		{Engine: "el-a:8551", BlockValueWei: "100"},
+		{Engine: "el-b:8551", BlockValueWei: "300", Won: true},
+		{Engine: "el-c:8551", Error: "forkchoiceUpdated failed: connection refused"},
+		{Engine: "el-d:8551", BlockValueWei: "300"},
+	}, results)
+}
+
+func TestRacePayload_SingleEngine(t *testing.T) {
+	b := newRaceTestBuilder(&raceTestEngine{value: 100})
+
+	resp, winner, results, err := b.racePayload(t.Context(), &engineall.ForkchoiceUpdatedRequest{}, enginev.DataVersionPrague, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(100), resp.BlockValue.Uint64())
+	assert.Empty(t, winner)
+	assert.Nil(t, results)
+
+	b = newRaceTestBuilder(&raceTestEngine{err: errors.New("connection refused")})
+
+	_, _, _, err = b.racePayload(t.Context(), &engineall.ForkchoiceUpdatedRequest{}, enginev.DataVersionPrague, nil)
+	require.EqualError(t, err, "forkchoiceUpdated failed: connection refused")
+}
+
+func TestRacePayload_DropsSlowRaceEngine(t *testing.T) {
+	b := newRaceTestBuilder(&raceTestEngine{err: errors.New("syncing")},
+		EngineEndpoint{Name: "el-b:8551", Client: &raceTestEngine{hang: true}},
+	)
+
+	_, _, results, err := b.racePayload(t.Context(), &engineall.ForkchoiceUpdatedRequest{}, enginev.DataVersionPrague, nil)
+	require.ErrorContains(t, err, "all 2 engines failed")
+	require.ErrorContains(t, err, "el-b:8551: failed to get payload: context deadline exceeded")
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Won || results[1].Won)
+}
+
+func TestEngineName(t *testing.T) {
+	assert.Equal(t, "el-a:8551", EngineName("http://el-a:8551"))
+	assert.Equal(t, "el-a:8551", EngineName("http://el-a:8551/engine"))
+	assert.Equal(t, "el-a", EngineName("el-a"))
+}
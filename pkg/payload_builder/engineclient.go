@@ -2,11 +2,14 @@ package payload_builder
 
 import (
 	"context"
+	"time"
 
 	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
 	"github.com/ethpandaops/go-eth-engine-client/spec/identification"
 	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
 	enginev "github.com/ethpandaops/go-eth-engine-client/spec/version"
+
+	"github.com/ethpandaops/buildoor/pkg/metrics"
 )
 
 // EngineClient is the subset of the go-eth-engine-client JSON-RPC service used
@@ -34,3 +37,46 @@ type EngineClient interface {
 		clientVersion *identification.ClientVersion,
 	) ([]*identification.ClientVersion, error)
 }
+
+// instrumentedEngineClient times every engine API call into the
+// buildoor_engine_call_duration_seconds histogram.
+type instrumentedEngineClient struct {
+	EngineClient
+}
+
+// ForkchoiceUpdatedAgnostic implements EngineClient.
+func (c *instrumentedEngineClient) ForkchoiceUpdatedAgnostic(
+	ctx context.Context,
+	request *engineall.ForkchoiceUpdatedRequest,
+) (*paris.ForkchoiceUpdatedResponse, error) {
+	start := time.Now()
+	resp, err := c.EngineClient.ForkchoiceUpdatedAgnostic(ctx, request)
+	metrics.ObserveEngineCall("engine_forkchoiceUpdated", start, err)
+
+	return resp, err
+}
+
+// GetPayloadAgnostic implements EngineClient.
+func (c *instrumentedEngineClient) GetPayloadAgnostic(
+	ctx context.Context,
+	dataVersion enginev.DataVersion,
+	payloadID paris.PayloadID,
+) (*engineall.GetPayloadResponse, error) {
+	start := time.Now()
+	resp, err := c.EngineClient.GetPayloadAgnostic(ctx, dataVersion, payloadID)
+	metrics.ObserveEngineCall("engine_getPayload", start, err)
+
+	return resp, err
+}
+
+// ClientVersion implements EngineClient.
+func (c *instrumentedEngineClient) ClientVersion(
+	ctx context.Context,
+	clientVersion *identification.ClientVersion,
+) ([]*identification.ClientVersion, error) {
+	start := time.Now()
+	versions, err := c.EngineClient.ClientVersion(ctx, clientVersion)
+	metrics.ObserveEngineCall("engine_getClientVersion", start, err)
+
+	return versions, err
+}
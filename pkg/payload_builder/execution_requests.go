@@ -12,6 +12,8 @@ import (
 	"github.com/ethpandaops/go-eth2-client/spec/version"
 
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	dynssz "github.com/pk910/dynamic-ssz"
+	"github.com/pk910/dynamic-ssz/sszutils"
 )
 
 const (
@@ -28,12 +30,57 @@ const (
 	builderExitRequestSize    = 68  // 20 + 48
 )
 
+// requestTypeSchema describes one EIP-7685 request type in a fork's
+// ExecutionRequests container.
+type requestTypeSchema struct {
+	name string
+	// limitSpec/limitDefault resolve the SSZ list limit from the network's
+	// preset; an empty limitSpec means the list is unbounded (progressive).
+	limitSpec    string
+	limitDefault uint64
+}
+
+// electraRequestTypes are the request types of the Electra container, reused
+// unchanged by Fulu (bounded SSZ lists).
+var electraRequestTypes = map[byte]requestTypeSchema{
+	depositRequestType:       {name: "deposit", limitSpec: "MAX_DEPOSIT_REQUESTS_PER_PAYLOAD", limitDefault: 8192},
+	withdrawalRequestType:    {name: "withdrawal", limitSpec: "MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD", limitDefault: 16},
+	consolidationRequestType: {name: "consolidation", limitSpec: "MAX_CONSOLIDATION_REQUESTS_PER_PAYLOAD", limitDefault: 2},
+}
+
+// gloasRequestTypes are the request types of the Gloas container (EIP-8282):
+// the Electra types plus builder deposits and exits, all progressive lists.
+var gloasRequestTypes = map[byte]requestTypeSchema{
+	depositRequestType:        {name: "deposit"},
+	withdrawalRequestType:     {name: "withdrawal"},
+	consolidationRequestType:  {name: "consolidation"},
+	builderDepositRequestType: {name: "builder deposit"},
+	builderExitRequestType:    {name: "builder exit"},
+}
+
+// executionRequestTypes returns the request types valid in the fork's
+// ExecutionRequests container; nil before Electra (no requests).
+func executionRequestTypes(dataVersion version.DataVersion) map[byte]requestTypeSchema {
+	switch {
+	case dataVersion >= version.DataVersionGloas:
+		return gloasRequestTypes
+	case dataVersion >= version.DataVersionElectra:
+		return electraRequestTypes
+	default:
+		return nil
+	}
+}
+
 // ParseExecutionRequests decodes raw EIP-7685 execution request bytes from the
 // Engine API into a versioned eth2all.ExecutionRequests.
 //
 // Each element in raw is: [type_prefix_byte || request_1 || request_2 || ...]
 // where all requests of the same type are concatenated after a single prefix byte.
-// Builder deposit (0x03) and builder exit (0x04) requests are only valid from Gloas onwards.
+// Validation is strict and fork-versioned: type prefixes must be strictly
+// ascending (no duplicates), known, and valid for dataVersion — builder deposit
+// (0x03) and builder exit (0x04) requests only from Gloas onwards, no requests
+// at all before Electra — and Electra/Fulu request counts must fit the
+// preset's list limits.
 func ParseExecutionRequests(raw []prague.ExecutionRequest, dataVersion version.DataVersion) (*eth2all.ExecutionRequests, error) {
 	// All lists are initialized to empty (non-nil) slices so JSON marshalling
 	// emits `[]` rather than `null`. Strict SSZ-JSON consumers (e.g. Lodestar's
@@ -47,6 +94,9 @@ func ParseExecutionRequests(raw []prague.ExecutionRequest, dataVersion version.D
 		BuilderExits:    make([]*gloas.BuilderExitRequest, 0),
 	}
 
+	types := executionRequestTypes(dataVersion)
+	prevType := -1
+
 	for i, entry := range raw {
 		if len(entry) == 0 {
 			return nil, fmt.Errorf("execution request %d: empty entry", i)
@@ -55,62 +105,89 @@ func ParseExecutionRequests(raw []prague.ExecutionRequest, dataVersion version.D
 		reqType := entry[0]
 		data := entry[1:]
 
+		if int(reqType) <= prevType {
+			return nil, fmt.Errorf("execution request %d: type 0x%02x after 0x%02x (types must be strictly ascending)",
+				i, reqType, prevType)
+		}
+
+		prevType = int(reqType)
+
+		schema, known := types[reqType]
+		if !known {
+			if gloasType, ok := gloasRequestTypes[reqType]; ok && dataVersion >= version.DataVersionElectra {
+				return nil, fmt.Errorf("execution request %d: %s request not valid before Gloas", i, gloasType.name)
+			}
+
+			if types == nil {
+				return nil, fmt.Errorf("execution request %d: execution requests not valid before Electra", i)
+			}
+
+			return nil, fmt.Errorf("execution request %d: unknown type 0x%02x", i, reqType)
+		}
+
 		// An entry with only the type prefix and no data means zero requests of
 		// that type — skip it (matches go-ethereum's CalcRequestsHash behavior).
 		if len(data) == 0 {
 			continue
 		}
 
+		var (
+			count int
+			err   error
+		)
+
 		switch reqType {
 		case depositRequestType:
-			deposits, err := parseDepositRequests(data)
-			if err != nil {
-				return nil, fmt.Errorf("execution request %d: %w", i, err)
-			}
-			result.Deposits = deposits
+			result.Deposits, err = parseDepositRequests(data)
+			count = len(result.Deposits)
 
 		case withdrawalRequestType:
-			withdrawals, err := parseWithdrawalRequests(data)
-			if err != nil {
-				return nil, fmt.Errorf("execution request %d: %w", i, err)
-			}
-			result.Withdrawals = withdrawals
+			result.Withdrawals, err = parseWithdrawalRequests(data)
+			count = len(result.Withdrawals)
 
 		case consolidationRequestType:
-			consolidations, err := parseConsolidationRequests(data)
-			if err != nil {
-				return nil, fmt.Errorf("execution request %d: %w", i, err)
-			}
-			result.Consolidations = consolidations
+			result.Consolidations, err = parseConsolidationRequests(data)
+			count = len(result.Consolidations)
 
 		case builderDepositRequestType:
-			if dataVersion < version.DataVersionGloas {
-				return nil, fmt.Errorf("execution request %d: builder deposit request not valid before Gloas", i)
-			}
-			builderDeposits, err := parseBuilderDepositRequests(data)
-			if err != nil {
-				return nil, fmt.Errorf("execution request %d: %w", i, err)
-			}
-			result.BuilderDeposits = builderDeposits
+			result.BuilderDeposits, err = parseBuilderDepositRequests(data)
+			count = len(result.BuilderDeposits)
 
 		case builderExitRequestType:
-			if dataVersion < version.DataVersionGloas {
-				return nil, fmt.Errorf("execution request %d: builder exit request not valid before Gloas", i)
-			}
-			builderExits, err := parseBuilderExitRequests(data)
-			if err != nil {
-				return nil, fmt.Errorf("execution request %d: %w", i, err)
-			}
-			result.BuilderExits = builderExits
+			result.BuilderExits, err = parseBuilderExitRequests(data)
+			count = len(result.BuilderExits)
+		}
 
-		default:
-			return nil, fmt.Errorf("execution request %d: unknown type 0x%02x", i, reqType)
+		if err != nil {
+			return nil, fmt.Errorf("execution request %d: %w", i, err)
+		}
+
+		if err := checkRequestLimit(schema, count); err != nil {
+			return nil, fmt.Errorf("execution request %d: %w", i, err)
 		}
 	}
 
 	return result, nil
 }
 
+// checkRequestLimit checks count against the schema's preset list limit.
+func checkRequestLimit(schema requestTypeSchema, count int) error {
+	if schema.limitSpec == "" {
+		return nil
+	}
+
+	limit, err := sszutils.ResolveSpecValueWithDefault(dynssz.GetGlobalDynSsz(), schema.limitSpec, schema.limitDefault)
+	if err != nil {
+		return fmt.Errorf("%s requests: failed to resolve %s: %w", schema.name, schema.limitSpec, err)
+	}
+
+	if uint64(count) > limit {
+		return fmt.Errorf("%s requests: %d exceed %s (%d)", schema.name, count, schema.limitSpec, limit)
+	}
+
+	return nil
+}
+
 func parseDepositRequests(data []byte) ([]*electra.DepositRequest, error) {
 	if len(data)%depositRequestSize != 0 {
 		return nil, fmt.Errorf("deposit requests: length %d not divisible by %d", len(data), depositRequestSize)
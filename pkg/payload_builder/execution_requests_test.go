@@ -2,12 +2,15 @@ package payload_builder
 
 import (
 	"encoding/binary"
+	"math/rand"
 	"testing"
 
 	"github.com/ethpandaops/go-eth-engine-client/spec/prague"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
+	dynssz "github.com/pk910/dynamic-ssz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -321,3 +324,167 @@ func TestParseExecutionRequests_InvalidConsolidationLength(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not divisible by")
 }
+
+func TestParseExecutionRequests_NotAscending(t *testing.T) {
+	withdrawal := append([]byte{withdrawalRequestType}, make([]byte, withdrawalRequestSize)...)
+	deposit := append([]byte{depositRequestType}, make([]byte, depositRequestSize)...)
+
+	_, err := ParseExecutionRequests([]prague.ExecutionRequest{withdrawal, deposit}, version.DataVersionFulu)
+	assert.ErrorContains(t, err, "strictly ascending")
+
+	_, err = ParseExecutionRequests([]prague.ExecutionRequest{deposit, deposit}, version.DataVersionFulu)
+	assert.ErrorContains(t, err, "strictly ascending", "a duplicated type would silently drop requests")
+}
+
+func TestParseExecutionRequests_BeforeElectra(t *testing.T) {
+	result, err := ParseExecutionRequests(nil, version.DataVersionDeneb)
+	require.NoError(t, err)
+	assert.Empty(t, result.Deposits)
+
+	deposit := append([]byte{depositRequestType}, make([]byte, depositRequestSize)...)
+	_, err = ParseExecutionRequests([]prague.ExecutionRequest{deposit}, version.DataVersionDeneb)
+	assert.ErrorContains(t, err, "not valid before Electra")
+}
+
+func TestParseExecutionRequests_ListLimits(t *testing.T) {
+	// MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD is 16 in the Electra/Fulu container;
+	// Gloas lists are progressive (unbounded).
+	entry := append([]byte{withdrawalRequestType}, make([]byte, 17*withdrawalRequestSize)...)
+	raw := []prague.ExecutionRequest{entry}
+
+	for _, fork := range []version.DataVersion{version.DataVersionElectra, version.DataVersionFulu} {
+		_, err := ParseExecutionRequests(raw, fork)
+		assert.ErrorContains(t, err, "17 exceed MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD (16)", fork.String())
+	}
+
+	result, err := ParseExecutionRequests(raw, version.DataVersionGloas)
+	require.NoError(t, err)
+	assert.Len(t, result.Withdrawals, 17)
+}
+
+// encodeExecutionRequests is the inverse of ParseExecutionRequests: the
+// Engine API form of the requests (ascending types, empty types omitted).
+func encodeExecutionRequests(t *testing.T, requests *eth2all.ExecutionRequests) []prague.ExecutionRequest {
+	t.Helper()
+
+	raw := []prague.ExecutionRequest{}
+	add := func(reqType byte, size int, count int, write func(i int, d []byte)) {
+		if count == 0 {
+			return
+		}
+
+		entry := make([]byte, 1+size*count)
+		entry[0] = reqType
+
+		for i := range count {
+			write(i, entry[1+i*size:1+(i+1)*size])
+		}
+
+		raw = append(raw, entry)
+	}
+
+	add(depositRequestType, depositRequestSize, len(requests.Deposits), func(i int, d []byte) {
+		r := requests.Deposits[i]
+		copy(d[0:48], r.Pubkey[:])
+		copy(d[48:80], r.WithdrawalCredentials)
+		binary.LittleEndian.PutUint64(d[80:88], uint64(r.Amount))
+		copy(d[88:184], r.Signature[:])
+		binary.LittleEndian.PutUint64(d[184:192], r.Index)
+	})
+	add(withdrawalRequestType, withdrawalRequestSize, len(requests.Withdrawals), func(i int, d []byte) {
+		r := requests.Withdrawals[i]
+		copy(d[0:20], r.SourceAddress[:])
+		copy(d[20:68], r.ValidatorPubkey[:])
+		binary.LittleEndian.PutUint64(d[68:76], uint64(r.Amount))
+	})
+	add(consolidationRequestType, consolidationRequestSize, len(requests.Consolidations), func(i int, d []byte) {
+		r := requests.Consolidations[i]
+		copy(d[0:20], r.SourceAddress[:])
+		copy(d[20:68], r.SourcePubkey[:])
+		copy(d[68:116], r.TargetPubkey[:])
+	})
+	add(builderDepositRequestType, builderDepositRequestSize, len(requests.BuilderDeposits), func(i int, d []byte) {
+		r := requests.BuilderDeposits[i]
+		copy(d[0:48], r.Pubkey[:])
+		copy(d[48:80], r.WithdrawalCredentials)
+		binary.LittleEndian.PutUint64(d[80:88], uint64(r.Amount))
+		copy(d[88:184], r.Signature[:])
+	})
+	add(builderExitRequestType, builderExitRequestSize, len(requests.BuilderExits), func(i int, d []byte) {
+		r := requests.BuilderExits[i]
+		copy(d[0:20], r.SourceAddress[:])
+		copy(d[20:68], r.Pubkey[:])
+	})
+
+	return raw
+}
+
+// randomExecutionRequests returns random Engine API requests valid for fork:
+// every type of the fork's container, each with a random count (zero
+// included) within its list limit.
+func randomExecutionRequests(rng *rand.Rand, fork version.DataVersion) []prague.ExecutionRequest {
+	sizes := map[byte]int{
+		depositRequestType:        depositRequestSize,
+		withdrawalRequestType:     withdrawalRequestSize,
+		consolidationRequestType:  consolidationRequestSize,
+		builderDepositRequestType: builderDepositRequestSize,
+		builderExitRequestType:    builderExitRequestSize,
+	}
+
+	raw := []prague.ExecutionRequest{}
+
+	for reqType := byte(0); reqType <= builderExitRequestType; reqType++ {
+		schema, ok := executionRequestTypes(fork)[reqType]
+		if !ok {
+			continue
+		}
+
+		maxCount := 8
+		if schema.limitDefault != 0 && schema.limitDefault < uint64(maxCount) {
+			maxCount = int(schema.limitDefault)
+		}
+
+		count := rng.Intn(maxCount + 1)
+		if count == 0 {
+			continue
+		}
+
+		entry := make([]byte, 1+sizes[reqType]*count)
+		entry[0] = reqType
+		_, _ = rng.Read(entry[1:])
+
+		raw = append(raw, entry)
+	}
+
+	return raw
+}
+
+func TestExecutionRequests_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(7685))
+	ds := dynssz.GetGlobalDynSsz()
+
+	for _, fork := range []version.DataVersion{version.DataVersionElectra, version.DataVersionFulu, version.DataVersionGloas} {
+		for iteration := range 50 {
+			raw := randomExecutionRequests(rng, fork)
+
+			// engine → builder API
+			parsed, err := ParseExecutionRequests(raw, fork)
+			require.NoError(t, err, "%s #%d", fork, iteration)
+			require.Equal(t, raw, encodeExecutionRequests(t, parsed), "%s #%d: engine form survives parsing", fork, iteration)
+
+			// builder API → SSZ → builder API
+			encoded, err := ds.MarshalSSZ(parsed)
+			require.NoError(t, err, "%s #%d", fork, iteration)
+
+			decoded := &eth2all.ExecutionRequests{Version: fork}
+			require.NoError(t, ds.UnmarshalSSZ(decoded, encoded), "%s #%d", fork, iteration)
+			require.Equal(t, raw, encodeExecutionRequests(t, decoded), "%s #%d: SSZ round trip", fork, iteration)
+
+			parsedRoot, err := ds.HashTreeRoot(parsed)
+			require.NoError(t, err)
+			decodedRoot, err := ds.HashTreeRoot(decoded)
+			require.NoError(t, err)
+			require.Equal(t, parsedRoot, decodedRoot, "%s #%d", fork, iteration)
+		}
+	}
+}
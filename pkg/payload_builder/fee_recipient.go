@@ -0,0 +1,41 @@
+package payload_builder
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// builderFeeRecipient returns the builder's own fee recipient (the payload
+// coinbase) for a build at the given slot: the configured one, or the pool
+// address the fee recipient rotation selects for the slot.
+func (b *PayloadBuilder) builderFeeRecipient(slot phase0.Slot) common.Address {
+	return rotatedFeeRecipient(&b.cfg.FeeRecipientRotation, b.feeRecipient, slot, b.chainSvc.GetEpochOfSlot(slot))
+}
+
+// rotatedFeeRecipient picks the pool address at index slot (or epoch) modulo
+// the pool size. Rotation off or an empty pool keeps the fallback.
+func rotatedFeeRecipient(
+	rotation *config.FeeRecipientRotationConfig,
+	fallback common.Address,
+	slot phase0.Slot,
+	epoch phase0.Epoch,
+) common.Address {
+	if len(rotation.Pool) == 0 {
+		return fallback
+	}
+
+	var index uint64
+
+	switch rotation.NormalizedMode() {
+	case config.FeeRecipientRotationSlot:
+		index = uint64(slot)
+	case config.FeeRecipientRotationEpoch:
+		index = uint64(epoch)
+	default:
+		return fallback
+	}
+
+	return common.HexToAddress(rotation.Pool[index%uint64(len(rotation.Pool))])
+}
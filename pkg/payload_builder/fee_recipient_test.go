@@ -0,0 +1,33 @@
+package payload_builder
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func TestRotatedFeeRecipient(t *testing.T) {
+	fallback := common.HexToAddress("0x00000000000000000000000000000000000000ff")
+	pool := []string{
+		"0x0000000000000000000000000000000000000001",
+		"0x0000000000000000000000000000000000000002",
+		"0x0000000000000000000000000000000000000003",
+	}
+
+	off := &config.FeeRecipientRotationConfig{Mode: config.FeeRecipientRotationOff, Pool: pool}
+	assert.Equal(t, fallback, rotatedFeeRecipient(off, fallback, 5, 0))
+
+	empty := &config.FeeRecipientRotationConfig{Mode: config.FeeRecipientRotationSlot}
+	assert.Equal(t, fallback, rotatedFeeRecipient(empty, fallback, 5, 0))
+
+	perSlot := &config.FeeRecipientRotationConfig{Mode: config.FeeRecipientRotationSlot, Pool: pool}
+	assert.Equal(t, common.HexToAddress(pool[0]), rotatedFeeRecipient(perSlot, fallback, 3, 0))
+	assert.Equal(t, common.HexToAddress(pool[2]), rotatedFeeRecipient(perSlot, fallback, 5, 0))
+
+	perEpoch := &config.FeeRecipientRotationConfig{Mode: config.FeeRecipientRotationEpoch, Pool: pool}
+	assert.Equal(t, common.HexToAddress(pool[1]), rotatedFeeRecipient(perEpoch, fallback, 5, 4))
+	assert.Equal(t, common.HexToAddress(pool[1]), rotatedFeeRecipient(perEpoch, fallback, 63, 4))
+}
@@ -0,0 +1,117 @@
+package payload_builder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth-engine-client/spec/prague"
+)
+
+// GasLimitFault is the PayloadFault field recording a deliberate gas limit
+// violation (--gas-limit-violate).
+const GasLimitFault = "gas_limit"
+
+// ParentHeaderReader reads the header of a build's parent block from the EL
+// JSON-RPC. *execution.Client satisfies it.
+type ParentHeaderReader interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// SetParentHeaderReader enables gas limit adjustment of built payloads
+// (pre-Gloas targets and deliberate violations), which needs the parent's gas
+// limit. Register before Start().
+func (s *Service) SetParentHeaderReader(reader ParentHeaderReader) {
+	s.parentHeaders = reader
+}
+
+// nextGasLimit returns the gas limit of a block whose parent has
+// parentGasLimit, moved toward target by at most the protocol step (as the EL
+// does) or, with violate, the full step away from it: a consensus-valid gas
+// limit that ignores the proposer's target.
+func nextGasLimit(parentGasLimit, target uint64, violate bool) uint64 {
+	step := parentGasLimit/params.GasLimitBoundDivisor - 1
+	if parentGasLimit < params.GasLimitBoundDivisor {
+		step = 0
+	}
+
+	up := parentGasLimit + step
+	down := max(parentGasLimit-step, params.MinGasLimit)
+
+	switch {
+	case violate && target > parentGasLimit:
+		return down
+	case violate:
+		return up
+	case target > parentGasLimit:
+		return min(up, target)
+	default:
+		return max(down, target)
+	}
+}
+
+// adjustPayloadGasLimit rewrites the gas limit of a built payload in place to
+// nextGasLimit (never below its gas used) behind a recomputed block hash. It
+// returns the original gas limit and whether the payload changed. The
+// transactions stay as the EL executed them: only the header limit moves.
+func adjustPayloadGasLimit(
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	parentGasLimit, target uint64,
+	violate bool,
+) (uint64, bool, error) {
+	original := p.GasLimit
+	gasLimit := max(nextGasLimit(parentGasLimit, target, violate), p.GasUsed)
+
+	if gasLimit == original {
+		return original, false, nil
+	}
+
+	header, err := verifiedHeaderFromPayload(p, parentBeaconBlockRoot, executionRequests)
+	if err != nil {
+		return original, false, err
+	}
+
+	header.GasLimit = gasLimit
+	p.GasLimit = gasLimit
+	p.BlockHash = paris.Hash32(header.Hash())
+
+	return original, true, nil
+}
+
+// adjustGasLimit applies the slot's gas limit target to a built payload:
+// pre-Gloas the engine API cannot carry the target, so the payload is moved
+// toward it here; with violate it is moved away from it on any fork. The
+// returned fault records a violation.
+func (b *PayloadBuilder) adjustGasLimit(
+	ctx context.Context,
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	target uint64,
+	violate bool,
+) (*PayloadFault, error) {
+	parent, err := b.parentHeaders.HeaderByHash(ctx, common.Hash(p.ParentHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent header: %w", err)
+	}
+
+	original, changed, err := adjustPayloadGasLimit(p, executionRequests, parentBeaconBlockRoot,
+		parent.GasLimit, target, violate)
+	if err != nil || !changed || !violate {
+		return nil, err
+	}
+
+	return &PayloadFault{
+		Field:     GasLimitFault,
+		Original:  strconv.FormatUint(original, 10),
+		Corrupted: strconv.FormatUint(p.GasLimit, 10),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package payload_builder
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextGasLimit(t *testing.T) {
+	const parent = 30_000_000
+
+	step := uint64(parent/1024 - 1)
+
+	tests := []struct {
+		name    string
+		target  uint64
+		violate bool
+		want    uint64
+	}{
+		{name: "at target", target: parent, want: parent},
+		{name: "up capped by step", target: 60_000_000, want: parent + step},
+		{name: "down capped by step", target: 10_000_000, want: parent - step},
+		{name: "up within step", target: parent + 100, want: parent + 100},
+		{name: "down within step", target: parent - 100, want: parent - 100},
+		{name: "violate upward target", target: 60_000_000, violate: true, want: parent - step},
+		{name: "violate downward target", target: 10_000_000, violate: true, want: parent + step},
+		{name: "violate at target", target: parent, violate: true, want: parent + step},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextGasLimit(parent, tt.target, tt.violate))
+		})
+	}
+}
+
+func TestAdjustPayloadGasLimit(t *testing.T) {
+	p := emptyPaymentPayload(t, types.EmptyRootHash, common.Address{0xc0})
+	originalHash := p.BlockHash
+
+	// Already at the target: untouched.
+	original, changed, err := adjustPayloadGasLimit(p, nil, common.Hash{0x02}, 30_000_000, 30_000_000, false)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, uint64(30_000_000), original)
+	assert.Equal(t, originalHash, p.BlockHash)
+
+	original, changed, err = adjustPayloadGasLimit(p, nil, common.Hash{0x02}, 30_000_000, 36_000_000, true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, uint64(30_000_000), original)
+	assert.Equal(t, uint64(30_000_000-(30_000_000/1024-1)), p.GasLimit)
+	assert.NotEqual(t, originalHash, p.BlockHash)
+
+	// The rewritten block hash verifies against the new header.
+	_, err = verifiedHeaderFromPayload(p, common.Hash{0x02}, nil)
+	require.NoError(t, err)
+
+	// Never below the gas the payload already used.
+	p = emptyPaymentPayload(t, types.EmptyRootHash, common.Address{0xc0})
+	p.GasUsed = 29_990_000
+	header, err := buildHeaderFromPayload(p, common.Hash{0x02}, nil)
+	require.NoError(t, err)
+	p.BlockHash = paris.Hash32(header.Hash())
+
+	_, changed, err = adjustPayloadGasLimit(p, nil, common.Hash{0x02}, 30_000_000, 10_000_000, false)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, uint64(29_990_000), p.GasLimit)
+}
@@ -0,0 +1,127 @@
+package payload_builder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// scheduleCandidateBuild schedules a candidate payload build on the parent
+// named by the payload_attributes event, once per slot and parent and for at
+// most 1 + BuilderAPI.ParentCandidates parents per slot. Candidates let bid
+// requests for any plausible parent be served when the chain is unstable
+// (late blocks, short reorgs). Parent-reorg slots build on a deliberately
+// different parent and get no candidates.
+func (s *Service) scheduleCandidateBuild(event *beacon.PayloadAttributesEvent, frozen *action_plan.FrozenPlan) {
+	limit := s.cfg.BuilderAPI.ParentCandidates
+	if limit <= 0 || frozen.Build.ReorgParentPayload {
+		return
+	}
+
+	slot := event.ProposalSlot
+
+	s.scheduledBuildMu.Lock()
+
+	parents := s.candidateParents[slot]
+	if parents == nil {
+		parents = make(map[phase0.Hash32]bool, limit+1)
+		s.candidateParents[slot] = parents
+	}
+
+	if parents[event.ParentBlockHash] || len(parents) > limit {
+		s.scheduledBuildMu.Unlock()
+
+		return
+	}
+
+	parents[event.ParentBlockHash] = true
+	s.scheduledBuildMu.Unlock()
+
+	buildTime := s.chainSvc.SlotToTime(slot).Add(time.Duration(frozen.Build.BuildStartTimeMs) * time.Millisecond)
+
+	clk := s.chainSvc.Clock()
+	clk.AfterFunc(max(clk.Until(buildTime), 0), func() {
+		s.executeCandidateBuild(event)
+	})
+}
+
+// executeCandidateBuild builds and caches a candidate payload on the event's
+// parent, unless a build on that parent already started or the slot's regular
+// build is still due and will build on it (the parent is the latest one).
+// Candidates are stored next to the slot's latest payload and do not feed
+// the p2p bidder or the payload ready subscribers.
+func (s *Service) executeCandidateBuild(event *beacon.PayloadAttributesEvent) {
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return
+	}
+
+	slot := event.ProposalSlot
+
+	s.scheduledBuildMu.Lock()
+
+	started := s.buildParents[slot]
+	latest := s.clClient.Events().GetLatestPayloadAttributes(slot)
+	regularDue := len(started) == 0 && latest != nil && latest.ParentBlockHash == event.ParentBlockHash
+
+	if started[event.ParentBlockHash] || regularDue {
+		s.scheduledBuildMu.Unlock()
+
+		return
+	}
+
+	s.markBuildParentLocked(slot, event.ParentBlockHash)
+	s.scheduledBuildMu.Unlock()
+
+	log := s.log.WithFields(logrus.Fields{
+		"slot":        slot,
+		"parent_hash": fmt.Sprintf("%x", event.ParentBlockHash[:8]),
+	})
+	log.Info("Starting candidate payload build on alternative parent")
+
+	buildTimeout := time.Duration(s.cfg.PayloadBuildTime)*time.Millisecond + buildCallTimeout
+	ctx, cancel := context.WithTimeout(s.ctx, buildTimeout)
+	defer cancel()
+
+	payload, err := s.payloadBuilder.BuildPayloadFromAttributes(ctx, event)
+	if err != nil {
+		log.WithError(err).Warn("Failed to build candidate payload")
+		return
+	}
+
+	if err := s.applyPayloadTransform(ctx, slot, payload); err != nil {
+		log.WithError(err).Warn("Candidate payload transform failed")
+		return
+	}
+
+	s.payloadCache.StoreCandidate(payload)
+
+	log.WithFields(logrus.Fields{
+		"block_hash":  fmt.Sprintf("%x", payload.BlockHash[:8]),
+		"block_value": payload.BlockValue,
+	}).Info("Candidate payload built")
+}
+
+// markBuildParent records that a build on parentHash started for the slot.
+func (s *Service) markBuildParent(slot phase0.Slot, parentHash phase0.Hash32) {
+	s.scheduledBuildMu.Lock()
+	defer s.scheduledBuildMu.Unlock()
+
+	s.markBuildParentLocked(slot, parentHash)
+}
+
+// markBuildParentLocked is markBuildParent with scheduledBuildMu held.
+func (s *Service) markBuildParentLocked(slot phase0.Slot, parentHash phase0.Hash32) {
+	parents := s.buildParents[slot]
+	if parents == nil {
+		parents = make(map[phase0.Hash32]bool, 2)
+		s.buildParents[slot] = parents
+	}
+
+	parents[parentHash] = true
+}
@@ -0,0 +1,102 @@
+package payload_builder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// candidateChainService places every slot in the past, so scheduled
+// candidate builds fire immediately.
+type candidateChainService struct {
+	stubChainService
+}
+
+func (s *candidateChainService) SlotToTime(_ phase0.Slot) time.Time { return time.Time{} }
+
+func newCandidateTestService(t *testing.T, parentCandidates int) *Service {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.BuilderAPI.ParentCandidates = parentCandidates
+
+	chainSvc := &candidateChainService{stubChainService{spec: &chain.ChainSpec{
+		SecondsPerSlot: 12 * time.Second,
+		SlotsPerEpoch:  32,
+	}}}
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	// The client never connects (delayed start); only its event stream is used.
+	clClient, err := beacon.NewClient(context.Background(), "http://127.0.0.1:1", false, log)
+	require.NoError(t, err)
+
+	svc, err := NewService(cfg, clClient, chainSvc, action_plan.NewPlanService(cfg, chainSvc, log),
+		nil, common.Address{}, log)
+	require.NoError(t, err)
+
+	return svc
+}
+
+func candidateAttributes(slot phase0.Slot, parent byte) *beacon.PayloadAttributesEvent {
+	return &beacon.PayloadAttributesEvent{ProposalSlot: slot, ParentBlockHash: phase0.Hash32{parent}}
+}
+
+// TestScheduleCandidateBuildBoundsParents schedules one candidate per parent,
+// for at most 1 + ParentCandidates parents per slot.
+func TestScheduleCandidateBuildBoundsParents(t *testing.T) {
+	frozen := &action_plan.FrozenPlan{Build: &action_plan.ResolvedBuildSettings{Build: true}}
+
+	svc := newCandidateTestService(t, 1)
+
+	// A cancelled service context makes the fired builds no-ops.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc.ctx = ctx
+
+	for _, parent := range []byte{0xa1, 0xa1, 0xa2, 0xa3} {
+		svc.scheduleCandidateBuild(candidateAttributes(20, parent), frozen)
+	}
+
+	assert.Equal(t, map[phase0.Hash32]bool{{0xa1}: true, {0xa2}: true}, svc.candidateParents[20])
+
+	disabled := newCandidateTestService(t, 0)
+	disabled.scheduleCandidateBuild(candidateAttributes(20, 0xa1), frozen)
+	assert.Empty(t, disabled.candidateParents)
+
+	reorg := newCandidateTestService(t, 1)
+	reorg.scheduleCandidateBuild(candidateAttributes(20, 0xa1), &action_plan.FrozenPlan{
+		Build: &action_plan.ResolvedBuildSettings{Build: true, ReorgParentPayload: true},
+	})
+	assert.Empty(t, reorg.candidateParents, "parent-reorg slots get no candidates")
+}
+
+// TestExecuteCandidateBuildSkipsCoveredParents leaves parents to builds that
+// already started on them and to the slot's still-due regular build.
+func TestExecuteCandidateBuildSkipsCoveredParents(t *testing.T) {
+	svc := newCandidateTestService(t, 2)
+	svc.ctx = context.Background()
+
+	require.True(t, svc.clClient.Events().InjectPayloadAttributes(candidateAttributes(20, 0xa2)))
+
+	// Regular build not started yet and 0xa2 is the latest parent: it is due.
+	svc.executeCandidateBuild(candidateAttributes(20, 0xa2))
+	assert.Empty(t, svc.buildParents[20])
+
+	// A build on 0xa1 already started.
+	svc.markBuildParent(20, phase0.Hash32{0xa1})
+	svc.executeCandidateBuild(candidateAttributes(20, 0xa1))
+	assert.Equal(t, map[phase0.Hash32]bool{{0xa1}: true}, svc.buildParents[20])
+}
@@ -31,11 +31,40 @@ type Payload struct {
 	ExecutionRequests *eth2all.ExecutionRequests
 
 	// Metadata not carried by the objects above.
-	BlockHash    phase0.Hash32  // block hash after extra-data injection
+	BlockHash    phase0.Hash32  // block hash after extra-data injection (and payment sealing)
 	FeeRecipient common.Address // resolved proposer fee recipient for the bid
 	BlockValue   *big.Int       // EL-reported block value (wei)
 	ReadyAt      time.Time      // when the payload became ready
 
+	// PaymentTxHash is the proposer payment appended by the payment sealer
+	// (--builder-api-payment-tx); zero when the payload is unsealed.
+	PaymentTxHash common.Hash
+
+	// InjectedTxs are the injected transactions (TxInjector) this payload
+	// carries; nil when it carries none.
+	InjectedTxs []common.Hash
+
+	// Faults are the fields fault injection corrupted in this payload; nil
+	// for an honest payload.
+	Faults []PayloadFault
+
+	// Engine is the engine API endpoint that built the payload when builds
+	// are raced across several (--el-engine-race-apis); EngineRace holds
+	// every engine's outcome of that race. Both are empty with a single engine.
+	Engine     string
+	EngineRace []EngineResult
+
+	// Supersedes is the block hash of the slot's earlier payload this rebuild
+	// replaced (stale-bid replacement); zero for the slot's first build.
+	Supersedes phase0.Hash32
+
+	// WithdrawalsSource is where the payload's withdrawals were taken from
+	// (config.WithdrawalsSource*). WithdrawalsDivergence describes how the
+	// payload_attributes withdrawals disagreed with the beacon node's
+	// expected withdrawals; empty when they agreed or were not compared.
+	WithdrawalsSource     string
+	WithdrawalsDivergence string
+
 	// activity is the bid/reveal log, appended by the payload_bidder and read by
 	// the WebUI. The mutex also makes Payload copy-unsafe, enforcing the
 	// pass-by-pointer rule.
@@ -68,10 +97,21 @@ type RevealRecord struct {
 	At              time.Time
 }
 
+// PublishRecord records one beacon node's publication of the block carrying
+// this payload (Builder API: the unblinded block is fanned out to every
+// configured beacon node).
+type PublishRecord struct {
+	Node    string        // beacon node host (no credentials)
+	Latency time.Duration // time until the node answered
+	Err     string        // empty when the node accepted the block
+	At      time.Time
+}
+
 type payloadActivity struct {
-	mu     sync.RWMutex
-	bids   []BidRecord
-	reveal *RevealRecord
+	mu        sync.RWMutex
+	bids      []BidRecord
+	reveal    *RevealRecord
+	publishes []PublishRecord
 }
 
 // AddBid appends a bid record to the payload's activity log.
@@ -93,6 +133,26 @@ func (p *Payload) Bids() []BidRecord {
 	return out
 }
 
+// AddPublish appends a block publication record to the payload's activity log.
+func (p *Payload) AddPublish(rec PublishRecord) {
+	p.activity.mu.Lock()
+	defer p.activity.mu.Unlock()
+
+	p.activity.publishes = append(p.activity.publishes, rec)
+}
+
+// Publishes returns a snapshot copy of the block publications recorded for
+// this payload.
+func (p *Payload) Publishes() []PublishRecord {
+	p.activity.mu.RLock()
+	defer p.activity.mu.RUnlock()
+
+	out := make([]PublishRecord, len(p.activity.publishes))
+	copy(out, p.activity.publishes)
+
+	return out
+}
+
 // MarkRevealed records the reveal of this payload's envelope. The first reveal
 // wins; subsequent calls are ignored.
 func (p *Payload) MarkRevealed(rec RevealRecord) {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/chain"
 	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/metrics"
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 )
 
@@ -28,8 +29,19 @@ type PayloadBuilder struct {
 
 	settingsResolvers []ProposerSettingsResolver // asked in order for proposer settings; first match wins
 	cfg               *config.Config             // shared config; mutable settings are read live, never cached
+	sealer            *PaymentSealer             // appends the proposer payment pre-Gloas; nil disables
+	faultsFor         func(phase0.Slot) []string // payload fields to corrupt for a slot; nil disables
+	raceEngines       []EngineEndpoint           // further engines raced against engineClient; nil builds on it alone
+	injector          *TxInjector                // injected transactions submitted ahead of every build; nil disables
+	stuffer           *BlobStuffer               // blob transactions submitted ahead of stuffed builds; nil disables
+	blobStuffingFor   func(phase0.Slot) string   // blob stuffing level for a slot ("" = none)
 	log               logrus.FieldLogger
 
+	// Gas limit adjustment: the slot's target override (0 = none) and
+	// violation, applied against the parent's gas limit (nil reader disables).
+	parentHeaders ParentHeaderReader
+	gasLimitFor   func(phase0.Slot) (uint64, bool)
+
 	// Active build tracking
 	activeBuild *activeBuild
 	mu          sync.Mutex
@@ -77,6 +89,18 @@ func NewPayloadBuilder(
 func (b *PayloadBuilder) BuildPayloadFromAttributes(
 	ctx context.Context,
 	attrs *beacon.PayloadAttributesEvent,
+) (*Payload, error) {
+	start := time.Now()
+	payload, err := b.buildPayloadFromAttributes(ctx, attrs)
+	metrics.ObservePayloadBuild(time.Since(start), err)
+
+	return payload, err
+}
+
+// buildPayloadFromAttributes implements BuildPayloadFromAttributes.
+func (b *PayloadBuilder) buildPayloadFromAttributes(
+	ctx context.Context,
+	attrs *beacon.PayloadAttributesEvent,
 ) (*Payload, error) {
 	b.mu.Lock()
 
@@ -123,8 +147,10 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 	// pre-Gloas); the first match wins.
 	// Post-Gloas fallbacks: TargetGasLimit / SuggestedFeeRecipient from the
 	//                       payload_attributes event.
-	// Final fallback:       the builder's configured fee recipient.
-	proposerFeeRecipient := b.feeRecipient
+	// Final fallback:       the builder's own fee recipient (rotated across the
+	//                       pool when fee recipient rotation is on).
+	builderFeeRecipient := b.builderFeeRecipient(attrs.ProposalSlot)
+	proposerFeeRecipient := builderFeeRecipient
 	var targetGasLimit uint64
 
 	for _, resolver := range b.settingsResolvers {
@@ -154,7 +180,7 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 		// payload_attributes. This ensures bids match the proposer's expected fee
 		// recipient even when preferences aren't received via SSE (e.g. same-node
 		// P2P broadcast doesn't loop back).
-		if proposerFeeRecipient == b.feeRecipient && attrs.SuggestedFeeRecipient != (common.Address{}) {
+		if proposerFeeRecipient == builderFeeRecipient && attrs.SuggestedFeeRecipient != (common.Address{}) {
 			proposerFeeRecipient = attrs.SuggestedFeeRecipient
 			b.log.WithFields(logrus.Fields{
 				"slot":           attrs.ProposalSlot,
@@ -164,14 +190,28 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 		}
 	}
 
+	// The configured or planned gas limit target overrides the proposer's.
+	var violateGasLimit bool
+
+	if b.gasLimitFor != nil {
+		var override uint64
+		if override, violateGasLimit = b.gasLimitFor(attrs.ProposalSlot); override != 0 {
+			targetGasLimit = override
+		}
+	}
+
+	// Withdrawals per the configured source priority (payload_attributes or
+	// the beacon node's expected withdrawals), cross-checked when configured.
+	withdrawals := b.resolveWithdrawals(buildCtx, attrs)
+
 	// Build the fork-agnostic payload attributes and forkchoice request. The
 	// engine client dispatches to the correct engine_forkchoiceUpdated version.
 	payloadAttrs := &engineall.PayloadAttributes{
 		Version:               engineVersion,
 		Timestamp:             attrs.Timestamp,
 		PrevRandao:            paris.Hash32(attrs.PrevRandao),
-		SuggestedFeeRecipient: paris.Address(b.feeRecipient),
-		Withdrawals:           convertWithdrawalsToEngineFormat(attrs.Withdrawals),
+		SuggestedFeeRecipient: paris.Address(builderFeeRecipient),
+		Withdrawals:           convertWithdrawalsToEngineFormat(withdrawals.withdrawals),
 		ParentBeaconBlockRoot: paris.Hash32(attrs.ParentBeaconBlockRoot),
 		SlotNumber:            uint64(attrs.ProposalSlot),
 		TargetGasLimit:        targetGasLimit,
@@ -201,74 +241,138 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 		"parent_hash":      fmt.Sprintf("%x", attrs.ParentBlockHash[:8]),
 		"engine_version":   engineVersion,
 		"target_gas_limit": targetGasLimit,
+		"coinbase":         builderFeeRecipient.Hex(),
 	}).Debug("Building payload from attributes")
 
-	fcuResp, err := b.engineClient.ForkchoiceUpdatedAgnostic(buildCtx, fcuReq)
-	if err != nil {
-		return nil, fmt.Errorf("forkchoiceUpdated failed: %w", err)
-	}
-
-	status := fcuResp.PayloadStatus.Status
-	if status != paris.PayloadValidationStatusValid && status != paris.PayloadValidationStatusSyncing {
-		return nil, fmt.Errorf("forkchoice status: %s", status)
-	}
-
-	if fcuResp.PayloadID == nil {
-		return nil, fmt.Errorf("no payload ID returned")
+	// Stuffing and injected transactions must sit in the mempool when the EL
+	// starts assembling the block.
+	if b.stuffer != nil {
+		if level := b.blobStuffingFor(attrs.ProposalSlot); level != "" {
+			b.stuffer.stuff(buildCtx, attrs.ProposalSlot, level)
+		}
 	}
 
-	payloadID := *fcuResp.PayloadID
-
-	b.mu.Lock()
-	if b.activeBuild != nil && b.activeBuild.slot == attrs.ProposalSlot {
-		b.activeBuild.payloadID = payloadID
+	if b.injector != nil {
+		b.injector.submit(buildCtx, attrs.ProposalSlot)
 	}
-	b.mu.Unlock()
-
-	b.log.WithFields(logrus.Fields{
-		"slot":       attrs.ProposalSlot,
-		"payload_id": fmt.Sprintf("%x", payloadID[:]),
-	}).Debug("Payload build requested from attributes")
 
-	// Read the build time live from config so UI overrides take effect immediately.
-	payloadBuildTime := b.cfg.PayloadBuildTime
+	b.log.Infof("Allowing payload to build for: %dms", b.cfg.PayloadBuildTime)
 
-	b.log.Infof("Allowing payload to build for: %dms", payloadBuildTime)
-
-	// Wait for the EL to accumulate transactions, but abort early (with an error)
-	// if the build is cancelled by a newer slot or the context deadline is hit,
-	// rather than sleeping into a doomed getPayload call.
-	buildTimer := time.NewTimer(time.Duration(payloadBuildTime) * time.Millisecond)
-	defer buildTimer.Stop()
-
-	select {
-	case <-buildCtx.Done():
-		return nil, fmt.Errorf("build aborted while waiting for payload: %w", buildCtx.Err())
-	case <-buildTimer.C:
-	}
+	resp, engineName, engineRace, err := b.racePayload(buildCtx, fcuReq, engineVersion, func(payloadID paris.PayloadID) {
+		b.mu.Lock()
+		if b.activeBuild != nil && b.activeBuild.slot == attrs.ProposalSlot {
+			b.activeBuild.payloadID = payloadID
+		}
+		b.mu.Unlock()
 
-	// Retrieve the built payload as the fork-agnostic union.
-	resp, err := b.engineClient.GetPayloadAgnostic(buildCtx, engineVersion, payloadID)
+		b.log.WithFields(logrus.Fields{
+			"slot":       attrs.ProposalSlot,
+			"payload_id": fmt.Sprintf("%x", payloadID[:]),
+		}).Debug("Payload build requested from attributes")
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get payload: %w", err)
+		return nil, err
 	}
 
 	enginePayload := resp.ExecutionPayload
-	if enginePayload == nil {
-		return nil, fmt.Errorf("getPayload returned no execution payload")
-	}
 
 	// Inject our extra-data marker and recompute the block hash on the typed payload.
 	newHash, err := ModifyPayloadExtraData(
 		enginePayload,
 		resp.ExecutionRequests,
-		[]byte(b.cfg.ExtraData),
+		[]byte(b.cfg.ExtraDataBranding()),
 		common.Hash(attrs.ParentBeaconBlockRoot),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to modify payload extra data: %w", err)
 	}
 
+	// Pre-Gloas the engine API carries no gas limit target, so the payload is
+	// moved toward it here; a violation moves it away on any fork.
+	var gasLimitFault *PayloadFault
+
+	if targetGasLimit != 0 && (violateGasLimit || beaconFork < version.DataVersionGloas) {
+		switch {
+		case b.parentHeaders != nil:
+			gasLimitFault, err = b.adjustGasLimit(buildCtx, enginePayload, resp.ExecutionRequests,
+				common.Hash(attrs.ParentBeaconBlockRoot), targetGasLimit, violateGasLimit)
+			if err != nil {
+				b.log.WithError(err).WithField("slot", attrs.ProposalSlot).Warn(
+					"Failed to adjust the payload gas limit, serving the EL's")
+			}
+
+			newHash = common.Hash(enginePayload.BlockHash)
+		case violateGasLimit:
+			b.log.WithField("slot", attrs.ProposalSlot).Warn("Gas limit violation needs --el-rpc, serving the EL's gas limit")
+		}
+	}
+
+	blockValue := new(big.Int)
+	if resp.BlockValue != nil {
+		blockValue = resp.BlockValue.ToBig()
+	}
+
+	// Pre-Gloas the coinbase is the builder's, so the proposer is paid by a
+	// transfer appended to the block. Gloas+ bids are paid on the CL.
+	var paymentTxHash common.Hash
+
+	if b.sealer != nil && beaconFork < version.DataVersionGloas &&
+		proposerFeeRecipient != builderFeeRecipient && blockValue.Sign() > 0 {
+		sealed, err := b.sealPayment(buildCtx, attrs.ProposalSlot, enginePayload, resp.ExecutionRequests,
+			common.Hash(attrs.ParentBeaconBlockRoot), proposerFeeRecipient, blockValue)
+		if err != nil {
+			b.log.WithError(err).WithField("slot", attrs.ProposalSlot).Warn(
+				"Failed to seal proposer payment, serving the payload unsealed")
+		} else {
+			newHash = sealed.BlockHash
+			paymentTxHash = sealed.TxHash
+		}
+	}
+
+	// Fault injection corrupts the finished payload (after sealing, so the
+	// recomputed block hash covers everything the bid commits to).
+	var faults []PayloadFault
+
+	if b.faultsFor != nil {
+		if fields := b.faultsFor(attrs.ProposalSlot); len(fields) > 0 {
+			faults, err = injectPayloadFaults(
+				enginePayload,
+				resp.BlobsBundle,
+				resp.ExecutionRequests,
+				common.Hash(attrs.ParentBeaconBlockRoot),
+				fields,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to inject payload faults: %w", err)
+			}
+
+			newHash = common.Hash(enginePayload.BlockHash)
+
+			b.log.WithFields(logrus.Fields{
+				"slot":       attrs.ProposalSlot,
+				"block_hash": fmt.Sprintf("%x", newHash[:8]),
+				"faults":     faults,
+			}).Warn("Injected faults into built payload")
+		}
+	}
+
+	if gasLimitFault != nil {
+		faults = append(faults, *gasLimitFault)
+
+		b.log.WithFields(logrus.Fields{
+			"slot":      attrs.ProposalSlot,
+			"target":    targetGasLimit,
+			"el":        gasLimitFault.Original,
+			"gas_limit": gasLimitFault.Corrupted,
+		}).Warn("Built payload deliberately violates the gas limit target")
+	}
+
+	var injectedTxs []common.Hash
+
+	if b.injector != nil {
+		injectedTxs = b.injector.markIncluded(attrs.ProposalSlot, phase0.Hash32(newHash), enginePayload.Transactions)
+	}
+
 	// Single fork-independent conversions to the beacon types: the execution
 	// payload and (Electra+) the execution requests are converted here, once,
 	// so consumers never touch the raw engine forms.
@@ -279,11 +383,6 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 		return nil, fmt.Errorf("failed to parse execution requests: %w", err)
 	}
 
-	blockValue := new(big.Int)
-	if resp.BlockValue != nil {
-		blockValue = resp.BlockValue.ToBig()
-	}
-
 	event := &Payload{
 		Attributes:        attrs,
 		ExecutionPayload:  beaconPayload,
@@ -293,6 +392,14 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 		FeeRecipient:      proposerFeeRecipient,
 		BlockValue:        blockValue,
 		ReadyAt:           time.Now(),
+		PaymentTxHash:     paymentTxHash,
+		InjectedTxs:       injectedTxs,
+		Faults:            faults,
+		Engine:            engineName,
+		EngineRace:        engineRace,
+
+		WithdrawalsSource:     withdrawals.source,
+		WithdrawalsDivergence: withdrawals.divergence,
 	}
 
 	b.log.WithFields(logrus.Fields{
@@ -302,9 +409,12 @@ func (b *PayloadBuilder) BuildPayloadFromAttributes(
 		"block_value":       blockValue.String(),
 		"has_blobs":         resp.BlobsBundle != nil,
 		"has_exec_requests": len(resp.ExecutionRequests) > 0,
+		"payment_sealed":    paymentTxHash != (common.Hash{}),
+		"injected_txs":      len(injectedTxs),
 		"txs_in_payload":    len(beaconPayload.Transactions),
 		"target_gas_limit":  targetGasLimit,
 		"payload_gas_limit": beaconPayload.GasLimit,
+		"engine":            engineName,
 	}).Info("Payload built from attributes")
 
 	return event, nil
@@ -13,10 +13,20 @@ const (
 
 // PayloadCache stores built payloads for a limited number of slots.
 // It uses a simple LRU-like approach, keeping only the most recent slots.
+// Payloads replaced by a rebuild of their slot are kept as superseded: a bid
+// committing to them may still win and must remain revealable. Candidates are
+// payloads built on alternative parents of the slot (unstable chain); they
+// only serve bid requests for their parent and never replace the latest.
+// Invalidated payloads were built from outdated payload_attributes; they are
+// only resolvable by block hash (the reveal of a bid already committing to
+// them).
 type PayloadCache struct {
-	payloads map[phase0.Slot]*Payload
-	maxSlots int
-	mu       sync.RWMutex
+	payloads    map[phase0.Slot]*Payload
+	superseded  map[phase0.Slot][]*Payload
+	candidates  map[phase0.Slot][]*Payload
+	invalidated map[phase0.Slot][]*Payload
+	maxSlots    int
+	mu          sync.RWMutex
 }
 
 // NewPayloadCache creates a new payload cache with the specified maximum slots.
@@ -26,22 +36,86 @@ func NewPayloadCache(maxSlots int) *PayloadCache {
 	}
 
 	return &PayloadCache{
-		payloads: make(map[phase0.Slot]*Payload, maxSlots),
-		maxSlots: maxSlots,
+		payloads:    make(map[phase0.Slot]*Payload, maxSlots),
+		superseded:  make(map[phase0.Slot][]*Payload),
+		candidates:  make(map[phase0.Slot][]*Payload),
+		invalidated: make(map[phase0.Slot][]*Payload),
+		maxSlots:    maxSlots,
 	}
 }
 
-// Store stores a payload in the cache.
-// It automatically evicts old payloads to maintain the size limit.
+// Store stores a payload in the cache, superseding a different payload
+// already stored for the slot. It automatically evicts old payloads to
+// maintain the size limit.
 func (c *PayloadCache) Store(event *Payload) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.payloads[event.Attributes.ProposalSlot] = event
-	c.evictOld(event.Attributes.ProposalSlot)
+	slot := event.Attributes.ProposalSlot
+
+	if prev, ok := c.payloads[slot]; ok && prev.BlockHash != event.BlockHash {
+		c.superseded[slot] = append(c.superseded[slot], prev)
+	}
+
+	c.payloads[slot] = event
+	c.evictOld(slot)
+}
+
+// Supersede withdraws the slot's latest payload if it is the one with the
+// given block hash: Get returns nil until the next Store, while the payload
+// stays servable for its own parent (GetByParent) and revealable.
+func (c *PayloadCache) Supersede(slot phase0.Slot, blockHash phase0.Hash32) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	payload, ok := c.payloads[slot]
+	if !ok || payload.BlockHash != blockHash {
+		return false
+	}
+
+	delete(c.payloads, slot)
+	c.superseded[slot] = append(c.superseded[slot], payload)
+
+	return true
 }
 
-// Get retrieves a payload for the given slot.
+// Invalidate withdraws the slot's latest payload if it is the one with the
+// given block hash, leaving it resolvable by block hash only.
+func (c *PayloadCache) Invalidate(slot phase0.Slot, blockHash phase0.Hash32) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	payload, ok := c.payloads[slot]
+	if !ok || payload.BlockHash != blockHash {
+		return false
+	}
+
+	delete(c.payloads, slot)
+	c.invalidated[slot] = append(c.invalidated[slot], payload)
+
+	return true
+}
+
+// StoreCandidate stores a payload built on an alternative parent of its slot,
+// replacing an earlier candidate on the same parent.
+func (c *PayloadCache) StoreCandidate(event *Payload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	slot := event.Attributes.ProposalSlot
+	candidates := c.candidates[slot]
+
+	for i, candidate := range candidates {
+		if candidate.Attributes.ParentBlockHash == event.Attributes.ParentBlockHash {
+			candidates[i] = event
+			return
+		}
+	}
+
+	c.candidates[slot] = append(candidates, event)
+}
+
+// Get retrieves the latest payload for the given slot.
 func (c *PayloadCache) Get(slot phase0.Slot) *Payload {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -49,7 +123,35 @@ func (c *PayloadCache) Get(slot phase0.Slot) *Payload {
 	return c.payloads[slot]
 }
 
-// GetByBlockHash retrieves a payload by its block hash.
+// GetByParent retrieves the slot's most recent payload built on the given
+// parent block hash: the latest payload, else a candidate, else a superseded
+// payload.
+func (c *PayloadCache) GetByParent(slot phase0.Slot, parentHash phase0.Hash32) *Payload {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if payload := c.payloads[slot]; payload != nil && payload.Attributes.ParentBlockHash == parentHash {
+		return payload
+	}
+
+	for _, payload := range c.candidates[slot] {
+		if payload.Attributes.ParentBlockHash == parentHash {
+			return payload
+		}
+	}
+
+	superseded := c.superseded[slot]
+	for i := len(superseded) - 1; i >= 0; i-- {
+		if superseded[i].Attributes.ParentBlockHash == parentHash {
+			return superseded[i]
+		}
+	}
+
+	return nil
+}
+
+// GetByBlockHash retrieves a payload by its block hash, superseded,
+// candidate and invalidated payloads included.
 func (c *PayloadCache) GetByBlockHash(blockHash phase0.Hash32) *Payload {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -60,18 +162,45 @@ func (c *PayloadCache) GetByBlockHash(blockHash phase0.Hash32) *Payload {
 		}
 	}
 
+	for _, payloads := range c.superseded {
+		for _, payload := range payloads {
+			if payload.BlockHash == blockHash {
+				return payload
+			}
+		}
+	}
+
+	for _, payloads := range c.candidates {
+		for _, payload := range payloads {
+			if payload.BlockHash == blockHash {
+				return payload
+			}
+		}
+	}
+
+	for _, payloads := range c.invalidated {
+		for _, payload := range payloads {
+			if payload.BlockHash == blockHash {
+				return payload
+			}
+		}
+	}
+
 	return nil
 }
 
-// Delete removes a payload for the given slot.
+// Delete removes the payloads for the given slot.
 func (c *PayloadCache) Delete(slot phase0.Slot) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	delete(c.payloads, slot)
+	delete(c.superseded, slot)
+	delete(c.candidates, slot)
+	delete(c.invalidated, slot)
 }
 
-// GetAll returns all cached payloads.
+// GetAll returns the latest cached payload of every slot.
 func (c *PayloadCache) GetAll() []*Payload {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -111,6 +240,9 @@ func (c *PayloadCache) evictOld(_ phase0.Slot) {
 	// Keep evicting until we're at the limit
 	for len(c.payloads) > c.maxSlots {
 		delete(c.payloads, oldestSlot)
+		delete(c.superseded, oldestSlot)
+		delete(c.candidates, oldestSlot)
+		delete(c.invalidated, oldestSlot)
 
 		// Find next oldest
 		oldestSlot = 0
@@ -133,4 +265,22 @@ func (c *PayloadCache) Cleanup(olderThan phase0.Slot) {
 			delete(c.payloads, slot)
 		}
 	}
+
+	for slot := range c.superseded {
+		if slot < olderThan {
+			delete(c.superseded, slot)
+		}
+	}
+
+	for slot := range c.candidates {
+		if slot < olderThan {
+			delete(c.candidates, slot)
+		}
+	}
+
+	for slot := range c.invalidated {
+		if slot < olderThan {
+			delete(c.invalidated, slot)
+		}
+	}
 }
@@ -0,0 +1,105 @@
+package payload_builder
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+func newCachedPayload(slot phase0.Slot, hash byte) *Payload {
+	return &Payload{
+		Attributes: &beacon.PayloadAttributesEvent{ProposalSlot: slot},
+		BlockHash:  phase0.Hash32{hash},
+	}
+}
+
+func TestPayloadCacheKeepsSupersededPayloads(t *testing.T) {
+	cache := NewPayloadCache(2)
+
+	first := newCachedPayload(10, 0x01)
+	rebuilt := newCachedPayload(10, 0x02)
+
+	cache.Store(first)
+	cache.Store(first) // re-storing the same payload does not supersede it
+	cache.Store(rebuilt)
+
+	assert.Equal(t, rebuilt, cache.Get(10), "Get returns the latest payload")
+	assert.Equal(t, first, cache.GetByBlockHash(first.BlockHash), "superseded payloads stay resolvable")
+	assert.Len(t, cache.superseded[10], 1)
+
+	cache.Store(newCachedPayload(11, 0x03))
+	cache.Store(newCachedPayload(12, 0x04)) // evicts slot 10
+
+	assert.Nil(t, cache.Get(10))
+	assert.Nil(t, cache.GetByBlockHash(first.BlockHash), "eviction drops superseded payloads too")
+
+	cache.Store(newCachedPayload(12, 0x05))
+	cache.Delete(12)
+	require.Nil(t, cache.GetByBlockHash(phase0.Hash32{0x04}))
+}
+
+func TestPayloadCacheServesCandidatesByParent(t *testing.T) {
+	cache := NewPayloadCache(2)
+
+	withParent := func(hash, parent byte) *Payload {
+		payload := newCachedPayload(10, hash)
+		payload.Attributes.ParentBlockHash = phase0.Hash32{parent}
+
+		return payload
+	}
+
+	stale := withParent(0x01, 0xa1)
+	latest := withParent(0x02, 0xa2)
+	candidate := withParent(0x03, 0xa3)
+	rebuiltCandidate := withParent(0x04, 0xa3)
+
+	cache.Store(stale)
+	cache.Store(latest)
+	cache.StoreCandidate(candidate)
+	cache.StoreCandidate(rebuiltCandidate) // same parent: replaces the candidate
+
+	assert.Equal(t, latest, cache.Get(10), "candidates never replace the latest payload")
+	assert.Equal(t, latest, cache.GetByParent(10, phase0.Hash32{0xa2}))
+	assert.Equal(t, rebuiltCandidate, cache.GetByParent(10, phase0.Hash32{0xa3}))
+	assert.Equal(t, stale, cache.GetByParent(10, phase0.Hash32{0xa1}), "superseded payloads serve their parent")
+	assert.Nil(t, cache.GetByParent(10, phase0.Hash32{0xa4}))
+	assert.Nil(t, cache.GetByParent(11, phase0.Hash32{0xa2}))
+	assert.Equal(t, rebuiltCandidate, cache.GetByBlockHash(rebuiltCandidate.BlockHash))
+
+	cache.Cleanup(11)
+	assert.Nil(t, cache.GetByParent(10, phase0.Hash32{0xa3}), "cleanup drops candidates too")
+}
+
+func TestPayloadCacheWithdrawsStalePayloads(t *testing.T) {
+	cache := NewPayloadCache(4)
+
+	onParent := func(slot phase0.Slot, hash, parent byte) *Payload {
+		payload := newCachedPayload(slot, hash)
+		payload.Attributes.ParentBlockHash = phase0.Hash32{parent}
+
+		return payload
+	}
+
+	superseded := onParent(10, 0x01, 0xa1)
+	cache.Store(superseded)
+
+	assert.False(t, cache.Supersede(10, phase0.Hash32{0x09}), "only the named payload is withdrawn")
+	require.True(t, cache.Supersede(10, superseded.BlockHash))
+	assert.Nil(t, cache.Get(10))
+	assert.Equal(t, superseded, cache.GetByParent(10, phase0.Hash32{0xa1}), "superseded payloads serve their parent")
+
+	invalidated := onParent(11, 0x02, 0xa2)
+	cache.Store(invalidated)
+
+	require.True(t, cache.Invalidate(11, invalidated.BlockHash))
+	assert.Nil(t, cache.Get(11))
+	assert.Nil(t, cache.GetByParent(11, phase0.Hash32{0xa2}), "invalidated payloads are never served")
+	assert.Equal(t, invalidated, cache.GetByBlockHash(invalidated.BlockHash), "invalidated payloads stay revealable")
+
+	cache.Cleanup(12)
+	assert.Nil(t, cache.GetByBlockHash(invalidated.BlockHash))
+}
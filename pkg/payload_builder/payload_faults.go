@@ -0,0 +1,137 @@
+package payload_builder
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth-engine-client/spec/prague"
+	"github.com/ethpandaops/go-eth-engine-client/spec/shanghai"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// PayloadFault records one field corrupted by fault injection: its value
+// before and after the corruption.
+type PayloadFault struct {
+	Field     string `json:"field"`
+	Original  string `json:"original"`
+	Corrupted string `json:"corrupted"`
+}
+
+// injectPayloadFaults corrupts the given fields (config.PayloadFault*) of a
+// built payload in place and returns what was corrupted. Header fields are
+// corrupted behind a recomputed block hash, so the payload passes the block
+// hash check and is only rejected by execution (state / receipts root,
+// withdrawals) or by the blob commitment checks. Fields the payload does not
+// carry (blob commitments without blobs, withdrawals pre-Shanghai) are
+// skipped.
+func injectPayloadFaults(
+	p *engineall.ExecutionPayload,
+	blobs *engineall.BlobsBundle,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	fields []string,
+) ([]PayloadFault, error) {
+	header, err := verifiedHeaderFromPayload(p, parentBeaconBlockRoot, executionRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	faults := make([]PayloadFault, 0, len(fields))
+
+	for _, field := range fields {
+		switch field {
+		case config.PayloadFaultStateRoot:
+			original := p.StateRoot
+			p.StateRoot = corruptHash(original)
+			header.Root = common.Hash(p.StateRoot)
+
+			faults = append(faults, hashFault(field, original, p.StateRoot))
+
+		case config.PayloadFaultReceiptsRoot:
+			original := p.ReceiptsRoot
+			p.ReceiptsRoot = corruptHash(original)
+			header.ReceiptHash = common.Hash(p.ReceiptsRoot)
+
+			faults = append(faults, hashFault(field, original, p.ReceiptsRoot))
+
+		case config.PayloadFaultWithdrawals:
+			if header.WithdrawalsHash == nil {
+				continue
+			}
+
+			faults = append(faults, corruptWithdrawals(p))
+
+			wRoot := withdrawalsRoot(p.Withdrawals)
+			header.WithdrawalsHash = &wRoot
+
+		case config.PayloadFaultBlobCommitments:
+			if blobs == nil || len(blobs.Commitments) == 0 {
+				continue
+			}
+
+			original := blobs.Commitments[0]
+			blobs.Commitments[0][len(original)-1] ^= 0xff
+
+			faults = append(faults, PayloadFault{
+				Field:     field,
+				Original:  fmt.Sprintf("%#x", original[:]),
+				Corrupted: fmt.Sprintf("%#x", blobs.Commitments[0][:]),
+			})
+
+		default:
+			return nil, fmt.Errorf("unknown payload fault field %q", field)
+		}
+	}
+
+	p.BlockHash = paris.Hash32(header.Hash())
+
+	return faults, nil
+}
+
+// corruptHash flips every bit of the last byte, a value no honest payload
+// carries.
+func corruptHash(h paris.Hash32) paris.Hash32 {
+	h[len(h)-1] ^= 0xff
+
+	return h
+}
+
+func hashFault(field string, original, corrupted paris.Hash32) PayloadFault {
+	return PayloadFault{
+		Field:     field,
+		Original:  fmt.Sprintf("%#x", original[:]),
+		Corrupted: fmt.Sprintf("%#x", corrupted[:]),
+	}
+}
+
+// corruptWithdrawals pays the first withdrawal one gwei more than the beacon
+// state owes, or adds a bogus withdrawal to an empty list. The list is
+// copied: the withdrawals may be shared with the payload attributes.
+func corruptWithdrawals(p *engineall.ExecutionPayload) PayloadFault {
+	fault := PayloadFault{Field: config.PayloadFaultWithdrawals}
+
+	if len(p.Withdrawals) == 0 {
+		p.Withdrawals = []*shanghai.Withdrawal{{Amount: 1}}
+		fault.Original = "no withdrawals"
+		fault.Corrupted = "1 bogus withdrawal (validator 0, 1 gwei)"
+
+		return fault
+	}
+
+	withdrawals := make([]*shanghai.Withdrawal, len(p.Withdrawals))
+	copy(withdrawals, p.Withdrawals)
+
+	first := *withdrawals[0]
+	first.Amount++
+	withdrawals[0] = &first
+	p.Withdrawals = withdrawals
+
+	fault.Original = fmt.Sprintf("withdrawal %d amount %d gwei", first.Index, first.Amount-1)
+	fault.Corrupted = fmt.Sprintf("withdrawal %d amount %d gwei", first.Index, first.Amount)
+
+	return fault
+}
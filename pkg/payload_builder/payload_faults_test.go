@@ -0,0 +1,76 @@
+package payload_builder
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/cancun"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth-engine-client/spec/shanghai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func TestInjectPayloadFaults(t *testing.T) {
+	parentRoot := common.Hash{0x02}
+
+	p := emptyPaymentPayload(t, common.Hash{0xaa}, common.Address{0x01})
+	honestHash := p.BlockHash
+	stateRoot := p.StateRoot
+
+	blobs := &engineall.BlobsBundle{Commitments: []cancun.KZGCommitment{{0x0c}}}
+
+	faults, err := injectPayloadFaults(p, blobs, nil, parentRoot, config.PayloadFaultFields)
+	require.NoError(t, err)
+	require.Len(t, faults, 4)
+
+	assert.Equal(t, config.PayloadFaultStateRoot, faults[0].Field)
+	assert.NotEqual(t, stateRoot, p.StateRoot)
+	assert.Equal(t, byte(0xff), p.StateRoot[31])
+	assert.Equal(t, config.PayloadFaultReceiptsRoot, faults[1].Field)
+	assert.Equal(t, "no withdrawals", faults[2].Original)
+	require.Len(t, p.Withdrawals, 1)
+	assert.Equal(t, byte(0xff), blobs.Commitments[0][47])
+
+	// The block hash covers the corrupted header, so only execution (or the
+	// blob checks) can reject the payload.
+	assert.NotEqual(t, honestHash, p.BlockHash)
+
+	header, err := buildHeaderFromPayload(p, parentRoot, nil)
+	require.NoError(t, err)
+	assert.Equal(t, paris.Hash32(header.Hash()), p.BlockHash)
+}
+
+func TestInjectPayloadFaults_Withdrawals(t *testing.T) {
+	p := emptyPaymentPayload(t, common.Hash{0xaa}, common.Address{0x01})
+	shared := &shanghai.Withdrawal{Index: 7, ValidatorIndex: 3, Amount: 100}
+	p.Withdrawals = []*shanghai.Withdrawal{shared}
+
+	header, err := buildHeaderFromPayload(p, common.Hash{0x02}, nil)
+	require.NoError(t, err)
+	p.BlockHash = paris.Hash32(header.Hash())
+
+	faults, err := injectPayloadFaults(p, nil, nil, common.Hash{0x02},
+		[]string{config.PayloadFaultWithdrawals, config.PayloadFaultBlobCommitments})
+	require.NoError(t, err)
+
+	require.Len(t, faults, 1, "blob commitments are skipped without blobs")
+	assert.Equal(t, PayloadFault{
+		Field:     config.PayloadFaultWithdrawals,
+		Original:  "withdrawal 7 amount 100 gwei",
+		Corrupted: "withdrawal 7 amount 101 gwei",
+	}, faults[0])
+	assert.Equal(t, uint64(101), p.Withdrawals[0].Amount)
+	assert.Equal(t, uint64(100), shared.Amount, "the shared withdrawal is not modified")
+}
+
+func TestInjectPayloadFaults_RejectsUnverifiableHash(t *testing.T) {
+	p := emptyPaymentPayload(t, common.Hash{0xaa}, common.Address{0x01})
+	p.BlockHash = paris.Hash32{0x99}
+
+	_, err := injectPayloadFaults(p, nil, nil, common.Hash{0x02}, []string{config.PayloadFaultStateRoot})
+	require.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
 	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
 	"github.com/ethpandaops/go-eth-engine-client/spec/prague"
+	"github.com/ethpandaops/go-eth-engine-client/spec/shanghai"
 	enginev "github.com/ethpandaops/go-eth-engine-client/spec/version"
 )
 
@@ -39,35 +40,9 @@ func ModifyPayloadExtraData(
 	extraDataPrefix []byte,
 	parentBeaconBlockRoot common.Hash,
 ) (common.Hash, error) {
-	header, err := buildHeaderFromPayload(p, parentBeaconBlockRoot, executionRequests)
+	header, err := verifiedHeaderFromPayload(p, parentBeaconBlockRoot, executionRequests)
 	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to build header from payload: %w", err)
-	}
-
-	// Verify our reconstruction matches the original block hash. This catches
-	// cases where the fork added new header fields we don't handle yet.
-	originalHash := common.Hash(p.BlockHash)
-	computedHash := header.Hash()
-
-	if computedHash != originalHash {
-		// Fallback: try toggling requestsHash. The engine API may not always
-		// clearly signal whether executionRequests are present (field absent
-		// vs null vs empty array). Try the opposite setting.
-		if header.RequestsHash == nil {
-			emptyReqHash := types.CalcRequestsHash(nil)
-			header.RequestsHash = &emptyReqHash
-		} else {
-			header.RequestsHash = nil
-		}
-
-		computedHash = header.Hash()
-		if computedHash != originalHash {
-			return common.Hash{}, fmt.Errorf(
-				"hash verification failed: computed %s but payload has %s "+
-					"(this may indicate an unhandled fork with new header fields)",
-				computedHash.Hex(), originalHash.Hex(),
-			)
-		}
+		return common.Hash{}, err
 	}
 
 	// Build new extra data: prefix + "/" separator + original (truncated to
@@ -102,6 +77,46 @@ func ModifyPayloadExtraData(
 	return newHash, nil
 }
 
+// verifiedHeaderFromPayload reconstructs the payload's header and verifies
+// it hashes to the payload's block hash. This catches cases where the fork
+// added new header fields we don't handle yet.
+func verifiedHeaderFromPayload(
+	p *engineall.ExecutionPayload,
+	parentBeaconBlockRoot common.Hash,
+	executionRequests []prague.ExecutionRequest,
+) (*types.Header, error) {
+	header, err := buildHeaderFromPayload(p, parentBeaconBlockRoot, executionRequests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build header from payload: %w", err)
+	}
+
+	originalHash := common.Hash(p.BlockHash)
+	computedHash := header.Hash()
+
+	if computedHash != originalHash {
+		// Fallback: try toggling requestsHash. The engine API may not always
+		// clearly signal whether executionRequests are present (field absent
+		// vs null vs empty array). Try the opposite setting.
+		if header.RequestsHash == nil {
+			emptyReqHash := types.CalcRequestsHash(nil)
+			header.RequestsHash = &emptyReqHash
+		} else {
+			header.RequestsHash = nil
+		}
+
+		computedHash = header.Hash()
+		if computedHash != originalHash {
+			return nil, fmt.Errorf(
+				"hash verification failed: computed %s but payload has %s "+
+					"(this may indicate an unhandled fork with new header fields)",
+				computedHash.Hex(), originalHash.Hex(),
+			)
+		}
+	}
+
+	return header, nil
+}
+
 // buildHeaderFromPayload reconstructs a go-ethereum types.Header from the
 // engine execution payload fields: deriving transactionsRoot and
 // withdrawalsRoot from the typed arrays, computing requestsHash from execution
@@ -152,17 +167,7 @@ func buildHeaderFromPayload(
 
 	// add shanghai specific fields
 	if p.Version >= enginev.DataVersionShanghai {
-		ws := make(types.Withdrawals, len(p.Withdrawals))
-		for i, w := range p.Withdrawals {
-			ws[i] = &types.Withdrawal{
-				Index:     w.Index,
-				Validator: w.ValidatorIndex,
-				Address:   common.Address(w.Address),
-				Amount:    w.Amount,
-			}
-		}
-
-		wRoot := types.DeriveSha(ws, trie.NewStackTrie(nil))
+		wRoot := withdrawalsRoot(p.Withdrawals)
 		header.WithdrawalsHash = &wRoot
 	}
 
@@ -197,3 +202,18 @@ func buildHeaderFromPayload(
 
 	return header, nil
 }
+
+// withdrawalsRoot is the header withdrawals root of an engine withdrawals list.
+func withdrawalsRoot(withdrawals []*shanghai.Withdrawal) common.Hash {
+	ws := make(types.Withdrawals, len(withdrawals))
+	for i, w := range withdrawals {
+		ws[i] = &types.Withdrawal{
+			Index:     w.Index,
+			Validator: w.ValidatorIndex,
+			Address:   common.Address(w.Address),
+			Amount:    w.Amount,
+		}
+	}
+
+	return types.DeriveSha(ws, trie.NewStackTrie(nil))
+}
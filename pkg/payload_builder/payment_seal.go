@@ -0,0 +1,540 @@
+package payload_builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth-engine-client/spec/prague"
+	enginev "github.com/ethpandaops/go-eth-engine-client/spec/version"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/metrics"
+	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
+)
+
+// paymentSealMinTime is the least time that must be left before the proposal
+// slot starts (when the proposer requests the header) for a payment seal to
+// be attempted. The seal itself is cancelled at the slot start.
+const paymentSealMinTime = 500 * time.Millisecond
+
+// PayloadImporter imports a payload into the EL without making it canonical
+// (engine_newPayload). The engine client satisfies it.
+type PayloadImporter interface {
+	NewPayloadAgnostic(ctx context.Context, request *engineall.NewPayloadRequest) (*paris.PayloadStatus, error)
+}
+
+// PaymentStateReader reads the post-state and receipts of an imported block
+// from the EL JSON-RPC. *execution.Client satisfies it.
+type PaymentStateReader interface {
+	GetChainID(ctx context.Context) (*big.Int, error)
+	GetProof(ctx context.Context, address common.Address, blockHash common.Hash) (*execution.AccountProof, error)
+	GetBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error)
+}
+
+// PaymentSigner signs the payment transaction and reserves its nonce against
+// the wallet's own transactions. *wallet.Wallet satisfies it.
+type PaymentSigner interface {
+	Address() common.Address
+	SignTransaction(tx *types.Transaction) (*types.Transaction, error)
+	ReserveNonce(ctx context.Context, nonce uint64, until time.Time) (func(), error)
+}
+
+// SealedPayment describes the payment appended by SealPayment.
+type SealedPayment struct {
+	BlockHash common.Hash // block hash of the sealed payload
+	TxHash    common.Hash // the payment transaction
+	Value     *big.Int    // wei transferred to the fee recipient
+}
+
+// PaymentSealer appends a proposer payment to built payloads: a plain value
+// transfer from the builder wallet to the proposer's fee recipient as the
+// block's last transaction.
+//
+// No engine method executes extra transactions on a built payload, so the
+// payment is executed locally with go-ethereum's state transition on top of
+// the payload's post-state: the payload is imported (engine_newPayload, not
+// made canonical), the accounts the transfer touches are proven against its
+// state root (eth_getProof) and loaded into a proof-backed state, and the new
+// state root, receipts root, gas used and block hash are derived from the
+// result. The sealed payload is imported as well and only used when the EL
+// accepts it as VALID.
+//
+// The payment's nonce is the sender's nonce in the payload's post-state. It
+// is reserved through the wallet (PaymentSigner.ReserveNonce), so sealing is
+// refused while a wallet transaction (deposit, blob stuffing, injection) is in
+// flight at that nonce, and the wallet holds its own transactions back until
+// the sealed block's slot has passed.
+//
+// Sealing costs two engine_newPayload imports, up to three eth_getProof calls
+// and an eth_getBlockReceipts call on the build path; the builder bounds it by
+// the proposal slot start (see paymentSealMinTime).
+//
+// Sealing needs an EL that serves the state of imported non-canonical blocks.
+// Payloads with block access lists (Amsterdam+) are not sealed.
+type PaymentSealer struct {
+	importer PayloadImporter
+	state    PaymentStateReader
+	signer   PaymentSigner
+	log      logrus.FieldLogger
+
+	chainIDMu sync.Mutex
+	chainID   *big.Int
+}
+
+// SetPaymentSealer enables sealing pre-Gloas payloads with a proposer
+// payment when the proposer's fee recipient is not the builder's. Register
+// before Start().
+func (s *Service) SetPaymentSealer(sealer *PaymentSealer) {
+	s.paymentSealer = sealer
+}
+
+// sealPayment seals the payload of slot with the proposer payment, within the
+// time left before the slot starts. The payment's nonce stays reserved in the
+// wallet until the slot has passed.
+func (b *PayloadBuilder) sealPayment(
+	ctx context.Context,
+	slot phase0.Slot,
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	feeRecipient common.Address,
+	value *big.Int,
+) (*SealedPayment, error) {
+	left := b.chainSvc.Clock().Until(b.chainSvc.SlotToTime(slot))
+	if left < paymentSealMinTime {
+		return nil, fmt.Errorf("too close to the slot start (%s left)", left.Round(time.Millisecond))
+	}
+
+	sealCtx, cancel := context.WithTimeout(ctx, left)
+	defer cancel()
+
+	return b.sealer.SealPayment(sealCtx, p, executionRequests, parentBeaconBlockRoot, feeRecipient, value,
+		b.chainSvc.SlotToTime(slot+1))
+}
+
+// NewPaymentSealer creates a payment sealer paying from signer's address.
+func NewPaymentSealer(
+	importer PayloadImporter,
+	stateReader PaymentStateReader,
+	signer PaymentSigner,
+	log logrus.FieldLogger,
+) *PaymentSealer {
+	return &PaymentSealer{
+		importer: importer,
+		state:    stateReader,
+		signer:   signer,
+		log:      log.WithField("component", "payment-sealer"),
+	}
+}
+
+// Address returns the address payments are sent from.
+func (s *PaymentSealer) Address() common.Address {
+	return s.signer.Address()
+}
+
+// SealPayment appends the transfer of value to feeRecipient to the payload
+// and recomputes its state root, receipts root, gas used, logs bloom and
+// block hash. p is only modified on success; on error the caller keeps the
+// unsealed payload.
+//
+// parentBeaconBlockRoot and executionRequests are needed to import the
+// payload and to compute its block hash (see ModifyPayloadExtraData). The
+// payment's nonce stays reserved in the wallet until reserveUntil.
+func (s *PaymentSealer) SealPayment(
+	ctx context.Context,
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	feeRecipient common.Address,
+	value *big.Int,
+	reserveUntil time.Time,
+) (*SealedPayment, error) {
+	if p.Version >= enginev.DataVersionAmsterdam {
+		return nil, fmt.Errorf("payloads with block access lists (%s) cannot be sealed", p.Version)
+	}
+
+	if p.GasLimit-p.GasUsed < params.TxGas {
+		return nil, fmt.Errorf("no gas left for the payment (used %d of %d)", p.GasUsed, p.GasLimit)
+	}
+
+	chainID, err := s.getChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Import the payload so the EL serves its post-state.
+	if err := s.importPayload(ctx, p, executionRequests, parentBeaconBlockRoot); err != nil {
+		return nil, fmt.Errorf("payload import failed: %w", err)
+	}
+
+	blockHash := common.Hash(p.BlockHash)
+	sender := s.signer.Address()
+
+	proofs, err := s.fetchProofs(ctx, blockHash, sender, feeRecipient, common.Address(p.FeeRecipient))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPaymentAccounts(p, proofs[sender], proofs[feeRecipient], value); err != nil {
+		return nil, err
+	}
+
+	nonce := uint64(proofs[sender].Nonce)
+
+	release, err := s.signer.ReserveNonce(ctx, nonce, reserveUntil)
+	if err != nil {
+		return nil, fmt.Errorf("payment nonce unavailable: %w", err)
+	}
+
+	tx, err := s.signer.SignTransaction(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: new(big.Int),
+		GasFeeCap: p.BaseFeePerGas.ToBig(),
+		Gas:       params.TxGas,
+		To:        &feeRecipient,
+		Value:     value,
+	}))
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to sign payment: %w", err)
+	}
+
+	sealed, err := s.applyAndImport(ctx, p, executionRequests, parentBeaconBlockRoot, chainID, proofs, tx)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	*p = *sealed
+
+	s.log.WithFields(logrus.Fields{
+		"block_number":  p.BlockNumber,
+		"block_hash":    common.Hash(p.BlockHash).Hex(),
+		"tx_hash":       tx.Hash().Hex(),
+		"fee_recipient": feeRecipient.Hex(),
+		"value":         value.String(),
+	}).Info("Payload sealed with proposer payment")
+
+	return &SealedPayment{
+		BlockHash: common.Hash(p.BlockHash),
+		TxHash:    tx.Hash(),
+		Value:     new(big.Int).Set(value),
+	}, nil
+}
+
+// applyAndImport executes the payment on the payload and imports the sealed
+// payload.
+func (s *PaymentSealer) applyAndImport(
+	ctx context.Context,
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	chainID *big.Int,
+	proofs map[common.Address]*execution.AccountProof,
+	tx *types.Transaction,
+) (*engineall.ExecutionPayload, error) {
+	receipts, err := s.state.GetBlockReceipts(ctx, common.Hash(p.BlockHash))
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := applyPayment(p, executionRequests, parentBeaconBlockRoot, chainID, proofs, receipts, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The EL has the final say: only a sealed payload it accepts is served.
+	if err := s.importPayload(ctx, sealed, executionRequests, parentBeaconBlockRoot); err != nil {
+		return nil, fmt.Errorf("sealed payload rejected: %w", err)
+	}
+
+	return sealed, nil
+}
+
+// getChainID returns the EL's chain ID, fetched once.
+func (s *PaymentSealer) getChainID(ctx context.Context) (*big.Int, error) {
+	s.chainIDMu.Lock()
+	defer s.chainIDMu.Unlock()
+
+	if s.chainID == nil {
+		chainID, err := s.state.GetChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.chainID = chainID
+	}
+
+	return s.chainID, nil
+}
+
+// importPayload submits the payload via engine_newPayload and requires it to
+// be VALID (an ACCEPTED or SYNCING payload has no post-state to read).
+func (s *PaymentSealer) importPayload(
+	ctx context.Context,
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+) error {
+	blobHashes, err := blobVersionedHashes(p)
+	if err != nil {
+		return err
+	}
+
+	request := &engineall.NewPayloadRequest{
+		Version:          p.Version,
+		ExecutionPayload: p,
+	}
+
+	if p.Version >= enginev.DataVersionCancun {
+		request.ExpectedBlobVersionedHashes = blobHashes
+		request.ParentBeaconBlockRoot = paris.Hash32(parentBeaconBlockRoot)
+	}
+
+	if p.Version >= enginev.DataVersionPrague {
+		request.ExecutionRequests = executionRequests
+	}
+
+	start := time.Now()
+	status, err := s.importer.NewPayloadAgnostic(ctx, request)
+	metrics.ObserveEngineCall("engine_newPayload", start, err)
+
+	if err != nil {
+		return err
+	}
+
+	if status.Status != paris.PayloadValidationStatusValid {
+		return fmt.Errorf("newPayload status %s: %s", status.Status, string(status.ValidationError))
+	}
+
+	return nil
+}
+
+// fetchProofs proves each (distinct) address against the block's state.
+func (s *PaymentSealer) fetchProofs(
+	ctx context.Context,
+	blockHash common.Hash,
+	addresses ...common.Address,
+) (map[common.Address]*execution.AccountProof, error) {
+	proofs := make(map[common.Address]*execution.AccountProof, len(addresses))
+
+	for _, address := range addresses {
+		if _, ok := proofs[address]; ok {
+			continue
+		}
+
+		proof, err := s.state.GetProof(ctx, address, blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		proofs[address] = proof
+	}
+
+	return proofs, nil
+}
+
+// checkPaymentAccounts refuses payments the local execution cannot model or
+// the chain would reject: a fee recipient with code (the transfer would run
+// it against state we do not hold) and a sender that cannot cover the
+// payment before the block's withdrawals are credited (the transaction runs
+// before them on chain, but after them in the post-state we execute on).
+func checkPaymentAccounts(
+	p *engineall.ExecutionPayload,
+	sender, recipient *execution.AccountProof,
+	value *big.Int,
+) error {
+	if recipient.CodeHash != (common.Hash{}) && recipient.CodeHash != types.EmptyCodeHash {
+		return fmt.Errorf("fee recipient %s has code", recipient.Address.Hex())
+	}
+
+	if sender.CodeHash != (common.Hash{}) && sender.CodeHash != types.EmptyCodeHash {
+		return fmt.Errorf("payment sender %s has code", sender.Address.Hex())
+	}
+
+	available := new(big.Int).Set(sender.Balance.ToInt())
+
+	for _, w := range p.Withdrawals {
+		if common.Address(w.Address) == sender.Address {
+			available.Sub(available, new(big.Int).Mul(new(big.Int).SetUint64(w.Amount), big.NewInt(params.GWei)))
+		}
+	}
+
+	cost := new(big.Int).Mul(p.BaseFeePerGas.ToBig(), big.NewInt(int64(params.TxGas)))
+	cost.Add(cost, value)
+
+	if available.Cmp(cost) < 0 {
+		return fmt.Errorf("payment sender %s cannot cover %s wei (has %s before withdrawals)",
+			sender.Address.Hex(), cost.String(), available.String())
+	}
+
+	return nil
+}
+
+// applyPayment executes the signed payment on the payload's post-state,
+// loaded from the account proofs, and returns a sealed copy of the payload.
+// receipts are the payload's receipts, verified against its receipts root.
+func applyPayment(
+	p *engineall.ExecutionPayload,
+	executionRequests []prague.ExecutionRequest,
+	parentBeaconBlockRoot common.Hash,
+	chainID *big.Int,
+	proofs map[common.Address]*execution.AccountProof,
+	receipts []*types.Receipt,
+	tx *types.Transaction,
+) (*engineall.ExecutionPayload, error) {
+	if root := types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)); root != common.Hash(p.ReceiptsRoot) {
+		return nil, fmt.Errorf("receipts root mismatch: EL receipts hash to %s, payload has %s",
+			root.Hex(), common.Hash(p.ReceiptsRoot).Hex())
+	}
+
+	statedb, err := proofState(common.Hash(p.StateRoot), proofs)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := verifiedHeaderFromPayload(p, parentBeaconBlockRoot, executionRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	// A transfer's execution does not depend on the fork between Shanghai and
+	// Osaka; the blob base fee is irrelevant to a non-blob transaction.
+	chainConfig := *params.MergedTestChainConfig
+	chainConfig.ChainID = chainID
+
+	evmHeader := types.CopyHeader(header)
+	evmHeader.ExcessBlobGas = nil
+
+	coinbase := header.Coinbase
+	evm := vm.NewEVM(core.NewEVMBlockContext(evmHeader, nil, &coinbase), statedb, &chainConfig, vm.Config{})
+
+	txIndex := len(p.Transactions)
+	statedb.SetTxContext(tx.Hash(), txIndex, uint32(txIndex+1))
+
+	receipt, _, err := core.ApplyTransaction(evm, core.NewGasPool(p.GasLimit-p.GasUsed), statedb, header, tx)
+	if err != nil {
+		return nil, fmt.Errorf("payment execution failed: %w", err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errors.New("payment execution reverted")
+	}
+
+	stateRoot := statedb.IntermediateRoot(true)
+	if err := statedb.Error(); err != nil {
+		return nil, fmt.Errorf("payment touched state outside the proofs: %w", err)
+	}
+
+	receipt.CumulativeGasUsed = p.GasUsed + receipt.GasUsed
+	receipts = append(receipts, receipt)
+
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payment: %w", err)
+	}
+
+	sealed := *p
+	sealed.Transactions = append(append(make([]paris.Transaction, 0, len(p.Transactions)+1),
+		p.Transactions...), txBytes)
+	sealed.StateRoot = paris.Hash32(stateRoot)
+	sealed.ReceiptsRoot = paris.Hash32(types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)))
+	sealed.GasUsed = receipt.CumulativeGasUsed
+
+	for i := range sealed.LogsBloom {
+		sealed.LogsBloom[i] |= receipt.Bloom[i]
+	}
+
+	// The sealed header is the verified original with the changed fields, so
+	// it keeps the original's requests hash variant.
+	sealedHeader := types.CopyHeader(header)
+	sealedHeader.Root = stateRoot
+	sealedHeader.ReceiptHash = common.Hash(sealed.ReceiptsRoot)
+	sealedHeader.Bloom = types.Bloom(sealed.LogsBloom)
+	sealedHeader.GasUsed = sealed.GasUsed
+
+	txs := make(types.Transactions, 0, len(sealed.Transactions))
+	for i, raw := range sealed.Transactions {
+		decoded := new(types.Transaction)
+		if err := decoded.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+
+		txs = append(txs, decoded)
+	}
+
+	sealedHeader.TxHash = types.DeriveSha(txs, trie.NewStackTrie(nil))
+	sealed.BlockHash = paris.Hash32(sealedHeader.Hash())
+
+	return &sealed, nil
+}
+
+// proofState returns a state over root backed only by the proven trie nodes:
+// reads outside the proven paths fail (surfaced through StateDB.Error), and
+// the proofs are checked against root by the node hashes themselves.
+func proofState(root common.Hash, proofs map[common.Address]*execution.AccountProof) (*state.StateDB, error) {
+	memdb := rawdb.NewMemoryDatabase()
+
+	for _, proof := range proofs {
+		for _, node := range proof.AccountProof {
+			rawdb.WriteLegacyTrieNode(memdb, crypto.Keccak256Hash(node), node)
+		}
+	}
+
+	statedb, err := state.New(root, state.NewMPTDatabase(triedb.NewDatabase(memdb, triedb.HashDefaults), state.NewCodeDB(memdb)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open proof state: %w", err)
+	}
+
+	for address, proof := range proofs {
+		balance := statedb.GetBalance(address)
+		if err := statedb.Error(); err != nil {
+			return nil, fmt.Errorf("invalid proof of %s: %w", address.Hex(), err)
+		}
+
+		want, overflow := uint256.FromBig(proof.Balance.ToInt())
+		if overflow || balance.Cmp(want) != 0 || statedb.GetNonce(address) != uint64(proof.Nonce) {
+			return nil, fmt.Errorf("proof of %s does not match the payload state", address.Hex())
+		}
+	}
+
+	return statedb, nil
+}
+
+// blobVersionedHashes returns the versioned hashes of the payload's blob
+// transactions, in order (engine_newPayload's expected hashes).
+func blobVersionedHashes(p *engineall.ExecutionPayload) ([]paris.Hash32, error) {
+	hashes := []paris.Hash32{}
+
+	for i, txBytes := range p.Transactions {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+
+		for _, hash := range tx.BlobHashes() {
+			hashes = append(hashes, paris.Hash32(hash))
+		}
+	}
+
+	return hashes, nil
+}
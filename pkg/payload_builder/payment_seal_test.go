@@ -0,0 +1,408 @@
+package payload_builder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	engineall "github.com/ethpandaops/go-eth-engine-client/spec/all"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth-engine-client/spec/shanghai"
+	enginev "github.com/ethpandaops/go-eth-engine-client/spec/version"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
+	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
+)
+
+// proofNodes collects the trie nodes of a proof.
+type proofNodes []hexutil.Bytes
+
+func (n *proofNodes) Put(_ []byte, value []byte) error {
+	*n = append(*n, common.CopyBytes(value))
+	return nil
+}
+
+func (n *proofNodes) Delete([]byte) error { return nil }
+
+// paymentTestEL stands in for the EL: it imports every payload as VALID and
+// serves proofs of a real state as the post-state of any block.
+type paymentTestEL struct {
+	db       *state.MPTDatabase
+	root     common.Hash
+	chainID  *big.Int
+	imported []common.Hash
+}
+
+func newPaymentTestEL(t *testing.T, alloc map[common.Address]types.Account) *paymentTestEL {
+	t.Helper()
+
+	memdb := rawdb.NewMemoryDatabase()
+	db := state.NewMPTDatabase(triedb.NewDatabase(memdb, triedb.HashDefaults), state.NewCodeDB(memdb))
+
+	statedb, err := state.New(types.EmptyRootHash, db)
+	require.NoError(t, err)
+
+	for address, account := range alloc {
+		statedb.SetBalance(address, uint256.MustFromBig(account.Balance), tracing.BalanceChangeUnspecified)
+		statedb.SetNonce(address, account.Nonce, tracing.NonceChangeUnspecified)
+		statedb.SetCode(address, account.Code, tracing.CodeChangeUnspecified)
+	}
+
+	root, err := statedb.Commit(0, true, false)
+	require.NoError(t, err)
+
+	return &paymentTestEL{db: db, root: root, chainID: big.NewInt(1337)}
+}
+
+func (el *paymentTestEL) NewPayloadAgnostic(
+	_ context.Context, request *engineall.NewPayloadRequest,
+) (*paris.PayloadStatus, error) {
+	el.imported = append(el.imported, common.Hash(request.ExecutionPayload.BlockHash))
+	return &paris.PayloadStatus{Status: paris.PayloadValidationStatusValid}, nil
+}
+
+func (el *paymentTestEL) GetChainID(context.Context) (*big.Int, error) {
+	return el.chainID, nil
+}
+
+func (el *paymentTestEL) GetProof(
+	_ context.Context, address common.Address, _ common.Hash,
+) (*execution.AccountProof, error) {
+	tr, err := el.db.OpenTrie(el.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes proofNodes
+	if err := tr.Prove(crypto.Keccak256(address.Bytes()), &nodes); err != nil {
+		return nil, err
+	}
+
+	statedb, err := state.New(el.root, el.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution.AccountProof{
+		Address:      address,
+		AccountProof: nodes,
+		Balance:      (*hexutil.Big)(statedb.GetBalance(address).ToBig()),
+		CodeHash:     statedb.GetCodeHash(address),
+		Nonce:        hexutil.Uint64(statedb.GetNonce(address)),
+	}, nil
+}
+
+func (el *paymentTestEL) GetBlockReceipts(context.Context, common.Hash) ([]*types.Receipt, error) {
+	return []*types.Receipt{}, nil
+}
+
+type paymentTestSigner struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+
+	reserveErr error // returned by ReserveNonce
+	reserved   []uint64
+	released   int
+}
+
+func (s *paymentTestSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *paymentTestSigner) SignTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, types.NewCancunSigner(s.chainID), s.key)
+}
+
+func (s *paymentTestSigner) ReserveNonce(_ context.Context, nonce uint64, _ time.Time) (func(), error) {
+	if s.reserveErr != nil {
+		return nil, s.reserveErr
+	}
+
+	s.reserved = append(s.reserved, nonce)
+
+	return func() { s.released++ }, nil
+}
+
+// emptyPaymentPayload returns an empty Cancun payload over stateRoot with a
+// consistent block hash.
+func emptyPaymentPayload(t *testing.T, stateRoot common.Hash, coinbase common.Address) *engineall.ExecutionPayload {
+	t.Helper()
+
+	p := &engineall.ExecutionPayload{
+		Version:       enginev.DataVersionCancun,
+		ParentHash:    paris.Hash32{0x01},
+		FeeRecipient:  paris.Address(coinbase),
+		StateRoot:     paris.Hash32(stateRoot),
+		ReceiptsRoot:  paris.Hash32(types.EmptyReceiptsHash),
+		BlockNumber:   100,
+		GasLimit:      30_000_000,
+		Timestamp:     1_700_000_000,
+		BaseFeePerGas: uint256.NewInt(7 * params.GWei),
+		Withdrawals:   []*shanghai.Withdrawal{},
+	}
+
+	header, err := buildHeaderFromPayload(p, common.Hash{0x02}, nil)
+	require.NoError(t, err)
+
+	p.BlockHash = paris.Hash32(header.Hash())
+
+	return p
+}
+
+func TestSealPayment(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000fe")
+	value := big.NewInt(params.Ether / 100)
+
+	el := newPaymentTestEL(t, map[common.Address]types.Account{
+		sender:   {Balance: big.NewInt(params.Ether), Nonce: 3},
+		coinbase: {Balance: big.NewInt(params.Ether)},
+	})
+
+	p := emptyPaymentPayload(t, el.root, coinbase)
+	original := common.Hash(p.BlockHash)
+
+	signer := &paymentTestSigner{key: key, chainID: el.chainID}
+	sealer := NewPaymentSealer(el, el, signer, logrus.New())
+
+	sealed, err := sealer.SealPayment(context.Background(), p, nil, common.Hash{0x02}, recipient, value, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3}, signer.reserved, "the payment nonce is reserved through the wallet")
+	assert.Zero(t, signer.released)
+
+	// The expected post-state, computed directly on the full state: the
+	// sender pays value plus the burnt base fee, the coinbase gets no tip.
+	expected, err := state.New(el.root, el.db)
+	require.NoError(t, err)
+
+	fee := new(big.Int).Mul(big.NewInt(21_000), big.NewInt(7*params.GWei))
+	expected.SubBalance(sender, uint256.MustFromBig(new(big.Int).Add(value, fee)), tracing.BalanceChangeUnspecified)
+	expected.SetNonce(sender, 4, tracing.NonceChangeUnspecified)
+	expected.AddBalance(recipient, uint256.MustFromBig(value), tracing.BalanceChangeUnspecified)
+
+	assert.Equal(t, expected.IntermediateRoot(true), common.Hash(p.StateRoot))
+	assert.Equal(t, uint64(21_000), p.GasUsed)
+	require.Len(t, p.Transactions, 1)
+
+	tx := new(types.Transaction)
+	require.NoError(t, tx.UnmarshalBinary(p.Transactions[0]))
+	assert.Equal(t, recipient, *tx.To())
+	assert.Equal(t, value, tx.Value())
+	assert.Equal(t, uint64(3), tx.Nonce())
+	assert.Equal(t, tx.Hash(), sealed.TxHash)
+
+	// The sealed block hash commits to the new header and was imported too.
+	_, err = verifiedHeaderFromPayload(p, common.Hash{0x02}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sealed.BlockHash, common.Hash(p.BlockHash))
+	assert.NotEqual(t, original, sealed.BlockHash)
+	assert.Equal(t, []common.Hash{original, sealed.BlockHash}, el.imported)
+}
+
+func TestSealPayment_Refused(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	contract := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000fe")
+
+	el := newPaymentTestEL(t, map[common.Address]types.Account{
+		sender:   {Balance: big.NewInt(params.Ether)},
+		contract: {Balance: big.NewInt(0), Code: []byte{0x00}},
+	})
+	sealer := NewPaymentSealer(el, el, &paymentTestSigner{key: key, chainID: el.chainID}, logrus.New())
+
+	tests := []struct {
+		name      string
+		modify    func(p *engineall.ExecutionPayload)
+		recipient common.Address
+		value     *big.Int
+		errSubstr string
+	}{
+		{
+			name:      "recipient has code",
+			recipient: contract,
+			value:     big.NewInt(1),
+			errSubstr: "has code",
+		},
+		{
+			name:      "sender balance too low",
+			recipient: recipient,
+			value:     big.NewInt(params.Ether),
+			errSubstr: "cannot cover",
+		},
+		{
+			name: "withdrawal credited to the sender does not count",
+			modify: func(p *engineall.ExecutionPayload) {
+				p.Withdrawals = []*shanghai.Withdrawal{{Address: paris.Address(sender), Amount: 1e9 / 2}}
+			},
+			recipient: recipient,
+			value:     big.NewInt(params.Ether / 2),
+			errSubstr: "cannot cover",
+		},
+		{
+			name: "no gas left",
+			modify: func(p *engineall.ExecutionPayload) {
+				p.GasUsed = p.GasLimit - 20_000
+			},
+			recipient: recipient,
+			value:     big.NewInt(1),
+			errSubstr: "no gas left",
+		},
+		{
+			name: "block access list",
+			modify: func(p *engineall.ExecutionPayload) {
+				p.Version = enginev.DataVersionAmsterdam
+			},
+			recipient: recipient,
+			value:     big.NewInt(1),
+			errSubstr: "block access lists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := emptyPaymentPayload(t, el.root, coinbase)
+			if tt.modify != nil {
+				tt.modify(p)
+			}
+
+			before := *p
+
+			_, err := sealer.SealPayment(context.Background(), p, nil, common.Hash{0x02}, tt.recipient, tt.value, time.Time{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errSubstr)
+			assert.Equal(t, before, *p, "a refused payload must stay unsealed")
+		})
+	}
+}
+
+// TestSealPayment_NonceInFlight asserts no payment is sealed at a nonce the
+// wallet cannot reserve (a wallet transaction is in flight at it).
+func TestSealPayment_NonceInFlight(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+
+	el := newPaymentTestEL(t, map[common.Address]types.Account{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(params.Ether)},
+	})
+
+	signer := &paymentTestSigner{key: key, chainID: el.chainID, reserveErr: errors.New("wallet transaction in flight")}
+	sealer := NewPaymentSealer(el, el, signer, logrus.New())
+
+	p := emptyPaymentPayload(t, el.root, coinbase)
+	before := *p
+
+	_, err = sealer.SealPayment(context.Background(), p, nil, common.Hash{0x02},
+		common.HexToAddress("0xfe"), big.NewInt(1), time.Time{})
+	require.ErrorContains(t, err, "payment nonce unavailable")
+	assert.Equal(t, before, *p)
+	assert.Len(t, el.imported, 1, "only the unsealed payload was imported")
+}
+
+// sealChainService places the proposal slot relative to a fake clock.
+type sealChainService struct {
+	chain.Service
+
+	clk       *clock.Fake
+	slotStart time.Time // start of slot 0
+}
+
+func (s *sealChainService) Clock() clock.Clock { return s.clk }
+
+func (s *sealChainService) SlotToTime(slot phase0.Slot) time.Time {
+	return s.slotStart.Add(time.Duration(slot) * 12 * time.Second)
+}
+
+// TestSealPayment_SkippedNearSlotStart asserts the seal's engine and RPC
+// round-trips are not started when the proposal slot is about to begin.
+func TestSealPayment_SkippedNearSlotStart(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+
+	el := newPaymentTestEL(t, map[common.Address]types.Account{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(params.Ether)},
+	})
+
+	clk := clock.NewFake(time.Unix(1_700_000_000, 0))
+	builder := &PayloadBuilder{
+		chainSvc: &sealChainService{clk: clk, slotStart: clk.Now().Add(paymentSealMinTime / 2)},
+		sealer:   NewPaymentSealer(el, el, &paymentTestSigner{key: key, chainID: el.chainID}, logrus.New()),
+	}
+
+	p := emptyPaymentPayload(t, el.root, coinbase)
+
+	_, err = builder.sealPayment(context.Background(), 0, p, nil, common.Hash{0x02}, common.HexToAddress("0xfe"), big.NewInt(1))
+	require.ErrorContains(t, err, "too close to the slot start")
+	assert.Empty(t, el.imported)
+
+	sealed, err := builder.sealPayment(context.Background(), 1, p, nil, common.Hash{0x02}, common.HexToAddress("0xfe"), big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, sealed.BlockHash, common.Hash(p.BlockHash))
+}
+
+func TestApplyPayment_StateOutsideProofs(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000fe")
+
+	el := newPaymentTestEL(t, map[common.Address]types.Account{
+		sender:   {Balance: big.NewInt(params.Ether)},
+		coinbase: {Balance: big.NewInt(params.Ether)},
+	})
+	p := emptyPaymentPayload(t, el.root, coinbase)
+
+	senderProof, err := el.GetProof(context.Background(), sender, common.Hash{})
+	require.NoError(t, err)
+
+	recipientProof, err := el.GetProof(context.Background(), recipient, common.Hash{})
+	require.NoError(t, err)
+
+	signer := &paymentTestSigner{key: key, chainID: el.chainID}
+	tx, err := signer.SignTransaction(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   el.chainID,
+		GasFeeCap: p.BaseFeePerGas.ToBig(),
+		Gas:       params.TxGas,
+		To:        &recipient,
+		Value:     big.NewInt(1),
+	}))
+	require.NoError(t, err)
+
+	// Without the coinbase proof the fee credit cannot be applied.
+	proofs := map[common.Address]*execution.AccountProof{sender: senderProof, recipient: recipientProof}
+
+	_, err = applyPayment(p, nil, common.Hash{0x02}, el.chainID, proofs, []*types.Receipt{}, tx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the proofs")
+}
@@ -3,11 +3,13 @@ package payload_builder
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethpandaops/go-eth-engine-client/spec/identification"
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
@@ -45,6 +47,12 @@ type Service struct {
 	engineClient           EngineClient
 	feeRecipient           common.Address
 	settingsResolvers      []ProposerSettingsResolver // ordered proposer-settings sources (register before Start)
+	paymentSealer          *PaymentSealer             // pre-Gloas proposer payment (register before Start); nil disables
+	raceEngines            []EngineEndpoint           // engines raced against engineClient (register before Start)
+	heads                  HeadSource                 // EL new-heads source (register before Start); nil disables
+	txInjector             *TxInjector                // injected transactions (register before Start); nil disables
+	blobStuffer            *BlobStuffer               // blob stuffing (register before Start); nil disables
+	parentHeaders          ParentHeaderReader         // parent gas limits for gas limit adjustment (register before Start); nil disables
 	payloadBuilder         *PayloadBuilder
 	payloadCache           *PayloadCache
 	payloadReadyDispatcher *utils.Dispatcher[*Payload]
@@ -67,13 +75,15 @@ type Service struct {
 
 	// Build tracking
 	scheduledBuildMu  sync.Mutex
-	buildStartedSlots map[phase0.Slot]bool // Slots where building has started (to prevent re-building)
-	skipFiredSlots    map[phase0.Slot]bool // Slots a BuildSkippedEvent was fired for (dedup per slot)
-	attrFallbackArmed map[phase0.Slot]bool // Slots a missing-attributes fallback check is armed for
+	buildStartedSlots map[phase0.Slot]bool     // Slots where building has started (to prevent re-building)
+	skipFiredSlots    map[phase0.Slot]bool     // Slots a BuildSkippedEvent was fired for (dedup per slot)
+	attrFallbackArmed map[phase0.Slot]bool     // Slots a missing-attributes fallback check is armed for
+	rebuildingSlots   map[phase0.Slot]bool     // Slots with a stale-payload rebuild in flight
+	headMovedPayloads map[phase0.Slot]*Payload // Payloads withdrawn on an EL head move, awaiting new attributes
 
-	// Payload inclusion tracking (deduplication between detection methods)
-	wonPayloadsMu sync.Mutex
-	wonPayloads   map[phase0.Hash32]phase0.Slot
+	// Parent candidate tracking (guarded by scheduledBuildMu)
+	candidateParents map[phase0.Slot]map[phase0.Hash32]bool // Parents a candidate build was scheduled for
+	buildParents     map[phase0.Slot]map[phase0.Hash32]bool // Parents a build (latest or candidate) started on
 
 	// lastBuiltSlot tracks the most recently built slot (WebUI status).
 	lastBuiltSlot atomic.Uint64
@@ -113,7 +123,7 @@ func NewService(
 		clClient:               clClient,
 		chainSvc:               chainSvc,
 		planSvc:                planSvc,
-		engineClient:           engineClient,
+		engineClient:           &instrumentedEngineClient{EngineClient: engineClient},
 		feeRecipient:           feeRecipient,
 		payloadCache:           NewPayloadCache(DefaultCacheSize),
 		payloadReadyDispatcher: &utils.Dispatcher[*Payload]{},
@@ -125,7 +135,10 @@ func NewService(
 		buildStartedSlots:      make(map[phase0.Slot]bool),
 		skipFiredSlots:         make(map[phase0.Slot]bool, 16),
 		attrFallbackArmed:      make(map[phase0.Slot]bool, 16),
-		wonPayloads:            make(map[phase0.Hash32]phase0.Slot, 16),
+		rebuildingSlots:        make(map[phase0.Slot]bool, 4),
+		headMovedPayloads:      make(map[phase0.Slot]*Payload, 4),
+		candidateParents:       make(map[phase0.Slot]map[phase0.Hash32]bool, 4),
+		buildParents:           make(map[phase0.Slot]map[phase0.Hash32]bool, 4),
 	}
 
 	return s, nil
@@ -145,6 +158,24 @@ func (s *Service) Start(ctx context.Context) error {
 		s.log,
 		s.settingsResolvers,
 	)
+	s.payloadBuilder.sealer = s.paymentSealer
+	s.payloadBuilder.raceEngines = s.raceEngines
+	s.payloadBuilder.injector = s.txInjector
+	s.payloadBuilder.faultsFor = func(slot phase0.Slot) []string {
+		return s.planSvc.Freeze(slot).Build.Faults
+	}
+	s.payloadBuilder.parentHeaders = s.parentHeaders
+	s.payloadBuilder.gasLimitFor = func(slot phase0.Slot) (uint64, bool) {
+		build := s.planSvc.Freeze(slot).Build
+		return build.GasLimit, build.GasLimitViolate
+	}
+
+	if s.blobStuffer != nil {
+		s.payloadBuilder.stuffer = s.blobStuffer
+		s.payloadBuilder.blobStuffingFor = func(slot phase0.Slot) string {
+			return s.planSvc.Freeze(slot).Build.BlobStuffing
+		}
+	}
 
 	// Start event stream
 	if err := s.clClient.Events().Start(s.ctx); err != nil {
@@ -254,7 +285,10 @@ func (s *Service) GetStats() BuilderStats {
 	s.statsMu.RLock()
 	defer s.statsMu.RUnlock()
 
-	return *s.stats
+	stats := *s.stats
+	stats.EngineWins = maps.Clone(s.stats.EngineWins)
+
+	return stats
 }
 
 // GetConfig returns the current configuration.
@@ -333,63 +367,38 @@ func (s *Service) GetPayloadCache() *PayloadCache {
 func (s *Service) run() {
 	defer s.wg.Done()
 
-	headSub := s.clClient.Events().SubscribeHead()
 	payloadAttrSub := s.clClient.Events().SubscribePayloadAttributes()
 	payloadSub := s.clClient.Events().SubscribePayloadAvailable()
 
-	defer headSub.Unsubscribe()
 	defer payloadAttrSub.Unsubscribe()
 	defer payloadSub.Unsubscribe()
 
+	// A nil channel never fires: without an EL head source only the beacon
+	// events drive builds.
+	var headCh <-chan *types.Header
+
+	if s.heads != nil {
+		headSub := s.heads.Subscribe(16)
+		defer headSub.Unsubscribe()
+
+		headCh = headSub.Channel()
+	}
+
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
 
-		case event := <-headSub.Channel():
-			s.handleHeadEvent(event)
-
 		case event := <-payloadAttrSub.Channel():
 			s.handlePayloadAttributesEvent(event)
 
 		case event := <-payloadSub.Channel():
 			s.handlePayloadAvailableEvent(event)
-		}
-	}
-}
-
-// handleHeadEvent processes a head event (new block received).
-// Checks if the block's execution payload matches one of our built payloads.
-func (s *Service) handleHeadEvent(event *beacon.HeadEvent) {
-	s.log.WithFields(logrus.Fields{
-		"head_slot": event.Slot,
-		"fork":      s.chainSvc.GetCurrentFork().String(),
-	}).Debug("Head event received")
-
-	go s.checkPayloadInclusion(event)
-}
-
-// checkPayloadInclusion fetches the block info and checks if its execution
-// payload hash matches any of our built payloads.
-func (s *Service) checkPayloadInclusion(event *beacon.HeadEvent) {
-	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
-	defer cancel()
-
-	blockInfo, err := s.clClient.GetBlockInfo(ctx, fmt.Sprintf("0x%x", event.Block[:]))
-	if err != nil {
-		s.log.WithError(err).WithField("slot", event.Slot).Debug(
-			"Failed to get block info for payload inclusion check",
-		)
 
-		return
-	}
-
-	payload := s.payloadCache.GetByBlockHash(blockInfo.ExecutionBlockHash)
-	if payload == nil {
-		return
+		case head := <-headCh:
+			s.handleELHead(head)
+		}
 	}
-
-	s.markPayloadWon(blockInfo.ExecutionBlockHash, payload.Attributes.ProposalSlot)
 }
 
 // handlePayloadAttributesEvent processes a payload_attributes event.
@@ -404,12 +413,6 @@ func (s *Service) handlePayloadAttributesEvent(event *beacon.PayloadAttributesEv
 		"withdrawals":   len(event.Withdrawals),
 	}).Info("Payload attributes event received")
 
-	// Check if the parent block hash matches one of our built payloads
-	// (this means our payload was included as the parent of this new block).
-	if payload := s.payloadCache.GetByBlockHash(event.ParentBlockHash); payload != nil {
-		s.markPayloadWon(event.ParentBlockHash, payload.Attributes.ProposalSlot)
-	}
-
 	// Arm the missing-block fallback for the NEXT proposal slot: if its
 	// block goes missing entirely, some clients never emit fresh attributes
 	// and this slot's attributes get re-used instead.
@@ -431,10 +434,16 @@ func (s *Service) handlePayloadAttributesEvent(event *beacon.PayloadAttributesEv
 		return
 	}
 
+	// Unstable chain: keep a candidate payload for every parent the slot's
+	// attributes name (bounded by --builder-api-parent-candidates).
+	s.scheduleCandidateBuild(event, frozen)
+
 	// Check if already scheduled/building/built for this slot
 	s.scheduledBuildMu.Lock()
 	if s.buildStartedSlots[event.ProposalSlot] {
 		s.scheduledBuildMu.Unlock()
+		s.checkStalePayload(event, frozen)
+
 		return
 	}
 	s.buildStartedSlots[event.ProposalSlot] = true
@@ -443,6 +452,95 @@ func (s *Service) handlePayloadAttributesEvent(event *beacon.PayloadAttributesEv
 	s.scheduleBuildForSlot(event.ProposalSlot, frozen.Build.BuildStartTimeMs)
 }
 
+// checkStalePayload rebuilds an already built slot when newer
+// payload_attributes arrived for it (e.g. a late block or a reorg after our
+// build moved the parent, or changed the withdrawals). The stale payload is
+// withdrawn at once so it is neither bid nor served any longer: a payload on
+// an outdated parent stays servable for that parent, any other change
+// invalidates it. The rebuild supersedes it if it still fits the slot's build
+// budget; the p2p scheduler then bids the rebuilt payload (replacing the bid
+// committing to the stale one with epbs.replace_stale_bids). A build still in
+// flight re-checks the attributes itself when it finishes. Parent-reorg
+// builds are never rebuilt (their parent deliberately differs from the
+// attributes).
+func (s *Service) checkStalePayload(event *beacon.PayloadAttributesEvent, frozen *action_plan.FrozenPlan) {
+	if frozen.Build.ReorgParentPayload {
+		return
+	}
+
+	slot := event.ProposalSlot
+
+	// A payload withdrawn on an EL head move (handleELHead) is rebuilt here
+	// once the attributes for the new head arrive.
+	cached, withdrawn := s.payloadCache.Get(slot), false
+	if cached == nil {
+		if cached = s.takeHeadMovedPayload(slot); cached == nil {
+			// Still building (or already withdrawn).
+			return
+		}
+
+		withdrawn = true
+	}
+
+	changed := changedAttributes(cached.Attributes, event)
+	if len(changed) == 0 {
+		if withdrawn {
+			// The EL head moved back: the payload is current again.
+			s.payloadCache.Store(cached)
+		}
+
+		return
+	}
+
+	s.scheduledBuildMu.Lock()
+	if s.rebuildingSlots[slot] {
+		s.scheduledBuildMu.Unlock()
+
+		return
+	}
+
+	s.rebuildingSlots[slot] = true
+	s.scheduledBuildMu.Unlock()
+
+	switch {
+	case withdrawn:
+	case cached.Attributes.ParentBlockHash != event.ParentBlockHash:
+		s.payloadCache.Supersede(slot, cached.BlockHash)
+	default:
+		s.payloadCache.Invalidate(slot, cached.BlockHash)
+	}
+
+	log := s.log.WithFields(logrus.Fields{
+		"slot":         slot,
+		"stale_hash":   fmt.Sprintf("%x", cached.BlockHash[:8]),
+		"stale_parent": fmt.Sprintf("%x", cached.Attributes.ParentBlockHash[:8]),
+		"parent_hash":  fmt.Sprintf("%x", event.ParentBlockHash[:8]),
+		"changed":      changed,
+	})
+
+	if !s.canRebuild(slot) {
+		s.scheduledBuildMu.Lock()
+		delete(s.rebuildingSlots, slot)
+		s.scheduledBuildMu.Unlock()
+
+		log.Warn("Payload attributes changed after build, no build time left: stale payload withdrawn")
+
+		return
+	}
+
+	log.Warn("Payload attributes changed after build, rebuilding stale payload")
+
+	go func() {
+		defer func() {
+			s.scheduledBuildMu.Lock()
+			delete(s.rebuildingSlots, slot)
+			s.scheduledBuildMu.Unlock()
+		}()
+
+		s.executeBuildForSlot(slot, cached.BlockHash)
+	}()
+}
+
 // fireBuildSkipped emits a BuildSkippedEvent (once per slot) when the skip is
 // worth recording: a plan exists for the slot or a consumer is effectively
 // active (build.PlanInvolved), so a results tracker can explain why no
@@ -513,7 +611,8 @@ func (s *Service) scheduleAttributesFallback(targetSlot phase0.Slot) {
 	fireAt := s.chainSvc.SlotToTime(targetSlot).
 		Add(time.Duration(s.cfg.EPBS.BuildStartTime) * time.Millisecond)
 
-	time.AfterFunc(max(time.Until(fireAt), 0), func() {
+	clk := s.chainSvc.Clock()
+	clk.AfterFunc(max(clk.Until(fireAt), 0), func() {
 		s.applyAttributesFallback(targetSlot)
 	})
 }
@@ -585,7 +684,7 @@ func (s *Service) applyAttributesFallback(targetSlot phase0.Slot) {
 func (s *Service) scheduleBuildForSlot(slot phase0.Slot, buildStartMs int64) {
 	slotStart := s.chainSvc.SlotToTime(slot)
 	buildTime := slotStart.Add(time.Duration(buildStartMs) * time.Millisecond)
-	delay := time.Until(buildTime)
+	delay := s.chainSvc.Clock().Until(buildTime)
 
 	if delay <= 0 {
 		// Build time already passed – build immediately.
@@ -594,7 +693,7 @@ func (s *Service) scheduleBuildForSlot(slot phase0.Slot, buildStartMs int64) {
 			"delay_ms": delay.Milliseconds(),
 		}).Debug("Build start time already passed, building immediately")
 
-		go s.executeBuildForSlot(slot)
+		go s.executeBuildForSlot(slot, phase0.Hash32{})
 
 		return
 	}
@@ -604,14 +703,15 @@ func (s *Service) scheduleBuildForSlot(slot phase0.Slot, buildStartMs int64) {
 		"delay_ms": delay.Milliseconds(),
 	}).Info("Scheduling build for slot")
 
-	time.AfterFunc(delay, func() {
-		s.executeBuildForSlot(slot)
+	s.chainSvc.Clock().AfterFunc(delay, func() {
+		s.executeBuildForSlot(slot, phase0.Hash32{})
 	})
 }
 
 // executeBuildForSlot fetches the latest cached payload_attributes for the
-// given slot and performs payload building.
-func (s *Service) executeBuildForSlot(slot phase0.Slot) {
+// given slot and performs payload building. supersedes is the block hash of
+// the stale payload a rebuild replaces (zero for the slot's first build).
+func (s *Service) executeBuildForSlot(slot phase0.Slot, supersedes phase0.Hash32) {
 	event := s.clClient.Events().GetLatestPayloadAttributes(slot)
 	if event == nil {
 		s.log.WithField("slot", slot).Warn(
@@ -620,6 +720,22 @@ func (s *Service) executeBuildForSlot(slot phase0.Slot) {
 		return
 	}
 
+	s.executeBuild(slot, event, supersedes, nil)
+}
+
+// executeBuild builds the slot's payload from event. A finished payload whose
+// attributes were replaced by newer ones during the build is dropped and
+// rebuilt; behind are attributes known to lag behind event (the EL head
+// rebuild of handleELHead builds ahead of the beacon node's attributes) and
+// do not make the payload stale.
+func (s *Service) executeBuild(
+	slot phase0.Slot,
+	event *beacon.PayloadAttributesEvent,
+	supersedes phase0.Hash32,
+	behind *beacon.PayloadAttributesEvent,
+) {
+	s.markBuildParent(slot, event.ParentBlockHash)
+
 	s.log.WithFields(logrus.Fields{
 		"slot":        event.ProposalSlot,
 		"parent_hash": fmt.Sprintf("%x", event.ParentBlockHash[:8]),
@@ -630,6 +746,7 @@ func (s *Service) executeBuildForSlot(slot phase0.Slot) {
 	// so, we build from an effective attributes copy whose parent fields point
 	// at the grandparent, so the build, the stored payload and the bid all
 	// agree on the parent.
+	attrs := event
 	event = s.effectiveBuildAttributes(slot, event)
 
 	// Notify subscribers that building has started so the build can be rendered
@@ -663,6 +780,22 @@ func (s *Service) executeBuildForSlot(slot phase0.Slot) {
 		return
 	}
 
+	if payloadEvent.WithdrawalsDivergence != "" {
+		s.incrementStat(func(stats *BuilderStats) {
+			stats.WithdrawalMismatches++
+		})
+	}
+
+	if payloadEvent.Engine != "" {
+		s.incrementStat(func(stats *BuilderStats) {
+			if stats.EngineWins == nil {
+				stats.EngineWins = make(map[string]uint64)
+			}
+
+			stats.EngineWins[payloadEvent.Engine]++
+		})
+	}
+
 	// Apply the slot's frozen payload transform (if any) before the payload
 	// feeds the bid commitment and the envelope reveal.
 	if err := s.applyPayloadTransform(ctx, slot, payloadEvent); err != nil {
@@ -677,9 +810,47 @@ func (s *Service) executeBuildForSlot(slot phase0.Slot) {
 		return
 	}
 
+	// The attributes may have changed while we were building: the payload is
+	// stale before it was ever served. Rebuild right away while the budget
+	// allows, never publish it.
+	if latest := s.clClient.Events().GetLatestPayloadAttributes(slot); latest != nil && latest != behind {
+		if changed := changedAttributes(attrs, latest); len(changed) > 0 {
+			s.handleStaleBuild(slot, supersedes, changed)
+			return
+		}
+	}
+
+	payloadEvent.Supersedes = supersedes
+
 	s.emitPayloadReady(slot, payloadEvent)
 }
 
+// handleStaleBuild handles a finished build whose payload_attributes changed
+// during the build: the payload is dropped and the slot rebuilt from the
+// latest attributes, or the build reported failed when no build time is left.
+func (s *Service) handleStaleBuild(slot phase0.Slot, supersedes phase0.Hash32, changed []string) {
+	log := s.log.WithFields(logrus.Fields{
+		"slot":    slot,
+		"changed": changed,
+	})
+
+	if !s.canRebuild(slot) {
+		log.Warn("Payload attributes changed during build, no build time left: payload dropped")
+
+		s.buildFailedDispatcher.Fire(&PayloadBuildFailedEvent{
+			Slot:     slot,
+			Error:    "payload attributes changed during the build and no build time was left to rebuild",
+			FailedAt: time.Now(),
+		})
+
+		return
+	}
+
+	log.Warn("Payload attributes changed during build, rebuilding")
+
+	s.executeBuildForSlot(slot, supersedes)
+}
+
 // applyPayloadTransform rewrites the built execution payload with the slot's
 // frozen jq payload transform (idempotent Freeze), in place. Because the bid
 // commits to the payload's block hash, Payload.BlockHash is re-synced to the
@@ -816,6 +987,11 @@ func (s *Service) emitPayloadReady(slot phase0.Slot, payloadEvent *Payload) {
 		"parent_block_hash": fmt.Sprintf("%x", payloadEvent.Attributes.ParentBlockHash[:8]),
 	}).Info("Payload built and dispatched")
 
+	if payloadEvent.Supersedes != (phase0.Hash32{}) {
+		// Stale-payload rebuild: the slot was already accounted for.
+		return
+	}
+
 	// Mark slot as built (next_n schedule accounting + WebUI status).
 	s.planSvc.OnSlotBuilt(slot)
 	s.lastBuiltSlot.Store(uint64(slot))
@@ -837,48 +1013,18 @@ func (s *Service) emitPayloadReady(slot phase0.Slot, payloadEvent *Payload) {
 				delete(s.buildStartedSlots, oldSlot)
 			}
 		}
-		s.scheduledBuildMu.Unlock()
 
-		// Cleanup old won payload tracking, keeping the 10 most recent.
-		const keepWonPayloads = 10
-		s.wonPayloadsMu.Lock()
-		for len(s.wonPayloads) > keepWonPayloads {
-			var oldestHash phase0.Hash32
-			var oldestSlot phase0.Slot
-			first := true
-
-			for hash, wonSlot := range s.wonPayloads {
-				if first || wonSlot < oldestSlot {
-					oldestHash = hash
-					oldestSlot = wonSlot
-					first = false
-				}
+		for oldSlot := range s.candidateParents {
+			if oldSlot < cleanupSlot {
+				delete(s.candidateParents, oldSlot)
 			}
-
-			delete(s.wonPayloads, oldestHash)
 		}
-		s.wonPayloadsMu.Unlock()
-	}
-}
 
-// markPayloadWon records a payload as won (included on-chain), deduplicating
-// between the two detection methods (payload_attributes parent hash and head event).
-func (s *Service) markPayloadWon(blockHash phase0.Hash32, slot phase0.Slot) {
-	s.wonPayloadsMu.Lock()
-	if _, ok := s.wonPayloads[blockHash]; ok {
-		s.wonPayloadsMu.Unlock()
-		return
+		for oldSlot := range s.buildParents {
+			if oldSlot < cleanupSlot {
+				delete(s.buildParents, oldSlot)
+			}
+		}
+		s.scheduledBuildMu.Unlock()
 	}
-
-	s.wonPayloads[blockHash] = slot
-	s.wonPayloadsMu.Unlock()
-
-	s.log.WithFields(logrus.Fields{
-		"slot":       slot,
-		"block_hash": fmt.Sprintf("%x", blockHash[:8]),
-	}).Info("Our payload was included on-chain")
-
-	s.incrementStat(func(stats *BuilderStats) {
-		stats.BlocksIncluded++
-	})
 }
@@ -10,6 +10,33 @@ type BuilderStats struct {
 	RevealsSuccess uint64
 	RevealsFailed  uint64
 	RevealsSkipped uint64
+
+	// RevealsSkippedNotIncluded is the subset of RevealsSkipped withheld
+	// because our bid was never confirmed included (reveal.require_inclusion).
+	RevealsSkippedNotIncluded uint64
+
+	// RevealsChaosWithheld / RevealsChaosDelayed count reveals deliberately
+	// withheld / scheduled late by reveal chaos. Like plan suppressions,
+	// withheld reveals are not skips; delayed ones count their outcome as
+	// usual.
+	RevealsChaosWithheld uint64
+	RevealsChaosDelayed  uint64
+
+	// CanaryBidsSubmitted is the subset of BidsSubmitted priced at the
+	// canary bid value (canary mode).
+	CanaryBidsSubmitted uint64
+
+	// WithdrawalMismatches counts builds whose payload_attributes
+	// withdrawals diverged from the beacon node's expected withdrawals.
+	WithdrawalMismatches uint64
+
+	// ELHeadWithdrawals counts payloads withdrawn because the EL head moved
+	// off their parent before the new payload_attributes arrived.
+	ELHeadWithdrawals uint64
+
+	// EngineWins counts, per engine API endpoint, the payload races it won
+	// (--el-engine-race-apis); nil without racing.
+	EngineWins map[string]uint64
 }
 
 // incrementStat safely increments statistics.
@@ -28,6 +55,14 @@ func (s *Service) IncrementBidsSubmitted() {
 	})
 }
 
+// IncrementCanaryBidsSubmitted increments the canary bids counter. Called
+// alongside IncrementBidsSubmitted for bids sent in canary mode.
+func (s *Service) IncrementCanaryBidsSubmitted() {
+	s.incrementStat(func(stats *BuilderStats) {
+		stats.CanaryBidsSubmitted++
+	})
+}
+
 // IncrementBlocksIncluded increments the blocks included and bids won counters.
 // Called by the ePBS service when our payload is included in a beacon block.
 func (s *Service) IncrementBlocksIncluded() {
@@ -51,9 +86,33 @@ func (s *Service) IncrementRevealsFailed() {
 	})
 }
 
+// IncrementRevealsSkippedNotIncluded increments the skipped reveals counter
+// and its bid-not-included share (reveals requiring inclusion whose bid was
+// never confirmed at the head).
+func (s *Service) IncrementRevealsSkippedNotIncluded() {
+	s.incrementStat(func(stats *BuilderStats) {
+		stats.RevealsSkipped++
+		stats.RevealsSkippedNotIncluded++
+	})
+}
+
 // IncrementRevealsSkipped increments the skipped reveals counter.
 func (s *Service) IncrementRevealsSkipped() {
 	s.incrementStat(func(stats *BuilderStats) {
 		stats.RevealsSkipped++
 	})
 }
+
+// IncrementRevealsChaosWithheld increments the chaos-withheld reveals counter.
+func (s *Service) IncrementRevealsChaosWithheld() {
+	s.incrementStat(func(stats *BuilderStats) {
+		stats.RevealsChaosWithheld++
+	})
+}
+
+// IncrementRevealsChaosDelayed increments the chaos-delayed reveals counter.
+func (s *Service) IncrementRevealsChaosDelayed() {
+	s.incrementStat(func(stats *BuilderStats) {
+		stats.RevealsChaosDelayed++
+	})
+}
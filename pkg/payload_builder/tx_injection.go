@@ -0,0 +1,278 @@
+package payload_builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxInjectedTxs bounds the queue of pending injected transactions.
+	maxInjectedTxs = 256
+
+	// injectedTxHistory is the number of finished (included, dropped or
+	// failed) injected transactions kept for the API.
+	injectedTxHistory = 128
+
+	// maxInjectAttempts is the number of builds an injected transaction is
+	// submitted for before it is dropped from the queue.
+	maxInjectAttempts = 8
+)
+
+// Injected transaction states.
+const (
+	InjectedTxPending  = "pending"
+	InjectedTxIncluded = "included"
+	InjectedTxDropped  = "dropped"
+	InjectedTxFailed   = "failed"
+)
+
+// ErrTxQueueFull is returned when the injected transaction queue is full.
+var ErrTxQueueFull = errors.New("injected transaction queue is full")
+
+// TxSender submits a signed transaction to the EL's mempool
+// (eth_sendRawTransaction). *execution.Client satisfies it.
+type TxSender interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// InjectedTx is a raw signed transaction queued for the next built payloads.
+type InjectedTx struct {
+	Hash     common.Hash
+	Type     uint8
+	Nonce    uint64
+	Size     uint64 // encoded size in bytes (with blob sidecar)
+	Blobs    int
+	QueuedAt time.Time
+
+	Status   string // InjectedTx* state
+	Attempts int    // builds the transaction was submitted for
+	Error    string // last submission error
+
+	// IncludedSlot / IncludedBlockHash identify the first built payload
+	// carrying the transaction.
+	IncludedSlot      phase0.Slot
+	IncludedBlockHash phase0.Hash32
+
+	tx *types.Transaction
+}
+
+// TxInjector forces raw signed transactions into built payloads. No engine
+// method adds transactions to a payload build, so queued transactions are
+// submitted to the EL's mempool right before every build's forkchoiceUpdated
+// and picked up by the EL's block building (ordered by its own rules). A
+// transaction stays queued until a built payload (legacy or ePBS) carries it,
+// or is dropped after maxInjectAttempts builds without it.
+//
+// The mempool gossips the transactions, so other builders may include them
+// as well.
+type TxInjector struct {
+	sender TxSender
+	log    logrus.FieldLogger
+
+	mu      sync.Mutex
+	pending []*InjectedTx
+	done    []*InjectedTx // finished transactions, oldest first
+}
+
+// SetTxInjector enables transaction injection into built payloads. Register
+// before Start().
+func (s *Service) SetTxInjector(injector *TxInjector) {
+	s.txInjector = injector
+}
+
+// TxInjector returns the transaction injector, nil when injection is
+// unavailable (no --el-rpc).
+func (s *Service) TxInjector() *TxInjector {
+	return s.txInjector
+}
+
+// NewTxInjector creates a transaction injector submitting through sender.
+func NewTxInjector(sender TxSender, log logrus.FieldLogger) *TxInjector {
+	return &TxInjector{
+		sender: sender,
+		log:    log.WithField("component", "tx-injector"),
+	}
+}
+
+// Queue decodes the raw signed transactions (hex, blob transactions in their
+// network form with sidecar) and queues them for the next builds. Nothing is
+// queued unless all decode and fit the queue.
+func (i *TxInjector) Queue(rawTxs []string) ([]InjectedTx, error) {
+	queued := make([]*InjectedTx, 0, len(rawTxs))
+
+	for idx, raw := range rawTxs {
+		data, err := hexutil.Decode(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: invalid hex: %w", idx, err)
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", idx, err)
+		}
+
+		queued = append(queued, &InjectedTx{
+			Hash:     tx.Hash(),
+			Type:     tx.Type(),
+			Nonce:    tx.Nonce(),
+			Size:     uint64(len(data)),
+			Blobs:    len(tx.BlobHashes()),
+			QueuedAt: time.Now(),
+			Status:   InjectedTxPending,
+			tx:       tx,
+		})
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if len(i.pending)+len(queued) > maxInjectedTxs {
+		return nil, fmt.Errorf("%w (%d pending, max %d)", ErrTxQueueFull, len(i.pending), maxInjectedTxs)
+	}
+
+	i.pending = append(i.pending, queued...)
+
+	result := make([]InjectedTx, len(queued))
+	for idx, tx := range queued {
+		result[idx] = *tx
+	}
+
+	i.log.WithField("count", len(queued)).Info("Transactions queued for injection")
+
+	return result, nil
+}
+
+// Transactions returns the pending transactions followed by the recently
+// finished ones, newest first.
+func (i *TxInjector) Transactions() []InjectedTx {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	result := make([]InjectedTx, 0, len(i.pending)+len(i.done))
+	for _, tx := range i.pending {
+		result = append(result, *tx)
+	}
+
+	for idx := len(i.done) - 1; idx >= 0; idx-- {
+		result = append(result, *i.done[idx])
+	}
+
+	return result
+}
+
+// submit sends the pending transactions to the EL's mempool ahead of the
+// slot's build. A transaction the mempool already holds counts as submitted;
+// one it rejects otherwise (e.g. a nonce already used) fails. Transactions
+// submitted maxInjectAttempts times without inclusion are dropped.
+func (i *TxInjector) submit(ctx context.Context, slot phase0.Slot) {
+	type submission struct {
+		entry *InjectedTx
+		tx    *types.Transaction
+	}
+
+	i.mu.Lock()
+	submissions := make([]submission, 0, len(i.pending))
+
+	for _, entry := range append([]*InjectedTx(nil), i.pending...) {
+		if entry.Attempts >= maxInjectAttempts {
+			entry.Status = InjectedTxDropped
+			i.finish(entry)
+
+			continue
+		}
+
+		entry.Attempts++
+		submissions = append(submissions, submission{entry: entry, tx: entry.tx})
+	}
+	i.mu.Unlock()
+
+	for _, sub := range submissions {
+		err := i.sender.SendTransaction(ctx, sub.tx)
+		if err != nil && strings.Contains(err.Error(), "already known") {
+			err = nil
+		}
+
+		if err == nil {
+			continue
+		}
+
+		i.mu.Lock()
+		if sub.entry.Status == InjectedTxPending {
+			sub.entry.Status = InjectedTxFailed
+			sub.entry.Error = err.Error()
+			i.finish(sub.entry)
+		}
+		i.mu.Unlock()
+
+		i.log.WithError(err).WithFields(logrus.Fields{
+			"slot":    slot,
+			"tx_hash": sub.entry.Hash.Hex(),
+		}).Warn("Injected transaction rejected by the EL")
+	}
+}
+
+// markIncluded records the pending transactions carried by a built payload
+// and returns their hashes.
+func (i *TxInjector) markIncluded(slot phase0.Slot, blockHash phase0.Hash32, txs []paris.Transaction) []common.Hash {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if len(i.pending) == 0 {
+		return nil
+	}
+
+	inPayload := make(map[common.Hash]bool, len(txs))
+
+	for _, raw := range txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err == nil {
+			inPayload[tx.Hash()] = true
+		}
+	}
+
+	var included []common.Hash
+
+	for _, tx := range append([]*InjectedTx(nil), i.pending...) {
+		if !inPayload[tx.Hash] {
+			continue
+		}
+
+		tx.Status = InjectedTxIncluded
+		tx.IncludedSlot = slot
+		tx.IncludedBlockHash = blockHash
+		i.finish(tx)
+
+		included = append(included, tx.Hash)
+	}
+
+	return included
+}
+
+// finish moves a transaction from the pending queue to the history. Callers
+// hold i.mu.
+func (i *TxInjector) finish(tx *InjectedTx) {
+	for idx, pending := range i.pending {
+		if pending == tx {
+			i.pending = append(i.pending[:idx], i.pending[idx+1:]...)
+			break
+		}
+	}
+
+	tx.tx = nil
+
+	i.done = append(i.done, tx)
+	if len(i.done) > injectedTxHistory {
+		i.done = i.done[len(i.done)-injectedTxHistory:]
+	}
+}
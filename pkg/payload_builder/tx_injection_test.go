@@ -0,0 +1,105 @@
+package payload_builder
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTxSender struct {
+	sent []common.Hash
+	errs map[common.Hash]error
+}
+
+func (s *stubTxSender) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	s.sent = append(s.sent, tx.Hash())
+	return s.errs[tx.Hash()]
+}
+
+func TestTxInjector(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := types.NewCancunSigner(big.NewInt(1))
+	to := common.Address{0x01}
+
+	raw := make([]string, 3)
+	hashes := make([]common.Hash, 3)
+	encoded := make([]paris.Transaction, 3)
+
+	for i := range raw {
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     uint64(i),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1),
+			Gas:       21000,
+			To:        &to,
+		})
+
+		data, err := tx.MarshalBinary()
+		require.NoError(t, err)
+
+		raw[i], hashes[i], encoded[i] = hexutil.Encode(data), tx.Hash(), data
+	}
+
+	sender := &stubTxSender{errs: map[common.Hash]error{
+		hashes[1]: errors.New("already known"),
+		hashes[2]: errors.New("nonce too low"),
+	}}
+	injector := NewTxInjector(sender, logrus.New())
+
+	_, err = injector.Queue([]string{raw[0], "0x1234"})
+	require.Error(t, err)
+	assert.Empty(t, injector.Transactions(), "nothing queued on a decode error")
+
+	queued, err := injector.Queue(raw)
+	require.NoError(t, err)
+	require.Len(t, queued, 3)
+	assert.Equal(t, hashes[0], queued[0].Hash)
+	assert.Equal(t, uint64(1), queued[1].Nonce)
+	assert.Equal(t, InjectedTxPending, queued[2].Status)
+
+	// A mempool rejection fails the transaction; "already known" does not.
+	injector.submit(context.Background(), 20)
+	assert.Equal(t, hashes, sender.sent)
+
+	txs := injector.Transactions()
+	require.Len(t, txs, 3)
+	assert.Equal(t, InjectedTxPending, txs[0].Status)
+	assert.Equal(t, InjectedTxPending, txs[1].Status)
+	assert.Equal(t, InjectedTxFailed, txs[2].Status)
+	assert.Equal(t, "nonce too low", txs[2].Error)
+
+	// A built payload carrying a transaction takes it off the queue.
+	included := injector.markIncluded(20, phase0.Hash32{0xaa}, encoded[:1])
+	assert.Equal(t, []common.Hash{hashes[0]}, included)
+
+	txs = injector.Transactions()
+	require.Len(t, txs, 3)
+	assert.Equal(t, hashes[1], txs[0].Hash, "pending first")
+	assert.Equal(t, InjectedTxIncluded, txs[1].Status, "then newest finished")
+	assert.Equal(t, phase0.Slot(20), txs[1].IncludedSlot)
+	assert.Equal(t, phase0.Hash32{0xaa}, txs[1].IncludedBlockHash)
+
+	// Transactions never included are dropped after maxInjectAttempts builds.
+	for range maxInjectAttempts {
+		injector.submit(context.Background(), 21)
+	}
+
+	txs = injector.Transactions()
+	assert.Equal(t, InjectedTxDropped, txs[0].Status)
+	assert.Equal(t, maxInjectAttempts, txs[0].Attempts)
+	assert.Nil(t, injector.markIncluded(22, phase0.Hash32{0xbb}, encoded))
+}
@@ -0,0 +1,104 @@
+package payload_builder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethpandaops/go-eth2-client/spec/capella"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// resolvedWithdrawals are the withdrawals a build uses and how they were
+// chosen.
+type resolvedWithdrawals struct {
+	withdrawals []*capella.Withdrawal
+	source      string // config.WithdrawalsSource*
+	divergence  string // how the two sources disagreed; empty when they agreed or were not compared
+}
+
+// resolveWithdrawals picks the build's withdrawals per the configured source
+// priority. The beacon node's expected withdrawals (computed from the parent
+// state) are fetched when they are the preferred source or the cross-check is
+// on; whenever both sources are at hand they are compared and a divergence is
+// reported. A failed state lookup falls back to the payload_attributes list.
+func (b *PayloadBuilder) resolveWithdrawals(
+	ctx context.Context, attrs *beacon.PayloadAttributesEvent,
+) *resolvedWithdrawals {
+	wcfg := b.cfg.Withdrawals
+	preferred := wcfg.NormalizedSource()
+
+	resolved := &resolvedWithdrawals{
+		withdrawals: attrs.Withdrawals,
+		source:      config.WithdrawalsSourceAttributes,
+	}
+
+	if preferred != config.WithdrawalsSourceState && !wcfg.CrossCheck {
+		return resolved
+	}
+
+	log := b.log.WithField("slot", attrs.ProposalSlot)
+
+	stateWithdrawals, err := b.expectedWithdrawals(ctx, attrs)
+	if err != nil {
+		if preferred == config.WithdrawalsSourceState {
+			log.WithError(err).Warn("Expected withdrawals unavailable, using payload_attributes withdrawals")
+		} else {
+			log.WithError(err).Debug("Expected withdrawals unavailable, withdrawals cross-check skipped")
+		}
+
+		return resolved
+	}
+
+	if preferred == config.WithdrawalsSourceState {
+		resolved.withdrawals = stateWithdrawals
+		resolved.source = config.WithdrawalsSourceState
+	}
+
+	resolved.divergence = withdrawalsDivergence(attrs.Withdrawals, stateWithdrawals)
+	if resolved.divergence != "" {
+		log.WithFields(logrus.Fields{
+			"source":     resolved.source,
+			"divergence": resolved.divergence,
+		}).Warn("payload_attributes withdrawals diverge from the beacon node's expected withdrawals")
+	}
+
+	return resolved
+}
+
+// expectedWithdrawals fetches the beacon node's expected withdrawals for the
+// proposal, computed from the state of the attributes' parent block.
+func (b *PayloadBuilder) expectedWithdrawals(
+	ctx context.Context, attrs *beacon.PayloadAttributesEvent,
+) ([]*capella.Withdrawal, error) {
+	parent, err := b.clClient.GetBlockInfo(ctx, fmt.Sprintf("%#x", attrs.ParentBlockRoot[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent block: %w", err)
+	}
+
+	return b.clClient.GetExpectedWithdrawals(ctx, fmt.Sprintf("%#x", parent.StateRoot[:]), attrs.ProposalSlot)
+}
+
+// withdrawalsDivergence describes the first difference between two
+// withdrawal lists (payload_attributes vs expected), or returns "" when they
+// are identical.
+func withdrawalsDivergence(attrs, expected []*capella.Withdrawal) string {
+	for i := range min(len(attrs), len(expected)) {
+		a, e := attrs[i], expected[i]
+		if *a == *e {
+			continue
+		}
+
+		return fmt.Sprintf("withdrawal %d differs: attributes index %d validator %d address %#x amount %d gwei, "+
+			"expected index %d validator %d address %#x amount %d gwei",
+			i, a.Index, a.ValidatorIndex, a.Address[:], a.Amount, e.Index, e.ValidatorIndex, e.Address[:], e.Amount)
+	}
+
+	if len(attrs) != len(expected) {
+		return fmt.Sprintf("attributes carry %d withdrawals, expected %d", len(attrs), len(expected))
+	}
+
+	return ""
+}
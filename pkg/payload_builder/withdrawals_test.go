@@ -0,0 +1,28 @@
+package payload_builder
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/capella"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithdrawalsDivergence(t *testing.T) {
+	withdrawal := func(index capella.WithdrawalIndex, amount phase0.Gwei) *capella.Withdrawal {
+		return &capella.Withdrawal{Index: index, ValidatorIndex: 7, Amount: amount}
+	}
+
+	attrs := []*capella.Withdrawal{withdrawal(1, 100), withdrawal(2, 200)}
+
+	assert.Empty(t, withdrawalsDivergence(attrs, []*capella.Withdrawal{withdrawal(1, 100), withdrawal(2, 200)}))
+	assert.Empty(t, withdrawalsDivergence(nil, nil))
+
+	assert.Contains(t,
+		withdrawalsDivergence(attrs, []*capella.Withdrawal{withdrawal(1, 100), withdrawal(2, 250)}),
+		"withdrawal 1 differs")
+	assert.Equal(t, "attributes carry 2 withdrawals, expected 1",
+		withdrawalsDivergence(attrs, []*capella.Withdrawal{withdrawal(1, 100)}))
+	assert.Equal(t, "attributes carry 0 withdrawals, expected 2",
+		withdrawalsDivergence(nil, attrs))
+}
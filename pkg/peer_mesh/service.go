@@ -0,0 +1,423 @@
+// Package peer_mesh shares observed p2p bids between buildoor instances on the
+// same devnet over a simple HTTP mesh. Every node serves the bids it observed
+// itself, signed as gossiped, and polls its peers. Peer bids whose signature
+// verifies against their builder's registered pubkey feed the p2p bidder's
+// competitor view; the others are dropped.
+package peer_mesh
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+)
+
+const (
+	// BidsPath is the API route serving a node's locally observed bids.
+	BidsPath = "/api/buildoor/peer/bids"
+
+	// fetchTimeout bounds one peer poll so a dead peer never stalls the
+	// others.
+	fetchTimeout = 2 * time.Second
+
+	// maxSnapshotBytes caps a peer response body.
+	maxSnapshotBytes = 1 << 20
+
+	// pollLookbackSlots is how far behind the current slot peers are asked
+	// for bids (bids for slot N arrive late in slot N-1).
+	pollLookbackSlots = 1
+
+	// NetworkViewSlots is how many recent slots the dashboard view covers.
+	NetworkViewSlots = 4
+)
+
+// SharedBid is one observed p2p bid as exchanged between mesh peers.
+type SharedBid struct {
+	Slot                 uint64 `json:"slot"`
+	BuilderIndex         uint64 `json:"builder_index"`
+	BlockHash            string `json:"block_hash"`
+	ValueGwei            uint64 `json:"value_gwei"`
+	ExecutionPaymentGwei uint64 `json:"execution_payment_gwei"`
+	Source               string `json:"source,omitempty"` // mesh peer name ("" = observed by this node)
+
+	// Fork, Message and Signature are the bid as signed by its builder
+	// (Message is the fork's ExecutionPayloadBid in beacon API JSON). The
+	// summary fields above are informational; merging reads the message.
+	Fork      string          `json:"fork,omitempty"`
+	Message   json.RawMessage `json:"message,omitempty"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// Snapshot is the bid view a node serves to its peers.
+type Snapshot struct {
+	Name          string      `json:"name"`
+	BuilderPubkey string      `json:"builder_pubkey"`
+	BuilderIndex  uint64      `json:"builder_index"`
+	Registered    bool        `json:"registered"`
+	Bids          []SharedBid `json:"bids"`
+}
+
+// PeerStatus is the last poll outcome of one configured peer.
+type PeerStatus struct {
+	URL           string     `json:"url"`
+	Name          string     `json:"name,omitempty"`
+	BuilderPubkey string     `json:"builder_pubkey,omitempty"`
+	BuilderIndex  uint64     `json:"builder_index"`
+	Registered    bool       `json:"registered"`
+	LastSeen      *time.Time `json:"last_seen,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	BidsMerged    uint64     `json:"bids_merged"`
+	BidsRejected  uint64     `json:"bids_rejected"` // unsigned or failing verification
+}
+
+// NetworkView is the dashboard view: this node, its peers and every bid
+// known for the recent slots.
+type NetworkView struct {
+	Name          string       `json:"name"`
+	BuilderPubkey string       `json:"builder_pubkey"`
+	Peers         []PeerStatus `json:"peers"`
+	Bids          []SharedBid  `json:"bids"`
+}
+
+// Service serves local bids to peers and polls the configured peers.
+type Service struct {
+	cfg      *config.PeerMeshConfig
+	chainSvc chain.Service
+	bidder   *p2p_bidder.Service
+	name     string
+	client   *http.Client
+
+	mu    sync.RWMutex
+	peers []*PeerStatus
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewService creates the peer mesh service for the p2p bidder's bid tracker.
+func NewService(cfg *config.PeerMeshConfig, chainSvc chain.Service, bidder *p2p_bidder.Service,
+	log logrus.FieldLogger) *Service {
+	name := cfg.Name
+	if name == "" && bidder != nil {
+		pubkey := bidder.GetBuilderPubkey()
+		name = fmt.Sprintf("%x", pubkey[:4])
+	}
+
+	peers := make([]*PeerStatus, 0, len(cfg.Peers))
+	for _, url := range cfg.Peers {
+		peers = append(peers, &PeerStatus{URL: strings.TrimRight(url, "/")})
+	}
+
+	return &Service{
+		cfg:      cfg,
+		chainSvc: chainSvc,
+		bidder:   bidder,
+		name:     name,
+		client:   &http.Client{Timeout: fetchTimeout},
+		peers:    peers,
+		log:      log.WithField("component", "peer-mesh"),
+	}
+}
+
+// Start launches the peer polling loop. Without configured peers the node
+// only serves its own bids.
+func (s *Service) Start(ctx context.Context) error {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	if len(s.peers) == 0 {
+		return nil
+	}
+
+	s.wg.Add(1)
+
+	go s.run()
+
+	s.log.WithField("peers", len(s.peers)).Info("Peer mesh started")
+
+	return nil
+}
+
+// Stop terminates the polling loop.
+func (s *Service) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.wg.Wait()
+}
+
+func (s *Service) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(s.cfg.PollIntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+
+		case <-ticker.C:
+			s.pollPeers()
+		}
+	}
+}
+
+// pollPeers polls every peer concurrently and merges their bids.
+func (s *Service) pollPeers() {
+	tracker := s.bidTracker()
+	if tracker == nil {
+		return
+	}
+
+	minSlot := s.chainSvc.GetCurrentSlot()
+	if minSlot >= pollLookbackSlots {
+		minSlot -= pollLookbackSlots
+	}
+
+	var wg sync.WaitGroup
+
+	for _, peer := range s.peers {
+		wg.Add(1)
+
+		go func(peer *PeerStatus) {
+			defer wg.Done()
+
+			snapshot, err := s.fetchSnapshot(s.ctx, peer.URL, minSlot)
+			if err != nil {
+				s.mu.Lock()
+				peer.LastError = err.Error()
+				s.mu.Unlock()
+
+				s.log.WithError(err).WithField("peer", peer.URL).Debug("Peer poll failed")
+
+				return
+			}
+
+			// Merged bids are marked with the peer name; it must be
+			// non-empty or they would be relayed as locally observed.
+			if snapshot.Name == "" {
+				snapshot.Name = peer.URL
+			}
+
+			merged, rejected := MergeSnapshot(s.chainSvc, tracker, snapshot)
+			now := time.Now()
+
+			s.mu.Lock()
+			peer.Name = snapshot.Name
+			peer.BuilderPubkey = snapshot.BuilderPubkey
+			peer.BuilderIndex = snapshot.BuilderIndex
+			peer.Registered = snapshot.Registered
+			peer.LastSeen = &now
+			peer.LastError = ""
+			peer.BidsMerged += uint64(merged)
+			peer.BidsRejected += uint64(rejected)
+			s.mu.Unlock()
+		}(peer)
+	}
+
+	wg.Wait()
+}
+
+// fetchSnapshot requests a peer's locally observed bids for slots >= minSlot.
+func (s *Service) fetchSnapshot(ctx context.Context, baseURL string, minSlot phase0.Slot) (*Snapshot, error) {
+	url := fmt.Sprintf("%s%s?min_slot=%d", baseURL, BidsPath, minSlot)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSnapshotBytes)).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// LocalSnapshot returns the bids this node observed itself for slots >=
+// minSlot (served to peers; merged peer bids are never relayed).
+func (s *Service) LocalSnapshot(minSlot phase0.Slot) *Snapshot {
+	snapshot := &Snapshot{
+		Name: s.name,
+		Bids: []SharedBid{},
+	}
+
+	if s.bidder == nil {
+		return snapshot
+	}
+
+	snapshot.BuilderPubkey = fmt.Sprintf("%#x", s.bidder.GetBuilderPubkey())
+	snapshot.BuilderIndex = s.bidder.GetBuilderIndex()
+	snapshot.Registered = s.bidder.IsRegistered()
+
+	if tracker := s.bidTracker(); tracker != nil {
+		snapshot.Bids = toSharedBids(tracker.BidsSince(minSlot, true))
+	}
+
+	return snapshot
+}
+
+// NetworkView returns the peer statuses and every bid (local and merged)
+// known for the last NetworkViewSlots slots.
+func (s *Service) NetworkView() *NetworkView {
+	view := &NetworkView{
+		Name:  s.name,
+		Peers: make([]PeerStatus, 0, len(s.peers)),
+		Bids:  []SharedBid{},
+	}
+
+	if s.bidder != nil {
+		view.BuilderPubkey = fmt.Sprintf("%#x", s.bidder.GetBuilderPubkey())
+	}
+
+	s.mu.RLock()
+	for _, peer := range s.peers {
+		view.Peers = append(view.Peers, *peer)
+	}
+	s.mu.RUnlock()
+
+	if tracker := s.bidTracker(); tracker != nil {
+		minSlot := s.chainSvc.GetCurrentSlot()
+		if minSlot >= NetworkViewSlots {
+			minSlot -= NetworkViewSlots
+		}
+
+		view.Bids = toSharedBids(tracker.BidsSince(minSlot, false))
+	}
+
+	return view
+}
+
+// bidTracker returns the p2p bidder's bid tracker, or nil before the bidder
+// has started.
+func (s *Service) bidTracker() *p2p_bidder.BidTracker {
+	if s.bidder == nil {
+		return nil
+	}
+
+	return s.bidder.GetBidTracker()
+}
+
+// MergeSnapshot merges a peer's bids into the tracker and returns how many
+// were taken over and how many were rejected. Only bids whose signature
+// verifies against their builder's registered pubkey are merged, built from
+// the signed message rather than the unsigned summary fields.
+func MergeSnapshot(chainSvc chain.Service, tracker *p2p_bidder.BidTracker, snapshot *Snapshot) (merged, rejected int) {
+	for _, shared := range snapshot.Bids {
+		signed, err := decodeSharedBid(&shared)
+		if err == nil {
+			err = payload_bidder.VerifyBidSignature(chainSvc, signed)
+		}
+
+		if err != nil {
+			rejected++
+			continue
+		}
+
+		msg := signed.Message
+		bid := &p2p_bidder.ExecutionPayloadBid{
+			ParentBlockHash:  msg.ParentBlockHash,
+			ParentBlockRoot:  msg.ParentBlockRoot,
+			BlockHash:        msg.BlockHash,
+			FeeRecipient:     msg.FeeRecipient,
+			GasLimit:         msg.GasLimit,
+			BuilderIndex:     uint64(msg.BuilderIndex),
+			Slot:             msg.Slot,
+			Value:            uint64(msg.Value),
+			ExecutionPayment: uint64(msg.ExecutionPayment),
+			Signed:           signed,
+		}
+
+		if tracker.TrackPeerBid(bid, snapshot.Name) {
+			merged++
+		}
+	}
+
+	return merged, rejected
+}
+
+// decodeSharedBid decodes the signed bid carried by a shared bid.
+func decodeSharedBid(shared *SharedBid) (*eth2all.SignedExecutionPayloadBid, error) {
+	if len(shared.Message) == 0 || shared.Signature == "" {
+		return nil, errors.New("bid is not signed")
+	}
+
+	fork, err := version.DataVersionFromString(shared.Fork)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fork: %w", err)
+	}
+
+	msg := &eth2all.ExecutionPayloadBid{Version: fork}
+	if err := msg.UnmarshalJSON(shared.Message); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(shared.Signature, "0x"))
+	if err != nil || len(sig) != len(phase0.BLSSignature{}) {
+		return nil, errors.New("invalid signature")
+	}
+
+	return &eth2all.SignedExecutionPayloadBid{
+		Version:   fork,
+		Message:   msg,
+		Signature: phase0.BLSSignature(sig),
+	}, nil
+}
+
+func toSharedBids(tracked []p2p_bidder.TrackedBid) []SharedBid {
+	bids := make([]SharedBid, 0, len(tracked))
+
+	for _, t := range tracked {
+		shared := SharedBid{
+			Slot:                 uint64(t.Bid.Slot),
+			BuilderIndex:         t.BuilderIndex,
+			BlockHash:            fmt.Sprintf("%#x", t.Bid.BlockHash),
+			ValueGwei:            t.Bid.Value,
+			ExecutionPaymentGwei: t.Bid.ExecutionPayment,
+			Source:               t.Peer,
+		}
+
+		if signed := t.Bid.Signed; signed != nil && signed.Message != nil {
+			if msg, err := signed.Message.MarshalJSON(); err == nil {
+				shared.Fork = signed.Version.String()
+				shared.Message = msg
+				shared.Signature = fmt.Sprintf("%#x", signed.Signature)
+			}
+		}
+
+		bids = append(bids, shared)
+	}
+
+	return bids
+}
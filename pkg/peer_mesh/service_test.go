@@ -0,0 +1,175 @@
+package peer_mesh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/gloas"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+func testLogger() logrus.FieldLogger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	return log
+}
+
+// meshChain is a chain.Service stub with a Gloas genesis and one registered
+// builder, enough to verify peer bid signatures.
+type meshChain struct {
+	chain.Service
+	builder *chain.BuilderInfo
+}
+
+var meshGenesis = beacon.Genesis{GenesisValidatorsRoot: phase0.Root{0x42}, GenesisForkVersion: phase0.Version{0x10}}
+
+func (c *meshChain) GetGenesis() *beacon.Genesis { return &meshGenesis }
+
+func (c *meshChain) GetChainSpec() *chain.ChainSpec {
+	return &chain.ChainSpec{
+		SlotsPerEpoch: 32,
+		ForkSchedule: []chain.ForkSchedule{
+			{Fork: version.DataVersionGloas, Version: phase0.Version{0x07}, Epoch: 0},
+		},
+	}
+}
+
+func (c *meshChain) ActiveForkAtEpoch(phase0.Epoch) version.DataVersion {
+	return version.DataVersionGloas
+}
+
+func (c *meshChain) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / 32)
+}
+
+func (c *meshChain) GetBuilderByIndex(index uint64) *chain.BuilderInfo {
+	if c.builder != nil && c.builder.Index == index {
+		return c.builder
+	}
+
+	return nil
+}
+
+// signedSharedBid returns a shared bid for the given builder, signed by key.
+func signedSharedBid(t *testing.T, chainSvc chain.Service, key *signer.BLSSigner, builderIndex, value uint64) SharedBid {
+	t.Helper()
+
+	msg := &eth2all.ExecutionPayloadBid{
+		Version:      version.DataVersionGloas,
+		BlockHash:    phase0.Hash32{byte(builderIndex)},
+		BuilderIndex: gloas.BuilderIndex(builderIndex),
+		Slot:         100,
+		Value:        phase0.Gwei(value),
+	}
+
+	root, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+
+	domain, err := payload_bidder.ExpectedSigningDomain(chainSvc, payload_bidder.SigningArtifactBid, msg.Slot)
+	require.NoError(t, err)
+
+	sig, err := key.SignWithDomain(root, domain.Domain)
+	require.NoError(t, err)
+
+	shared := toSharedBids([]p2p_bidder.TrackedBid{{
+		BuilderIndex: builderIndex,
+		Bid: &p2p_bidder.ExecutionPayloadBid{
+			Slot:      msg.Slot,
+			BlockHash: msg.BlockHash,
+			Value:     value,
+			Signed:    &eth2all.SignedExecutionPayloadBid{Version: msg.Version, Message: msg, Signature: sig},
+		},
+	}})
+	require.NotEmpty(t, shared[0].Message)
+
+	return shared[0]
+}
+
+func TestFetchAndMergeSnapshot(t *testing.T) {
+	key, err := signer.NewBLSSigner("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	chainSvc := &meshChain{builder: &chain.BuilderInfo{Index: 5, Pubkey: key.PublicKey()}}
+
+	valid := signedSharedBid(t, chainSvc, key, 5, 700)
+
+	tampered := valid
+	tampered.Message = json.RawMessage(strings.Replace(string(valid.Message), `"value":"700"`, `"value":"7000"`, 1))
+	require.NotEqual(t, valid.Message, tampered.Message)
+
+	unknownBuilder := signedSharedBid(t, chainSvc, key, 6, 800)
+
+	var gotQuery string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, BidsPath, r.URL.Path)
+		gotQuery = r.URL.RawQuery
+
+		_ = json.NewEncoder(w).Encode(&Snapshot{
+			Name:         "node-b",
+			BuilderIndex: 5,
+			Bids: []SharedBid{
+				valid,
+				tampered,
+				unknownBuilder,
+				{Slot: 100, BuilderIndex: 3, ValueGwei: 900}, // unsigned
+			},
+		})
+	}))
+	defer srv.Close()
+
+	svc := NewService(&config.PeerMeshConfig{Peers: []string{srv.URL + "/"}}, chainSvc, nil, testLogger())
+	require.Equal(t, srv.URL, svc.peers[0].URL, "trailing slashes are trimmed")
+
+	snapshot, err := svc.fetchSnapshot(context.Background(), svc.peers[0].URL, 99)
+	require.NoError(t, err)
+	require.Equal(t, "min_slot=99", gotQuery)
+
+	tracker := p2p_bidder.NewBidTracker(1, testLogger())
+
+	merged, rejected := MergeSnapshot(chainSvc, tracker, snapshot)
+	require.Equal(t, 1, merged)
+	require.Equal(t, 3, rejected)
+
+	best, ok := tracker.GetHighestCompetitorBid(100, 1)
+	require.True(t, ok, "verified peer bids feed the competitor view")
+	require.Equal(t, uint64(700), best)
+
+	bids := toSharedBids(tracker.BidsSince(100, false))
+	require.Len(t, bids, 1)
+	require.Equal(t, "node-b", bids[0].Source)
+	require.Equal(t, fmt.Sprintf("%#x", phase0.Hash32{5}), bids[0].BlockHash)
+	require.Equal(t, valid.Signature, bids[0].Signature, "merged bids keep their signature")
+
+	require.Empty(t, tracker.BidsSince(100, true), "merged bids are never relayed")
+}
+
+func TestFetchSnapshotRejectsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	svc := NewService(&config.PeerMeshConfig{}, nil, nil, testLogger())
+
+	_, err := svc.fetchSnapshot(context.Background(), srv.URL, 0)
+	require.Error(t, err)
+}
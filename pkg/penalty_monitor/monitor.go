@@ -0,0 +1,405 @@
+// Package penalty_monitor watches the beacon node event stream for evidence
+// that exposes our builder to protocol penalties: bids carrying one of our
+// builder indices for a payload this instance never built (a leaked key or a
+// second instance running it, i.e. builder equivocation), and proposer
+// slashings of slots we bid in. Every incident raises a critical alert and
+// holds bidding (p2p and the Builder API) until an operator acknowledges it.
+package penalty_monitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// Incident kinds.
+const (
+	// IncidentBidEquivocation is a bid with our builder index for a block
+	// hash we never built.
+	IncidentBidEquivocation = "bid_equivocation"
+	// IncidentProposerSlashing is a proposer slashing of a slot we bid in.
+	IncidentProposerSlashing = "proposer_slashing"
+)
+
+// AlertRulePrefix prefixes the incident kind in the alert rule name.
+const AlertRulePrefix = "penalty_monitor:"
+
+// ActionHoldBidding is the alert action of the bid hold.
+const ActionHoldBidding = "hold_bidding"
+
+// bidSlotRetention is how many slots our bid slots are remembered for:
+// slashings may be included long after the offence.
+const bidSlotRetention = 8192
+
+// maxIncidents bounds the kept incident history; the oldest acknowledged
+// incidents are dropped first.
+const maxIncidents = 256
+
+// EventSource provides the beacon node events (implemented by the beacon
+// event stream).
+type EventSource interface {
+	SubscribeBids() *utils.Subscription[*beacon.BidEvent]
+	SubscribeProposerSlashings() *utils.Subscription[*beacon.ProposerSlashingEvent]
+}
+
+// PayloadLookup resolves a block hash to a payload we built (implemented by
+// the payload cache).
+type PayloadLookup interface {
+	GetByBlockHash(blockHash phase0.Hash32) *payload_builder.Payload
+}
+
+// Builder is one of our builder keys (implemented by the p2p bidder).
+type Builder interface {
+	GetBuilderIndex() uint64
+	IsRegistered() bool
+}
+
+// BidHolder halts bidding while a reason is set (implemented by the p2p
+// bidder and the Builder API server).
+type BidHolder interface {
+	SetBidHold(reason string)
+}
+
+// Incident is one piece of penalty evidence.
+type Incident struct {
+	ID           uint64    `json:"id"`
+	Kind         string    `json:"kind"`
+	Slot         uint64    `json:"slot"`
+	BuilderIndex uint64    `json:"builder_index"`
+	Message      string    `json:"message"`
+	DetectedAt   time.Time `json:"detected_at"`
+
+	Acknowledged   bool       `json:"acknowledged"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+}
+
+// Monitor matches beacon events against our builders and holds bidding on
+// evidence.
+type Monitor struct {
+	chainSvc chain.Service
+	events   EventSource
+	payloads PayloadLookup
+	builders []Builder
+	holders  []BidHolder
+	alerts   *alerting.Engine // nil = incidents are only logged
+
+	mu        sync.Mutex
+	startSlot phase0.Slot // bids of the slot in progress at start may predate our cache
+	bidSlots  map[phase0.Slot]uint64
+	seen      map[string]bool
+	incidents []*Incident
+	nextID    uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewMonitor creates the penalty monitor for our builder keys; bids are held
+// on every holder. alerts may be nil.
+func NewMonitor(chainSvc chain.Service, events EventSource, payloads PayloadLookup, builders []Builder,
+	holders []BidHolder, alerts *alerting.Engine, log logrus.FieldLogger) *Monitor {
+	return &Monitor{
+		chainSvc: chainSvc,
+		events:   events,
+		payloads: payloads,
+		builders: builders,
+		holders:  holders,
+		alerts:   alerts,
+		bidSlots: make(map[phase0.Slot]uint64),
+		seen:     make(map[string]bool),
+		log:      log.WithField("component", "penalty-monitor"),
+	}
+}
+
+// Start subscribes to the bid and proposer slashing events.
+func (m *Monitor) Start(ctx context.Context) error {
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.startSlot = m.chainSvc.GetCurrentSlot()
+
+	bidSub := m.events.SubscribeBids()
+	slashingSub := m.events.SubscribeProposerSlashings()
+
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+		defer bidSub.Unsubscribe()
+		defer slashingSub.Unsubscribe()
+
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case event := <-bidSub.Channel():
+				m.HandleBid(event)
+			case event := <-slashingSub.Channel():
+				m.HandleProposerSlashing(event)
+			}
+		}
+	}()
+
+	m.log.WithField("builders", len(m.builders)).Info("Penalty monitor started")
+
+	return nil
+}
+
+// Stop terminates the monitor loop.
+func (m *Monitor) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	m.wg.Wait()
+}
+
+// HandleBid checks a gossiped bid: one carrying our builder index must be for
+// a payload we built, anything else is equivocation evidence. Our genuine
+// bids mark their slot for proposer slashing matching.
+func (m *Monitor) HandleBid(event *beacon.BidEvent) {
+	if !m.isOurs(event.BuilderIndex) {
+		return
+	}
+
+	m.mu.Lock()
+	startSlot := m.startSlot
+	m.mu.Unlock()
+
+	if m.payloads.GetByBlockHash(event.BlockHash) != nil {
+		m.markBidSlot(event.Slot, event.BuilderIndex)
+		return
+	}
+
+	if event.Slot <= startSlot {
+		return
+	}
+
+	m.raise(&Incident{
+		Kind:         IncidentBidEquivocation,
+		Slot:         uint64(event.Slot),
+		BuilderIndex: event.BuilderIndex,
+		Message: fmt.Sprintf("bid for slot %d with our builder index %d carries block hash %#x that this instance never built",
+			event.Slot, event.BuilderIndex, event.BlockHash[:8]),
+	}, fmt.Sprintf("%d/%x", event.Slot, event.BlockHash))
+}
+
+// HandleProposerSlashing raises an incident for a proposer slashing of a slot
+// we bid in: our bid may be part of the slashed block.
+func (m *Monitor) HandleProposerSlashing(event *beacon.ProposerSlashingEvent) {
+	m.mu.Lock()
+	builderIndex, ok := m.bidSlots[event.Slot]
+	m.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	m.raise(&Incident{
+		Kind:         IncidentProposerSlashing,
+		Slot:         uint64(event.Slot),
+		BuilderIndex: builderIndex,
+		Message: fmt.Sprintf("proposer %d was slashed for equivocating in slot %d, in which builder %d bid",
+			event.ProposerIndex, event.Slot, builderIndex),
+	}, fmt.Sprintf("%d/%d", event.Slot, event.ProposerIndex))
+}
+
+// Incidents returns a snapshot of the incident history, oldest first.
+func (m *Monitor) Incidents() []Incident {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	incidents := make([]Incident, 0, len(m.incidents))
+	for _, incident := range m.incidents {
+		incidents = append(incidents, *incident)
+	}
+
+	return incidents
+}
+
+// HoldReason returns why bidding is held, or empty when it is not.
+func (m *Monitor) HoldReason() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.holdReason()
+}
+
+// Acknowledge marks the incident with the given ID (0: all) acknowledged and
+// lifts the bid hold once none is left unacknowledged.
+func (m *Monitor) Acknowledge(id uint64, actor string) error {
+	now := time.Now()
+
+	var acknowledged []*Incident
+
+	m.mu.Lock()
+
+	for _, incident := range m.incidents {
+		if incident.Acknowledged || (id != 0 && incident.ID != id) {
+			continue
+		}
+
+		incident.Acknowledged = true
+		incident.AcknowledgedAt = &now
+		incident.AcknowledgedBy = actor
+
+		snapshot := *incident
+		acknowledged = append(acknowledged, &snapshot)
+	}
+
+	reason := m.holdReason()
+	m.mu.Unlock()
+
+	if id != 0 && len(acknowledged) == 0 {
+		return fmt.Errorf("no unacknowledged incident %d", id)
+	}
+
+	m.hold(reason)
+
+	for _, incident := range acknowledged {
+		m.log.WithFields(logrus.Fields{
+			"incident": incident.ID,
+			"kind":     incident.Kind,
+			"actor":    actor,
+		}).Info("Penalty incident acknowledged")
+
+		m.publish(incident, alerting.AlertResolved, "acknowledged by "+actor+": "+incident.Message)
+	}
+
+	return nil
+}
+
+// raise records a new incident (deduplicated by key), alerts and holds
+// bidding.
+func (m *Monitor) raise(incident *Incident, key string) {
+	m.mu.Lock()
+
+	key = incident.Kind + "/" + key
+	if m.seen[key] {
+		m.mu.Unlock()
+		return
+	}
+
+	m.seen[key] = true
+	m.nextID++
+	incident.ID = m.nextID
+	incident.DetectedAt = time.Now()
+	m.incidents = append(m.incidents, incident)
+	m.trimIncidents()
+
+	snapshot := *incident
+	reason := m.holdReason()
+	m.mu.Unlock()
+
+	m.log.WithFields(logrus.Fields{
+		"incident":      snapshot.ID,
+		"kind":          snapshot.Kind,
+		"slot":          snapshot.Slot,
+		"builder_index": snapshot.BuilderIndex,
+	}).Error(snapshot.Message + "; bidding held until acknowledged")
+
+	m.hold(reason)
+	m.publish(&snapshot, alerting.AlertFiring, snapshot.Message+"; bidding held until acknowledged")
+}
+
+// hold applies the hold reason (empty: lifted) to every holder.
+func (m *Monitor) hold(reason string) {
+	for _, holder := range m.holders {
+		holder.SetBidHold(reason)
+	}
+}
+
+func (m *Monitor) publish(incident *Incident, state, message string) {
+	if m.alerts == nil {
+		return
+	}
+
+	m.alerts.Publish(&alerting.Alert{
+		Rule:     AlertRulePrefix + incident.Kind,
+		Severity: alerting.SeverityCritical,
+		State:    state,
+		Slot:     incident.Slot,
+		Message:  message,
+		Actions:  []string{ActionHoldBidding},
+	})
+}
+
+// holdReason summarizes the unacknowledged incidents; the caller holds mu.
+func (m *Monitor) holdReason() string {
+	var (
+		pending int
+		latest  *Incident
+	)
+
+	for _, incident := range m.incidents {
+		if !incident.Acknowledged {
+			pending++
+			latest = incident
+		}
+	}
+
+	if latest == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("penalty monitor: %d unacknowledged incident(s), latest: %s", pending, latest.Message)
+}
+
+// trimIncidents drops the oldest acknowledged incidents beyond maxIncidents;
+// the caller holds mu.
+func (m *Monitor) trimIncidents() {
+	for i := 0; len(m.incidents) > maxIncidents && i < len(m.incidents); {
+		if m.incidents[i].Acknowledged {
+			m.incidents = append(m.incidents[:i], m.incidents[i+1:]...)
+			continue
+		}
+
+		i++
+	}
+}
+
+// markBidSlot remembers a slot we bid in, forgetting slots beyond the
+// retention.
+func (m *Monitor) markBidSlot(slot phase0.Slot, builderIndex uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.bidSlots[slot]; ok {
+		return
+	}
+
+	m.bidSlots[slot] = builderIndex
+
+	if slot < bidSlotRetention {
+		return
+	}
+
+	for bidSlot := range m.bidSlots {
+		if bidSlot < slot-bidSlotRetention {
+			delete(m.bidSlots, bidSlot)
+		}
+	}
+}
+
+// isOurs reports whether index is the index of one of our registered
+// builders.
+func (m *Monitor) isOurs(index uint64) bool {
+	for _, builder := range m.builders {
+		if builder.IsRegistered() && builder.GetBuilderIndex() == index {
+			return true
+		}
+	}
+
+	return false
+}
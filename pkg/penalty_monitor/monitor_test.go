@@ -0,0 +1,117 @@
+package penalty_monitor
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+type stubPayloads map[phase0.Hash32]bool
+
+func (p stubPayloads) GetByBlockHash(blockHash phase0.Hash32) *payload_builder.Payload {
+	if p[blockHash] {
+		return &payload_builder.Payload{BlockHash: blockHash}
+	}
+
+	return nil
+}
+
+type stubBuilder struct {
+	index      uint64
+	registered bool
+}
+
+func (b stubBuilder) GetBuilderIndex() uint64 { return b.index }
+func (b stubBuilder) IsRegistered() bool      { return b.registered }
+
+type stubHolder struct {
+	reason string
+}
+
+func (h *stubHolder) SetBidHold(reason string) { h.reason = reason }
+
+func newTestMonitor(payloads stubPayloads, holder *stubHolder) *Monitor {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	m := NewMonitor(nil, nil, payloads, []Builder{stubBuilder{index: 3, registered: true}, stubBuilder{index: 9}},
+		[]BidHolder{holder}, nil, log)
+	m.startSlot = 10
+
+	return m
+}
+
+func TestHandleBid(t *testing.T) {
+	ours := phase0.Hash32{0x01}
+	holder := &stubHolder{}
+	m := newTestMonitor(stubPayloads{ours: true}, holder)
+
+	m.HandleBid(&beacon.BidEvent{Slot: 11, BuilderIndex: 3, BlockHash: ours})
+	m.HandleBid(&beacon.BidEvent{Slot: 11, BuilderIndex: 4, BlockHash: phase0.Hash32{0x02}})
+	m.HandleBid(&beacon.BidEvent{Slot: 11, BuilderIndex: 9, BlockHash: phase0.Hash32{0x02}})
+	m.HandleBid(&beacon.BidEvent{Slot: 10, BuilderIndex: 3, BlockHash: phase0.Hash32{0x02}})
+	assert.Empty(t, m.Incidents(), "our payload, other builders, an unregistered key and the start slot are ignored")
+	assert.Empty(t, holder.reason)
+
+	foreign := &beacon.BidEvent{Slot: 12, BuilderIndex: 3, BlockHash: phase0.Hash32{0x02}}
+	m.HandleBid(foreign)
+	m.HandleBid(foreign)
+
+	incidents := m.Incidents()
+	require.Len(t, incidents, 1, "repeated evidence is deduplicated")
+	assert.Equal(t, IncidentBidEquivocation, incidents[0].Kind)
+	assert.Equal(t, uint64(12), incidents[0].Slot)
+	assert.Contains(t, holder.reason, "1 unacknowledged incident(s)")
+	assert.Equal(t, holder.reason, m.HoldReason())
+}
+
+func TestHandleProposerSlashing(t *testing.T) {
+	ours := phase0.Hash32{0x01}
+	holder := &stubHolder{}
+	m := newTestMonitor(stubPayloads{ours: true}, holder)
+
+	m.HandleBid(&beacon.BidEvent{Slot: 20, BuilderIndex: 3, BlockHash: ours})
+
+	m.HandleProposerSlashing(&beacon.ProposerSlashingEvent{Slot: 21, ProposerIndex: 5})
+	assert.Empty(t, m.Incidents(), "no bid of ours in the slot")
+
+	m.HandleProposerSlashing(&beacon.ProposerSlashingEvent{Slot: 20, ProposerIndex: 5})
+
+	incidents := m.Incidents()
+	require.Len(t, incidents, 1)
+	assert.Equal(t, IncidentProposerSlashing, incidents[0].Kind)
+	assert.Equal(t, uint64(3), incidents[0].BuilderIndex)
+	assert.NotEmpty(t, holder.reason)
+}
+
+func TestAcknowledge(t *testing.T) {
+	holder := &stubHolder{}
+	m := newTestMonitor(stubPayloads{}, holder)
+
+	m.HandleBid(&beacon.BidEvent{Slot: 11, BuilderIndex: 3, BlockHash: phase0.Hash32{0x02}})
+	m.HandleBid(&beacon.BidEvent{Slot: 12, BuilderIndex: 3, BlockHash: phase0.Hash32{0x03}})
+	require.Len(t, m.Incidents(), 2)
+
+	require.Error(t, m.Acknowledge(7, "alice"), "unknown incident")
+
+	require.NoError(t, m.Acknowledge(1, "alice"))
+	assert.Contains(t, holder.reason, "1 unacknowledged incident(s)", "still held")
+
+	require.Error(t, m.Acknowledge(1, "alice"), "already acknowledged")
+
+	require.NoError(t, m.Acknowledge(0, "bob"))
+	assert.Empty(t, holder.reason)
+	assert.Empty(t, m.HoldReason())
+
+	incidents := m.Incidents()
+	assert.Equal(t, "alice", incidents[0].AcknowledgedBy)
+	assert.Equal(t, "bob", incidents[1].AcknowledgedBy)
+	assert.NotNil(t, incidents[1].AcknowledgedAt)
+}
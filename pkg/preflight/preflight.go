@@ -0,0 +1,132 @@
+// Package preflight is the structured startup report buildoor prints once
+// every service is wired: the detected fork and spec preset, the builder's
+// registration, wallet balances, the enabled services, the effective timing
+// profile and any warnings. It lets an operator verify a deployment at a
+// glance; the same report is served at /api/status/preflight.
+package preflight
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Service is one buildoor service and whether it runs.
+type Service struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// Wallet is the balance of one EL wallet, or the error reading it.
+type Wallet struct {
+	Label      string `json:"label"`
+	Address    string `json:"address"`
+	BalanceWei string `json:"balance_wei,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Registration is the builder key's registration in the beacon state.
+type Registration struct {
+	BuilderPubkey string `json:"builder_pubkey"`
+	Registered    bool   `json:"registered"`
+	BuilderIndex  uint64 `json:"builder_index,omitempty"`
+	BalanceGwei   uint64 `json:"balance_gwei,omitempty"`
+	Active        bool   `json:"active"`
+}
+
+// Timing is the effective slot timing profile in milliseconds relative to
+// the slot start (after slot-time defaults and persisted overrides).
+type Timing struct {
+	SlotTimeMs       int64  `json:"slot_time_ms"`
+	BuildStartTime   int64  `json:"build_start_time"`
+	PayloadBuildTime uint64 `json:"payload_build_time"`
+	BidStartTime     int64  `json:"bid_start_time"`
+	BidEndTime       int64  `json:"bid_end_time"`
+	BidInterval      int64  `json:"bid_interval"`
+	RevealTime       int64  `json:"reveal_time"`
+}
+
+// Report is the startup report.
+type Report struct {
+	GeneratedAt  time.Time    `json:"generated_at"`
+	Version      string       `json:"version"`
+	Fork         string       `json:"fork"`
+	Preset       string       `json:"preset,omitempty"`
+	ConfigName   string       `json:"config_name,omitempty"`
+	GloasEpoch   *uint64      `json:"gloas_epoch,omitempty"` // nil = Gloas not scheduled
+	Registration Registration `json:"registration"`
+	Wallets      []Wallet     `json:"wallets,omitempty"`
+	Services     []Service    `json:"services"`
+	Timing       Timing       `json:"timing"`
+	Warnings     []string     `json:"warnings"`
+}
+
+// Warn records a warning.
+func (r *Report) Warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Enabled reports whether the named service runs.
+func (r *Report) Enabled(name string) bool {
+	for _, svc := range r.Services {
+		if svc.Name == name {
+			return svc.Enabled
+		}
+	}
+
+	return false
+}
+
+// Check adds the warnings the report's own contents imply: no bidding path
+// enabled, an unregistered builder bidding via ePBS, empty or unreadable
+// wallets and an inconsistent timing profile.
+func (r *Report) Check() {
+	if !r.Enabled(ServiceEPBS) && !r.Enabled(ServiceBuilderAPI) {
+		r.Warn("neither ePBS bidding nor the Builder API is enabled: blocks are built but never offered")
+	}
+
+	if r.Enabled(ServiceEPBS) && !r.Registration.Registered && !r.Enabled(ServiceLifecycle) {
+		r.Warn("builder %s is not registered and lifecycle management is disabled: p2p bids will not be accepted",
+			r.Registration.BuilderPubkey)
+	}
+
+	for _, w := range r.Wallets {
+		if w.Error != "" {
+			r.Warn("%s wallet %s: balance unavailable: %s", w.Label, w.Address, w.Error)
+			continue
+		}
+
+		if balance, ok := new(big.Int).SetString(w.BalanceWei, 10); ok && balance.Sign() == 0 {
+			r.Warn("%s wallet %s has no balance", w.Label, w.Address)
+		}
+	}
+
+	t := r.Timing
+	if t.BidEndTime < t.BidStartTime {
+		r.Warn("bid_end_time (%dms) is before bid_start_time (%dms): no bids are sent", t.BidEndTime, t.BidStartTime)
+	}
+
+	if t.BuildStartTime+int64(t.PayloadBuildTime) > t.BidEndTime {
+		r.Warn("payload ready at %dms, after bid_end_time (%dms)",
+			t.BuildStartTime+int64(t.PayloadBuildTime), t.BidEndTime)
+	}
+
+	if t.SlotTimeMs > 0 && t.RevealTime >= t.SlotTimeMs {
+		r.Warn("reveal_time (%dms) is not within the slot (%dms)", t.RevealTime, t.SlotTimeMs)
+	}
+}
+
+// Service names.
+const (
+	ServiceBuilder    = "builder"
+	ServiceEPBS       = "epbs"
+	ServiceBuilderAPI = "builder_api"
+	ServiceLifecycle  = "lifecycle"
+	ServicePeerMesh   = "peer_mesh"
+	ServiceRelayProxy = "relay_proxy"
+	ServiceAlerting   = "alerting"
+	ServiceBreaker    = "circuit_breaker"
+	ServicePenalties  = "penalty_monitor"
+	ServiceWebUI      = "webui"
+)
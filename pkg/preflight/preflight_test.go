@@ -0,0 +1,124 @@
+package preflight
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func healthyReport() *Report {
+	return &Report{
+		Version:      "test",
+		Fork:         "fulu",
+		Preset:       "minimal",
+		Registration: Registration{BuilderPubkey: "0xb1", Registered: true, BuilderIndex: 3, BalanceGwei: 32_000_000_000},
+		Wallets:      []Wallet{{Label: "builder", Address: "0xaa", BalanceWei: "1000"}},
+		Services: []Service{
+			{Name: ServiceBuilder, Enabled: true},
+			{Name: ServiceEPBS, Enabled: true},
+			{Name: ServiceLifecycle, Enabled: true},
+		},
+		Timing: Timing{
+			SlotTimeMs: 12000, BuildStartTime: -2900, PayloadBuildTime: 2100,
+			BidStartTime: -400, BidEndTime: -100, RevealTime: 5000,
+		},
+		Warnings: []string{},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(r *Report)
+		want   []string
+	}{
+		{
+			name:   "healthy deployment",
+			mutate: func(*Report) {},
+		},
+		{
+			name: "no bidding path",
+			mutate: func(r *Report) {
+				r.Services = []Service{{Name: ServiceBuilder, Enabled: true}}
+			},
+			want: []string{"neither ePBS bidding nor the Builder API is enabled: blocks are built but never offered"},
+		},
+		{
+			name: "unregistered without lifecycle",
+			mutate: func(r *Report) {
+				r.Registration = Registration{BuilderPubkey: "0xb1"}
+				r.Services[2].Enabled = false
+			},
+			want: []string{"builder 0xb1 is not registered and lifecycle management is disabled: p2p bids will not be accepted"},
+		},
+		{
+			name: "unregistered with lifecycle deposits",
+			mutate: func(r *Report) {
+				r.Registration = Registration{BuilderPubkey: "0xb1"}
+			},
+		},
+		{
+			name: "empty and unreadable wallets",
+			mutate: func(r *Report) {
+				r.Wallets = []Wallet{
+					{Label: "builder", Address: "0xaa", BalanceWei: "0"},
+					{Label: "extra-0", Address: "0xbb", Error: "timeout"},
+				}
+			},
+			want: []string{
+				"builder wallet 0xaa has no balance",
+				"extra-0 wallet 0xbb: balance unavailable: timeout",
+			},
+		},
+		{
+			name: "inconsistent timing",
+			mutate: func(r *Report) {
+				r.Timing.BidEndTime = -900
+				r.Timing.RevealTime = 12000
+			},
+			want: []string{
+				"bid_end_time (-900ms) is before bid_start_time (-400ms): no bids are sent",
+				"payload ready at -800ms, after bid_end_time (-900ms)",
+				"reveal_time (12000ms) is not within the slot (12000ms)",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := healthyReport()
+			test.mutate(report)
+			report.Check()
+
+			if test.want == nil {
+				assert.Empty(t, report.Warnings)
+				return
+			}
+
+			assert.Equal(t, test.want, report.Warnings)
+		})
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	report := healthyReport()
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+
+	text := buf.String()
+	assert.Contains(t, text, "- (preset minimal)")
+	assert.Contains(t, text, "fulu (gloas: not scheduled)")
+	assert.Contains(t, text, "0xb1 index 3, balance 32000000000 gwei")
+	assert.Contains(t, text, "0xaa 1000 wei")
+	assert.Contains(t, text, "bids -400ms..-100ms")
+	assert.Regexp(t, `epbs\s+enabled`, text)
+	assert.Contains(t, text, "no warnings")
+
+	report.Warn("beacon node lacks %s", "head_votes")
+	buf.Reset()
+	require.NoError(t, report.WriteText(&buf))
+	assert.Contains(t, buf.String(), "1 warning(s):\n  - beacon node lacks head_votes\n")
+}
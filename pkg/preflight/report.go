@@ -0,0 +1,81 @@
+package preflight
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteText renders the report for the startup log.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	network := r.ConfigName
+	if network == "" {
+		network = "-"
+	}
+
+	preset := r.Preset
+	if preset == "" {
+		preset = "-"
+	}
+
+	gloas := "not scheduled"
+	if r.GloasEpoch != nil {
+		gloas = fmt.Sprintf("epoch %d", *r.GloasEpoch)
+	}
+
+	fmt.Fprintf(tw, "version\t%s\n", r.Version)
+	fmt.Fprintf(tw, "network\t%s (preset %s)\n", network, preset)
+	fmt.Fprintf(tw, "fork\t%s (gloas: %s)\n", r.Fork, gloas)
+
+	reg := r.Registration
+	if reg.Registered {
+		fmt.Fprintf(tw, "builder\t%s index %d, balance %d gwei, active %v\n",
+			reg.BuilderPubkey, reg.BuilderIndex, reg.BalanceGwei, reg.Active)
+	} else {
+		fmt.Fprintf(tw, "builder\t%s not registered\n", reg.BuilderPubkey)
+	}
+
+	for _, wallet := range r.Wallets {
+		balance := wallet.BalanceWei + " wei"
+		if wallet.Error != "" {
+			balance = "error: " + wallet.Error
+		}
+
+		fmt.Fprintf(tw, "wallet %s\t%s %s\n", wallet.Label, wallet.Address, balance)
+	}
+
+	t := r.Timing
+	fmt.Fprintf(tw, "timing\tslot %dms, build %dms (+%dms), bids %dms..%dms every %dms, reveal %dms\n",
+		t.SlotTimeMs, t.BuildStartTime, t.PayloadBuildTime, t.BidStartTime, t.BidEndTime, t.BidInterval, t.RevealTime)
+
+	fmt.Fprintln(tw, "\t")
+	fmt.Fprintln(tw, "SERVICE\tSTATE\tDETAIL")
+
+	for _, svc := range r.Services {
+		state := "disabled"
+		if svc.Enabled {
+			state = "enabled"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", svc.Name, state, svc.Detail)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Warnings) == 0 {
+		fmt.Fprintln(w, "\nno warnings")
+		return nil
+	}
+
+	fmt.Fprintf(w, "\n%d warning(s):\n", len(r.Warnings))
+
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(w, "  - %s\n", warning)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package probe
+
+// Features the capability check can disable at runtime.
+const (
+	FeatureHeadVotes           = "head_vote_tracking"
+	FeatureP2PBidding          = "p2p_bidding"
+	FeatureProposerPreferences = "proposer_preferences"
+)
+
+// Degradation is a feature disabled because the beacon node lacks one of its
+// prerequisites.
+type Degradation struct {
+	Feature          string   `json:"feature"`
+	Effect           string   `json:"effect"`
+	MissingTopics    []string `json:"missing_topics,omitempty"`
+	MissingEndpoints []string `json:"missing_endpoints,omitempty"`
+}
+
+// Degradations is the set of features disabled for one beacon node.
+type Degradations []Degradation
+
+// Has reports whether feature is disabled.
+func (d Degradations) Has(feature string) bool {
+	for _, degradation := range d {
+		if degradation.Feature == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Topics returns the SSE topics of the disabled features the node does not
+// serve, to be dropped from the event stream.
+func (d Degradations) Topics() []string {
+	var topics []string
+
+	for _, degradation := range d {
+		topics = append(topics, degradation.MissingTopics...)
+	}
+
+	return topics
+}
+
+// featureRequirements are the topics and endpoints each optional feature is
+// built on; gloas features are only evaluated when the Gloas fork is
+// scheduled (they do not run otherwise).
+var featureRequirements = []struct {
+	feature   string
+	effect    string
+	topics    []string
+	endpoints []string
+	gloas     bool
+}{
+	{
+		feature: FeatureHeadVotes,
+		effect:  "head votes are not tracked; vote-gated reveals fall back to the time gate",
+		topics:  []string{"single_attestation"},
+	},
+	{
+		feature:   FeatureP2PBidding,
+		effect:    "p2p bidding is disabled",
+		topics:    []string{"execution_payload_bid"},
+		endpoints: []string{"bid submission"},
+		gloas:     true,
+	},
+	{
+		feature: FeatureProposerPreferences,
+		effect:  "proposer preferences are not received",
+		topics:  []string{"proposer_preferences"},
+		gloas:   true,
+	},
+}
+
+// Degradations lists the optional features to disable for the node. Only
+// checks the node answered as unsupported count: a transient error keeps the
+// feature enabled.
+func (r *Report) Degradations(gloas bool) Degradations {
+	unsupported := func(checks []Check, names []string) []string {
+		var missing []string
+
+		for _, name := range names {
+			for _, c := range checks {
+				if c.Name == name && c.Result == ResultUnsupported {
+					missing = append(missing, name)
+				}
+			}
+		}
+
+		return missing
+	}
+
+	var degradations Degradations
+
+	for _, req := range featureRequirements {
+		if req.gloas && !gloas {
+			continue
+		}
+
+		topics := unsupported(r.Topics, req.topics)
+		endpoints := unsupported(r.Endpoints, req.endpoints)
+
+		if len(topics) == 0 && len(endpoints) == 0 {
+			continue
+		}
+
+		degradations = append(degradations, Degradation{
+			Feature:          req.feature,
+			Effect:           req.effect,
+			MissingTopics:    topics,
+			MissingEndpoints: endpoints,
+		})
+	}
+
+	return degradations
+}
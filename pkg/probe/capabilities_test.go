@@ -0,0 +1,45 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportDegradations(t *testing.T) {
+	report := &Report{
+		Topics: []Check{
+			{Name: "head", Result: ResultSupported},
+			{Name: "execution_payload_bid", Result: ResultSupported},
+			{Name: "single_attestation", Result: ResultUnsupported},
+			{Name: "proposer_preferences", Result: ResultError},
+		},
+		Endpoints: []Check{
+			{Name: "bid submission", Result: ResultUnsupported},
+		},
+	}
+
+	degradations := report.Degradations(true)
+	assert.Equal(t, Degradations{
+		{
+			Feature:       FeatureHeadVotes,
+			Effect:        "head votes are not tracked; vote-gated reveals fall back to the time gate",
+			MissingTopics: []string{"single_attestation"},
+		},
+		{
+			Feature:          FeatureP2PBidding,
+			Effect:           "p2p bidding is disabled",
+			MissingEndpoints: []string{"bid submission"},
+		},
+	}, degradations, "a probe error keeps proposer preferences enabled")
+	assert.True(t, degradations.Has(FeatureP2PBidding))
+	assert.False(t, degradations.Has(FeatureProposerPreferences))
+	assert.Equal(t, []string{"single_attestation"}, degradations.Topics())
+
+	// Before Gloas is scheduled only the fork-independent features count.
+	degradations = report.Degradations(false)
+	assert.Len(t, degradations, 1)
+	assert.True(t, degradations.Has(FeatureHeadVotes))
+
+	assert.Empty(t, (&Report{}).Degradations(true))
+}
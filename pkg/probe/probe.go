@@ -0,0 +1,430 @@
+// Package probe checks a beacon node for the APIs buildoor depends on: the
+// SSE topics of the event stream, the ePBS bid and payload envelope endpoints,
+// the builder registry in the beacon state, and the fork schedule. The result
+// is a compatibility report for the `buildoor probe` command.
+//
+// Probes never submit valid objects: submission endpoints are called with an
+// empty JSON body, and a validation error (400) proves the route exists.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// Check results.
+const (
+	ResultSupported   = "supported"
+	ResultUnsupported = "unsupported"
+	ResultError       = "error"
+)
+
+// Fork states.
+const (
+	ForkActive      = "active"
+	ForkScheduled   = "scheduled"
+	ForkUnscheduled = "unscheduled"
+)
+
+// requiredTopics are the SSE topics buildoor cannot build without.
+var requiredTopics = map[string]bool{
+	"head":               true,
+	"payload_attributes": true,
+}
+
+// Check is the outcome of probing one topic or endpoint.
+type Check struct {
+	Name     string `json:"name"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Required bool   `json:"required"`
+	Status   int    `json:"status,omitempty"` // HTTP status, 0 when no response
+	Result   string `json:"result"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// Fork is one fork of the node's spec.
+type Fork struct {
+	Name  string `json:"name"`
+	Epoch uint64 `json:"epoch"` // math.MaxUint64 = not scheduled
+	State string `json:"state"`
+}
+
+// Report is the compatibility matrix of one beacon node.
+type Report struct {
+	URL          string  `json:"url"`
+	Version      string  `json:"version"`
+	CurrentEpoch uint64  `json:"current_epoch"`
+	Forks        []Fork  `json:"forks"`
+	Topics       []Check `json:"topics"`
+	Endpoints    []Check `json:"endpoints"`
+}
+
+// Missing lists what buildoor needs but the node lacks: required checks that
+// did not pass and an unscheduled Gloas (ePBS) fork.
+func (r *Report) Missing() []string {
+	var missing []string
+
+	for _, checks := range [][]Check{r.Topics, r.Endpoints} {
+		for _, c := range checks {
+			if c.Required && c.Result != ResultSupported {
+				missing = append(missing, c.Name)
+			}
+		}
+	}
+
+	gloas := false
+
+	for _, f := range r.Forks {
+		if f.Name == version.DataVersionGloas.String() && f.State != ForkUnscheduled {
+			gloas = true
+		}
+	}
+
+	if !gloas {
+		missing = append(missing, "gloas fork")
+	}
+
+	return missing
+}
+
+// Prober probes one beacon node.
+type Prober struct {
+	url    string
+	client *http.Client
+}
+
+// NewProber creates a prober for the beacon node at baseURL; timeout bounds
+// every single request.
+func NewProber(baseURL string, timeout time.Duration) *Prober {
+	return &Prober{
+		url:    strings.TrimRight(baseURL, "/"),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Run probes the node. It fails only when the node's version, spec or genesis
+// cannot be read; every other problem is recorded in the report.
+func (p *Prober) Run(ctx context.Context) (*Report, error) {
+	report := &Report{URL: p.url}
+
+	var nodeVersion struct {
+		Data struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+
+	if err := p.getJSON(ctx, "/eth/v1/node/version", &nodeVersion); err != nil {
+		return nil, err
+	}
+
+	report.Version = nodeVersion.Data.Version
+
+	if err := p.probeForks(ctx, report); err != nil {
+		return nil, err
+	}
+
+	for _, topic := range beacon.StreamTopics() {
+		report.Topics = append(report.Topics, p.probeTopic(ctx, topic))
+	}
+
+	report.Endpoints = []Check{
+		p.probeSubmission(ctx, "bid submission", "/eth/v1/beacon/execution_payload_bids", true),
+		p.probeSubmission(ctx, "payload envelope submission", "/eth/v1/beacon/execution_payload_envelopes", true),
+		p.probeGet(ctx, "payload envelope fetch", "/eth/v1/beacon/execution_payload_envelopes/head", false),
+		p.probeBuilderRegistry(ctx),
+		p.probeGet(ctx, "expected withdrawals", "/eth/v1/builder/states/head/expected_withdrawals", false),
+	}
+
+	return report, nil
+}
+
+// probeForks reads the fork schedule from the spec and places the current
+// epoch (from genesis) in it.
+func (p *Prober) probeForks(ctx context.Context, report *Report) error {
+	var spec struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+
+	if err := p.getJSON(ctx, "/eth/v1/config/spec", &spec); err != nil {
+		return err
+	}
+
+	var genesis struct {
+		Data struct {
+			GenesisTime string `json:"genesis_time"`
+		} `json:"data"`
+	}
+
+	if err := p.getJSON(ctx, "/eth/v1/beacon/genesis", &genesis); err != nil {
+		return err
+	}
+
+	specUint := func(key string) (uint64, error) {
+		var raw string
+		if err := json.Unmarshal(spec.Data[key], &raw); err != nil {
+			return 0, fmt.Errorf("spec value %s missing or not a string", key)
+		}
+
+		return strconv.ParseUint(raw, 10, 64)
+	}
+
+	genesisTime, err := strconv.ParseInt(genesis.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid genesis time %q: %w", genesis.Data.GenesisTime, err)
+	}
+
+	secondsPerSlot, err := specUint("SECONDS_PER_SLOT")
+	if err != nil {
+		return err
+	}
+
+	slotsPerEpoch, err := specUint("SLOTS_PER_EPOCH")
+	if err != nil {
+		return err
+	}
+
+	if secondsPerSlot == 0 || slotsPerEpoch == 0 {
+		return fmt.Errorf("spec has a zero slot duration or epoch length")
+	}
+
+	if elapsed := time.Now().Unix() - genesisTime; elapsed > 0 {
+		report.CurrentEpoch = uint64(elapsed) / secondsPerSlot / slotsPerEpoch
+	}
+
+	for key := range spec.Data {
+		name, ok := strings.CutSuffix(key, "_FORK_EPOCH")
+		if !ok {
+			continue
+		}
+
+		epoch, err := specUint(key)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+
+		fork := Fork{Name: strings.ToLower(name), Epoch: epoch}
+
+		switch {
+		case epoch == math.MaxUint64:
+			fork.State = ForkUnscheduled
+		case epoch <= report.CurrentEpoch:
+			fork.State = ForkActive
+		default:
+			fork.State = ForkScheduled
+		}
+
+		report.Forks = append(report.Forks, fork)
+	}
+
+	sort.Slice(report.Forks, func(i, j int) bool {
+		if report.Forks[i].Epoch != report.Forks[j].Epoch {
+			return report.Forks[i].Epoch < report.Forks[j].Epoch
+		}
+
+		return report.Forks[i].Name < report.Forks[j].Name
+	})
+
+	return nil
+}
+
+// probeTopic opens the topic's event stream and closes it once the node
+// answered: 200 accepts the subscription, 400 rejects an unknown topic.
+func (p *Prober) probeTopic(ctx context.Context, topic string) Check {
+	check := Check{
+		Name:     topic,
+		Method:   http.MethodGet,
+		Path:     "/eth/v1/events?topics=" + url.QueryEscape(topic),
+		Required: requiredTopics[topic],
+	}
+
+	resp, err := p.do(ctx, check.Method, check.Path, nil, "text/event-stream")
+	if err != nil {
+		return check.failed(err)
+	}
+	defer resp.Body.Close()
+
+	check.Status = resp.StatusCode
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		check.Result = ResultSupported
+	case resp.StatusCode == http.StatusBadRequest:
+		check.Result = ResultUnsupported
+		check.Detail = errorMessage(resp)
+	default:
+		check.Result = ResultError
+		check.Detail = errorMessage(resp)
+	}
+
+	return check
+}
+
+// probeSubmission posts an empty object: a rejected body (400, 415, 422)
+// proves the route, a missing route answers 404, 405 or 501.
+func (p *Prober) probeSubmission(ctx context.Context, name, path string, required bool) Check {
+	check := Check{Name: name, Method: http.MethodPost, Path: path, Required: required}
+
+	resp, err := p.do(ctx, check.Method, check.Path, strings.NewReader("{}"), "application/json")
+	if err != nil {
+		return check.failed(err)
+	}
+	defer resp.Body.Close()
+
+	check.Status = resp.StatusCode
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusBadRequest,
+		http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		check.Result = ResultSupported
+	default:
+		check.classifyFailure(resp)
+	}
+
+	return check
+}
+
+// probeGet requests a read endpoint at head.
+func (p *Prober) probeGet(ctx context.Context, name, path string, required bool) Check {
+	check := Check{Name: name, Method: http.MethodGet, Path: path, Required: required}
+
+	resp, err := p.do(ctx, check.Method, check.Path, nil, "application/json")
+	if err != nil {
+		return check.failed(err)
+	}
+	defer resp.Body.Close()
+
+	check.Status = resp.StatusCode
+
+	if resp.StatusCode == http.StatusOK {
+		check.Result = ResultSupported
+	} else {
+		check.classifyFailure(resp)
+	}
+
+	return check
+}
+
+// probeBuilderRegistry requests the head state, which carries the builder
+// registry from Gloas on. Only the consensus version header is inspected; the
+// state itself is not downloaded.
+func (p *Prober) probeBuilderRegistry(ctx context.Context) Check {
+	check := Check{
+		Name:     "builder registry",
+		Method:   http.MethodGet,
+		Path:     "/eth/v2/debug/beacon/states/head",
+		Required: true,
+	}
+
+	resp, err := p.do(ctx, check.Method, check.Path, nil, "application/octet-stream")
+	if err != nil {
+		return check.failed(err)
+	}
+	defer resp.Body.Close()
+
+	check.Status = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		check.classifyFailure(resp)
+		return check
+	}
+
+	stateVersion := resp.Header.Get("Eth-Consensus-Version")
+
+	fork, err := version.DataVersionFromString(stateVersion)
+
+	switch {
+	case err != nil:
+		check.Result = ResultError
+		check.Detail = fmt.Sprintf("unknown state version %q", stateVersion)
+	case fork < version.DataVersionGloas:
+		check.Result = ResultUnsupported
+		check.Detail = fmt.Sprintf("head state is %s, the registry starts at gloas", stateVersion)
+	default:
+		check.Result = ResultSupported
+	}
+
+	return check
+}
+
+func (p *Prober) do(ctx context.Context, method, path string, body io.Reader, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", accept)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return p.client.Do(req)
+}
+
+func (p *Prober) getJSON(ctx context.Context, path string, out any) error {
+	resp, err := p.do(ctx, http.MethodGet, path, nil, "application/json")
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d: %s", path, resp.StatusCode, errorMessage(resp))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c Check) failed(err error) Check {
+	c.Result = ResultError
+	c.Detail = err.Error()
+
+	return c
+}
+
+// classifyFailure maps a missing route (404, 405, 501) to unsupported and
+// anything else to an error.
+func (c *Check) classifyFailure(resp *http.Response) {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		c.Result = ResultUnsupported
+	default:
+		c.Result = ResultError
+	}
+
+	c.Detail = errorMessage(resp)
+}
+
+// errorMessage extracts the beacon API error message of a response, or its
+// raw (truncated) body.
+func errorMessage(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+
+	return strings.TrimSpace(string(body))
+}
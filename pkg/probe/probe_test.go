@@ -0,0 +1,107 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNode(t *testing.T, stateVersion string) *httptest.Server {
+	t.Helper()
+
+	// Genesis 100 epochs (of 4 slots of 1s) ago.
+	genesis := time.Now().Add(-400 * time.Second).Unix()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/node/version", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"data":{"version":"Lighthouse/v9.9.9"}}`)
+	})
+	mux.HandleFunc("GET /eth/v1/config/spec", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"1","SLOTS_PER_EPOCH":"4",`+
+			`"FULU_FORK_EPOCH":"0","GLOAS_FORK_EPOCH":"200","HEZE_FORK_EPOCH":"18446744073709551615",`+
+			`"BLOB_SCHEDULE":[{"EPOCH":"0"}]}}`)
+	})
+	mux.HandleFunc("GET /eth/v1/beacon/genesis", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"genesis_time":"%d"}}`, genesis)
+	})
+	mux.HandleFunc("GET /eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("topics") == "proposer_preferences" {
+			http.Error(w, `{"code":400,"message":"invalid topic"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("POST /eth/v1/beacon/execution_payload_bids", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"code":400,"message":"invalid bid"}`, http.StatusBadRequest)
+	})
+	mux.HandleFunc("GET /eth/v2/debug/beacon/states/head", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Eth-Consensus-Version", stateVersion)
+		_, _ = w.Write(bytes.Repeat([]byte{0}, 1024))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProberRun(t *testing.T) {
+	srv := newTestNode(t, "fulu")
+
+	report, err := NewProber(srv.URL+"/", time.Second).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Lighthouse/v9.9.9", report.Version)
+	assert.Equal(t, uint64(100), report.CurrentEpoch)
+	assert.Equal(t, []Fork{
+		{Name: "fulu", Epoch: 0, State: ForkActive},
+		{Name: "gloas", Epoch: 200, State: ForkScheduled},
+		{Name: "heze", Epoch: 18446744073709551615, State: ForkUnscheduled},
+	}, report.Forks)
+
+	results := func(checks []Check) map[string]string {
+		out := make(map[string]string, len(checks))
+		for _, c := range checks {
+			out[c.Name] = c.Result
+		}
+
+		return out
+	}
+
+	topics := results(report.Topics)
+	assert.Equal(t, ResultSupported, topics["head"])
+	assert.Equal(t, ResultSupported, topics["payload_attributes"])
+	assert.Equal(t, ResultUnsupported, topics["proposer_preferences"])
+
+	endpoints := results(report.Endpoints)
+	assert.Equal(t, ResultSupported, endpoints["bid submission"], "a rejected empty bid proves the route")
+	assert.Equal(t, ResultUnsupported, endpoints["payload envelope submission"])
+	assert.Equal(t, ResultUnsupported, endpoints["payload envelope fetch"])
+	assert.Equal(t, ResultUnsupported, endpoints["builder registry"], "pre-Gloas state")
+
+	assert.Equal(t, []string{"payload envelope submission", "builder registry"}, report.Missing())
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	assert.Contains(t, out.String(), "incompatible, missing: payload envelope submission, builder registry")
+}
+
+func TestProberRunUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	_, err := NewProber(srv.URL, time.Second).Run(context.Background())
+	require.ErrorContains(t, err, "/eth/v1/node/version returned status 404")
+}
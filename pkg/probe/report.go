@@ -0,0 +1,61 @@
+package probe
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteText renders the report as a human-readable compatibility matrix.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "beacon node: %s  version: %s  current epoch: %d\n\n", r.URL, r.Version, r.CurrentEpoch)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FORK\tEPOCH\tSTATE")
+
+	for _, f := range r.Forks {
+		epoch := "-"
+		if f.Epoch != math.MaxUint64 {
+			epoch = fmt.Sprintf("%d", f.Epoch)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.Name, epoch, f.State)
+	}
+
+	fmt.Fprintln(tw, "\t\t")
+	writeChecks(tw, "TOPIC", r.Topics)
+	fmt.Fprintln(tw, "\t\t")
+	writeChecks(tw, "ENDPOINT", r.Endpoints)
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if missing := r.Missing(); len(missing) > 0 {
+		fmt.Fprintf(w, "\nincompatible, missing: %s\n", strings.Join(missing, ", "))
+	} else {
+		fmt.Fprintln(w, "\ncompatible")
+	}
+
+	return nil
+}
+
+func writeChecks(tw io.Writer, title string, checks []Check) {
+	fmt.Fprintf(tw, "%s\tREQUEST\tRESULT\tSTATUS\tDETAIL\n", title)
+
+	for _, c := range checks {
+		name := c.Name
+		if c.Required {
+			name += " *"
+		}
+
+		status := "-"
+		if c.Status != 0 {
+			status = fmt.Sprintf("%d", c.Status)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s %s\t%s\t%s\t%s\n", name, c.Method, c.Path, c.Result, status, c.Detail)
+	}
+}
@@ -0,0 +1,617 @@
+// Package relay_proxy turns buildoor into an observability tap for relay
+// traffic on devnets: validator clients (or their mev-boost) point at
+// {buildoor}/relay-proxy instead of a relay, every Builder API request is
+// forwarded to all configured relays in parallel, and each relay's answer is
+// recorded locally. The proposer sees the best relay header, exactly as with
+// mev-boost.
+package relay_proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+	"github.com/ethpandaops/buildoor/version"
+)
+
+const (
+	// PathPrefix is the route prefix validator clients point their builder
+	// (mev-boost relay) URL at.
+	PathPrefix = "/relay-proxy"
+
+	// Per-request relay deadlines (the mev-boost defaults): a header later
+	// than this is useless to the proposer.
+	statusTimeout      = 2 * time.Second
+	registerTimeout    = 3 * time.Second
+	getHeaderTimeout   = 950 * time.Millisecond
+	submitBlockTimeout = 4 * time.Second
+
+	// maxBodyBytes caps request and relay response bodies.
+	maxBodyBytes = 10 << 20
+
+	// maxExchanges is how many recorded exchanges are kept.
+	maxExchanges = 1024
+
+	// maxHeaderRelays bounds the block hash → serving relay map used to
+	// route blinded block submissions.
+	maxHeaderRelays = 256
+)
+
+// Exchange kinds.
+const (
+	ExchangeStatus             = "status"
+	ExchangeRegisterValidators = "register_validators"
+	ExchangeGetHeader          = "get_header"
+	ExchangeSubmitBlindedBlock = "submit_blinded_block"
+)
+
+// Exchange is one forwarded request and one relay's answer to it.
+type Exchange struct {
+	ID            uint64    `json:"id"`
+	Kind          string    `json:"kind"`
+	Relay         string    `json:"relay"`
+	Slot          uint64    `json:"slot,omitempty"`
+	Pubkey        string    `json:"pubkey,omitempty"`        // proposer pubkey (get_header)
+	Registrations int       `json:"registrations,omitempty"` // registrations in the request (register_validators)
+	StatusCode    int       `json:"status_code"`             // 0 = no response
+	DurationMs    int64     `json:"duration_ms"`
+	BlockHash     string    `json:"block_hash,omitempty"`
+	ValueWei      string    `json:"value_wei,omitempty"`
+	Selected      bool      `json:"selected"` // this relay's answer was returned to the validator client
+	Error         string    `json:"error,omitempty"`
+	At            time.Time `json:"at"`
+}
+
+// RelayStatus is the running tally of one configured relay.
+type RelayStatus struct {
+	URL       string     `json:"url"`
+	Requests  uint64     `json:"requests"`
+	Failures  uint64     `json:"failures"`
+	LastSeen  *time.Time `json:"last_seen,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// View is the recorded relay traffic: the relays and the latest exchanges,
+// newest first.
+type View struct {
+	Relays    []RelayStatus `json:"relays"`
+	Exchanges []Exchange    `json:"exchanges"`
+}
+
+// relayResponse is one relay's raw answer to a forwarded request.
+type relayResponse struct {
+	relay    *RelayStatus
+	status   int
+	header   http.Header
+	body     []byte
+	err      error
+	duration time.Duration
+}
+
+// Service forwards Builder API requests to the configured relays and records
+// every exchange.
+type Service struct {
+	client *http.Client
+
+	mu           sync.Mutex
+	relays       []*RelayStatus
+	exchanges    []Exchange // ring, oldest first
+	nextID       uint64
+	headerRelays map[string]string // block hash → relay whose header was selected
+	headerOrder  []string
+
+	dispatcher utils.Dispatcher[*Exchange]
+	log        logrus.FieldLogger
+
+	userAgent string // identifies this builder to the relays; set via SetIdentity
+}
+
+// NewService creates the relay proxy for the configured relays.
+func NewService(cfg *config.RelayProxyConfig, log logrus.FieldLogger) *Service {
+	relays := make([]*RelayStatus, 0, len(cfg.Relays))
+	for _, url := range cfg.Relays {
+		relays = append(relays, &RelayStatus{URL: strings.TrimRight(url, "/")})
+	}
+
+	return &Service{
+		client:       &http.Client{},
+		relays:       relays,
+		headerRelays: make(map[string]string, maxHeaderRelays),
+		log:          log.WithField("component", "relay-proxy"),
+	}
+}
+
+// SetIdentity identifies this builder to the relays: every forwarded request
+// carries a "buildoor/<version> (<name>; <url>; <contact>)" User-Agent (empty
+// identity fields are left out).
+func (s *Service) SetIdentity(identity *config.IdentityConfig) {
+	var details []string
+
+	for _, field := range []string{identity.Name, identity.URL, identity.Contact} {
+		if field != "" {
+			details = append(details, field)
+		}
+	}
+
+	s.userAgent = "buildoor/" + version.GetBuildVersion()
+	if len(details) > 0 {
+		s.userAgent += " (" + strings.Join(details, "; ") + ")"
+	}
+}
+
+// RegisterRoutes mounts the proxied Builder API under PathPrefix.
+func (s *Service) RegisterRoutes(router *mux.Router) {
+	proxy := router.PathPrefix(PathPrefix).Subrouter()
+	proxy.HandleFunc("/eth/v1/builder/status", s.handleStatus).Methods(http.MethodGet)
+	proxy.HandleFunc("/eth/v1/builder/validators", s.handleRegisterValidators).Methods(http.MethodPost)
+	proxy.HandleFunc("/eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}", s.handleGetHeader).Methods(http.MethodGet)
+	proxy.HandleFunc("/eth/v1/builder/blinded_blocks", s.handleSubmitBlindedBlock).Methods(http.MethodPost)
+	proxy.HandleFunc("/eth/v2/builder/blinded_blocks", s.handleSubmitBlindedBlock).Methods(http.MethodPost)
+}
+
+// SubscribeExchanges subscribes to recorded exchanges.
+func (s *Service) SubscribeExchanges(capacity int, blocking bool) *utils.Subscription[*Exchange] {
+	return s.dispatcher.Subscribe(capacity, blocking)
+}
+
+// GetView returns the relays and up to limit latest exchanges (0 = all kept).
+func (s *Service) GetView(limit int) *View {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	view := &View{
+		Relays:    make([]RelayStatus, 0, len(s.relays)),
+		Exchanges: make([]Exchange, 0, len(s.exchanges)),
+	}
+
+	for _, relay := range s.relays {
+		view.Relays = append(view.Relays, *relay)
+	}
+
+	for i := len(s.exchanges) - 1; i >= 0; i-- {
+		if limit > 0 && len(view.Exchanges) >= limit {
+			break
+		}
+
+		view.Exchanges = append(view.Exchanges, s.exchanges[i])
+	}
+
+	return view
+}
+
+// handleStatus answers 200 when any relay is up.
+func (s *Service) handleStatus(w http.ResponseWriter, r *http.Request) {
+	responses := s.forward(r.Context(), s.targets(""), http.MethodGet, relayPath(r), nil, nil, statusTimeout)
+
+	ok := false
+
+	for _, resp := range responses {
+		selected := !ok && resp.status == http.StatusOK
+		ok = ok || selected
+
+		s.record(resp, &Exchange{Kind: ExchangeStatus, Selected: selected})
+	}
+
+	if !ok {
+		writeError(w, http.StatusServiceUnavailable, "no relay available")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleRegisterValidators forwards a registration list to every relay and
+// succeeds when any relay accepted it.
+func (s *Service) handleRegisterValidators(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+
+	count := 0
+	if regs := []json.RawMessage{}; json.Unmarshal(body, &regs) == nil {
+		count = len(regs)
+	}
+
+	responses := s.forward(r.Context(), s.targets(""), http.MethodPost, relayPath(r), forwardHeaders(r), body,
+		registerTimeout)
+
+	var accepted *relayResponse
+
+	for _, resp := range responses {
+		selected := accepted == nil && resp.status == http.StatusOK
+		if selected {
+			accepted = resp
+		}
+
+		s.record(resp, &Exchange{Kind: ExchangeRegisterValidators, Registrations: count, Selected: selected})
+	}
+
+	if accepted == nil {
+		writeRelayFailure(w, responses)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// headerResponse is the part of a getHeader JSON response the proxy reads.
+type headerResponse struct {
+	Data struct {
+		Message struct {
+			Header struct {
+				BlockHash string `json:"block_hash"`
+			} `json:"header"`
+			Value string `json:"value"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// handleGetHeader asks every relay for a header and returns the most
+// valuable one; 204 when no relay has a bid.
+func (s *Service) handleGetHeader(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	slot, _ := strconv.ParseUint(vars["slot"], 10, 64)
+
+	// Relay answers are parsed, so they are requested as JSON regardless of
+	// the validator client's preference.
+	header := http.Header{"Accept": []string{"application/json"}}
+
+	responses := s.forward(r.Context(), s.targets(""), http.MethodGet, relayPath(r), header, nil, getHeaderTimeout)
+
+	var (
+		best      *relayResponse
+		bestValue *big.Int
+		bestHash  string
+	)
+
+	exchanges := make([]*Exchange, len(responses))
+
+	for i, resp := range responses {
+		exchange := &Exchange{Kind: ExchangeGetHeader, Slot: slot, Pubkey: vars["pubkey"]}
+		exchanges[i] = exchange
+
+		if resp.status != http.StatusOK {
+			continue
+		}
+
+		var decoded headerResponse
+		if err := json.Unmarshal(resp.body, &decoded); err != nil {
+			exchange.Error = "invalid header response: " + err.Error()
+			continue
+		}
+
+		value, ok := new(big.Int).SetString(decoded.Data.Message.Value, 10)
+		if !ok {
+			exchange.Error = fmt.Sprintf("invalid bid value %q", decoded.Data.Message.Value)
+			continue
+		}
+
+		exchange.BlockHash = decoded.Data.Message.Header.BlockHash
+		exchange.ValueWei = value.String()
+
+		if bestValue == nil || value.Cmp(bestValue) > 0 {
+			best, bestValue, bestHash = resp, value, exchange.BlockHash
+		}
+	}
+
+	for i, resp := range responses {
+		exchanges[i].Selected = resp == best
+		s.record(resp, exchanges[i])
+	}
+
+	if best == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.rememberHeaderRelay(bestHash, best.relay.URL)
+
+	if version := best.header.Get("Eth-Consensus-Version"); version != "" {
+		w.Header().Set("Eth-Consensus-Version", version)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(best.body)
+}
+
+// blindedBlockRequest is the part of a signed blinded beacon block the proxy
+// reads to route the submission.
+type blindedBlockRequest struct {
+	Message struct {
+		Slot string `json:"slot"`
+		Body struct {
+			ExecutionPayloadHeader struct {
+				BlockHash string `json:"block_hash"`
+			} `json:"execution_payload_header"`
+		} `json:"body"`
+	} `json:"message"`
+}
+
+// handleSubmitBlindedBlock forwards a signed blinded block to the relay whose
+// header was selected (every relay when unknown) and returns the first
+// successful answer.
+func (s *Service) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+
+	var decoded blindedBlockRequest
+
+	_ = json.Unmarshal(body, &decoded)
+
+	blockHash := decoded.Message.Body.ExecutionPayloadHeader.BlockHash
+	slot, _ := strconv.ParseUint(decoded.Message.Slot, 10, 64)
+
+	responses := s.forward(r.Context(), s.targets(blockHash), http.MethodPost, relayPath(r), forwardHeaders(r), body,
+		submitBlockTimeout)
+
+	var accepted *relayResponse
+
+	for _, resp := range responses {
+		selected := accepted == nil && resp.err == nil && resp.status >= 200 && resp.status < 300
+		if selected {
+			accepted = resp
+		}
+
+		s.record(resp, &Exchange{
+			Kind:      ExchangeSubmitBlindedBlock,
+			Slot:      slot,
+			BlockHash: blockHash,
+			Selected:  selected,
+		})
+	}
+
+	if accepted == nil {
+		writeRelayFailure(w, responses)
+		return
+	}
+
+	for _, key := range []string{"Content-Type", "Eth-Consensus-Version"} {
+		if value := accepted.header.Get(key); value != "" {
+			w.Header().Set(key, value)
+		}
+	}
+
+	w.WriteHeader(accepted.status)
+	_, _ = w.Write(accepted.body)
+}
+
+// targets returns the relays a request goes to: the relay that served the
+// header of blockHash when known, otherwise every relay.
+func (s *Service) targets(blockHash string) []*RelayStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if url, ok := s.headerRelays[blockHash]; ok && blockHash != "" {
+		for _, relay := range s.relays {
+			if relay.URL == url {
+				return []*RelayStatus{relay}
+			}
+		}
+	}
+
+	return append([]*RelayStatus(nil), s.relays...)
+}
+
+// forward sends the request to every target in parallel, each with its own
+// deadline, and returns the answers in target order.
+func (s *Service) forward(ctx context.Context, targets []*RelayStatus, method, path string, header http.Header,
+	body []byte, timeout time.Duration) []*relayResponse {
+	responses := make([]*relayResponse, len(targets))
+
+	var wg sync.WaitGroup
+
+	for i, relay := range targets {
+		wg.Add(1)
+
+		go func(i int, relay *RelayStatus) {
+			defer wg.Done()
+
+			responses[i] = s.send(ctx, relay, method, path, header, body, timeout)
+		}(i, relay)
+	}
+
+	wg.Wait()
+
+	return responses
+}
+
+// send forwards one request to one relay.
+func (s *Service) send(ctx context.Context, relay *RelayStatus, method, path string, header http.Header,
+	body []byte, timeout time.Duration) *relayResponse {
+	resp := &relayResponse{relay: relay}
+	start := time.Now()
+
+	defer func() {
+		resp.duration = time.Since(start)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, relay.URL+path, reader)
+	if err != nil {
+		resp.err = fmt.Errorf("failed to build request: %w", err)
+		return resp
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	httpResp, err := s.client.Do(req)
+	if err != nil {
+		resp.err = err
+		if errors.Is(err, context.DeadlineExceeded) {
+			resp.err = fmt.Errorf("no response within %s", timeout)
+		}
+
+		return resp
+	}
+	defer httpResp.Body.Close()
+
+	resp.status = httpResp.StatusCode
+	resp.header = httpResp.Header
+
+	resp.body, err = io.ReadAll(io.LimitReader(httpResp.Body, maxBodyBytes))
+	if err != nil {
+		resp.err = fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return resp
+}
+
+// record stores the exchange of one relay answer and fires it.
+func (s *Service) record(resp *relayResponse, exchange *Exchange) {
+	exchange.Relay = resp.relay.URL
+	exchange.StatusCode = resp.status
+	exchange.DurationMs = resp.duration.Milliseconds()
+	exchange.At = time.Now()
+
+	switch {
+	case resp.err != nil:
+		exchange.Error = resp.err.Error()
+	case exchange.Error == "" && resp.status >= 400:
+		exchange.Error = relayErrorMessage(resp)
+	}
+
+	s.mu.Lock()
+
+	s.nextID++
+	exchange.ID = s.nextID
+
+	s.exchanges = append(s.exchanges, *exchange)
+	if len(s.exchanges) > maxExchanges {
+		s.exchanges = s.exchanges[len(s.exchanges)-maxExchanges:]
+	}
+
+	resp.relay.Requests++
+	if exchange.Error != "" {
+		resp.relay.Failures++
+		resp.relay.LastError = exchange.Error
+	} else {
+		now := exchange.At
+		resp.relay.LastSeen = &now
+	}
+
+	s.mu.Unlock()
+
+	s.log.WithFields(logrus.Fields{
+		"kind":        exchange.Kind,
+		"relay":       exchange.Relay,
+		"slot":        exchange.Slot,
+		"status_code": exchange.StatusCode,
+		"duration_ms": exchange.DurationMs,
+		"selected":    exchange.Selected,
+	}).Debug("Relay exchange")
+
+	s.dispatcher.Fire(exchange)
+}
+
+// rememberHeaderRelay notes which relay served the selected header of a
+// block hash, so its blinded block submission is routed there.
+func (s *Service) rememberHeaderRelay(blockHash, relayURL string) {
+	if blockHash == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.headerRelays[blockHash]; !ok {
+		s.headerOrder = append(s.headerOrder, blockHash)
+	}
+
+	s.headerRelays[blockHash] = relayURL
+
+	for len(s.headerOrder) > maxHeaderRelays {
+		delete(s.headerRelays, s.headerOrder[0])
+		s.headerOrder = s.headerOrder[1:]
+	}
+}
+
+// relayPath returns the request path without PathPrefix, with its query.
+func relayPath(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+
+	return path
+}
+
+// forwardHeaders returns the request headers relayed to the relays.
+func forwardHeaders(r *http.Request) http.Header {
+	header := http.Header{}
+
+	for _, key := range []string{"Content-Type", "Accept", "Eth-Consensus-Version"} {
+		if value := r.Header.Get(key); value != "" {
+			header.Set(key, value)
+		}
+	}
+
+	return header
+}
+
+// relayErrorMessage extracts the message of a Builder API error response.
+func relayErrorMessage(resp *relayResponse) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(resp.body, &apiErr) == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+
+	return fmt.Sprintf("status %d", resp.status)
+}
+
+// writeRelayFailure answers with the first relay's error status (502 when no
+// relay answered at all).
+func writeRelayFailure(w http.ResponseWriter, responses []*relayResponse) {
+	for _, resp := range responses {
+		if resp.err == nil && resp.status >= 400 {
+			writeError(w, resp.status, relayErrorMessage(resp))
+			return
+		}
+	}
+
+	writeError(w, http.StatusBadGateway, "no relay accepted the request")
+}
+
+// writeError writes a Builder API error response.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    code,
+		"message": message,
+	})
+}
@@ -0,0 +1,161 @@
+package relay_proxy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+func testLogger() logrus.FieldLogger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	return log
+}
+
+// testRelay serves a getHeader bid of the given value (none when empty) and
+// counts blinded block submissions.
+type testRelay struct {
+	srv         *httptest.Server
+	blockHash   string
+	value       string
+	delay       time.Duration
+	submissions atomic.Int32
+}
+
+func newTestRelay(t *testing.T, blockHash, value string) *testRelay {
+	t.Helper()
+
+	relay := &testRelay{blockHash: blockHash, value: value}
+	relay.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/eth/v1/builder/validators":
+			w.WriteHeader(http.StatusOK)
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/builder/header/"):
+			time.Sleep(relay.delay)
+
+			if relay.value == "" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			w.Header().Set("Eth-Consensus-Version", "fulu")
+			fmt.Fprintf(w, `{"version":"fulu","data":{"message":{"header":{"block_hash":%q},"value":%q}}}`,
+				relay.blockHash, relay.value)
+		case r.URL.Path == "/eth/v2/builder/blinded_blocks":
+			relay.submissions.Add(1)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(relay.srv.Close)
+
+	return relay
+}
+
+func TestRelayProxy(t *testing.T) {
+	low := newTestRelay(t, "0xaa", "100")
+	high := newTestRelay(t, "0xbb", "2000000000000000000000")
+	empty := newTestRelay(t, "", "")
+	slow := newTestRelay(t, "0xcc", "9000000000000000000000")
+	slow.delay = getHeaderTimeout + 200*time.Millisecond
+
+	svc := NewService(&config.RelayProxyConfig{
+		Relays: []string{low.srv.URL + "/", high.srv.URL, empty.srv.URL, slow.srv.URL},
+	}, testLogger())
+
+	router := mux.NewRouter()
+	svc.RegisterRoutes(router)
+
+	proxy := httptest.NewServer(router)
+	defer proxy.Close()
+
+	resp, err := http.Post(proxy.URL+PathPrefix+"/eth/v1/builder/validators", "application/json",
+		strings.NewReader(`[{},{}]`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(proxy.URL + PathPrefix + "/eth/v1/builder/header/7/0x01/0x02")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "fulu", resp.Header.Get("Eth-Consensus-Version"))
+	require.Contains(t, string(body), `"0xbb"`, "the most valuable timely bid is served")
+
+	resp, err = http.Post(proxy.URL+PathPrefix+"/eth/v2/builder/blinded_blocks", "application/json",
+		strings.NewReader(`{"message":{"slot":"7","body":{"execution_payload_header":{"block_hash":"0xbb"}}}}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Equal(t, int32(1), high.submissions.Load())
+	require.Equal(t, int32(0), low.submissions.Load(), "the block goes to the relay that served its header")
+
+	view := svc.GetView(0)
+	require.Len(t, view.Relays, 4)
+	require.Equal(t, low.srv.URL, view.Relays[0].URL, "trailing slashes are trimmed")
+	require.Len(t, view.Exchanges, 4+4+1)
+
+	submit := view.Exchanges[0]
+	require.Equal(t, ExchangeSubmitBlindedBlock, submit.Kind)
+	require.Equal(t, uint64(7), submit.Slot)
+	require.True(t, submit.Selected)
+
+	headers := map[string]Exchange{}
+	for _, exchange := range view.Exchanges[1:5] {
+		require.Equal(t, ExchangeGetHeader, exchange.Kind)
+		headers[exchange.Relay] = exchange
+	}
+
+	require.True(t, headers[high.srv.URL].Selected)
+	require.Equal(t, "2000000000000000000000", headers[high.srv.URL].ValueWei)
+	require.False(t, headers[low.srv.URL].Selected)
+	require.Equal(t, http.StatusNoContent, headers[empty.srv.URL].StatusCode)
+	require.Contains(t, headers[slow.srv.URL].Error, "no response within")
+
+	for _, exchange := range view.Exchanges[5:] {
+		require.Equal(t, ExchangeRegisterValidators, exchange.Kind)
+		require.Equal(t, 2, exchange.Registrations)
+	}
+
+	require.Len(t, svc.GetView(2).Exchanges, 2)
+}
+
+func TestRelayProxyIdentity(t *testing.T) {
+	userAgents := make(chan string, 1)
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.Header.Get("User-Agent")
+	}))
+	defer relay.Close()
+
+	svc := NewService(&config.RelayProxyConfig{Relays: []string{relay.URL}}, testLogger())
+	svc.SetIdentity(&config.IdentityConfig{Name: "alice", Contact: "ops@example.com"})
+
+	router := mux.NewRouter()
+	svc.RegisterRoutes(router)
+
+	proxy := httptest.NewServer(router)
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL + PathPrefix + "/eth/v1/builder/status")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	userAgent := <-userAgents
+	require.True(t, strings.HasPrefix(userAgent, "buildoor/"))
+	require.True(t, strings.HasSuffix(userAgent, " (alice; ops@example.com)"), userAgent)
+}
@@ -1,8 +1,13 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	nethttp "net/http"
+	"strings"
+	"time"
 
 	eth2client "github.com/ethpandaops/go-eth2-client"
 	"github.com/ethpandaops/go-eth2-client/api"
@@ -10,8 +15,12 @@ import (
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/deneb"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
 )
 
+// executionPayloadBidsEndpoint is the beacon API bid gossip endpoint.
+const executionPayloadBidsEndpoint = "/eth/v1/beacon/execution_payload_bids"
+
 // SubmitExecutionPayloadBid submits a signed execution payload bid to the beacon node.
 // The consensus version header and body encoding (SSZ or JSON per the client's content
 // negotiation) are derived from the bid's Version by go-eth2-client.
@@ -30,6 +39,33 @@ func (c *Client) SubmitExecutionPayloadBid(ctx context.Context, bid *eth2all.Sig
 	return nil
 }
 
+// SubmitRawExecutionPayloadBid posts a JSON-encoded signed execution payload
+// bid to the beacon node as is (direct HTTP), so deliberately malformed bids
+// reach the node's validation unaltered. The error carries the node's
+// response for a rejected bid.
+func (c *Client) SubmitRawExecutionPayloadBid(ctx context.Context, fork version.DataVersion, body []byte) error {
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, c.baseURL+executionPayloadBidsEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Eth-Consensus-Version", strings.ToLower(fork.String()))
+
+	resp, err := c.httpClient(10 * time.Second).Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nethttp.StatusOK && resp.StatusCode != nethttp.StatusAccepted {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
+
 // SubmitExecutionPayloadEnvelope submits a signed execution payload envelope using the
 // stateless flow (SignedExecutionPayloadEnvelopeContents body, Eth-Execution-Payload-Blinded
 // false). The stateful/blinded flow only works when the beacon node cached the full envelope
@@ -50,20 +86,9 @@ func (c *Client) SubmitExecutionPayloadEnvelope(
 		return fmt.Errorf("client does not support execution payload envelope submission")
 	}
 
-	// Map the broadcast_validation level to the query parameter; gossip is
-	// the beacon-API default and is sent as "no parameter".
-	var validation *apiv2.BroadcastValidation
-
-	switch broadcastValidation {
-	case "", "gossip":
-	case "consensus":
-		v := apiv2.BroadcastValidationConsensus
-		validation = &v
-	case "consensus_and_equivocation":
-		v := apiv2.BroadcastValidationConsensusAndEquivocation
-		validation = &v
-	default:
-		return fmt.Errorf("invalid broadcast validation level %q", broadcastValidation)
+	validation, err := ParseBroadcastValidation(broadcastValidation)
+	if err != nil {
+		return err
 	}
 
 	typedBlobs := make([]deneb.Blob, len(blobs))
@@ -106,13 +131,15 @@ func (c *Client) GetExecutionPayloadEnvelope(
 	ctx context.Context,
 	blockID string,
 ) (*eth2all.SignedExecutionPayloadEnvelope, error) {
-	provider, ok := c.client.(eth2client.ExecutionPayloadProvider)
-	if !ok {
-		return nil, fmt.Errorf("client does not support execution payload envelope provider")
-	}
+	resp, err := fetchDecoded(ctx, c, "envelope", func(svc eth2client.Service) (*api.Response[*eth2all.SignedExecutionPayloadEnvelope], error) {
+		provider, ok := svc.(eth2client.ExecutionPayloadProvider)
+		if !ok {
+			return nil, fmt.Errorf("client does not support execution payload envelope provider")
+		}
 
-	resp, err := provider.AgnosticSignedExecutionPayloadEnvelope(ctx, &api.SignedExecutionPayloadEnvelopeOpts{
-		Block: blockID,
+		return provider.AgnosticSignedExecutionPayloadEnvelope(ctx, &api.SignedExecutionPayloadEnvelopeOpts{
+			Block: blockID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payload envelope: %w", err)
@@ -125,6 +152,27 @@ func (c *Client) GetExecutionPayloadEnvelope(
 	return resp.Data, nil
 }
 
+// ParseBroadcastValidation maps a broadcast_validation level (gossip |
+// consensus | consensus_and_equivocation) to the query parameter of the block
+// and envelope submission APIs. Gossip is the beacon-API default and is sent
+// as "no parameter" (nil).
+func ParseBroadcastValidation(level string) (*apiv2.BroadcastValidation, error) {
+	var validation apiv2.BroadcastValidation
+
+	switch level {
+	case "", "gossip":
+		return nil, nil
+	case "consensus":
+		validation = apiv2.BroadcastValidationConsensus
+	case "consensus_and_equivocation":
+		validation = apiv2.BroadcastValidationConsensusAndEquivocation
+	default:
+		return nil, fmt.Errorf("invalid broadcast validation level %q", level)
+	}
+
+	return &validation, nil
+}
+
 // SubmitVoluntaryExit submits a signed voluntary exit to the beacon node.
 func (c *Client) SubmitVoluntaryExit(ctx context.Context, exit *phase0.SignedVoluntaryExit) error {
 	submitter, ok := c.client.(eth2client.VoluntaryExitSubmitter)
@@ -143,14 +191,7 @@ func (c *Client) SubmitVoluntaryExit(ctx context.Context, exit *phase0.SignedVol
 
 // GetSignedBlock fetches a beacon block as the fork-agnostic signed block.
 func (c *Client) GetSignedBlock(ctx context.Context, blockID string) (*eth2all.SignedBeaconBlock, error) {
-	provider, ok := c.client.(eth2client.SignedBeaconBlockProvider)
-	if !ok {
-		return nil, fmt.Errorf("client does not support signed beacon block provider")
-	}
-
-	resp, err := provider.AgnosticSignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
-		Block: blockID,
-	})
+	resp, err := c.fetchSignedBeaconBlock(ctx, blockID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get beacon block: %w", err)
 	}
@@ -162,6 +203,31 @@ func (c *Client) GetSignedBlock(ctx context.Context, blockID string) (*eth2all.S
 	return resp.Data, nil
 }
 
+// GetBeaconState fetches a beacon state as the fork-agnostic state. The
+// stateID can be a state root (hex), slot number, or "head"/"finalized"/
+// "genesis"/"justified".
+func (c *Client) GetBeaconState(ctx context.Context, stateID string) (*eth2all.BeaconState, error) {
+	resp, err := fetchDecoded(ctx, c, "state", func(svc eth2client.Service) (*api.Response[*eth2all.BeaconState], error) {
+		provider, ok := svc.(eth2client.BeaconStateProvider)
+		if !ok {
+			return nil, fmt.Errorf("client does not support beacon state provider")
+		}
+
+		return provider.AgnosticBeaconState(ctx, &api.BeaconStateOpts{
+			State: stateID,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beacon state: %w", err)
+	}
+
+	if resp.Data == nil {
+		return nil, fmt.Errorf("beacon state response is nil")
+	}
+
+	return resp.Data, nil
+}
+
 // BlockAttestationEvents reduces a block's attestations to the
 // AttestationEvent shape consumed by the head vote tracker. Handles both the
 // Electra+ format (committee_bits + concatenated aggregation_bits) and the
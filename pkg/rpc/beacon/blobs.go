@@ -0,0 +1,105 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"time"
+
+	eth2client "github.com/ethpandaops/go-eth2-client"
+	"github.com/ethpandaops/go-eth2-client/api"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+)
+
+// blobSidecarsEndpoint is the separate blob sidecar publication endpoint some
+// CL client/devnet combinations require next to the block publication.
+const blobSidecarsEndpoint = "/eth/v1/beacon/blob_sidecars"
+
+// blobSidecarsRequest is the JSON body of a blob sidecar publication: the
+// block's blobs with their commitments and (cell) KZG proofs.
+type blobSidecarsRequest struct {
+	BlockRoot      string   `json:"block_root"`
+	Blobs          []string `json:"blobs"`
+	KZGCommitments []string `json:"kzg_commitments"`
+	KZGProofs      []string `json:"kzg_proofs"`
+}
+
+// GetBlobCount returns how many blobs the beacon node holds for a block
+// (/eth/v1/beacon/blobs). A block the node does not know yields 0.
+func (c *Client) GetBlobCount(ctx context.Context, blockID string) (int, error) {
+	provider, ok := c.client.(eth2client.BlobsProvider)
+	if !ok {
+		return 0, fmt.Errorf("client does not support blobs provider")
+	}
+
+	resp, err := provider.Blobs(ctx, &api.BlobsOpts{Block: blockID})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == nethttp.StatusNotFound {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to get blobs: %w", err)
+	}
+
+	return len(resp.Data), nil
+}
+
+// SubmitBlobSidecars publishes a block's blobs via the separate blob sidecar
+// endpoint (direct HTTP; go-eth2-client has no submitter for it). proofs are
+// the blobs' KZG proofs in the fork's layout (cell proofs from Fulu onwards).
+func (c *Client) SubmitBlobSidecars(ctx context.Context, fork version.DataVersion, blockRoot phase0.Root,
+	commitments []deneb.KZGCommitment, blobs []deneb.Blob, proofs []deneb.KZGProof) error {
+	body := blobSidecarsRequest{
+		BlockRoot:      fmt.Sprintf("%#x", blockRoot[:]),
+		Blobs:          make([]string, len(blobs)),
+		KZGCommitments: make([]string, len(commitments)),
+		KZGProofs:      make([]string, len(proofs)),
+	}
+
+	for i := range blobs {
+		body.Blobs[i] = fmt.Sprintf("%#x", blobs[i][:])
+	}
+
+	for i := range commitments {
+		body.KZGCommitments[i] = fmt.Sprintf("%#x", commitments[i][:])
+	}
+
+	for i := range proofs {
+		body.KZGProofs[i] = fmt.Sprintf("%#x", proofs[i][:])
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode blob sidecars: %w", err)
+	}
+
+	url := c.baseURL + blobSidecarsEndpoint
+
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Eth-Consensus-Version", fork.String())
+
+	resp, err := c.httpClient(10 * time.Second).Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nethttp.StatusOK && resp.StatusCode != nethttp.StatusAccepted {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitBlobSidecars(t *testing.T) {
+	var (
+		consensusVersion string
+		body             blobSidecarsRequest
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != blobSidecarsEndpoint {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		consensusVersion = r.Header.Get("Eth-Consensus-Version")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		if len(body.Blobs) == 0 {
+			http.Error(w, `{"code":400,"message":"no blobs"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL}
+	ctx := context.Background()
+
+	err := client.SubmitBlobSidecars(ctx, version.DataVersionFulu, phase0.Root{0xaa},
+		[]deneb.KZGCommitment{{0x01}}, []deneb.Blob{{0x02}}, []deneb.KZGProof{{0x03}, {0x04}})
+	require.NoError(t, err)
+
+	require.Equal(t, "fulu", consensusVersion)
+	require.Equal(t, "0xaa"+strings.Repeat("00", 31), body.BlockRoot)
+	require.Len(t, body.Blobs, 1)
+	require.Equal(t, "0x01"+strings.Repeat("00", 47), body.KZGCommitments[0])
+	require.Len(t, body.KZGProofs, 2)
+
+	err = client.SubmitBlobSidecars(ctx, version.DataVersionFulu, phase0.Root{}, nil, nil, nil)
+	require.ErrorContains(t, err, "status 400")
+}
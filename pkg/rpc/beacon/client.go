@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	nethttp "net/http"
@@ -31,10 +32,15 @@ type Genesis struct {
 	GenesisForkVersion    phase0.Version
 }
 
-// Client wraps the consensus layer client for beacon node interactions.
+// Client wraps the consensus layer client for beacon node interactions. A
+// client over several beacon nodes fails over between them (see nodePool).
 type Client struct {
 	client      eth2client.Service
+	jsonClient  eth2client.Service // JSON-only fallback for undecodable SSZ responses; nil when SSZ is disabled
 	baseURL     string
+	pool        *nodePool            // nil for a single beacon node
+	transport   nethttp.RoundTripper // failover transport; nil (default transport) for a single node
+	stopHealth  context.CancelFunc
 	eventStream *EventStream
 	log         logrus.FieldLogger
 }
@@ -42,33 +48,124 @@ type Client struct {
 // NewClient creates a new CL client connected to the specified beacon node.
 // The client allows delayed start so it can be created even when the beacon node
 // is not yet reachable; callers should retry API calls until the node is ready.
-func NewClient(ctx context.Context, baseURL string, log logrus.FieldLogger) (*Client, error) {
+// With preferSSZ, requests that support it negotiate SSZ (decoded per the
+// response's consensus version), and block/state/envelope fetches whose SSZ
+// response cannot be decoded are retried as JSON; otherwise JSON is enforced.
+func NewClient(ctx context.Context, baseURL string, preferSSZ bool, log logrus.FieldLogger) (*Client, error) {
+	return NewFailoverClient(ctx, []string{baseURL}, preferSSZ, log)
+}
+
+// NewFailoverClient creates a CL client over one or more beacon nodes. The
+// first URL is the primary node. With several nodes every REST request goes
+// to the active node and fails over to the others on connection errors, the
+// event streams reconnect to the active node, and background health checks
+// score the nodes and move the active node to a healthier one.
+func NewFailoverClient(ctx context.Context, baseURLs []string, preferSSZ bool, log logrus.FieldLogger) (*Client, error) {
+	if len(baseURLs) == 0 {
+		return nil, fmt.Errorf("no beacon node URL")
+	}
+
 	clientLog := log.WithField("component", "cl-client")
 
-	httpClient, err := http.New(ctx,
-		http.WithAddress(baseURL),
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURLs[0], "/"),
+		log:     clientLog,
+	}
+
+	if len(baseURLs) > 1 {
+		pool, err := newNodePool(baseURLs, clientLog)
+		if err != nil {
+			return nil, err
+		}
+
+		base := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+		base.MaxIdleConnsPerHost = 64
+
+		c.pool = pool
+		c.transport = &failoverTransport{pool: pool, primary: pool.nodes[0].url, base: base}
+		pool.onSwitch = c.followActiveNode
+
+		healthCtx, cancel := context.WithCancel(ctx)
+		c.stopHealth = cancel
+
+		go pool.runHealthChecks(healthCtx, base)
+	}
+
+	var err error
+
+	if c.client, err = c.newHTTPService(ctx, !preferSSZ); err != nil {
+		return nil, err
+	}
+
+	if preferSSZ {
+		if c.jsonClient, err = c.newHTTPService(ctx, true); err != nil {
+			return nil, err
+		}
+	}
+
+	c.eventStream = NewEventStream(c)
+
+	return c, nil
+}
+
+// newHTTPService creates a go-eth2-client HTTP service for the beacon node
+// (the primary node, failing over through the client's transport).
+func (c *Client) newHTTPService(ctx context.Context, enforceJSON bool) (eth2client.Service, error) {
+	params := []http.Parameter{
+		http.WithAddress(c.baseURL),
 		http.WithLogLevel(zerolog.WarnLevel),
-		http.WithTimeout(30*time.Second),
+		http.WithTimeout(30 * time.Second),
 		http.WithAllowDelayedStart(true),
 		http.WithCustomSpecSupport(true),
-	)
+		http.WithEnforceJSON(enforceJSON),
+	}
+
+	if c.transport != nil {
+		params = append(params, http.WithHTTPClient(&nethttp.Client{Transport: c.transport}))
+	}
+
+	httpClient, err := http.New(ctx, params...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	c := &Client{
-		client:  httpClient,
-		baseURL: baseURL,
-		log:     clientLog,
+	return httpClient, nil
+}
+
+// httpClient returns an HTTP client for direct requests against baseURL
+// (failing over like the go-eth2-client requests).
+func (c *Client) httpClient(timeout time.Duration) *nethttp.Client {
+	return &nethttp.Client{Timeout: timeout, Transport: c.transport}
+}
+
+// followActiveNode re-establishes the event streams after the active node
+// changed, so they follow it rather than stay on a lagging node.
+func (c *Client) followActiveNode() {
+	if !c.eventStream.isRunning() {
+		return
 	}
 
-	c.eventStream = NewEventStream(c)
+	if err := c.eventStream.Restart(); err != nil {
+		c.log.WithError(err).Debug("Event streams not restarted after beacon node switch")
+	}
+}
 
-	return c, nil
+// Nodes returns the health and score of every beacon node in config order;
+// nil for a single-node client.
+func (c *Client) Nodes() []NodeStatus {
+	if c.pool == nil {
+		return nil
+	}
+
+	return c.pool.Status()
 }
 
 // Close closes the client and stops the event stream.
 func (c *Client) Close() {
+	if c.stopHealth != nil {
+		c.stopHealth()
+	}
+
 	if c.eventStream != nil {
 		c.eventStream.Stop()
 	}
@@ -79,8 +176,13 @@ func (c *Client) Events() *EventStream {
 	return c.eventStream
 }
 
-// GetBaseURL returns the base URL of the beacon node.
+// GetBaseURL returns the base URL of the beacon node (the active one of a
+// failover client).
 func (c *Client) GetBaseURL() string {
+	if c.pool != nil {
+		return strings.TrimSuffix(c.pool.activeURL().String(), "/")
+	}
+
 	return c.baseURL
 }
 
@@ -103,9 +205,29 @@ func (c *Client) InitGlobalSSZSpecs(ctx context.Context) error {
 
 	dynssz.SetGlobalSpecs(resp.Data)
 
+	// Log the preset-dependent sizes the codecs resolved, so a preset mismatch
+	// (wrong roots, rejected signatures) is visible at startup.
+	fields := logrus.Fields{"preset": resp.Data["PRESET_BASE"]}
+
+	for _, name := range presetSpecValues {
+		if resolved, value, err := dynssz.GetGlobalDynSsz().ResolveSpecValue(name); err == nil && resolved {
+			fields[strings.ToLower(name)] = value
+		}
+	}
+
+	c.log.WithFields(fields).Info("SSZ codecs configured with the chain spec")
+
 	return nil
 }
 
+// presetSpecValues are preset-dependent SSZ sizes logged by InitGlobalSSZSpecs.
+var presetSpecValues = []string{
+	"SYNC_COMMITTEE_SIZE",
+	"MAX_WITHDRAWALS_PER_PAYLOAD",
+	"MAX_BLOB_COMMITMENTS_PER_BLOCK",
+	"PTC_SIZE",
+}
+
 // GetRawSpecData fetches /eth/v1/config/spec via direct HTTP, bypassing go-eth2-client.
 // Returns both a string map (for simple values) and the raw JSON map (for complex values like BLOB_SCHEDULE).
 func (c *Client) GetRawSpecData(ctx context.Context) (map[string]string, map[string]json.RawMessage, error) {
@@ -116,7 +238,7 @@ func (c *Client) GetRawSpecData(ctx context.Context) (map[string]string, map[str
 		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := (&nethttp.Client{Timeout: 10 * time.Second}).Do(req)
+	resp, err := c.httpClient(10 * time.Second).Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -156,7 +278,7 @@ func (c *Client) GetGenesis(ctx context.Context) (*Genesis, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := (&nethttp.Client{Timeout: 10 * time.Second}).Do(req)
+	resp, err := c.httpClient(10 * time.Second).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get genesis: %w", err)
 	}
@@ -275,6 +397,9 @@ type BlockInfo struct {
 	FinalitySafeExecutionBlockHash phase0.Hash32
 	ParentRoot                     phase0.Root
 	StateRoot                      phase0.Root
+	// Builder index of the block's signed execution payload bid (Gloas+;
+	// nil before, where the payload is embedded in the block).
+	BidBuilderIndex *uint64
 }
 
 // FinalityInfo contains finality checkpoint execution block hashes.
@@ -291,14 +416,7 @@ type FinalityInfo struct {
 // type's Root() routes through fastssz code generated for mainnet preset sizes,
 // so it returns wrong roots on non-mainnet presets (e.g. minimal).
 func (c *Client) GetBlockInfo(ctx context.Context, blockID string) (*BlockInfo, error) {
-	provider, ok := c.client.(eth2client.SignedBeaconBlockProvider)
-	if !ok {
-		return nil, fmt.Errorf("client does not support signed beacon block provider")
-	}
-
-	resp, err := provider.AgnosticSignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
-		Block: blockID,
-	})
+	resp, err := c.fetchSignedBeaconBlock(ctx, blockID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get beacon block: %w", err)
 	}
@@ -324,14 +442,54 @@ func (c *Client) GetBlockInfo(ctx context.Context, blockID string) (*BlockInfo,
 		return nil, fmt.Errorf("failed to get finality-safe execution block hash: %w", err)
 	}
 
-	return &BlockInfo{
+	info := &BlockInfo{
 		Slot:                           msg.Slot,
 		Root:                           root,
 		ExecutionBlockHash:             execBlockHash,
 		FinalitySafeExecutionBlockHash: finalitySafeHash,
 		ParentRoot:                     msg.ParentRoot,
 		StateRoot:                      msg.StateRoot,
-	}, nil
+	}
+
+	// agnosticExecutionBlockHash already required the bid from Gloas on.
+	if msg.Version >= version.DataVersionGloas {
+		builderIndex := uint64(msg.Body.SignedExecutionPayloadBid.Message.BuilderIndex)
+		info.BidBuilderIndex = &builderIndex
+	}
+
+	return info, nil
+}
+
+// GetSignedBeaconBlock fetches the full fork-agnostic signed beacon block at
+// the given block ID. Returns (nil, nil) when the beacon node has no block
+// for the ID (e.g. a missed slot).
+func (c *Client) GetSignedBeaconBlock(ctx context.Context, blockID string) (*all.SignedBeaconBlock, error) {
+	resp, err := c.fetchSignedBeaconBlock(ctx, blockID)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == nethttp.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get beacon block: %w", err)
+	}
+
+	return resp.Data, nil
+}
+
+// fetchSignedBeaconBlock fetches the fork-agnostic signed block for blockID,
+// retrying as JSON when an SSZ response cannot be decoded.
+func (c *Client) fetchSignedBeaconBlock(ctx context.Context, blockID string) (*api.Response[*all.SignedBeaconBlock], error) {
+	return fetchDecoded(ctx, c, "block", func(svc eth2client.Service) (*api.Response[*all.SignedBeaconBlock], error) {
+		provider, ok := svc.(eth2client.SignedBeaconBlockProvider)
+		if !ok {
+			return nil, fmt.Errorf("client does not support signed beacon block provider")
+		}
+
+		return provider.AgnosticSignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: blockID,
+		})
+	})
 }
 
 // agnosticExecutionBlockHash extracts the execution block hash from a
@@ -445,13 +603,15 @@ func (c *Client) GetFinalityInfo(ctx context.Context) (*FinalityInfo, error) {
 // GetValidatorIndexToPubkeyMap fetches the beacon state once and returns a map of validator index to pubkey.
 // Used to refresh an index→pubkey cache once per epoch instead of querying per payload build.
 func (c *Client) GetValidatorIndexToPubkeyMap(ctx context.Context, stateID string) (map[phase0.ValidatorIndex]phase0.BLSPubKey, error) {
-	provider, ok := c.client.(eth2client.BeaconStateProvider)
-	if !ok {
-		return nil, fmt.Errorf("client does not support beacon state provider")
-	}
+	resp, err := fetchDecoded(ctx, c, "state", func(svc eth2client.Service) (*api.Response[*spec.VersionedBeaconState], error) {
+		provider, ok := svc.(eth2client.BeaconStateProvider)
+		if !ok {
+			return nil, fmt.Errorf("client does not support beacon state provider")
+		}
 
-	resp, err := provider.BeaconState(ctx, &api.BeaconStateOpts{
-		State: stateID,
+		return provider.BeaconState(ctx, &api.BeaconStateOpts{
+			State: stateID,
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get beacon state: %w", err)
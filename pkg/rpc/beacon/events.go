@@ -14,9 +14,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/capella"
 	"github.com/ethpandaops/go-eth2-client/spec/gloas"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
 
 	"github.com/ethpandaops/buildoor/pkg/utils"
 )
@@ -32,6 +34,15 @@ type HeadEvent struct {
 	CurrentDutyDependentRoot  phase0.Root
 }
 
+// ProposerSlashingEvent represents a proposer_slashing event: the beacon node
+// received two conflicting signed block headers of one proposer for a slot.
+type ProposerSlashingEvent struct {
+	Slot          phase0.Slot
+	ProposerIndex phase0.ValidatorIndex
+	HeaderRoots   [2]phase0.Root // body roots of the two conflicting headers
+	ReceivedAt    time.Time
+}
+
 // headEventJSON is used for JSON unmarshaling of head events.
 type headEventJSON struct {
 	Slot                      string `json:"slot"`
@@ -56,7 +67,10 @@ type BidEvent struct {
 	ExecutionPayment   uint64
 	BlobKZGCommitments [][]byte
 	Signature          phase0.BLSSignature
-	ReceivedAt         time.Time
+	// SignedBid is the full signed bid decoded for the event's fork (nil
+	// when the fork has no bid schema or the message does not decode).
+	SignedBid  *eth2all.SignedExecutionPayloadBid
+	ReceivedAt time.Time
 }
 
 // PayloadAvailableEvent represents an execution_payload_available event (Gloas).
@@ -158,6 +172,21 @@ type proposerPreferencesEventJSON struct {
 	Data    *gloas.SignedProposerPreferences `json:"data"`
 }
 
+// signedHeaderJSON is a signed beacon block header of a proposer_slashing event.
+type signedHeaderJSON struct {
+	Message struct {
+		Slot          string `json:"slot"`
+		ProposerIndex string `json:"proposer_index"`
+		BodyRoot      string `json:"body_root"`
+	} `json:"message"`
+}
+
+// proposerSlashingEventJSON is used for JSON unmarshaling of proposer_slashing events.
+type proposerSlashingEventJSON struct {
+	SignedHeader1 signedHeaderJSON `json:"signed_header_1"`
+	SignedHeader2 signedHeaderJSON `json:"signed_header_2"`
+}
+
 // payloadAvailableEventJSON is used for JSON unmarshaling of execution_payload_available events.
 type payloadAvailableEventJSON struct {
 	Slot      string `json:"slot"`
@@ -196,7 +225,9 @@ type EventStream struct {
 	payloadAttributesDispatcher   *utils.Dispatcher[*PayloadAttributesEvent]
 	singleAttestationDispatcher   *utils.Dispatcher[*SingleAttestationEvent]
 	proposerPreferencesDispatcher *utils.Dispatcher[*gloas.SignedProposerPreferences]
+	proposerSlashingDispatcher    *utils.Dispatcher[*ProposerSlashingEvent]
 	cancelFunc                    context.CancelFunc
+	parentCtx                     context.Context // Start's context, reused by Restart
 	running                       bool
 	mu                            sync.Mutex
 	wg                            sync.WaitGroup
@@ -206,6 +237,38 @@ type EventStream struct {
 	// we always keep the latest one so the builder uses the most up-to-date data.
 	payloadAttrCache   map[phase0.Slot]*PayloadAttributesEvent
 	payloadAttrCacheMu sync.RWMutex
+
+	// Per-topic stream health (see Stats); keys fixed at construction.
+	topicStats map[string]*topicStats
+
+	// Topics the beacon node does not serve (see DisableTopics); guarded by mu.
+	disabled map[string]bool
+}
+
+// streamTopics are the SSE topics the event stream subscribes to, each on its
+// own connection, with the delay before reconnecting after an error.
+var streamTopics = []struct {
+	topic      string
+	retryDelay time.Duration
+}{
+	{"head", 5 * time.Second},
+	{"payload_attributes", 5 * time.Second},
+	{"execution_payload_bid", 30 * time.Second},
+	{"execution_payload_available", 30 * time.Second},
+	{"single_attestation", 5 * time.Second},
+	{"proposer_preferences", 5 * time.Second},
+	{"proposer_slashing", 30 * time.Second},
+}
+
+// StreamTopics returns the SSE topics the event stream subscribes to.
+func StreamTopics() []string {
+	topics := make([]string, len(streamTopics))
+
+	for i, t := range streamTopics {
+		topics[i] = t.topic
+	}
+
+	return topics
 }
 
 // NewEventStream creates a new event stream for the given client.
@@ -218,7 +281,9 @@ func NewEventStream(client *Client) *EventStream {
 		payloadAttributesDispatcher:   &utils.Dispatcher[*PayloadAttributesEvent]{},
 		singleAttestationDispatcher:   &utils.Dispatcher[*SingleAttestationEvent]{},
 		proposerPreferencesDispatcher: &utils.Dispatcher[*gloas.SignedProposerPreferences]{},
+		proposerSlashingDispatcher:    &utils.Dispatcher[*ProposerSlashingEvent]{},
 		payloadAttrCache:              make(map[phase0.Slot]*PayloadAttributesEvent, 4),
+		topicStats:                    newTopicStatsMap(),
 	}
 }
 
@@ -232,22 +297,57 @@ func (e *EventStream) Start(ctx context.Context) error {
 
 	streamCtx, cancel := context.WithCancel(ctx)
 	e.cancelFunc = cancel
+	e.parentCtx = ctx
 	e.running = true
 	e.mu.Unlock()
 
 	// Start separate goroutines for each topic
-	e.wg.Add(6)
+	for _, t := range streamTopics {
+		if e.isDisabled(t.topic) {
+			continue
+		}
 
-	go e.runTopicLoop(streamCtx, "head", 5*time.Second)
-	go e.runTopicLoop(streamCtx, "payload_attributes", 5*time.Second)
-	go e.runTopicLoop(streamCtx, "execution_payload_bid", 30*time.Second)
-	go e.runTopicLoop(streamCtx, "execution_payload_available", 30*time.Second)
-	go e.runTopicLoop(streamCtx, "single_attestation", 5*time.Second)
-	go e.runTopicLoop(streamCtx, "proposer_preferences", 5*time.Second)
+		e.wg.Add(1)
+
+		go e.runTopicLoop(streamCtx, t.topic, t.retryDelay)
+	}
 
 	return nil
 }
 
+// DisableTopics excludes topics from the stream, e.g. those a capability
+// probe found the beacon node does not serve; their subscribers then never
+// receive events instead of the topic loop retrying forever. Takes effect
+// on the next (re)start.
+func (e *EventStream) DisableTopics(topics ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.disabled == nil {
+		e.disabled = make(map[string]bool, len(topics))
+	}
+
+	for _, topic := range topics {
+		e.disabled[topic] = true
+	}
+}
+
+// isDisabled reports whether topic was excluded by DisableTopics.
+func (e *EventStream) isDisabled(topic string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.disabled[topic]
+}
+
+// isRunning reports whether the stream was started and not stopped since.
+func (e *EventStream) isRunning() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.running
+}
+
 // Stop stops the event stream.
 func (e *EventStream) Stop() {
 	e.mu.Lock()
@@ -262,6 +362,23 @@ func (e *EventStream) Stop() {
 	e.wg.Wait()
 }
 
+// Restart drops and re-establishes every topic connection under the context
+// of the last Start, e.g. after the liveness watchdog found the streams
+// silently dead. Subscriptions are kept.
+func (e *EventStream) Restart() error {
+	e.mu.Lock()
+	ctx := e.parentCtx
+	e.mu.Unlock()
+
+	if ctx == nil {
+		return fmt.Errorf("event stream was never started")
+	}
+
+	e.Stop()
+
+	return e.Start(ctx)
+}
+
 // SubscribeHead returns a subscription for head events.
 func (e *EventStream) SubscribeHead() *utils.Subscription[*HeadEvent] {
 	return e.headDispatcher.Subscribe(16, false)
@@ -295,6 +412,45 @@ func (e *EventStream) SubscribeProposerPreferences() *utils.Subscription[*gloas.
 	return e.proposerPreferencesDispatcher.Subscribe(32, false)
 }
 
+// SubscribeProposerSlashings subscribes to proposer slashing events.
+func (e *EventStream) SubscribeProposerSlashings() *utils.Subscription[*ProposerSlashingEvent] {
+	return e.proposerSlashingDispatcher.Subscribe(16, false)
+}
+
+// SubscribeHeadWithContext is SubscribeHead, unsubscribed once ctx is cancelled.
+func (e *EventStream) SubscribeHeadWithContext(ctx context.Context) *utils.Subscription[*HeadEvent] {
+	return e.SubscribeHead().BindContext(ctx)
+}
+
+// SubscribeBidsWithContext is SubscribeBids, unsubscribed once ctx is cancelled.
+func (e *EventStream) SubscribeBidsWithContext(ctx context.Context) *utils.Subscription[*BidEvent] {
+	return e.SubscribeBids().BindContext(ctx)
+}
+
+// SubscribePayloadAvailableWithContext is SubscribePayloadAvailable,
+// unsubscribed once ctx is cancelled.
+func (e *EventStream) SubscribePayloadAvailableWithContext(ctx context.Context) *utils.Subscription[*PayloadAvailableEvent] {
+	return e.SubscribePayloadAvailable().BindContext(ctx)
+}
+
+// SubscribePayloadAttributesWithContext is SubscribePayloadAttributes,
+// unsubscribed once ctx is cancelled.
+func (e *EventStream) SubscribePayloadAttributesWithContext(ctx context.Context) *utils.Subscription[*PayloadAttributesEvent] {
+	return e.SubscribePayloadAttributes().BindContext(ctx)
+}
+
+// SubscribeSingleAttestationsWithContext is SubscribeSingleAttestations,
+// unsubscribed once ctx is cancelled.
+func (e *EventStream) SubscribeSingleAttestationsWithContext(ctx context.Context) *utils.Subscription[*SingleAttestationEvent] {
+	return e.SubscribeSingleAttestations().BindContext(ctx)
+}
+
+// SubscribeProposerPreferencesWithContext is SubscribeProposerPreferences,
+// unsubscribed once ctx is cancelled.
+func (e *EventStream) SubscribeProposerPreferencesWithContext(ctx context.Context) *utils.Subscription[*gloas.SignedProposerPreferences] {
+	return e.SubscribeProposerPreferences().BindContext(ctx)
+}
+
 // GetLatestPayloadAttributes returns the latest cached payload_attributes event
 // for the given slot, or nil if none has been received.
 func (e *EventStream) GetLatestPayloadAttributes(slot phase0.Slot) *PayloadAttributesEvent {
@@ -343,6 +499,16 @@ func (e *EventStream) CleanupPayloadAttributesCache(beforeSlot phase0.Slot) {
 func (e *EventStream) runTopicLoop(ctx context.Context, topic string, retryDelay time.Duration) {
 	defer e.wg.Done()
 
+	// A panic while decoding or dispatching an event must not silently stop
+	// the topic: the loop is supervised and restarted after a backoff.
+	utils.Supervise(ctx, "beacon-events-"+topic, e.client.log, func(ctx context.Context) {
+		e.streamTopic(ctx, topic, retryDelay)
+	})
+}
+
+// streamTopic keeps an SSE connection for topic open, reconnecting after
+// errors, until ctx is cancelled.
+func (e *EventStream) streamTopic(ctx context.Context, topic string, retryDelay time.Duration) {
 	currentDelay := retryDelay
 
 	for {
@@ -354,6 +520,12 @@ func (e *EventStream) runTopicLoop(ctx context.Context, topic string, retryDelay
 
 		err := e.connectAndStreamTopic(ctx, topic)
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			e.recordReconnect(topic, err)
+
 			// Check if this is a 400 error (topic not supported)
 			if strings.Contains(err.Error(), "status 400") {
 				e.client.log.WithField("topic", topic).Debug(
@@ -391,11 +563,7 @@ func (e *EventStream) connectAndStreamTopic(ctx context.Context, topic string) e
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
 
-	httpClient := &http.Client{
-		Timeout: 0, // No timeout for SSE
-	}
-
-	resp, err := httpClient.Do(req)
+	resp, err := e.client.httpClient(0).Do(req) // No timeout for SSE
 	if err != nil {
 		return fmt.Errorf("failed to connect to event stream: %w", err)
 	}
@@ -407,6 +575,9 @@ func (e *EventStream) connectAndStreamTopic(ctx context.Context, topic string) e
 
 	e.client.log.WithField("topic", topic).Info("Connected to beacon node event stream")
 
+	e.recordConnected(topic, true)
+	defer e.recordConnected(topic, false)
+
 	return e.processStream(ctx, resp.Body)
 }
 
@@ -455,17 +626,21 @@ func (e *EventStream) processStream(ctx context.Context, body io.Reader) error {
 
 // handleEvent processes a completed SSE event.
 func (e *EventStream) handleEvent(eventType, data string) {
+	e.recordEvent(eventType)
+
 	switch eventType {
 	case "head":
 		var raw headEventJSON
 		if err := json.Unmarshal([]byte(data), &raw); err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to parse head event JSON")
+			e.recordParseFailure(eventType)
 			return
 		}
 
 		event, err := parseHeadEvent(&raw)
 		if err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to convert head event")
+			e.recordParseFailure(eventType)
 			return
 		}
 
@@ -475,15 +650,18 @@ func (e *EventStream) handleEvent(eventType, data string) {
 		var raw bidEventJSON
 		if err := json.Unmarshal([]byte(data), &raw); err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to parse bid event JSON")
+			e.recordParseFailure(eventType)
 			return
 		}
 
 		event, err := parseBidEvent(&raw)
 		if err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to convert bid event")
+			e.recordParseFailure(eventType)
 			return
 		}
 
+		event.SignedBid = decodeSignedBid(raw.Version, data)
 		event.ReceivedAt = time.Now()
 		e.bidDispatcher.Fire(event)
 
@@ -491,12 +669,14 @@ func (e *EventStream) handleEvent(eventType, data string) {
 		var raw payloadAvailableEventJSON
 		if err := json.Unmarshal([]byte(data), &raw); err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to parse payload available event JSON")
+			e.recordParseFailure(eventType)
 			return
 		}
 
 		event, err := parsePayloadAvailableEvent(&raw)
 		if err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to convert payload available event")
+			e.recordParseFailure(eventType)
 			return
 		}
 
@@ -507,12 +687,14 @@ func (e *EventStream) handleEvent(eventType, data string) {
 		var raw payloadAttributesEventJSON
 		if err := json.Unmarshal([]byte(data), &raw); err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to parse payload attributes event JSON")
+			e.recordParseFailure(eventType)
 			return
 		}
 
 		event, err := parsePayloadAttributesEvent(&raw)
 		if err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to convert payload attributes event")
+			e.recordParseFailure(eventType)
 			return
 		}
 
@@ -532,12 +714,14 @@ func (e *EventStream) handleEvent(eventType, data string) {
 		var raw singleAttestationEventJSON
 		if err := json.Unmarshal([]byte(data), &raw); err != nil {
 			e.client.log.WithError(err).Debug("Failed to parse single attestation event JSON")
+			e.recordParseFailure(eventType)
 			return
 		}
 
 		event, err := parseSingleAttestationEvent(&raw)
 		if err != nil {
 			e.client.log.WithError(err).Debug("Failed to convert single attestation event")
+			e.recordParseFailure(eventType)
 			return
 		}
 
@@ -550,11 +734,13 @@ func (e *EventStream) handleEvent(eventType, data string) {
 		var raw proposerPreferencesEventJSON
 		if err := json.Unmarshal([]byte(data), &raw); err != nil {
 			e.client.log.WithError(err).WithField("data", data).Warn("Failed to parse proposer_preferences event JSON")
+			e.recordParseFailure(eventType)
 			return
 		}
 
 		if raw.Data == nil || raw.Data.Message == nil {
 			e.client.log.Warn("Received proposer_preferences event with nil data")
+			e.recordParseFailure(eventType)
 			return
 		}
 
@@ -566,6 +752,24 @@ func (e *EventStream) handleEvent(eventType, data string) {
 
 		e.proposerPreferencesDispatcher.Fire(raw.Data)
 
+	case "proposer_slashing":
+		var raw proposerSlashingEventJSON
+		if err := json.Unmarshal([]byte(data), &raw); err != nil {
+			e.client.log.WithError(err).WithField("data", data).Warn("Failed to parse proposer slashing event JSON")
+			e.recordParseFailure(eventType)
+			return
+		}
+
+		event, err := parseProposerSlashingEvent(&raw)
+		if err != nil {
+			e.client.log.WithError(err).WithField("data", data).Warn("Failed to convert proposer slashing event")
+			e.recordParseFailure(eventType)
+			return
+		}
+
+		event.ReceivedAt = time.Now()
+		e.proposerSlashingDispatcher.Fire(event)
+
 	default:
 		e.client.log.WithField("event_type", eventType).Debug("Unknown event type")
 	}
@@ -683,6 +887,30 @@ func parseBidEvent(raw *bidEventJSON) (*BidEvent, error) {
 	}, nil
 }
 
+// decodeSignedBid decodes the full SignedExecutionPayloadBid of a bid event
+// for its fork, or returns nil.
+func decodeSignedBid(forkName, data string) *eth2all.SignedExecutionPayloadBid {
+	fork, err := version.DataVersionFromString(strings.ToLower(forkName))
+	if err != nil {
+		return nil
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &envelope); err != nil {
+		return nil
+	}
+
+	bid := &eth2all.SignedExecutionPayloadBid{Version: fork}
+	if err := bid.UnmarshalJSON(envelope.Data); err != nil {
+		return nil
+	}
+
+	return bid
+}
+
 // parseRoot parses a hex string (with 0x prefix) into a phase0.Root.
 func parseRoot(s string) (phase0.Root, error) {
 	var root phase0.Root
@@ -890,3 +1118,38 @@ func parseSingleAttestationEvent(raw *singleAttestationEventJSON) (*SingleAttest
 		BeaconBlockRoot: blockRoot,
 	}, nil
 }
+
+// parseProposerSlashingEvent converts a raw JSON proposer_slashing event to
+// the typed ProposerSlashingEvent. Both headers must name the same slot and
+// proposer.
+func parseProposerSlashingEvent(raw *proposerSlashingEventJSON) (*ProposerSlashingEvent, error) {
+	event := &ProposerSlashingEvent{}
+
+	for i, header := range []*signedHeaderJSON{&raw.SignedHeader1, &raw.SignedHeader2} {
+		slot, err := strconv.ParseUint(header.Message.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signed_header_%d slot: %w", i+1, err)
+		}
+
+		proposerIndex, err := strconv.ParseUint(header.Message.ProposerIndex, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signed_header_%d proposer_index: %w", i+1, err)
+		}
+
+		bodyRoot, err := parseRoot(header.Message.BodyRoot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signed_header_%d body_root: %w", i+1, err)
+		}
+
+		if i > 0 && (phase0.Slot(slot) != event.Slot || phase0.ValidatorIndex(proposerIndex) != event.ProposerIndex) {
+			return nil, fmt.Errorf("headers of slot %d/proposer %d and slot %d/proposer %d do not conflict",
+				event.Slot, event.ProposerIndex, slot, proposerIndex)
+		}
+
+		event.Slot = phase0.Slot(slot)
+		event.ProposerIndex = phase0.ValidatorIndex(proposerIndex)
+		event.HeaderRoots[i] = bodyRoot
+	}
+
+	return event, nil
+}
@@ -0,0 +1,171 @@
+package beacon
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	eventsReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_beacon_events_total",
+		Help: "Beacon node SSE events received, per topic.",
+	}, []string{"topic"})
+
+	eventParseFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_beacon_event_parse_failures_total",
+		Help: "Beacon node SSE events that could not be decoded, per topic.",
+	}, []string{"topic"})
+
+	eventStreamReconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_beacon_event_stream_reconnects_total",
+		Help: "Beacon node SSE stream connection failures followed by a reconnect, per topic.",
+	}, []string{"topic"})
+
+	eventStreamConnected = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "buildoor_beacon_event_stream_connected",
+		Help: "Whether the beacon node SSE stream for a topic is currently connected (1) or not (0).",
+	}, []string{"topic"})
+
+	eventLastReceived = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "buildoor_beacon_event_last_received_timestamp_seconds",
+		Help: "Unix time of the last beacon node SSE event received, per topic.",
+	}, []string{"topic"})
+)
+
+// TopicStats is a snapshot of one SSE topic's stream health. Timestamps are
+// unix ms, 0 when the event never happened.
+type TopicStats struct {
+	Topic           string `json:"topic"`
+	Connected       bool   `json:"connected"`
+	Disabled        bool   `json:"disabled,omitempty"`
+	Events          uint64 `json:"events"`
+	ParseFailures   uint64 `json:"parse_failures"`
+	Reconnects      uint64 `json:"reconnects"`
+	LastEventAt     int64  `json:"last_event_at,omitempty"`
+	LastConnectedAt int64  `json:"last_connected_at,omitempty"`
+	LastError       string `json:"last_error,omitempty"`
+	LastErrorAt     int64  `json:"last_error_at,omitempty"`
+}
+
+// topicStats tracks one topic's counters; updated from the topic loop and the
+// event handler, read by Stats.
+type topicStats struct {
+	connected       atomic.Bool
+	events          atomic.Uint64
+	parseFailures   atomic.Uint64
+	reconnects      atomic.Uint64
+	lastEventAt     atomic.Int64
+	lastConnectedAt atomic.Int64
+
+	mu          sync.Mutex
+	lastError   string
+	lastErrorAt int64
+}
+
+// newTopicStatsMap returns zeroed stats for every streamed topic. The map is
+// never written afterwards, so lookups need no locking.
+func newTopicStatsMap() map[string]*topicStats {
+	stats := make(map[string]*topicStats, len(streamTopics))
+	for _, t := range streamTopics {
+		stats[t.topic] = &topicStats{}
+	}
+
+	return stats
+}
+
+// Stats returns a snapshot of every topic's stream health, in subscription
+// order.
+func (e *EventStream) Stats() []TopicStats {
+	result := make([]TopicStats, 0, len(streamTopics))
+
+	for _, t := range streamTopics {
+		s := e.topicStats[t.topic]
+		if s == nil {
+			continue
+		}
+
+		s.mu.Lock()
+		lastError, lastErrorAt := s.lastError, s.lastErrorAt
+		s.mu.Unlock()
+
+		result = append(result, TopicStats{
+			Topic:           t.topic,
+			Connected:       s.connected.Load(),
+			Disabled:        e.isDisabled(t.topic),
+			Events:          s.events.Load(),
+			ParseFailures:   s.parseFailures.Load(),
+			Reconnects:      s.reconnects.Load(),
+			LastEventAt:     s.lastEventAt.Load(),
+			LastConnectedAt: s.lastConnectedAt.Load(),
+			LastError:       lastError,
+			LastErrorAt:     lastErrorAt,
+		})
+	}
+
+	return result
+}
+
+// recordEvent counts a received event (before decoding). Events of topics
+// that are not streamed are ignored to keep metric labels bounded.
+func (e *EventStream) recordEvent(topic string) {
+	s := e.topicStats[topic]
+	if s == nil {
+		return
+	}
+
+	now := time.Now()
+
+	s.events.Add(1)
+	s.lastEventAt.Store(now.UnixMilli())
+
+	eventsReceived.WithLabelValues(topic).Inc()
+	eventLastReceived.WithLabelValues(topic).Set(float64(now.UnixMilli()) / 1000)
+}
+
+// recordParseFailure counts an event that could not be decoded.
+func (e *EventStream) recordParseFailure(topic string) {
+	s := e.topicStats[topic]
+	if s == nil {
+		return
+	}
+
+	s.parseFailures.Add(1)
+	eventParseFailures.WithLabelValues(topic).Inc()
+}
+
+// recordConnected marks the topic's stream as connected (true) or dropped.
+func (e *EventStream) recordConnected(topic string, connected bool) {
+	s := e.topicStats[topic]
+	if s == nil {
+		return
+	}
+
+	s.connected.Store(connected)
+
+	if connected {
+		s.lastConnectedAt.Store(time.Now().UnixMilli())
+		eventStreamConnected.WithLabelValues(topic).Set(1)
+	} else {
+		eventStreamConnected.WithLabelValues(topic).Set(0)
+	}
+}
+
+// recordReconnect counts a stream failure that is followed by a reconnect.
+func (e *EventStream) recordReconnect(topic string, err error) {
+	s := e.topicStats[topic]
+	if s == nil {
+		return
+	}
+
+	s.reconnects.Add(1)
+	eventStreamReconnects.WithLabelValues(topic).Inc()
+
+	s.mu.Lock()
+	s.lastError = err.Error()
+	s.lastErrorAt = time.Now().UnixMilli()
+	s.mu.Unlock()
+}
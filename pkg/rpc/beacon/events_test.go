@@ -1,10 +1,15 @@
 package beacon
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -138,6 +143,32 @@ func TestParseSingleAttestationEvent_Invalid(t *testing.T) {
 	}
 }
 
+// TestParseProposerSlashingEvent parses the two conflicting headers and
+// rejects headers of different slots.
+func TestParseProposerSlashingEvent(t *testing.T) {
+	header := func(slot, bodyByte string) string {
+		return `{"message":{"slot":"` + slot + `","proposer_index":"7","parent_root":"0x` + zeroHex32 +
+			`","state_root":"0x` + zeroHex32 + `","body_root":"0x` + strings.Repeat(bodyByte, 32) +
+			`"},"signature":"0xabcdef"}`
+	}
+
+	var raw proposerSlashingEventJSON
+	require.NoError(t, json.Unmarshal([]byte(`{"signed_header_1":`+header("42", "01")+
+		`,"signed_header_2":`+header("42", "02")+`}`), &raw))
+
+	event, err := parseProposerSlashingEvent(&raw)
+	require.NoError(t, err)
+	assert.Equal(t, phase0.Slot(42), event.Slot)
+	assert.Equal(t, phase0.ValidatorIndex(7), event.ProposerIndex)
+	assert.NotEqual(t, event.HeaderRoots[0], event.HeaderRoots[1])
+
+	require.NoError(t, json.Unmarshal([]byte(`{"signed_header_1":`+header("42", "01")+
+		`,"signed_header_2":`+header("43", "02")+`}`), &raw))
+
+	_, err = parseProposerSlashingEvent(&raw)
+	require.ErrorContains(t, err, "do not conflict")
+}
+
 // TestInjectPayloadAttributes caches and dispatches synthesized attributes,
 // but never overwrites a slot that already has node-received attributes.
 func TestInjectPayloadAttributes(t *testing.T) {
@@ -160,3 +191,41 @@ func TestInjectPayloadAttributes(t *testing.T) {
 	require.False(t, stream.InjectPayloadAttributes(&PayloadAttributesEvent{ProposalSlot: 10}))
 	assert.Equal(t, synthesized, stream.GetLatestPayloadAttributes(10))
 }
+
+// TestEventStreamStats counts received events per topic, attributes decode
+// failures to their topic, ignores topics that are not streamed, and flags
+// disabled topics.
+func TestEventStreamStats(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	stream := NewEventStream(&Client{log: log})
+
+	root := "0x" + strings.Repeat("ab", 32)
+	body := "event: head\n" +
+		`data: {"slot":"5","block":"` + root + `","state":"` + root + `","previous_duty_dependent_root":"` + root + `","current_duty_dependent_root":"` + root + `"}` + "\n\n" +
+		"event: head\ndata: {not json}\n\n" +
+		"event: something_else\ndata: {}\n\n"
+
+	err := stream.processStream(context.Background(), strings.NewReader(body))
+	require.ErrorIs(t, err, io.EOF)
+
+	stream.recordReconnect("execution_payload_bid", errors.New("event stream returned status 503"))
+	stream.DisableTopics("proposer_preferences")
+
+	stats := make(map[string]TopicStats)
+	for _, s := range stream.Stats() {
+		stats[s.Topic] = s
+	}
+
+	require.Len(t, stats, len(streamTopics))
+	assert.Equal(t, uint64(2), stats["head"].Events)
+	assert.Equal(t, uint64(1), stats["head"].ParseFailures)
+	assert.NotZero(t, stats["head"].LastEventAt)
+	assert.Equal(t, uint64(1), stats["execution_payload_bid"].Reconnects)
+	assert.Equal(t, "event stream returned status 503", stats["execution_payload_bid"].LastError)
+	assert.Zero(t, stats["payload_attributes"].Events)
+	assert.NotContains(t, stats, "something_else")
+	assert.True(t, stats["proposer_preferences"].Disabled)
+	assert.False(t, stats["head"].Disabled)
+}
@@ -0,0 +1,406 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// nodeHealthInterval is the wait between health checks of every node.
+	nodeHealthInterval = 3 * time.Second
+	// nodeHealthTimeout bounds one node's health check.
+	nodeHealthTimeout = 2 * time.Second
+	// nodeFailoverMargin is how much better another node must score before a
+	// still-reachable active node is replaced (hysteresis against flapping).
+	nodeFailoverMargin = 30
+)
+
+// NodeStatus is the health and score of one beacon node of a failover
+// client. The URL has its credentials redacted.
+type NodeStatus struct {
+	URL                 string `json:"url"`
+	Active              bool   `json:"active"`
+	Healthy             bool   `json:"healthy"`
+	Syncing             bool   `json:"syncing"`
+	HeadSlot            uint64 `json:"head_slot"`
+	Score               int    `json:"score"`
+	ConsecutiveFailures uint64 `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	LastCheckAt         int64  `json:"last_check_at,omitempty"` // unix ms
+}
+
+// poolNode is one beacon node of the pool. Guarded by nodePool.mu.
+type poolNode struct {
+	url       *url.URL
+	healthy   bool
+	syncing   bool
+	headSlot  uint64
+	failures  uint64 // consecutive failed requests
+	lastError string
+	lastCheck time.Time
+}
+
+// nodePool tracks the beacon nodes of a failover client and which one is
+// active. Every request goes to the active node first and fails over to the
+// others in score order; health checks re-score the nodes and move the active
+// node to a healthier one.
+type nodePool struct {
+	mu       sync.RWMutex
+	nodes    []*poolNode
+	active   int
+	onSwitch func() // called (in its own goroutine) after the active node changed
+	log      logrus.FieldLogger
+}
+
+func newNodePool(baseURLs []string, log logrus.FieldLogger) (*nodePool, error) {
+	p := &nodePool{
+		nodes: make([]*poolNode, 0, len(baseURLs)),
+		log:   log,
+	}
+
+	for _, raw := range baseURLs {
+		u, err := url.Parse(strings.TrimSuffix(raw, "/"))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid beacon node URL %q", raw)
+		}
+
+		// Nodes start healthy: the first health check runs right away.
+		p.nodes = append(p.nodes, &poolNode{url: u, healthy: true})
+	}
+
+	return p, nil
+}
+
+// score rates a node from 0 (unreachable) to 100: reachable nodes lose
+// points for lagging the best head, syncing and recent request failures.
+func (n *poolNode) score(bestHead uint64) int {
+	if !n.healthy {
+		return 0
+	}
+
+	score := 100 - 10*int(min(bestHead-min(n.headSlot, bestHead), 5)) - 10*int(min(n.failures, 4))
+	if n.syncing {
+		score -= 20
+	}
+
+	return max(score, 1)
+}
+
+// bestHeadLocked returns the highest head slot any healthy node reported.
+func (p *nodePool) bestHeadLocked() uint64 {
+	var best uint64
+
+	for _, n := range p.nodes {
+		if n.healthy && n.headSlot > best {
+			best = n.headSlot
+		}
+	}
+
+	return best
+}
+
+// candidates returns the nodes in request order: the active node first,
+// then the others by descending score (config order on ties).
+func (p *nodePool) candidates() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	best := p.bestHeadLocked()
+	active := p.nodes[p.active]
+
+	others := make([]*poolNode, 0, len(p.nodes)-1)
+	for _, n := range p.nodes {
+		if n != active {
+			others = append(others, n)
+		}
+	}
+
+	sort.SliceStable(others, func(i, j int) bool {
+		return others[i].score(best) > others[j].score(best)
+	})
+
+	return append([]*poolNode{active}, others...)
+}
+
+// activeURL returns the active node's base URL.
+func (p *nodePool) activeURL() *url.URL {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.nodes[p.active].url
+}
+
+func (p *nodePool) recordSuccess(n *poolNode) {
+	p.mu.Lock()
+	n.failures = 0
+	p.mu.Unlock()
+}
+
+// recordFailure counts a failed request. A connection error marks the node
+// unhealthy until its next successful health check, so the next requests
+// go straight to a reachable node.
+func (p *nodePool) recordFailure(n *poolNode, err error, unreachable bool) {
+	p.mu.Lock()
+	n.failures++
+	n.lastError = err.Error()
+
+	if unreachable {
+		n.healthy = false
+	}
+	p.mu.Unlock()
+
+	p.reselect()
+}
+
+// reselect moves the active node to the best-scored one when the active node
+// is unreachable or scores nodeFailoverMargin below it.
+func (p *nodePool) reselect() {
+	p.mu.Lock()
+
+	best := p.bestHeadLocked()
+	current := p.nodes[p.active]
+	currentScore := current.score(best)
+
+	bestIdx, bestScore := p.active, currentScore
+
+	for i, n := range p.nodes {
+		if s := n.score(best); s > bestScore {
+			bestIdx, bestScore = i, s
+		}
+	}
+
+	if bestIdx == p.active || (currentScore > 0 && bestScore < currentScore+nodeFailoverMargin) {
+		p.mu.Unlock()
+		return
+	}
+
+	p.active = bestIdx
+	next := p.nodes[bestIdx]
+	onSwitch := p.onSwitch
+	p.mu.Unlock()
+
+	p.log.WithFields(logrus.Fields{
+		"from":       current.url.Redacted(),
+		"from_score": currentScore,
+		"to":         next.url.Redacted(),
+		"to_score":   bestScore,
+	}).Warn("Switched active beacon node")
+
+	if onSwitch != nil {
+		go onSwitch()
+	}
+}
+
+// Status returns a snapshot of every node in config order.
+func (p *nodePool) Status() []NodeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	best := p.bestHeadLocked()
+	out := make([]NodeStatus, len(p.nodes))
+
+	for i, n := range p.nodes {
+		out[i] = NodeStatus{
+			URL:                 n.url.Redacted(),
+			Active:              i == p.active,
+			Healthy:             n.healthy,
+			Syncing:             n.syncing,
+			HeadSlot:            n.headSlot,
+			Score:               n.score(best),
+			ConsecutiveFailures: n.failures,
+			LastError:           n.lastError,
+		}
+
+		if !n.lastCheck.IsZero() {
+			out[i].LastCheckAt = n.lastCheck.UnixMilli()
+		}
+	}
+
+	return out
+}
+
+// runHealthChecks checks every node each nodeHealthInterval until ctx is
+// cancelled.
+func (p *nodePool) runHealthChecks(ctx context.Context, transport nethttp.RoundTripper) {
+	ticker := time.NewTicker(nodeHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		p.checkHealth(ctx, transport)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHealth queries every node's sync status concurrently, then re-selects
+// the active node.
+func (p *nodePool) checkHealth(ctx context.Context, transport nethttp.RoundTripper) {
+	var wg sync.WaitGroup
+
+	for _, n := range p.nodes {
+		wg.Add(1)
+
+		go func(n *poolNode) {
+			defer wg.Done()
+
+			headSlot, syncing, err := querySyncStatus(ctx, transport, n.url)
+
+			p.mu.Lock()
+			n.lastCheck = time.Now()
+			n.healthy = err == nil
+
+			if err != nil {
+				n.lastError = err.Error()
+			} else {
+				n.headSlot = headSlot
+				n.syncing = syncing
+			}
+			p.mu.Unlock()
+		}(n)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() == nil {
+		p.reselect()
+	}
+}
+
+// querySyncStatus fetches /eth/v1/node/syncing from one node.
+func querySyncStatus(ctx context.Context, transport nethttp.RoundTripper, base *url.URL) (uint64, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, nodeHealthTimeout)
+	defer cancel()
+
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodGet, base.String()+"/eth/v1/node/syncing", nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := (&nethttp.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nethttp.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, false, fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data struct {
+			HeadSlot  string `json:"head_slot"`
+			IsSyncing bool   `json:"is_syncing"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, false, fmt.Errorf("failed to decode sync status: %w", err)
+	}
+
+	headSlot, err := strconv.ParseUint(result.Data.HeadSlot, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid head slot %q: %w", result.Data.HeadSlot, err)
+	}
+
+	return headSlot, result.Data.IsSyncing, nil
+}
+
+// failoverTransport sends each request, built against the primary node's
+// URL, to the pool's nodes in candidate order: a connection error or a 503
+// moves on to the next node. Requests whose body cannot be replayed are
+// only tried on the active node.
+type failoverTransport struct {
+	pool    *nodePool
+	primary *url.URL
+	base    nethttp.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *failoverTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	candidates := t.pool.candidates()
+
+	var lastErr error
+
+	for i, n := range candidates {
+		out := req.Clone(req.Context())
+		out.URL = t.rewrite(req.URL, n.url)
+		out.Host = ""
+
+		if n.url.User != nil {
+			password, _ := n.url.User.Password()
+			out.SetBasicAuth(n.url.User.Username(), password)
+		}
+
+		if i > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				break
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				break
+			}
+
+			out.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(out)
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+
+		last := i == len(candidates)-1
+
+		switch {
+		case err != nil:
+			t.pool.recordFailure(n, err, true)
+			lastErr = err
+
+			continue
+		case resp.StatusCode == nethttp.StatusServiceUnavailable && !last:
+			resp.Body.Close()
+			t.pool.recordFailure(n, fmt.Errorf("status %d", resp.StatusCode), false)
+			lastErr = fmt.Errorf("%s: status %d", n.url.Redacted(), resp.StatusCode)
+
+			continue
+		}
+
+		t.pool.recordSuccess(n)
+
+		return resp, nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no beacon node available")
+	}
+
+	return nil, lastErr
+}
+
+// rewrite points a request URL built against the primary node at node.
+func (t *failoverTransport) rewrite(reqURL, node *url.URL) *url.URL {
+	out := *reqURL
+	out.Scheme = node.Scheme
+	out.Host = node.Host
+	out.User = nil
+	out.Path = node.Path + strings.TrimPrefix(reqURL.Path, t.primary.Path)
+	out.RawPath = ""
+
+	return &out
+}
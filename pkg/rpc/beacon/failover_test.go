@@ -0,0 +1,119 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// newSyncingNode serves /eth/v1/node/syncing with the given head slot (and an
+// empty data list on every other path), recording every request path.
+func newSyncingNode(t *testing.T, headSlot uint64, syncing bool, paths *[]string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if paths != nil {
+			*paths = append(*paths, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			_, _ = fmt.Fprintf(w, `{"data":{"head_slot":"%d","sync_distance":"0","is_syncing":%t}}`, headSlot, syncing)
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newTestPoolClient(t *testing.T, urls ...string) *Client {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	pool, err := newNodePool(urls, log)
+	require.NoError(t, err)
+
+	return &Client{
+		baseURL:   urls[0],
+		pool:      pool,
+		transport: &failoverTransport{pool: pool, primary: pool.nodes[0].url, base: http.DefaultTransport},
+		log:       log,
+	}
+}
+
+func TestFailoverTransport_FailsOverOnConnectionError(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	var paths []string
+
+	up := newSyncingNode(t, 10, false, &paths)
+	client := newTestPoolClient(t, down.URL, up.URL)
+
+	_, err := client.GetExpectedWithdrawals(context.Background(), "head", 11)
+	require.NoError(t, err, "the request fails over to the reachable node")
+	require.Equal(t, []string{"/eth/v1/builder/states/head/expected_withdrawals"}, paths)
+
+	nodes := client.Nodes()
+	require.False(t, nodes[0].Healthy, "a connection error marks the node unhealthy")
+	require.Equal(t, uint64(1), nodes[0].ConsecutiveFailures)
+	require.False(t, nodes[0].Active)
+	require.True(t, nodes[1].Active, "the reachable node took over")
+	require.Equal(t, up.URL, client.GetBaseURL())
+}
+
+func TestNodePool_HealthChecksScoreNodes(t *testing.T) {
+	primary := newSyncingNode(t, 90, false, nil)
+	fallback := newSyncingNode(t, 100, false, nil)
+	client := newTestPoolClient(t, primary.URL, fallback.URL)
+
+	// Lagging the best head by 10 slots costs the primary 50 points, past
+	// the failover margin.
+	client.pool.checkHealth(context.Background(), http.DefaultTransport)
+
+	nodes := client.Nodes()
+	require.Equal(t, 50, nodes[0].Score)
+	require.Equal(t, 100, nodes[1].Score)
+	require.True(t, nodes[1].Active)
+	require.Equal(t, uint64(100), nodes[1].HeadSlot)
+	require.NotZero(t, nodes[1].LastCheckAt)
+}
+
+func TestNodePool_KeepsActiveWithinMargin(t *testing.T) {
+	primary := newSyncingNode(t, 99, false, nil)
+	fallback := newSyncingNode(t, 100, false, nil)
+	client := newTestPoolClient(t, primary.URL, fallback.URL)
+
+	client.pool.checkHealth(context.Background(), http.DefaultTransport)
+
+	nodes := client.Nodes()
+	require.Equal(t, 90, nodes[0].Score)
+	require.True(t, nodes[0].Active, "a slightly lagging active node is kept")
+}
+
+func TestFailoverTransport_RewritesPathPrefix(t *testing.T) {
+	var paths []string
+
+	node := newSyncingNode(t, 1, false, &paths)
+	client := newTestPoolClient(t, "http://127.0.0.1:1/primary", node.URL+"/beacon")
+
+	_, err := client.GetExpectedWithdrawals(context.Background(), "head", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/beacon/eth/v1/builder/states/head/expected_withdrawals"}, paths)
+}
+
+func TestNewNodePool_RejectsInvalidURL(t *testing.T) {
+	_, err := newNodePool([]string{"http://ok:5052", "not a url"}, logrus.New())
+	require.ErrorContains(t, err, "not a url")
+}
@@ -0,0 +1,47 @@
+package beacon
+
+import (
+	"context"
+	"strings"
+
+	eth2client "github.com/ethpandaops/go-eth2-client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var sszFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "buildoor_beacon_ssz_fallbacks_total",
+	Help: "Beacon API fetches retried as JSON after an SSZ response could not be decoded, per object kind.",
+}, []string{"kind"})
+
+// isDecodeError reports whether a go-eth2-client fetch failed while decoding
+// the response body (as opposed to transport or API errors, which a JSON
+// retry would not fix).
+func isDecodeError(err error) bool {
+	msg := err.Error()
+
+	return strings.Contains(msg, "failed to decode") ||
+		strings.Contains(msg, "failed to unmarshal") ||
+		strings.Contains(msg, "unhandled content type")
+}
+
+// fetchDecoded runs fetch against the primary (SSZ-preferring) beacon client
+// and, when its response could not be decoded, retries once against the
+// JSON-only client. Without SSZ enabled the primary client already speaks
+// JSON and no retry happens. kind labels the fallback metric and log.
+func fetchDecoded[T any](
+	ctx context.Context,
+	c *Client,
+	kind string,
+	fetch func(svc eth2client.Service) (T, error),
+) (T, error) {
+	result, err := fetch(c.client)
+	if err == nil || c.jsonClient == nil || ctx.Err() != nil || !isDecodeError(err) {
+		return result, err
+	}
+
+	sszFallbacks.WithLabelValues(kind).Inc()
+	c.log.WithError(err).WithField("kind", kind).Warn("Failed to decode SSZ response, retrying as JSON")
+
+	return fetch(c.jsonClient)
+}
@@ -0,0 +1,67 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	eth2client "github.com/ethpandaops/go-eth2-client"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubService is a named eth2client.Service so tests can tell the primary and
+// the JSON fallback client apart.
+type stubService struct{ name string }
+
+func (s *stubService) Name() string    { return s.name }
+func (s *stubService) Address() string { return "" }
+func (s *stubService) IsActive() bool  { return true }
+func (s *stubService) IsSynced() bool  { return true }
+
+func TestFetchDecoded(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	decodeErr := errors.Join(errors.New("failed to decode gloas signed beacon block"), errors.New("unexpected EOF"))
+
+	tests := []struct {
+		name       string
+		jsonClient bool
+		sszErr     error
+		want       string
+		wantErr    bool
+	}{
+		{name: "ssz success", jsonClient: true, want: "ssz"},
+		{name: "decode failure falls back to json", jsonClient: true, sszErr: decodeErr, want: "json"},
+		{name: "transport error is not retried", jsonClient: true, sszErr: errors.New("failed to call GET endpoint"), wantErr: true},
+		{name: "no fallback without ssz", jsonClient: false, sszErr: decodeErr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{client: &stubService{name: "ssz"}, log: log}
+			if tt.jsonClient {
+				c.jsonClient = &stubService{name: "json"}
+			}
+
+			got, err := fetchDecoded(context.Background(), c, "block", func(svc eth2client.Service) (string, error) {
+				if svc.Name() == "ssz" && tt.sszErr != nil {
+					return "", tt.sszErr
+				}
+
+				return svc.Name(), nil
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
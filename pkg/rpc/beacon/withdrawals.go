@@ -0,0 +1,50 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/capella"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// GetExpectedWithdrawals returns the withdrawals the beacon node computes from
+// the given state for a block proposed at proposalSlot on top of it
+// (/eth/v1/builder/states/{state_id}/expected_withdrawals; direct HTTP,
+// go-eth2-client has no provider for it).
+func (c *Client) GetExpectedWithdrawals(ctx context.Context, stateID string,
+	proposalSlot phase0.Slot) ([]*capella.Withdrawal, error) {
+	url := fmt.Sprintf("%s/eth/v1/builder/states/%s/expected_withdrawals?proposal_slot=%d",
+		c.baseURL, stateID, proposalSlot)
+
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient(10 * time.Second).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nethttp.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data []*capella.Withdrawal `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode expected withdrawals: %w", err)
+	}
+
+	return result.Data, nil
+}
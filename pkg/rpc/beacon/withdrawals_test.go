@@ -0,0 +1,43 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/capella"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExpectedWithdrawals(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/builder/states/0xabcd/expected_withdrawals" {
+			http.Error(w, `{"code":404,"message":"state not found"}`, http.StatusNotFound)
+			return
+		}
+
+		require.Equal(t, "21", r.URL.Query().Get("proposal_slot"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"execution_optimistic":false,"finalized":false,"data":[` +
+			`{"index":"7","validator_index":"3","address":"0x00000000000000000000000000000000000000aa","amount":"1000"}]}`))
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL}
+	ctx := context.Background()
+
+	withdrawals, err := client.GetExpectedWithdrawals(ctx, "0xabcd", 21)
+	require.NoError(t, err)
+	require.Equal(t, []*capella.Withdrawal{{
+		Index:          7,
+		ValidatorIndex: 3,
+		Address:        [20]byte{19: 0xaa},
+		Amount:         phase0.Gwei(1000),
+	}}, withdrawals)
+
+	_, err = client.GetExpectedWithdrawals(ctx, "0xffff", 21)
+	require.ErrorContains(t, err, "status 404")
+}
@@ -0,0 +1,260 @@
+// Package engine provides the Engine API client for the execution layer: the
+// go-eth-engine-client JSON-RPC service over a dedicated, persistent HTTP
+// connection that is kept warm and health-checked in the background, so the
+// calls at the critical build moment never pay for a fresh dial. WebSocket
+// endpoints are not supported (the library has no WebSocket transport).
+package engine
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	enginejsonrpc "github.com/ethpandaops/go-eth-engine-client/jsonrpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// requestTimeout bounds a single engine API call (the library default).
+	requestTimeout = 10 * time.Second
+
+	// idleConnTimeout keeps idle connections open across slots; the health
+	// checks use them well within it.
+	idleConnTimeout = 5 * time.Minute
+
+	// maxIdleConns is the number of idle connections kept per engine, enough
+	// for the concurrent forkchoiceUpdated, getPayload and getBlobs calls of
+	// a build to each reuse a connection.
+	maxIdleConns = 16
+
+	// healthInterval is how often the connection is probed.
+	healthInterval = 5 * time.Second
+
+	// healthTimeout bounds one health probe.
+	healthTimeout = 2 * time.Second
+)
+
+var (
+	connectionsOpened = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_engine_connections_opened_total",
+		Help: "Connections dialled to an engine API, per engine (host:port).",
+	}, []string{"engine"})
+
+	connectionHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "buildoor_engine_healthy",
+		Help: "Whether the last health probe of an engine API succeeded (1) or not (0), per engine (host:port).",
+	}, []string{"engine"})
+)
+
+// Client is an Engine API client over a persistent connection. It embeds the
+// go-eth-engine-client JSON-RPC service, which mints a fresh JWT for every
+// request, so tokens never go stale on a long-lived connection.
+type Client struct {
+	*enginejsonrpc.Service
+
+	name       string
+	transport  *http.Transport
+	dials      atomic.Uint64
+	stopHealth context.CancelFunc
+	log        logrus.FieldLogger
+
+	mu        sync.Mutex
+	healthy   bool
+	failures  int
+	lastError string
+	lastCheck time.Time
+}
+
+// Status is a snapshot of an engine connection's health.
+type Status struct {
+	Healthy             bool
+	ConsecutiveFailures int
+	LastError           string
+	LastCheck           time.Time
+	ConnectionsOpened   uint64
+}
+
+// NewClient creates an Engine API client for address authenticated with the
+// JWT secret in jwtSecretFile, and starts probing the connection every
+// healthInterval until ctx is cancelled or the client is closed. A failed
+// probe or call drops the idle connections, so the next call reconnects.
+func NewClient(ctx context.Context, address, jwtSecretFile string, log logrus.FieldLogger) (*Client, error) {
+	c, err := newClient(ctx, address, jwtSecretFile, log)
+	if err != nil {
+		return nil, err
+	}
+
+	healthCtx, cancel := context.WithCancel(ctx)
+	c.stopHealth = cancel
+
+	go c.runHealthChecks(healthCtx)
+
+	return c, nil
+}
+
+// newClient creates the client without starting the health checks.
+func newClient(ctx context.Context, address, jwtSecretFile string, log logrus.FieldLogger) (*Client, error) {
+	c := &Client{
+		name: engineName(address),
+	}
+
+	c.log = log.WithFields(logrus.Fields{
+		"component": "engine-client",
+		"engine":    c.name,
+	})
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+	c.transport = http.DefaultTransport.(*http.Transport).Clone()
+	c.transport.MaxIdleConnsPerHost = maxIdleConns
+	c.transport.IdleConnTimeout = idleConnTimeout
+	c.transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err == nil {
+			c.dials.Add(1)
+			connectionsOpened.WithLabelValues(c.name).Inc()
+		}
+
+		return conn, err
+	}
+
+	svc, err := enginejsonrpc.New(ctx,
+		enginejsonrpc.WithAddress(address),
+		enginejsonrpc.WithJWTSecretFile(jwtSecretFile),
+		enginejsonrpc.WithLogger(log),
+		enginejsonrpc.WithHTTPClient(&http.Client{
+			Timeout:   requestTimeout,
+			Transport: &reconnectTransport{client: c},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Service = svc
+
+	return c, nil
+}
+
+// Close stops the health checks and closes the idle connections.
+func (c *Client) Close() {
+	if c.stopHealth != nil {
+		c.stopHealth()
+	}
+
+	c.transport.CloseIdleConnections()
+}
+
+// Status returns a snapshot of the connection's health.
+func (c *Client) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Status{
+		Healthy:             c.healthy,
+		ConsecutiveFailures: c.failures,
+		LastError:           c.lastError,
+		LastCheck:           c.lastCheck,
+		ConnectionsOpened:   c.dials.Load(),
+	}
+}
+
+// runHealthChecks probes the connection every healthInterval until ctx is
+// cancelled.
+func (c *Client) runHealthChecks(ctx context.Context) {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	for {
+		c.checkHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHealth probes the engine with engine_exchangeCapabilities, which keeps
+// an idle connection warm and surfaces a dead or unauthorised endpoint before
+// the next build needs it.
+func (c *Client) checkHealth(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	_, err := c.ExchangeCapabilities(probeCtx, nil)
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err != nil {
+		// The connection may be half-open; reconnect on the next call.
+		c.transport.CloseIdleConnections()
+	}
+
+	c.mu.Lock()
+	wasHealthy := c.healthy
+	c.healthy = err == nil
+	c.lastCheck = time.Now()
+
+	if err != nil {
+		c.failures++
+		c.lastError = err.Error()
+	} else {
+		c.failures = 0
+		c.lastError = ""
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		connectionHealthy.WithLabelValues(c.name).Set(0)
+
+		if wasHealthy {
+			c.log.WithError(err).Warn("Engine API connection unhealthy")
+		}
+
+		return
+	}
+
+	connectionHealthy.WithLabelValues(c.name).Set(1)
+
+	if !wasHealthy {
+		c.log.Info("Engine API connection healthy")
+	}
+}
+
+// reconnectTransport drops the client's idle connections after a failed
+// round trip, so a connection the EL closed (restart, proxy timeout) is not
+// reused and the next call dials afresh.
+type reconnectTransport struct {
+	client *Client
+}
+
+// RoundTrip performs the request on the client's persistent transport.
+func (t *reconnectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.client.transport.RoundTrip(req)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		t.client.transport.CloseIdleConnections()
+		t.client.log.WithError(err).Debug("Engine API request failed, dropping idle connections")
+	}
+
+	return resp, err
+}
+
+// engineName returns the host:port of an engine URL, or the URL itself when
+// it has no host.
+func engineName(address string) string {
+	if u, err := url.Parse(address); err == nil && u.Host != "" {
+		return u.Host
+	}
+
+	return address
+}
@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var failing atomic.Bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		assert.Contains(t, r.Header.Get("Authorization"), "Bearer ")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":["engine_newPayloadV4"]}`))
+	}))
+	defer srv.Close()
+
+	jwtPath := filepath.Join(t.TempDir(), "jwtsecret")
+	require.NoError(t, os.WriteFile(jwtPath, []byte(hex.EncodeToString(make([]byte, 32))), 0o600))
+
+	c, err := newClient(t.Context(), srv.URL, jwtPath, logrus.New())
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Calls reuse the persistent connection.
+	for range 3 {
+		_, err := c.ExchangeCapabilities(t.Context(), nil)
+		require.NoError(t, err)
+	}
+
+	c.checkHealth(t.Context())
+
+	status := c.Status()
+	assert.True(t, status.Healthy)
+	assert.Equal(t, uint64(1), status.ConnectionsOpened)
+
+	// A failed probe marks the engine unhealthy and drops the connection.
+	failing.Store(true)
+	c.checkHealth(t.Context())
+	c.checkHealth(t.Context())
+
+	status = c.Status()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Contains(t, status.LastError, "unexpected HTTP status 503")
+
+	// The next call reconnects.
+	failing.Store(false)
+	c.checkHealth(t.Context())
+
+	status = c.Status()
+	assert.True(t, status.Healthy)
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, uint64(3), status.ConnectionsOpened)
+}
+
+func TestEngineName(t *testing.T) {
+	assert.Equal(t, "el-a:8551", engineName("http://el-a:8551"))
+	assert.Equal(t, "el-a", engineName("el-a"))
+}
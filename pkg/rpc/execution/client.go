@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -213,6 +214,16 @@ func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return gasTipCap, nil
 }
 
+// BlobBaseFee returns the blob base fee of the next block.
+func (c *Client) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	blobBaseFee, err := c.ethClient.BlobBaseFee(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob base fee: %w", err)
+	}
+
+	return blobBaseFee, nil
+}
+
 // HeaderByNumber returns the header for a block number.
 func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	header, err := c.ethClient.HeaderByNumber(ctx, number)
@@ -223,6 +234,16 @@ func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.He
 	return header, nil
 }
 
+// HeaderByHash returns the header of a block hash.
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	header, err := c.ethClient.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header: %w", err)
+	}
+
+	return header, nil
+}
+
 // EstimateGas estimates gas for a transaction.
 func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
 	gas, err := c.ethClient.EstimateGas(ctx, msg)
@@ -232,3 +253,43 @@ func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64,
 
 	return gas, nil
 }
+
+// AccountProof is an account's eth_getProof result: the Merkle proof of the
+// account in the state trie and the proven account fields.
+type AccountProof struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+}
+
+// GetProof returns the account proof of address in the state of the block
+// with the given hash. The block need not be canonical, but the EL must
+// still hold its state.
+func (c *Client) GetProof(ctx context.Context, address common.Address, blockHash common.Hash) (*AccountProof, error) {
+	var proof AccountProof
+
+	err := c.rpcClient.CallContext(ctx, &proof, "eth_getProof",
+		address, []string{}, rpc.BlockNumberOrHashWithHash(blockHash, false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof of %s: %w", address.Hex(), err)
+	}
+
+	if proof.Balance == nil {
+		return nil, fmt.Errorf("no proof of %s at block %s", address.Hex(), blockHash.Hex())
+	}
+
+	return &proof, nil
+}
+
+// GetBlockReceipts returns all receipts of the block with the given hash.
+func (c *Client) GetBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error) {
+	receipts, err := c.ethClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(blockHash, false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block receipts: %w", err)
+	}
+
+	return receipts, nil
+}
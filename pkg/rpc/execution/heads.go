@@ -0,0 +1,137 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// headRetryDelay is the delay before re-subscribing after the newHeads
+// subscription failed.
+const headRetryDelay = 2 * time.Second
+
+var (
+	newHeadsReceived = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "buildoor_el_new_heads_total",
+		Help: "EL newHeads notifications received over the WebSocket subscription.",
+	})
+
+	headSubscriptionConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "buildoor_el_head_subscription_connected",
+		Help: "Whether the EL newHeads WebSocket subscription is currently connected (1) or not (0).",
+	})
+)
+
+// HeadStream subscribes to the EL's new chain heads (eth_subscribe newHeads)
+// over a WebSocket JSON-RPC endpoint and dispatches every head. The EL moves
+// its head as soon as the beacon node's forkchoiceUpdated lands, which tells
+// the builder about a parent change without waiting for payload_attributes.
+type HeadStream struct {
+	wsURL      string
+	dispatcher *utils.Dispatcher[*types.Header]
+	log        logrus.FieldLogger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewHeadStream creates a head stream for the EL WebSocket endpoint wsURL.
+func NewHeadStream(wsURL string, log logrus.FieldLogger) *HeadStream {
+	return &HeadStream{
+		wsURL:      wsURL,
+		dispatcher: &utils.Dispatcher[*types.Header]{},
+		log:        log.WithField("component", "el-heads"),
+	}
+}
+
+// Start subscribes to new heads, re-subscribing after errors, until ctx is
+// cancelled or Stop is called.
+func (h *HeadStream) Start(ctx context.Context) {
+	ctx, h.cancel = context.WithCancel(ctx)
+
+	h.wg.Add(1)
+
+	go func() {
+		defer h.wg.Done()
+
+		utils.Supervise(ctx, "el-heads", h.log, h.stream)
+	}()
+}
+
+// Stop ends the subscription and waits for it to close.
+func (h *HeadStream) Stop() {
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	h.wg.Wait()
+}
+
+// Subscribe returns a non-blocking subscription to new heads.
+func (h *HeadStream) Subscribe(capacity int) *utils.Subscription[*types.Header] {
+	return h.dispatcher.Subscribe(capacity, false)
+}
+
+// stream keeps the subscription open, re-subscribing after errors, until ctx
+// is cancelled.
+func (h *HeadStream) stream(ctx context.Context) {
+	for {
+		err := h.subscribe(ctx)
+
+		headSubscriptionConnected.Set(0)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		h.log.WithError(err).Warn("EL newHeads subscription failed, re-subscribing...")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(headRetryDelay):
+		}
+	}
+}
+
+// subscribe dials the endpoint and forwards new heads until the subscription
+// fails or ctx is cancelled.
+func (h *HeadStream) subscribe(ctx context.Context) error {
+	client, err := ethclient.DialContext(ctx, h.wsURL)
+	if err != nil {
+		return fmt.Errorf("failed to dial EL websocket: %w", err)
+	}
+	defer client.Close()
+
+	heads := make(chan *types.Header, 16)
+
+	sub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to newHeads: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	headSubscriptionConnected.Set(1)
+	h.log.Info("Subscribed to EL newHeads")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case head := <-heads:
+			newHeadsReceived.Inc()
+			h.dispatcher.Fire(head)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Built-in signing backends.
+const (
+	// BackendLocal signs with an in-process key (raw hex or mnemonic-derived).
+	BackendLocal = "local"
+	// BackendWeb3Signer signs with a key held by a Web3Signer-compatible
+	// signing service.
+	BackendWeb3Signer = "web3signer"
+)
+
+// ErrUnknownBackend is returned for a backend name nobody registered.
+var ErrUnknownBackend = errors.New("unknown signer backend")
+
+// BackendConfig selects and configures the builder key's signing backend.
+type BackendConfig struct {
+	Backend string // Backend name; empty selects BackendLocal
+
+	// BackendLocal: a raw hex key, or a mnemonic and account index.
+	PrivkeyHex string
+	Mnemonic   string
+	KeyIndex   uint64
+
+	// BackendWeb3Signer: the signing service URL and the key's public key
+	// (optional when it holds one key).
+	RemoteURL    string
+	RemotePubkey string
+}
+
+// BackendFactory creates a Signer from the backend configuration.
+type BackendFactory func(cfg BackendConfig) (Signer, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendFactory{
+		BackendLocal: func(cfg BackendConfig) (Signer, error) {
+			s, err := NewBuilderSigner(cfg.PrivkeyHex, cfg.Mnemonic, cfg.KeyIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			return s, nil
+		},
+		BackendWeb3Signer: func(cfg BackendConfig) (Signer, error) {
+			s, err := NewWeb3Signer(cfg.RemoteURL, cfg.RemotePubkey)
+			if err != nil {
+				return nil, err
+			}
+
+			return s, nil
+		},
+	}
+)
+
+// RegisterBackend makes an additional signing backend selectable by name,
+// e.g. an HSM reached through PKCS#11, which needs a vendor library and is
+// therefore not built in. Registering a name twice panics.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if _, exists := backends[name]; exists {
+		panic(fmt.Sprintf("signer backend %q already registered", name))
+	}
+
+	backends[name] = factory
+}
+
+// NewBackend creates the Signer of the configured backend.
+func NewBackend(cfg BackendConfig) (Signer, error) {
+	name := cfg.Backend
+	if name == "" {
+		name = BackendLocal
+	}
+
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q (available: %s)", ErrUnknownBackend, name, strings.Join(Backends(), ", "))
+	}
+
+	return factory(cfg)
+}
+
+// Backends returns the names of all registered backends, sorted.
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
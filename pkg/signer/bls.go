@@ -32,6 +32,11 @@ var (
 	// signatures cannot be replayed against the other contract.
 	DomainBuilderDeposit = phase0.DomainType{0x0E, 0x00, 0x00, 0x00}
 
+	// DomainBeaconProposer is DOMAIN_BEACON_PROPOSER (0x00000000): the domain proposers
+	// sign beacon blocks with. A blinded block shares the root of its full block, so
+	// the proposer signature over either verifies under this domain.
+	DomainBeaconProposer = phase0.DomainType{0x00, 0x00, 0x00, 0x00}
+
 	// DomainApplicationBuilder is the domain for builder API validator registration signatures.
 	// See https://github.com/ethereum/builder-specs
 	DomainApplicationBuilder = phase0.DomainType{0x00, 0x00, 0x00, 0x01}
@@ -50,7 +55,7 @@ func initBLS() {
 	})
 }
 
-// BLSSigner handles BLS signing operations for a builder.
+// BLSSigner is the local Signer backend: a BLS secret key held in process.
 type BLSSigner struct {
 	secretKey   *bls.SecretKey
 	publicKey   *bls.PublicKey
@@ -166,22 +171,11 @@ func ComputeDepositSigningRoot(
 	amountGwei uint64,
 	genesisForkVersion phase0.Version,
 ) (phase0.Root, error) {
-	// Create DepositMessage using the library type
-	depositMsg := &phase0.DepositMessage{
-		PublicKey:             pubkey,
-		WithdrawalCredentials: withdrawalCredentials[:],
-		Amount:                phase0.Gwei(amountGwei),
-	}
-
-	// Compute hash tree root using the library's SSZ implementation
-	depositMsgRoot, err := depositMsg.HashTreeRoot()
+	root, err := depositMessageRoot(pubkey, withdrawalCredentials, amountGwei)
 	if err != nil {
-		return phase0.Root{}, fmt.Errorf("failed to compute deposit message root: %w", err)
+		return phase0.Root{}, err
 	}
 
-	var root phase0.Root
-	copy(root[:], depositMsgRoot[:])
-
 	// Compute domain with GENESIS_FORK_VERSION and empty genesis_validators_root (per spec)
 	// For deposits, genesis_validators_root is always zero
 	var emptyRoot phase0.Root
@@ -201,6 +195,26 @@ func ComputeBuilderDepositSigningRoot(
 	withdrawalCredentials [32]byte,
 	amountGwei uint64,
 	genesisForkVersion phase0.Version,
+) (phase0.Root, error) {
+	root, err := depositMessageRoot(pubkey, withdrawalCredentials, amountGwei)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	// Builder deposits, like regular deposits, sign over a zero genesis_validators_root.
+	var emptyRoot phase0.Root
+
+	domain := ComputeDomain(DomainBuilderDeposit, genesisForkVersion, emptyRoot)
+
+	return ComputeSigningRoot(root, domain), nil
+}
+
+// depositMessageRoot computes the hash tree root of a DepositMessage using the
+// go-eth2-client library's SSZ implementation.
+func depositMessageRoot(
+	pubkey phase0.BLSPubKey,
+	withdrawalCredentials [32]byte,
+	amountGwei uint64,
 ) (phase0.Root, error) {
 	depositMsg := &phase0.DepositMessage{
 		PublicKey:             pubkey,
@@ -213,15 +227,7 @@ func ComputeBuilderDepositSigningRoot(
 		return phase0.Root{}, fmt.Errorf("failed to compute deposit message root: %w", err)
 	}
 
-	var root phase0.Root
-	copy(root[:], depositMsgRoot[:])
-
-	// Builder deposits, like regular deposits, sign over a zero genesis_validators_root.
-	var emptyRoot phase0.Root
-
-	domain := ComputeDomain(DomainBuilderDeposit, genesisForkVersion, emptyRoot)
-
-	return ComputeSigningRoot(root, domain), nil
+	return phase0.Root(depositMsgRoot), nil
 }
 
 // ComputeDepositDataRoot computes the hash tree root of DepositData.
@@ -270,19 +276,6 @@ func ComputeVoluntaryExitRoot(epoch phase0.Epoch, validatorIndex phase0.Validato
 	return root
 }
 
-// SignVoluntaryExit signs a voluntary exit message.
-func (s *BLSSigner) SignVoluntaryExit(
-	epoch phase0.Epoch,
-	validatorIndex phase0.ValidatorIndex,
-	forkVersion phase0.Version,
-	genesisValidatorsRoot phase0.Root,
-) (phase0.BLSSignature, error) {
-	exitRoot := ComputeVoluntaryExitRoot(epoch, validatorIndex)
-	domain := ComputeDomain(DomainVoluntaryExit, forkVersion, genesisValidatorsRoot)
-
-	return s.SignWithDomain(exitRoot, domain)
-}
-
 // VerifyBLSSignature verifies a BLS signature over a message with the given public key.
 // Returns true if the signature is valid.
 func VerifyBLSSignature(pubkey phase0.BLSPubKey, message []byte, signature phase0.BLSSignature) bool {
@@ -0,0 +1,28 @@
+package signer
+
+import (
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// SigningDomain is the complete signing context of a message: the domain type
+// and the fork version and genesis validators root mixed into the domain.
+type SigningDomain struct {
+	Type                  phase0.DomainType
+	ForkVersion           phase0.Version
+	GenesisValidatorsRoot phase0.Root
+	Domain                phase0.Domain
+}
+
+// NewSigningDomain computes the domain for the given inputs.
+func NewSigningDomain(
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) SigningDomain {
+	return SigningDomain{
+		Type:                  domainType,
+		ForkVersion:           forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Domain:                ComputeDomain(domainType, forkVersion, genesisValidatorsRoot),
+	}
+}
@@ -0,0 +1,150 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// Signer is a BLS signing backend holding one key. Every signature of the
+// builder goes through it: the in-process key (BLSSigner), a Web3Signer
+// instance (Web3Signer), or any backend registered with RegisterBackend (e.g.
+// an HSM). Implementations must be safe for concurrent use.
+type Signer interface {
+	// PublicKey returns the BLS public key of the signing key.
+	PublicKey() phase0.BLSPubKey
+	// SignWithDomain signs the signing root of root under domain.
+	SignWithDomain(root phase0.Root, domain phase0.Domain) (phase0.BLSSignature, error)
+}
+
+// BatchSigner is implemented by backends that sign several messages in one
+// operation (one round trip for remote backends).
+type BatchSigner interface {
+	SignBatch(requests []SigningRequest) ([]phase0.BLSSignature, error)
+}
+
+// TypedSigner is implemented by backends that sign the message itself rather
+// than its root (Web3Signer computes the signing root from a typed payload).
+// SignDeposit and SignVoluntaryExit hand such backends the message.
+type TypedSigner interface {
+	SignDepositMessage(msg *phase0.DepositMessage, genesisForkVersion phase0.Version) (phase0.BLSSignature, error)
+	SignVoluntaryExitMessage(
+		exit *phase0.VoluntaryExit,
+		forkVersion phase0.Version,
+		genesisValidatorsRoot phase0.Root,
+	) (phase0.BLSSignature, error)
+}
+
+// DomainRestrictedSigner is implemented by backends that can only sign
+// messages under some domains.
+type DomainRestrictedSigner interface {
+	CanSign(domainType phase0.DomainType) bool
+}
+
+// CanSign reports whether s can sign messages under domainType. Backends that
+// do not implement DomainRestrictedSigner sign under any domain.
+func CanSign(s Signer, domainType phase0.DomainType) bool {
+	if restricted, ok := s.(DomainRestrictedSigner); ok {
+		return restricted.CanSign(domainType)
+	}
+
+	return true
+}
+
+// SigningRequest is one message of a batch: an object root and its domain.
+type SigningRequest struct {
+	Root   phase0.Root
+	Domain phase0.Domain
+}
+
+// SignBatch signs requests with s, in a single operation when the backend
+// implements BatchSigner. Signatures are returned in request order.
+func SignBatch(s Signer, requests []SigningRequest) ([]phase0.BLSSignature, error) {
+	if batch, ok := s.(BatchSigner); ok {
+		sigs, err := batch.SignBatch(requests)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(sigs) != len(requests) {
+			return nil, fmt.Errorf("batch signer returned %d signatures for %d requests", len(sigs), len(requests))
+		}
+
+		return sigs, nil
+	}
+
+	sigs := make([]phase0.BLSSignature, len(requests))
+
+	for i, req := range requests {
+		sig, err := s.SignWithDomain(req.Root, req.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign request %d: %w", i, err)
+		}
+
+		sigs[i] = sig
+	}
+
+	return sigs, nil
+}
+
+// SignDeposit signs the DepositMessage of s's key as a validator deposit
+// proof-of-possession (DOMAIN_DEPOSIT, see ComputeDepositSigningRoot).
+func SignDeposit(
+	s Signer,
+	withdrawalCredentials [32]byte,
+	amountGwei uint64,
+	genesisForkVersion phase0.Version,
+) (phase0.BLSSignature, error) {
+	if typed, ok := s.(TypedSigner); ok {
+		return typed.SignDepositMessage(&phase0.DepositMessage{
+			PublicKey:             s.PublicKey(),
+			WithdrawalCredentials: withdrawalCredentials[:],
+			Amount:                phase0.Gwei(amountGwei),
+		}, genesisForkVersion)
+	}
+
+	root, err := depositMessageRoot(s.PublicKey(), withdrawalCredentials, amountGwei)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	return s.SignWithDomain(root, ComputeDomain(DomainDeposit, genesisForkVersion, phase0.Root{}))
+}
+
+// SignBuilderDeposit signs the DepositMessage of s's key as an EIP-8282
+// builder deposit proof-of-possession (DOMAIN_BUILDER_DEPOSIT, see
+// ComputeBuilderDepositSigningRoot).
+func SignBuilderDeposit(
+	s Signer,
+	withdrawalCredentials [32]byte,
+	amountGwei uint64,
+	genesisForkVersion phase0.Version,
+) (phase0.BLSSignature, error) {
+	root, err := depositMessageRoot(s.PublicKey(), withdrawalCredentials, amountGwei)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	return s.SignWithDomain(root, ComputeDomain(DomainBuilderDeposit, genesisForkVersion, phase0.Root{}))
+}
+
+// SignVoluntaryExit signs a voluntary exit message.
+func SignVoluntaryExit(
+	s Signer,
+	epoch phase0.Epoch,
+	validatorIndex phase0.ValidatorIndex,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) (phase0.BLSSignature, error) {
+	if typed, ok := s.(TypedSigner); ok {
+		return typed.SignVoluntaryExitMessage(&phase0.VoluntaryExit{
+			Epoch:          epoch,
+			ValidatorIndex: validatorIndex,
+		}, forkVersion, genesisValidatorsRoot)
+	}
+
+	exitRoot := ComputeVoluntaryExitRoot(epoch, validatorIndex)
+	domain := ComputeDomain(DomainVoluntaryExit, forkVersion, genesisValidatorsRoot)
+
+	return s.SignWithDomain(exitRoot, domain)
+}
@@ -0,0 +1,189 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testSigner(t *testing.T, seed byte) *BLSSigner {
+	t.Helper()
+
+	s, err := NewBLSSigner(fmt.Sprintf("%064x", seed))
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestVerifyBatch(t *testing.T) {
+	domain := ComputeDomain(DomainApplicationBuilder, phase0.Version{0x10}, phase0.Root{})
+
+	sets := make([]SignatureSet, 0, 20)
+
+	for i := 1; i <= 20; i++ {
+		key := testSigner(t, byte(i))
+		root := phase0.Root{byte(i)}
+
+		sig, err := key.SignWithDomain(root, domain)
+		require.NoError(t, err)
+
+		sets = append(sets, SignatureSet{Pubkey: key.PublicKey(), Root: root, Domain: domain, Signature: sig})
+	}
+
+	require.True(t, VerifyBatch(sets))
+	require.True(t, VerifyBatch(nil))
+
+	sets[7].Root = phase0.Root{0xff}
+	require.False(t, VerifyBatch(sets), "one bad signature fails the batch")
+	require.False(t, VerifyWithDomain(sets[7].Pubkey, sets[7].Root, sets[7].Domain, sets[7].Signature))
+}
+
+func TestNewBackend(t *testing.T) {
+	local, err := NewBackend(BackendConfig{PrivkeyHex: fmt.Sprintf("%064x", 1)})
+	require.NoError(t, err)
+	require.Equal(t, testSigner(t, 1).PublicKey(), local.PublicKey())
+
+	_, err = NewBackend(BackendConfig{Backend: "hsm"})
+	require.ErrorIs(t, err, ErrUnknownBackend)
+
+	RegisterBackend("test-hsm", func(BackendConfig) (Signer, error) {
+		return testSigner(t, 2), nil
+	})
+
+	hsm, err := NewBackend(BackendConfig{Backend: "test-hsm"})
+	require.NoError(t, err)
+	require.Equal(t, testSigner(t, 2).PublicKey(), hsm.PublicKey())
+}
+
+func TestWeb3Signer(t *testing.T) {
+	key := testSigner(t, 1)
+	other := testSigner(t, 2)
+	pubkey := fmt.Sprintf("%#x", key.PublicKey())
+	tamper := false
+	plain := false
+
+	var (
+		bodiesMu sync.Mutex
+		bodies   []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			require.Equal(t, "/api/v1/eth2/publicKeys", r.URL.Path)
+			require.NoError(t, json.NewEncoder(w).Encode([]string{pubkey}))
+
+			return
+		}
+
+		require.Equal(t, "/api/v1/eth2/sign/"+pubkey, r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		bodiesMu.Lock()
+		bodies = append(bodies, string(body))
+		bodiesMu.Unlock()
+
+		var req web3SignerSignRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+
+		signingRoot, err := decodeHex(req.SigningRoot, 32)
+		require.NoError(t, err)
+
+		if tamper {
+			signingRoot[0] ^= 0xff
+		}
+
+		sig, err := key.Sign(signingRoot)
+		require.NoError(t, err)
+
+		if plain {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = fmt.Fprintf(w, "%#x", sig)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(web3SignerSignResponse{Signature: fmt.Sprintf("%#x", sig)}))
+	}))
+	defer srv.Close()
+
+	web3, err := NewBackend(BackendConfig{Backend: BackendWeb3Signer, RemoteURL: srv.URL})
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey(), web3.PublicKey(), "the only loaded key is selected")
+
+	_, err = NewBackend(BackendConfig{
+		Backend:      BackendWeb3Signer,
+		RemoteURL:    srv.URL,
+		RemotePubkey: fmt.Sprintf("%#x", other.PublicKey()),
+	})
+	require.ErrorContains(t, err, "does not hold key")
+
+	// Request bodies follow the Web3Signer eth2 signing API schema.
+	withdrawalCredentials := [32]byte{0x01}
+	withdrawalCredentials[12] = 0xaa
+	genesisForkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+
+	sig, err := SignDeposit(web3, withdrawalCredentials, 32_000_000_000, genesisForkVersion)
+	require.NoError(t, err)
+
+	local, err := SignDeposit(key, withdrawalCredentials, 32_000_000_000, genesisForkVersion)
+	require.NoError(t, err)
+	require.Equal(t, local, sig)
+
+	exitForkVersion := phase0.Version{0x40, 0x00, 0x00, 0x38}
+	genesisValidatorsRoot := phase0.Root{0x9c, 0x67}
+
+	sig, err = SignVoluntaryExit(web3, 12, 34, exitForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	local, err = SignVoluntaryExit(key, 12, 34, exitForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, local, sig)
+
+	require.Len(t, bodies, 2)
+	require.JSONEq(t, readTestdata(t, "web3signer/deposit.json"), bodies[0])
+	require.JSONEq(t, readTestdata(t, "web3signer/voluntary_exit.json"), bodies[1])
+
+	plain = true
+
+	sig, err = SignVoluntaryExit(web3, 12, 34, exitForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err, "plain hex responses are accepted")
+	require.Equal(t, local, sig)
+
+	tamper = true
+
+	_, err = SignVoluntaryExit(web3, 13, 34, exitForkVersion, genesisValidatorsRoot)
+	require.ErrorContains(t, err, "does not verify", "signatures of another message are refused")
+
+	// Messages without a Web3Signer type are refused before any request.
+	for _, domainType := range []phase0.DomainType{DomainApplicationBuilder, DomainBuilderDeposit, {0x0B, 0x00, 0x00, 0x00}} {
+		require.False(t, CanSign(web3, domainType))
+
+		_, err = web3.SignWithDomain(phase0.Root{0x01}, ComputeDomain(domainType, phase0.Version{}, phase0.Root{}))
+		require.ErrorIs(t, err, ErrWeb3SignerUnsupported)
+	}
+
+	require.True(t, CanSign(web3, DomainDeposit))
+	require.True(t, CanSign(key, DomainApplicationBuilder))
+	require.Len(t, bodies, 4)
+}
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return string(data)
+}
@@ -0,0 +1,82 @@
+package signer
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// SignatureSet is one signature to verify: the signer's public key and the
+// object root and domain it signed.
+type SignatureSet struct {
+	Pubkey    phase0.BLSPubKey
+	Root      phase0.Root
+	Domain    phase0.Domain
+	Signature phase0.BLSSignature
+}
+
+// VerifyWithDomain verifies a signature over the signing root of root under
+// domain — the counterpart of Signer.SignWithDomain.
+func VerifyWithDomain(
+	pubkey phase0.BLSPubKey,
+	root phase0.Root,
+	domain phase0.Domain,
+	signature phase0.BLSSignature,
+) bool {
+	signingRoot := ComputeSigningRoot(root, domain)
+
+	return VerifyBLSSignature(pubkey, signingRoot[:], signature)
+}
+
+// VerifyBatch verifies all sets, spread over GOMAXPROCS workers. It returns
+// true only if every signature is valid and stops at the first invalid one;
+// it does not tell which one failed (use VerifyWithDomain on each set for
+// that). An empty batch is valid.
+//
+// Each set is verified on its own: herumi's randomized bls.MultiVerify walks
+// the packed messages with unsafe pointer arithmetic that aborts under the
+// race detector's checkptr instrumentation.
+func VerifyBatch(sets []SignatureSet) bool {
+	workers := min(runtime.GOMAXPROCS(0), len(sets))
+	if workers <= 1 {
+		for _, set := range sets {
+			if !VerifyWithDomain(set.Pubkey, set.Root, set.Domain, set.Signature) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	var (
+		next    atomic.Int64
+		invalid atomic.Bool
+		wg      sync.WaitGroup
+	)
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for !invalid.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= len(sets) {
+					return
+				}
+
+				set := sets[i]
+				if !VerifyWithDomain(set.Pubkey, set.Root, set.Domain, set.Signature) {
+					invalid.Store(true)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return !invalid.Load()
+}
@@ -0,0 +1,293 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// web3SignerTimeout bounds one signing round trip; a bid signed later than
+// this is unlikely to be useful anyway.
+const web3SignerTimeout = 2 * time.Second
+
+// ErrWeb3SignerUnsupported is returned for messages Web3Signer has no signing
+// type for. It only signs typed payloads and never a bare signing root, so
+// builder bids, payload envelopes and builder deposits cannot be signed.
+var ErrWeb3SignerUnsupported = errors.New("web3signer has no signing type for this message")
+
+// web3SignerSignRequest is the body of a Web3Signer eth2 signing request. The
+// signer recomputes the signing root from the typed payload and refuses the
+// request when it does not match signingRoot.
+type web3SignerSignRequest struct {
+	Type          string                `json:"type"`
+	ForkInfo      *web3SignerForkInfo   `json:"fork_info,omitempty"`
+	SigningRoot   string                `json:"signingRoot"`
+	Deposit       *web3SignerDeposit    `json:"deposit,omitempty"`
+	VoluntaryExit *phase0.VoluntaryExit `json:"voluntary_exit,omitempty"`
+}
+
+// web3SignerForkInfo is the fork_info of a signing request.
+type web3SignerForkInfo struct {
+	Fork                  *phase0.Fork `json:"fork"`
+	GenesisValidatorsRoot string       `json:"genesis_validators_root"`
+}
+
+// web3SignerDeposit is the deposit payload of a DEPOSIT signing request.
+type web3SignerDeposit struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+
+// web3SignerSignResponse is the JSON body of a Web3Signer signing response.
+type web3SignerSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Web3Signer is the Web3Signer Signer backend: the key lives in a signing
+// service speaking the Web3Signer eth2 API. The key must be listed by
+//
+//	GET {url}/api/v1/eth2/publicKeys
+//
+// and every message is signed from its typed payload, one request each:
+//
+//	POST {url}/api/v1/eth2/sign/{pubkey}
+//	{"type": "DEPOSIT", "signingRoot": "0x…", "deposit": {…}}  →  {"signature": "0x…"} (or plain hex)
+//
+// Only validator deposits (DEPOSIT) and voluntary exits (VOLUNTARY_EXIT) have
+// a Web3Signer type; SignWithDomain fails with ErrWeb3SignerUnsupported and
+// CanSign reports the domains startup may rely on. Every returned signature
+// is verified against the public key before use, so a misbehaving or
+// misconfigured signer cannot make the builder publish invalid signatures.
+type Web3Signer struct {
+	url    string
+	pubkey phase0.BLSPubKey
+	client *http.Client
+}
+
+// NewWeb3Signer creates a Web3Signer backend for the hex-encoded public key
+// at url. The key must be loaded in the signer; an empty pubkeyHex selects
+// the signer's only key.
+func NewWeb3Signer(url, pubkeyHex string) (*Web3Signer, error) {
+	if url == "" {
+		return nil, fmt.Errorf("web3signer URL is required")
+	}
+
+	s := &Web3Signer{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: web3SignerTimeout},
+	}
+
+	keys, err := s.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if pubkeyHex == "" {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("web3signer holds %d keys, a builder pubkey must be selected", len(keys))
+		}
+
+		s.pubkey = keys[0]
+
+		return s, nil
+	}
+
+	pubkeyBytes, err := decodeHex(pubkeyHex, len(phase0.BLSPubKey{}))
+	if err != nil {
+		return nil, fmt.Errorf("invalid web3signer pubkey: %w", err)
+	}
+
+	s.pubkey = phase0.BLSPubKey(pubkeyBytes)
+
+	for _, key := range keys {
+		if key == s.pubkey {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("web3signer does not hold key %#x", s.pubkey)
+}
+
+// publicKeys lists the keys loaded in the signer.
+func (s *Web3Signer) publicKeys() ([]phase0.BLSPubKey, error) {
+	resp, err := s.client.Get(s.url + "/api/v1/eth2/publicKeys")
+	if err != nil {
+		return nil, fmt.Errorf("web3signer key listing failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("web3signer key listing returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var listed []string
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+		return nil, fmt.Errorf("failed to decode web3signer key listing: %w", err)
+	}
+
+	keys := make([]phase0.BLSPubKey, 0, len(listed))
+
+	for _, raw := range listed {
+		key, err := decodeHex(raw, len(phase0.BLSPubKey{}))
+		if err != nil {
+			return nil, fmt.Errorf("invalid web3signer key %q: %w", raw, err)
+		}
+
+		keys = append(keys, phase0.BLSPubKey(key))
+	}
+
+	return keys, nil
+}
+
+// PublicKey returns the public key of the signer's key.
+func (s *Web3Signer) PublicKey() phase0.BLSPubKey {
+	return s.pubkey
+}
+
+// CanSign reports whether Web3Signer has a signing type for domainType.
+func (s *Web3Signer) CanSign(domainType phase0.DomainType) bool {
+	return domainType == DomainDeposit || domainType == DomainVoluntaryExit
+}
+
+// SignWithDomain always fails: Web3Signer does not sign bare signing roots.
+// Deposits and exits go through SignDeposit and SignVoluntaryExit.
+func (s *Web3Signer) SignWithDomain(_ phase0.Root, domain phase0.Domain) (phase0.BLSSignature, error) {
+	return phase0.BLSSignature{}, fmt.Errorf("%w (domain %#x)", ErrWeb3SignerUnsupported, domain[:4])
+}
+
+// SignDepositMessage signs msg as a validator deposit (DEPOSIT).
+func (s *Web3Signer) SignDepositMessage(
+	msg *phase0.DepositMessage,
+	genesisForkVersion phase0.Version,
+) (phase0.BLSSignature, error) {
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("failed to compute deposit message root: %w", err)
+	}
+
+	domain := ComputeDomain(DomainDeposit, genesisForkVersion, phase0.Root{})
+
+	return s.sign(web3SignerSignRequest{
+		Type: "DEPOSIT",
+		Deposit: &web3SignerDeposit{
+			Pubkey:                fmt.Sprintf("%#x", msg.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("%#x", msg.WithdrawalCredentials),
+			Amount:                fmt.Sprintf("%d", msg.Amount),
+			GenesisForkVersion:    fmt.Sprintf("%#x", genesisForkVersion),
+		},
+	}, root, domain)
+}
+
+// SignVoluntaryExitMessage signs exit (VOLUNTARY_EXIT). The fork info pins
+// forkVersion as both fork versions, so the signer derives exactly the domain
+// the caller chose.
+func (s *Web3Signer) SignVoluntaryExitMessage(
+	exit *phase0.VoluntaryExit,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) (phase0.BLSSignature, error) {
+	root, err := exit.HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("failed to compute voluntary exit root: %w", err)
+	}
+
+	domain := ComputeDomain(DomainVoluntaryExit, forkVersion, genesisValidatorsRoot)
+
+	return s.sign(web3SignerSignRequest{
+		Type: "VOLUNTARY_EXIT",
+		ForkInfo: &web3SignerForkInfo{
+			Fork: &phase0.Fork{
+				PreviousVersion: forkVersion,
+				CurrentVersion:  forkVersion,
+				Epoch:           exit.Epoch,
+			},
+			GenesisValidatorsRoot: fmt.Sprintf("%#x", genesisValidatorsRoot),
+		},
+		VoluntaryExit: exit,
+	}, root, domain)
+}
+
+// sign sends one signing request for the message with object root under
+// domain and verifies the returned signature.
+func (s *Web3Signer) sign(req web3SignerSignRequest, root phase0.Root, domain phase0.Domain) (phase0.BLSSignature, error) {
+	signingRoot := ComputeSigningRoot(root, domain)
+	req.SigningRoot = fmt.Sprintf("%#x", signingRoot)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("failed to encode web3signer request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost,
+		fmt.Sprintf("%s/api/v1/eth2/sign/%#x", s.url, s.pubkey), bytes.NewReader(data))
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("web3signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("failed to read web3signer response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return phase0.BLSSignature{}, fmt.Errorf("web3signer returned status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Web3Signer answers in JSON when asked to, plain hex otherwise.
+	raw := strings.TrimSpace(string(body))
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var decoded web3SignerSignResponse
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			return phase0.BLSSignature{}, fmt.Errorf("failed to decode web3signer response: %w", err)
+		}
+
+		raw = decoded.Signature
+	}
+
+	sig, err := decodeHex(raw, len(phase0.BLSSignature{}))
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("invalid web3signer signature: %w", err)
+	}
+
+	if !VerifyWithDomain(s.pubkey, root, domain, phase0.BLSSignature(sig)) {
+		return phase0.BLSSignature{}, fmt.Errorf("web3signer returned a signature that does not verify against %#x", s.pubkey)
+	}
+
+	return phase0.BLSSignature(sig), nil
+}
+
+// decodeHex decodes a 0x-prefixed (or bare) hex string of exactly size bytes.
+func decodeHex(value string, size int) ([]byte, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(decoded))
+	}
+
+	return decoded, nil
+}
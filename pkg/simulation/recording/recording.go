@@ -0,0 +1,198 @@
+// Package recording captures the beacon node's head and payload_attributes
+// event sequence to disk (one JSON line per event, timed relative to its
+// slot start) and loads it back for `buildoor simulate --replay`, which
+// reproduces the recorded timing against the simulated chain.
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+)
+
+// Recorded event topics.
+const (
+	TopicHead              = "head"
+	TopicPayloadAttributes = "payload_attributes"
+)
+
+// Event is one recorded beacon event. Offsets are relative to the start of
+// the event's slot (the block slot of head events, the proposal slot of
+// payload_attributes events), so they stay meaningful on a chain with other
+// slot numbers and, scaled by SlotTimeMs, another slot time.
+type Event struct {
+	Topic        string      `json:"topic"` // Topic* constants
+	Slot         phase0.Slot `json:"slot"`
+	OffsetMs     int64       `json:"offset_ms"`
+	SlotTimeMs   int64       `json:"slot_time_ms"`
+	FeeRecipient string      `json:"fee_recipient,omitempty"` // payload_attributes only
+}
+
+// Recorder appends every head and payload_attributes event received from
+// the beacon node to a file.
+type Recorder struct {
+	path     string
+	events   *beacon.EventStream
+	chainSvc chain.Service
+
+	file *os.File
+	enc  *json.Encoder
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewRecorder creates a recorder writing to path.
+func NewRecorder(path string, events *beacon.EventStream, chainSvc chain.Service, log logrus.FieldLogger) *Recorder {
+	return &Recorder{
+		path:     path,
+		events:   events,
+		chainSvc: chainSvc,
+		log:      log.WithField("component", "event-recorder"),
+	}
+}
+
+// Start opens the recording (appending to an existing one) and launches the
+// recorder loop.
+func (r *Recorder) Start(ctx context.Context) error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open event recording: %w", err)
+	}
+
+	r.file = file
+	r.enc = json.NewEncoder(file)
+	r.ctx, r.cancel = context.WithCancel(ctx)
+
+	headSub := r.events.SubscribeHead()
+	attrSub := r.events.SubscribePayloadAttributes()
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		defer headSub.Unsubscribe()
+		defer attrSub.Unsubscribe()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				return
+			case event := <-headSub.Channel():
+				r.record(&Event{Topic: TopicHead, Slot: event.Slot})
+			case event := <-attrSub.Channel():
+				r.record(&Event{
+					Topic:        TopicPayloadAttributes,
+					Slot:         event.ProposalSlot,
+					FeeRecipient: event.SuggestedFeeRecipient.Hex(),
+				})
+			}
+		}
+	}()
+
+	r.log.WithField("file", r.path).Info("Recording head and payload attributes events")
+
+	return nil
+}
+
+// Stop terminates the recorder loop and closes the recording.
+func (r *Recorder) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+
+	r.wg.Wait()
+
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			r.log.WithError(err).Warn("Failed to close event recording")
+		}
+	}
+}
+
+// record stamps the event with its arrival offset and appends it.
+func (r *Recorder) record(event *Event) {
+	now := r.chainSvc.Clock().Now()
+
+	event.OffsetMs = now.Sub(r.chainSvc.SlotToTime(event.Slot)).Milliseconds()
+	event.SlotTimeMs = r.chainSvc.GetChainSpec().SecondsPerSlot.Milliseconds()
+
+	if err := r.enc.Encode(event); err != nil {
+		r.log.WithError(err).WithField("slot", event.Slot).Warn("Failed to record event")
+	}
+}
+
+// Load reads a recording written by a Recorder.
+func Load(path string) ([]*Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event recording: %w", err)
+	}
+	defer file.Close()
+
+	return Decode(bufio.NewScanner(file))
+}
+
+// Decode parses recorded events, one JSON object per line; blank lines are
+// skipped.
+func Decode(scanner *bufio.Scanner) ([]*Event, error) {
+	var events []*Event
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if err := event.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, errors.New("recording holds no events")
+	}
+
+	return events, nil
+}
+
+// validate checks a decoded event.
+func (e *Event) validate() error {
+	if e.Topic != TopicHead && e.Topic != TopicPayloadAttributes {
+		return fmt.Errorf("unknown topic %q", e.Topic)
+	}
+
+	if e.SlotTimeMs <= 0 {
+		return fmt.Errorf("slot time must be > 0")
+	}
+
+	return nil
+}
+
+// Offset returns the event's offset scaled to slotTime.
+func (e *Event) Offset(slotTime time.Duration) time.Duration {
+	return time.Duration(e.OffsetMs) * slotTime / time.Duration(e.SlotTimeMs)
+}
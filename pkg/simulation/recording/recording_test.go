@@ -0,0 +1,34 @@
+package recording
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	events, err := Decode(bufio.NewScanner(strings.NewReader(`{"topic":"payload_attributes","slot":"11","offset_ms":-8000,"slot_time_ms":12000,"fee_recipient":"0x00000000000000000000000000000000000000fe"}
+
+{"topic":"head","slot":"11","offset_ms":600,"slot_time_ms":12000}
+`)))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, TopicPayloadAttributes, events[0].Topic)
+	assert.Equal(t, -8*time.Second/3, events[0].Offset(4*time.Second))
+	assert.Equal(t, 200*time.Millisecond, events[1].Offset(4*time.Second))
+
+	for name, input := range map[string]string{
+		"empty":         "\n",
+		"unknown topic": `{"topic":"block","slot":"1","slot_time_ms":12000}`,
+		"no slot time":  `{"topic":"head","slot":"1"}`,
+		"malformed":     `{"topic":`,
+	} {
+		_, err := Decode(bufio.NewScanner(strings.NewReader(input)))
+		assert.Error(t, err, name)
+	}
+}
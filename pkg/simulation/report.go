@@ -0,0 +1,162 @@
+package simulation
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// SlotOutcome is the market and result of one simulated slot.
+type SlotOutcome struct {
+	Slot           phase0.Slot `json:"slot"`
+	BlockValueGwei uint64      `json:"block_value_gwei"`
+	CompetitorGwei uint64      `json:"competitor_gwei,omitempty"` // 0 = uncontested
+	Built          bool        `json:"built"`
+	Bids           int         `json:"bids"`
+	BidGwei        uint64      `json:"bid_gwei,omitempty"` // our highest bid on the head
+	Result         string      `json:"result"`             // Result* constants
+	Reveal         string      `json:"reveal,omitempty"`   // Reveal* constants (won slots)
+}
+
+// Report is the result of a simulation run.
+type Report struct {
+	Strategy       string        `json:"strategy"`
+	Seed           uint64        `json:"seed"`
+	Speedup        float64       `json:"speedup"`
+	Slots          int           `json:"slots"`
+	ReplayEvents   int           `json:"replay_events,omitempty"` // recorded events replayed (0 = seeded timing)
+	Elapsed        time.Duration `json:"elapsed"`
+	SlotsPerMinute float64       `json:"slots_per_minute"`
+
+	Built     int `json:"built"`     // slots a payload was built for
+	Bids      int `json:"bids"`      // bids submitted
+	Contested int `json:"contested"` // slots with a competing bid
+
+	Won    int     `json:"won"`
+	Outbid int     `json:"outbid"`
+	NoBid  int     `json:"no_bid"`
+	Missed int     `json:"missed,omitempty"` // replay: slots without a recorded block
+	Rate   float64 `json:"win_rate"`
+
+	RevealsOK      int `json:"reveals_ok"`
+	RevealsFailed  int `json:"reveals_failed"`
+	RevealsSkipped int `json:"reveals_skipped"`
+	RevealsMissing int `json:"reveals_missing"`
+
+	// Won slots only: the payload value captured, the bids paid to the
+	// proposers and the difference.
+	BlockValueGwei uint64 `json:"block_value_gwei"`
+	PaidGwei       uint64 `json:"paid_gwei"`
+	ProfitGwei     int64  `json:"profit_gwei"`
+
+	Outcomes []*SlotOutcome `json:"outcomes"`
+}
+
+// report completes the outcomes with the collected builds and reveals and
+// sums them up.
+func (r *Runner) report(outcomes []*SlotOutcome, elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		Seed:         r.cfg.Seed,
+		Speedup:      r.cfg.Speedup,
+		Slots:        len(outcomes),
+		ReplayEvents: len(r.cfg.Replay),
+		Elapsed:      elapsed,
+		Outcomes:     outcomes,
+	}
+
+	if r.builderCfg != nil {
+		report.Strategy = r.builderCfg.EPBS.NormalizedBidStrategy()
+	}
+
+	if elapsed > 0 {
+		report.SlotsPerMinute = float64(len(outcomes)) / elapsed.Minutes()
+	}
+
+	for _, outcome := range outcomes {
+		outcome.Built = r.built[outcome.Slot]
+		report.Bids += outcome.Bids
+
+		if outcome.Built {
+			report.Built++
+		}
+
+		if outcome.CompetitorGwei > 0 {
+			report.Contested++
+		}
+
+		switch outcome.Result {
+		case ResultWon:
+			report.Won++
+			report.BlockValueGwei += outcome.BlockValueGwei
+			report.PaidGwei += outcome.BidGwei
+
+			outcome.Reveal = r.reveals[outcome.Slot]
+			if outcome.Reveal == "" {
+				outcome.Reveal = RevealMissing
+			}
+
+			switch outcome.Reveal {
+			case RevealOK:
+				report.RevealsOK++
+			case RevealFailed:
+				report.RevealsFailed++
+			case RevealSkipped:
+				report.RevealsSkipped++
+			default:
+				report.RevealsMissing++
+			}
+		case ResultOutbid:
+			report.Outbid++
+		case ResultMissed:
+			report.Missed++
+		default:
+			report.NoBid++
+		}
+	}
+
+	report.ProfitGwei = int64(report.BlockValueGwei) - int64(report.PaidGwei) //nolint:gosec // gwei sums fit
+	if len(outcomes) > 0 {
+		report.Rate = float64(report.Won) / float64(len(outcomes))
+	}
+
+	return report
+}
+
+// WriteText renders the report summary as a human-readable table.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "strategy: %s  seed: %d  speedup: %gx  slots: %d  elapsed: %s  (%.0f slots/min)\n",
+		r.Strategy, r.Seed, r.Speedup, r.Slots, r.Elapsed.Round(time.Millisecond), r.SlotsPerMinute)
+
+	if r.ReplayEvents > 0 {
+		fmt.Fprintf(w, "replayed %d recorded events\n", r.ReplayEvents)
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "built\t%d\n", r.Built)
+	fmt.Fprintf(tw, "bids\t%d\n", r.Bids)
+	fmt.Fprintf(tw, "contested\t%d\n", r.Contested)
+	fmt.Fprintf(tw, "won\t%d\t(%.1f%%)\n", r.Won, r.Rate*100)
+	fmt.Fprintf(tw, "outbid\t%d\n", r.Outbid)
+	fmt.Fprintf(tw, "no bid\t%d\n", r.NoBid)
+
+	if r.ReplayEvents > 0 {
+		fmt.Fprintf(tw, "missed (recorded)\t%d\n", r.Missed)
+	}
+
+	fmt.Fprintf(tw, "reveals\tok %d  failed %d  skipped %d  missing %d\n",
+		r.RevealsOK, r.RevealsFailed, r.RevealsSkipped, r.RevealsMissing)
+	fmt.Fprintf(tw, "block value (won)\t%d gwei\n", r.BlockValueGwei)
+	fmt.Fprintf(tw, "paid\t%d gwei\n", r.PaidGwei)
+	fmt.Fprintf(tw, "profit\t%d gwei\n", r.ProfitGwei)
+
+	return tw.Flush()
+}
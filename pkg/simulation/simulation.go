@@ -0,0 +1,745 @@
+// Package simulation runs buildoor's full build/bid/reveal pipeline against
+// the in-process fake beacon node and engine API of pkg/testing/harness on
+// an accelerated clock. Every simulated slot gets a seeded block value and,
+// optionally, a competing bid; the proposer takes the highest bid, and the
+// report sums up how the configured bid strategy fared. It tunes bidding
+// offline, without a devnet. With a replay recording (see
+// pkg/simulation/recording), the slots follow the recorded head and
+// payload_attributes timing instead, reproducing timing bugs
+// deterministically.
+package simulation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/gloas"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/buildoor"
+	"github.com/ethpandaops/buildoor/pkg/clock"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+	"github.com/ethpandaops/buildoor/pkg/simulation/recording"
+	"github.com/ethpandaops/buildoor/pkg/testing/harness"
+)
+
+// builderPrivkey is the simulated builder's key; the operator's key is never
+// used against the fakes.
+const builderPrivkey = "0x2c1e6a3f8b0d4e7a9c5b1d3f6e8a0c2b4d6f8e0a1c3b5d7f9e1a3c5b7d9f0e2a"
+
+// competitorPrivkey is the key of the competing builder (builder index 0).
+const competitorPrivkey = "0x5a7c9e1b3d5f7a9c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a7c"
+
+const (
+	bidPath = "/eth/v1/beacon/execution_payload_bids"
+
+	// builderBalance funds both builders well beyond any simulated bid.
+	builderBalance = phase0.Gwei(1_000_000_000_000)
+
+	gasLimit = 60_000_000
+
+	// readyTimeout bounds the wait for buildoor's event streams.
+	readyTimeout = 15 * time.Second
+)
+
+// feeRecipient is the proposers' fee recipient in every slot.
+var feeRecipient = bellatrix.ExecutionAddress{0xfe, 0xe0}
+
+// Slot outcomes (SlotOutcome.Result).
+const (
+	// ResultWon means our highest bid beat the competitor and was included.
+	ResultWon = "won"
+	// ResultOutbid means we bid, but not above the competitor.
+	ResultOutbid = "outbid"
+	// ResultNoBid means no bid of ours on the slot's parent arrived in time.
+	ResultNoBid = "no_bid"
+	// ResultMissed means the replayed recording has no block for the slot.
+	ResultMissed = "missed"
+)
+
+// Reveal outcomes of won slots (SlotOutcome.Reveal).
+const (
+	RevealOK      = "ok"
+	RevealFailed  = "failed"
+	RevealSkipped = "skipped"
+	RevealMissing = "missing"
+)
+
+// Config configures a simulation run. The bid strategy itself is the
+// buildoor config handed to NewRunner.
+type Config struct {
+	// Slots is the number of simulated proposal slots (ignored when
+	// replaying: the recording's head slots are simulated).
+	Slots int
+	// Speedup is the clock speedup of the fake chain and buildoor. The fake
+	// chain runs 4s slots, so 50 is 750 slots per minute; high factors
+	// shrink the bid window to a few ticks of the bidder (10ms wall).
+	Speedup float64
+	// Seed seeds the block values and competing bids; equal seeds replay
+	// the same market.
+	Seed uint64
+	// BlockValueMinGwei and BlockValueMaxGwei bound the payload value the
+	// fake engine reports per slot (uniformly drawn).
+	BlockValueMinGwei uint64
+	BlockValueMaxGwei uint64
+	// CompetitorRatio is the fraction (0-1) of slots with a competing bid.
+	CompetitorRatio float64
+	// CompetitorMinGwei and CompetitorMaxGwei bound the competing bid's
+	// value (uniformly drawn).
+	CompetitorMinGwei uint64
+	CompetitorMaxGwei uint64
+	// CompetitorBidTime is when the competing bid is gossiped, milliseconds
+	// relative to the slot start.
+	CompetitorBidTime int64
+	// Replay is a recorded head/payload_attributes event sequence (see
+	// recording.Load). When set, blocks and payload attributes follow the
+	// recorded timing, scaled to the simulated slot time, instead of
+	// arriving at each slot start; the market is drawn as usual.
+	Replay []*recording.Event
+}
+
+// DefaultConfig returns a simulation configuration with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Slots:             1000,
+		Speedup:           50,
+		Seed:              1,
+		BlockValueMinGwei: 5_000_000,
+		BlockValueMaxGwei: 20_000_000,
+		CompetitorRatio:   0.5,
+		CompetitorMinGwei: 5_000_000,
+		CompetitorMaxGwei: 25_000_000,
+		CompetitorBidTime: -1500,
+	}
+}
+
+// Validate checks the configuration for consistency.
+func (c *Config) Validate() error {
+	if c.Slots <= 0 {
+		return fmt.Errorf("slots must be > 0")
+	}
+
+	if c.Speedup < 1 {
+		return fmt.Errorf("speedup must be >= 1")
+	}
+
+	if c.BlockValueMinGwei > c.BlockValueMaxGwei {
+		return fmt.Errorf("block value min must not exceed max")
+	}
+
+	if c.CompetitorRatio < 0 || c.CompetitorRatio > 1 {
+		return fmt.Errorf("competitor ratio must be within [0, 1]")
+	}
+
+	if c.CompetitorMinGwei > c.CompetitorMaxGwei {
+		return fmt.Errorf("competitor bid min must not exceed max")
+	}
+
+	return nil
+}
+
+// Runner executes a simulation.
+type Runner struct {
+	cfg        *Config
+	builderCfg *config.Config
+	log        *logrus.Logger
+	rng        *rand.Rand
+
+	mu      sync.Mutex
+	built   map[phase0.Slot]bool
+	reveals map[phase0.Slot]string
+}
+
+// NewRunner creates a simulation runner. builderCfg supplies the strategy
+// under test: the bidding, reveal, bid budget and schedule settings carry
+// over, everything else (endpoints, keys, persistence) is replaced by the
+// simulation's own.
+func NewRunner(cfg *Config, builderCfg *config.Config, log *logrus.Logger) (*Runner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		cfg:        cfg,
+		builderCfg: builderCfg,
+		log:        log,
+		rng:        rand.New(rand.NewPCG(cfg.Seed, cfg.Seed)), //nolint:gosec // reproducible market, not security
+		built:      make(map[phase0.Slot]bool),
+		reveals:    make(map[phase0.Slot]string),
+	}, nil
+}
+
+// buildoorConfig derives the simulated builder's config from the strategy
+// under test. Timing fields left at zero get defaults that keep the bid
+// window several bidder ticks wide under the speedup.
+func (r *Runner) buildoorConfig(beaconURL, engineURL, jwtPath string) *config.Config {
+	base := r.builderCfg
+	cfg := config.DefaultConfig()
+
+	cfg.BuilderPrivkey = builderPrivkey
+	cfg.CLClient = beaconURL
+	cfg.ELEngineAPI = engineURL
+	cfg.ELJWTSecret = jwtPath
+	cfg.EPBSEnabled = true
+	cfg.ClockSpeedup = r.cfg.Speedup
+
+	if base != nil {
+		cfg.EPBS = base.EPBS
+		cfg.Reveal = base.Reveal
+		cfg.BidBudget = base.BidBudget
+		cfg.Schedule = base.Schedule
+		cfg.ExtraData = base.ExtraData
+	}
+
+	if cfg.EPBS.BuildStartTime == 0 {
+		cfg.EPBS.BuildStartTime = -3000
+	}
+
+	if cfg.EPBS.BidStartTime == 0 {
+		cfg.EPBS.BidStartTime = -2500
+	}
+
+	if cfg.EPBS.BidEndTime == 0 {
+		cfg.EPBS.BidEndTime = -100
+	}
+
+	return cfg
+}
+
+// buildoorLogger caps buildoor's own logging at warnings (a run logs
+// thousands of slots), unless the run logger is at debug level or beyond.
+func (r *Runner) buildoorLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(r.log.Out)
+	log.SetFormatter(r.log.Formatter)
+	log.SetLevel(logrus.WarnLevel)
+
+	if r.log.GetLevel() >= logrus.DebugLevel {
+		log.SetLevel(r.log.GetLevel())
+	}
+
+	return log
+}
+
+// Run starts the fakes and buildoor, drives the configured number of slots
+// and returns the report.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	sc := harness.GloasScenario()
+	sc.Speedup = r.cfg.Speedup
+
+	node, err := harness.NewBeacon(sc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start fake beacon node: %w", err)
+	}
+	defer node.Close()
+
+	engine := harness.NewEngine()
+	defer engine.Close()
+
+	competitorKey, err := signer.NewBLSSigner(competitorPrivkey)
+	if err != nil {
+		return nil, err
+	}
+
+	builderKey, err := signer.NewBLSSigner(builderPrivkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The competitor takes builder index 0, which the blocks of lost slots
+	// (synthetic bids) commit to.
+	competitorIndex := node.AddBuilder(competitorKey.PublicKey(), builderBalance)
+	node.AddBuilder(builderKey.PublicKey(), builderBalance)
+
+	jwtDir, err := os.MkdirTemp("", "buildoor-simulation-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(jwtDir)
+
+	jwtPath := filepath.Join(jwtDir, "jwtsecret")
+	if err := os.WriteFile(jwtPath, []byte(hex.EncodeToString(make([]byte, 32))), 0o600); err != nil {
+		return nil, err
+	}
+
+	b, err := buildoor.New(r.buildoorConfig(node.URL(), engine.URL(), jwtPath), r.buildoorLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := b.Start(runCtx); err != nil {
+		return nil, err
+	}
+	defer b.Stop()
+
+	if err := waitStreams(runCtx, b); err != nil {
+		return nil, err
+	}
+
+	r.collect(runCtx, b)
+
+	sim := &slotDriver{
+		Runner:          r,
+		beacon:          node,
+		engine:          engine,
+		clock:           node.Clock(),
+		competitorIndex: competitorIndex,
+		bids:            make(map[phase0.Slot][]*gloas.SignedExecutionPayloadBid),
+	}
+
+	start := time.Now()
+
+	var outcomes []*SlotOutcome
+
+	if r.cfg.Replay != nil {
+		outcomes, err = sim.replay(runCtx)
+	} else {
+		outcomes, err = sim.run(runCtx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.report(outcomes, time.Since(start)), nil
+}
+
+// waitStreams waits until buildoor's event streams are connected, so events
+// published afterwards are not lost.
+func waitStreams(ctx context.Context, b *buildoor.Buildoor) error {
+	events := b.PayloadBuilder().GetCLClient().Events()
+	deadline := time.Now().Add(readyTimeout)
+
+	for _, topic := range []string{"head", "payload_attributes", "proposer_preferences", "execution_payload_bid"} {
+		for !topicConnected(events.Stats(), topic) {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("buildoor did not subscribe to %s events", topic)
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(20 * time.Millisecond):
+			}
+		}
+	}
+
+	return nil
+}
+
+// topicConnected reports whether the stream of topic is connected.
+func topicConnected(stats []beacon.TopicStats, topic string) bool {
+	for _, s := range stats {
+		if s.Topic == topic {
+			return s.Connected
+		}
+	}
+
+	return false
+}
+
+// collect records built payloads and reveal results in the background
+// until ctx ends.
+func (r *Runner) collect(ctx context.Context, b *buildoor.Buildoor) {
+	payloads := b.SubscribePayloadReady(64)
+	reveals := b.SubscribeRevealResults(64)
+
+	go func() {
+		defer payloads.Unsubscribe()
+		defer reveals.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case payload := <-payloads.Channel():
+				r.mu.Lock()
+				r.built[payload.Attributes.ProposalSlot] = true
+				r.mu.Unlock()
+			case result := <-reveals.Channel():
+				outcome := RevealFailed
+
+				switch {
+				case result.Success:
+					outcome = RevealOK
+				case result.Skipped:
+					outcome = RevealSkipped
+				}
+
+				r.mu.Lock()
+				// A retry's success supersedes an earlier failed attempt.
+				if r.reveals[result.Slot] != RevealOK {
+					r.reveals[result.Slot] = outcome
+				}
+				r.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// slotDriver plays beacon node and proposer for the simulated slots.
+type slotDriver struct {
+	*Runner
+
+	beacon          *harness.Beacon
+	engine          *harness.Engine
+	clock           clock.Clock
+	competitorIndex gloas.BuilderIndex
+
+	seen int // bid submissions decoded so far
+	bids map[phase0.Slot][]*gloas.SignedExecutionPayloadBid
+}
+
+// run drives the slots: at each slot start the proposer picks the winning
+// bid for the slot and the next slot's proposer preferences and payload
+// attributes go out; the competing bid is gossiped at CompetitorBidTime.
+func (d *slotDriver) run(ctx context.Context) ([]*SlotOutcome, error) {
+	first := d.beacon.CurrentSlot() + 2
+	outcomes := make([]*SlotOutcome, d.cfg.Slots)
+
+	if err := d.waitUntil(ctx, d.beacon.SlotStart(first-1)); err != nil {
+		return nil, err
+	}
+
+	if _, err := d.beacon.ProduceBlock(first - 1); err != nil {
+		return nil, err
+	}
+
+	outcomes[0] = d.prepare(first)
+
+	for i, outcome := range outcomes {
+		slot := outcome.Slot
+
+		if outcome.CompetitorGwei > 0 {
+			if err := d.waitUntil(ctx, d.beacon.SlotStart(slot).Add(time.Duration(d.cfg.CompetitorBidTime)*time.Millisecond)); err != nil {
+				return nil, err
+			}
+
+			d.publishCompetitorBid(slot, outcome.CompetitorGwei)
+		}
+
+		if err := d.waitUntil(ctx, d.beacon.SlotStart(slot)); err != nil {
+			return nil, err
+		}
+
+		if err := d.settle(outcome); err != nil {
+			return nil, err
+		}
+
+		if i+1 < len(outcomes) {
+			outcomes[i+1] = d.prepare(slot + 1)
+		}
+	}
+
+	// Won slots reveal within their slot.
+	if err := d.waitUntil(ctx, d.beacon.SlotStart(outcomes[len(outcomes)-1].Slot+1)); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// prepare draws the slot's market and announces the slot to buildoor.
+func (d *slotDriver) prepare(slot phase0.Slot) *SlotOutcome {
+	outcome := d.market(slot)
+	d.announce(outcome, feeRecipient)
+
+	return outcome
+}
+
+// announce sets the slot's block value and publishes its proposer
+// preferences and payload attributes.
+func (d *slotDriver) announce(outcome *SlotOutcome, recipient bellatrix.ExecutionAddress) {
+	wei := new(big.Int).Mul(new(big.Int).SetUint64(outcome.BlockValueGwei), big.NewInt(1_000_000_000))
+	d.engine.SetBlockValue(wei)
+
+	if err := d.beacon.PublishProposerPreferences(outcome.Slot, recipient, gasLimit); err != nil {
+		d.log.WithError(err).WithField("slot", outcome.Slot).Warn("Failed to publish proposer preferences")
+	}
+
+	d.beacon.PublishPayloadAttributes(outcome.Slot, recipient)
+}
+
+// replayAction is one timed action of a replay: a recorded event or, with a
+// nil event, the slot's competing bid.
+type replayAction struct {
+	at      time.Time
+	event   *recording.Event
+	outcome *SlotOutcome
+}
+
+// replay drives the slots spanned by the recording's head events: each
+// recorded head produces its slot's block (the proposer settles the slot as
+// in run) and each payload_attributes event announces its proposal slot, at
+// the recorded offsets scaled to the simulated slot time. Slots without a
+// recorded head stay empty (ResultMissed).
+func (d *slotDriver) replay(ctx context.Context) ([]*SlotOutcome, error) {
+	firstRecorded, lastRecorded := replaySpan(d.cfg.Replay)
+
+	first := d.beacon.CurrentSlot() + 2
+	slotTime := d.beacon.SlotStart(first + 1).Sub(d.beacon.SlotStart(first))
+
+	// Every slot's market is drawn up front, in slot order, so it only
+	// depends on the seed.
+	outcomes := make([]*SlotOutcome, lastRecorded-firstRecorded+1)
+	actions := make([]*replayAction, 0, len(d.cfg.Replay)+len(outcomes))
+
+	for i := range outcomes {
+		outcomes[i] = d.market(first + phase0.Slot(i))
+
+		if outcomes[i].CompetitorGwei > 0 {
+			actions = append(actions, &replayAction{
+				at:      d.beacon.SlotStart(outcomes[i].Slot).Add(time.Duration(d.cfg.CompetitorBidTime) * time.Millisecond),
+				outcome: outcomes[i],
+			})
+		}
+	}
+
+	for _, event := range d.cfg.Replay {
+		if event.Slot < firstRecorded || event.Slot > lastRecorded {
+			continue
+		}
+
+		outcome := outcomes[event.Slot-firstRecorded]
+		actions = append(actions, &replayAction{
+			at:      d.beacon.SlotStart(outcome.Slot).Add(event.Offset(slotTime)),
+			event:   event,
+			outcome: outcome,
+		})
+	}
+
+	// Stable: events due at the same time keep their recorded order.
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].at.Before(actions[j].at) })
+
+	if err := d.waitUntil(ctx, d.beacon.SlotStart(first-1)); err != nil {
+		return nil, err
+	}
+
+	if _, err := d.beacon.ProduceBlock(first - 1); err != nil {
+		return nil, err
+	}
+
+	for _, action := range actions {
+		if err := d.waitUntil(ctx, action.at); err != nil {
+			return nil, err
+		}
+
+		if err := d.runAction(action); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, outcome := range outcomes {
+		if outcome.Result == "" {
+			outcome.Result = ResultMissed
+		}
+	}
+
+	// Won slots reveal within their slot.
+	if err := d.waitUntil(ctx, d.beacon.SlotStart(outcomes[len(outcomes)-1].Slot+1)); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// runAction executes one replay action.
+func (d *slotDriver) runAction(action *replayAction) error {
+	outcome := action.outcome
+
+	switch {
+	case action.event == nil:
+		d.publishCompetitorBid(outcome.Slot, outcome.CompetitorGwei)
+	case action.event.Topic == recording.TopicPayloadAttributes:
+		recipient := feeRecipient
+		if action.event.FeeRecipient != "" {
+			recipient = bellatrix.ExecutionAddress(common.HexToAddress(action.event.FeeRecipient))
+		}
+
+		d.announce(outcome, recipient)
+	case outcome.Result == "":
+		return d.settle(outcome)
+	default:
+		// A repeated head of a settled slot (reorg): the slot's block
+		// is produced again on the current head.
+		_, err := d.beacon.ProduceBlock(outcome.Slot)
+
+		return err
+	}
+
+	return nil
+}
+
+// replaySpan returns the first and last slot with a recorded head, or the
+// span of all events when the recording has no head event.
+func replaySpan(events []*recording.Event) (phase0.Slot, phase0.Slot) {
+	first, last := events[0].Slot, events[0].Slot
+	heads := false
+
+	for _, event := range events {
+		if event.Topic != recording.TopicHead {
+			continue
+		}
+
+		if !heads || event.Slot < first {
+			first = event.Slot
+		}
+
+		if !heads || event.Slot > last {
+			last = event.Slot
+		}
+
+		heads = true
+	}
+
+	if heads {
+		return first, last
+	}
+
+	for _, event := range events {
+		first = min(first, event.Slot)
+		last = max(last, event.Slot)
+	}
+
+	return first, last
+}
+
+// market draws the slot's block value and competing bid (0 = none). Every
+// draw happens every slot, so the market only depends on the seed.
+func (r *Runner) market(slot phase0.Slot) *SlotOutcome {
+	outcome := &SlotOutcome{
+		Slot:           slot,
+		BlockValueGwei: r.uniform(r.cfg.BlockValueMinGwei, r.cfg.BlockValueMaxGwei),
+	}
+
+	contested := r.rng.Float64() < r.cfg.CompetitorRatio
+	competitorGwei := r.uniform(r.cfg.CompetitorMinGwei, r.cfg.CompetitorMaxGwei)
+
+	if contested {
+		outcome.CompetitorGwei = max(competitorGwei, 1)
+	}
+
+	return outcome
+}
+
+// uniform draws a value in [minValue, maxValue].
+func (r *Runner) uniform(minValue, maxValue uint64) uint64 {
+	return minValue + r.rng.Uint64N(maxValue-minValue+1)
+}
+
+// publishCompetitorBid gossips the competing builder's bid on the head.
+func (d *slotDriver) publishCompetitorBid(slot phase0.Slot, valueGwei uint64) {
+	_, headRoot, headHash := d.beacon.Head()
+
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(slot))
+
+	d.beacon.PublishBid(&gloas.SignedExecutionPayloadBid{
+		Message: &gloas.ExecutionPayloadBid{
+			ParentBlockHash:    headHash,
+			ParentBlockRoot:    headRoot,
+			BlockHash:          phase0.Hash32(sha256.Sum256(append([]byte("competitor-block-"), buf[:]...))),
+			FeeRecipient:       feeRecipient,
+			GasLimit:           gasLimit,
+			BuilderIndex:       d.competitorIndex,
+			Slot:               slot,
+			Value:              phase0.Gwei(valueGwei),
+			BlobKZGCommitments: []deneb.KZGCommitment{},
+		},
+	})
+}
+
+// settle plays the slot's proposer: our highest bid on the head wins when
+// it beats the competing bid, otherwise the block commits to the
+// competitor.
+func (d *slotDriver) settle(outcome *SlotOutcome) error {
+	if err := d.pollBids(); err != nil {
+		return err
+	}
+
+	_, headRoot, _ := d.beacon.Head()
+
+	var best *gloas.SignedExecutionPayloadBid
+
+	for _, bid := range d.bids[outcome.Slot] {
+		outcome.Bids++
+
+		if bid.Message.ParentBlockRoot == headRoot && (best == nil || bid.Message.Value > best.Message.Value) {
+			best = bid
+		}
+	}
+
+	delete(d.bids, outcome.Slot)
+
+	switch {
+	case best == nil:
+		outcome.Result = ResultNoBid
+	case uint64(best.Message.Value) > outcome.CompetitorGwei:
+		outcome.Result = ResultWon
+		outcome.BidGwei = uint64(best.Message.Value)
+
+		_, err := d.beacon.IncludeBid(best)
+
+		return err
+	default:
+		outcome.Result = ResultOutbid
+		outcome.BidGwei = uint64(best.Message.Value)
+	}
+
+	_, err := d.beacon.ProduceBlock(outcome.Slot)
+
+	return err
+}
+
+// pollBids decodes the bid submissions received since the last poll.
+func (d *slotDriver) pollBids() error {
+	submissions := d.beacon.Submissions(bidPath)
+
+	for _, submission := range submissions[d.seen:] {
+		bid, err := d.beacon.DecodeBid(submission)
+		if err != nil {
+			return fmt.Errorf("failed to decode bid submission: %w", err)
+		}
+
+		d.bids[bid.Message.Slot] = append(d.bids[bid.Message.Slot], bid)
+	}
+
+	d.seen = len(submissions)
+
+	return nil
+}
+
+// waitUntil blocks until the chain clock reaches t.
+func (d *slotDriver) waitUntil(ctx context.Context, t time.Time) error {
+	wait := d.clock.Until(t)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-d.clock.After(wait):
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package simulation
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/simulation/recording"
+)
+
+func TestRunDrivesSlotsThroughThePipeline(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	cfg := DefaultConfig()
+	cfg.Slots = 24
+	cfg.Speedup = 20
+	// Competitors bid far below the block value, so every bid of ours wins.
+	cfg.CompetitorRatio = 0.5
+	cfg.CompetitorMinGwei = 1
+	cfg.CompetitorMaxGwei = 1000
+
+	runner, err := NewRunner(cfg, config.DefaultConfig(), log)
+	require.NoError(t, err)
+
+	report, err := runner.Run(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, report.Outcomes, cfg.Slots)
+	assert.Equal(t, config.BidStrategyLinear, report.Strategy)
+	assert.Equal(t, cfg.Slots, report.Won+report.Outbid+report.NoBid)
+	assert.Positive(t, report.Contested)
+	assert.Zero(t, report.Outbid)
+	assert.Positive(t, report.Won, "no slot won")
+	assert.Positive(t, report.RevealsOK, "no payload revealed")
+	assert.GreaterOrEqual(t, report.Built, report.Won)
+
+	for i, outcome := range report.Outcomes {
+		assert.Equal(t, report.Outcomes[0].Slot+phase0.Slot(i), outcome.Slot)
+
+		if outcome.Result == ResultWon {
+			assert.Greater(t, outcome.BidGwei, outcome.CompetitorGwei)
+		}
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	assert.Contains(t, out.String(), "strategy: linear")
+}
+
+func TestMarketIsReproducible(t *testing.T) {
+	draw := func(seed uint64) []*SlotOutcome {
+		cfg := DefaultConfig()
+		cfg.Seed = seed
+
+		runner, err := NewRunner(cfg, nil, logrus.New())
+		require.NoError(t, err)
+
+		outcomes := make([]*SlotOutcome, 0, 32)
+
+		for slot := range phase0.Slot(32) {
+			outcome := runner.market(slot)
+			assert.GreaterOrEqual(t, outcome.BlockValueGwei, cfg.BlockValueMinGwei)
+			assert.LessOrEqual(t, outcome.BlockValueGwei, cfg.BlockValueMaxGwei)
+
+			outcomes = append(outcomes, outcome)
+		}
+
+		return outcomes
+	}
+
+	assert.Equal(t, draw(7), draw(7))
+	assert.NotEqual(t, draw(7), draw(8))
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultConfig().Validate())
+
+	for name, mutate := range map[string]func(*Config){
+		"no slots":         func(c *Config) { c.Slots = 0 },
+		"slowdown":         func(c *Config) { c.Speedup = 0.5 },
+		"block value":      func(c *Config) { c.BlockValueMinGwei = c.BlockValueMaxGwei + 1 },
+		"competitor ratio": func(c *Config) { c.CompetitorRatio = 1.5 },
+		"competitor value": func(c *Config) { c.CompetitorMinGwei = c.CompetitorMaxGwei + 1 },
+	} {
+		cfg := DefaultConfig()
+		mutate(cfg)
+		assert.Error(t, cfg.Validate(), name)
+	}
+}
+
+func TestReplayFollowsRecordedTiming(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	// Eight recorded 12s slots: payload attributes 8s before each slot,
+	// the block 500ms into it; slot 103 has no block.
+	var events []*recording.Event
+
+	for slot := phase0.Slot(100); slot < 108; slot++ {
+		events = append(events, &recording.Event{
+			Topic:      recording.TopicPayloadAttributes,
+			Slot:       slot,
+			OffsetMs:   -8000,
+			SlotTimeMs: 12000,
+		})
+
+		if slot != 103 {
+			events = append(events, &recording.Event{Topic: recording.TopicHead, Slot: slot, OffsetMs: 500, SlotTimeMs: 12000})
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.Speedup = 20
+	cfg.CompetitorRatio = 0
+	cfg.Replay = events
+
+	runner, err := NewRunner(cfg, config.DefaultConfig(), log)
+	require.NoError(t, err)
+
+	report, err := runner.Run(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, report.Outcomes, 8)
+	assert.Equal(t, len(events), report.ReplayEvents)
+	assert.Equal(t, 1, report.Missed)
+	assert.Equal(t, ResultMissed, report.Outcomes[3].Result)
+	assert.Positive(t, report.Won, "no slot won")
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	assert.Contains(t, out.String(), "replayed 15 recorded events")
+}
@@ -0,0 +1,137 @@
+package slot_results
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethpandaops/buildoor/pkg/db"
+)
+
+// Archive store names, the file name prefix of each store's archive files.
+const (
+	ArchiveStoreResults   = "slot-results"
+	ArchiveStoreArtifacts = "slot-artifacts"
+)
+
+// Archiver writes records due for pruning to compressed NDJSON files in a
+// directory, one file per store and UTC day of the record's slot:
+// <dir>/<store>-YYYY-MM-DD.ndjson.gz. Every archive run appends one gzip
+// member to the day's file; multi-member gzip files decompress as a single
+// stream (zcat, gzip.Reader).
+type Archiver struct {
+	dir string
+}
+
+// NewArchiver creates an archiver writing to dir, or returns nil when dir is
+// empty (archiving disabled). The directory is created on first write.
+func NewArchiver(dir string) *Archiver {
+	if dir == "" {
+		return nil
+	}
+
+	return &Archiver{dir: dir}
+}
+
+// newWriter opens an archive run for one store. The caller must Close it.
+func (a *Archiver) newWriter(store string) *archiveWriter {
+	return &archiveWriter{dir: a.dir, store: store}
+}
+
+// archiveWriter appends records of one store, switching files when the
+// record day changes. Records should be written in slot order so every day's
+// file is opened once per run.
+type archiveWriter struct {
+	dir   string
+	store string
+
+	day  string
+	file *os.File
+	gz   *gzip.Writer
+	enc  *json.Encoder
+}
+
+// Write appends one record to the file of the day containing at (UTC).
+func (w *archiveWriter) Write(at time.Time, record any) error {
+	if day := at.UTC().Format(time.DateOnly); day != w.day || w.enc == nil {
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		if err := w.open(day); err != nil {
+			return err
+		}
+	}
+
+	if err := w.enc.Encode(record); err != nil {
+		return fmt.Errorf("failed to write %s archive record: %w", w.store, err)
+	}
+
+	return nil
+}
+
+func (w *archiveWriter) open(day string) error {
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	path := filepath.Join(w.dir, fmt.Sprintf("%s-%s.ndjson.gz", w.store, day))
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644) //nolint:gosec // operator-configured path
+	if err != nil {
+		return fmt.Errorf("failed to open archive file: %w", err)
+	}
+
+	w.day = day
+	w.file = file
+	w.gz = gzip.NewWriter(file)
+	w.enc = json.NewEncoder(w.gz)
+
+	return nil
+}
+
+// Close finishes the current gzip member and syncs the file. Safe to call
+// repeatedly.
+func (w *archiveWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+
+	err := errors.Join(w.gz.Close(), w.file.Sync(), w.file.Close())
+
+	w.file, w.gz, w.enc = nil, nil, nil
+
+	if err != nil {
+		return fmt.Errorf("failed to close %s archive file: %w", w.store, err)
+	}
+
+	return nil
+}
+
+// artifactArchiveRecord is the archived form of a slot artifact; Data is the
+// base64-encoded SSZ object.
+type artifactArchiveRecord struct {
+	Slot      uint64 `json:"slot"`
+	Kind      string `json:"kind"`
+	Idx       int    `json:"idx"`
+	Fork      int64  `json:"fork"`
+	Meta      string `json:"meta,omitempty"`
+	Data      []byte `json:"data"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func newArtifactArchiveRecord(artifact *db.SlotArtifact) *artifactArchiveRecord {
+	return &artifactArchiveRecord{
+		Slot:      artifact.Slot,
+		Kind:      artifact.Kind,
+		Idx:       artifact.Idx,
+		Fork:      artifact.Fork,
+		Meta:      artifact.Meta,
+		Data:      artifact.Data,
+		CreatedAt: artifact.CreatedAt,
+	}
+}
@@ -365,6 +365,47 @@ func (s *ArtifactStore) ListBids(slot phase0.Slot) ([]db.SlotArtifact, error) {
 	return s.stateDB.GetSlotArtifactMetas(uint64(slot), ArtifactKindBid)
 }
 
+// SizeCapCutoff returns the slot below which the stored artifacts must be
+// pruned to keep their data within maxBytes (newest slots are kept), or 0
+// when they already fit.
+func (s *ArtifactStore) SizeCapCutoff(maxBytes uint64) (phase0.Slot, error) {
+	sizes, err := s.stateDB.GetSlotArtifactSizes()
+	if err != nil {
+		return 0, err
+	}
+
+	var total uint64
+
+	for _, size := range sizes {
+		total += uint64(max(size.Bytes, 0))
+		if total > maxBytes {
+			return phase0.Slot(size.Slot + 1), nil
+		}
+	}
+
+	return 0, nil
+}
+
+// ArchiveBefore writes all stored artifacts for slots below the cutoff to the
+// archiver (day files by slot time) and returns the number of archived
+// artifacts.
+func (s *ArtifactStore) ArchiveBefore(cutoff phase0.Slot, archiver *Archiver,
+	slotTime func(slot phase0.Slot) time.Time) (int, error) {
+	writer := archiver.newWriter(ArchiveStoreArtifacts)
+	archived := 0
+
+	err := s.stateDB.ForEachSlotArtifactBefore(uint64(cutoff), func(artifact *db.SlotArtifact) error {
+		archived++
+		return writer.Write(slotTime(phase0.Slot(artifact.Slot)), newArtifactArchiveRecord(artifact))
+	})
+
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+
+	return archived, err
+}
+
 // PruneBefore drops all artifacts for slots below the cutoff from the buffer
 // and the database.
 func (s *ArtifactStore) PruneBefore(cutoff phase0.Slot) {
@@ -0,0 +1,236 @@
+package slot_results
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// AssertionStatus is the verdict of one slot assertion.
+type AssertionStatus string
+
+// Assertion verdicts. Skip marks assertions that do not apply to the slot
+// (e.g. nothing to reveal for a slot that was not won).
+const (
+	AssertionPass    AssertionStatus = "pass"
+	AssertionFail    AssertionStatus = "fail"
+	AssertionPending AssertionStatus = "pending"
+	AssertionSkip    AssertionStatus = "skip"
+)
+
+// Assertion names, in evaluation order.
+const (
+	AssertionBuiltOnTime = "built_on_time"
+	AssertionBidAboveMin = "bid_above_min"
+	AssertionRevealed    = "revealed"
+	AssertionBlockLanded = "block_landed"
+)
+
+// Assertion is one expected builder behaviour and its verdict for a slot.
+type Assertion struct {
+	Name   string          `json:"name"`
+	Status AssertionStatus `json:"status"`
+	Detail string          `json:"detail,omitempty"`
+}
+
+// SlotAssertions summarizes the expected builder behaviour of a slot for
+// automated (devnet) assertions. Status is fail when any assertion failed,
+// pending when any is still undecided, skip when none applied, pass otherwise.
+type SlotAssertions struct {
+	Slot       phase0.Slot     `json:"slot"`
+	Status     AssertionStatus `json:"status"`
+	Assertions []Assertion     `json:"assertions"`
+}
+
+// Assess evaluates the slot's recorded history against the expected builder
+// behaviour: the payload was built before slotStart, a bid at or above the
+// slot's minimum was placed, the payload of a won slot was revealed, and it
+// landed canonically. currentSlot decides when missing outcomes turn from
+// pending into failures (the slot has passed).
+func Assess(result *SlotResult, slotStart time.Time, currentSlot phase0.Slot) *SlotAssertions {
+	assessment := &SlotAssertions{
+		Slot: result.Slot,
+		Assertions: []Assertion{
+			assessBuild(result, slotStart, currentSlot),
+			assessBids(result),
+			assessReveal(result),
+			assessLanding(result, currentSlot),
+		},
+	}
+
+	assessment.Status = AssertionSkip
+
+	for _, a := range assessment.Assertions {
+		switch {
+		case a.Status == AssertionFail:
+			assessment.Status = AssertionFail
+		case a.Status == AssertionPending && assessment.Status != AssertionFail:
+			assessment.Status = AssertionPending
+		case a.Status == AssertionPass && assessment.Status == AssertionSkip:
+			assessment.Status = AssertionPass
+		}
+	}
+
+	return assessment
+}
+
+// buildExpected reports whether the applied plan scheduled a build.
+func buildExpected(result *SlotResult) bool {
+	return result.AppliedPlan != nil && result.AppliedPlan.Build != nil && result.AppliedPlan.Build.Build
+}
+
+// won reports whether the slot's payload was requested by its proposer: an
+// ePBS reveal was due, or a blinded block was submitted.
+func won(result *SlotResult) bool {
+	return len(result.RevealAttempts) > 0 || len(result.BlockSubmissions) > 0 || result.Inclusion != nil
+}
+
+func assessBuild(result *SlotResult, slotStart time.Time, currentSlot phase0.Slot) Assertion {
+	a := Assertion{Name: AssertionBuiltOnTime}
+	build := result.Build
+
+	switch {
+	case build == nil && buildExpected(result) && currentSlot > result.Slot:
+		a.Status, a.Detail = AssertionFail, "no build recorded"
+	case build == nil && buildExpected(result):
+		a.Status, a.Detail = AssertionPending, "build not started"
+	case build == nil:
+		a.Status, a.Detail = AssertionSkip, "no build scheduled"
+	case build.Status == BuildStatusSkipped:
+		a.Status, a.Detail = AssertionSkip, "build skipped: "+build.SkipReason
+	case build.Status == BuildStatusReady && !slotStart.IsZero() && build.At.After(slotStart):
+		a.Status = AssertionFail
+		a.Detail = fmt.Sprintf("payload ready %dms after slot start", build.At.Sub(slotStart).Milliseconds())
+	case build.Status == BuildStatusReady:
+		a.Status = AssertionPass
+	case build.Status == BuildStatusFailed || build.Status == BuildStatusNoAttributes:
+		a.Status, a.Detail = AssertionFail, fmt.Sprintf("build %s", build.Status)
+		if build.Error != "" {
+			a.Detail += ": " + build.Error
+		}
+	case currentSlot > result.Slot:
+		a.Status, a.Detail = AssertionFail, fmt.Sprintf("build still %s after the slot", build.Status)
+	default:
+		a.Status, a.Detail = AssertionPending, fmt.Sprintf("build %s", build.Status)
+	}
+
+	return a
+}
+
+func assessBids(result *SlotResult) Assertion {
+	a := Assertion{Name: AssertionBidAboveMin}
+
+	var minGwei uint64
+
+	p2pExpected := buildExpected(result) && result.AppliedPlan.Bid != nil
+	if p2pExpected && !result.AppliedPlan.Bid.Canary {
+		minGwei = result.AppliedPlan.Bid.MinGwei
+	}
+
+	var (
+		placed  bool
+		highest uint64
+	)
+
+	for _, bid := range result.Bids {
+		if bid.Status != BidStatusSubmitted && bid.Status != BidStatusServed {
+			continue
+		}
+
+		placed = true
+		highest = max(highest, bid.TotalValueGwei)
+	}
+
+	switch {
+	case !placed && p2pExpected:
+		a.Status, a.Detail = AssertionFail, "no bid submitted or served"
+	case !placed:
+		a.Status, a.Detail = AssertionSkip, "no bid expected"
+	case highest < minGwei:
+		a.Status = AssertionFail
+		a.Detail = fmt.Sprintf("highest bid %d gwei below minimum %d gwei", highest, minGwei)
+	default:
+		a.Status = AssertionPass
+		a.Detail = fmt.Sprintf("highest bid %d gwei, minimum %d gwei", highest, minGwei)
+	}
+
+	return a
+}
+
+func assessReveal(result *SlotResult) Assertion {
+	a := Assertion{Name: AssertionRevealed}
+
+	if !won(result) {
+		a.Status, a.Detail = AssertionSkip, "slot not won"
+		return a
+	}
+
+	suppressed := len(result.RevealAttempts) > 0
+	lastIssue := ""
+
+	for _, reveal := range result.RevealAttempts {
+		switch reveal.Status {
+		case RevealStatusPublished:
+			a.Status = AssertionPass
+			return a
+		case RevealStatusSuppressed:
+		default:
+			suppressed = false
+			lastIssue = fmt.Sprintf("reveal %s", reveal.Status)
+
+			if reveal.SkipReason != "" {
+				lastIssue += ": " + reveal.SkipReason
+			} else if reveal.Error != "" {
+				lastIssue += ": " + reveal.Error
+			}
+		}
+	}
+
+	for _, submission := range result.BlockSubmissions {
+		switch submission.Status {
+		case SubmissionStatusAccepted:
+			a.Status = AssertionPass
+			return a
+		case SubmissionStatusFailed:
+			lastIssue = "block submission failed: " + submission.Error
+		}
+	}
+
+	switch {
+	case suppressed && len(result.BlockSubmissions) == 0:
+		a.Status, a.Detail = AssertionSkip, "reveal suppressed by plan"
+	case result.Inclusion != nil:
+		// Pre-Gloas wins outside the recorded submissions (e.g. a retained
+		// inclusion after a restart): the payload landed, so it was revealed.
+		a.Status = AssertionPass
+	case lastIssue == "":
+		a.Status, a.Detail = AssertionPending, "reveal in progress"
+	default:
+		a.Status, a.Detail = AssertionFail, lastIssue
+	}
+
+	return a
+}
+
+func assessLanding(result *SlotResult, currentSlot phase0.Slot) Assertion {
+	a := Assertion{Name: AssertionBlockLanded}
+	inclusion := result.Inclusion
+
+	switch {
+	case inclusion == nil && !won(result):
+		a.Status, a.Detail = AssertionSkip, "slot not won"
+	case inclusion == nil && currentSlot > result.Slot+1:
+		a.Status, a.Detail = AssertionFail, "won payload not seen at the head"
+	case inclusion == nil:
+		a.Status, a.Detail = AssertionPending, "inclusion not seen yet"
+	case inclusion.PayloadStatus == PayloadStatusPending:
+		a.Status, a.Detail = AssertionPending, "awaiting the follow-up block"
+	case inclusion.PayloadStatus == PayloadStatusMissed || inclusion.PayloadStatus == PayloadStatusOrphaned:
+		a.Status, a.Detail = AssertionFail, "payload "+string(inclusion.PayloadStatus)
+	default:
+		a.Status, a.Detail = AssertionPass, inclusion.BlockHash
+	}
+
+	return a
+}
@@ -0,0 +1,118 @@
+package slot_results
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+)
+
+// verdicts maps each assertion of the assessment to its status.
+func verdicts(a *SlotAssertions) map[string]AssertionStatus {
+	out := make(map[string]AssertionStatus, len(a.Assertions))
+	for _, assertion := range a.Assertions {
+		out[assertion.Name] = assertion.Status
+	}
+
+	return out
+}
+
+func TestAssess(t *testing.T) {
+	slotStart := time.Unix(1_700_000_000, 0)
+	plan := &action_plan.FrozenPlan{
+		Build: &action_plan.ResolvedBuildSettings{Build: true},
+		Bid:   &action_plan.ResolvedBidSettings{MinGwei: 1000},
+	}
+
+	wonSlot := func() *SlotResult {
+		return &SlotResult{
+			Slot:           10,
+			AppliedPlan:    plan,
+			Build:          &BuildOutcome{Status: BuildStatusReady, At: slotStart.Add(-2 * time.Second)},
+			Bids:           []BidAttempt{{Status: BidStatusSubmitted, TotalValueGwei: 1500}},
+			RevealAttempts: []RevealAttempt{{Status: RevealStatusPublished}},
+			Inclusion:      &InclusionResult{BlockHash: "0xab", PayloadStatus: PayloadStatusCanonical},
+		}
+	}
+
+	t.Run("won slot passes", func(t *testing.T) {
+		a := Assess(wonSlot(), slotStart, 12)
+		assert.Equal(t, AssertionPass, a.Status)
+		assert.Equal(t, map[string]AssertionStatus{
+			AssertionBuiltOnTime: AssertionPass,
+			AssertionBidAboveMin: AssertionPass,
+			AssertionRevealed:    AssertionPass,
+			AssertionBlockLanded: AssertionPass,
+		}, verdicts(a))
+	})
+
+	t.Run("late build and low bid fail", func(t *testing.T) {
+		result := wonSlot()
+		result.Build.At = slotStart.Add(250 * time.Millisecond)
+		result.Bids[0].TotalValueGwei = 999
+
+		a := Assess(result, slotStart, 12)
+		assert.Equal(t, AssertionFail, a.Status)
+		assert.Equal(t, AssertionFail, verdicts(a)[AssertionBuiltOnTime])
+		assert.Equal(t, "payload ready 250ms after slot start", a.Assertions[0].Detail)
+		assert.Equal(t, AssertionFail, verdicts(a)[AssertionBidAboveMin])
+	})
+
+	t.Run("lost slot skips reveal and landing", func(t *testing.T) {
+		result := wonSlot()
+		result.RevealAttempts = nil
+		result.Inclusion = nil
+
+		a := Assess(result, slotStart, 12)
+		assert.Equal(t, AssertionPass, a.Status)
+		assert.Equal(t, AssertionSkip, verdicts(a)[AssertionRevealed])
+		assert.Equal(t, AssertionSkip, verdicts(a)[AssertionBlockLanded])
+	})
+
+	t.Run("undecided outcomes are pending until the slot passed", func(t *testing.T) {
+		result := wonSlot()
+		result.Inclusion = nil
+
+		assert.Equal(t, AssertionPending, Assess(result, slotStart, 11).Status)
+		assert.Equal(t, AssertionFail, Assess(result, slotStart, 12).Status)
+
+		result = wonSlot()
+		result.Inclusion.PayloadStatus = PayloadStatusPending
+		assert.Equal(t, AssertionPending, Assess(result, slotStart, 12).Status)
+
+		result.Inclusion.PayloadStatus = PayloadStatusMissed
+		assert.Equal(t, AssertionFail, Assess(result, slotStart, 12).Status)
+	})
+
+	t.Run("failed reveal and suppressed reveal", func(t *testing.T) {
+		result := wonSlot()
+		result.Inclusion = nil
+		result.RevealAttempts = []RevealAttempt{{Status: RevealStatusSkipped, SkipReason: "late"}}
+
+		a := Assess(result, slotStart, 11)
+		require.Equal(t, AssertionFail, verdicts(a)[AssertionRevealed])
+		assert.Equal(t, "reveal skipped: late", a.Assertions[2].Detail)
+
+		result.RevealAttempts = []RevealAttempt{{Status: RevealStatusSuppressed}}
+		assert.Equal(t, AssertionSkip, verdicts(Assess(result, slotStart, 11))[AssertionRevealed])
+	})
+
+	t.Run("unscheduled slot skips everything", func(t *testing.T) {
+		a := Assess(&SlotResult{Slot: 10}, slotStart, 12)
+		assert.Equal(t, AssertionSkip, a.Status)
+	})
+
+	t.Run("Builder API submission counts as reveal", func(t *testing.T) {
+		result := wonSlot()
+		result.RevealAttempts = nil
+		result.BlockSubmissions = []BlockSubmission{
+			{Status: SubmissionStatusReceived},
+			{Status: SubmissionStatusAccepted},
+		}
+
+		assert.Equal(t, AssertionPass, verdicts(Assess(result, slotStart, 12))[AssertionRevealed])
+	})
+}
@@ -3,6 +3,8 @@ package slot_results
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -10,6 +12,7 @@ import (
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/go-eth2-client/spec/version"
+	dynssz "github.com/pk910/dynamic-ssz"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
@@ -39,7 +42,8 @@ const (
 // the single sink for build/bid/submission/reveal/inclusion signals — event
 // subscriptions for the in-process services, direct recorder calls for the
 // request-scoped Builder API handlers — and prunes both stores to their
-// retention windows on epoch transitions.
+// retention windows and size caps on epoch transitions, archiving them first
+// when an archive directory is configured.
 type Tracker struct {
 	cfg      *config.Config
 	chainSvc chain.Service
@@ -50,9 +54,11 @@ type Tracker struct {
 	epbsSvc          *p2p_bidder.Service // may be nil pre-Gloas
 	revealSvc        *payload_bidder.RevealService
 	inclusionTracker *payload_bidder.InclusionTracker
+	signingAuditor   *payload_bidder.SigningAuditor // optional; SetSigningAuditor
 
 	store     *memstore.Store[phase0.Slot, *SlotResult]
 	artifacts *ArtifactStore
+	archiver  *Archiver // nil unless cfg.RetentionArchiveDir is set
 
 	mu        sync.Mutex
 	lastFired map[phase0.Slot]time.Time // update-event coalescing per slot
@@ -89,6 +95,7 @@ func NewTracker(cfg *config.Config, chainSvc chain.Service, stateDB *db.Database
 		inclusionTracker: inclusionTracker,
 		store:            memstore.New[phase0.Slot, *SlotResult](),
 		artifacts:        NewArtifactStore(stateDB, trackerLog),
+		archiver:         NewArchiver(cfg.RetentionArchiveDir),
 		lastFired:        make(map[phase0.Slot]time.Time, 8),
 		pending:          make(map[phase0.Slot]*SlotResult, 8),
 		flushPending:     make(map[phase0.Slot]bool, 8),
@@ -104,6 +111,12 @@ func (t *Tracker) SetPersistence(ctx context.Context, stateDB *db.Database) {
 	t.store.SetPersistence(ctx, db.NewKVPersistence(stateDB, Namespace, ResultCodec{}), t.log)
 }
 
+// SetSigningAuditor wires the optional signing auditor whose records are
+// stored on the slots' results. Must be called before Start.
+func (t *Tracker) SetSigningAuditor(auditor *payload_bidder.SigningAuditor) {
+	t.signingAuditor = auditor
+}
+
 // Start subscribes to all outcome sources and launches the tracker loop and
 // the artifact writer.
 func (t *Tracker) Start(ctx context.Context) error {
@@ -130,13 +143,18 @@ func (t *Tracker) Start(ctx context.Context) error {
 		revealSub = t.revealSvc.SubscribeResults(16, true)
 	}
 
+	var signingSub *utils.Subscription[*payload_bidder.SigningRecord]
+	if t.signingAuditor != nil {
+		signingSub = t.signingAuditor.SubscribeRecords(64, true)
+	}
+
 	includedSub := t.inclusionTracker.SubscribeIncluded(16, true)
 	payloadStatusSub := t.inclusionTracker.SubscribePayloadStatus(16, true)
 
 	t.wg.Add(1)
 
 	go t.run(readySub, startedSub, failedSub, skippedSub, bidSub, revealSub,
-		includedSub, payloadStatusSub, epochSub)
+		signingSub, includedSub, payloadStatusSub, epochSub)
 
 	t.log.Info("Slot results tracker started")
 
@@ -165,6 +183,7 @@ func (t *Tracker) run(
 	skippedSub *utils.Subscription[*payload_builder.BuildSkippedEvent],
 	bidSub *utils.Subscription[*p2p_bidder.BidSubmissionEvent],
 	revealSub *utils.Subscription[*payload_bidder.RevealResult],
+	signingSub *utils.Subscription[*payload_bidder.SigningRecord],
 	includedSub *utils.Subscription[*payload_bidder.PayloadIncludedEvent],
 	payloadStatusSub *utils.Subscription[*payload_bidder.PayloadStatusEvent],
 	epochSub *utils.Subscription[*chain.EpochStats],
@@ -192,10 +211,16 @@ func (t *Tracker) run(
 		revealCh = revealSub.Channel()
 	}
 
+	var signingCh <-chan *payload_bidder.SigningRecord
+	if signingSub != nil {
+		defer signingSub.Unsubscribe()
+		signingCh = signingSub.Channel()
+	}
+
 	// Slot clock for baseline materialization: makes "planned/active but
 	// nothing happened" observable.
 	lastTickedSlot := t.chainSvc.GetCurrentSlot()
-	slotTimer := time.NewTimer(t.durationToNextSlot())
+	slotTimer := chain.NewSlotTimer(t.chainSvc)
 
 	defer slotTimer.Stop()
 
@@ -246,6 +271,13 @@ func (t *Tracker) run(
 
 			t.handleRevealResult(result)
 
+		case record, ok := <-signingCh:
+			if !ok {
+				return
+			}
+
+			t.handleSigningRecord(record)
+
 		case event, ok := <-includedSub.Channel():
 			if !ok {
 				return
@@ -267,7 +299,7 @@ func (t *Tracker) run(
 
 			t.pruneForEpoch(epochStats.Epoch)
 
-		case <-slotTimer.C:
+		case <-slotTimer.C():
 			currentSlot := t.chainSvc.GetCurrentSlot()
 
 			// Bounded catch-up: a stall or clock jump must not generate
@@ -286,24 +318,11 @@ func (t *Tracker) run(
 				lastTickedSlot = currentSlot
 			}
 
-			slotTimer.Reset(t.durationToNextSlot())
+			chain.ResetSlotTimer(t.chainSvc, slotTimer)
 		}
 	}
 }
 
-// durationToNextSlot computes the wait until the next slot boundary,
-// re-derived every tick so clock jumps self-correct.
-func (t *Tracker) durationToNextSlot() time.Duration {
-	nextSlotTime := t.chainSvc.SlotToTime(t.chainSvc.GetCurrentSlot() + 1)
-
-	wait := time.Until(nextSlotTime)
-	if wait < 10*time.Millisecond {
-		wait = 10 * time.Millisecond
-	}
-
-	return wait
-}
-
 // materializeBaseline creates a baseline record at slot start for slots with
 // an explicit plan or any effectively active service, so slots where nothing
 // arrives afterwards (no attributes, no requests) remain explainable.
@@ -380,6 +399,16 @@ func (t *Tracker) handlePayloadReady(payload *payload_builder.Payload) {
 		FeeRecipient:    payload.FeeRecipient.Hex(),
 		At:              payload.ReadyAt,
 		Attributes:      attributesSnapshot(payload.Attributes),
+
+		WithdrawalsSource:     payload.WithdrawalsSource,
+		WithdrawalsDivergence: payload.WithdrawalsDivergence,
+		Faults:                payload.Faults,
+		Engine:                payload.Engine,
+		EngineRace:            payload.EngineRace,
+	}
+
+	if payload.Supersedes != (phase0.Hash32{}) {
+		outcome.Supersedes = fmt.Sprintf("%#x", payload.Supersedes)
 	}
 
 	if ep := payload.ExecutionPayload; ep != nil {
@@ -447,6 +476,10 @@ func fillBidDetail(attempt *BidAttempt, signedBid *eth2all.SignedExecutionPayloa
 	}
 
 	bid := signedBid.Message
+	if root, err := dynssz.GetGlobalDynSsz().HashTreeRoot(bid); err == nil {
+		attempt.BidRoot = fmt.Sprintf("%#x", root)
+	}
+
 	attempt.BlockHash = fmt.Sprintf("%#x", bid.BlockHash)
 	attempt.ParentBlockHash = fmt.Sprintf("%#x", bid.ParentBlockHash)
 	attempt.ParentBlockRoot = fmt.Sprintf("%#x", bid.ParentBlockRoot)
@@ -498,12 +531,20 @@ func (t *Tracker) handleBidSubmission(event *p2p_bidder.BidSubmissionEvent) {
 		Transport:          string(payload_builder.BidTransportP2P),
 		TotalValueGwei:     event.Value,
 		CompetitorHighGwei: event.CompetitorHighGwei,
+		Canary:             event.Canary,
+		Manual:             event.Manual,
+		Fault:              event.Fault,
+		Snipe:              event.Snipe,
 		Error:              event.Error,
 		At:                 time.Now(),
 	}
 
 	fillBidDetail(&attempt, event.SignedBid)
 
+	if event.Replaces != ([32]byte{}) {
+		attempt.ReplacesBlockHash = fmt.Sprintf("%#x", event.Replaces)
+	}
+
 	switch event.Status {
 	case p2p_bidder.BidStatusSubmitted:
 		attempt.Status = BidStatusSubmitted
@@ -538,15 +579,31 @@ func (t *Tracker) handleBidSubmission(event *p2p_bidder.BidSubmissionEvent) {
 		}
 	}
 
-	t.appendBid(event.Slot, attempt)
+	if attempt.ReplacesBlockHash == "" || attempt.Status != BidStatusSubmitted {
+		t.appendBid(event.Slot, attempt)
+		return
+	}
+
+	t.upsert(event.Slot, func(result *SlotResult) {
+		for i := range result.Bids {
+			if result.Bids[i].BlockHash == attempt.ReplacesBlockHash &&
+				result.Bids[i].Status == BidStatusSubmitted {
+				result.Bids[i].Replaced = true
+			}
+		}
+
+		appendCapped(&result.Bids, attempt, "bids", result)
+	})
 }
 
 func (t *Tracker) handleRevealResult(result *payload_bidder.RevealResult) {
 	attempt := RevealAttempt{
 		Transport:  string(result.Transport),
 		SkipReason: result.SkipReason,
+		Chaos:      result.Chaos,
 		Error:      result.Error,
 		Attempt:    result.Attempt,
+		Manual:     result.Manual,
 		At:         time.Now(),
 	}
 
@@ -560,7 +617,8 @@ func (t *Tracker) handleRevealResult(result *payload_bidder.RevealResult) {
 	}
 
 	switch {
-	case result.Skipped && result.SkipReason == payload_bidder.RevealSkipReasonPlanDisabled:
+	case result.Skipped && (result.SkipReason == payload_bidder.RevealSkipReasonPlanDisabled ||
+		result.SkipReason == payload_bidder.RevealSkipReasonChaos):
 		attempt.Status = RevealStatusSuppressed
 	case result.Skipped:
 		attempt.Status = RevealStatusSkipped
@@ -583,6 +641,23 @@ func (t *Tracker) handleRevealResult(result *payload_bidder.RevealResult) {
 	})
 }
 
+func (t *Tracker) handleSigningRecord(record *payload_bidder.SigningRecord) {
+	entry := SigningRecord{
+		Artifact:              record.Artifact,
+		MessageRoot:           fmt.Sprintf("%#x", record.MessageRoot),
+		DomainType:            fmt.Sprintf("%#x", record.Domain.Type),
+		ForkVersion:           fmt.Sprintf("%#x", record.Domain.ForkVersion),
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", record.Domain.GenesisValidatorsRoot),
+		Domain:                fmt.Sprintf("%#x", record.Domain.Domain),
+		Error:                 record.Error,
+		At:                    record.At,
+	}
+
+	t.upsert(record.Slot, func(result *SlotResult) {
+		appendCapped(&result.Signatures, entry, "signatures", result)
+	})
+}
+
 func (t *Tracker) handleIncluded(event *payload_bidder.PayloadIncludedEvent) {
 	if event.WonBlock == nil {
 		return
@@ -609,6 +684,7 @@ func (t *Tracker) handleIncluded(event *payload_bidder.PayloadIncludedEvent) {
 			ValueETH:        won.ValueETH,
 			Timestamp:       time.UnixMilli(won.Timestamp),
 			PayloadStatus:   payloadStatus,
+			Publishes:       slices.Clone(won.Publishes),
 		}
 	})
 }
@@ -643,6 +719,13 @@ func (t *Tracker) RecordBuilderAPIBid(slot phase0.Slot, forkName string, signedB
 		At:                   time.Now(),
 	}
 
+	// Served bids were priced from the slot's frozen settings (Freeze is
+	// idempotent; the handler froze the slot before pricing).
+	if frozen := t.planSvc.Freeze(slot); frozen.BuilderAPI != nil {
+		attempt.Canary = frozen.BuilderAPI.Canary
+		attempt.ValueBoostPct = frozen.BuilderAPI.ValueBoostPct
+	}
+
 	// The epbs dialect serves Gloas+ bids with the full message; the legacy
 	// dialect's versioned header bids stay aggregate-only.
 	if signed, ok := signedBid.(*eth2all.SignedExecutionPayloadBid); ok {
@@ -873,6 +956,7 @@ func (t *Tracker) GetWonBlocks(offset, limit int) ([]*payload_bidder.WonBlock, i
 			ValueWei:        result.Inclusion.ValueWei,
 			ValueETH:        result.Inclusion.ValueETH,
 			Timestamp:       result.Inclusion.Timestamp.UnixMilli(),
+			Publishes:       result.Inclusion.Publishes,
 		})
 	}
 
@@ -892,33 +976,142 @@ func (t *Tracker) SubscribeUpdates(capacity int) *utils.Subscription[*SlotResult
 }
 
 // pruneForEpoch drops result summaries and artifacts outside their (separate)
-// retention windows.
+// retention windows and size caps. With an archive directory configured,
+// each store's records are archived first; a store whose archive run fails
+// is not pruned this epoch.
 func (t *Tracker) pruneForEpoch(epoch phase0.Epoch) {
 	slotsPerEpoch := t.chainSvc.GetChainSpec().SlotsPerEpoch
 
+	var resultCutoff, artifactCutoff phase0.Slot
+
 	if retention := t.cfg.SlotResultRetentionEpochs; retention > 0 && uint64(epoch) > retention {
-		cutoff := phase0.Slot((uint64(epoch) - retention) * slotsPerEpoch)
-
-		pruned := t.store.Prune(func(slot phase0.Slot) bool { return slot < cutoff })
-		if pruned > 0 {
-			t.log.WithFields(logrus.Fields{
-				"epoch":  epoch,
-				"cutoff": cutoff,
-				"pruned": pruned,
-			}).Debug("Pruned slot results")
+		resultCutoff = phase0.Slot((uint64(epoch) - retention) * slotsPerEpoch)
+	}
+
+	if maxSlots := t.cfg.SlotResultMaxSlots; maxSlots > 0 {
+		resultCutoff = max(resultCutoff, t.resultCapCutoff(maxSlots))
+	}
+
+	if retention := t.cfg.SlotArtifactRetentionEpochs; retention > 0 && uint64(epoch) > retention {
+		artifactCutoff = phase0.Slot((uint64(epoch) - retention) * slotsPerEpoch)
+	}
+
+	if maxMB := t.cfg.SlotArtifactMaxMB; maxMB > 0 {
+		capCutoff, err := t.artifacts.SizeCapCutoff(maxMB * 1024 * 1024)
+		if err != nil {
+			t.log.WithError(err).Warn("Failed to measure slot artifact size")
 		}
 
-		t.mu.Lock()
-		for slot := range t.lastFired {
-			if slot < cutoff {
-				delete(t.lastFired, slot)
+		artifactCutoff = max(artifactCutoff, capCutoff)
+	}
+
+	if resultCutoff > 0 {
+		t.pruneResults(epoch, resultCutoff)
+	}
+
+	if artifactCutoff > 0 {
+		if t.archiver != nil {
+			archived, err := t.artifacts.ArchiveBefore(artifactCutoff, t.archiver, t.chainSvc.SlotToTime)
+			if err != nil {
+				t.log.WithError(err).WithField("cutoff", artifactCutoff).
+					Warn("Failed to archive slot artifacts, skipping prune")
+
+				return
+			}
+
+			if archived > 0 {
+				t.log.WithFields(logrus.Fields{
+					"cutoff":   artifactCutoff,
+					"archived": archived,
+				}).Debug("Archived slot artifacts")
 			}
 		}
-		t.mu.Unlock()
+
+		t.artifacts.PruneBefore(artifactCutoff)
 	}
+}
 
-	if retention := t.cfg.SlotArtifactRetentionEpochs; retention > 0 && uint64(epoch) > retention {
-		cutoff := phase0.Slot((uint64(epoch) - retention) * slotsPerEpoch)
-		t.artifacts.PruneBefore(cutoff)
+// resultCapCutoff returns the slot below which result summaries must be
+// pruned to keep the newest maxSlots, or 0 when the history already fits.
+func (t *Tracker) resultCapCutoff(maxSlots uint64) phase0.Slot {
+	if uint64(t.store.Len()) <= maxSlots {
+		return 0
+	}
+
+	slots := slices.Collect(maps.Keys(t.store.Entries()))
+	if uint64(len(slots)) <= maxSlots {
+		return 0
+	}
+
+	slices.Sort(slots)
+
+	return slots[uint64(len(slots))-maxSlots]
+}
+
+// pruneResults archives (when configured) and drops the result summaries for
+// slots below the cutoff.
+func (t *Tracker) pruneResults(epoch phase0.Epoch, cutoff phase0.Slot) {
+	if t.archiver != nil {
+		if err := t.archiveResults(cutoff); err != nil {
+			t.log.WithError(err).WithField("cutoff", cutoff).
+				Warn("Failed to archive slot results, skipping prune")
+
+			return
+		}
+	}
+
+	pruned := t.store.Prune(func(slot phase0.Slot) bool { return slot < cutoff })
+	if pruned > 0 {
+		t.log.WithFields(logrus.Fields{
+			"epoch":  epoch,
+			"cutoff": cutoff,
+			"pruned": pruned,
+		}).Debug("Pruned slot results")
 	}
+
+	t.mu.Lock()
+	for slot := range t.lastFired {
+		if slot < cutoff {
+			delete(t.lastFired, slot)
+		}
+	}
+	t.mu.Unlock()
+}
+
+// archiveResults writes the result summaries for slots below the cutoff to
+// the archiver, slot-ascending.
+func (t *Tracker) archiveResults(cutoff phase0.Slot) error {
+	results := make([]*SlotResult, 0, 64)
+
+	for slot, result := range t.store.Entries() {
+		if slot < cutoff {
+			results = append(results, result)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Slot < results[j].Slot })
+
+	writer := t.archiver.newWriter(ArchiveStoreResults)
+
+	for _, result := range results {
+		if err := writer.Write(t.chainSvc.SlotToTime(result.Slot), result); err != nil {
+			_ = writer.Close()
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	t.log.WithFields(logrus.Fields{
+		"cutoff":   cutoff,
+		"archived": len(results),
+	}).Debug("Archived slot results")
+
+	return nil
 }
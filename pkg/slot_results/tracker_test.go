@@ -1,8 +1,12 @@
 package slot_results
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"io"
 	"math/big"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -15,9 +19,13 @@ import (
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/signer"
 	"github.com/ethpandaops/buildoor/pkg/utils"
 )
 
@@ -29,6 +37,7 @@ type stubChainService struct {
 	genesisTime time.Time
 	currentSlot phase0.Slot
 	fork        version.DataVersion
+	clk         *clock.Fake // when set, the current slot follows it
 }
 
 func newStubChain() *stubChainService {
@@ -44,7 +53,15 @@ func newStubChain() *stubChainService {
 }
 
 func (s *stubChainService) GetChainSpec() *chain.ChainSpec { return s.spec }
-func (s *stubChainService) GetCurrentSlot() phase0.Slot    { return s.currentSlot }
+func (s *stubChainService) Clock() clock.Clock             { return s.clk }
+func (s *stubChainService) GetCurrentSlot() phase0.Slot {
+	if s.clk != nil {
+		return phase0.Slot(s.clk.Now().Sub(s.genesisTime) / s.spec.SecondsPerSlot)
+	}
+
+	return s.currentSlot
+}
+
 func (s *stubChainService) GetEpochOfSlot(slot phase0.Slot) phase0.Epoch {
 	return phase0.Epoch(uint64(slot) / s.spec.SlotsPerEpoch)
 }
@@ -180,6 +197,25 @@ func TestGetReturnsClones(t *testing.T) {
 	require.Nil(t, second.Build)
 }
 
+func TestSigningRecordStored(t *testing.T) {
+	env := newTrackerTestEnv(t, false)
+
+	env.tracker.handleSigningRecord(&payload_bidder.SigningRecord{
+		Artifact:    payload_bidder.SigningArtifactEnvelope,
+		Slot:        2000,
+		MessageRoot: phase0.Root{0x01},
+		Domain: signer.NewSigningDomain(payload_bidder.DomainBeaconBuilder,
+			phase0.Version{0x07}, phase0.Root{0x42}),
+	})
+
+	result := env.tracker.Get(2000)
+	require.Len(t, result.Signatures, 1)
+	require.Equal(t, "envelope", result.Signatures[0].Artifact)
+	require.Equal(t, "0x07000000", result.Signatures[0].ForkVersion)
+	require.Equal(t, "0x0b000000", result.Signatures[0].DomainType)
+	require.Empty(t, result.Signatures[0].Error)
+}
+
 func TestRecordBuilderAPIBidCapturesArtifact(t *testing.T) {
 	env := newTrackerTestEnv(t, true)
 
@@ -364,6 +400,128 @@ func TestPruneForEpochSeparateWindows(t *testing.T) {
 	require.NotNil(t, kept)
 }
 
+func TestPruneForEpochSizeCaps(t *testing.T) {
+	env := newTrackerTestEnv(t, true)
+	env.cfg.SlotResultMaxSlots = 2
+	env.cfg.SlotArtifactMaxMB = 1
+
+	for _, slot := range []phase0.Slot{100, 200, 300} {
+		env.tracker.RecordBlockSubmission(slot, "epbs", string(SubmissionStatusAccepted), "")
+	}
+
+	// 600 KiB per slot: only the newest slot fits the 1 MB cap.
+	var batch []db.SlotArtifact
+	for _, slot := range []uint64{100, 200, 300} {
+		batch = append(batch, db.SlotArtifact{Slot: slot, Kind: ArtifactKindPayload, Data: make([]byte, 600*1024)})
+	}
+
+	require.NoError(t, env.stateDB.InsertSlotArtifacts(batch))
+
+	// Epoch 10 is inside both retention windows; only the caps apply.
+	env.tracker.pruneForEpoch(10)
+
+	require.Nil(t, env.tracker.Get(100), "result beyond the slot cap must be pruned")
+	require.NotNil(t, env.tracker.Get(200))
+	require.NotNil(t, env.tracker.Get(300))
+
+	sizes, err := env.stateDB.GetSlotArtifactSizes()
+	require.NoError(t, err)
+	require.Equal(t, []db.SlotArtifactSize{{Slot: 300, Bytes: 600 * 1024}}, sizes)
+}
+
+func TestPruneForEpochArchives(t *testing.T) {
+	env := newTrackerTestEnv(t, true)
+	env.cfg.SlotResultRetentionEpochs = 4
+	env.cfg.SlotArtifactRetentionEpochs = 4
+
+	// Slots 100-102 fall on one UTC day.
+	env.chainSvc.genesisTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	dir := t.TempDir()
+	env.tracker.archiver = NewArchiver(dir)
+
+	for _, slot := range []phase0.Slot{100, 101, 300} {
+		env.tracker.RecordBlockSubmission(slot, "epbs", string(SubmissionStatusAccepted), "")
+	}
+
+	require.NoError(t, env.stateDB.InsertSlotArtifacts([]db.SlotArtifact{
+		{Slot: 100, Kind: ArtifactKindPayload, Fork: 7, Data: []byte{1, 2}},
+		{Slot: 300, Kind: ArtifactKindPayload, Data: []byte{3}},
+	}))
+
+	// Epoch 10: cutoff (10-4)*32 = 192 for both stores; run twice to check
+	// that a second run appends instead of truncating.
+	env.tracker.pruneForEpoch(10)
+	env.tracker.RecordBlockSubmission(102, "epbs", string(SubmissionStatusAccepted), "")
+	env.tracker.pruneForEpoch(10)
+
+	require.Nil(t, env.tracker.Get(100))
+	require.NotNil(t, env.tracker.Get(300))
+
+	day := env.chainSvc.SlotToTime(100).UTC().Format(time.DateOnly)
+
+	readArchive := func(store string) []map[string]any {
+		file, err := os.Open(filepath.Join(dir, store+"-"+day+".ndjson.gz"))
+		require.NoError(t, err)
+
+		defer file.Close()
+
+		gz, err := gzip.NewReader(file)
+		require.NoError(t, err)
+
+		var records []map[string]any
+
+		dec := json.NewDecoder(gz)
+		for dec.More() {
+			record := map[string]any{}
+			require.NoError(t, dec.Decode(&record))
+			records = append(records, record)
+		}
+
+		return records
+	}
+
+	results := readArchive(ArchiveStoreResults)
+	require.Len(t, results, 3)
+	require.Equal(t, "100", results[0]["slot"])
+	require.Equal(t, "101", results[1]["slot"])
+	require.Equal(t, "102", results[2]["slot"], "second run appended")
+
+	artifacts := readArchive(ArchiveStoreArtifacts)
+	require.Len(t, artifacts, 1)
+	require.Equal(t, float64(100), artifacts[0]["slot"])
+	require.Equal(t, float64(7), artifacts[0]["fork"])
+	require.Equal(t, "AQI=", artifacts[0]["data"])
+
+	gone, err := env.stateDB.GetSlotArtifact(100, ArtifactKindPayload, 0)
+	require.NoError(t, err)
+	require.Nil(t, gone)
+}
+
+func TestPruneForEpochArchiveFailureKeepsRecords(t *testing.T) {
+	env := newTrackerTestEnv(t, true)
+	env.cfg.SlotResultRetentionEpochs = 4
+	env.cfg.SlotArtifactRetentionEpochs = 4
+
+	// A file where the archive directory should be: every archive run fails.
+	blocker := filepath.Join(t.TempDir(), "archive")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o600))
+	env.tracker.archiver = NewArchiver(blocker)
+
+	env.tracker.RecordBlockSubmission(100, "epbs", string(SubmissionStatusAccepted), "")
+	require.NoError(t, env.stateDB.InsertSlotArtifacts([]db.SlotArtifact{
+		{Slot: 100, Kind: ArtifactKindPayload, Data: []byte{1}},
+	}))
+
+	env.tracker.pruneForEpoch(10)
+
+	require.NotNil(t, env.tracker.Get(100), "unarchived results must not be pruned")
+
+	kept, err := env.stateDB.GetSlotArtifact(100, ArtifactKindPayload, 0)
+	require.NoError(t, err)
+	require.NotNil(t, kept, "unarchived artifacts must not be pruned")
+}
+
 func TestPersistenceRoundTrip(t *testing.T) {
 	env := newTrackerTestEnv(t, true)
 
@@ -559,3 +717,39 @@ func TestBuildValueFromBigInt(t *testing.T) {
 	value := big.NewInt(1_500_000_000)
 	require.Equal(t, "1500000000", value.String())
 }
+
+// emptySub returns a subscription that never delivers.
+func emptySub[T any]() *utils.Subscription[T] {
+	return (&utils.Dispatcher[T]{}).Subscribe(1, false)
+}
+
+func TestSlotClockFollowsChainClock(t *testing.T) {
+	env := newTrackerTestEnv(t, false)
+
+	// The chain clock is a fake: a wall-clock slot timer would wait 12s.
+	clk := clock.NewFake(env.chainSvc.SlotToTime(1000).Add(time.Second))
+	env.chainSvc.clk = clk
+
+	ctx, cancel := context.WithCancel(t.Context())
+	env.tracker.ctx = ctx
+	env.tracker.wg.Add(1)
+
+	go env.tracker.run(emptySub[*payload_builder.Payload](), emptySub[*payload_builder.PayloadBuildStartedEvent](),
+		emptySub[*payload_builder.PayloadBuildFailedEvent](), emptySub[*payload_builder.BuildSkippedEvent](),
+		(*utils.Subscription[*p2p_bidder.BidSubmissionEvent])(nil), (*utils.Subscription[*payload_bidder.RevealResult])(nil),
+		(*utils.Subscription[*payload_bidder.SigningRecord])(nil), emptySub[*payload_bidder.PayloadIncludedEvent](),
+		emptySub[*payload_bidder.PayloadStatusEvent](), emptySub[*chain.EpochStats]())
+
+	t.Cleanup(func() {
+		cancel()
+		env.tracker.wg.Wait()
+	})
+
+	require.Eventually(t, func() bool { return clk.Pending() > 0 }, time.Second, time.Millisecond)
+	require.Nil(t, env.tracker.Get(1001))
+
+	clk.Advance(11 * time.Second)
+
+	require.Eventually(t, func() bool { return env.tracker.Get(1001) != nil }, time.Second, 5*time.Millisecond,
+		"slot 1001 baseline not materialized on the chain clock")
+}
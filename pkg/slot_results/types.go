@@ -8,11 +8,14 @@ package slot_results
 
 import (
 	"maps"
+	"slices"
 	"time"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
 )
 
 // BuildStatus is the lifecycle state of a slot's payload build.
@@ -120,6 +123,27 @@ type BuildOutcome struct {
 	// Attributes is the payload_attributes snapshot the build ran on.
 	Attributes *AttributesSnapshot `json:"attributes,omitempty"`
 
+	// Supersedes is the block hash of the stale payload this rebuild
+	// replaced (stale-bid replacement); empty for the slot's first build.
+	Supersedes string `json:"supersedes,omitempty"`
+
+	// WithdrawalsSource is where the payload's withdrawals were taken from
+	// (attributes | state); WithdrawalsDivergence describes how the
+	// payload_attributes withdrawals disagreed with the beacon node's
+	// expected withdrawals (cross-check).
+	WithdrawalsSource     string `json:"withdrawals_source,omitempty"`
+	WithdrawalsDivergence string `json:"withdrawals_divergence,omitempty"`
+
+	// Faults audits the fields fault injection corrupted in the payload
+	// (original and corrupted values); empty for an honest payload.
+	Faults []payload_builder.PayloadFault `json:"faults,omitempty"`
+
+	// Engine is the engine API endpoint whose payload won the build race
+	// (--el-engine-race-apis); EngineRace lists every engine's outcome.
+	// Both are empty with a single engine.
+	Engine     string                         `json:"engine,omitempty"`
+	EngineRace []payload_builder.EngineResult `json:"engine_race,omitempty"`
+
 	Error string    `json:"error,omitempty"`
 	At    time.Time `json:"at"`
 }
@@ -152,10 +176,31 @@ type BidAttempt struct {
 	// time (p2p only, our own builder index excluded).
 	CompetitorHighGwei *uint64 `json:"competitor_high_gwei,omitempty"`
 
+	// Canary marks a bid priced at the canary value (canary mode).
+	Canary bool `json:"canary,omitempty"`
+	// ValueBoostPct marks a Builder API bid whose reported value was boosted
+	// to this percentage of its real value (test-only value boosting), so
+	// TotalValueGwei is not what the payload pays.
+	ValueBoostPct uint64 `json:"value_boost_pct,omitempty"`
+	// Manual marks an operator-forced p2p bid (manual bid API).
+	Manual bool `json:"manual,omitempty"`
+	// Fault is the bid fault of a deliberately invalid manual bid
+	// (zero_value, exceeds_balance, malformed_value); Status and Error
+	// record whether the beacon node accepted it and why it rejected it.
+	Fault string `json:"fault,omitempty"`
+	// Snipe marks a late-slot snipe bid, or a snipe skipped on a competitor
+	// bid above the threshold.
+	Snipe bool `json:"snipe,omitempty"`
+	// ReplacesBlockHash is the block hash of our earlier bid this one
+	// replaces (rebuilt payload); Replaced marks a bid a later one replaced.
+	ReplacesBlockHash string `json:"replaces_block_hash,omitempty"`
+	Replaced          bool   `json:"replaced,omitempty"`
+
 	// Full bid message properties (Gloas+ bids; blob commitments aggregated
 	// to a count). Empty for legacy Builder API bids and pre-construction
 	// failures.
 	BlockHash          string `json:"block_hash,omitempty"`
+	BidRoot            string `json:"bid_root,omitempty"` // hash-tree-root of the bid message
 	ParentBlockHash    string `json:"parent_block_hash,omitempty"`
 	ParentBlockRoot    string `json:"parent_block_root,omitempty"`
 	PrevRandao         string `json:"prev_randao,omitempty"`
@@ -185,9 +230,11 @@ type BlockSubmission struct {
 type RevealAttempt struct {
 	Status     RevealStatus `json:"status"`
 	Transport  string       `json:"transport"`
-	SkipReason string       `json:"skip_reason,omitempty"` // plan_disabled | disabled | late | vote_gate_timeout
+	SkipReason string       `json:"skip_reason,omitempty"` // plan_disabled | disabled | late | vote_gate_timeout | not_included | chaos_withheld
+	Chaos      string       `json:"chaos,omitempty"`       // withhold | delay (reveal chaos)
 	Error      string       `json:"error,omitempty"`
 	Attempt    int          `json:"attempt"`
+	Manual     bool         `json:"manual,omitempty"` // operator-triggered publish (numbered separately)
 	At         time.Time    `json:"at"`
 
 	// StartedAt is when the attempt began (envelope construction + submit
@@ -195,6 +242,19 @@ type RevealAttempt struct {
 	StartedAt *time.Time `json:"started_at,omitempty"`
 }
 
+// SigningRecord is the signing context of one builder signature, validated
+// against the connected chain at sign time.
+type SigningRecord struct {
+	Artifact              string    `json:"artifact"` // bid | header | envelope
+	MessageRoot           string    `json:"message_root"`
+	DomainType            string    `json:"domain_type"`
+	ForkVersion           string    `json:"fork_version"`
+	GenesisValidatorsRoot string    `json:"genesis_validators_root"`
+	Domain                string    `json:"domain"`
+	Error                 string    `json:"error,omitempty"` // domain mismatch: the message was not signed
+	At                    time.Time `json:"at"`
+}
+
 // InclusionResult records that the slot's payload was seen included at the
 // head. Field semantics match the WonBlock wire shape (minus the slot).
 type InclusionResult struct {
@@ -213,6 +273,9 @@ type InclusionResult struct {
 	PayloadStatus PayloadStatus `json:"payload_status,omitempty"`
 	// PayloadCheckSlot is the follow-up block's slot the verdict came from.
 	PayloadCheckSlot phase0.Slot `json:"payload_check_slot,omitempty"`
+
+	// Publishes are the per-beacon-node block publications (Builder API).
+	Publishes []payload_bidder.WonBlockPublish `json:"publishes,omitempty"`
 }
 
 // SlotResult is the complete recorded history of one slot. Values held by the
@@ -232,6 +295,11 @@ type SlotResult struct {
 	RevealAttempts   []RevealAttempt   `json:"reveal_attempts,omitempty"`
 	Inclusion        *InclusionResult  `json:"inclusion,omitempty"`
 
+	// Signatures is the signing context of every bid, header and envelope
+	// signed for the slot (bid/envelope records match BidAttempt.BidRoot and
+	// the envelope root by MessageRoot).
+	Signatures []SigningRecord `json:"signatures,omitempty"`
+
 	// DroppedAttempts counts attempts beyond the per-kind retention cap,
 	// keyed by kind ("bids", "block_submissions", "reveal_attempts").
 	DroppedAttempts map[string]int `json:"dropped_attempts,omitempty"`
@@ -249,11 +317,14 @@ func (r *SlotResult) Clone() *SlotResult {
 
 	if r.Build != nil {
 		build := *r.Build
+		build.Faults = slices.Clone(r.Build.Faults)
+		build.EngineRace = slices.Clone(r.Build.EngineRace)
 		c.Build = &build
 	}
 
 	if r.Inclusion != nil {
 		inclusion := *r.Inclusion
+		inclusion.Publishes = slices.Clone(r.Inclusion.Publishes)
 		c.Inclusion = &inclusion
 	}
 
@@ -283,6 +354,11 @@ func (r *SlotResult) Clone() *SlotResult {
 		copy(c.RevealAttempts, r.RevealAttempts)
 	}
 
+	if r.Signatures != nil {
+		c.Signatures = make([]SigningRecord, len(r.Signatures))
+		copy(c.Signatures, r.Signatures)
+	}
+
 	if r.DroppedAttempts != nil {
 		c.DroppedAttempts = make(map[string]int, len(r.DroppedAttempts))
 		maps.Copy(c.DroppedAttempts, r.DroppedAttempts)
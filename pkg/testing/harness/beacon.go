@@ -0,0 +1,872 @@
+package harness
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	bitfield "github.com/OffchainLabs/go-bitfield"
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/altair"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/gloas"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/gorilla/mux"
+	"github.com/holiman/uint256"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/clock"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// domainProposerPreferences is DOMAIN_PROPOSER_PREFERENCES (Gloas).
+var domainProposerPreferences = phase0.DomainType{0x0d, 0x00, 0x00, 0x00}
+
+// Submission is one POST request received by the fake beacon node (bids,
+// envelopes, blocks), kept verbatim for assertions.
+type Submission struct {
+	Path             string
+	ConsensusVersion string
+	ContentType      string
+	Body             []byte
+	ReceivedAt       time.Time
+}
+
+// sseEvent is one server-sent event queued for a subscriber.
+type sseEvent struct {
+	topic string
+	data  []byte
+}
+
+// Beacon is a fake beacon node serving the subset of the beacon API buildoor
+// uses: node/config/genesis endpoints, SSZ states and blocks for the head,
+// per-topic SSE streams, and recording of submitted bids, envelopes and
+// blocks. The chain is synthetic: every slot has a block whose execution
+// block hash is derived from the slot, and validator i proposes every slot
+// congruent to i modulo the validator count.
+type Beacon struct {
+	sc          *Scenario
+	ds          *dynssz.DynSsz
+	server      *httptest.Server
+	clock       clock.Clock
+	genesisTime time.Time
+	gvr         phase0.Root
+	keys        []*signer.BLSSigner
+
+	done     chan struct{}
+	doneOnce sync.Once
+
+	mu          sync.Mutex
+	builders    []*gloas.Builder
+	head        *all.SignedBeaconBlock
+	headRoot    phase0.Root
+	headHash    phase0.Hash32
+	blocks      map[phase0.Root]*all.SignedBeaconBlock
+	submissions []*Submission
+	subscribers map[string][]chan sseEvent
+}
+
+// NewBeacon starts a fake beacon node for the scenario. Genesis is placed so
+// the current slot is sc.SlotsSinceGenesis (or slightly later with a
+// speedup, as the genesis time has second precision), and the head block
+// sits one slot behind it.
+func NewBeacon(sc *Scenario) (*Beacon, error) {
+	keys := make([]*signer.BLSSigner, sc.Validators)
+
+	for i := range keys {
+		key, err := signer.NewBLSSigner(fmt.Sprintf("%064x", i+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive validator key %d: %w", i, err)
+		}
+
+		keys[i] = key
+	}
+
+	sinceGenesis := time.Duration(sc.SlotsSinceGenesis) * sc.SlotDuration() //nolint:gosec // small fixture values
+	if sc.Speedup > 1 {
+		sinceGenesis = time.Duration(float64(sinceGenesis) / sc.Speedup)
+	}
+
+	genesis := time.Unix(time.Now().Add(-sinceGenesis).Unix(), 0)
+
+	var clk clock.Clock = clock.Real()
+	if sc.Speedup > 1 {
+		clk = clock.NewScaled(genesis, sc.Speedup)
+	}
+
+	b := &Beacon{
+		sc:          sc,
+		ds:          dynssz.NewDynSsz(sc.sszSpecs()),
+		clock:       clk,
+		genesisTime: genesis,
+		gvr:         phase0.Root(sha256.Sum256([]byte("buildoor-harness/" + sc.Name))),
+		keys:        keys,
+		done:        make(chan struct{}),
+		blocks:      make(map[phase0.Root]*all.SignedBeaconBlock),
+		subscribers: make(map[string][]chan sseEvent),
+	}
+
+	if _, err := b.produceBlock(b.CurrentSlot()-1, nil); err != nil {
+		return nil, err
+	}
+
+	b.server = httptest.NewServer(b.router())
+
+	return b, nil
+}
+
+// URL returns the base URL of the fake node.
+func (b *Beacon) URL() string {
+	return b.server.URL
+}
+
+// Close ends all event streams and shuts the server down.
+func (b *Beacon) Close() {
+	b.doneOnce.Do(func() { close(b.done) })
+	b.server.Close()
+}
+
+// GenesisTime returns the chain's genesis time.
+func (b *Beacon) GenesisTime() time.Time {
+	return b.genesisTime
+}
+
+// GenesisValidatorsRoot returns the (synthetic) genesis validators root.
+func (b *Beacon) GenesisValidatorsRoot() phase0.Root {
+	return b.gvr
+}
+
+// Clock returns the chain's time source (scaled by the scenario's speedup).
+func (b *Beacon) Clock() clock.Clock {
+	return b.clock
+}
+
+// CurrentSlot returns the clock's current slot.
+func (b *Beacon) CurrentSlot() phase0.Slot {
+	return phase0.Slot(b.clock.Since(b.genesisTime) / b.sc.SlotDuration()) //nolint:gosec // after genesis
+}
+
+// SlotStart returns the start time of the slot.
+func (b *Beacon) SlotStart(slot phase0.Slot) time.Time {
+	return b.genesisTime.Add(time.Duration(slot) * b.sc.SlotDuration()) //nolint:gosec // small fixture values
+}
+
+// ValidatorKey returns the signing key of validator index.
+func (b *Beacon) ValidatorKey(index phase0.ValidatorIndex) *signer.BLSSigner {
+	return b.keys[index]
+}
+
+// ProposerIndex returns the proposer of the slot.
+func (b *Beacon) ProposerIndex(slot phase0.Slot) phase0.ValidatorIndex {
+	return phase0.ValidatorIndex(uint64(slot) % uint64(len(b.keys)))
+}
+
+// AddBuilder registers a builder in the Gloas builder registry, deposited at
+// genesis (so it is active once an epoch is finalized) with the given
+// balance. Returns its builder index. Must be called before buildoor starts
+// (the state is read at startup and on epoch transitions).
+func (b *Beacon) AddBuilder(pubkey phase0.BLSPubKey, balance phase0.Gwei) gloas.BuilderIndex {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.builders = append(b.builders, &gloas.Builder{
+		PublicKey:         pubkey,
+		Balance:           balance,
+		DepositEpoch:      0,
+		WithdrawableEpoch: phase0.Epoch(chain.FarFutureEpoch),
+	})
+
+	return gloas.BuilderIndex(len(b.builders) - 1) //nolint:gosec // small fixture values
+}
+
+// Head returns the head block's slot, root and execution block hash.
+func (b *Beacon) Head() (phase0.Slot, phase0.Root, phase0.Hash32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.head.Message.Slot, b.headRoot, b.headHash
+}
+
+// ProduceBlock extends the chain with a block at slot and publishes the
+// matching head event. Returns the new head root.
+func (b *Beacon) ProduceBlock(slot phase0.Slot) (phase0.Root, error) {
+	root, err := b.produceBlock(slot, nil)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	b.publishHead(slot, root)
+
+	return root, nil
+}
+
+// IncludeBid extends the chain with a Gloas block at the bid's slot that
+// commits to bid (as if the proposer selected it) and publishes the head
+// event, which is what makes buildoor reveal the payload.
+func (b *Beacon) IncludeBid(bid *gloas.SignedExecutionPayloadBid) (phase0.Root, error) {
+	if b.sc.Fork < version.DataVersionGloas {
+		return phase0.Root{}, fmt.Errorf("bids are not part of %s blocks", b.sc.Fork)
+	}
+
+	root, err := b.produceBlock(bid.Message.Slot, bid)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	b.publishHead(bid.Message.Slot, root)
+
+	return root, nil
+}
+
+// publishHead emits the head event for a newly produced block.
+func (b *Beacon) publishHead(slot phase0.Slot, root phase0.Root) {
+	epochStart := uint64(slot)%b.sc.SlotsPerEpoch() == 0
+
+	b.Publish("head", map[string]any{
+		"slot":                         fmt.Sprintf("%d", slot),
+		"block":                        hexBytes(root[:]),
+		"state":                        hexBytes(root[:]),
+		"epoch_transition":             epochStart,
+		"execution_optimistic":         false,
+		"previous_duty_dependent_root": hexBytes(make([]byte, 32)),
+		"current_duty_dependent_root":  hexBytes(make([]byte, 32)),
+	})
+}
+
+// produceBlock builds the block for slot on top of the current head and
+// makes it the new head. Gloas blocks commit to bid when given, otherwise to
+// a synthetic bid; pre-Gloas blocks embed a synthetic payload.
+func (b *Beacon) produceBlock(slot phase0.Slot, bid *gloas.SignedExecutionPayloadBid) (phase0.Root, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var parentRoot phase0.Root
+	if b.head != nil {
+		parentRoot = b.headRoot
+	}
+
+	body := &all.BeaconBlockBody{
+		Version:  b.sc.Fork,
+		ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		SyncAggregate: &altair.SyncAggregate{
+			SyncCommitteeBits: bitfield.NewBitvector512()[:b.sc.specUint64("SYNC_COMMITTEE_SIZE")/8],
+		},
+		ExecutionRequests: &all.ExecutionRequests{Version: b.sc.Fork},
+	}
+
+	blockHash := executionBlockHash(slot)
+
+	switch {
+	case b.sc.Fork >= version.DataVersionGloas && bid != nil:
+		msg := bid.Message
+		blockHash = msg.BlockHash
+		body.SignedExecutionPayloadBid = &all.SignedExecutionPayloadBid{
+			Version: b.sc.Fork,
+			Message: &all.ExecutionPayloadBid{
+				Version:               b.sc.Fork,
+				ParentBlockHash:       msg.ParentBlockHash,
+				ParentBlockRoot:       msg.ParentBlockRoot,
+				BlockHash:             msg.BlockHash,
+				PrevRandao:            msg.PrevRandao,
+				FeeRecipient:          msg.FeeRecipient,
+				GasLimit:              msg.GasLimit,
+				BuilderIndex:          msg.BuilderIndex,
+				Slot:                  msg.Slot,
+				Value:                 msg.Value,
+				ExecutionPayment:      msg.ExecutionPayment,
+				BlobKZGCommitments:    msg.BlobKZGCommitments,
+				ExecutionRequestsRoot: msg.ExecutionRequestsRoot,
+			},
+			Signature: bid.Signature,
+		}
+		body.ParentExecutionRequests = &all.ExecutionRequests{Version: b.sc.Fork}
+	case b.sc.Fork >= version.DataVersionGloas:
+		body.SignedExecutionPayloadBid = &all.SignedExecutionPayloadBid{
+			Version: b.sc.Fork,
+			Message: &all.ExecutionPayloadBid{
+				Version:         b.sc.Fork,
+				Slot:            slot,
+				ParentBlockRoot: parentRoot,
+				ParentBlockHash: b.headHash,
+				BlockHash:       blockHash,
+			},
+		}
+		body.ParentExecutionRequests = &all.ExecutionRequests{Version: b.sc.Fork}
+	default:
+		body.ExecutionPayload = &all.ExecutionPayload{
+			Version:       b.sc.Fork,
+			ParentHash:    b.headHash,
+			BlockNumber:   uint64(slot),
+			BlockHash:     blockHash,
+			Timestamp:     uint64(b.SlotStart(slot).Unix()), //nolint:gosec // after 1970
+			BaseFeePerGas: uint256.NewInt(7),
+		}
+	}
+
+	block := &all.SignedBeaconBlock{
+		Version: b.sc.Fork,
+		Message: &all.BeaconBlock{
+			Version:       b.sc.Fork,
+			Slot:          slot,
+			ProposerIndex: b.ProposerIndex(slot),
+			ParentRoot:    parentRoot,
+			StateRoot:     phase0.Root(sha256.Sum256([]byte(fmt.Sprintf("state-%d", slot)))),
+			Body:          body,
+		},
+	}
+
+	root, err := b.ds.HashTreeRoot(block.Message)
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to compute block root: %w", err)
+	}
+
+	b.blocks[root] = block
+	b.head = block
+	b.headRoot = root
+	b.headHash = blockHash
+
+	return root, nil
+}
+
+// executionBlockHash is the synthetic execution block hash of slot's payload.
+func executionBlockHash(slot phase0.Slot) phase0.Hash32 {
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(slot))
+
+	return phase0.Hash32(sha256.Sum256(append([]byte("execution-block-"), buf[:]...)))
+}
+
+// PublishPayloadAttributes emits a payload_attributes event for building on
+// the current head at slot, as the beacon node does ahead of each proposal.
+func (b *Beacon) PublishPayloadAttributes(slot phase0.Slot, feeRecipient bellatrix.ExecutionAddress) {
+	headSlot, headRoot, headHash := b.Head()
+
+	randao := sha256.Sum256([]byte(fmt.Sprintf("randao-%d", slot)))
+
+	b.Publish("payload_attributes", map[string]any{
+		"version": strings.ToLower(b.sc.Fork.String()),
+		"data": map[string]any{
+			"proposal_slot":       fmt.Sprintf("%d", slot),
+			"proposer_index":      fmt.Sprintf("%d", b.ProposerIndex(slot)),
+			"parent_block_root":   hexBytes(headRoot[:]),
+			"parent_block_number": fmt.Sprintf("%d", headSlot),
+			"parent_block_hash":   hexBytes(headHash[:]),
+			"payload_attributes": map[string]any{
+				"timestamp":                fmt.Sprintf("%d", b.SlotStart(slot).Unix()),
+				"prev_randao":              hexBytes(randao[:]),
+				"suggested_fee_recipient":  hexBytes(feeRecipient[:]),
+				"withdrawals":              []any{},
+				"parent_beacon_block_root": hexBytes(headRoot[:]),
+				"target_gas_limit":         "60000000",
+			},
+		},
+	})
+}
+
+// PublishProposerPreferences emits proposer_preferences for slot, signed by
+// the slot's proposer (Gloas only).
+func (b *Beacon) PublishProposerPreferences(slot phase0.Slot, feeRecipient bellatrix.ExecutionAddress, gasLimit uint64) error {
+	proposer := b.ProposerIndex(slot)
+
+	prefs := &gloas.ProposerPreferences{
+		ProposalSlot:   slot,
+		ValidatorIndex: proposer,
+		FeeRecipient:   feeRecipient,
+		TargetGasLimit: gasLimit,
+	}
+
+	root, err := b.ds.HashTreeRoot(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to compute preferences root: %w", err)
+	}
+
+	domain := signer.ComputeDomain(domainProposerPreferences, b.sc.ForkVersion(), b.gvr)
+
+	sig, err := b.keys[proposer].SignWithDomain(root, domain)
+	if err != nil {
+		return fmt.Errorf("failed to sign preferences: %w", err)
+	}
+
+	b.Publish("proposer_preferences", map[string]any{
+		"version": strings.ToLower(b.sc.Fork.String()),
+		"data":    &gloas.SignedProposerPreferences{Message: prefs, Signature: sig},
+	})
+
+	return nil
+}
+
+// PublishBid emits an execution_payload_bid event for a bid seen on gossip
+// (e.g. a competing builder's), as the beacon node streams them.
+func (b *Beacon) PublishBid(bid *gloas.SignedExecutionPayloadBid) {
+	b.Publish("execution_payload_bid", map[string]any{
+		"version": strings.ToLower(b.sc.Fork.String()),
+		"data":    bid,
+	})
+}
+
+// SignedRegistration returns a Builder API validator registration for
+// validator index, signed over the genesis fork version (as validator
+// clients do).
+func (b *Beacon) SignedRegistration(index phase0.ValidatorIndex, feeRecipient bellatrix.ExecutionAddress, gasLimit uint64) (*apiv1.SignedValidatorRegistration, error) {
+	key := b.keys[index]
+
+	msg := &apiv1.ValidatorRegistration{
+		FeeRecipient: feeRecipient,
+		GasLimit:     gasLimit,
+		Timestamp:    time.Unix(b.genesisTime.Unix(), 0),
+		Pubkey:       key.PublicKey(),
+	}
+
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute registration root: %w", err)
+	}
+
+	domain := signer.ComputeDomain(signer.DomainApplicationBuilder, b.sc.GenesisForkVersion(), phase0.Root{})
+
+	sig, err := key.SignWithDomain(root, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign registration: %w", err)
+	}
+
+	return &apiv1.SignedValidatorRegistration{Message: msg, Signature: sig}, nil
+}
+
+// Publish sends an event to every open stream subscribed to topic.
+func (b *Beacon) Publish(topic string, data any) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("unencodable %s event: %v", topic, err))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subscribers[topic] {
+		select {
+		case ch <- sseEvent{topic: topic, data: raw}:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of open streams subscribed to topic.
+func (b *Beacon) Subscribers(topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers[topic])
+}
+
+// Submissions returns the POST requests received on path so far.
+func (b *Beacon) Submissions(path string) []*Submission {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var out []*Submission
+
+	for _, s := range b.submissions {
+		if s.Path == path {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// DecodeBid decodes a submission to /eth/v1/beacon/execution_payload_bids.
+func (b *Beacon) DecodeBid(s *Submission) (*gloas.SignedExecutionPayloadBid, error) {
+	bid := &gloas.SignedExecutionPayloadBid{}
+
+	if strings.HasPrefix(s.ContentType, "application/json") {
+		return bid, json.Unmarshal(s.Body, bid)
+	}
+
+	return bid, b.ds.UnmarshalSSZ(bid, s.Body)
+}
+
+func (b *Beacon) router() *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/eth/v1/node/syncing", b.handleSyncing).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/node/version", b.handleVersion).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/node/identity", b.handleIdentity).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/config/spec", b.handleSpec).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/config/fork_schedule", b.handleForkSchedule).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/config/deposit_contract", b.handleDepositContract).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/beacon/genesis", b.handleGenesis).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/beacon/states/{state_id}/fork", b.handleFork).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/beacon/states/{state_id}/finality_checkpoints", b.handleFinality).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/beacon/headers/{block_id}", b.handleHeader).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v2/debug/beacon/states/{state_id}", b.handleState).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v2/beacon/blocks/{block_id}", b.handleBlock).Methods(http.MethodGet)
+	r.HandleFunc("/eth/v1/events", b.handleEvents).Methods(http.MethodGet)
+	r.PathPrefix("/eth/").HandlerFunc(b.handleSubmission).Methods(http.MethodPost)
+
+	return r
+}
+
+func (b *Beacon) handleSyncing(w http.ResponseWriter, _ *http.Request) {
+	slot, _, _ := b.Head()
+
+	writeData(w, map[string]any{
+		"head_slot":     fmt.Sprintf("%d", slot),
+		"sync_distance": "0",
+		"is_syncing":    false,
+		"is_optimistic": false,
+		"el_offline":    false,
+	})
+}
+
+func (b *Beacon) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]any{"version": "buildoor-harness/v0.0.0"})
+}
+
+func (b *Beacon) handleIdentity(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]any{
+		"peer_id":             "16Uiu2HAmHarness",
+		"enr":                 "",
+		"p2p_addresses":       []string{},
+		"discovery_addresses": []string{},
+		"metadata":            map[string]any{"seq_number": "0", "attnets": "0x00", "syncnets": "0x00"},
+	})
+}
+
+func (b *Beacon) handleSpec(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, b.sc.Spec)
+}
+
+func (b *Beacon) handleForkSchedule(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, []any{b.forkJSON()})
+}
+
+func (b *Beacon) handleDepositContract(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]any{
+		"chain_id": b.sc.specString("DEPOSIT_CHAIN_ID"),
+		"address":  b.sc.specString("DEPOSIT_CONTRACT_ADDRESS"),
+	})
+}
+
+func (b *Beacon) handleGenesis(w http.ResponseWriter, _ *http.Request) {
+	forkVersion := b.sc.GenesisForkVersion()
+
+	writeData(w, map[string]any{
+		"genesis_time":            fmt.Sprintf("%d", b.genesisTime.Unix()),
+		"genesis_validators_root": hexBytes(b.gvr[:]),
+		"genesis_fork_version":    hexBytes(forkVersion[:]),
+	})
+}
+
+func (b *Beacon) handleFork(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, b.forkJSON())
+}
+
+func (b *Beacon) forkJSON() map[string]any {
+	forkVersion := b.sc.ForkVersion()
+
+	return map[string]any{
+		"previous_version": hexBytes(forkVersion[:]),
+		"current_version":  hexBytes(forkVersion[:]),
+		"epoch":            "0",
+	}
+}
+
+// handleFinality reports zero-root checkpoints, so consumers fall back to
+// the head block for safe/finalized hashes.
+func (b *Beacon) handleFinality(w http.ResponseWriter, _ *http.Request) {
+	checkpoint := map[string]any{"epoch": "0", "root": hexBytes(make([]byte, 32))}
+
+	writeData(w, map[string]any{
+		"previous_justified": checkpoint,
+		"current_justified":  checkpoint,
+		"finalized":          checkpoint,
+	})
+}
+
+func (b *Beacon) handleHeader(w http.ResponseWriter, r *http.Request) {
+	block, root := b.lookupBlock(mux.Vars(r)["block_id"])
+	if block == nil {
+		http.Error(w, `{"code":404,"message":"block not found"}`, http.StatusNotFound)
+		return
+	}
+
+	msg := block.Message
+
+	writeData(w, map[string]any{
+		"root":      hexBytes(root[:]),
+		"canonical": true,
+		"header": map[string]any{
+			"message": map[string]any{
+				"slot":           fmt.Sprintf("%d", msg.Slot),
+				"proposer_index": fmt.Sprintf("%d", msg.ProposerIndex),
+				"parent_root":    hexBytes(msg.ParentRoot[:]),
+				"state_root":     hexBytes(msg.StateRoot[:]),
+				"body_root":      hexBytes(make([]byte, 32)),
+			},
+			"signature": hexBytes(block.Signature[:]),
+		},
+	})
+}
+
+func (b *Beacon) handleBlock(w http.ResponseWriter, r *http.Request) {
+	block, _ := b.lookupBlock(mux.Vars(r)["block_id"])
+	if block == nil {
+		http.Error(w, `{"code":404,"message":"block not found"}`, http.StatusNotFound)
+		return
+	}
+
+	data, err := block.MarshalSSZDyn(b.ds, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeSSZ(w, b.sc.Fork, data)
+}
+
+// lookupBlock resolves "head", "finalized", "justified", a slot number or a
+// 0x block root.
+func (b *Beacon) lookupBlock(id string) (*all.SignedBeaconBlock, phase0.Root) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch id {
+	case "head", "finalized", "justified":
+		return b.head, b.headRoot
+	}
+
+	for root, block := range b.blocks {
+		if id == hexBytes(root[:]) || id == fmt.Sprintf("%d", block.Message.Slot) {
+			return block, root
+		}
+	}
+
+	return nil, phase0.Root{}
+}
+
+func (b *Beacon) handleState(w http.ResponseWriter, _ *http.Request) {
+	state := b.buildState()
+
+	data, err := state.MarshalSSZDyn(b.ds, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeSSZ(w, b.sc.Fork, data)
+}
+
+// buildState assembles the head state: the validator set, the proposer
+// lookahead and (Gloas) the builder registry, with every fixed-size vector
+// sized from the scenario spec. The finalized checkpoint trails the head by
+// one epoch so genesis-deposited builders count as active.
+func (b *Beacon) buildState() *all.BeaconState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sc := b.sc
+	slot := b.head.Message.Slot
+	slotsPerEpoch := sc.SlotsPerEpoch()
+	epoch := phase0.Epoch(uint64(slot) / slotsPerEpoch)
+	forkVersion := sc.ForkVersion()
+
+	validators := make([]*phase0.Validator, len(b.keys))
+	balances := make([]phase0.Gwei, len(b.keys))
+
+	for i, key := range b.keys {
+		validators[i] = &phase0.Validator{
+			PublicKey:                  key.PublicKey(),
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           32_000_000_000,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  phase0.Epoch(chain.FarFutureEpoch),
+			WithdrawableEpoch:          phase0.Epoch(chain.FarFutureEpoch),
+		}
+		balances[i] = 32_000_000_000
+	}
+
+	lookahead := make([]phase0.ValidatorIndex, (sc.specUint64("MIN_SEED_LOOKAHEAD")+1)*slotsPerEpoch)
+	firstSlot := phase0.Slot(uint64(epoch) * slotsPerEpoch)
+
+	for i := range lookahead {
+		lookahead[i] = b.ProposerIndex(firstSlot + phase0.Slot(i)) //nolint:gosec // small fixture values
+	}
+
+	syncCommittee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, sc.specUint64("SYNC_COMMITTEE_SIZE")),
+	}
+
+	var finalized phase0.Epoch
+	if epoch > 0 {
+		finalized = epoch - 1
+	}
+
+	headHash := b.headHash
+
+	state := &all.BeaconState{
+		Version:               sc.Fork,
+		GenesisTime:           uint64(b.genesisTime.Unix()), //nolint:gosec // after 1970
+		GenesisValidatorsRoot: b.gvr,
+		Slot:                  slot,
+		Fork: &phase0.Fork{
+			PreviousVersion: forkVersion,
+			CurrentVersion:  forkVersion,
+		},
+		LatestBlockHeader:           &phase0.BeaconBlockHeader{Slot: slot, ParentRoot: b.head.Message.ParentRoot},
+		BlockRoots:                  make([]phase0.Root, sc.specUint64("SLOTS_PER_HISTORICAL_ROOT")),
+		StateRoots:                  make([]phase0.Root, sc.specUint64("SLOTS_PER_HISTORICAL_ROOT")),
+		ETH1Data:                    &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 make([]phase0.Root, sc.specUint64("EPOCHS_PER_HISTORICAL_VECTOR")),
+		Slashings:                   make([]phase0.Gwei, sc.specUint64("EPOCHS_PER_SLASHINGS_VECTOR")),
+		PreviousEpochParticipation:  make([]altair.ParticipationFlags, len(b.keys)),
+		CurrentEpochParticipation:   make([]altair.ParticipationFlags, len(b.keys)),
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{Epoch: finalized},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{Epoch: finalized},
+		FinalizedCheckpoint:         &phase0.Checkpoint{Epoch: finalized},
+		InactivityScores:            make([]uint64, len(b.keys)),
+		CurrentSyncCommittee:        syncCommittee,
+		NextSyncCommittee:           syncCommittee,
+		ProposerLookahead:           lookahead,
+	}
+
+	if sc.Fork >= version.DataVersionGloas {
+		ptcSize := sc.specUint64("PTC_SIZE")
+		ptcWindow := make([][]phase0.ValidatorIndex, (2+sc.specUint64("MIN_SEED_LOOKAHEAD"))*slotsPerEpoch)
+
+		for i := range ptcWindow {
+			ptcWindow[i] = make([]phase0.ValidatorIndex, ptcSize)
+		}
+
+		pendingPayments := make([]*gloas.BuilderPendingPayment, 2*slotsPerEpoch)
+		for i := range pendingPayments {
+			pendingPayments[i] = &gloas.BuilderPendingPayment{Withdrawal: &gloas.BuilderPendingWithdrawal{}}
+		}
+
+		state.Builders = append([]*gloas.Builder(nil), b.builders...)
+		state.LatestBlockHash = headHash
+		state.ExecutionPayloadAvailability = make([]uint8, sc.specUint64("SLOTS_PER_HISTORICAL_ROOT")/8)
+		state.BuilderPendingPayments = pendingPayments
+		state.PTCWindow = ptcWindow
+		state.LatestExecutionPayloadBid = &all.ExecutionPayloadBid{
+			Version:   sc.Fork,
+			Slot:      slot,
+			BlockHash: headHash,
+		}
+	} else {
+		state.LatestExecutionPayloadHeader = &all.ExecutionPayloadHeader{
+			Version:     sc.Fork,
+			BlockNumber: uint64(slot),
+			BlockHash:   headHash,
+		}
+	}
+
+	return state
+}
+
+// handleEvents streams the requested topics until the client disconnects or
+// the node is closed.
+func (b *Beacon) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	topics := strings.Split(r.URL.Query().Get("topics"), ",")
+	ch := make(chan sseEvent, 64)
+
+	b.mu.Lock()
+	for _, topic := range topics {
+		b.subscribers[topic] = append(b.subscribers[topic], ch)
+	}
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for _, topic := range topics {
+			subs := b.subscribers[topic]
+			for i, sub := range subs {
+				if sub == ch {
+					b.subscribers[topic] = append(subs[:i], subs[i+1:]...)
+					break
+				}
+			}
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-b.done:
+			return
+		case event := <-ch:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.topic, event.data)
+			flusher.Flush()
+		}
+	}
+}
+
+// handleSubmission records any POST under /eth/ and accepts it. An empty
+// JSON object is rejected unrecorded, as a real node's validation would (the
+// startup capability check probes the routes with one).
+func (b *Beacon) handleSubmission(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(string(body)) == "{}" {
+		http.Error(w, `{"code":400,"message":"invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+
+	b.mu.Lock()
+	b.submissions = append(b.submissions, &Submission{
+		Path:             r.URL.Path,
+		ConsensusVersion: r.Header.Get("Eth-Consensus-Version"),
+		ContentType:      r.Header.Get("Content-Type"),
+		Body:             body,
+		ReceivedAt:       time.Now(),
+	})
+	b.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func writeSSZ(w http.ResponseWriter, fork version.DataVersion, data []byte) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Eth-Consensus-Version", strings.ToLower(fork.String()))
+	_, _ = w.Write(data)
+}
+
+func hexBytes(data []byte) string {
+	return fmt.Sprintf("%#x", data)
+}
@@ -0,0 +1,332 @@
+package harness
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethpandaops/go-eth-engine-client/spec/amsterdam"
+	"github.com/ethpandaops/go-eth-engine-client/spec/cancun"
+	"github.com/ethpandaops/go-eth-engine-client/spec/identification"
+	"github.com/ethpandaops/go-eth-engine-client/spec/osaka"
+	"github.com/ethpandaops/go-eth-engine-client/spec/paris"
+	"github.com/ethpandaops/go-eth-engine-client/spec/prague"
+	"github.com/ethpandaops/go-eth-engine-client/spec/shanghai"
+	"github.com/holiman/uint256"
+)
+
+// harnessGasLimit is the gas limit of every payload the fake engine builds.
+const harnessGasLimit = 60_000_000
+
+// Engine is a fake execution-layer engine API. forkchoiceUpdated with
+// payload attributes "builds" an empty payload on the requested head (with a
+// correct block hash, so buildoor's extra-data rewrite verifies), and
+// getPayload returns it. Supports the Fulu (V3/V5) and Gloas (V4/V6) method
+// pairs; JWTs are accepted without verification.
+type Engine struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	calls      map[string]int
+	payloads   map[paris.PayloadID]*builtPayload
+	blockValue *big.Int
+	nextID     uint64
+}
+
+// builtPayload is a payload prepared by forkchoiceUpdated.
+type builtPayload struct {
+	amsterdam bool
+	payload   *amsterdam.ExecutionPayload
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewEngine starts a fake engine API.
+func NewEngine() *Engine {
+	e := &Engine{
+		calls:      make(map[string]int),
+		payloads:   make(map[paris.PayloadID]*builtPayload),
+		blockValue: big.NewInt(10_000_000_000_000_000), // 0.01 ETH
+	}
+
+	e.server = httptest.NewServer(http.HandlerFunc(e.handle))
+
+	return e
+}
+
+// URL returns the engine API endpoint.
+func (e *Engine) URL() string {
+	return e.server.URL
+}
+
+// Close shuts the server down.
+func (e *Engine) Close() {
+	e.server.Close()
+}
+
+// SetBlockValue sets the block value (wei) reported by getPayload.
+func (e *Engine) SetBlockValue(wei *big.Int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.blockValue = new(big.Int).Set(wei)
+}
+
+// Calls returns how often method was called.
+func (e *Engine) Calls(method string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.calls[method]
+}
+
+func (e *Engine) handle(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e.mu.Lock()
+	e.calls[req.Method]++
+	e.mu.Unlock()
+
+	result, err := e.dispatch(&req)
+
+	resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if err != nil {
+		resp["error"] = err
+	} else {
+		resp["result"] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (e *Engine) dispatch(req *rpcRequest) (any, *rpcError) {
+	switch req.Method {
+	case "engine_exchangeCapabilities":
+		return []string{
+			"engine_forkchoiceUpdatedV3", "engine_forkchoiceUpdatedV4",
+			"engine_getPayloadV5", "engine_getPayloadV6",
+		}, nil
+	case "engine_getClientVersionV1":
+		return []*identification.ClientVersion{{
+			Code:    []byte("HA"),
+			Name:    []byte("buildoor-harness"),
+			Version: []byte("v0.0.0"),
+		}}, nil
+	case "engine_forkchoiceUpdatedV3":
+		return e.forkchoiceUpdated(req.Params, false)
+	case "engine_forkchoiceUpdatedV4":
+		return e.forkchoiceUpdated(req.Params, true)
+	case "engine_getPayloadV5", "engine_getPayloadV6":
+		return e.getPayload(req.Params)
+	default:
+		return nil, &rpcError{Code: -32601, Message: "method not found: " + req.Method}
+	}
+}
+
+// forkchoiceUpdated starts a build when attributes are given.
+func (e *Engine) forkchoiceUpdated(params []json.RawMessage, isAmsterdam bool) (any, *rpcError) {
+	if len(params) < 1 {
+		return nil, &rpcError{Code: -32602, Message: "missing forkchoice state"}
+	}
+
+	state := &paris.ForkchoiceState{}
+	if err := json.Unmarshal(params[0], state); err != nil {
+		return nil, &rpcError{Code: -32602, Message: err.Error()}
+	}
+
+	resp := &paris.ForkchoiceUpdatedResponse{
+		PayloadStatus: paris.PayloadStatus{
+			Status:          paris.PayloadValidationStatusValid,
+			LatestValidHash: &state.HeadBlockHash,
+		},
+	}
+
+	if len(params) < 2 || string(params[1]) == "null" {
+		return resp, nil
+	}
+
+	var attrs amsterdam.PayloadAttributes
+
+	if isAmsterdam {
+		if err := json.Unmarshal(params[1], &attrs); err != nil {
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+	} else {
+		var cancunAttrs cancun.PayloadAttributes
+		if err := json.Unmarshal(params[1], &cancunAttrs); err != nil {
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+
+		attrs = amsterdam.PayloadAttributes{
+			Timestamp:             cancunAttrs.Timestamp,
+			PrevRandao:            cancunAttrs.PrevRandao,
+			SuggestedFeeRecipient: cancunAttrs.SuggestedFeeRecipient,
+			Withdrawals:           cancunAttrs.Withdrawals,
+			ParentBeaconBlockRoot: cancunAttrs.ParentBeaconBlockRoot,
+		}
+	}
+
+	payload := &amsterdam.ExecutionPayload{
+		ParentHash:    state.HeadBlockHash,
+		FeeRecipient:  attrs.SuggestedFeeRecipient,
+		StateRoot:     paris.Hash32(common.HexToHash("0x01")),
+		ReceiptsRoot:  paris.Hash32(types.EmptyReceiptsHash),
+		PrevRandao:    attrs.PrevRandao,
+		BlockNumber:   attrs.Timestamp,
+		GasLimit:      harnessGasLimit,
+		Timestamp:     attrs.Timestamp,
+		ExtraData:     []byte("harness"),
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  []paris.Transaction{},
+		Withdrawals:   attrs.Withdrawals,
+		SlotNumber:    attrs.SlotNumber,
+	}
+
+	if payload.Withdrawals == nil {
+		payload.Withdrawals = []*shanghai.Withdrawal{}
+	}
+
+	payload.BlockHash = payloadBlockHash(payload, common.Hash(attrs.ParentBeaconBlockRoot), isAmsterdam)
+
+	e.mu.Lock()
+	e.nextID++
+
+	var id paris.PayloadID
+
+	binary.BigEndian.PutUint64(id[:], e.nextID)
+	e.payloads[id] = &builtPayload{amsterdam: isAmsterdam, payload: payload}
+	e.mu.Unlock()
+
+	resp.PayloadID = &id
+
+	return resp, nil
+}
+
+// getPayload returns a payload prepared by forkchoiceUpdated.
+func (e *Engine) getPayload(params []json.RawMessage) (any, *rpcError) {
+	if len(params) < 1 {
+		return nil, &rpcError{Code: -32602, Message: "missing payload id"}
+	}
+
+	var id paris.PayloadID
+	if err := json.Unmarshal(params[0], &id); err != nil {
+		return nil, &rpcError{Code: -32602, Message: err.Error()}
+	}
+
+	e.mu.Lock()
+	built := e.payloads[id]
+	value, _ := uint256.FromBig(e.blockValue)
+	e.mu.Unlock()
+
+	if built == nil {
+		return nil, &rpcError{Code: -38001, Message: "unknown payload"}
+	}
+
+	bundle := &osaka.BlobsBundle{
+		Commitments: []cancun.KZGCommitment{},
+		Proofs:      []cancun.KZGProof{},
+		Blobs:       []cancun.Blob{},
+	}
+
+	if built.amsterdam {
+		return &amsterdam.GetPayloadResponse{
+			ExecutionPayload:  built.payload,
+			BlockValue:        value,
+			BlobsBundle:       bundle,
+			ExecutionRequests: []prague.ExecutionRequest{},
+		}, nil
+	}
+
+	p := built.payload
+
+	return &osaka.GetPayloadResponse{
+		ExecutionPayload: &cancun.ExecutionPayload{
+			ParentHash:    p.ParentHash,
+			FeeRecipient:  p.FeeRecipient,
+			StateRoot:     p.StateRoot,
+			ReceiptsRoot:  p.ReceiptsRoot,
+			LogsBloom:     p.LogsBloom,
+			PrevRandao:    p.PrevRandao,
+			BlockNumber:   p.BlockNumber,
+			GasLimit:      p.GasLimit,
+			GasUsed:       p.GasUsed,
+			Timestamp:     p.Timestamp,
+			ExtraData:     p.ExtraData,
+			BaseFeePerGas: p.BaseFeePerGas,
+			BlockHash:     p.BlockHash,
+			Transactions:  p.Transactions,
+			Withdrawals:   p.Withdrawals,
+		},
+		BlockValue:        value,
+		BlobsBundle:       bundle,
+		ExecutionRequests: []prague.ExecutionRequest{},
+	}, nil
+}
+
+// payloadBlockHash computes the execution block hash of an (empty) payload
+// from its Prague/Osaka header, plus the slot number from Amsterdam on.
+func payloadBlockHash(p *amsterdam.ExecutionPayload, parentBeaconRoot common.Hash, isAmsterdam bool) paris.Hash32 {
+	withdrawals := make(types.Withdrawals, len(p.Withdrawals))
+	for i, w := range p.Withdrawals {
+		withdrawals[i] = &types.Withdrawal{
+			Index:     w.Index,
+			Validator: w.ValidatorIndex,
+			Address:   common.Address(w.Address),
+			Amount:    w.Amount,
+		}
+	}
+
+	withdrawalsHash := types.DeriveSha(withdrawals, trie.NewStackTrie(nil))
+	requestsHash := types.CalcRequestsHash(nil)
+	blobGas := p.BlobGasUsed
+	excessBlobGas := p.ExcessBlobGas
+
+	header := &types.Header{
+		ParentHash:       common.Hash(p.ParentHash),
+		UncleHash:        types.EmptyUncleHash,
+		Coinbase:         common.Address(p.FeeRecipient),
+		Root:             common.Hash(p.StateRoot),
+		TxHash:           types.EmptyTxsHash,
+		ReceiptHash:      common.Hash(p.ReceiptsRoot),
+		Difficulty:       big.NewInt(0),
+		Number:           new(big.Int).SetUint64(p.BlockNumber),
+		GasLimit:         p.GasLimit,
+		GasUsed:          p.GasUsed,
+		Time:             p.Timestamp,
+		Extra:            p.ExtraData,
+		MixDigest:        common.Hash(p.PrevRandao),
+		BaseFee:          p.BaseFeePerGas.ToBig(),
+		WithdrawalsHash:  &withdrawalsHash,
+		BlobGasUsed:      &blobGas,
+		ExcessBlobGas:    &excessBlobGas,
+		ParentBeaconRoot: &parentBeaconRoot,
+		RequestsHash:     &requestsHash,
+	}
+
+	if isAmsterdam {
+		slot := p.SlotNumber
+		header.SlotNumber = &slot
+	}
+
+	return paris.Hash32(header.Hash())
+}
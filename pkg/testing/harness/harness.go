@@ -0,0 +1,147 @@
+package harness
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/buildoor"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/signer"
+)
+
+// builderPrivkey is the builder's secret key; distinct from every validator
+// scalar (1..Validators).
+const builderPrivkey = "0x3f6b8c0e0d4bd6d5c5a1e8d1f0c9b7a6e5d4c3b2a19080706050403020100f0e"
+
+const (
+	// defaultTimeout bounds every wait on buildoor (a few 4s slots).
+	defaultTimeout = 15 * time.Second
+	pollInterval   = 20 * time.Millisecond
+)
+
+// Harness wires a buildoor instance to a fake beacon node and engine API.
+// Create it with New, adjust Config() and the beacon fixture (e.g.
+// AddBuilder), then Start it; everything is torn down on test cleanup.
+type Harness struct {
+	t       testing.TB
+	cfg     *config.Config
+	builder *signer.BLSSigner
+
+	Beacon   *Beacon
+	Engine   *Engine
+	Buildoor *buildoor.Buildoor
+}
+
+// New starts the fakes for the scenario and prepares (but does not start) a
+// buildoor config pointing at them. Slot timings are pinned explicitly so
+// payloads are ready well inside the bid window of a 4s slot.
+func New(t testing.TB, sc *Scenario) *Harness {
+	t.Helper()
+
+	beacon, err := NewBeacon(sc)
+	require.NoError(t, err)
+	t.Cleanup(beacon.Close)
+
+	engine := NewEngine()
+	t.Cleanup(engine.Close)
+
+	jwtPath := filepath.Join(t.TempDir(), "jwtsecret")
+	require.NoError(t, os.WriteFile(jwtPath, []byte(hex.EncodeToString(make([]byte, 32))), 0o600))
+
+	builder, err := signer.NewBLSSigner(builderPrivkey)
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig()
+	cfg.BuilderPrivkey = builderPrivkey
+	cfg.CLClient = beacon.URL()
+	cfg.ELEngineAPI = engine.URL()
+	cfg.ELJWTSecret = jwtPath
+	cfg.EPBS.BuildStartTime = -1500
+	cfg.PayloadBuildTime = 200
+	cfg.EPBS.BidStartTime = -1000
+	cfg.EPBS.BidEndTime = -50
+	cfg.ClockSpeedup = sc.Speedup
+
+	return &Harness{
+		t:       t,
+		cfg:     cfg,
+		builder: builder,
+		Beacon:  beacon,
+		Engine:  engine,
+	}
+}
+
+// Config returns the buildoor config used by Start.
+func (h *Harness) Config() *config.Config {
+	return h.cfg
+}
+
+// BuilderKey returns the builder's signing key.
+func (h *Harness) BuilderKey() *signer.BLSSigner {
+	return h.builder
+}
+
+// EnableAPI serves the WebUI and Builder API on a free local port and
+// returns its base URL.
+func (h *Harness) EnableAPI() string {
+	h.t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(h.t, err)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(h.t, listener.Close())
+
+	h.cfg.APIPort = port
+	h.cfg.BuilderAPIEnabled = true
+
+	return fmt.Sprintf("http://127.0.0.1:%d", port)
+}
+
+// Start starts buildoor against the fakes and waits until its event streams
+// are connected, so events published afterwards are not lost.
+func (h *Harness) Start() *buildoor.Buildoor {
+	h.t.Helper()
+
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+
+	b, err := buildoor.New(h.cfg, log)
+	require.NoError(h.t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.t.Cleanup(cancel)
+
+	require.NoError(h.t, b.Start(ctx))
+	h.t.Cleanup(b.Stop)
+
+	h.Buildoor = b
+
+	// Readiness is judged by buildoor's own streams: the fake's subscriber
+	// count also includes connections the startup capability check just
+	// closed.
+	events := b.PayloadBuilder().GetCLClient().Events()
+
+	for _, topic := range []string{"head", "payload_attributes", "proposer_preferences"} {
+		require.Eventually(h.t, func() bool {
+			for _, stats := range events.Stats() {
+				if stats.Topic == topic {
+					return stats.Connected
+				}
+			}
+
+			return false
+		}, defaultTimeout, pollInterval, "no %s event stream", topic)
+	}
+
+	return b
+}
@@ -0,0 +1,230 @@
+package harness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	apiv1 "github.com/ethpandaops/go-eth2-client/api/v1"
+	"github.com/ethpandaops/go-eth2-client/spec/bellatrix"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/interop"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+var feeRecipient = bellatrix.ExecutionAddress{0xfe, 0xe0}
+
+// waitPayload waits for buildoor to finish building the payload for slot.
+func waitPayload(t *testing.T, ch <-chan *payload_builder.Payload, slot phase0.Slot) *payload_builder.Payload {
+	t.Helper()
+
+	timeout := time.After(defaultTimeout)
+
+	for {
+		select {
+		case payload := <-ch:
+			if payload.Attributes.ProposalSlot == slot {
+				return payload
+			}
+		case <-timeout:
+			t.Fatalf("no payload built for slot %d", slot)
+		}
+	}
+}
+
+// registerProposer registers the proposer of slot with the Builder API.
+func registerProposer(t *testing.T, h *Harness, apiURL string, slot phase0.Slot) *apiv1.SignedValidatorRegistration {
+	t.Helper()
+
+	reg, err := h.Beacon.SignedRegistration(h.Beacon.ProposerIndex(slot), feeRecipient, harnessGasLimit)
+	require.NoError(t, err)
+
+	body, err := json.Marshal([]*apiv1.SignedValidatorRegistration{reg})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Post(apiURL+"/eth/v1/builder/validators", "application/json", bytes.NewReader(body)) //nolint:noctx // test
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode == http.StatusOK
+	}, defaultTimeout, pollInterval, "validator registration rejected")
+
+	return reg
+}
+
+func TestFuluBuilderAPIServesHeader(t *testing.T) {
+	h := New(t, FuluScenario())
+	apiURL := h.EnableAPI()
+	b := h.Start()
+
+	sub := b.SubscribePayloadReady(8)
+	defer sub.Unsubscribe()
+
+	slot := h.Beacon.CurrentSlot() + 2
+	reg := registerProposer(t, h, apiURL, slot)
+
+	h.Beacon.PublishPayloadAttributes(slot, feeRecipient)
+
+	payload := waitPayload(t, sub.Channel(), slot)
+
+	_, _, headHash := h.Beacon.Head()
+	require.Equal(t, headHash, payload.Attributes.ParentBlockHash)
+	require.Positive(t, h.Engine.Calls("engine_forkchoiceUpdatedV3"))
+	require.Positive(t, h.Engine.Calls("engine_getPayloadV5"))
+
+	url := fmt.Sprintf("%s/eth/v1/builder/header/%d/%#x/%#x",
+		apiURL, slot, payload.Attributes.ParentBlockHash[:], reg.Message.Pubkey[:])
+
+	resp, err := http.Get(url) //nolint:noctx // test
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var header struct {
+		Version string `json:"version"`
+		Data    struct {
+			Message struct {
+				Header struct {
+					BlockHash string `json:"block_hash"`
+				} `json:"header"`
+			} `json:"message"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&header))
+	require.Equal(t, "fulu", header.Version)
+	require.Equal(t, fmt.Sprintf("%#x", payload.BlockHash[:]), header.Data.Message.Header.BlockHash)
+}
+
+// TestFuluEngineRace races the payload build across a second engine that
+// builds a more valuable payload.
+func TestFuluEngineRace(t *testing.T) {
+	h := New(t, FuluScenario())
+
+	raceEngine := NewEngine()
+	t.Cleanup(raceEngine.Close)
+	raceEngine.SetBlockValue(big.NewInt(20_000_000_000_000_000))
+
+	h.Config().ELEngineRaceAPIs = []string{raceEngine.URL()}
+	apiURL := h.EnableAPI()
+	b := h.Start()
+
+	sub := b.SubscribePayloadReady(8)
+	defer sub.Unsubscribe()
+
+	slot := h.Beacon.CurrentSlot() + 2
+	registerProposer(t, h, apiURL, slot)
+
+	h.Beacon.PublishPayloadAttributes(slot, feeRecipient)
+
+	payload := waitPayload(t, sub.Channel(), slot)
+
+	raceName := payload_builder.EngineName(raceEngine.URL())
+	require.Equal(t, raceName, payload.Engine)
+	require.Equal(t, big.NewInt(20_000_000_000_000_000), payload.BlockValue)
+	require.Len(t, payload.EngineRace, 2)
+	require.True(t, payload.EngineRace[1].Won)
+	require.Positive(t, h.Engine.Calls("engine_getPayloadV5"))
+	require.Positive(t, raceEngine.Calls("engine_getPayloadV5"))
+
+	require.Eventually(t, func() bool {
+		return b.PayloadBuilder().GetStats().EngineWins[raceName] >= 1
+	}, defaultTimeout, pollInterval)
+}
+
+// TestBuilderAPIClientInterop replays every client profile against the
+// Builder API with a real bid: SSZ and JSON headers, SSZ and JSON
+// registrations, "no bid" and error responses.
+func TestBuilderAPIClientInterop(t *testing.T) {
+	h := New(t, FuluScenario())
+	apiURL := h.EnableAPI()
+	b := h.Start()
+
+	sub := b.SubscribePayloadReady(8)
+	defer sub.Unsubscribe()
+
+	slot := h.Beacon.CurrentSlot() + 2
+	reg := registerProposer(t, h, apiURL, slot)
+
+	h.Beacon.PublishPayloadAttributes(slot, feeRecipient)
+
+	payload := waitPayload(t, sub.Channel(), slot)
+
+	profiles, err := interop.Profiles()
+	require.NoError(t, err)
+
+	report, err := interop.NewRunner(interop.Options{
+		Target:     apiURL,
+		Slot:       slot,
+		ParentHash: payload.Attributes.ParentBlockHash,
+		Pubkey:     reg.Message.Pubkey,
+	}).Run(t.Context(), profiles)
+	require.NoError(t, err)
+
+	for _, client := range report.Clients {
+		for _, c := range client.Checks {
+			require.Equal(t, interop.ResultPass, c.Result, "%s/%s: %s", client.Client, c.Exchange, c.Detail)
+
+			if c.Exchange == "get_header" {
+				require.Equal(t, http.StatusOK, c.Status, "%s got a bid", client.Client)
+			}
+		}
+	}
+}
+
+func TestGloasBidAndReveal(t *testing.T) {
+	h := New(t, GloasScenario())
+	builderIndex := h.Beacon.AddBuilder(h.BuilderKey().PublicKey(), 1_000_000_000_000)
+	h.Config().EPBSEnabled = true
+	b := h.Start()
+
+	sub := b.SubscribePayloadReady(8)
+	defer sub.Unsubscribe()
+
+	reveals := b.SubscribeRevealResults(8)
+	defer reveals.Unsubscribe()
+
+	slot := h.Beacon.CurrentSlot() + 2
+	require.NoError(t, h.Beacon.PublishProposerPreferences(slot, feeRecipient, harnessGasLimit))
+	h.Beacon.PublishPayloadAttributes(slot, feeRecipient)
+
+	payload := waitPayload(t, sub.Channel(), slot)
+	require.Positive(t, h.Engine.Calls("engine_forkchoiceUpdatedV4"))
+	require.Positive(t, h.Engine.Calls("engine_getPayloadV6"))
+
+	const bidPath = "/eth/v1/beacon/execution_payload_bids"
+
+	require.Eventually(t, func() bool { return len(h.Beacon.Submissions(bidPath)) > 0 },
+		defaultTimeout, pollInterval, "no bid submitted")
+
+	bid, err := h.Beacon.DecodeBid(h.Beacon.Submissions(bidPath)[0])
+	require.NoError(t, err)
+	require.Equal(t, slot, bid.Message.Slot)
+	require.Equal(t, builderIndex, bid.Message.BuilderIndex)
+	require.Equal(t, payload.BlockHash, bid.Message.BlockHash)
+	require.Equal(t, feeRecipient, bid.Message.FeeRecipient)
+
+	// The proposer selects our bid; buildoor reveals the payload.
+	_, err = h.Beacon.IncludeBid(bid)
+	require.NoError(t, err)
+
+	select {
+	case result := <-reveals.Channel():
+		require.Equal(t, slot, result.Slot)
+		require.True(t, result.Success, result.Error)
+	case <-time.After(defaultTimeout):
+		t.Fatal("payload not revealed")
+	}
+
+	require.NotEmpty(t, h.Beacon.Submissions("/eth/v1/beacon/execution_payload_envelopes"))
+}
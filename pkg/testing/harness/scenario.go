@@ -0,0 +1,146 @@
+// Package harness is an in-process test harness for buildoor: a fake beacon
+// node (spec/state/block endpoints, SSE topics, submission recording) and a
+// fake engine API, wired to a real pkg/buildoor instance. It exercises the
+// ePBS bid/reveal loop and the Builder API flow in CI without kurtosis.
+package harness
+
+import (
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Scenario is one network fixture: the beacon spec served by the fake node,
+// the fork the chain runs at and the size of the validator set.
+type Scenario struct {
+	Name string
+
+	// Fork is the fork active from genesis; states and blocks are served in
+	// this fork's schema.
+	Fork version.DataVersion
+
+	// Validators is the number of active genesis validators. Validator i's
+	// secret key is the scalar i+1 (see ValidatorKey).
+	Validators int
+
+	// SlotsSinceGenesis places the chain head this many slots after genesis
+	// when the harness starts.
+	SlotsSinceGenesis uint64
+
+	// Speedup runs the chain's clock this many times faster than wall time
+	// from genesis on (see buildoor's --clock-speedup, which must match).
+	// 0 or 1 is wall time.
+	Speedup float64
+
+	// Spec is the /eth/v1/config/spec response (string values as served by
+	// beacon nodes; BLOB_SCHEDULE is kept raw).
+	Spec map[string]json.RawMessage
+}
+
+// FuluScenario returns a minimal-preset network running Fulu from genesis,
+// with Gloas unscheduled (Builder API legacy dialect, no p2p bidding).
+func FuluScenario() *Scenario {
+	return mustLoadScenario("fulu", version.DataVersionFulu)
+}
+
+// GloasScenario returns a minimal-preset network running Gloas from genesis
+// (p2p bidding, reveals and the Builder API epbs dialect).
+func GloasScenario() *Scenario {
+	return mustLoadScenario("gloas", version.DataVersionGloas)
+}
+
+func mustLoadScenario(name string, fork version.DataVersion) *Scenario {
+	data, err := fixtures.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		panic(fmt.Sprintf("missing scenario fixture %s: %v", name, err))
+	}
+
+	spec := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &spec); err != nil {
+		panic(fmt.Sprintf("invalid scenario fixture %s: %v", name, err))
+	}
+
+	return &Scenario{
+		Name:              name,
+		Fork:              fork,
+		Validators:        64,
+		SlotsSinceGenesis: 20,
+		Spec:              spec,
+	}
+}
+
+// SetSpec overrides one spec value (e.g. "SECONDS_PER_SLOT").
+func (s *Scenario) SetSpec(key, value string) {
+	raw, _ := json.Marshal(value)
+	s.Spec[key] = raw
+}
+
+// specString returns a string spec value ("" when absent or not a string).
+func (s *Scenario) specString(key string) string {
+	var value string
+	if err := json.Unmarshal(s.Spec[key], &value); err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// specUint64 returns a numeric spec value (0 when absent).
+func (s *Scenario) specUint64(key string) uint64 {
+	value, _ := strconv.ParseUint(s.specString(key), 10, 64)
+	return value
+}
+
+// SlotDuration returns SECONDS_PER_SLOT.
+func (s *Scenario) SlotDuration() time.Duration {
+	return time.Duration(s.specUint64("SECONDS_PER_SLOT")) * time.Second
+}
+
+// SlotsPerEpoch returns SLOTS_PER_EPOCH.
+func (s *Scenario) SlotsPerEpoch() uint64 {
+	return s.specUint64("SLOTS_PER_EPOCH")
+}
+
+// ForkVersion returns the fork version of the scenario's fork.
+func (s *Scenario) ForkVersion() phase0.Version {
+	return s.version(strings.ToUpper(s.Fork.String()) + "_FORK_VERSION")
+}
+
+// GenesisForkVersion returns GENESIS_FORK_VERSION.
+func (s *Scenario) GenesisForkVersion() phase0.Version {
+	return s.version("GENESIS_FORK_VERSION")
+}
+
+func (s *Scenario) version(key string) phase0.Version {
+	var v phase0.Version
+
+	raw, _ := hex.DecodeString(strings.TrimPrefix(s.specString(key), "0x"))
+	copy(v[:], raw)
+
+	return v
+}
+
+// sszSpecs returns the numeric spec values as the dynamic-ssz spec map, so
+// the fake node encodes states and blocks with the same preset sizes the
+// buildoor client decodes them with.
+func (s *Scenario) sszSpecs() map[string]any {
+	specs := make(map[string]any, len(s.Spec))
+
+	for key := range s.Spec {
+		if value, err := strconv.ParseUint(s.specString(key), 10, 64); err == nil {
+			specs[key] = value
+		}
+	}
+
+	return specs
+}
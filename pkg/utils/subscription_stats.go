@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dispatcherDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_dispatcher_dropped_events_total",
+		Help: "Events dropped because a subscriber's buffer was full.",
+	}, []string{"dispatcher"})
+
+	dispatcherHighWater = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "buildoor_dispatcher_buffer_high_water",
+		Help: "Highest number of queued events seen in any subscriber buffer.",
+	}, []string{"dispatcher"})
+)
+
+// SubscriptionStats describes one live Dispatcher subscription.
+type SubscriptionStats struct {
+	ID           uint64 `json:"id"`
+	Dispatcher   string `json:"dispatcher"` // event type, e.g. "*beacon.HeadEvent"
+	Capacity     int    `json:"capacity"`
+	Blocking     bool   `json:"blocking"`
+	TimeoutMs    int64  `json:"timeout_ms,omitempty"`
+	Lag          int    `json:"lag"` // queued, not yet consumed events
+	HighWater    int    `json:"high_water"`
+	Delivered    uint64 `json:"delivered"`
+	Dropped      uint64 `json:"dropped"`
+	SubscribedAt int64  `json:"subscribed_at"` // unix ms
+}
+
+// highWaterByName backs the high-water gauge: dispatchers sharing an event
+// type share a label, so the gauge only ever rises.
+var highWaterByName = struct {
+	mu    sync.Mutex
+	marks map[string]int
+}{marks: make(map[string]int, 32)}
+
+func raiseHighWater(name string, fill int) {
+	highWaterByName.mu.Lock()
+	defer highWaterByName.mu.Unlock()
+
+	if fill > highWaterByName.marks[name] {
+		highWaterByName.marks[name] = fill
+		dispatcherHighWater.WithLabelValues(name).Set(float64(fill))
+	}
+}
+
+type subscriptionStatser interface {
+	Stats() SubscriptionStats
+}
+
+// subscriptionRegistry tracks every live subscription across all dispatchers
+// for diagnostics.
+var subscriptionRegistry = struct {
+	mu     sync.Mutex
+	nextID uint64
+	subs   map[uint64]subscriptionStatser
+}{subs: make(map[uint64]subscriptionStatser, 64)}
+
+// registerSubscription assigns s its ID and adds it to the registry.
+func registerSubscription[T any](s *Subscription[T]) {
+	subscriptionRegistry.mu.Lock()
+	defer subscriptionRegistry.mu.Unlock()
+
+	subscriptionRegistry.nextID++
+	s.id = subscriptionRegistry.nextID
+	subscriptionRegistry.subs[s.id] = s
+}
+
+func unregisterSubscription(id uint64) {
+	subscriptionRegistry.mu.Lock()
+	defer subscriptionRegistry.mu.Unlock()
+
+	delete(subscriptionRegistry.subs, id)
+}
+
+// Subscriptions returns the statistics of all live subscriptions, ordered by
+// dispatcher and subscription age.
+func Subscriptions() []SubscriptionStats {
+	subscriptionRegistry.mu.Lock()
+
+	stats := make([]SubscriptionStats, 0, len(subscriptionRegistry.subs))
+	for _, s := range subscriptionRegistry.subs {
+		stats = append(stats, s.Stats())
+	}
+
+	subscriptionRegistry.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Dispatcher != stats[j].Dispatcher {
+			return stats[i].Dispatcher < stats[j].Dispatcher
+		}
+
+		return stats[i].ID < stats[j].ID
+	})
+
+	return stats
+}
@@ -1,36 +1,90 @@
 package utils
 
-import "sync"
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 type Subscription[T any] struct {
 	channel    chan T
 	blocking   bool
+	timeout    time.Duration // blocking only: give up (and drop) after this long; 0 waits forever
 	dispatcher *Dispatcher[T]
+
+	id           uint64
+	name         string // dispatcher label
+	subscribedAt time.Time
+	delivered    atomic.Uint64
+	dropped      atomic.Uint64
+	highWater    atomic.Int64
+
+	stopAfter atomic.Pointer[func() bool] // detaches the BindContext hook
 }
 
 type Dispatcher[T any] struct {
 	mutex         sync.Mutex
 	subscriptions []*Subscription[T]
+	name          string // metrics/diagnostics label, derived from T on first use
+	highWater     int
 }
 
 func (d *Dispatcher[T]) Subscribe(capacity int, blocking bool) *Subscription[T] {
+	return d.subscribe(capacity, blocking, 0)
+}
+
+// SubscribeWithTimeout returns a blocking subscription whose Fire waits at
+// most timeout for buffer space before dropping the event (counted like a
+// non-blocking drop), so a stalled consumer cannot wedge the dispatcher.
+func (d *Dispatcher[T]) SubscribeWithTimeout(capacity int, timeout time.Duration) *Subscription[T] {
+	return d.subscribe(capacity, true, timeout)
+}
+
+// SubscribeWithContext is Subscribe with the subscription bound to ctx: it is
+// unsubscribed automatically once ctx is cancelled (see BindContext).
+func (d *Dispatcher[T]) SubscribeWithContext(ctx context.Context, capacity int, blocking bool) *Subscription[T] {
+	return d.subscribe(capacity, blocking, 0).BindContext(ctx)
+}
+
+func (d *Dispatcher[T]) subscribe(capacity int, blocking bool, timeout time.Duration) *Subscription[T] {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 	subscription := &Subscription[T]{
-		channel:    make(chan T, capacity),
-		blocking:   blocking,
-		dispatcher: d,
+		channel:      make(chan T, capacity),
+		blocking:     blocking,
+		timeout:      timeout,
+		dispatcher:   d,
+		name:         d.labelLocked(),
+		subscribedAt: time.Now(),
 	}
 	d.subscriptions = append(d.subscriptions, subscription)
+	registerSubscription(subscription)
 
 	return subscription
 }
 
+// BindContext ties the subscription to ctx so it is unsubscribed once ctx is
+// cancelled, and returns it for chaining. The channel is not closed, so
+// consumers should also select on ctx.Done(). An explicit Unsubscribe before
+// that detaches the hook. Bind a subscription at most once.
+func (s *Subscription[T]) BindContext(ctx context.Context) *Subscription[T] {
+	stop := context.AfterFunc(ctx, s.Unsubscribe)
+	s.stopAfter.Store(&stop)
+
+	return s
+}
+
 func (s *Subscription[T]) Unsubscribe() {
 	if s.dispatcher == nil {
 		return
 	}
 
+	if stop := s.stopAfter.Swap(nil); stop != nil {
+		(*stop)()
+	}
+
 	s.dispatcher.Unsubscribe(s)
 }
 
@@ -38,6 +92,28 @@ func (s *Subscription[T]) Channel() <-chan T {
 	return s.channel
 }
 
+// Dropped returns how many events this subscription missed because its
+// buffer was full (or, for SubscribeWithTimeout, stayed full for too long).
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Stats returns the subscription's current delivery statistics.
+func (s *Subscription[T]) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		ID:           s.id,
+		Dispatcher:   s.name,
+		Capacity:     cap(s.channel),
+		Blocking:     s.blocking,
+		TimeoutMs:    s.timeout.Milliseconds(),
+		Lag:          len(s.channel),
+		HighWater:    int(s.highWater.Load()),
+		Delivered:    s.delivered.Load(),
+		Dropped:      s.dropped.Load(),
+		SubscribedAt: s.subscribedAt.UnixMilli(),
+	}
+}
+
 func (d *Dispatcher[T]) Unsubscribe(subscription *Subscription[T]) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
@@ -51,6 +127,7 @@ func (d *Dispatcher[T]) Unsubscribe(subscription *Subscription[T]) {
 			}
 
 			d.subscriptions = d.subscriptions[:count-1]
+			unregisterSubscription(subscription.id)
 
 			return
 		}
@@ -62,13 +139,61 @@ func (d *Dispatcher[T]) Fire(data T) {
 	defer d.mutex.Unlock()
 
 	for _, s := range d.subscriptions {
-		if s.blocking {
-			s.channel <- data
-		} else {
-			select {
-			case s.channel <- data:
-			default:
+		if !d.send(s, data) {
+			s.dropped.Add(1)
+			dispatcherDropped.WithLabelValues(s.name).Inc()
+
+			continue
+		}
+
+		s.delivered.Add(1)
+
+		if fill := len(s.channel); int64(fill) > s.highWater.Load() {
+			s.highWater.Store(int64(fill))
+
+			if fill > d.highWater {
+				d.highWater = fill
+				raiseHighWater(s.name, fill)
 			}
 		}
 	}
 }
+
+// send delivers data to s according to its mode and reports whether it was
+// delivered. Must hold d.mutex.
+func (d *Dispatcher[T]) send(s *Subscription[T], data T) bool {
+	select {
+	case s.channel <- data:
+		return true
+	default:
+	}
+
+	if !s.blocking {
+		return false
+	}
+
+	if s.timeout <= 0 {
+		s.channel <- data
+		return true
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case s.channel <- data:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// labelLocked derives the dispatcher name from its event type (e.g.
+// "*beacon.HeadEvent"). Must hold d.mutex.
+func (d *Dispatcher[T]) labelLocked() string {
+	if d.name == "" {
+		d.name = reflect.TypeOf((*T)(nil)).Elem().String()
+	}
+
+	return d.name
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -161,3 +162,118 @@ func TestDispatcherConcurrentSubscribeUnsubscribeFire(t *testing.T) {
 		})
 	}
 }
+
+func TestDispatcherDropAccounting(t *testing.T) {
+	d := &Dispatcher[int]{}
+	sub := d.Subscribe(2, false)
+	defer sub.Unsubscribe()
+
+	for i := 0; i < 5; i++ {
+		d.Fire(i)
+	}
+
+	require.Equal(t, uint64(3), sub.Dropped())
+
+	stats := sub.Stats()
+	require.Equal(t, "int", stats.Dispatcher)
+	require.Equal(t, 2, stats.Capacity)
+	require.Equal(t, 2, stats.Lag)
+	require.Equal(t, 2, stats.HighWater)
+	require.Equal(t, uint64(2), stats.Delivered)
+	require.Equal(t, uint64(3), stats.Dropped)
+
+	<-sub.Channel()
+	require.Equal(t, 1, sub.Stats().Lag)
+	require.Equal(t, 2, sub.Stats().HighWater, "high water is sticky")
+}
+
+func TestDispatcherBlockingWithTimeoutDrops(t *testing.T) {
+	d := &Dispatcher[int]{}
+	sub := d.SubscribeWithTimeout(1, 10*time.Millisecond)
+	defer sub.Unsubscribe()
+
+	d.Fire(1)
+
+	start := time.Now()
+	d.Fire(2) // buffer full, nobody consuming
+	require.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	require.Equal(t, uint64(1), sub.Dropped())
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		<-sub.Channel()
+	}()
+
+	d.Fire(3) // space frees up within the timeout
+	require.Equal(t, uint64(1), sub.Dropped())
+	require.Equal(t, 3, <-sub.Channel())
+}
+
+func TestSubscriptionsRegistry(t *testing.T) {
+	d := &Dispatcher[string]{}
+	sub := d.Subscribe(4, false)
+
+	find := func() bool {
+		for _, stats := range Subscriptions() {
+			if stats.ID == sub.Stats().ID {
+				require.Equal(t, "string", stats.Dispatcher)
+				return true
+			}
+		}
+
+		return false
+	}
+
+	require.True(t, find())
+
+	sub.Unsubscribe()
+	require.False(t, find())
+}
+
+func (d *Dispatcher[T]) subscriberCount() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return len(d.subscriptions)
+}
+
+func TestDispatcherSubscribeWithContextUnsubscribesOnCancel(t *testing.T) {
+	d := &Dispatcher[int]{}
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := d.SubscribeWithContext(ctx, 4, false)
+
+	d.Fire(1)
+	require.Equal(t, 1, <-sub.Channel())
+
+	cancel()
+	require.Eventually(t, func() bool { return d.subscriberCount() == 0 }, time.Second, 5*time.Millisecond)
+
+	d.Fire(2)
+
+	select {
+	case v := <-sub.Channel():
+		t.Fatalf("received event %d after context cancel", v)
+	default:
+	}
+}
+
+func TestSubscriptionBindContextAlreadyCancelled(t *testing.T) {
+	d := &Dispatcher[int]{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d.Subscribe(1, false).BindContext(ctx)
+	require.Eventually(t, func() bool { return d.subscriberCount() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSubscriptionExplicitUnsubscribeDetachesContext(t *testing.T) {
+	d := &Dispatcher[int]{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := d.SubscribeWithContext(ctx, 1, false)
+	sub.Unsubscribe()
+
+	require.Zero(t, d.subscriberCount())
+	require.Nil(t, sub.stopAfter.Load())
+}
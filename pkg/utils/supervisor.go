@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+// Restart backoff of supervised goroutines: doubles from supervisorMinBackoff
+// per consecutive crash up to supervisorMaxBackoff, and resets once a run
+// survived supervisorStableAfter. Variables so tests can shorten them.
+var (
+	supervisorMinBackoff  = time.Second
+	supervisorMaxBackoff  = 30 * time.Second
+	supervisorStableAfter = time.Minute
+)
+
+var (
+	supervisorPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_goroutine_panics_total",
+		Help: "Panics recovered in supervised goroutines.",
+	}, []string{"goroutine"})
+
+	supervisorRestarts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "buildoor_goroutine_restarts_total",
+		Help: "Restarts of supervised goroutines after a panic.",
+	}, []string{"goroutine"})
+)
+
+// Supervise runs fn until it returns, restarting it after a panic: the panic
+// and its stack are logged, counted in buildoor_goroutine_panics_total, and fn
+// is re-run after an exponential backoff (counted in
+// buildoor_goroutine_restarts_total). Blocks until fn returns normally or ctx
+// is cancelled while backing off. fn must honour ctx and must not own state
+// that a panic leaves inconsistent (set subscriptions up outside fn).
+func Supervise(ctx context.Context, name string, log logrus.FieldLogger, fn func(ctx context.Context)) {
+	backoff := supervisorMinBackoff
+
+	for {
+		started := time.Now()
+
+		if !runRecovered(ctx, name, log, fn) {
+			return
+		}
+
+		if time.Since(started) >= supervisorStableAfter {
+			backoff = supervisorMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		supervisorRestarts.WithLabelValues(name).Inc()
+		log.WithField("goroutine", name).Warn("Restarting supervised goroutine after panic")
+
+		backoff = min(backoff*2, supervisorMaxBackoff)
+	}
+}
+
+// runRecovered runs fn once and reports whether it panicked.
+func runRecovered(ctx context.Context, name string, log logrus.FieldLogger, fn func(ctx context.Context)) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+
+			supervisorPanics.WithLabelValues(name).Inc()
+			log.WithFields(logrus.Fields{
+				"goroutine": name,
+				"panic":     r,
+				"stack":     string(debug.Stack()),
+			}).Error("Supervised goroutine panicked")
+		}
+	}()
+
+	fn(ctx)
+
+	return false
+}
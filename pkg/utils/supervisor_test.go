@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperviseRestartsAfterPanic(t *testing.T) {
+	supervisorMinBackoff = time.Millisecond
+	supervisorMaxBackoff = 4 * time.Millisecond
+
+	t.Cleanup(func() {
+		supervisorMinBackoff = time.Second
+		supervisorMaxBackoff = 30 * time.Second
+	})
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	runs := 0
+	Supervise(context.Background(), "test-panicky", log, func(context.Context) {
+		runs++
+		if runs < 3 {
+			panic("boom")
+		}
+	})
+
+	require.Equal(t, 3, runs, "re-run until a normal return")
+	require.Equal(t, 2.0, testutil.ToFloat64(supervisorPanics.WithLabelValues("test-panicky")))
+	require.Equal(t, 2.0, testutil.ToFloat64(supervisorRestarts.WithLabelValues("test-panicky")))
+}
+
+func TestSuperviseStopsOnCancelDuringBackoff(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		Supervise(ctx, "test-cancelled", log, func(context.Context) { panic("boom") })
+	}()
+
+	time.Sleep(10 * time.Millisecond) // first run panicked, now backing off (1s)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Supervise did not return after cancellation")
+	}
+
+	require.Equal(t, 0.0, testutil.ToFloat64(supervisorRestarts.WithLabelValues("test-cancelled")))
+}
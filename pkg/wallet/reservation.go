@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNonceReserved is returned by NextNonce while the wallet's next nonce is
+// reserved for a transaction that bypasses the mempool (see ReserveNonce).
+var ErrNonceReserved = errors.New("nonce reserved")
+
+// nonceReservation holds back the wallet's own use of nonce until it expires
+// or the node reports it used.
+type nonceReservation struct {
+	nonce uint64
+	until time.Time
+}
+
+// ReserveNonce claims nonce, which must be the wallet's next nonce, for a
+// transaction the caller places into a block itself rather than the mempool
+// (the proposer payment sealed into built payloads). Until the reservation
+// expires at until or the nonce is mined, NextNonce returns ErrNonceReserved
+// and SendAndConfirm waits, so no wallet transaction collides with it. A
+// reservation of an already reserved nonce extends it.
+//
+// release drops the reservation early, for a transaction that will not be
+// used after all; it is a no-op once the reservation was extended or replaced.
+func (w *Wallet) ReserveNonce(ctx context.Context, nonce uint64, until time.Time) (func(), error) {
+	w.reserveMu.Lock()
+	defer w.reserveMu.Unlock()
+
+	next, err := w.nodeNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != nonce {
+		return nil, fmt.Errorf("nonce %d is not the wallet's next nonce %d (wallet transaction in flight)", nonce, next)
+	}
+
+	if r := w.reservation; r != nil && r.nonce == nonce && w.clock.Now().Before(r.until) {
+		if until.After(r.until) {
+			r.until = until
+		}
+
+		return func() {}, nil
+	}
+
+	reservation := &nonceReservation{nonce: nonce, until: until}
+	w.reservation = reservation
+
+	return func() {
+		w.reserveMu.Lock()
+		defer w.reserveMu.Unlock()
+
+		if w.reservation == reservation {
+			w.reservation = nil
+		}
+	}, nil
+}
+
+// checkReservation returns ErrNonceReserved when next is reserved, and drops
+// reservations that expired or whose nonce the node reports used.
+func (w *Wallet) checkReservation(next uint64) error {
+	w.reserveMu.Lock()
+	defer w.reserveMu.Unlock()
+
+	r := w.reservation
+	if r == nil {
+		return nil
+	}
+
+	if next > r.nonce || !w.clock.Now().Before(r.until) {
+		w.reservation = nil
+		return nil
+	}
+
+	if next == r.nonce {
+		return fmt.Errorf("%w: nonce %d held until %s", ErrNonceReserved, r.nonce, r.until.Format(time.RFC3339))
+	}
+
+	return nil
+}
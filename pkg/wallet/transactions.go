@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+const (
+	// txConfirmationDepth is how many blocks (inclusion block included) a
+	// mined transaction needs before it is reported confirmed.
+	txConfirmationDepth = 3
+	// txConfirmationTimeout bounds how long a mined transaction is followed
+	// towards txConfirmationDepth.
+	txConfirmationTimeout = 10 * time.Minute
+	// txHistorySize bounds the transaction history kept for the API.
+	txHistorySize = 100
+)
+
+// TxStatus is a wallet transaction's lifecycle state.
+type TxStatus string
+
+const (
+	TxStatusSubmitted TxStatus = "submitted" // accepted by the node, pending
+	TxStatusMined     TxStatus = "mined"     // included in a block
+	TxStatusConfirmed TxStatus = "confirmed" // txConfirmationDepth blocks deep
+	TxStatusFailed    TxStatus = "failed"    // reverted, rejected or timed out
+	// TxStatusReplaced: the nonce was taken by another transaction or the tx
+	// was dropped; the send is retried under a new hash (a new record).
+	TxStatusReplaced TxStatus = "replaced"
+)
+
+// TxRecord is a wallet transaction and its latest lifecycle state. Every
+// send attempt is its own record; Attempt counts them per send.
+type TxRecord struct {
+	Kind          string   `json:"kind"` // deposit, topup, batch_topup, exit, early_deposit
+	Hash          string   `json:"hash"`
+	Nonce         uint64   `json:"nonce"`
+	To            string   `json:"to"`
+	ValueWei      string   `json:"value_wei"`
+	Attempt       int      `json:"attempt"`
+	Status        TxStatus `json:"status"`
+	BlockNumber   uint64   `json:"block_number,omitempty"`
+	GasUsed       uint64   `json:"gas_used,omitempty"`
+	Confirmations uint64   `json:"confirmations,omitempty"`
+	Error         string   `json:"error,omitempty"`
+	SubmittedAt   int64    `json:"submitted_at"` // unix ms
+	UpdatedAt     int64    `json:"updated_at"`   // unix ms
+}
+
+// SubscribeTransactions subscribes to wallet transaction updates: one event
+// (a copy of the record) per state change.
+func (w *Wallet) SubscribeTransactions(capacity int) *utils.Subscription[*TxRecord] {
+	return w.txDispatch.Subscribe(capacity, false)
+}
+
+// GetTransactions returns the recent wallet transactions, newest first.
+func (w *Wallet) GetTransactions() []TxRecord {
+	w.txHistoryMu.Lock()
+	defer w.txHistoryMu.Unlock()
+
+	records := make([]TxRecord, 0, len(w.txHistory))
+
+	for i := len(w.txHistory) - 1; i >= 0; i-- {
+		records = append(records, *w.txHistory[i])
+	}
+
+	return records
+}
+
+// trackTx adds a submitted transaction's record and fires it.
+func (w *Wallet) trackTx(kind string, tx *types.Transaction, attempt int) *TxRecord {
+	now := time.Now().UnixMilli()
+	record := &TxRecord{
+		Kind:        kind,
+		Hash:        tx.Hash().Hex(),
+		Nonce:       tx.Nonce(),
+		To:          tx.To().Hex(),
+		ValueWei:    tx.Value().String(),
+		Attempt:     attempt,
+		Status:      TxStatusSubmitted,
+		SubmittedAt: now,
+		UpdatedAt:   now,
+	}
+
+	w.txHistoryMu.Lock()
+
+	w.txHistory = append(w.txHistory, record)
+	if len(w.txHistory) > txHistorySize {
+		w.txHistory = w.txHistory[len(w.txHistory)-txHistorySize:]
+	}
+
+	snapshot := *record
+
+	w.txHistoryMu.Unlock()
+
+	w.txDispatch.Fire(&snapshot)
+
+	return record
+}
+
+// updateTx applies update to a tracked record and fires the new state.
+func (w *Wallet) updateTx(record *TxRecord, update func(*TxRecord)) {
+	w.txHistoryMu.Lock()
+
+	update(record)
+	record.UpdatedAt = time.Now().UnixMilli()
+	snapshot := *record
+
+	w.txHistoryMu.Unlock()
+
+	w.txDispatch.Fire(&snapshot)
+}
+
+// followConfirmations reports a mined transaction confirmed once it is
+// txConfirmationDepth blocks deep. A reorg that moves it to another block is
+// reported as mined again; giving up (timeout) leaves it mined.
+func (w *Wallet) followConfirmations(record *TxRecord, txHash common.Hash, blockNumber uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), txConfirmationTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		head, err := w.rpcClient.HeaderByNumber(ctx, nil)
+		if err != nil || head.Number == nil {
+			continue
+		}
+
+		receipt, err := w.rpcClient.GetTransactionReceipt(ctx, txHash)
+		if err != nil || receipt.BlockNumber == nil {
+			continue // reorged out for now; wait for re-inclusion
+		}
+
+		if mined := receipt.BlockNumber.Uint64(); mined != blockNumber {
+			blockNumber = mined
+
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusMined
+				r.BlockNumber = mined
+				r.Confirmations = 0
+			})
+		}
+
+		headNumber := head.Number.Uint64()
+		if headNumber < blockNumber {
+			continue
+		}
+
+		if confirmations := headNumber - blockNumber + 1; confirmations >= w.confirmationDepth {
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusConfirmed
+				r.Confirmations = confirmations
+			})
+
+			w.log.WithFields(logrus.Fields{
+				"hash":          txHash.Hex(),
+				"block_number":  blockNumber,
+				"confirmations": confirmations,
+			}).Debug("Transaction confirmed")
+
+			return
+		}
+	}
+}
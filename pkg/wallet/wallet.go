@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -16,7 +17,9 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/clock"
 	"github.com/ethpandaops/buildoor/pkg/rpc/execution"
+	"github.com/ethpandaops/buildoor/pkg/utils"
 )
 
 // Transaction submission tuning for the multi-instance-safe send path.
@@ -60,13 +63,25 @@ type Wallet struct {
 	chainID    *big.Int
 	mu         sync.Mutex // protects balance
 	txMu       sync.Mutex // serializes this process's transaction submissions
+	reserveMu  sync.Mutex // protects reservation
 	balance    *big.Int
 	log        logrus.FieldLogger
+	clock      clock.Clock // times nonce reservations; chain clock once set
 
 	// Transaction confirmation tuning (defaults from the tx* consts; overridable in tests).
-	pollInterval    time.Duration
-	conflictBackoff time.Duration
-	maxAttempts     int
+	pollInterval      time.Duration
+	conflictBackoff   time.Duration
+	maxAttempts       int
+	confirmationDepth uint64
+
+	// Transaction history and lifecycle events (see transactions.go).
+	// Nonce held back for a transaction placed into a block directly (see
+	// reservation.go); nil when none.
+	reservation *nonceReservation
+
+	txHistory   []*TxRecord
+	txHistoryMu sync.Mutex
+	txDispatch  utils.Dispatcher[*TxRecord]
 }
 
 // NewWallet creates a new wallet from a hex-encoded private key.
@@ -96,17 +111,29 @@ func NewWallet(
 	address := crypto.PubkeyToAddress(privkey.PublicKey)
 
 	return &Wallet{
-		privkey:         privkey,
-		address:         address,
-		rpcClient:       rpcClient,
-		execClient:      rpcClient,
-		log:             walletLog,
-		pollInterval:    txPollInterval,
-		conflictBackoff: txConflictBackoff,
-		maxAttempts:     txMaxAttempts,
+		privkey:           privkey,
+		address:           address,
+		rpcClient:         rpcClient,
+		execClient:        rpcClient,
+		log:               walletLog,
+		clock:             clock.Real(),
+		pollInterval:      txPollInterval,
+		conflictBackoff:   txConflictBackoff,
+		maxAttempts:       txMaxAttempts,
+		confirmationDepth: txConfirmationDepth,
 	}, nil
 }
 
+// SetClock sets the clock nonce reservations expire against. Reservations
+// run until a slot time, so this is the chain clock (scaled under
+// --clock-speedup); the system clock is used until it is set.
+func (w *Wallet) SetClock(clk clock.Clock) {
+	w.reserveMu.Lock()
+	defer w.reserveMu.Unlock()
+
+	w.clock = clk
+}
+
 // Address returns the wallet's Ethereum address.
 func (w *Wallet) Address() common.Address {
 	return w.address
@@ -213,7 +240,7 @@ func (w *Wallet) BuildTransaction(
 	// nonce *below* the latest (confirmed) nonce, which would make us stamp an
 	// already-used nonce and get "nonce too low". The latest nonce is the authoritative
 	// floor, so taking the larger of the two is correct on every client.
-	nonce, err := w.nextNonce(ctx)
+	nonce, err := w.NextNonce(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -237,11 +264,26 @@ func (w *Wallet) BuildTransaction(
 	return tx, nil
 }
 
-// nextNonce returns the next usable nonce by reading the node fresh: the larger of the
+// NextNonce returns the next usable nonce by reading the node fresh: the larger of the
 // pending nonce and the latest (confirmed) nonce. The latest nonce is an authoritative
 // floor that protects against clients (e.g. ethrex) whose pending nonce can lag below
 // it; the pending nonce covers legitimate in-flight mempool txs on correct clients.
-func (w *Wallet) nextNonce(ctx context.Context) (uint64, error) {
+// While that nonce is reserved (see ReserveNonce) it returns ErrNonceReserved.
+func (w *Wallet) NextNonce(ctx context.Context) (uint64, error) {
+	nonce, err := w.nodeNonce(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := w.checkReservation(nonce); err != nil {
+		return 0, err
+	}
+
+	return nonce, nil
+}
+
+// nodeNonce returns max(pending, latest) nonce as reported by the node.
+func (w *Wallet) nodeNonce(ctx context.Context) (uint64, error) {
 	pending, err := w.rpcClient.GetNonce(ctx, w.address)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
@@ -384,8 +426,12 @@ const (
 //
 // txMu serializes this process's own submissions so concurrent lifecycle operations
 // (e.g. the startup deposit and a balance top-up) never collide with each other.
+//
+// Every attempt is tracked under kind (see SubscribeTransactions): submitted,
+// then mined, confirmed, failed or replaced.
 func (w *Wallet) SendAndConfirm(
 	ctx context.Context,
+	kind string,
 	to common.Address,
 	value *big.Int,
 	data []byte,
@@ -417,16 +463,41 @@ func (w *Wallet) SendAndConfirm(
 			}).Info("Transaction sent")
 		}
 
+		record := w.trackTx(kind, signedTx, attempt)
+
 		receipt, outcome, err := w.resolve(ctx, signedTx.Hash(), nonce, sendErr, timeout)
 
 		switch outcome {
 		case outcomeIncluded:
+			blockNumber := receipt.BlockNumber.Uint64()
+
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusMined
+				r.BlockNumber = blockNumber
+				r.GasUsed = receipt.GasUsed
+				r.Error = ""
+			})
+
+			go w.followConfirmations(record, signedTx.Hash(), blockNumber)
+
 			return receipt, nil
 		case outcomeReverted:
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusFailed
+				r.BlockNumber = receipt.BlockNumber.Uint64()
+				r.GasUsed = receipt.GasUsed
+				r.Error = err.Error()
+			})
+
 			return receipt, err
 		case outcomeRetry:
 			lastErr = err
 
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusReplaced
+				r.Error = err.Error()
+			})
+
 			w.log.WithFields(logrus.Fields{
 				"hash":    signedTx.Hash().Hex(),
 				"nonce":   nonce,
@@ -439,9 +510,18 @@ func (w *Wallet) SendAndConfirm(
 
 			continue
 		case outcomeFailed:
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusFailed
+				r.Error = err.Error()
+			})
+
 			return nil, fmt.Errorf("send transaction (nonce %d): %w", nonce, err)
 		case outcomePending:
 			// resolve only returns terminal outcomes; treat as failure defensively.
+			w.updateTx(record, func(r *TxRecord) {
+				r.Status = TxStatusFailed
+			})
+
 			return nil, fmt.Errorf("send transaction (nonce %d): %w", nonce, err)
 		}
 	}
@@ -449,7 +529,9 @@ func (w *Wallet) SendAndConfirm(
 	return nil, fmt.Errorf("transaction failed after %d attempts: %w", w.maxAttempts, lastErr)
 }
 
-// buildAndSign builds a transaction with a fresh nonce and signs it.
+// buildAndSign builds a transaction with a fresh nonce and signs it. While the
+// next nonce is reserved (see ReserveNonce) it waits for the reservation to
+// resolve.
 func (w *Wallet) buildAndSign(
 	ctx context.Context,
 	to common.Address,
@@ -457,12 +539,22 @@ func (w *Wallet) buildAndSign(
 	data []byte,
 	gasLimit uint64,
 ) (*types.Transaction, error) {
-	tx, err := w.BuildTransaction(ctx, to, value, data, gasLimit)
-	if err != nil {
-		return nil, err
-	}
+	for {
+		tx, err := w.BuildTransaction(ctx, to, value, data, gasLimit)
+		if errors.Is(err, ErrNonceReserved) {
+			if waitErr := sleepCtx(ctx, w.pollInterval); waitErr != nil {
+				return nil, waitErr
+			}
 
-	return w.SignTransaction(tx)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return w.SignTransaction(tx)
+	}
 }
 
 // resolve polls node state until it can decide the fate of a sent transaction, without
@@ -520,7 +612,7 @@ func (w *Wallet) resolve(
 			// 3. Our tx is not known. Decide by where the node's next nonce now sits, using
 			//    the same max(pending, confirmed) the build uses so a buggy pending nonce
 			//    (ethrex) doesn't hide that our slot was already consumed.
-			next, nerr := w.nextNonce(ctx)
+			next, nerr := w.NextNonce(ctx)
 			if nerr != nil {
 				continue
 			}
@@ -13,6 +13,9 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/clock"
+	"github.com/ethpandaops/buildoor/pkg/utils"
 )
 
 var errNotFound = errors.New("not found")
@@ -73,7 +76,7 @@ func (f *fakeBackend) SuggestGasTipCap(context.Context) (*big.Int, error) {
 }
 
 func (f *fakeBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
-	return &types.Header{BaseFee: big.NewInt(1_000_000_000)}, nil
+	return &types.Header{BaseFee: big.NewInt(1_000_000_000), Number: big.NewInt(100)}, nil
 }
 
 func (f *fakeBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
@@ -153,13 +156,15 @@ func newTestWallet(t *testing.T, backend walletBackend) *Wallet {
 	log.SetLevel(logrus.PanicLevel)
 
 	return &Wallet{
-		privkey:         priv,
-		address:         crypto.PubkeyToAddress(priv.PublicKey),
-		rpcClient:       backend,
-		log:             log,
-		pollInterval:    time.Millisecond,
-		conflictBackoff: time.Millisecond,
-		maxAttempts:     8,
+		privkey:           priv,
+		address:           crypto.PubkeyToAddress(priv.PublicKey),
+		rpcClient:         backend,
+		log:               log,
+		clock:             clock.Real(),
+		pollInterval:      time.Millisecond,
+		conflictBackoff:   time.Millisecond,
+		maxAttempts:       8,
+		confirmationDepth: 1,
 	}
 }
 
@@ -167,7 +172,7 @@ func sendOnce(t *testing.T, w *Wallet) (*types.Receipt, error) {
 	t.Helper()
 
 	return w.SendAndConfirm(
-		context.Background(), common.Address{}, big.NewInt(1), nil, 21000, 5*time.Second,
+		context.Background(), "test", common.Address{}, big.NewInt(1), nil, 21000, 5*time.Second,
 	)
 }
 
@@ -270,3 +275,131 @@ func TestSendAndConfirmFatalErrorNoRetry(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, 1, backend.sendCalls, "fatal send error must not retry")
 }
+
+// collectTxStatuses drains tx events until want statuses arrived or it times out.
+func collectTxStatuses(t *testing.T, sub *utils.Subscription[*TxRecord], want int) []TxStatus {
+	t.Helper()
+
+	statuses := make([]TxStatus, 0, want)
+	timeout := time.After(5 * time.Second)
+
+	for len(statuses) < want {
+		select {
+		case record := <-sub.Channel():
+			statuses = append(statuses, record.Status)
+		case <-timeout:
+			t.Fatalf("timed out waiting for tx events, got %v", statuses)
+		}
+	}
+
+	return statuses
+}
+
+// TestSendAndConfirmTracksTransaction asserts a send is reported submitted, mined and
+// then confirmed, and kept in the history.
+func TestSendAndConfirmTracksTransaction(t *testing.T) {
+	backend := newFakeBackend()
+	w := newTestWallet(t, backend)
+
+	sub := w.SubscribeTransactions(16)
+	defer sub.Unsubscribe()
+
+	_, err := sendOnce(t, w)
+	require.NoError(t, err)
+
+	require.Equal(t, []TxStatus{TxStatusSubmitted, TxStatusMined, TxStatusConfirmed},
+		collectTxStatuses(t, sub, 3))
+
+	history := w.GetTransactions()
+	require.Len(t, history, 1)
+	require.Equal(t, "test", history[0].Kind)
+	require.Equal(t, TxStatusConfirmed, history[0].Status)
+	require.Equal(t, uint64(100), history[0].BlockNumber)
+	require.Equal(t, uint64(1), history[0].Confirmations)
+}
+
+// TestSendAndConfirmTracksReplacement asserts a displaced attempt is reported replaced
+// and the resubmission tracked as its own record.
+func TestSendAndConfirmTracksReplacement(t *testing.T) {
+	backend := newFakeBackend()
+	backend.displaceFirstN = 1
+	w := newTestWallet(t, backend)
+
+	sub := w.SubscribeTransactions(16)
+	defer sub.Unsubscribe()
+
+	_, err := sendOnce(t, w)
+	require.NoError(t, err)
+
+	require.Equal(t, []TxStatus{
+		TxStatusSubmitted, TxStatusReplaced, TxStatusSubmitted, TxStatusMined, TxStatusConfirmed,
+	}, collectTxStatuses(t, sub, 5))
+
+	history := w.GetTransactions()
+	require.Len(t, history, 2)
+	require.Equal(t, 2, history[0].Attempt)
+	require.Equal(t, TxStatusConfirmed, history[0].Status)
+	require.Equal(t, TxStatusReplaced, history[1].Status)
+}
+
+// TestReserveNonce asserts a reserved nonce holds back the wallet's own
+// transactions until it is mined, and that only the next nonce can be
+// reserved.
+func TestReserveNonce(t *testing.T) {
+	backend := newFakeBackend()
+	backend.pendingNonce = 5
+	backend.confirmedNonce = 5
+	w := newTestWallet(t, backend)
+
+	_, err := w.ReserveNonce(context.Background(), 4, time.Now().Add(time.Minute))
+	require.Error(t, err, "a nonce below the next one is taken")
+
+	_, err = w.ReserveNonce(context.Background(), 5, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	_, err = w.NextNonce(context.Background())
+	require.ErrorIs(t, err, ErrNonceReserved)
+
+	// The sealed block lands: the node's nonce moves past the reservation
+	// while SendAndConfirm waits.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+
+		backend.mu.Lock()
+		backend.pendingNonce = 6
+		backend.confirmedNonce = 6
+		backend.mu.Unlock()
+	}()
+
+	_, err = sendOnce(t, w)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), backend.lastNonce, "the wallet never reuses the reserved nonce")
+}
+
+func TestReserveNonceReleaseAndExpiry(t *testing.T) {
+	backend := newFakeBackend()
+	w := newTestWallet(t, backend)
+
+	clk := clock.NewFake(time.Unix(1_700_000_000, 0))
+	w.SetClock(clk)
+
+	release, err := w.ReserveNonce(context.Background(), 0, clk.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	release()
+
+	nonce, err := w.NextNonce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, nonce, "a released reservation frees the nonce")
+
+	_, err = w.ReserveNonce(context.Background(), 0, clk.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	_, err = w.NextNonce(context.Background())
+	require.ErrorIs(t, err, ErrNonceReserved, "reservations run on the wallet's clock, not wall time")
+
+	clk.Advance(time.Minute)
+
+	_, err = w.NextNonce(context.Background())
+	require.NoError(t, err, "an expired reservation frees the nonce")
+}
@@ -0,0 +1,228 @@
+// Package watchdog verifies the builder's end-to-end liveness once per
+// finished epoch: a payload must have been built for at least one slot the
+// action plan made eligible, and the builder must have evaluated some slot
+// at all. Silently dead event loops otherwise only show up as empty
+// dashboards. A failed check raises a critical alert and optionally
+// reconnects the beacon node event stream.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/epoch_summary"
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// AlertRule is the rule name of the watchdog's alerts.
+const AlertRule = "watchdog:liveness"
+
+// ActionRestartEventStream is the alert action of an event stream restart.
+const ActionRestartEventStream = "restart_event_stream"
+
+// SummarySource provides the finished-epoch summaries (implemented by the
+// epoch summary aggregator).
+type SummarySource interface {
+	SubscribeSummaries(capacity int) *utils.Subscription[*epoch_summary.EpochSummary]
+}
+
+// FrozenPlans provides the slots' frozen action plans (implemented by the
+// plan service).
+type FrozenPlans interface {
+	GetFrozen(slot phase0.Slot) *action_plan.FrozenPlan
+}
+
+// Restarter reconnects a stream (implemented by the beacon event stream).
+type Restarter interface {
+	Restart() error
+}
+
+// Verdict is the liveness check of one finished epoch.
+type Verdict struct {
+	Epoch uint64 `json:"epoch"`
+	// EvaluatedSlots counts the epoch's slots with a build decision (frozen
+	// action plan); EligibleSlots the subset that was to be built.
+	EvaluatedSlots int `json:"evaluated_slots"`
+	EligibleSlots  int `json:"eligible_slots"`
+	SlotsBuilt     int `json:"slots_built"`
+
+	Live      bool   `json:"live"`
+	Reason    string `json:"reason,omitempty"`
+	Restarted bool   `json:"restarted,omitempty"`
+}
+
+// Watchdog checks every finished epoch's summary for liveness.
+type Watchdog struct {
+	cfg       *config.Config
+	chainSvc  chain.Service
+	summaries SummarySource
+	plans     FrozenPlans
+	events    Restarter        // nil = no restarts
+	alerts    *alerting.Engine // nil = failures are only logged
+
+	mu         sync.Mutex
+	startEpoch phase0.Epoch // the epoch in progress at start is not checked
+	failing    bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    logrus.FieldLogger
+}
+
+// NewWatchdog creates the liveness watchdog. events is only used with
+// cfg.Watchdog.RestartEventStream; events and alerts may be nil.
+func NewWatchdog(cfg *config.Config, chainSvc chain.Service, summaries SummarySource, plans FrozenPlans,
+	events Restarter, alerts *alerting.Engine, log logrus.FieldLogger) *Watchdog {
+	return &Watchdog{
+		cfg:       cfg,
+		chainSvc:  chainSvc,
+		summaries: summaries,
+		plans:     plans,
+		events:    events,
+		alerts:    alerts,
+		log:       log.WithField("component", "watchdog"),
+	}
+}
+
+// Start subscribes to the epoch summaries.
+func (w *Watchdog) Start(ctx context.Context) error {
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.startEpoch = w.chainSvc.GetCurrentEpoch()
+
+	sub := w.summaries.SubscribeSummaries(8)
+
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case <-w.ctx.Done():
+				return
+			case summary, ok := <-sub.Channel():
+				if !ok {
+					return
+				}
+
+				w.Check(summary)
+			}
+		}
+	}()
+
+	w.log.WithField("restart_event_stream", w.cfg.Watchdog.RestartEventStream).Info("Liveness watchdog started")
+
+	return nil
+}
+
+// Stop terminates the watchdog loop.
+func (w *Watchdog) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+
+	w.wg.Wait()
+}
+
+// Check evaluates one finished epoch and raises (or resolves) the liveness
+// alert. Epochs that had not fully elapsed since start are ignored; nil is
+// returned for them.
+func (w *Watchdog) Check(summary *epoch_summary.EpochSummary) *Verdict {
+	if phase0.Epoch(summary.Epoch) <= w.startEpoch {
+		return nil
+	}
+
+	verdict := &Verdict{Epoch: summary.Epoch, SlotsBuilt: summary.SlotsBuilt, Live: true}
+
+	for slot := summary.StartSlot; slot <= summary.EndSlot; slot++ {
+		frozen := w.plans.GetFrozen(phase0.Slot(slot))
+		if frozen == nil {
+			continue
+		}
+
+		verdict.EvaluatedSlots++
+
+		if frozen.Build != nil && frozen.Build.Build {
+			verdict.EligibleSlots++
+		}
+	}
+
+	switch {
+	case verdict.EligibleSlots > 0 && verdict.SlotsBuilt == 0:
+		verdict.Live = false
+		verdict.Reason = fmt.Sprintf("no payload built for any of %d eligible slots", verdict.EligibleSlots)
+	case verdict.EvaluatedSlots == 0 && (w.cfg.EPBSEnabled || w.cfg.BuilderAPIEnabled):
+		verdict.Live = false
+		verdict.Reason = "no slot was evaluated (no payload attributes received)"
+	}
+
+	w.mu.Lock()
+	wasFailing := w.failing
+	w.failing = !verdict.Live
+	w.mu.Unlock()
+
+	switch {
+	case !verdict.Live:
+		w.fail(verdict, summary)
+	case wasFailing:
+		w.log.WithField("epoch", verdict.Epoch).Info("Liveness restored")
+		w.publish(verdict, summary, alerting.AlertResolved, "liveness restored")
+	}
+
+	return verdict
+}
+
+// fail restarts the event stream (when configured) and raises the alert.
+func (w *Watchdog) fail(verdict *Verdict, summary *epoch_summary.EpochSummary) {
+	message := fmt.Sprintf("epoch %d failed the liveness check: %s", verdict.Epoch, verdict.Reason)
+
+	if w.cfg.Watchdog.RestartEventStream && w.events != nil {
+		if err := w.events.Restart(); err != nil {
+			message += fmt.Sprintf(" (event stream restart failed: %v)", err)
+		} else {
+			verdict.Restarted = true
+			message += "; event stream restarted"
+		}
+	}
+
+	w.log.WithFields(logrus.Fields{
+		"epoch":    verdict.Epoch,
+		"eligible": verdict.EligibleSlots,
+		"built":    verdict.SlotsBuilt,
+	}).Error(message)
+
+	w.publish(verdict, summary, alerting.AlertFiring, message)
+}
+
+func (w *Watchdog) publish(verdict *Verdict, summary *epoch_summary.EpochSummary, state, message string) {
+	if w.alerts == nil {
+		return
+	}
+
+	alert := &alerting.Alert{
+		Rule:      AlertRule,
+		Severity:  alerting.SeverityCritical,
+		State:     state,
+		Value:     float64(verdict.SlotsBuilt),
+		Slot:      summary.EndSlot,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+
+	if verdict.Restarted {
+		alert.Actions = []string{ActionRestartEventStream}
+	}
+
+	w.alerts.Publish(alert)
+}
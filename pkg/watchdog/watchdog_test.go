@@ -0,0 +1,101 @@
+package watchdog
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/epoch_summary"
+)
+
+type stubChainService struct {
+	chain.Service
+}
+
+func (stubChainService) GetCurrentEpoch() phase0.Epoch { return 1 }
+
+type stubPlans map[phase0.Slot]*action_plan.FrozenPlan
+
+func (p stubPlans) GetFrozen(slot phase0.Slot) *action_plan.FrozenPlan { return p[slot] }
+
+type stubRestarter struct {
+	restarts int
+	err      error
+}
+
+func (r *stubRestarter) Restart() error {
+	r.restarts++
+	return r.err
+}
+
+func frozen(build bool) *action_plan.FrozenPlan {
+	return &action_plan.FrozenPlan{Build: &action_plan.ResolvedBuildSettings{Build: build}}
+}
+
+func TestCheck(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	cfg := config.DefaultConfig()
+	cfg.EPBSEnabled = true
+	cfg.Watchdog.RestartEventStream = true
+
+	plans := stubPlans{}
+	restarter := &stubRestarter{}
+
+	w := NewWatchdog(cfg, stubChainService{}, nil, plans, restarter, nil, log)
+	w.startEpoch = 1
+
+	summary := func(epoch uint64, built int) *epoch_summary.EpochSummary {
+		return &epoch_summary.EpochSummary{Epoch: epoch, StartSlot: epoch * 32, EndSlot: epoch*32 + 31, SlotsBuilt: built}
+	}
+
+	require.Nil(t, w.Check(summary(1, 0)), "the epoch in progress at start is not checked")
+
+	// Epoch 2: eligible slots, nothing built.
+	plans[64], plans[65], plans[66] = frozen(true), frozen(true), frozen(false)
+
+	verdict := w.Check(summary(2, 0))
+	require.False(t, verdict.Live)
+	require.Equal(t, 3, verdict.EvaluatedSlots)
+	require.Equal(t, 2, verdict.EligibleSlots)
+	require.Contains(t, verdict.Reason, "2 eligible slots")
+	require.True(t, verdict.Restarted)
+	require.Equal(t, 1, restarter.restarts)
+
+	// Epoch 3: nothing evaluated at all.
+	verdict = w.Check(summary(3, 0))
+	require.False(t, verdict.Live)
+	require.Contains(t, verdict.Reason, "no slot was evaluated")
+
+	// Epoch 4: built.
+	plans[128] = frozen(true)
+
+	verdict = w.Check(summary(4, 1))
+	require.True(t, verdict.Live)
+	require.False(t, w.failing)
+
+	// Epoch 5: an epoch of skipped slots only (inactive schedule) is live.
+	plans[160] = frozen(false)
+	require.True(t, w.Check(summary(5, 0)).Live)
+
+	// Epoch 6: with every consumer disabled an idle epoch is live.
+	cfg.EPBSEnabled = false
+	require.True(t, w.Check(summary(6, 0)).Live)
+
+	// A failed restart is reported, not retried.
+	cfg.EPBSEnabled = true
+	restarter.err = errors.New("boom")
+
+	verdict = w.Check(summary(7, 0))
+	require.False(t, verdict.Live)
+	require.False(t, verdict.Restarted)
+	require.Equal(t, 3, restarter.restarts)
+}
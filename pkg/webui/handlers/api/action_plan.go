@@ -249,10 +249,13 @@ type UpdateSettingsRequest map[string]json.RawMessage
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param If-Match header string false "Settings ETag the edit is based on (from GET /api/config)"
 // @Param request body object true "Map of settings key paths to values"
 // @Success 200 {object} map[string]string "Success"
+// @Header 200 {string} ETag "New settings version"
 // @Failure 400 {object} map[string]string "Unknown key or invalid value"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 412 {object} map[string]string "Settings changed since If-Match"
 // @Router /api/config/settings [post]
 func (h *APIHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
 	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
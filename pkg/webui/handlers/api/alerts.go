@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+)
+
+// AlertsResponse is the alerting rule status list.
+type AlertsResponse struct {
+	Rules []alerting.RuleStatus `json:"rules"`
+}
+
+// GetAlerts godoc
+// @Id getAlerts
+// @Summary Get alerting rule status
+// @Tags Stats
+// @Description Returns every configured alerting rule (from --alert-rules-file) with its
+// @Description current state (ok, firing or no_data), last evaluated value and fire count.
+// @Description State transitions are also emitted as alert SSE events.
+// @Produce json
+// @Success 200 {object} AlertsResponse
+// @Failure 503 {object} map[string]string "Alerting not configured"
+// @Router /api/buildoor/alerts [get]
+func (h *APIHandler) GetAlerts(w http.ResponseWriter, _ *http.Request) {
+	if h.alerts == nil {
+		writeError(w, http.StatusServiceUnavailable, "alerting not configured")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, AlertsResponse{Rules: h.alerts.GetStatuses()})
+}
@@ -3,13 +3,21 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/ethpandaops/buildoor/pkg/builderapi/legacy"
 	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/lifecycle"
 	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/preflight"
+	"github.com/ethpandaops/buildoor/pkg/probe"
+	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
+	"github.com/ethpandaops/buildoor/pkg/wallet"
 	"github.com/ethpandaops/buildoor/version"
 )
 
@@ -28,6 +36,18 @@ type StatusResponse struct {
 	WalletBalance     string `json:"wallet_balance_wei,omitempty"`
 	DepositEpoch      uint64 `json:"deposit_epoch,omitempty"`
 	WithdrawableEpoch uint64 `json:"withdrawable_epoch,omitempty"`
+
+	// EventStreams is the health of each beacon node SSE topic stream.
+	EventStreams []beacon.TopicStats `json:"event_streams,omitempty"`
+
+	// BeaconNodes is the health and failover score of each beacon node when
+	// fallback nodes are configured.
+	BeaconNodes []beacon.NodeStatus `json:"beacon_nodes,omitempty"`
+
+	// Degraded is set when the startup capability check disabled features
+	// the beacon node lacks the topics or endpoints for (see Degradations).
+	Degraded     bool                `json:"degraded"`
+	Degradations []probe.Degradation `json:"degradations,omitempty"`
 }
 
 // StatsResponse is the response for the stats endpoint.
@@ -40,6 +60,27 @@ type StatsResponse struct {
 	RevealsSuccess uint64 `json:"reveals_success"`
 	RevealsFailed  uint64 `json:"reveals_failed"`
 	RevealsSkipped uint64 `json:"reveals_skipped"`
+	// Share of reveals_skipped withheld because our bid was never confirmed
+	// included (reveal.require_inclusion)
+	RevealsSkippedNotIncluded uint64 `json:"reveals_skipped_not_included"`
+	// Reveals deliberately withheld / delayed by reveal chaos
+	RevealsChaosWithheld uint64 `json:"reveals_chaos_withheld"`
+	RevealsChaosDelayed  uint64 `json:"reveals_chaos_delayed"`
+	// Canary mode: whether it is on and how many of the submitted bids were
+	// priced at the canary value
+	CanaryMode          bool   `json:"canary_mode"`
+	CanaryBidsSubmitted uint64 `json:"canary_bids_submitted"`
+	// Test-only Builder API value boost in percent of the real bid value
+	// (0 = off)
+	ValueBoostPct uint64 `json:"value_boost_pct"`
+	// Builds whose payload_attributes withdrawals diverged from the beacon
+	// node's expected withdrawals
+	WithdrawalMismatches uint64 `json:"withdrawal_mismatches"`
+	// Payloads withdrawn because the EL head moved off their parent before
+	// the new payload_attributes arrived (--el-ws-rpc)
+	ELHeadWithdrawals uint64 `json:"el_head_withdrawals"`
+	// Payload races won per engine API endpoint (--el-engine-race-apis)
+	EngineWins map[string]uint64 `json:"engine_wins,omitempty"`
 	// Builder API stats
 	BuilderAPIHeadersRequested     uint64 `json:"builder_api_headers_requested"`
 	BuilderAPIBlocksPublished      uint64 `json:"builder_api_blocks_published"`
@@ -65,6 +106,12 @@ type UpdateEPBSRequest struct {
 	BidInterval       *int64  `json:"bid_interval,omitempty"`
 	PayloadBuildDelay *int64  `json:"payload_build_delay,omitempty"`
 	BidSubsidy        *uint64 `json:"bid_subsidy,omitempty"`
+	// BidStrategy is one of fixed, linear, counter-bid, last-moment-snipe,
+	// random-walk; the gwei fields are the strategies' parameters.
+	BidStrategy        *string `json:"bid_strategy,omitempty"`
+	CounterBidStepGwei *uint64 `json:"counter_bid_step_gwei,omitempty"`
+	CounterBidMaxGwei  *uint64 `json:"counter_bid_max_gwei,omitempty"`
+	RandomWalkStepGwei *uint64 `json:"random_walk_step_gwei,omitempty"`
 }
 
 // UpdateBuilderConfigRequest is the request for updating shared builder config.
@@ -94,6 +141,12 @@ type LifecycleStatusResponse struct {
 	PendingPayments   uint64 `json:"pending_payments_gwei"`
 	DepositEpoch      uint64 `json:"deposit_epoch"`
 	WithdrawableEpoch uint64 `json:"withdrawable_epoch"`
+	ReadOnly          bool   `json:"read_only"` // out-of-band registration, tracked only
+}
+
+// LifecycleTransactionsResponse is the response for the wallet transaction history.
+type LifecycleTransactionsResponse struct {
+	Transactions []wallet.TxRecord `json:"transactions"`
 }
 
 // GetVersion godoc
@@ -114,17 +167,69 @@ func (h *APIHandler) GetVersion(w http.ResponseWriter, _ *http.Request) {
 // @Summary Get builder status
 // @Tags Status
 // @Description Returns the current builder status including running state, current slot,
-// @Description builder index and public key.
+// @Description builder index and public key, and the health of each beacon node event stream
+// @Description topic (event/parse-failure/reconnect counts, last event time). degraded is set
+// @Description when the startup capability check disabled features the beacon node lacks the
+// @Description topics or endpoints for; degradations lists them with their effect. With
+// @Description fallback beacon nodes, beacon_nodes reports each node's health and failover score.
 // @Produce json
 // @Success 200 {object} StatusResponse "Success"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/status [get]
 func (h *APIHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.buildStatus(r.Context()))
+}
+
+// SetDegradations sets the features the startup capability check disabled,
+// reported by the status endpoint.
+func (h *APIHandler) SetDegradations(degradations probe.Degradations) {
+	h.degradations = degradations
+}
+
+// SetPreflight sets the startup report served by the preflight endpoint.
+func (h *APIHandler) SetPreflight(report *preflight.Report) {
+	h.preflight = report
+}
+
+// GetPreflight godoc
+// @Id getPreflight
+// @Summary Get the startup preflight report
+// @Tags Status
+// @Description Returns the structured report assembled at startup once every service was wired:
+// @Description detected fork, spec preset and config name, the builder's registration, wallet
+// @Description balances, the enabled services, the effective slot timing profile (ms relative to
+// @Description the slot start) and warnings (degraded beacon node features, unregistered builder,
+// @Description empty wallets, inconsistent timing). It is a startup snapshot; see /api/status for
+// @Description live state.
+// @Produce json
+// @Success 200 {object} preflight.Report "Success"
+// @Failure 503 {object} map[string]string "Report not available"
+// @Router /api/status/preflight [get]
+func (h *APIHandler) GetPreflight(w http.ResponseWriter, _ *http.Request) {
+	if h.preflight == nil {
+		writeError(w, http.StatusServiceUnavailable, "preflight report not available")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.preflight)
+}
+
+// buildStatus assembles the builder status (shared by GetStatus and the admin
+// JSON-RPC buildoor_status method).
+func (h *APIHandler) buildStatus(ctx context.Context) StatusResponse {
 	resp := StatusResponse{
 		Running:     true,
 		CurrentSlot: uint64(h.builderSvc.GetCurrentSlot()),
 	}
 
+	if clClient := h.builderSvc.GetCLClient(); clClient != nil && clClient.Events() != nil {
+		resp.EventStreams = clClient.Events().Stats()
+		resp.BeaconNodes = clClient.Nodes()
+	}
+
+	resp.Degraded = len(h.degradations) > 0
+	resp.Degradations = h.degradations
+
 	// Get builder identity and pending payments from ePBS service
 	if h.epbsSvc != nil {
 		resp.BuilderIndex = h.epbsSvc.GetBuilderIndex()
@@ -159,13 +264,13 @@ func (h *APIHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 		if wallet := h.lifecycleMgr.GetWallet(); wallet != nil {
 			resp.WalletAddress = wallet.Address().Hex()
 
-			if balance, err := wallet.GetBalance(r.Context()); err == nil && balance != nil {
+			if balance, err := wallet.GetBalance(ctx); err == nil && balance != nil {
 				resp.WalletBalance = balance.String()
 			}
 		}
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	return resp
 }
 
 // GetStats godoc
@@ -179,6 +284,12 @@ func (h *APIHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/stats [get]
 func (h *APIHandler) GetStats(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.buildStats())
+}
+
+// buildStats assembles the builder statistics (shared by GetStats and the
+// admin JSON-RPC buildoor_status method).
+func (h *APIHandler) buildStats() StatsResponse {
 	stats := h.builderSvc.GetStats()
 
 	resp := StatsResponse{
@@ -190,6 +301,19 @@ func (h *APIHandler) GetStats(w http.ResponseWriter, _ *http.Request) {
 		RevealsSuccess: stats.RevealsSuccess,
 		RevealsFailed:  stats.RevealsFailed,
 		RevealsSkipped: stats.RevealsSkipped,
+
+		RevealsSkippedNotIncluded: stats.RevealsSkippedNotIncluded,
+
+		RevealsChaosWithheld: stats.RevealsChaosWithheld,
+		RevealsChaosDelayed:  stats.RevealsChaosDelayed,
+
+		CanaryMode:          h.builderSvc.GetConfig().Canary.Enabled,
+		CanaryBidsSubmitted: stats.CanaryBidsSubmitted,
+		ValueBoostPct:       h.builderSvc.GetConfig().BuilderAPI.ValueBoostPct,
+
+		WithdrawalMismatches: stats.WithdrawalMismatches,
+		ELHeadWithdrawals:    stats.ELHeadWithdrawals,
+		EngineWins:           stats.EngineWins,
 	}
 
 	if h.builderAPISvc != nil {
@@ -199,7 +323,7 @@ func (h *APIHandler) GetStats(w http.ResponseWriter, _ *http.Request) {
 		resp.BuilderAPIRegisteredValidators = apiStats.ValidatorCount
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	return resp
 }
 
 // GetConfig godoc
@@ -209,12 +333,19 @@ func (h *APIHandler) GetStats(w http.ResponseWriter, _ *http.Request) {
 // @Description Returns the buildoor configuration in use. Sensitive fields (builder key, wallet key, JWT secret) are redacted.
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Success"
+// @Header 200 {string} ETag "Settings version, sent back as If-Match on config updates"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/config [get]
 func (h *APIHandler) GetConfig(w http.ResponseWriter, _ *http.Request) {
 	cfg := h.builderSvc.GetConfig()
 	// Redact sensitive fields before returning
 	out := configToMap(cfg)
+
+	// The ETag is the settings version; send it back as If-Match on updates.
+	if h.settingsSvc != nil {
+		w.Header().Set("ETag", configETag(h.settingsSvc.Version()))
+	}
+
 	writeJSON(w, http.StatusOK, out)
 }
 
@@ -227,10 +358,13 @@ func (h *APIHandler) GetConfig(w http.ResponseWriter, _ *http.Request) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param If-Match header string false "Settings ETag the edit is based on (from GET /api/config)"
 // @Param request body UpdateScheduleRequest true "Schedule configuration"
 // @Success 200 {object} map[string]string "Success"
+// @Header 200 {string} ETag "New settings version"
 // @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 412 {object} map[string]string "Settings changed since If-Match"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/config/schedule [post]
 func (h *APIHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
@@ -279,10 +413,13 @@ func (h *APIHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param If-Match header string false "Settings ETag the edit is based on (from GET /api/config)"
 // @Param request body UpdateEPBSRequest true "EPBS configuration"
 // @Success 200 {object} map[string]string "Success"
+// @Header 200 {string} ETag "New settings version"
 // @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 412 {object} map[string]string "Settings changed since If-Match"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/config/epbs [post]
 func (h *APIHandler) UpdateEPBS(w http.ResponseWriter, r *http.Request) {
@@ -338,6 +475,27 @@ func (h *APIHandler) UpdateEPBS(w http.ResponseWriter, r *http.Request) {
 		updates[config.KeyEPBSBidSubsidy] = mustJSON(*req.BidSubsidy)
 	}
 
+	if req.BidStrategy != nil {
+		if !config.IsBidStrategy(*req.BidStrategy) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown bid strategy %q", *req.BidStrategy))
+			return
+		}
+
+		updates[config.KeyEPBSBidStrategy] = mustJSON(*req.BidStrategy)
+	}
+
+	if req.CounterBidStepGwei != nil {
+		updates[config.KeyEPBSCounterBidStep] = mustJSON(*req.CounterBidStepGwei)
+	}
+
+	if req.CounterBidMaxGwei != nil {
+		updates[config.KeyEPBSCounterBidMax] = mustJSON(*req.CounterBidMaxGwei)
+	}
+
+	if req.RandomWalkStepGwei != nil {
+		updates[config.KeyEPBSRandomWalkStep] = mustJSON(*req.RandomWalkStepGwei)
+	}
+
 	if !h.applySettings(w, r, token, "config.epbs", req, updates) {
 		return
 	}
@@ -370,6 +528,7 @@ func (h *APIHandler) GetLifecycleStatus(w http.ResponseWriter, _ *http.Request)
 		Balance:           state.Balance,
 		DepositEpoch:      state.DepositEpoch,
 		WithdrawableEpoch: state.WithdrawableEpoch,
+		ReadOnly:          h.lifecycleMgr.IsReadOnly(),
 	}
 
 	// Get pending payments from the shared payment tracker
@@ -398,6 +557,7 @@ func (h *APIHandler) GetLifecycleStatus(w http.ResponseWriter, _ *http.Request)
 // @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "Lifecycle management not enabled"
+// @Failure 409 {object} map[string]string "Lifecycle is read-only"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/lifecycle/deposit [post]
 func (h *APIHandler) PostDeposit(w http.ResponseWriter, r *http.Request) {
@@ -423,7 +583,7 @@ func (h *APIHandler) PostDeposit(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.lifecycleMgr.EnsureBuilderRegistered(context.Background()); err != nil {
 		h.audit(r, token, "lifecycle.deposit", "", req, "error: "+err.Error())
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeError(w, lifecycleErrorStatus(err), err.Error())
 
 		return
 	}
@@ -438,13 +598,18 @@ func (h *APIHandler) PostDeposit(w http.ResponseWriter, r *http.Request) {
 // @Summary Trigger balance top-up
 // @Tags Lifecycle
 // @Description Checks the builder balance and initiates a top-up if needed based on
-// @Description configured thresholds. Requires authentication.
+// @Description configured thresholds. With amounts_gwei, tops up those amounts instead
+// @Description (one transaction via the deposit batching contract when configured).
+// @Description Requires authentication.
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param request body object{amounts_gwei=[]uint64} false "Explicit top-up amounts in gwei"
 // @Success 200 {object} map[string]string "Success"
+// @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "Lifecycle management not enabled"
+// @Failure 409 {object} map[string]string "Lifecycle is read-only"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/lifecycle/topup [post]
 func (h *APIHandler) PostTopup(w http.ResponseWriter, r *http.Request) {
@@ -459,31 +624,128 @@ func (h *APIHandler) PostTopup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.lifecycleMgr.CheckAndTopup(context.Background()); err != nil {
-		h.audit(r, token, "lifecycle.topup", "", nil, "error: "+err.Error())
-		writeError(w, http.StatusInternalServerError, err.Error())
+	var req struct {
+		Amounts []uint64 `json:"amounts_gwei"`
+	}
+
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	for _, amount := range req.Amounts {
+		if amount == 0 {
+			writeError(w, http.StatusBadRequest, "top-up amounts must be positive")
+			return
+		}
+	}
+
+	var err error
+	if len(req.Amounts) > 0 {
+		err = h.lifecycleMgr.Topup(context.Background(), req.Amounts)
+	} else {
+		err = h.lifecycleMgr.CheckAndTopup(context.Background())
+	}
+
+	if err != nil {
+		h.audit(r, token, "lifecycle.topup", "", req, "error: "+err.Error())
+		writeError(w, lifecycleErrorStatus(err), err.Error())
 
 		return
 	}
 
-	h.audit(r, token, "lifecycle.topup", "", nil, "ok")
+	h.audit(r, token, "lifecycle.topup", "", req, "ok")
 
 	writeJSON(w, http.StatusOK, map[string]string{"status": "topup initiated"})
 }
 
+// GetLifecycleTransactions godoc
+// @Id getLifecycleTransactions
+// @Summary Get wallet transactions
+// @Tags Lifecycle
+// @Description Returns the recent transactions sent by the builder wallet (deposits,
+// @Description top-ups, exits), newest first, with their lifecycle state: submitted,
+// @Description mined, confirmed, failed or replaced. Updates stream as wallet_tx events.
+// @Produce json
+// @Success 200 {object} LifecycleTransactionsResponse "Success"
+// @Failure 404 {object} map[string]string "Lifecycle management not enabled"
+// @Router /api/lifecycle/transactions [get]
+func (h *APIHandler) GetLifecycleTransactions(w http.ResponseWriter, _ *http.Request) {
+	if h.lifecycleMgr == nil || h.lifecycleMgr.GetWallet() == nil {
+		writeError(w, http.StatusNotFound, "lifecycle management not enabled")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, LifecycleTransactionsResponse{
+		Transactions: h.lifecycleMgr.GetWallet().GetTransactions(),
+	})
+}
+
+// GetDepositPreview godoc
+// @Id getDepositPreview
+// @Summary Preview a deposit or top-up
+// @Tags Lifecycle
+// @Description Estimates a deposit (unregistered builder) or top-ups without sending
+// @Description anything: queue fee, gas estimate and cost, total cost against the wallet
+// @Description balance, resulting builder balance and (registrations) the estimated
+// @Description activation epoch. Several amounts preview a batched top-up when a
+// @Description deposit batching contract is configured.
+// @Produce json
+// @Param amounts query string false "Comma-separated amounts in gwei (default: the configured deposit/top-up amount)"
+// @Success 200 {object} lifecycle.DepositPreview "Success"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Lifecycle management not enabled"
+// @Failure 409 {object} map[string]string "Lifecycle is read-only"
+// @Failure 500 {object} map[string]string "Server Error"
+// @Router /api/lifecycle/deposit/preview [get]
+func (h *APIHandler) GetDepositPreview(w http.ResponseWriter, r *http.Request) {
+	if h.lifecycleMgr == nil {
+		writeError(w, http.StatusNotFound, "lifecycle management not enabled")
+		return
+	}
+
+	var amounts []uint64
+
+	if raw := r.URL.Query().Get("amounts"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			amount, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+			if err != nil || amount == 0 {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid amount %q", part))
+				return
+			}
+
+			amounts = append(amounts, amount)
+		}
+	}
+
+	preview, err := h.lifecycleMgr.PreviewDeposit(r.Context(), amounts)
+	if err != nil {
+		writeError(w, lifecycleErrorStatus(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, preview)
+}
+
 // PostExit godoc
 // @Id postExit
 // @Summary Trigger voluntary exit
 // @Tags Lifecycle
 // @Description Initiates a voluntary exit for the builder. This will begin the withdrawal
 // @Description process and the builder will stop being eligible for block building after
-// @Description the exit is processed. Requires authentication.
+// @Description the exit is processed. Refused while won bids are unrevealed or bids are
+// @Description still in play (409 with the blockers) unless force=true. Requires authentication.
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
+// @Param force query bool false "Exit despite outstanding payments and bids"
 // @Success 200 {object} map[string]string "Success"
+// @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "Lifecycle management not enabled"
+// @Failure 409 {object} map[string]string "Lifecycle is read-only or exit blocked"
 // @Failure 500 {object} map[string]string "Server Error"
 // @Router /api/lifecycle/exit [post]
 func (h *APIHandler) PostExit(w http.ResponseWriter, r *http.Request) {
@@ -498,18 +760,54 @@ func (h *APIHandler) PostExit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.lifecycleMgr.InitiateExit(context.Background()); err != nil {
-		h.audit(r, token, "lifecycle.exit", "", nil, "error: "+err.Error())
-		writeError(w, http.StatusInternalServerError, err.Error())
+	force := false
+
+	if raw := r.URL.Query().Get("force"); raw != "" {
+		var err error
+
+		force, err = strconv.ParseBool(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid force: "+raw)
+			return
+		}
+	}
+
+	var detail any
+	if force {
+		detail = map[string]bool{"force": true}
+	}
+
+	if err := h.lifecycleMgr.InitiateExit(context.Background(), force); err != nil {
+		h.audit(r, token, "lifecycle.exit", "", detail, "error: "+err.Error())
+
+		if errors.Is(err, lifecycle.ErrExitBlocked) {
+			writeJSON(w, http.StatusConflict, map[string]any{
+				"error":    err.Error(),
+				"blockers": h.lifecycleMgr.ExitBlockers(),
+			})
+
+			return
+		}
+
+		writeError(w, lifecycleErrorStatus(err), err.Error())
 
 		return
 	}
 
-	h.audit(r, token, "lifecycle.exit", "", nil, "ok")
+	h.audit(r, token, "lifecycle.exit", "", detail, "ok")
 
 	writeJSON(w, http.StatusOK, map[string]string{"status": "exit initiated"})
 }
 
+// lifecycleErrorStatus maps a lifecycle action error to its HTTP status.
+func lifecycleErrorStatus(err error) int {
+	if errors.Is(err, lifecycle.ErrReadOnly) {
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
 // GetValidators godoc
 // @Id getValidators
 // @Summary List registered validators
@@ -525,6 +823,9 @@ type ValidatorRegistrationResponse struct {
 	FeeRecipient string `json:"fee_recipient"` // Hex-encoded Ethereum address
 	GasLimit     uint64 `json:"gas_limit"`     // Gas limit for blocks
 	Timestamp    uint64 `json:"timestamp"`     // Unix timestamp
+	// Source is where the registration came from: "local" or the URL of the
+	// relay it was backfilled from (omitted without the relay backfill).
+	Source string `json:"source,omitempty"`
 }
 
 // GetValidatorsResponse is the response for GetValidators.
@@ -532,11 +833,18 @@ type GetValidatorsResponse struct {
 	Validators []ValidatorRegistrationResponse `json:"validators"`
 }
 
+// SetRegistrationBackfill sets the relay registration backfill (nil when
+// disabled).
+func (h *APIHandler) SetRegistrationBackfill(backfill *legacy.RegistrationBackfill) {
+	h.regBackfill = backfill
+}
+
 // GetValidators godoc
 // @Id getValidators
 // @Summary List registered validators
 // @Tags Buildoor
-// @Description Returns the list of validators registered via the Builder API (fee recipient preferences). Not paginated.
+// @Description Returns the list of validators registered via the Builder API (fee recipient preferences),
+// @Description including registrations backfilled from relays (marked by source). Not paginated.
 // @Produce json
 // @Success 200 {object} GetValidatorsResponse "Success"
 // @Failure 500 {object} map[string]string "Server Error"
@@ -552,12 +860,16 @@ func (h *APIHandler) GetValidators(w http.ResponseWriter, _ *http.Request) {
 		if reg.Message == nil {
 			continue
 		}
-		formatted = append(formatted, ValidatorRegistrationResponse{
+		entry := ValidatorRegistrationResponse{
 			Pubkey:       fmt.Sprintf("%#x", reg.Message.Pubkey),
 			FeeRecipient: fmt.Sprintf("%#x", reg.Message.FeeRecipient),
 			GasLimit:     reg.Message.GasLimit,
 			Timestamp:    uint64(reg.Message.Timestamp.Unix()),
-		})
+		}
+		if h.regBackfill != nil {
+			entry.Source = h.regBackfill.Source(reg.Message.Pubkey)
+		}
+		formatted = append(formatted, entry)
 	}
 	writeJSON(w, http.StatusOK, GetValidatorsResponse{Validators: formatted})
 }
@@ -721,37 +1033,44 @@ func (h *APIHandler) ToggleServices(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(updates) > 0 {
-		if err := h.settingsSvc.SetMany(updates, actorFromToken(token)); err != nil {
-			h.audit(r, token, "services.toggle", "", req, "error: "+err.Error())
-			writeError(w, http.StatusBadRequest, err.Error())
-
+		if !h.updateSettings(w, r, token, "services.toggle", req, updates) {
 			return
 		}
+	} else {
+		h.audit(r, token, "services.toggle", "", req, "ok")
 	}
 
-	h.audit(r, token, "services.toggle", "", req, "ok")
-
 	// Broadcast updated status to all connected clients
 	if h.eventStreamMgr != nil {
 		h.eventStreamMgr.BroadcastServiceStatus()
 	}
 
 	// Return current status
-	regState := "unknown"
+	writeJSON(w, http.StatusOK, h.serviceStatus())
+}
+
+// serviceStatus reports the availability and enabled state of the toggleable
+// services.
+func (h *APIHandler) serviceStatus() ServiceStatusEvent {
+	regState, identityErr, bidHold := "unknown", "", ""
 	if h.epbsSvc != nil {
 		regState = p2p_bidder.RegistrationStateName(h.epbsSvc.GetRegistrationState())
+		identityErr = h.epbsSvc.IdentityError()
+		bidHold = h.epbsSvc.BidHold()
 	}
 
-	status := ServiceStatusEvent{
+	return ServiceStatusEvent{
 		EPBSAvailable:         h.epbsSvc != nil,
 		EPBSEnabled:           h.epbsSvc != nil && h.epbsSvc.IsEnabled(),
 		EPBSRegistrationState: regState,
+		EPBSIdentityError:     identityErr,
+		EPBSBidHold:           bidHold,
 		BuilderAPIAvailable:   h.builderAPISvc != nil,
 		BuilderAPIEnabled:     h.builderAPISvc != nil && h.builderAPISvc.IsEnabled(),
 		LifecycleAvailable:    h.lifecycleMgr != nil,
 		LifecycleEnabled:      h.lifecycleMgr != nil && h.lifecycleMgr.IsEnabled(),
+		LifecycleReadOnly:     h.lifecycleMgr != nil && h.lifecycleMgr.IsReadOnly(),
 	}
-	writeJSON(w, http.StatusOK, status)
 }
 
 // BidsWonResponse is the response for GetBidsWon.
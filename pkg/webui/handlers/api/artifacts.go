@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"mime"
 	"net/http"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 
 	legacytypes "github.com/ethpandaops/buildoor/pkg/builderapi/legacy/types"
 	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/slot_results"
 )
 
@@ -33,6 +35,38 @@ type SlotBidArtifactsResponse struct {
 	Bids []BidArtifactMetaEntry `json:"bids"`
 }
 
+// BidFieldRootEntry is one top-level field of an inspected bid message.
+type BidFieldRootEntry struct {
+	Name   string `json:"name"`
+	GIndex uint64 `json:"gindex"`
+	Root   string `json:"root"`
+}
+
+// BidInspectionResponse is the signing breakdown of one stored signed bid.
+type BidInspectionResponse struct {
+	Slot     uint64 `json:"slot"`
+	Index    int    `json:"index"`
+	Fork     string `json:"fork"`
+	Artifact string `json:"artifact"` // "bid" (Gloas+) or "header" (legacy)
+
+	// Message is the decoded bid message (all field values).
+	Message     any                 `json:"message"`
+	Fields      []BidFieldRootEntry `json:"fields"`
+	MessageRoot string              `json:"message_root"`
+
+	DomainType            string `json:"domain_type"`
+	ForkVersion           string `json:"fork_version"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	Domain                string `json:"domain"`
+	SigningRoot           string `json:"signing_root"`
+	Signature             string `json:"signature"`
+
+	// Pubkey and SignatureValid are omitted when the signing key is unknown
+	// (a payload bid whose builder is not in the current epoch stats).
+	Pubkey         string `json:"pubkey,omitempty"`
+	SignatureValid *bool  `json:"signature_valid,omitempty"`
+}
+
 // negotiateArtifact resolves the Accept header (q-values respected) between
 // application/octet-stream (raw SSZ) and application/json (versioned JSON
 // envelope). An absent Accept header defaults to JSON; an Accept header
@@ -238,6 +272,105 @@ func (h *APIHandler) GetSlotBidArtifact(w http.ResponseWriter, r *http.Request)
 	h.serveArtifact(w, r, slot_results.ArtifactKindBid, index)
 }
 
+// GetSlotBidInspection godoc
+// @Id getSlotBidInspection
+// @Summary Inspect the signing of one signed bid of a slot
+// @Tags ActionPlan
+// @Description Breaks down one stored signed bid for cross-checking against a
+// @Description consensus client's verification: the decoded message, the
+// @Description hash tree root and generalized index of every top-level field,
+// @Description the message root, the connected chain's signing domain and its
+// @Description inputs, the signing root and the signature. The signature is
+// @Description verified against the builder's registered key (Gloas+) or the
+// @Description bid's embedded key (legacy) when that key is known.
+// @Produce json
+// @Param slot path int true "Slot"
+// @Param index path int true "Bid artifact index"
+// @Success 200 {object} BidInspectionResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "No artifact for this slot/index"
+// @Failure 500 {object} map[string]string "Inspection failed"
+// @Failure 503 {object} map[string]string "Results tracker or chain unavailable"
+// @Router /api/buildoor/slot-results/{slot}/bids/{index}/inspect [get]
+func (h *APIHandler) GetSlotBidInspection(w http.ResponseWriter, r *http.Request) {
+	if h.resultTracker == nil || h.chainSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "slot results tracker or chain service not available")
+		return
+	}
+
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil || index < 0 {
+		writeError(w, http.StatusBadRequest, "invalid index: must be a non-negative number")
+		return
+	}
+
+	artifact, err := h.resultTracker.Artifacts().Get(slot, slot_results.ArtifactKindBid, index)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load artifact: "+err.Error())
+		return
+	}
+
+	if artifact == nil {
+		writeError(w, http.StatusNotFound, "artifact not found")
+		return
+	}
+
+	decoded, err := decodeArtifact(artifact)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to decode stored artifact: "+err.Error())
+		return
+	}
+
+	inspection, err := payload_bidder.InspectBid(h.chainSvc, slot, decoded)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to inspect bid: "+err.Error())
+		return
+	}
+
+	resp := &BidInspectionResponse{
+		Slot:                  uint64(slot),
+		Index:                 index,
+		Fork:                  version.DataVersion(artifact.Fork).String(),
+		Artifact:              inspection.Artifact,
+		Fields:                make([]BidFieldRootEntry, 0, len(inspection.Fields)),
+		MessageRoot:           fmt.Sprintf("%#x", inspection.MessageRoot),
+		DomainType:            fmt.Sprintf("%#x", inspection.Domain.Type),
+		ForkVersion:           fmt.Sprintf("%#x", inspection.Domain.ForkVersion),
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", inspection.Domain.GenesisValidatorsRoot),
+		Domain:                fmt.Sprintf("%#x", inspection.Domain.Domain),
+		SigningRoot:           fmt.Sprintf("%#x", inspection.SigningRoot),
+		Signature:             fmt.Sprintf("%#x", inspection.Signature),
+	}
+
+	switch bid := decoded.(type) {
+	case *eth2all.SignedExecutionPayloadBid:
+		resp.Message = bid.Message
+	case *legacytypes.SignedBuilderBid:
+		resp.Message = bid.Message
+	}
+
+	for _, field := range inspection.Fields {
+		resp.Fields = append(resp.Fields, BidFieldRootEntry{
+			Name:   field.Name,
+			GIndex: field.GIndex,
+			Root:   fmt.Sprintf("%#x", field.Root),
+		})
+	}
+
+	if inspection.PubkeyKnown {
+		valid := inspection.SignatureValid
+		resp.Pubkey = fmt.Sprintf("%#x", inspection.Pubkey)
+		resp.SignatureValid = &valid
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
 func (h *APIHandler) serveArtifact(w http.ResponseWriter, r *http.Request, kind string, index int) {
 	if h.resultTracker == nil {
 		writeError(w, http.StatusServiceUnavailable, "slot results tracker not available")
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+// GetSlotAssertions godoc
+// @Id getSlotAssertions
+// @Summary Pass/fail of the expected builder behaviour for a slot
+// @Tags ActionPlan
+// @Description Machine-readable verdicts for automated devnet assertions
+// @Description (e.g. assertoor): built_on_time (payload ready before the slot
+// @Description start), bid_above_min (a submitted/served bid at or above the
+// @Description slot's minimum), revealed (payload of a won slot revealed or
+// @Description released) and block_landed (payload included canonically).
+// @Description Each verdict is pass, fail, pending or skip (not applicable);
+// @Description the overall status is fail on any failure, pending while any
+// @Description verdict is undecided.
+// @Produce json
+// @Param slot path int true "Slot"
+// @Success 200 {object} slot_results.SlotAssertions
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "No results recorded for this slot"
+// @Failure 503 {object} map[string]string "Results tracker unavailable"
+// @Router /api/buildoor/assertions/{slot} [get]
+func (h *APIHandler) GetSlotAssertions(w http.ResponseWriter, r *http.Request) {
+	if h.resultTracker == nil {
+		writeError(w, http.StatusServiceUnavailable, "slot results tracker not available")
+		return
+	}
+
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	result := h.resultTracker.Get(slot)
+	if result == nil {
+		writeError(w, http.StatusNotFound, "no results recorded for this slot")
+		return
+	}
+
+	var (
+		slotStart   time.Time
+		currentSlot phase0.Slot
+	)
+
+	if h.chainSvc != nil {
+		slotStart = h.chainSvc.SlotToTime(slot)
+		currentSlot = h.chainSvc.GetCurrentSlot()
+	}
+
+	writeJSON(w, http.StatusOK, slot_results.Assess(result, slotStart, currentSlot))
+}
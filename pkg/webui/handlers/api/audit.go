@@ -2,14 +2,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/db"
 )
 
@@ -76,19 +79,91 @@ func mustJSON(v any) json.RawMessage {
 	return b
 }
 
-// applySettings applies a batch of UI setting overrides via the settings
-// service, broadcasts a config update on success, and records an audit entry.
-// It writes an error response and returns false on failure. An empty update set
-// is treated as a successful no-op.
+// settingsAuditDetail is the audit detail of a settings update: the request
+// plus the effective values it changed and the resulting settings version.
+type settingsAuditDetail struct {
+	Request any             `json:"request"`
+	Changes []config.Change `json:"changes,omitempty"`
+	Version int64           `json:"version"`
+}
+
+// configETag formats a settings version as a strong ETag.
+func configETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion parses the request's If-Match header into the settings version
+// the edit was based on. Returns -1 (no precondition) when the header is absent
+// or "*"; ok is false for a malformed header.
+func ifMatchVersion(r *http.Request) (version int64, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return -1, true
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	if err != nil || version < 0 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// updateSettings applies a batch of UI setting overrides via the settings
+// service (see changeSettings). It writes an error response and returns false
+// on failure.
+func (h *APIHandler) updateSettings(w http.ResponseWriter, r *http.Request, token *jwt.Token, action string, detail any, updates map[string]json.RawMessage) bool {
+	return h.changeSettings(w, r, token, action, detail, func(ifVersion int64) ([]config.Change, int64, error) {
+		return h.settingsSvc.Update(updates, actorFromToken(token), ifVersion)
+	})
+}
+
+// changeSettings runs a settings change honouring the request's If-Match
+// precondition (412 when the settings moved on, 404 for an unknown rollback
+// revision), and records an audit entry with the applied changes. On success
+// the response carries the new settings ETag. It writes an error response and
+// returns false on failure.
+func (h *APIHandler) changeSettings(w http.ResponseWriter, r *http.Request, token *jwt.Token, action string, detail any,
+	apply func(ifVersion int64) ([]config.Change, int64, error)) bool {
+	ifVersion, ok := ifMatchVersion(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid If-Match header")
+		return false
+	}
+
+	changes, version, err := apply(ifVersion)
+	if err != nil {
+		h.audit(r, token, action, "", settingsAuditDetail{Request: detail, Version: version}, "error: "+err.Error())
+
+		switch {
+		case errors.Is(err, config.ErrVersionConflict):
+			w.Header().Set("ETag", configETag(version))
+			writeError(w, http.StatusPreconditionFailed, err.Error())
+		case errors.Is(err, config.ErrUnknownRevision):
+			writeError(w, http.StatusNotFound, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+
+		return false
+	}
+
+	w.Header().Set("ETag", configETag(version))
+	h.audit(r, token, action, "", settingsAuditDetail{Request: detail, Changes: changes, Version: version}, "ok")
+
+	return true
+}
+
+// applySettings applies a batch of UI setting overrides (see updateSettings)
+// and broadcasts a config update on success. It writes an error response and
+// returns false on failure. An empty update set is treated as a successful
+// no-op.
 func (h *APIHandler) applySettings(w http.ResponseWriter, r *http.Request, token *jwt.Token, action string, detail any, updates map[string]json.RawMessage) bool {
 	if len(updates) == 0 {
 		return true
 	}
 
-	if err := h.settingsSvc.SetMany(updates, actorFromToken(token)); err != nil {
-		h.audit(r, token, action, "", detail, "error: "+err.Error())
-		writeError(w, http.StatusBadRequest, err.Error())
-
+	if !h.updateSettings(w, r, token, action, detail, updates) {
 		return false
 	}
 
@@ -96,8 +171,6 @@ func (h *APIHandler) applySettings(w http.ResponseWriter, r *http.Request, token
 		h.eventStreamMgr.BroadcastConfigUpdate()
 	}
 
-	h.audit(r, token, action, "", detail, "ok")
-
 	return true
 }
 
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// balanceHistoryCapacity bounds the in-memory series. With the heartbeat
+	// below a flat balance costs one sample per heartbeat, so the default
+	// keeps roughly a week of history.
+	balanceHistoryCapacity = 50000
+
+	// balanceHistoryHeartbeat is the maximum gap between two samples while
+	// nothing changes, so charts still get points on flat stretches.
+	balanceHistoryHeartbeat = 12 * time.Second
+
+	balanceHistoryDefaultPoints = 300
+	balanceHistoryMaxPoints     = 2000
+)
+
+// BalanceSample is one recorded builder balance snapshot.
+type BalanceSample struct {
+	Timestamp        int64  `json:"timestamp"`
+	Slot             uint64 `json:"slot"`
+	CLBalance        uint64 `json:"cl_balance_gwei"`
+	PendingPayments  uint64 `json:"pending_payments_gwei"`
+	EffectiveBalance uint64 `json:"effective_balance_gwei"`
+	WalletBalance    string `json:"wallet_balance_wei,omitempty"`
+}
+
+// sameBalances reports whether two samples carry identical balance values.
+func (s *BalanceSample) sameBalances(o *BalanceSample) bool {
+	return s.CLBalance == o.CLBalance &&
+		s.PendingPayments == o.PendingPayments &&
+		s.EffectiveBalance == o.EffectiveBalance &&
+		s.WalletBalance == o.WalletBalance
+}
+
+// BalanceHistory is a bounded in-memory time series of builder balance
+// snapshots fed by the event stream poll loop. Unchanged snapshots are only
+// kept once per heartbeat so the 100ms poll does not flood the buffer.
+type BalanceHistory struct {
+	mu      sync.RWMutex
+	samples []BalanceSample // ring buffer, oldest at head once full
+	head    int
+	full    bool
+}
+
+// NewBalanceHistory creates a balance history holding up to capacity samples.
+func NewBalanceHistory(capacity int) *BalanceHistory {
+	if capacity <= 0 {
+		capacity = balanceHistoryCapacity
+	}
+
+	return &BalanceHistory{
+		samples: make([]BalanceSample, 0, capacity),
+	}
+}
+
+// Record appends a sample unless it repeats the previous balances within the
+// heartbeat interval. Returns true if the sample was stored.
+func (b *BalanceHistory) Record(sample BalanceSample) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if last, ok := b.lastLocked(); ok && last.sameBalances(&sample) &&
+		sample.Timestamp-last.Timestamp < balanceHistoryHeartbeat.Milliseconds() {
+		return false
+	}
+
+	if !b.full {
+		b.samples = append(b.samples, sample)
+		b.full = len(b.samples) == cap(b.samples)
+
+		return true
+	}
+
+	b.samples[b.head] = sample
+	b.head = (b.head + 1) % len(b.samples)
+
+	return true
+}
+
+func (b *BalanceHistory) lastLocked() (*BalanceSample, bool) {
+	if len(b.samples) == 0 {
+		return nil, false
+	}
+
+	idx := len(b.samples) - 1
+	if b.full {
+		idx = (b.head + len(b.samples) - 1) % len(b.samples)
+	}
+
+	return &b.samples[idx], true
+}
+
+// Range returns the samples with from <= timestamp <= to (unix ms), oldest
+// first.
+func (b *BalanceHistory) Range(from, to int64) []BalanceSample {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make([]BalanceSample, 0, 64)
+
+	for i := range b.samples {
+		s := b.samples[(b.head+i)%len(b.samples)]
+		if s.Timestamp >= from && s.Timestamp <= to {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// BalanceHistoryPoint is one downsampled bucket of the balance series. The
+// balance fields carry the last sample in the bucket; the effective balance
+// min/max keep short dips visible after downsampling.
+type BalanceHistoryPoint struct {
+	BalanceSample
+	MinEffectiveBalance uint64 `json:"min_effective_balance_gwei"`
+	MaxEffectiveBalance uint64 `json:"max_effective_balance_gwei"`
+	Samples             int    `json:"samples"`
+}
+
+// BalanceHistoryResponse is the response for GET /api/buildoor/balance-history.
+type BalanceHistoryResponse struct {
+	From     int64                 `json:"from"`
+	To       int64                 `json:"to"`
+	BucketMs int64                 `json:"bucket_ms"`
+	Samples  int                   `json:"samples"`
+	Points   []BalanceHistoryPoint `json:"points"`
+}
+
+// downsampleBalances splits [from, to] into at most maxPoints equal-width
+// buckets and folds the samples of each non-empty bucket into one point.
+// Returns the points and the bucket width used.
+func downsampleBalances(samples []BalanceSample, from, to int64, maxPoints int) ([]BalanceHistoryPoint, int64) {
+	bucketMs := max((to-from+1+int64(maxPoints)-1)/int64(maxPoints), 1)
+	points := make([]BalanceHistoryPoint, 0, min(len(samples), maxPoints))
+
+	lastBucket := int64(-1)
+
+	for _, s := range samples {
+		bucket := (s.Timestamp - from) / bucketMs
+
+		if bucket != lastBucket {
+			lastBucket = bucket
+
+			points = append(points, BalanceHistoryPoint{
+				MinEffectiveBalance: s.EffectiveBalance,
+				MaxEffectiveBalance: s.EffectiveBalance,
+			})
+		}
+
+		p := &points[len(points)-1]
+		p.BalanceSample = s
+		p.MinEffectiveBalance = min(p.MinEffectiveBalance, s.EffectiveBalance)
+		p.MaxEffectiveBalance = max(p.MaxEffectiveBalance, s.EffectiveBalance)
+		p.Samples++
+	}
+
+	return points, bucketMs
+}
+
+// GetBalanceHistory godoc
+// @Id getBalanceHistory
+// @Summary Get the builder balance history
+// @Tags Buildoor
+// @Description Returns the recorded builder CL balance, pending payments,
+// @Description effective balance and wallet balance over time, downsampled into
+// @Description at most points equal-width buckets. Each point carries the last
+// @Description sample of its bucket plus the bucket's effective balance range.
+// @Description History is kept in memory and starts when the web UI starts.
+// @Produce json
+// @Param from query int false "Window start, unix ms (default: oldest sample)"
+// @Param to query int false "Window end, unix ms (default: now)"
+// @Param points query int false "Maximum number of points (default 300, max 2000)"
+// @Success 200 {object} BalanceHistoryResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 503 {object} map[string]string "Balance history unavailable"
+// @Router /api/buildoor/balance-history [get]
+func (h *APIHandler) GetBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	if h.eventStreamMgr == nil {
+		writeError(w, http.StatusServiceUnavailable, "balance history unavailable")
+		return
+	}
+
+	query := r.URL.Query()
+	to := time.Now().UnixMilli()
+	from := int64(0)
+	points := balanceHistoryDefaultPoints
+
+	if v := query.Get("from"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from: must be a unix ms timestamp")
+			return
+		}
+
+		from = parsed
+	}
+
+	if v := query.Get("to"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to: must be a unix ms timestamp")
+			return
+		}
+
+		to = parsed
+	}
+
+	if from > to {
+		writeError(w, http.StatusBadRequest, "invalid range: from is after to")
+		return
+	}
+
+	if v := query.Get("points"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid points: must be a positive number")
+			return
+		}
+
+		points = min(parsed, balanceHistoryMaxPoints)
+	}
+
+	samples := h.eventStreamMgr.GetBalanceHistory().Range(from, to)
+
+	// Without an explicit start the window begins at the oldest sample so
+	// the buckets are not stretched back to the epoch.
+	if query.Get("from") == "" && len(samples) > 0 {
+		from = samples[0].Timestamp
+	}
+
+	downsampled, bucketMs := downsampleBalances(samples, from, to, points)
+
+	writeJSON(w, http.StatusOK, BalanceHistoryResponse{
+		From:     from,
+		To:       to,
+		BucketMs: bucketMs,
+		Samples:  len(samples),
+		Points:   downsampled,
+	})
+}
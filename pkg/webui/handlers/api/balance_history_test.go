@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceHistoryRecordCoalescesAndWraps(t *testing.T) {
+	history := NewBalanceHistory(3)
+
+	require.True(t, history.Record(BalanceSample{Timestamp: 1000, CLBalance: 10}))
+	require.False(t, history.Record(BalanceSample{Timestamp: 1100, CLBalance: 10}), "unchanged poll within heartbeat")
+	require.True(t, history.Record(BalanceSample{Timestamp: 1200, CLBalance: 11}))
+
+	heartbeat := 1200 + balanceHistoryHeartbeat.Milliseconds()
+	require.True(t, history.Record(BalanceSample{Timestamp: heartbeat, CLBalance: 11}), "unchanged poll after heartbeat")
+	require.True(t, history.Record(BalanceSample{Timestamp: heartbeat + 1, CLBalance: 12}))
+
+	samples := history.Range(0, heartbeat+1)
+	require.Len(t, samples, 3, "oldest sample should be overwritten")
+	require.Equal(t, []uint64{11, 11, 12}, []uint64{samples[0].CLBalance, samples[1].CLBalance, samples[2].CLBalance})
+
+	samples = history.Range(1200, 1200)
+	require.Len(t, samples, 1)
+	require.Equal(t, uint64(11), samples[0].CLBalance)
+}
+
+func TestDownsampleBalances(t *testing.T) {
+	samples := []BalanceSample{
+		{Timestamp: 0, EffectiveBalance: 100},
+		{Timestamp: 10, EffectiveBalance: 40},
+		{Timestamp: 20, EffectiveBalance: 90},
+		{Timestamp: 75, EffectiveBalance: 80},
+	}
+
+	points, bucketMs := downsampleBalances(samples, 0, 99, 4)
+	require.Equal(t, int64(25), bucketMs)
+	require.Len(t, points, 2, "empty buckets are omitted")
+
+	require.Equal(t, int64(20), points[0].Timestamp, "bucket carries its last sample")
+	require.Equal(t, uint64(90), points[0].EffectiveBalance)
+	require.Equal(t, uint64(40), points[0].MinEffectiveBalance)
+	require.Equal(t, uint64(100), points[0].MaxEffectiveBalance)
+	require.Equal(t, 3, points[0].Samples)
+
+	require.Equal(t, int64(75), points[1].Timestamp)
+	require.Equal(t, 1, points[1].Samples)
+}
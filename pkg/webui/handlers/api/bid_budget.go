@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+)
+
+// GetBidBudget godoc
+// @Id getBidBudget
+// @Summary Epoch bid budget
+// @Tags Buildoor
+// @Description Returns the p2p bid budget of the current epoch (--bid-budget-epoch-gwei): the
+// @Description configured maximum and distribution, the spend so far (the highest bid gossiped
+// @Description per slot) and the remaining budget. Bids are capped to their slot's share of
+// @Description the remaining budget and refused once it is exhausted.
+// @Produce json
+// @Success 200 {object} p2p_bidder.BidBudgetStatus "Success"
+// @Failure 503 {object} map[string]string "ePBS bidder not available"
+// @Router /api/buildoor/bid-budget [get]
+func (h *APIHandler) GetBidBudget(w http.ResponseWriter, _ *http.Request) {
+	if h.epbsSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "ePBS bidder not available")
+		return
+	}
+
+	status := h.epbsSvc.GetBidBudgetStatus()
+	if status == nil {
+		writeError(w, http.StatusServiceUnavailable, "ePBS bidder not started")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,454 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+// Payment statuses of a won slot's bid payment.
+const (
+	// blockPaymentPaid: the payload was revealed, the payment is deducted.
+	blockPaymentPaid = "paid"
+	// blockPaymentPending: unrevealed, held in the 2-epoch pending ledger.
+	blockPaymentPending = "pending"
+	// blockPaymentUnknown: neither revealed nor pending (ledger entry expired
+	// or lost across a restart without a state-db).
+	blockPaymentUnknown = "unknown"
+)
+
+// BlockViewResponse is the explorer-style drill-down of a slot we won,
+// assembled server-side from the beacon node, the payload cache, the payment
+// ledger and the recorded slot result.
+type BlockViewResponse struct {
+	Slot  uint64 `json:"slot"`
+	Epoch uint64 `json:"epoch"`
+	Fork  string `json:"fork,omitempty"`
+
+	// Block is the consensus block at the slot (nil when the beacon node has
+	// none, e.g. it was reorged out).
+	Block *BlockViewBlock `json:"block,omitempty"`
+	// Bid is the execution payload bid the block committed to (Gloas+).
+	Bid *BlockViewBid `json:"bid,omitempty"`
+	// Payload is our built payload (nil once evicted from the payload cache).
+	Payload *BlockViewPayload `json:"payload,omitempty"`
+	// Payment is the bid payment state (Gloas+).
+	Payment *BlockViewPayment `json:"payment,omitempty"`
+	// Blobs are the committed blobs (bid commitments, or our payload's
+	// bundle when no bid is at hand).
+	Blobs []BlockViewBlob `json:"blobs,omitempty"`
+	// PTC is the payload timeliness committee outcome, taken from the payload
+	// attestations of the next slot's block (Gloas+; nil while that block is
+	// not available).
+	PTC *BlockViewPTC `json:"ptc,omitempty"`
+
+	// Result is the recorded slot history (build, bids, reveals, inclusion).
+	Result *slot_results.SlotResult `json:"result,omitempty"`
+}
+
+// BlockViewBlock summarizes the consensus block.
+type BlockViewBlock struct {
+	Root               string `json:"root"`
+	ParentRoot         string `json:"parent_root"`
+	StateRoot          string `json:"state_root"`
+	ProposerIndex      uint64 `json:"proposer_index"`
+	Graffiti           string `json:"graffiti,omitempty"`
+	ExecutionBlockHash string `json:"execution_block_hash,omitempty"` // embedded payload (pre-Gloas)
+
+	NumAttestations        int `json:"num_attestations"`
+	NumPayloadAttestations int `json:"num_payload_attestations,omitempty"`
+	NumDeposits            int `json:"num_deposits"`
+	NumVoluntaryExits      int `json:"num_voluntary_exits"`
+	NumProposerSlashings   int `json:"num_proposer_slashings"`
+	NumAttesterSlashings   int `json:"num_attester_slashings"`
+	NumBLSChanges          int `json:"num_bls_changes"`
+}
+
+// BlockViewBid is the block's signed execution payload bid.
+type BlockViewBid struct {
+	BuilderIndex         uint64 `json:"builder_index"`
+	Ours                 bool   `json:"ours"` // builder index matches ours
+	BlockHash            string `json:"block_hash"`
+	ParentBlockHash      string `json:"parent_block_hash"`
+	ParentBlockRoot      string `json:"parent_block_root"`
+	FeeRecipient         string `json:"fee_recipient"`
+	GasLimit             uint64 `json:"gas_limit"`
+	ValueGwei            uint64 `json:"value_gwei"`
+	ExecutionPaymentGwei uint64 `json:"execution_payment_gwei,omitempty"`
+	NumBlobCommitments   int    `json:"num_blob_commitments"`
+}
+
+// BlockViewPayload summarizes our built execution payload.
+type BlockViewPayload struct {
+	BlockHash      string   `json:"block_hash"`
+	ParentHash     string   `json:"parent_hash"`
+	BlockNumber    uint64   `json:"block_number"`
+	FeeRecipient   string   `json:"fee_recipient"`
+	StateRoot      string   `json:"state_root"`
+	Timestamp      uint64   `json:"timestamp"`
+	GasLimit       uint64   `json:"gas_limit"`
+	GasUsed        uint64   `json:"gas_used"`
+	BaseFeePerGas  string   `json:"base_fee_per_gas"` // wei
+	BlockValueWei  string   `json:"block_value_wei"`
+	NumWithdrawals int      `json:"num_withdrawals"`
+	Transactions   []string `json:"transactions"` // transaction hashes, in block order
+
+	// Reveal of the payload (Gloas+): when and through which flow it was
+	// published; nil while unrevealed.
+	RevealedAt      *time.Time `json:"revealed_at,omitempty"`
+	RevealTransport string     `json:"reveal_transport,omitempty"`
+}
+
+// BlockViewPayment is the won bid's payment state.
+type BlockViewPayment struct {
+	ValueGwei   uint64 `json:"value_gwei"`
+	Status      string `json:"status"` // paid | pending | unknown
+	PendingGwei uint64 `json:"pending_gwei,omitempty"`
+}
+
+// BlockViewBlob is one committed blob.
+type BlockViewBlob struct {
+	Index         int    `json:"index"`
+	KZGCommitment string `json:"kzg_commitment"`
+	VersionedHash string `json:"versioned_hash"`
+}
+
+// BlockViewPTC is the payload timeliness committee vote on the block's
+// payload, as included in the next slot's block.
+type BlockViewPTC struct {
+	CheckSlot         uint64 `json:"check_slot"` // block the payload attestations came from
+	Size              uint64 `json:"size"`       // PTC_SIZE
+	Votes             int    `json:"votes"`
+	PayloadPresent    int    `json:"payload_present"`
+	BlobDataAvailable int    `json:"blob_data_available"`
+	// Timely is the PTC verdict: more than half the committee saw the
+	// payload present.
+	Timely bool `json:"timely"`
+}
+
+// GetBlockView godoc
+// @Id getBlockView
+// @Summary Explorer drill-down of a won slot's block
+// @Tags Buildoor
+// @Description Assembles the full view of a slot we won so the WebUI never
+// @Description queries the beacon node from the browser: the consensus block,
+// @Description its committed bid (Gloas+), our built payload with transaction
+// @Description hashes, the bid payment state, the committed blobs, the
+// @Description payload timeliness committee outcome from the next slot's block
+// @Description and the recorded slot result. A slot counts as won when its
+// @Description result records an inclusion, its bid carries our builder index
+// @Description or it embeds a payload we built.
+// @Produce json
+// @Param slot path int true "Slot"
+// @Success 200 {object} BlockViewResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Slot not won by us"
+// @Failure 502 {object} map[string]string "Beacon node request failed"
+// @Failure 503 {object} map[string]string "Beacon node unavailable"
+// @Router /api/buildoor/blocks/{slot} [get]
+func (h *APIHandler) GetBlockView(w http.ResponseWriter, r *http.Request) {
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	clClient := h.builderSvc.GetCLClient()
+	if clClient == nil {
+		writeError(w, http.StatusServiceUnavailable, "beacon node unavailable")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	block, err := clClient.GetSignedBeaconBlock(ctx, strconv.FormatUint(uint64(slot), 10))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "failed to fetch block: "+err.Error())
+		return
+	}
+
+	var result *slot_results.SlotResult
+	if h.resultTracker != nil {
+		result = h.resultTracker.Get(slot)
+	}
+
+	builderIndex, builderIndexKnown := uint64(0), false
+	if h.inclusionTracker != nil {
+		builderIndex, builderIndexKnown = h.inclusionTracker.BuilderIndex()
+	}
+
+	view := &BlockViewResponse{Slot: uint64(slot), Result: result}
+
+	if h.chainSvc != nil {
+		epoch := h.chainSvc.GetEpochOfSlot(slot)
+		view.Epoch = uint64(epoch)
+		view.Fork = h.chainSvc.ActiveForkAtEpoch(epoch).String()
+	}
+
+	var (
+		payload   *payload_builder.Payload
+		blockRoot phase0.Root
+	)
+
+	if block != nil && block.Message != nil && block.Message.Body != nil {
+		view.Block, blockRoot, err = blockViewBlock(block.Message)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, "failed to decode block: "+err.Error())
+			return
+		}
+
+		view.Bid = blockViewBid(block.Message.Body, builderIndex, builderIndexKnown)
+
+		if execHash, ok := committedBlockHash(block.Message.Body); ok {
+			payload = h.builderSvc.GetPayloadCache().GetByBlockHash(execHash)
+		}
+	}
+
+	won := payload != nil || (view.Bid != nil && view.Bid.Ours) || (result != nil && result.Inclusion != nil)
+	if !won {
+		writeError(w, http.StatusNotFound, "slot was not won by us")
+		return
+	}
+
+	if payload != nil {
+		view.Payload = blockViewPayload(payload)
+	}
+
+	view.Blobs = blockViewBlobs(block, payload)
+
+	if view.Bid != nil {
+		view.Payment = h.blockViewPayment(slot, view.Bid.ValueGwei, payload)
+
+		// The PTC votes on the payload land in the next slot's block; a
+		// missing or not yet proposed block leaves the outcome open.
+		next, err := clClient.GetSignedBeaconBlock(ctx, strconv.FormatUint(uint64(slot)+1, 10))
+		if err == nil && next != nil && next.Message != nil && next.Message.Body != nil && h.chainSvc != nil {
+			view.PTC = blockViewPTC(next.Message, slot, blockRoot, h.chainSvc.GetChainSpec().PtcSize)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, view)
+}
+
+// blockViewPayment derives the payment state of a won bid: paid once revealed,
+// pending while the payment ledger still holds the slot.
+func (h *APIHandler) blockViewPayment(
+	slot phase0.Slot, valueGwei uint64, payload *payload_builder.Payload,
+) *BlockViewPayment {
+	payment := &BlockViewPayment{ValueGwei: valueGwei, Status: blockPaymentUnknown}
+
+	var pending *payload_bidder.PendingPayment
+	if h.payments != nil {
+		pending = h.payments.GetPendingPayment(slot)
+	}
+
+	switch {
+	case pending != nil:
+		payment.Status = blockPaymentPending
+		payment.PendingGwei = pending.Value
+	case payload != nil && payload.Reveal() != nil:
+		payment.Status = blockPaymentPaid
+	}
+
+	return payment
+}
+
+// blockViewBlock summarizes the consensus block and returns its root.
+func blockViewBlock(msg *eth2all.BeaconBlock) (*BlockViewBlock, phase0.Root, error) {
+	root, err := dynssz.GetGlobalDynSsz().HashTreeRoot(msg)
+	if err != nil {
+		return nil, phase0.Root{}, fmt.Errorf("failed to compute block root: %w", err)
+	}
+
+	body := msg.Body
+	view := &BlockViewBlock{
+		Root:                   fmt.Sprintf("%#x", root[:]),
+		ParentRoot:             msg.ParentRoot.String(),
+		StateRoot:              msg.StateRoot.String(),
+		ProposerIndex:          uint64(msg.ProposerIndex),
+		Graffiti:               strings.TrimRight(string(body.Graffiti[:]), "\x00"),
+		NumAttestations:        len(body.Attestations),
+		NumPayloadAttestations: len(body.PayloadAttestations),
+		NumDeposits:            len(body.Deposits),
+		NumVoluntaryExits:      len(body.VoluntaryExits),
+		NumProposerSlashings:   len(body.ProposerSlashings),
+		NumAttesterSlashings:   len(body.AttesterSlashings),
+		NumBLSChanges:          len(body.BLSToExecutionChanges),
+	}
+
+	if body.ExecutionPayload != nil {
+		view.ExecutionBlockHash = body.ExecutionPayload.BlockHash.String()
+	}
+
+	return view, root, nil
+}
+
+// blockViewBid extracts the block's committed bid (Gloas+; nil before).
+func blockViewBid(body *eth2all.BeaconBlockBody, builderIndex uint64, builderIndexKnown bool) *BlockViewBid {
+	if body.SignedExecutionPayloadBid == nil || body.SignedExecutionPayloadBid.Message == nil {
+		return nil
+	}
+
+	bid := body.SignedExecutionPayloadBid.Message
+
+	return &BlockViewBid{
+		BuilderIndex:         uint64(bid.BuilderIndex),
+		Ours:                 builderIndexKnown && uint64(bid.BuilderIndex) == builderIndex,
+		BlockHash:            bid.BlockHash.String(),
+		ParentBlockHash:      bid.ParentBlockHash.String(),
+		ParentBlockRoot:      bid.ParentBlockRoot.String(),
+		FeeRecipient:         bid.FeeRecipient.String(),
+		GasLimit:             bid.GasLimit,
+		ValueGwei:            uint64(bid.Value),
+		ExecutionPaymentGwei: uint64(bid.ExecutionPayment),
+		NumBlobCommitments:   len(bid.BlobKZGCommitments),
+	}
+}
+
+// committedBlockHash returns the execution block hash the block commits to:
+// the bid's block hash (Gloas+) or the embedded payload's (pre-Gloas).
+func committedBlockHash(body *eth2all.BeaconBlockBody) (phase0.Hash32, bool) {
+	switch {
+	case body.SignedExecutionPayloadBid != nil && body.SignedExecutionPayloadBid.Message != nil:
+		return body.SignedExecutionPayloadBid.Message.BlockHash, true
+	case body.ExecutionPayload != nil:
+		return body.ExecutionPayload.BlockHash, true
+	default:
+		return phase0.Hash32{}, false
+	}
+}
+
+// blockViewPayload summarizes our built payload. Transactions that fail to
+// decode are listed as "invalid" to keep the block order intact.
+func blockViewPayload(payload *payload_builder.Payload) *BlockViewPayload {
+	exec := payload.ExecutionPayload
+	view := &BlockViewPayload{
+		BlockHash:      payload.BlockHash.String(),
+		ParentHash:     exec.ParentHash.String(),
+		BlockNumber:    exec.BlockNumber,
+		FeeRecipient:   payload.FeeRecipient.Hex(),
+		StateRoot:      exec.StateRoot.String(),
+		Timestamp:      exec.Timestamp,
+		GasLimit:       exec.GasLimit,
+		GasUsed:        exec.GasUsed,
+		BaseFeePerGas:  "0",
+		BlockValueWei:  "0",
+		NumWithdrawals: len(exec.Withdrawals),
+		Transactions:   make([]string, 0, len(exec.Transactions)),
+	}
+
+	if exec.BaseFeePerGas != nil {
+		view.BaseFeePerGas = exec.BaseFeePerGas.Dec()
+	}
+
+	if payload.BlockValue != nil {
+		view.BlockValueWei = payload.BlockValue.String()
+	}
+
+	for _, raw := range exec.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			view.Transactions = append(view.Transactions, "invalid")
+			continue
+		}
+
+		view.Transactions = append(view.Transactions, tx.Hash().Hex())
+	}
+
+	if reveal := payload.Reveal(); reveal != nil {
+		at := reveal.At
+		view.RevealedAt = &at
+		view.RevealTransport = string(reveal.Transport)
+	}
+
+	return view
+}
+
+// blockViewBlobs lists the committed blobs with their versioned hashes: the
+// block's commitments (bid for Gloas+, body before), else our payload's
+// bundle.
+func blockViewBlobs(block *eth2all.SignedBeaconBlock, payload *payload_builder.Payload) []BlockViewBlob {
+	var commitments []deneb.KZGCommitment
+
+	switch {
+	case block != nil && block.Message != nil && block.Message.Body != nil:
+		body := block.Message.Body
+		if body.SignedExecutionPayloadBid != nil && body.SignedExecutionPayloadBid.Message != nil {
+			commitments = body.SignedExecutionPayloadBid.Message.BlobKZGCommitments
+		} else {
+			commitments = body.BlobKZGCommitments
+		}
+	case payload != nil && payload.BlobsBundle != nil:
+		commitments = payload.BlobsBundle.Commitments
+	}
+
+	return blobViews(commitments)
+}
+
+// blobViews pairs each KZG commitment with its versioned hash.
+func blobViews(commitments []deneb.KZGCommitment) []BlockViewBlob {
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	blobs := make([]BlockViewBlob, len(commitments))
+	for i, commitment := range commitments {
+		kzgCommitment := kzg4844.Commitment(commitment)
+		versionedHash := kzg4844.CalcBlobHashV1(sha256.New(), &kzgCommitment)
+
+		blobs[i] = BlockViewBlob{
+			Index:         i,
+			KZGCommitment: fmt.Sprintf("%#x", commitment[:]),
+			VersionedHash: fmt.Sprintf("%#x", versionedHash[:]),
+		}
+	}
+
+	return blobs
+}
+
+// blockViewPTC tallies the payload attestations for (slot, root) carried by
+// the next block. Nil before Gloas (no payload attestations exist).
+func blockViewPTC(next *eth2all.BeaconBlock, slot phase0.Slot, root phase0.Root, ptcSize uint64) *BlockViewPTC {
+	if next.Version < version.DataVersionGloas {
+		return nil
+	}
+
+	ptc := &BlockViewPTC{CheckSlot: uint64(next.Slot), Size: ptcSize}
+
+	for _, att := range next.Body.PayloadAttestations {
+		if att == nil || att.Data == nil || att.Data.Slot != slot || att.Data.BeaconBlockRoot != root {
+			continue
+		}
+
+		votes := int(att.AggregationBits.Count())
+		ptc.Votes += votes
+
+		if att.Data.PayloadPresent {
+			ptc.PayloadPresent += votes
+		}
+
+		if att.Data.BlobDataAvailable {
+			ptc.BlobDataAvailable += votes
+		}
+	}
+
+	ptc.Timely = uint64(ptc.PayloadPresent) > ptcSize/2
+
+	return ptc
+}
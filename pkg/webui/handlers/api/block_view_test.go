@@ -0,0 +1,71 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/OffchainLabs/go-bitfield"
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+	"github.com/ethpandaops/go-eth2-client/spec/deneb"
+	"github.com/ethpandaops/go-eth2-client/spec/gloas"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/go-eth2-client/spec/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockViewPTC(t *testing.T) {
+	root := phase0.Root{0x01}
+
+	attestation := func(votes int, slot phase0.Slot, root phase0.Root, present, blobs bool) *gloas.PayloadAttestation {
+		bits := bitfield.NewBitvector512()
+		for i := range votes {
+			bits.SetBitAt(uint64(i), true)
+		}
+
+		return &gloas.PayloadAttestation{
+			AggregationBits: bits,
+			Data: &gloas.PayloadAttestationData{
+				BeaconBlockRoot: root, Slot: slot, PayloadPresent: present, BlobDataAvailable: blobs,
+			},
+		}
+	}
+
+	next := &eth2all.BeaconBlock{
+		Version: version.DataVersionGloas,
+		Slot:    11,
+		Body: &eth2all.BeaconBlockBody{
+			PayloadAttestations: []*gloas.PayloadAttestation{
+				attestation(300, 10, root, true, true),
+				attestation(100, 10, root, false, false),
+				attestation(50, 10, phase0.Root{0x02}, true, true), // other block
+				attestation(50, 9, root, true, true),               // other slot
+			},
+		},
+	}
+
+	ptc := blockViewPTC(next, 10, root, 512)
+	require.NotNil(t, ptc)
+	assert.Equal(t, uint64(11), ptc.CheckSlot)
+	assert.Equal(t, 400, ptc.Votes)
+	assert.Equal(t, 300, ptc.PayloadPresent)
+	assert.Equal(t, 300, ptc.BlobDataAvailable)
+	assert.True(t, ptc.Timely)
+
+	next.Body.PayloadAttestations = next.Body.PayloadAttestations[1:]
+	assert.False(t, blockViewPTC(next, 10, root, 512).Timely)
+
+	next.Version = version.DataVersionFulu
+	assert.Nil(t, blockViewPTC(next, 10, root, 512))
+}
+
+func TestBlobViews(t *testing.T) {
+	assert.Nil(t, blobViews(nil))
+
+	blobs := blobViews([]deneb.KZGCommitment{{0xc0}, {0xc1}})
+	require.Len(t, blobs, 2)
+	assert.Equal(t, 1, blobs[1].Index)
+	assert.Equal(t, "0xc1", blobs[1].KZGCommitment[:4])
+	// Versioned hashes carry the KZG version byte.
+	assert.Equal(t, "0x01", blobs[0].VersionedHash[:4])
+	assert.NotEqual(t, blobs[0].VersionedHash, blobs[1].VersionedHash)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/buildoor/pkg/builderapi"
+)
+
+// BuilderAPICapturesResponse is the response for GetBuilderAPICaptures.
+type BuilderAPICapturesResponse struct {
+	Captures []builderapi.CapturedExchange `json:"captures"`
+}
+
+// GetBuilderAPICaptures godoc
+// @Id getBuilderAPICaptures
+// @Summary Download the recent Builder API request/response captures
+// @Tags Buildoor
+// @Description Returns the most recent /eth/v*/builder/* exchanges, newest first, with full
+// @Description request and response headers and bodies (capped at --builder-api-capture-max-body,
+// @Description binary SSZ bodies base64 encoded). Auth headers and secret-looking JSON fields are
+// @Description scrubbed. Served as a JSON attachment. Requires authentication.
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Maximum number of exchanges (default all captured)"
+// @Success 200 {object} BuilderAPICapturesResponse "Success"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Capturing disabled"
+// @Router /api/buildoor/builder-api/captures [get]
+func (h *APIHandler) GetBuilderAPICaptures(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.builderAPISvc == nil || !h.builderAPISvc.CapturesEnabled() {
+		writeError(w, http.StatusNotFound, "builder API capturing is disabled")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q",
+		fmt.Sprintf("buildoor-builder-api-captures-%d.json", time.Now().Unix())))
+
+	writeJSON(w, http.StatusOK, BuilderAPICapturesResponse{
+		Captures: h.builderAPISvc.Captures(limit),
+	})
+}
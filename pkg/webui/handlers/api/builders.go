@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/lifecycle"
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+)
+
+// BuilderIdentity is an extra builder key run by this instance: its p2p
+// bidder and lifecycle manager (nil without).
+type BuilderIdentity struct {
+	Bidder    *p2p_bidder.Service
+	Lifecycle *lifecycle.Manager
+}
+
+// BuilderSummary is one builder key's identity, registration and balances.
+type BuilderSummary struct {
+	BuilderInfoEvent
+	Primary             bool     `json:"primary"` // the builder key (Builder API, reveals of Builder API wins)
+	RegistrationState   string   `json:"registration_state"`
+	IdentityError       string   `json:"identity_error,omitempty"`
+	LifecycleReadOnly   bool     `json:"lifecycle_read_only"`
+	OutstandingBidSlots []uint64 `json:"outstanding_bid_slots"`
+}
+
+// BuildersResponse lists the builder keys run by this instance.
+type BuildersResponse struct {
+	Builders []BuilderSummary `json:"builders"`
+}
+
+// SetExtraBuilders sets the extra builder keys listed next to the primary
+// builder.
+func (h *APIHandler) SetExtraBuilders(builders []BuilderIdentity) {
+	h.extraBuilders = builders
+}
+
+// GetBuilders godoc
+// @Id getBuilders
+// @Summary List builder keys
+// @Tags Buildoor
+// @Description Returns every builder key this instance bids with: the primary builder
+// @Description first, then the extra builder keys (--extra-builder-privkeys), each with
+// @Description its index, registration state, balances, wallet and the slots its bids
+// @Description are still outstanding for.
+// @Produce json
+// @Success 200 {object} BuildersResponse
+// @Router /api/builders [get]
+func (h *APIHandler) GetBuilders(w http.ResponseWriter, r *http.Request) {
+	resp := BuildersResponse{Builders: make([]BuilderSummary, 0, 1+len(h.extraBuilders))}
+
+	if h.epbsSvc != nil {
+		resp.Builders = append(resp.Builders, h.builderSummary(r.Context(), h.epbsSvc, h.lifecycleMgr, true))
+	}
+
+	for _, extra := range h.extraBuilders {
+		resp.Builders = append(resp.Builders, h.builderSummary(r.Context(), extra.Bidder, extra.Lifecycle, false))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// builderSummary collects one builder key's summary. The local balance
+// adjustment of the payment ledger only applies to the primary builder.
+func (h *APIHandler) builderSummary(
+	ctx context.Context, bidder *p2p_bidder.Service, lifecycleMgr *lifecycle.Manager, primary bool,
+) BuilderSummary {
+	pubkey := bidder.GetBuilderPubkey()
+
+	summary := BuilderSummary{
+		BuilderInfoEvent: BuilderInfoEvent{
+			BuilderPubkey: pubkey.String(),
+			BuilderIndex:  bidder.GetBuilderIndex(),
+			IsRegistered:  bidder.IsRegistered(),
+		},
+		Primary:             primary,
+		RegistrationState:   p2p_bidder.RegistrationStateName(bidder.GetRegistrationState()),
+		IdentityError:       bidder.IdentityError(),
+		OutstandingBidSlots: []uint64{},
+	}
+
+	if h.chainSvc != nil {
+		if info := h.chainSvc.GetBuilderByPubkey(pubkey); info != nil {
+			summary.CLBalance = info.Balance
+			summary.PendingPayments = info.PendingPayments
+			summary.DepositEpoch = info.DepositEpoch
+			summary.WithdrawableEpoch = info.WithdrawableEpoch
+		}
+	}
+
+	if primary && h.payments != nil {
+		adjusted := int64(summary.CLBalance) + h.payments.GetBalanceAdjustment()
+		if adjusted < 0 {
+			adjusted = 0
+		}
+
+		summary.CLBalance = uint64(adjusted)
+	}
+
+	if summary.CLBalance > summary.PendingPayments {
+		summary.EffectiveBalance = summary.CLBalance - summary.PendingPayments
+	}
+
+	if lifecycleMgr != nil {
+		summary.LifecycleEnabled = lifecycleMgr.IsEnabled()
+		summary.LifecycleReadOnly = lifecycleMgr.IsReadOnly()
+
+		if wallet := lifecycleMgr.GetWallet(); wallet != nil {
+			summary.WalletAddress = wallet.Address().Hex()
+
+			if balance, err := wallet.GetBalance(ctx); err == nil && balance != nil {
+				summary.WalletBalance = balance.String()
+			}
+		}
+	}
+
+	if bidder.GetBidTracker() != nil {
+		for _, slot := range bidder.OutstandingBidSlots() {
+			summary.OutstandingBidSlots = append(summary.OutstandingBidSlots, uint64(slot))
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/circuit_breaker"
+)
+
+// CircuitBreakerResponse is the circuit breaker state.
+type CircuitBreakerResponse struct {
+	Circuits []circuit_breaker.Circuit `json:"circuits"`
+}
+
+// ResetCircuitBreakerRequest selects the service to re-enable.
+type ResetCircuitBreakerRequest struct {
+	Service string `json:"service"` // epbs | builder_api
+}
+
+// GetCircuitBreaker godoc
+// @Id getCircuitBreaker
+// @Summary Get circuit breaker state
+// @Tags Buildoor
+// @Description Returns the consecutive-failure circuits (p2p bid submissions, p2p and
+// @Description Builder API reveals), their threshold and whether they tripped and
+// @Description disabled their service.
+// @Produce json
+// @Success 200 {object} CircuitBreakerResponse
+// @Failure 503 {object} map[string]string "Circuit breaker disabled"
+// @Router /api/buildoor/circuit-breaker [get]
+func (h *APIHandler) GetCircuitBreaker(w http.ResponseWriter, _ *http.Request) {
+	if h.breaker == nil {
+		writeError(w, http.StatusServiceUnavailable, "circuit breaker disabled")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CircuitBreakerResponse{Circuits: h.breaker.GetCircuits()})
+}
+
+// ResetCircuitBreaker godoc
+// @Id resetCircuitBreaker
+// @Summary Re-enable a service disabled by the circuit breaker
+// @Tags Buildoor
+// @Description Re-enables the service (epbs or builder_api) and closes its circuits.
+// @Description Requires authentication.
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body ResetCircuitBreakerRequest true "Service to re-enable"
+// @Success 200 {object} CircuitBreakerResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Circuit breaker disabled"
+// @Router /api/buildoor/circuit-breaker/reset [post]
+func (h *APIHandler) ResetCircuitBreaker(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.breaker == nil {
+		writeError(w, http.StatusServiceUnavailable, "circuit breaker disabled")
+		return
+	}
+
+	var req ResetCircuitBreakerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	key, ok := circuit_breaker.ServiceSetting(req.Service)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "unknown service: must be epbs or builder_api")
+		return
+	}
+
+	if (req.Service == circuit_breaker.ServiceEPBS && h.epbsSvc == nil) ||
+		(req.Service == circuit_breaker.ServiceBuilderAPI && h.builderAPISvc == nil) {
+		writeError(w, http.StatusBadRequest, "service not available")
+		return
+	}
+
+	// Close the circuits first so a failure racing the re-enable counts
+	// towards a fresh trip rather than being lost.
+	if err := h.breaker.Reset(req.Service); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.settingsSvc.Set(key, mustJSON(true), actorFromToken(token)); err != nil {
+		h.audit(r, token, "circuit_breaker.reset", req.Service, req, "error: "+err.Error())
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	h.audit(r, token, "circuit_breaker.reset", req.Service, req, "ok")
+
+	if h.eventStreamMgr != nil {
+		h.eventStreamMgr.BroadcastServiceStatus()
+	}
+
+	writeJSON(w, http.StatusOK, CircuitBreakerResponse{Circuits: h.breaker.GetCircuits()})
+}
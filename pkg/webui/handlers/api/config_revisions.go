@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+)
+
+// ConfigRevisionsResponse is the response for GetConfigRevisions.
+type ConfigRevisionsResponse struct {
+	Version   int64             `json:"version"`
+	Revisions []config.Revision `json:"revisions"`
+}
+
+// ConfigRollbackRequest is the request for RollbackConfig.
+type ConfigRollbackRequest struct {
+	Version int64 `json:"version"`
+}
+
+// ConfigRollbackResponse is the response for RollbackConfig.
+type ConfigRollbackResponse struct {
+	Status  string          `json:"status"`
+	Version int64           `json:"version"`
+	Changes []config.Change `json:"changes"`
+}
+
+// GetConfigRevisions godoc
+// @Id getConfigRevisions
+// @Summary Get the config revision history
+// @Tags Config
+// @Description Returns the newest runtime config revisions (API updates, operator config
+// @Description changes picked up on restart, rollbacks) with before/after values, newest
+// @Description first. Persisted when a state-db is configured.
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Maximum number of revisions (max 1000)" default(50)
+// @Success 200 {object} ConfigRevisionsResponse "Success"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /api/config/revisions [get]
+func (h *APIHandler) GetConfigRevisions(w http.ResponseWriter, r *http.Request) {
+	// Revisions name their actors: privileged like the audit log.
+	if h.authHandler.CheckAuthToken(r.Header.Get("Authorization")) == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit := 50
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	w.Header().Set("ETag", configETag(h.settingsSvc.Version()))
+	writeJSON(w, http.StatusOK, ConfigRevisionsResponse{
+		Version:   h.settingsSvc.Version(),
+		Revisions: h.settingsSvc.Revisions(limit),
+	})
+}
+
+// RollbackConfig godoc
+// @Id rollbackConfig
+// @Summary Roll the config back to a previous revision
+// @Tags Config
+// @Description Restores every setting changed after the given revision to the value it had
+// @Description at that revision (as UI overrides). The rollback is recorded as a revision
+// @Description itself. Honours If-Match like the other config updates. Requires authentication.
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param If-Match header string false "Settings ETag the rollback is based on (from GET /api/config)"
+// @Param request body ConfigRollbackRequest true "Revision to restore"
+// @Success 200 {object} ConfigRollbackResponse "Success"
+// @Header 200 {string} ETag "New settings version"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Unknown revision"
+// @Failure 412 {object} map[string]string "Settings changed since If-Match"
+// @Router /api/config/rollback [post]
+func (h *APIHandler) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req ConfigRollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var (
+		changes []config.Change
+		version int64
+	)
+
+	if !h.changeSettings(w, r, token, "config.rollback", req, func(ifVersion int64) ([]config.Change, int64, error) {
+		var err error
+		changes, version, err = h.settingsSvc.Rollback(req.Version, actorFromToken(token), ifVersion)
+
+		return changes, version, err
+	}) {
+		return
+	}
+
+	if changes == nil {
+		changes = []config.Change{}
+	}
+
+	if len(changes) > 0 && h.eventStreamMgr != nil {
+		h.eventStreamMgr.BroadcastConfigUpdate()
+	}
+
+	writeJSON(w, http.StatusOK, ConfigRollbackResponse{
+		Status:  "rolled_back",
+		Version: version,
+		Changes: changes,
+	})
+}
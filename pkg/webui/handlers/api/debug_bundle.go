@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/debug_bundle"
+)
+
+// GetDebugBundle godoc
+// @Id getDebugBundle
+// @Summary Download a post-mortem debug bundle for a slot
+// @Tags Buildoor
+// @Description Gathers everything known about the slot — redacted config, logs around the
+// @Description slot, the cached payload with its bid/reveal log, p2p bids seen, the recorded
+// @Description slot result, the action plan, raw SSZ artifacts and the beacon block — into a
+// @Description single tar.gz. Sections that could not be collected are listed in the bundle's
+// @Description manifest.json. Requires authentication.
+// @Produce application/gzip
+// @Param Authorization header string true "Bearer token"
+// @Param slot path int true "Slot"
+// @Success 200 {file} file "tar.gz bundle"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Server Error"
+// @Router /api/buildoor/debug-bundle/{slot} [get]
+func (h *APIHandler) GetDebugBundle(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	src := &debug_bundle.Sources{
+		Config:       configToMap(h.builderSvc.GetConfig()),
+		ChainSvc:     h.chainSvc,
+		Results:      h.resultTracker,
+		Plans:        h.planSvc,
+		PayloadCache: h.builderSvc.GetPayloadCache(),
+		Logs:         h.logBuffer,
+	}
+
+	// Typed nil pointers must not become non-nil interface values.
+	if clClient := h.builderSvc.GetCLClient(); clClient != nil {
+		src.Blocks = clClient
+	}
+
+	if h.epbsSvc != nil {
+		src.BidTracker = h.epbsSvc.GetBidTracker()
+	}
+
+	// Buffer the bundle so a collection failure can still be reported as a
+	// JSON error instead of a truncated download.
+	var buf bytes.Buffer
+	if err := debug_bundle.Write(r.Context(), &buf, slot, src); err != nil {
+		logrus.WithError(err).WithField("module", "webui-api").Warn("failed to generate debug bundle")
+		writeError(w, http.StatusInternalServerError, "failed to generate debug bundle: "+err.Error())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("buildoor-debug-slot-%d.tar.gz", slot)))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
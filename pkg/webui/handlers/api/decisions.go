@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/audit"
+)
+
+// SlotDecisionsResponse is the decision journal of one slot.
+type SlotDecisionsResponse struct {
+	Slot      uint64           `json:"slot"`
+	Decisions []audit.Decision `json:"decisions"`
+}
+
+// SetDecisionJournal wires the per-slot decision journal served by
+// GetSlotDecisions.
+func (h *APIHandler) SetDecisionJournal(journal *audit.Journal) {
+	h.decisions = journal
+}
+
+// GetSlotDecisions godoc
+// @Id getSlotDecisions
+// @Summary Get the decision journal of a slot
+// @Tags ActionPlan
+// @Description Returns why buildoor did or did not build, bid and reveal for
+// @Description the slot, in recording order: one entry per stage decision
+// @Description with its outcome (done, skipped, failed), a reason code and
+// @Description detail. Returns an empty list for slots without decisions.
+// @Produce json
+// @Param slot path int true "Slot"
+// @Success 200 {object} SlotDecisionsResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 503 {object} map[string]string "Decision journal unavailable"
+// @Router /api/slots/{slot}/decisions [get]
+func (h *APIHandler) GetSlotDecisions(w http.ResponseWriter, r *http.Request) {
+	if h.decisions == nil {
+		writeError(w, http.StatusServiceUnavailable, "decision journal not available")
+		return
+	}
+
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	decisions := h.decisions.Decisions(slot)
+	if decisions == nil {
+		decisions = []audit.Decision{}
+	}
+
+	writeJSON(w, http.StatusOK, &SlotDecisionsResponse{
+		Slot:      uint64(slot),
+		Decisions: decisions,
+	})
+}
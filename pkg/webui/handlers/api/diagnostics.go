@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/utils"
+)
+
+// SubscriptionDiagnosticsResponse is the response for GetSubscriptionDiagnostics.
+type SubscriptionDiagnosticsResponse struct {
+	Subscriptions []utils.SubscriptionStats `json:"subscriptions"`
+	TotalDropped  uint64                    `json:"total_dropped"`
+}
+
+// GetSubscriptionDiagnostics godoc
+// @Id getSubscriptionDiagnostics
+// @Summary Get internal event subscription diagnostics
+// @Tags Buildoor
+// @Description Lists every live internal event subscription (dispatcher event type, buffer
+// @Description capacity, current lag, buffer high-water mark, delivered and dropped events).
+// @Description A growing dropped count means the subscriber cannot keep up.
+// @Produce json
+// @Success 200 {object} SubscriptionDiagnosticsResponse "Success"
+// @Router /api/buildoor/diagnostics/subscriptions [get]
+func (h *APIHandler) GetSubscriptionDiagnostics(w http.ResponseWriter, _ *http.Request) {
+	resp := SubscriptionDiagnosticsResponse{Subscriptions: utils.Subscriptions()}
+	for _, stats := range resp.Subscriptions {
+		resp.TotalDropped += stats.Dropped
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+)
+
+// GetEpochSummary godoc
+// @Id getEpochSummary
+// @Summary Get the builder activity summary of an epoch
+// @Tags Stats
+// @Description Returns the aggregated builder activity of a finished epoch: slots
+// @Description built, bids sent and won, value earned, reveals missed and head vote
+// @Description participation averages. Summaries are emitted as epoch_summary SSE
+// @Description events at each epoch boundary; epochs not summarized while running
+// @Description are recomputed from the retained slot results (without participation).
+// @Produce json
+// @Param epoch path int true "Epoch"
+// @Success 200 {object} epoch_summary.EpochSummary
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Epoch not finished yet"
+// @Failure 503 {object} map[string]string "Epoch summaries unavailable"
+// @Router /api/buildoor/epochs/{epoch} [get]
+func (h *APIHandler) GetEpochSummary(w http.ResponseWriter, r *http.Request) {
+	epoch, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid epoch: must be a number")
+		return
+	}
+
+	if h.epochSummaries == nil {
+		writeError(w, http.StatusServiceUnavailable, "epoch summaries unavailable")
+		return
+	}
+
+	summary, ok := h.epochSummaries.GetSummary(phase0.Epoch(epoch))
+	if !ok {
+		writeError(w, http.StatusNotFound, "epoch not finished yet")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
@@ -6,15 +6,21 @@ import (
 	"fmt"
 	mathbits "math/bits"
 	"net/http"
+	"reflect"
 	"sync"
 	"time"
 
 	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
 	"github.com/ethpandaops/buildoor/pkg/builderapi"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/circuit_breaker"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/epoch_summary"
 	"github.com/ethpandaops/buildoor/pkg/lifecycle"
 	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
@@ -22,6 +28,7 @@ import (
 	"github.com/ethpandaops/buildoor/pkg/rpc/beacon"
 	"github.com/ethpandaops/buildoor/pkg/slot_results"
 	"github.com/ethpandaops/buildoor/pkg/utils"
+	"github.com/ethpandaops/buildoor/pkg/wallet"
 )
 
 // EventType represents the type of event being streamed.
@@ -61,6 +68,10 @@ const (
 	EventTypeSlotResultUpdated           EventType = "slot_result_updated"
 	EventTypeLifecycle                   EventType = "lifecycle"
 	EventTypeBidIncluded                 EventType = "bid_included"
+	EventTypeEpochSummary                EventType = "epoch_summary"
+	EventTypeAlert                       EventType = "alert"
+	EventTypeCircuitBreaker              EventType = "circuit_breaker"
+	EventTypeWalletTx                    EventType = "wallet_tx"
 )
 
 // StreamEvent is a wrapper for all event types sent to clients.
@@ -196,10 +207,12 @@ type RevealStreamEvent struct {
 	Transport   string `json:"transport,omitempty"`
 	Success     bool   `json:"success"`
 	Skipped     bool   `json:"skipped"`
-	SkipReason  string `json:"skip_reason,omitempty"` // plan_disabled | disabled | late | vote_gate_timeout (skips only)
+	SkipReason  string `json:"skip_reason,omitempty"` // plan_disabled | disabled | late | vote_gate_timeout | not_included | chaos_withheld (skips only)
+	Chaos       string `json:"chaos,omitempty"`       // withhold | delay (reveal chaos)
 	Error       string `json:"error,omitempty"`
 	Attempt     int    `json:"attempt,omitempty"`
 	MaxAttempts int    `json:"max_attempts,omitempty"`
+	Manual      bool   `json:"manual,omitempty"` // operator-triggered publish
 	StartedAt   int64  `json:"started_at,omitempty"`
 	Timestamp   int64  `json:"timestamp"`
 
@@ -398,17 +411,25 @@ type ServiceStatusEvent struct {
 	EPBSAvailable         bool   `json:"epbs_available"`
 	EPBSEnabled           bool   `json:"epbs_enabled"`
 	EPBSRegistrationState string `json:"epbs_registration_state"`
+	EPBSIdentityError     string `json:"epbs_identity_error,omitempty"` // builder record mismatch halting bidding
+	EPBSBidHold           string `json:"epbs_bid_hold,omitempty"`       // penalty monitor hold halting bidding
 	BuilderAPIAvailable   bool   `json:"builder_api_available"`
 	BuilderAPIEnabled     bool   `json:"builder_api_enabled"`
 	LifecycleAvailable    bool   `json:"lifecycle_available"`
 	LifecycleEnabled      bool   `json:"lifecycle_enabled"`
+	LifecycleReadOnly     bool   `json:"lifecycle_read_only,omitempty"`
 }
 
 // LifecycleStreamEvent is sent when a lifecycle action occurs (deposit, topup, exit, state change).
+// Code and Params are the machine-readable form of Message (e.g. code
+// "topup_confirmed" with params {"amount_gwei": 1000000000}), so consumers can
+// render or translate the event without parsing the English description.
 type LifecycleStreamEvent struct {
-	Action  string `json:"action"`  // "deposit", "topup", "exit", "state_change", "waiting_gloas", "balance_topup"
-	Message string `json:"message"` // Human-readable description
-	Status  string `json:"status"`  // "info", "success", "warning", "error"
+	Action  string         `json:"action"` // "deposit", "topup", "exit", "state_change", "waiting_gloas", "balance_topup"
+	Code    string         `json:"code,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
+	Message string         `json:"message"` // Human-readable description
+	Status  string         `json:"status"`  // "info", "success", "warning", "error"
 }
 
 // BuilderAPIGetHeaderReceivedEvent is sent when a getHeader request is received.
@@ -484,6 +505,10 @@ type EventStreamManager struct {
 	payments         *payload_bidder.PaymentTracker   // Optional shared payment tracker (Gloas+)
 	planSvc          *action_plan.PlanService         // Optional action plan service
 	resultTracker    *slot_results.Tracker            // Optional slot results tracker
+	epochSummaries   *epoch_summary.Aggregator        // Optional epoch summary aggregator
+	alerts           *alerting.Engine                 // Optional alerting rules engine
+	breaker          *circuit_breaker.Breaker         // Optional circuit breaker
+	settingsSvc      *config.Service                  // Optional; versions the config events
 
 	clients map[chan *StreamEvent]struct{}
 	// mu guards clients, eventCache and seq. Broadcasts, cache appends and
@@ -514,6 +539,9 @@ type EventStreamManager struct {
 	lastBuilderInfo   BuilderInfoEvent
 	lastBuilderInfoMu sync.Mutex
 
+	// Builder balance time series, fed by the builder info poll
+	balanceHistory *BalanceHistory
+
 	// Track last sent service status to avoid spam
 	lastServiceStatus   ServiceStatusEvent
 	lastServiceStatusMu sync.Mutex
@@ -531,6 +559,10 @@ func NewEventStreamManager(
 	payments *payload_bidder.PaymentTracker,
 	planSvc *action_plan.PlanService,
 	resultTracker *slot_results.Tracker,
+	epochSummaries *epoch_summary.Aggregator,
+	alerts *alerting.Engine,
+	breaker *circuit_breaker.Breaker,
+	settingsSvc *config.Service,
 ) *EventStreamManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -545,6 +577,10 @@ func NewEventStreamManager(
 		payments:         payments,
 		planSvc:          planSvc,
 		resultTracker:    resultTracker,
+		epochSummaries:   epochSummaries,
+		alerts:           alerts,
+		breaker:          breaker,
+		settingsSvc:      settingsSvc,
 		clients:          make(map[chan *StreamEvent]struct{}, 8),
 		eventCache:       make([]cachedStreamEvent, 0, 256),
 		// Seed the sequence from wall-clock micros so it stays monotonic
@@ -556,6 +592,8 @@ func NewEventStreamManager(
 		cancel:        cancel,
 		slotStates:    make(map[phase0.Slot]*SlotStateEvent, 16),
 		seenHeadRoots: make(map[phase0.Slot]map[phase0.Root]struct{}, 16),
+
+		balanceHistory: NewBalanceHistory(balanceHistoryCapacity),
 	}
 }
 
@@ -617,7 +655,13 @@ func (m *EventStreamManager) Start() {
 	// Wire lifecycle event callback (if lifecycle manager available)
 	if m.lifecycleMgr != nil {
 		m.lifecycleMgr.SetEventCallback(func(event *lifecycle.LifecycleEvent) {
-			m.BroadcastLifecycle(event.Action, event.Message, event.Status)
+			m.BroadcastLifecycle(LifecycleStreamEvent{
+				Action:  event.Action,
+				Code:    event.Code,
+				Params:  event.Params,
+				Message: event.Message,
+				Status:  event.Status,
+			})
 		})
 	}
 
@@ -643,6 +687,48 @@ func (m *EventStreamManager) Start() {
 		resultUpdateChan = resultUpdateSub.Channel()
 	}
 
+	// Subscribe to finished-epoch summaries (if the aggregator is running)
+	var epochSummarySub *utils.Subscription[*epoch_summary.EpochSummary]
+
+	var epochSummaryChan <-chan *epoch_summary.EpochSummary
+
+	if m.epochSummaries != nil {
+		epochSummarySub = m.epochSummaries.SubscribeSummaries(8)
+		epochSummaryChan = epochSummarySub.Channel()
+	}
+
+	// Subscribe to alert transitions (if the alerting engine is running)
+	var alertSub *utils.Subscription[*alerting.Alert]
+
+	var alertChan <-chan *alerting.Alert
+
+	if m.alerts != nil {
+		alertSub = m.alerts.SubscribeAlerts(16)
+		alertChan = alertSub.Channel()
+	}
+
+	// Subscribe to circuit trips/resets (if the circuit breaker is enabled)
+	var circuitSub *utils.Subscription[*circuit_breaker.Circuit]
+
+	var circuitChan <-chan *circuit_breaker.Circuit
+
+	if m.breaker != nil {
+		circuitSub = m.breaker.SubscribeChanges(16)
+		circuitChan = circuitSub.Channel()
+	}
+
+	// Subscribe to wallet transaction updates (if lifecycle has a wallet)
+	var walletTxSub *utils.Subscription[*wallet.TxRecord]
+
+	var walletTxChan <-chan *wallet.TxRecord
+
+	if m.lifecycleMgr != nil {
+		if w := m.lifecycleMgr.GetWallet(); w != nil {
+			walletTxSub = w.SubscribeTransactions(16)
+			walletTxChan = walletTxSub.Channel()
+		}
+	}
+
 	// Subscribe to head vote + subnet coverage updates (if chain service available)
 	var hvSub *utils.Subscription[*chain.HeadVoteUpdate]
 
@@ -656,7 +742,14 @@ func (m *EventStreamManager) Start() {
 
 	var blockDetailChan <-chan *eth2all.SignedBeaconBlock
 
+	var forkSub *utils.Subscription[*chain.ForkTransition]
+
+	var forkChan <-chan *chain.ForkTransition
+
 	if m.chainSvc != nil {
+		forkSub = m.chainSvc.SubscribeForkTransitions()
+		forkChan = forkSub.Channel()
+
 		if tracker := m.chainSvc.GetHeadVoteTracker(); tracker != nil {
 			covSub = tracker.SubscribeCoverage()
 			coverageChan = covSub.Channel()
@@ -707,6 +800,10 @@ func (m *EventStreamManager) Start() {
 			defer blockDetailSub.Unsubscribe()
 		}
 
+		if forkSub != nil {
+			defer forkSub.Unsubscribe()
+		}
+
 		if planChangeSub != nil {
 			defer planChangeSub.Unsubscribe()
 		}
@@ -715,158 +812,237 @@ func (m *EventStreamManager) Start() {
 			defer resultUpdateSub.Unsubscribe()
 		}
 
-		// Slot tracking ticker
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
-
-		var lastSlot phase0.Slot
-
-		for {
-			select {
-			case <-m.ctx.Done():
-				return
-
-			case event := <-payloadSub.Channel():
-				m.handlePayloadReady(event)
-
-			case event := <-buildStartedSub.Channel():
-				m.handlePayloadBuildStarted(event)
-
-			case event := <-buildFailedSub.Channel():
-				m.handlePayloadBuildFailed(event)
-
-			case event := <-headSub.Channel():
-				m.handleHeadEvent(event)
-
-			case event := <-bidSub.Channel():
-				m.handleBidEvent(event)
-
-			case event := <-payloadAvailSub.Channel():
-				m.handlePayloadAvailableEvent(event)
-
-			case event := <-payloadAttrSub.Channel():
-				m.handlePayloadAttributesEvent(event)
-
-			case event, ok := <-bidSubmitChan:
-				if !ok {
-					// Channel closed: disable this select case (nil channels block forever).
-					bidSubmitChan = nil
-					continue
-				}
-
-				m.handleBidSubmissionEvent(event)
-
-			case event, ok := <-headVoteChan:
-				if !ok {
-					headVoteChan = nil
-					continue
-				}
-
-				m.handleHeadVoteUpdate(event)
-
-			case event, ok := <-coverageChan:
-				if !ok {
-					coverageChan = nil
-					continue
-				}
-
-				m.Broadcast(&StreamEvent{
-					Type:      EventTypeVoteCoverage,
-					Timestamp: time.Now().UnixMilli(),
-					Data:      voteCoverageEvent(event),
-				})
-
-			case event, ok := <-blockDetailChan:
-				if !ok {
-					blockDetailChan = nil
-					continue
-				}
-
-				m.handleBlockDetail(event)
-
-			case event, ok := <-planChangeChan:
-				if !ok {
-					planChangeChan = nil
-					continue
-				}
-
-				m.Broadcast(&StreamEvent{
-					Type:      EventTypeActionPlanUpdated,
-					Timestamp: time.Now().UnixMilli(),
-					Data:      event,
-				})
-
-			case event, ok := <-resultUpdateChan:
-				if !ok {
-					resultUpdateChan = nil
-					continue
-				}
-
-				m.Broadcast(&StreamEvent{
-					Type:      EventTypeSlotResultUpdated,
-					Timestamp: time.Now().UnixMilli(),
-					Data:      event,
-				})
-
-			case event, ok := <-revealChan:
-				if !ok {
-					revealChan = nil
-					continue
-				}
+		if epochSummarySub != nil {
+			defer epochSummarySub.Unsubscribe()
+		}
 
-				m.BroadcastReveal(event)
+		if alertSub != nil {
+			defer alertSub.Unsubscribe()
+		}
 
-			case event, ok := <-revealStartChan:
-				if !ok {
-					revealStartChan = nil
-					continue
-				}
+		if circuitSub != nil {
+			defer circuitSub.Unsubscribe()
+		}
 
-				m.broadcastForSlot(event.Slot, &StreamEvent{
-					Type:      EventTypeRevealStarted,
-					Timestamp: time.Now().UnixMilli(),
-					Data: RevealStartedStreamEvent{
-						Slot:      uint64(event.Slot),
-						Attempt:   event.Attempt,
-						StartedAt: event.StartedAt.UnixMilli(),
-						Timestamp: event.StartedAt.UnixMilli(),
-					},
-				})
-
-			case event, ok := <-bidIncludedChan:
-				if !ok {
-					bidIncludedChan = nil
-					continue
-				}
+		if walletTxSub != nil {
+			defer walletTxSub.Unsubscribe()
+		}
 
-				m.broadcastForSlot(event.Payload.Attributes.ProposalSlot, &StreamEvent{
-					Type:      EventTypeBidIncluded,
-					Timestamp: time.Now().UnixMilli(),
-					Data: map[string]any{
-						"slot":       uint64(event.Payload.Attributes.ProposalSlot),
-						"block_hash": fmt.Sprintf("0x%x", event.BlockInfo.ExecutionBlockHash[:]),
-						"bid_value":  event.BidValueGwei,
-					},
-				})
-
-				// The inclusion tracker's won-block record doubles as the
-				// bid_won event (Builder API and p2p wins alike).
-				if event.WonBlock != nil {
-					m.BroadcastBidWon(event.WonBlock)
-				}
+		// Slot tracking ticker
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
 
-			case <-ticker.C:
-				currentSlot := m.builderSvc.GetCurrentSlot()
-				if currentSlot != lastSlot {
-					lastSlot = currentSlot
-					m.handleSlotStart(currentSlot)
+		// A panicking handler must not silently kill the stream: the loop is
+		// supervised (restarted after a backoff); subscriptions live outside it.
+		utils.Supervise(m.ctx, "event-stream-manager", logrus.WithField("module", "webui-api"), func(ctx context.Context) {
+			var lastSlot phase0.Slot
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case event := <-payloadSub.Channel():
+					m.handlePayloadReady(event)
+
+				case event := <-buildStartedSub.Channel():
+					m.handlePayloadBuildStarted(event)
+
+				case event := <-buildFailedSub.Channel():
+					m.handlePayloadBuildFailed(event)
+
+				case event := <-headSub.Channel():
+					m.handleHeadEvent(event)
+
+				case event := <-bidSub.Channel():
+					m.handleBidEvent(event)
+
+				case event := <-payloadAvailSub.Channel():
+					m.handlePayloadAvailableEvent(event)
+
+				case event := <-payloadAttrSub.Channel():
+					m.handlePayloadAttributesEvent(event)
+
+				case event, ok := <-bidSubmitChan:
+					if !ok {
+						// Channel closed: disable this select case (nil channels block forever).
+						bidSubmitChan = nil
+						continue
+					}
+
+					m.handleBidSubmissionEvent(event)
+
+				case event, ok := <-headVoteChan:
+					if !ok {
+						headVoteChan = nil
+						continue
+					}
+
+					m.handleHeadVoteUpdate(event)
+
+				case event, ok := <-coverageChan:
+					if !ok {
+						coverageChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeVoteCoverage,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      voteCoverageEvent(event),
+					})
+
+				case event, ok := <-blockDetailChan:
+					if !ok {
+						blockDetailChan = nil
+						continue
+					}
+
+					m.handleBlockDetail(event)
+
+				case event, ok := <-forkChan:
+					if !ok {
+						forkChan = nil
+						continue
+					}
+
+					m.broadcastForkTransition(event)
+
+				case event, ok := <-planChangeChan:
+					if !ok {
+						planChangeChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeActionPlanUpdated,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      event,
+					})
+
+				case event, ok := <-resultUpdateChan:
+					if !ok {
+						resultUpdateChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeSlotResultUpdated,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      event,
+					})
+
+				case event, ok := <-epochSummaryChan:
+					if !ok {
+						epochSummaryChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeEpochSummary,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      event,
+					})
+
+				case event, ok := <-alertChan:
+					if !ok {
+						alertChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeAlert,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      event,
+					})
+
+				case event, ok := <-circuitChan:
+					if !ok {
+						circuitChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeCircuitBreaker,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      event,
+					})
+
+					// A trip flips a service enable flag.
+					m.BroadcastServiceStatus()
+
+				case event, ok := <-walletTxChan:
+					if !ok {
+						walletTxChan = nil
+						continue
+					}
+
+					m.Broadcast(&StreamEvent{
+						Type:      EventTypeWalletTx,
+						Timestamp: time.Now().UnixMilli(),
+						Data:      event,
+					})
+
+				case event, ok := <-revealChan:
+					if !ok {
+						revealChan = nil
+						continue
+					}
+
+					m.BroadcastReveal(event)
+
+				case event, ok := <-revealStartChan:
+					if !ok {
+						revealStartChan = nil
+						continue
+					}
+
+					m.broadcastForSlot(event.Slot, &StreamEvent{
+						Type:      EventTypeRevealStarted,
+						Timestamp: time.Now().UnixMilli(),
+						Data: RevealStartedStreamEvent{
+							Slot:      uint64(event.Slot),
+							Attempt:   event.Attempt,
+							StartedAt: event.StartedAt.UnixMilli(),
+							Timestamp: event.StartedAt.UnixMilli(),
+						},
+					})
+
+				case event, ok := <-bidIncludedChan:
+					if !ok {
+						bidIncludedChan = nil
+						continue
+					}
+
+					m.broadcastForSlot(event.Payload.Attributes.ProposalSlot, &StreamEvent{
+						Type:      EventTypeBidIncluded,
+						Timestamp: time.Now().UnixMilli(),
+						Data: map[string]any{
+							"slot":       uint64(event.Payload.Attributes.ProposalSlot),
+							"block_hash": fmt.Sprintf("0x%x", event.BlockInfo.ExecutionBlockHash[:]),
+							"bid_value":  event.BidValueGwei,
+						},
+					})
+
+					// The inclusion tracker's won-block record doubles as the
+					// bid_won event (Builder API and p2p wins alike).
+					if event.WonBlock != nil {
+						m.BroadcastBidWon(event.WonBlock)
+					}
+
+				case <-ticker.C:
+					currentSlot := m.builderSvc.GetCurrentSlot()
+					if currentSlot != lastSlot {
+						lastSlot = currentSlot
+						m.handleSlotStart(currentSlot)
+					}
+					// Periodically send stats, builder info, and service status
+					m.sendStats()
+					m.sendBuilderInfo()
+					m.sendServiceStatus()
 				}
-				// Periodically send stats, builder info, and service status
-				m.sendStats()
-				m.sendBuilderInfo()
-				m.sendServiceStatus()
 			}
-		}
+		})
 	}()
 }
 
@@ -1392,6 +1568,19 @@ func (m *EventStreamManager) buildStatsResponse() StatsResponse {
 		RevealsSuccess: stats.RevealsSuccess,
 		RevealsFailed:  stats.RevealsFailed,
 		RevealsSkipped: stats.RevealsSkipped,
+
+		RevealsSkippedNotIncluded: stats.RevealsSkippedNotIncluded,
+
+		RevealsChaosWithheld: stats.RevealsChaosWithheld,
+		RevealsChaosDelayed:  stats.RevealsChaosDelayed,
+
+		CanaryMode:          m.builderSvc.GetConfig().Canary.Enabled,
+		CanaryBidsSubmitted: stats.CanaryBidsSubmitted,
+		ValueBoostPct:       m.builderSvc.GetConfig().BuilderAPI.ValueBoostPct,
+
+		WithdrawalMismatches: stats.WithdrawalMismatches,
+		ELHeadWithdrawals:    stats.ELHeadWithdrawals,
+		EngineWins:           stats.EngineWins,
 	}
 
 	if m.builderAPISvc != nil {
@@ -1409,7 +1598,7 @@ func (m *EventStreamManager) sendStats() {
 
 	// Only send if stats changed
 	m.lastStatsMu.Lock()
-	changed := resp != m.lastStats
+	changed := !reflect.DeepEqual(resp, m.lastStats)
 	if changed {
 		m.lastStats = resp
 	}
@@ -1428,6 +1617,7 @@ func (m *EventStreamManager) sendStats() {
 
 func (m *EventStreamManager) sendBuilderInfo() {
 	info := m.getBuilderInfo()
+	m.recordBalance(&info)
 
 	// Only send if info changed
 	m.lastBuilderInfoMu.Lock()
@@ -1448,6 +1638,28 @@ func (m *EventStreamManager) sendBuilderInfo() {
 	})
 }
 
+// recordBalance appends the polled balances to the balance history. Polls
+// without a builder identity or wallet carry no balances and are skipped.
+func (m *EventStreamManager) recordBalance(info *BuilderInfoEvent) {
+	if info.BuilderPubkey == "" && info.WalletAddress == "" {
+		return
+	}
+
+	m.balanceHistory.Record(BalanceSample{
+		Timestamp:        time.Now().UnixMilli(),
+		Slot:             uint64(m.builderSvc.GetCurrentSlot()),
+		CLBalance:        info.CLBalance,
+		PendingPayments:  info.PendingPayments,
+		EffectiveBalance: info.EffectiveBalance,
+		WalletBalance:    info.WalletBalance,
+	})
+}
+
+// GetBalanceHistory returns the recorded builder balance time series.
+func (m *EventStreamManager) GetBalanceHistory() *BalanceHistory {
+	return m.balanceHistory
+}
+
 func (m *EventStreamManager) getBuilderInfo() BuilderInfoEvent {
 	info := BuilderInfoEvent{}
 
@@ -1502,19 +1714,24 @@ func (m *EventStreamManager) getBuilderInfo() BuilderInfoEvent {
 }
 
 func (m *EventStreamManager) getServiceStatus() ServiceStatusEvent {
-	regState := "unknown"
+	regState, identityErr, bidHold := "unknown", "", ""
 	if m.epbsSvc != nil {
 		regState = p2p_bidder.RegistrationStateName(m.epbsSvc.GetRegistrationState())
+		identityErr = m.epbsSvc.IdentityError()
+		bidHold = m.epbsSvc.BidHold()
 	}
 
 	return ServiceStatusEvent{
 		EPBSAvailable:         m.epbsSvc != nil,
 		EPBSEnabled:           m.epbsSvc != nil && m.epbsSvc.IsEnabled(),
 		EPBSRegistrationState: regState,
+		EPBSIdentityError:     identityErr,
+		EPBSBidHold:           bidHold,
 		BuilderAPIAvailable:   m.builderAPISvc != nil,
 		BuilderAPIEnabled:     m.builderAPISvc != nil && m.builderAPISvc.IsEnabled(),
 		LifecycleAvailable:    m.lifecycleMgr != nil,
 		LifecycleEnabled:      m.lifecycleMgr != nil && m.lifecycleMgr.IsEnabled(),
+		LifecycleReadOnly:     m.lifecycleMgr != nil && m.lifecycleMgr.IsReadOnly(),
 	}
 }
 
@@ -1577,7 +1794,31 @@ func (m *EventStreamManager) emitRegistrationStateChange(from, to string) {
 		logStatus = "info"
 	}
 
-	m.BroadcastLifecycle("state_change", message, logStatus)
+	m.BroadcastLifecycle(LifecycleStreamEvent{
+		Action:  "state_change",
+		Code:    "registration_state",
+		Params:  map[string]any{"from_state": from, "to_state": to},
+		Message: message,
+		Status:  logStatus,
+	})
+}
+
+// broadcastForkTransition announces a runtime fork transition in the event
+// log and refreshes the service status (fork-dependent availability).
+func (m *EventStreamManager) broadcastForkTransition(event *chain.ForkTransition) {
+	m.BroadcastLifecycle(LifecycleStreamEvent{
+		Action: "state_change",
+		Code:   "fork_transition",
+		Params: map[string]any{
+			"epoch":         uint64(event.Epoch),
+			"previous_fork": event.Previous.String(),
+			"fork":          event.Current.String(),
+		},
+		Message: fmt.Sprintf("Fork transition: %s → %s at epoch %d", event.Previous, event.Current, event.Epoch),
+		Status:  "success",
+	})
+
+	m.BroadcastServiceStatus()
 }
 
 // BroadcastServiceStatus broadcasts the current service status.
@@ -1627,7 +1868,7 @@ func (m *EventStreamManager) SendInitialState(ctx context.Context, ch chan *Stre
 	if !send(&StreamEvent{
 		Type:      EventTypeConfig,
 		Timestamp: time.Now().UnixMilli(),
-		Data:      m.builderSvc.GetConfig(),
+		Data:      m.configEvent(),
 	}) {
 		return
 	}
@@ -1874,6 +2115,8 @@ func (m *EventStreamManager) BroadcastReveal(event *payload_bidder.RevealResult)
 		Error:       event.Error,
 		Attempt:     event.Attempt,
 		MaxAttempts: event.MaxAttempts,
+		Manual:      event.Manual,
+		Chaos:       event.Chaos,
 		StartedAt:   startedAt,
 		Timestamp:   completedAt,
 	}
@@ -1902,14 +2145,30 @@ func (m *EventStreamManager) BroadcastReveal(event *payload_bidder.RevealResult)
 
 // BroadcastConfigUpdate broadcasts a config update event.
 func (m *EventStreamManager) BroadcastConfigUpdate() {
-	cfg := m.builderSvc.GetConfig()
 	m.Broadcast(&StreamEvent{
 		Type:      EventTypeConfig,
 		Timestamp: time.Now().UnixMilli(),
-		Data:      cfg,
+		Data:      m.configEvent(),
 	})
 }
 
+// ConfigEvent is the config event payload: the config plus the settings
+// version it reflects (the ETag edits send back as If-Match).
+type ConfigEvent struct {
+	*config.Config
+	Version int64 `json:"version"`
+}
+
+// configEvent snapshots the config with its settings version.
+func (m *EventStreamManager) configEvent() ConfigEvent {
+	event := ConfigEvent{Config: m.builderSvc.GetConfig()}
+	if m.settingsSvc != nil {
+		event.Version = m.settingsSvc.Version()
+	}
+
+	return event
+}
+
 // BroadcastBuilderAPIGetHeaderReceived broadcasts when a getHeader request is received.
 func (m *EventStreamManager) BroadcastBuilderAPIGetHeaderReceived(slot uint64, parentHash, pubkey string) {
 	now := time.Now().UnixMilli()
@@ -2048,14 +2307,10 @@ func (m *EventStreamManager) BroadcastBidWon(wonBlock *payload_bidder.WonBlock)
 // Lifecycle events have no slot of their own; they are tagged with the
 // current slot so they participate in the connect-time replay of the event
 // log alongside the slot-scoped events.
-func (m *EventStreamManager) BroadcastLifecycle(action, message, status string) {
+func (m *EventStreamManager) BroadcastLifecycle(event LifecycleStreamEvent) {
 	m.broadcastForSlot(m.builderSvc.GetCurrentSlot(), &StreamEvent{
 		Type:      EventTypeLifecycle,
 		Timestamp: time.Now().UnixMilli(),
-		Data: LifecycleStreamEvent{
-			Action:  action,
-			Message: message,
-			Status:  status,
-		},
+		Data:      event,
 	})
 }
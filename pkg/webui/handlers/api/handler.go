@@ -5,15 +5,26 @@ import (
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
+	"github.com/ethpandaops/buildoor/pkg/audit"
 	"github.com/ethpandaops/buildoor/pkg/builderapi"
+	"github.com/ethpandaops/buildoor/pkg/builderapi/legacy"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/circuit_breaker"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/debug_bundle"
+	"github.com/ethpandaops/buildoor/pkg/epoch_summary"
 	"github.com/ethpandaops/buildoor/pkg/lifecycle"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
 	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/peer_mesh"
+	"github.com/ethpandaops/buildoor/pkg/penalty_monitor"
+	"github.com/ethpandaops/buildoor/pkg/preflight"
+	"github.com/ethpandaops/buildoor/pkg/probe"
+	"github.com/ethpandaops/buildoor/pkg/relay_proxy"
 	"github.com/ethpandaops/buildoor/pkg/slot_results"
 	"github.com/ethpandaops/buildoor/pkg/validatorranges"
 	"github.com/ethpandaops/buildoor/pkg/webui/handlers/auth"
@@ -39,6 +50,18 @@ type APIHandler struct {
 	payments         *payload_bidder.PaymentTracker   // May be nil (Gloas not scheduled)
 	planSvc          *action_plan.PlanService         // May be nil
 	resultTracker    *slot_results.Tracker            // May be nil
+	peerMesh         *peer_mesh.Service               // May be nil (Gloas not scheduled)
+	logBuffer        *debug_bundle.LogBuffer          // May be nil (log capture disabled)
+	epochSummaries   *epoch_summary.Aggregator        // May be nil
+	alerts           *alerting.Engine                 // May be nil (no rules file)
+	breaker          *circuit_breaker.Breaker         // May be nil (threshold 0)
+	penalties        *penalty_monitor.Monitor         // May be nil (see SetPenaltyMonitor)
+	relayProxy       *relay_proxy.Service             // May be nil (no relays configured)
+	regBackfill      *legacy.RegistrationBackfill     // May be nil (see SetRegistrationBackfill)
+	extraBuilders    []BuilderIdentity                // Extra builder keys (see SetExtraBuilders)
+	degradations     probe.Degradations               // Features disabled by the capability check (see SetDegradations)
+	preflight        *preflight.Report                // Startup report (see SetPreflight)
+	decisions        *audit.Journal                   // Per-slot decision journal (see SetDecisionJournal)
 }
 
 // NewAPIHandler creates a new API handler.
@@ -59,6 +82,12 @@ func NewAPIHandler(
 	payments *payload_bidder.PaymentTracker,
 	planSvc *action_plan.PlanService,
 	resultTracker *slot_results.Tracker,
+	peerMesh *peer_mesh.Service,
+	logBuffer *debug_bundle.LogBuffer,
+	epochSummaries *epoch_summary.Aggregator,
+	alerts *alerting.Engine,
+	breaker *circuit_breaker.Breaker,
+	relayProxy *relay_proxy.Service,
 ) *APIHandler {
 	h := &APIHandler{
 		authHandler:    authHandler,
@@ -78,6 +107,12 @@ func NewAPIHandler(
 		payments:         payments,
 		planSvc:          planSvc,
 		resultTracker:    resultTracker,
+		peerMesh:         peerMesh,
+		logBuffer:        logBuffer,
+		epochSummaries:   epochSummaries,
+		alerts:           alerts,
+		breaker:          breaker,
+		relayProxy:       relayProxy,
 	}
 
 	// Create and start event stream manager
@@ -85,7 +120,8 @@ func NewAPIHandler(
 		h.eventStreamMgr = NewEventStreamManager(
 			builderSvc, epbsSvc, lifecycleMgr, chainSvc,
 			builderAPISvc, revealSvc, inclusionTracker, payments,
-			planSvc, resultTracker,
+			planSvc, resultTracker, epochSummaries, alerts, breaker,
+			settingsSvc,
 		)
 		h.eventStreamMgr.Start()
 	}
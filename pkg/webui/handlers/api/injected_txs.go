@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+)
+
+// InjectTxsRequest queues raw signed transactions for the next built payloads.
+type InjectTxsRequest struct {
+	// Raw signed transactions (0x-prefixed hex); blob transactions in their
+	// network form with sidecar
+	Txs []string `json:"txs"`
+}
+
+// InjectedTxResponse is a transaction queued for injection and its state.
+type InjectedTxResponse struct {
+	Hash     string `json:"hash"`
+	Type     uint8  `json:"type"`
+	Nonce    uint64 `json:"nonce"`
+	Size     uint64 `json:"size"` // Encoded size in bytes (with blob sidecar)
+	Blobs    int    `json:"blobs"`
+	QueuedAt int64  `json:"queued_at"` // Unix milliseconds
+	Status   string `json:"status"`    // pending | included | dropped | failed
+	Attempts int    `json:"attempts"`  // Builds the transaction was submitted for
+	Error    string `json:"error,omitempty"`
+	// First built payload carrying the transaction (status included)
+	IncludedSlot      uint64 `json:"included_slot,omitempty"`
+	IncludedBlockHash string `json:"included_block_hash,omitempty"`
+}
+
+// InjectedTxsResponse lists the pending and recently finished injected
+// transactions.
+type InjectedTxsResponse struct {
+	Transactions []InjectedTxResponse `json:"transactions"`
+}
+
+// GetInjectedTxs godoc
+// @Id getInjectedTxs
+// @Summary Injected transactions
+// @Tags Buildoor
+// @Description Returns the transactions queued for injection into built payloads, pending ones
+// @Description first, then the recently included, dropped or failed ones (newest first).
+// @Produce json
+// @Success 200 {object} InjectedTxsResponse "Success"
+// @Failure 503 {object} map[string]string "Transaction injection not available"
+// @Router /api/buildoor/txs [get]
+func (h *APIHandler) GetInjectedTxs(w http.ResponseWriter, _ *http.Request) {
+	injector := h.builderSvc.TxInjector()
+	if injector == nil {
+		writeError(w, http.StatusServiceUnavailable, "transaction injection not available (requires --el-rpc)")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, InjectedTxsResponse{Transactions: injectedTxResponses(injector.Transactions())})
+}
+
+// InjectTxs godoc
+// @Id injectTxs
+// @Summary Inject transactions into built payloads
+// @Tags Buildoor
+// @Description Queues raw signed transactions for the next built payloads (legacy and ePBS).
+// @Description They are submitted to the EL mempool ahead of every build until a built payload
+// @Description carries them, or dropped after 8 builds without; the mempool gossips them, so
+// @Description other builders may include them too. Requires authentication.
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body InjectTxsRequest true "Raw signed transactions"
+// @Success 200 {object} InjectedTxsResponse "Queued transactions"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Queue full"
+// @Failure 503 {object} map[string]string "Transaction injection not available"
+// @Router /api/buildoor/txs [post]
+func (h *APIHandler) InjectTxs(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	injector := h.builderSvc.TxInjector()
+	if injector == nil {
+		writeError(w, http.StatusServiceUnavailable, "transaction injection not available (requires --el-rpc)")
+		return
+	}
+
+	var req InjectTxsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if len(req.Txs) == 0 {
+		writeError(w, http.StatusBadRequest, "no transactions")
+		return
+	}
+
+	queued, err := injector.Queue(req.Txs)
+	if err != nil {
+		h.audit(r, token, "txs.inject", "", map[string]int{"count": len(req.Txs)}, "error: "+err.Error())
+
+		if errors.Is(err, payload_builder.ErrTxQueueFull) {
+			writeError(w, http.StatusConflict, err.Error())
+		} else {
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+
+		return
+	}
+
+	hashes := make([]string, len(queued))
+	for i, tx := range queued {
+		hashes[i] = tx.Hash.Hex()
+	}
+
+	h.audit(r, token, "txs.inject", "", map[string][]string{"hashes": hashes}, "ok")
+	writeJSON(w, http.StatusOK, InjectedTxsResponse{Transactions: injectedTxResponses(queued)})
+}
+
+// injectedTxResponses converts injected transactions to their API form.
+func injectedTxResponses(txs []payload_builder.InjectedTx) []InjectedTxResponse {
+	result := make([]InjectedTxResponse, len(txs))
+
+	for i, tx := range txs {
+		result[i] = InjectedTxResponse{
+			Hash:     tx.Hash.Hex(),
+			Type:     tx.Type,
+			Nonce:    tx.Nonce,
+			Size:     tx.Size,
+			Blobs:    tx.Blobs,
+			QueuedAt: tx.QueuedAt.UnixMilli(),
+			Status:   tx.Status,
+			Attempts: tx.Attempts,
+			Error:    tx.Error,
+		}
+
+		if tx.Status == payload_builder.InjectedTxIncluded {
+			result[i].IncludedSlot = uint64(tx.IncludedSlot)
+			result[i].IncludedBlockHash = fmt.Sprintf("%#x", tx.IncludedBlockHash)
+		}
+	}
+
+	return result
+}
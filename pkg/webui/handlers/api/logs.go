@@ -0,0 +1,226 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/buildoor/pkg/debug_bundle"
+)
+
+const (
+	logStreamDefaultBackfill = 100
+	logStreamMaxBackfill     = 1000
+	logStreamBufferSize      = 1024
+)
+
+// logStreamFilter selects the log entries a stream client asked for.
+type logStreamFilter struct {
+	maxLevel logrus.Level    // least severe level included
+	modules  map[string]bool // "module" field values; empty = all
+	slot     string          // "slot" field value; empty = all
+}
+
+// parseLogStreamFilter reads the level/module/slot query parameters.
+func parseLogStreamFilter(r *http.Request) (*logStreamFilter, error) {
+	q := r.URL.Query()
+	filter := &logStreamFilter{maxLevel: logrus.TraceLevel}
+
+	if raw := q.Get("level"); raw != "" {
+		level, err := logrus.ParseLevel(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level: %w", err)
+		}
+
+		filter.maxLevel = level
+	}
+
+	if raw := q.Get("module"); raw != "" {
+		filter.modules = make(map[string]bool)
+
+		for _, module := range strings.Split(raw, ",") {
+			if module = strings.TrimSpace(module); module != "" {
+				filter.modules[module] = true
+			}
+		}
+	}
+
+	if raw := q.Get("slot"); raw != "" {
+		if _, err := strconv.ParseUint(raw, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid slot: must be a number")
+		}
+
+		filter.slot = raw
+	}
+
+	return filter, nil
+}
+
+// matches reports whether the entry passes the filter.
+func (f *logStreamFilter) matches(entry *debug_bundle.LogEntry) bool {
+	if level, err := logrus.ParseLevel(entry.Level); err == nil && level > f.maxLevel {
+		return false
+	}
+
+	if len(f.modules) > 0 {
+		module, _ := entry.Fields["module"].(string)
+		if !f.modules[module] {
+			return false
+		}
+	}
+
+	if f.slot != "" {
+		slot, ok := entry.Fields["slot"]
+		if !ok || fmt.Sprint(slot) != f.slot {
+			return false
+		}
+	}
+
+	return true
+}
+
+// StreamLogs godoc
+// @Id streamLogs
+// @Summary Stream the builder log live
+// @Tags Buildoor
+// @Description Server-sent events tailing the structured log captured in
+// @Description memory: each "data:" line is one JSON log entry (time, level,
+// @Description msg, fields). The stream opens with up to `backfill` recent
+// @Description matching entries, then follows new ones. Filters: `level` is
+// @Description the least severe level included (e.g. "warn" streams warnings
+// @Description and errors), `module` a comma-separated list of component
+// @Description (module field) names, `slot` a slot field value. Entries a slow
+// @Description client cannot keep up with are dropped and reported as a
+// @Description "dropped" event. Requires authentication.
+// @Produce text/event-stream
+// @Param Authorization header string true "Bearer token"
+// @Param level query string false "Least severe level included (trace|debug|info|warn|error)"
+// @Param module query string false "Comma-separated module names"
+// @Param slot query int false "Only entries logged for this slot"
+// @Param backfill query int false "Recent entries to replay first (max 1000)" default(100)
+// @Success 200 {string} string "SSE stream of log entries"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 503 {object} map[string]string "Log capture disabled"
+// @Router /api/logs/stream [get]
+func (h *APIHandler) StreamLogs(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.logBuffer == nil {
+		writeError(w, http.StatusServiceUnavailable, "log capture disabled")
+		return
+	}
+
+	filter, err := parseLogStreamFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	backfill := logStreamDefaultBackfill
+	if raw := r.URL.Query().Get("backfill"); raw != "" {
+		backfill, err = strconv.Atoi(raw)
+		if err != nil || backfill < 0 {
+			writeError(w, http.StatusBadRequest, "invalid backfill: must be a non-negative number")
+			return
+		}
+
+		backfill = min(backfill, logStreamMaxBackfill)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	// Subscribe before reading the backfill so no entry falls in between;
+	// the overlap is skipped by timestamp.
+	sub := h.logBuffer.Subscribe(r.Context(), logStreamBufferSize)
+	defer sub.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disable proxy buffering (nginx) so entries flush immediately to clients.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+
+	var lastBackfilled time.Time
+
+	if backfill > 0 {
+		// Scan the whole buffer so filtered streams still get `backfill`
+		// matching entries.
+		recent := h.logBuffer.Recent(debug_bundle.DefaultLogBufferSize)
+		matching := make([]*debug_bundle.LogEntry, 0, backfill)
+
+		for i := len(recent) - 1; i >= 0 && len(matching) < backfill; i-- {
+			if filter.matches(&recent[i]) {
+				matching = append(matching, &recent[i])
+			}
+		}
+
+		for i := len(matching) - 1; i >= 0; i-- {
+			writeLogEvent(w, matching[i])
+		}
+
+		if len(recent) > 0 {
+			lastBackfilled = recent[len(recent)-1].Time
+		}
+	}
+
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	var reportedDrops uint64
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-heartbeat.C:
+			if dropped := sub.Dropped(); dropped > reportedDrops {
+				fmt.Fprintf(w, "event: dropped\ndata: {\"dropped\":%d}\n\n", dropped-reportedDrops)
+				reportedDrops = dropped
+			}
+
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+
+		case entry, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+
+			if !entry.Time.After(lastBackfilled) || !filter.matches(&entry) {
+				continue
+			}
+
+			writeLogEvent(w, &entry)
+			flusher.Flush()
+		}
+	}
+}
+
+// writeLogEvent writes one log entry as an SSE data event.
+func writeLogEvent(w http.ResponseWriter, entry *debug_bundle.LogEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/debug_bundle"
+)
+
+func TestLogStreamFilter(t *testing.T) {
+	filter, err := parseLogStreamFilter(httptest.NewRequest(http.MethodGet, "/api/logs/stream?level=warn&module=reveal,payload-builder&slot=42", nil))
+	require.NoError(t, err)
+
+	entry := func(level, module string, slot any) *debug_bundle.LogEntry {
+		return &debug_bundle.LogEntry{Level: level, Fields: map[string]any{"module": module, "slot": slot}}
+	}
+
+	assert.True(t, filter.matches(entry("warning", "reveal", uint64(42))))
+	assert.True(t, filter.matches(entry("error", "payload-builder", "42")))
+	assert.False(t, filter.matches(entry("info", "reveal", 42)), "below min severity")
+	assert.False(t, filter.matches(entry("error", "chain", 42)), "other module")
+	assert.False(t, filter.matches(entry("error", "reveal", 43)), "other slot")
+
+	filter, err = parseLogStreamFilter(httptest.NewRequest(http.MethodGet, "/api/logs/stream", nil))
+	require.NoError(t, err)
+	assert.True(t, filter.matches(&debug_bundle.LogEntry{Level: "trace"}))
+
+	_, err = parseLogStreamFilter(httptest.NewRequest(http.MethodGet, "/api/logs/stream?level=loud", nil))
+	require.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
+)
+
+// ManualBidRequest forces a one-off p2p bid.
+type ManualBidRequest struct {
+	Slot  uint64 `json:"slot"`
+	Value uint64 `json:"value"` // Bid value in gwei
+	// Deliberately invalid bid: zero_value | exceeds_balance | malformed_value
+	Fault string `json:"fault,omitempty"`
+	// JSON value gossiped by a malformed_value bid (default 2^64)
+	RawValue string `json:"raw_value,omitempty"`
+}
+
+// ManualBidResponse is the outcome of a manual bid.
+type ManualBidResponse struct {
+	Slot               uint64  `json:"slot"`
+	BlockHash          string  `json:"block_hash"`
+	Value              uint64  `json:"value"`     // Bid value in gwei
+	BidCount           int     `json:"bid_count"` // Bids sent for the slot, this one included
+	Status             string  `json:"status"`    // submitted | constructed | failed
+	Error              string  `json:"error,omitempty"`
+	Fault              string  `json:"fault,omitempty"`
+	CompetitorHighGwei *uint64 `json:"competitor_high_gwei,omitempty"`
+}
+
+// SubmitManualBid godoc
+// @Id submitManualBid
+// @Summary Force a one-off bid for a slot
+// @Tags Buildoor
+// @Description Signs and gossips a single p2p bid of the given value (gwei) for the slot
+// @Description from its cached payload, bypassing the automatic bid strategy (bid window,
+// @Description interval, enable flag, proposer preferences and canary pricing). Meant for
+// @Description interactive devnet experiments. With a fault, a deliberately invalid bid is
+// @Description gossiped instead to exercise the beacon node's bid validation: zero_value,
+// @Description exceeds_balance (value defaults to the builder balance + 1) or malformed_value
+// @Description (JSON value replaced by raw_value, default 2^64). Fault bids answer 200 with
+// @Description the beacon node's verdict (status submitted when accepted, otherwise error holds
+// @Description the rejection reason), are recorded in the slot results and leave the bid
+// @Description budget and circuit breaker untouched. Requires authentication.
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body ManualBidRequest true "Slot and bid value"
+// @Success 200 {object} ManualBidResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No cached payload for slot"
+// @Failure 409 {object} map[string]string "Bidding closed for slot"
+// @Failure 502 {object} ManualBidResponse "Bid construction or submission failed"
+// @Failure 503 {object} map[string]string "ePBS bidder not available"
+// @Router /api/buildoor/bid [post]
+func (h *APIHandler) SubmitManualBid(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.epbsSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "ePBS bidder not available")
+		return
+	}
+
+	var req ManualBidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Fault != "" {
+		h.submitFaultBid(w, r, token, &req)
+		return
+	}
+
+	if req.Value == 0 {
+		writeError(w, http.StatusBadRequest, "value must be greater than 0")
+		return
+	}
+
+	target := strconv.FormatUint(req.Slot, 10)
+
+	event, err := h.epbsSvc.SubmitManualBid(r.Context(), phase0.Slot(req.Slot), req.Value)
+	if err != nil {
+		h.audit(r, token, "bid.manual", target, req, "error: "+err.Error())
+
+		switch {
+		case errors.Is(err, p2p_bidder.ErrNoCachedPayload):
+			writeError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, p2p_bidder.ErrBidsClosed):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusServiceUnavailable, err.Error())
+		}
+
+		return
+	}
+
+	resp := manualBidResponse(event)
+
+	if !event.Success {
+		h.audit(r, token, "bid.manual", target, req, "error: "+event.Error)
+		writeJSON(w, http.StatusBadGateway, resp)
+
+		return
+	}
+
+	h.audit(r, token, "bid.manual", target, req, "ok")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// submitFaultBid gossips a fault bid and answers with the beacon node's
+// verdict.
+func (h *APIHandler) submitFaultBid(w http.ResponseWriter, r *http.Request, token *jwt.Token, req *ManualBidRequest) {
+	if !p2p_bidder.IsBidFault(req.Fault) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown fault %q", req.Fault))
+		return
+	}
+
+	value := req.Value
+
+	if value == 0 {
+		switch req.Fault {
+		case p2p_bidder.BidFaultExceedsBalance:
+			if h.lifecycleMgr == nil || !h.lifecycleMgr.GetBuilderState().IsRegistered {
+				writeError(w, http.StatusBadRequest, "value required: builder balance unknown")
+				return
+			}
+
+			value = h.lifecycleMgr.GetBuilderState().Balance + 1
+		case p2p_bidder.BidFaultMalformedValue:
+			value = 1
+		}
+	}
+
+	target := strconv.FormatUint(req.Slot, 10)
+
+	event, err := h.epbsSvc.SubmitFaultBid(r.Context(), phase0.Slot(req.Slot), req.Fault, value, req.RawValue)
+	if err != nil {
+		h.audit(r, token, "bid.fault", target, req, "error: "+err.Error())
+
+		switch {
+		case errors.Is(err, p2p_bidder.ErrNoCachedPayload):
+			writeError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, p2p_bidder.ErrBidsClosed):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusServiceUnavailable, err.Error())
+		}
+
+		return
+	}
+
+	if event.Success {
+		h.audit(r, token, "bid.fault", target, req, "accepted")
+	} else {
+		h.audit(r, token, "bid.fault", target, req, "rejected: "+event.Error)
+	}
+
+	writeJSON(w, http.StatusOK, manualBidResponse(event))
+}
+
+// manualBidResponse converts a manual bid's submission event to its API form.
+func manualBidResponse(event *p2p_bidder.BidSubmissionEvent) ManualBidResponse {
+	return ManualBidResponse{
+		Slot:               uint64(event.Slot),
+		BlockHash:          fmt.Sprintf("%#x", event.BlockHash),
+		Value:              event.Value,
+		BidCount:           event.BidCount,
+		Status:             event.Status,
+		Error:              event.Error,
+		Fault:              event.Fault,
+		CompetitorHighGwei: event.CompetitorHighGwei,
+	}
+}
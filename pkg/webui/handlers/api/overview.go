@@ -20,6 +20,8 @@ type OverviewServices struct {
 	EPBSAvailable         bool   `json:"epbs_available"`
 	EPBSEnabled           bool   `json:"epbs_enabled"`
 	EPBSRegistrationState string `json:"epbs_registration_state,omitempty"`
+	EPBSIdentityError     string `json:"epbs_identity_error,omitempty"`
+	EPBSBidHold           string `json:"epbs_bid_hold,omitempty"`
 	BuilderAPIAvailable   bool   `json:"builder_api_available"`
 	BuilderAPIEnabled     bool   `json:"builder_api_enabled"`
 	LifecycleAvailable    bool   `json:"lifecycle_available"`
@@ -108,6 +110,8 @@ func (h *APIHandler) GetOverview(w http.ResponseWriter, r *http.Request) {
 		resp.BuilderIndex = h.epbsSvc.GetBuilderIndex()
 		resp.IsRegistered = h.epbsSvc.IsRegistered()
 		resp.Services.EPBSRegistrationState = p2p_bidder.RegistrationStateName(h.epbsSvc.GetRegistrationState())
+		resp.Services.EPBSIdentityError = h.epbsSvc.IdentityError()
+		resp.Services.EPBSBidHold = h.epbsSvc.BidHold()
 
 		if h.payments != nil {
 			resp.Balances.PendingPaymentsGwei = h.payments.GetTotalPendingPayments()
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+// GetPeerBids godoc
+// @Id getPeerBids
+// @Summary Locally observed p2p bids for mesh peers
+// @Tags Buildoor
+// @Description Returns the p2p bids this node observed itself for slots >= min_slot, plus
+// @Description the node's name and builder identity. Polled by other buildoor instances
+// @Description listed in their --peer-urls; bids merged from peers are never relayed.
+// @Produce json
+// @Param min_slot query int false "First slot to include (default 0)"
+// @Success 200 {object} peer_mesh.Snapshot "Success"
+// @Failure 400 {object} map[string]string "Invalid min_slot"
+// @Failure 404 {object} map[string]string "Peer mesh not available"
+// @Router /api/buildoor/peer/bids [get]
+func (h *APIHandler) GetPeerBids(w http.ResponseWriter, r *http.Request) {
+	if h.peerMesh == nil {
+		writeError(w, http.StatusNotFound, "peer mesh not available")
+		return
+	}
+
+	var minSlot uint64
+
+	if raw := r.URL.Query().Get("min_slot"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid min_slot")
+			return
+		}
+
+		minSlot = parsed
+	}
+
+	writeJSON(w, http.StatusOK, h.peerMesh.LocalSnapshot(phase0.Slot(minSlot)))
+}
+
+// GetPeers godoc
+// @Id getPeers
+// @Summary Network-wide builder view
+// @Tags Buildoor
+// @Description Returns the configured mesh peers with their last poll outcome and every p2p
+// @Description bid known for the recent slots, locally observed or merged from peers.
+// @Produce json
+// @Success 200 {object} peer_mesh.NetworkView "Success"
+// @Failure 404 {object} map[string]string "Peer mesh not available"
+// @Router /api/buildoor/peers [get]
+func (h *APIHandler) GetPeers(w http.ResponseWriter, _ *http.Request) {
+	if h.peerMesh == nil {
+		writeError(w, http.StatusNotFound, "peer mesh not available")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.peerMesh.NetworkView())
+}
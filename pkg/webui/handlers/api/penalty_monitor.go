@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethpandaops/buildoor/pkg/penalty_monitor"
+)
+
+// PenaltyMonitorResponse is the penalty monitor state.
+type PenaltyMonitorResponse struct {
+	// HoldReason is set while bidding is held on unacknowledged incidents.
+	HoldReason string                     `json:"hold_reason,omitempty"`
+	Incidents  []penalty_monitor.Incident `json:"incidents"`
+}
+
+// AcknowledgePenaltyRequest selects the incident to acknowledge.
+type AcknowledgePenaltyRequest struct {
+	ID uint64 `json:"id"` // 0 = all
+}
+
+// SetPenaltyMonitor sets the penalty monitor (nil when disabled).
+func (h *APIHandler) SetPenaltyMonitor(monitor *penalty_monitor.Monitor) {
+	h.penalties = monitor
+}
+
+// GetPenaltyMonitor godoc
+// @Id getPenaltyMonitor
+// @Summary Get penalty monitor incidents
+// @Tags Buildoor
+// @Description Returns the equivocation and proposer slashing incidents affecting our
+// @Description builder indices and why bidding is held, if it is.
+// @Produce json
+// @Success 200 {object} PenaltyMonitorResponse
+// @Failure 503 {object} map[string]string "Penalty monitor disabled"
+// @Router /api/buildoor/penalty-monitor [get]
+func (h *APIHandler) GetPenaltyMonitor(w http.ResponseWriter, _ *http.Request) {
+	if h.penalties == nil {
+		writeError(w, http.StatusServiceUnavailable, "penalty monitor disabled")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.penaltyMonitorResponse())
+}
+
+// AcknowledgePenalty godoc
+// @Id acknowledgePenalty
+// @Summary Acknowledge a penalty monitor incident
+// @Tags Buildoor
+// @Description Acknowledges one incident (or all with id 0); bidding resumes once none
+// @Description is left unacknowledged. Requires authentication.
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body AcknowledgePenaltyRequest true "Incident to acknowledge"
+// @Success 200 {object} PenaltyMonitorResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Unknown or acknowledged incident"
+// @Failure 503 {object} map[string]string "Penalty monitor disabled"
+// @Router /api/buildoor/penalty-monitor/acknowledge [post]
+func (h *APIHandler) AcknowledgePenalty(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.penalties == nil {
+		writeError(w, http.StatusServiceUnavailable, "penalty monitor disabled")
+		return
+	}
+
+	var req AcknowledgePenaltyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	target := fmt.Sprintf("%d", req.ID)
+
+	if err := h.penalties.Acknowledge(req.ID, actorFromToken(token)); err != nil {
+		h.audit(r, token, "penalty_monitor.acknowledge", target, req, "error: "+err.Error())
+		writeError(w, http.StatusNotFound, err.Error())
+
+		return
+	}
+
+	h.audit(r, token, "penalty_monitor.acknowledge", target, req, "ok")
+
+	if h.eventStreamMgr != nil {
+		h.eventStreamMgr.BroadcastServiceStatus()
+	}
+
+	writeJSON(w, http.StatusOK, h.penaltyMonitorResponse())
+}
+
+func (h *APIHandler) penaltyMonitorResponse() PenaltyMonitorResponse {
+	return PenaltyMonitorResponse{
+		HoldReason: h.penalties.HoldReason(),
+		Incidents:  h.penalties.Incidents(),
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// GetRelayProxy godoc
+// @Id getRelayProxy
+// @Summary Recorded relay proxy traffic
+// @Tags Buildoor
+// @Description Returns the relays configured with --relay-proxy-urls and the latest validator
+// @Description requests forwarded to them through /relay-proxy, one entry per relay answer,
+// @Description newest first.
+// @Produce json
+// @Param limit query int false "Maximum number of exchanges to return (default all kept)"
+// @Success 200 {object} relay_proxy.View "Success"
+// @Failure 400 {object} map[string]string "Invalid limit"
+// @Failure 404 {object} map[string]string "Relay proxy not enabled"
+// @Router /api/buildoor/relay-proxy [get]
+func (h *APIHandler) GetRelayProxy(w http.ResponseWriter, r *http.Request) {
+	if h.relayProxy == nil {
+		writeError(w, http.StatusNotFound, "relay proxy not enabled")
+		return
+	}
+
+	var limit int
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+
+		limit = parsed
+	}
+
+	writeJSON(w, http.StatusOK, h.relayProxy.GetView(limit))
+}
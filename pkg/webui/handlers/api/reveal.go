@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	eth2all "github.com/ethpandaops/go-eth2-client/spec/all"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
+)
+
+// RevealPreviewResponse is the JSON view of a slot's reveal envelope.
+type RevealPreviewResponse struct {
+	Version             string                                  `json:"version"`
+	Data                *eth2all.SignedExecutionPayloadEnvelope `json:"data" swaggertype:"object"`
+	Transport           string                                  `json:"transport"`
+	BlobCount           int                                     `json:"blob_count"`
+	BroadcastValidation string                                  `json:"broadcast_validation"`
+	Attempts            int                                     `json:"attempts"`  // Automatic publish attempts so far
+	Published           bool                                    `json:"published"` // Beacon node accepted the envelope
+	Done                bool                                    `json:"done"`      // No further automatic attempts
+}
+
+// ManualRevealRequest optionally overrides the broadcast validation level.
+type ManualRevealRequest struct {
+	BroadcastValidation string `json:"broadcast_validation,omitempty"` // gossip | consensus | consensus_and_equivocation
+}
+
+// ManualRevealResponse is the outcome of a manual reveal.
+type ManualRevealResponse struct {
+	Slot                uint64 `json:"slot"`
+	Transport           string `json:"transport"`
+	Success             bool   `json:"success"`
+	Error               string `json:"error,omitempty"`
+	Attempt             int    `json:"attempt"`                        // Manual attempt number for the slot
+	BroadcastValidation string `json:"broadcast_validation,omitempty"` // Override applied (empty = the slot's frozen level)
+	StartedAt           int64  `json:"started_at"`                     // unix milliseconds
+	CompletedAt         int64  `json:"completed_at"`                   // unix milliseconds
+}
+
+// GetRevealPreview godoc
+// @Id getRevealPreview
+// @Summary Preview the reveal envelope of a committed slot
+// @Tags Buildoor
+// @Description Returns the signed execution payload envelope the slot's reveal publishes
+// @Description (or published), building it if no attempt did yet; the built envelope is
+// @Description then reused by the reveal. Only slots with a reveal request (a bid of ours
+// @Description committed in a beacon block, up to two slots after the reveal finished)
+// @Description are available. With "Accept: application/octet-stream" the raw SSZ bytes
+// @Description are served. Requires authentication (the envelope exposes the payload).
+// @Produce json,application/octet-stream
+// @Param Authorization header string true "Bearer token"
+// @Param slot path int true "Slot"
+// @Success 200 {object} RevealPreviewResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No reveal request for slot"
+// @Failure 406 {object} map[string]string "No acceptable content type"
+// @Failure 500 {object} map[string]string "Envelope construction failed"
+// @Failure 503 {object} map[string]string "Reveal service not available"
+// @Router /api/buildoor/reveal/{slot}/preview [get]
+func (h *APIHandler) GetRevealPreview(w http.ResponseWriter, r *http.Request) {
+	if h.authHandler.CheckAuthToken(r.Header.Get("Authorization")) == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.revealSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "reveal service not available")
+		return
+	}
+
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	wantSSZ, notAcceptable := negotiateArtifact(r)
+	if notAcceptable {
+		writeError(w, http.StatusNotAcceptable,
+			"acceptable content types: application/octet-stream, application/json")
+		return
+	}
+
+	preview, err := h.revealSvc.PreviewReveal(r.Context(), slot)
+	if err != nil {
+		if errors.Is(err, payload_bidder.ErrNoRevealRequest) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	forkName := preview.Envelope.Version.String()
+
+	w.Header().Set("Eth-Consensus-Version", forkName)
+	w.Header().Set("Vary", "Accept")
+
+	if wantSSZ {
+		data, err := preview.Envelope.MarshalSSZ()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to SSZ-encode envelope: "+err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RevealPreviewResponse{
+		Version:             forkName,
+		Data:                preview.Envelope,
+		Transport:           string(preview.Transport),
+		BlobCount:           preview.BlobCount,
+		BroadcastValidation: preview.BroadcastValidation,
+		Attempts:            preview.Attempts,
+		Published:           preview.Published,
+		Done:                preview.Done,
+	})
+}
+
+// TriggerReveal godoc
+// @Id triggerReveal
+// @Summary Publish a committed slot's reveal immediately
+// @Tags Buildoor
+// @Description Publishes the slot's signed envelope now, bypassing its reveal gates,
+// @Description suppression and deadline. Works on finished reveals too (re-publishing
+// @Description the same envelope); manual attempts never consume the automatic retry
+// @Description budget and a success ends the automatic schedule. The body may
+// @Description override the broadcast validation level. Requires authentication.
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param slot path int true "Slot"
+// @Param request body ManualRevealRequest false "Broadcast validation override"
+// @Success 200 {object} ManualRevealResponse
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No reveal request for slot"
+// @Failure 502 {object} ManualRevealResponse "Envelope construction or publish failed"
+// @Failure 503 {object} map[string]string "Reveal service not available"
+// @Router /api/buildoor/reveal/{slot} [post]
+func (h *APIHandler) TriggerReveal(w http.ResponseWriter, r *http.Request) {
+	token := h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+	if token == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if h.revealSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "reveal service not available")
+		return
+	}
+
+	slot, ok := parseArtifactSlot(w, r)
+	if !ok {
+		return
+	}
+
+	var req ManualRevealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	switch req.BroadcastValidation {
+	case "", config.BroadcastValidationGossip, config.BroadcastValidationConsensus,
+		config.BroadcastValidationConsensusAndEquivocation:
+	default:
+		writeError(w, http.StatusBadRequest,
+			"broadcast_validation must be gossip, consensus or consensus_and_equivocation")
+		return
+	}
+
+	target := strconv.FormatUint(uint64(slot), 10)
+
+	result, err := h.revealSvc.PublishNow(r.Context(), slot, req.BroadcastValidation)
+	if err != nil {
+		h.audit(r, token, "reveal.manual", target, req, "error: "+err.Error())
+
+		if errors.Is(err, payload_bidder.ErrNoRevealRequest) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+
+		return
+	}
+
+	resp := ManualRevealResponse{
+		Slot:                uint64(result.Slot),
+		Transport:           string(result.Transport),
+		Success:             result.Success,
+		Error:               result.Error,
+		Attempt:             result.Attempt,
+		BroadcastValidation: req.BroadcastValidation,
+		StartedAt:           result.StartedAt.UnixMilli(),
+		CompletedAt:         result.CompletedAt.UnixMilli(),
+	}
+
+	if !result.Success {
+		h.audit(r, token, "reveal.manual", target, req, "error: "+result.Error)
+		writeJSON(w, http.StatusBadGateway, resp)
+
+		return
+	}
+
+	h.audit(r, token, "reveal.manual", target, req, "ok")
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,464 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+)
+
+// JSON-RPC 2.0 error codes (spec-reserved range plus buildoor's server errors).
+const (
+	rpcErrParse          = -32700
+	rpcErrInvalidRequest = -32600
+	rpcErrMethodNotFound = -32601
+	rpcErrInvalidParams  = -32602
+	rpcErrInternal       = -32603
+	rpcErrUnauthorized   = -32001
+	rpcErrUnavailable    = -32002
+)
+
+// Service names accepted by buildoor_toggleService and buildoor_setSubsidy.
+const (
+	rpcServiceEPBS       = "epbs"
+	rpcServiceBuilderAPI = "builder_api"
+	rpcServiceLifecycle  = "lifecycle"
+)
+
+const (
+	rpcBidHistoryDefaultLimit = 100
+	rpcBidHistoryMaxLimit     = 1000
+	rpcMaxBodyBytes           = 1 << 20
+)
+
+// RPCRequest is a JSON-RPC 2.0 request object.
+type RPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // absent for notifications
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"` // positional array or named object
+}
+
+// RPCResponse is a JSON-RPC 2.0 response object.
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC 2.0 error object.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements error.
+func (e *RPCError) Error() string {
+	return e.Message
+}
+
+// RPCStatusResult is the result of buildoor_status.
+type RPCStatusResult struct {
+	Status   StatusResponse     `json:"status"`
+	Stats    StatsResponse      `json:"stats"`
+	Services ServiceStatusEvent `json:"services"`
+}
+
+// RPCSetSubsidyResult is the result of buildoor_setSubsidy: the settings keys
+// that were set and the resulting settings version.
+type RPCSetSubsidyResult struct {
+	SubsidyGwei uint64   `json:"subsidy_gwei"`
+	Keys        []string `json:"keys"`
+	Version     int64    `json:"version"`
+}
+
+// RPCBidHistoryEntry is one submitted bid of buildoor_bidHistory.
+type RPCBidHistoryEntry struct {
+	Slot uint64 `json:"slot"`
+	slot_results.BidAttempt
+	// Included marks the bid whose block hash landed on chain.
+	Included bool `json:"included"`
+}
+
+// rpcMethod handles one admin RPC method. token is the caller's validated
+// auth token, nil when unauthenticated.
+type rpcMethod struct {
+	auth    bool // mutating: requires a valid auth token
+	handler func(h *APIHandler, r *http.Request, token *jwt.Token, params json.RawMessage) (any, error)
+}
+
+// rpcMethods is the buildoor_ admin namespace. Read methods are open like the
+// REST GETs; mutating methods require the same bearer token as the REST API.
+var rpcMethods = map[string]rpcMethod{
+	"buildoor_status":        {handler: (*APIHandler).rpcStatus},
+	"buildoor_setSubsidy":    {auth: true, handler: (*APIHandler).rpcSetSubsidy},
+	"buildoor_toggleService": {auth: true, handler: (*APIHandler).rpcToggleService},
+	"buildoor_bidHistory":    {handler: (*APIHandler).rpcBidHistory},
+}
+
+// AdminRPC godoc
+// @Id adminRPC
+// @Summary Admin JSON-RPC endpoint (buildoor_ namespace)
+// @Tags Buildoor
+// @Description JSON-RPC 2.0 admin surface mirroring the RPC-style admin APIs of
+// @Description other builders, for tooling built against them. Accepts single
+// @Description and batch requests; params may be positional or named.
+// @Description Methods: buildoor_status() → status, stats and services;
+// @Description buildoor_setSubsidy(subsidy_gwei, service?) sets the ePBS and/or
+// @Description Builder API subsidy; buildoor_toggleService(service, enabled)
+// @Description enables or disables epbs, builder_api or lifecycle;
+// @Description buildoor_bidHistory(limit?) lists our submitted bids, newest
+// @Description first. Mutating methods require the Authorization header
+// @Description (error -32001 otherwise). Errors are JSON-RPC error objects
+// @Description on an HTTP 200 response.
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "Bearer token (mutating methods)"
+// @Param request body RPCRequest true "JSON-RPC request (or an array of them)"
+// @Success 200 {object} RPCResponse "JSON-RPC response (or an array of them)"
+// @Router /api/rpc [post]
+func (h *APIHandler) AdminRPC(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, rpcMaxBodyBytes))
+	if err != nil {
+		writeJSON(w, http.StatusOK, rpcErrorResponse(nil, rpcErrParse, "failed to read request"))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+
+	// Batch: an array of requests answered by an array of responses
+	// (notifications omitted).
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			writeJSON(w, http.StatusOK, rpcErrorResponse(nil, rpcErrParse, "parse error"))
+			return
+		}
+
+		if len(batch) == 0 {
+			writeJSON(w, http.StatusOK, rpcErrorResponse(nil, rpcErrInvalidRequest, "empty batch"))
+			return
+		}
+
+		responses := make([]*RPCResponse, 0, len(batch))
+
+		for _, raw := range batch {
+			if resp := h.handleRPC(r, raw); resp != nil {
+				responses = append(responses, resp)
+			}
+		}
+
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, responses)
+
+		return
+	}
+
+	resp := h.handleRPC(r, body)
+	if resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleRPC dispatches one request. It returns nil for notifications.
+func (h *APIHandler) handleRPC(r *http.Request, raw json.RawMessage) *RPCResponse {
+	var req RPCRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return rpcErrorResponse(nil, rpcErrParse, "parse error")
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcErrorResponse(req.ID, rpcErrInvalidRequest, "invalid request")
+	}
+
+	result, err := h.callRPC(r, &req)
+
+	if req.ID == nil {
+		return nil
+	}
+
+	if err != nil {
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &RPCError{Code: rpcErrInternal, Message: err.Error()}
+		}
+
+		return &RPCResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+
+	return &RPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// callRPC resolves the method, checks auth for mutating methods and runs it.
+func (h *APIHandler) callRPC(r *http.Request, req *RPCRequest) (any, error) {
+	method, ok := rpcMethods[req.Method]
+	if !ok {
+		return nil, &RPCError{Code: rpcErrMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	var token *jwt.Token
+
+	if method.auth {
+		if h.authHandler != nil {
+			token = h.authHandler.CheckAuthToken(r.Header.Get("Authorization"))
+		}
+
+		if token == nil {
+			return nil, &RPCError{Code: rpcErrUnauthorized, Message: "unauthorized"}
+		}
+	}
+
+	return method.handler(h, r, token, req.Params)
+}
+
+// rpcStatus implements buildoor_status.
+func (h *APIHandler) rpcStatus(r *http.Request, _ *jwt.Token, _ json.RawMessage) (any, error) {
+	if h.builderSvc == nil {
+		return nil, &RPCError{Code: rpcErrUnavailable, Message: "builder service not available"}
+	}
+
+	return &RPCStatusResult{
+		Status:   h.buildStatus(r.Context()),
+		Stats:    h.buildStats(),
+		Services: h.serviceStatus(),
+	}, nil
+}
+
+// rpcSetSubsidy implements buildoor_setSubsidy(subsidy_gwei, service?). With
+// no service the subsidy is applied to every available bidding flow.
+func (h *APIHandler) rpcSetSubsidy(r *http.Request, token *jwt.Token, raw json.RawMessage) (any, error) {
+	var params struct {
+		SubsidyGwei *uint64 `json:"subsidy_gwei"`
+		Service     string  `json:"service"`
+	}
+	if err := decodeRPCParams(raw, []string{"subsidy_gwei", "service"}, &params); err != nil {
+		return nil, err
+	}
+
+	if params.SubsidyGwei == nil {
+		return nil, &RPCError{Code: rpcErrInvalidParams, Message: "missing subsidy_gwei"}
+	}
+
+	updates := map[string]json.RawMessage{}
+
+	switch params.Service {
+	case "":
+		if h.epbsSvc != nil {
+			updates[config.KeyEPBSBidSubsidy] = mustJSON(*params.SubsidyGwei)
+		}
+
+		if h.builderAPISvc != nil {
+			updates[config.KeyBuilderAPISubsidy] = mustJSON(*params.SubsidyGwei)
+		}
+	case rpcServiceEPBS:
+		if h.epbsSvc == nil {
+			return nil, &RPCError{Code: rpcErrUnavailable, Message: "epbs service not available"}
+		}
+
+		updates[config.KeyEPBSBidSubsidy] = mustJSON(*params.SubsidyGwei)
+	case rpcServiceBuilderAPI:
+		if h.builderAPISvc == nil {
+			return nil, &RPCError{Code: rpcErrUnavailable, Message: "builder_api service not available"}
+		}
+
+		updates[config.KeyBuilderAPISubsidy] = mustJSON(*params.SubsidyGwei)
+	default:
+		return nil, &RPCError{Code: rpcErrInvalidParams, Message: "unknown service: " + params.Service}
+	}
+
+	if len(updates) == 0 {
+		return nil, &RPCError{Code: rpcErrUnavailable, Message: "no bidding service available"}
+	}
+
+	version, err := h.rpcUpdateSettings(r, token, "rpc.set_subsidy", params, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return &RPCSetSubsidyResult{SubsidyGwei: *params.SubsidyGwei, Keys: keys, Version: version}, nil
+}
+
+// rpcToggleService implements buildoor_toggleService(service, enabled) and
+// returns the resulting service status.
+func (h *APIHandler) rpcToggleService(r *http.Request, token *jwt.Token, raw json.RawMessage) (any, error) {
+	var params struct {
+		Service string `json:"service"`
+		Enabled *bool  `json:"enabled"`
+	}
+	if err := decodeRPCParams(raw, []string{"service", "enabled"}, &params); err != nil {
+		return nil, err
+	}
+
+	if params.Enabled == nil {
+		return nil, &RPCError{Code: rpcErrInvalidParams, Message: "missing enabled"}
+	}
+
+	var (
+		key       string
+		available bool
+	)
+
+	switch params.Service {
+	case rpcServiceEPBS:
+		key, available = config.KeyEPBSEnabled, h.epbsSvc != nil
+	case rpcServiceBuilderAPI:
+		key, available = config.KeyBuilderAPIEnabled, h.builderAPISvc != nil
+	case rpcServiceLifecycle:
+		key, available = config.KeyLifecycleEnabled, h.lifecycleMgr != nil
+	default:
+		return nil, &RPCError{Code: rpcErrInvalidParams, Message: "unknown service: " + params.Service}
+	}
+
+	if !available {
+		return nil, &RPCError{Code: rpcErrUnavailable, Message: params.Service + " service not available"}
+	}
+
+	updates := map[string]json.RawMessage{key: mustJSON(*params.Enabled)}
+	if _, err := h.rpcUpdateSettings(r, token, "rpc.toggle_service", params, updates); err != nil {
+		return nil, err
+	}
+
+	if h.eventStreamMgr != nil {
+		h.eventStreamMgr.BroadcastServiceStatus()
+	}
+
+	return h.serviceStatus(), nil
+}
+
+// rpcBidHistory implements buildoor_bidHistory(limit?): our submitted bids
+// across the retained slot results, newest first.
+func (h *APIHandler) rpcBidHistory(_ *http.Request, _ *jwt.Token, raw json.RawMessage) (any, error) {
+	var params struct {
+		Limit int `json:"limit"`
+	}
+	if err := decodeRPCParams(raw, []string{"limit"}, &params); err != nil {
+		return nil, err
+	}
+
+	if h.resultTracker == nil {
+		return nil, &RPCError{Code: rpcErrUnavailable, Message: "slot results tracker not available"}
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = rpcBidHistoryDefaultLimit
+	}
+
+	limit = min(limit, rpcBidHistoryMaxLimit)
+
+	return bidHistory(h.resultTracker.GetRange(0, phase0.Slot(^uint64(0))), limit), nil
+}
+
+// bidHistory flattens the slots' bid attempts into history entries, newest
+// first (slots descending, bids in reverse submission order).
+func bidHistory(results []*slot_results.SlotResult, limit int) []RPCBidHistoryEntry {
+	entries := make([]RPCBidHistoryEntry, 0, min(limit, len(results)))
+
+	for i := len(results) - 1; i >= 0 && len(entries) < limit; i-- {
+		result := results[i]
+
+		for j := len(result.Bids) - 1; j >= 0 && len(entries) < limit; j-- {
+			bid := result.Bids[j]
+			entries = append(entries, RPCBidHistoryEntry{
+				Slot:       uint64(result.Slot),
+				BidAttempt: bid,
+				Included: result.Inclusion != nil && bid.BlockHash != "" &&
+					strings.EqualFold(result.Inclusion.BlockHash, bid.BlockHash),
+			})
+		}
+	}
+
+	return entries
+}
+
+// rpcUpdateSettings applies settings overrides on behalf of an RPC caller
+// (audited like the REST updates) and broadcasts the config change. It
+// returns the new settings version.
+func (h *APIHandler) rpcUpdateSettings(r *http.Request, token *jwt.Token, action string, detail any,
+	updates map[string]json.RawMessage) (int64, error) {
+	if h.settingsSvc == nil {
+		return 0, &RPCError{Code: rpcErrUnavailable, Message: "settings service not available"}
+	}
+
+	changes, version, err := h.settingsSvc.Update(updates, actorFromToken(token), -1)
+	if err != nil {
+		h.audit(r, token, action, "", settingsAuditDetail{Request: detail, Version: version}, "error: "+err.Error())
+		return 0, &RPCError{Code: rpcErrInvalidParams, Message: err.Error()}
+	}
+
+	h.audit(r, token, action, "", settingsAuditDetail{Request: detail, Changes: changes, Version: version}, "ok")
+
+	if h.eventStreamMgr != nil {
+		h.eventStreamMgr.BroadcastConfigUpdate()
+	}
+
+	return version, nil
+}
+
+// decodeRPCParams decodes positional (array, mapped onto names in order) or
+// named (object) params into dst. Absent params leave dst untouched.
+func decodeRPCParams(raw json.RawMessage, names []string, dst any) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	if raw[0] == '[' {
+		var positional []json.RawMessage
+		if err := json.Unmarshal(raw, &positional); err != nil {
+			return &RPCError{Code: rpcErrInvalidParams, Message: "invalid params: " + err.Error()}
+		}
+
+		if len(positional) > len(names) {
+			return &RPCError{Code: rpcErrInvalidParams,
+				Message: fmt.Sprintf("too many params: got %d, want at most %d", len(positional), len(names))}
+		}
+
+		named := make(map[string]json.RawMessage, len(positional))
+		for i, value := range positional {
+			named[names[i]] = value
+		}
+
+		raw = mustJSON(named)
+	}
+
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return &RPCError{Code: rpcErrInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+
+	return nil
+}
+
+// rpcErrorResponse builds an error response for the given request id.
+func rpcErrorResponse(id json.RawMessage, code int, message string) *RPCResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+
+	return &RPCResponse{JSONRPC: "2.0", ID: id, Error: &RPCError{Code: code, Message: message}}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/buildoor/pkg/builderapi"
+	"github.com/ethpandaops/buildoor/pkg/config"
+	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/slot_results"
+	"github.com/ethpandaops/buildoor/pkg/webui/handlers/auth"
+)
+
+func postRPC(t *testing.T, h *APIHandler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/rpc", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.AdminRPC(rec, req)
+
+	return rec
+}
+
+func decodeRPCResponse(t *testing.T, rec *httptest.ResponseRecorder) RPCResponse {
+	t.Helper()
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp RPCResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+	return resp
+}
+
+func TestAdminRPC_Errors(t *testing.T) {
+	h := NewAPIHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	resp := decodeRPCResponse(t, postRPC(t, h, `{"jsonrpc":"2.0","id":1,"method":"buildoor_nope"}`))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcErrMethodNotFound, resp.Error.Code)
+	assert.JSONEq(t, `1`, string(resp.ID))
+
+	resp = decodeRPCResponse(t, postRPC(t, h, `{"jsonrpc":"2.0",`))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcErrParse, resp.Error.Code)
+
+	resp = decodeRPCResponse(t, postRPC(t, h, `{"id":"a","method":"buildoor_status"}`))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcErrInvalidRequest, resp.Error.Code)
+
+	// Mutating methods need a valid token.
+	resp = decodeRPCResponse(t, postRPC(t, h, `{"jsonrpc":"2.0","id":2,"method":"buildoor_setSubsidy","params":[5]}`))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcErrUnauthorized, resp.Error.Code)
+
+	// Notifications get no response.
+	assert.Equal(t, http.StatusNoContent, postRPC(t, h, `{"jsonrpc":"2.0","method":"buildoor_status"}`).Code)
+}
+
+func TestAdminRPC_BatchAndSetSubsidy(t *testing.T) {
+	authHandler, err := auth.NewAuthHandler(context.Background(), "")
+	require.NoError(t, err)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	defaults := config.DefaultConfig()
+	effective := *defaults
+	settingsSvc, err := config.NewService(&effective, defaults, map[string]bool{}, db.NewDatabase(&db.Config{}, log), log)
+	require.NoError(t, err)
+
+	srv := builderapi.NewServer(&effective.BuilderAPI, log, nil, nil, nil, nil, nil)
+
+	h := NewAPIHandler(authHandler, settingsSvc, nil, nil, nil, nil, nil, nil, srv, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	rec := postRPC(t, h, `[
+		{"jsonrpc":"2.0","id":1,"method":"buildoor_setSubsidy","params":{"subsidy_gwei":4242,"service":"builder_api"}},
+		{"jsonrpc":"2.0","id":2,"method":"buildoor_setSubsidy","params":[1,"epbs"]},
+		{"jsonrpc":"2.0","method":"buildoor_status"}
+	]`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var batch []RPCResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&batch))
+	require.Len(t, batch, 2)
+
+	require.Nil(t, batch[0].Error)
+	assert.Equal(t, uint64(4242), settingsSvc.Load().BuilderAPI.BlockValueSubsidyGwei)
+
+	// No ePBS service wired.
+	require.NotNil(t, batch[1].Error)
+	assert.Equal(t, rpcErrUnavailable, batch[1].Error.Code)
+}
+
+func TestDecodeRPCParams(t *testing.T) {
+	var params struct {
+		Service string `json:"service"`
+		Enabled *bool  `json:"enabled"`
+	}
+
+	require.NoError(t, decodeRPCParams(json.RawMessage(`["epbs", true]`), []string{"service", "enabled"}, &params))
+	assert.Equal(t, "epbs", params.Service)
+	require.NotNil(t, params.Enabled)
+	assert.True(t, *params.Enabled)
+
+	require.NoError(t, decodeRPCParams(nil, []string{"service"}, &params))
+
+	err := decodeRPCParams(json.RawMessage(`["a", true, 3]`), []string{"service", "enabled"}, &params)
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, rpcErrInvalidParams, rpcErr.Code)
+}
+
+func TestBidHistory(t *testing.T) {
+	results := []*slot_results.SlotResult{
+		{Slot: 10, Bids: []slot_results.BidAttempt{{BlockHash: "0xaa", TotalValueGwei: 1}, {BlockHash: "0xbb", TotalValueGwei: 2}}},
+		{
+			Slot:      11,
+			Bids:      []slot_results.BidAttempt{{BlockHash: "0xCC", TotalValueGwei: 3}},
+			Inclusion: &slot_results.InclusionResult{BlockHash: "0xcc"},
+		},
+	}
+
+	entries := bidHistory(results, 10)
+	require.Len(t, entries, 3)
+	assert.Equal(t, uint64(11), entries[0].Slot)
+	assert.True(t, entries[0].Included)
+	assert.Equal(t, uint64(2), entries[1].TotalValueGwei)
+	assert.False(t, entries[1].Included)
+
+	assert.Len(t, bidHistory(results, 2), 2)
+}
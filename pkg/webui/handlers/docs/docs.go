@@ -15,6 +15,27 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/builders": {
+            "get": {
+                "description": "Returns every builder key this instance bids with: the primary builder\nfirst, then the extra builder keys (--extra-builder-privkeys), each with\nits index, registration state, balances, wallet and the slots its bids\nare still outstanding for.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "List builder keys",
+                "operationId": "getBuilders",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.BuildersResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/buildoor/action-plan": {
             "get": {
                 "description": "Returns all per-slot action plans within the inclusive slot range.",
@@ -190,49 +211,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/audit-log": {
+        "/api/buildoor/alerts": {
             "get": {
-                "description": "Returns a paginated list of authenticated mutating actions. Empty when no state-db is configured.",
+                "description": "Returns every configured alerting rule (from --alert-rules-file) with its\ncurrent state (ok, firing or no_data), last evaluated value and fire count.\nState transitions are also emitted as alert SSE events.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Buildoor"
+                    "Stats"
                 ],
-                "summary": "Get the audit log",
-                "operationId": "getAuditLog",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
+                "summary": "Get alerting rule status",
+                "operationId": "getAlerts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AlertsResponse"
+                        }
                     },
+                    "503": {
+                        "description": "Alerting not configured",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/assertions/{slot}": {
+            "get": {
+                "description": "Machine-readable verdicts for automated devnet assertions\n(e.g. assertoor): built_on_time (payload ready before the slot\nstart), bid_above_min (a submitted/served bid at or above the\nslot's minimum), revealed (payload of a won slot revealed or\nreleased) and block_landed (payload included canonically).\nEach verdict is pass, fail, pending or skip (not applicable);\nthe overall status is fail on any failure, pending while any\nverdict is undecided.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Pass/fail of the expected builder behaviour for a slot",
+                "operationId": "getSlotAssertions",
+                "parameters": [
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "Limit for pagination (max 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.AuditLogResponse"
+                            "$ref": "#/definitions/slot_results.SlotAssertions"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -240,8 +277,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "404": {
+                        "description": "No results recorded for this slot",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Results tracker unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -252,18 +298,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/bids-won": {
+        "/api/buildoor/audit-log": {
             "get": {
-                "description": "Returns a paginated list of won blocks (Builder API and p2p ePBS) with transaction counts, blob counts, and values, read from the shared inclusion tracker.",
+                "description": "Returns a paginated list of authenticated mutating actions. Empty when no state-db is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Buildoor"
                 ],
-                "summary": "Get bids won (blocks of ours included on chain)",
-                "operationId": "getBidsWon",
+                "summary": "Get the audit log",
+                "operationId": "getAuditLog",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 0,
@@ -283,38 +336,17 @@ const docTemplate = `{
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.BidsWonResponse"
+                            "$ref": "#/definitions/api.AuditLogResponse"
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/buildoor/builder-api-status": {
-            "get": {
-                "description": "Returns the current status of the Builder API including configuration and validator count.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Buildoor"
-                ],
-                "summary": "Get Builder API status",
-                "operationId": "getBuilderAPIStatus",
-                "responses": {
-                    "200": {
-                        "description": "Success",
-                        "schema": {
-                            "$ref": "#/definitions/api.BuilderAPIStatusResponse"
-                        }
                     },
                     "500": {
                         "description": "Server Error",
@@ -328,26 +360,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/builder-preferences": {
+        "/api/buildoor/balance-history": {
             "get": {
-                "description": "Returns all builder preferences currently in the cache, submitted by proposers via the submitBuilderPreferences API.",
+                "description": "Returns the recorded builder CL balance, pending payments,\neffective balance and wallet balance over time, downsampled into\nat most points equal-width buckets. Each point carries the last\nsample of its bucket plus the bucket's effective balance range.\nHistory is kept in memory and starts when the web UI starts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Buildoor"
                 ],
-                "summary": "Get cached builder preferences",
-                "operationId": "getBuilderPreferences",
+                "summary": "Get the builder balance history",
+                "operationId": "getBalanceHistory",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Window start, unix ms (default: oldest sample)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Window end, unix ms (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of points (default 300, max 2000)",
+                        "name": "points",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.BuilderPreferencesResponse"
+                            "$ref": "#/definitions/api.BalanceHistoryResponse"
                         }
                     },
-                    "404": {
-                        "description": "Builder API not enabled",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Balance history unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -358,43 +419,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/head-votes/{slot}": {
-            "get": {
-                "description": "Returns the slot's raw single-attestation arrivals grouped by\nvalidator-ranges client name into fixed-width time buckets\nfrom the slot start, plus per-name totals and the count of\nattesters that landed on chain without being seen as singles.\nOnly slots still retained by the head vote tracker are served.",
+        "/api/buildoor/bid": {
+            "post": {
+                "description": "Signs and gossips a single p2p bid of the given value (gwei) for the slot\nfrom its cached payload, bypassing the automatic bid strategy (bid window,\ninterval, enable flag, proposer preferences and canary pricing). Meant for\ninteractive devnet experiments. With a fault, a deliberately invalid bid is\ngossiped instead to exercise the beacon node's bid validation: zero_value,\nexceeds_balance (value defaults to the builder balance + 1) or malformed_value\n(JSON value replaced by raw_value, default 2^64). Fault bids answer 200 with\nthe beacon node's verdict (status submitted when accepted, otherwise error holds\nthe rejection reason), are recorded in the slot results and leave the bid\nbudget and circuit breaker untouched. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stats"
+                    "Buildoor"
                 ],
-                "summary": "Per-name head-vote arrival heatmap for a slot",
-                "operationId": "getHeadVoteDetail",
+                "summary": "Force a one-off bid for a slot",
+                "operationId": "submitManualBid",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Slot",
-                        "name": "slot",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "Beacon block root (default: the slot's primary root)",
-                        "name": "root",
-                        "in": "query"
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Bucket width in ms (default slot_ms/24, clamped to [50, slot_ms])",
-                        "name": "bucket_ms",
-                        "in": "query"
+                        "description": "Slot and bid value",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ManualBidRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.HeadVoteDetailResponse"
+                            "$ref": "#/definitions/api.ManualBidResponse"
                         }
                     },
                     "400": {
@@ -406,8 +467,17 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "No vote detail retained for this slot",
+                        "description": "No cached payload for slot",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -415,59 +485,53 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "503": {
-                        "description": "Head vote tracker unavailable",
+                    "409": {
+                        "description": "Bidding closed for slot",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/buildoor/overview": {
-            "get": {
-                "description": "Returns a single-payload summary used by the multi-instance overview UI:\nrunning state, builder pubkey, current slot, EL client info, available/enabled\nservices, balances, and recent build stats.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Buildoor"
-                ],
-                "summary": "Get a compact overview of this buildoor instance",
-                "operationId": "getOverview",
-                "responses": {
-                    "200": {
-                        "description": "Success",
+                    },
+                    "502": {
+                        "description": "Bid construction or submission failed",
                         "schema": {
-                            "$ref": "#/definitions/api.OverviewResponse"
+                            "$ref": "#/definitions/api.ManualBidResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ePBS bidder not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/buildoor/proposer-preferences": {
+        "/api/buildoor/bid-budget": {
             "get": {
-                "description": "Returns all proposer preferences currently in the cache, received via P2P gossip.",
+                "description": "Returns the p2p bid budget of the current epoch (--bid-budget-epoch-gwei): the\nconfigured maximum and distribution, the spend so far (the highest bid gossiped\nper slot) and the remaining budget. Bids are capped to their slot's share of\nthe remaining budget and refused once it is exhausted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Buildoor"
                 ],
-                "summary": "Get cached proposer preferences",
-                "operationId": "getProposerPreferences",
+                "summary": "Epoch bid budget",
+                "operationId": "getBidBudget",
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.ProposerPreferencesResponse"
+                            "$ref": "#/definitions/p2p_bidder.BidBudgetStatus"
                         }
                     },
-                    "404": {
-                        "description": "Proposer preferences not enabled",
+                    "503": {
+                        "description": "ePBS bidder not available",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -478,51 +542,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/slot-results": {
+        "/api/buildoor/bids-won": {
             "get": {
-                "description": "Returns the recorded outcome history (build, bids, block\nsubmissions, reveals, inclusion, applied plan) for every\nactive slot within the inclusive slot range.",
+                "description": "Returns a paginated list of won blocks (Builder API and p2p ePBS) with transaction counts, blob counts, and values, read from the shared inclusion tracker.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ActionPlan"
+                    "Buildoor"
                 ],
-                "summary": "Get per-slot results",
-                "operationId": "getSlotResults",
+                "summary": "Get bids won (blocks of ours included on chain)",
+                "operationId": "getBidsWon",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Range start slot (inclusive)",
-                        "name": "min_slot",
-                        "in": "query",
-                        "required": true
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Range end slot (inclusive)",
-                        "name": "max_slot",
-                        "in": "query",
-                        "required": true
+                        "default": 20,
+                        "description": "Limit for pagination (max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.SlotResultsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Success",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.BidsWonResponse"
                         }
                     },
-                    "503": {
-                        "description": "Results tracker unavailable",
+                    "500": {
+                        "description": "Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -533,17 +588,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/slot-results/{slot}/bids": {
+        "/api/buildoor/blocks/{slot}": {
             "get": {
-                "description": "Lists the metadata of every signed bid stored for the slot\n(JSON only; fetch individual bids by index for SSZ). Returns\nan empty list for slots without bid artifacts.",
+                "description": "Assembles the full view of a slot we won so the WebUI never\nqueries the beacon node from the browser: the consensus block,\nits committed bid (Gloas+), our built payload with transaction\nhashes, the bid payment state, the committed blobs, the\npayload timeliness committee outcome from the next slot's block\nand the recorded slot result. A slot counts as won when its\nresult records an inclusion, its bid carries our builder index\nor it embeds a payload we built.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ActionPlan"
+                    "Buildoor"
                 ],
-                "summary": "List a slot's signed bid artifacts",
-                "operationId": "getSlotBidArtifacts",
+                "summary": "Explorer drill-down of a won slot's block",
+                "operationId": "getBlockView",
                 "parameters": [
                     {
                         "type": "integer",
@@ -557,7 +612,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SlotBidArtifactsResponse"
+                            "$ref": "#/definitions/api.BlockViewResponse"
                         }
                     },
                     "400": {
@@ -569,56 +624,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "503": {
-                        "description": "Results tracker unavailable",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/buildoor/slot-results/{slot}/bids/{index}": {
-            "get": {
-                "description": "Returns one signed bid created for the slot, addressed by its\nartifact index (see the bid listing endpoint). Pre-Gloas bids\nare SignedBuilderBid, Gloas+ bids SignedExecutionPayloadBid.\nContent negotiation as with the payload artifact endpoint.",
-                "produces": [
-                    "application/json",
-                    "application/octet-stream"
-                ],
-                "tags": [
-                    "ActionPlan"
-                ],
-                "summary": "Get one signed bid of a slot",
-                "operationId": "getSlotBidArtifact",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Slot",
-                        "name": "slot",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Bid artifact index",
-                        "name": "index",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Versioned signed bid (or raw SSZ)",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Slot not won by us",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -626,8 +633,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "No artifact for this slot/index",
+                    "502": {
+                        "description": "Beacon node request failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -635,8 +642,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "406": {
-                        "description": "No acceptable content type",
+                    "503": {
+                        "description": "Beacon node unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -647,55 +654,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/slot-results/{slot}/envelope": {
+        "/api/buildoor/builder-api-status": {
             "get": {
-                "description": "Returns the signed execution payload envelope constructed for\nthe slot's reveal (stored at construction time, so failed\npublishes remain inspectable). Content negotiation as with the\npayload artifact endpoint.",
+                "description": "Returns the current status of the Builder API including configuration and validator count.",
                 "produces": [
-                    "application/json",
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "ActionPlan"
-                ],
-                "summary": "Get the signed payload envelope of a slot",
-                "operationId": "getSlotEnvelopeArtifact",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Slot",
-                        "name": "slot",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Buildoor"
                 ],
+                "summary": "Get Builder API status",
+                "operationId": "getBuilderAPIStatus",
                 "responses": {
                     "200": {
-                        "description": "Versioned envelope (or raw SSZ)",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "No artifact for this slot",
+                        "description": "Success",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.BuilderAPIStatusResponse"
                         }
                     },
-                    "406": {
-                        "description": "No acceptable content type",
+                    "500": {
+                        "description": "Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -706,37 +684,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/slot-results/{slot}/payload": {
+        "/api/buildoor/builder-api/captures": {
             "get": {
-                "description": "Returns the exact execution payload built for the slot. With\n\"Accept: application/octet-stream\" the raw SSZ bytes are\nserved; otherwise a {\"version\", \"data\"} JSON envelope. The\nEth-Consensus-Version response header carries the fork name.",
+                "description": "Returns the most recent /eth/v*/builder/* exchanges, newest first, with full\nrequest and response headers and bodies (capped at --builder-api-capture-max-body,\nbinary SSZ bodies base64 encoded). Auth headers and secret-looking JSON fields are\nscrubbed. Served as a JSON attachment. Requires authentication.",
                 "produces": [
-                    "application/json",
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "ActionPlan"
+                    "Buildoor"
                 ],
-                "summary": "Get the built execution payload of a slot",
-                "operationId": "getSlotPayloadArtifact",
+                "summary": "Download the recent Builder API request/response captures",
+                "operationId": "getBuilderAPICaptures",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Slot",
-                        "name": "slot",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of exchanges (default all captured)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Versioned payload (or raw SSZ)",
+                        "description": "Success",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.BuilderAPICapturesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -745,16 +727,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "No artifact for this slot",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "406": {
-                        "description": "No acceptable content type",
+                        "description": "Capturing disabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -765,26 +738,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/buildoor/validators": {
+        "/api/buildoor/builder-preferences": {
             "get": {
-                "description": "Returns the list of validators registered via the Builder API (fee recipient preferences). Not paginated.",
+                "description": "Returns all builder preferences currently in the cache, submitted by proposers via the submitBuilderPreferences API.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Buildoor"
                 ],
-                "summary": "List registered validators",
-                "operationId": "getValidators",
+                "summary": "Get cached builder preferences",
+                "operationId": "getBuilderPreferences",
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.GetValidatorsResponse"
+                            "$ref": "#/definitions/api.BuilderPreferencesResponse"
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "404": {
+                        "description": "Builder API not enabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -795,27 +768,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/config": {
+        "/api/buildoor/circuit-breaker": {
             "get": {
-                "description": "Returns the buildoor configuration in use. Sensitive fields (builder key, wallet key, JWT secret) are redacted.",
+                "description": "Returns the consecutive-failure circuits (p2p bid submissions, p2p and\nBuilder API reveals), their threshold and whether they tripped and\ndisabled their service.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Config"
+                    "Buildoor"
                 ],
-                "summary": "Get buildoor configuration",
-                "operationId": "getConfig",
+                "summary": "Get circuit breaker state",
+                "operationId": "getCircuitBreaker",
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.CircuitBreakerResponse"
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "503": {
+                        "description": "Circuit breaker disabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -826,9 +798,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/config/epbs": {
+        "/api/buildoor/circuit-breaker/reset": {
             "post": {
-                "description": "Updates the EPBS (enshrined PBS) configuration including timing and bid\nparameters. Requires authentication.",
+                "description": "Re-enables the service (epbs or builder_api) and closes its circuits.\nRequires authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -836,10 +808,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Config"
+                    "Buildoor"
                 ],
-                "summary": "Update EPBS configuration",
-                "operationId": "updateEPBS",
+                "summary": "Re-enable a service disabled by the circuit breaker",
+                "operationId": "resetCircuitBreaker",
                 "parameters": [
                     {
                         "type": "string",
@@ -849,23 +821,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "EPBS configuration",
+                        "description": "Service to re-enable",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.UpdateEPBSRequest"
+                            "$ref": "#/definitions/api.ResetCircuitBreakerRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.CircuitBreakerResponse"
                         }
                     },
                     "400": {
@@ -886,8 +855,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "503": {
+                        "description": "Circuit breaker disabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -898,20 +867,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/config/schedule": {
-            "post": {
-                "description": "Updates the builder schedule configuration including mode, every_nth, and next_n\nsettings. Requires authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/buildoor/debug-bundle/{slot}": {
+            "get": {
+                "description": "Gathers everything known about the slot — redacted config, logs around the\nslot, the cached payload with its bid/reveal log, p2p bids seen, the recorded\nslot result, the action plan, raw SSZ artifacts and the beacon block — into a\nsingle tar.gz. Sections that could not be collected are listed in the bundle's\nmanifest.json. Requires authentication.",
                 "produces": [
-                    "application/json"
+                    "application/gzip"
                 ],
                 "tags": [
-                    "Config"
+                    "Buildoor"
                 ],
-                "summary": "Update schedule configuration",
-                "operationId": "updateSchedule",
+                "summary": "Download a post-mortem debug bundle for a slot",
+                "operationId": "getDebugBundle",
                 "parameters": [
                     {
                         "type": "string",
@@ -921,23 +887,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Schedule configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.UpdateScheduleRequest"
-                        }
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "tar.gz bundle",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -970,41 +931,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/config/settings": {
-            "post": {
-                "description": "Applies partial global settings updates keyed by canonical\nsettings paths (e.g. {\"epbs.bid_subsidy\": 1000,\n\"schedule.mode\": \"all\"}) without requiring full config\nobjects. Unknown keys are rejected. Requires authentication.",
-                "consumes": [
+        "/api/buildoor/diagnostics/subscriptions": {
+            "get": {
+                "description": "Lists every live internal event subscription (dispatcher event type, buffer\ncapacity, current lag, buffer high-water mark, delivered and dropped events).\nA growing dropped count means the subscriber cannot keep up.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Get internal event subscription diagnostics",
+                "operationId": "getSubscriptionDiagnostics",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.SubscriptionDiagnosticsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/epochs/{epoch}": {
+            "get": {
+                "description": "Returns the aggregated builder activity of a finished epoch: slots\nbuilt, bids sent and won, value earned, reveals missed and head vote\nparticipation averages. Summaries are emitted as epoch_summary SSE\nevents at each epoch boundary; epochs not summarized while running\nare recomputed from the retained slot results (without participation).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Config"
+                    "Stats"
                 ],
-                "summary": "Update global settings by key path",
-                "operationId": "updateSettings",
+                "summary": "Get the builder activity summary of an epoch",
+                "operationId": "getEpochSummary",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Epoch",
+                        "name": "epoch",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Map of settings key paths to values",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/epoch_summary.EpochSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1012,8 +988,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Unknown key or invalid value",
+                    "404": {
+                        "description": "Epoch not finished yet",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1021,8 +997,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "503": {
+                        "description": "Epoch summaries unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1033,51 +1009,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/lifecycle/deposit": {
-            "post": {
-                "description": "Initiates a builder registration deposit. If the builder is not yet\nregistered, this will register it with the specified amount. Requires authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/buildoor/head-votes/{slot}": {
+            "get": {
+                "description": "Returns the slot's raw single-attestation arrivals grouped by\nvalidator-ranges client name into fixed-width time buckets\nfrom the slot start, plus per-name totals and the count of\nattesters that landed on chain without being seen as singles.\nOnly slots still retained by the head vote tracker are served.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lifecycle"
+                    "Stats"
                 ],
-                "summary": "Trigger builder deposit",
-                "operationId": "postDeposit",
+                "summary": "Per-name head-vote arrival heatmap for a slot",
+                "operationId": "getHeadVoteDetail",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Deposit amount in gwei",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "amount_gwei": {
-                                    "type": "integer"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Beacon block root (default: the slot's primary root)",
+                        "name": "root",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bucket width in ms (default slot_ms/24, clamped to [50, slot_ms])",
+                        "name": "bucket_ms",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.HeadVoteDetailResponse"
                         }
                     },
                     "400": {
@@ -1089,17 +1057,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
-                        "description": "Lifecycle management not enabled",
+                        "description": "No vote detail retained for this slot",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1107,8 +1066,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "503": {
+                        "description": "Head vote tracker unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1119,41 +1078,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/lifecycle/exit": {
-            "post": {
-                "description": "Initiates a voluntary exit for the builder. This will begin the withdrawal\nprocess and the builder will stop being eligible for block building after\nthe exit is processed. Requires authentication.",
-                "consumes": [
+        "/api/buildoor/overview": {
+            "get": {
+                "description": "Returns a single-payload summary used by the multi-instance overview UI:\nrunning state, builder pubkey, current slot, EL client info, available/enabled\nservices, balances, and recent build stats.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Get a compact overview of this buildoor instance",
+                "operationId": "getOverview",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.OverviewResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/peer/bids": {
+            "get": {
+                "description": "Returns the p2p bids this node observed itself for slots \u003e= min_slot, plus\nthe node's name and builder identity. Polled by other buildoor instances\nlisted in their --peer-urls; bids merged from peers are never relayed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lifecycle"
+                    "Buildoor"
                 ],
-                "summary": "Trigger voluntary exit",
-                "operationId": "postExit",
+                "summary": "Locally observed p2p bids for mesh peers",
+                "operationId": "getPeerBids",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "description": "First slot to include (default 0)",
+                        "name": "min_slot",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/peer_mesh.Snapshot"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid min_slot",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1162,16 +1135,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Lifecycle management not enabled",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Server Error",
+                        "description": "Peer mesh not available",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1182,35 +1146,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/lifecycle/status": {
+        "/api/buildoor/peers": {
             "get": {
-                "description": "Returns the builder lifecycle status including registration state, balance,\npending payments, and epoch information.",
+                "description": "Returns the configured mesh peers with their last poll outcome and every p2p\nbid known for the recent slots, locally observed or merged from peers.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lifecycle"
+                    "Buildoor"
                 ],
-                "summary": "Get lifecycle status",
-                "operationId": "getLifecycleStatus",
+                "summary": "Network-wide builder view",
+                "operationId": "getPeers",
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.LifecycleStatusResponse"
+                            "$ref": "#/definitions/peer_mesh.NetworkView"
                         }
                     },
                     "404": {
-                        "description": "Lifecycle management not enabled",
+                        "description": "Peer mesh not available",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/penalty-monitor": {
+            "get": {
+                "description": "Returns the equivocation and proposer slashing incidents affecting our\nbuilder indices and why bidding is held, if it is.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Get penalty monitor incidents",
+                "operationId": "getPenaltyMonitor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PenaltyMonitorResponse"
+                        }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "503": {
+                        "description": "Penalty monitor disabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1221,9 +1206,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/lifecycle/topup": {
+        "/api/buildoor/penalty-monitor/acknowledge": {
             "post": {
-                "description": "Checks the builder balance and initiates a top-up if needed based on\nconfigured thresholds. Requires authentication.",
+                "description": "Acknowledges one incident (or all with id 0); bidding resumes once none\nis left unacknowledged. Requires authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1231,10 +1216,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lifecycle"
+                    "Buildoor"
                 ],
-                "summary": "Trigger balance top-up",
-                "operationId": "postTopup",
+                "summary": "Acknowledge a penalty monitor incident",
+                "operationId": "acknowledgePenalty",
                 "parameters": [
                     {
                         "type": "string",
@@ -1242,11 +1227,26 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Incident to acknowledge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.AcknowledgePenaltyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PenaltyMonitorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1264,7 +1264,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Lifecycle management not enabled",
+                        "description": "Unknown or acknowledged incident",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1272,8 +1272,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "503": {
+                        "description": "Penalty monitor disabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1284,26 +1284,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/stats": {
+        "/api/buildoor/proposer-preferences": {
             "get": {
-                "description": "Returns builder statistics including slots built, bids submitted/won,\ntotal paid, and reveal success/failure counts.",
+                "description": "Returns all proposer preferences currently in the cache, received via P2P gossip.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stats"
+                    "Buildoor"
                 ],
-                "summary": "Get builder statistics",
-                "operationId": "getStats",
+                "summary": "Get cached proposer preferences",
+                "operationId": "getProposerPreferences",
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.StatsResponse"
+                            "$ref": "#/definitions/api.ProposerPreferencesResponse"
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "404": {
+                        "description": "Proposer preferences not enabled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1314,236 +1314,3832 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/status": {
+        "/api/buildoor/relay-proxy": {
             "get": {
-                "description": "Returns the current builder status including running state, current slot,\nbuilder index and public key.",
+                "description": "Returns the relays configured with --relay-proxy-urls and the latest validator\nrequests forwarded to them through /relay-proxy, one entry per relay answer,\nnewest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Status"
+                    "Buildoor"
+                ],
+                "summary": "Recorded relay proxy traffic",
+                "operationId": "getRelayProxy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of exchanges to return (default all kept)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get builder status",
-                "operationId": "getStatus",
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.StatusResponse"
+                            "$ref": "#/definitions/relay_proxy.View"
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "400": {
+                        "description": "Invalid limit",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
+                    },
+                    "404": {
+                        "description": "Relay proxy not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/reveal/{slot}": {
+            "post": {
+                "description": "Publishes the slot's signed envelope now, bypassing its reveal gates,\nsuppression and deadline. Works on finished reveals too (re-publishing\nthe same envelope); manual attempts never consume the automatic retry\nbudget and a success ends the automatic schedule. The body may\noverride the broadcast validation level. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Publish a committed slot's reveal immediately",
+                "operationId": "triggerReveal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Broadcast validation override",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ManualRevealRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ManualRevealResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No reveal request for slot",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Envelope construction or publish failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ManualRevealResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Reveal service not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/reveal/{slot}/preview": {
+            "get": {
+                "description": "Returns the signed execution payload envelope the slot's reveal publishes\n(or published), building it if no attempt did yet; the built envelope is\nthen reused by the reveal. Only slots with a reveal request (a bid of ours\ncommitted in a beacon block, up to two slots after the reveal finished)\nare available. With \"Accept: application/octet-stream\" the raw SSZ bytes\nare served. Requires authentication (the envelope exposes the payload).",
+                "produces": [
+                    "application/json",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Preview the reveal envelope of a committed slot",
+                "operationId": "getRevealPreview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RevealPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No reveal request for slot",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "No acceptable content type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Envelope construction failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Reveal service not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/slot-results": {
+            "get": {
+                "description": "Returns the recorded outcome history (build, bids, block\nsubmissions, reveals, inclusion, applied plan) for every\nactive slot within the inclusive slot range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Get per-slot results",
+                "operationId": "getSlotResults",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Range start slot (inclusive)",
+                        "name": "min_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Range end slot (inclusive)",
+                        "name": "max_slot",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SlotResultsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Results tracker unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/slot-results/{slot}/bids": {
+            "get": {
+                "description": "Lists the metadata of every signed bid stored for the slot\n(JSON only; fetch individual bids by index for SSZ). Returns\nan empty list for slots without bid artifacts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "List a slot's signed bid artifacts",
+                "operationId": "getSlotBidArtifacts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SlotBidArtifactsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Results tracker unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/slot-results/{slot}/bids/{index}": {
+            "get": {
+                "description": "Returns one signed bid created for the slot, addressed by its\nartifact index (see the bid listing endpoint). Pre-Gloas bids\nare SignedBuilderBid, Gloas+ bids SignedExecutionPayloadBid.\nContent negotiation as with the payload artifact endpoint.",
+                "produces": [
+                    "application/json",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Get one signed bid of a slot",
+                "operationId": "getSlotBidArtifact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bid artifact index",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versioned signed bid (or raw SSZ)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No artifact for this slot/index",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "No acceptable content type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/slot-results/{slot}/bids/{index}/inspect": {
+            "get": {
+                "description": "Breaks down one stored signed bid for cross-checking against a\nconsensus client's verification: the decoded message, the\nhash tree root and generalized index of every top-level field,\nthe message root, the connected chain's signing domain and its\ninputs, the signing root and the signature. The signature is\nverified against the builder's registered key (Gloas+) or the\nbid's embedded key (legacy) when that key is known.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Inspect the signing of one signed bid of a slot",
+                "operationId": "getSlotBidInspection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bid artifact index",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.BidInspectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No artifact for this slot/index",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Inspection failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Results tracker or chain unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/slot-results/{slot}/envelope": {
+            "get": {
+                "description": "Returns the signed execution payload envelope constructed for\nthe slot's reveal (stored at construction time, so failed\npublishes remain inspectable). Content negotiation as with the\npayload artifact endpoint.",
+                "produces": [
+                    "application/json",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Get the signed payload envelope of a slot",
+                "operationId": "getSlotEnvelopeArtifact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versioned envelope (or raw SSZ)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No artifact for this slot",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "No acceptable content type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/slot-results/{slot}/payload": {
+            "get": {
+                "description": "Returns the exact execution payload built for the slot. With\n\"Accept: application/octet-stream\" the raw SSZ bytes are\nserved; otherwise a {\"version\", \"data\"} JSON envelope. The\nEth-Consensus-Version response header carries the fork name.",
+                "produces": [
+                    "application/json",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Get the built execution payload of a slot",
+                "operationId": "getSlotPayloadArtifact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versioned payload (or raw SSZ)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No artifact for this slot",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "No acceptable content type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/txs": {
+            "get": {
+                "description": "Returns the transactions queued for injection into built payloads, pending ones\nfirst, then the recently included, dropped or failed ones (newest first).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Injected transactions",
+                "operationId": "getInjectedTxs",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.InjectedTxsResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Transaction injection not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Queues raw signed transactions for the next built payloads (legacy and ePBS).\nThey are submitted to the EL mempool ahead of every build until a built payload\ncarries them, or dropped after 8 builds without; the mempool gossips them, so\nother builders may include them too. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Inject transactions into built payloads",
+                "operationId": "injectTxs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Raw signed transactions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.InjectTxsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Queued transactions",
+                        "schema": {
+                            "$ref": "#/definitions/api.InjectedTxsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Queue full",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Transaction injection not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/buildoor/validators": {
+            "get": {
+                "description": "Returns the list of validators registered via the Builder API (fee recipient preferences),\nincluding registrations backfilled from relays (marked by source). Not paginated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "List registered validators",
+                "operationId": "getValidators",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetValidatorsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config": {
+            "get": {
+                "description": "Returns the buildoor configuration in use. Sensitive fields (builder key, wallet key, JWT secret) are redacted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "Get buildoor configuration",
+                "operationId": "getConfig",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Settings version, sent back as If-Match on config updates"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/epbs": {
+            "post": {
+                "description": "Updates the EPBS (enshrined PBS) configuration including timing and bid\nparameters. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "Update EPBS configuration",
+                "operationId": "updateEPBS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Settings ETag the edit is based on (from GET /api/config)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "EPBS configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpdateEPBSRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New settings version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Settings changed since If-Match",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/revisions": {
+            "get": {
+                "description": "Returns the newest runtime config revisions (API updates, operator config\nchanges picked up on restart, rollbacks) with before/after values, newest\nfirst. Persisted when a state-db is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "Get the config revision history",
+                "operationId": "getConfigRevisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of revisions (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.ConfigRevisionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/rollback": {
+            "post": {
+                "description": "Restores every setting changed after the given revision to the value it had\nat that revision (as UI overrides). The rollback is recorded as a revision\nitself. Honours If-Match like the other config updates. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "Roll the config back to a previous revision",
+                "operationId": "rollbackConfig",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Settings ETag the rollback is based on (from GET /api/config)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Revision to restore",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ConfigRollbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.ConfigRollbackResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New settings version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown revision",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Settings changed since If-Match",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/schedule": {
+            "post": {
+                "description": "Updates the builder schedule configuration including mode, every_nth, and next_n\nsettings. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "Update schedule configuration",
+                "operationId": "updateSchedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Settings ETag the edit is based on (from GET /api/config)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Schedule configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New settings version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Settings changed since If-Match",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/config/settings": {
+            "post": {
+                "description": "Applies partial global settings updates keyed by canonical\nsettings paths (e.g. {\"epbs.bid_subsidy\": 1000,\n\"schedule.mode\": \"all\"}) without requiring full config\nobjects. Unknown keys are rejected. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Config"
+                ],
+                "summary": "Update global settings by key path",
+                "operationId": "updateSettings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Settings ETag the edit is based on (from GET /api/config)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Map of settings key paths to values",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New settings version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown key or invalid value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Settings changed since If-Match",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lifecycle/deposit": {
+            "post": {
+                "description": "Initiates a builder registration deposit. If the builder is not yet\nregistered, this will register it with the specified amount. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lifecycle"
+                ],
+                "summary": "Trigger builder deposit",
+                "operationId": "postDeposit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Deposit amount in gwei",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "amount_gwei": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lifecycle management not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Lifecycle is read-only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lifecycle/deposit/preview": {
+            "get": {
+                "description": "Estimates a deposit (unregistered builder) or top-ups without sending\nanything: queue fee, gas estimate and cost, total cost against the wallet\nbalance, resulting builder balance and (registrations) the estimated\nactivation epoch. Several amounts preview a batched top-up when a\ndeposit batching contract is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lifecycle"
+                ],
+                "summary": "Preview a deposit or top-up",
+                "operationId": "getDepositPreview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated amounts in gwei (default: the configured deposit/top-up amount)",
+                        "name": "amounts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/lifecycle.DepositPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lifecycle management not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Lifecycle is read-only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lifecycle/exit": {
+            "post": {
+                "description": "Initiates a voluntary exit for the builder. This will begin the withdrawal\nprocess and the builder will stop being eligible for block building after\nthe exit is processed. Refused while won bids are unrevealed or bids are\nstill in play (409 with the blockers) unless force=true. Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lifecycle"
+                ],
+                "summary": "Trigger voluntary exit",
+                "operationId": "postExit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exit despite outstanding payments and bids",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lifecycle management not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Lifecycle is read-only or exit blocked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lifecycle/status": {
+            "get": {
+                "description": "Returns the builder lifecycle status including registration state, balance,\npending payments, and epoch information.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lifecycle"
+                ],
+                "summary": "Get lifecycle status",
+                "operationId": "getLifecycleStatus",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.LifecycleStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lifecycle management not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lifecycle/topup": {
+            "post": {
+                "description": "Checks the builder balance and initiates a top-up if needed based on\nconfigured thresholds. With amounts_gwei, tops up those amounts instead\n(one transaction via the deposit batching contract when configured).\nRequires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lifecycle"
+                ],
+                "summary": "Trigger balance top-up",
+                "operationId": "postTopup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Explicit top-up amounts in gwei",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "amounts_gwei": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "integer"
+                                    }
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Lifecycle management not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Lifecycle is read-only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lifecycle/transactions": {
+            "get": {
+                "description": "Returns the recent transactions sent by the builder wallet (deposits,\ntop-ups, exits), newest first, with their lifecycle state: submitted,\nmined, confirmed, failed or replaced. Updates stream as wallet_tx events.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lifecycle"
+                ],
+                "summary": "Get wallet transactions",
+                "operationId": "getLifecycleTransactions",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.LifecycleTransactionsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lifecycle management not enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logs/stream": {
+            "get": {
+                "description": "Server-sent events tailing the structured log captured in\nmemory: each \"data:\" line is one JSON log entry (time, level,\nmsg, fields). The stream opens with up to ` + "`" + `backfill` + "`" + ` recent\nmatching entries, then follows new ones. Filters: ` + "`" + `level` + "`" + ` is\nthe least severe level included (e.g. \"warn\" streams warnings\nand errors), ` + "`" + `module` + "`" + ` a comma-separated list of component\n(module field) names, ` + "`" + `slot` + "`" + ` a slot field value. Entries a slow\nclient cannot keep up with are dropped and reported as a\n\"dropped\" event. Requires authentication.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Stream the builder log live",
+                "operationId": "streamLogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Least severe level included (trace|debug|info|warn|error)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated module names",
+                        "name": "module",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only entries logged for this slot",
+                        "name": "slot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Recent entries to replay first (max 1000)",
+                        "name": "backfill",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SSE stream of log entries",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Log capture disabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/rpc": {
+            "post": {
+                "description": "JSON-RPC 2.0 admin surface mirroring the RPC-style admin APIs of\nother builders, for tooling built against them. Accepts single\nand batch requests; params may be positional or named.\nMethods: buildoor_status() → status, stats and services;\nbuildoor_setSubsidy(subsidy_gwei, service?) sets the ePBS and/or\nBuilder API subsidy; buildoor_toggleService(service, enabled)\nenables or disables epbs, builder_api or lifecycle;\nbuildoor_bidHistory(limit?) lists our submitted bids, newest\nfirst. Mutating methods require the Authorization header\n(error -32001 otherwise). Errors are JSON-RPC error objects\non an HTTP 200 response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buildoor"
+                ],
+                "summary": "Admin JSON-RPC endpoint (buildoor_ namespace)",
+                "operationId": "adminRPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token (mutating methods)",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "JSON-RPC request (or an array of them)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RPCRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JSON-RPC response (or an array of them)",
+                        "schema": {
+                            "$ref": "#/definitions/api.RPCResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slots/{slot}/decisions": {
+            "get": {
+                "description": "Returns why buildoor did or did not build, bid and reveal for\nthe slot, in recording order: one entry per stage decision\nwith its outcome (done, skipped, failed), a reason code and\ndetail. Returns an empty list for slots without decisions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ActionPlan"
+                ],
+                "summary": "Get the decision journal of a slot",
+                "operationId": "getSlotDecisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SlotDecisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Decision journal unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats": {
+            "get": {
+                "description": "Returns builder statistics including slots built, bids submitted/won,\ntotal paid, and reveal success/failure counts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get builder statistics",
+                "operationId": "getStats",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/status": {
+            "get": {
+                "description": "Returns the current builder status including running state, current slot,\nbuilder index and public key, and the health of each beacon node event stream\ntopic (event/parse-failure/reconnect counts, last event time). degraded is set\nwhen the startup capability check disabled features the beacon node lacks the\ntopics or endpoints for; degradations lists them with their effect. With\nfallback beacon nodes, beacon_nodes reports each node's health and failover score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Get builder status",
+                "operationId": "getStatus",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatusResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/status/preflight": {
+            "get": {
+                "description": "Returns the structured report assembled at startup once every service was wired:\ndetected fork, spec preset and config name, the builder's registration, wallet\nbalances, the enabled services, the effective slot timing profile (ms relative to\nthe slot start) and warnings (degraded beacon node features, unregistered builder,\nempty wallets, inconsistent timing). It is a startup snapshot; see /api/status for\nlive state.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Get the startup preflight report",
+                "operationId": "getPreflight",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/preflight.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Report not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/version": {
+            "get": {
+                "description": "Returns the current version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Version"
+                ],
+                "summary": "Get the current version",
+                "operationId": "getVersion",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "action_plan.BidPlan": {
+            "type": "object",
+            "properties": {
+                "bid_end_time": {
+                    "type": "integer"
+                },
+                "bid_increase": {
+                    "description": "gwei",
+                    "type": "integer"
+                },
+                "bid_interval": {
+                    "description": "ms, \u003e= 0, 0 = single bid",
+                    "type": "integer"
+                },
+                "bid_min_amount": {
+                    "description": "gwei",
+                    "type": "integer"
+                },
+                "bid_start_time": {
+                    "type": "integer"
+                },
+                "bid_subsidy": {
+                    "description": "gwei",
+                    "type": "integer"
+                },
+                "bid_value_gwei": {
+                    "description": "BidValueGwei is an absolute bid base value replacing\nmax(blockValue, min) + subsidy; BidIncrease still applies per re-bid.\nAllows underbidding the block value for testing.",
+                    "type": "integer"
+                },
+                "ignore_missing_prefs": {
+                    "description": "IgnoreMissingPrefs bids with the payload's fee recipient when no gossip\nproposer preferences arrived for the slot, bypassing the skip gate.",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "$ref": "#/definitions/action_plan.Mode"
+                }
+            }
+        },
+        "action_plan.BuildPlan": {
+            "type": "object",
+            "properties": {
+                "blob_stuffing": {
+                    "description": "BlobStuffing fills the slot's block with synthetic blob transactions\nfrom the builder wallet: target, max or a blob count, or off to\nsuppress the --blob-stuffing schedule for the slot.",
+                    "type": "string"
+                },
+                "faults": {
+                    "description": "Faults corrupts these payload fields (config.PayloadFault*) after the\nbuild, before the payload feeds the bid and the reveal — an invalid\npayload for testing client rejection paths. Set on a slot it wins over\nthe probabilistic --payload-fault-pct selection.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "gas_limit": {
+                    "description": "GasLimit is the gas limit target of the slot's payload, replacing\n--gas-limit and the proposer's target. 0 inherits.",
+                    "type": "integer"
+                },
+                "gas_limit_violate": {
+                    "description": "GasLimitViolate moves the slot's payload gas limit away from its\ntarget (negative testing, see --gas-limit-violate).",
+                    "type": "boolean"
+                },
+                "reorg_parent_payload": {
+                    "description": "ReorgParentPayload builds on the grandparent (n-2) execution payload\ninstead of the immediate parent: the FCU head block hash and the payload\nattributes' withdrawals are taken from the PARENT slot's payload\nattributes (whose parent is n-2), while every other property comes from\nthe current slot. This is a deliberate parent-payload reorg attempt —\nrejected by mainnet forkchoice, but useful for exercising the reveal /\ninclusion path against a withheld parent.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "action_plan.BuilderAPIPlan": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "$ref": "#/definitions/action_plan.Mode"
+                },
+                "response_delay_ms": {
+                    "description": "ResponseDelayMs delays the bid response by this many milliseconds\n(context-cancellable, capped at one slot).",
+                    "type": "integer"
+                },
+                "total_value_override_gwei": {
+                    "description": "TotalValueOverrideGwei is the absolute total proposer-visible bid value\n(before the Gloas execution-payment split), replacing block value +\nsubsidy. May exceed the block value to test payment edge cases.",
+                    "type": "integer"
+                },
+                "value_subsidy_gwei": {
+                    "description": "ValueSubsidyGwei replaces the global BlockValueSubsidyGwei for this slot.",
+                    "type": "integer"
+                }
+            }
+        },
+        "action_plan.FrozenPlan": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "description": "Resolved effective settings; a nil category = suppressed for this slot\n(by plan or by the global enable flags / protocol applicability).",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/action_plan.ResolvedBidSettings"
+                        }
+                    ]
+                },
+                "build": {
+                    "description": "Build is the complete resolved build decision for the slot (schedule +\nplan force/suppress). Always non-nil.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/action_plan.ResolvedBuildSettings"
+                        }
+                    ]
+                },
+                "builder_api": {
+                    "$ref": "#/definitions/action_plan.ResolvedBuilderAPISettings"
+                },
+                "fork": {
+                    "description": "fork name at the target slot",
+                    "type": "string"
+                },
+                "frozen_at": {
+                    "type": "string"
+                },
+                "plan": {
+                    "description": "raw sparse plan; nil = none existed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/action_plan.SlotPlan"
+                        }
+                    ]
+                },
+                "reveal": {
+                    "$ref": "#/definitions/action_plan.ResolvedRevealSettings"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "transforms": {
+                    "description": "Transforms carries the effective jq transform expressions (empty when no\ntransform plan applies). Nil only when no plan expression is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/action_plan.ResolvedTransforms"
+                        }
+                    ]
+                }
+            }
+        },
+        "action_plan.Mode": {
+            "type": "string",
+            "enum": [
+                "disabled",
+                "custom"
+            ],
+            "x-enum-varnames": [
+                "ModeDisabled",
+                "ModeCustom"
+            ]
+        },
+        "action_plan.PlanUpdate": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "build": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "builder_api": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "delete": {
+                    "type": "boolean"
+                },
+                "from_slot": {
+                    "type": "integer"
+                },
+                "reveal": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "set": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "to_slot": {
+                    "type": "integer"
+                },
+                "transforms": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "action_plan.ResolvedBidSettings": {
+            "type": "object",
+            "properties": {
+                "canary": {
+                    "description": "Canary marks that canary mode priced the slot's bids at the fixed\ncanary value (ValueGwei; no increase or subsidy).",
+                    "type": "boolean"
+                },
+                "counter_max_gwei": {
+                    "type": "integer"
+                },
+                "counter_step_gwei": {
+                    "type": "integer"
+                },
+                "end_ms": {
+                    "type": "integer"
+                },
+                "forced": {
+                    "description": "Forced marks that the plan activated bidding although the module is\nglobally disabled.",
+                    "type": "boolean"
+                },
+                "ignore_missing_prefs": {
+                    "description": "IgnoreMissingPrefs bids without gossip proposer preferences.",
+                    "type": "boolean"
+                },
+                "increase_gwei": {
+                    "type": "integer"
+                },
+                "interval_ms": {
+                    "type": "integer"
+                },
+                "min_gwei": {
+                    "type": "integer"
+                },
+                "random_walk_step_gwei": {
+                    "type": "integer"
+                },
+                "replace_stale": {
+                    "description": "ReplaceStale replaces bids committing to a stale payload with the\nrebuilt payload's bid (epbs.replace_stale_bids).",
+                    "type": "boolean"
+                },
+                "snipe": {
+                    "description": "Snipe sends a single bid SnipeLeadMs before EndMs, only when no\ncompetitor bid above SnipeMaxCompetitorGwei was seen (epbs.snipe_*).",
+                    "type": "boolean"
+                },
+                "snipe_lead_ms": {
+                    "type": "integer"
+                },
+                "snipe_max_competitor_gwei": {
+                    "type": "integer"
+                },
+                "start_ms": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "description": "Strategy prices the slot's automatic bids (epbs.bid_strategy, one of\nthe config.BidStrategy* names); the counter-bid and random-walk\nparameters are only set for their strategy.",
+                    "type": "string"
+                },
+                "subsidy_gwei": {
+                    "type": "integer"
+                },
+                "value_gwei": {
+                    "description": "ValueGwei, when set, is the absolute bid base value replacing the\nmax(blockValue, min) + subsidy formula (IncreaseGwei still applies).",
+                    "type": "integer"
+                }
+            }
+        },
+        "action_plan.ResolvedBuildSettings": {
+            "type": "object",
+            "properties": {
+                "blob_stuffing": {
+                    "description": "BlobStuffing is the blob count the block is filled to with synthetic\nblob transactions (target, max or a number): the plan's\nbuild.blob_stuffing, else --blob-stuffing when the schedule selects the\nslot. Empty for no stuffing.",
+                    "type": "string"
+                },
+                "build": {
+                    "description": "Build is the final decision: build a payload for this slot or not.",
+                    "type": "boolean"
+                },
+                "build_start_time_ms": {
+                    "description": "BuildStartTimeMs is the effective build start time, milliseconds\nrelative to slot start (signed).",
+                    "type": "integer"
+                },
+                "faults": {
+                    "description": "Faults are the payload fields (config.PayloadFault*) corrupted after\nthe build: the plan's build.faults, else the configured fields when the\nslot's roll falls within --payload-fault-pct.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "forced": {
+                    "description": "Forced marks builds the plan pushed past the schedule (they never\nconsume the next_n budget).",
+                    "type": "boolean"
+                },
+                "gas_limit": {
+                    "description": "GasLimit is the gas limit target replacing the proposer's: the plan's\nbuild.gas_limit, else --gas-limit. 0 keeps the proposer's target.",
+                    "type": "integer"
+                },
+                "gas_limit_violate": {
+                    "description": "GasLimitViolate moves the payload's gas limit away from the target\n(build.gas_limit_violate or --gas-limit-violate).",
+                    "type": "boolean"
+                },
+                "plan_involved": {
+                    "description": "PlanInvolved marks decisions where a per-slot plan existed or any\nconsumer was effectively active — i.e. skips worth surfacing.",
+                    "type": "boolean"
+                },
+                "reorg_parent_payload": {
+                    "description": "ReorgParentPayload builds the slot's payload on the grandparent (n-2)\nexecution payload instead of the immediate parent — a deliberate\nparent-payload reorg attempt (see BuildPlan.ReorgParentPayload).",
+                    "type": "boolean"
+                },
+                "skip_reason": {
+                    "description": "SkipReason is one of the BuildSkipReason* constants when Build is\nfalse, empty otherwise.",
+                    "type": "string"
+                }
+            }
+        },
+        "action_plan.ResolvedBuilderAPISettings": {
+            "type": "object",
+            "properties": {
+                "canary": {
+                    "description": "Canary marks that canary mode priced the served bid at the fixed\ncanary value (TotalValueGwei; no subsidy).",
+                    "type": "boolean"
+                },
+                "delay_ms": {
+                    "type": "integer"
+                },
+                "forced": {
+                    "description": "Forced marks that the plan activated serving although the module is\nglobally disabled.",
+                    "type": "boolean"
+                },
+                "subsidy_gwei": {
+                    "type": "integer"
+                },
+                "total_value_gwei": {
+                    "description": "TotalValueGwei, when set, is the absolute total proposer-visible bid\nvalue (before the Gloas execution-payment split).",
+                    "type": "integer"
+                },
+                "value_boost_pct": {
+                    "description": "ValueBoostPct, when non-zero, reports the served bid's total value at\nthis percentage of its real value (builder_api.value_boost_pct,\ntest-only; see BoostGwei).",
+                    "type": "integer"
+                }
+            }
+        },
+        "action_plan.ResolvedRevealSettings": {
+            "type": "object",
+            "properties": {
+                "broadcast_validation": {
+                    "description": "BroadcastValidation is the envelope submission's broadcast_validation\nlevel: gossip | consensus | consensus_and_equivocation.",
+                    "type": "string"
+                },
+                "bypass_deadline": {
+                    "description": "BypassDeadline disables the \"past the in-slot deadline → skip\" check so\ndeliberately late reveals are attempted.",
+                    "type": "boolean"
+                },
+                "chaos": {
+                    "description": "Chaos is the slot's reveal chaos outcome (RevealChaos* constants):\nwithheld reveals are Suppressed, delayed ones are time-gated\nreveal.chaos_delay_ms after the reveal time with the deadline\nbypassed. Empty without chaos.",
+                    "type": "string"
+                },
+                "gate_mode": {
+                    "description": "GateMode decides the reveal moment: time | vote | vote_or_time |\nvote_and_time.",
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "description": "MaxAttempts / RetryIntervalMs are the publish retry policy\n(global-only, no per-slot override).",
+                    "type": "integer"
+                },
+                "require_inclusion": {
+                    "description": "RequireInclusion holds the reveal until the committing block is seen\nat the head with our bid (global-only, no per-slot override).",
+                    "type": "boolean"
+                },
+                "retry_interval_ms": {
+                    "type": "integer"
+                },
+                "reveal_time_ms": {
+                    "type": "integer"
+                },
+                "suppressed": {
+                    "type": "boolean"
+                },
+                "vote_threshold_pct": {
+                    "description": "VoteThresholdPct is the participation threshold (percent) opening the\nvote gate.",
+                    "type": "integer"
+                }
+            }
+        },
+        "action_plan.ResolvedTransforms": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "type": "string"
+                },
+                "envelope": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                }
+            }
+        },
+        "action_plan.RevealPlan": {
+            "type": "object",
+            "properties": {
+                "broadcast_validation": {
+                    "description": "BroadcastValidation overrides the envelope submission's broadcast\nvalidation level: gossip | consensus | consensus_and_equivocation.",
+                    "type": "string"
+                },
+                "gate_mode": {
+                    "description": "GateMode overrides the reveal gate: time | vote | vote_or_time |\nvote_and_time.",
+                    "type": "string"
+                },
+                "mode": {
+                    "$ref": "#/definitions/action_plan.Mode"
+                },
+                "reveal_time_ms": {
+                    "description": "RevealTimeMs is signed milliseconds relative to slot start (time gate).",
+                    "type": "integer"
+                },
+                "vote_threshold_pct": {
+                    "description": "VoteThresholdPct overrides the vote gate's participation threshold.",
+                    "type": "integer"
+                }
+            }
+        },
+        "action_plan.SlotPlan": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "$ref": "#/definitions/action_plan.BidPlan"
+                },
+                "build": {
+                    "$ref": "#/definitions/action_plan.BuildPlan"
+                },
+                "builder_api": {
+                    "$ref": "#/definitions/action_plan.BuilderAPIPlan"
+                },
+                "reveal": {
+                    "$ref": "#/definitions/action_plan.RevealPlan"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "transforms": {
+                    "$ref": "#/definitions/action_plan.TransformPlan"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "action_plan.TransformPlan": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "type": "string"
+                },
+                "envelope": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                }
+            }
+        },
+        "alerting.RuleStatus": {
+            "type": "object",
+            "properties": {
+                "action_error": {
+                    "type": "string"
+                },
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "evaluated_slot": {
+                    "type": "integer"
+                },
+                "fire_count": {
+                    "type": "integer"
+                },
+                "firing_since": {
+                    "type": "string"
+                },
+                "last_fired_at": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "min_samples": {
+                    "description": "MinSamples is the minimum number of window samples (e.g. slots with a\nreveal attempt) before the rule is evaluated; fewer reports no_data.",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "\u003e, \u003e=, \u003c, \u003c=",
+                    "type": "string"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "window_slots": {
+                    "description": "WindowSlots is the sliding slot window of window metrics.",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.AcknowledgePenaltyRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "0 = all",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ActionPlanResponse": {
+            "type": "object",
+            "properties": {
+                "max_slot": {
+                    "type": "integer"
+                },
+                "min_slot": {
+                    "type": "integer"
+                },
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/action_plan.SlotPlan"
+                    }
+                }
+            }
+        },
+        "api.AlertsResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/alerting.RuleStatus"
+                    }
+                }
+            }
+        },
+        "api.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.AuditLog"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BalanceHistoryPoint": {
+            "type": "object",
+            "properties": {
+                "cl_balance_gwei": {
+                    "type": "integer"
+                },
+                "effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "max_effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "min_effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "pending_payments_gwei": {
+                    "type": "integer"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "wallet_balance_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BalanceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "bucket_ms": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BalanceHistoryPoint"
+                    }
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BidArtifactMetaEntry": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "description": "unix milliseconds",
+                    "type": "integer"
+                },
+                "execution_payment_gwei": {
+                    "type": "integer"
+                },
+                "fork": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "total_value_gwei": {
+                    "type": "integer"
+                },
+                "transport": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BidFieldRootEntry": {
+            "type": "object",
+            "properties": {
+                "gindex": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "root": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BidInspectionResponse": {
+            "type": "object",
+            "properties": {
+                "artifact": {
+                    "description": "\"bid\" (Gloas+) or \"header\" (legacy)",
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "domain_type": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BidFieldRootEntry"
+                    }
+                },
+                "fork": {
+                    "type": "string"
+                },
+                "fork_version": {
+                    "type": "string"
+                },
+                "genesis_validators_root": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Message is the decoded bid message (all field values)."
+                },
+                "message_root": {
+                    "type": "string"
+                },
+                "pubkey": {
+                    "description": "Pubkey and SignatureValid are omitted when the signing key is unknown\n(a payload bid whose builder is not in the current epoch stats).",
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signature_valid": {
+                    "type": "boolean"
+                },
+                "signing_root": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BidsWonResponse": {
+            "type": "object",
+            "properties": {
+                "bids_won": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload_bidder.WonBlock"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BlockViewBid": {
+            "type": "object",
+            "properties": {
+                "block_hash": {
+                    "type": "string"
+                },
+                "builder_index": {
+                    "type": "integer"
+                },
+                "execution_payment_gwei": {
+                    "type": "integer"
+                },
+                "fee_recipient": {
+                    "type": "string"
+                },
+                "gas_limit": {
+                    "type": "integer"
+                },
+                "num_blob_commitments": {
+                    "type": "integer"
+                },
+                "ours": {
+                    "description": "builder index matches ours",
+                    "type": "boolean"
+                },
+                "parent_block_hash": {
+                    "type": "string"
+                },
+                "parent_block_root": {
+                    "type": "string"
+                },
+                "value_gwei": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BlockViewBlob": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "kzg_commitment": {
+                    "type": "string"
+                },
+                "versioned_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BlockViewBlock": {
+            "type": "object",
+            "properties": {
+                "execution_block_hash": {
+                    "description": "embedded payload (pre-Gloas)",
+                    "type": "string"
+                },
+                "graffiti": {
+                    "type": "string"
+                },
+                "num_attestations": {
+                    "type": "integer"
+                },
+                "num_attester_slashings": {
+                    "type": "integer"
+                },
+                "num_bls_changes": {
+                    "type": "integer"
+                },
+                "num_deposits": {
+                    "type": "integer"
+                },
+                "num_payload_attestations": {
+                    "type": "integer"
+                },
+                "num_proposer_slashings": {
+                    "type": "integer"
+                },
+                "num_voluntary_exits": {
+                    "type": "integer"
+                },
+                "parent_root": {
+                    "type": "string"
+                },
+                "proposer_index": {
+                    "type": "integer"
+                },
+                "root": {
+                    "type": "string"
+                },
+                "state_root": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BlockViewPTC": {
+            "type": "object",
+            "properties": {
+                "blob_data_available": {
+                    "type": "integer"
+                },
+                "check_slot": {
+                    "description": "block the payload attestations came from",
+                    "type": "integer"
+                },
+                "payload_present": {
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "PTC_SIZE",
+                    "type": "integer"
+                },
+                "timely": {
+                    "description": "Timely is the PTC verdict: more than half the committee saw the\npayload present.",
+                    "type": "boolean"
+                },
+                "votes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BlockViewPayload": {
+            "type": "object",
+            "properties": {
+                "base_fee_per_gas": {
+                    "description": "wei",
+                    "type": "string"
+                },
+                "block_hash": {
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "block_value_wei": {
+                    "type": "string"
+                },
+                "fee_recipient": {
+                    "type": "string"
+                },
+                "gas_limit": {
+                    "type": "integer"
+                },
+                "gas_used": {
+                    "type": "integer"
+                },
+                "num_withdrawals": {
+                    "type": "integer"
+                },
+                "parent_hash": {
+                    "type": "string"
+                },
+                "reveal_transport": {
+                    "type": "string"
+                },
+                "revealed_at": {
+                    "description": "Reveal of the payload (Gloas+): when and through which flow it was\npublished; nil while unrevealed.",
+                    "type": "string"
+                },
+                "state_root": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "description": "transaction hashes, in block order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.BlockViewPayment": {
+            "type": "object",
+            "properties": {
+                "pending_gwei": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "paid | pending | unknown",
+                    "type": "string"
+                },
+                "value_gwei": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BlockViewResponse": {
+            "type": "object",
+            "properties": {
+                "bid": {
+                    "description": "Bid is the execution payload bid the block committed to (Gloas+).",
+                    "$ref": "#/definitions/api.BlockViewBid"
+                },
+                "blobs": {
+                    "description": "Blobs are the committed blobs (bid commitments, or our payload's\nbundle when no bid is at hand).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BlockViewBlob"
+                    }
+                },
+                "block": {
+                    "description": "Block is the consensus block at the slot (nil when the beacon node has\nnone, e.g. it was reorged out).",
+                    "$ref": "#/definitions/api.BlockViewBlock"
+                },
+                "epoch": {
+                    "type": "integer"
+                },
+                "fork": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is our built payload (nil once evicted from the payload cache).",
+                    "$ref": "#/definitions/api.BlockViewPayload"
+                },
+                "payment": {
+                    "description": "Payment is the bid payment state (Gloas+).",
+                    "$ref": "#/definitions/api.BlockViewPayment"
+                },
+                "ptc": {
+                    "description": "PTC is the payload timeliness committee outcome, taken from the payload\nattestations of the next slot's block (Gloas+; nil while that block is\nnot available).",
+                    "$ref": "#/definitions/api.BlockViewPTC"
+                },
+                "result": {
+                    "description": "Result is the recorded slot history (build, bids, reveals, inclusion).",
+                    "$ref": "#/definitions/slot_results.SlotResult"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BuilderAPICapturesResponse": {
+            "type": "object",
+            "properties": {
+                "captures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/builderapi.CapturedExchange"
+                    }
+                }
+            }
+        },
+        "api.BuilderAPIStatusResponse": {
+            "type": "object",
+            "properties": {
+                "block_value_subsidy_gwei": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "validator_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BuilderPreferencesEntry": {
+            "type": "object",
+            "properties": {
+                "max_execution_payment": {
+                    "type": "integer"
+                },
+                "validator_pubkey": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.BuilderPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BuilderPreferencesEntry"
+                    }
+                }
+            }
+        },
+        "api.BuilderSummary": {
+            "type": "object",
+            "properties": {
+                "builder_index": {
+                    "type": "integer"
+                },
+                "builder_pubkey": {
+                    "type": "string"
+                },
+                "cl_balance_gwei": {
+                    "type": "integer"
+                },
+                "deposit_epoch": {
+                    "type": "integer"
+                },
+                "effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "identity_error": {
+                    "type": "string"
+                },
+                "is_registered": {
+                    "type": "boolean"
+                },
+                "lifecycle_enabled": {
+                    "type": "boolean"
+                },
+                "lifecycle_read_only": {
+                    "type": "boolean"
+                },
+                "outstanding_bid_slots": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pending_payments_gwei": {
+                    "type": "integer"
+                },
+                "primary": {
+                    "description": "the builder key (Builder API, reveals of Builder API wins)",
+                    "type": "boolean"
+                },
+                "registration_state": {
+                    "type": "string"
+                },
+                "wallet_address": {
+                    "type": "string"
+                },
+                "wallet_balance_wei": {
+                    "type": "string"
+                },
+                "withdrawable_epoch": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.BuildersResponse": {
+            "type": "object",
+            "properties": {
+                "builders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BuilderSummary"
+                    }
+                }
+            }
+        },
+        "api.CircuitBreakerResponse": {
+            "type": "object",
+            "properties": {
+                "circuits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/circuit_breaker.Circuit"
+                    }
+                }
+            }
+        },
+        "api.ConfigRevisionsResponse": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Revision"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ConfigRollbackRequest": {
+            "type": "object",
+            "properties": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ConfigRollbackResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Change"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.GetValidatorsResponse": {
+            "type": "object",
+            "properties": {
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ValidatorRegistrationResponse"
+                    }
+                }
+            }
+        },
+        "api.HeadVoteDetailResponse": {
+            "type": "object",
+            "properties": {
+                "bucket_count": {
+                    "type": "integer"
+                },
+                "bucket_ms": {
+                    "type": "integer"
+                },
+                "root": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HeadVoteDetailRow"
+                    }
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "slot_start_ms": {
+                    "type": "integer"
+                },
+                "total_members": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.HeadVoteDetailRow": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "in_block_unseen": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seen": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.InjectTxsRequest": {
+            "type": "object",
+            "properties": {
+                "txs": {
+                    "description": "Raw signed transactions (0x-prefixed hex); blob transactions in their\nnetwork form with sidecar",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.InjectedTxResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Builds the transaction was submitted for",
+                    "type": "integer"
+                },
+                "blobs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "included_block_hash": {
+                    "type": "string"
+                },
+                "included_slot": {
+                    "description": "First built payload carrying the transaction (status included)",
+                    "type": "integer"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "queued_at": {
+                    "description": "Unix milliseconds",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "Encoded size in bytes (with blob sidecar)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending | included | dropped | failed",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.InjectedTxsResponse": {
+            "type": "object",
+            "properties": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.InjectedTxResponse"
+                    }
+                }
+            }
+        },
+        "api.LifecycleStatusResponse": {
+            "type": "object",
+            "properties": {
+                "balance_gwei": {
+                    "type": "integer"
+                },
+                "builder_index": {
+                    "type": "integer"
+                },
+                "deposit_epoch": {
+                    "type": "integer"
+                },
+                "effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "is_registered": {
+                    "type": "boolean"
+                },
+                "pending_payments_gwei": {
+                    "type": "integer"
+                },
+                "read_only": {
+                    "type": "boolean"
+                },
+                "withdrawable_epoch": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.LifecycleTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/wallet.TxRecord"
+                    }
+                }
+            }
+        },
+        "api.ManualBidRequest": {
+            "type": "object",
+            "properties": {
+                "fault": {
+                    "description": "Deliberately invalid bid: zero_value | exceeds_balance | malformed_value",
+                    "type": "string"
+                },
+                "raw_value": {
+                    "description": "JSON value gossiped by a malformed_value bid (default 2^64)",
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Bid value in gwei",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ManualBidResponse": {
+            "type": "object",
+            "properties": {
+                "bid_count": {
+                    "description": "Bids sent for the slot, this one included",
+                    "type": "integer"
+                },
+                "block_hash": {
+                    "type": "string"
+                },
+                "competitor_high_gwei": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fault": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "submitted | constructed | failed",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Bid value in gwei",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ManualRevealRequest": {
+            "type": "object",
+            "properties": {
+                "broadcast_validation": {
+                    "description": "gossip | consensus | consensus_and_equivocation",
+                    "type": "string"
+                }
+            }
+        },
+        "api.ManualRevealResponse": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "Manual attempt number for the slot",
+                    "type": "integer"
+                },
+                "broadcast_validation": {
+                    "description": "Override applied (empty = the slot's frozen level)",
+                    "type": "string"
+                },
+                "completed_at": {
+                    "description": "unix milliseconds",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "description": "unix milliseconds",
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "transport": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OverviewBalances": {
+            "type": "object",
+            "properties": {
+                "cl_balance_gwei": {
+                    "type": "integer"
+                },
+                "effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "pending_payments_gwei": {
+                    "type": "integer"
+                },
+                "wallet_address": {
+                    "type": "string"
+                },
+                "wallet_balance_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OverviewELClient": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OverviewResponse": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "$ref": "#/definitions/api.OverviewBalances"
+                },
+                "builder_index": {
+                    "type": "integer"
+                },
+                "builder_pubkey": {
+                    "type": "string"
+                },
+                "current_slot": {
+                    "type": "integer"
+                },
+                "el_client": {
+                    "$ref": "#/definitions/api.OverviewELClient"
+                },
+                "is_registered": {
+                    "type": "boolean"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "services": {
+                    "$ref": "#/definitions/api.OverviewServices"
+                },
+                "stats": {
+                    "$ref": "#/definitions/api.OverviewStats"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OverviewServices": {
+            "type": "object",
+            "properties": {
+                "builder_api_available": {
+                    "type": "boolean"
+                },
+                "builder_api_enabled": {
+                    "type": "boolean"
+                },
+                "epbs_available": {
+                    "type": "boolean"
+                },
+                "epbs_bid_hold": {
+                    "type": "string"
+                },
+                "epbs_enabled": {
+                    "type": "boolean"
+                },
+                "epbs_identity_error": {
+                    "type": "string"
+                },
+                "epbs_registration_state": {
+                    "type": "string"
+                },
+                "lifecycle_available": {
+                    "type": "boolean"
+                },
+                "lifecycle_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.OverviewStats": {
+            "type": "object",
+            "properties": {
+                "bids_submitted": {
+                    "type": "integer"
+                },
+                "bids_won": {
+                    "type": "integer"
+                },
+                "blocks_included": {
+                    "type": "integer"
+                },
+                "builder_api_blocks_published": {
+                    "type": "integer"
+                },
+                "builder_api_headers_requested": {
+                    "type": "integer"
+                },
+                "builder_api_registered_validators": {
+                    "type": "integer"
+                },
+                "slots_built": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.PenaltyMonitorResponse": {
+            "type": "object",
+            "properties": {
+                "hold_reason": {
+                    "description": "HoldReason is set while bidding is held on unacknowledged incidents.",
+                    "type": "string"
+                },
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/penalty_monitor.Incident"
+                    }
+                }
+            }
+        },
+        "api.ProposerPreferencesEntry": {
+            "type": "object",
+            "properties": {
+                "client_name": {
+                    "type": "string"
+                },
+                "fee_recipient": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "target_gas_limit": {
+                    "type": "integer"
+                },
+                "validator_index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ProposerPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ProposerPreferencesEntry"
+                    }
+                }
+            }
+        },
+        "api.RPCError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.RPCRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "absent for notifications",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "jsonrpc": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "positional array or named object",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "api.RPCResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/api.RPCError"
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "jsonrpc": {
+                    "type": "string"
+                },
+                "result": {}
+            }
+        },
+        "api.ResetCircuitBreakerRequest": {
+            "type": "object",
+            "properties": {
+                "service": {
+                    "description": "epbs | builder_api",
+                    "type": "string"
+                }
+            }
+        },
+        "api.RevealPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Automatic publish attempts so far",
+                    "type": "integer"
+                },
+                "blob_count": {
+                    "type": "integer"
+                },
+                "broadcast_validation": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "done": {
+                    "description": "No further automatic attempts",
+                    "type": "boolean"
+                },
+                "published": {
+                    "description": "Beacon node accepted the envelope",
+                    "type": "boolean"
+                },
+                "transport": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SlotBidArtifactsResponse": {
+            "type": "object",
+            "properties": {
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BidArtifactMetaEntry"
+                    }
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
         },
-        "/api/version": {
-            "get": {
-                "description": "Returns the current version",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Version"
-                ],
-                "summary": "Get the current version",
-                "operationId": "getVersion",
-                "responses": {
-                    "200": {
-                        "description": "Success",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
+        "api.SlotDecisionsResponse": {
+            "type": "object",
+            "properties": {
+                "decisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Decision"
                     }
+                },
+                "slot": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "action_plan.BidPlan": {
+        },
+        "api.SlotResultsResponse": {
             "type": "object",
             "properties": {
-                "bid_end_time": {
+                "max_slot": {
                     "type": "integer"
                 },
-                "bid_increase": {
-                    "description": "gwei",
+                "min_slot": {
                     "type": "integer"
                 },
-                "bid_interval": {
-                    "description": "ms, \u003e= 0, 0 = single bid",
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slot_results.SlotResult"
+                    }
+                }
+            }
+        },
+        "api.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "bids_submitted": {
                     "type": "integer"
                 },
-                "bid_min_amount": {
-                    "description": "gwei",
+                "bids_won": {
                     "type": "integer"
                 },
-                "bid_start_time": {
+                "blocks_included": {
                     "type": "integer"
                 },
-                "bid_subsidy": {
-                    "description": "gwei",
+                "builder_api_blocks_published": {
                     "type": "integer"
                 },
-                "bid_value_gwei": {
-                    "description": "BidValueGwei is an absolute bid base value replacing\nmax(blockValue, min) + subsidy; BidIncrease still applies per re-bid.\nAllows underbidding the block value for testing.",
+                "builder_api_headers_requested": {
+                    "description": "Builder API stats",
                     "type": "integer"
                 },
-                "ignore_missing_prefs": {
-                    "description": "IgnoreMissingPrefs bids with the payload's fee recipient when no gossip\nproposer preferences arrived for the slot, bypassing the skip gate.",
-                    "type": "boolean"
+                "builder_api_registered_validators": {
+                    "type": "integer"
                 },
-                "mode": {
-                    "$ref": "#/definitions/action_plan.Mode"
-                }
-            }
-        },
-        "action_plan.BuildPlan": {
-            "type": "object",
-            "properties": {
-                "reorg_parent_payload": {
-                    "description": "ReorgParentPayload builds on the grandparent (n-2) execution payload\ninstead of the immediate parent: the FCU head block hash and the payload\nattributes' withdrawals are taken from the PARENT slot's payload\nattributes (whose parent is n-2), while every other property comes from\nthe current slot. This is a deliberate parent-payload reorg attempt —\nrejected by mainnet forkchoice, but useful for exercising the reveal /\ninclusion path against a withheld parent.",
+                "canary_bids_submitted": {
+                    "type": "integer"
+                },
+                "canary_mode": {
+                    "description": "Canary mode: whether it is on and how many of the submitted bids were\npriced at the canary value",
                     "type": "boolean"
-                }
-            }
-        },
-        "action_plan.BuilderAPIPlan": {
-            "type": "object",
-            "properties": {
-                "mode": {
-                    "$ref": "#/definitions/action_plan.Mode"
                 },
-                "response_delay_ms": {
-                    "description": "ResponseDelayMs delays the bid response by this many milliseconds\n(context-cancellable, capped at one slot).",
+                "el_head_withdrawals": {
+                    "description": "Payloads withdrawn because the EL head moved off their parent before\nthe new payload_attributes arrived (--el-ws-rpc)",
                     "type": "integer"
                 },
-                "total_value_override_gwei": {
-                    "description": "TotalValueOverrideGwei is the absolute total proposer-visible bid value\n(before the Gloas execution-payment split), replacing block value +\nsubsidy. May exceed the block value to test payment edge cases.",
+                "engine_wins": {
+                    "description": "Payload races won per engine API endpoint (--el-engine-race-apis)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reveals_chaos_delayed": {
                     "type": "integer"
                 },
-                "value_subsidy_gwei": {
-                    "description": "ValueSubsidyGwei replaces the global BlockValueSubsidyGwei for this slot.",
+                "reveals_chaos_withheld": {
+                    "description": "Reveals deliberately withheld / delayed by reveal chaos",
+                    "type": "integer"
+                },
+                "reveals_failed": {
+                    "type": "integer"
+                },
+                "reveals_skipped": {
+                    "type": "integer"
+                },
+                "reveals_skipped_not_included": {
+                    "description": "Share of reveals_skipped withheld because our bid was never confirmed\nincluded (reveal.require_inclusion)",
+                    "type": "integer"
+                },
+                "reveals_success": {
+                    "type": "integer"
+                },
+                "slots_built": {
+                    "type": "integer"
+                },
+                "total_paid_gwei": {
+                    "type": "integer"
+                },
+                "value_boost_pct": {
+                    "description": "Test-only Builder API value boost in percent of the real bid value\n(0 = off)",
+                    "type": "integer"
+                },
+                "withdrawal_mismatches": {
+                    "description": "Builds whose payload_attributes withdrawals diverged from the beacon\nnode's expected withdrawals",
                     "type": "integer"
                 }
             }
         },
-        "action_plan.FrozenPlan": {
+        "api.StatusResponse": {
             "type": "object",
             "properties": {
-                "bid": {
-                    "description": "Resolved effective settings; a nil category = suppressed for this slot\n(by plan or by the global enable flags / protocol applicability).",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/action_plan.ResolvedBidSettings"
-                        }
-                    ]
+                "beacon_nodes": {
+                    "description": "BeaconNodes is the health and failover score of each beacon node when\nfallback nodes are configured.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/beacon.NodeStatus"
+                    }
                 },
-                "build": {
-                    "description": "Build is the complete resolved build decision for the slot (schedule +\nplan force/suppress). Always non-nil.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/action_plan.ResolvedBuildSettings"
-                        }
-                    ]
+                "builder_index": {
+                    "type": "integer"
                 },
-                "builder_api": {
-                    "$ref": "#/definitions/action_plan.ResolvedBuilderAPISettings"
+                "builder_pubkey": {
+                    "type": "string"
                 },
-                "fork": {
-                    "description": "fork name at the target slot",
+                "cl_balance_gwei": {
+                    "type": "integer"
+                },
+                "current_slot": {
+                    "type": "integer"
+                },
+                "degraded": {
+                    "description": "Degraded is set when the startup capability check disabled features\nthe beacon node lacks the topics or endpoints for (see Degradations).",
+                    "type": "boolean"
+                },
+                "degradations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/probe.Degradation"
+                    }
+                },
+                "deposit_epoch": {
+                    "type": "integer"
+                },
+                "effective_balance_gwei": {
+                    "type": "integer"
+                },
+                "event_streams": {
+                    "description": "EventStreams is the health of each beacon node SSE topic stream.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/beacon.TopicStats"
+                    }
+                },
+                "is_registered": {
+                    "type": "boolean"
+                },
+                "lifecycle_enabled": {
+                    "type": "boolean"
+                },
+                "pending_payments_gwei": {
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "wallet_address": {
                     "type": "string"
                 },
-                "frozen_at": {
+                "wallet_balance_wei": {
                     "type": "string"
                 },
-                "plan": {
-                    "description": "raw sparse plan; nil = none existed",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/action_plan.SlotPlan"
-                        }
-                    ]
+                "withdrawable_epoch": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.SubscriptionDiagnosticsResponse": {
+            "type": "object",
+            "properties": {
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.SubscriptionStats"
+                    }
                 },
-                "reveal": {
-                    "$ref": "#/definitions/action_plan.ResolvedRevealSettings"
+                "total_dropped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.TestTransformRequest": {
+            "type": "object",
+            "properties": {
+                "expression": {
+                    "description": "Expression is the jq program to evaluate.",
+                    "type": "string"
                 },
-                "slot": {
+                "sample_slot": {
+                    "description": "SampleSlot, when \u003e 0, sources the input from that slot's captured\nartifact (falling back to a template when it is unavailable).",
                     "type": "integer"
                 },
-                "transforms": {
-                    "description": "Transforms carries the effective jq transform expressions (empty when no\ntransform plan applies). Nil only when no plan expression is set.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/action_plan.ResolvedTransforms"
-                        }
-                    ]
+                "target": {
+                    "description": "Target is one of payload | bid | envelope.",
+                    "type": "string"
                 }
             }
         },
-        "action_plan.Mode": {
-            "type": "string",
-            "enum": [
-                "disabled",
-                "custom"
-            ],
-            "x-enum-varnames": [
-                "ModeDisabled",
-                "ModeCustom"
-            ]
+        "api.TestTransformResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is the parse/eval error message (present when the expression fails).",
+                    "type": "string"
+                },
+                "input": {
+                    "description": "Input is the JSON the expression ran against (message JSON for bid /\nenvelope; the payload for payload), pretty-printed as a string.",
+                    "type": "string"
+                },
+                "input_source": {
+                    "description": "InputSource is \"artifact:slot-N\" or \"template\".",
+                    "type": "string"
+                },
+                "output": {
+                    "description": "Output is the transform result, pretty-printed (present when Error is empty).",
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
         },
-        "action_plan.PlanUpdate": {
+        "api.UpdateActionPlanRequest": {
             "type": "object",
             "properties": {
-                "bid": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "build": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "builder_api": {
+                "updates": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/action_plan.PlanUpdate"
                     }
-                },
-                "delete": {
-                    "type": "boolean"
-                },
-                "from_slot": {
-                    "type": "integer"
-                },
-                "reveal": {
+                }
+            }
+        },
+        "api.UpdateActionPlanResponse": {
+            "type": "object",
+            "properties": {
+                "plans": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
-                    }
-                },
-                "set": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        }
+                        "$ref": "#/definitions/action_plan.SlotPlan"
                     }
                 },
                 "slots": {
@@ -1552,892 +5148,1040 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
-                "to_slot": {
-                    "type": "integer"
-                },
-                "transforms": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "action_plan.ResolvedBidSettings": {
+        "api.UpdateEPBSRequest": {
             "type": "object",
             "properties": {
-                "end_ms": {
+                "bid_end_time": {
                     "type": "integer"
                 },
-                "forced": {
-                    "description": "Forced marks that the plan activated bidding although the module is\nglobally disabled.",
-                    "type": "boolean"
-                },
-                "ignore_missing_prefs": {
-                    "description": "IgnoreMissingPrefs bids without gossip proposer preferences.",
-                    "type": "boolean"
-                },
-                "increase_gwei": {
+                "bid_increase": {
                     "type": "integer"
                 },
-                "interval_ms": {
+                "bid_interval": {
                     "type": "integer"
                 },
-                "min_gwei": {
+                "bid_min_amount": {
                     "type": "integer"
                 },
-                "start_ms": {
+                "bid_start_time": {
                     "type": "integer"
                 },
-                "subsidy_gwei": {
+                "bid_strategy": {
+                    "description": "BidStrategy is one of fixed, linear, counter-bid, last-moment-snipe,\nrandom-walk; the gwei fields are the strategies' parameters.",
+                    "type": "string"
+                },
+                "bid_subsidy": {
                     "type": "integer"
                 },
-                "value_gwei": {
-                    "description": "ValueGwei, when set, is the absolute bid base value replacing the\nmax(blockValue, min) + subsidy formula (IncreaseGwei still applies).",
+                "build_start_time": {
                     "type": "integer"
-                }
-            }
-        },
-        "action_plan.ResolvedBuildSettings": {
-            "type": "object",
-            "properties": {
-                "build": {
-                    "description": "Build is the final decision: build a payload for this slot or not.",
-                    "type": "boolean"
                 },
-                "build_start_time_ms": {
-                    "description": "BuildStartTimeMs is the effective build start time, milliseconds\nrelative to slot start (signed).",
+                "counter_bid_max_gwei": {
                     "type": "integer"
                 },
-                "forced": {
-                    "description": "Forced marks builds the plan pushed past the schedule (they never\nconsume the next_n budget).",
-                    "type": "boolean"
+                "counter_bid_step_gwei": {
+                    "type": "integer"
                 },
-                "plan_involved": {
-                    "description": "PlanInvolved marks decisions where a per-slot plan existed or any\nconsumer was effectively active — i.e. skips worth surfacing.",
-                    "type": "boolean"
+                "payload_build_delay": {
+                    "type": "integer"
                 },
-                "reorg_parent_payload": {
-                    "description": "ReorgParentPayload builds the slot's payload on the grandparent (n-2)\nexecution payload instead of the immediate parent — a deliberate\nparent-payload reorg attempt (see BuildPlan.ReorgParentPayload).",
-                    "type": "boolean"
+                "random_walk_step_gwei": {
+                    "type": "integer"
                 },
-                "skip_reason": {
-                    "description": "SkipReason is one of the BuildSkipReason* constants when Build is\nfalse, empty otherwise.",
-                    "type": "string"
+                "reveal_time": {
+                    "type": "integer"
                 }
             }
         },
-        "action_plan.ResolvedBuilderAPISettings": {
+        "api.UpdateScheduleRequest": {
             "type": "object",
             "properties": {
-                "delay_ms": {
+                "every_nth": {
                     "type": "integer"
                 },
-                "forced": {
-                    "description": "Forced marks that the plan activated serving although the module is\nglobally disabled.",
-                    "type": "boolean"
+                "mode": {
+                    "type": "string"
                 },
-                "subsidy_gwei": {
+                "next_n": {
                     "type": "integer"
                 },
-                "total_value_gwei": {
-                    "description": "TotalValueGwei, when set, is the absolute total proposer-visible bid\nvalue (before the Gloas execution-payment split).",
+                "start_slot": {
                     "type": "integer"
                 }
             }
         },
-        "action_plan.ResolvedRevealSettings": {
+        "api.ValidatorRegistrationResponse": {
+            "description": "Returns the list of validators registered via the Builder API (fee recipient preferences). Not paginated.",
             "type": "object",
             "properties": {
-                "broadcast_validation": {
-                    "description": "BroadcastValidation is the envelope submission's broadcast_validation\nlevel: gossip | consensus | consensus_and_equivocation.",
-                    "type": "string"
-                },
-                "bypass_deadline": {
-                    "description": "BypassDeadline disables the \"past the in-slot deadline → skip\" check so\ndeliberately late reveals are attempted.",
-                    "type": "boolean"
-                },
-                "gate_mode": {
-                    "description": "GateMode decides the reveal moment: time | vote | vote_or_time |\nvote_and_time.",
+                "fee_recipient": {
+                    "description": "Hex-encoded Ethereum address",
                     "type": "string"
                 },
-                "max_attempts": {
-                    "description": "MaxAttempts / RetryIntervalMs are the publish retry policy\n(global-only, no per-slot override).",
-                    "type": "integer"
-                },
-                "retry_interval_ms": {
+                "gas_limit": {
+                    "description": "Gas limit for blocks",
                     "type": "integer"
                 },
-                "reveal_time_ms": {
-                    "type": "integer"
+                "pubkey": {
+                    "description": "Hex-encoded BLS public key",
+                    "type": "string"
                 },
-                "suppressed": {
-                    "type": "boolean"
+                "source": {
+                    "description": "Source is where the registration came from: \"local\" or the URL of the\nrelay it was backfilled from (omitted without the relay backfill).",
+                    "type": "string"
                 },
-                "vote_threshold_pct": {
-                    "description": "VoteThresholdPct is the participation threshold (percent) opening the\nvote gate.",
+                "timestamp": {
+                    "description": "Unix timestamp",
                     "type": "integer"
                 }
             }
         },
-        "action_plan.ResolvedTransforms": {
+        "audit.Decision": {
             "type": "object",
             "properties": {
-                "bid": {
+                "at": {
                     "type": "string"
                 },
-                "envelope": {
+                "detail": {
                     "type": "string"
                 },
-                "payload": {
-                    "type": "string"
-                }
-            }
-        },
-        "action_plan.RevealPlan": {
-            "type": "object",
-            "properties": {
-                "broadcast_validation": {
-                    "description": "BroadcastValidation overrides the envelope submission's broadcast\nvalidation level: gossip | consensus | consensus_and_equivocation.",
+                "outcome": {
+                    "description": "Outcome* constants",
                     "type": "string"
                 },
-                "gate_mode": {
-                    "description": "GateMode overrides the reveal gate: time | vote | vote_or_time |\nvote_and_time.",
+                "reason": {
                     "type": "string"
                 },
-                "mode": {
-                    "$ref": "#/definitions/action_plan.Mode"
-                },
-                "reveal_time_ms": {
-                    "description": "RevealTimeMs is signed milliseconds relative to slot start (time gate).",
-                    "type": "integer"
-                },
-                "vote_threshold_pct": {
-                    "description": "VoteThresholdPct overrides the vote gate's participation threshold.",
-                    "type": "integer"
+                "stage": {
+                    "description": "Stage* constants",
+                    "type": "string"
                 }
             }
         },
-        "action_plan.SlotPlan": {
+        "beacon.NodeStatus": {
             "type": "object",
             "properties": {
-                "bid": {
-                    "$ref": "#/definitions/action_plan.BidPlan"
+                "active": {
+                    "type": "boolean"
                 },
-                "build": {
-                    "$ref": "#/definitions/action_plan.BuildPlan"
+                "consecutive_failures": {
+                    "type": "integer"
                 },
-                "builder_api": {
-                    "$ref": "#/definitions/action_plan.BuilderAPIPlan"
+                "head_slot": {
+                    "type": "integer"
                 },
-                "reveal": {
-                    "$ref": "#/definitions/action_plan.RevealPlan"
+                "healthy": {
+                    "type": "boolean"
                 },
-                "slot": {
+                "last_check_at": {
+                    "description": "unix ms",
                     "type": "integer"
                 },
-                "transforms": {
-                    "$ref": "#/definitions/action_plan.TransformPlan"
-                },
-                "updated_at": {
+                "last_error": {
                     "type": "string"
                 },
-                "updated_by": {
-                    "type": "string"
-                }
-            }
-        },
-        "action_plan.TransformPlan": {
-            "type": "object",
-            "properties": {
-                "bid": {
-                    "type": "string"
+                "score": {
+                    "type": "integer"
                 },
-                "envelope": {
-                    "type": "string"
+                "syncing": {
+                    "type": "boolean"
                 },
-                "payload": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "api.ActionPlanResponse": {
+        "beacon.TopicStats": {
             "type": "object",
             "properties": {
-                "max_slot": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "events": {
                     "type": "integer"
                 },
-                "min_slot": {
+                "last_connected_at": {
                     "type": "integer"
                 },
-                "plans": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/action_plan.SlotPlan"
-                    }
-                }
-            }
-        },
-        "api.AuditLogResponse": {
-            "type": "object",
-            "properties": {
-                "entries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.AuditLog"
-                    }
+                "last_error": {
+                    "type": "string"
                 },
-                "limit": {
+                "last_error_at": {
+                    "type": "integer"
+                },
+                "last_event_at": {
                     "type": "integer"
                 },
-                "offset": {
+                "parse_failures": {
                     "type": "integer"
                 },
-                "total": {
+                "reconnects": {
                     "type": "integer"
+                },
+                "topic": {
+                    "type": "string"
                 }
             }
         },
-        "api.BidArtifactMetaEntry": {
+        "builderapi.CapturedExchange": {
             "type": "object",
             "properties": {
-                "at": {
-                    "description": "unix milliseconds",
-                    "type": "integer"
+                "duration_ms": {
+                    "type": "number"
                 },
-                "execution_payment_gwei": {
+                "id": {
                     "type": "integer"
                 },
-                "fork": {
+                "method": {
                     "type": "string"
                 },
-                "index": {
-                    "type": "integer"
+                "path": {
+                    "type": "string"
                 },
-                "total_value_gwei": {
+                "remote_addr": {
+                    "type": "string"
+                },
+                "request": {
+                    "$ref": "#/definitions/builderapi.CapturedMessage"
+                },
+                "response": {
+                    "$ref": "#/definitions/builderapi.CapturedMessage"
+                },
+                "route": {
+                    "description": "route template, e.g. /eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}",
+                    "type": "string"
+                },
+                "status": {
                     "type": "integer"
                 },
-                "transport": {
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "api.BidsWonResponse": {
+        "builderapi.CapturedMessage": {
             "type": "object",
             "properties": {
-                "bids_won": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/payload_bidder.WonBlock"
-                    }
+                "body": {
+                    "description": "Body is the captured body: text as is, binary (SSZ) bodies base64\nencoded (BodyEncoding \"base64\").",
+                    "type": "string"
                 },
-                "limit": {
-                    "type": "integer"
+                "body_encoding": {
+                    "type": "string"
                 },
-                "offset": {
+                "body_size": {
+                    "description": "full body size in bytes",
                     "type": "integer"
                 },
-                "total": {
-                    "type": "integer"
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "truncated": {
+                    "type": "boolean"
                 }
             }
         },
-        "api.BuilderAPIStatusResponse": {
+        "circuit_breaker.Circuit": {
             "type": "object",
             "properties": {
-                "block_value_subsidy_gwei": {
+                "consecutive_failures": {
                     "type": "integer"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "last_error": {
+                    "type": "string"
                 },
-                "validator_count": {
+                "name": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "threshold": {
                     "type": "integer"
-                }
-            }
-        },
-        "api.BuilderPreferencesEntry": {
-            "type": "object",
-            "properties": {
-                "max_execution_payment": {
+                },
+                "trip_count": {
                     "type": "integer"
                 },
-                "validator_pubkey": {
+                "tripped": {
+                    "type": "boolean"
+                },
+                "tripped_at": {
                     "type": "string"
                 }
             }
         },
-        "api.BuilderPreferencesResponse": {
+        "config.Change": {
             "type": "object",
             "properties": {
-                "preferences": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.BuilderPreferencesEntry"
-                    }
-                }
+                "from": {},
+                "key": {
+                    "type": "string"
+                },
+                "to": {}
             }
         },
-        "api.GetValidatorsResponse": {
+        "config.Revision": {
             "type": "object",
             "properties": {
-                "validators": {
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/api.ValidatorRegistrationResponse"
+                        "$ref": "#/definitions/config.Change"
                     }
+                },
+                "source": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "unix ms",
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "api.HeadVoteDetailResponse": {
+        "db.AuditLog": {
             "type": "object",
             "properties": {
-                "bucket_count": {
-                    "type": "integer"
-                },
-                "bucket_ms": {
-                    "type": "integer"
+                "action": {
+                    "type": "string"
                 },
-                "root": {
+                "actor": {
                     "type": "string"
                 },
-                "rows": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.HeadVoteDetailRow"
-                    }
+                "detail": {
+                    "type": "string"
                 },
-                "slot": {
+                "id": {
                     "type": "integer"
                 },
-                "slot_start_ms": {
-                    "type": "integer"
+                "remote_addr": {
+                    "type": "string"
                 },
-                "total_members": {
+                "result": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "timestamp": {
                     "type": "integer"
                 }
             }
         },
-        "api.HeadVoteDetailRow": {
+        "epoch_summary.EpochSummary": {
             "type": "object",
             "properties": {
-                "counts": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "avg_participation_pct": {
+                    "type": "number"
                 },
-                "in_block_unseen": {
+                "bids_boosted": {
+                    "description": "BidsBoosted is the subset of BidsSent reporting a boosted value\n(test-only value boosting).",
                     "type": "integer"
                 },
-                "members": {
+                "bids_canary": {
+                    "description": "BidsCanary is the subset of BidsSent priced at the canary value.",
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "bids_failed": {
+                    "type": "integer"
                 },
-                "seen": {
+                "bids_sent": {
                     "type": "integer"
-                }
-            }
-        },
-        "api.LifecycleStatusResponse": {
-            "type": "object",
-            "properties": {
-                "balance_gwei": {
+                },
+                "bids_won": {
                     "type": "integer"
                 },
-                "builder_index": {
+                "builds_failed": {
                     "type": "integer"
                 },
-                "deposit_epoch": {
+                "builds_skipped": {
                     "type": "integer"
                 },
-                "effective_balance_gwei": {
+                "end_slot": {
                     "type": "integer"
                 },
-                "is_registered": {
-                    "type": "boolean"
+                "epoch": {
+                    "type": "integer"
                 },
-                "pending_payments_gwei": {
+                "generated_at": {
+                    "type": "string"
+                },
+                "min_participation_pct": {
+                    "type": "number"
+                },
+                "participation_slots": {
+                    "description": "Head vote participation of the epoch's slots, as last streamed by the\nhead vote tracker. Only slots observed while running are included.",
                     "type": "integer"
                 },
-                "withdrawable_epoch": {
+                "payloads_canonical": {
                     "type": "integer"
-                }
-            }
-        },
-        "api.OverviewBalances": {
-            "type": "object",
-            "properties": {
-                "cl_balance_gwei": {
+                },
+                "payloads_missed": {
                     "type": "integer"
                 },
-                "effective_balance_gwei": {
+                "payloads_orphaned": {
                     "type": "integer"
                 },
-                "pending_payments_gwei": {
+                "reveals_missed": {
+                    "description": "RevealsMissed counts slots whose reveal attempts never published and\nended failed or skipped (plan suppressions are intentional and not\ncounted).",
                     "type": "integer"
                 },
-                "wallet_address": {
-                    "type": "string"
+                "reveals_published": {
+                    "type": "integer"
                 },
-                "wallet_balance_wei": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.OverviewELClient": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
+                "slots_bid": {
+                    "description": "SlotsBid counts slots with at least one submitted or served bid.",
+                    "type": "integer"
                 },
-                "commit": {
-                    "type": "string"
+                "slots_built": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "slots_tracked": {
+                    "description": "SlotsTracked counts slots with a recorded result (ePBS or Builder API\nactive).",
+                    "type": "integer"
                 },
-                "version": {
+                "start_slot": {
+                    "type": "integer"
+                },
+                "value_earned_wei": {
+                    "description": "ValueEarnedWei sums the won block values whose payload was not missed\nor orphaned.",
                     "type": "string"
                 }
             }
         },
-        "api.OverviewResponse": {
+        "lifecycle.DepositPreview": {
             "type": "object",
             "properties": {
-                "balances": {
-                    "$ref": "#/definitions/api.OverviewBalances"
-                },
-                "builder_index": {
+                "activation_epoch": {
+                    "description": "estimate, registrations only",
                     "type": "integer"
                 },
-                "builder_pubkey": {
+                "amounts_gwei": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "batch_contract": {
                     "type": "string"
                 },
-                "current_slot": {
+                "batched": {
+                    "description": "Batched is set when the amounts go out as one transaction through the\nconfigured batching contract.",
+                    "type": "boolean"
+                },
+                "contract_active": {
+                    "type": "boolean"
+                },
+                "current_balance_gwei": {
                     "type": "integer"
                 },
-                "el_client": {
-                    "$ref": "#/definitions/api.OverviewELClient"
+                "current_epoch": {
+                    "type": "integer"
                 },
-                "is_registered": {
-                    "type": "boolean"
+                "estimated_gas": {
+                    "description": "summed; the gas limit when estimation failed",
+                    "type": "integer"
                 },
-                "running": {
-                    "type": "boolean"
+                "estimated_gas_cost_wei": {
+                    "type": "string"
                 },
-                "services": {
-                    "$ref": "#/definitions/api.OverviewServices"
+                "gas_limit": {
+                    "description": "summed over the transactions",
+                    "type": "integer"
                 },
-                "stats": {
-                    "$ref": "#/definitions/api.OverviewStats"
+                "gas_price_wei": {
+                    "description": "base fee + priority fee",
+                    "type": "string"
                 },
-                "version": {
+                "kind": {
+                    "description": "\"deposit\" (registration) or \"topup\"",
                     "type": "string"
-                }
-            }
-        },
-        "api.OverviewServices": {
-            "type": "object",
-            "properties": {
-                "builder_api_available": {
-                    "type": "boolean"
                 },
-                "builder_api_enabled": {
+                "queue_fee_too_high": {
+                    "description": "QueueFeeTooHigh is set when the queue fee exceeds --deposit-max-fee:\nthe send would be delayed until it drops.",
                     "type": "boolean"
                 },
-                "epbs_available": {
-                    "type": "boolean"
+                "queue_fee_wei": {
+                    "description": "per request",
+                    "type": "string"
                 },
-                "epbs_enabled": {
+                "resulting_balance_gwei": {
+                    "type": "integer"
+                },
+                "sufficient_funds": {
                     "type": "boolean"
                 },
-                "epbs_registration_state": {
+                "total_amount_gwei": {
+                    "type": "integer"
+                },
+                "total_cost_wei": {
+                    "description": "TotalCostWei is stake + queue fees + estimated gas cost.",
                     "type": "string"
                 },
-                "lifecycle_available": {
-                    "type": "boolean"
+                "transactions": {
+                    "type": "integer"
                 },
-                "lifecycle_enabled": {
-                    "type": "boolean"
+                "wallet_balance_wei": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "api.OverviewStats": {
+        "p2p_bidder.BidBudgetStatus": {
             "type": "object",
             "properties": {
-                "bids_submitted": {
+                "bids_capped": {
+                    "description": "BidsCapped and SlotsRefused count since startup the bids lowered to\ntheir slot's allowance and the slots not bid on for lack of budget.",
+                    "type": "integer"
+                },
+                "distribution": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "epoch": {
                     "type": "integer"
                 },
-                "bids_won": {
+                "epoch_max_gwei": {
                     "type": "integer"
                 },
-                "blocks_included": {
+                "exhausted": {
+                    "type": "boolean"
+                },
+                "remaining_gwei": {
                     "type": "integer"
                 },
-                "builder_api_blocks_published": {
+                "slots_bid": {
                     "type": "integer"
                 },
-                "builder_api_headers_requested": {
+                "slots_left": {
+                    "description": "slots of the epoch from the current one on",
                     "type": "integer"
                 },
-                "builder_api_registered_validators": {
+                "slots_refused": {
                     "type": "integer"
                 },
-                "slots_built": {
+                "spent_gwei": {
+                    "description": "SpentGwei is the sum of the highest bid gossiped per slot this epoch.",
                     "type": "integer"
                 }
             }
         },
-        "api.ProposerPreferencesEntry": {
+        "payload_bidder.WonBlock": {
             "type": "object",
             "properties": {
-                "client_name": {
+                "block_hash": {
                     "type": "string"
                 },
-                "fee_recipient": {
-                    "type": "string"
+                "num_blobs": {
+                    "type": "integer"
+                },
+                "num_transactions": {
+                    "type": "integer"
+                },
+                "publishes": {
+                    "description": "Publishes are the per-beacon-node publications of the block (Builder\nAPI flow only; the block was fanned out to every configured node).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload_bidder.WonBlockPublish"
+                    }
                 },
                 "slot": {
                     "type": "integer"
                 },
-                "target_gas_limit": {
+                "source": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Unix milliseconds at inclusion time",
                     "type": "integer"
                 },
-                "validator_index": {
+                "value_eth": {
+                    "type": "string"
+                },
+                "value_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "payload_bidder.WonBlockPublish": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "empty when the node accepted the block",
+                    "type": "string"
+                },
+                "latency_ms": {
                     "type": "integer"
+                },
+                "node": {
+                    "type": "string"
                 }
             }
         },
-        "api.ProposerPreferencesResponse": {
+        "payload_builder.EngineResult": {
             "type": "object",
             "properties": {
-                "preferences": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.ProposerPreferencesEntry"
-                    }
+                "block_value_wei": {
+                    "type": "string"
+                },
+                "engine": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "won": {
+                    "type": "boolean"
                 }
             }
         },
-        "api.SlotBidArtifactsResponse": {
+        "payload_builder.PayloadFault": {
             "type": "object",
             "properties": {
-                "bids": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.BidArtifactMetaEntry"
-                    }
+                "corrupted": {
+                    "type": "string"
                 },
-                "slot": {
-                    "type": "integer"
+                "field": {
+                    "type": "string"
+                },
+                "original": {
+                    "type": "string"
                 }
             }
         },
-        "api.SlotResultsResponse": {
+        "peer_mesh.NetworkView": {
             "type": "object",
             "properties": {
-                "max_slot": {
-                    "type": "integer"
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/peer_mesh.SharedBid"
+                    }
                 },
-                "min_slot": {
-                    "type": "integer"
+                "builder_pubkey": {
+                    "type": "string"
                 },
-                "results": {
+                "name": {
+                    "type": "string"
+                },
+                "peers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/slot_results.SlotResult"
+                        "$ref": "#/definitions/peer_mesh.PeerStatus"
                     }
                 }
             }
         },
-        "api.StatsResponse": {
+        "peer_mesh.PeerStatus": {
             "type": "object",
             "properties": {
-                "bids_submitted": {
+                "bids_merged": {
                     "type": "integer"
                 },
-                "bids_won": {
+                "bids_rejected": {
+                    "description": "unsigned or failing verification",
                     "type": "integer"
                 },
-                "blocks_included": {
+                "builder_index": {
                     "type": "integer"
                 },
-                "builder_api_blocks_published": {
-                    "type": "integer"
+                "builder_pubkey": {
+                    "type": "string"
                 },
-                "builder_api_headers_requested": {
-                    "description": "Builder API stats",
-                    "type": "integer"
+                "last_error": {
+                    "type": "string"
                 },
-                "builder_api_registered_validators": {
-                    "type": "integer"
+                "last_seen": {
+                    "type": "string"
                 },
-                "reveals_failed": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "reveals_skipped": {
+                "registered": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "peer_mesh.SharedBid": {
+            "type": "object",
+            "properties": {
+                "block_hash": {
+                    "type": "string"
+                },
+                "builder_index": {
                     "type": "integer"
                 },
-                "reveals_success": {
+                "execution_payment_gwei": {
                     "type": "integer"
                 },
-                "slots_built": {
+                "fork": {
+                    "description": "Fork, Message and Signature are the bid as signed by its builder\n(Message is the fork's ExecutionPayloadBid in beacon API JSON). The\nsummary fields above are informational; merging reads the message.",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "slot": {
                     "type": "integer"
                 },
-                "total_paid_gwei": {
+                "source": {
+                    "description": "mesh peer name (\"\" = observed by this node)",
+                    "type": "string"
+                },
+                "value_gwei": {
                     "type": "integer"
                 }
             }
         },
-        "api.StatusResponse": {
+        "peer_mesh.Snapshot": {
             "type": "object",
             "properties": {
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/peer_mesh.SharedBid"
+                    }
+                },
                 "builder_index": {
                     "type": "integer"
                 },
                 "builder_pubkey": {
                     "type": "string"
                 },
-                "cl_balance_gwei": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "current_slot": {
-                    "type": "integer"
+                "registered": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "penalty_monitor.Incident": {
+            "type": "object",
+            "properties": {
+                "acknowledged": {
+                    "type": "boolean"
                 },
-                "deposit_epoch": {
-                    "type": "integer"
+                "acknowledged_at": {
+                    "type": "string"
                 },
-                "effective_balance_gwei": {
-                    "type": "integer"
+                "acknowledged_by": {
+                    "type": "string"
                 },
-                "is_registered": {
-                    "type": "boolean"
+                "builder_index": {
+                    "type": "integer"
                 },
-                "lifecycle_enabled": {
-                    "type": "boolean"
+                "detected_at": {
+                    "type": "string"
                 },
-                "pending_payments_gwei": {
+                "id": {
                     "type": "integer"
                 },
-                "running": {
-                    "type": "boolean"
-                },
-                "wallet_address": {
+                "kind": {
                     "type": "string"
                 },
-                "wallet_balance_wei": {
+                "message": {
                     "type": "string"
                 },
-                "withdrawable_epoch": {
+                "slot": {
                     "type": "integer"
                 }
             }
         },
-        "api.TestTransformRequest": {
+        "preflight.Registration": {
             "type": "object",
             "properties": {
-                "expression": {
-                    "description": "Expression is the jq program to evaluate.",
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "sample_slot": {
-                    "description": "SampleSlot, when \u003e 0, sources the input from that slot's captured\nartifact (falling back to a template when it is unavailable).",
+                "balance_gwei": {
                     "type": "integer"
                 },
-                "target": {
-                    "description": "Target is one of payload | bid | envelope.",
+                "builder_index": {
+                    "type": "integer"
+                },
+                "builder_pubkey": {
                     "type": "string"
+                },
+                "registered": {
+                    "type": "boolean"
                 }
             }
         },
-        "api.TestTransformResponse": {
+        "preflight.Report": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "Error is the parse/eval error message (present when the expression fails).",
+                "config_name": {
                     "type": "string"
                 },
-                "input": {
-                    "description": "Input is the JSON the expression ran against (message JSON for bid /\nenvelope; the payload for payload), pretty-printed as a string.",
+                "fork": {
                     "type": "string"
                 },
-                "input_source": {
-                    "description": "InputSource is \"artifact:slot-N\" or \"template\".",
+                "generated_at": {
                     "type": "string"
                 },
-                "output": {
-                    "description": "Output is the transform result, pretty-printed (present when Error is empty).",
-                    "type": "string"
+                "gloas_epoch": {
+                    "description": "nil = Gloas not scheduled",
+                    "type": "integer"
                 },
-                "target": {
+                "preset": {
                     "type": "string"
-                }
-            }
-        },
-        "api.UpdateActionPlanRequest": {
-            "type": "object",
-            "properties": {
-                "updates": {
+                },
+                "registration": {
+                    "$ref": "#/definitions/preflight.Registration"
+                },
+                "services": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/action_plan.PlanUpdate"
+                        "$ref": "#/definitions/preflight.Service"
                     }
-                }
-            }
-        },
-        "api.UpdateActionPlanResponse": {
-            "type": "object",
-            "properties": {
-                "plans": {
+                },
+                "timing": {
+                    "$ref": "#/definitions/preflight.Timing"
+                },
+                "version": {
+                    "type": "string"
+                },
+                "wallets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/action_plan.SlotPlan"
+                        "$ref": "#/definitions/preflight.Wallet"
                     }
                 },
-                "slots": {
+                "warnings": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
+                }
+            }
+        },
+        "preflight.Service": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
                 },
-                "status": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "api.UpdateEPBSRequest": {
+        "preflight.Timing": {
             "type": "object",
             "properties": {
                 "bid_end_time": {
                     "type": "integer"
                 },
-                "bid_increase": {
-                    "type": "integer"
-                },
                 "bid_interval": {
                     "type": "integer"
                 },
-                "bid_min_amount": {
-                    "type": "integer"
-                },
                 "bid_start_time": {
                     "type": "integer"
                 },
-                "bid_subsidy": {
-                    "type": "integer"
-                },
                 "build_start_time": {
                     "type": "integer"
                 },
-                "payload_build_delay": {
+                "payload_build_time": {
                     "type": "integer"
                 },
                 "reveal_time": {
                     "type": "integer"
+                },
+                "slot_time_ms": {
+                    "type": "integer"
                 }
             }
         },
-        "api.UpdateScheduleRequest": {
+        "preflight.Wallet": {
             "type": "object",
             "properties": {
-                "every_nth": {
-                    "type": "integer"
+                "address": {
+                    "type": "string"
                 },
-                "mode": {
+                "balance_wei": {
                     "type": "string"
                 },
-                "next_n": {
-                    "type": "integer"
+                "error": {
+                    "type": "string"
                 },
-                "start_slot": {
-                    "type": "integer"
+                "label": {
+                    "type": "string"
                 }
             }
         },
-        "api.ValidatorRegistrationResponse": {
-            "description": "Returns the list of validators registered via the Builder API (fee recipient preferences). Not paginated.",
+        "probe.Degradation": {
             "type": "object",
             "properties": {
-                "fee_recipient": {
-                    "description": "Hex-encoded Ethereum address",
+                "effect": {
                     "type": "string"
                 },
-                "gas_limit": {
-                    "description": "Gas limit for blocks",
-                    "type": "integer"
-                },
-                "pubkey": {
-                    "description": "Hex-encoded BLS public key",
+                "feature": {
                     "type": "string"
                 },
-                "timestamp": {
-                    "description": "Unix timestamp",
-                    "type": "integer"
+                "missing_endpoints": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missing_topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "db.AuditLog": {
+        "relay_proxy.Exchange": {
             "type": "object",
             "properties": {
-                "action": {
+                "at": {
                     "type": "string"
                 },
-                "actor": {
+                "block_hash": {
                     "type": "string"
                 },
-                "detail": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "remote_addr": {
+                "kind": {
                     "type": "string"
                 },
-                "result": {
-                    "type": "string"
+                "pubkey": {
+                    "type": "string",
+                    "description": "proposer pubkey (get_header)"
                 },
-                "target": {
+                "registrations": {
+                    "type": "integer",
+                    "description": "registrations in the request (register_validators)"
+                },
+                "relay": {
                     "type": "string"
                 },
-                "timestamp": {
+                "selected": {
+                    "type": "boolean",
+                    "description": "this relay's answer was returned to the validator client"
+                },
+                "slot": {
                     "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "description": "0 = no response"
+                },
+                "value_wei": {
+                    "type": "string"
                 }
             }
         },
-        "payload_bidder.WonBlock": {
+        "relay_proxy.RelayStatus": {
             "type": "object",
             "properties": {
-                "block_hash": {
-                    "type": "string"
-                },
-                "num_blobs": {
+                "failures": {
                     "type": "integer"
                 },
-                "num_transactions": {
-                    "type": "integer"
-                },
-                "slot": {
-                    "type": "integer"
+                "last_error": {
+                    "type": "string"
                 },
-                "source": {
+                "last_seen": {
                     "type": "string"
                 },
-                "timestamp": {
-                    "description": "Unix milliseconds at inclusion time",
+                "requests": {
                     "type": "integer"
                 },
-                "value_eth": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "relay_proxy.View": {
+            "type": "object",
+            "properties": {
+                "exchanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/relay_proxy.Exchange"
+                    }
                 },
-                "value_wei": {
+                "relays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/relay_proxy.RelayStatus"
+                    }
+                }
+            }
+        },
+        "slot_results.Assertion": {
+            "type": "object",
+            "properties": {
+                "detail": {
                     "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/slot_results.AssertionStatus"
                 }
             }
         },
+        "slot_results.AssertionStatus": {
+            "type": "string",
+            "enum": [
+                "pass",
+                "fail",
+                "pending",
+                "skip"
+            ],
+            "x-enum-varnames": [
+                "AssertionPass",
+                "AssertionFail",
+                "AssertionPending",
+                "AssertionSkip"
+            ]
+        },
         "slot_results.AttributesSnapshot": {
             "type": "object",
             "properties": {
@@ -2486,6 +6230,10 @@ const docTemplate = `{
                 "at": {
                     "type": "string"
                 },
+                "bid_root": {
+                    "description": "hash-tree-root of the bid message",
+                    "type": "string"
+                },
                 "block_hash": {
                     "description": "Full bid message properties (Gloas+ bids; blob commitments aggregated\nto a count). Empty for legacy Builder API bids and pre-construction\nfailures.",
                     "type": "string"
@@ -2493,6 +6241,10 @@ const docTemplate = `{
                 "builder_index": {
                     "type": "integer"
                 },
+                "canary": {
+                    "description": "Canary marks a bid priced at the canary value (canary mode).",
+                    "type": "boolean"
+                },
                 "competitor_high_gwei": {
                     "description": "CompetitorHighGwei is the highest known competitor bid at submission\ntime (p2p only, our own builder index excluded).",
                     "type": "integer"
@@ -2503,12 +6255,20 @@ const docTemplate = `{
                 "execution_payment_gwei": {
                     "type": "integer"
                 },
+                "fault": {
+                    "description": "Fault is the bid fault of a deliberately invalid manual bid\n(zero_value, exceeds_balance, malformed_value); Status and Error\nrecord whether the beacon node accepted it and why it rejected it.",
+                    "type": "string"
+                },
                 "fee_recipient": {
                     "type": "string"
                 },
                 "gas_limit": {
                     "type": "integer"
                 },
+                "manual": {
+                    "description": "Manual marks an operator-forced p2p bid (manual bid API).",
+                    "type": "boolean"
+                },
                 "num_blob_commitments": {
                     "type": "integer"
                 },
@@ -2521,6 +6281,17 @@ const docTemplate = `{
                 "prev_randao": {
                     "type": "string"
                 },
+                "replaced": {
+                    "type": "boolean"
+                },
+                "replaces_block_hash": {
+                    "description": "ReplacesBlockHash is the block hash of our earlier bid this one\nreplaces (rebuilt payload); Replaced marks a bid a later one replaced.",
+                    "type": "string"
+                },
+                "snipe": {
+                    "description": "Snipe marks a late-slot snipe bid, or a snipe skipped on a competitor\nbid above the threshold.",
+                    "type": "boolean"
+                },
                 "status": {
                     "$ref": "#/definitions/slot_results.BidStatus"
                 },
@@ -2530,6 +6301,10 @@ const docTemplate = `{
                 "transport": {
                     "description": "payload_builder.BidTransport values",
                     "type": "string"
+                },
+                "value_boost_pct": {
+                    "description": "ValueBoostPct marks a Builder API bid whose reported value was boosted\nto this percentage of its real value (test-only value boosting), so\nTotalValueGwei is not what the payload pays.",
+                    "type": "integer"
                 }
             }
         },
@@ -2601,6 +6376,16 @@ const docTemplate = `{
                 "block_value_wei": {
                     "type": "string"
                 },
+                "engine": {
+                    "description": "Engine is the engine API endpoint whose payload won the build race\n(--el-engine-race-apis); EngineRace lists every engine's outcome.\nBoth are empty with a single engine.",
+                    "type": "string"
+                },
+                "engine_race": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload_builder.EngineResult"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -2611,6 +6396,13 @@ const docTemplate = `{
                     "description": "0x-hex",
                     "type": "string"
                 },
+                "faults": {
+                    "description": "Faults audits the fields fault injection corrupted in the payload\n(original and corrupted values); empty for an honest payload.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload_builder.PayloadFault"
+                    }
+                },
                 "fee_recipient": {
                     "type": "string"
                 },
@@ -2651,8 +6443,19 @@ const docTemplate = `{
                 "status": {
                     "$ref": "#/definitions/slot_results.BuildStatus"
                 },
+                "supersedes": {
+                    "description": "Supersedes is the block hash of the stale payload this rebuild\nreplaced (stale-bid replacement); empty for the slot's first build.",
+                    "type": "string"
+                },
                 "timestamp": {
                     "type": "integer"
+                },
+                "withdrawals_divergence": {
+                    "type": "string"
+                },
+                "withdrawals_source": {
+                    "description": "WithdrawalsSource is where the payload's withdrawals were taken from\n(attributes | state); WithdrawalsDivergence describes how the\npayload_attributes withdrawals disagreed with the beacon node's\nexpected withdrawals (cross-check).",
+                    "type": "string"
                 }
             }
         },
@@ -2699,6 +6502,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "publishes": {
+                    "description": "Publishes are the per-beacon-node block publications (Builder API).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload_bidder.WonBlockPublish"
+                    }
+                },
                 "source": {
                     "description": "\"epbs\" | \"builder_api\"",
                     "type": "string"
@@ -2738,11 +6548,19 @@ const docTemplate = `{
                 "attempt": {
                     "type": "integer"
                 },
+                "chaos": {
+                    "description": "withhold | delay (reveal chaos)",
+                    "type": "string"
+                },
                 "error": {
                     "type": "string"
                 },
+                "manual": {
+                    "description": "operator-triggered publish (numbered separately)",
+                    "type": "boolean"
+                },
                 "skip_reason": {
-                    "description": "plan_disabled | disabled | late | vote_gate_timeout",
+                    "description": "plan_disabled | disabled | late | vote_gate_timeout | not_included | chaos_withheld",
                     "type": "string"
                 },
                 "started_at": {
@@ -2772,6 +6590,54 @@ const docTemplate = `{
                 "RevealStatusSkipped"
             ]
         },
+        "slot_results.SigningRecord": {
+            "type": "object",
+            "properties": {
+                "artifact": {
+                    "description": "bid | header | envelope",
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "domain_type": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "domain mismatch: the message was not signed",
+                    "type": "string"
+                },
+                "fork_version": {
+                    "type": "string"
+                },
+                "genesis_validators_root": {
+                    "type": "string"
+                },
+                "message_root": {
+                    "type": "string"
+                }
+            }
+        },
+        "slot_results.SlotAssertions": {
+            "type": "object",
+            "properties": {
+                "assertions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slot_results.Assertion"
+                    }
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/slot_results.AssertionStatus"
+                }
+            }
+        },
         "slot_results.SlotResult": {
             "type": "object",
             "properties": {
@@ -2820,6 +6686,13 @@ const docTemplate = `{
                         "$ref": "#/definitions/slot_results.RevealAttempt"
                     }
                 },
+                "signatures": {
+                    "description": "Signatures is the signing context of every bid, header and envelope\nsigned for the slot (bid/envelope records match BidAttempt.BidRoot and\nthe envelope root by MessageRoot).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slot_results.SigningRecord"
+                    }
+                },
                 "slot": {
                     "type": "integer"
                 },
@@ -2840,6 +6713,108 @@ const docTemplate = `{
                 "SubmissionStatusAccepted",
                 "SubmissionStatusFailed"
             ]
+        },
+        "utils.SubscriptionStats": {
+            "type": "object",
+            "properties": {
+                "blocking": {
+                    "type": "boolean"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "delivered": {
+                    "type": "integer"
+                },
+                "dispatcher": {
+                    "description": "event type, e.g. \"*beacon.HeadEvent\"",
+                    "type": "string"
+                },
+                "dropped": {
+                    "type": "integer"
+                },
+                "high_water": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lag": {
+                    "description": "queued, not yet consumed events",
+                    "type": "integer"
+                },
+                "subscribed_at": {
+                    "description": "unix ms",
+                    "type": "integer"
+                },
+                "timeout_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "wallet.TxRecord": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "gas_used": {
+                    "type": "integer"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "deposit, topup, batch_topup, exit, early_deposit",
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/wallet.TxStatus"
+                },
+                "submitted_at": {
+                    "description": "unix ms",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "unix ms",
+                    "type": "integer"
+                },
+                "value_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "wallet.TxStatus": {
+            "type": "string",
+            "enum": [
+                "submitted",
+                "mined",
+                "confirmed",
+                "failed",
+                "replaced"
+            ],
+            "x-enum-varnames": [
+                "TxStatusSubmitted",
+                "TxStatusMined",
+                "TxStatusConfirmed",
+                "TxStatusFailed",
+                "TxStatusReplaced"
+            ]
         }
     }
 }`
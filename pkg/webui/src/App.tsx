@@ -8,6 +8,7 @@ const ValidatorsPage = React.lazy(() => import('./pages/ValidatorsPage'));
 const BidsWonPage = React.lazy(() => import('./pages/BidsWonPage'));
 const ProposerPreferencesPage = React.lazy(() => import('./pages/ProposerPreferencesPage'));
 const BuilderPreferencesPage = React.lazy(() => import('./pages/BuilderPreferencesPage'));
+const PeersPage = React.lazy(() => import('./pages/PeersPage'));
 const AuditLogPage = React.lazy(() => import('./pages/AuditLogPage'));
 const ApiDocsPage = React.lazy(() => import('./pages/ApiDocsPage'));
 
@@ -25,6 +26,7 @@ export const App: React.FC = () => {
           {currentView === 'bids-won' && <BidsWonPage />}
           {currentView === 'proposer-preferences' && <ProposerPreferencesPage />}
           {currentView === 'builder-preferences' && <BuilderPreferencesPage />}
+          {currentView === 'peers' && <PeersPage />}
           {currentView === 'audit-log' && <AuditLogPage />}
           {currentView === 'api-docs' && <ApiDocsPage />}
         </Suspense>
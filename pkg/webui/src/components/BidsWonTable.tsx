@@ -1,6 +1,7 @@
 import React, { useState } from 'react';
 import { BidWonEntry } from '../types';
 import { Pagination } from './Pagination';
+import { formatWeiEth } from '../utils';
 
 interface BidsWonTableProps {
   bidsWon: BidWonEntry[];
@@ -89,7 +90,7 @@ export const BidsWonTable: React.FC<BidsWonTableProps> = ({
                   <td className="text-end">{bid.num_transactions}</td>
                   <td className="text-end">{bid.num_blobs}</td>
                   <td className="text-end font-monospace">
-                    {parseFloat(bid.value_eth).toFixed(6)}
+                    {formatWeiEth(bid.value_wei)}
                   </td>
                   <td className="text-end text-muted">
                     <small>{formatTimestamp(bid.timestamp)}</small>
@@ -17,6 +17,8 @@ export const BuilderConfigPanel: React.FC<BuilderConfigPanelProps> = ({ config }
   const [collapsed, setCollapsed] = useState(true);
   const [editing, setEditing] = useState(false);
   const [editingSchedule, setEditingSchedule] = useState(false);
+  const [formVersion, setFormVersion] = useState<number | undefined>(undefined);
+  const [scheduleVersion, setScheduleVersion] = useState<number | undefined>(undefined);
 
   const [form, setForm] = useState<BuilderFormState>({
     build_start_time: 0,
@@ -46,6 +48,7 @@ export const BuilderConfigPanel: React.FC<BuilderConfigPanelProps> = ({ config }
         payload_build_delay: payloadBuildDelay,
         extra_data: config.extra_data ?? '',
       });
+      setFormVersion(config.version);
     }
   }, [config, editing, payloadBuildDelay]);
 
@@ -53,6 +56,7 @@ export const BuilderConfigPanel: React.FC<BuilderConfigPanelProps> = ({ config }
   useEffect(() => {
     if (!editingSchedule && config?.schedule) {
       setScheduleForm(config.schedule);
+      setScheduleVersion(config.version);
     }
   }, [config, editingSchedule]);
 
@@ -63,6 +67,9 @@ export const BuilderConfigPanel: React.FC<BuilderConfigPanelProps> = ({ config }
     if (authToken) {
       headers['Authorization'] = `Bearer ${authToken}`;
     }
+    if (formVersion !== undefined) {
+      headers['If-Match'] = `"${formVersion}"`;
+    }
     try {
       const response = await fetch('/api/config/builder', {
         method: 'POST',
@@ -87,6 +94,9 @@ export const BuilderConfigPanel: React.FC<BuilderConfigPanelProps> = ({ config }
     if (authToken) {
       headers['Authorization'] = `Bearer ${authToken}`;
     }
+    if (scheduleVersion !== undefined) {
+      headers['If-Match'] = `"${scheduleVersion}"`;
+    }
     try {
       const response = await fetch('/api/config/schedule', {
         method: 'POST',
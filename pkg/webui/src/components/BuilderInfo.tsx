@@ -2,6 +2,7 @@ import React, { useState } from 'react';
 import { useAuthContext } from '../context/AuthContext';
 import type { BuilderInfo as BuilderInfoType, ServiceStatus, Config } from '../types';
 import { CopyableHash } from './CopyableHash';
+import { useBuilders } from '../hooks/useBuilders';
 
 interface BuilderInfoProps {
   builderInfo: BuilderInfoType | null;
@@ -28,17 +29,24 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
   const [editingLifecycle, setEditingLifecycle] = useState(false);
   const [lcThreshold, setLcThreshold] = useState('');
   const [lcAmount, setLcAmount] = useState('');
+  const [lcVersion, setLcVersion] = useState<number | undefined>(undefined);
   const [exitState, setExitState] = useState<'idle' | 'confirm' | 'submitting' | 'done' | 'error'>('idle');
   const [exitError, setExitError] = useState('');
+  const builders = useBuilders();
+  const [selectedPubkey, setSelectedPubkey] = useState<string | null>(null);
 
-  const lifecycleAvailable = serviceStatus?.lifecycle_available ?? false;
+  // Extra builder keys are shown from the polled /api/builders summary; the
+  // lifecycle controls only act on the primary builder.
+  const selectedExtra = builders.find((b) => !b.primary && b.builder_pubkey === selectedPubkey) ?? null;
+  const lifecycleAvailable = !selectedExtra && (serviceStatus?.lifecycle_available ?? false);
   const lifecycleEnabled = serviceStatus?.lifecycle_enabled ?? false;
-  const registrationState = serviceStatus?.epbs_registration_state;
+  const registrationState = selectedExtra ? selectedExtra.registration_state : serviceStatus?.epbs_registration_state;
   const canExit = registrationState === 'registered' || registrationState === 'pending_finalization';
 
   const startEditingLifecycle = () => {
     setLcThreshold(config ? String(config.topup_threshold / 1e9) : '');
     setLcAmount(config ? String(config.topup_amount / 1e9) : '');
+    setLcVersion(config?.version);
     setEditingLifecycle(true);
   };
 
@@ -49,8 +57,11 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
     if (authToken) {
       headers['Authorization'] = `Bearer ${authToken}`;
     }
+    if (lcVersion !== undefined) {
+      headers['If-Match'] = `"${lcVersion}"`;
+    }
     try {
-      await fetch('/api/config/lifecycle', {
+      const response = await fetch('/api/config/lifecycle', {
         method: 'POST',
         headers,
         body: JSON.stringify({
@@ -58,6 +69,11 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
           topup_amount: Math.round(parseFloat(lcAmount) * 1e9),
         }),
       });
+      const result = await response.json();
+      if (result.error) {
+        alert('Failed to update: ' + result.error);
+        return;
+      }
       setEditingLifecycle(false);
     } catch (err) {
       console.error('Failed to update lifecycle config:', err);
@@ -73,7 +89,19 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
     }
     setExitState('submitting');
     try {
-      const resp = await fetch('/api/lifecycle/exit', { method: 'POST', headers });
+      let resp = await fetch('/api/lifecycle/exit', { method: 'POST', headers });
+      if (resp.status === 409) {
+        // Exit interlock: pending payments or bids in play. Offer to force.
+        const body = await resp.json().catch(() => null);
+        if (!body?.blockers) {
+          throw new Error(body?.error || `HTTP ${resp.status}`);
+        }
+        if (!window.confirm(`${body.error}\n\nExit anyway? The outstanding payments may not be covered.`)) {
+          setExitState('idle');
+          return;
+        }
+        resp = await fetch('/api/lifecycle/exit?force=true', { method: 'POST', headers });
+      }
       if (!resp.ok) {
         const body = await resp.json().catch(() => null);
         throw new Error(body?.error || `HTTP ${resp.status}`);
@@ -120,10 +148,35 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
     );
   }
 
+  const info = selectedExtra ?? builderInfo;
+
   return (
     <div className="card mb-3">
       <div className="card-header d-flex align-items-center">
         <h5 className="mb-0 me-2">Builder Info</h5>
+        {builders.length > 1 && (
+          <select
+            className="form-select form-select-sm w-auto me-2"
+            value={selectedExtra?.builder_pubkey ?? ''}
+            onChange={(e) => setSelectedPubkey(e.target.value || null)}
+            title="Builder key"
+          >
+            {builders.map((b) => (
+              <option key={b.builder_pubkey} value={b.primary ? '' : b.builder_pubkey}>
+                {b.builder_pubkey.slice(0, 10)}…{b.primary ? ' (primary)' : ''}
+              </option>
+            ))}
+          </select>
+        )}
+        {selectedExtra && (
+          selectedExtra.lifecycle_read_only ? (
+            <span className="badge bg-dark">Lifecycle Read-Only</span>
+          ) : selectedExtra.lifecycle_enabled ? (
+            <span className="badge bg-success">Lifecycle Active</span>
+          ) : (
+            <span className="badge bg-secondary">Lifecycle Inactive</span>
+          )
+        )}
         {lifecycleAvailable && (
           <>
             {lifecycleEnabled ? (
@@ -143,7 +196,7 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
             )}
           </>
         )}
-        {!lifecycleAvailable && (
+        {!lifecycleAvailable && !selectedExtra && (
           <span className="badge bg-dark">No Lifecycle</span>
         )}
       </div>
@@ -160,29 +213,34 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
             after its registry slot is reused by another builder&apos;s deposit.
           </div>
         )}
+        {selectedExtra?.identity_error && (
+          <div className="alert alert-danger py-2 px-2 small mb-2">
+            <i className="fas fa-exclamation-circle me-1"></i>
+            {selectedExtra.identity_error}
+          </div>
+        )}
         <table className="table table-sm table-borderless mb-0">
           <tbody>
             {/* Builder Identity */}
             <tr>
               <td className="text-muted">Pubkey:</td>
               <td className="text-end font-monospace small">
-                <CopyableHash value={builderInfo.builder_pubkey} chars={8} />
+                <CopyableHash value={info.builder_pubkey} chars={8} />
               </td>
             </tr>
             <tr>
               <td className="text-muted">Index:</td>
               <td className="text-end">
                 {(() => {
-                  const state = serviceStatus?.epbs_registration_state;
-                  switch (state) {
+                  switch (registrationState) {
                     case 'registered':
-                      return <span className="badge bg-success">{builderInfo.builder_index}</span>;
+                      return <span className="badge bg-success">{info.builder_index}</span>;
                     case 'pending_finalization':
-                      return <span className="badge bg-info">#{builderInfo.builder_index} (Pending Finalization)</span>;
+                      return <span className="badge bg-info">#{info.builder_index} (Pending Finalization)</span>;
                     case 'exiting':
-                      return <span className="badge bg-warning text-dark">#{builderInfo.builder_index} (Exiting)</span>;
+                      return <span className="badge bg-warning text-dark">#{info.builder_index} (Exiting)</span>;
                     case 'exited':
-                      return <span className="badge bg-secondary">#{builderInfo.builder_index} (Exited)</span>;
+                      return <span className="badge bg-secondary">#{info.builder_index} (Exited)</span>;
                     case 'waiting_gloas':
                       return <span className="badge bg-info">Awaiting Gloas</span>;
                     case 'pending':
@@ -197,11 +255,11 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
             </tr>
 
             {/* Wallet Info (if lifecycle enabled) */}
-            {builderInfo.lifecycle_enabled && builderInfo.wallet_address && (
+            {info.lifecycle_enabled && info.wallet_address && (
               <tr>
                 <td className="text-muted">Wallet:</td>
                 <td className="text-end font-monospace small">
-                  <CopyableHash value={builderInfo.wallet_address} chars={6} />
+                  <CopyableHash value={info.wallet_address} chars={6} />
                 </td>
               </tr>
             )}
@@ -216,7 +274,7 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
               <td className="text-muted">CL Balance:</td>
               <td className="text-end">
                 <span className="text-primary fw-bold">
-                  {formatGwei(builderInfo.cl_balance_gwei)} ETH
+                  {formatGwei(info.cl_balance_gwei)} ETH
                 </span>
               </td>
             </tr>
@@ -225,24 +283,24 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
             <tr>
               <td className="text-muted">Pending Payments:</td>
               <td className="text-end text-warning">
-                {builderInfo.pending_payments_gwei > 0
-                  ? `-${formatGwei(builderInfo.pending_payments_gwei)} ETH`
+                {info.pending_payments_gwei > 0
+                  ? `-${formatGwei(info.pending_payments_gwei)} ETH`
                   : '0 ETH'}
               </td>
             </tr>
 
             {/* Effective Balance (shown when pending payments reduce it) */}
-            {builderInfo.pending_payments_gwei > 0 && (
+            {info.pending_payments_gwei > 0 && (
               <tr>
                 <td className="text-muted">Effective Balance:</td>
                 <td className="text-end text-success fw-bold">
-                  {formatGwei(builderInfo.effective_balance_gwei)} ETH
+                  {formatGwei(info.effective_balance_gwei)} ETH
                 </td>
               </tr>
             )}
 
             {/* Wallet Balance (if lifecycle enabled) */}
-            {builderInfo.lifecycle_enabled && builderInfo.wallet_balance_wei && (
+            {info.lifecycle_enabled && info.wallet_balance_wei && (
               <>
                 <tr>
                   <td colSpan={2}><hr className="my-1" /></td>
@@ -250,26 +308,26 @@ export const BuilderInfo: React.FC<BuilderInfoProps> = ({ builderInfo, serviceSt
                 <tr>
                   <td className="text-muted">Wallet Balance:</td>
                   <td className="text-end">
-                    {formatWei(builderInfo.wallet_balance_wei)} ETH
+                    {formatWei(info.wallet_balance_wei)} ETH
                   </td>
                 </tr>
               </>
             )}
 
             {/* Epoch Info - show when builder has an index in beacon state */}
-            {builderInfo.builder_index > 0 && (
+            {info.builder_index > 0 && (
               <>
                 <tr>
                   <td colSpan={2}><hr className="my-1" /></td>
                 </tr>
                 <tr>
                   <td className="text-muted small">Deposit Epoch:</td>
-                  <td className="text-end small">{builderInfo.deposit_epoch}</td>
+                  <td className="text-end small">{info.deposit_epoch}</td>
                 </tr>
-                {builderInfo.withdrawable_epoch > 0 && builderInfo.withdrawable_epoch < 18446744073709551615 && (
+                {info.withdrawable_epoch > 0 && info.withdrawable_epoch < 18446744073709551615 && (
                   <tr>
                     <td className="text-muted small text-warning">Withdrawable:</td>
-                    <td className="text-end small text-warning">Epoch {builderInfo.withdrawable_epoch}</td>
+                    <td className="text-end small text-warning">Epoch {info.withdrawable_epoch}</td>
                   </tr>
                 )}
               </>
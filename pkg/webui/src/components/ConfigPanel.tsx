@@ -1,6 +1,6 @@
 import React, { useState, useEffect } from 'react';
 import { useAuthContext } from '../context/AuthContext';
-import type { Config, EPBSConfig, ServiceStatus } from '../types';
+import type { BidStrategy, Config, EPBSConfig, ServiceStatus } from '../types';
 
 interface ConfigPanelProps {
   config: Config | null;
@@ -9,11 +9,20 @@ interface ConfigPanelProps {
 
 type EPBSFormState = EPBSConfig;
 
+const bidStrategies: { value: BidStrategy; label: string; help: string }[] = [
+  { value: 'linear', label: 'Linear', help: 'Every re-bid adds the bid increase.' },
+  { value: 'fixed', label: 'Fixed', help: 'One bid at the base value per payload.' },
+  { value: 'counter-bid', label: 'Counter-bid', help: 'Re-bids only when outbid, the step above the highest competitor bid.' },
+  { value: 'last-moment-snipe', label: 'Last-moment snipe', help: 'A single bid shortly before the bid end, skipped on contested slots.' },
+  { value: 'random-walk', label: 'Random walk', help: 'Every re-bid moves a random step up or down, never below the base value.' },
+];
+
 export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus }) => {
   const { isLoggedIn, getAuthHeader } = useAuthContext();
   const [collapsed, setCollapsed] = useState(true);
   const [editingTiming, setEditingTiming] = useState(false);
   const [toggling, setToggling] = useState(false);
+  const [timingVersion, setTimingVersion] = useState<number | undefined>(undefined);
 
   const [timingForm, setTimingForm] = useState<EPBSFormState>({
     build_start_time: 0,
@@ -23,12 +32,17 @@ export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus
     bid_increase: 0,
     bid_interval: 0,
     bid_subsidy: 0,
+    bid_strategy: 'linear',
+    counter_bid_step_gwei: 0,
+    counter_bid_max_gwei: 0,
+    random_walk_step_gwei: 0,
   });
 
   // Sync timing form state when not editing
   useEffect(() => {
     if (!editingTiming && config?.epbs) {
       setTimingForm({ ...config.epbs });
+      setTimingVersion(config.version);
     }
   }, [config, editingTiming]);
 
@@ -39,6 +53,9 @@ export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus
     if (authToken) {
       headers['Authorization'] = `Bearer ${authToken}`;
     }
+    if (timingVersion !== undefined) {
+      headers['If-Match'] = `"${timingVersion}"`;
+    }
     try {
       const response = await fetch('/api/config/epbs', {
         method: 'POST',
@@ -50,6 +67,10 @@ export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus
           bid_increase: timingForm.bid_increase,
           bid_interval: timingForm.bid_interval,
           bid_subsidy: timingForm.bid_subsidy,
+          bid_strategy: timingForm.bid_strategy,
+          counter_bid_step_gwei: timingForm.counter_bid_step_gwei,
+          counter_bid_max_gwei: timingForm.counter_bid_max_gwei,
+          random_walk_step_gwei: timingForm.random_walk_step_gwei,
         }),
       });
       const result = await response.json();
@@ -65,6 +86,8 @@ export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus
 
   const canEdit = isLoggedIn;
   const epbs = config?.epbs;
+  const strategy = epbs?.bid_strategy || 'linear';
+  const formStrategy = timingForm.bid_strategy || 'linear';
   const isActive = serviceStatus?.epbs_enabled ?? false;
   const isAvailable = serviceStatus?.epbs_available ?? false;
   const registrationState = serviceStatus?.epbs_registration_state ?? 'unknown';
@@ -193,6 +216,32 @@ export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus
                   <div className="config-item-value">{epbs?.bid_subsidy || 0} gwei</div>
                 </div>
               </div>
+              <div className="col-6">
+                <div className="config-item">
+                  <div className="config-item-label">Bid Strategy</div>
+                  <div className="config-item-value">
+                    {bidStrategies.find((s) => s.value === strategy)?.label ?? strategy}
+                  </div>
+                </div>
+              </div>
+              {strategy === 'counter-bid' && (
+                <div className="col-6">
+                  <div className="config-item">
+                    <div className="config-item-label">Counter Step / Max</div>
+                    <div className="config-item-value">
+                      {epbs?.counter_bid_step_gwei || 0} / {epbs?.counter_bid_max_gwei || 'no cap'} gwei
+                    </div>
+                  </div>
+                </div>
+              )}
+              {strategy === 'random-walk' && (
+                <div className="col-6">
+                  <div className="config-item">
+                    <div className="config-item-label">Random Walk Step</div>
+                    <div className="config-item-value">±{epbs?.random_walk_step_gwei || 0} gwei</div>
+                  </div>
+                </div>
+              )}
             </div>
           ) : (
             <form onSubmit={handleTimingSave}>
@@ -260,6 +309,53 @@ export const ConfigPanel: React.FC<ConfigPanelProps> = ({ config, serviceStatus
                   threshold. Set to 0 to bid the real block value.
                 </div>
               </div>
+              <div className="mb-2">
+                <label className="form-label">Bid Strategy</label>
+                <select
+                  className="form-select form-select-sm"
+                  value={formStrategy}
+                  onChange={(e) => setTimingForm({ ...timingForm, bid_strategy: e.target.value as BidStrategy })}
+                >
+                  {bidStrategies.map((s) => (
+                    <option key={s.value} value={s.value}>{s.label}</option>
+                  ))}
+                </select>
+                <div className="form-text">{bidStrategies.find((s) => s.value === formStrategy)?.help}</div>
+              </div>
+              {formStrategy === 'counter-bid' && (
+                <>
+                  <div className="mb-2">
+                    <label className="form-label">Counter-bid Step (gwei)</label>
+                    <input
+                      type="number"
+                      className="form-control form-control-sm"
+                      value={timingForm.counter_bid_step_gwei ?? 0}
+                      onChange={(e) => setTimingForm({ ...timingForm, counter_bid_step_gwei: parseInt(e.target.value) || 0 })}
+                    />
+                  </div>
+                  <div className="mb-2">
+                    <label className="form-label">Counter-bid Max (gwei)</label>
+                    <input
+                      type="number"
+                      className="form-control form-control-sm"
+                      value={timingForm.counter_bid_max_gwei ?? 0}
+                      onChange={(e) => setTimingForm({ ...timingForm, counter_bid_max_gwei: parseInt(e.target.value) || 0 })}
+                    />
+                    <div className="form-text">0 = no cap.</div>
+                  </div>
+                </>
+              )}
+              {formStrategy === 'random-walk' && (
+                <div className="mb-2">
+                  <label className="form-label">Random Walk Step (gwei)</label>
+                  <input
+                    type="number"
+                    className="form-control form-control-sm"
+                    value={timingForm.random_walk_step_gwei ?? 0}
+                    onChange={(e) => setTimingForm({ ...timingForm, random_walk_step_gwei: parseInt(e.target.value) || 0 })}
+                  />
+                </div>
+              )}
               <div className="d-flex gap-2">
                 <button type="submit" className="btn btn-sm btn-primary">Save</button>
                 <button type="button" className="btn btn-sm btn-secondary" onClick={() => setEditingTiming(false)}>
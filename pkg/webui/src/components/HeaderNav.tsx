@@ -12,6 +12,7 @@ const NAV_ITEMS: Array<{ view: ViewType; label: string; requiresAuth?: boolean }
   { view: 'validators', label: 'Validators' },
   { view: 'proposer-preferences', label: 'Proposer Prefs' },
   { view: 'builder-preferences', label: 'Builder Prefs' },
+  { view: 'peers', label: 'Peers' },
   { view: 'audit-log', label: 'Audit Log', requiresAuth: true },
   { view: 'api-docs', label: 'API' },
 ];
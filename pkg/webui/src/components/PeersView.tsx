@@ -0,0 +1,161 @@
+import React, { useMemo } from 'react';
+import type { PeerNetworkView } from '../types';
+import { formatGwei } from '../utils';
+
+interface PeersViewProps {
+  view: PeerNetworkView | null;
+  loading?: boolean;
+  error?: string | null;
+}
+
+function shortHex(value: string | undefined, chars = 10): string {
+  if (!value) return '-';
+  return value.length > chars + 2 ? `${value.slice(0, chars + 2)}…` : value;
+}
+
+function formatLastSeen(lastSeen: string | undefined): string {
+  if (!lastSeen) return 'never';
+  const seconds = Math.max(0, Math.round((Date.now() - new Date(lastSeen).getTime()) / 1000));
+  return `${seconds}s ago`;
+}
+
+export const PeersView: React.FC<PeersViewProps> = ({ view, loading, error }) => {
+  // Newest slot first; within a slot, highest value first.
+  const bids = useMemo(
+    () =>
+      (view?.bids || [])
+        .slice()
+        .sort((a, b) => b.slot - a.slot || b.value_gwei - a.value_gwei),
+    [view],
+  );
+
+  if (loading) {
+    return (
+      <div className="card mb-3">
+        <div className="card-header">
+          <h5 className="mb-0">Peers</h5>
+        </div>
+        <div className="card-body">
+          <div className="text-muted text-center">Loading...</div>
+        </div>
+      </div>
+    );
+  }
+
+  if (!view) {
+    return (
+      <div className="card mb-3">
+        <div className="card-header">
+          <h5 className="mb-0">Peers</h5>
+        </div>
+        <div className="card-body">
+          <div className="text-muted text-center small">
+            {error && error.includes('not available')
+              ? 'Peer mesh not available (requires a Gloas-scheduled network).'
+              : error || 'No data'}
+          </div>
+        </div>
+      </div>
+    );
+  }
+
+  const peers = view.peers || [];
+
+  return (
+    <>
+      <div className="card mb-3">
+        <div className="card-header d-flex justify-content-between align-items-center">
+          <h5 className="mb-0">
+            Peers <span className="text-muted small ms-2">this node: {view.name}</span>
+          </h5>
+          <span className="badge bg-primary">{peers.length}</span>
+        </div>
+        <div className="card-body p-2">
+          {peers.length === 0 ? (
+            <div className="text-muted text-center small">
+              No peers configured. Run with --peer-urls to share observed bids with other buildoor instances.
+            </div>
+          ) : (
+            <table className="table table-sm table-borderless mb-0">
+              <thead className="sticky-top" style={{ background: 'var(--bs-body-bg)' }}>
+                <tr>
+                  <th className="small">Name</th>
+                  <th className="small">URL</th>
+                  <th className="small">Builder</th>
+                  <th className="small text-end">Index</th>
+                  <th className="small">Last Seen</th>
+                  <th className="small text-end">Bids Merged</th>
+                  <th className="small text-end">Bids Rejected</th>
+                </tr>
+              </thead>
+              <tbody>
+                {peers.map((peer) => (
+                  <tr key={peer.url}>
+                    <td className="small">{peer.name || '-'}</td>
+                    <td className="small font-monospace">{peer.url}</td>
+                    <td className="small font-monospace" title={peer.builder_pubkey}>
+                      {shortHex(peer.builder_pubkey)}
+                    </td>
+                    <td className="small text-end font-monospace">
+                      {peer.registered ? peer.builder_index : <span className="text-muted">unregistered</span>}
+                    </td>
+                    <td className="small">
+                      {peer.last_error ? (
+                        <span className="text-danger" title={peer.last_error}>
+                          error ({formatLastSeen(peer.last_seen)})
+                        </span>
+                      ) : (
+                        formatLastSeen(peer.last_seen)
+                      )}
+                    </td>
+                    <td className="small text-end font-monospace">{peer.bids_merged.toLocaleString()}</td>
+                    <td className="small text-end font-monospace">{peer.bids_rejected.toLocaleString()}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+      </div>
+
+      <div className="card mb-3">
+        <div className="card-header d-flex justify-content-between align-items-center">
+          <h5 className="mb-0">Network Bids</h5>
+          <span className="badge bg-primary">{bids.length}</span>
+        </div>
+        <div className="card-body p-2">
+          {bids.length === 0 ? (
+            <div className="text-muted text-center small">No bids observed in the recent slots</div>
+          ) : (
+            <table className="table table-sm table-borderless mb-0">
+              <thead className="sticky-top" style={{ background: 'var(--bs-body-bg)' }}>
+                <tr>
+                  <th className="small">Slot</th>
+                  <th className="small text-end">Builder Index</th>
+                  <th className="small text-end">Value</th>
+                  <th className="small text-end">Execution Payment</th>
+                  <th className="small">Block Hash</th>
+                  <th className="small">Source</th>
+                </tr>
+              </thead>
+              <tbody>
+                {bids.map((bid) => (
+                  <tr key={`${bid.slot}-${bid.builder_index}`}>
+                    <td className="small font-monospace">{bid.slot}</td>
+                    <td className="small text-end font-monospace">{bid.builder_index}</td>
+                    <td className="small text-end font-monospace">{formatGwei(bid.value_gwei)}</td>
+                    <td className="small text-end font-monospace">{formatGwei(bid.execution_payment_gwei)}</td>
+                    <td className="small font-monospace" title={bid.block_hash}>
+                      {shortHex(bid.block_hash)}
+                    </td>
+                    <td className="small">{bid.source || <span className="text-muted">local</span>}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+      </div>
+    </>
+  );
+};
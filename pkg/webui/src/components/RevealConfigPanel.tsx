@@ -42,6 +42,7 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
   const [collapsed, setCollapsed] = useState(true);
   const [editing, setEditing] = useState(false);
   const [toggling, setToggling] = useState(false);
+  const [formVersion, setFormVersion] = useState<number | undefined>(undefined);
 
   const reveal = config?.reveal;
 
@@ -53,20 +54,28 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
     broadcast_validation: 'gossip',
     max_attempts: 3,
     retry_interval_ms: 500,
+    require_inclusion: false,
+    chaos_withhold_pct: 0,
+    chaos_delay_pct: 0,
+    chaos_delay_ms: 4000,
   });
 
   useEffect(() => {
     if (!editing && reveal) {
       setForm({ ...reveal });
+      setFormVersion(config?.version);
     }
-  }, [reveal, editing]);
+  }, [reveal, editing, config?.version]);
 
-  const postSettings = async (settings: Record<string, unknown>): Promise<boolean> => {
+  const postSettings = async (settings: Record<string, unknown>, version?: number): Promise<boolean> => {
     const headers: HeadersInit = { 'Content-Type': 'application/json' };
     const authToken = await getAuthHeader();
     if (authToken) {
       headers['Authorization'] = `Bearer ${authToken}`;
     }
+    if (version !== undefined) {
+      headers['If-Match'] = `"${version}"`;
+    }
     try {
       const response = await fetch('/api/config/settings', {
         method: 'POST',
@@ -94,7 +103,11 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
       'reveal.broadcast_validation': form.broadcast_validation,
       'reveal.max_attempts': form.max_attempts,
       'reveal.retry_interval_ms': form.retry_interval_ms,
-    });
+      'reveal.require_inclusion': form.require_inclusion,
+      'reveal.chaos_withhold_pct': form.chaos_withhold_pct,
+      'reveal.chaos_delay_pct': form.chaos_delay_pct,
+      'reveal.chaos_delay_ms': form.chaos_delay_ms,
+    }, formVersion);
     if (ok) setEditing(false);
   };
 
@@ -113,6 +126,7 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
   const gateMode = reveal?.gate_mode ?? 'time';
   const timeGated = gateMode !== 'vote';
   const voteGated = gateMode !== 'time';
+  const chaosActive = (reveal?.chaos_withhold_pct ?? 0) > 0 || (reveal?.chaos_delay_pct ?? 0) > 0;
 
   return (
     <div className="card mb-3">
@@ -128,6 +142,11 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
         ) : (
           <span className="badge bg-secondary">Disabled</span>
         )}
+        {chaosActive && (
+          <span className="badge bg-warning text-dark ms-2" title="Reveal chaos withholds or delays a share of reveals">
+            Chaos
+          </span>
+        )}
         {isLoggedIn && (
           <button
             className={`btn btn-sm ms-auto ${enabled ? 'btn-outline-danger' : 'btn-outline-success'}`}
@@ -203,6 +222,22 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
                   <div className="config-item-value">{reveal?.retry_interval_ms ?? 0} ms</div>
                 </div>
               </div>
+              <div className="col-6">
+                <div className="config-item">
+                  <div className="config-item-label">Require Inclusion</div>
+                  <div className="config-item-value">{reveal?.require_inclusion ? 'yes' : 'no'}</div>
+                </div>
+              </div>
+              <div className="col-6">
+                <div className="config-item">
+                  <div className="config-item-label">Chaos</div>
+                  <div className="config-item-value">
+                    {chaosActive
+                      ? `withhold ${reveal?.chaos_withhold_pct ?? 0}% / delay ${reveal?.chaos_delay_pct ?? 0}% (+${reveal?.chaos_delay_ms ?? 0} ms)`
+                      : 'off'}
+                  </div>
+                </div>
+              </div>
             </div>
           ) : (
             <form onSubmit={handleSave}>
@@ -290,6 +325,63 @@ export const RevealConfigPanel: React.FC<RevealConfigPanelProps> = ({ config })
                   required
                 />
               </div>
+              <div className="mb-2 form-check">
+                <input
+                  type="checkbox"
+                  className="form-check-input"
+                  id="reveal-require-inclusion"
+                  checked={form.require_inclusion}
+                  onChange={(e) => setForm({ ...form, require_inclusion: e.target.checked })}
+                />
+                <label className="form-check-label" htmlFor="reveal-require-inclusion">
+                  Require confirmed inclusion
+                </label>
+                <div className="form-text">
+                  Reveal only once the committing block is seen at the head
+                  carrying our bid; unconfirmed reveals are withheld at slot end.
+                </div>
+              </div>
+              <div className="mb-2">
+                <label className="form-label">Chaos: Withhold (% of slots)</label>
+                <input
+                  type="number"
+                  min={0}
+                  max={100}
+                  className="form-control form-control-sm"
+                  value={form.chaos_withhold_pct}
+                  onChange={(e) => setForm({ ...form, chaos_withhold_pct: parseInt(e.target.value) || 0 })}
+                  required
+                />
+              </div>
+              <div className="mb-2">
+                <label className="form-label">Chaos: Delay (% of slots)</label>
+                <input
+                  type="number"
+                  min={0}
+                  max={100}
+                  className="form-control form-control-sm"
+                  value={form.chaos_delay_pct}
+                  onChange={(e) => setForm({ ...form, chaos_delay_pct: parseInt(e.target.value) || 0 })}
+                  required
+                />
+              </div>
+              <div className="mb-2">
+                <label className="form-label">Chaos: Delay (ms past reveal time)</label>
+                <input
+                  type="number"
+                  min={0}
+                  className="form-control form-control-sm"
+                  value={form.chaos_delay_ms}
+                  disabled={form.chaos_delay_pct === 0}
+                  onChange={(e) => setForm({ ...form, chaos_delay_ms: parseInt(e.target.value) || 0 })}
+                  required
+                />
+                <div className="form-text">
+                  Testing only: deliberately withhold or late-reveal a share of
+                  won payloads to exercise how clients and the PTC handle
+                  withheld payloads. Per-slot reveal plans take precedence.
+                </div>
+              </div>
               <div className="d-flex gap-2">
                 <button type="submit" className="btn btn-sm btn-primary">Save</button>
                 <button type="button" className="btn btn-sm btn-secondary" onClick={() => setEditing(false)}>
@@ -28,6 +28,16 @@ export const StatsPanel: React.FC<StatsPanelProps> = ({ stats, serviceStatus })
       >
         <i className={`fas fa-chevron-${collapsed ? 'right' : 'down'} me-2`}></i>
         <h5 className="mb-0">Statistics</h5>
+        {stats?.canary_mode && (
+          <span className="badge bg-warning text-dark ms-2" title="Canary mode: bids are priced at the fixed canary value">
+            Canary
+          </span>
+        )}
+        {(stats?.value_boost_pct || 0) > 0 && (
+          <span className="badge bg-danger ms-2" title="Value boost: Builder API bids report an inflated value, decoupled from the payload value (test-only)">
+            Value Boost {stats?.value_boost_pct}%
+          </span>
+        )}
       </div>
 
       {!collapsed && (
@@ -47,6 +57,14 @@ export const StatsPanel: React.FC<StatsPanelProps> = ({ stats, serviceStatus })
                 <span className="stat-item-value">{stats?.blocks_included || 0}</span>
               </div>
             </div>
+            {Object.entries(stats?.engine_wins || {}).map(([engine, wins]) => (
+              <div className="col-6" key={engine}>
+                <div className="stat-item" title="Payload races won by this engine API endpoint">
+                  <span className="stat-item-label">Won Races: {engine}</span>
+                  <span className="stat-item-value">{wins}</span>
+                </div>
+              </div>
+            ))}
           </div>
 
           {/* ePBS Bidder */}
@@ -78,6 +96,24 @@ export const StatsPanel: React.FC<StatsPanelProps> = ({ stats, serviceStatus })
                     <span className="stat-item-value">{formatGwei(stats?.total_paid || 0)}</span>
                   </div>
                 </div>
+                {(stats?.canary_mode || (stats?.canary_bids_submitted || 0) > 0) && (
+                  <div className="col-6">
+                    <div className="stat-item">
+                      <span className="stat-item-label">Canary Bids</span>
+                      <span className="stat-item-value">{stats?.canary_bids_submitted || 0}</span>
+                    </div>
+                  </div>
+                )}
+                {((stats?.reveals_chaos_withheld || 0) > 0 || (stats?.reveals_chaos_delayed || 0) > 0) && (
+                  <div className="col-6">
+                    <div className="stat-item" title="Reveals withheld / delayed by reveal chaos">
+                      <span className="stat-item-label">Chaos Withheld / Delayed</span>
+                      <span className="stat-item-value">
+                        {stats?.reveals_chaos_withheld || 0} / {stats?.reveals_chaos_delayed || 0}
+                      </span>
+                    </div>
+                  </div>
+                )}
               </div>
             </>
           )}
@@ -108,6 +108,10 @@ const SlotCellInner: React.FC<SlotCellProps> = ({
   if (plan?.builder_api) titleParts.push(`builder api: ${plan.builder_api.mode}`);
   if (plan?.reveal) titleParts.push(`reveal: ${plan.reveal.mode}`);
   if (reorgParent) titleParts.push('build: reorg parent (n-2)');
+  if (plan?.build?.faults?.length) titleParts.push(`build: faults ${plan.build.faults.join(', ')}`);
+  if (plan?.build?.blob_stuffing) titleParts.push(`build: blob stuffing ${plan.build.blob_stuffing}`);
+  if (plan?.build?.gas_limit) titleParts.push(`build: gas limit ${plan.build.gas_limit}`);
+  if (plan?.build?.gas_limit_violate) titleParts.push('build: gas limit violation');
   if (hasTransform) {
     const targets = ['payload', 'bid', 'envelope'].filter((k) => t?.[k as keyof typeof t]);
     titleParts.push(`jq transform: ${targets.join(', ')}`);
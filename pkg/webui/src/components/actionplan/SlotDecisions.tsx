@@ -0,0 +1,72 @@
+import React, { useEffect, useState } from 'react';
+import type { SlotDecision, SlotDecisionsResponse } from '../../types';
+
+const OUTCOME_BADGES: Record<string, string> = {
+  done: 'success',
+  skipped: 'secondary',
+  failed: 'danger',
+};
+
+// Decision journal of a slot: why buildoor did or did not build, bid and
+// reveal, in recording order. Fetched on mount from the decisions REST
+// endpoint (only slots within the retention window are served).
+export const SlotDecisions: React.FC<{ slot: number }> = ({ slot }) => {
+  const [decisions, setDecisions] = useState<SlotDecision[] | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    let cancelled = false;
+
+    fetch(`/api/slots/${slot}/decisions`)
+      .then(async res => {
+        if (!res.ok) throw new Error(`Request failed (${res.status})`);
+        return res.json() as Promise<SlotDecisionsResponse>;
+      })
+      .then(data => { if (!cancelled) setDecisions(data.decisions); })
+      .catch(err => { if (!cancelled) setError(err instanceof Error ? err.message : String(err)); });
+
+    return () => { cancelled = true; };
+  }, [slot]);
+
+  if (error) {
+    return <div className="alert alert-warning small py-1 px-2">Decisions unavailable: {error}</div>;
+  }
+  if (!decisions || decisions.length === 0) {
+    return null;
+  }
+
+  return (
+    <div className="card mb-3">
+      <div className="card-header py-1">
+        <strong className="small">Decisions</strong>
+      </div>
+      <div className="table-responsive">
+        <table className="table table-sm small mb-0">
+          <thead>
+            <tr>
+              <th>Stage</th>
+              <th>Outcome</th>
+              <th>Reason</th>
+              <th>Detail</th>
+              <th className="text-end">At</th>
+            </tr>
+          </thead>
+          <tbody>
+            {decisions.map((decision, i) => {
+              const variant = OUTCOME_BADGES[decision.outcome] || 'secondary';
+              return (
+                <tr key={i}>
+                  <td>{decision.stage}</td>
+                  <td><span className={`badge bg-${variant}`}>{decision.outcome}</span></td>
+                  <td className="font-monospace">{decision.reason}</td>
+                  <td className="ap-break">{decision.detail || ''}</td>
+                  <td className="text-end text-muted">{new Date(decision.at).toLocaleString()}</td>
+                </tr>
+              );
+            })}
+          </tbody>
+        </table>
+      </div>
+    </div>
+  );
+};
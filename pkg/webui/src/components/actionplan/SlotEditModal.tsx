@@ -8,6 +8,8 @@ import type {
 } from '../../types';
 import type { ApplyUpdatesResult } from '../../hooks/useActionPlan';
 import { TransformEditor, type TransformState } from './TransformEditor';
+import { SlotDecisions } from './SlotDecisions';
+import { formatWeiEth } from '../../utils';
 
 // Target of the modal: either an explicit slot list (single slot or grid
 // selection) or an inclusive from/to range (may extend beyond the grid).
@@ -469,6 +471,26 @@ const FrozenPlanSection: React.FC<{ frozen: FrozenPlan }> = ({ frozen }) => (
               reorg parent
             </span>
           )}
+          {frozen.build.faults && frozen.build.faults.length > 0 && (
+            <span className={`ms-1 ${badgeClass('danger')}`} title="Payload fields corrupted after the build">
+              faults: {frozen.build.faults.join(', ')}
+            </span>
+          )}
+          {frozen.build.blob_stuffing && (
+            <span className={`ms-1 ${badgeClass('info')}`} title="Block filled with synthetic blob transactions">
+              blobs: {frozen.build.blob_stuffing}
+            </span>
+          )}
+          {frozen.build.gas_limit ? (
+            <span className={`ms-1 ${badgeClass('info')}`} title="Gas limit target replacing the proposer's">
+              gas limit: {frozen.build.gas_limit.toLocaleString()}
+            </span>
+          ) : null}
+          {frozen.build.gas_limit_violate && (
+            <span className={`ms-1 ${badgeClass('danger')}`} title="Gas limit moved away from the target">
+              gas limit violation
+            </span>
+          )}
         </KV>
         <KV label="Build Start">{frozen.build.build_start_time_ms} ms</KV>
       </div>
@@ -480,6 +502,18 @@ const FrozenPlanSection: React.FC<{ frozen: FrozenPlan }> = ({ frozen }) => (
             {frozen.bid.start_ms}–{frozen.bid.end_ms} ms
           </KV>
           <KV label="Interval">{frozen.bid.interval_ms} ms</KV>
+          <KV label="Strategy">
+            {frozen.bid.strategy || 'linear'}
+            {frozen.bid.strategy === 'counter-bid' && (
+              <span className="text-muted ms-1">
+                +{formatGwei(frozen.bid.counter_step_gwei)}
+                {frozen.bid.counter_max_gwei ? ` ≤ ${formatGwei(frozen.bid.counter_max_gwei)}` : ''}
+              </span>
+            )}
+            {frozen.bid.strategy === 'random-walk' && (
+              <span className="text-muted ms-1">±{formatGwei(frozen.bid.random_walk_step_gwei)}</span>
+            )}
+          </KV>
           <KV label="Min / Increase">
             {formatGwei(frozen.bid.min_gwei)} / {formatGwei(frozen.bid.increase_gwei)}
           </KV>
@@ -579,6 +613,7 @@ const ResultView: React.FC<{
     return (
       <>
         <div className="alert alert-secondary small">No result recorded for this slot.</div>
+        <SlotDecisions slot={slot} />
         {storedPlan && (
           <>
             <div className="section-header mb-1">Stored Plan</div>
@@ -599,6 +634,8 @@ const ResultView: React.FC<{
     <>
       {result.applied_plan && <FrozenPlanSection frozen={result.applied_plan} />}
 
+      <SlotDecisions slot={slot} />
+
       {build && (
         <div className="card mb-3">
           <div className="card-header py-1 d-flex flex-wrap align-items-center gap-2">
@@ -894,6 +931,7 @@ const ResultView: React.FC<{
                     </td>
                     <td>{attempt.transport}</td>
                     <td className="small">
+                      {attempt.chaos && <span className="badge bg-warning text-dark me-1">chaos {attempt.chaos}</span>}
                       {attempt.skip_reason && <span className="text-muted">{attempt.skip_reason}</span>}
                       {attempt.error && <span className="text-danger"> {attempt.error}</span>}
                     </td>
@@ -938,7 +976,7 @@ const ResultView: React.FC<{
               <KV label="Txs / Blobs">
                 {result.inclusion.num_transactions} / {result.inclusion.num_blobs}
               </KV>
-              <KV label="Value">{parseFloat(result.inclusion.value_eth).toFixed(6)} ETH</KV>
+              <KV label="Value">{formatWeiEth(result.inclusion.value_wei)} ETH</KV>
               <KV label="At">{formatDateTime(result.inclusion.timestamp)}</KV>
               {result.inclusion.payload_check_slot !== undefined && (
                 <KV label="Verdict from slot">{String(result.inclusion.payload_check_slot)}</KV>
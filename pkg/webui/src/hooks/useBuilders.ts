@@ -0,0 +1,28 @@
+import { useEffect, useState } from 'react';
+import { REFRESH_INTERVAL_LIVE_MS } from './refreshIntervals';
+import type { BuilderSummary, BuildersResponse } from '../types';
+
+// Builder keys run by this instance. Extra builder keys have no SSE events,
+// so their balances and registration state are polled.
+export function useBuilders() {
+  const [builders, setBuilders] = useState<BuilderSummary[]>([]);
+
+  useEffect(() => {
+    const fetchBuilders = async () => {
+      try {
+        const response = await fetch('/api/builders');
+        if (!response.ok) return;
+        const data: BuildersResponse = await response.json();
+        setBuilders(data.builders ?? []);
+      } catch (err) {
+        console.error('Failed to fetch builders:', err);
+      }
+    };
+
+    fetchBuilders();
+    const interval = setInterval(fetchBuilders, REFRESH_INTERVAL_LIVE_MS);
+    return () => clearInterval(interval);
+  }, []);
+
+  return builders;
+}
@@ -1,5 +1,6 @@
 import { useState, useEffect, useCallback, useRef, useSyncExternalStore } from 'react';
-import type { Config, ChainInfo, Stats, SlotState, LogEvent, OurBid, ExternalBid, BuilderInfo, HeadVoteDataPoint, ServiceStatus, RevealAttempt, VoteCoverage } from '../types';
+import type { Config, ChainInfo, Stats, SlotState, LogEvent, OurBid, ExternalBid, BuilderInfo, HeadVoteDataPoint, ServiceStatus, RevealAttempt, VoteCoverage, WalletTx, LifecycleStreamEvent } from '../types';
+import { formatWeiEth } from '../utils';
 
 // ---------------------------------------------------------------------------
 // Module-level SSE fan-out: lets other hooks/components subscribe to raw
@@ -615,8 +616,8 @@ export function useEventStream(): UseEventStreamResult {
         case 'bid_won': {
           // Event handled by BidsWonView component directly
           // No need to store in main state, just log it
-          const data = event.data as { slot: number; block_hash: string; num_transactions: number; value_eth: string };
-          addEvent('bid_won', `Bid won for slot ${data.slot} (${data.num_transactions} txs, ${parseFloat(data.value_eth).toFixed(6)} ETH)`, event.timestamp);
+          const data = event.data as { slot: number; block_hash: string; num_transactions: number; value_wei: string };
+          addEvent('bid_won', `Bid won for slot ${data.slot} (${data.num_transactions} txs, ${formatWeiEth(data.value_wei)} ETH)`, event.timestamp);
           break;
         }
 
@@ -628,7 +629,7 @@ export function useEventStream(): UseEventStreamResult {
         }
 
         case 'lifecycle': {
-          const data = event.data as { action: string; message: string; status: string };
+          const data = event.data as LifecycleStreamEvent;
           const eventType = data.status === 'error' ? 'lifecycle_error'
             : data.status === 'warning' ? 'lifecycle_warning'
             : data.status === 'success' ? 'lifecycle_success'
@@ -636,6 +637,19 @@ export function useEventStream(): UseEventStreamResult {
           addEvent(eventType, data.message, event.timestamp);
           break;
         }
+
+        case 'wallet_tx': {
+          const data = event.data as WalletTx;
+          const eventType = data.status === 'failed' ? 'lifecycle_error'
+            : data.status === 'replaced' ? 'lifecycle_warning'
+            : data.status === 'confirmed' ? 'lifecycle_success'
+            : 'lifecycle';
+          const detail = data.status === 'mined' ? ` in block ${data.block_number}`
+            : data.status === 'confirmed' ? ` (${data.confirmations} blocks)`
+            : data.error ? `: ${data.error}` : '';
+          addEvent(eventType, `Wallet ${data.kind} tx ${data.hash.slice(0, 10)}… ${data.status}${detail}`, event.timestamp);
+          break;
+        }
       }
 
       // Fan out every event to module-level subscribers (shared connection).
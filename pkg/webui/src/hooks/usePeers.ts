@@ -0,0 +1,46 @@
+import { useEffect, useRef, useState } from 'react';
+import { REFRESH_INTERVAL_LIVE_MS } from './refreshIntervals';
+import type { PeerNetworkView } from '../types';
+
+export function usePeers() {
+  const [view, setView] = useState<PeerNetworkView | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+  const initialFetchDone = useRef(false);
+
+  const fetchPeers = async () => {
+    try {
+      const response = await fetch('/api/buildoor/peers');
+
+      if (response.status === 404) {
+        setView(null);
+        setError('peer mesh not available');
+        return;
+      }
+
+      if (!response.ok) {
+        throw new Error(`Failed to fetch peers: ${response.statusText}`);
+      }
+
+      const data: PeerNetworkView = await response.json();
+      setView(data);
+      setError(null);
+    } catch (err) {
+      setError(err instanceof Error ? err.message : 'Unknown error');
+    } finally {
+      if (!initialFetchDone.current) {
+        initialFetchDone.current = true;
+        setLoading(false);
+      }
+    }
+  };
+
+  useEffect(() => {
+    fetchPeers();
+    // Shared bids change slot-by-slot; only polled while the page is open.
+    const interval = setInterval(fetchPeers, REFRESH_INTERVAL_LIVE_MS);
+    return () => clearInterval(interval);
+  }, []);
+
+  return { view, loading, error, refetch: fetchPeers };
+}
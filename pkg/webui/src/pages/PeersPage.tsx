@@ -0,0 +1,11 @@
+import React from 'react';
+import { usePeers } from '../hooks/usePeers';
+import { PeersView } from '../components/PeersView';
+
+const PeersPage: React.FC = () => {
+  const { view, loading, error } = usePeers();
+
+  return <PeersView view={view} loading={loading} error={error} />;
+};
+
+export default PeersPage;
@@ -7,6 +7,7 @@ export type ViewType =
   | 'validators'
   | 'proposer-preferences'
   | 'builder-preferences'
+  | 'peers'
   | 'audit-log'
   | 'api-docs';
 
@@ -17,6 +18,7 @@ const VIEW_PATHS: Record<ViewType, string> = {
   validators: '/validators',
   'proposer-preferences': '/proposer-preferences',
   'builder-preferences': '/builder-preferences',
+  peers: '/peers',
   'audit-log': '/audit-log',
   'api-docs': '/api-docs',
 };
@@ -28,6 +28,25 @@ export interface Config {
   topup_amount: number;
   payload_build_time?: number;
   extra_data?: string;
+  // Settings version (ETag); edits send the version they started from as
+  // If-Match so a concurrent edit is rejected instead of clobbered.
+  version?: number;
+}
+
+// One setting changed by a config revision (effective value before/after).
+export interface ConfigChange {
+  key: string;
+  from: unknown;
+  to: unknown;
+}
+
+// A recorded runtime config change (GET /api/config/revisions).
+export interface ConfigRevision {
+  version: number;
+  timestamp: number; // unix ms
+  actor: string;
+  source: 'api' | 'reload' | 'rollback';
+  changes: ConfigChange[];
 }
 
 // Payload reveal config (own section, shared by the p2p bidder and Builder
@@ -40,6 +59,10 @@ export interface RevealConfig {
   broadcast_validation: string; // gossip | consensus | consensus_and_equivocation
   max_attempts: number;
   retry_interval_ms: number;
+  require_inclusion: boolean; // reveal only once our bid is seen included at the head
+  chaos_withhold_pct: number; // chaos testing: percent of slots whose reveal is withheld
+  chaos_delay_pct: number; // chaos testing: percent of slots whose reveal is delayed
+  chaos_delay_ms: number;
 }
 
 export interface ScheduleConfig {
@@ -58,16 +81,26 @@ export interface EPBSConfig {
   bid_interval: number;
   bid_subsidy: number;
   payload_build_delay?: number;
+  bid_strategy?: BidStrategy;
+  counter_bid_step_gwei?: number;
+  counter_bid_max_gwei?: number;
+  random_walk_step_gwei?: number;
 }
 
+// p2p bid strategies (epbs.bid_strategy).
+export type BidStrategy = 'fixed' | 'linear' | 'counter-bid' | 'last-moment-snipe' | 'random-walk';
+
 export interface ServiceStatus {
   epbs_available: boolean;
   epbs_enabled: boolean;
   epbs_registration_state: string; // "unknown" | "unregistered" | "waiting_gloas" | "pending" | "pending_finalization" | "registered" | "exiting" | "exited"
+  epbs_identity_error?: string; // set while bidding is halted on a builder record mismatch
+  epbs_bid_hold?: string; // set while the penalty monitor holds bidding until incidents are acknowledged
   builder_api_available: boolean;
   builder_api_enabled: boolean;
   lifecycle_available: boolean;
   lifecycle_enabled: boolean;
+  lifecycle_read_only?: boolean; // tracking an out-of-band registration only
 }
 
 export interface ChainInfo {
@@ -85,6 +118,15 @@ export interface Stats {
   reveals_success: number;
   reveals_failed: number;
   reveals_skipped: number;
+  reveals_skipped_not_included: number;
+  reveals_chaos_withheld: number;
+  reveals_chaos_delayed: number;
+  canary_mode: boolean;
+  canary_bids_submitted: number;
+  value_boost_pct: number;
+  withdrawal_mismatches: number;
+  el_head_withdrawals: number;
+  engine_wins?: Record<string, number>; // payload races won per engine API endpoint
   builder_api_headers_requested: number;
   builder_api_blocks_published: number;
   builder_api_registered_validators: number;
@@ -104,6 +146,51 @@ export interface BuilderInfo {
   withdrawable_epoch: number;
 }
 
+// One builder key run by this instance (/api/builders): the primary builder
+// first, then the extra builder keys.
+export interface BuilderSummary extends BuilderInfo {
+  primary: boolean;
+  registration_state: string;
+  identity_error?: string;
+  lifecycle_read_only: boolean;
+  outstanding_bid_slots: number[];
+}
+
+export interface BuildersResponse {
+  builders: BuilderSummary[];
+}
+
+// A lifecycle event (deposit, topup, exit, state change). code and params are
+// the machine-readable form of message, e.g. code "topup_confirmed" with
+// params {amount_gwei}; registration state changes carry
+// {from_state, to_state}. Extra builders' events add params.builder_pubkey.
+export interface LifecycleStreamEvent {
+  action: string;
+  code?: string;
+  params?: Record<string, unknown>;
+  message: string; // English description
+  status: 'info' | 'success' | 'warning' | 'error';
+}
+
+export type WalletTxStatus = 'submitted' | 'mined' | 'confirmed' | 'failed' | 'replaced';
+
+// A transaction sent by the builder wallet (wallet_tx event, /api/lifecycle/transactions).
+export interface WalletTx {
+  kind: string;
+  hash: string;
+  nonce: number;
+  to: string;
+  value_wei: string;
+  attempt: number;
+  status: WalletTxStatus;
+  block_number?: number;
+  gas_used?: number;
+  confirmations?: number;
+  error?: string;
+  submitted_at: number;
+  updated_at: number;
+}
+
 export interface SlotStartEvent {
   slot: number;
   slot_start_time: number;
@@ -157,6 +244,7 @@ export interface RevealEvent {
   error?: string;
   attempt?: number;
   max_attempts?: number;
+  manual?: boolean;
   timestamp: number;
 }
 
@@ -185,6 +273,21 @@ export interface VoteCoverage {
   low: boolean;
 }
 
+// One entry of a slot's decision journal (REST:
+// GET /api/slots/{slot}/decisions): why a pipeline stage did or did not act.
+export interface SlotDecision {
+  stage: 'build' | 'bid' | 'reveal';
+  outcome: 'done' | 'skipped' | 'failed';
+  reason: string;
+  detail?: string;
+  at: string;
+}
+
+export interface SlotDecisionsResponse {
+  slot: number;
+  decisions: SlotDecision[];
+}
+
 // Per-name head-vote arrival heatmap of one slot (REST:
 // GET /api/buildoor/head-votes/{slot}); counts are vote arrivals per
 // fixed-width time bucket from the slot start.
@@ -404,6 +507,7 @@ export interface ValidatorRegistration {
   fee_recipient: string;
   gas_limit: number;
   timestamp: number;
+  source?: string; // "local" or the relay it was backfilled from
 }
 
 export interface ValidatorsResponse {
@@ -427,6 +531,14 @@ export interface BidWonEntry {
   value_eth: string;
   value_wei: string;
   timestamp: number;
+  publishes?: WonBlockPublish[];
+}
+
+// One beacon node's publication of a won Builder API block.
+export interface WonBlockPublish {
+  node: string;
+  latency_ms: number;
+  error?: string; // absent when the node accepted the block
 }
 
 export interface BidsWonResponse {
@@ -478,6 +590,70 @@ export interface BuilderPreferencesResponse {
   preferences: BuilderPreference[];
 }
 
+// Peer mesh types (wire shapes of pkg/peer_mesh)
+export interface PeerSharedBid {
+  slot: number;
+  builder_index: number;
+  block_hash: string;
+  value_gwei: number;
+  execution_payment_gwei: number;
+  source?: string; // mesh peer name; absent when observed by this node
+  fork?: string;
+  message?: unknown; // signed ExecutionPayloadBid message (beacon API JSON)
+  signature?: string;
+}
+
+export interface PeerStatus {
+  url: string;
+  name?: string;
+  builder_pubkey?: string;
+  builder_index: number;
+  registered: boolean;
+  last_seen?: string;
+  last_error?: string;
+  bids_merged: number;
+  bids_rejected: number; // unsigned or failing verification
+}
+
+export interface PeerNetworkView {
+  name: string;
+  builder_pubkey: string;
+  peers: PeerStatus[];
+  bids: PeerSharedBid[];
+}
+
+// Relay proxy types (wire shapes of pkg/relay_proxy)
+export type RelayExchangeKind = 'status' | 'register_validators' | 'get_header' | 'submit_blinded_block';
+
+export interface RelayExchange {
+  id: number;
+  kind: RelayExchangeKind;
+  relay: string;
+  slot?: number;
+  pubkey?: string; // proposer pubkey (get_header)
+  registrations?: number; // registrations in the request (register_validators)
+  status_code: number; // 0 = no response
+  duration_ms: number;
+  block_hash?: string;
+  value_wei?: string;
+  selected: boolean; // this relay's answer was returned to the validator client
+  error?: string;
+  at: string;
+}
+
+export interface RelayProxyStatus {
+  url: string;
+  requests: number;
+  failures: number;
+  last_seen?: string;
+  last_error?: string;
+}
+
+export interface RelayProxyView {
+  relays: RelayProxyStatus[];
+  exchanges: RelayExchange[];
+}
+
 // ---------------------------------------------------------------------------
 // Per-slot action plan types (wire shapes of pkg/action_plan; snake_case JSON)
 // ---------------------------------------------------------------------------
@@ -517,8 +693,27 @@ export interface RevealPlan {
 
 // The build category has no custom/disabled mode: it only tweaks how the
 // slot's payload is built when a build happens.
+// PayloadFault is one payload field corrupted by fault injection.
+export interface PayloadFault {
+  field: string;
+  original: string;
+  corrupted: string;
+}
+
+// EngineResult is one engine API endpoint's outcome in a payload race.
+export interface EngineResult {
+  engine: string;
+  block_value_wei?: string;
+  error?: string;
+  won?: boolean;
+}
+
 export interface BuildPlan {
   reorg_parent_payload?: boolean;
+  faults?: string[]; // "state_root" | "receipts_root" | "withdrawals" | "blob_commitments"
+  blob_stuffing?: string; // "target" | "max" | blob count | "off"
+  gas_limit?: number;
+  gas_limit_violate?: boolean;
 }
 
 // The transforms category has no mode: each field is a jq expression applied
@@ -579,6 +774,10 @@ export interface ResolvedBuildSettings {
   plan_involved?: boolean;
   build_start_time_ms: number;
   reorg_parent_payload?: boolean;
+  faults?: string[];
+  blob_stuffing?: string; // "target" | "max" | blob count
+  gas_limit?: number;
+  gas_limit_violate?: boolean;
 }
 
 export interface ResolvedBidSettings {
@@ -591,6 +790,15 @@ export interface ResolvedBidSettings {
   value_gwei?: number;
   ignore_missing_prefs?: boolean;
   forced?: boolean;
+  canary?: boolean;
+  replace_stale?: boolean;
+  snipe?: boolean;
+  snipe_lead_ms?: number;
+  snipe_max_competitor_gwei?: number;
+  strategy?: BidStrategy;
+  counter_step_gwei?: number;
+  counter_max_gwei?: number;
+  random_walk_step_gwei?: number;
 }
 
 export interface ResolvedBuilderAPISettings {
@@ -598,6 +806,8 @@ export interface ResolvedBuilderAPISettings {
   total_value_gwei?: number;
   delay_ms?: number;
   forced?: boolean;
+  canary?: boolean;
+  value_boost_pct?: number;
 }
 
 export interface ResolvedRevealSettings {
@@ -609,6 +819,8 @@ export interface ResolvedRevealSettings {
   max_attempts: number;
   retry_interval_ms: number;
   bypass_deadline?: boolean;
+  require_inclusion?: boolean;
+  chaos?: string; // "withhold" | "delay" (reveal chaos)
 }
 
 // FrozenPlan is the immutable per-slot execution snapshot; a nil bid /
@@ -676,6 +888,12 @@ export interface BuildOutcome {
   num_withdrawals?: number;
   num_execution_requests?: number;
   attributes?: AttributesSnapshot;
+  supersedes?: string; // block hash of the stale payload a rebuild replaced
+  withdrawals_source?: 'attributes' | 'state';
+  withdrawals_divergence?: string; // payload_attributes vs expected withdrawals mismatch
+  faults?: PayloadFault[]; // fields fault injection corrupted
+  engine?: string; // engine API endpoint that won the payload race
+  engine_race?: EngineResult[];
   error?: string;
   at: string;
 }
@@ -701,8 +919,16 @@ export interface SlotBidAttempt {
   total_value_gwei: number;
   execution_payment_gwei?: number;
   competitor_high_gwei?: number;
+  canary?: boolean;
+  value_boost_pct?: number;     // reported value boosted to this % of the real value (test-only)
+  manual?: boolean;
+  fault?: string;               // "zero_value" | "exceeds_balance" | "malformed_value" (fault bid)
+  snipe?: boolean;              // late-slot snipe bid (or contested-slot skip)
+  replaces_block_hash?: string; // earlier bid of ours this one replaces
+  replaced?: boolean;           // a later bid replaced this one
   artifact_index?: number;
   // Full bid message properties (blob commitments aggregated to a count).
+  bid_root?: string;
   block_hash?: string;
   parent_block_hash?: string;
   parent_block_root?: string;
@@ -725,13 +951,28 @@ export interface SlotBlockSubmission {
 export interface SlotRevealAttempt {
   status: RevealAttemptStatus;
   transport: string;
-  skip_reason?: string; // "plan_disabled" | "disabled" | "late" | "vote_gate_timeout"
+  skip_reason?: string; // "plan_disabled" | "disabled" | "late" | "vote_gate_timeout" | "not_included" | "chaos_withheld"
+  chaos?: string; // "withhold" | "delay" (reveal chaos)
   error?: string;
   attempt: number;
+  manual?: boolean;
   at: string;
   started_at?: string;
 }
 
+// Signing context of one builder signature (validated against the connected
+// chain at sign time; error set when the signature was refused).
+export interface SlotSigningRecord {
+  artifact: string; // "bid" | "header" | "envelope"
+  message_root: string;
+  domain_type: string;
+  fork_version: string;
+  genesis_validators_root: string;
+  domain: string;
+  error?: string;
+  at: string;
+}
+
 export type PayloadCanonicalStatus = 'pending' | 'canonical' | 'missed' | 'orphaned';
 
 export interface SlotInclusionResult {
@@ -746,6 +987,7 @@ export interface SlotInclusionResult {
   // next block (revised on reorgs while inside the tracking window).
   payload_status?: PayloadCanonicalStatus;
   payload_check_slot?: number | string;
+  publishes?: WonBlockPublish[];
 }
 
 export interface SlotResult {
@@ -758,10 +1000,94 @@ export interface SlotResult {
   block_submissions?: SlotBlockSubmission[];
   reveal_attempts?: SlotRevealAttempt[];
   inclusion?: SlotInclusionResult;
+  signatures?: SlotSigningRecord[];
   dropped_attempts?: Record<string, number>;
   updated_at: string;
 }
 
+// Explorer drill-down of a won slot (REST: GET /api/buildoor/blocks/{slot}),
+// assembled server-side from the beacon node, payload cache and payment ledger.
+export interface BlockView {
+  slot: number;
+  epoch: number;
+  fork?: string;
+  block?: BlockViewBlock;
+  bid?: BlockViewBid;
+  payload?: BlockViewPayload;
+  payment?: BlockViewPayment;
+  blobs?: BlockViewBlob[];
+  // PTC outcome from the next slot's block; absent until it is proposed.
+  ptc?: BlockViewPTC;
+  result?: SlotResult;
+}
+
+export interface BlockViewBlock {
+  root: string;
+  parent_root: string;
+  state_root: string;
+  proposer_index: number;
+  graffiti?: string;
+  execution_block_hash?: string;
+  num_attestations: number;
+  num_payload_attestations?: number;
+  num_deposits: number;
+  num_voluntary_exits: number;
+  num_proposer_slashings: number;
+  num_attester_slashings: number;
+  num_bls_changes: number;
+}
+
+export interface BlockViewBid {
+  builder_index: number;
+  ours: boolean;
+  block_hash: string;
+  parent_block_hash: string;
+  parent_block_root: string;
+  fee_recipient: string;
+  gas_limit: number;
+  value_gwei: number;
+  execution_payment_gwei?: number;
+  num_blob_commitments: number;
+}
+
+export interface BlockViewPayload {
+  block_hash: string;
+  parent_hash: string;
+  block_number: number;
+  fee_recipient: string;
+  state_root: string;
+  timestamp: number;
+  gas_limit: number;
+  gas_used: number;
+  base_fee_per_gas: string;
+  block_value_wei: string;
+  num_withdrawals: number;
+  transactions: string[];
+  revealed_at?: string;
+  reveal_transport?: string;
+}
+
+export interface BlockViewPayment {
+  value_gwei: number;
+  status: 'paid' | 'pending' | 'unknown';
+  pending_gwei?: number;
+}
+
+export interface BlockViewBlob {
+  index: number;
+  kzg_commitment: string;
+  versioned_hash: string;
+}
+
+export interface BlockViewPTC {
+  check_slot: number;
+  size: number;
+  votes: number;
+  payload_present: number;
+  blob_data_available: number;
+  timely: boolean;
+}
+
 export interface SlotResultsResponse {
   results: SlotResult[];
   min_slot: number;
@@ -782,3 +1108,47 @@ export interface SlotBidArtifactsResponse {
   slot: number;
   bids: BidArtifactMetaEntry[];
 }
+
+// Bid signing breakdown (GET /api/buildoor/slot-results/{slot}/bids/{index}/inspect).
+export interface BidFieldRootEntry {
+  name: string;
+  gindex: number;
+  root: string;
+}
+
+export interface BidInspectionResponse {
+  slot: number;
+  index: number;
+  fork: string;
+  artifact: 'bid' | 'header';
+  message: Record<string, unknown>;
+  fields: BidFieldRootEntry[];
+  message_root: string;
+  domain_type: string;
+  fork_version: string;
+  genesis_validators_root: string;
+  domain: string;
+  signing_root: string;
+  signature: string;
+  pubkey?: string; // absent when the signing key is unknown
+  signature_valid?: boolean;
+}
+
+// Live internal event subscription (GET /api/buildoor/diagnostics/subscriptions).
+export interface SubscriptionStats {
+  id: number;
+  dispatcher: string; // event type, e.g. "*beacon.HeadEvent"
+  capacity: number;
+  blocking: boolean;
+  timeout_ms?: number;
+  lag: number; // queued, not yet consumed events
+  high_water: number;
+  delivered: number;
+  dropped: number;
+  subscribed_at: number; // unix ms
+}
+
+export interface SubscriptionDiagnostics {
+  subscriptions: SubscriptionStats[];
+  total_dropped: number;
+}
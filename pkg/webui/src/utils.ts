@@ -17,6 +17,22 @@ export function formatGwei(gwei: number): string {
   return gwei + ' gwei';
 }
 
+// formatWeiEth renders a decimal wei string as ETH with the given number of
+// decimals (truncated), exactly: API values are raw wei, formatting is ours.
+export function formatWeiEth(wei: string, decimals = 6): string {
+  let value: bigint;
+  try {
+    value = BigInt(wei || '0');
+  } catch {
+    return wei;
+  }
+  const negative = value < 0n;
+  if (negative) value = -value;
+  const whole = value / 10n ** 18n;
+  const fraction = (value % 10n ** 18n).toString().padStart(18, '0').slice(0, decimals);
+  return `${negative ? '-' : ''}${whole}${decimals > 0 ? '.' + fraction : ''}`;
+}
+
 export function isSlotScheduled(slot: number, schedule: ScheduleConfig | undefined): boolean {
   if (!schedule) return true;
   const mode = schedule.mode || 'all';
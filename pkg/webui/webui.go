@@ -3,6 +3,7 @@ package webui
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,15 +13,22 @@ import (
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 
 	"github.com/ethpandaops/buildoor/pkg/action_plan"
+	"github.com/ethpandaops/buildoor/pkg/alerting"
 	"github.com/ethpandaops/buildoor/pkg/builderapi"
 	"github.com/ethpandaops/buildoor/pkg/chain"
+	"github.com/ethpandaops/buildoor/pkg/circuit_breaker"
 	"github.com/ethpandaops/buildoor/pkg/config"
 	"github.com/ethpandaops/buildoor/pkg/db"
+	"github.com/ethpandaops/buildoor/pkg/debug_bundle"
+	"github.com/ethpandaops/buildoor/pkg/epoch_summary"
 	"github.com/ethpandaops/buildoor/pkg/lifecycle"
 	"github.com/ethpandaops/buildoor/pkg/memstore"
+	"github.com/ethpandaops/buildoor/pkg/metrics"
 	"github.com/ethpandaops/buildoor/pkg/p2p_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_bidder"
 	"github.com/ethpandaops/buildoor/pkg/payload_builder"
+	"github.com/ethpandaops/buildoor/pkg/peer_mesh"
+	"github.com/ethpandaops/buildoor/pkg/relay_proxy"
 	"github.com/ethpandaops/buildoor/pkg/slot_results"
 	"github.com/ethpandaops/buildoor/pkg/validatorranges"
 	"github.com/ethpandaops/buildoor/pkg/webui/handlers"
@@ -28,7 +36,6 @@ import (
 	"github.com/ethpandaops/buildoor/pkg/webui/handlers/auth"
 	"github.com/ethpandaops/buildoor/pkg/webui/types"
 	"github.com/gorilla/mux"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/urfave/negroni"
@@ -43,7 +50,7 @@ var (
 	staticEmbedFS embed.FS
 )
 
-func StartHttpServer(frontendConfig *types.FrontendConfig, settingsSvc *config.Service, stateDB *db.Database, builderSvc *payload_builder.Service, epbsSvc *p2p_bidder.Service, lifecycleMgr *lifecycle.Manager, chainSvc chain.Service, validatorStore *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration], builderAPISvc *builderapi.Server, propPrefSvc *payload_bidder.ProposerPreferencesService, valRanges *validatorranges.Resolver, revealSvc *payload_bidder.RevealService, inclusionTracker *payload_bidder.InclusionTracker, payments *payload_bidder.PaymentTracker, planSvc *action_plan.PlanService, resultTracker *slot_results.Tracker) *api.APIHandler {
+func StartHttpServer(frontendConfig *types.FrontendConfig, settingsSvc *config.Service, stateDB *db.Database, builderSvc *payload_builder.Service, epbsSvc *p2p_bidder.Service, lifecycleMgr *lifecycle.Manager, chainSvc chain.Service, validatorStore *memstore.Store[phase0.BLSPubKey, *apiv1.SignedValidatorRegistration], builderAPISvc *builderapi.Server, propPrefSvc *payload_bidder.ProposerPreferencesService, valRanges *validatorranges.Resolver, revealSvc *payload_bidder.RevealService, inclusionTracker *payload_bidder.InclusionTracker, payments *payload_bidder.PaymentTracker, planSvc *action_plan.PlanService, resultTracker *slot_results.Tracker, peerMesh *peer_mesh.Service, logBuffer *debug_bundle.LogBuffer, epochSummaries *epoch_summary.Aggregator, alerts *alerting.Engine, breaker *circuit_breaker.Breaker, relayProxy *relay_proxy.Service) (*api.APIHandler, *http.Server) {
 	authHandler, err := auth.NewAuthHandler(context.Background(), frontendConfig.AuthProviderURL)
 	if err != nil {
 		logrus.WithError(err).Fatal("failed to initialize auth handler")
@@ -60,16 +67,28 @@ func StartHttpServer(frontendConfig *types.FrontendConfig, settingsSvc *config.S
 		builderAPISvc.RegisterRoutes(router)
 	}
 
+	// Relay proxy routes (validator clients point their builder URL here)
+	if relayProxy != nil {
+		relayProxy.RegisterRoutes(router)
+	}
+
 	// API routes
-	apiHandler := api.NewAPIHandler(authHandler, settingsSvc, stateDB, builderSvc, epbsSvc, lifecycleMgr, chainSvc, validatorStore, builderAPISvc, propPrefSvc, valRanges, revealSvc, inclusionTracker, payments, planSvc, resultTracker)
+	apiHandler := api.NewAPIHandler(authHandler, settingsSvc, stateDB, builderSvc, epbsSvc, lifecycleMgr, chainSvc, validatorStore, builderAPISvc, propPrefSvc, valRanges, revealSvc, inclusionTracker, payments, planSvc, resultTracker, peerMesh, logBuffer, epochSummaries, alerts, breaker, relayProxy)
 	apiRouter := router.PathPrefix("/api").Subrouter()
 	apiRouter.HandleFunc("/version", apiHandler.GetVersion).Methods("GET")
 	apiRouter.HandleFunc("/status", apiHandler.GetStatus).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/status/preflight", apiHandler.GetPreflight).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/stats", apiHandler.GetStats).Methods(http.MethodGet)
 
+	// Admin JSON-RPC (buildoor_ namespace) for RPC-style builder tooling
+	apiRouter.HandleFunc("/rpc", apiHandler.AdminRPC).Methods(http.MethodPost)
+
 	// Event stream endpoint for real-time updates
 	apiRouter.HandleFunc("/events", apiHandler.EventStream).Methods(http.MethodGet)
 
+	// Live log tail (SSE) with level/module/slot filters
+	apiRouter.HandleFunc("/logs/stream", apiHandler.StreamLogs).Methods(http.MethodGet)
+
 	// Configuration endpoints
 	apiRouter.HandleFunc("/config", apiHandler.GetConfig).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/config/schedule", apiHandler.UpdateSchedule).Methods(http.MethodPost)
@@ -87,6 +106,10 @@ func StartHttpServer(frontendConfig *types.FrontendConfig, settingsSvc *config.S
 	// Generic path-based settings endpoint (partial updates by canonical key)
 	apiRouter.HandleFunc("/config/settings", apiHandler.UpdateSettings).Methods(http.MethodPost)
 
+	// Config revision history + rollback
+	apiRouter.HandleFunc("/config/revisions", apiHandler.GetConfigRevisions).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/config/rollback", apiHandler.RollbackConfig).Methods(http.MethodPost)
+
 	// Per-slot action plan + results endpoints
 	apiRouter.HandleFunc("/buildoor/action-plan", apiHandler.GetActionPlan).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/action-plan", apiHandler.UpdateActionPlan).Methods(http.MethodPost)
@@ -95,27 +118,53 @@ func StartHttpServer(frontendConfig *types.FrontendConfig, settingsSvc *config.S
 	apiRouter.HandleFunc("/buildoor/slot-results/{slot}/payload", apiHandler.GetSlotPayloadArtifact).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/slot-results/{slot}/bids", apiHandler.GetSlotBidArtifacts).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/slot-results/{slot}/bids/{index}", apiHandler.GetSlotBidArtifact).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/slot-results/{slot}/bids/{index}/inspect", apiHandler.GetSlotBidInspection).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/slot-results/{slot}/envelope", apiHandler.GetSlotEnvelopeArtifact).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/slots/{slot}/decisions", apiHandler.GetSlotDecisions).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/assertions/{slot}", apiHandler.GetSlotAssertions).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/head-votes/{slot}", apiHandler.GetHeadVoteDetail).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/blocks/{slot}", apiHandler.GetBlockView).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/debug-bundle/{slot}", apiHandler.GetDebugBundle).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/diagnostics/subscriptions", apiHandler.GetSubscriptionDiagnostics).Methods(http.MethodGet)
 
 	// Buildoor endpoints
 	apiRouter.HandleFunc("/buildoor/validators", apiHandler.GetValidators).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/bids-won", apiHandler.GetBidsWon).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/builder-api-status", apiHandler.GetBuilderAPIStatus).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/builder-api/captures", apiHandler.GetBuilderAPICaptures).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/balance-history", apiHandler.GetBalanceHistory).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/alerts", apiHandler.GetAlerts).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/circuit-breaker", apiHandler.GetCircuitBreaker).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/circuit-breaker/reset", apiHandler.ResetCircuitBreaker).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/buildoor/penalty-monitor", apiHandler.GetPenaltyMonitor).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/penalty-monitor/acknowledge", apiHandler.AcknowledgePenalty).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/buildoor/bid", apiHandler.SubmitManualBid).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/buildoor/bid-budget", apiHandler.GetBidBudget).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/txs", apiHandler.GetInjectedTxs).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/txs", apiHandler.InjectTxs).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/buildoor/reveal/{slot}/preview", apiHandler.GetRevealPreview).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/reveal/{slot}", apiHandler.TriggerReveal).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/buildoor/epochs/{epoch}", apiHandler.GetEpochSummary).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/overview", apiHandler.GetOverview).Methods(http.MethodGet, http.MethodOptions)
 	apiRouter.HandleFunc("/buildoor/proposer-preferences", apiHandler.GetProposerPreferences).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/builder-preferences", apiHandler.GetBuilderPreferences).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/buildoor/audit-log", apiHandler.GetAuditLog).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/peer/bids", apiHandler.GetPeerBids).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/peers", apiHandler.GetPeers).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/buildoor/relay-proxy", apiHandler.GetRelayProxy).Methods(http.MethodGet)
 
 	// Lifecycle endpoints (if manager available)
+	apiRouter.HandleFunc("/builders", apiHandler.GetBuilders).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/lifecycle/status", apiHandler.GetLifecycleStatus).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/lifecycle/deposit", apiHandler.PostDeposit).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/lifecycle/deposit/preview", apiHandler.GetDepositPreview).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/lifecycle/transactions", apiHandler.GetLifecycleTransactions).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/lifecycle/topup", apiHandler.PostTopup).Methods(http.MethodPost)
 	apiRouter.HandleFunc("/lifecycle/exit", apiHandler.PostExit).Methods(http.MethodPost)
 	apiRouter.HandleFunc("/config/lifecycle", apiHandler.UpdateLifecycleConfig).Methods(http.MethodPost)
 
 	// metrics endpoint
-	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
 
 	// swagger
 	router.PathPrefix("/api/docs/").Handler(httpSwagger.Handler(func(c *httpSwagger.Config) {
@@ -169,10 +218,10 @@ func StartHttpServer(frontendConfig *types.FrontendConfig, settingsSvc *config.S
 
 	logrus.Printf("http server listening on %v", srv.Addr)
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logrus.WithError(err).Fatal("Error serving frontend")
 		}
 	}()
 
-	return apiHandler
+	return apiHandler, srv
 }